@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxAlertMuteDuration is the longest time the alerts of a watch list item can be muted for,
+// so that a forgotten mute does not silence a stock for good.
+const MaxAlertMuteDuration = 365 * 24 * time.Hour
+
+// ParseAlertMuteDuration parses how long to mute the alerts of a stock, such as "7d", "12h" or "30m".
+func ParseAlertMuteDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("ミュート期間の形式が不正です (例: 7d, 12h, 30m): %s", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("ミュート期間の形式が不正です (例: 7d, 12h, 30m): %s", s)
+		}
+	}
+
+	if d < time.Minute {
+		return 0, fmt.Errorf("ミュート期間は1分以上で指定してください: %s", s)
+	}
+	if d > MaxAlertMuteDuration {
+		return 0, fmt.Errorf("ミュート期間は%d日以内で指定してください: %s", int(MaxAlertMuteDuration/(24*time.Hour)), s)
+	}
+	return d, nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAlertMuteDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "12h", want: 12 * time.Hour},
+		{input: "30m", want: 30 * time.Minute},
+		{input: " 1D ", want: 24 * time.Hour},
+		{input: "365d", want: MaxAlertMuteDuration},
+		{input: "366d", wantErr: true},
+		{input: "30s", wantErr: true},
+		{input: "0d", wantErr: true},
+		{input: "-1d", wantErr: true},
+		{input: "week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAlertMuteDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAlertMuteDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAlertMuteDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
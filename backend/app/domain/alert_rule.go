@@ -0,0 +1,293 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metrics available in alert rule conditions.
+const (
+	MetricPrice         = "price"          // 終値
+	MetricChangePercent = "change_percent" // 前日比(%)
+	MetricVolume        = "volume"         // 出来高
+	MetricAvgVolume     = "avg_volume"     // 平均出来高(直近20日、当日除く)
+	MetricVolumeRatio   = "volume_ratio"   // 出来高 / 平均出来高
+	MetricRSI           = "rsi"
+	MetricMACD          = "macd"
+	MetricMACDSignal    = "macd_signal"
+	MetricMACDHistogram = "macd_histogram"
+	MetricShortMA       = "ma_short"
+	MetricMediumMA      = "ma_medium"
+	MetricLongMA        = "ma_long"
+	MetricMADeviation   = "ma_deviation" // 中期移動平均乖離率(%)
+)
+
+// DefaultAlertCooldown is applied to rules without a cooldown
+const DefaultAlertCooldown = 24 * time.Hour
+
+// alertVolumeAveragePeriod is the number of days used for the average volume
+const alertVolumeAveragePeriod = 20
+
+var alertMetrics = map[string]bool{
+	MetricPrice: true, MetricChangePercent: true, MetricVolume: true, MetricAvgVolume: true,
+	MetricVolumeRatio: true, MetricRSI: true, MetricMACD: true, MetricMACDSignal: true,
+	MetricMACDHistogram: true, MetricShortMA: true, MetricMediumMA: true, MetricLongMA: true,
+	MetricMADeviation: true,
+}
+
+var alertOperators = map[string]func(a, b float64) bool{
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+}
+
+// Condition match modes of an alert rule.
+const (
+	AlertMatchAll = "all"
+	AlertMatchAny = "any"
+)
+
+// AlertCondition compares a metric with a constant value or another metric multiplied by factor.
+type AlertCondition struct {
+	Metric string   `yaml:"metric"`
+	Op     string   `yaml:"op"`
+	Value  *float64 `yaml:"value,omitempty"`
+	Ref    string   `yaml:"ref,omitempty"`
+	Factor float64  `yaml:"factor,omitempty"`
+}
+
+// AlertRuleDefinition is an alert rule written in YAML, for example:
+//
+//	name: oversold-volume-spike
+//	description: RSIが30未満かつ出来高が平均の2倍超
+//	match: all
+//	conditions:
+//	  - {metric: rsi, op: "<", value: 30}
+//	  - {metric: volume, op: ">", ref: avg_volume, factor: 2}
+//	cooldown: 24h
+type AlertRuleDefinition struct {
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description,omitempty"`
+	Codes       []string         `yaml:"codes,omitempty"` // empty means all watch list stocks
+	Match       string           `yaml:"match,omitempty"` // all (default) or any
+	Conditions  []AlertCondition `yaml:"conditions"`
+	Cooldown    time.Duration    `yaml:"cooldown,omitempty"` // minimum interval between notifications per stock (default 24h)
+}
+
+// AlertMetrics holds the metric values of a stock. Metrics that cannot be calculated are absent.
+type AlertMetrics map[string]float64
+
+// AlertEvaluation is the result of evaluating a rule against a stock's metrics.
+type AlertEvaluation struct {
+	Matched bool
+	Values  AlertMetrics // values of the metrics referenced by the rule
+}
+
+// ParseAlertRuleDefinition parses and validates a YAML alert rule. Unknown fields are rejected.
+func ParseAlertRuleDefinition(raw []byte) (*AlertRuleDefinition, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+
+	def := &AlertRuleDefinition{}
+	if err := decoder.Decode(def); err != nil {
+		return nil, fmt.Errorf("ルールのYAMLが不正です: %w", err)
+	}
+
+	if def.Match == "" {
+		def.Match = AlertMatchAll
+	}
+	if def.Cooldown == 0 {
+		def.Cooldown = DefaultAlertCooldown
+	}
+	for i := range def.Conditions {
+		if def.Conditions[i].Ref != "" && def.Conditions[i].Factor == 0 {
+			def.Conditions[i].Factor = 1
+		}
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// MarshalYAMLString returns the normalized rule as YAML for storage.
+func (d *AlertRuleDefinition) MarshalYAMLString() (string, error) {
+	b, err := yaml.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Validate validates an alert rule definition.
+func (d *AlertRuleDefinition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("ルール名は必須です")
+	}
+
+	if d.Match != AlertMatchAll && d.Match != AlertMatchAny {
+		return fmt.Errorf("matchはallまたはanyである必要があります: %s", d.Match)
+	}
+
+	if len(d.Conditions) == 0 {
+		return fmt.Errorf("条件を1つ以上指定してください")
+	}
+
+	for i, c := range d.Conditions {
+		if !alertMetrics[c.Metric] {
+			return fmt.Errorf("条件%d: 未知の指標です: %s", i+1, c.Metric)
+		}
+		if _, ok := alertOperators[c.Op]; !ok {
+			return fmt.Errorf("条件%d: 演算子は<, <=, >, >=のいずれかである必要があります: %s", i+1, c.Op)
+		}
+		if (c.Value == nil) == (c.Ref == "") {
+			return fmt.Errorf("条件%d: valueまたはrefのどちらか一方を指定してください", i+1)
+		}
+		if c.Ref != "" && !alertMetrics[c.Ref] {
+			return fmt.Errorf("条件%d: 未知の指標です: %s", i+1, c.Ref)
+		}
+	}
+
+	if d.Cooldown < 0 {
+		return fmt.Errorf("cooldownは0以上である必要があります")
+	}
+
+	return nil
+}
+
+// Evaluate evaluates the conditions against the metrics.
+// A condition referring to a metric that is not available does not match.
+func (d *AlertRuleDefinition) Evaluate(metrics AlertMetrics) AlertEvaluation {
+	evaluation := AlertEvaluation{Values: AlertMetrics{}}
+
+	matchedCount := 0
+	for _, c := range d.Conditions {
+		if c.evaluate(metrics, evaluation.Values) {
+			matchedCount++
+		}
+	}
+
+	if d.Match == AlertMatchAny {
+		evaluation.Matched = matchedCount > 0
+	} else {
+		evaluation.Matched = matchedCount == len(d.Conditions)
+	}
+	return evaluation
+}
+
+// evaluate evaluates a condition and records the referenced metric values.
+func (c AlertCondition) evaluate(metrics, values AlertMetrics) bool {
+	left, ok := metrics[c.Metric]
+	if !ok {
+		return false
+	}
+	values[c.Metric] = left
+
+	right := 0.0
+	if c.Value != nil {
+		right = *c.Value
+	} else {
+		ref, ok := metrics[c.Ref]
+		if !ok {
+			return false
+		}
+		values[c.Ref] = ref
+		right = ref * c.Factor
+	}
+
+	return alertOperators[c.Op](left, right)
+}
+
+// String returns a readable form of the condition such as "volume > avg_volume × 2".
+func (c AlertCondition) String() string {
+	if c.Value != nil {
+		return fmt.Sprintf("%s %s %g", c.Metric, c.Op, *c.Value)
+	}
+	if c.Factor != 1 {
+		return fmt.Sprintf("%s %s %s × %g", c.Metric, c.Op, c.Ref, c.Factor)
+	}
+	return fmt.Sprintf("%s %s %s", c.Metric, c.Op, c.Ref)
+}
+
+// Describe returns the conditions joined by AND or OR.
+func (d *AlertRuleDefinition) Describe() string {
+	parts := make([]string, len(d.Conditions))
+	for i, c := range d.Conditions {
+		parts[i] = c.String()
+	}
+
+	separator := " AND "
+	if d.Match == AlertMatchAny {
+		separator = " OR "
+	}
+	return strings.Join(parts, separator)
+}
+
+// FormatValues returns the metric values sorted by name, such as "rsi=28.50, volume=120000.00".
+func (m AlertMetrics) FormatValues() string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%.2f", name, m[name])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BuildAlertMetrics calculates the alert metrics from the price history (oldest first)
+// and the technical indicators, which may be nil if there is not enough data.
+func BuildAlertMetrics(prices []StockPriceData, indicator *TechnicalIndicatorData) AlertMetrics {
+	metrics := AlertMetrics{}
+	if len(prices) == 0 {
+		return metrics
+	}
+
+	latest := prices[len(prices)-1]
+	metrics[MetricPrice] = latest.Close
+	metrics[MetricVolume] = float64(latest.Volume)
+
+	if len(prices) >= 2 {
+		if previous := prices[len(prices)-2].Close; previous > 0 {
+			metrics[MetricChangePercent] = (latest.Close - previous) / previous * 100
+		}
+
+		start := len(prices) - 1 - alertVolumeAveragePeriod
+		if start < 0 {
+			start = 0
+		}
+		var total int64
+		for _, p := range prices[start : len(prices)-1] {
+			total += p.Volume
+		}
+		if avg := float64(total) / float64(len(prices)-1-start); avg > 0 {
+			metrics[MetricAvgVolume] = avg
+			metrics[MetricVolumeRatio] = float64(latest.Volume) / avg
+		}
+	}
+
+	if indicator != nil {
+		metrics[MetricRSI] = indicator.RSI
+		metrics[MetricMACD] = indicator.MACD
+		metrics[MetricMACDSignal] = indicator.Signal
+		metrics[MetricMACDHistogram] = indicator.Histogram
+		metrics[MetricShortMA] = indicator.MA5
+		metrics[MetricMediumMA] = indicator.MA25
+		metrics[MetricLongMA] = indicator.MA75
+		if indicator.MA25 > 0 {
+			metrics[MetricMADeviation] = indicator.MADeviation
+		}
+	}
+
+	return metrics
+}
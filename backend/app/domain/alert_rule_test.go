@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const oversoldVolumeSpikeRule = `
+name: oversold-volume-spike
+description: RSIが30未満かつ出来高が平均の2倍超
+conditions:
+  - {metric: rsi, op: "<", value: 30}
+  - {metric: volume, op: ">", ref: avg_volume, factor: 2}
+cooldown: 24h
+`
+
+func TestParseAlertRuleDefinition(t *testing.T) {
+	def, err := ParseAlertRuleDefinition([]byte(oversoldVolumeSpikeRule))
+	if err != nil {
+		t.Fatalf("ParseAlertRuleDefinition() error = %v", err)
+	}
+
+	if def.Match != AlertMatchAll {
+		t.Errorf("Match = %q, want %q", def.Match, AlertMatchAll)
+	}
+	if def.Cooldown != 24*time.Hour {
+		t.Errorf("Cooldown = %v, want 24h", def.Cooldown)
+	}
+	if got, want := def.Describe(), "rsi < 30 AND volume > avg_volume × 2"; got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+
+	// Normalized YAML can be parsed again
+	normalized, err := def.MarshalYAMLString()
+	if err != nil {
+		t.Fatalf("MarshalYAMLString() error = %v", err)
+	}
+	reparsed, err := ParseAlertRuleDefinition([]byte(normalized))
+	if err != nil {
+		t.Fatalf("ParseAlertRuleDefinition(normalized) error = %v", err)
+	}
+	if diff := cmp.Diff(def, reparsed); diff != "" {
+		t.Errorf("Reparsed rule mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseAlertRuleDefinition_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{name: "Missing name", yaml: `conditions: [{metric: rsi, op: "<", value: 30}]`},
+		{name: "No conditions", yaml: `name: empty`},
+		{name: "Unknown metric", yaml: `{name: x, conditions: [{metric: per, op: "<", value: 10}]}`},
+		{name: "Unknown operator", yaml: `{name: x, conditions: [{metric: rsi, op: "==", value: 30}]}`},
+		{name: "Both value and ref", yaml: `{name: x, conditions: [{metric: rsi, op: "<", value: 30, ref: macd}]}`},
+		{name: "Neither value nor ref", yaml: `{name: x, conditions: [{metric: rsi, op: "<"}]}`},
+		{name: "Invalid match", yaml: `{name: x, match: some, conditions: [{metric: rsi, op: "<", value: 30}]}`},
+		{name: "Unknown field", yaml: `{name: x, when: [], conditions: [{metric: rsi, op: "<", value: 30}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseAlertRuleDefinition([]byte(tt.yaml)); err == nil {
+				t.Error("ParseAlertRuleDefinition() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestAlertRuleDefinition_Evaluate(t *testing.T) {
+	def, err := ParseAlertRuleDefinition([]byte(oversoldVolumeSpikeRule))
+	if err != nil {
+		t.Fatalf("ParseAlertRuleDefinition() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		match   string
+		metrics AlertMetrics
+		want    AlertEvaluation
+	}{
+		{
+			name:    "All conditions match",
+			metrics: AlertMetrics{MetricRSI: 25, MetricVolume: 300, MetricAvgVolume: 100, MetricPrice: 1000},
+			want: AlertEvaluation{
+				Matched: true,
+				Values:  AlertMetrics{MetricRSI: 25, MetricVolume: 300, MetricAvgVolume: 100},
+			},
+		},
+		{
+			name:    "One condition does not match",
+			metrics: AlertMetrics{MetricRSI: 25, MetricVolume: 150, MetricAvgVolume: 100},
+			want: AlertEvaluation{
+				Matched: false,
+				Values:  AlertMetrics{MetricRSI: 25, MetricVolume: 150, MetricAvgVolume: 100},
+			},
+		},
+		{
+			name:    "Any condition matches",
+			match:   AlertMatchAny,
+			metrics: AlertMetrics{MetricRSI: 25, MetricVolume: 150, MetricAvgVolume: 100},
+			want: AlertEvaluation{
+				Matched: true,
+				Values:  AlertMetrics{MetricRSI: 25, MetricVolume: 150, MetricAvgVolume: 100},
+			},
+		},
+		{
+			name:    "Missing metric does not match",
+			metrics: AlertMetrics{MetricVolume: 300, MetricAvgVolume: 100},
+			want: AlertEvaluation{
+				Matched: false,
+				Values:  AlertMetrics{MetricVolume: 300, MetricAvgVolume: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := *def
+			if tt.match != "" {
+				rule.Match = tt.match
+			}
+			if diff := cmp.Diff(tt.want, rule.Evaluate(tt.metrics)); diff != "" {
+				t.Errorf("Evaluate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildAlertMetrics(t *testing.T) {
+	prices := []StockPriceData{
+		{Close: 100, Volume: 1000},
+		{Close: 110, Volume: 3000},
+		{Close: 99, Volume: 4000},
+	}
+	indicator := &TechnicalIndicatorData{RSI: 28, MACD: 1.5, Signal: 1.0, Histogram: 0.5, MA5: 101, MA25: 102, MA75: 103, MADeviation: -2.9}
+
+	want := AlertMetrics{
+		MetricPrice:         99,
+		MetricVolume:        4000,
+		MetricChangePercent: -10,
+		MetricAvgVolume:     2000,
+		MetricVolumeRatio:   2,
+		MetricRSI:           28,
+		MetricMACD:          1.5,
+		MetricMACDSignal:    1.0,
+		MetricMACDHistogram: 0.5,
+		MetricShortMA:       101,
+		MetricMediumMA:      102,
+		MetricLongMA:        103,
+		MetricMADeviation:   -2.9,
+	}
+
+	if diff := cmp.Diff(want, BuildAlertMetrics(prices, indicator)); diff != "" {
+		t.Errorf("BuildAlertMetrics() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := BuildAlertMetrics(prices[:1], nil); len(got) != 2 {
+		t.Errorf("BuildAlertMetrics() with one price = %v, want only price and volume", got)
+	}
+}
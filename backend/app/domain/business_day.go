@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// BusinessDay counts and shifts dates by the business days of a market, judged in the time zone
+// of the market. Weekends and the holidays given to the calendar are not business days.
+type BusinessDay struct {
+	location *time.Location
+	holiday  func(date time.Time) bool
+}
+
+// NewBusinessDay creates a business day calendar in the given time zone.
+// holiday receives dates at midnight in that time zone and may be nil when only weekends are closed.
+func NewBusinessDay(location *time.Location, holiday func(date time.Time) bool) *BusinessDay {
+	if location == nil {
+		location = time.Local
+	}
+	return &BusinessDay{location: location, holiday: holiday}
+}
+
+// NewMarketBusinessDay creates the business day calendar of a market.
+// The Tokyo Stock Exchange is closed on Japanese national holidays and the year-end holidays,
+// while other markets only close on weekends.
+func NewMarketBusinessDay(market MarketHours) *BusinessDay {
+	if market.Name() == MarketTokyo {
+		return NewBusinessDay(market.Location(), IsTokyoExchangeHoliday)
+	}
+	return NewBusinessDay(market.Location(), nil)
+}
+
+// Location returns the time zone of the calendar.
+func (b *BusinessDay) Location() *time.Location {
+	return b.location
+}
+
+// IsBusinessDay reports whether the day of t in the calendar time zone is a business day.
+func (b *BusinessDay) IsBusinessDay(t time.Time) bool {
+	date := b.date(t)
+	if weekday := date.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	return b.holiday == nil || !b.holiday(date)
+}
+
+// Add returns the date n business days after the day of t, or before it if n is negative.
+// The result is at midnight in the calendar time zone, and n of zero returns the day of t
+// whether or not it is a business day.
+func (b *BusinessDay) Add(t time.Time, n int) time.Time {
+	date := b.date(t)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		date = date.AddDate(0, 0, step)
+		if b.IsBusinessDay(date) {
+			n--
+		}
+	}
+	return date
+}
+
+// Count counts the business days from the day of from to the day of to, both inclusive.
+// Returns zero if to is before from.
+func (b *BusinessDay) Count(from, to time.Time) int {
+	count := 0
+	for date, last := b.date(from), b.date(to); !date.After(last); date = date.AddDate(0, 0, 1) {
+		if b.IsBusinessDay(date) {
+			count++
+		}
+	}
+	return count
+}
+
+// PeriodStart returns the first day of the last n business days up to the day of t, which
+// counts as one of them if it is a business day.
+func (b *BusinessDay) PeriodStart(t time.Time, n int) time.Time {
+	if n <= 0 {
+		return b.date(t)
+	}
+	if b.IsBusinessDay(t) {
+		n--
+	}
+	start := b.Add(t, -n)
+	for !b.IsBusinessDay(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// date returns midnight of the day of t in the calendar time zone.
+func (b *BusinessDay) date(t time.Time) time.Time {
+	local := t.In(b.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, b.location)
+}
+
+// PriceHistoryPeriod is a period of price history going back from now, either in calendar days or in
+// business days such as "the last 30 business days".
+type PriceHistoryPeriod struct {
+	Days         int
+	BusinessDays bool
+}
+
+// Validate validates the history period.
+func (p PriceHistoryPeriod) Validate() error {
+	if p.Days <= 0 {
+		return fmt.Errorf("期間の日数は1以上である必要があります: %d", p.Days)
+	}
+	return nil
+}
+
+// Start returns the first day of the period ending on the day of now.
+// Calendar days go back Days days from now, and business days are counted on the calendar,
+// falling back to weekdays in the time zone of now if calendar is nil.
+func (p PriceHistoryPeriod) Start(now time.Time, calendar *BusinessDay) time.Time {
+	if !p.BusinessDays {
+		return now.AddDate(0, 0, -p.Days)
+	}
+	if calendar == nil {
+		calendar = NewBusinessDay(now.Location(), nil)
+	}
+	return calendar.PeriodStart(now, p.Days)
+}
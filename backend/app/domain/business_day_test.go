@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsTokyoExchangeHoliday(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+		want bool
+	}{
+		{"New Year's Day", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"year-end holiday", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), true},
+		{"first trading day", time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), false},
+		{"Coming of Age Day", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), true},
+		{"Emperor's Birthday", time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC), true},
+		{"Vernal Equinox Day", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), true},
+		{"substitute holiday", time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), true},
+		{"Autumnal Equinox Day", time.Date(2024, 9, 22, 0, 0, 0, 0, time.UTC), true},
+		{"substitute of Autumnal Equinox Day", time.Date(2024, 9, 23, 0, 0, 0, 0, time.UTC), true},
+		{"Sports Day", time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC), true},
+		{"New Year's Eve", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), true},
+		{"citizen's holiday", time.Date(2015, 9, 22, 0, 0, 0, 0, time.UTC), true},
+		{"enthronement citizen's holiday", time.Date(2019, 4, 30, 0, 0, 0, 0, time.UTC), true},
+		{"Olympic Sports Day", time.Date(2021, 7, 23, 0, 0, 0, 0, time.UTC), true},
+		{"usual Sports Day in Olympic year", time.Date(2021, 10, 11, 0, 0, 0, 0, time.UTC), false},
+		{"Emperor's Birthday before 2019", time.Date(2018, 12, 24, 0, 0, 0, 0, time.UTC), true},
+		{"ordinary weekday", time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTokyoExchangeHoliday(tt.date); got != tt.want {
+				t.Errorf("IsTokyoExchangeHoliday(%s) = %v, want %v", tt.date.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBusinessDay(t *testing.T) {
+	tokyo := NewMarketBusinessDay(TokyoMarketHours())
+	jst := tokyo.Location()
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, jst)
+	}
+
+	t.Run("IsBusinessDay judges in the market time zone", func(t *testing.T) {
+		// Friday 16:00 UTC is Saturday 1:00 in Tokyo.
+		if tokyo.IsBusinessDay(time.Date(2024, 6, 14, 16, 0, 0, 0, time.UTC)) {
+			t.Error("Saturday in JST should not be a business day")
+		}
+		if !tokyo.IsBusinessDay(time.Date(2024, 6, 14, 14, 0, 0, 0, time.UTC)) {
+			t.Error("Friday in JST should be a business day")
+		}
+	})
+
+	t.Run("Add skips weekends and holidays", func(t *testing.T) {
+		tests := []struct {
+			from time.Time
+			n    int
+			want time.Time
+		}{
+			{date(5, 2), 1, date(5, 7)},                               // over Golden Week
+			{date(5, 7), -1, date(5, 2)},                              // back over Golden Week
+			{date(12, 27), 1, date(12, 30)},                           // over the weekend
+			{date(12, 30), 1, time.Date(2025, 1, 6, 0, 0, 0, 0, jst)}, // over the year-end holidays
+			{date(6, 15), 0, date(6, 15)},
+		}
+		for _, tt := range tests {
+			if got := tokyo.Add(tt.from, tt.n); !got.Equal(tt.want) {
+				t.Errorf("Add(%s, %d) = %s, want %s", tt.from.Format("2006-01-02"), tt.n,
+					got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		}
+	})
+
+	t.Run("Count includes both ends", func(t *testing.T) {
+		if got := tokyo.Count(date(4, 29), date(5, 10)); got != 7 {
+			t.Errorf("Count() = %d, want 7", got)
+		}
+		if got := tokyo.Count(date(5, 10), date(4, 29)); got != 0 {
+			t.Errorf("Count() of a reversed range = %d, want 0", got)
+		}
+	})
+
+	t.Run("PeriodStart", func(t *testing.T) {
+		tests := []struct {
+			name string
+			end  time.Time
+			n    int
+			want time.Time
+		}{
+			{"ends on a business day", date(5, 10), 5, date(5, 2)},
+			{"ends on a weekend", date(5, 12), 5, date(5, 2)},
+			{"one business day", date(5, 12), 1, date(5, 10)},
+		}
+		for _, tt := range tests {
+			got := tokyo.PeriodStart(tt.end, tt.n)
+			if !got.Equal(tt.want) {
+				t.Errorf("%s: PeriodStart() = %s, want %s", tt.name, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+			if count := tokyo.Count(got, tt.end); count != tt.n {
+				t.Errorf("%s: %d business days in the period, want %d", tt.name, count, tt.n)
+			}
+		}
+	})
+
+	t.Run("other markets only close on weekends", func(t *testing.T) {
+		newYork := NewMarketBusinessDay(NewYorkMarketHours())
+		if !newYork.IsBusinessDay(time.Date(2024, 5, 6, 12, 0, 0, 0, newYork.Location())) {
+			t.Error("a Japanese holiday should be a business day in New York")
+		}
+	})
+}
+
+func TestHistoryPeriod_Start(t *testing.T) {
+	calendar := NewMarketBusinessDay(TokyoMarketHours())
+	now := time.Date(2024, 5, 10, 15, 0, 0, 0, calendar.Location())
+
+	tests := []struct {
+		name   string
+		period PriceHistoryPeriod
+		want   time.Time
+	}{
+		{"calendar days", PriceHistoryPeriod{Days: 10}, now.AddDate(0, 0, -10)},
+		{"business days", PriceHistoryPeriod{Days: 5, BusinessDays: true}, time.Date(2024, 5, 2, 0, 0, 0, 0, calendar.Location())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Start(now, calendar); !got.Equal(tt.want) {
+				t.Errorf("Start() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := (PriceHistoryPeriod{}).Validate(); err == nil {
+		t.Error("Validate() should fail for zero days")
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"math"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// BuyingPower is the cash that can be spent on new purchases while keeping the target cash
+// position of the portfolio and the margin of the short positions.
+type BuyingPower struct {
+	Cash              float64 // cash balances held
+	TargetCashPercent float64 // share of the portfolio value kept in cash
+	Reserved          float64 // cash kept for the target cash position
+	Margin            float64 // required margin of the short positions
+	Available         float64 // cash left for purchases, 0 if the cash is below the reserve
+	InvestedPercent   float64 // share of the portfolio value invested in other than cash
+}
+
+// CashPercent returns the share of the portfolio value held in cash.
+func (b BuyingPower) CashPercent() float64 {
+	return 100 - b.InvestedPercent
+}
+
+// BelowTarget reports whether the cash is below the target cash position.
+func (b BuyingPower) BelowTarget() bool {
+	return b.Cash < b.Reserved
+}
+
+// Covers reports whether a purchase of the amount fits in the buying power.
+func (b BuyingPower) Covers(amount float64) bool {
+	return amount <= b.Available
+}
+
+// CalculateBuyingPower calculates the buying power from the cash holdings of the portfolio.
+// The target cash position is kept out of the buying power, as is the margin of the short positions.
+func CalculateBuyingPower(summary *PortfolioSummary, targetCashPercent float64) BuyingPower {
+	power := BuyingPower{TargetCashPercent: targetCashPercent}
+	for _, holding := range summary.Holdings {
+		if holding.AssetClass == models.AssetClassCash {
+			power.Cash += holding.CurrentValue
+		}
+		power.Margin += holding.RequiredMargin
+	}
+
+	if summary.TotalValue > 0 {
+		power.InvestedPercent = (summary.TotalValue - power.Cash) / summary.TotalValue * 100
+		power.Reserved = summary.TotalValue * targetCashPercent / 100
+	}
+	power.Available = math.Max(power.Cash-power.Reserved-power.Margin, 0)
+	return power
+}
+
+// HasCash reports whether the portfolio holds a cash balance, without which the buying power is not
+// tracked.
+func HasCash(summary *PortfolioSummary) bool {
+	for _, holding := range summary.Holdings {
+		if holding.AssetClass == models.AssetClassCash {
+			return true
+		}
+	}
+	return false
+}
+
+// JudgeBuySignals sets the cost of one trading unit and whether it fits in the buying power to the
+// technical summaries with a buy signal.
+func JudgeBuySignals(technicals []TechnicalSummary, power BuyingPower) {
+	for i := range technicals {
+		if technicals[i].Action != "buy" || technicals[i].Price <= 0 {
+			continue
+		}
+		technicals[i].LotCost = technicals[i].Price * PaperLotSize
+		technicals[i].WithinBuyingPower = power.Covers(technicals[i].LotCost)
+	}
+}
+
+// FormatBuyingPowerSection formats the buying power as a section of the report.
+func FormatBuyingPowerSection(power BuyingPower) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("buying_power.section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	b.WriteString(strings.Join(FormatBuyingPowerLines(power), "\n") + "\n")
+	return b.String()
+}
+
+// FormatBuyingPowerLines formats the buying power as lines of the report.
+func FormatBuyingPowerLines(power BuyingPower) []string {
+	lines := []string{
+		i18n.T("buying_power.cash", formatCurrency(power.Cash), power.CashPercent()),
+		i18n.T("buying_power.invested", power.InvestedPercent),
+	}
+	if power.TargetCashPercent > 0 {
+		lines = append(lines, i18n.T("buying_power.reserved", power.TargetCashPercent, formatCurrency(power.Reserved)))
+	}
+	if power.Margin > 0 {
+		lines = append(lines, i18n.T("buying_power.margin", formatCurrency(power.Margin)))
+	}
+	lines = append(lines, i18n.T("buying_power.available", formatCurrency(power.Available)))
+	if power.BelowTarget() {
+		lines = append(lines, i18n.T("buying_power.below_target"))
+	}
+	return lines
+}
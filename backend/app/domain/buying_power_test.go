@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCalculateBuyingPower(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *PortfolioSummary
+		target  float64
+		want    BuyingPower
+	}{
+		{
+			name: "Cash above the target",
+			summary: &PortfolioSummary{
+				TotalValue: 2000000,
+				Holdings: []HoldingSummary{
+					{Code: "7203", AssetClass: models.AssetClassStock, CurrentValue: 1500000},
+					{Code: "JPY", AssetClass: models.AssetClassCash, CurrentValue: 500000},
+				},
+			},
+			target: 10,
+			want:   BuyingPower{Cash: 500000, TargetCashPercent: 10, Reserved: 200000, Available: 300000, InvestedPercent: 75},
+		},
+		{
+			name: "Short margin is kept out",
+			summary: &PortfolioSummary{
+				TotalValue: 2000000,
+				Holdings: []HoldingSummary{
+					{Code: "6758", AssetClass: models.AssetClassStock, CurrentValue: 1500000, RequiredMargin: 250000},
+					{Code: "JPY", AssetClass: models.AssetClassCash, CurrentValue: 500000},
+				},
+			},
+			target: 10,
+			want:   BuyingPower{Cash: 500000, TargetCashPercent: 10, Reserved: 200000, Margin: 250000, Available: 50000, InvestedPercent: 75},
+		},
+		{
+			name: "Cash below the target",
+			summary: &PortfolioSummary{
+				TotalValue: 1000000,
+				Holdings: []HoldingSummary{
+					{Code: "7203", AssetClass: models.AssetClassStock, CurrentValue: 950000},
+					{Code: "JPY", AssetClass: models.AssetClassCash, CurrentValue: 50000},
+				},
+			},
+			target: 10,
+			want:   BuyingPower{Cash: 50000, TargetCashPercent: 10, Reserved: 100000, Available: 0, InvestedPercent: 95},
+		},
+		{
+			name:    "Empty portfolio",
+			summary: &PortfolioSummary{},
+			target:  10,
+			want:    BuyingPower{TargetCashPercent: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateBuyingPower(tt.summary, tt.target)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CalculateBuyingPower() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJudgeBuySignals(t *testing.T) {
+	technicals := []TechnicalSummary{
+		{Code: "7203", Action: "buy", Price: 2500},
+		{Code: "6758", Action: "buy", Price: 12000},
+		{Code: "9984", Action: "sell", Price: 8000},
+	}
+	JudgeBuySignals(technicals, BuyingPower{Available: 300000})
+
+	want := []TechnicalSummary{
+		{Code: "7203", Action: "buy", Price: 2500, LotCost: 250000, WithinBuyingPower: true},
+		{Code: "6758", Action: "buy", Price: 12000, LotCost: 1200000},
+		{Code: "9984", Action: "sell", Price: 8000},
+	}
+	if diff := cmp.Diff(want, technicals); diff != "" {
+		t.Errorf("JudgeBuySignals() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// HighCollectErrorRate is the share of stocks failing in a collection run that raises an alert.
+const HighCollectErrorRate = 0.5
+
+// minCollectErrorRateStocks is the number of stocks a collection run needs for its error rate to
+// raise an alert, so that a single failing stock collected on its own does not.
+const minCollectErrorRateStocks = 4
+
+// collectErrorSamples is the number of failed stocks listed in the error rate alert.
+const collectErrorSamples = 5
+
+// IsHighCollectErrorRate reports whether failed of total stocks is a high error rate.
+func IsHighCollectErrorRate(failed, total int) bool {
+	if total < minCollectErrorRateStocks {
+		return false
+	}
+	return float64(failed)/float64(total) >= HighCollectErrorRate
+}
+
+// FormatCollectErrorRateAlert formats the alert of a collection run in which many stocks failed,
+// listing a few of the failed stocks in code order.
+func FormatCollectErrorRateAlert(failed map[string]error, total int) string {
+	codes := make([]string, 0, len(failed))
+	for code := range failed {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	lines := []string{i18n.T("collect_alert.error_rate", len(failed), total, float64(len(failed))/float64(total)*100)}
+	for i, code := range codes {
+		if i == collectErrorSamples {
+			lines = append(lines, i18n.T("collect_alert.error_more", len(codes)-collectErrorSamples))
+			break
+		}
+		lines = append(lines, "- "+code+": "+failed[code].Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatPriceAnomalyAlert formats the alert of a collected price far from the latest stored one.
+func FormatPriceAnomalyAlert(code string, previousClose, close float64) string {
+	return i18n.T("collect_alert.anomaly", code, previousClose, close, (close/previousClose-1)*100)
+}
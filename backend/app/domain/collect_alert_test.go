@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsHighCollectErrorRate(t *testing.T) {
+	tests := []struct {
+		failed, total int
+		want          bool
+	}{
+		{failed: 0, total: 10, want: false},
+		{failed: 4, total: 10, want: false},
+		{failed: 5, total: 10, want: true},
+		{failed: 3, total: 3, want: false}, // too few stocks to tell
+		{failed: 2, total: 4, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := IsHighCollectErrorRate(tt.failed, tt.total); got != tt.want {
+			t.Errorf("IsHighCollectErrorRate(%d, %d) = %v, want %v", tt.failed, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCollectErrorRateAlert(t *testing.T) {
+	failed := map[string]error{}
+	for _, code := range []string{"9984", "1301", "7203", "6758", "8306", "2914"} {
+		failed[code] = errors.New("timeout")
+	}
+
+	got := FormatCollectErrorRateAlert(failed, 8)
+
+	want := "🚨 価格収集のエラー率が高くなっています: 6 / 8銘柄 (75%) が失敗\n" +
+		"- 1301: timeout\n- 2914: timeout\n- 6758: timeout\n- 7203: timeout\n- 8306: timeout\n" +
+		"...他1銘柄"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FormatCollectErrorRateAlert() mismatch (-want +got):\n%s", diff)
+	}
+}
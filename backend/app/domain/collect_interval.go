@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCollectInterval is the collection interval of stocks without their own interval,
+// the same as the schedule of the price update during market hours.
+const DefaultCollectInterval = 5 * time.Minute
+
+// collectIntervalTolerance absorbs the delay of the scheduled runs, so that a stock is not
+// skipped by one run because it was collected a few seconds less than the interval ago.
+const collectIntervalTolerance = time.Minute
+
+// CollectInterval returns the collection interval of a watch list item set in minutes,
+// or DefaultCollectInterval if it is not set.
+func CollectInterval(minutes int) time.Duration {
+	if minutes <= 0 {
+		return DefaultCollectInterval
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// ParseCollectInterval parses a collection interval such as "5m", "2h" or "1d" into minutes.
+// "default" or "0" returns 0, which means the default interval.
+func ParseCollectInterval(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "default" || s == "0" {
+		return 0, nil
+	}
+
+	var minutes int
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("収集間隔の形式が不正です (例: 5m, 1h, 1d): %s", s)
+		}
+		minutes = n * 24 * 60
+	} else {
+		d, err := time.ParseDuration(s)
+		if err != nil || d%time.Minute != 0 {
+			return 0, fmt.Errorf("収集間隔の形式が不正です (例: 5m, 1h, 1d): %s", s)
+		}
+		minutes = int(d / time.Minute)
+	}
+
+	if minutes < int(DefaultCollectInterval/time.Minute) {
+		return 0, fmt.Errorf("収集間隔は%d分以上で指定してください: %s", int(DefaultCollectInterval/time.Minute), s)
+	}
+	return minutes, nil
+}
+
+// FormatCollectInterval formats a collection interval in minutes, e.g. "1d", "2h" or "30m".
+func FormatCollectInterval(minutes int) string {
+	switch {
+	case minutes <= 0:
+		return fmt.Sprintf("default (%dm)", int(DefaultCollectInterval/time.Minute))
+	case minutes%(24*60) == 0:
+		return fmt.Sprintf("%dd", minutes/(24*60))
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// IsCollectionDue reports whether a stock last collected at last is due for collection at now.
+// Stocks never collected are always due.
+func IsCollectionDue(interval time.Duration, last, now time.Time) bool {
+	if last.IsZero() {
+		return true
+	}
+	return now.Sub(last) >= interval-collectIntervalTolerance
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCollectInterval(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{input: "5m", want: 5},
+		{input: "90m", want: 90},
+		{input: "2h", want: 120},
+		{input: "1d", want: 1440},
+		{input: "default", want: 0},
+		{input: "0", want: 0},
+		{input: "1m", wantErr: true},
+		{input: "30s", wantErr: true},
+		{input: "daily", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseCollectInterval(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCollectInterval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCollectInterval() = %d, want %d", got, tt.want)
+			}
+			if !tt.wantErr && tt.want > 0 {
+				if back, _ := ParseCollectInterval(FormatCollectInterval(got)); back != got {
+					t.Errorf("FormatCollectInterval(%d) = %s does not parse back", got, FormatCollectInterval(got))
+				}
+			}
+		})
+	}
+}
+
+func TestIsCollectionDue(t *testing.T) {
+	last := time.Date(2024, 6, 7, 10, 0, 5, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		last     time.Time
+		now      time.Time
+		want     bool
+	}{
+		{name: "Never collected", interval: time.Hour, now: last, want: true},
+		{name: "Next run of the default interval", interval: DefaultCollectInterval, last: last, now: last.Add(5*time.Minute - 5*time.Second), want: true},
+		{name: "Within the interval", interval: time.Hour, last: last, now: last.Add(30 * time.Minute), want: false},
+		{name: "Interval elapsed", interval: time.Hour, last: last, now: last.Add(time.Hour), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCollectionDue(tt.interval, tt.last, tt.now); got != tt.want {
+				t.Errorf("IsCollectionDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// Default quarantine of the stocks whose price collection keeps failing, e.g. delisted stocks.
+const (
+	DefaultQuarantineThreshold = 5              // consecutive failures after which a stock is quarantined
+	DefaultQuarantinePeriod    = 24 * time.Hour // period until the first retry
+)
+
+// maxQuarantinePeriod is the longest period between the retries of a quarantined stock.
+const maxQuarantinePeriod = 7 * 24 * time.Hour
+
+// RecordCollectFailure counts a failure to collect the price of a stock on the record of its earlier
+// failures, nil if it has none, and returns the updated record. The stock is quarantined once it
+// reaches threshold failures in a row, for a period doubling with each failed retry up to a week.
+// Returns true as well if the stock has just been quarantined after being collected normally.
+func RecordCollectFailure(failure *models.CollectFailure, code string, err error, now time.Time, threshold int, period time.Duration) (*models.CollectFailure, bool) {
+	if failure == nil {
+		failure = &models.CollectFailure{Code: code}
+	}
+	wasQuarantined := failure.QuarantinedUntil != nil
+
+	failure.ConsecutiveFailures++
+	failure.LastError = err.Error()
+	failure.LastFailedAt = now
+
+	if failure.ConsecutiveFailures < threshold {
+		return failure, false
+	}
+
+	retry := period
+	for i := threshold; i < failure.ConsecutiveFailures && retry < maxQuarantinePeriod; i++ {
+		retry *= 2
+	}
+	until := now.Add(min(retry, maxQuarantinePeriod))
+	failure.QuarantinedUntil = &until
+	return failure, !wasQuarantined
+}
+
+// FormatQuarantineAlert formats the notice of a stock quarantined after failing in a row.
+func FormatQuarantineAlert(failure *models.CollectFailure) string {
+	return i18n.T("collect_quarantine.quarantined", failure.Code, failure.ConsecutiveFailures,
+		failure.QuarantinedUntil.Format("2006-01-02 15:04"), failure.LastError)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+func TestRecordCollectFailure(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	err := errors.New("not found")
+
+	var failure *models.CollectFailure
+	for i := 1; i < 3; i++ {
+		var quarantined bool
+		failure, quarantined = RecordCollectFailure(failure, "1234", err, now, 3, 24*time.Hour)
+		if quarantined || failure.QuarantinedUntil != nil {
+			t.Fatalf("failure %d quarantined the stock before the threshold", i)
+		}
+	}
+
+	// Quarantined at the threshold, for the period
+	failure, quarantined := RecordCollectFailure(failure, "1234", err, now, 3, 24*time.Hour)
+	if !quarantined || !failure.IsQuarantined(now) || !failure.QuarantinedUntil.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("RecordCollectFailure() at threshold = %+v, %v, want quarantined for a day", failure, quarantined)
+	}
+	if failure.IsQuarantined(now.Add(24 * time.Hour)) {
+		t.Error("IsQuarantined() after the period = true, want a retry")
+	}
+
+	// Each failed retry doubles the period, not notified again, up to a week
+	wantDays := []int{2, 4, 7, 7}
+	for _, days := range wantDays {
+		failure, quarantined = RecordCollectFailure(failure, "1234", err, now, 3, 24*time.Hour)
+		if quarantined {
+			t.Error("RecordCollectFailure() on a failed retry reported a new quarantine")
+		}
+		if got := failure.QuarantinedUntil.Sub(now); got != time.Duration(days)*24*time.Hour {
+			t.Errorf("after %d failures quarantined for %v, want %d days", failure.ConsecutiveFailures, got, days)
+		}
+	}
+	if failure.ConsecutiveFailures != 7 || failure.LastError != "not found" {
+		t.Errorf("failure = %+v, want 7 failures with the last error", failure)
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
 )
 
 // Package-level service instances for compatibility
@@ -22,23 +23,29 @@ func GeneratePortfolioReport(summary *PortfolioSummary) string {
 
 // CalculateAllIndicators is a compatibility wrapper for the technical analysis service method
 func CalculateAllIndicators(prices []models.StockPrice) *models.TechnicalIndicator {
+	return CalculateAllIndicatorsWithParameters(prices, DefaultIndicatorParameters())
+}
+
+// CalculateAllIndicatorsWithParameters is a compatibility wrapper that applies strategy parameters
+func CalculateAllIndicatorsWithParameters(prices []models.StockPrice, params IndicatorParameters) *models.TechnicalIndicator {
 	// Convert to StockPriceData
 	priceData := make([]StockPriceData, len(prices))
-	for i, p := range prices {
+	for i, price := range prices {
+		p := price.Adjusted()
 		priceData[i] = StockPriceData{
 			Code:      p.Code,
 			Date:      p.Date,
-			Open:      technicalAnalysisService.decimalToFloat(p.OpenPrice),
-			High:      technicalAnalysisService.decimalToFloat(p.HighPrice),
-			Low:       technicalAnalysisService.decimalToFloat(p.LowPrice),
-			Close:     technicalAnalysisService.decimalToFloat(p.ClosePrice),
+			Open:      utility.DecimalToFloat(p.OpenPrice),
+			High:      utility.DecimalToFloat(p.HighPrice),
+			Low:       utility.DecimalToFloat(p.LowPrice),
+			Close:     utility.DecimalToFloat(p.ClosePrice),
 			Volume:    p.Volume,
 			Timestamp: p.Date,
 		}
 	}
 
 	// Calculate indicators
-	indicatorData := technicalAnalysisService.CalculateAllIndicators(priceData)
+	indicatorData := technicalAnalysisService.CalculateAllIndicatorsWithParameters(priceData, params)
 	if indicatorData == nil {
 		return nil
 	}
@@ -1,11 +1,12 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/boost-jp/stock-automation/app/domain/models"
-	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/utility"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -28,7 +29,7 @@ func TestCalculatePortfolioSummary(t *testing.T) {
 					Code:          "1234",
 					Name:          "Test Stock",
 					Shares:        100,
-					PurchasePrice: client.FloatToDecimal(1000.0),
+					PurchasePrice: utility.FloatToDecimal(1000.0),
 					PurchaseDate:  time.Now(),
 				},
 			},
@@ -47,7 +48,7 @@ func TestCalculatePortfolioSummary(t *testing.T) {
 					Code:          "5678",
 					Name:          "Test Stock 2",
 					Shares:        50,
-					PurchasePrice: client.FloatToDecimal(2000.0),
+					PurchasePrice: utility.FloatToDecimal(2000.0),
 					PurchaseDate:  time.Now(),
 				},
 			},
@@ -66,14 +67,14 @@ func TestCalculatePortfolioSummary(t *testing.T) {
 					Code:          "1234",
 					Name:          "Test Stock 1",
 					Shares:        100,
-					PurchasePrice: client.FloatToDecimal(1000.0),
+					PurchasePrice: utility.FloatToDecimal(1000.0),
 					PurchaseDate:  time.Now(),
 				},
 				{
 					Code:          "5678",
 					Name:          "Test Stock 2",
 					Shares:        50,
-					PurchasePrice: client.FloatToDecimal(2000.0),
+					PurchasePrice: utility.FloatToDecimal(2000.0),
 					PurchaseDate:  time.Now(),
 				},
 			},
@@ -102,7 +103,7 @@ func TestCalculatePortfolioSummary(t *testing.T) {
 					Code:          "1234",
 					Name:          "Test Stock",
 					Shares:        100,
-					PurchasePrice: client.FloatToDecimal(1000.0),
+					PurchasePrice: utility.FloatToDecimal(1000.0),
 					PurchaseDate:  time.Now(),
 				},
 			},
@@ -232,7 +233,7 @@ func TestGeneratePortfolioReport(t *testing.T) {
 			report := GeneratePortfolioReport(tt.summary)
 
 			for _, expectedString := range tt.expectedContains {
-				if !contains(report, expectedString) {
+				if !strings.Contains(report, expectedString) {
 					t.Errorf("Report should contain expected string: %s\nActual report:\n%s", expectedString, report)
 				}
 			}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// ContributionRankingSize is the number of holdings in each ranking of the contribution analysis.
+const ContributionRankingSize = 5
+
+// HoldingContribution is the profit or loss of a holding and its contribution to the return of the portfolio.
+type HoldingContribution struct {
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	Amount       float64 `json:"amount"`       // profit or loss in yen
+	Percent      float64 `json:"percent"`      // return of the holding: gain on cost, or price change from the previous close
+	Contribution float64 `json:"contribution"` // contribution to the return of the portfolio in percentage points
+}
+
+// ContributionAnalysis ranks the holdings by their contribution to the unrealized gain of the
+// portfolio, and by how much their prices moved from the previous close.
+type ContributionAnalysis struct {
+	Top                []HoldingContribution `json:"top"`    // largest contributions to the unrealized gain first
+	Worst              []HoldingContribution `json:"worst"`  // largest drags on the unrealized gain first
+	Movers             []HoldingContribution `json:"movers"` // largest price changes from the previous close first
+	DailyChange        float64               `json:"daily_change"`
+	DailyChangePercent float64               `json:"daily_change_percent"`
+}
+
+// CalculateContributions analyzes the contributions of the holdings with a current price.
+//
+// The contribution of a holding to the unrealized gain is its gain on the total cost of the
+// portfolio, so that the contributions add up to the return of the portfolio. The daily change
+// of a holding is the change of its value from the previous close, and its contribution is the
+// change on the value of the portfolio at the previous close. Holdings without a previous price
+// are valued at their current price for the previous close, and cash balances do not move.
+func CalculateContributions(portfolios []*models.Portfolio, currentPrices, previousPrices map[string]float64) ContributionAnalysis {
+	var (
+		analysis      ContributionAnalysis
+		gains, movers []HoldingContribution
+		totalCost     float64
+		previousTotal float64
+	)
+
+	for _, holding := range portfolios {
+		if holding.IsCash() {
+			totalCost += holding.CalculatePurchaseCost()
+			previousTotal += holding.CalculateCurrentValue(1)
+			continue
+		}
+		current, ok := currentPrices[holding.Code]
+		if !ok {
+			continue
+		}
+		totalCost += holding.CalculatePurchaseCost()
+
+		if gain := holding.CalculateGain(current); gain != 0 {
+			gains = append(gains, HoldingContribution{
+				Code:    holding.Code,
+				Name:    holding.Name,
+				Amount:  gain,
+				Percent: holding.CalculateGainPercent(current),
+			})
+		}
+
+		previous, ok := previousPrices[holding.Code]
+		if !ok || previous <= 0 {
+			previousTotal += holding.CalculateCurrentValue(current)
+			continue
+		}
+		previousTotal += holding.CalculateCurrentValue(previous)
+		change := holding.CalculateCurrentValue(current) - holding.CalculateCurrentValue(previous)
+		analysis.DailyChange += change
+		movers = append(movers, HoldingContribution{
+			Code:    holding.Code,
+			Name:    holding.Name,
+			Amount:  change,
+			Percent: (current/previous - 1) * 100,
+		})
+	}
+
+	for i := range gains {
+		if totalCost > 0 {
+			gains[i].Contribution = gains[i].Amount / totalCost * 100
+		}
+	}
+	for i := range movers {
+		if previousTotal > 0 {
+			movers[i].Contribution = movers[i].Amount / previousTotal * 100
+		}
+	}
+	if previousTotal > 0 {
+		analysis.DailyChangePercent = analysis.DailyChange / previousTotal * 100
+	}
+
+	sort.SliceStable(gains, func(i, j int) bool { return gains[i].Amount > gains[j].Amount })
+	for _, gain := range gains {
+		if gain.Amount > 0 && len(analysis.Top) < ContributionRankingSize {
+			analysis.Top = append(analysis.Top, gain)
+		}
+	}
+	for i := len(gains) - 1; i >= 0 && gains[i].Amount < 0 && len(analysis.Worst) < ContributionRankingSize; i-- {
+		analysis.Worst = append(analysis.Worst, gains[i])
+	}
+
+	sort.SliceStable(movers, func(i, j int) bool { return math.Abs(movers[i].Percent) > math.Abs(movers[j].Percent) })
+	if len(movers) > ContributionRankingSize {
+		movers = movers[:ContributionRankingSize]
+	}
+	analysis.Movers = movers
+
+	return analysis
+}
+
+// ContributionRanking is a ranking of the contribution analysis formatted for display.
+type ContributionRanking struct {
+	Title string
+	Lines []string
+}
+
+// FormatContributionRankings formats the rankings of the contribution analysis: the top and worst
+// contributors to the unrealized gain and the biggest movers of the day. Rankings without holdings are left out.
+func FormatContributionRankings(analysis ContributionAnalysis) []ContributionRanking {
+	var rankings []ContributionRanking
+	gainRanking := func(title string, contributions []HoldingContribution) {
+		if len(contributions) == 0 {
+			return
+		}
+		ranking := ContributionRanking{Title: title}
+		for i, c := range contributions {
+			ranking.Lines = append(ranking.Lines, i18n.T("contribution.gain_item", i+1, c.Name, c.Code,
+				formatSignedCurrency(c.Amount), c.Percent, c.Contribution))
+		}
+		rankings = append(rankings, ranking)
+	}
+	gainRanking(i18n.T("contribution.top", ContributionRankingSize), analysis.Top)
+	gainRanking(i18n.T("contribution.worst", ContributionRankingSize), analysis.Worst)
+
+	if len(analysis.Movers) > 0 {
+		ranking := ContributionRanking{
+			Title: i18n.T("contribution.movers", formatSignedCurrency(analysis.DailyChange), analysis.DailyChangePercent),
+		}
+		for i, c := range analysis.Movers {
+			ranking.Lines = append(ranking.Lines, i18n.T("contribution.mover_item", i+1, c.Name, c.Code,
+				c.Percent, formatSignedCurrency(c.Amount), c.Contribution))
+		}
+		rankings = append(rankings, ranking)
+	}
+	return rankings
+}
+
+// FormatContributionSection formats the contribution analysis as a section of the report.
+func FormatContributionSection(analysis ContributionAnalysis) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("contribution.section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	for _, ranking := range FormatContributionRankings(analysis) {
+		b.WriteString(ranking.Title + "\n")
+		for _, line := range ranking.Lines {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// formatSignedCurrency formats an amount in yen with comma separators and an explicit sign.
+func formatSignedCurrency(value float64) string {
+	if math.Round(value) > 0 {
+		return "+" + formatCurrency(value)
+	}
+	return formatCurrency(value)
+}
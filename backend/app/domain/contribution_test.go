@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCalculateContributions(t *testing.T) {
+	holding := func(code string, shares int, price float64, positionType string) *models.Portfolio {
+		return &models.Portfolio{
+			Code:          code,
+			Name:          "Stock " + code,
+			Shares:        shares,
+			PurchasePrice: utility.FloatToDecimal(price),
+			PositionType:  positionType,
+			AssetClass:    models.AssetClassStock,
+		}
+	}
+	portfolios := []*models.Portfolio{
+		holding("1001", 100, 1000, models.PositionTypeLong),  // cost 100,000
+		holding("1002", 100, 2000, models.PositionTypeLong),  // cost 200,000
+		holding("1003", 100, 1500, models.PositionTypeShort), // cost 150,000
+		holding("1004", 100, 500, models.PositionTypeLong),   // no price
+		{Code: "JPY", Name: "現金", Shares: 50000, PurchasePrice: utility.FloatToDecimal(1), AssetClass: models.AssetClassCash},
+	}
+	current := map[string]float64{"1001": 1200, "1002": 1800, "1003": 1400}
+	previous := map[string]float64{"1001": 1100, "1002": 1900}
+
+	got := CalculateContributions(portfolios, current, previous)
+
+	// Total cost 500,000 and value at the previous close 110,000 + 190,000 + 160,000 + 50,000
+	want := ContributionAnalysis{
+		Top: []HoldingContribution{
+			{Code: "1001", Name: "Stock 1001", Amount: 20000, Percent: 20, Contribution: 4},
+			{Code: "1003", Name: "Stock 1003", Amount: 10000, Percent: 20.0 / 3, Contribution: 2},
+		},
+		Worst: []HoldingContribution{
+			{Code: "1002", Name: "Stock 1002", Amount: -20000, Percent: -10, Contribution: -4},
+		},
+		Movers: []HoldingContribution{
+			{Code: "1001", Name: "Stock 1001", Amount: 10000, Percent: 100.0 / 11, Contribution: 10000.0 / 5100},
+			{Code: "1002", Name: "Stock 1002", Amount: -10000, Percent: -100.0 / 19, Contribution: -10000.0 / 5100},
+		},
+		DailyChange:        0,
+		DailyChangePercent: 0,
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("CalculateContributions() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCalculateContributions_RankingSize(t *testing.T) {
+	var portfolios []*models.Portfolio
+	current := map[string]float64{}
+	for i := 0; i < ContributionRankingSize+2; i++ {
+		code := string(rune('A' + i))
+		portfolios = append(portfolios, &models.Portfolio{Code: code, Shares: 1, PurchasePrice: utility.FloatToDecimal(100)})
+		current[code] = float64(101 + i)
+	}
+
+	got := CalculateContributions(portfolios, current, nil)
+
+	if len(got.Top) != ContributionRankingSize || got.Top[0].Code != "G" {
+		t.Errorf("Top = %+v, want %d holdings led by G", got.Top, ContributionRankingSize)
+	}
+	if len(got.Worst) != 0 || len(got.Movers) != 0 {
+		t.Errorf("Worst = %+v, Movers = %+v, want none", got.Worst, got.Movers)
+	}
+}
+
+func TestFormatContributionSection(t *testing.T) {
+	section := FormatContributionSection(ContributionAnalysis{
+		Top:         []HoldingContribution{{Code: "7203", Name: "トヨタ自動車", Amount: 123456, Percent: 12.5, Contribution: 3.2}},
+		Movers:      []HoldingContribution{{Code: "6758", Name: "ソニーグループ", Amount: -4000, Percent: -2.5, Contribution: -0.1}},
+		DailyChange: -4000,
+	})
+
+	for _, want := range []string{"トヨタ自動車 (7203): ¥+123,456 (+12.50%", "ソニーグループ (6758): -2.50% ¥-4,000"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("section should contain %q:\n%s", want, section)
+		}
+	}
+}
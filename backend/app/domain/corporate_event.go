@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// CorporateEventType is the kind of a scheduled corporate event of a stock.
+type CorporateEventType string
+
+// Kinds of corporate events registered to the calendar.
+const (
+	CorporateEventEarnings        CorporateEventType = "earnings"
+	CorporateEventRecordDate      CorporateEventType = "record_date"
+	CorporateEventDividendPayment CorporateEventType = "dividend_payment"
+)
+
+// CorporateEvent is a scheduled earnings announcement or dividend date of a stock.
+type CorporateEvent struct {
+	Code string
+	Name string
+	Type CorporateEventType
+	Date time.Time
+	// Period identifies the fiscal period or distribution the event belongs to, e.g. "2025-03 Q1",
+	// so that an event whose date is changed keeps the same key
+	Period string
+	Detail string
+}
+
+// Key identifies the event across syncs: the stock, the kind and the period, or the date if the period is unknown.
+func (e CorporateEvent) Key() string {
+	period := e.Period
+	if period == "" {
+		period = e.Date.Format("2006-01-02")
+	}
+	return strings.Join([]string{e.Code, string(e.Type), period}, "/")
+}
+
+// Title returns the title of the event, e.g. "トヨタ自動車 (7203) 決算発表".
+func (e CorporateEvent) Title() string {
+	label := e.Code
+	if e.Name != "" {
+		label = fmt.Sprintf("%s (%s)", e.Name, e.Code)
+	}
+	return i18n.T("corporate_event."+string(e.Type), label)
+}
+
+// Description returns the description of the event, starting with its details if any.
+func (e CorporateEvent) Description() string {
+	description := i18n.T("corporate_event.description", e.Code)
+	if e.Detail != "" {
+		description = e.Detail + "\n" + description
+	}
+	return description
+}
+
+// UpcomingCorporateEvents returns the events from the day of from until to in date and code order.
+// Events with the same key are merged, keeping the latest date, as a rescheduled event may be listed twice.
+func UpcomingCorporateEvents(events []CorporateEvent, from, to time.Time) []CorporateEvent {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+
+	byKey := make(map[string]CorporateEvent)
+	for _, event := range events {
+		if event.Date.Before(start) || event.Date.After(to) {
+			continue
+		}
+		if existing, ok := byKey[event.Key()]; ok && !event.Date.After(existing.Date) {
+			continue
+		}
+		byKey[event.Key()] = event
+	}
+
+	upcoming := make([]CorporateEvent, 0, len(byKey))
+	for _, event := range byKey {
+		upcoming = append(upcoming, event)
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		if !upcoming[i].Date.Equal(upcoming[j].Date) {
+			return upcoming[i].Date.Before(upcoming[j].Date)
+		}
+		if upcoming[i].Code != upcoming[j].Code {
+			return upcoming[i].Code < upcoming[j].Code
+		}
+		return upcoming[i].Type < upcoming[j].Type
+	})
+	return upcoming
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUpcomingCorporateEvents(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.Local) }
+	events := []CorporateEvent{
+		{Code: "9984", Type: CorporateEventEarnings, Date: day(20), Period: "2025-03 Q1"},
+		{Code: "7203", Type: CorporateEventRecordDate, Date: day(5)}, // before the range
+		{Code: "7203", Type: CorporateEventEarnings, Date: day(12), Period: "2025-03 Q1"},
+		// Rescheduled: the later listing of the same period wins
+		{Code: "7203", Type: CorporateEventEarnings, Date: day(14), Period: "2025-03 Q1"},
+		{Code: "6758", Type: CorporateEventDividendPayment, Date: day(20)},
+		{Code: "6758", Type: CorporateEventRecordDate, Date: day(31)}, // after the range
+	}
+
+	got := UpcomingCorporateEvents(events, time.Date(2024, 6, 10, 9, 30, 0, 0, time.Local), day(30))
+
+	var keys []string
+	for _, event := range got {
+		keys = append(keys, event.Key()+" "+event.Date.Format("01-02"))
+	}
+	want := []string{
+		"7203/earnings/2025-03 Q1 06-14",
+		"6758/dividend_payment/2024-06-20 06-20",
+		"9984/earnings/2025-03 Q1 06-20",
+	}
+	if diff := cmp.Diff(want, keys); diff != "" {
+		t.Errorf("UpcomingCorporateEvents() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCorporateEvent_Title(t *testing.T) {
+	event := CorporateEvent{Code: "7203", Name: "トヨタ自動車", Type: CorporateEventRecordDate, Date: time.Now(), Detail: "1株あたり配当 45円"}
+	if got, want := event.Title(), "トヨタ自動車 (7203) 権利確定日"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if got, want := event.Description(), "1株あたり配当 45円\n銘柄コード: 7203\nstock-automation が登録した予定です"; got != want {
+		t.Errorf("Description() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,283 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// CorrelationAnalysisService analyzes how the daily returns of holdings move together.
+type CorrelationAnalysisService struct {
+	// MinObservations is the minimum number of common daily returns required
+	MinObservations int
+	// HighCorrelation is the correlation from which a pair is reported as moving together
+	HighCorrelation float64
+}
+
+// NewCorrelationAnalysisService creates a new correlation analysis service.
+func NewCorrelationAnalysisService() *CorrelationAnalysisService {
+	return &CorrelationAnalysisService{
+		MinObservations: 20,
+		HighCorrelation: 0.7,
+	}
+}
+
+// CorrelationInput is the price history of a holding.
+type CorrelationInput struct {
+	Code   string
+	Name   string
+	Weight float64          // current value of the holding
+	Short  bool             // returns of short positions are inverted
+	Prices []StockPriceData // oldest first
+}
+
+// CorrelationPair is the correlation of two holdings.
+type CorrelationPair struct {
+	CodeA       string
+	CodeB       string
+	Correlation float64
+}
+
+// CorrelationAnalysis is the correlation matrix of holdings and the resulting diversification.
+type CorrelationAnalysis struct {
+	Codes              []string
+	Names              []string
+	Matrix             [][]float64 // Matrix[i][j] is the correlation of Codes[i] and Codes[j]
+	Observations       int         // number of common daily returns
+	AverageCorrelation float64     // mean of the correlations of all pairs
+	EffectiveHoldings  float64     // number of independent holdings the portfolio is equivalent to
+	HighPairs          []CorrelationPair
+	Excluded           []string // codes without enough price history
+}
+
+// Analyze calculates the correlation matrix of the daily returns on the dates common to all holdings.
+// Holdings with fewer than MinObservations returns are excluded. The effective number of holdings is
+// 1 / Σᵢ Σⱼ wᵢ wⱼ ρᵢⱼ with value weights, which equals the number of holdings when they are uncorrelated
+// and equally weighted, and approaches 1 as the correlations approach 1.
+func (s *CorrelationAnalysisService) Analyze(inputs []CorrelationInput) *CorrelationAnalysis {
+	analysis := &CorrelationAnalysis{}
+
+	// Daily returns keyed by date
+	type series struct {
+		input   CorrelationInput
+		returns map[string]float64
+	}
+	var included []series
+	for _, input := range inputs {
+		returns := dailyReturns(input.Prices, input.Short)
+		if len(returns) < s.MinObservations {
+			analysis.Excluded = append(analysis.Excluded, input.Code)
+			continue
+		}
+		included = append(included, series{input: input, returns: returns})
+	}
+
+	if len(included) == 0 {
+		return analysis
+	}
+
+	// Dates common to all included holdings
+	var dates []string
+	for date := range included[0].returns {
+		common := true
+		for _, other := range included[1:] {
+			if _, ok := other.returns[date]; !ok {
+				common = false
+				break
+			}
+		}
+		if common {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	if len(dates) < s.MinObservations {
+		for _, sr := range included {
+			analysis.Excluded = append(analysis.Excluded, sr.input.Code)
+		}
+		return analysis
+	}
+
+	n := len(included)
+	values := make([][]float64, n)
+	totalWeight := 0.0
+	for i, sr := range included {
+		analysis.Codes = append(analysis.Codes, sr.input.Code)
+		analysis.Names = append(analysis.Names, sr.input.Name)
+		values[i] = make([]float64, len(dates))
+		for k, date := range dates {
+			values[i][k] = sr.returns[date]
+		}
+		totalWeight += math.Abs(sr.input.Weight)
+	}
+	analysis.Observations = len(dates)
+
+	analysis.Matrix = make([][]float64, n)
+	for i := range analysis.Matrix {
+		analysis.Matrix[i] = make([]float64, n)
+		analysis.Matrix[i][i] = 1
+	}
+
+	pairSum := 0.0
+	pairs := 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			correlation := pearsonCorrelation(values[i], values[j])
+			analysis.Matrix[i][j] = correlation
+			analysis.Matrix[j][i] = correlation
+			pairSum += correlation
+			pairs++
+
+			if correlation >= s.HighCorrelation {
+				analysis.HighPairs = append(analysis.HighPairs, CorrelationPair{
+					CodeA:       analysis.Codes[i],
+					CodeB:       analysis.Codes[j],
+					Correlation: correlation,
+				})
+			}
+		}
+	}
+	if pairs > 0 {
+		analysis.AverageCorrelation = pairSum / float64(pairs)
+	}
+	sort.SliceStable(analysis.HighPairs, func(a, b int) bool {
+		return analysis.HighPairs[a].Correlation > analysis.HighPairs[b].Correlation
+	})
+
+	// Equal weights are used when no value is known
+	weights := make([]float64, n)
+	for i, sr := range included {
+		if totalWeight > 0 {
+			weights[i] = math.Abs(sr.input.Weight) / totalWeight
+		} else {
+			weights[i] = 1 / float64(n)
+		}
+	}
+
+	concentration := 0.0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			concentration += weights[i] * weights[j] * analysis.Matrix[i][j]
+		}
+	}
+	if concentration > 0 {
+		analysis.EffectiveHoldings = 1 / concentration
+	} else {
+		analysis.EffectiveHoldings = float64(n)
+	}
+
+	return analysis
+}
+
+// DiversificationLevel rates the diversification by the average correlation.
+func (a *CorrelationAnalysis) DiversificationLevel() string {
+	switch {
+	case len(a.Codes) < 2:
+		return i18n.T("correlation.level.unknown")
+	case a.AverageCorrelation < 0.3:
+		return i18n.T("correlation.level.good")
+	case a.AverageCorrelation < 0.6:
+		return i18n.T("correlation.level.fair")
+	default:
+		return i18n.T("correlation.level.poor")
+	}
+}
+
+// FormatCorrelationReport formats the analysis as a report section.
+func (s *CorrelationAnalysisService) FormatCorrelationReport(analysis *CorrelationAnalysis) string {
+	report := i18n.T("correlation.title") + "\n"
+	report += "━━━━━━━━━━━━━━━━━━━━\n"
+
+	if len(analysis.Codes) < 2 {
+		report += i18n.T("correlation.too_few_holdings") + "\n"
+		if len(analysis.Excluded) > 0 {
+			report += i18n.T("correlation.insufficient_history", strings.Join(analysis.Excluded, ", ")) + "\n"
+		}
+		return report
+	}
+
+	report += i18n.T("correlation.targets", len(analysis.Codes), analysis.Observations) + "\n"
+	report += i18n.T("correlation.average", analysis.AverageCorrelation) + "\n"
+	report += i18n.T("correlation.effective_holdings", analysis.EffectiveHoldings, len(analysis.Codes)) + "\n"
+	report += i18n.T("correlation.diversification", analysis.DiversificationLevel()) + "\n\n"
+
+	// 相関行列
+	report += i18n.T("correlation.matrix") + "\n"
+	report += fmt.Sprintf("%-6s", "")
+	for _, code := range analysis.Codes {
+		report += fmt.Sprintf(" %6s", code)
+	}
+	report += "\n"
+	for i, code := range analysis.Codes {
+		report += fmt.Sprintf("%-6s", code)
+		for j := range analysis.Codes {
+			report += fmt.Sprintf(" %6.2f", analysis.Matrix[i][j])
+		}
+		report += "\n"
+	}
+
+	if len(analysis.HighPairs) > 0 {
+		report += "\n" + i18n.T("correlation.high_pairs", s.HighCorrelation) + "\n"
+		for _, pair := range analysis.HighPairs {
+			report += fmt.Sprintf("   - %s / %s: %.2f\n", pair.CodeA, pair.CodeB, pair.Correlation)
+		}
+	}
+
+	if len(analysis.Excluded) > 0 {
+		report += "\n" + i18n.T("correlation.excluded", strings.Join(analysis.Excluded, ", ")) + "\n"
+	}
+
+	return report
+}
+
+// dailyReturns calculates close-to-close returns keyed by date. Returns of short positions are inverted.
+func dailyReturns(prices []StockPriceData, short bool) map[string]float64 {
+	returns := make(map[string]float64, len(prices))
+	for i := 1; i < len(prices); i++ {
+		previous := prices[i-1].Close
+		if previous <= 0 {
+			continue
+		}
+
+		r := prices[i].Close/previous - 1
+		if short {
+			r = -r
+		}
+		returns[prices[i].Date.Format("2006-01-02")] = r
+	}
+	return returns
+}
+
+// pearsonCorrelation calculates the correlation coefficient of two series of the same length.
+// Returns 0 if either series has no variance.
+func pearsonCorrelation(x, y []float64) float64 {
+	n := float64(len(x))
+	if n == 0 {
+		return 0
+	}
+
+	meanX, meanY := 0.0, 0.0
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	cov, varX, varY := 0.0, 0.0, 0.0
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
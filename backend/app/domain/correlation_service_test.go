@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// pricesFromReturns builds a daily price series starting at 1000 from the given returns.
+func pricesFromReturns(returns []float64) []StockPriceData {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := []StockPriceData{{Date: start, Close: 1000}}
+	for i, r := range returns {
+		prices = append(prices, StockPriceData{
+			Date:  start.AddDate(0, 0, i+1),
+			Close: prices[i].Close * (1 + r),
+		})
+	}
+	return prices
+}
+
+func TestCorrelationAnalysisService_Analyze(t *testing.T) {
+	service := NewCorrelationAnalysisService()
+	service.MinObservations = 4
+
+	base := []float64{0.01, -0.02, 0.015, -0.005, 0.02, -0.01}
+	inverse := make([]float64, len(base))
+	for i, r := range base {
+		inverse[i] = -r
+	}
+
+	tests := []struct {
+		name     string
+		inputs   []CorrelationInput
+		expected *CorrelationAnalysis
+	}{
+		{
+			name: "Perfectly correlated holdings",
+			inputs: []CorrelationInput{
+				{Code: "7203", Name: "トヨタ", Weight: 100, Prices: pricesFromReturns(base)},
+				{Code: "7267", Name: "ホンダ", Weight: 300, Prices: pricesFromReturns(base)},
+			},
+			expected: &CorrelationAnalysis{
+				Codes:              []string{"7203", "7267"},
+				Names:              []string{"トヨタ", "ホンダ"},
+				Matrix:             [][]float64{{1, 1}, {1, 1}},
+				Observations:       6,
+				AverageCorrelation: 1,
+				EffectiveHoldings:  1,
+				HighPairs:          []CorrelationPair{{CodeA: "7203", CodeB: "7267", Correlation: 1}},
+			},
+		},
+		{
+			name: "Short position hedging an inverse holding",
+			inputs: []CorrelationInput{
+				{Code: "7203", Weight: 100, Prices: pricesFromReturns(base)},
+				{Code: "1357", Weight: 100, Short: true, Prices: pricesFromReturns(inverse)},
+			},
+			expected: &CorrelationAnalysis{
+				Codes:              []string{"7203", "1357"},
+				Names:              []string{"", ""},
+				Matrix:             [][]float64{{1, 1}, {1, 1}},
+				Observations:       6,
+				AverageCorrelation: 1,
+				EffectiveHoldings:  1,
+				HighPairs:          []CorrelationPair{{CodeA: "7203", CodeB: "1357", Correlation: 1}},
+			},
+		},
+		{
+			name: "Holding without enough history is excluded",
+			inputs: []CorrelationInput{
+				{Code: "7203", Weight: 100, Prices: pricesFromReturns(base)},
+				{Code: "9983", Weight: 100, Prices: pricesFromReturns(base[:2])},
+			},
+			expected: &CorrelationAnalysis{
+				Codes:             []string{"7203"},
+				Names:             []string{""},
+				Matrix:            [][]float64{{1}},
+				Observations:      6,
+				EffectiveHoldings: 1,
+				Excluded:          []string{"9983"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.Analyze(tt.inputs)
+			if diff := cmp.Diff(tt.expected, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Analyze() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCorrelationAnalysisService_EffectiveHoldings(t *testing.T) {
+	service := NewCorrelationAnalysisService()
+	service.MinObservations = 4
+
+	// Two uncorrelated holdings of equal value count as two independent holdings
+	a := []float64{0.01, -0.01, 0.01, -0.01}
+	b := []float64{0.01, 0.01, -0.01, -0.01}
+	analysis := service.Analyze([]CorrelationInput{
+		{Code: "A", Weight: 100, Prices: pricesFromReturns(a)},
+		{Code: "B", Weight: 100, Prices: pricesFromReturns(b)},
+	})
+
+	if diff := cmp.Diff(2.0, analysis.EffectiveHoldings, cmpopts.EquateApprox(0, 1e-2)); diff != "" {
+		t.Errorf("EffectiveHoldings mismatch (-want +got):\n%s", diff)
+	}
+	if analysis.DiversificationLevel() != "良好" {
+		t.Errorf("DiversificationLevel() = %s, want 良好", analysis.DiversificationLevel())
+	}
+
+	report := service.FormatCorrelationReport(analysis)
+	for _, want := range []string{"平均相関", "有効銘柄数: 2.0 / 2銘柄", "相関行列"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report does not contain %q:\n%s", want, report)
+		}
+	}
+}
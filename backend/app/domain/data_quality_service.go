@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// DataQualityService handles price data quality analysis.
+type DataQualityService struct {
+	// MaxDailyChangePercent is the close-to-close change treated as an anomaly
+	MaxDailyChangePercent float64
+}
+
+// NewDataQualityService creates a new data quality service.
+func NewDataQualityService() *DataQualityService {
+	return &DataQualityService{
+		MaxDailyChangePercent: 30.0,
+	}
+}
+
+// StockDataQuality represents data quality metrics of a single stock.
+type StockDataQuality struct {
+	Code         string
+	Name         string
+	ExpectedDays int
+	ActualDays   int
+	MissingDays  int
+	MissingRate  float64 // 0-100 (%)
+	AnomalyCount int
+	LastUpdated  time.Time
+}
+
+// HasIssues reports whether the stock has any quality issue.
+func (q StockDataQuality) HasIssues() bool {
+	return q.MissingDays > 0 || q.AnomalyCount > 0 || q.LastUpdated.IsZero()
+}
+
+// DataQualityReport represents a data quality report for multiple stocks.
+type DataQualityReport struct {
+	From        time.Time
+	To          time.Time
+	Stocks      []StockDataQuality
+	GeneratedAt time.Time
+}
+
+// IssueCount returns the number of stocks with quality issues.
+func (r *DataQualityReport) IssueCount() int {
+	count := 0
+	for _, stock := range r.Stocks {
+		if stock.HasIssues() {
+			count++
+		}
+	}
+	return count
+}
+
+// AnalyzeStockPrices calculates data quality metrics for the given period.
+// Expected trading days are weekdays in the period; exchange holidays are not excluded.
+// The prices are one per day, as stored with a unique code and date.
+func (s *DataQualityService) AnalyzeStockPrices(code, name string, prices []StockPriceData, from, to time.Time) StockDataQuality {
+	quality := StockDataQuality{
+		Code:         code,
+		Name:         name,
+		ExpectedDays: countWeekdays(from, to),
+	}
+
+	var previousClose float64
+
+	for _, price := range prices {
+		if weekday := price.Date.Weekday(); weekday != time.Saturday && weekday != time.Sunday {
+			quality.ActualDays++
+		}
+
+		if s.isAnomaly(price, previousClose) {
+			quality.AnomalyCount++
+		}
+		if price.Close > 0 {
+			previousClose = price.Close
+		}
+
+		if price.Date.After(quality.LastUpdated) {
+			quality.LastUpdated = price.Date
+		}
+	}
+
+	quality.MissingDays = quality.ExpectedDays - quality.ActualDays
+	if quality.MissingDays < 0 {
+		quality.MissingDays = 0
+	}
+
+	if quality.ExpectedDays > 0 {
+		quality.MissingRate = float64(quality.MissingDays) / float64(quality.ExpectedDays) * 100
+	}
+
+	return quality
+}
+
+// isAnomaly checks OHLC consistency and abnormal daily changes.
+func (s *DataQualityService) isAnomaly(price StockPriceData, previousClose float64) bool {
+	if price.Open <= 0 || price.High <= 0 || price.Low <= 0 || price.Close <= 0 {
+		return true
+	}
+
+	if price.High < price.Low {
+		return true
+	}
+
+	if price.Close > price.High || price.Close < price.Low || price.Open > price.High || price.Open < price.Low {
+		return true
+	}
+
+	if price.Volume < 0 {
+		return true
+	}
+
+	return s.IsAbnormalChange(previousClose, price.Close)
+}
+
+// IsAbnormalChange reports whether the change from the previous close exceeds MaxDailyChangePercent.
+// A change from an unknown previous close is never abnormal.
+func (s *DataQualityService) IsAbnormalChange(previousClose, close float64) bool {
+	if previousClose <= 0 {
+		return false
+	}
+	change := math.Abs(close-previousClose) / previousClose * 100
+	return change > s.MaxDailyChangePercent
+}
+
+// GenerateDataQualityReport generates a formatted data quality report.
+func (s *DataQualityService) GenerateDataQualityReport(report *DataQualityReport) string {
+	return RenderReportText(s.BuildDataQualityReport(report))
+}
+
+// BuildDataQualityReport builds the data quality report as a document for the renderers of the outputs.
+func (s *DataQualityService) BuildDataQualityReport(report *DataQualityReport) *ReportDocument {
+	builder := NewReportBuilder(i18n.T("data_quality.title")).
+		Subtitle(i18n.T("data_quality.period", report.From.Format("2006-01-02"), report.To.Format("2006-01-02"))).
+		Blank()
+
+	if len(report.Stocks) == 0 {
+		return builder.Line(i18n.T("report.no_stocks")).Document()
+	}
+
+	builder.Section("", i18n.T("data_quality.summary", len(report.Stocks), report.IssueCount()))
+
+	for _, stock := range report.Stocks {
+		icon := "✅"
+		if stock.HasIssues() {
+			icon = "⚠️"
+		}
+
+		lastUpdated := i18n.T("data_quality.no_data")
+		if !stock.LastUpdated.IsZero() {
+			lastUpdated = stock.LastUpdated.Format("2006-01-02")
+		}
+
+		builder.Item(icon, fmt.Sprintf("%s (%s)", stock.Name, stock.Code),
+			i18n.T("data_quality.missing", stock.MissingRate, stock.MissingDays, stock.ExpectedDays),
+			i18n.T("data_quality.anomalies", stock.AnomalyCount),
+			i18n.T("data_quality.last_updated", lastUpdated))
+	}
+
+	return builder.Document()
+}
+
+// countWeekdays counts weekdays between from and to (inclusive).
+func countWeekdays(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	count := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			count++
+		}
+	}
+	return count
+}
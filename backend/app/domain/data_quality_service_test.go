@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDataQualityService_AnalyzeStockPrices(t *testing.T) {
+	service := NewDataQualityService()
+
+	// 2024-01-08 (Mon) - 2024-01-12 (Fri): 5 weekdays
+	from := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		prices   []StockPriceData
+		expected StockDataQuality
+	}{
+		{
+			name: "Complete data",
+			prices: []StockPriceData{
+				{Date: day(8), Open: 100, High: 105, Low: 95, Close: 100},
+				{Date: day(9), Open: 100, High: 105, Low: 95, Close: 101},
+				{Date: day(10), Open: 100, High: 105, Low: 95, Close: 102},
+				{Date: day(11), Open: 100, High: 105, Low: 95, Close: 103},
+				{Date: day(12), Open: 100, High: 105, Low: 95, Close: 104},
+			},
+			expected: StockDataQuality{
+				Code:         "7203",
+				Name:         "トヨタ自動車",
+				ExpectedDays: 5,
+				ActualDays:   5,
+				LastUpdated:  day(12),
+			},
+		},
+		{
+			name: "Missing and anomaly",
+			prices: []StockPriceData{
+				{Date: day(8), Open: 100, High: 105, Low: 95, Close: 100},
+				{Date: day(9), Open: 100, High: 90, Low: 95, Close: 101},    // high < low
+				{Date: day(11), Open: 140, High: 150, Low: 130, Close: 140}, // +38.6%
+			},
+			expected: StockDataQuality{
+				Code:         "7203",
+				Name:         "トヨタ自動車",
+				ExpectedDays: 5,
+				ActualDays:   3,
+				MissingDays:  2,
+				MissingRate:  40,
+				AnomalyCount: 2,
+				LastUpdated:  day(11),
+			},
+		},
+		{
+			name:   "No data",
+			prices: []StockPriceData{},
+			expected: StockDataQuality{
+				Code:         "7203",
+				Name:         "トヨタ自動車",
+				ExpectedDays: 5,
+				MissingDays:  5,
+				MissingRate:  100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := service.AnalyzeStockPrices("7203", "トヨタ自動車", tt.prices, from, to)
+			if diff := cmp.Diff(tt.expected, result); diff != "" {
+				t.Errorf("Data quality mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDataQualityService_GenerateDataQualityReport(t *testing.T) {
+	service := NewDataQualityService()
+
+	report := &DataQualityReport{
+		From: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+		Stocks: []StockDataQuality{
+			{Code: "7203", Name: "トヨタ自動車", ExpectedDays: 5, ActualDays: 5, LastUpdated: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+			{Code: "6758", Name: "ソニーグループ", ExpectedDays: 5, MissingDays: 5, MissingRate: 100},
+		},
+	}
+
+	text := service.GenerateDataQualityReport(report)
+
+	expectedContents := []string{
+		"価格データ品質レポート",
+		"対象期間: 2024-01-08 〜 2024-01-12",
+		"対象銘柄: 2件 / 要確認: 1件",
+		"✅ トヨタ自動車 (7203)",
+		"⚠️ ソニーグループ (6758)",
+		"欠損率: 100.0% (5/5日)",
+		"最終更新日: データなし",
+	}
+
+	for _, expected := range expectedContents {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Report should contain %q\n%s", expected, text)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// minDiscoveryBaseDays is the number of trading days before the recent days needed to judge
+// a volume surge or a new high.
+const minDiscoveryBaseDays = 20
+
+// DiscoveryCriteria configures the detection of watch list candidates from the daily prices of the market.
+type DiscoveryCriteria struct {
+	LookbackDays     int     // trading days of prices compared, including the recent days
+	RecentDays       int     // latest trading days checked for a volume surge or a new high
+	VolumeSurgeRatio float64 // average volume of the recent days to that of the earlier days
+	MinAverageVolume float64 // average volume of the earlier days below which a stock is too illiquid
+	MaxCandidates    int     // candidates proposed at most
+}
+
+// DefaultDiscoveryCriteria returns the criteria of the weekly proposal.
+func DefaultDiscoveryCriteria() DiscoveryCriteria {
+	return DiscoveryCriteria{
+		LookbackDays:     60,
+		RecentDays:       5,
+		VolumeSurgeRatio: 3.0,
+		MinAverageVolume: 100000,
+		MaxCandidates:    10,
+	}
+}
+
+// Validate validates discovery criteria.
+func (c DiscoveryCriteria) Validate() error {
+	if c.RecentDays <= 0 {
+		return fmt.Errorf("検出対象日数は1以上である必要があります")
+	}
+	if c.LookbackDays < c.RecentDays+minDiscoveryBaseDays {
+		return fmt.Errorf("比較期間は検出対象日数より%d営業日以上長い必要があります", minDiscoveryBaseDays)
+	}
+	if c.VolumeSurgeRatio <= 1 {
+		return fmt.Errorf("出来高急増の倍率は1より大きい必要があります")
+	}
+	if c.MinAverageVolume < 0 {
+		return fmt.Errorf("最低平均出来高は0以上である必要があります")
+	}
+	if c.MaxCandidates <= 0 {
+		return fmt.Errorf("候補数の上限は1以上である必要があります")
+	}
+	return nil
+}
+
+// DiscoveryCandidate is a stock proposed for the watch list.
+type DiscoveryCandidate struct {
+	Code          string
+	Name          string
+	Close         float64
+	ChangePercent float64 // change over the recent days
+	VolumeRatio   float64 // average volume of the recent days to that of the earlier days
+	VolumeSurge   bool
+	NewHigh       bool // the recent days reached above the high of the earlier days
+	LookbackDays  int  // trading days of the earlier days compared
+}
+
+// FindDiscoveryCandidates detects the stocks whose volume surged or which made a new high in the recent
+// days compared with the earlier days of their price history, ranked with the stocks showing both first
+// and then by the volume ratio. Price histories must be oldest first; stocks with too short a history
+// or too little volume are left out.
+func FindDiscoveryCandidates(history map[string][]StockPriceData, criteria DiscoveryCriteria) []DiscoveryCandidate {
+	var candidates []DiscoveryCandidate
+	for code, prices := range history {
+		base := len(prices) - criteria.RecentDays
+		if base < minDiscoveryBaseDays {
+			continue
+		}
+
+		baseVolume, baseHigh := 0.0, 0.0
+		for _, price := range prices[:base] {
+			baseVolume += float64(price.Volume)
+			baseHigh = max(baseHigh, price.High)
+		}
+		baseVolume /= float64(base)
+		if baseVolume <= 0 || baseVolume < criteria.MinAverageVolume {
+			continue
+		}
+
+		recentVolume, recentHigh := 0.0, 0.0
+		for _, price := range prices[base:] {
+			recentVolume += float64(price.Volume)
+			recentHigh = max(recentHigh, price.High)
+		}
+		recentVolume /= float64(criteria.RecentDays)
+
+		candidate := DiscoveryCandidate{
+			Code:         code,
+			Close:        prices[len(prices)-1].Close,
+			VolumeRatio:  recentVolume / baseVolume,
+			NewHigh:      recentHigh > baseHigh,
+			LookbackDays: base,
+		}
+		candidate.VolumeSurge = candidate.VolumeRatio >= criteria.VolumeSurgeRatio
+		if !candidate.VolumeSurge && !candidate.NewHigh {
+			continue
+		}
+		if previous := prices[base-1].Close; previous > 0 {
+			candidate.ChangePercent = (candidate.Close/previous - 1) * 100
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if both := a.VolumeSurge && a.NewHigh; both != (b.VolumeSurge && b.NewHigh) {
+			return both
+		}
+		if a.VolumeRatio != b.VolumeRatio {
+			return a.VolumeRatio > b.VolumeRatio
+		}
+		return a.Code < b.Code
+	})
+
+	if len(candidates) > criteria.MaxCandidates {
+		candidates = candidates[:criteria.MaxCandidates]
+	}
+	return candidates
+}
+
+// GenerateDiscoveryReport generates the weekly proposal of watch list candidates.
+// added lists the codes of the candidates added to the watch list automatically.
+func GenerateDiscoveryReport(candidates []DiscoveryCandidate, added []string) string {
+	return RenderReportText(BuildDiscoveryReport(candidates, added))
+}
+
+// BuildDiscoveryReport builds the weekly proposal as a document for the renderers of the outputs.
+func BuildDiscoveryReport(candidates []DiscoveryCandidate, added []string) *ReportDocument {
+	report := NewReportBuilder(i18n.T("discovery.title")).Section("", "")
+
+	if len(candidates) == 0 {
+		return report.Line(i18n.T("discovery.none")).Document()
+	}
+
+	addedCodes := make(map[string]bool, len(added))
+	for _, code := range added {
+		addedCodes[code] = true
+	}
+
+	for i, candidate := range candidates {
+		name := candidate.Name
+		if name == "" {
+			name = candidate.Code
+		}
+
+		var reasons []string
+		if candidate.VolumeSurge {
+			reasons = append(reasons, i18n.T("discovery.volume_surge", candidate.VolumeRatio))
+		}
+		if candidate.NewHigh {
+			reasons = append(reasons, i18n.T("discovery.new_high", candidate.LookbackDays))
+		}
+		if addedCodes[candidate.Code] {
+			reasons = append(reasons, i18n.T("discovery.added"))
+		}
+		report.Item("", i18n.T("discovery.candidate", i+1, name, candidate.Code, formatCurrency(candidate.Close), candidate.ChangePercent),
+			reasons...)
+	}
+
+	if len(added) == 0 {
+		report.Blank().Line(i18n.T("discovery.hint"))
+	}
+	return report.Document()
+}
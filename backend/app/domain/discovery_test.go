@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// discoveryPrices returns 25 days of prices at close 100 and volume 200000, followed by the recent days.
+func discoveryPrices(recent ...StockPriceData) []StockPriceData {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var prices []StockPriceData
+	for i := 0; i < 25; i++ {
+		prices = append(prices, StockPriceData{Date: start.AddDate(0, 0, i), Open: 100, High: 105, Low: 95, Close: 100, Volume: 200000})
+	}
+	return append(prices, recent...)
+}
+
+// quietDays returns n days like the earlier days.
+func quietDays(n int) []StockPriceData {
+	days := make([]StockPriceData, n)
+	for i := range days {
+		days[i] = StockPriceData{Open: 100, High: 104, Low: 96, Close: 101, Volume: 200000}
+	}
+	return days
+}
+
+func TestFindDiscoveryCandidates(t *testing.T) {
+	surge := quietDays(5)
+	surge[4].Volume = 3800000 // the recent days average 4.6x the usual volume
+
+	high := quietDays(5)
+	high[2].High = 110
+	high[4].Close = 108
+
+	both := quietDays(5)
+	for i := range both {
+		both[i].Volume = 700000
+	}
+	both[4].High = 106
+
+	illiquid := quietDays(5)
+	illiquid[4].Volume = 100000000
+
+	history := map[string][]StockPriceData{
+		"1001": discoveryPrices(surge...),
+		"1002": discoveryPrices(high...),
+		"1003": discoveryPrices(both...),
+		"1004": discoveryPrices(quietDays(5)...),
+		"1005": quietDays(10), // too short
+		"1006": append(quietDays(25), illiquid...),
+	}
+	for i := range history["1006"][:25] {
+		history["1006"][i].Volume = 1000
+	}
+
+	criteria := DefaultDiscoveryCriteria()
+	candidates := FindDiscoveryCandidates(history, criteria)
+
+	var codes []string
+	for _, candidate := range candidates {
+		codes = append(codes, candidate.Code)
+	}
+	// Both signals first, then by the volume ratio
+	if diff := cmp.Diff([]string{"1003", "1001", "1002"}, codes); diff != "" {
+		t.Fatalf("candidates mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := candidates[1]; !got.VolumeSurge || got.NewHigh || got.VolumeRatio != 4.6 {
+		t.Errorf("volume surge candidate = %+v, want a 4.6x volume surge only", got)
+	}
+	if got := candidates[2]; got.VolumeSurge || !got.NewHigh || got.LookbackDays != 25 || math.Abs(got.ChangePercent-8) > 1e-9 {
+		t.Errorf("new high candidate = %+v, want a new high over 25 days with +8%%", got)
+	}
+
+	criteria.MaxCandidates = 1
+	if got := FindDiscoveryCandidates(history, criteria); len(got) != 1 || got[0].Code != "1003" {
+		t.Errorf("FindDiscoveryCandidates() with MaxCandidates 1 = %+v, want 1003 only", got)
+	}
+}
+
+func TestDiscoveryCriteria_Validate(t *testing.T) {
+	if err := DefaultDiscoveryCriteria().Validate(); err != nil {
+		t.Errorf("default criteria should be valid: %v", err)
+	}
+
+	short := DefaultDiscoveryCriteria()
+	short.LookbackDays = short.RecentDays + 10
+	if err := short.Validate(); err == nil {
+		t.Error("lookback too short for the earlier days should be invalid")
+	}
+
+	ratio := DefaultDiscoveryCriteria()
+	ratio.VolumeSurgeRatio = 1
+	if err := ratio.Validate(); err == nil {
+		t.Error("volume surge ratio of 1 should be invalid")
+	}
+}
+
+func TestGenerateDiscoveryReport(t *testing.T) {
+	candidates := []DiscoveryCandidate{
+		{Code: "7203", Name: "トヨタ自動車", Close: 3590, ChangePercent: 5.2, VolumeRatio: 3.4, VolumeSurge: true, NewHigh: true, LookbackDays: 55},
+		{Code: "130A", Close: 815, ChangePercent: -1.5, VolumeRatio: 3.1, VolumeSurge: true},
+	}
+
+	report := GenerateDiscoveryReport(candidates, []string{"7203"})
+	for _, want := range []string{"1. トヨタ自動車 (7203) ¥3,590 (+5.20%)", "3.4倍", "直近55営業日", "追加しました", "2. 130A (130A) ¥815 (-1.50%)"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report should contain %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "discover --add") {
+		t.Errorf("report should not suggest adding candidates already added:\n%s", report)
+	}
+
+	if report := GenerateDiscoveryReport(nil, nil); !strings.Contains(report, "候補はありません") {
+		t.Errorf("empty report = %q", report)
+	}
+}
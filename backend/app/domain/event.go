@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Event names published on the event bus.
+const (
+	EventPriceUpdated    = "price.updated"
+	EventSignalGenerated = "signal.generated"
+	EventAlertSent       = "alert.sent"
+)
+
+// Event is something that happened in the application, published on the event bus so that
+// subscribers such as notifications, logs and statistics can react to it.
+type Event interface {
+	EventName() string
+}
+
+// PriceUpdatedEvent is published when a price collection run has completed.
+type PriceUpdatedEvent struct {
+	Updated []string // codes whose price was collected
+	Failed  []string // codes whose price could not be collected
+	Silent  bool     // the collection was run without alerts
+	At      time.Time
+}
+
+// EventName returns the name of the event.
+func (PriceUpdatedEvent) EventName() string { return EventPriceUpdated }
+
+// SignalGeneratedEvent is published when a buy or sell signal has been generated for a stock.
+type SignalGeneratedEvent struct {
+	Code       string
+	Action     string // "buy" or "sell"
+	Confidence float64
+	Reason     string
+	Price      float64
+	At         time.Time
+}
+
+// EventName returns the name of the event.
+func (SignalGeneratedEvent) EventName() string { return EventSignalGenerated }
+
+// AlertSentEvent is published when an alert has been sent to the notification channels.
+type AlertSentEvent struct {
+	Kind    string // message kind of the notification, e.g. "critical"
+	Code    string // stock code of a stock alert, empty otherwise
+	Message string
+	At      time.Time
+}
+
+// EventName returns the name of the event.
+func (AlertSentEvent) EventName() string { return EventAlertSent }
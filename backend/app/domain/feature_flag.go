@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Feature flags of the features rolled out step by step per environment.
+const (
+	FeaturePaperTrading     = "paper_trading"
+	FeatureDiscovery        = "discovery"
+	FeaturePriceMoveNotify  = "price_move_notification"
+	FeatureMADeviationAlert = "ma_deviation_alert"
+	FeatureHedgeAdvice      = "hedge_advice"
+	FeatureReportFollowUp   = "report_followup"
+	FeatureMorningBriefing  = "morning_briefing"
+)
+
+// DefaultFeatureEnvironment is the environment whose flags are read from the flag file when none is configured.
+const DefaultFeatureEnvironment = "development"
+
+// Sources of the state of a feature flag, from the lowest precedence to the highest.
+const (
+	FeatureFlagSourceDefault  = "default"
+	FeatureFlagSourceFile     = "file"
+	FeatureFlagSourceDatabase = "database"
+)
+
+// FeatureFlagDefinition describes a feature that can be turned on and off without a deployment.
+type FeatureFlagDefinition struct {
+	Name        string
+	Description string
+	Default     bool // state when neither the flag file nor the database sets it
+}
+
+// featureFlagDefinitions holds the registered feature flags by name.
+var featureFlagDefinitions = map[string]FeatureFlagDefinition{}
+
+func init() {
+	RegisterFeatureFlag(FeatureFlagDefinition{Name: FeaturePaperTrading, Description: "シグナルによるペーパートレードと週次成績レポートの定期実行", Default: true})
+	RegisterFeatureFlag(FeatureFlagDefinition{Name: FeatureDiscovery, Description: "監視銘柄候補の週次提案", Default: true})
+	RegisterFeatureFlag(FeatureFlagDefinition{Name: FeaturePriceMoveNotify, Description: "価格収集後の値動きサマリー通知", Default: true})
+	RegisterFeatureFlag(FeatureFlagDefinition{Name: FeatureMADeviationAlert, Description: "価格収集後の移動平均乖離率アラート", Default: true})
+	RegisterFeatureFlag(FeatureFlagDefinition{Name: FeatureHedgeAdvice, Description: "保有銘柄の急落時のヘッジ案の提案", Default: true})
+	RegisterFeatureFlag(FeatureFlagDefinition{Name: FeatureReportFollowUp, Description: "大引け後の確定値を日次レポートのスレッドに返信", Default: false})
+	RegisterFeatureFlag(FeatureFlagDefinition{Name: FeatureMorningBriefing, Description: "寄り前の市況・前日終値・決算予定のブリーフィング", Default: true})
+}
+
+// RegisterFeatureFlag registers a feature flag definition.
+// It panics on a duplicate or unnamed definition, as definitions are registered at init time.
+func RegisterFeatureFlag(def FeatureFlagDefinition) {
+	if def.Name == "" {
+		panic(fmt.Sprintf("incomplete feature flag definition: %+v", def))
+	}
+	if _, exists := featureFlagDefinitions[def.Name]; exists {
+		panic(fmt.Sprintf("feature flag %s is already registered", def.Name))
+	}
+	featureFlagDefinitions[def.Name] = def
+}
+
+// GetFeatureFlagDefinition returns the feature flag definition with the given name.
+func GetFeatureFlagDefinition(name string) (FeatureFlagDefinition, error) {
+	def, ok := featureFlagDefinitions[name]
+	if !ok {
+		return FeatureFlagDefinition{}, fmt.Errorf("フィーチャーフラグ %s は登録されていません", name)
+	}
+	return def, nil
+}
+
+// FeatureFlagDefinitions returns the registered feature flag definitions ordered by name.
+func FeatureFlagDefinitions() []FeatureFlagDefinition {
+	defs := make([]FeatureFlagDefinition, 0, len(featureFlagDefinitions))
+	for _, def := range featureFlagDefinitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// FeatureFlagFile is a feature flag file in YAML, for example:
+//
+//	defaults:
+//	  paper_trading: false
+//	environments:
+//	  staging:
+//	    paper_trading: true
+//
+// The flags of the environment of the process override the defaults of the file.
+type FeatureFlagFile struct {
+	Defaults     map[string]bool            `yaml:"defaults"`
+	Environments map[string]map[string]bool `yaml:"environments"`
+}
+
+// ParseFeatureFlagFile parses and validates a YAML feature flag file.
+// Unknown fields and flags not registered are rejected.
+func ParseFeatureFlagFile(raw []byte) (*FeatureFlagFile, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+
+	file := &FeatureFlagFile{}
+	if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("フィーチャーフラグファイルのYAMLが不正です: %w", err)
+	}
+
+	for name := range file.Defaults {
+		if _, err := GetFeatureFlagDefinition(name); err != nil {
+			return nil, err
+		}
+	}
+	for env, flags := range file.Environments {
+		for name := range flags {
+			if _, err := GetFeatureFlagDefinition(name); err != nil {
+				return nil, fmt.Errorf("環境 %s: %w", env, err)
+			}
+		}
+	}
+	return file, nil
+}
+
+// Flags returns the flags the file sets in the environment.
+func (f *FeatureFlagFile) Flags(environment string) map[string]bool {
+	flags := make(map[string]bool, len(f.Defaults))
+	for name, enabled := range f.Defaults {
+		flags[name] = enabled
+	}
+	for name, enabled := range f.Environments[environment] {
+		flags[name] = enabled
+	}
+	return flags
+}
+
+// FeatureFlagState is the effective state of a feature flag and where it is set.
+type FeatureFlagState struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Source      string `json:"source"`
+}
+
+// ResolveFeatureFlags resolves the state of the registered flags, with the database overrides
+// taking precedence over the flags of the file, which take precedence over the defaults.
+func ResolveFeatureFlags(fileFlags, overrides map[string]bool) []FeatureFlagState {
+	defs := FeatureFlagDefinitions()
+	states := make([]FeatureFlagState, len(defs))
+	for i, def := range defs {
+		state := FeatureFlagState{Name: def.Name, Description: def.Description, Enabled: def.Default, Source: FeatureFlagSourceDefault}
+		if enabled, ok := fileFlags[def.Name]; ok {
+			state.Enabled, state.Source = enabled, FeatureFlagSourceFile
+		}
+		if enabled, ok := overrides[def.Name]; ok {
+			state.Enabled, state.Source = enabled, FeatureFlagSourceDatabase
+		}
+		states[i] = state
+	}
+	return states
+}
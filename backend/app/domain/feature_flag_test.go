@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFeatureFlagFile(t *testing.T) {
+	raw := []byte(`
+defaults:
+  paper_trading: false
+  discovery: true
+environments:
+  staging:
+    paper_trading: true
+`)
+
+	file, err := ParseFeatureFlagFile(raw)
+	if err != nil {
+		t.Fatalf("ParseFeatureFlagFile() error = %v", err)
+	}
+
+	tests := []struct {
+		environment string
+		want        map[string]bool
+	}{
+		{"staging", map[string]bool{FeaturePaperTrading: true, FeatureDiscovery: true}},
+		{"production", map[string]bool{FeaturePaperTrading: false, FeatureDiscovery: true}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, file.Flags(tt.environment)); diff != "" {
+			t.Errorf("Flags(%s) mismatch (-want +got):\n%s", tt.environment, diff)
+		}
+	}
+
+	invalid := map[string]string{
+		"unknown flag":             "defaults:\n  no_such_feature: true\n",
+		"unknown environment flag": "environments:\n  staging:\n    no_such_feature: true\n",
+		"unknown field":            "flags:\n  paper_trading: true\n",
+		"not a boolean":            "defaults:\n  paper_trading: maybe\n",
+	}
+	for name, raw := range invalid {
+		if _, err := ParseFeatureFlagFile([]byte(raw)); err == nil {
+			t.Errorf("%s: ParseFeatureFlagFile() should fail", name)
+		}
+	}
+
+	if _, err := ParseFeatureFlagFile(nil); err != nil {
+		t.Errorf("an empty file should set no flags: %v", err)
+	}
+}
+
+func TestResolveFeatureFlags(t *testing.T) {
+	states := ResolveFeatureFlags(
+		map[string]bool{FeaturePaperTrading: false, FeatureDiscovery: false},
+		map[string]bool{FeatureDiscovery: true},
+	)
+
+	got := make(map[string]FeatureFlagState, len(states))
+	for _, state := range states {
+		state.Description = ""
+		got[state.Name] = state
+	}
+	want := map[string]FeatureFlagState{
+		FeaturePaperTrading:     {Name: FeaturePaperTrading, Enabled: false, Source: FeatureFlagSourceFile},
+		FeatureDiscovery:        {Name: FeatureDiscovery, Enabled: true, Source: FeatureFlagSourceDatabase},
+		FeaturePriceMoveNotify:  {Name: FeaturePriceMoveNotify, Enabled: true, Source: FeatureFlagSourceDefault},
+		FeatureMADeviationAlert: {Name: FeatureMADeviationAlert, Enabled: true, Source: FeatureFlagSourceDefault},
+		FeatureHedgeAdvice:      {Name: FeatureHedgeAdvice, Enabled: true, Source: FeatureFlagSourceDefault},
+		FeatureReportFollowUp:   {Name: FeatureReportFollowUp, Enabled: false, Source: FeatureFlagSourceDefault},
+		FeatureMorningBriefing:  {Name: FeatureMorningBriefing, Enabled: true, Source: FeatureFlagSourceDefault},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolveFeatureFlags() mismatch (-want +got):\n%s", diff)
+	}
+}
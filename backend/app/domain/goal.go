@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// GoalStatus is the pace of a goal.
+type GoalStatus string
+
+const (
+	GoalStatusOnTrack  GoalStatus = "on_track" // progress keeps up with the elapsed time
+	GoalStatusBehind   GoalStatus = "behind"   // progress lags behind the elapsed time
+	GoalStatusAchieved GoalStatus = "achieved" // the target value is reached
+	GoalStatusMissed   GoalStatus = "missed"   // the deadline passed without reaching the target
+)
+
+// goalPaceTolerance is how many points progress may lag behind the elapsed time before a goal is behind,
+// so that daily price moves do not flip the pace back and forth.
+const goalPaceTolerance = 5.0
+
+// GoalProgress represents the progress of a goal at the current portfolio value.
+type GoalProgress struct {
+	GoalID          string     `json:"goal_id"`
+	Name            string     `json:"name"`
+	BaselineValue   float64    `json:"baseline_value"`
+	TargetValue     float64    `json:"target_value"`
+	CurrentValue    float64    `json:"current_value"`
+	Deadline        time.Time  `json:"deadline"`
+	ProgressPercent float64    `json:"progress_percent"` // share of the targeted increase reached
+	ElapsedPercent  float64    `json:"elapsed_percent"`  // share of the period elapsed
+	Status          GoalStatus `json:"status"`
+}
+
+// Remaining returns the value still needed to reach the target, 0 if reached.
+func (p GoalProgress) Remaining() float64 {
+	return math.Max(p.TargetValue-p.CurrentValue, 0)
+}
+
+// CalculateGoalProgress calculates the progress and pace of a goal at currentValue on now.
+// A goal is behind when its progress lags more than 5 points behind the elapsed share of its period.
+func CalculateGoalProgress(goal *models.PortfolioGoal, currentValue float64, now time.Time) GoalProgress {
+	progress := GoalProgress{
+		GoalID:        goal.ID,
+		Name:          goal.Name,
+		BaselineValue: goal.BaselineValue,
+		TargetValue:   goal.TargetValue,
+		CurrentValue:  currentValue,
+		Deadline:      goal.Deadline,
+	}
+
+	if span := goal.TargetValue - goal.BaselineValue; span > 0 {
+		progress.ProgressPercent = (currentValue - goal.BaselineValue) / span * 100
+	}
+	if period := goal.Deadline.Sub(goal.StartDate); period > 0 {
+		elapsed := now.Sub(goal.StartDate).Seconds() / period.Seconds() * 100
+		progress.ElapsedPercent = math.Min(math.Max(elapsed, 0), 100)
+	}
+
+	switch {
+	case currentValue >= goal.TargetValue:
+		progress.Status = GoalStatusAchieved
+	case !now.Before(goal.Deadline):
+		progress.Status = GoalStatusMissed
+	case progress.ProgressPercent < progress.ElapsedPercent-goalPaceTolerance:
+		progress.Status = GoalStatusBehind
+	default:
+		progress.Status = GoalStatusOnTrack
+	}
+
+	return progress
+}
+
+// goalStatusIcons are the icons of goal paces in reports.
+var goalStatusIcons = map[GoalStatus]string{
+	GoalStatusOnTrack:  "🟢",
+	GoalStatusBehind:   "🟡",
+	GoalStatusAchieved: "🎉",
+	GoalStatusMissed:   "🔴",
+}
+
+// FormatGoalLine formats the progress of a goal in one line for reports.
+func FormatGoalLine(progress GoalProgress) string {
+	return i18n.T("goal.line",
+		goalStatusIcons[progress.Status],
+		progress.Name,
+		progress.ProgressPercent,
+		progress.ElapsedPercent,
+		i18n.T("goal.status."+string(progress.Status)))
+}
+
+// FormatGoalDetail formats the current and target values and the deadline of a goal.
+func FormatGoalDetail(progress GoalProgress) string {
+	return i18n.T("goal.detail",
+		formatCurrency(progress.CurrentValue),
+		formatCurrency(progress.TargetValue),
+		progress.Deadline.Format("2006-01-02"))
+}
+
+// FormatGoalSection formats the progress of goals as a report section.
+func FormatGoalSection(goals []GoalProgress) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("goal.section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	for _, goal := range goals {
+		b.WriteString(FormatGoalLine(goal) + "\n")
+		b.WriteString("  " + FormatGoalDetail(goal) + "\n")
+	}
+	return b.String()
+}
+
+// FormatGoalNotification formats the notification of a goal whose pace changed.
+func FormatGoalNotification(progress GoalProgress) string {
+	switch progress.Status {
+	case GoalStatusAchieved:
+		return i18n.T("goal.notify.achieved", progress.Name,
+			formatCurrency(progress.CurrentValue), formatCurrency(progress.TargetValue))
+	case GoalStatusMissed:
+		return i18n.T("goal.notify.missed", progress.Name, progress.Deadline.Format("2006-01-02"),
+			formatCurrency(progress.CurrentValue), formatCurrency(progress.TargetValue))
+	case GoalStatusBehind:
+		return i18n.T("goal.notify.behind", progress.Name,
+			progress.ProgressPercent, progress.ElapsedPercent, formatCurrency(progress.Remaining()))
+	default:
+		return i18n.T("goal.notify.on_track", progress.Name, progress.ProgressPercent, progress.ElapsedPercent)
+	}
+}
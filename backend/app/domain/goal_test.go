@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCalculateGoalProgress(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	// +10% from 1,000,000 over 100 days
+	goal := &models.PortfolioGoal{
+		ID:            "goal-1",
+		Name:          "year-end",
+		BaselineValue: 1000000,
+		TargetValue:   1100000,
+		StartDate:     day(1, 1),
+		Deadline:      day(4, 10),
+	}
+
+	type result struct {
+		Progress, Elapsed float64
+		Status            GoalStatus
+	}
+
+	tests := []struct {
+		name         string
+		currentValue float64
+		now          time.Time
+		want         result
+	}{
+		{
+			name:         "On track ahead of the elapsed time",
+			currentValue: 1060000,
+			now:          day(2, 20), // 50 days
+			want:         result{Progress: 60, Elapsed: 50, Status: GoalStatusOnTrack},
+		},
+		{
+			name:         "On track within the tolerance",
+			currentValue: 1046000,
+			now:          day(2, 20),
+			want:         result{Progress: 46, Elapsed: 50, Status: GoalStatusOnTrack},
+		},
+		{
+			name:         "Behind the elapsed time",
+			currentValue: 1020000,
+			now:          day(2, 20),
+			want:         result{Progress: 20, Elapsed: 50, Status: GoalStatusBehind},
+		},
+		{
+			name:         "Loss from the baseline",
+			currentValue: 950000,
+			now:          day(1, 11),
+			want:         result{Progress: -50, Elapsed: 10, Status: GoalStatusBehind},
+		},
+		{
+			name:         "Achieved before the deadline",
+			currentValue: 1120000,
+			now:          day(2, 20),
+			want:         result{Progress: 120, Elapsed: 50, Status: GoalStatusAchieved},
+		},
+		{
+			name:         "Missed after the deadline",
+			currentValue: 1090000,
+			now:          day(4, 20),
+			want:         result{Progress: 90, Elapsed: 100, Status: GoalStatusMissed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := CalculateGoalProgress(goal, tt.currentValue, tt.now)
+			got := result{Progress: progress.ProgressPercent, Elapsed: progress.ElapsedPercent, Status: progress.Status}
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b float64) bool {
+				return a-b < 1e-9 && b-a < 1e-9
+			})); diff != "" {
+				t.Errorf("CalculateGoalProgress() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// Hedge actions proposed when held stocks fall sharply. The actions are only advised, never executed.
+const (
+	HedgeActionInverseETF     = "inverse_etf"     // buy an inverse ETF against the falling exposure
+	HedgeActionRaiseCash      = "raise_cash"      // sell holdings to raise the cash ratio to the target
+	HedgeActionReducePosition = "reduce_position" // sell part of a holding that fell much further than the threshold
+)
+
+// HedgeAdviceRules holds the thresholds of the hedge advice.
+type HedgeAdviceRules struct {
+	DropPercent        float64 // fall from the previous close from which a long holding triggers the advice
+	InverseETFCode     string  // inverse ETF proposed as the hedge, none if empty
+	HedgeRatioPercent  float64 // share of the value of the falling holdings to hedge with the inverse ETF
+	MinExposurePercent float64 // share of the portfolio the falling holdings must reach for the inverse ETF to be proposed
+	TargetCashPercent  float64 // cash ratio the cash raising aims at, none if zero
+}
+
+// reducePositionDropMultiple is how many times the threshold a holding must fall for a partial
+// sale to be proposed, and reducePositionShare is the share of the holding proposed to sell.
+const (
+	reducePositionDropMultiple = 2
+	reducePositionShare        = 0.5
+)
+
+// HedgeDrop is a long holding whose price fell by the threshold or more from the previous close.
+type HedgeDrop struct {
+	PriceMove
+	Value float64 // current value of the holding
+}
+
+// HedgeAdvice is a hedge action proposed against the falling holdings.
+type HedgeAdvice struct {
+	Action string
+	Code   string  // stock to trade, empty to sell from the falling holdings
+	Amount float64 // amount in yen to buy or sell
+	Reason string
+}
+
+// FindHedgeDrops returns the long stock and ETF holdings of the summary that fell by the threshold
+// or more from the previous closes, largest falls first. Holdings without a previous close are left out.
+func FindHedgeDrops(summary *PortfolioSummary, previousCloses map[string]float64, dropPercent float64) []HedgeDrop {
+	if summary == nil || dropPercent <= 0 {
+		return nil
+	}
+
+	var drops []HedgeDrop
+	for _, holding := range summary.Holdings {
+		if holding.PositionType == models.PositionTypeShort ||
+			(holding.AssetClass != models.AssetClassStock && holding.AssetClass != models.AssetClassETF) {
+			continue
+		}
+		previousClose := previousCloses[holding.Code]
+		if previousClose <= 0 {
+			continue
+		}
+		move := NewPriceMove(holding.Code, holding.Name, previousClose, holding.CurrentPrice)
+		if move.ChangePercent > -dropPercent {
+			continue
+		}
+		drops = append(drops, HedgeDrop{PriceMove: move, Value: holding.CurrentValue})
+	}
+	sort.SliceStable(drops, func(i, j int) bool { return drops[i].ChangePercent < drops[j].ChangePercent })
+	return drops
+}
+
+// EvaluateHedgeAdvice proposes the hedge actions against the falling holdings by the rules:
+//   - an inverse ETF for a share of the falling value, when the falling holdings are a large enough part of the portfolio
+//   - selling holdings to raise cash, when the cash ratio is below the target
+//   - selling half of each holding that fell twice the threshold or more
+func EvaluateHedgeAdvice(summary *PortfolioSummary, drops []HedgeDrop, rules HedgeAdviceRules) []HedgeAdvice {
+	if summary == nil || len(drops) == 0 || summary.TotalValue <= 0 {
+		return nil
+	}
+
+	var advice []HedgeAdvice
+
+	exposure := 0.0
+	for _, drop := range drops {
+		exposure += drop.Value
+	}
+	exposurePercent := exposure / summary.TotalValue * 100
+	if rules.InverseETFCode != "" && rules.HedgeRatioPercent > 0 && exposurePercent >= rules.MinExposurePercent &&
+		!holdsLong(summary, rules.InverseETFCode) {
+		amount := exposure * rules.HedgeRatioPercent / 100
+		advice = append(advice, HedgeAdvice{
+			Action: HedgeActionInverseETF,
+			Code:   rules.InverseETFCode,
+			Amount: amount,
+			Reason: i18n.T("hedge.inverse_etf", rules.InverseETFCode, FormatCurrency(amount),
+				FormatCurrency(exposure), exposurePercent, rules.HedgeRatioPercent),
+		})
+	}
+
+	if rules.TargetCashPercent > 0 {
+		cashPercent := 0.0
+		for _, allocation := range summary.Allocations {
+			if allocation.AssetClass == models.AssetClassCash {
+				cashPercent = allocation.Percent
+			}
+		}
+		if cashPercent < rules.TargetCashPercent {
+			amount := summary.TotalValue * (rules.TargetCashPercent - cashPercent) / 100
+			advice = append(advice, HedgeAdvice{
+				Action: HedgeActionRaiseCash,
+				Amount: amount,
+				Reason: i18n.T("hedge.raise_cash", cashPercent, rules.TargetCashPercent, FormatCurrency(amount)),
+			})
+		}
+	}
+
+	for _, drop := range drops {
+		if drop.ChangePercent > -rules.DropPercent*reducePositionDropMultiple {
+			continue
+		}
+		amount := drop.Value * reducePositionShare
+		advice = append(advice, HedgeAdvice{
+			Action: HedgeActionReducePosition,
+			Code:   drop.Code,
+			Amount: amount,
+			Reason: i18n.T("hedge.reduce_position", drop.label(), drop.ChangePercent, FormatCurrency(amount)),
+		})
+	}
+	return advice
+}
+
+// holdsLong reports whether the summary has a long position in the stock.
+func holdsLong(summary *PortfolioSummary, code string) bool {
+	for _, holding := range summary.Holdings {
+		if holding.Code == code && holding.PositionType != models.PositionTypeShort {
+			return true
+		}
+	}
+	return false
+}
+
+// label returns the code and the name of the falling holding.
+func (d HedgeDrop) label() string {
+	if d.Name == "" {
+		return d.Code
+	}
+	return d.Code + " " + d.Name
+}
+
+// FormatHedgeAdvice formats the notification of the falling holdings and the hedge actions proposed against them.
+func FormatHedgeAdvice(drops []HedgeDrop, advice []HedgeAdvice, dropPercent float64) string {
+	lines := []string{i18n.T("hedge.title", dropPercent, len(drops))}
+	for _, drop := range drops {
+		lines = append(lines, i18n.T("hedge.drop_line", drop.label(), drop.PreviousClose, drop.Price,
+			drop.ChangePercent, FormatCurrency(math.Round(drop.Value))))
+	}
+	lines = append(lines, "", i18n.T("hedge.advice_header"))
+	for _, a := range advice {
+		lines = append(lines, "- "+a.Reason)
+	}
+	lines = append(lines, i18n.T("hedge.disclaimer"))
+	return strings.Join(lines, "\n")
+}
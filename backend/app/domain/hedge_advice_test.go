@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+var hedgeTestPurchaseDate = time.Date(2024, 1, 4, 0, 0, 0, 0, time.Local)
+
+// hedgeTestPortfolio returns a portfolio worth ¥2,430,000 at the test prices, of which cash is ¥1,000,000.
+func hedgeTestPortfolio() []*models.Portfolio {
+	return []*models.Portfolio{
+		{Code: "7203", Name: "トヨタ自動車", Shares: 100, PurchasePrice: utility.FloatToDecimal(3000), PurchaseDate: hedgeTestPurchaseDate},
+		{Code: "6758", Name: "ソニーグループ", Shares: 100, PurchasePrice: utility.FloatToDecimal(12000), PurchaseDate: hedgeTestPurchaseDate},
+		{Code: "JPY", Name: "現金", Shares: 1000000, PurchasePrice: utility.FloatToDecimal(1), PurchaseDate: hedgeTestPurchaseDate,
+			AssetClass: models.AssetClassCash},
+	}
+}
+
+var hedgeTestPrices = map[string]float64{"7203": 2600, "6758": 11700, "9984": 8000}
+
+func TestFindHedgeDrops(t *testing.T) {
+	previous := map[string]float64{"7203": 3000, "6758": 12000, "9984": 9000}
+
+	portfolio := append(hedgeTestPortfolio(), &models.Portfolio{
+		Code: "9984", Name: "ソフトバンクグループ", Shares: 100, PurchasePrice: utility.FloatToDecimal(9000),
+		PurchaseDate: hedgeTestPurchaseDate, PositionType: models.PositionTypeShort,
+	})
+	summary := CalculatePortfolioSummary(portfolio, hedgeTestPrices)
+
+	drops := FindHedgeDrops(summary, previous, 5)
+	// 6758 fell only 2.5%, and the short 9984 gains from its fall
+	want := []HedgeDrop{{PriceMove: NewPriceMove("7203", "トヨタ自動車", 3000, 2600), Value: 260000}}
+	if diff := cmp.Diff(want, drops); diff != "" {
+		t.Errorf("FindHedgeDrops() mismatch (-want +got):\n%s", diff)
+	}
+
+	if drops := FindHedgeDrops(summary, previous, 0); drops != nil {
+		t.Errorf("FindHedgeDrops() = %v with a zero threshold, want none", drops)
+	}
+}
+
+func TestEvaluateHedgeAdvice(t *testing.T) {
+	summary := CalculatePortfolioSummary(hedgeTestPortfolio(), hedgeTestPrices)
+	drops := FindHedgeDrops(summary, map[string]float64{"7203": 3000}, 5)
+	rules := HedgeAdviceRules{
+		DropPercent:        5,
+		InverseETFCode:     "1571",
+		HedgeRatioPercent:  50,
+		MinExposurePercent: 10,
+		TargetCashPercent:  50,
+	}
+
+	// The falling 7203 is worth ¥260,000 after falling 13.33%
+	want := []HedgeAdvice{
+		{Action: HedgeActionInverseETF, Code: "1571", Amount: 130000,
+			Reason: "インバースETF 1571 を約¥130,000買い、下落銘柄の評価額 ¥260,000(ポートフォリオの10.7%)の50%をヘッジ"},
+		{Action: HedgeActionRaiseCash, Amount: 215000,
+			Reason: "現金比率 41.2% を目標の50%まで引き上げるため、約¥215,000を売却して現金化"},
+		{Action: HedgeActionReducePosition, Code: "7203", Amount: 130000,
+			Reason: "7203 トヨタ自動車 が-13.33%と大きく下落、保有の半分(約¥130,000)の売却を検討"},
+	}
+	got := EvaluateHedgeAdvice(summary, drops, rules)
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b float64) bool { return a-b < 0.01 && b-a < 0.01 })); diff != "" {
+		t.Errorf("EvaluateHedgeAdvice() mismatch (-want +got):\n%s", diff)
+	}
+
+	// A smaller exposure than the minimum and enough cash propose only the partial sale
+	rules.MinExposurePercent = 20
+	rules.TargetCashPercent = 30
+	got = EvaluateHedgeAdvice(summary, drops, rules)
+	if len(got) != 1 || got[0].Action != HedgeActionReducePosition {
+		t.Errorf("EvaluateHedgeAdvice() = %+v, want only %s", got, HedgeActionReducePosition)
+	}
+
+	if got := EvaluateHedgeAdvice(summary, nil, rules); got != nil {
+		t.Errorf("EvaluateHedgeAdvice() = %+v without drops, want none", got)
+	}
+}
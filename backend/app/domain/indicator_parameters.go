@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IndicatorParameters holds the tunable parameters used for technical indicator
+// calculation and signal generation. It is stored as JSON in strategy profiles.
+type IndicatorParameters struct {
+	RSIPeriod        int     `json:"rsi_period"`
+	RSIOversold      float64 `json:"rsi_oversold"`
+	RSIOverbought    float64 `json:"rsi_overbought"`
+	ShortMAPeriod    int     `json:"short_ma_period"`
+	MediumMAPeriod   int     `json:"medium_ma_period"`
+	LongMAPeriod     int     `json:"long_ma_period"`
+	MACDFastPeriod   int     `json:"macd_fast_period"`
+	MACDSlowPeriod   int     `json:"macd_slow_period"`
+	MACDSignalPeriod int     `json:"macd_signal_period"`
+
+	// Volatility breakout and range detection. The box is the highest high and the lowest low of
+	// the last RangePeriod days, and its height and the breakout distance are measured in ATRs.
+	ATRPeriod           int     `json:"atr_period"`
+	RangePeriod         int     `json:"range_period"`
+	BreakoutATRMultiple float64 `json:"breakout_atr_multiple"` // distance out of the box for a breakout
+	RangeATRMultiple    float64 `json:"range_atr_multiple"`    // maximum height of the box for a range
+
+	// Weights of the conditions added to (bullish) or taken from (bearish) the signal score
+	RSIWeight         float64 `json:"rsi_weight"`
+	MAAlignmentWeight float64 `json:"ma_alignment_weight"`
+	MACDWeight        float64 `json:"macd_weight"`
+	PriceMAWeight     float64 `json:"price_ma_weight"`
+	BreakoutWeight    float64 `json:"breakout_weight"`
+	// The score is multiplied by RangeScoreFactor while the market is in a range, 1 keeping it as is
+	RangeScoreFactor float64 `json:"range_score_factor"`
+	// Scores above BuyThreshold signal a buy and scores below SellThreshold a sell
+	BuyThreshold  float64 `json:"buy_threshold"`
+	SellThreshold float64 `json:"sell_threshold"`
+}
+
+// DefaultIndicatorParameters returns the parameters used when no strategy profile is applied.
+func DefaultIndicatorParameters() IndicatorParameters {
+	return IndicatorParameters{
+		RSIPeriod:        14,
+		RSIOversold:      30,
+		RSIOverbought:    70,
+		ShortMAPeriod:    5,
+		MediumMAPeriod:   25,
+		LongMAPeriod:     75,
+		MACDFastPeriod:   12,
+		MACDSlowPeriod:   26,
+		MACDSignalPeriod: 9,
+
+		ATRPeriod:           14,
+		RangePeriod:         20,
+		BreakoutATRMultiple: 1.0,
+		RangeATRMultiple:    4.0,
+
+		RSIWeight:         2.0,
+		MAAlignmentWeight: 1.5,
+		MACDWeight:        1.0,
+		PriceMAWeight:     0.5,
+		BreakoutWeight:    0,
+		RangeScoreFactor:  1.0,
+		BuyThreshold:      1.0,
+		SellThreshold:     -1.0,
+	}
+}
+
+// ParseIndicatorParameters parses JSON parameters. Omitted fields fall back to the defaults.
+func ParseIndicatorParameters(raw []byte) (IndicatorParameters, error) {
+	params := DefaultIndicatorParameters()
+	if len(raw) == 0 {
+		return params, nil
+	}
+
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return IndicatorParameters{}, fmt.Errorf("パラメータのJSONが不正です: %w", err)
+	}
+
+	if err := params.Validate(); err != nil {
+		return IndicatorParameters{}, err
+	}
+
+	return params, nil
+}
+
+// MarshalJSONString returns the parameters as a JSON string for storage.
+func (p IndicatorParameters) MarshalJSONString() (string, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Validate validates indicator parameters.
+func (p IndicatorParameters) Validate() error {
+	if p.RSIPeriod <= 0 {
+		return fmt.Errorf("RSI期間は1以上である必要があります")
+	}
+
+	if p.RSIOversold < 0 || p.RSIOverbought > 100 || p.RSIOversold >= p.RSIOverbought {
+		return fmt.Errorf("RSI閾値は0 <= 売られすぎ < 買われすぎ <= 100 である必要があります")
+	}
+
+	if p.ShortMAPeriod <= 0 || p.MediumMAPeriod <= 0 || p.LongMAPeriod <= 0 {
+		return fmt.Errorf("移動平均期間は1以上である必要があります")
+	}
+
+	if p.ShortMAPeriod >= p.MediumMAPeriod || p.MediumMAPeriod >= p.LongMAPeriod {
+		return fmt.Errorf("移動平均期間は短期 < 中期 < 長期 である必要があります")
+	}
+
+	if p.MACDFastPeriod <= 0 || p.MACDSlowPeriod <= 0 || p.MACDSignalPeriod <= 0 {
+		return fmt.Errorf("MACD期間は1以上である必要があります")
+	}
+
+	if p.MACDFastPeriod >= p.MACDSlowPeriod {
+		return fmt.Errorf("MACDの短期期間は長期期間より短い必要があります")
+	}
+
+	if p.ATRPeriod <= 0 || p.RangePeriod <= 0 {
+		return fmt.Errorf("ATR期間とレンジ判定期間は1以上である必要があります")
+	}
+
+	if p.BreakoutATRMultiple <= 0 || p.RangeATRMultiple <= 0 {
+		return fmt.Errorf("ブレイクアウトとレンジ判定のATR倍率は正の値である必要があります")
+	}
+
+	if p.RSIWeight < 0 || p.MAAlignmentWeight < 0 || p.MACDWeight < 0 || p.PriceMAWeight < 0 || p.BreakoutWeight < 0 {
+		return fmt.Errorf("シグナルの重みは0以上である必要があります")
+	}
+
+	if p.MaxSignalScore() <= 0 {
+		return fmt.Errorf("シグナルの重みのいずれかを正の値にする必要があります")
+	}
+
+	if p.RangeScoreFactor < 0 || p.RangeScoreFactor > 1 {
+		return fmt.Errorf("レンジ中のスコア係数は0から1の範囲である必要があります")
+	}
+
+	if p.SellThreshold >= p.BuyThreshold {
+		return fmt.Errorf("シグナル閾値は売り < 買い である必要があります")
+	}
+
+	return nil
+}
+
+// MaxSignalScore returns the absolute score of a signal on which all conditions agree.
+func (p IndicatorParameters) MaxSignalScore() float64 {
+	return p.RSIWeight + p.MAAlignmentWeight + p.MACDWeight + p.PriceMAWeight + p.BreakoutWeight
+}
+
+// RequiredDataPoints returns the minimum number of price records needed to calculate all indicators
+// the trading signal uses.
+func (p IndicatorParameters) RequiredDataPoints() int {
+	required := p.LongMAPeriod
+	if p.MACDSlowPeriod > required {
+		required = p.MACDSlowPeriod
+	}
+	if p.RSIPeriod+1 > required {
+		required = p.RSIPeriod + 1
+	}
+	// The breakout and range detection only count when the signal uses them. A breakout compares
+	// the last day with the ATR and the box of the days before it.
+	if p.BreakoutWeight > 0 || p.RangeScoreFactor < 1 {
+		if p.ATRPeriod+2 > required {
+			required = p.ATRPeriod + 2
+		}
+		if p.RangePeriod+1 > required {
+			required = p.RangePeriod + 1
+		}
+	}
+	return required
+}
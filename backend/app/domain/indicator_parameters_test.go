@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseIndicatorParameters(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		expected  IndicatorParameters
+		wantError bool
+	}{
+		{
+			name:     "Empty input returns defaults",
+			raw:      "",
+			expected: DefaultIndicatorParameters(),
+		},
+		{
+			name: "Partial input is merged with defaults",
+			raw:  `{"rsi_period": 9, "short_ma_period": 10, "rsi_oversold": 20}`,
+			expected: IndicatorParameters{
+				RSIPeriod:        9,
+				RSIOversold:      20,
+				RSIOverbought:    70,
+				ShortMAPeriod:    10,
+				MediumMAPeriod:   25,
+				LongMAPeriod:     75,
+				MACDFastPeriod:   12,
+				MACDSlowPeriod:   26,
+				MACDSignalPeriod: 9,
+
+				ATRPeriod:           14,
+				RangePeriod:         20,
+				BreakoutATRMultiple: 1.0,
+				RangeATRMultiple:    4.0,
+
+				RSIWeight:         2.0,
+				MAAlignmentWeight: 1.5,
+				MACDWeight:        1.0,
+				PriceMAWeight:     0.5,
+				BreakoutWeight:    0,
+				RangeScoreFactor:  1.0,
+				BuyThreshold:      1.0,
+				SellThreshold:     -1.0,
+			},
+		},
+		{
+			name:      "Invalid JSON",
+			raw:       `{"rsi_period":`,
+			wantError: true,
+		},
+		{
+			name:      "MA periods out of order",
+			raw:       `{"short_ma_period": 30}`,
+			wantError: true,
+		},
+		{
+			name:      "RSI thresholds reversed",
+			raw:       `{"rsi_oversold": 80, "rsi_overbought": 20}`,
+			wantError: true,
+		},
+		{
+			name:      "MACD fast period longer than slow period",
+			raw:       `{"macd_fast_period": 30}`,
+			wantError: true,
+		},
+		{
+			name:      "Negative signal weight",
+			raw:       `{"rsi_weight": -1}`,
+			wantError: true,
+		},
+		{
+			name:      "All signal weights zero",
+			raw:       `{"rsi_weight": 0, "ma_alignment_weight": 0, "macd_weight": 0, "price_ma_weight": 0}`,
+			wantError: true,
+		},
+		{
+			name:      "Non-positive breakout ATR multiple",
+			raw:       `{"breakout_atr_multiple": 0}`,
+			wantError: true,
+		},
+		{
+			name:      "Range score factor above one",
+			raw:       `{"range_score_factor": 1.5}`,
+			wantError: true,
+		},
+		{
+			name:      "Signal thresholds reversed",
+			raw:       `{"buy_threshold": -1, "sell_threshold": 1}`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseIndicatorParameters([]byte(tt.raw))
+
+			if tt.wantError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, result); diff != "" {
+				t.Errorf("Parameters mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIndicatorParameters_MarshalJSONString(t *testing.T) {
+	params := DefaultIndicatorParameters()
+	params.RSIPeriod = 21
+
+	raw, err := params.MarshalJSONString()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	parsed, err := ParseIndicatorParameters([]byte(raw))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(params, parsed); diff != "" {
+		t.Errorf("Round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIndicatorParameters_RequiredDataPoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   IndicatorParameters
+		expected int
+	}{
+		{
+			name:     "Default parameters require long MA period",
+			params:   DefaultIndicatorParameters(),
+			expected: 75,
+		},
+		{
+			name: "MACD slow period is the longest",
+			params: IndicatorParameters{
+				RSIPeriod:      14,
+				LongMAPeriod:   20,
+				MACDSlowPeriod: 26,
+			},
+			expected: 26,
+		},
+		{
+			name: "RSI needs one extra data point",
+			params: IndicatorParameters{
+				RSIPeriod:      30,
+				LongMAPeriod:   20,
+				MACDSlowPeriod: 26,
+			},
+			expected: 31,
+		},
+		{
+			name: "Range detection used by the signal needs its box",
+			params: IndicatorParameters{
+				RSIPeriod:        14,
+				LongMAPeriod:     20,
+				MACDSlowPeriod:   26,
+				ATRPeriod:        14,
+				RangePeriod:      30,
+				RangeScoreFactor: 0.5,
+			},
+			expected: 31,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expected, tt.params.RequiredDataPoints()); diff != "" {
+				t.Errorf("Required data points mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
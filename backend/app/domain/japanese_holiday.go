@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+// IsTokyoExchangeHoliday reports whether the Tokyo Stock Exchange is closed on a weekday of date:
+// Japanese national holidays and the year-end holidays from December 31 to January 3.
+// The date is judged by its year, month and day whatever its time zone.
+func IsTokyoExchangeHoliday(date time.Time) bool {
+	switch month, day := date.Month(), date.Day(); {
+	case month == time.January && day <= 3, month == time.December && day == 31:
+		return true
+	}
+	return IsJapaneseHoliday(date)
+}
+
+// IsJapaneseHoliday reports whether date is a Japanese national holiday, including substitute
+// holidays and the citizen's holidays between two holidays. The rules from 2000 on are applied.
+func IsJapaneseHoliday(date time.Time) bool {
+	return japaneseHolidays(date.Year())[date.YearDay()]
+}
+
+var (
+	japaneseHolidayMu    sync.Mutex
+	japaneseHolidayCache = map[int]map[int]bool{}
+)
+
+// japaneseHolidays returns the days of year of the national holidays of a year, computed once per year.
+func japaneseHolidays(year int) map[int]bool {
+	japaneseHolidayMu.Lock()
+	defer japaneseHolidayMu.Unlock()
+
+	if holidays, ok := japaneseHolidayCache[year]; ok {
+		return holidays
+	}
+	holidays := computeJapaneseHolidays(year)
+	japaneseHolidayCache[year] = holidays
+	return holidays
+}
+
+// computeJapaneseHolidays computes the national holidays of a year by the Act on National Holidays.
+func computeJapaneseHolidays(year int) map[int]bool {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	mondayOf := func(month time.Month, week int) time.Time {
+		first := day(month, 1)
+		offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+(week-1)*7)
+	}
+
+	dates := []time.Time{
+		day(time.January, 1),                          // 元日
+		mondayOf(time.January, 2),                     // 成人の日
+		day(time.February, 11),                        // 建国記念の日
+		day(time.March, vernalEquinoxDay(year)),       // 春分の日
+		day(time.April, 29),                           // 昭和の日
+		day(time.May, 3),                              // 憲法記念日
+		day(time.May, 5),                              // こどもの日
+		day(time.September, autumnalEquinoxDay(year)), // 秋分の日
+		day(time.November, 3),                         // 文化の日
+		day(time.November, 23),                        // 勤労感謝の日
+	}
+	if year >= 2007 {
+		dates = append(dates, day(time.May, 4)) // みどりの日
+	}
+
+	// 海の日, 山の日 and スポーツの日 moved for the Tokyo Olympics in 2020 and 2021.
+	switch year {
+	case 2020:
+		dates = append(dates, day(time.July, 23), day(time.July, 24), day(time.August, 10))
+	case 2021:
+		dates = append(dates, day(time.July, 22), day(time.July, 23), day(time.August, 8))
+	default:
+		if year >= 2003 {
+			dates = append(dates, mondayOf(time.July, 3)) // 海の日
+		} else {
+			dates = append(dates, day(time.July, 20))
+		}
+		if year >= 2016 {
+			dates = append(dates, day(time.August, 11)) // 山の日
+		}
+		dates = append(dates, mondayOf(time.October, 2)) // スポーツの日 (体育の日)
+	}
+	if year >= 2003 {
+		dates = append(dates, mondayOf(time.September, 3)) // 敬老の日
+	} else {
+		dates = append(dates, day(time.September, 15))
+	}
+
+	// 天皇誕生日, and the enthronement holidays of 2019.
+	switch {
+	case year >= 2020:
+		dates = append(dates, day(time.February, 23))
+	case year == 2019:
+		dates = append(dates, day(time.May, 1), day(time.October, 22))
+	default:
+		dates = append(dates, day(time.December, 23))
+	}
+
+	holidays := make(map[int]bool, len(dates)+4)
+	for _, date := range dates {
+		holidays[date.YearDay()] = true
+	}
+
+	// A holiday on a Sunday moves to the next day that is not a holiday.
+	for _, date := range dates {
+		if date.Weekday() != time.Sunday {
+			continue
+		}
+		substitute := date.AddDate(0, 0, 1)
+		for holidays[substitute.YearDay()] {
+			substitute = substitute.AddDate(0, 0, 1)
+		}
+		if substitute.Year() == year {
+			holidays[substitute.YearDay()] = true
+		}
+	}
+
+	// A day between two holidays is a citizen's holiday.
+	for date := day(time.January, 2); date.Year() == year && date.Month() < time.December; date = date.AddDate(0, 0, 1) {
+		if holidays[date.YearDay()] || date.Weekday() == time.Sunday {
+			continue
+		}
+		before, after := date.AddDate(0, 0, -1), date.AddDate(0, 0, 1)
+		if holidays[before.YearDay()] && holidays[after.YearDay()] {
+			holidays[date.YearDay()] = true
+		}
+	}
+
+	return holidays
+}
+
+// vernalEquinoxDay approximates the day of March of the vernal equinox, valid until 2099.
+func vernalEquinoxDay(year int) int {
+	return int(20.8431+0.242194*float64(year-1980)) - (year-1980)/4
+}
+
+// autumnalEquinoxDay approximates the day of September of the autumnal equinox, valid until 2099.
+func autumnalEquinoxDay(year int) int {
+	return int(23.2488+0.242194*float64(year-1980)) - (year-1980)/4
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// DefaultMADeviationAlertPercent is the deviation from the 25-day moving average beyond which a
+// stock is considered overheated, or oversold below the moving average.
+const DefaultMADeviationAlertPercent = 10.0
+
+// MADeviationAlert is a stock whose close deviates from its 25-day moving average by more than
+// the alert threshold.
+type MADeviationAlert struct {
+	Code             string
+	Name             string
+	Price            float64
+	MovingAverage    float64
+	DeviationPercent float64
+}
+
+// IsMADeviationAlert reports whether a deviation from the moving average is beyond the threshold
+// in either direction. A threshold of zero or less disables the alert.
+func IsMADeviationAlert(deviationPercent, thresholdPercent float64) bool {
+	return thresholdPercent > 0 && math.Abs(deviationPercent) > thresholdPercent
+}
+
+// FormatMADeviationAlert formats the alert of the stocks deviating from their 25-day moving
+// average beyond the threshold, largest deviations first.
+func FormatMADeviationAlert(alerts []MADeviationAlert, thresholdPercent float64) string {
+	sorted := append([]MADeviationAlert(nil), alerts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return math.Abs(sorted[i].DeviationPercent) > math.Abs(sorted[j].DeviationPercent)
+	})
+
+	lines := []string{i18n.T("ma_deviation.title", thresholdPercent, len(sorted))}
+	for _, alert := range sorted {
+		label := alert.Code
+		if alert.Name != "" {
+			label += " " + alert.Name
+		}
+		state := i18n.T("ma_deviation.overheated")
+		if alert.DeviationPercent < 0 {
+			state = i18n.T("ma_deviation.oversold")
+		}
+		lines = append(lines, i18n.T("ma_deviation.line", label, alert.Price, alert.MovingAverage, alert.DeviationPercent, state))
+	}
+	return strings.Join(lines, "\n")
+}
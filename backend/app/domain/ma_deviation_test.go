@@ -0,0 +1,36 @@
+package domain
+
+import "testing"
+
+func TestIsMADeviationAlert(t *testing.T) {
+	tests := []struct {
+		deviation float64
+		threshold float64
+		want      bool
+	}{
+		{deviation: 10.5, threshold: 10, want: true},
+		{deviation: -12, threshold: 10, want: true},
+		{deviation: 10, threshold: 10, want: false},
+		{deviation: 30, threshold: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsMADeviationAlert(tt.deviation, tt.threshold); got != tt.want {
+			t.Errorf("IsMADeviationAlert(%v, %v) = %v, want %v", tt.deviation, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMADeviationAlert(t *testing.T) {
+	alerts := []MADeviationAlert{
+		{Code: "7203", Name: "トヨタ自動車", Price: 3300, MovingAverage: 3000, DeviationPercent: 10},
+		{Code: "9984", Price: 6000, MovingAverage: 7500, DeviationPercent: -20},
+	}
+
+	want := "🔥 25日移動平均乖離率が±10%を超えた銘柄 (2銘柄)\n" +
+		"- 9984 ¥6000.00 / 25日線 ¥7500.00 (乖離率 -20.00%、売られすぎ)\n" +
+		"- 7203 トヨタ自動車 ¥3300.00 / 25日線 ¥3000.00 (乖離率 +10.00%、買われすぎ)"
+	if got := FormatMADeviationAlert(alerts, 10); got != want {
+		t.Errorf("FormatMADeviationAlert() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// maxDigestAlerts is the number of suppressed alerts listed in a maintenance digest.
+const maxDigestAlerts = 30
+
+// ParseMaintenanceUntil parses the end of a maintenance window relative to now:
+// a time of day such as "22:00" (the next occurrence), a date and time such as "2024-06-05 22:00",
+// or a duration such as "90m".
+func ParseMaintenanceUntil(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if clock, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		until := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if !until.After(now) {
+			until = until.AddDate(0, 0, 1)
+		}
+		return until, nil
+	}
+
+	if until, err := time.ParseInLocation("2006-01-02 15:04", value, now.Location()); err == nil {
+		if !until.After(now) {
+			return time.Time{}, fmt.Errorf("end time is in the past: %s", value)
+		}
+		return until, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("duration must be positive: %s", value)
+		}
+		return now.Add(d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid end time %q: use HH:MM, YYYY-MM-DD HH:MM or a duration such as 90m", value)
+}
+
+// FormatMaintenanceDigest formats the alerts suppressed during a maintenance window into one message.
+// Each alert is listed by the first line of its message.
+func FormatMaintenanceDigest(window *models.MaintenanceWindow, alerts []*models.SuppressedAlert) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("maintenance.digest_title", len(alerts)) + "\n")
+	b.WriteString(i18n.T("maintenance.digest_period",
+		window.StartsAt.Format("2006-01-02 15:04"), window.EndsAt.Format("2006-01-02 15:04")) + "\n")
+	if window.Reason != "" {
+		b.WriteString(i18n.T("maintenance.digest_reason", window.Reason) + "\n")
+	}
+	b.WriteString("\n")
+
+	for i, alert := range alerts {
+		if i == maxDigestAlerts {
+			b.WriteString(i18n.T("maintenance.digest_more", len(alerts)-maxDigestAlerts) + "\n")
+			break
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(alert.Message), "\n")
+		fmt.Fprintf(&b, "• %s %s\n", alert.SuppressedAt.Format("15:04"), line)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseMaintenanceUntil(t *testing.T) {
+	now := time.Date(2024, 6, 5, 20, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"Time later today", "22:00", time.Date(2024, 6, 5, 22, 0, 0, 0, time.Local), false},
+		{"Time already passed is tomorrow", "08:00", time.Date(2024, 6, 6, 8, 0, 0, 0, time.Local), false},
+		{"Date and time", "2024-06-07 09:00", time.Date(2024, 6, 7, 9, 0, 0, 0, time.Local), false},
+		{"Duration", "90m", time.Date(2024, 6, 5, 22, 0, 0, 0, time.Local), false},
+		{"Date and time in the past", "2024-06-01 09:00", time.Time{}, true},
+		{"Negative duration", "-1h", time.Time{}, true},
+		{"Invalid value", "tonight", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMaintenanceUntil(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMaintenanceUntil() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseMaintenanceUntil() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	// Embedded time zone database, so that the markets resolve their time zones on hosts without one
+	_ "time/tzdata"
+)
+
+// MarketHours tells whether a market is trading, judged in the time zone of the market
+// whatever the time zone of the given time.
+type MarketHours interface {
+	// Name returns the name of the market, e.g. tokyo.
+	Name() string
+	// Location returns the time zone of the market.
+	Location() *time.Location
+	// IsTradingDay reports whether the market trades on the day of t in its time zone.
+	IsTradingDay(t time.Time) bool
+	// IsOpen reports whether the market is in a trading session at t.
+	IsOpen(t time.Time) bool
+}
+
+// TradingSession is a continuous trading period of a day, as offsets from midnight in the market time zone.
+type TradingSession struct {
+	Open  time.Duration
+	Close time.Duration
+}
+
+// SessionMarketHours implements MarketHours for a market trading in fixed sessions on weekdays.
+// Exchange holidays are not taken into account.
+type SessionMarketHours struct {
+	name     string
+	location *time.Location
+	sessions []TradingSession
+}
+
+// NewSessionMarketHours creates market hours trading in the given sessions on weekdays.
+func NewSessionMarketHours(name string, location *time.Location, sessions ...TradingSession) *SessionMarketHours {
+	return &SessionMarketHours{name: name, location: location, sessions: sessions}
+}
+
+// Market names known by LookupMarketHours.
+const (
+	MarketTokyo   = "tokyo"
+	MarketNewYork = "newyork"
+)
+
+// marketTimeZones maps the markets to their time zones.
+var marketTimeZones = map[string]string{
+	MarketTokyo:   "Asia/Tokyo",
+	MarketNewYork: "America/New_York",
+}
+
+// TokyoMarketHours returns the trading hours of the Tokyo Stock Exchange:
+// 9:00 to 11:30 and 12:30 to 15:00 JST.
+func TokyoMarketHours() *SessionMarketHours {
+	return NewSessionMarketHours(MarketTokyo, marketLocation(MarketTokyo),
+		TradingSession{Open: 9 * time.Hour, Close: 11*time.Hour + 30*time.Minute},
+		TradingSession{Open: 12*time.Hour + 30*time.Minute, Close: 15 * time.Hour},
+	)
+}
+
+// NewYorkMarketHours returns the regular trading hours of the New York Stock Exchange:
+// 9:30 to 16:00 in New York time, following daylight saving time.
+func NewYorkMarketHours() *SessionMarketHours {
+	return NewSessionMarketHours(MarketNewYork, marketLocation(MarketNewYork),
+		TradingSession{Open: 9*time.Hour + 30*time.Minute, Close: 16 * time.Hour},
+	)
+}
+
+// marketLocation loads the time zone of a market, which the embedded time zone database always contains.
+func marketLocation(market string) *time.Location {
+	location, err := time.LoadLocation(marketTimeZones[market])
+	if err != nil {
+		panic(fmt.Sprintf("time zone of market %s: %v", market, err))
+	}
+	return location
+}
+
+// LookupMarketHours returns the trading hours of a market by name (tokyo or newyork).
+// An empty name is the Tokyo Stock Exchange.
+func LookupMarketHours(name string) (MarketHours, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", MarketTokyo:
+		return TokyoMarketHours(), nil
+	case MarketNewYork:
+		return NewYorkMarketHours(), nil
+	}
+
+	names := make([]string, 0, len(marketTimeZones))
+	for market := range marketTimeZones {
+		names = append(names, market)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown market %q (expected one of %s)", name, strings.Join(names, ", "))
+}
+
+// Name returns the name of the market.
+func (m *SessionMarketHours) Name() string {
+	return m.name
+}
+
+// Location returns the time zone of the market.
+func (m *SessionMarketHours) Location() *time.Location {
+	return m.location
+}
+
+// IsTradingDay reports whether the day of t in the market time zone is a weekday.
+func (m *SessionMarketHours) IsTradingDay(t time.Time) bool {
+	weekday := t.In(m.location).Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+// IsOpen reports whether t falls in a trading session of a trading day.
+func (m *SessionMarketHours) IsOpen(t time.Time) bool {
+	if !m.IsTradingDay(t) {
+		return false
+	}
+
+	local := t.In(m.location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	for _, session := range m.sessions {
+		if sinceMidnight >= session.Open && sinceMidnight < session.Close {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionMarketHours_IsOpen(t *testing.T) {
+	tokyo := TokyoMarketHours()
+	newYork := NewYorkMarketHours()
+
+	tests := []struct {
+		name   string
+		market MarketHours
+		at     time.Time
+		want   bool
+	}{
+		// Times are given in UTC to check that the market judges them in its own time zone
+		{"Tokyo morning session", tokyo, time.Date(2024, 1, 5, 1, 0, 0, 0, time.UTC), true},       // 10:00 JST
+		{"Tokyo lunch break", tokyo, time.Date(2024, 1, 5, 3, 0, 0, 0, time.UTC), false},          // 12:00 JST
+		{"Tokyo afternoon close", tokyo, time.Date(2024, 1, 5, 6, 0, 0, 0, time.UTC), false},      // 15:00 JST
+		{"Tokyo Monday before open", tokyo, time.Date(2024, 1, 7, 23, 30, 0, 0, time.UTC), false}, // Mon 8:30 JST
+		{"Tokyo Saturday in JST", tokyo, time.Date(2024, 1, 5, 16, 0, 0, 0, time.UTC), false},     // Sat 1:00 JST
+		{"New York winter open", newYork, time.Date(2024, 1, 5, 14, 30, 0, 0, time.UTC), true},
+		{"New York winter before open", newYork, time.Date(2024, 1, 5, 14, 29, 0, 0, time.UTC), false},
+		{"New York daylight saving", newYork, time.Date(2024, 7, 5, 13, 30, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.market.IsOpen(tt.at); got != tt.want {
+				t.Errorf("IsOpen(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionMarketHours_IsTradingDay(t *testing.T) {
+	tokyo := TokyoMarketHours()
+
+	// Friday 20:00 UTC is already Saturday in Tokyo
+	if tokyo.IsTradingDay(time.Date(2024, 1, 5, 20, 0, 0, 0, time.UTC)) {
+		t.Error("Saturday in JST should not be a trading day")
+	}
+	// Sunday 20:00 UTC is already Monday in Tokyo
+	if !tokyo.IsTradingDay(time.Date(2024, 1, 7, 20, 0, 0, 0, time.UTC)) {
+		t.Error("Monday in JST should be a trading day")
+	}
+}
+
+func TestLookupMarketHours(t *testing.T) {
+	for name, want := range map[string]string{"": MarketTokyo, "Tokyo": MarketTokyo, "newyork": MarketNewYork} {
+		market, err := LookupMarketHours(name)
+		if err != nil {
+			t.Fatalf("LookupMarketHours(%q) error = %v", name, err)
+		}
+		if market.Name() != want {
+			t.Errorf("LookupMarketHours(%q) = %s, want %s", name, market.Name(), want)
+		}
+	}
+
+	if _, err := LookupMarketHours("london"); err == nil {
+		t.Error("LookupMarketHours should fail for an unknown market")
+	}
+}
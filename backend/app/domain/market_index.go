@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// marketIndexPrefix starts the symbols of the market indices, e.g. ^N225.
+const marketIndexPrefix = "^"
+
+// MarketIndex is a market index whose prices are collected for the market section of the daily
+// report and as the benchmark of the portfolio. Its prices are stored under its symbol like the
+// prices of a stock, but it is never part of the portfolio.
+type MarketIndex struct {
+	Symbol string `json:"symbol"` // symbol of the data source, e.g. ^N225
+	Name   string `json:"name"`
+}
+
+// IsMarketIndexSymbol reports whether the code is the symbol of a market index.
+func IsMarketIndexSymbol(code string) bool {
+	return strings.HasPrefix(strings.TrimSpace(code), marketIndexPrefix)
+}
+
+// ParseMarketIndices parses the indices to collect, given as comma separated symbols with an
+// optional name, e.g. "^N225=日経平均株価,^DJI=NYダウ". An index without a name is shown by its symbol.
+func ParseMarketIndices(spec string) ([]MarketIndex, error) {
+	var indices []MarketIndex
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		symbol, name, _ := strings.Cut(entry, "=")
+		symbol = NormalizeCode(symbol)
+		if !IsMarketIndexSymbol(symbol) || len(symbol) == len(marketIndexPrefix) {
+			return nil, fmt.Errorf("指数のシンボルは ^ で始めてください(例: ^N225): %s", entry)
+		}
+		if seen[symbol] {
+			return nil, fmt.Errorf("指数 %s が重複しています", symbol)
+		}
+		seen[symbol] = true
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = symbol
+		}
+		indices = append(indices, MarketIndex{Symbol: symbol, Name: name})
+	}
+	return indices, nil
+}
+
+// MarketIndexQuote is the latest close of an index and its change from the previous close.
+type MarketIndexQuote struct {
+	MarketIndex
+	Date          time.Time `json:"date"`
+	Close         float64   `json:"close"`
+	ChangePercent float64   `json:"change_percent"`
+	HasPrevious   bool      `json:"has_previous"` // false if no previous close is stored, so there is no change
+}
+
+// NewMarketIndexQuote returns the quote of an index at its latest close. A previous close of zero
+// or less means there is none.
+func NewMarketIndexQuote(index MarketIndex, date time.Time, close, previousClose float64) MarketIndexQuote {
+	quote := MarketIndexQuote{MarketIndex: index, Date: date, Close: close}
+	if previousClose > 0 {
+		quote.HasPrevious = true
+		quote.ChangePercent = (close/previousClose - 1) * 100
+	}
+	return quote
+}
+
+// BenchmarkComparison compares the daily change of the portfolio with the change of an index.
+type BenchmarkComparison struct {
+	Name             string  `json:"name"`
+	PortfolioPercent float64 `json:"portfolio_percent"`
+	BenchmarkPercent float64 `json:"benchmark_percent"`
+}
+
+// ExcessPercent returns how many percentage points the portfolio beat the benchmark by.
+func (c BenchmarkComparison) ExcessPercent() float64 {
+	return c.PortfolioPercent - c.BenchmarkPercent
+}
+
+// MarketSummary is the market section of the daily report.
+type MarketSummary struct {
+	Indices   []MarketIndexQuote   `json:"indices"`
+	Benchmark *BenchmarkComparison `json:"benchmark,omitempty"`
+}
+
+// NewMarketSummary returns the market section of the quotes, comparing the daily change of the
+// portfolio with the index of the benchmark symbol if its change is known.
+func NewMarketSummary(quotes []MarketIndexQuote, benchmark string, portfolioPercent float64) MarketSummary {
+	summary := MarketSummary{Indices: quotes}
+	for _, quote := range quotes {
+		if quote.Symbol == NormalizeCode(benchmark) && quote.HasPrevious {
+			summary.Benchmark = &BenchmarkComparison{
+				Name:             quote.Name,
+				PortfolioPercent: portfolioPercent,
+				BenchmarkPercent: quote.ChangePercent,
+			}
+		}
+	}
+	return summary
+}
+
+// FormatMarketSection formats the closes of the indices and the comparison with the benchmark.
+func FormatMarketSection(summary MarketSummary) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("market.section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	for _, quote := range summary.Indices {
+		if quote.HasPrevious {
+			b.WriteString(i18n.T("market.index_change", quote.Name, formatIndexValue(quote.Close), quote.ChangePercent) + "\n")
+		} else {
+			b.WriteString(i18n.T("market.index", quote.Name, formatIndexValue(quote.Close)) + "\n")
+		}
+	}
+	if c := summary.Benchmark; c != nil {
+		b.WriteString(i18n.T("market.benchmark", c.PortfolioPercent, c.Name, c.BenchmarkPercent, c.ExcessPercent()) + "\n")
+	}
+	return b.String()
+}
+
+// formatIndexValue formats the value of an index with comma separators and two decimals.
+func formatIndexValue(value float64) string {
+	integer, fraction, _ := strings.Cut(fmt.Sprintf("%.2f", math.Abs(value)), ".")
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+	return sign + addCommaToNumber(integer) + "." + fraction
+}
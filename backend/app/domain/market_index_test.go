@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseMarketIndices(t *testing.T) {
+	got, err := ParseMarketIndices(" ^N225=日経平均株価, ^dji ,")
+	if err != nil {
+		t.Fatalf("ParseMarketIndices() error = %v", err)
+	}
+	want := []MarketIndex{
+		{Symbol: "^N225", Name: "日経平均株価"},
+		{Symbol: "^DJI", Name: "^DJI"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseMarketIndices() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, spec := range []string{"N225=日経平均", "^", "^N225,^N225=日経平均"} {
+		if _, err := ParseMarketIndices(spec); err == nil {
+			t.Errorf("ParseMarketIndices(%q) error = nil, want an error", spec)
+		}
+	}
+	if indices, err := ParseMarketIndices(""); err != nil || len(indices) != 0 {
+		t.Errorf("ParseMarketIndices(\"\") = %v, %v, want no indices", indices, err)
+	}
+}
+
+func TestNewMarketSummary(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	nikkei := NewMarketIndexQuote(MarketIndex{Symbol: "^N225", Name: "日経平均株価"}, date, 39910.82, 39166.19)
+	dow := NewMarketIndexQuote(MarketIndex{Symbol: "^DJI", Name: "NYダウ"}, date, 39087.38, 0)
+	if dow.HasPrevious || dow.ChangePercent != 0 {
+		t.Errorf("quote without a previous close = %+v, want no change", dow)
+	}
+
+	summary := NewMarketSummary([]MarketIndexQuote{nikkei, dow}, "^n225", 1.2)
+	if summary.Benchmark == nil {
+		t.Fatal("NewMarketSummary() has no benchmark comparison")
+	}
+	if math.Abs(summary.Benchmark.BenchmarkPercent-1.901) > 0.001 || math.Abs(summary.Benchmark.ExcessPercent()+0.701) > 0.001 {
+		t.Errorf("Benchmark = %+v, want the Nikkei up 1.90%% and the portfolio 0.70pt behind", summary.Benchmark)
+	}
+	if got := NewMarketSummary([]MarketIndexQuote{dow}, "^DJI", 1.2); got.Benchmark != nil {
+		t.Errorf("NewMarketSummary() = %+v, want no comparison without a previous close", got.Benchmark)
+	}
+
+	section := FormatMarketSection(summary)
+	for _, want := range []string{"市況", "日経平均株価: 39,910.82 (+1.90%)", "NYダウ: 39,087.38\n", "ポートフォリオ +1.20% vs 日経平均株価 +1.90% (差 -0.70pt)"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("FormatMarketSection() lacks %q:\n%s", want, section)
+		}
+	}
+}
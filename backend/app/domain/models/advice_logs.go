@@ -0,0 +1,32 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Types of the advice recorded in the advice logs.
+const (
+	AdviceTypeHedge = "hedge" // hedge actions proposed when held stocks fall sharply
+)
+
+// AdviceLog is an action proposed to the user. Advice is only notified and recorded, never executed.
+type AdviceLog struct {
+	ID           string
+	AdviceType   string    // アドバイス種別(hedge)
+	Action       string    // 提案内容(inverse_etf/raise_cash/reduce_position)
+	Code         string    // 対象銘柄コード(なければ空)
+	Amount       float64   // 提案金額
+	Reason       string    // 提案理由
+	TriggerCodes string    // 提案のきっかけになった銘柄コード(カンマ区切り)
+	Notified     bool      // 通知済み(送信失敗はfalse)
+	CreatedAt    time.Time // 記録日時
+}
+
+// Triggers returns the codes of the stocks that triggered the advice.
+func (l *AdviceLog) Triggers() []string {
+	if l.TriggerCodes == "" {
+		return nil
+	}
+	return strings.Split(l.TriggerCodes, ",")
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+)
+
+// PricePeriod is the period of aggregated price bars.
+type PricePeriod string
+
+const (
+	PricePeriodWeekly  PricePeriod = "weekly"  // weeks from Monday
+	PricePeriodMonthly PricePeriod = "monthly" // calendar months
+)
+
+// ParsePricePeriod parses a price period (weekly or monthly).
+func ParsePricePeriod(s string) (PricePeriod, error) {
+	switch period := PricePeriod(strings.ToLower(s)); period {
+	case PricePeriodWeekly, PricePeriodMonthly:
+		return period, nil
+	default:
+		return "", fmt.Errorf("期間はweeklyまたはmonthlyを指定してください: %s", s)
+	}
+}
+
+// Start returns the first day of the period containing t.
+func (p PricePeriod) Start(t time.Time) time.Time {
+	day := TruncateToDate(t)
+	if p == PricePeriodMonthly {
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	// Monday is the first day of a week
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// AggregatedPrice is a weekly or monthly price bar aggregated from daily prices.
+// Prices are actual prices like the daily prices; the adjusted close is the one of the last trading day.
+type AggregatedPrice struct {
+	Code          string            // 銘柄コード
+	PeriodStart   time.Time         // 期間開始日
+	PeriodEnd     time.Time         // 期間内の最終取引日
+	OpenPrice     types.Decimal     // 始値
+	HighPrice     types.Decimal     // 高値
+	LowPrice      types.Decimal     // 安値
+	ClosePrice    types.Decimal     // 終値
+	AdjClosePrice types.NullDecimal // 分割調整後終値
+	Volume        int64             // 出来高
+	TradingDays   int               // 取引日数
+	CreatedAt     null.Time         // 作成日時
+	UpdatedAt     null.Time         // 更新日時
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+)
+
+// AlertRule is a stored alert rule. The conditions are defined in YAML.
+type AlertRule struct {
+	ID         string
+	Name       string    // ルール名
+	Definition string    // ルール定義(YAML)
+	Enabled    bool      // 有効フラグ
+	CreatedAt  null.Time // 作成日時
+	UpdatedAt  null.Time // 更新日時
+}
+
+// Validate validates alert rule data
+func (r *AlertRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("ルール名は必須です")
+	}
+
+	if r.Definition == "" {
+		return fmt.Errorf("ルール定義は必須です")
+	}
+
+	return nil
+}
+
+// AlertRuleEvaluation is a matched evaluation of an alert rule for a stock.
+type AlertRuleEvaluation struct {
+	ID           string
+	AlertRuleID  string    // アラートルールID
+	RuleName     string    // ルール名(参照時のみ)
+	Code         string    // 銘柄コード
+	MetricValues string    // 評価時の指標値(JSON)
+	Notified     bool      // 通知済み(クールダウン中・送信失敗はfalse)
+	EvaluatedAt  time.Time // 評価日時
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+)
+
+// Sources of audited operations.
+const (
+	AuditSourceCLI       = "cli"
+	AuditSourceAPI       = "api"
+	AuditSourceScheduler = "scheduler"
+	AuditSourceUnknown   = "unknown"
+)
+
+// Audited operations.
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// Audited entity types.
+const (
+	AuditEntityPortfolio = "portfolio"
+	AuditEntityWatchList = "watch_list"
+)
+
+// AuditLog records who changed which record and how.
+type AuditLog struct {
+	ID         string
+	Source     string      // 操作元(cli/api/scheduler)
+	Actor      string      // 操作者(CLIはOSユーザー、スケジューラはジョブ名)
+	Action     string      // 操作(create/update/delete/restore)
+	EntityType string      // 対象種別(portfolio/watch_list)
+	EntityID   string      // 対象ID
+	Code       string      // 銘柄コード
+	Before     null.String // 変更前の値(JSON)
+	After      null.String // 変更後の値(JSON)
+	CreatedAt  time.Time   // 記録日時
+}
+
+// Validate validates audit log data
+func (l *AuditLog) Validate() error {
+	switch l.Action {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionRestore:
+	default:
+		return fmt.Errorf("操作はcreate, update, delete, restoreのいずれかである必要があります: %s", l.Action)
+	}
+
+	if l.EntityType == "" {
+		return fmt.Errorf("対象種別は必須です")
+	}
+
+	if l.EntityID == "" {
+		return fmt.Errorf("対象IDは必須です")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+)
+
+// Order sides.
+const (
+	OrderSideBuy  = "buy"
+	OrderSideSell = "sell"
+)
+
+// Order types.
+const (
+	OrderTypeMarket = "market"
+	OrderTypeLimit  = "limit"
+)
+
+// Order statuses.
+const (
+	OrderStatusPending  = "pending"
+	OrderStatusFilled   = "filled"
+	OrderStatusCanceled = "canceled"
+	OrderStatusRejected = "rejected"
+)
+
+// BrokerOrder is an order placed with a broker.
+type BrokerOrder struct {
+	ID             string
+	Broker         string            // ブローカー名(paperなど)
+	Code           string            // 銘柄コード
+	Side           string            // 売買区分(buy/sell)
+	OrderType      string            // 注文種別(market/limit)
+	Quantity       int               // 注文数量
+	LimitPrice     types.NullDecimal // 指値
+	Status         string            // 注文状態(pending/filled/canceled/rejected)
+	FilledQuantity int               // 約定数量
+	AveragePrice   types.NullDecimal // 平均約定価格
+	Message        null.String       // 取消・拒否理由
+	CreatedAt      null.Time         // 作成日時
+	UpdatedAt      null.Time         // 更新日時
+}
+
+// Validate validates broker order data
+func (o *BrokerOrder) Validate() error {
+	if o.Code == "" {
+		return fmt.Errorf("銘柄コードは必須です")
+	}
+
+	if o.Side != OrderSideBuy && o.Side != OrderSideSell {
+		return fmt.Errorf("売買区分はbuyまたはsellである必要があります: %s", o.Side)
+	}
+
+	switch o.OrderType {
+	case OrderTypeMarket:
+		if !o.LimitPrice.IsZero() {
+			return fmt.Errorf("成行注文に指値は指定できません")
+		}
+	case OrderTypeLimit:
+		if o.LimitPrice.IsZero() || o.LimitPrice.Big.Sign() <= 0 {
+			return fmt.Errorf("指値注文の指値は0より大きい必要があります")
+		}
+	default:
+		return fmt.Errorf("注文種別はmarketまたはlimitである必要があります: %s", o.OrderType)
+	}
+
+	if o.Quantity <= 0 {
+		return fmt.Errorf("注文数量は0より大きい必要があります")
+	}
+
+	return nil
+}
+
+// BrokerExecution is a fill of a broker order.
+type BrokerExecution struct {
+	ID         string
+	OrderID    string        // 注文ID
+	Broker     string        // ブローカー名
+	Code       string        // 銘柄コード
+	Side       string        // 売買区分(buy/sell)
+	Quantity   int           // 約定数量
+	Price      types.Decimal // 約定価格
+	ExecutedAt time.Time     // 約定日時
+}
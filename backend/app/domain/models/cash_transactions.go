@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Types of a cash transaction.
+const (
+	CashTransactionDeposit    = "deposit"    // 入金
+	CashTransactionWithdrawal = "withdrawal" // 出金
+)
+
+// CashTransaction is a deposit to or a withdrawal from the cash balance of the portfolio.
+type CashTransaction struct {
+	ID              string
+	TransactionType string    // 入出金種別(deposit/withdrawal)
+	Amount          int       // 金額(円)
+	BalanceAfter    int       // 入出金後の現金残高(円)
+	Note            string    // メモ
+	TransactedOn    time.Time // 入出金日
+	CreatedAt       time.Time // 記録日時
+}
+
+// Validate checks the type and amount of the transaction.
+func (t *CashTransaction) Validate() error {
+	if t.TransactionType != CashTransactionDeposit && t.TransactionType != CashTransactionWithdrawal {
+		return fmt.Errorf("入出金種別が不正です: %s", t.TransactionType)
+	}
+	if t.Amount <= 0 {
+		return fmt.Errorf("金額は1円以上を指定してください: %d", t.Amount)
+	}
+	return nil
+}
+
+// SignedAmount returns the change of the cash balance, negative for a withdrawal.
+func (t *CashTransaction) SignedAmount() int {
+	if t.TransactionType == CashTransactionWithdrawal {
+		return -t.Amount
+	}
+	return t.Amount
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// CollectFailure records the consecutive failures to collect the price of a stock, and the period
+// it is left out of the collection once it has failed too many times in a row.
+type CollectFailure struct {
+	Code                string     // 銘柄コード
+	ConsecutiveFailures int        // 連続失敗回数
+	LastError           string     // 最後のエラー
+	LastFailedAt        time.Time  // 最後に失敗した日時
+	QuarantinedUntil    *time.Time // 隔離期限(隔離されていなければnil)。期限後に再試行する
+}
+
+// IsQuarantined reports whether the stock is left out of the collection at the given time.
+func (f *CollectFailure) IsQuarantined(now time.Time) bool {
+	return f.QuarantinedUntil != nil && now.Before(*f.QuarantinedUntil)
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/aarondl/null/v8"
+)
+
+// Default exit lines applied to holdings without registered targets.
+const (
+	DefaultTakeProfitPercent = 20.0
+	DefaultStopLossPercent   = 10.0
+)
+
+// ExitSignal is the kind of exit line reached by a holding.
+type ExitSignal string
+
+const (
+	ExitSignalNone       ExitSignal = ""
+	ExitSignalTakeProfit ExitSignal = "take_profit"
+	ExitSignalStopLoss   ExitSignal = "stop_loss"
+)
+
+// ExitTarget holds the take-profit and stop-loss lines of a portfolio holding.
+type ExitTarget struct {
+	Code               string    // 銘柄コード
+	TakeProfitPercent  float64   // 利確ライン(損益率%)
+	StopLossPercent    float64   // 損切りライン(損失率%)
+	TakeProfitNotified bool      // 利確ライン通知済み
+	StopLossNotified   bool      // 損切りライン通知済み
+	CreatedAt          null.Time // 作成日時
+	UpdatedAt          null.Time // 更新日時
+}
+
+// NewDefaultExitTarget creates exit targets with the default lines
+func NewDefaultExitTarget(code string) *ExitTarget {
+	return &ExitTarget{
+		Code:              code,
+		TakeProfitPercent: DefaultTakeProfitPercent,
+		StopLossPercent:   DefaultStopLossPercent,
+	}
+}
+
+// Evaluate checks the gain percent of the holding against the exit lines.
+// A line is signaled once and suppressed until the gain moves back inside it and reaches it again.
+// changed reports whether the notified state was updated and needs to be saved.
+func (t *ExitTarget) Evaluate(gainPercent float64) (signal ExitSignal, changed bool) {
+	takeProfitReached := gainPercent >= t.TakeProfitPercent
+	if takeProfitReached != t.TakeProfitNotified {
+		t.TakeProfitNotified = takeProfitReached
+		changed = true
+		if takeProfitReached {
+			signal = ExitSignalTakeProfit
+		}
+	}
+
+	stopLossReached := gainPercent <= -t.StopLossPercent
+	if stopLossReached != t.StopLossNotified {
+		t.StopLossNotified = stopLossReached
+		changed = true
+		if stopLossReached {
+			signal = ExitSignalStopLoss
+		}
+	}
+
+	return signal, changed
+}
+
+// TakeProfitPrice calculates the price at which the holding reaches the take-profit line
+func (t *ExitTarget) TakeProfitPrice(holding *Portfolio) float64 {
+	if holding.IsShort() {
+		return holding.GetPurchasePrice() * (1 - t.TakeProfitPercent/100)
+	}
+	return holding.GetPurchasePrice() * (1 + t.TakeProfitPercent/100)
+}
+
+// StopLossPrice calculates the price at which the holding reaches the stop-loss line
+func (t *ExitTarget) StopLossPrice(holding *Portfolio) float64 {
+	if holding.IsShort() {
+		return holding.GetPurchasePrice() * (1 + t.StopLossPercent/100)
+	}
+	return holding.GetPurchasePrice() * (1 - t.StopLossPercent/100)
+}
+
+// Validate validates exit target data
+func (t *ExitTarget) Validate() error {
+	if t.Code == "" {
+		return fmt.Errorf("銘柄コードは必須です")
+	}
+	if t.TakeProfitPercent <= 0 {
+		return fmt.Errorf("利確ラインは0より大きい必要があります")
+	}
+	if t.StopLossPercent <= 0 || t.StopLossPercent > 100 {
+		return fmt.Errorf("損切りラインは0より大きく100以下である必要があります")
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExitTarget_Evaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		gains       []float64
+		wantSignals []ExitSignal
+	}{
+		{
+			name:        "Take profit reached once",
+			gains:       []float64{5.0, 20.0, 25.0},
+			wantSignals: []ExitSignal{ExitSignalNone, ExitSignalTakeProfit, ExitSignalNone},
+		},
+		{
+			name:        "Take profit reached again after falling back",
+			gains:       []float64{21.0, 15.0, 22.0},
+			wantSignals: []ExitSignal{ExitSignalTakeProfit, ExitSignalNone, ExitSignalTakeProfit},
+		},
+		{
+			name:        "Stop loss reached once",
+			gains:       []float64{-5.0, -10.0, -12.0},
+			wantSignals: []ExitSignal{ExitSignalNone, ExitSignalStopLoss, ExitSignalNone},
+		},
+		{
+			name:        "Stop loss after take profit",
+			gains:       []float64{20.0, -11.0},
+			wantSignals: []ExitSignal{ExitSignalTakeProfit, ExitSignalStopLoss},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NewDefaultExitTarget("1234")
+
+			signals := make([]ExitSignal, 0, len(tt.gains))
+			for _, gain := range tt.gains {
+				signal, _ := target.Evaluate(gain)
+				signals = append(signals, signal)
+			}
+
+			if diff := cmp.Diff(tt.wantSignals, signals); diff != "" {
+				t.Errorf("Evaluate signals mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExitTarget_Evaluate_Changed(t *testing.T) {
+	target := NewDefaultExitTarget("1234")
+
+	if _, changed := target.Evaluate(5.0); changed {
+		t.Error("State should not change inside the lines")
+	}
+	if _, changed := target.Evaluate(20.0); !changed {
+		t.Error("State should change when the take profit line is reached")
+	}
+	if _, changed := target.Evaluate(30.0); changed {
+		t.Error("State should not change while staying above the take profit line")
+	}
+	if _, changed := target.Evaluate(10.0); !changed {
+		t.Error("State should change when falling back inside the lines")
+	}
+}
+
+func TestExitTarget_LinePrices(t *testing.T) {
+	target := NewDefaultExitTarget("1234")
+
+	tests := []struct {
+		name           string
+		positionType   string
+		wantTakeProfit float64
+		wantStopLoss   float64
+	}{
+		{
+			name:           "Long position",
+			positionType:   PositionTypeLong,
+			wantTakeProfit: 1200.0,
+			wantStopLoss:   900.0,
+		},
+		{
+			name:           "Short position",
+			positionType:   PositionTypeShort,
+			wantTakeProfit: 800.0,
+			wantStopLoss:   1100.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holding := &Portfolio{
+				Code:          "1234",
+				Shares:        100,
+				PurchasePrice: utility.FloatToDecimal(1000.0),
+				PositionType:  tt.positionType,
+			}
+
+			if diff := cmp.Diff(tt.wantTakeProfit, target.TakeProfitPrice(holding)); diff != "" {
+				t.Errorf("TakeProfitPrice mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantStopLoss, target.StopLossPrice(holding)); diff != "" {
+				t.Errorf("StopLossPrice mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExitTarget_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    *ExitTarget
+		wantError bool
+	}{
+		{
+			name:      "Default targets",
+			target:    NewDefaultExitTarget("1234"),
+			wantError: false,
+		},
+		{
+			name:      "Empty code",
+			target:    NewDefaultExitTarget(""),
+			wantError: true,
+		},
+		{
+			name:      "Zero take profit",
+			target:    &ExitTarget{Code: "1234", TakeProfitPercent: 0, StopLossPercent: 10},
+			wantError: true,
+		},
+		{
+			name:      "Stop loss over 100",
+			target:    &ExitTarget{Code: "1234", TakeProfitPercent: 20, StopLossPercent: 120},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.target.Validate()
+			if tt.wantError && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("Expected no validation error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/aarondl/null/v8"
+)
+
+// FeatureFlag is a feature flag turned on or off in the database, overriding the flag file and the default.
+type FeatureFlag struct {
+	Name      string
+	Enabled   bool      // 有効フラグ
+	UpdatedBy string    // 変更者
+	CreatedAt null.Time // 作成日時
+	UpdatedAt null.Time // 更新日時
+}
+
+// Validate validates feature flag data
+func (f *FeatureFlag) Validate() error {
+	if f.Name == "" {
+		return fmt.Errorf("フラグ名は必須です")
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+)
+
+// MaintenanceWindow is a period during which alerts are not sent but recorded,
+// to be sent as a digest after the period ends.
+type MaintenanceWindow struct {
+	ID           string
+	StartsAt     time.Time // 開始日時
+	EndsAt       time.Time // 終了日時
+	Reason       string    // 理由
+	DigestSentAt null.Time // ダイジェスト送信日時
+	CreatedAt    null.Time // 作成日時
+	UpdatedAt    null.Time // 更新日時
+}
+
+// Validate validates maintenance window data
+func (w *MaintenanceWindow) Validate() error {
+	if !w.EndsAt.After(w.StartsAt) {
+		return fmt.Errorf("終了日時は開始日時より後である必要があります")
+	}
+
+	return nil
+}
+
+// IsActive reports whether alerts are suppressed at t.
+func (w *MaintenanceWindow) IsActive(t time.Time) bool {
+	return !t.Before(w.StartsAt) && t.Before(w.EndsAt)
+}
+
+// SuppressedAlert is an alert recorded instead of being sent during a maintenance window.
+type SuppressedAlert struct {
+	ID                  string
+	MaintenanceWindowID string    // メンテナンスウィンドウID
+	AlertType           string    // アラート種別
+	Message             string    // メッセージ
+	SuppressedAt        time.Time // 抑制日時
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// NotificationThread is the Slack message starting the thread of a key, e.g. the daily report of a
+// day, so that the later messages of the key are sent as replies in its thread.
+type NotificationThread struct {
+	ThreadKey string
+	Channel   string    // SlackチャンネルID
+	TS        string    // 親メッセージのタイムスタンプ
+	CreatedAt time.Time // 作成日時
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+)
+
+// PortfolioGoal is a target of the portfolio value to reach by a deadline, e.g. +10% by the end of the year.
+// Progress is measured from the value when the goal was set.
+type PortfolioGoal struct {
+	ID            string
+	Name          string    // 目標名
+	BaselineValue float64   // 設定時の評価額
+	TargetValue   float64   // 目標評価額
+	StartDate     time.Time // 開始日
+	Deadline      time.Time // 期限
+	LastStatus    string    // 最後に通知したペース(on_track/behind/achieved/missed)
+	CreatedAt     null.Time // 作成日時
+	UpdatedAt     null.Time // 更新日時
+}
+
+// Validate validates portfolio goal data
+func (g *PortfolioGoal) Validate() error {
+	if g.Name == "" {
+		return fmt.Errorf("目標名は必須です")
+	}
+	if g.BaselineValue <= 0 {
+		return fmt.Errorf("設定時の評価額は0より大きい必要があります")
+	}
+	if g.TargetValue <= g.BaselineValue {
+		return fmt.Errorf("目標評価額は設定時の評価額より大きい必要があります")
+	}
+	if !g.Deadline.After(g.StartDate) {
+		return fmt.Errorf("期限は開始日より後である必要があります")
+	}
+	return nil
+}
+
+// TargetPercent returns the targeted increase of the value in percent.
+func (g *PortfolioGoal) TargetPercent() float64 {
+	return (g.TargetValue/g.BaselineValue - 1) * 100
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// PortfolioLot is the shares of a holding bought on one date at one price.
+// The portfolio holding carries the total shares and the average purchase price of its lots.
+// AveragePrice is set by a sale with the average method and leaves the purchase price as bought.
+type PortfolioLot struct {
+	ID            string
+	Code          string            // 銘柄コード
+	Shares        int               // 残株数
+	PurchasePrice types.Decimal     // 取得単価
+	AveragePrice  types.NullDecimal // 平均取得単価(移動平均法で売却した後)
+	PurchaseDate  time.Time         // 取得日
+	CreatedAt     null.Time         // 作成日時
+	UpdatedAt     null.Time         // 更新日時
+}
+
+// Validate validates portfolio lot data
+func (l *PortfolioLot) Validate() error {
+	if l.Code == "" {
+		return fmt.Errorf("銘柄コードは必須です")
+	}
+	if l.Shares <= 0 {
+		return fmt.Errorf("株数は1以上である必要があります")
+	}
+	if l.GetPurchasePrice() <= 0 {
+		return fmt.Errorf("取得単価は0より大きい必要があります")
+	}
+	return nil
+}
+
+// GetPurchasePrice returns the purchase price per share as float64
+func (l *PortfolioLot) GetPurchasePrice() float64 {
+	return utility.DecimalToFloat(l.PurchasePrice)
+}
+
+// CalculatePurchaseCost calculates the purchase cost of the remaining shares
+func (l *PortfolioLot) CalculatePurchaseCost() float64 {
+	return float64(l.Shares) * l.GetPurchasePrice()
+}
+
+// GetCostPrice returns the cost per share of the lot: the average price after a sale with the
+// average method, otherwise the purchase price
+func (l *PortfolioLot) GetCostPrice() float64 {
+	if l.AveragePrice.Big != nil {
+		return utility.NullDecimalToFloat(l.AveragePrice)
+	}
+	return l.GetPurchasePrice()
+}
+
+// CalculateCost calculates the cost of the remaining shares at the cost price
+func (l *PortfolioLot) CalculateCost() float64 {
+	return float64(l.Shares) * l.GetCostPrice()
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/aarondl/null/v8"
+)
+
+// SnapshotDateFormat is the date layout used to identify a daily portfolio snapshot.
+const SnapshotDateFormat = "2006-01-02"
+
+// PortfolioSnapshot holds the portfolio valuation at the end of a day.
+type PortfolioSnapshot struct {
+	SnapshotDate  time.Time // スナップショット日
+	TotalValue    float64   // 評価額
+	TotalCost     float64   // 取得額
+	TotalGain     float64   // 評価損益
+	HoldingsCount int       // 保有銘柄数
+	CreatedAt     null.Time // 作成日時
+	UpdatedAt     null.Time // 更新日時
+}
+
+// NewPortfolioSnapshot creates a snapshot for the day of date.
+func NewPortfolioSnapshot(date time.Time, totalValue, totalCost float64, holdingsCount int) *PortfolioSnapshot {
+	return &PortfolioSnapshot{
+		SnapshotDate:  TruncateToDate(date),
+		TotalValue:    totalValue,
+		TotalCost:     totalCost,
+		TotalGain:     totalValue - totalCost,
+		HoldingsCount: holdingsCount,
+	}
+}
+
+// DateKey returns the snapshot date as YYYY-MM-DD.
+func (s *PortfolioSnapshot) DateKey() string {
+	return s.SnapshotDate.Format(SnapshotDateFormat)
+}
+
+// GainPercent returns the gain relative to the total cost in percent.
+func (s *PortfolioSnapshot) GainPercent() float64 {
+	if s.TotalCost == 0 {
+		return 0
+	}
+	return s.TotalGain / s.TotalCost * 100
+}
+
+// TruncateToDate returns midnight of the day of t in its location.
+func TruncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -5,39 +5,154 @@ package models
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"time"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/boost-jp/stock-automation/app/utility"
 )
 
-//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Name,Shares,PurchasePrice,PurchaseDate,CreatedAt,UpdatedAt, Portfolio
+//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Name,Shares,PurchasePrice,PurchaseDate,PositionType,MarginRate,InterestRate,AssetClass,Isin,CreatedAt,UpdatedAt,DeletedAt, Portfolio
 
 // You can edit this as you like.
 
+// Position types of a portfolio holding.
+const (
+	PositionTypeLong  = "long"
+	PositionTypeShort = "short"
+)
+
+// Asset classes of a portfolio holding.
+const (
+	AssetClassStock  = "stock"  // 個別株
+	AssetClassETF    = "etf"    // 上場投資信託
+	AssetClassFund   = "fund"   // 投資信託
+	AssetClassCrypto = "crypto" // 暗号資産
+	AssetClassCash   = "cash"   // 現金残高
+)
+
+// AssetClasses lists the asset classes in the order of the reports.
+var AssetClasses = []string{AssetClassStock, AssetClassETF, AssetClassFund, AssetClassCrypto, AssetClassCash}
+
+const (
+	// FundPriceUnits is the number of units of an investment trust its net asset value is quoted for.
+	FundPriceUnits = 10000
+	// CryptoQuantityUnits is the number of shares of a crypto asset holding per coin, as the
+	// quantities held are fractional down to 0.00000001 coins.
+	CryptoQuantityUnits = 100000000
+)
+
 // Portfolio is an object representing the database table.
 // Set the "validate" tags as needed.
 // https://pkg.go.dev/gopkg.in/go-playground/validator.v10
 type Portfolio struct {
 	ID            string
-	Code          string        // 銘柄コード
-	Name          string        // 銘柄名
-	Shares        int           // 保有株数
-	PurchasePrice types.Decimal // 購入価格
-	PurchaseDate  time.Time     // 購入日
-	CreatedAt     null.Time     // 作成日時
-	UpdatedAt     null.Time     // 更新日時
+	Code          string            // 銘柄コード
+	Name          string            // 銘柄名
+	Shares        int               // 保有株数
+	PurchasePrice types.Decimal     // 購入価格
+	PurchaseDate  time.Time         // 購入日
+	PositionType  string            // ポジション種別(long/short)
+	MarginRate    types.NullDecimal // 委託保証金率(%)
+	InterestRate  types.NullDecimal // 年率金利・貸株料(%)
+	AssetClass    string            // 資産クラス(stock/etf/fund/crypto/cash)
+	Isin          null.String       // ISINコード(投資信託)
+	CreatedAt     null.Time         // 作成日時
+	UpdatedAt     null.Time         // 更新日時
+	DeletedAt     null.Time         // 削除日時(論理削除)
+}
+
+// IsShort reports whether this holding is a short (margin sell) position.
+// An empty position type is treated as a long position.
+func (p *Portfolio) IsShort() bool {
+	return p.PositionType == PositionTypeShort
+}
+
+// GetAssetClass returns the asset class of this holding. An empty asset class is treated as a stock.
+func (p *Portfolio) GetAssetClass() string {
+	if p.AssetClass == "" {
+		return AssetClassStock
+	}
+	return p.AssetClass
 }
 
-// CalculateCurrentValue calculates the current value of this portfolio holding
+// IsCash reports whether this holding is a cash balance, whose shares are the amount in yen.
+func (p *Portfolio) IsCash() bool {
+	return p.AssetClass == AssetClassCash
+}
+
+// IsFund reports whether this holding is an investment trust priced by its net asset value.
+func (p *Portfolio) IsFund() bool {
+	return p.AssetClass == AssetClassFund
+}
+
+// IsCrypto reports whether this holding is a crypto asset, whose shares are in units of
+// 1/CryptoQuantityUnits coins.
+func (p *Portfolio) IsCrypto() bool {
+	return p.AssetClass == AssetClassCrypto
+}
+
+// CryptoQuantity returns the number of coins of a crypto asset holding.
+func (p *Portfolio) CryptoQuantity() float64 {
+	return float64(p.Shares) / CryptoQuantityUnits
+}
+
+// CryptoSharesFromQuantity converts a number of coins into the shares of a crypto asset holding.
+func CryptoSharesFromQuantity(quantity float64) int {
+	return int(math.Round(quantity * CryptoQuantityUnits))
+}
+
+// IsListed reports whether this holding is traded on an exchange, so that its prices are
+// collected from the stock data sources.
+func (p *Portfolio) IsListed() bool {
+	class := p.GetAssetClass()
+	return class == AssetClassStock || class == AssetClassETF
+}
+
+// CalculateCurrentValue calculates the current value of this portfolio holding.
+// For a short position the value moves inversely to the price, starting from the sell proceeds.
+// The price of an investment trust is its net asset value per FundPriceUnits units, and
+// the price of a crypto asset is per coin.
 func (p *Portfolio) CalculateCurrentValue(currentPrice float64) float64 {
-	return float64(p.Shares) * currentPrice
+	if p.IsShort() {
+		return p.CalculatePurchaseCost() + float64(p.Shares)*(p.getPurchasePrice()-currentPrice)
+	}
+	return float64(p.Shares) * currentPrice / p.priceUnits()
+}
+
+// CalculateRequiredMargin calculates the required margin deposit at the current price
+func (p *Portfolio) CalculateRequiredMargin(currentPrice float64) float64 {
+	return float64(p.Shares) * currentPrice * p.GetMarginRate() / 100
+}
+
+// CalculateInterestCost calculates the accrued interest (or stock lending fee) up to asOf.
+// Interest accrues daily on the opening amount at the annual rate.
+func (p *Portfolio) CalculateInterestCost(asOf time.Time) float64 {
+	rate := p.GetInterestRate()
+	if rate <= 0 || !asOf.After(p.PurchaseDate) {
+		return 0
+	}
+	days := math.Floor(asOf.Sub(p.PurchaseDate).Hours() / 24)
+	return p.CalculatePurchaseCost() * rate / 100 * days / 365
 }
 
 // CalculatePurchaseCost calculates the total purchase cost
 func (p *Portfolio) CalculatePurchaseCost() float64 {
 	purchasePrice := p.getPurchasePrice()
-	return float64(p.Shares) * purchasePrice
+	return float64(p.Shares) * purchasePrice / p.priceUnits()
+}
+
+// priceUnits returns the number of shares or units the price of this holding is quoted for.
+func (p *Portfolio) priceUnits() float64 {
+	switch p.AssetClass {
+	case AssetClassFund:
+		return FundPriceUnits
+	case AssetClassCrypto:
+		return CryptoQuantityUnits
+	}
+	return 1
 }
 
 // CalculateGain calculates profit/loss for this holding
@@ -69,16 +184,40 @@ func (p *Portfolio) Validate() error {
 	if p.getPurchasePrice() <= 0 {
 		return fmt.Errorf("購入価格は0より大きい必要があります")
 	}
+	if p.PositionType != "" && p.PositionType != PositionTypeLong && p.PositionType != PositionTypeShort {
+		return fmt.Errorf("ポジション種別はlongまたはshortである必要があります")
+	}
+	if rate := p.GetMarginRate(); rate < 0 || rate > 100 {
+		return fmt.Errorf("委託保証金率は0から100の範囲である必要があります")
+	}
+	if p.GetInterestRate() < 0 {
+		return fmt.Errorf("金利は0以上である必要があります")
+	}
+	if !slices.Contains(AssetClasses, p.GetAssetClass()) {
+		return fmt.Errorf("資産クラスはstock、etf、fund、crypto、cashのいずれかである必要があります")
+	}
+	if p.IsShort() && !p.IsListed() {
+		return fmt.Errorf("空売りは株式またはETFのみ可能です")
+	}
+	if p.IsFund() && len(p.Isin.String) != 12 {
+		return fmt.Errorf("投資信託には12桁のISINコードが必要です")
+	}
 	return nil
 }
 
 // GetPurchasePrice is a helper to extract float64 from types.Decimal
 func (p *Portfolio) GetPurchasePrice() float64 {
-	if p.PurchasePrice.Big == nil {
-		return 0.0
-	}
-	f, _ := p.PurchasePrice.Big.Float64()
-	return f
+	return utility.DecimalToFloat(p.PurchasePrice)
+}
+
+// GetMarginRate returns the margin rate in percent, or 0 if not set
+func (p *Portfolio) GetMarginRate() float64 {
+	return utility.NullDecimalToFloat(p.MarginRate)
+}
+
+// GetInterestRate returns the annual interest rate in percent, or 0 if not set
+func (p *Portfolio) GetInterestRate() float64 {
+	return utility.NullDecimalToFloat(p.InterestRate)
 }
 
 // GetTestPrice is a helper to get test price from external map
@@ -99,8 +238,14 @@ func NewPortfolio(
 	Shares int,
 	PurchasePrice types.Decimal,
 	PurchaseDate time.Time,
+	PositionType string,
+	MarginRate types.NullDecimal,
+	InterestRate types.NullDecimal,
+	AssetClass string,
+	Isin null.String,
 	CreatedAt null.Time,
 	UpdatedAt null.Time,
+	DeletedAt null.Time,
 ) *Portfolio {
 	do := &Portfolio{
 		ID:            ID,
@@ -109,8 +254,14 @@ func NewPortfolio(
 		Shares:        Shares,
 		PurchasePrice: PurchasePrice,
 		PurchaseDate:  PurchaseDate,
+		PositionType:  PositionType,
+		MarginRate:    MarginRate,
+		InterestRate:  InterestRate,
+		AssetClass:    AssetClass,
+		Isin:          Isin,
 		CreatedAt:     CreatedAt,
 		UpdatedAt:     UpdatedAt,
+		DeletedAt:     DeletedAt,
 	}
 	return do
 }
@@ -1,23 +1,15 @@
 package models
 
 import (
-	"fmt"
+	"math"
 	"testing"
 	"time"
 
-	"github.com/aarondl/sqlboiler/v4/types"
-	"github.com/ericlagergren/decimal"
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/utility"
 	"github.com/google/go-cmp/cmp"
 )
 
-// floatToDecimal converts float64 to types.Decimal for testing
-func floatToDecimal(value float64) types.Decimal {
-	decimalStr := fmt.Sprintf("%.6f", value)
-	d := new(decimal.Big)
-	d.SetString(decimalStr)
-	return types.Decimal{Big: d}
-}
-
 func TestPortfolio_CalculateCurrentValue(t *testing.T) {
 	portfolio := &Portfolio{
 		Code:   "1234",
@@ -62,7 +54,7 @@ func TestPortfolio_CalculatePurchaseCost(t *testing.T) {
 		Code:          "1234",
 		Name:          "Test Stock",
 		Shares:        100,
-		PurchasePrice: floatToDecimal(1000.0),
+		PurchasePrice: utility.FloatToDecimal(1000.0),
 	}
 
 	expected := 100000.0 // 100 shares * 1000.0 price
@@ -73,12 +65,57 @@ func TestPortfolio_CalculatePurchaseCost(t *testing.T) {
 	}
 }
 
+func TestPortfolio_FundPriceUnits(t *testing.T) {
+	// Net asset values of funds are quoted per 10,000 units
+	fund := &Portfolio{
+		Code:          "0331418A",
+		Name:          "Test Fund",
+		Shares:        500000,
+		PurchasePrice: utility.FloatToDecimal(18000.0),
+		AssetClass:    AssetClassFund,
+	}
+
+	if diff := cmp.Diff(900000.0, fund.CalculatePurchaseCost()); diff != "" {
+		t.Errorf("CalculatePurchaseCost mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(1000000.0, fund.CalculateCurrentValue(20000.0)); diff != "" {
+		t.Errorf("CalculateCurrentValue mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortfolio_CryptoQuantity(t *testing.T) {
+	// Quantities of crypto assets are held in units of 0.00000001 coins, priced per coin
+	crypto := &Portfolio{
+		Code:          "BTC",
+		Name:          "Bitcoin",
+		Shares:        CryptoSharesFromQuantity(0.05),
+		PurchasePrice: utility.FloatToDecimal(9000000.0),
+		AssetClass:    AssetClassCrypto,
+	}
+
+	if diff := cmp.Diff(5000000, crypto.Shares); diff != "" {
+		t.Errorf("CryptoSharesFromQuantity mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(0.05, crypto.CryptoQuantity()); diff != "" {
+		t.Errorf("CryptoQuantity mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(450000.0, crypto.CalculatePurchaseCost()); diff != "" {
+		t.Errorf("CalculatePurchaseCost mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(500000.0, crypto.CalculateCurrentValue(10000000.0)); diff != "" {
+		t.Errorf("CalculateCurrentValue mismatch (-want +got):\n%s", diff)
+	}
+	if crypto.IsListed() {
+		t.Error("Crypto asset should not be listed")
+	}
+}
+
 func TestPortfolio_CalculateGain(t *testing.T) {
 	portfolio := &Portfolio{
 		Code:          "1234",
 		Name:          "Test Stock",
 		Shares:        100,
-		PurchasePrice: floatToDecimal(1000.0),
+		PurchasePrice: utility.FloatToDecimal(1000.0),
 	}
 
 	tests := []struct {
@@ -118,7 +155,7 @@ func TestPortfolio_CalculateGainPercent(t *testing.T) {
 		Code:          "1234",
 		Name:          "Test Stock",
 		Shares:        100,
-		PurchasePrice: floatToDecimal(1000.0),
+		PurchasePrice: utility.FloatToDecimal(1000.0),
 	}
 
 	tests := []struct {
@@ -170,7 +207,7 @@ func TestPortfolio_Validate(t *testing.T) {
 				Code:          "1234",
 				Name:          "Test Stock",
 				Shares:        100,
-				PurchasePrice: floatToDecimal(1000.0),
+				PurchasePrice: utility.FloatToDecimal(1000.0),
 				PurchaseDate:  time.Now(),
 			},
 			wantError: false,
@@ -181,7 +218,7 @@ func TestPortfolio_Validate(t *testing.T) {
 				Code:          "",
 				Name:          "Test Stock",
 				Shares:        100,
-				PurchasePrice: floatToDecimal(1000.0),
+				PurchasePrice: utility.FloatToDecimal(1000.0),
 				PurchaseDate:  time.Now(),
 			},
 			wantError: true,
@@ -192,7 +229,7 @@ func TestPortfolio_Validate(t *testing.T) {
 				Code:          "1234",
 				Name:          "",
 				Shares:        100,
-				PurchasePrice: floatToDecimal(1000.0),
+				PurchasePrice: utility.FloatToDecimal(1000.0),
 				PurchaseDate:  time.Now(),
 			},
 			wantError: true,
@@ -203,7 +240,7 @@ func TestPortfolio_Validate(t *testing.T) {
 				Code:          "1234",
 				Name:          "Test Stock",
 				Shares:        0,
-				PurchasePrice: floatToDecimal(1000.0),
+				PurchasePrice: utility.FloatToDecimal(1000.0),
 				PurchaseDate:  time.Now(),
 			},
 			wantError: true,
@@ -214,8 +251,111 @@ func TestPortfolio_Validate(t *testing.T) {
 				Code:          "1234",
 				Name:          "Test Stock",
 				Shares:        -100,
-				PurchasePrice: floatToDecimal(1000.0),
+				PurchasePrice: utility.FloatToDecimal(1000.0),
+				PurchaseDate:  time.Now(),
+			},
+			wantError: true,
+		},
+		{
+			name: "Valid short position",
+			portfolio: &Portfolio{
+				Code:          "1234",
+				Name:          "Test Stock",
+				Shares:        100,
+				PurchasePrice: utility.FloatToDecimal(1000.0),
+				PurchaseDate:  time.Now(),
+				PositionType:  PositionTypeShort,
+				MarginRate:    utility.FloatToNullDecimal(30.0),
+				InterestRate:  utility.FloatToNullDecimal(1.15),
+			},
+			wantError: false,
+		},
+		{
+			name: "Unknown position type",
+			portfolio: &Portfolio{
+				Code:          "1234",
+				Name:          "Test Stock",
+				Shares:        100,
+				PurchasePrice: utility.FloatToDecimal(1000.0),
+				PurchaseDate:  time.Now(),
+				PositionType:  "option",
+			},
+			wantError: true,
+		},
+		{
+			name: "Margin rate over 100",
+			portfolio: &Portfolio{
+				Code:          "1234",
+				Name:          "Test Stock",
+				Shares:        100,
+				PurchasePrice: utility.FloatToDecimal(1000.0),
+				PurchaseDate:  time.Now(),
+				PositionType:  PositionTypeShort,
+				MarginRate:    utility.FloatToNullDecimal(120.0),
+			},
+			wantError: true,
+		},
+		{
+			name: "Valid fund",
+			portfolio: &Portfolio{
+				Code:          "0331418A",
+				Name:          "Test Fund",
+				Shares:        10000,
+				PurchasePrice: utility.FloatToDecimal(18000.0),
+				PurchaseDate:  time.Now(),
+				AssetClass:    AssetClassFund,
+				Isin:          null.StringFrom("JP90C000H1T1"),
+			},
+			wantError: false,
+		},
+		{
+			name: "Fund without ISIN",
+			portfolio: &Portfolio{
+				Code:          "0331418A",
+				Name:          "Test Fund",
+				Shares:        10000,
+				PurchasePrice: utility.FloatToDecimal(18000.0),
+				PurchaseDate:  time.Now(),
+				AssetClass:    AssetClassFund,
+			},
+			wantError: true,
+		},
+		{
+			name: "Short fund",
+			portfolio: &Portfolio{
+				Code:          "0331418A",
+				Name:          "Test Fund",
+				Shares:        10000,
+				PurchasePrice: utility.FloatToDecimal(18000.0),
+				PurchaseDate:  time.Now(),
+				AssetClass:    AssetClassFund,
+				Isin:          null.StringFrom("JP90C000H1T1"),
+				PositionType:  PositionTypeShort,
+			},
+			wantError: true,
+		},
+		{
+			name: "Unknown asset class",
+			portfolio: &Portfolio{
+				Code:          "1234",
+				Name:          "Test Stock",
+				Shares:        100,
+				PurchasePrice: utility.FloatToDecimal(1000.0),
+				PurchaseDate:  time.Now(),
+				AssetClass:    "bond",
+			},
+			wantError: true,
+		},
+		{
+			name: "Negative interest rate",
+			portfolio: &Portfolio{
+				Code:          "1234",
+				Name:          "Test Stock",
+				Shares:        100,
+				PurchasePrice: utility.FloatToDecimal(1000.0),
 				PurchaseDate:  time.Now(),
+				PositionType:  PositionTypeShort,
+				InterestRate:  utility.FloatToNullDecimal(-1.0),
 			},
 			wantError: true,
 		},
@@ -237,3 +377,123 @@ func TestPortfolio_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPortfolio_ShortPosition(t *testing.T) {
+	portfolio := &Portfolio{
+		Code:          "1234",
+		Name:          "Test Stock",
+		Shares:        100,
+		PurchasePrice: utility.FloatToDecimal(1000.0),
+		PositionType:  PositionTypeShort,
+	}
+
+	tests := []struct {
+		name         string
+		currentPrice float64
+		wantValue    float64
+		wantGain     float64
+		wantPercent  float64
+	}{
+		{
+			name:         "Price dropped",
+			currentPrice: 900.0,
+			wantValue:    110000.0,
+			wantGain:     10000.0,
+			wantPercent:  10.0,
+		},
+		{
+			name:         "Price rose",
+			currentPrice: 1200.0,
+			wantValue:    80000.0,
+			wantGain:     -20000.0,
+			wantPercent:  -20.0,
+		},
+		{
+			name:         "Price unchanged",
+			currentPrice: 1000.0,
+			wantValue:    100000.0,
+			wantGain:     0.0,
+			wantPercent:  0.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.wantValue, portfolio.CalculateCurrentValue(tt.currentPrice)); diff != "" {
+				t.Errorf("CalculateCurrentValue mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantGain, portfolio.CalculateGain(tt.currentPrice)); diff != "" {
+				t.Errorf("CalculateGain mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantPercent, portfolio.CalculateGainPercent(tt.currentPrice)); diff != "" {
+				t.Errorf("CalculateGainPercent mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPortfolio_CalculateRequiredMargin(t *testing.T) {
+	portfolio := &Portfolio{
+		Shares:        100,
+		PurchasePrice: utility.FloatToDecimal(1000.0),
+		PositionType:  PositionTypeShort,
+		MarginRate:    utility.FloatToNullDecimal(30.0),
+	}
+
+	if diff := cmp.Diff(36000.0, portfolio.CalculateRequiredMargin(1200.0)); diff != "" {
+		t.Errorf("CalculateRequiredMargin mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortfolio_CalculateInterestCost(t *testing.T) {
+	purchaseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		interestRate float64
+		asOf         time.Time
+		expected     float64
+	}{
+		{
+			name:         "One year",
+			interestRate: 1.5,
+			asOf:         purchaseDate.AddDate(0, 0, 365),
+			expected:     1500.0,
+		},
+		{
+			name:         "73 days",
+			interestRate: 2.0,
+			asOf:         purchaseDate.AddDate(0, 0, 73),
+			expected:     400.0,
+		},
+		{
+			name:         "Before purchase date",
+			interestRate: 2.0,
+			asOf:         purchaseDate.AddDate(0, 0, -1),
+			expected:     0.0,
+		},
+		{
+			name:         "No interest rate",
+			interestRate: 0.0,
+			asOf:         purchaseDate.AddDate(0, 0, 30),
+			expected:     0.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			portfolio := &Portfolio{
+				Shares:        100,
+				PurchasePrice: utility.FloatToDecimal(1000.0),
+				PurchaseDate:  purchaseDate,
+				PositionType:  PositionTypeShort,
+				InterestRate:  utility.FloatToNullDecimal(tt.interestRate),
+			}
+
+			result := portfolio.CalculateInterestCost(tt.asOf)
+			if math.Abs(result-tt.expected) > 0.001 {
+				t.Errorf("CalculateInterestCost = %f, want %f", result, tt.expected)
+			}
+		})
+	}
+}
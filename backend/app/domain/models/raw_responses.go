@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RawResponse is the body of a data source response that could not be parsed, kept so that the
+// cause can be investigated and the body parsed again after fixing the parser.
+type RawResponse struct {
+	ID         int64
+	Source     string    // データソース(yahoo)
+	Endpoint   string    // エンドポイント(current/historical/intraday)
+	Code       string    // 銘柄コード
+	URL        string    // リクエストURL
+	StatusCode int       // HTTPステータスコード
+	Body       []byte    // レスポンスボディ(上限を超えた分は切り捨て)
+	Truncated  bool      // ボディが上限で切り捨てられたか
+	Error      string    // パースに失敗したエラー
+	CreatedAt  time.Time // 保存日時
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// ReportPreference turns a section of the daily report on or off. Sections without a preference
+// are shown.
+type ReportPreference struct {
+	Section   string    // レポートの項目(holdings/technicals など)
+	Enabled   bool      // 表示するか
+	UpdatedAt time.Time // 更新日時
+}
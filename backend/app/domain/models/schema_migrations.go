@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+)
+
+// SchemaMigrationPhase is the phase of a zero-downtime schema migration.
+type SchemaMigrationPhase string
+
+// Schema migration phases, in the order a migration goes through them.
+const (
+	// SchemaMigrationPending: only the old schema is written and read.
+	SchemaMigrationPending SchemaMigrationPhase = "pending"
+	// SchemaMigrationDualWrite: both schemas are written, the old one is read.
+	SchemaMigrationDualWrite SchemaMigrationPhase = "dual_write"
+	// SchemaMigrationBackfilling: rows written before dual-writing are being copied to the new schema.
+	SchemaMigrationBackfilling SchemaMigrationPhase = "backfilling"
+	// SchemaMigrationReadNew: both schemas are written, the new one is read.
+	SchemaMigrationReadNew SchemaMigrationPhase = "read_new"
+	// SchemaMigrationCompleted: only the new schema is written and read, and the old one can be dropped.
+	SchemaMigrationCompleted SchemaMigrationPhase = "completed"
+)
+
+// WritesOld reports whether the old schema is written in the phase.
+func (p SchemaMigrationPhase) WritesOld() bool {
+	return p != SchemaMigrationCompleted
+}
+
+// WritesNew reports whether the new schema is written in the phase.
+func (p SchemaMigrationPhase) WritesNew() bool {
+	return p != SchemaMigrationPending
+}
+
+// ReadsNew reports whether the new schema is read in the phase.
+func (p SchemaMigrationPhase) ReadsNew() bool {
+	return p == SchemaMigrationReadNew || p == SchemaMigrationCompleted
+}
+
+// SchemaMigration is the phase and backfill progress of a zero-downtime schema migration.
+type SchemaMigration struct {
+	Name              string
+	Phase             SchemaMigrationPhase // フェーズ
+	TotalRows         int64                // バックフィル対象行数
+	BackfilledRows    int64                // バックフィル済み行数
+	LastKey           string               // バックフィル済みの最後のキー
+	StartedAt         null.Time            // dual-write開始日時
+	BackfillStartedAt null.Time            // バックフィル開始日時
+	BackfilledAt      null.Time            // バックフィル完了日時
+	CompletedAt       null.Time            // 移行完了日時
+	CreatedAt         null.Time            // 作成日時
+	UpdatedAt         null.Time            // 更新日時
+}
+
+// Validate validates schema migration data
+func (m *SchemaMigration) Validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("マイグレーション名は必須です")
+	}
+
+	switch m.Phase {
+	case SchemaMigrationPending, SchemaMigrationDualWrite, SchemaMigrationBackfilling,
+		SchemaMigrationReadNew, SchemaMigrationCompleted:
+	default:
+		return fmt.Errorf("不明なフェーズです: %s", m.Phase)
+	}
+
+	if m.BackfilledRows < 0 || m.TotalRows < 0 {
+		return fmt.Errorf("行数は0以上である必要があります")
+	}
+
+	return nil
+}
+
+// BackfillPercent returns the backfill progress in percent.
+func (m *SchemaMigration) BackfillPercent() float64 {
+	if m.BackfilledAt.Valid {
+		return 100
+	}
+	if m.TotalRows <= 0 {
+		return 0
+	}
+	percent := float64(m.BackfilledRows) / float64(m.TotalRows) * 100
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
+// BackfillETA estimates the time left to finish the backfill from the pace since it started.
+// Returns zero if the pace is not known yet.
+func (m *SchemaMigration) BackfillETA(now time.Time) time.Duration {
+	if m.BackfilledAt.Valid || !m.BackfillStartedAt.Valid || m.BackfilledRows <= 0 || m.TotalRows <= m.BackfilledRows {
+		return 0
+	}
+	elapsed := now.Sub(m.BackfillStartedAt.Time)
+	if elapsed <= 0 {
+		return 0
+	}
+	remaining := m.TotalRows - m.BackfilledRows
+	return time.Duration(float64(elapsed) / float64(m.BackfilledRows) * float64(remaining))
+}
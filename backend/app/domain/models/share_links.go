@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ShareLink grants read-only access to the daily report over the web to anyone holding its token,
+// until it expires or is revoked.
+type ShareLink struct {
+	ID        string
+	Label     string     // 共有相手などのメモ
+	ExpiresAt time.Time  // 有効期限
+	RevokedAt *time.Time // 失効日時(失効していなければnil)
+	CreatedAt time.Time  // 作成日時
+}
+
+// IsActive reports whether the link can be used at the given time.
+func (l *ShareLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
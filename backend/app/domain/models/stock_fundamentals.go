@@ -0,0 +1,40 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/aarondl/null/v8"
+)
+
+// StockFundamental holds financial indicators of a stock.
+type StockFundamental struct {
+	Code          string       // 銘柄コード
+	Per           null.Float64 // 株価収益率
+	Pbr           null.Float64 // 株価純資産倍率
+	Roe           null.Float64 // 自己資本利益率(%)
+	DividendYield null.Float64 // 配当利回り(%)
+	CreatedAt     null.Time    // 作成日時
+	UpdatedAt     null.Time    // 更新日時
+}
+
+// HasAnyValue reports whether at least one indicator is set.
+func (f *StockFundamental) HasAnyValue() bool {
+	return f.Per.Valid || f.Pbr.Valid || f.Roe.Valid || f.DividendYield.Valid
+}
+
+// Validate validates fundamental data
+func (f *StockFundamental) Validate() error {
+	if f.Code == "" {
+		return fmt.Errorf("銘柄コードは必須です")
+	}
+	if !f.HasAnyValue() {
+		return fmt.Errorf("財務指標を1つ以上指定してください")
+	}
+	if f.Pbr.Valid && f.Pbr.Float64 < 0 {
+		return fmt.Errorf("PBRは0以上である必要があります")
+	}
+	if f.DividendYield.Valid && f.DividendYield.Float64 < 0 {
+		return fmt.Errorf("配当利回りは0以上である必要があります")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNicknameLength is the maximum number of characters of a nickname.
+const MaxNicknameLength = 50
+
+// StockNickname is the name of a stock shown in the reports, the CLI and the notifications instead
+// of its official name, which stays stored in the watch list and the portfolio.
+type StockNickname struct {
+	Code      string
+	Nickname  string    // 表示名
+	CreatedAt time.Time // 作成日時
+	UpdatedAt time.Time // 更新日時
+}
+
+// Validate checks the nickname.
+func (n *StockNickname) Validate() error {
+	if n.Code == "" {
+		return fmt.Errorf("銘柄コードを指定してください")
+	}
+	if strings.TrimSpace(n.Nickname) == "" {
+		return fmt.Errorf("ニックネームを指定してください")
+	}
+	if utf8.RuneCountInString(n.Nickname) > MaxNicknameLength {
+		return fmt.Errorf("ニックネームは%d文字以内で指定してください", MaxNicknameLength)
+	}
+	return nil
+}
@@ -4,13 +4,16 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/ericlagergren/decimal"
 )
 
-//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Date,OpenPrice,HighPrice,LowPrice,ClosePrice,Volume,CreatedAt,UpdatedAt, StockPrice
+//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Date,OpenPrice,HighPrice,LowPrice,ClosePrice,AdjClosePrice,Volume,Source,FetchedAt,Final,CreatedAt,UpdatedAt, StockPrice
 
 // You can edit this as you like.
 
@@ -18,16 +21,93 @@ import (
 // Set the "validate" tags as needed.
 // https://pkg.go.dev/gopkg.in/go-playground/validator.v10
 type StockPrice struct {
-	ID         string
-	Code       string        // 銘柄コード
-	Date       time.Time     // 取引日
-	OpenPrice  types.Decimal // 始値
-	HighPrice  types.Decimal // 高値
-	LowPrice   types.Decimal // 安値
-	ClosePrice types.Decimal // 終値
-	Volume     int64         // 出来高
-	CreatedAt  null.Time     // 作成日時
-	UpdatedAt  null.Time     // 更新日時
+	ID            string
+	Code          string            // 銘柄コード
+	Date          time.Time         // 取引日
+	OpenPrice     types.Decimal     // 始値(未調整の実価格)
+	HighPrice     types.Decimal     // 高値(未調整の実価格)
+	LowPrice      types.Decimal     // 安値(未調整の実価格)
+	ClosePrice    types.Decimal     // 終値(未調整の実価格)
+	AdjClosePrice types.NullDecimal // 分割調整後終値
+	Volume        int64             // 出来高(未調整)
+	Source        string            // 取得元データソース(yahoo/stooq/jquants等)
+	FetchedAt     null.Time         // 取得日時
+	Final         bool              // 確定値フラグ(大引け後の確報収集か終了した取引日の履歴取得で確定、ザラ場中の取得値は暫定)
+	CreatedAt     null.Time         // 作成日時
+	UpdatedAt     null.Time         // 更新日時
+}
+
+// AdjustmentFactor returns the ratio of the split-adjusted close to the actual close.
+// Returns 1 when no adjusted close is stored (no split after the date, or data saved before it was recorded).
+func (p *StockPrice) AdjustmentFactor() float64 {
+	adjClose := utility.NullDecimalToFloat(p.AdjClosePrice)
+	closePrice := utility.DecimalToFloat(p.ClosePrice)
+	if adjClose <= 0 || closePrice <= 0 {
+		return 1
+	}
+	return adjClose / closePrice
+}
+
+// Adjusted returns a copy with split-adjusted prices and volume, for technical analysis
+// over periods including splits. Trading records should use the actual prices instead.
+func (p *StockPrice) Adjusted() *StockPrice {
+	adjusted := *p
+	factor := p.AdjustmentFactor()
+	if factor == 1 {
+		return &adjusted
+	}
+
+	adjusted.OpenPrice = scaleDecimal(p.OpenPrice, factor)
+	adjusted.HighPrice = scaleDecimal(p.HighPrice, factor)
+	adjusted.LowPrice = scaleDecimal(p.LowPrice, factor)
+	adjusted.ClosePrice = types.NewDecimal(new(decimal.Big).Copy(p.AdjClosePrice.Big))
+	adjusted.Volume = int64(math.Round(float64(p.Volume) / factor))
+	return &adjusted
+}
+
+// scaleDecimal multiplies a decimal price by factor.
+func scaleDecimal(d types.Decimal, factor float64) types.Decimal {
+	if d.Big == nil {
+		return d
+	}
+	return utility.FloatToDecimal(utility.DecimalToFloat(d) * factor)
+}
+
+// SameTradingDay reports whether both prices are of the same trading day.
+func (p *StockPrice) SameTradingDay(other *StockPrice) bool {
+	y1, m1, d1 := p.Date.Date()
+	y2, m2, d2 := other.Date.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// SameQuote reports whether prices and volume are unchanged, compared at the
+// precision stored in the database. The adjusted close is compared only when both have one,
+// as current quotes come without it.
+func (p *StockPrice) SameQuote(other *StockPrice) bool {
+	if p.Volume != other.Volume {
+		return false
+	}
+	pairs := [][2]float64{
+		{utility.DecimalToFloat(p.OpenPrice), utility.DecimalToFloat(other.OpenPrice)},
+		{utility.DecimalToFloat(p.HighPrice), utility.DecimalToFloat(other.HighPrice)},
+		{utility.DecimalToFloat(p.LowPrice), utility.DecimalToFloat(other.LowPrice)},
+		{utility.DecimalToFloat(p.ClosePrice), utility.DecimalToFloat(other.ClosePrice)},
+	}
+	for _, pair := range pairs {
+		if !sameRounded(pair[0], pair[1], 2) {
+			return false
+		}
+	}
+	if p.AdjClosePrice.Big == nil || other.AdjClosePrice.Big == nil {
+		return true
+	}
+	return sameRounded(utility.NullDecimalToFloat(p.AdjClosePrice), utility.NullDecimalToFloat(other.AdjClosePrice), 4)
+}
+
+// sameRounded reports whether two values are equal when rounded to places.
+func sameRounded(a, b float64, places int) bool {
+	scale := math.Pow10(places)
+	return math.Round(a*scale) == math.Round(b*scale)
 }
 
 func NewStockPrice(
@@ -38,21 +118,29 @@ func NewStockPrice(
 	HighPrice types.Decimal,
 	LowPrice types.Decimal,
 	ClosePrice types.Decimal,
+	AdjClosePrice types.NullDecimal,
 	Volume int64,
+	Source string,
+	FetchedAt null.Time,
+	Final bool,
 	CreatedAt null.Time,
 	UpdatedAt null.Time,
 ) *StockPrice {
 	do := &StockPrice{
-		ID:         ID,
-		Code:       Code,
-		Date:       Date,
-		OpenPrice:  OpenPrice,
-		HighPrice:  HighPrice,
-		LowPrice:   LowPrice,
-		ClosePrice: ClosePrice,
-		Volume:     Volume,
-		CreatedAt:  CreatedAt,
-		UpdatedAt:  UpdatedAt,
+		ID:            ID,
+		Code:          Code,
+		Date:          Date,
+		OpenPrice:     OpenPrice,
+		HighPrice:     HighPrice,
+		LowPrice:      LowPrice,
+		ClosePrice:    ClosePrice,
+		AdjClosePrice: AdjClosePrice,
+		Volume:        Volume,
+		Source:        Source,
+		FetchedAt:     FetchedAt,
+		Final:         Final,
+		CreatedAt:     CreatedAt,
+		UpdatedAt:     UpdatedAt,
 	}
 	return do
 }
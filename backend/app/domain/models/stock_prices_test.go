@@ -0,0 +1,125 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/ericlagergren/decimal"
+)
+
+func TestStockPrice_Adjusted(t *testing.T) {
+	dec := func(v float64) types.Decimal {
+		return types.NewDecimal(new(decimal.Big).SetFloat64(v))
+	}
+	price := func(v types.Decimal) float64 {
+		f, _ := v.Big.Float64()
+		return f
+	}
+
+	tests := []struct {
+		name       string
+		adjClose   types.NullDecimal
+		wantFactor float64
+		wantClose  float64
+		wantOpen   float64
+		wantVolume int64
+	}{
+		{
+			name:       "Before a 1:5 split",
+			adjClose:   types.NewNullDecimal(new(decimal.Big).SetFloat64(1000)),
+			wantFactor: 0.2,
+			wantClose:  1000,
+			wantOpen:   980,
+			wantVolume: 50000,
+		},
+		{
+			name:       "No adjusted close stored",
+			adjClose:   types.NullDecimal{},
+			wantFactor: 1,
+			wantClose:  5000,
+			wantOpen:   4900,
+			wantVolume: 10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &StockPrice{
+				Code:          "7203",
+				OpenPrice:     dec(4900),
+				HighPrice:     dec(5100),
+				LowPrice:      dec(4800),
+				ClosePrice:    dec(5000),
+				AdjClosePrice: tt.adjClose,
+				Volume:        10000,
+			}
+
+			if got := p.AdjustmentFactor(); math.Abs(got-tt.wantFactor) > 1e-9 {
+				t.Errorf("AdjustmentFactor() = %v, want %v", got, tt.wantFactor)
+			}
+
+			adjusted := p.Adjusted()
+			if got := price(adjusted.ClosePrice); got != tt.wantClose {
+				t.Errorf("Adjusted() close = %v, want %v", got, tt.wantClose)
+			}
+			if got := price(adjusted.OpenPrice); math.Abs(got-tt.wantOpen) > 1e-6 {
+				t.Errorf("Adjusted() open = %v, want %v", got, tt.wantOpen)
+			}
+			if adjusted.Volume != tt.wantVolume {
+				t.Errorf("Adjusted() volume = %d, want %d", adjusted.Volume, tt.wantVolume)
+			}
+			if got := price(p.ClosePrice); got != 5000 {
+				t.Errorf("Adjusted() should not modify the actual price, got close %v", got)
+			}
+		})
+	}
+}
+
+func TestStockPrice_SameQuote(t *testing.T) {
+	dec := func(v float64) types.Decimal {
+		return types.NewDecimal(new(decimal.Big).SetFloat64(v))
+	}
+	base := func() *StockPrice {
+		return &StockPrice{
+			Code:          "7203",
+			Date:          time.Date(2024, 6, 5, 0, 0, 0, 0, time.Local),
+			OpenPrice:     dec(3530),
+			HighPrice:     dec(3600),
+			LowPrice:      dec(3510),
+			ClosePrice:    dec(3590),
+			AdjClosePrice: types.NewNullDecimal(new(decimal.Big).SetFloat64(3590)),
+			Volume:        9876500,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *StockPrice)
+		want   bool
+	}{
+		{name: "Unchanged", modify: func(p *StockPrice) {}, want: true},
+		{name: "Difference below the stored precision", modify: func(p *StockPrice) { p.ClosePrice = dec(3590.001) }, want: true},
+		{name: "Close changed", modify: func(p *StockPrice) { p.ClosePrice = dec(3591) }, want: false},
+		{name: "Volume changed", modify: func(p *StockPrice) { p.Volume++ }, want: false},
+		{name: "Adjusted close not fetched", modify: func(p *StockPrice) { p.AdjClosePrice = types.NullDecimal{} }, want: true},
+		{name: "Adjusted close changed", modify: func(p *StockPrice) { p.AdjClosePrice = types.NewNullDecimal(new(decimal.Big).SetFloat64(1795)) }, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := base()
+			fetched := base()
+			fetched.Date = time.Date(2024, 6, 5, 14, 30, 0, 0, time.Local)
+			tt.modify(fetched)
+
+			if !fetched.SameTradingDay(stored) {
+				t.Error("SameTradingDay() = false, want true for the same date")
+			}
+			if got := fetched.SameQuote(stored); got != tt.want {
+				t.Errorf("SameQuote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
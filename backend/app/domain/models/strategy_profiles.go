@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/aarondl/null/v8"
+)
+
+// StrategyProfile is a named set of technical indicator parameters.
+type StrategyProfile struct {
+	ID          string
+	Name        string      // プロファイル名
+	Description null.String // 説明
+	Parameters  string      // テクニカル指標パラメータ(JSON)
+	CreatedAt   null.Time   // 作成日時
+	UpdatedAt   null.Time   // 更新日時
+}
+
+// Validate validates strategy profile data
+func (s *StrategyProfile) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("プロファイル名は必須です")
+	}
+
+	if s.Parameters == "" {
+		return fmt.Errorf("パラメータは必須です")
+	}
+
+	return nil
+}
+
+func NewStrategyProfile(
+	ID string,
+	Name string,
+	Description null.String,
+	Parameters string,
+	CreatedAt null.Time,
+	UpdatedAt null.Time,
+) *StrategyProfile {
+	do := &StrategyProfile{
+		ID:          ID,
+		Name:        Name,
+		Description: Description,
+		Parameters:  Parameters,
+		CreatedAt:   CreatedAt,
+		UpdatedAt:   UpdatedAt,
+	}
+	return do
+}
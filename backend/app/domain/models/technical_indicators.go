@@ -10,7 +10,7 @@ import (
 	"github.com/aarondl/sqlboiler/v4/types"
 )
 
-//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Date,Rsi14,Macd,MacdSignal,MacdHistogram,Sma5,Sma25,Sma75,CreatedAt,UpdatedAt, TechnicalIndicator
+//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Date,Rsi14,Macd,MacdSignal,MacdHistogram,Sma5,Sma25,Sma75,Ma25Deviation,CreatedAt,UpdatedAt, TechnicalIndicator
 
 // You can edit this as you like.
 
@@ -28,6 +28,7 @@ type TechnicalIndicator struct {
 	Sma5          types.NullDecimal // 5日移動平均
 	Sma25         types.NullDecimal // 25日移動平均
 	Sma75         types.NullDecimal // 75日移動平均
+	Ma25Deviation types.NullDecimal // 25日移動平均乖離率(%)
 	CreatedAt     null.Time         // 作成日時
 	UpdatedAt     null.Time         // 更新日時
 }
@@ -43,6 +44,7 @@ func NewTechnicalIndicator(
 	Sma5 types.NullDecimal,
 	Sma25 types.NullDecimal,
 	Sma75 types.NullDecimal,
+	Ma25Deviation types.NullDecimal,
 	CreatedAt null.Time,
 	UpdatedAt null.Time,
 ) *TechnicalIndicator {
@@ -57,6 +59,7 @@ func NewTechnicalIndicator(
 		Sma5:          Sma5,
 		Sma25:         Sma25,
 		Sma75:         Sma75,
+		Ma25Deviation: Ma25Deviation,
 		CreatedAt:     CreatedAt,
 		UpdatedAt:     UpdatedAt,
 	}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Kinds of trade notes.
+const (
+	TradeNoteKindHypothesis = "hypothesis" // 投資仮説
+	TradeNoteKindEntry      = "entry"      // エントリー理由
+	TradeNoteKindReview     = "review"     // 振り返り
+)
+
+// MaxTradeNoteLength is the maximum number of characters of the body of a trade note.
+const MaxTradeNoteLength = 2000
+
+// TradeNote is a note on the idea of a trade of a stock: the hypothesis, the reason of an entry or
+// a review afterwards. Source refers to the signal or report the note was written from.
+type TradeNote struct {
+	ID        string
+	Code      string    // 銘柄コード
+	Kind      string    // 種別(hypothesis/entry/review)
+	Body      string    // 本文
+	Source    string    // 参照元(signal:<ルール名>、report:<日付>、空なら手動)
+	CreatedAt time.Time // 作成日時
+	UpdatedAt time.Time // 更新日時
+}
+
+// Validate checks the note.
+func (n *TradeNote) Validate() error {
+	if n.Code == "" {
+		return fmt.Errorf("銘柄コードを指定してください")
+	}
+	switch n.Kind {
+	case TradeNoteKindHypothesis, TradeNoteKindEntry, TradeNoteKindReview:
+	default:
+		return fmt.Errorf("ノートの種別は %s、%s、%s のいずれかを指定してください: %s",
+			TradeNoteKindHypothesis, TradeNoteKindEntry, TradeNoteKindReview, n.Kind)
+	}
+	if strings.TrimSpace(n.Body) == "" {
+		return fmt.Errorf("ノートの本文を指定してください")
+	}
+	if utf8.RuneCountInString(n.Body) > MaxTradeNoteLength {
+		return fmt.Errorf("ノートの本文は%d文字以内で指定してください", MaxTradeNoteLength)
+	}
+	return nil
+}
@@ -4,11 +4,15 @@
 package models
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/boost-jp/stock-automation/app/utility"
 )
 
-//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Name,TargetBuyPrice,TargetSellPrice,IsActive,CreatedAt,UpdatedAt, WatchList
+//go:generate go run  ../../../cmd/generator/repoinit --fields=ID,Code,Name,TargetBuyPrice,TargetSellPrice,IsActive,CollectIntervalMinutes,MutedUntil,CreatedAt,UpdatedAt,DeletedAt, WatchList
 
 // You can edit this as you like.
 
@@ -16,14 +20,50 @@ import (
 // Set the "validate" tags as needed.
 // https://pkg.go.dev/gopkg.in/go-playground/validator.v10
 type WatchList struct {
-	ID              string
-	Code            string            // 銘柄コード
-	Name            string            // 銘柄名
-	TargetBuyPrice  types.NullDecimal // 目標買い価格
-	TargetSellPrice types.NullDecimal // 目標売り価格
-	IsActive        null.Bool         // アクティブフラグ
-	CreatedAt       null.Time         // 作成日時
-	UpdatedAt       null.Time         // 更新日時
+	ID                     string
+	Code                   string            // 銘柄コード
+	Name                   string            // 銘柄名
+	TargetBuyPrice         types.NullDecimal // 目標買い価格
+	TargetSellPrice        types.NullDecimal // 目標売り価格
+	IsActive               null.Bool         // アクティブフラグ
+	CollectIntervalMinutes int               // 収集間隔(分、0は既定の間隔)
+	MutedUntil             null.Time         // 通知ミュート期限
+	CreatedAt              null.Time         // 作成日時
+	UpdatedAt              null.Time         // 更新日時
+	DeletedAt              null.Time         // 削除日時(論理削除)
+}
+
+// Validate validates watch list data
+func (w *WatchList) Validate() error {
+	if w.Code == "" {
+		return fmt.Errorf("銘柄コードは必須です")
+	}
+
+	if w.Name == "" {
+		return fmt.Errorf("銘柄名は必須です")
+	}
+
+	buy := utility.NullDecimalToFloat(w.TargetBuyPrice)
+	sell := utility.NullDecimalToFloat(w.TargetSellPrice)
+
+	if buy < 0 || sell < 0 {
+		return fmt.Errorf("目標価格は0以上である必要があります")
+	}
+
+	if buy > 0 && sell > 0 && buy >= sell {
+		return fmt.Errorf("目標買い価格は目標売り価格より低い必要があります")
+	}
+
+	if w.CollectIntervalMinutes < 0 {
+		return fmt.Errorf("収集間隔は0以上である必要があります")
+	}
+
+	return nil
+}
+
+// IsMuted reports whether the alerts of the stock are muted at the given time.
+func (w *WatchList) IsMuted(at time.Time) bool {
+	return w.MutedUntil.Valid && at.Before(w.MutedUntil.Time)
 }
 
 func NewWatchList(
@@ -33,18 +73,24 @@ func NewWatchList(
 	TargetBuyPrice types.NullDecimal,
 	TargetSellPrice types.NullDecimal,
 	IsActive null.Bool,
+	CollectIntervalMinutes int,
+	MutedUntil null.Time,
 	CreatedAt null.Time,
 	UpdatedAt null.Time,
+	DeletedAt null.Time,
 ) *WatchList {
 	do := &WatchList{
-		ID:              ID,
-		Code:            Code,
-		Name:            Name,
-		TargetBuyPrice:  TargetBuyPrice,
-		TargetSellPrice: TargetSellPrice,
-		IsActive:        IsActive,
-		CreatedAt:       CreatedAt,
-		UpdatedAt:       UpdatedAt,
+		ID:                     ID,
+		Code:                   Code,
+		Name:                   Name,
+		TargetBuyPrice:         TargetBuyPrice,
+		TargetSellPrice:        TargetSellPrice,
+		IsActive:               IsActive,
+		CollectIntervalMinutes: CollectIntervalMinutes,
+		MutedUntil:             MutedUntil,
+		CreatedAt:              CreatedAt,
+		UpdatedAt:              UpdatedAt,
+		DeletedAt:              DeletedAt,
 	}
 	return do
 }
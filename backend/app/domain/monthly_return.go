@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// MonthlyReturnMonths is the number of months shown in the monthly return calendar.
+const MonthlyReturnMonths = 12
+
+// Heatmap thresholds of a monthly return in percent.
+const (
+	monthlyReturnStrongGain = 3.0
+	monthlyReturnStrongLoss = -3.0
+)
+
+// MonthlyReturn represents the portfolio return of a calendar month.
+type MonthlyReturn struct {
+	Year       int
+	Month      time.Month
+	StartValue float64 // value at the end of the previous month, or on the first snapshot of the month
+	EndValue   float64 // value on the last snapshot of the month
+	NetFlow    float64 // change of the cost, treated as money added (positive) or withdrawn (negative)
+	Return     float64 // percent
+}
+
+// MonthlyReturnSummary represents the monthly returns of the last months and the year-to-date return.
+type MonthlyReturnSummary struct {
+	Months    []MonthlyReturn // months with snapshots, oldest first
+	YTDReturn float64         // percent, chain-linked from the monthly returns of the year
+	HasYTD    bool
+	AsOf      time.Time
+}
+
+// CalculateMonthlyReturns calculates the returns of the last months calendar months up to the month of asOf
+// from daily portfolio snapshots, and the year-to-date return of the year of asOf.
+// Buying and selling change the value without being a return, so the change of the cost in a month is
+// treated as money added at mid-month (Modified Dietz method). Months without two snapshots are omitted.
+func CalculateMonthlyReturns(snapshots []*models.PortfolioSnapshot, asOf time.Time, months int) MonthlyReturnSummary {
+	sorted := make([]*models.PortfolioSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if !snapshot.SnapshotDate.After(asOf) {
+			sorted = append(sorted, snapshot)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].SnapshotDate.Before(sorted[j].SnapshotDate)
+	})
+
+	summary := MonthlyReturnSummary{AsOf: asOf}
+	first := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, asOf.Location()).AddDate(0, -(months - 1), 0)
+	growth := 1.0
+	for i := 0; i < months; i++ {
+		monthStart := first.AddDate(0, i, 0)
+		monthEnd := monthStart.AddDate(0, 1, 0)
+
+		var start, end *models.PortfolioSnapshot
+		for _, snapshot := range sorted {
+			switch {
+			case snapshot.SnapshotDate.Before(monthStart):
+				start = snapshot
+			case snapshot.SnapshotDate.Before(monthEnd):
+				if start == nil {
+					start = snapshot
+				}
+				end = snapshot
+			}
+		}
+		if start == nil || end == nil || start == end {
+			continue
+		}
+
+		netFlow := end.TotalCost - start.TotalCost
+		base := start.TotalValue + netFlow/2
+		if base <= 0 {
+			continue
+		}
+		monthly := MonthlyReturn{
+			Year:       monthStart.Year(),
+			Month:      monthStart.Month(),
+			StartValue: start.TotalValue,
+			EndValue:   end.TotalValue,
+			NetFlow:    netFlow,
+			Return:     (end.TotalValue - start.TotalValue - netFlow) / base * 100,
+		}
+		summary.Months = append(summary.Months, monthly)
+
+		if monthly.Year == asOf.Year() {
+			growth *= 1 + monthly.Return/100
+			summary.HasYTD = true
+		}
+	}
+	if summary.HasYTD {
+		summary.YTDReturn = (growth - 1) * 100
+	}
+
+	return summary
+}
+
+// monthlyReturnMark returns the heatmap cell of a monthly return.
+func monthlyReturnMark(r float64) string {
+	switch {
+	case r >= monthlyReturnStrongGain:
+		return "🟩"
+	case r >= 0:
+		return "🟢"
+	case r > monthlyReturnStrongLoss:
+		return "🔴"
+	default:
+		return "🟥"
+	}
+}
+
+// FormatMonthlyReturnSection formats the monthly return calendar as a heatmap with a row per year,
+// followed by the list of monthly returns and the year-to-date return.
+func FormatMonthlyReturnSection(summary MonthlyReturnSummary) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("monthly_return.title") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+
+	if len(summary.Months) == 0 {
+		b.WriteString(i18n.T("monthly_return.no_data") + "\n")
+		return b.String()
+	}
+
+	byMonth := make(map[string]float64, len(summary.Months))
+	for _, m := range summary.Months {
+		byMonth[fmt.Sprintf("%04d-%02d", m.Year, int(m.Month))] = m.Return
+	}
+
+	b.WriteString("      ")
+	for month := 1; month <= 12; month++ {
+		fmt.Fprintf(&b, "%-2d", month)
+	}
+	b.WriteString("\n")
+	for year := summary.Months[0].Year; year <= summary.Months[len(summary.Months)-1].Year; year++ {
+		fmt.Fprintf(&b, "%d  ", year)
+		for month := 1; month <= 12; month++ {
+			if r, ok := byMonth[fmt.Sprintf("%04d-%02d", year, month)]; ok {
+				b.WriteString(monthlyReturnMark(r))
+			} else {
+				b.WriteString("⬜")
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(i18n.T("monthly_return.legend") + "\n\n")
+
+	for _, m := range summary.Months {
+		b.WriteString(i18n.T("monthly_return.line", monthlyReturnMark(m.Return), m.Year, int(m.Month), m.Return) + "\n")
+	}
+	if summary.HasYTD {
+		b.WriteString("\n" + i18n.T("monthly_return.ytd", summary.AsOf.Year(), summary.YTDReturn) + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCalculateMonthlyReturns(t *testing.T) {
+	snapshot := func(year int, month time.Month, day int, value, cost float64) *models.PortfolioSnapshot {
+		return models.NewPortfolioSnapshot(time.Date(year, month, day, 0, 0, 0, 0, time.UTC), value, cost, 1)
+	}
+	asOf := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	snapshots := []*models.PortfolioSnapshot{
+		snapshot(2024, 1, 31, 1100000, 1000000),
+		snapshot(2023, 12, 29, 1000000, 1000000),
+		snapshot(2024, 2, 15, 1000000, 1000000),
+		// 500,000 added in February
+		snapshot(2024, 2, 29, 1540000, 1500000),
+		snapshot(2024, 3, 14, 1463000, 1500000),
+		// After asOf
+		snapshot(2024, 3, 20, 2000000, 1500000),
+	}
+
+	got := CalculateMonthlyReturns(snapshots, asOf, 4)
+	want := MonthlyReturnSummary{
+		Months: []MonthlyReturn{
+			{Year: 2024, Month: time.January, StartValue: 1000000, EndValue: 1100000, Return: 10},
+			{Year: 2024, Month: time.February, StartValue: 1100000, EndValue: 1540000, NetFlow: 500000, Return: -60000.0 / 1350000 * 100},
+			{Year: 2024, Month: time.March, StartValue: 1540000, EndValue: 1463000, Return: -5},
+		},
+		YTDReturn: (1.1*(1-60000.0/1350000)*0.95 - 1) * 100,
+		HasYTD:    true,
+		AsOf:      asOf,
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("CalculateMonthlyReturns() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Months before the first snapshot and those of the previous year are not in the YTD return
+	got = CalculateMonthlyReturns([]*models.PortfolioSnapshot{
+		snapshot(2023, 12, 1, 1000000, 1000000),
+		snapshot(2023, 12, 29, 1200000, 1000000),
+	}, asOf, 12)
+	if len(got.Months) != 1 || got.Months[0].Month != time.December || math.Abs(got.Months[0].Return-20) > 1e-9 {
+		t.Errorf("Months = %+v, want December +20%%", got.Months)
+	}
+	if got.HasYTD {
+		t.Errorf("HasYTD = true without snapshots of the year")
+	}
+}
+
+func TestFormatMonthlyReturnSection(t *testing.T) {
+	summary := MonthlyReturnSummary{
+		Months: []MonthlyReturn{
+			{Year: 2023, Month: time.December, Return: 4.2},
+			{Year: 2024, Month: time.January, Return: -1.5},
+			{Year: 2024, Month: time.February, Return: -3.5},
+		},
+		YTDReturn: -4.95,
+		HasYTD:    true,
+		AsOf:      time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+	}
+
+	got := FormatMonthlyReturnSection(summary)
+	for _, want := range []string{
+		"2023  ⬜⬜⬜⬜⬜⬜⬜⬜⬜⬜⬜🟩\n",
+		"2024  🔴🟥⬜⬜⬜⬜⬜⬜⬜⬜⬜⬜\n",
+		"🟥 2024年2月: -3.50%",
+		"年初来リターン (2024年): -4.95%",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatMonthlyReturnSection() missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// HoldingClose is the close of a held stock on the last trading day and its change from the close before.
+type HoldingClose struct {
+	Code          string
+	Name          string
+	Close         float64
+	ChangePercent float64
+	HasPrevious   bool // false if no previous close is stored, so there is no change
+}
+
+// NewHoldingClose returns the close of a held stock. A previous close of zero or less means there is none.
+func NewHoldingClose(code, name string, close, previousClose float64) HoldingClose {
+	holding := HoldingClose{Code: code, Name: name, Close: close}
+	if previousClose > 0 {
+		holding.HasPrevious = true
+		holding.ChangePercent = (close/previousClose - 1) * 100
+	}
+	return holding
+}
+
+// MorningBriefing is the summary sent before the market opens: the closes of the market indices
+// overnight, the closes of the holdings on the last trading day and the earnings announced today.
+type MorningBriefing struct {
+	Date     time.Time
+	Indices  []MarketIndexQuote
+	Holdings []HoldingClose
+	Earnings []CorporateEvent
+	// EarningsUnavailable is set when the earnings schedule cannot be read, e.g. without J-Quants
+	EarningsUnavailable bool
+}
+
+// TodaysEarnings returns the earnings announcements on the day of today in code order.
+func TodaysEarnings(events []CorporateEvent, today time.Time) []CorporateEvent {
+	year, month, day := today.Date()
+	var earnings []CorporateEvent
+	for _, event := range UpcomingCorporateEvents(events, today, today.AddDate(0, 0, 1)) {
+		y, m, d := event.Date.Date()
+		if event.Type == CorporateEventEarnings && y == year && m == month && d == day {
+			earnings = append(earnings, event)
+		}
+	}
+	sort.SliceStable(earnings, func(i, j int) bool { return earnings[i].Code < earnings[j].Code })
+	return earnings
+}
+
+// FormatMorningBriefing formats the briefing sent before the market opens.
+func FormatMorningBriefing(briefing MorningBriefing) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("briefing.title", briefing.Date.Format("2006-01-02")) + "\n\n")
+
+	b.WriteString(i18n.T("briefing.market_section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	if len(briefing.Indices) == 0 {
+		b.WriteString(i18n.T("briefing.no_indices") + "\n")
+	}
+	for _, quote := range briefing.Indices {
+		if quote.HasPrevious {
+			b.WriteString(i18n.T("market.index_change", quote.Name, formatIndexValue(quote.Close), quote.ChangePercent) + "\n")
+		} else {
+			b.WriteString(i18n.T("market.index", quote.Name, formatIndexValue(quote.Close)) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + i18n.T("briefing.holdings_section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	if len(briefing.Holdings) == 0 {
+		b.WriteString(i18n.T("briefing.no_holdings") + "\n")
+	}
+	for _, holding := range briefing.Holdings {
+		if holding.HasPrevious {
+			b.WriteString(i18n.T("briefing.holding_change", holding.Name, holding.Code, formatCurrency(holding.Close), holding.ChangePercent) + "\n")
+		} else {
+			b.WriteString(i18n.T("briefing.holding", holding.Name, holding.Code, formatCurrency(holding.Close)) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + i18n.T("briefing.earnings_section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	switch {
+	case briefing.EarningsUnavailable:
+		b.WriteString(i18n.T("briefing.earnings_unavailable") + "\n")
+	case len(briefing.Earnings) == 0:
+		b.WriteString(i18n.T("briefing.no_earnings") + "\n")
+	}
+	for _, event := range briefing.Earnings {
+		b.WriteString("• " + event.Title() + "\n")
+	}
+	return b.String()
+}
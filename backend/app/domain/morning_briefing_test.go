@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTodaysEarnings(t *testing.T) {
+	today := time.Date(2024, 5, 8, 8, 30, 0, 0, time.Local)
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.Local) }
+	events := []CorporateEvent{
+		{Code: "7203", Type: CorporateEventEarnings, Date: day(8), Period: "2024-03 FY"},
+		{Code: "6758", Type: CorporateEventEarnings, Date: day(9), Period: "2024-03 FY"},
+		{Code: "6501", Type: CorporateEventEarnings, Date: day(8), Period: "2024-03 FY"},
+		{Code: "9432", Type: CorporateEventRecordDate, Date: day(8)},
+		{Code: "8306", Type: CorporateEventEarnings, Date: day(7), Period: "2024-03 FY"},
+	}
+
+	got := TodaysEarnings(events, today)
+	if len(got) != 2 || got[0].Code != "6501" || got[1].Code != "7203" {
+		t.Errorf("TodaysEarnings() = %+v, want the earnings of 6501 and 7203 today", got)
+	}
+}
+
+func TestFormatMorningBriefing(t *testing.T) {
+	briefing := MorningBriefing{
+		Date:     time.Date(2024, 5, 8, 8, 30, 0, 0, time.Local),
+		Indices:  []MarketIndexQuote{NewMarketIndexQuote(MarketIndex{Symbol: "^DJI", Name: "NYダウ"}, time.Time{}, 38884.26, 38852.27)},
+		Holdings: []HoldingClose{NewHoldingClose("7203", "トヨタ自動車", 3340, 3400), NewHoldingClose("6758", "ソニーG", 12500, 0)},
+		Earnings: []CorporateEvent{{Code: "7203", Name: "トヨタ自動車", Type: CorporateEventEarnings}},
+	}
+
+	message := FormatMorningBriefing(briefing)
+	for _, want := range []string{
+		"寄り前ブリーフィング (2024-05-08)",
+		"NYダウ: 38,884.26 (+0.08%)",
+		"トヨタ自動車 (7203): ¥3,340 (-1.76%)",
+		"ソニーG (6758): ¥12,500\n",
+		"• トヨタ自動車 (7203) 決算発表",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("FormatMorningBriefing() lacks %q:\n%s", want, message)
+		}
+	}
+
+	message = FormatMorningBriefing(MorningBriefing{Date: briefing.Date, EarningsUnavailable: true})
+	for _, want := range []string{"指数の価格がありません", "保有銘柄の価格がありません", "J-Quants"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("FormatMorningBriefing() of an empty briefing lacks %q:\n%s", want, message)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"math"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// PaperLotSize is the trading unit of Japanese stocks used for paper orders.
+const PaperLotSize = 100
+
+// PaperFillPrice simulates the fill of an order on the first trading day after it was placed.
+// Market orders fill at the open. Limit orders fill at the open if it is at or better than the
+// limit, otherwise at the limit if the day's range reaches it. Returns false if the order is not filled.
+func PaperFillPrice(side string, limitPrice *float64, bar StockPriceData) (float64, bool) {
+	if limitPrice == nil {
+		return bar.Open, true
+	}
+
+	limit := *limitPrice
+	if side == models.OrderSideBuy {
+		if bar.Open <= limit {
+			return bar.Open, true
+		}
+		if bar.Low <= limit {
+			return limit, true
+		}
+		return 0, false
+	}
+
+	if bar.Open >= limit {
+		return bar.Open, true
+	}
+	if bar.High >= limit {
+		return limit, true
+	}
+	return 0, false
+}
+
+// PaperOrderQuantity returns the shares in whole lots that can be bought with the amount.
+func PaperOrderQuantity(amount, price float64) int {
+	if price <= 0 {
+		return 0
+	}
+	lots := int(math.Floor(amount / (price * PaperLotSize)))
+	return lots * PaperLotSize
+}
+
+// PaperPosition represents a paper trading position at its latest price.
+type PaperPosition struct {
+	Code         string  `json:"code"`
+	Quantity     int     `json:"quantity"`
+	AveragePrice float64 `json:"average_price"`
+	CurrentPrice float64 `json:"current_price"`
+	Value        float64 `json:"value"`
+	Gain         float64 `json:"gain"`
+	GainPercent  float64 `json:"gain_percent"`
+}
+
+// NewPaperPosition values a position at the current price.
+func NewPaperPosition(code string, quantity int, averagePrice, currentPrice float64) PaperPosition {
+	position := PaperPosition{
+		Code:         code,
+		Quantity:     quantity,
+		AveragePrice: averagePrice,
+		CurrentPrice: currentPrice,
+		Value:        currentPrice * float64(quantity),
+	}
+	cost := averagePrice * float64(quantity)
+	position.Gain = position.Value - cost
+	if cost > 0 {
+		position.GainPercent = position.Gain / cost * 100
+	}
+	return position
+}
+
+// PaperTradePerformance compares the paper trading account with the real portfolio.
+type PaperTradePerformance struct {
+	InitialCash     float64         `json:"initial_cash"`
+	Cash            float64         `json:"cash"`
+	MarketValue     float64         `json:"market_value"`
+	TotalValue      float64         `json:"total_value"`
+	Gain            float64         `json:"gain"`
+	GainPercent     float64         `json:"gain_percent"`
+	TradeCount      int             `json:"trade_count"`
+	Positions       []PaperPosition `json:"positions"`
+	RealValue       float64         `json:"real_value"`
+	RealGain        float64         `json:"real_gain"`
+	RealGainPercent float64         `json:"real_gain_percent"`
+	Difference      float64         `json:"difference"` // paper gain % minus real gain %
+}
+
+// NewPaperTradePerformance calculates the paper trading return against the initial cash
+// and compares it with the return of the real portfolio.
+func NewPaperTradePerformance(initialCash, cash float64, positions []PaperPosition, tradeCount int, actual *PortfolioSummary) *PaperTradePerformance {
+	performance := &PaperTradePerformance{
+		InitialCash: initialCash,
+		Cash:        cash,
+		TradeCount:  tradeCount,
+		Positions:   positions,
+	}
+
+	for _, position := range positions {
+		performance.MarketValue += position.Value
+	}
+	performance.TotalValue = cash + performance.MarketValue
+	performance.Gain = performance.TotalValue - initialCash
+	if initialCash > 0 {
+		performance.GainPercent = performance.Gain / initialCash * 100
+	}
+
+	if actual != nil {
+		performance.RealValue = actual.TotalValue
+		performance.RealGain = actual.TotalGain
+		performance.RealGainPercent = actual.TotalGainPercent
+	}
+	performance.Difference = performance.GainPercent - performance.RealGainPercent
+
+	return performance
+}
+
+// GeneratePaperTradeReport generates the formatted comparison of paper trading and the real portfolio.
+func GeneratePaperTradeReport(performance *PaperTradePerformance) string {
+	return RenderReportText(BuildPaperTradeReport(performance))
+}
+
+// BuildPaperTradeReport builds the paper trading report as a document for the renderers of the outputs.
+func BuildPaperTradeReport(performance *PaperTradePerformance) *ReportDocument {
+	report := NewReportBuilder(i18n.T("paper_trade.title")).
+		Section("", "").
+		Line(i18n.T("paper_trade.value", performance.TotalValue, performance.Cash, performance.MarketValue)).
+		Line(i18n.T("paper_trade.gain", performance.Gain, performance.GainPercent, performance.InitialCash)).
+		Line(i18n.T("paper_trade.trades", performance.TradeCount))
+
+	if len(performance.Positions) > 0 {
+		report.Blank().Line(i18n.T("paper_trade.positions"))
+		for _, position := range performance.Positions {
+			report.Bullet(i18n.T("paper_trade.position",
+				position.Code, position.Quantity, position.AveragePrice, position.CurrentPrice, position.GainPercent))
+		}
+	}
+
+	result := i18n.T("paper_trade.paper_ahead")
+	if performance.Difference < 0 {
+		result = i18n.T("paper_trade.real_ahead")
+	}
+	return report.Blank().
+		Line(i18n.T("paper_trade.comparison")).
+		Line(i18n.T("paper_trade.real", performance.RealValue, performance.RealGainPercent)).
+		Line(i18n.T("paper_trade.difference", performance.Difference, result)).
+		Document()
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPaperFillPrice(t *testing.T) {
+	bar := StockPriceData{Open: 1000, High: 1050, Low: 960, Close: 1020}
+	limit := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name       string
+		side       string
+		limitPrice *float64
+		wantPrice  float64
+		wantFilled bool
+	}{
+		{name: "market buy at open", side: models.OrderSideBuy, wantPrice: 1000, wantFilled: true},
+		{name: "market sell at open", side: models.OrderSideSell, wantPrice: 1000, wantFilled: true},
+		{name: "buy limit above open fills at open", side: models.OrderSideBuy, limitPrice: limit(1010), wantPrice: 1000, wantFilled: true},
+		{name: "buy limit within range fills at limit", side: models.OrderSideBuy, limitPrice: limit(970), wantPrice: 970, wantFilled: true},
+		{name: "buy limit below low", side: models.OrderSideBuy, limitPrice: limit(950), wantFilled: false},
+		{name: "sell limit below open fills at open", side: models.OrderSideSell, limitPrice: limit(990), wantPrice: 1000, wantFilled: true},
+		{name: "sell limit within range fills at limit", side: models.OrderSideSell, limitPrice: limit(1040), wantPrice: 1040, wantFilled: true},
+		{name: "sell limit above high", side: models.OrderSideSell, limitPrice: limit(1060), wantFilled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, filled := PaperFillPrice(tt.side, tt.limitPrice, bar)
+			if filled != tt.wantFilled || price != tt.wantPrice {
+				t.Errorf("PaperFillPrice() = (%v, %v), want (%v, %v)", price, filled, tt.wantPrice, tt.wantFilled)
+			}
+		})
+	}
+}
+
+func TestPaperOrderQuantity(t *testing.T) {
+	tests := []struct {
+		amount float64
+		price  float64
+		want   int
+	}{
+		{amount: 1000000, price: 2500, want: 400},
+		{amount: 1000000, price: 3000, want: 300},
+		{amount: 100000, price: 2500, want: 0},
+		{amount: 1000000, price: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := PaperOrderQuantity(tt.amount, tt.price); got != tt.want {
+			t.Errorf("PaperOrderQuantity(%v, %v) = %d, want %d", tt.amount, tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestNewPaperTradePerformance(t *testing.T) {
+	positions := []PaperPosition{
+		NewPaperPosition("7203", 100, 2000, 2200),
+		NewPaperPosition("6758", 100, 3000, 2700),
+	}
+	actual := &PortfolioSummary{TotalValue: 1100000, TotalGain: 100000, TotalGainPercent: 10}
+
+	got := NewPaperTradePerformance(1000000, 520000, positions, 3, actual)
+
+	want := &PaperTradePerformance{
+		InitialCash: 1000000,
+		Cash:        520000,
+		MarketValue: 490000,
+		TotalValue:  1010000,
+		Gain:        10000,
+		GainPercent: 1,
+		TradeCount:  3,
+		Positions: []PaperPosition{
+			{Code: "7203", Quantity: 100, AveragePrice: 2000, CurrentPrice: 2200, Value: 220000, Gain: 20000, GainPercent: 10},
+			{Code: "6758", Quantity: 100, AveragePrice: 3000, CurrentPrice: 2700, Value: 270000, Gain: -30000, GainPercent: -10},
+		},
+		RealValue:       1100000,
+		RealGain:        100000,
+		RealGainPercent: 10,
+		Difference:      -9,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewPaperTradePerformance() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// HistoryPeriod is the period of a portfolio history.
+type HistoryPeriod string
+
+const (
+	HistoryPeriod1M HistoryPeriod = "1M"
+	HistoryPeriod3M HistoryPeriod = "3M"
+	HistoryPeriod1Y HistoryPeriod = "1Y"
+)
+
+// ParseHistoryPeriod parses a history period (1M, 3M or 1Y).
+func ParseHistoryPeriod(s string) (HistoryPeriod, error) {
+	switch period := HistoryPeriod(strings.ToUpper(s)); period {
+	case HistoryPeriod1M, HistoryPeriod3M, HistoryPeriod1Y:
+		return period, nil
+	default:
+		return "", fmt.Errorf("期間は1M, 3M, 1Yのいずれかを指定してください: %s", s)
+	}
+}
+
+// StartDate returns the first day of the period ending on end.
+func (p HistoryPeriod) StartDate(end time.Time) time.Time {
+	end = models.TruncateToDate(end)
+	switch p {
+	case HistoryPeriod3M:
+		return end.AddDate(0, -3, 0)
+	case HistoryPeriod1Y:
+		return end.AddDate(-1, 0, 0)
+	default:
+		return end.AddDate(0, -1, 0)
+	}
+}
+
+// PortfolioHistoryPoint represents the portfolio valuation on a day.
+type PortfolioHistoryPoint struct {
+	Date         time.Time `json:"date"`
+	TotalValue   float64   `json:"total_value"`
+	TotalCost    float64   `json:"total_cost"`
+	TotalGain    float64   `json:"total_gain"`
+	GainPercent  float64   `json:"gain_percent"`
+	Interpolated bool      `json:"interpolated"` // true if carried over from the previous snapshot
+}
+
+// FillPortfolioHistory builds a daily history from from to to (inclusive).
+// Days without a snapshot carry over the previous snapshot; previous is the latest snapshot
+// before from and may be nil. Days before the first available snapshot are omitted.
+func FillPortfolioHistory(snapshots []*models.PortfolioSnapshot, previous *models.PortfolioSnapshot, from, to time.Time) []PortfolioHistoryPoint {
+	byDate := make(map[string]*models.PortfolioSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		byDate[snapshot.DateKey()] = snapshot
+	}
+
+	points := []PortfolioHistoryPoint{}
+	last := previous
+	for date := models.TruncateToDate(from); !date.After(to); date = date.AddDate(0, 0, 1) {
+		snapshot, ok := byDate[date.Format(models.SnapshotDateFormat)]
+		if ok {
+			last = snapshot
+		} else if last == nil {
+			continue
+		}
+
+		points = append(points, PortfolioHistoryPoint{
+			Date:         date,
+			TotalValue:   last.TotalValue,
+			TotalCost:    last.TotalCost,
+			TotalGain:    last.TotalGain,
+			GainPercent:  last.GainPercent(),
+			Interpolated: !ok,
+		})
+	}
+
+	return points
+}
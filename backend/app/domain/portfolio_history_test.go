@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseHistoryPeriod(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    HistoryPeriod
+		wantErr bool
+	}{
+		{input: "1M", want: HistoryPeriod1M},
+		{input: "3m", want: HistoryPeriod3M},
+		{input: "1Y", want: HistoryPeriod1Y},
+		{input: "6M", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseHistoryPeriod(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHistoryPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseHistoryPeriod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryPeriod_StartDate(t *testing.T) {
+	end := time.Date(2024, 3, 31, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period HistoryPeriod
+		want   time.Time
+	}{
+		{period: HistoryPeriod1M, want: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{period: HistoryPeriod3M, want: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{period: HistoryPeriod1Y, want: time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.period), func(t *testing.T) {
+			if got := tt.period.StartDate(end); !got.Equal(tt.want) {
+				t.Errorf("StartDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillPortfolioHistory(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	snapshot := func(d int, value float64) *models.PortfolioSnapshot {
+		return models.NewPortfolioSnapshot(day(d), value, 1000, 1)
+	}
+	point := func(d int, value float64, interpolated bool) PortfolioHistoryPoint {
+		return PortfolioHistoryPoint{
+			Date:         day(d),
+			TotalValue:   value,
+			TotalCost:    1000,
+			TotalGain:    value - 1000,
+			GainPercent:  (value - 1000) / 10,
+			Interpolated: interpolated,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		snapshots []*models.PortfolioSnapshot
+		previous  *models.PortfolioSnapshot
+		want      []PortfolioHistoryPoint
+	}{
+		{
+			name:      "Fill missing days with previous value",
+			snapshots: []*models.PortfolioSnapshot{snapshot(1, 1100), snapshot(4, 1200)},
+			want: []PortfolioHistoryPoint{
+				point(1, 1100, false),
+				point(2, 1100, true),
+				point(3, 1100, true),
+				point(4, 1200, false),
+				point(5, 1200, true),
+			},
+		},
+		{
+			name:      "Carry over snapshot before the period",
+			snapshots: []*models.PortfolioSnapshot{snapshot(3, 900)},
+			previous:  models.NewPortfolioSnapshot(day(0), 1050, 1000, 1),
+			want: []PortfolioHistoryPoint{
+				point(1, 1050, true),
+				point(2, 1050, true),
+				point(3, 900, false),
+				point(4, 900, true),
+				point(5, 900, true),
+			},
+		},
+		{
+			name:      "Omit days before the first snapshot",
+			snapshots: []*models.PortfolioSnapshot{snapshot(4, 1000)},
+			want: []PortfolioHistoryPoint{
+				point(4, 1000, false),
+				point(5, 1000, true),
+			},
+		},
+		{
+			name: "No snapshots",
+			want: []PortfolioHistoryPoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FillPortfolioHistory(tt.snapshots, tt.previous, day(1), day(5))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FillPortfolioHistory() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// CostMethod is the method of calculating the cost of sold shares.
+type CostMethod string
+
+const (
+	// CostMethodFIFO charges the purchase price of the oldest lots first.
+	CostMethodFIFO CostMethod = "fifo"
+	// CostMethodAverage charges the average purchase price of all lots.
+	CostMethodAverage CostMethod = "average"
+)
+
+// ParseCostMethod parses a cost method (fifo or average).
+func ParseCostMethod(s string) (CostMethod, error) {
+	switch method := CostMethod(strings.ToLower(s)); method {
+	case CostMethodFIFO, CostMethodAverage:
+		return method, nil
+	default:
+		return "", fmt.Errorf("損益計算方法はfifoまたはaverageを指定してください: %s", s)
+	}
+}
+
+// ConsumedLot represents the shares of a lot sold in a sale.
+type ConsumedLot struct {
+	LotID           string    `json:"lot_id"`
+	PurchaseDate    time.Time `json:"purchase_date"`
+	PurchasePrice   float64   `json:"purchase_price"`
+	Shares          int       `json:"shares"`
+	RemainingShares int       `json:"remaining_shares"`
+	CostBasis       float64   `json:"cost_basis"`
+	RealizedGain    float64   `json:"realized_gain"`
+}
+
+// SaleResult represents the realized profit/loss of a sale and the lots it consumed.
+type SaleResult struct {
+	Code                string                 `json:"code"`
+	Method              CostMethod             `json:"method"`
+	Shares              int                    `json:"shares"`
+	SellPrice           float64                `json:"sell_price"`
+	Proceeds            float64                `json:"proceeds"`
+	CostBasis           float64                `json:"cost_basis"`
+	RealizedGain        float64                `json:"realized_gain"`
+	RealizedGainPercent float64                `json:"realized_gain_percent"`
+	ConsumedLots        []ConsumedLot          `json:"consumed_lots"`
+	RemainingLots       []*models.PortfolioLot `json:"-"`
+}
+
+// SortLots sorts lots from the oldest purchase, in the order they are sold.
+func (s *PortfolioService) SortLots(lots []*models.PortfolioLot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		if !lots[i].PurchaseDate.Equal(lots[j].PurchaseDate) {
+			return lots[i].PurchaseDate.Before(lots[j].PurchaseDate)
+		}
+		return lots[i].ID < lots[j].ID
+	})
+}
+
+// CalculateSale calculates the sale of shares from the lots at sellPrice.
+// Shares are always taken from the oldest lots; the method only decides the cost charged for them:
+// the purchase price of each lot for FIFO, or the average cost price of all lots for the average method.
+// With the average method the remaining lots keep their purchase price and carry the average as
+// AveragePrice, so that a later average sale charges the same average while a FIFO sale still charges
+// the purchase price of each lot. A FIFO sale clears AveragePrice of the remaining lots.
+// The lots are not modified; the remaining lots are returned as copies.
+func (s *PortfolioService) CalculateSale(lots []*models.PortfolioLot, shares int, sellPrice float64, method CostMethod) (*SaleResult, error) {
+	if shares <= 0 {
+		return nil, fmt.Errorf("売却株数は1以上である必要があります")
+	}
+	if sellPrice <= 0 {
+		return nil, fmt.Errorf("売却価格は0より大きい必要があります")
+	}
+	if _, err := ParseCostMethod(string(method)); err != nil {
+		return nil, err
+	}
+
+	sorted := append([]*models.PortfolioLot(nil), lots...)
+	s.SortLots(sorted)
+
+	totalShares, totalCost := 0, 0.0
+	for _, lot := range sorted {
+		totalShares += lot.Shares
+		totalCost += lot.CalculateCost()
+	}
+	if shares > totalShares {
+		return nil, fmt.Errorf("売却株数が保有株数を超えています: %d > %d", shares, totalShares)
+	}
+	averagePrice := totalCost / float64(totalShares)
+
+	result := &SaleResult{
+		Method:    method,
+		Shares:    shares,
+		SellPrice: sellPrice,
+		Proceeds:  float64(shares) * sellPrice,
+	}
+
+	remainingLot := func(lot *models.PortfolioLot, shares int) {
+		copied := *lot
+		copied.Shares = shares
+		copied.AveragePrice = types.NullDecimal{}
+		if method == CostMethodAverage {
+			copied.AveragePrice = utility.FloatToNullDecimal(averagePrice)
+		}
+		result.RemainingLots = append(result.RemainingLots, &copied)
+	}
+
+	remaining := shares
+	for _, lot := range sorted {
+		if remaining == 0 {
+			remainingLot(lot, lot.Shares)
+			continue
+		}
+
+		sold := min(remaining, lot.Shares)
+		remaining -= sold
+
+		unitCost := lot.GetPurchasePrice()
+		if method == CostMethodAverage {
+			unitCost = averagePrice
+		}
+		consumed := ConsumedLot{
+			LotID:           lot.ID,
+			PurchaseDate:    lot.PurchaseDate,
+			PurchasePrice:   lot.GetPurchasePrice(),
+			Shares:          sold,
+			RemainingShares: lot.Shares - sold,
+			CostBasis:       float64(sold) * unitCost,
+		}
+		consumed.RealizedGain = float64(sold)*sellPrice - consumed.CostBasis
+		result.ConsumedLots = append(result.ConsumedLots, consumed)
+		result.CostBasis += consumed.CostBasis
+
+		if consumed.RemainingShares > 0 {
+			remainingLot(lot, consumed.RemainingShares)
+		}
+	}
+
+	if len(sorted) > 0 {
+		result.Code = sorted[0].Code
+	}
+	result.RealizedGain = result.Proceeds - result.CostBasis
+	if result.CostBasis > 0 {
+		result.RealizedGainPercent = result.RealizedGain / result.CostBasis * 100
+	}
+
+	return result, nil
+}
+
+// ApplyLots sets the shares, average purchase price and first purchase date of a holding from its lots.
+// The price is averaged over the cost price of the lots, the average price after an average sale.
+func (s *PortfolioService) ApplyLots(holding *models.Portfolio, lots []*models.PortfolioLot) {
+	shares, cost := 0, 0.0
+	var firstPurchase time.Time
+	for _, lot := range lots {
+		shares += lot.Shares
+		cost += lot.CalculateCost()
+		if firstPurchase.IsZero() || lot.PurchaseDate.Before(firstPurchase) {
+			firstPurchase = lot.PurchaseDate
+		}
+	}
+	if shares == 0 {
+		holding.Shares = 0
+		return
+	}
+
+	holding.Shares = shares
+	holding.PurchasePrice = utility.FloatToDecimal(cost / float64(shares))
+	holding.PurchaseDate = firstPurchase
+}
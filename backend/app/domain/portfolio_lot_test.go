@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func createTestLot(id string, shares int, price float64, date time.Time) *models.PortfolioLot {
+	return &models.PortfolioLot{
+		ID:            id,
+		Code:          "7203",
+		Shares:        shares,
+		PurchasePrice: utility.FloatToDecimal(price),
+		PurchaseDate:  date,
+	}
+}
+
+func TestPortfolioService_CalculateSale(t *testing.T) {
+	service := NewPortfolioService()
+	day1 := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	// Lots are passed newest first to check they are sold from the oldest
+	lots := []*models.PortfolioLot{
+		createTestLot("lot2", 100, 1300, day2),
+		createTestLot("lot1", 100, 1000, day1),
+	}
+
+	tests := []struct {
+		name             string
+		shares           int
+		method           CostMethod
+		wantConsumed     []ConsumedLot
+		wantGain         float64
+		wantRemainShares []int
+	}{
+		{
+			name:   "FIFO within the oldest lot",
+			shares: 60,
+			method: CostMethodFIFO,
+			wantConsumed: []ConsumedLot{
+				{LotID: "lot1", PurchaseDate: day1, PurchasePrice: 1000, Shares: 60, RemainingShares: 40, CostBasis: 60000, RealizedGain: 12000},
+			},
+			wantGain:         12000,
+			wantRemainShares: []int{40, 100},
+		},
+		{
+			name:   "FIFO across lots",
+			shares: 150,
+			method: CostMethodFIFO,
+			wantConsumed: []ConsumedLot{
+				{LotID: "lot1", PurchaseDate: day1, PurchasePrice: 1000, Shares: 100, RemainingShares: 0, CostBasis: 100000, RealizedGain: 20000},
+				{LotID: "lot2", PurchaseDate: day2, PurchasePrice: 1300, Shares: 50, RemainingShares: 50, CostBasis: 65000, RealizedGain: -5000},
+			},
+			wantGain:         15000,
+			wantRemainShares: []int{50},
+		},
+		{
+			name:   "Average cost",
+			shares: 150,
+			method: CostMethodAverage,
+			wantConsumed: []ConsumedLot{
+				{LotID: "lot1", PurchaseDate: day1, PurchasePrice: 1000, Shares: 100, RemainingShares: 0, CostBasis: 115000, RealizedGain: 5000},
+				{LotID: "lot2", PurchaseDate: day2, PurchasePrice: 1300, Shares: 50, RemainingShares: 50, CostBasis: 57500, RealizedGain: 2500},
+			},
+			wantGain:         7500,
+			wantRemainShares: []int{50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CalculateSale(lots, tt.shares, 1200, tt.method)
+			if err != nil {
+				t.Fatalf("CalculateSale() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.wantConsumed, result.ConsumedLots); diff != "" {
+				t.Errorf("ConsumedLots mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantGain, result.RealizedGain); diff != "" {
+				t.Errorf("RealizedGain mismatch (-want +got):\n%s", diff)
+			}
+
+			var remainShares []int
+			for _, lot := range result.RemainingLots {
+				remainShares = append(remainShares, lot.Shares)
+			}
+			if diff := cmp.Diff(tt.wantRemainShares, remainShares); diff != "" {
+				t.Errorf("RemainingLots shares mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// The input lots are not modified
+	if lots[0].Shares != 100 || lots[1].Shares != 100 {
+		t.Errorf("CalculateSale() modified the lots")
+	}
+}
+
+func TestPortfolioService_CalculateSale_AverageInSteps(t *testing.T) {
+	service := NewPortfolioService()
+	day1 := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	lots := []*models.PortfolioLot{
+		createTestLot("lot1", 100, 1000, day1),
+		createTestLot("lot2", 100, 2000, day1.AddDate(0, 2, 0)),
+	}
+
+	purchasePrices := map[string]float64{"lot1": 1000, "lot2": 2000}
+
+	// Each step sells from the remaining lots of the previous one, as the lots are saved after a sale
+	var totalCost float64
+	for i, shares := range []int{50, 100, 50} {
+		result, err := service.CalculateSale(lots, shares, 1800, CostMethodAverage)
+		if err != nil {
+			t.Fatalf("CalculateSale() step %d error = %v", i+1, err)
+		}
+		if diff := cmp.Diff(float64(shares)*1500, result.CostBasis); diff != "" {
+			t.Errorf("CostBasis of step %d mismatch (-want +got):\n%s", i+1, diff)
+		}
+		for _, lot := range result.RemainingLots {
+			if lot.GetCostPrice() != 1500 || lot.GetPurchasePrice() != purchasePrices[lot.ID] {
+				t.Errorf("remaining lot %s cost price = %v, purchase price = %v after step %d, want the average 1500 and the purchase price kept",
+					lot.ID, lot.GetCostPrice(), lot.GetPurchasePrice(), i+1)
+			}
+		}
+		totalCost += result.CostBasis
+		lots = result.RemainingLots
+	}
+
+	if diff := cmp.Diff(300000.0, totalCost); diff != "" {
+		t.Errorf("total CostBasis mismatch (-want +got):\n%s", diff)
+	}
+	if len(lots) != 0 {
+		t.Errorf("RemainingLots = %d lots, want all sold", len(lots))
+	}
+}
+
+func TestPortfolioService_CalculateSale_AverageThenFIFO(t *testing.T) {
+	service := NewPortfolioService()
+	day1 := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	lots := []*models.PortfolioLot{
+		createTestLot("lot1", 100, 1000, day1),
+		createTestLot("lot2", 100, 2000, day1.AddDate(0, 2, 0)),
+	}
+
+	average, err := service.CalculateSale(lots, 50, 1800, CostMethodAverage)
+	if err != nil {
+		t.Fatalf("CalculateSale(average) error = %v", err)
+	}
+
+	// Switching back to FIFO charges the purchase price of each lot, not the average
+	fifo, err := service.CalculateSale(average.RemainingLots, 100, 1800, CostMethodFIFO)
+	if err != nil {
+		t.Fatalf("CalculateSale(fifo) error = %v", err)
+	}
+	if diff := cmp.Diff(50*1000.0+50*2000.0, fifo.CostBasis); diff != "" {
+		t.Errorf("CostBasis mismatch (-want +got):\n%s", diff)
+	}
+	for _, lot := range fifo.RemainingLots {
+		if lot.AveragePrice.Big != nil || lot.GetCostPrice() != 2000 {
+			t.Errorf("remaining lot %s cost price = %v, want the purchase price after a FIFO sale", lot.ID, lot.GetCostPrice())
+		}
+	}
+}
+
+func TestPortfolioService_CalculateSale_Invalid(t *testing.T) {
+	service := NewPortfolioService()
+	lots := []*models.PortfolioLot{createTestLot("lot1", 100, 1000, time.Now())}
+
+	if _, err := service.CalculateSale(lots, 101, 1200, CostMethodFIFO); err == nil {
+		t.Error("Expected error when selling more shares than held")
+	}
+	if _, err := service.CalculateSale(lots, 10, 1200, CostMethod("lifo")); err == nil {
+		t.Error("Expected error for an unknown cost method")
+	}
+}
+
+func TestPortfolioService_ApplyLots(t *testing.T) {
+	service := NewPortfolioService()
+	day1 := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	holding := &models.Portfolio{Code: "7203"}
+
+	service.ApplyLots(holding, []*models.PortfolioLot{
+		createTestLot("lot2", 100, 1300, day1.AddDate(0, 1, 0)),
+		createTestLot("lot1", 300, 1000, day1),
+	})
+
+	if diff := cmp.Diff(400, holding.Shares); diff != "" {
+		t.Errorf("Shares mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(1075.0, holding.GetPurchasePrice()); diff != "" {
+		t.Errorf("PurchasePrice mismatch (-want +got):\n%s", diff)
+	}
+	if !holding.PurchaseDate.Equal(day1) {
+		t.Errorf("PurchaseDate = %v, want %v", holding.PurchaseDate, day1)
+	}
+}
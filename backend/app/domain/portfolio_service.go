@@ -3,10 +3,12 @@ package domain
 import (
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
 )
 
 // PortfolioService handles portfolio business logic.
@@ -24,21 +26,49 @@ type PortfolioSummary struct {
 	TotalGain        float64
 	TotalGainPercent float64
 	Holdings         []HoldingSummary
+	Allocations      []AssetAllocation            // value by asset class, in the order of models.AssetClasses
+	Goals            []GoalProgress               // progress of the active goals, set by the daily report
+	Contributions    *ContributionAnalysis        // rankings of the holdings by contribution, set by the daily report
+	Technicals       []TechnicalSummary           // technical views of the listed holdings, set by the daily report
+	BuyingPower      *BuyingPower                 // cash available for purchases, set by the daily report if cash is held
+	HideHoldings     bool                         // leaves the details of the holdings out of the daily report
+	Notes            map[string]*models.TradeNote // newest trade note of each holding by code, set by the daily report
+	Market           *MarketSummary               // closes of the market indices, set by the daily report
 	UpdatedAt        time.Time
 }
 
 // HoldingSummary represents individual holding performance.
 type HoldingSummary struct {
-	Code          string
-	Name          string
-	Shares        int
-	CurrentPrice  float64
-	PurchasePrice float64
-	CurrentValue  float64
-	PurchaseCost  float64
-	Gain          float64
-	GainPercent   float64
-	LastUpdated   time.Time
+	Code           string
+	Name           string
+	PositionType   string
+	AssetClass     string
+	Shares         int
+	CurrentPrice   float64
+	PurchasePrice  float64
+	CurrentValue   float64
+	PurchaseCost   float64
+	Gain           float64
+	GainPercent    float64
+	RequiredMargin float64
+	InterestCost   float64
+	LastUpdated    time.Time
+}
+
+// FormatQuantity returns the quantity held, as a fractional number of coins for a crypto asset.
+func (h HoldingSummary) FormatQuantity() string {
+	if h.AssetClass == models.AssetClassCrypto {
+		return strconv.FormatFloat(float64(h.Shares)/models.CryptoQuantityUnits, 'f', -1, 64)
+	}
+	return strconv.Itoa(h.Shares)
+}
+
+// AssetAllocation is the value of the holdings of an asset class and its share of the portfolio.
+type AssetAllocation struct {
+	AssetClass string
+	Value      float64
+	Percent    float64
+	Holdings   int
 }
 
 // CalculatePortfolioSummary calculates portfolio performance using domain model methods.
@@ -55,6 +85,10 @@ func (s *PortfolioService) CalculatePortfolioSummary(
 
 	for _, holding := range portfolios {
 		currentPrice, exists := currentPrices[holding.Code]
+		if holding.IsCash() {
+			// A cash balance is held as shares of one yen
+			currentPrice, exists = 1, true
+		}
 		if !exists {
 			continue // Skip if no current price available
 		}
@@ -65,17 +99,26 @@ func (s *PortfolioService) CalculatePortfolioSummary(
 		gain := holding.CalculateGain(currentPrice)
 		gainPercent := holding.CalculateGainPercent(currentPrice)
 
+		positionType := models.PositionTypeLong
+		if holding.IsShort() {
+			positionType = models.PositionTypeShort
+		}
+
 		holdingSummary := HoldingSummary{
-			Code:          holding.Code,
-			Name:          holding.Name,
-			Shares:        holding.Shares,
-			CurrentPrice:  currentPrice,
-			PurchasePrice: holding.GetPurchasePrice(),
-			CurrentValue:  currentValue,
-			PurchaseCost:  purchaseCost,
-			Gain:          gain,
-			GainPercent:   gainPercent,
-			LastUpdated:   time.Now(),
+			Code:           holding.Code,
+			Name:           holding.Name,
+			PositionType:   positionType,
+			AssetClass:     holding.GetAssetClass(),
+			Shares:         holding.Shares,
+			CurrentPrice:   currentPrice,
+			PurchasePrice:  holding.GetPurchasePrice(),
+			CurrentValue:   currentValue,
+			PurchaseCost:   purchaseCost,
+			Gain:           gain,
+			GainPercent:    gainPercent,
+			RequiredMargin: holding.CalculateRequiredMargin(currentPrice),
+			InterestCost:   holding.CalculateInterestCost(summary.UpdatedAt),
+			LastUpdated:    time.Now(),
 		}
 
 		summary.Holdings = append(summary.Holdings, holdingSummary)
@@ -87,55 +130,161 @@ func (s *PortfolioService) CalculatePortfolioSummary(
 	if summary.TotalCost > 0 {
 		summary.TotalGainPercent = (summary.TotalGain / summary.TotalCost) * 100
 	}
+	summary.Allocations = calculateAssetAllocations(summary.Holdings, summary.TotalValue)
 
 	return summary
 }
 
+// calculateAssetAllocations sums the values of the holdings by asset class, leaving out the classes without holdings.
+func calculateAssetAllocations(holdings []HoldingSummary, totalValue float64) []AssetAllocation {
+	var allocations []AssetAllocation
+	for _, class := range models.AssetClasses {
+		allocation := AssetAllocation{AssetClass: class}
+		for _, holding := range holdings {
+			if holding.AssetClass == class {
+				allocation.Value += holding.CurrentValue
+				allocation.Holdings++
+			}
+		}
+		if allocation.Holdings == 0 {
+			continue
+		}
+		if totalValue > 0 {
+			allocation.Percent = allocation.Value / totalValue * 100
+		}
+		allocations = append(allocations, allocation)
+	}
+	return allocations
+}
+
+// FormatAssetAllocations formats the value and share of each asset class, one line per class.
+func FormatAssetAllocations(allocations []AssetAllocation) []string {
+	lines := make([]string, 0, len(allocations))
+	for _, allocation := range allocations {
+		lines = append(lines, i18n.T("portfolio.allocation",
+			i18n.T("asset_class."+allocation.AssetClass), formatCurrency(allocation.Value), allocation.Percent))
+	}
+	return lines
+}
+
 // GeneratePortfolioReport generates a formatted report.
 func (s *PortfolioService) GeneratePortfolioReport(summary *PortfolioSummary) string {
 	if len(summary.Holdings) == 0 {
-		return "ポートフォリオにデータがありません"
+		return i18n.T("portfolio.empty")
 	}
 
-	report := "📊 ポートフォリオレポート\n\n"
+	report := i18n.T("portfolio.title") + "\n\n"
 
 	// 総資産状況
-	report += "💰 総資産状況\n"
+	report += i18n.T("portfolio.total_section") + "\n"
 	report += "━━━━━━━━━━━━━━━━━━━━\n"
-	report += fmt.Sprintf("現在価値: ¥%s\n", formatCurrency(summary.TotalValue))
-	report += fmt.Sprintf("投資元本: ¥%s\n", formatCurrency(summary.TotalCost))
+	report += i18n.T("portfolio.total_value", formatCurrency(summary.TotalValue)) + "\n"
+	report += i18n.T("portfolio.total_cost", formatCurrency(summary.TotalCost)) + "\n"
 
 	gainIcon := "📈"
 	if summary.TotalGain < 0 {
 		gainIcon = "📉"
 	}
 
-	report += fmt.Sprintf("損益: %s ¥%s (%.2f%%)\n\n",
+	report += i18n.T("portfolio.total_gain",
 		gainIcon,
 		formatCurrency(summary.TotalGain),
-		summary.TotalGainPercent)
+		summary.TotalGainPercent) + "\n\n"
 
-	// 個別銘柄
-	report += "📋 個別銘柄\n"
-	report += "━━━━━━━━━━━━━━━━━━━━\n"
+	// 市況(監視対象指数とベンチマーク比較)
+	if summary.Market != nil && len(summary.Market.Indices) > 0 {
+		report += FormatMarketSection(*summary.Market) + "\n"
+	}
 
-	for _, holding := range summary.Holdings {
-		icon := "📈"
-		if holding.Gain < 0 {
-			icon = "📉"
+	// 資産クラス別配分 is shown once the portfolio holds more than stocks
+	if len(summary.Allocations) > 1 {
+		report += i18n.T("portfolio.allocation_section") + "\n"
+		report += "━━━━━━━━━━━━━━━━━━━━\n"
+		report += strings.Join(FormatAssetAllocations(summary.Allocations), "\n") + "\n\n"
+	}
+
+	// 個別銘柄(レポート設定でオフにされていなければ)
+	if !summary.HideHoldings {
+		report += i18n.T("portfolio.holdings_section") + "\n"
+		report += "━━━━━━━━━━━━━━━━━━━━\n"
+
+		for _, holding := range summary.Holdings {
+			icon := "📈"
+			if holding.Gain < 0 {
+				icon = "📉"
+			}
+
+			switch {
+			case holding.AssetClass == models.AssetClassCash:
+				report += fmt.Sprintf("💴 %s (%s)\n", holding.Name, holding.Code)
+				report += "  " + i18n.T("portfolio.cash_balance", formatCurrency(holding.CurrentValue)) + "\n\n"
+				continue
+			case holding.PositionType == models.PositionTypeShort:
+				report += i18n.T("portfolio.short_holding", icon, holding.Name, holding.Code) + "\n"
+				report += "  " + i18n.T("portfolio.short_shares", holding.Shares, formatCurrency(holding.PurchasePrice)) + "\n"
+			case holding.AssetClass == models.AssetClassFund:
+				report += fmt.Sprintf("%s %s (%s)\n", icon, holding.Name, holding.Code)
+				report += "  " + i18n.T("portfolio.fund_units", formatCurrency(float64(holding.Shares)), formatCurrency(holding.PurchasePrice)) + "\n"
+			case holding.AssetClass == models.AssetClassCrypto:
+				report += fmt.Sprintf("%s %s (%s)\n", icon, holding.Name, holding.Code)
+				report += "  " + i18n.T("portfolio.crypto_quantity", holding.FormatQuantity(), holding.Code, formatCurrency(holding.PurchasePrice)) + "\n"
+			case holding.AssetClass == models.AssetClassETF:
+				report += fmt.Sprintf("%s %s (%s)\n", icon, holding.Name, holding.Code)
+				report += "  " + i18n.T("portfolio.etf_units", holding.Shares, formatCurrency(holding.PurchasePrice)) + "\n"
+			default:
+				report += fmt.Sprintf("%s %s (%s)\n", icon, holding.Name, holding.Code)
+				report += "  " + i18n.T("portfolio.long_shares", holding.Shares, formatCurrency(holding.PurchasePrice)) + "\n"
+			}
+			report += "  " + i18n.T("portfolio.current_price", formatCurrency(holding.CurrentPrice)) + "\n"
+			if holding.RequiredMargin > 0 {
+				report += "  " + i18n.T("portfolio.required_margin", formatCurrency(holding.RequiredMargin)) + "\n"
+			}
+			if holding.InterestCost > 0 {
+				report += "  " + i18n.T("portfolio.interest_cost", formatCurrency(holding.InterestCost)) + "\n"
+			}
+			report += "  " + i18n.T("portfolio.holding_gain",
+				formatCurrency(holding.Gain),
+				holding.GainPercent) + "\n"
+			if note := summary.Notes[holding.Code]; note != nil {
+				report += "  " + FormatTradeNoteRef(note) + "\n"
+			}
+			report += "\n"
 		}
+	}
 
-		report += fmt.Sprintf("%s %s (%s)\n", icon, holding.Name, holding.Code)
-		report += fmt.Sprintf("  保有数: %d株 @ ¥%s\n", holding.Shares, formatCurrency(holding.PurchasePrice))
-		report += fmt.Sprintf("  現在価格: ¥%s\n", formatCurrency(holding.CurrentPrice))
-		report += fmt.Sprintf("  損益: ¥%s (%.2f%%)\n\n",
-			formatCurrency(holding.Gain),
-			holding.GainPercent)
+	if summary.BuyingPower != nil {
+		report += FormatBuyingPowerSection(*summary.BuyingPower) + "\n"
+	}
+	if len(summary.Technicals) > 0 {
+		report += FormatTechnicalSection(summary.Technicals) + "\n"
+	}
+	if summary.Contributions != nil {
+		report += FormatContributionSection(*summary.Contributions) + "\n"
+	}
+	if len(summary.Goals) > 0 {
+		report += FormatGoalSection(summary.Goals) + "\n"
 	}
 
 	return report
 }
 
+// FormatCurrency formats an amount in yen with comma separators, without the currency sign.
+func FormatCurrency(value float64) string {
+	return formatCurrency(value)
+}
+
+// FormatClosingFollowUp formats the closing values of the portfolio replied to the daily report,
+// with the change in value from the previous closes the report was based on.
+func FormatClosingFollowUp(summary *PortfolioSummary, previousValue float64) string {
+	change := summary.TotalValue - previousValue
+	changePercent := 0.0
+	if previousValue > 0 {
+		changePercent = change / previousValue * 100
+	}
+	return i18n.T("report.closing_followup", formatCurrency(summary.TotalValue), formatSignedCurrency(change), changePercent,
+		formatSignedCurrency(summary.TotalGain), summary.TotalGainPercent)
+}
+
 // formatCurrency formats a float64 as Japanese currency with comma separators.
 func formatCurrency(value float64) string {
 	// Round to 0 decimal places
@@ -1,14 +1,14 @@
 package domain
 
 import (
-	"fmt"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/boost-jp/stock-automation/app/domain/models"
-	"github.com/ericlagergren/decimal"
+	"github.com/boost-jp/stock-automation/app/utility"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestNewPortfolioService(t *testing.T) {
@@ -138,6 +138,8 @@ func TestPortfolioService_CalculatePortfolioSummary_HoldingDetails(t *testing.T)
 	expected := HoldingSummary{
 		Code:          "1234",
 		Name:          "Test Stock",
+		PositionType:  models.PositionTypeLong,
+		AssetClass:    models.AssetClassStock,
 		Shares:        100,
 		CurrentPrice:  1100.0,
 		PurchasePrice: 1000.0,
@@ -156,6 +158,96 @@ func TestPortfolioService_CalculatePortfolioSummary_HoldingDetails(t *testing.T)
 	}
 }
 
+func TestPortfolioService_CalculatePortfolioSummary_ShortPosition(t *testing.T) {
+	service := NewPortfolioService()
+
+	short := createTestPortfolio("1234", "Short Stock", 100, 1000.0)
+	short.PositionType = models.PositionTypeShort
+	short.MarginRate = utility.FloatToNullDecimal(30.0)
+	short.InterestRate = utility.FloatToNullDecimal(1.15)
+	short.PurchaseDate = time.Now().AddDate(0, 0, -30)
+
+	summary := service.CalculatePortfolioSummary(
+		[]*models.Portfolio{short},
+		map[string]float64{"1234": 900.0},
+	)
+
+	if len(summary.Holdings) != 1 {
+		t.Fatalf("Expected 1 holding, got %d", len(summary.Holdings))
+	}
+
+	holding := summary.Holdings[0]
+	if diff := cmp.Diff(models.PositionTypeShort, holding.PositionType); diff != "" {
+		t.Errorf("PositionType mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(10000.0, holding.Gain); diff != "" {
+		t.Errorf("Gain mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(27000.0, holding.RequiredMargin); diff != "" {
+		t.Errorf("RequiredMargin mismatch (-want +got):\n%s", diff)
+	}
+	if holding.InterestCost <= 0 {
+		t.Errorf("InterestCost should be positive, got %f", holding.InterestCost)
+	}
+	if diff := cmp.Diff(10000.0, summary.TotalGain); diff != "" {
+		t.Errorf("TotalGain mismatch (-want +got):\n%s", diff)
+	}
+
+	report := service.GeneratePortfolioReport(summary)
+	for _, expected := range []string{"[空売り]", "売建数:", "必要保証金: ¥27,000", "金利コスト:"} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Report should contain expected string: %s", expected)
+		}
+	}
+}
+
+func TestPortfolioService_CalculatePortfolioSummary_AssetClasses(t *testing.T) {
+	service := NewPortfolioService()
+
+	stock := createTestPortfolio("7203", "トヨタ自動車", 100, 2000.0)
+	fund := createTestPortfolio("0331418A", "全世界株式", 1000000, 18000.0)
+	fund.AssetClass = models.AssetClassFund
+	cash := createTestPortfolio("JPY", "現金", 100000, 1.0)
+	cash.AssetClass = models.AssetClassCash
+
+	summary := service.CalculatePortfolioSummary(
+		[]*models.Portfolio{cash, fund, stock},
+		map[string]float64{"7203": 2200.0, "0331418A": 20000.0},
+	)
+
+	// The fund is valued per 10,000 units and the cash balance needs no price
+	want := []AssetAllocation{
+		{AssetClass: models.AssetClassStock, Value: 220000, Percent: 220000 / 2320000.0 * 100, Holdings: 1},
+		{AssetClass: models.AssetClassFund, Value: 2000000, Percent: 2000000 / 2320000.0 * 100, Holdings: 1},
+		{AssetClass: models.AssetClassCash, Value: 100000, Percent: 100000 / 2320000.0 * 100, Holdings: 1},
+	}
+	if diff := cmp.Diff(want, summary.Allocations, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("Allocations mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(2100000.0, summary.TotalCost); diff != "" {
+		t.Errorf("TotalCost mismatch (-want +got):\n%s", diff)
+	}
+
+	report := service.GeneratePortfolioReport(summary)
+	for _, expected := range []string{"資産クラス別配分", "投資信託: ¥2,000,000 (86.2%)", "保有口数: 1,000,000口", "残高: ¥100,000"} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Report should contain expected string: %s\n%s", expected, report)
+		}
+	}
+}
+
+func TestHoldingSummary_FormatQuantity(t *testing.T) {
+	stock := HoldingSummary{AssetClass: models.AssetClassStock, Shares: 100}
+	crypto := HoldingSummary{AssetClass: models.AssetClassCrypto, Shares: models.CryptoSharesFromQuantity(0.125)}
+
+	if diff := cmp.Diff("100", stock.FormatQuantity()); diff != "" {
+		t.Errorf("FormatQuantity mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("0.125", crypto.FormatQuantity()); diff != "" {
+		t.Errorf("FormatQuantity mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestPortfolioService_GeneratePortfolioReport(t *testing.T) {
 	service := NewPortfolioService()
 
@@ -258,7 +350,7 @@ func TestPortfolioService_GeneratePortfolioReport(t *testing.T) {
 			}
 
 			for _, expectedString := range tt.expectedContains {
-				if !containsString(report, expectedString) {
+				if !strings.Contains(report, expectedString) {
 					t.Errorf("Report should contain expected string: %s", expectedString)
 				}
 			}
@@ -388,23 +480,14 @@ func (tp TestPortfolio) ToPortfolio() *models.Portfolio {
 		Code:          tp.Code,
 		Name:          tp.Name,
 		Shares:        tp.Shares,
-		PurchasePrice: floatToDecimal(tp.PurchasePrice),
+		PurchasePrice: utility.FloatToDecimal(tp.PurchasePrice),
 		PurchaseDate:  tp.PurchaseDate,
 	}
 }
 
-// floatToDecimal converts float64 to types.Decimal for testing
-func floatToDecimal(value float64) types.Decimal {
-	decimalStr := fmt.Sprintf("%.6f", value)
-	d := new(decimal.Big)
-	d.SetString(decimalStr)
-	return types.Decimal{Big: d}
-}
-
 // Helper function to create a portfolio with test price
 func createTestPortfolio(code, name string, shares int, purchasePrice float64) *models.Portfolio {
-	// Convert float to decimal using the infrastructure client helper
-	decimalValue := floatToDecimal(purchasePrice)
+	decimalValue := utility.FloatToDecimal(purchasePrice)
 
 	return &models.Portfolio{
 		Code:          code,
@@ -414,20 +497,3 @@ func createTestPortfolio(code, name string, shares int, purchasePrice float64) *
 		PurchaseDate:  time.Now(),
 	}
 }
-
-// Helper function to check if a string contains a substring
-func containsString(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || contains(s, substr))
-}
-
-func contains(s, substr string) bool {
-	if len(substr) == 0 {
-		return true
-	}
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"math"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// AggregatePrices aggregates daily prices sorted by date into bars of the period, oldest first.
+// A bar takes the open of its first trading day, the close and adjusted close of its last,
+// the highest high, the lowest low and the total volume.
+func AggregatePrices(prices []*models.StockPrice, period models.PricePeriod) []*models.AggregatedPrice {
+	var bars []*models.AggregatedPrice
+	var bar *models.AggregatedPrice
+	var high, low float64
+
+	flush := func() {
+		if bar != nil {
+			bar.HighPrice = utility.FloatToDecimal(high)
+			bar.LowPrice = utility.FloatToDecimal(low)
+			bars = append(bars, bar)
+		}
+	}
+
+	for _, price := range prices {
+		start := period.Start(price.Date)
+		if bar == nil || !bar.PeriodStart.Equal(start) {
+			flush()
+			bar = &models.AggregatedPrice{
+				Code:        price.Code,
+				PeriodStart: start,
+				OpenPrice:   price.OpenPrice,
+			}
+			high, low = math.Inf(-1), math.Inf(1)
+		}
+
+		high = math.Max(high, utility.DecimalToFloat(price.HighPrice))
+		low = math.Min(low, utility.DecimalToFloat(price.LowPrice))
+		bar.PeriodEnd = models.TruncateToDate(price.Date)
+		bar.ClosePrice = price.ClosePrice
+		bar.AdjClosePrice = price.AdjClosePrice
+		bar.Volume += price.Volume
+		bar.TradingDays++
+	}
+	flush()
+
+	return bars
+}
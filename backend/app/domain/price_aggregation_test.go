@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAggregatePrices(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	price := func(date time.Time, open, high, low, close float64, volume int64) *models.StockPrice {
+		return &models.StockPrice{
+			Code:       "7203",
+			Date:       date,
+			OpenPrice:  utility.FloatToDecimal(open),
+			HighPrice:  utility.FloatToDecimal(high),
+			LowPrice:   utility.FloatToDecimal(low),
+			ClosePrice: utility.FloatToDecimal(close),
+			Volume:     volume,
+		}
+	}
+
+	// Thursday 2024-02-29 to Tuesday 2024-03-05
+	prices := []*models.StockPrice{
+		price(day(2, 29), 100, 110, 95, 105, 1000),
+		price(day(3, 1), 105, 120, 100, 115, 2000),
+		price(day(3, 4), 115, 118, 90, 92, 3000),
+		price(day(3, 5), 92, 99, 91, 98, 4000),
+	}
+
+	type bar struct {
+		Start, End             time.Time
+		Open, High, Low, Close float64
+		Volume                 int64
+		Days                   int
+	}
+	summarize := func(bars []*models.AggregatedPrice) []bar {
+		var got []bar
+		for _, b := range bars {
+			got = append(got, bar{
+				Start:  b.PeriodStart,
+				End:    b.PeriodEnd,
+				Open:   utility.DecimalToFloat(b.OpenPrice),
+				High:   utility.DecimalToFloat(b.HighPrice),
+				Low:    utility.DecimalToFloat(b.LowPrice),
+				Close:  utility.DecimalToFloat(b.ClosePrice),
+				Volume: b.Volume,
+				Days:   b.TradingDays,
+			})
+		}
+		return got
+	}
+
+	tests := []struct {
+		name   string
+		period models.PricePeriod
+		want   []bar
+	}{
+		{
+			name:   "Weekly from Monday",
+			period: models.PricePeriodWeekly,
+			want: []bar{
+				{Start: day(2, 26), End: day(3, 1), Open: 100, High: 120, Low: 95, Close: 115, Volume: 3000, Days: 2},
+				{Start: day(3, 4), End: day(3, 5), Open: 115, High: 118, Low: 90, Close: 98, Volume: 7000, Days: 2},
+			},
+		},
+		{
+			name:   "Monthly",
+			period: models.PricePeriodMonthly,
+			want: []bar{
+				{Start: day(2, 1), End: day(2, 29), Open: 100, High: 110, Low: 95, Close: 105, Volume: 1000, Days: 1},
+				{Start: day(3, 1), End: day(3, 5), Open: 105, High: 120, Low: 90, Close: 98, Volume: 9000, Days: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarize(AggregatePrices(prices, tt.period))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("AggregatePrices() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
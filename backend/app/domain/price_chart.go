@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// Default size of the ASCII price chart, fitting a terminal and a Slack code block.
+const (
+	DefaultChartHeight = 12
+	DefaultChartWidth  = 60
+)
+
+// sparkBlocks are the block characters of a sparkline from the lowest to the highest value.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a one-line chart of block characters.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	low, high := valueRange(values)
+	var b strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if high > low {
+			level = int(math.Round((v - low) / (high - low) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// valueRange returns the lowest and the highest of the values.
+func valueRange(values []float64) (low, high float64) {
+	low, high = values[0], values[0]
+	for _, v := range values[1:] {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+	return low, high
+}
+
+// PriceChart is the close price history of a stock to be rendered as a chart, oldest first.
+type PriceChart struct {
+	Code   string
+	Name   string
+	Dates  []time.Time
+	Closes []float64
+}
+
+// NewPriceChart creates the chart of the closes of the prices.
+func NewPriceChart(code, name string, prices []StockPriceData) *PriceChart {
+	chart := &PriceChart{
+		Code:   code,
+		Name:   name,
+		Dates:  make([]time.Time, len(prices)),
+		Closes: make([]float64, len(prices)),
+	}
+	for i, price := range prices {
+		chart.Dates[i] = price.Date
+		chart.Closes[i] = price.Close
+	}
+	return chart
+}
+
+// downsample returns at most width values, taking the last value of each bucket so that the
+// latest close always appears at the right end.
+func downsample(values []float64, width int) []float64 {
+	if width <= 0 || len(values) <= width {
+		return values
+	}
+	sampled := make([]float64, width)
+	for i := range sampled {
+		end := (i + 1) * len(values) / width
+		sampled[i] = values[end-1]
+	}
+	return sampled
+}
+
+// Sparkline renders the closes as a sparkline of at most width characters.
+func (c *PriceChart) Sparkline(width int) string {
+	return Sparkline(downsample(c.Closes, width))
+}
+
+// Render renders the closes as an ASCII line chart of height rows and at most width columns, with
+// the price axis on the left and the first and last dates below. Only ASCII characters are used so
+// that the chart stays aligned in a Slack code block.
+func (c *PriceChart) Render(height, width int) string {
+	if len(c.Closes) == 0 {
+		return ""
+	}
+	if height < 2 {
+		height = 2
+	}
+
+	values := downsample(c.Closes, width)
+	low, high := valueRange(values)
+	rowOf := func(v float64) int {
+		if high == low {
+			return height / 2
+		}
+		return int(math.Round((v - low) / (high - low) * float64(height-1)))
+	}
+
+	grid := make([][]byte, height)
+	for row := range grid {
+		grid[row] = []byte(strings.Repeat(" ", len(values)))
+	}
+	previous := rowOf(values[0])
+	for col, v := range values {
+		row := rowOf(v)
+		// Connect the point to the previous one so that large moves read as a line
+		for r := min(row, previous) + 1; r < max(row, previous); r++ {
+			grid[r][col] = '|'
+		}
+		grid[row][col] = '*'
+		previous = row
+	}
+
+	labelWidth := len(fmt.Sprintf("%.2f", high))
+	if w := len(fmt.Sprintf("%.2f", low)); w > labelWidth {
+		labelWidth = w
+	}
+
+	var lines []string
+	for row := height - 1; row >= 0; row-- {
+		label := strings.Repeat(" ", labelWidth)
+		switch {
+		case row == height-1:
+			label = fmt.Sprintf("%*.2f", labelWidth, high)
+		case row == 0:
+			label = fmt.Sprintf("%*.2f", labelWidth, low)
+		case high > low && row == (height-1)/2:
+			label = fmt.Sprintf("%*.2f", labelWidth, low+(high-low)*float64(row)/float64(height-1))
+		}
+		lines = append(lines, strings.TrimRight(label+" |"+string(grid[row]), " "))
+	}
+	lines = append(lines, strings.Repeat(" ", labelWidth)+" +"+strings.Repeat("-", len(values)))
+
+	first := c.Dates[0].Format("2006-01-02")
+	last := c.Dates[len(c.Dates)-1].Format("2006-01-02")
+	dates := strings.Repeat(" ", labelWidth+2) + first
+	if gap := labelWidth + 2 + len(values) - len(dates) - len(last); gap > 0 {
+		dates += strings.Repeat(" ", gap) + last
+	} else if last != first {
+		dates += " " + last
+	}
+	lines = append(lines, dates)
+
+	return strings.Join(lines, "\n")
+}
+
+// Summary returns the title of the chart with the period, the high and low closes and the change
+// over the period.
+func (c *PriceChart) Summary() string {
+	label := c.Code
+	if c.Name != "" {
+		label += " " + c.Name
+	}
+	if len(c.Closes) == 0 {
+		return i18n.T("price_chart.no_data", label)
+	}
+
+	low, high := valueRange(c.Closes)
+	change := 0.0
+	if first := c.Closes[0]; first > 0 {
+		change = (c.Closes[len(c.Closes)-1]/first - 1) * 100
+	}
+	return i18n.T("price_chart.title", label, c.Dates[0].Format("2006-01-02"), c.Dates[len(c.Dates)-1].Format("2006-01-02"), len(c.Closes)) +
+		"\n" + i18n.T("price_chart.summary", c.Closes[len(c.Closes)-1], high, low, change)
+}
+
+// FormatPriceChartMessage formats the chart as a notification message, with the ASCII chart in a
+// code block so that chat services render it in a fixed-width font.
+func FormatPriceChartMessage(chart *PriceChart, height, width int) string {
+	if len(chart.Closes) == 0 {
+		return chart.Summary()
+	}
+	return chart.Summary() + "\n" + chart.Sparkline(width) + "\n```\n" + chart.Render(height, width) + "\n```"
+}
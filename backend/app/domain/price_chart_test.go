@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   string
+	}{
+		{"empty", nil, ""},
+		{"rising", []float64{1, 2, 3, 4, 5, 6, 7, 8}, "▁▂▃▄▅▆▇█"},
+		{"flat", []float64{100, 100, 100}, "▅▅▅"},
+		{"lowest and highest", []float64{10, 30, 20}, "▁█▅"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.values); got != tt.want {
+				t.Errorf("Sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func chartOf(closes ...float64) *PriceChart {
+	prices := make([]StockPriceData, len(closes))
+	start := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	for i, c := range closes {
+		prices[i] = StockPriceData{Date: start.AddDate(0, 0, i), Close: c}
+	}
+	return NewPriceChart("7203", "トヨタ自動車", prices)
+}
+
+func TestPriceChart_Render(t *testing.T) {
+	chart := chartOf(100, 102, 101, 104)
+
+	want := strings.Join([]string{
+		"104.00 |   *",
+		"       |   |",
+		"       | * |",
+		"101.60 | |||",
+		"       | |*",
+		"100.00 |*",
+		"       +----",
+		"        2024-06-03 2024-06-06",
+	}, "\n")
+	if diff := cmp.Diff(want, chart.Render(6, 60)); diff != "" {
+		t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPriceChart_RenderDownsamples(t *testing.T) {
+	closes := make([]float64, 120)
+	for i := range closes {
+		closes[i] = float64(1000 + i)
+	}
+	chart := chartOf(closes...)
+
+	lines := strings.Split(chart.Render(5, 40), "\n")
+	axis := lines[len(lines)-2]
+	if got := strings.Count(axis, "-"); got != 40 {
+		t.Errorf("chart width = %d columns, want 40", got)
+	}
+	if !strings.HasPrefix(lines[0], "1119.00 |") || !strings.HasSuffix(lines[0], "*") {
+		t.Errorf("top row = %q, want the latest close at the right end", lines[0])
+	}
+	if got := []rune(chart.Sparkline(40)); len(got) != 40 {
+		t.Errorf("sparkline width = %d, want 40", len(got))
+	}
+}
+
+func TestFormatPriceChartMessage(t *testing.T) {
+	message := FormatPriceChartMessage(chartOf(100, 110), 4, 60)
+
+	for _, want := range []string{"7203 トヨタ自動車", "2024-06-03", "2024-06-04", "+10.00%", "```\n110.00 |", "\n```"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message does not contain %q:\n%s", want, message)
+		}
+	}
+
+	if got := FormatPriceChartMessage(chartOf(), 4, 60); strings.Contains(got, "```") {
+		t.Errorf("chart without prices should have no code block: %q", got)
+	}
+}
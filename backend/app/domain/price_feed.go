@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// DefaultPriceFeedMinPercent is the share of the expected stocks that must have a price of the
+// trading day for the price feed to be considered working.
+const DefaultPriceFeedMinPercent = 50.0
+
+// priceFeedStaleSamples is the number of stocks without a price listed in the price feed alert.
+const priceFeedStaleSamples = 10
+
+// PriceFeedStatus compares the stocks whose price of a trading day is stored with the stocks
+// expected to have one, one price per stock and trading day.
+type PriceFeedStatus struct {
+	Date     time.Time
+	Expected int      // stocks collected on the day
+	Updated  int      // stocks with a price of the day
+	Stale    []string // codes without a price of the day, in code order
+}
+
+// UpdatedPercent returns the share of the expected stocks with a price of the day.
+func (s PriceFeedStatus) UpdatedPercent() float64 {
+	if s.Expected == 0 {
+		return 100
+	}
+	return float64(s.Updated) / float64(s.Expected) * 100
+}
+
+// IsStalled reports whether far fewer stocks than expected have a price of the day, i.e. the
+// collection is failing silently.
+func (s PriceFeedStatus) IsStalled(minPercent float64) bool {
+	return s.Expected > 0 && s.UpdatedPercent() < minPercent
+}
+
+// FormatPriceFeedAlert formats the alert of a stalled price feed, listing a few of the stocks
+// without a price of the day.
+func FormatPriceFeedAlert(status PriceFeedStatus) string {
+	lines := []string{
+		i18n.T("price_feed.stalled", status.Date.Format("2006-01-02"), status.Updated, status.Expected, status.UpdatedPercent()),
+	}
+	for i, code := range status.Stale {
+		if i == priceFeedStaleSamples {
+			lines = append(lines, i18n.T("collect_alert.error_more", len(status.Stale)-priceFeedStaleSamples))
+			break
+		}
+		lines = append(lines, "- "+code)
+	}
+	lines = append(lines, i18n.T("price_feed.hint"))
+	return strings.Join(lines, "\n")
+}
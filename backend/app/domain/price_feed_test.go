@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormatPriceFeedAlert(t *testing.T) {
+	status := PriceFeedStatus{
+		Date:     time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local),
+		Expected: 4,
+		Updated:  1,
+		Stale:    []string{"1301", "1302", "1303"},
+	}
+	want := "🚨 価格が更新されていない銘柄が多すぎます: 2024-06-10 の価格があるのは 1 / 4銘柄 (25%)\n" +
+		"- 1301\n- 1302\n- 1303\n" +
+		"収集ジョブはエラーなく終了している可能性があります。データソースの応答とスケジューラのログを確認してください"
+	if diff := cmp.Diff(want, FormatPriceFeedAlert(status)); diff != "" {
+		t.Errorf("FormatPriceFeedAlert() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// PriceMove is a stock whose price moved by the notification threshold or more from the
+// close of the previous trading day.
+type PriceMove struct {
+	Code          string
+	Name          string
+	PreviousClose float64
+	Price         float64
+	ChangePercent float64
+}
+
+// NewPriceMove creates the move of a stock from the previous close to the price.
+func NewPriceMove(code, name string, previousClose, price float64) PriceMove {
+	return PriceMove{
+		Code:          code,
+		Name:          name,
+		PreviousClose: previousClose,
+		Price:         price,
+		ChangePercent: (price/previousClose - 1) * 100,
+	}
+}
+
+// PriceMoveLevel returns how many times a change crosses the threshold, negative for falls.
+// A stock is notified again on a day only when its move reaches a new level, so that a stock
+// staying around the threshold is not notified by every collection run.
+func PriceMoveLevel(changePercent, thresholdPercent float64) int {
+	if thresholdPercent <= 0 {
+		return 0
+	}
+	return int(changePercent / thresholdPercent)
+}
+
+// IsNewPriceMoveLevel reports whether level is beyond the level notified before on the same day.
+// A move turning from a rise to a fall, or the other way around, is always new.
+func IsNewPriceMoveLevel(level, notified int) bool {
+	if level == 0 {
+		return false
+	}
+	if notified == 0 || (level > 0) != (notified > 0) {
+		return true
+	}
+	return abs(level) > abs(notified)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// FormatPriceMoveSummary formats the summary notification of the stocks that moved by the
+// threshold or more, largest moves first.
+func FormatPriceMoveSummary(moves []PriceMove, thresholdPercent float64) string {
+	sorted := append([]PriceMove(nil), moves...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return math.Abs(sorted[i].ChangePercent) > math.Abs(sorted[j].ChangePercent)
+	})
+
+	lines := []string{i18n.T("price_move.title", thresholdPercent, len(sorted))}
+	for _, move := range sorted {
+		label := move.Code
+		if move.Name != "" {
+			label += " " + move.Name
+		}
+		lines = append(lines, i18n.T("price_move.line", label, move.PreviousClose, move.Price, move.ChangePercent))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsNewPriceMoveLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   float64
+		notified int
+		want     bool
+	}{
+		{name: "below threshold", change: 2.9, notified: 0, want: false},
+		{name: "first rise", change: 3.2, notified: 0, want: true},
+		{name: "same level", change: 5.9, notified: 1, want: false},
+		{name: "higher level", change: 6.1, notified: 1, want: true},
+		{name: "back below threshold", change: -1, notified: 2, want: false},
+		{name: "turned to fall", change: -3.5, notified: 2, want: true},
+		{name: "same fall", change: -4, notified: -1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNewPriceMoveLevel(PriceMoveLevel(tt.change, 3), tt.notified); got != tt.want {
+				t.Errorf("IsNewPriceMoveLevel(%v%%, %d) = %v, want %v", tt.change, tt.notified, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPriceMoveSummary(t *testing.T) {
+	moves := []PriceMove{
+		NewPriceMove("7203", "トヨタ自動車", 3000, 3100),
+		NewPriceMove("9984", "", 8000, 7200),
+	}
+
+	got := FormatPriceMoveSummary(moves, 3)
+
+	want := "📊 前回終値から±3%以上動いた銘柄 (2銘柄)\n" +
+		"- 9984 ¥8000.00 → ¥7200.00 (-10.00%)\n" +
+		"- 7203 トヨタ自動車 ¥3000.00 → ¥3100.00 (+3.33%)"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FormatPriceMoveSummary() mismatch (-want +got):\n%s", diff)
+	}
+}
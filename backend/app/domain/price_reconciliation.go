@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// Results of the reconciliation of the stored prices of a stock and period with a data source.
+const (
+	ReconcileMatched           = "matched"
+	ReconcileMismatched        = "mismatched"          // both have prices but the counts or close sums differ
+	ReconcileMissingInDatabase = "missing_in_database" // the source has prices of the period but none are stored
+	ReconcileMissingInSource   = "missing_in_source"   // prices are stored but the source has none of the period
+)
+
+// PriceChecksum is the number of daily prices of a stock in a period and the sum of their closes.
+// Comparing the checksums of the stored prices with those of a source finds missing, duplicated or
+// changed prices after an archive, restore or backfill without comparing every row.
+type PriceChecksum struct {
+	Code        string    `json:"code"`
+	PeriodStart time.Time `json:"period_start"`
+	Count       int       `json:"count"`
+	CloseSum    float64   `json:"close_sum"`
+}
+
+// PriceReconciliation is the stored and source checksums of a stock and period and how they compare.
+type PriceReconciliation struct {
+	Code        string         `json:"code"`
+	PeriodStart time.Time      `json:"period_start"`
+	Status      string         `json:"status"`
+	Stored      *PriceChecksum `json:"stored,omitempty"`
+	Source      *PriceChecksum `json:"source,omitempty"`
+}
+
+// CalculatePriceChecksums returns the checksums of the daily prices of a stock for each period, oldest first.
+func CalculatePriceChecksums(prices []*models.StockPrice, period models.PricePeriod) []PriceChecksum {
+	byStart := make(map[time.Time]*PriceChecksum)
+	for _, price := range prices {
+		start := period.Start(price.Date)
+		checksum, ok := byStart[start]
+		if !ok {
+			checksum = &PriceChecksum{Code: price.Code, PeriodStart: start}
+			byStart[start] = checksum
+		}
+		checksum.Count++
+		checksum.CloseSum += roundPrice(utility.DecimalToFloat(price.ClosePrice))
+	}
+
+	checksums := make([]PriceChecksum, 0, len(byStart))
+	for _, checksum := range byStart {
+		checksum.CloseSum = roundPrice(checksum.CloseSum)
+		checksums = append(checksums, *checksum)
+	}
+	sort.Slice(checksums, func(i, j int) bool { return checksums[i].PeriodStart.Before(checksums[j].PeriodStart) })
+	return checksums
+}
+
+// ReconcilePriceChecksums compares the checksums of the stored prices of a stock with those of the
+// source period by period, oldest first. The counts must be equal and the close sums may differ by
+// tolerancePercent of the source sum, for sources that round the closes differently.
+func ReconcilePriceChecksums(stored, source []PriceChecksum, tolerancePercent float64) []PriceReconciliation {
+	type pair struct{ stored, source *PriceChecksum }
+	byStart := make(map[time.Time]*pair)
+	get := func(checksum PriceChecksum) *pair {
+		p, ok := byStart[checksum.PeriodStart]
+		if !ok {
+			p = &pair{}
+			byStart[checksum.PeriodStart] = p
+		}
+		return p
+	}
+	for i := range stored {
+		get(stored[i]).stored = &stored[i]
+	}
+	for i := range source {
+		get(source[i]).source = &source[i]
+	}
+
+	reconciliations := make([]PriceReconciliation, 0, len(byStart))
+	for start, p := range byStart {
+		reconciliation := PriceReconciliation{PeriodStart: start, Stored: p.stored, Source: p.source}
+		switch {
+		case p.stored == nil:
+			reconciliation.Code = p.source.Code
+			reconciliation.Status = ReconcileMissingInDatabase
+		case p.source == nil:
+			reconciliation.Code = p.stored.Code
+			reconciliation.Status = ReconcileMissingInSource
+		default:
+			reconciliation.Code = p.stored.Code
+			reconciliation.Status = ReconcileMatched
+			if p.stored.Count != p.source.Count ||
+				math.Abs(p.stored.CloseSum-p.source.CloseSum) > closeSumTolerance(p.source.CloseSum, tolerancePercent) {
+				reconciliation.Status = ReconcileMismatched
+			}
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+	sort.Slice(reconciliations, func(i, j int) bool {
+		return reconciliations[i].PeriodStart.Before(reconciliations[j].PeriodStart)
+	})
+	return reconciliations
+}
+
+// closeSumTolerance returns the allowed difference of the close sums, at least the error of summing floats.
+func closeSumTolerance(sourceSum, tolerancePercent float64) float64 {
+	return math.Max(math.Abs(sourceSum)*tolerancePercent/100, 0.005)
+}
+
+// roundPrice rounds a price to the precision the prices are stored with, so that the closes of a
+// source with more digits sum up as they would once stored.
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
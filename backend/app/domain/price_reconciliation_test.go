@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCalculatePriceChecksums(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	price := func(date time.Time, close float64) *models.StockPrice {
+		return &models.StockPrice{Code: "7203", Date: date, ClosePrice: utility.FloatToDecimal(close)}
+	}
+
+	// Unsorted, with a source close carrying more digits than stored
+	prices := []*models.StockPrice{
+		price(day(3, 4), 92),
+		price(day(2, 29), 105.004),
+		price(day(3, 1), 115.5),
+	}
+
+	want := []PriceChecksum{
+		{Code: "7203", PeriodStart: day(2, 1), Count: 1, CloseSum: 105},
+		{Code: "7203", PeriodStart: day(3, 1), Count: 2, CloseSum: 207.5},
+	}
+	if diff := cmp.Diff(want, CalculatePriceChecksums(prices, models.PricePeriodMonthly)); diff != "" {
+		t.Errorf("CalculatePriceChecksums() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReconcilePriceChecksums(t *testing.T) {
+	month := func(m time.Month) time.Time {
+		return time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+	checksum := func(m time.Month, count int, sum float64) PriceChecksum {
+		return PriceChecksum{Code: "7203", PeriodStart: month(m), Count: count, CloseSum: sum}
+	}
+
+	stored := []PriceChecksum{
+		checksum(1, 21, 52000),
+		checksum(2, 20, 50000),
+		checksum(3, 20, 50010),
+		checksum(5, 22, 56000),
+	}
+	source := []PriceChecksum{
+		checksum(1, 21, 52000),
+		checksum(2, 19, 50000), // a duplicated or wrong day stored
+		checksum(3, 20, 50000), // a close differs
+		checksum(4, 21, 53000), // lost in a restore
+	}
+
+	tests := []struct {
+		name      string
+		tolerance float64
+		want      map[time.Month]string
+	}{
+		{
+			name: "exact",
+			want: map[time.Month]string{
+				1: ReconcileMatched,
+				2: ReconcileMismatched,
+				3: ReconcileMismatched,
+				4: ReconcileMissingInDatabase,
+				5: ReconcileMissingInSource,
+			},
+		},
+		{
+			name:      "tolerance covers the close difference but not the count",
+			tolerance: 0.1,
+			want: map[time.Month]string{
+				1: ReconcileMatched,
+				2: ReconcileMismatched,
+				3: ReconcileMatched,
+				4: ReconcileMissingInDatabase,
+				5: ReconcileMissingInSource,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[time.Month]string)
+			for i, r := range ReconcilePriceChecksums(stored, source, tt.tolerance) {
+				if r.PeriodStart.Month() != time.Month(i+1) || r.Code != "7203" {
+					t.Errorf("reconciliation %d is %s of %s, want month %d of 7203", i, r.PeriodStart.Format("2006-01"), r.Code, i+1)
+				}
+				got[r.PeriodStart.Month()] = r.Status
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ReconcilePriceChecksums() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// DefaultPriceSourcePriority ranks the official J-Quants data first, then Yahoo Finance and Stooq.
+const DefaultPriceSourcePriority = "jquants,yahoo,stooq"
+
+// PriceSourcePriority ranks the data sources of prices of the same trading day, the most preferred first.
+// Unknown sources rank after the listed ones, and prices saved without a source rank last,
+// so that they are replaced by any source.
+type PriceSourcePriority []string
+
+// ParsePriceSourcePriority parses a comma separated list of data sources, e.g. "jquants,yahoo,stooq".
+func ParsePriceSourcePriority(value string) PriceSourcePriority {
+	var priority PriceSourcePriority
+	for _, source := range strings.Split(value, ",") {
+		if source = strings.ToLower(strings.TrimSpace(source)); source != "" {
+			priority = append(priority, source)
+		}
+	}
+	return priority
+}
+
+// Rank returns the rank of the source, lower is preferred.
+func (p PriceSourcePriority) Rank(source string) int {
+	if source == "" {
+		return len(p) + 1
+	}
+	for i, s := range p {
+		if s == source {
+			return i
+		}
+	}
+	return len(p)
+}
+
+// Outranks reports whether a price from source a is preferred to one from source b.
+func (p PriceSourcePriority) Outranks(a, b string) bool {
+	return p.Rank(a) < p.Rank(b)
+}
+
+// Resolve keeps one price per code and trading day from prices of several sources: the one of the
+// most preferred source, or the most recently fetched one of the same source. The result is sorted
+// by code and date.
+func (p PriceSourcePriority) Resolve(prices []*models.StockPrice) []*models.StockPrice {
+	type key struct {
+		code string
+		date string
+	}
+
+	chosen := make(map[key]*models.StockPrice, len(prices))
+	for _, price := range prices {
+		k := key{code: price.Code, date: price.Date.Format("2006-01-02")}
+		current, ok := chosen[k]
+		if !ok || p.prefers(price, current) {
+			chosen[k] = price
+		}
+	}
+
+	resolved := make([]*models.StockPrice, 0, len(chosen))
+	for _, price := range chosen {
+		resolved = append(resolved, price)
+	}
+	sort.Slice(resolved, func(i, j int) bool {
+		if resolved[i].Code != resolved[j].Code {
+			return resolved[i].Code < resolved[j].Code
+		}
+		return resolved[i].Date.Before(resolved[j].Date)
+	})
+	return resolved
+}
+
+// MarkFinalPrices marks the prices of the trading days before today as final. The daily bar of a
+// finished trading day no longer changes, while the bar of today may still move until the closing
+// prices are confirmed after the market closes.
+func MarkFinalPrices(prices []*models.StockPrice, today time.Time) {
+	day := today.Format("2006-01-02")
+	for _, price := range prices {
+		if price.Date.Format("2006-01-02") < day {
+			price.Final = true
+		}
+	}
+}
+
+// prefers reports whether price a is preferred to price b of the same trading day.
+func (p PriceSourcePriority) prefers(a, b *models.StockPrice) bool {
+	if rankA, rankB := p.Rank(a.Source), p.Rank(b.Source); rankA != rankB {
+		return rankA < rankB
+	}
+	return a.FetchedAt.Time.After(b.FetchedAt.Time)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPriceSourcePriority_Resolve(t *testing.T) {
+	priority := ParsePriceSourcePriority(" JQuants, yahoo ,stooq,")
+	if diff := cmp.Diff(PriceSourcePriority{"jquants", "yahoo", "stooq"}, priority); diff != "" {
+		t.Fatalf("ParsePriceSourcePriority() mismatch (-want +got):\n%s", diff)
+	}
+
+	day1 := time.Date(2024, 6, 3, 0, 0, 0, 0, time.Local)
+	day2 := time.Date(2024, 6, 4, 0, 0, 0, 0, time.Local)
+	fetched := time.Date(2024, 6, 4, 16, 0, 0, 0, time.Local)
+	price := func(code string, date time.Time, source string, fetchedAt time.Time) *models.StockPrice {
+		return &models.StockPrice{Code: code, Date: date, Source: source, FetchedAt: null.TimeFrom(fetchedAt)}
+	}
+
+	prices := []*models.StockPrice{
+		price("7203", day2, "stooq", fetched),
+		price("7203", day2, "yahoo", fetched),
+		price("7203", day1, "", fetched),
+		price("7203", day1, "other", fetched),
+		price("6758", day1, "yahoo", fetched),
+		price("6758", day1, "yahoo", fetched.Add(time.Hour)),
+		price("6758", day1, "jquants", fetched.Add(-time.Hour)),
+	}
+
+	type resolved struct {
+		Code, Date, Source string
+	}
+	var got []resolved
+	for _, p := range priority.Resolve(prices) {
+		got = append(got, resolved{Code: p.Code, Date: p.Date.Format("2006-01-02"), Source: p.Source})
+	}
+	want := []resolved{
+		{Code: "6758", Date: "2024-06-03", Source: "jquants"},
+		{Code: "7203", Date: "2024-06-03", Source: "other"},
+		{Code: "7203", Date: "2024-06-04", Source: "yahoo"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Resolve() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The latest fetch wins between prices of the same source
+	latest := priority.Resolve(prices[4:6])
+	if len(latest) != 1 || !latest[0].FetchedAt.Time.Equal(fetched.Add(time.Hour)) {
+		t.Errorf("Resolve() should keep the latest fetch of the same source, got %+v", latest)
+	}
+
+	if !priority.Outranks("yahoo", "") || priority.Outranks("", "other") || priority.Outranks("stooq", "yahoo") {
+		t.Error("Outranks() should rank listed sources, then unknown sources, then prices without a source")
+	}
+}
+
+func TestMarkFinalPrices(t *testing.T) {
+	today := time.Date(2024, 6, 7, 18, 0, 0, 0, time.Local)
+	prices := []*models.StockPrice{
+		{Code: "7203", Date: time.Date(2024, 6, 6, 0, 0, 0, 0, time.Local)},
+		{Code: "7203", Date: time.Date(2024, 6, 7, 0, 0, 0, 0, time.Local)},
+	}
+
+	MarkFinalPrices(prices, today)
+
+	if !prices[0].Final || prices[1].Final {
+		t.Errorf("MarkFinalPrices() final = %t, %t, want only the previous day final", prices[0].Final, prices[1].Final)
+	}
+}
@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// reportDivider is the rule drawn under the section headings of text reports.
+const reportDivider = "━━━━━━━━━━━━━━━━━━━━"
+
+// ReportBlockKind is the kind of a block of a report document.
+type ReportBlockKind int
+
+const (
+	// ReportHeading starts a section.
+	ReportHeading ReportBlockKind = iota
+	// ReportText is a line of text.
+	ReportText
+	// ReportItem is an entry of a list, e.g. a stock, with its details on indented lines.
+	ReportItem
+	// ReportBullet is an entry of a bulleted list.
+	ReportBullet
+	// ReportTable is a table with a header row.
+	ReportTable
+	// ReportBlank separates blocks.
+	ReportBlank
+)
+
+// ReportBlock is a block of a report document.
+type ReportBlock struct {
+	Kind    ReportBlockKind
+	Icon    string // emoji decoration, left out by renderers that cannot show it
+	Text    string
+	Details []string // indented lines of an item
+	Table   *ReportTableData
+}
+
+// ReportTableData is the content of a table block.
+type ReportTableData struct {
+	Headers    []string
+	Rows       [][]string
+	AlignRight []bool // columns aligned to the right, e.g. amounts
+}
+
+// ReportDocument is the structure of a report, independent of the output it is rendered to.
+type ReportDocument struct {
+	Title    string
+	Subtitle string
+	Blocks   []ReportBlock
+}
+
+// ReportRenderer renders report documents for an output such as Slack, email or PDF.
+type ReportRenderer interface {
+	Render(w io.Writer, doc *ReportDocument) error
+}
+
+// ReportBuilder assembles a report document block by block.
+type ReportBuilder struct {
+	doc ReportDocument
+}
+
+// NewReportBuilder creates a report builder for a report with the title.
+func NewReportBuilder(title string) *ReportBuilder {
+	return &ReportBuilder{doc: ReportDocument{Title: title}}
+}
+
+// Subtitle sets the line shown under the title, e.g. the period of the report.
+func (b *ReportBuilder) Subtitle(text string) *ReportBuilder {
+	b.doc.Subtitle = text
+	return b
+}
+
+// Section starts a section with the heading. An empty heading only divides the title from the body.
+func (b *ReportBuilder) Section(icon, heading string) *ReportBuilder {
+	return b.add(ReportBlock{Kind: ReportHeading, Icon: icon, Text: heading})
+}
+
+// Line adds a line of text.
+func (b *ReportBuilder) Line(text string) *ReportBuilder {
+	return b.add(ReportBlock{Kind: ReportText, Text: text})
+}
+
+// Item adds a list entry with its details on indented lines.
+func (b *ReportBuilder) Item(icon, text string, details ...string) *ReportBuilder {
+	return b.add(ReportBlock{Kind: ReportItem, Icon: icon, Text: text, Details: details})
+}
+
+// Bullet adds an entry of a bulleted list.
+func (b *ReportBuilder) Bullet(text string) *ReportBuilder {
+	return b.add(ReportBlock{Kind: ReportBullet, Text: text})
+}
+
+// Table adds a table. alignRight lists the columns aligned to the right.
+func (b *ReportBuilder) Table(headers []string, rows [][]string, alignRight ...bool) *ReportBuilder {
+	return b.add(ReportBlock{Kind: ReportTable, Table: &ReportTableData{Headers: headers, Rows: rows, AlignRight: alignRight}})
+}
+
+// Blank adds an empty line between blocks.
+func (b *ReportBuilder) Blank() *ReportBuilder {
+	return b.add(ReportBlock{Kind: ReportBlank})
+}
+
+// Document returns the assembled document.
+func (b *ReportBuilder) Document() *ReportDocument {
+	doc := b.doc
+	doc.Blocks = append([]ReportBlock(nil), b.doc.Blocks...)
+	return &doc
+}
+
+// String renders the document as the plain text of the notifications.
+func (b *ReportBuilder) String() string {
+	return RenderReportText(b.Document())
+}
+
+func (b *ReportBuilder) add(block ReportBlock) *ReportBuilder {
+	b.doc.Blocks = append(b.doc.Blocks, block)
+	return b
+}
+
+// TextRenderer renders report documents as plain text with emoji, as posted to Slack and
+// Discord and sent as the body of emails.
+type TextRenderer struct{}
+
+// Render writes the document as text.
+func (TextRenderer) Render(w io.Writer, doc *ReportDocument) error {
+	var text strings.Builder
+	text.WriteString(doc.Title + "\n")
+	if doc.Subtitle != "" {
+		text.WriteString(doc.Subtitle + "\n")
+	}
+
+	for _, block := range doc.Blocks {
+		switch block.Kind {
+		case ReportHeading:
+			// A section without a heading just divides the title from the body
+			if block.Text != "" {
+				text.WriteString(withIcon(block.Icon, block.Text) + "\n")
+			}
+			text.WriteString(reportDivider + "\n")
+		case ReportText:
+			text.WriteString(block.Text + "\n")
+		case ReportItem:
+			text.WriteString(withIcon(block.Icon, block.Text) + "\n")
+			for _, detail := range block.Details {
+				text.WriteString("  " + detail + "\n")
+			}
+		case ReportBullet:
+			text.WriteString("• " + block.Text + "\n")
+		case ReportTable:
+			writeTextTable(&text, block.Table)
+		case ReportBlank:
+			text.WriteString("\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, text.String()); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// RenderReportText renders the document with the text renderer.
+func RenderReportText(doc *ReportDocument) string {
+	var text strings.Builder
+	_ = TextRenderer{}.Render(&text, doc)
+	return text.String()
+}
+
+// MarkdownRenderer renders report documents as Markdown, e.g. for HTML emails and webhooks
+// that format Markdown.
+type MarkdownRenderer struct{}
+
+// Render writes the document as Markdown.
+func (MarkdownRenderer) Render(w io.Writer, doc *ReportDocument) error {
+	var text strings.Builder
+	text.WriteString("# " + doc.Title + "\n")
+	if doc.Subtitle != "" {
+		text.WriteString("\n" + doc.Subtitle + "\n")
+	}
+
+	for _, block := range doc.Blocks {
+		switch block.Kind {
+		case ReportHeading:
+			if block.Text != "" {
+				text.WriteString("\n## " + withIcon(block.Icon, block.Text) + "\n\n")
+			}
+		case ReportText:
+			text.WriteString(block.Text + "  \n")
+		case ReportItem:
+			text.WriteString("- **" + withIcon(block.Icon, block.Text) + "**\n")
+			for _, detail := range block.Details {
+				text.WriteString("  - " + detail + "\n")
+			}
+		case ReportBullet:
+			text.WriteString("- " + block.Text + "\n")
+		case ReportTable:
+			writeMarkdownTable(&text, block.Table)
+		case ReportBlank:
+			text.WriteString("\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, text.String()); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// withIcon prefixes the text with the icon if any.
+func withIcon(icon, text string) string {
+	if icon == "" {
+		return text
+	}
+	return icon + " " + text
+}
+
+// writeTextTable writes the table with its columns padded to the display width of their cells.
+func writeTextTable(text *strings.Builder, table *ReportTableData) {
+	widths := make([]int, len(table.Headers))
+	for _, row := range append([][]string{table.Headers}, table.Rows...) {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], displayWidth(cell))
+			}
+		}
+	}
+
+	writeRow := func(row []string) {
+		cells := make([]string, len(widths))
+		for i := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			padding := strings.Repeat(" ", widths[i]-displayWidth(cell))
+			if i < len(table.AlignRight) && table.AlignRight[i] {
+				cells[i] = padding + cell
+			} else {
+				cells[i] = cell + padding
+			}
+		}
+		text.WriteString(strings.TrimRight(strings.Join(cells, "  "), " ") + "\n")
+	}
+
+	writeRow(table.Headers)
+	for _, row := range table.Rows {
+		writeRow(row)
+	}
+}
+
+func writeMarkdownTable(text *strings.Builder, table *ReportTableData) {
+	text.WriteString("| " + strings.Join(table.Headers, " | ") + " |\n|")
+	for i := range table.Headers {
+		if i < len(table.AlignRight) && table.AlignRight[i] {
+			text.WriteString(" ---: |")
+		} else {
+			text.WriteString(" --- |")
+		}
+	}
+	text.WriteString("\n")
+	for _, row := range table.Rows {
+		text.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+}
+
+// displayWidth returns the width of the text in a monospaced font, counting full-width
+// characters such as kanji and emoji as two columns.
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case r == 0xfe0f || r == 0x200d:
+			// Variation selectors and joiners take no space
+		case r >= 0x1100 && r <= 0x115f,
+			r >= 0x2e80 && r <= 0xa4cf,
+			r >= 0xac00 && r <= 0xd7a3,
+			r >= 0xf900 && r <= 0xfaff,
+			r >= 0xfe30 && r <= 0xfe4f,
+			r >= 0xff00 && r <= 0xff60,
+			r >= 0xffe0 && r <= 0xffe6,
+			r >= 0x1f300 && r <= 0x1faff,
+			r >= 0x20000 && r <= 0x3fffd:
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestReport() *ReportBuilder {
+	return NewReportBuilder("📊 レポート").
+		Subtitle("2024-01-05").
+		Section("💼", "保有銘柄").
+		Item("📈", "トヨタ (7203)", "評価額: ¥1,000", "損益: +10.00%").
+		Bullet("メモ").
+		Blank().
+		Section("", "一覧").
+		Table([]string{"コード", "銘柄", "評価額"}, [][]string{{"7203", "トヨタ", "¥1,000"}, {"9984", "SBG", "¥12,345"}}, false, false, true)
+}
+
+func TestTextRenderer_Render(t *testing.T) {
+	want := `📊 レポート
+2024-01-05
+💼 保有銘柄
+━━━━━━━━━━━━━━━━━━━━
+📈 トヨタ (7203)
+  評価額: ¥1,000
+  損益: +10.00%
+• メモ
+
+一覧
+━━━━━━━━━━━━━━━━━━━━
+コード  銘柄     評価額
+7203    トヨタ   ¥1,000
+9984    SBG     ¥12,345
+`
+	if diff := cmp.Diff(want, newTestReport().String()); diff != "" {
+		t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarkdownRenderer_Render(t *testing.T) {
+	var text strings.Builder
+	if err := (MarkdownRenderer{}).Render(&text, newTestReport().Document()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# 📊 レポート\n",
+		"## 💼 保有銘柄\n",
+		"- **📈 トヨタ (7203)**\n  - 評価額: ¥1,000\n",
+		"| コード | 銘柄 | 評価額 |\n| --- | --- | ---: |\n| 7203 | トヨタ | ¥1,000 |\n",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Render() missing %q in:\n%s", want, text.String())
+		}
+	}
+}
+
+func TestReportBuilder_Document(t *testing.T) {
+	builder := NewReportBuilder("title").Line("a")
+	doc := builder.Document()
+	builder.Line("b")
+
+	// Later blocks do not change a document already returned
+	if len(doc.Blocks) != 1 {
+		t.Errorf("Blocks = %d, want 1", len(doc.Blocks))
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	for text, want := range map[string]int{"7203": 4, "トヨタ": 6, "¥1,000": 6, "⚠️": 1, "📈": 2} {
+		if got := displayWidth(text); got != want {
+			t.Errorf("displayWidth(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
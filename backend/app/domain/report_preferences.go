@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// ReportSection is an optional section of the daily report.
+type ReportSection string
+
+// Sections of the daily report that can be turned off. The totals are always shown.
+const (
+	ReportSectionMarket        ReportSection = "market"        // closes of the market indices and the benchmark
+	ReportSectionHoldings      ReportSection = "holdings"      // details of each holding
+	ReportSectionAllocations   ReportSection = "allocations"   // value by asset class
+	ReportSectionBuyingPower   ReportSection = "buying_power"  // cash available for purchases
+	ReportSectionTechnicals    ReportSection = "technicals"    // technical views of the listed holdings
+	ReportSectionContributions ReportSection = "contributions" // rankings of the holdings by contribution
+	ReportSectionGoals         ReportSection = "goals"         // progress of the goals
+)
+
+// ReportSections lists the optional sections in the order of the report.
+var ReportSections = []ReportSection{
+	ReportSectionMarket,
+	ReportSectionHoldings,
+	ReportSectionAllocations,
+	ReportSectionBuyingPower,
+	ReportSectionTechnicals,
+	ReportSectionContributions,
+	ReportSectionGoals,
+}
+
+// ParseReportSection returns the section of the given name.
+func ParseReportSection(name string) (ReportSection, error) {
+	for _, section := range ReportSections {
+		if string(section) == name {
+			return section, nil
+		}
+	}
+
+	names := make([]string, len(ReportSections))
+	for i, section := range ReportSections {
+		names[i] = string(section)
+	}
+	return "", fmt.Errorf("不明なレポート項目です: %s (%s のいずれかを指定してください)", name, strings.Join(names, ", "))
+}
+
+// ReportPreferences holds which sections of the daily report are shown.
+// Sections not set are shown, so the zero value shows the full report.
+type ReportPreferences map[ReportSection]bool
+
+// NewReportPreferences returns the preferences of the stored settings, ignoring unknown sections.
+func NewReportPreferences(preferences []*models.ReportPreference) ReportPreferences {
+	prefs := make(ReportPreferences, len(preferences))
+	for _, preference := range preferences {
+		if section, err := ParseReportSection(preference.Section); err == nil {
+			prefs[section] = preference.Enabled
+		}
+	}
+	return prefs
+}
+
+// Enabled reports whether the section is shown.
+func (p ReportPreferences) Enabled(section ReportSection) bool {
+	enabled, ok := p[section]
+	return !ok || enabled
+}
+
+// Apply leaves the sections turned off out of the summary of the daily report. The holdings stay
+// in the summary for the totals but are marked to be left out of the report.
+func (p ReportPreferences) Apply(summary *PortfolioSummary) {
+	if !p.Enabled(ReportSectionMarket) {
+		summary.Market = nil
+	}
+	summary.HideHoldings = !p.Enabled(ReportSectionHoldings)
+	if !p.Enabled(ReportSectionAllocations) {
+		summary.Allocations = nil
+	}
+	if !p.Enabled(ReportSectionBuyingPower) {
+		summary.BuyingPower = nil
+	}
+	if !p.Enabled(ReportSectionTechnicals) {
+		summary.Technicals = nil
+	}
+	if !p.Enabled(ReportSectionContributions) {
+		summary.Contributions = nil
+	}
+	if !p.Enabled(ReportSectionGoals) {
+		summary.Goals = nil
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+func TestParseReportSection(t *testing.T) {
+	section, err := ParseReportSection("technicals")
+	if err != nil || section != ReportSectionTechnicals {
+		t.Errorf("ParseReportSection(technicals) = %q, %v", section, err)
+	}
+	if _, err := ParseReportSection("news"); err == nil || !strings.Contains(err.Error(), "holdings") {
+		t.Errorf("ParseReportSection(news) error = %v, want the valid sections listed", err)
+	}
+}
+
+func TestReportPreferences_Apply(t *testing.T) {
+	prefs := NewReportPreferences([]*models.ReportPreference{
+		{Section: "holdings", Enabled: false},
+		{Section: "goals", Enabled: false},
+		{Section: "technicals", Enabled: true},
+		{Section: "news", Enabled: false},
+	})
+	if len(prefs) != 3 {
+		t.Errorf("NewReportPreferences() kept %d sections, want the unknown one ignored", len(prefs))
+	}
+	if !prefs.Enabled(ReportSectionAllocations) || !prefs.Enabled(ReportSectionTechnicals) || prefs.Enabled(ReportSectionGoals) {
+		t.Errorf("Enabled() = %v, want sections not set shown", prefs)
+	}
+
+	summary := &PortfolioSummary{
+		TotalValue: 110000,
+		TotalCost:  100000,
+		Holdings:   []HoldingSummary{{Code: "1234", Name: "Test Stock", Shares: 100}},
+		Goals:      []GoalProgress{{}},
+	}
+	prefs.Apply(summary)
+	if !summary.HideHoldings || summary.Goals != nil || len(summary.Holdings) != 1 {
+		t.Errorf("Apply() = %+v, want the holdings hidden but kept and the goals removed", summary)
+	}
+
+	report := GeneratePortfolioReport(summary)
+	if strings.Contains(report, "個別銘柄") || strings.Contains(report, "Test Stock") {
+		t.Errorf("report shows the hidden holdings:\n%s", report)
+	}
+	if !strings.Contains(report, "総資産状況") {
+		t.Errorf("report lacks the totals:\n%s", report)
+	}
+
+	// The zero value shows every section
+	var none ReportPreferences
+	summary = &PortfolioSummary{Holdings: []HoldingSummary{{Code: "1234"}}}
+	none.Apply(summary)
+	if summary.HideHoldings {
+		t.Error("Apply() of no preferences hid the holdings")
+	}
+}
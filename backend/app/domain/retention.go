@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RetentionAction is what the cleanup does with the rows past their retention period.
+type RetentionAction string
+
+// Actions of a retention rule.
+const (
+	RetentionDelete  RetentionAction = "delete"  // delete the rows
+	RetentionArchive RetentionAction = "archive" // write the rows to a file of the archive directory, then delete them
+)
+
+// RetentionTarget is a set of rows a retention rule can apply to, aged by a date or time column.
+type RetentionTarget struct {
+	Name        string
+	Table       string
+	Column      string // date or time column the age of a row is taken from
+	Condition   string // SQL condition narrowing the rows of the table, empty for all
+	Description string
+}
+
+// RetentionTargets lists the data a retention policy can declare periods for. Holdings, trades
+// and cash transactions are records rather than collected data, so they are never cleaned up.
+var RetentionTargets = []RetentionTarget{
+	{Name: "intraday", Table: "raw_responses", Column: "created_at", Condition: "endpoint = 'intraday'", Description: "分足の取得レスポンス"},
+	{Name: "stock_prices", Table: "stock_prices", Column: "date", Description: "日足"},
+	{Name: "stock_prices_weekly", Table: "stock_prices_weekly", Column: "period_end", Description: "週足"},
+	{Name: "stock_prices_monthly", Table: "stock_prices_monthly", Column: "period_end", Description: "月足"},
+	{Name: "technical_indicators", Table: "technical_indicators", Column: "date", Description: "テクニカル指標"},
+	{Name: "raw_responses", Table: "raw_responses", Column: "created_at", Description: "保存した取得レスポンス"},
+	{Name: "alert_rule_evaluations", Table: "alert_rule_evaluations", Column: "evaluated_at", Description: "アラートルールの評価履歴"},
+	{Name: "suppressed_alerts", Table: "suppressed_alerts", Column: "suppressed_at", Description: "メンテナンス中に抑制したアラート"},
+	{Name: "advice_logs", Table: "advice_logs", Column: "created_at", Description: "アドバイスの記録"},
+	{Name: "audit_logs", Table: "audit_logs", Column: "created_at", Description: "監査ログ"},
+}
+
+// GetRetentionTarget returns the retention target of the given name.
+func GetRetentionTarget(name string) (RetentionTarget, error) {
+	for _, target := range RetentionTargets {
+		if target.Name == name {
+			return target, nil
+		}
+	}
+
+	names := make([]string, len(RetentionTargets))
+	for i, target := range RetentionTargets {
+		names[i] = target.Name
+	}
+	return RetentionTarget{}, fmt.Errorf("保持ポリシーの対象にできないデータです: %s (%s のいずれかを指定してください)", name, strings.Join(names, ", "))
+}
+
+// RetentionPeriod is how long rows are kept, in calendar years, months and days.
+type RetentionPeriod struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ParseRetentionPeriod parses a period such as "30d", "8w", "6m" or "5y".
+func ParseRetentionPeriod(s string) (RetentionPeriod, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return RetentionPeriod{}, fmt.Errorf("保持期間が不正です: %q (30d, 8w, 6m, 5y のように指定してください)", s)
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return RetentionPeriod{}, fmt.Errorf("保持期間が不正です: %q (1以上の数に d, w, m, y を付けて指定してください)", s)
+	}
+
+	switch s[len(s)-1] {
+	case 'd':
+		return RetentionPeriod{Days: n}, nil
+	case 'w':
+		return RetentionPeriod{Days: n * 7}, nil
+	case 'm':
+		return RetentionPeriod{Months: n}, nil
+	case 'y':
+		return RetentionPeriod{Years: n}, nil
+	default:
+		return RetentionPeriod{}, fmt.Errorf("保持期間の単位が不正です: %q (d, w, m, y のいずれかを付けて指定してください)", s)
+	}
+}
+
+// Cutoff returns the start of the first day kept on the given day. Rows dated before it are past the period.
+func (p RetentionPeriod) Cutoff(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return day.AddDate(-p.Years, -p.Months, -p.Days)
+}
+
+// String returns the period in the notation of ParseRetentionPeriod.
+func (p RetentionPeriod) String() string {
+	switch {
+	case p.Years > 0:
+		return fmt.Sprintf("%dy", p.Years)
+	case p.Months > 0:
+		return fmt.Sprintf("%dm", p.Months)
+	default:
+		return fmt.Sprintf("%dd", p.Days)
+	}
+}
+
+// RetentionRule is the retention period of a target and what is done with the rows past it.
+type RetentionRule struct {
+	Target RetentionTarget
+	Keep   RetentionPeriod
+	Action RetentionAction
+}
+
+// RetentionPolicy is the retention rules of the data, applied by the cleanup job.
+type RetentionPolicy struct {
+	ArchiveDir string          // directory the archived rows are written to
+	Rules      []RetentionRule // in the order of RetentionTargets
+}
+
+// DefaultRetentionPolicy returns the policy used without a policy file, which deletes the daily
+// prices older than a year.
+func DefaultRetentionPolicy() *RetentionPolicy {
+	target, _ := GetRetentionTarget("stock_prices")
+	return &RetentionPolicy{
+		Rules: []RetentionRule{{Target: target, Keep: RetentionPeriod{Days: 365}, Action: RetentionDelete}},
+	}
+}
+
+// retentionPolicyFile is the YAML form of a retention policy.
+type retentionPolicyFile struct {
+	ArchiveDir string                         `yaml:"archive_dir"`
+	Targets    map[string]retentionPolicyRule `yaml:"targets"`
+}
+
+type retentionPolicyRule struct {
+	Keep   string `yaml:"keep"`
+	Action string `yaml:"action"`
+}
+
+// ParseRetentionPolicy parses and validates a YAML retention policy, for example:
+//
+//	archive_dir: data/archive
+//	targets:
+//	  intraday:
+//	    keep: 30d
+//	  stock_prices:
+//	    keep: 5y
+//	    action: archive
+//
+// The action defaults to delete. Unknown fields and targets are rejected.
+func ParseRetentionPolicy(raw []byte) (*RetentionPolicy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+
+	file := &retentionPolicyFile{}
+	if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("保持ポリシーファイルのYAMLが不正です: %w", err)
+	}
+
+	for name := range file.Targets {
+		if _, err := GetRetentionTarget(name); err != nil {
+			return nil, err
+		}
+	}
+
+	policy := &RetentionPolicy{ArchiveDir: file.ArchiveDir}
+	for _, target := range RetentionTargets {
+		rule, ok := file.Targets[target.Name]
+		if !ok {
+			continue
+		}
+
+		keep, err := ParseRetentionPeriod(rule.Keep)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", target.Name, err)
+		}
+
+		action := RetentionAction(rule.Action)
+		switch action {
+		case "":
+			action = RetentionDelete
+		case RetentionDelete:
+		case RetentionArchive:
+			if policy.ArchiveDir == "" {
+				return nil, fmt.Errorf("%s: アーカイブするには archive_dir を指定してください", target.Name)
+			}
+		default:
+			return nil, fmt.Errorf("%s: 不明な操作です: %s (delete または archive を指定してください)", target.Name, rule.Action)
+		}
+
+		policy.Rules = append(policy.Rules, RetentionRule{Target: target, Keep: keep, Action: action})
+	}
+	return policy, nil
+}
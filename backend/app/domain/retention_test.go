@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRetentionPeriod(t *testing.T) {
+	now := time.Date(2026, 3, 15, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		spec       string
+		wantCutoff time.Time
+	}{
+		{"30d", time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"6m", time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC)},
+		{"5y", time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		period, err := ParseRetentionPeriod(tt.spec)
+		if err != nil {
+			t.Errorf("ParseRetentionPeriod(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got := period.Cutoff(now); !got.Equal(tt.wantCutoff) {
+			t.Errorf("ParseRetentionPeriod(%q).Cutoff() = %v, want %v", tt.spec, got, tt.wantCutoff)
+		}
+	}
+
+	for _, spec := range []string{"", "d", "0d", "-1y", "10", "3h", "1.5y"} {
+		if _, err := ParseRetentionPeriod(spec); err == nil {
+			t.Errorf("ParseRetentionPeriod(%q) should fail", spec)
+		}
+	}
+}
+
+func TestParseRetentionPolicy(t *testing.T) {
+	raw := []byte(`
+archive_dir: data/archive
+targets:
+  technical_indicators:
+    keep: 2y
+  stock_prices:
+    keep: 5y
+    action: archive
+  intraday:
+    keep: 30d
+`)
+
+	policy, err := ParseRetentionPolicy(raw)
+	if err != nil {
+		t.Fatalf("ParseRetentionPolicy() error = %v", err)
+	}
+
+	type rule struct {
+		Target string
+		Keep   string
+		Action RetentionAction
+	}
+	var got []rule
+	for _, r := range policy.Rules {
+		got = append(got, rule{r.Target.Name, r.Keep.String(), r.Action})
+	}
+	// Rules follow the order of RetentionTargets, not of the file
+	want := []rule{
+		{"intraday", "30d", RetentionDelete},
+		{"stock_prices", "5y", RetentionArchive},
+		{"technical_indicators", "2y", RetentionDelete},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("rules mismatch (-want +got):\n%s", diff)
+	}
+	if policy.ArchiveDir != "data/archive" {
+		t.Errorf("ArchiveDir = %q, want data/archive", policy.ArchiveDir)
+	}
+
+	invalid := map[string]string{
+		"unknown target":         "targets:\n  portfolios:\n    keep: 1y\n",
+		"unknown field":          "tables:\n  stock_prices:\n    keep: 1y\n",
+		"missing period":         "targets:\n  stock_prices:\n    action: delete\n",
+		"unknown action":         "targets:\n  stock_prices:\n    keep: 1y\n    action: move\n",
+		"archive without a dir":  "targets:\n  stock_prices:\n    keep: 1y\n    action: archive\n",
+		"invalid period of rule": "targets:\n  raw_responses:\n    keep: 30\n",
+	}
+	for name, raw := range invalid {
+		if _, err := ParseRetentionPolicy([]byte(raw)); err == nil {
+			t.Errorf("%s: ParseRetentionPolicy() should fail", name)
+		}
+	}
+
+	empty, err := ParseRetentionPolicy(nil)
+	if err != nil {
+		t.Fatalf("an empty file should be a policy without rules: %v", err)
+	}
+	if len(empty.Rules) != 0 {
+		t.Errorf("an empty file should declare no rules, got %d", len(empty.Rules))
+	}
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// MaxSavingsPlanYears is the longest period a savings plan is projected over.
+const MaxSavingsPlanYears = 50
+
+// SavingsPlan is a plan of monthly contributions compounding at an expected annual return.
+type SavingsPlan struct {
+	Initial      float64 // value at the start of the plan
+	Monthly      float64 // contribution added at the end of each month
+	AnnualReturn float64 // expected return per year in percent
+}
+
+// Validate checks the amounts and the expected return of the plan.
+func (p SavingsPlan) Validate() error {
+	if p.Initial < 0 || p.Monthly < 0 {
+		return fmt.Errorf("初期資産と毎月の積立額は0以上で指定してください")
+	}
+	if p.AnnualReturn <= -100 || p.AnnualReturn > 100 {
+		return fmt.Errorf("想定利回りは-100%%より大きく100%%以下で指定してください: %.2f", p.AnnualReturn)
+	}
+	return nil
+}
+
+// monthlyRate returns the monthly return compounding to the annual return.
+func (p SavingsPlan) monthlyRate() float64 {
+	return math.Pow(1+p.AnnualReturn/100, 1.0/12) - 1
+}
+
+// valueAfter returns the planned value after the months from a start value, with the monthly
+// contributions added at the end of each month after it has grown.
+func (p SavingsPlan) valueAfter(start float64, months int) float64 {
+	rate := p.monthlyRate()
+	value := start
+	for i := 0; i < months; i++ {
+		value = value*(1+rate) + p.Monthly
+	}
+	return value
+}
+
+// PlanProjection is the planned value at the end of a year of a savings plan.
+type PlanProjection struct {
+	Year        int
+	Contributed float64 // initial value and contributions so far
+	Value       float64
+}
+
+// Gain returns the planned gain on the contributions.
+func (p PlanProjection) Gain() float64 {
+	return p.Value - p.Contributed
+}
+
+// Project returns the planned value at the end of each of the years.
+func (p SavingsPlan) Project(years int) []PlanProjection {
+	projections := make([]PlanProjection, 0, years)
+	for year := 1; year <= years; year++ {
+		months := year * 12
+		projections = append(projections, PlanProjection{
+			Year:        year,
+			Contributed: p.Initial + p.Monthly*float64(months),
+			Value:       p.valueAfter(p.Initial, months),
+		})
+	}
+	return projections
+}
+
+// PlanComparison compares the portfolio at the end of a month with the savings plan.
+type PlanComparison struct {
+	Date               time.Time // date of the last snapshot of the month
+	Months             int       // months since the start of the comparison
+	PlannedContributed float64
+	PlannedValue       float64
+	ActualContributed  float64 // cost of the portfolio
+	ActualValue        float64
+}
+
+// Difference returns how far the actual value is ahead of the plan, negative if behind.
+func (c PlanComparison) Difference() float64 {
+	return c.ActualValue - c.PlannedValue
+}
+
+// ComparePlan compares the portfolio snapshots from the day of since with the savings plan started
+// from the first of them: the plan starts at its value and cost instead of its initial value, and
+// each later month is compared at its last snapshot. The first comparison is the start itself.
+// Returns nil without snapshots.
+func ComparePlan(plan SavingsPlan, snapshots []*models.PortfolioSnapshot, since time.Time) []PlanComparison {
+	sorted := make([]*models.PortfolioSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if !snapshot.SnapshotDate.Before(models.TruncateToDate(since)) {
+			sorted = append(sorted, snapshot)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].SnapshotDate.Before(sorted[j].SnapshotDate)
+	})
+
+	start := sorted[0]
+	comparisons := []PlanComparison{{
+		Date:               start.SnapshotDate,
+		PlannedContributed: start.TotalCost,
+		PlannedValue:       start.TotalValue,
+		ActualContributed:  start.TotalCost,
+		ActualValue:        start.TotalValue,
+	}}
+	for i, snapshot := range sorted[1:] {
+		// Only the last snapshot of each month after the start month
+		if next := sorted[i+2:]; len(next) > 0 && sameMonth(next[0].SnapshotDate, snapshot.SnapshotDate) {
+			continue
+		}
+		months := monthsBetween(start.SnapshotDate, snapshot.SnapshotDate)
+		if months == 0 {
+			continue
+		}
+		comparisons = append(comparisons, PlanComparison{
+			Date:               snapshot.SnapshotDate,
+			Months:             months,
+			PlannedContributed: start.TotalCost + plan.Monthly*float64(months),
+			PlannedValue:       plan.valueAfter(start.TotalValue, months),
+			ActualContributed:  snapshot.TotalCost,
+			ActualValue:        snapshot.TotalValue,
+		})
+	}
+	return comparisons
+}
+
+// AverageMonthlyContribution returns the actual contribution per month over the comparisons, from the
+// increase of the cost of the portfolio. Returns 0 before a month has passed.
+func AverageMonthlyContribution(comparisons []PlanComparison) float64 {
+	if len(comparisons) < 2 {
+		return 0
+	}
+	first, last := comparisons[0], comparisons[len(comparisons)-1]
+	return (last.ActualContributed - first.ActualContributed) / float64(last.Months)
+}
+
+func sameMonth(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
+
+// monthsBetween returns the calendar months from the month of from to the month of to.
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+func TestSavingsPlan_Project(t *testing.T) {
+	// Without a return the value is the contributions
+	flat := SavingsPlan{Initial: 100000, Monthly: 10000}.Project(2)
+	if len(flat) != 2 || flat[1].Contributed != 340000 || flat[1].Value != 340000 || flat[1].Gain() != 0 {
+		t.Errorf("Project() without a return = %+v, want ¥340,000 contributed and valued after 2 years", flat)
+	}
+
+	// The monthly return compounds to the annual return
+	lump := SavingsPlan{Initial: 1000000, AnnualReturn: 5}.Project(10)
+	if want := 1000000 * math.Pow(1.05, 10); math.Abs(lump[9].Value-want) > 0.01 {
+		t.Errorf("Project() value after 10 years = %.2f, want %.2f", lump[9].Value, want)
+	}
+
+	// ¥50,000 a month at 5% for 20 years grows to about ¥20.3 million on ¥12 million contributed
+	savings := SavingsPlan{Monthly: 50000, AnnualReturn: 5}.Project(20)
+	if got := savings[19]; got.Contributed != 12000000 || got.Value < 20200000 || got.Value > 20400000 {
+		t.Errorf("Project() after 20 years = %+v, want about ¥20.3 million", got)
+	}
+}
+
+func TestSavingsPlan_Validate(t *testing.T) {
+	for _, plan := range []SavingsPlan{{Monthly: -1}, {Initial: -1}, {Monthly: 1, AnnualReturn: -100}, {Monthly: 1, AnnualReturn: 150}} {
+		if err := plan.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", plan)
+		}
+	}
+	if err := (SavingsPlan{Monthly: 30000, AnnualReturn: -5}).Validate(); err != nil {
+		t.Errorf("Validate() of a negative return = %v", err)
+	}
+}
+
+func TestComparePlan(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.Local) }
+	snapshots := []*models.PortfolioSnapshot{
+		models.NewPortfolioSnapshot(day(3, 29), 1150000, 1100000, 3),
+		models.NewPortfolioSnapshot(day(1, 10), 1000000, 1000000, 3),
+		models.NewPortfolioSnapshot(day(1, 31), 1010000, 1000000, 3),
+		models.NewPortfolioSnapshot(day(2, 15), 1030000, 1050000, 3),
+		models.NewPortfolioSnapshot(day(2, 29), 1080000, 1050000, 3),
+		models.NewPortfolioSnapshot(day(4, 2), 1140000, 1100000, 3),
+	}
+	plan := SavingsPlan{Monthly: 50000}
+
+	got := ComparePlan(plan, snapshots, day(1, 1))
+	wantDates := []time.Time{day(1, 10), day(2, 29), day(3, 29), day(4, 2)}
+	if len(got) != len(wantDates) {
+		t.Fatalf("ComparePlan() = %d comparisons, want the start and the last snapshot of each later month: %+v", len(got), got)
+	}
+	for i, want := range wantDates {
+		if !got[i].Date.Equal(want) {
+			t.Errorf("comparison %d date = %s, want %s", i, got[i].Date.Format("2006-01-02"), want.Format("2006-01-02"))
+		}
+	}
+	if march := got[2]; march.Months != 2 || march.PlannedContributed != 1100000 || march.PlannedValue != 1100000 || march.Difference() != 50000 {
+		t.Errorf("comparison of March = %+v, want ¥1,100,000 planned and ¥50,000 ahead", march)
+	}
+	if average := AverageMonthlyContribution(got); math.Abs(average-100000.0/3) > 0.01 {
+		t.Errorf("AverageMonthlyContribution() = %.2f, want ¥100,000 over 3 months", average)
+	}
+
+	if got := ComparePlan(plan, snapshots, day(5, 1)); got != nil {
+		t.Errorf("ComparePlan() after the last snapshot = %+v, want nil", got)
+	}
+}
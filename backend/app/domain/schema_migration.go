@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// SchemaMigrationDefinition describes a schema change applied without stopping the application,
+// such as adding a column or changing its type through a new column.
+//
+// The new column is added by schema.sql beforehand, the application writes both the old and the
+// new column while the migration is in dual_write or later, and the rows written before that are
+// copied by the backfill in batches of KeyColumn order.
+type SchemaMigrationDefinition struct {
+	Name        string
+	Description string
+	Table       string
+	KeyColumn   string // unique column the backfill walks through in order, such as the primary key
+	Backfill    string // SET clause copying the old schema to the new one, e.g. "price_decimal = price"
+	Pending     string // WHERE condition of rows not backfilled yet, e.g. "price_decimal IS NULL"
+}
+
+// schemaMigrationDefinitions holds the registered schema migrations by name.
+var schemaMigrationDefinitions = map[string]SchemaMigrationDefinition{}
+
+// RegisterSchemaMigration registers a schema migration definition.
+// It panics on a duplicate or incomplete definition, as definitions are registered at init time.
+func RegisterSchemaMigration(def SchemaMigrationDefinition) {
+	if def.Name == "" || def.Table == "" || def.KeyColumn == "" || def.Backfill == "" || def.Pending == "" {
+		panic(fmt.Sprintf("incomplete schema migration definition: %+v", def))
+	}
+	if _, exists := schemaMigrationDefinitions[def.Name]; exists {
+		panic(fmt.Sprintf("schema migration %s is already registered", def.Name))
+	}
+	schemaMigrationDefinitions[def.Name] = def
+}
+
+// GetSchemaMigrationDefinition returns the schema migration definition with the given name.
+func GetSchemaMigrationDefinition(name string) (SchemaMigrationDefinition, error) {
+	def, ok := schemaMigrationDefinitions[name]
+	if !ok {
+		return SchemaMigrationDefinition{}, fmt.Errorf("マイグレーション %s は登録されていません", name)
+	}
+	return def, nil
+}
+
+// SchemaMigrationDefinitions returns the registered schema migration definitions ordered by name.
+func SchemaMigrationDefinitions() []SchemaMigrationDefinition {
+	defs := make([]SchemaMigrationDefinition, 0, len(schemaMigrationDefinitions))
+	for _, def := range schemaMigrationDefinitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// NextSchemaMigrationPhase returns the phase a migration advances to from the given phase.
+// A migration in dual_write advances by backfilling, and a backfilling migration only advances
+// once no rows are left to backfill.
+func NextSchemaMigrationPhase(migration *models.SchemaMigration, remaining int64) (models.SchemaMigrationPhase, error) {
+	switch migration.Phase {
+	case models.SchemaMigrationPending:
+		return models.SchemaMigrationDualWrite, nil
+	case models.SchemaMigrationDualWrite:
+		return "", fmt.Errorf("バックフィルが実行されていません。backfill を実行してください")
+	case models.SchemaMigrationBackfilling:
+		if remaining > 0 {
+			return "", fmt.Errorf("バックフィルが完了していません (残り %d 行)", remaining)
+		}
+		return models.SchemaMigrationReadNew, nil
+	case models.SchemaMigrationReadNew:
+		return models.SchemaMigrationCompleted, nil
+	default:
+		return "", fmt.Errorf("マイグレーション %s は完了済みです", migration.Name)
+	}
+}
+
+// PreviousSchemaMigrationPhase returns the phase a migration rolls back to from the given phase.
+// Reads go back to the old schema first, and then writes to the new schema stop.
+// A completed migration cannot be rolled back as the old schema is no longer written.
+func PreviousSchemaMigrationPhase(migration *models.SchemaMigration) (models.SchemaMigrationPhase, error) {
+	switch migration.Phase {
+	case models.SchemaMigrationReadNew:
+		return models.SchemaMigrationBackfilling, nil
+	case models.SchemaMigrationDualWrite, models.SchemaMigrationBackfilling:
+		return models.SchemaMigrationPending, nil
+	case models.SchemaMigrationCompleted:
+		return "", fmt.Errorf("完了したマイグレーション %s はロールバックできません", migration.Name)
+	default:
+		return "", fmt.Errorf("マイグレーション %s は開始されていません", migration.Name)
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+func TestNextSchemaMigrationPhase(t *testing.T) {
+	tests := []struct {
+		phase     models.SchemaMigrationPhase
+		remaining int64
+		want      models.SchemaMigrationPhase
+		wantErr   bool
+	}{
+		{phase: models.SchemaMigrationPending, want: models.SchemaMigrationDualWrite},
+		{phase: models.SchemaMigrationDualWrite, wantErr: true},
+		{phase: models.SchemaMigrationBackfilling, remaining: 3, wantErr: true},
+		{phase: models.SchemaMigrationBackfilling, want: models.SchemaMigrationReadNew},
+		{phase: models.SchemaMigrationReadNew, want: models.SchemaMigrationCompleted},
+		{phase: models.SchemaMigrationCompleted, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NextSchemaMigrationPhase(&models.SchemaMigration{Name: "test", Phase: tt.phase}, tt.remaining)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NextSchemaMigrationPhase(%s, %d) = %q, %v, want %q (error: %v)", tt.phase, tt.remaining, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPreviousSchemaMigrationPhase(t *testing.T) {
+	tests := []struct {
+		phase   models.SchemaMigrationPhase
+		want    models.SchemaMigrationPhase
+		wantErr bool
+	}{
+		{phase: models.SchemaMigrationPending, wantErr: true},
+		{phase: models.SchemaMigrationDualWrite, want: models.SchemaMigrationPending},
+		{phase: models.SchemaMigrationBackfilling, want: models.SchemaMigrationPending},
+		{phase: models.SchemaMigrationReadNew, want: models.SchemaMigrationBackfilling},
+		{phase: models.SchemaMigrationCompleted, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := PreviousSchemaMigrationPhase(&models.SchemaMigration{Name: "test", Phase: tt.phase})
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("PreviousSchemaMigrationPhase(%s) = %q, %v, want %q (error: %v)", tt.phase, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
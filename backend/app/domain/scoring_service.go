@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// ScoringWeights holds the weights of technical and fundamental scores.
+type ScoringWeights struct {
+	Technical   float64
+	Fundamental float64
+}
+
+// DefaultScoringWeights returns the default scoring weights.
+func DefaultScoringWeights() ScoringWeights {
+	return ScoringWeights{
+		Technical:   0.6,
+		Fundamental: 0.4,
+	}
+}
+
+// Validate validates scoring weights.
+func (w ScoringWeights) Validate() error {
+	if w.Technical < 0 || w.Fundamental < 0 {
+		return fmt.Errorf("スコアの重みは0以上である必要があります")
+	}
+	if w.Technical+w.Fundamental == 0 {
+		return fmt.Errorf("スコアの重みの合計は0より大きい必要があります")
+	}
+	return nil
+}
+
+// StockScore represents the composite score of a stock.
+type StockScore struct {
+	Code             string
+	Name             string
+	TechnicalScore   float64 // 0-100
+	FundamentalScore float64 // 0-100
+	HasFundamentals  bool
+	TotalScore       float64 // 0-100
+	Action           string
+}
+
+// ScoringService combines technical and fundamental indicators into a single score.
+type ScoringService struct {
+	weights ScoringWeights
+}
+
+// NewScoringService creates a new scoring service.
+func NewScoringService(weights ScoringWeights) *ScoringService {
+	return &ScoringService{weights: weights}
+}
+
+// TechnicalScore converts a trading signal score (-5 to +5) to 0-100.
+func (s *ScoringService) TechnicalScore(signal *TradingSignal) float64 {
+	if signal == nil {
+		return 50
+	}
+	return clampScore((signal.Score + 5) / 10 * 100)
+}
+
+// FundamentalScore scores financial indicators to 0-100.
+// Each available indicator is scored linearly and averaged; false is returned when no indicator is available.
+func (s *ScoringService) FundamentalScore(f *models.StockFundamental) (float64, bool) {
+	if f == nil {
+		return 0, false
+	}
+
+	var scores []float64
+
+	// PER: 10倍以下で満点、40倍以上(または赤字)で0点
+	if f.Per.Valid {
+		if f.Per.Float64 <= 0 {
+			scores = append(scores, 0)
+		} else {
+			scores = append(scores, linearScore(f.Per.Float64, 40, 10))
+		}
+	}
+
+	// PBR: 1倍以下で満点、5倍以上で0点
+	if f.Pbr.Valid {
+		scores = append(scores, linearScore(f.Pbr.Float64, 5, 1))
+	}
+
+	// ROE: 15%以上で満点、0%以下で0点
+	if f.Roe.Valid {
+		scores = append(scores, linearScore(f.Roe.Float64, 0, 15))
+	}
+
+	// 配当利回り: 4%以上で満点、0%で0点
+	if f.DividendYield.Valid {
+		scores = append(scores, linearScore(f.DividendYield.Float64, 0, 4))
+	}
+
+	if len(scores) == 0 {
+		return 0, false
+	}
+
+	sum := 0.0
+	for _, score := range scores {
+		sum += score
+	}
+	return sum / float64(len(scores)), true
+}
+
+// Score calculates the composite score of a stock.
+// When no fundamental data is available, the total score equals the technical score.
+func (s *ScoringService) Score(code, name string, signal *TradingSignal, fundamental *models.StockFundamental) StockScore {
+	score := StockScore{
+		Code:           code,
+		Name:           name,
+		TechnicalScore: s.TechnicalScore(signal),
+	}
+	if signal != nil {
+		score.Action = signal.Action
+	}
+
+	score.FundamentalScore, score.HasFundamentals = s.FundamentalScore(fundamental)
+
+	technicalWeight := s.weights.Technical
+	fundamentalWeight := s.weights.Fundamental
+	if !score.HasFundamentals {
+		fundamentalWeight = 0
+	}
+
+	if technicalWeight+fundamentalWeight == 0 {
+		score.TotalScore = score.TechnicalScore
+		return score
+	}
+
+	score.TotalScore = (score.TechnicalScore*technicalWeight + score.FundamentalScore*fundamentalWeight) /
+		(technicalWeight + fundamentalWeight)
+	return score
+}
+
+// RankScores sorts scores by total score in descending order (ties by code).
+func (s *ScoringService) RankScores(scores []StockScore) []StockScore {
+	ranked := make([]StockScore, len(scores))
+	copy(ranked, scores)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].TotalScore != ranked[j].TotalScore {
+			return ranked[i].TotalScore > ranked[j].TotalScore
+		}
+		return ranked[i].Code < ranked[j].Code
+	})
+	return ranked
+}
+
+// GenerateRankingReport generates a formatted score ranking.
+func (s *ScoringService) GenerateRankingReport(ranked []StockScore) string {
+	return RenderReportText(s.BuildRankingReport(ranked))
+}
+
+// BuildRankingReport builds the ranking report as a document for the renderers of the outputs.
+func (s *ScoringService) BuildRankingReport(ranked []StockScore) *ReportDocument {
+	report := NewReportBuilder(i18n.T("scoring.title")).
+		Subtitle(i18n.T("scoring.weights", s.weightPercent(s.weights.Technical), s.weightPercent(s.weights.Fundamental))).
+		Section("", "")
+
+	if len(ranked) == 0 {
+		return report.Line(i18n.T("report.no_stocks")).Document()
+	}
+
+	for i, score := range ranked {
+		fundamental := "-"
+		if score.HasFundamentals {
+			fundamental = fmt.Sprintf("%.0f", score.FundamentalScore)
+		}
+		report.Item("", i18n.T("scoring.rank", i+1, score.Name, score.Code, score.TotalScore),
+			i18n.T("scoring.breakdown", score.TechnicalScore, fundamental))
+	}
+
+	return report.Document()
+}
+
+// weightPercent returns the weight as a percentage of the total weight.
+func (s *ScoringService) weightPercent(weight float64) float64 {
+	total := s.weights.Technical + s.weights.Fundamental
+	if total == 0 {
+		return 0
+	}
+	return weight / total * 100
+}
+
+// linearScore maps value linearly so that zeroAt scores 0 and fullAt scores 100.
+func linearScore(value, zeroAt, fullAt float64) float64 {
+	return clampScore((value - zeroAt) / (fullAt - zeroAt) * 100)
+}
+
+// clampScore limits a score to 0-100.
+func clampScore(score float64) float64 {
+	return math.Max(0, math.Min(100, score))
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+func TestScoringService_FundamentalScore(t *testing.T) {
+	service := NewScoringService(DefaultScoringWeights())
+
+	tests := []struct {
+		name        string
+		fundamental *models.StockFundamental
+		expected    float64
+		expectedOK  bool
+	}{
+		{
+			name:        "No data",
+			fundamental: nil,
+			expectedOK:  false,
+		},
+		{
+			name: "All indicators at full score",
+			fundamental: &models.StockFundamental{
+				Per:           null.Float64From(8),
+				Pbr:           null.Float64From(0.8),
+				Roe:           null.Float64From(20),
+				DividendYield: null.Float64From(5),
+			},
+			expected:   100,
+			expectedOK: true,
+		},
+		{
+			name: "Partial indicators",
+			fundamental: &models.StockFundamental{
+				Per: null.Float64From(25), // 50
+				Roe: null.Float64From(0),  // 0
+			},
+			expected:   25,
+			expectedOK: true,
+		},
+		{
+			name: "Negative PER",
+			fundamental: &models.StockFundamental{
+				Per: null.Float64From(-10),
+			},
+			expected:   0,
+			expectedOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := service.FundamentalScore(tt.fundamental)
+			if ok != tt.expectedOK {
+				t.Fatalf("Expected ok=%v, got %v", tt.expectedOK, ok)
+			}
+			if math.Abs(score-tt.expected) > 0.01 {
+				t.Errorf("Expected score %.2f, got %.2f", tt.expected, score)
+			}
+		})
+	}
+}
+
+func TestScoringService_Score(t *testing.T) {
+	service := NewScoringService(ScoringWeights{Technical: 0.5, Fundamental: 0.5})
+
+	signal := &TradingSignal{Action: "buy", Score: 3} // technical 80
+	fundamental := &models.StockFundamental{
+		Per: null.Float64From(40), // 0
+	}
+
+	withFundamentals := service.Score("7203", "トヨタ自動車", signal, fundamental)
+	if math.Abs(withFundamentals.TotalScore-40) > 0.01 {
+		t.Errorf("Expected total score 40, got %.2f", withFundamentals.TotalScore)
+	}
+
+	withoutFundamentals := service.Score("7203", "トヨタ自動車", signal, nil)
+	if math.Abs(withoutFundamentals.TotalScore-80) > 0.01 {
+		t.Errorf("Expected total score 80 (technical only), got %.2f", withoutFundamentals.TotalScore)
+	}
+	if withoutFundamentals.HasFundamentals {
+		t.Error("Expected HasFundamentals to be false")
+	}
+}
+
+func TestScoringService_RankingReport(t *testing.T) {
+	service := NewScoringService(DefaultScoringWeights())
+
+	ranked := service.RankScores([]StockScore{
+		{Code: "6758", Name: "ソニーグループ", TotalScore: 40, TechnicalScore: 40},
+		{Code: "7203", Name: "トヨタ自動車", TotalScore: 75, TechnicalScore: 70, FundamentalScore: 82.4, HasFundamentals: true},
+	})
+
+	if ranked[0].Code != "7203" {
+		t.Fatalf("Expected 7203 to rank first, got %s", ranked[0].Code)
+	}
+
+	report := service.GenerateRankingReport(ranked)
+	expectedContents := []string{
+		"重み: テクニカル 60% / ファンダメンタル 40%",
+		"1. トヨタ自動車 (7203) 75点",
+		"テクニカル: 70 / ファンダメンタル: 82",
+		"2. ソニーグループ (6758) 40点",
+		"テクニカル: 40 / ファンダメンタル: -",
+	}
+	for _, expected := range expectedContents {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Report should contain %q\n%s", expected, report)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxShareLinkDays is the longest validity of a share link, so that forgotten links stop working.
+const MaxShareLinkDays = 365
+
+// ErrInvalidShareToken is returned for a share token that is malformed, not signed with the secret or expired.
+var ErrInvalidShareToken = errors.New("invalid share token")
+
+// SignShareToken returns the token of a share link, carrying its ID and expiry signed with
+// HMAC-SHA256 so that the server rejects forged and expired tokens before looking them up.
+func SignShareToken(secret []byte, id string, expiresAt time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(id + "." + strconv.FormatInt(expiresAt.Unix(), 10)))
+	return payload + "." + shareTokenSignature(secret, payload)
+}
+
+// ParseShareToken verifies a share token and returns the ID of its share link.
+// Returns ErrInvalidShareToken if the signature does not match or the token has expired at now.
+func ParseShareToken(secret []byte, token string, now time.Time) (string, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(shareTokenSignature(secret, payload))) {
+		return "", ErrInvalidShareToken
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", ErrInvalidShareToken
+	}
+	id, expiry, ok := strings.Cut(string(decoded), ".")
+	if !ok || id == "" {
+		return "", ErrInvalidShareToken
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !now.Before(time.Unix(unix, 0)) {
+		return "", ErrInvalidShareToken
+	}
+	return id, nil
+}
+
+// shareTokenSignature returns the URL-safe HMAC-SHA256 signature of a token payload.
+func shareTokenSignature(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
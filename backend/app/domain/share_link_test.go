@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseShareToken(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	token := SignShareToken(secret, "01HQSHARE", now.Add(24*time.Hour))
+
+	id, err := ParseShareToken(secret, token, now)
+	if err != nil || id != "01HQSHARE" {
+		t.Fatalf("ParseShareToken() = %q, %v, want 01HQSHARE", id, err)
+	}
+
+	tests := []struct {
+		name   string
+		secret []byte
+		token  string
+		now    time.Time
+	}{
+		{name: "expired", secret: secret, token: token, now: now.Add(24 * time.Hour)},
+		{name: "other secret", secret: []byte("other"), token: token, now: now},
+		{name: "tampered payload", secret: secret, token: SignShareToken(secret, "01HQOTHER", now.Add(time.Hour))[:10] + token[10:], now: now},
+		{name: "no signature", secret: secret, token: "abc", now: now},
+		{name: "empty", secret: secret, token: "", now: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseShareToken(tt.secret, tt.token, tt.now); !errors.Is(err, ErrInvalidShareToken) {
+				t.Errorf("ParseShareToken() error = %v, want ErrInvalidShareToken", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// BacktestTrade is a round trip of a long position opened on a buy signal and closed on a sell signal.
+type BacktestTrade struct {
+	EntryDate  time.Time
+	EntryPrice float64
+	ExitDate   time.Time
+	ExitPrice  float64
+	Return     float64 // percent
+	Open       bool    // still held at the end of the period, valued at the last close
+}
+
+// SignalBacktestResult holds the performance of trading a stock on the signals of a strategy.
+type SignalBacktestResult struct {
+	Code             string
+	Days             int // trading days on which signals were evaluated
+	Trades           []BacktestTrade
+	TotalReturn      float64 // percent, compounded over the trades
+	BuyAndHoldReturn float64 // percent, from the close of the first evaluated day to the last close
+	MaxDrawdown      float64 // percent, of the equity at the daily closes
+}
+
+// WinningTrades returns the number of trades with a positive return.
+func (r *SignalBacktestResult) WinningTrades() int {
+	wins := 0
+	for _, trade := range r.Trades {
+		if trade.Return > 0 {
+			wins++
+		}
+	}
+	return wins
+}
+
+// RunSignalBacktest trades a stock on the signals generated with the parameters on each trading day from
+// the given date, using only the prices up to that day. A buy signal opens a long position and a sell signal
+// closes it, both filled at the open of the next trading day like a paper market order. Days before enough
+// prices are available for the indicators of the parameters are skipped. Prices must be oldest first.
+func RunSignalBacktest(prices []StockPriceData, params IndicatorParameters, from time.Time) *SignalBacktestResult {
+	result := &SignalBacktestResult{}
+	if len(prices) == 0 {
+		return result
+	}
+	result.Code = prices[0].Code
+
+	start := params.RequiredDataPoints() - 1
+	for start < len(prices) && prices[start].Date.Before(from) {
+		start++
+	}
+	// The signal of the last day cannot be filled
+	if start >= len(prices)-1 {
+		return result
+	}
+
+	service := NewTechnicalAnalysisService()
+	equity, peak := 1.0, 1.0
+	var position *BacktestTrade
+
+	for i := start; i < len(prices); i++ {
+		bar := prices[i]
+
+		// Fill the order of the previous day's signal at the open
+		if i > start {
+			signal := service.GenerateTradingSignalWithParameters(
+				service.CalculateAllIndicatorsWithParameters(prices[:i], params), prices[i-1].Close, params)
+			switch {
+			case signal.Action == "buy" && position == nil:
+				if price, _ := PaperFillPrice(models.OrderSideBuy, nil, bar); price > 0 {
+					position = &BacktestTrade{EntryDate: bar.Date, EntryPrice: price}
+				}
+			case signal.Action == "sell" && position != nil:
+				if price, _ := PaperFillPrice(models.OrderSideSell, nil, bar); price > 0 {
+					equity *= closeBacktestTrade(position, bar.Date, price)
+					result.Trades = append(result.Trades, *position)
+					position = nil
+				}
+			}
+		}
+		result.Days++
+
+		marked := equity
+		if position != nil {
+			marked *= bar.Close / position.EntryPrice
+		}
+		peak = math.Max(peak, marked)
+		result.MaxDrawdown = math.Max(result.MaxDrawdown, (peak-marked)/peak*100)
+	}
+
+	last := prices[len(prices)-1]
+	if position != nil {
+		equity *= closeBacktestTrade(position, last.Date, last.Close)
+		position.Open = true
+		result.Trades = append(result.Trades, *position)
+	}
+
+	result.TotalReturn = (equity - 1) * 100
+	if first := prices[start].Close; first > 0 {
+		result.BuyAndHoldReturn = (last.Close/first - 1) * 100
+	}
+	return result
+}
+
+// closeBacktestTrade records the exit of the trade and returns the ratio of the exit price to the entry price.
+func closeBacktestTrade(trade *BacktestTrade, date time.Time, price float64) float64 {
+	ratio := price / trade.EntryPrice
+	trade.ExitDate = date
+	trade.ExitPrice = price
+	trade.Return = (ratio - 1) * 100
+	return ratio
+}
+
+// StrategyBacktestSummary aggregates the backtests of a strategy over several stocks for comparison.
+type StrategyBacktestSummary struct {
+	Strategy          string
+	Stocks            int // stocks with enough prices to be evaluated
+	Trades            int
+	WinRate           float64 // percent of the trades with a positive return
+	AverageReturn     float64 // percent, mean of the total returns by stock
+	AverageBuyAndHold float64 // percent, mean of the buy-and-hold returns by stock
+	WorstDrawdown     float64 // percent, largest drawdown of the stocks
+}
+
+// SummarizeSignalBacktests aggregates the backtest results of a strategy.
+// Results without evaluated days are left out.
+func SummarizeSignalBacktests(strategy string, results []*SignalBacktestResult) StrategyBacktestSummary {
+	summary := StrategyBacktestSummary{Strategy: strategy}
+	wins := 0
+	for _, result := range results {
+		if result == nil || result.Days == 0 {
+			continue
+		}
+		summary.Stocks++
+		summary.Trades += len(result.Trades)
+		wins += result.WinningTrades()
+		summary.AverageReturn += result.TotalReturn
+		summary.AverageBuyAndHold += result.BuyAndHoldReturn
+		summary.WorstDrawdown = math.Max(summary.WorstDrawdown, result.MaxDrawdown)
+	}
+
+	if summary.Stocks > 0 {
+		summary.AverageReturn /= float64(summary.Stocks)
+		summary.AverageBuyAndHold /= float64(summary.Stocks)
+	}
+	if summary.Trades > 0 {
+		summary.WinRate = float64(wins) / float64(summary.Trades) * 100
+	}
+	return summary
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// backtestParameters returns short periods so that small price series can be backtested.
+// The RSI overbought threshold of 100 keeps a steady rise from signalling a sell.
+func backtestParameters() IndicatorParameters {
+	params := DefaultIndicatorParameters()
+	params.RSIPeriod = 3
+	params.RSIOverbought = 100
+	params.ShortMAPeriod = 2
+	params.MediumMAPeriod = 3
+	params.LongMAPeriod = 4
+	params.MACDFastPeriod = 2
+	params.MACDSlowPeriod = 3
+	params.MACDSignalPeriod = 2
+	return params
+}
+
+// backtestPrices returns daily prices opening at the previous close.
+func backtestPrices(closes ...float64) []StockPriceData {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := make([]StockPriceData, len(closes))
+	for i, c := range closes {
+		open := c
+		if i > 0 {
+			open = closes[i-1]
+		}
+		date := start.AddDate(0, 0, i)
+		prices[i] = StockPriceData{Code: "1234", Date: date, Timestamp: date, Open: open, High: math.Max(open, c), Low: math.Min(open, c), Close: c}
+	}
+	return prices
+}
+
+func TestRunSignalBacktest(t *testing.T) {
+	t.Run("Rising prices are bought and held", func(t *testing.T) {
+		prices := backtestPrices(100, 101, 102, 103, 104, 105, 106, 107, 108, 109)
+		result := RunSignalBacktest(prices, backtestParameters(), time.Time{})
+
+		if result.Days != 7 {
+			t.Errorf("Days = %d, want 7", result.Days)
+		}
+		if len(result.Trades) != 1 || !result.Trades[0].Open {
+			t.Fatalf("Trades = %+v, want one open trade", result.Trades)
+		}
+		trade := result.Trades[0]
+		if trade.EntryPrice != prices[4].Open || trade.ExitPrice != 109 {
+			t.Errorf("Trade = %+v, want entry at the open after the first signal and exit at the last close", trade)
+		}
+		if want := (109.0/trade.EntryPrice - 1) * 100; math.Abs(result.TotalReturn-want) > 1e-9 {
+			t.Errorf("TotalReturn = %v, want %v", result.TotalReturn, want)
+		}
+		if want := (109.0/103 - 1) * 100; math.Abs(result.BuyAndHoldReturn-want) > 1e-9 {
+			t.Errorf("BuyAndHoldReturn = %v, want %v", result.BuyAndHoldReturn, want)
+		}
+		if result.MaxDrawdown != 0 {
+			t.Errorf("MaxDrawdown = %v, want 0", result.MaxDrawdown)
+		}
+	})
+
+	t.Run("Position is closed on a sell signal", func(t *testing.T) {
+		prices := backtestPrices(100, 101, 102, 103, 104, 105, 100, 95, 90, 85, 80)
+		params := backtestParameters()
+		params.RSIOversold = 0 // keep a steady fall from signalling a buy
+		result := RunSignalBacktest(prices, params, time.Time{})
+
+		if len(result.Trades) != 1 || result.Trades[0].Open {
+			t.Fatalf("Trades = %+v, want one closed trade", result.Trades)
+		}
+		trade := result.Trades[0]
+		if math.Abs(result.TotalReturn-trade.Return) > 1e-9 {
+			t.Errorf("TotalReturn = %v, want the return of the trade %v", result.TotalReturn, trade.Return)
+		}
+		if result.MaxDrawdown <= 0 {
+			t.Errorf("MaxDrawdown = %v, want positive", result.MaxDrawdown)
+		}
+	})
+
+	t.Run("Days before the start date are not traded", func(t *testing.T) {
+		prices := backtestPrices(100, 101, 102, 103, 104, 105, 106, 107, 108, 109)
+		result := RunSignalBacktest(prices, backtestParameters(), prices[8].Date)
+
+		if result.Days != 2 {
+			t.Errorf("Days = %d, want 2", result.Days)
+		}
+		if len(result.Trades) != 1 || result.Trades[0].EntryPrice != prices[9].Open {
+			t.Errorf("Trades = %+v, want one trade entered on the last day", result.Trades)
+		}
+	})
+
+	t.Run("Too few prices", func(t *testing.T) {
+		result := RunSignalBacktest(backtestPrices(100, 101, 102), backtestParameters(), time.Time{})
+		if result.Days != 0 || len(result.Trades) != 0 {
+			t.Errorf("Result = %+v, want no evaluated days", result)
+		}
+	})
+}
+
+func TestSummarizeSignalBacktests(t *testing.T) {
+	results := []*SignalBacktestResult{
+		{Days: 10, Trades: []BacktestTrade{{Return: 5}, {Return: -2}}, TotalReturn: 2.9, BuyAndHoldReturn: 4, MaxDrawdown: 3},
+		{Days: 10, Trades: []BacktestTrade{{Return: 1, Open: true}}, TotalReturn: 1, BuyAndHoldReturn: -2, MaxDrawdown: 6},
+		{Days: 0},
+	}
+
+	summary := SummarizeSignalBacktests("swing", results)
+	want := StrategyBacktestSummary{
+		Strategy:          "swing",
+		Stocks:            2,
+		Trades:            3,
+		WinRate:           200.0 / 3,
+		AverageReturn:     1.95,
+		AverageBuyAndHold: 1,
+		WorstDrawdown:     6,
+	}
+	if math.Abs(summary.WinRate-want.WinRate) > 1e-9 || math.Abs(summary.AverageReturn-want.AverageReturn) > 1e-9 {
+		t.Errorf("SummarizeSignalBacktests() = %+v, want %+v", summary, want)
+	}
+	summary.WinRate, summary.AverageReturn = want.WinRate, want.AverageReturn
+	if summary != want {
+		t.Errorf("SummarizeSignalBacktests() = %+v, want %+v", summary, want)
+	}
+}
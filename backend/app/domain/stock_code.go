@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stock exchanges a StockCode can be listed on, named by their Yahoo Finance suffixes.
+const (
+	StockExchangeTokyo   = "T" // 東京証券取引所
+	StockExchangeNagoya  = "N" // 名古屋証券取引所
+	StockExchangeFukuoka = "F" // 福岡証券取引所
+	StockExchangeSapporo = "S" // 札幌証券取引所
+)
+
+// stockExchangeSuffixes maps the market suffixes of the data sources to the exchanges.
+var stockExchangeSuffixes = map[string]string{
+	"T":  StockExchangeTokyo,
+	"JP": StockExchangeTokyo, // Stooq
+	"N":  StockExchangeNagoya,
+	"F":  StockExchangeFukuoka,
+	"S":  StockExchangeSapporo,
+}
+
+// stockCodeLetters are the letters used in the alphanumeric securities codes issued since 2024.
+// B, E, I, O, Q, V and Z are left out as they are easily mistaken for digits.
+const stockCodeLetters = "ACDFGHJKLMNPRSTUWXY"
+
+// StockCode is a securities code of a Japanese listed stock or ETF, normalized to its four
+// characters, e.g. 7203 or 130A, with the exchange it is listed on.
+type StockCode struct {
+	code     string
+	exchange string
+}
+
+// ParseStockCode normalizes and validates a securities code given in any of the usual forms:
+// full-width characters, surrounding spaces, lower case, a market suffix such as 7203.T or
+// 7203.jp, and the 5-digit J-Quants form 72030. A code without a suffix is on the Tokyo Stock Exchange.
+func ParseStockCode(input string) (StockCode, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(foldWidth(input)))
+	if normalized == "" {
+		return StockCode{}, fmt.Errorf("銘柄コードは必須です")
+	}
+
+	code, exchange := normalized, StockExchangeTokyo
+	if i := strings.LastIndex(normalized, "."); i >= 0 {
+		suffix, ok := stockExchangeSuffixes[normalized[i+1:]]
+		if !ok {
+			return StockCode{}, fmt.Errorf("銘柄コード %q の市場 %s には対応していません", input, normalized[i+1:])
+		}
+		code, exchange = normalized[:i], suffix
+	}
+	if len(code) == 5 && code[4] == '0' {
+		code = code[:4]
+	}
+	if !isStockCode(code) {
+		return StockCode{}, fmt.Errorf("銘柄コード %q は4桁の証券コード(例: 7203、130A)である必要があります", input)
+	}
+	return StockCode{code: code, exchange: exchange}, nil
+}
+
+// NormalizeStockCode returns the normalized 4-character form of a securities code.
+func NormalizeStockCode(input string) (string, error) {
+	code, err := ParseStockCode(input)
+	if err != nil {
+		return "", err
+	}
+	return code.String(), nil
+}
+
+// NormalizeCode normalizes the code of a holding of any asset class. Securities codes are
+// normalized as by ParseStockCode, and other codes such as crypto symbols and fund codes are
+// only folded to half width and upper case.
+func NormalizeCode(input string) string {
+	if code, err := ParseStockCode(input); err == nil {
+		return code.String()
+	}
+	return strings.ToUpper(strings.TrimSpace(foldWidth(input)))
+}
+
+// String returns the 4-character securities code.
+func (c StockCode) String() string {
+	return c.code
+}
+
+// Exchange returns the exchange the stock is listed on, e.g. StockExchangeTokyo.
+func (c StockCode) Exchange() string {
+	return c.exchange
+}
+
+// Symbol returns the code with its market suffix as used by Yahoo Finance, e.g. 7203.T.
+func (c StockCode) Symbol() string {
+	return c.code + "." + c.exchange
+}
+
+// IsZero reports whether the code is the zero value.
+func (c StockCode) IsZero() bool {
+	return c.code == ""
+}
+
+// isStockCode reports whether code is a 4-character securities code: a leading non-zero digit,
+// a digit in the third place and a digit or letter in the second and fourth places.
+func isStockCode(code string) bool {
+	if len(code) != 4 || code[0] < '1' || code[0] > '9' || !isDigit(code[2]) {
+		return false
+	}
+	for _, c := range []byte{code[1], code[3]} {
+		if !isDigit(c) && !strings.ContainsRune(stockCodeLetters, rune(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// foldWidth maps full-width ASCII characters and the ideographic space to their half-width forms.
+func foldWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '！' && r <= '～':
+			return r - '！' + '!'
+		case r == '　':
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "testing"
+
+func TestParseStockCode(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantCode     string
+		wantExchange string
+		wantErr      bool
+	}{
+		{input: "7203", wantCode: "7203", wantExchange: StockExchangeTokyo},
+		{input: " 7203.T ", wantCode: "7203", wantExchange: StockExchangeTokyo},
+		{input: "7203.jp", wantCode: "7203", wantExchange: StockExchangeTokyo},
+		{input: "７２０３", wantCode: "7203", wantExchange: StockExchangeTokyo},
+		{input: "７２０３．Ｔ", wantCode: "7203", wantExchange: StockExchangeTokyo},
+		{input: "72030", wantCode: "7203", wantExchange: StockExchangeTokyo},
+		{input: "130a", wantCode: "130A", wantExchange: StockExchangeTokyo},
+		{input: "8130.N", wantCode: "8130", wantExchange: StockExchangeNagoya},
+		{input: "", wantErr: true},
+		{input: "720", wantErr: true},
+		{input: "72031", wantErr: true},
+		{input: "0203", wantErr: true},
+		{input: "130B", wantErr: true},
+		{input: "7203.L", wantErr: true},
+		{input: "BTC", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, err := ParseStockCode(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseStockCode(%q) = %s, want an error", tt.input, code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseStockCode(%q) error = %v", tt.input, err)
+			}
+			if code.String() != tt.wantCode || code.Exchange() != tt.wantExchange {
+				t.Errorf("ParseStockCode(%q) = %s on %s, want %s on %s",
+					tt.input, code, code.Exchange(), tt.wantCode, tt.wantExchange)
+			}
+		})
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	for input, want := range map[string]string{
+		"7203.T":    "7203",
+		"ｂｔｃ":       "BTC",
+		" 0331418a": "0331418A",
+	} {
+		if got := NormalizeCode(input); got != want {
+			t.Errorf("NormalizeCode(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// SyntheticCodePrefix marks the codes of synthetic stocks so they are never mistaken for listed ones.
+const SyntheticCodePrefix = "SYN"
+
+// SyntheticPriceSource is the data source recorded for synthetic prices.
+const SyntheticPriceSource = "synthetic"
+
+// SyntheticPriceParams configures the random walk of a synthetic stock.
+type SyntheticPriceParams struct {
+	InitialPrice float64 // close before the first day
+	Drift        float64 // mean of daily log returns
+	Volatility   float64 // standard deviation of daily log returns
+	BaseVolume   float64 // median daily volume
+}
+
+// SyntheticStockCode returns the code of the i-th synthetic stock (0-based), e.g. SYN0001.
+func SyntheticStockCode(i int) string {
+	return fmt.Sprintf("%s%04d", SyntheticCodePrefix, i+1)
+}
+
+// RandomSyntheticPriceParams draws parameters resembling Japanese stocks:
+// prices from 100 to 10,000 yen, daily volatility from 1% to 3% and volume from 10,000 to 1,000,000 shares.
+func RandomSyntheticPriceParams(rng *rand.Rand) SyntheticPriceParams {
+	return SyntheticPriceParams{
+		InitialPrice: math.Round(100 * math.Pow(100, rng.Float64())),
+		Drift:        (rng.Float64() - 0.5) * 0.0006,
+		Volatility:   0.01 + rng.Float64()*0.02,
+		BaseVolume:   10000 * math.Pow(100, rng.Float64()),
+	}
+}
+
+// GenerateSyntheticPrices generates daily prices on the weekdays from start to end by a geometric random walk.
+// The same rng state and arguments always give the same prices.
+func GenerateSyntheticPrices(rng *rand.Rand, code string, start, end time.Time, params SyntheticPriceParams) []*models.StockPrice {
+	var prices []*models.StockPrice
+	prevClose := params.InitialPrice
+
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for ; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+
+		// The open gaps from the previous close, and high and low extend beyond the open and close
+		open := prevClose * math.Exp(rng.NormFloat64()*params.Volatility*0.3)
+		closePrice := open * math.Exp(params.Drift+rng.NormFloat64()*params.Volatility)
+		high := math.Max(open, closePrice) * (1 + math.Abs(rng.NormFloat64())*params.Volatility*0.5)
+		low := math.Min(open, closePrice) * (1 - math.Min(math.Abs(rng.NormFloat64())*params.Volatility*0.5, 0.5))
+		volume := params.BaseVolume * math.Exp(rng.NormFloat64()*0.5)
+
+		bar := [4]float64{roundToYen(open), roundToYen(high), roundToYen(low), roundToYen(closePrice)}
+		bar[1] = math.Max(bar[1], math.Max(bar[0], bar[3]))
+		bar[2] = math.Min(bar[2], math.Min(bar[0], bar[3]))
+
+		prices = append(prices, &models.StockPrice{
+			Code:       code,
+			Date:       day,
+			OpenPrice:  utility.FloatToDecimal(bar[0]),
+			HighPrice:  utility.FloatToDecimal(bar[1]),
+			LowPrice:   utility.FloatToDecimal(bar[2]),
+			ClosePrice: utility.FloatToDecimal(bar[3]),
+			Volume:     int64(math.Round(volume)),
+			Source:     SyntheticPriceSource,
+		})
+		prevClose = closePrice
+	}
+
+	return prices
+}
+
+// roundToYen rounds a price to the yen, at least 1 yen.
+func roundToYen(price float64) float64 {
+	return math.Max(1, math.Round(price))
+}
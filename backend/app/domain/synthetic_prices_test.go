@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSyntheticStockCode(t *testing.T) {
+	got := []string{SyntheticStockCode(0), SyntheticStockCode(41), SyntheticStockCode(999)}
+	want := []string{"SYN0001", "SYN0042", "SYN1000"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SyntheticStockCode() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSyntheticPrices(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local) // Monday
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local)
+	generate := func(seed int64) []*models.StockPrice {
+		rng := rand.New(rand.NewSource(seed))
+		return GenerateSyntheticPrices(rng, "SYN0001", start, end, RandomSyntheticPriceParams(rng))
+	}
+	type bar struct {
+		Date                   string
+		Open, High, Low, Close float64
+		Volume                 int64
+	}
+	bars := func(prices []*models.StockPrice) []bar {
+		var out []bar
+		for _, p := range prices {
+			out = append(out, bar{
+				Date:   p.Date.Format("2006-01-02"),
+				Open:   utility.DecimalToFloat(p.OpenPrice),
+				High:   utility.DecimalToFloat(p.HighPrice),
+				Low:    utility.DecimalToFloat(p.LowPrice),
+				Close:  utility.DecimalToFloat(p.ClosePrice),
+				Volume: p.Volume,
+			})
+		}
+		return out
+	}
+
+	prices := bars(generate(42))
+
+	// 366 days of 2024 include 104 weekend days
+	if len(prices) != 262 {
+		t.Fatalf("generated %d days, want 262 weekdays", len(prices))
+	}
+	for _, p := range prices {
+		date, _ := time.Parse("2006-01-02", p.Date)
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			t.Errorf("%s is a weekend", p.Date)
+		}
+		if p.Low < 1 || p.Low > p.Open || p.Low > p.Close || p.High < p.Open || p.High < p.Close {
+			t.Errorf("%s has an inconsistent bar: %+v", p.Date, p)
+		}
+		if p.Volume <= 0 {
+			t.Errorf("%s has no volume", p.Date)
+		}
+	}
+
+	if diff := cmp.Diff(prices, bars(generate(42))); diff != "" {
+		t.Errorf("the same seed should generate the same prices (-first +second):\n%s", diff)
+	}
+	if cmp.Equal(prices, bars(generate(43))) {
+		t.Error("different seeds should generate different prices")
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"math"
 	"time"
 
-	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
 )
 
 // TechnicalAnalysisService handles technical analysis business logic.
@@ -39,9 +39,24 @@ type TechnicalIndicatorData struct {
 	MACD      float64
 	Signal    float64
 	Histogram float64
-	Timestamp time.Time
+	// MADeviation is the deviation of the last close from the medium moving average in percent
+	MADeviation float64
+	ATR         float64
+	Breakout    BreakoutDirection
+	InRange     bool // prices stay in a box, see IsRangeBound
+	Timestamp   time.Time
 }
 
+// BreakoutDirection is the direction of a volatility breakout.
+type BreakoutDirection int
+
+// Volatility breakout directions.
+const (
+	NoBreakout       BreakoutDirection = 0
+	UpsideBreakout   BreakoutDirection = 1
+	DownsideBreakout BreakoutDirection = -1
+)
+
 // TradingSignal represents buy/sell/hold signal.
 type TradingSignal struct {
 	Action     string  // "buy", "sell", "hold"
@@ -51,16 +66,18 @@ type TradingSignal struct {
 }
 
 // ConvertStockPrices converts SQLBoiler models to domain service format.
+// Prices are split-adjusted so that indicators are continuous across splits.
 func (s *TechnicalAnalysisService) ConvertStockPrices(prices []*models.StockPrice) []StockPriceData {
 	result := make([]StockPriceData, len(prices))
-	for i, p := range prices {
+	for i, price := range prices {
+		p := price.Adjusted()
 		result[i] = StockPriceData{
 			Code:      p.Code,
 			Date:      p.Date,
-			Open:      s.decimalToFloat(p.OpenPrice),
-			High:      s.decimalToFloat(p.HighPrice),
-			Low:       s.decimalToFloat(p.LowPrice),
-			Close:     s.decimalToFloat(p.ClosePrice),
+			Open:      utility.DecimalToFloat(p.OpenPrice),
+			High:      utility.DecimalToFloat(p.HighPrice),
+			Low:       utility.DecimalToFloat(p.LowPrice),
+			Close:     utility.DecimalToFloat(p.ClosePrice),
 			Volume:    p.Volume,
 			Timestamp: p.Date,
 		}
@@ -153,25 +170,114 @@ func (s *TechnicalAnalysisService) MACD(prices []StockPriceData, fastPeriod, slo
 	return macd, signal, histogram
 }
 
+// MADeviation calculates the deviation of the last close from its moving average in percent.
+// Returns 0 if there is not enough data for the moving average.
+func (s *TechnicalAnalysisService) MADeviation(prices []StockPriceData, period int) float64 {
+	ma := s.MovingAverage(prices, period)
+	if ma <= 0 {
+		return 0
+	}
+	return (prices[len(prices)-1].Close/ma - 1) * 100
+}
+
+// ATR calculates the Average True Range as the simple average of the true ranges of the last period days.
+func (s *TechnicalAnalysisService) ATR(prices []StockPriceData, period int) float64 {
+	if period <= 0 || len(prices) <= period {
+		return 0
+	}
+
+	sum := 0.0
+	for i := len(prices) - period; i < len(prices); i++ {
+		previousClose := prices[i-1].Close
+		trueRange := math.Max(prices[i].High-prices[i].Low,
+			math.Max(math.Abs(prices[i].High-previousClose), math.Abs(prices[i].Low-previousClose)))
+		sum += trueRange
+	}
+
+	return sum / float64(period)
+}
+
+// priceBox returns the highest high and the lowest low of the prices.
+func priceBox(prices []StockPriceData) (high, low float64) {
+	high, low = prices[0].High, prices[0].Low
+	for _, price := range prices[1:] {
+		high = math.Max(high, price.High)
+		low = math.Min(low, price.Low)
+	}
+	return high, low
+}
+
+// DetectBreakout detects a volatility breakout of the last day: its close leaving the box of the
+// preceding rangePeriod days by at least multiple times the ATR of the preceding days, so that the
+// breakout day itself does not widen the threshold.
+func (s *TechnicalAnalysisService) DetectBreakout(prices []StockPriceData, atrPeriod, rangePeriod int, multiple float64) BreakoutDirection {
+	if rangePeriod <= 0 || len(prices) <= rangePeriod || len(prices) <= atrPeriod+1 {
+		return NoBreakout
+	}
+
+	previous := prices[:len(prices)-1]
+	atr := s.ATR(previous, atrPeriod)
+	if atr == 0 {
+		return NoBreakout
+	}
+
+	high, low := priceBox(previous[len(previous)-rangePeriod:])
+	lastClose := prices[len(prices)-1].Close
+	switch {
+	case lastClose >= high+multiple*atr:
+		return UpsideBreakout
+	case lastClose <= low-multiple*atr:
+		return DownsideBreakout
+	default:
+		return NoBreakout
+	}
+}
+
+// IsRangeBound reports whether the market is in a range: the box of the last rangePeriod days is
+// at most multiple times the ATR high, i.e. prices went back and forth instead of trending.
+func (s *TechnicalAnalysisService) IsRangeBound(prices []StockPriceData, atrPeriod, rangePeriod int, multiple float64) bool {
+	if rangePeriod <= 0 || len(prices) < rangePeriod {
+		return false
+	}
+
+	atr := s.ATR(prices, atrPeriod)
+	if atr == 0 {
+		return false
+	}
+
+	high, low := priceBox(prices[len(prices)-rangePeriod:])
+	return high-low <= multiple*atr
+}
+
 // CalculateAllIndicators calculates all technical indicators for a stock.
 func (s *TechnicalAnalysisService) CalculateAllIndicators(prices []StockPriceData) *TechnicalIndicatorData {
+	return s.CalculateAllIndicatorsWithParameters(prices, DefaultIndicatorParameters())
+}
+
+// CalculateAllIndicatorsWithParameters calculates all technical indicators using the given parameters.
+// MA5/MA25/MA75 hold the short/medium/long moving averages of the applied parameters.
+func (s *TechnicalAnalysisService) CalculateAllIndicatorsWithParameters(prices []StockPriceData, params IndicatorParameters) *TechnicalIndicatorData {
 	if len(prices) == 0 {
 		return nil
 	}
 
 	lastPrice := prices[len(prices)-1]
-	macd, signal, histogram := s.MACD(prices, 12, 26, 9)
+	macd, signal, histogram := s.MACD(prices, params.MACDFastPeriod, params.MACDSlowPeriod, params.MACDSignalPeriod)
 
 	indicator := &TechnicalIndicatorData{
-		Code:      lastPrice.Code,
-		MA5:       s.MovingAverage(prices, 5),
-		MA25:      s.MovingAverage(prices, 25),
-		MA75:      s.MovingAverage(prices, 75),
-		RSI:       s.RSI(prices, 14),
-		MACD:      macd,
-		Signal:    signal,
-		Histogram: histogram,
-		Timestamp: lastPrice.Timestamp,
+		Code:        lastPrice.Code,
+		MA5:         s.MovingAverage(prices, params.ShortMAPeriod),
+		MA25:        s.MovingAverage(prices, params.MediumMAPeriod),
+		MA75:        s.MovingAverage(prices, params.LongMAPeriod),
+		RSI:         s.RSI(prices, params.RSIPeriod),
+		MACD:        macd,
+		Signal:      signal,
+		Histogram:   histogram,
+		MADeviation: s.MADeviation(prices, params.MediumMAPeriod),
+		ATR:         s.ATR(prices, params.ATRPeriod),
+		Breakout:    s.DetectBreakout(prices, params.ATRPeriod, params.RangePeriod, params.BreakoutATRMultiple),
+		InRange:     s.IsRangeBound(prices, params.ATRPeriod, params.RangePeriod, params.RangeATRMultiple),
+		Timestamp:   lastPrice.Timestamp,
 	}
 
 	return indicator
@@ -179,61 +285,91 @@ func (s *TechnicalAnalysisService) CalculateAllIndicators(prices []StockPriceDat
 
 // GenerateTradingSignal generates trading signal based on technical indicators.
 func (s *TechnicalAnalysisService) GenerateTradingSignal(indicator *TechnicalIndicatorData, currentPrice float64) *TradingSignal {
+	return s.GenerateTradingSignalWithParameters(indicator, currentPrice, DefaultIndicatorParameters())
+}
+
+// GenerateTradingSignalWithParameters generates trading signal using the RSI thresholds, the condition weights
+// and the buy/sell thresholds of the given parameters.
+func (s *TechnicalAnalysisService) GenerateTradingSignalWithParameters(indicator *TechnicalIndicatorData, currentPrice float64, params IndicatorParameters) *TradingSignal {
 	score := 0.0
 	reasons := []string{}
 
 	// RSI based signals
-	if indicator.RSI < 30 {
-		score += 2.0
+	if indicator.RSI < params.RSIOversold {
+		score += params.RSIWeight
 
 		reasons = append(reasons, "RSI oversold")
-	} else if indicator.RSI > 70 {
-		score -= 2.0
+	} else if indicator.RSI > params.RSIOverbought {
+		score -= params.RSIWeight
 
 		reasons = append(reasons, "RSI overbought")
 	}
 
 	// Moving Average signals
 	if indicator.MA5 > indicator.MA25 && indicator.MA25 > indicator.MA75 {
-		score += 1.5
+		score += params.MAAlignmentWeight
 
 		reasons = append(reasons, "Bullish MA alignment")
 	} else if indicator.MA5 < indicator.MA25 && indicator.MA25 < indicator.MA75 {
-		score -= 1.5
+		score -= params.MAAlignmentWeight
 
 		reasons = append(reasons, "Bearish MA alignment")
 	}
 
 	// MACD signals
 	if indicator.MACD > indicator.Signal && indicator.Histogram > 0 {
-		score += 1.0
+		score += params.MACDWeight
 
 		reasons = append(reasons, "MACD bullish")
 	} else if indicator.MACD < indicator.Signal && indicator.Histogram < 0 {
-		score -= 1.0
+		score -= params.MACDWeight
 
 		reasons = append(reasons, "MACD bearish")
 	}
 
 	// Price vs Moving Average
 	if currentPrice > indicator.MA5 && currentPrice > indicator.MA25 {
-		score += 0.5
+		score += params.PriceMAWeight
 
 		reasons = append(reasons, "Price above key MAs")
 	} else if currentPrice < indicator.MA5 && currentPrice < indicator.MA25 {
-		score -= 0.5
+		score -= params.PriceMAWeight
 
 		reasons = append(reasons, "Price below key MAs")
 	}
 
+	// Volatility breakout signals, only applied when weighted so that the reasons of other signals stay the same
+	if params.BreakoutWeight > 0 {
+		switch indicator.Breakout {
+		case UpsideBreakout:
+			score += params.BreakoutWeight
+
+			reasons = append(reasons, "Upside volatility breakout")
+		case DownsideBreakout:
+			score -= params.BreakoutWeight
+
+			reasons = append(reasons, "Downside volatility breakout")
+		}
+	}
+
+	// Signals are less reliable in a range, where prices tend to turn back at the edges of the box
+	if indicator.InRange && params.RangeScoreFactor < 1 {
+		score *= params.RangeScoreFactor
+
+		reasons = append(reasons, "Range-bound market")
+	}
+
 	// Determine action and confidence
 	var action string
 
-	confidence := math.Min(math.Abs(score)/5.0, 1.0) // Normalize to 0-1
+	confidence := 0.0
+	if maxScore := params.MaxSignalScore(); maxScore > 0 {
+		confidence = math.Min(math.Abs(score)/maxScore, 1.0) // Normalize to 0-1
+	}
 
-	if score > 1.0 {
+	if score > params.BuyThreshold {
 		action = "buy"
-	} else if score < -1.0 {
+	} else if score < params.SellThreshold {
 		action = "sell"
 	} else {
 		action = "hold"
@@ -259,48 +395,19 @@ func (s *TechnicalAnalysisService) GenerateTradingSignal(indicator *TechnicalInd
 // ConvertToModelIndicator converts domain indicator to SQLBoiler model.
 func (s *TechnicalAnalysisService) ConvertToModelIndicator(data *TechnicalIndicatorData) *models.TechnicalIndicator {
 	return &models.TechnicalIndicator{
-		Code: data.Code,
-		// Note: You'll need to convert float64 back to types.Decimal for storage
-		// This is left as an exercise based on your specific decimal library
-		Rsi14:         s.floatToDecimal(data.RSI),
-		Macd:          s.floatToDecimal(data.MACD),
-		MacdSignal:    s.floatToDecimal(data.Signal),
-		MacdHistogram: s.floatToDecimal(data.Histogram),
-		Sma5:          s.floatToDecimal(data.MA5),
-		Sma25:         s.floatToDecimal(data.MA25),
-		Sma75:         s.floatToDecimal(data.MA75),
+		Code:          data.Code,
+		Rsi14:         utility.FloatToNullDecimal(data.RSI),
+		Macd:          utility.FloatToNullDecimal(data.MACD),
+		MacdSignal:    utility.FloatToNullDecimal(data.Signal),
+		MacdHistogram: utility.FloatToNullDecimal(data.Histogram),
+		Sma5:          utility.FloatToNullDecimal(data.MA5),
+		Sma25:         utility.FloatToNullDecimal(data.MA25),
+		Sma75:         utility.FloatToNullDecimal(data.MA75),
+		Ma25Deviation: utility.FloatToNullDecimal(data.MADeviation),
 		Date:          data.Timestamp,
 	}
 }
 
-// decimalToFloat converts types.Decimal to float64.
-func (s *TechnicalAnalysisService) decimalToFloat(d any) float64 {
-	// This is a simplified conversion for testing
-	// In a real implementation, you would use the actual decimal library methods
-	switch v := d.(type) {
-	case float64:
-		return v
-	case int:
-		return float64(v)
-	case string:
-		var f float64
-		fmt.Sscanf(v, "%f", &f)
-		return f
-	default:
-		// For types.Decimal, we'll use string conversion as fallback
-		str := fmt.Sprintf("%v", d)
-		var f float64
-		fmt.Sscanf(str, "%f", &f)
-		return f
-	}
-}
-
-// floatToDecimal converts float64 to types.NullDecimal.
-func (s *TechnicalAnalysisService) floatToDecimal(f float64) types.NullDecimal {
-	// Simplified placeholder conversion - returns empty NullDecimal
-	return types.NullDecimal{}
-}
-
 // ValidateIndicator validates technical indicator values.
 func (s *TechnicalAnalysisService) ValidateIndicator(indicator *TechnicalIndicatorData) error {
 	if indicator.Code == "" {
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -190,7 +191,7 @@ func TestTechnicalAnalysisService_RSI(t *testing.T) {
 
 			// Use approximate comparison for RSI since it's a complex calculation
 			tolerance := 5.0
-			if abs(result-tt.expected) > tolerance {
+			if math.Abs(result-tt.expected) > tolerance {
 				t.Errorf("RSI mismatch: expected approximately %f, got %f", tt.expected, result)
 			}
 		})
@@ -222,6 +223,82 @@ func TestTechnicalAnalysisService_MACD(t *testing.T) {
 	}
 }
 
+// rangePrices returns count days moving between 99 and 101, each with a true range of 2.
+func rangePrices(count int) []StockPriceData {
+	prices := make([]StockPriceData, count)
+	for i := range prices {
+		prices[i] = StockPriceData{Code: "1234", High: 101, Low: 99, Close: 100}
+	}
+	return prices
+}
+
+func TestTechnicalAnalysisService_ATR(t *testing.T) {
+	service := NewTechnicalAnalysisService()
+
+	prices := []StockPriceData{
+		{High: 105, Low: 95, Close: 100},
+		{High: 104, Low: 98, Close: 102},  // high-low 6
+		{High: 110, Low: 106, Close: 108}, // gap up: high-previous close 8
+		{High: 107, Low: 100, Close: 101}, // low-previous close 8
+	}
+
+	if diff := cmp.Diff(22.0/3, service.ATR(prices, 3)); diff != "" {
+		t.Errorf("ATR mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(0.0, service.ATR(prices, 4)); diff != "" {
+		t.Errorf("ATR with insufficient data mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTechnicalAnalysisService_DetectBreakout(t *testing.T) {
+	service := NewTechnicalAnalysisService()
+
+	tests := []struct {
+		name      string
+		lastClose float64
+		expected  BreakoutDirection
+	}{
+		{name: "Close above the box by the ATR", lastClose: 103, expected: UpsideBreakout},
+		{name: "Close above the box within the ATR", lastClose: 102, expected: NoBreakout},
+		{name: "Close below the box by the ATR", lastClose: 96.5, expected: DownsideBreakout},
+		{name: "Close in the box", lastClose: 100, expected: NoBreakout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices := append(rangePrices(20), StockPriceData{High: tt.lastClose, Low: tt.lastClose, Close: tt.lastClose})
+
+			got := service.DetectBreakout(prices, 14, 10, 1.0)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("Breakout mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if got := service.DetectBreakout(rangePrices(5), 14, 10, 1.0); got != NoBreakout {
+		t.Errorf("Breakout with insufficient data = %d, want none", got)
+	}
+}
+
+func TestTechnicalAnalysisService_IsRangeBound(t *testing.T) {
+	service := NewTechnicalAnalysisService()
+
+	if !service.IsRangeBound(rangePrices(20), 14, 10, 4.0) {
+		t.Error("Prices moving in a box of one ATR should be range-bound")
+	}
+
+	trend := rangePrices(20)
+	for i := range trend {
+		step := float64(i) * 2
+		trend[i].High += step
+		trend[i].Low += step
+		trend[i].Close += step
+	}
+	if service.IsRangeBound(trend, 14, 10, 4.0) {
+		t.Error("Trending prices should not be range-bound")
+	}
+}
+
 func TestTechnicalAnalysisService_CalculateAllIndicators(t *testing.T) {
 	service := NewTechnicalAnalysisService()
 
@@ -266,6 +343,124 @@ func TestTechnicalAnalysisService_CalculateAllIndicators(t *testing.T) {
 	}
 }
 
+func TestTechnicalAnalysisService_CalculateAllIndicatorsWithParameters(t *testing.T) {
+	service := NewTechnicalAnalysisService()
+
+	prices := make([]StockPriceData, 0, 30)
+	for i := 0; i < 30; i++ {
+		prices = append(prices, StockPriceData{
+			Code:  "1234",
+			Close: float64(100 + i),
+		})
+	}
+
+	params := DefaultIndicatorParameters()
+	params.ShortMAPeriod = 3
+	params.MediumMAPeriod = 10
+	params.LongMAPeriod = 20
+
+	result := service.CalculateAllIndicatorsWithParameters(prices, params)
+	if result == nil {
+		t.Fatal("Expected non-nil indicator data")
+	}
+
+	expected := map[string]float64{
+		"MA5":  128.0, // (127+128+129)/3
+		"MA25": 124.5, // average of 120..129
+		"MA75": 119.5, // average of 110..129
+	}
+	got := map[string]float64{
+		"MA5":  result.MA5,
+		"MA25": result.MA25,
+		"MA75": result.MA75,
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Moving averages mismatch (-want +got):\n%s", diff)
+	}
+
+	// Default parameters cannot calculate the 75-day MA with 30 records
+	if defaultResult := service.CalculateAllIndicators(prices); defaultResult.MA75 != 0 {
+		t.Errorf("Expected MA75 to be 0 with default parameters, got %f", defaultResult.MA75)
+	}
+}
+
+func TestTechnicalAnalysisService_GenerateTradingSignalWithParameters(t *testing.T) {
+	service := NewTechnicalAnalysisService()
+
+	indicator := &TechnicalIndicatorData{
+		Code: "1234",
+		MA5:  100.0,
+		MA25: 100.0,
+		MA75: 100.0,
+		RSI:  35.0,
+	}
+
+	// RSI 35 is neutral with default thresholds
+	if signal := service.GenerateTradingSignal(indicator, 100.0); signal.Reason == "RSI oversold" {
+		t.Error("RSI 35 should not be oversold with default thresholds")
+	}
+
+	params := DefaultIndicatorParameters()
+	params.RSIOversold = 40
+
+	signal := service.GenerateTradingSignalWithParameters(indicator, 100.0, params)
+	if diff := cmp.Diff("RSI oversold", signal.Reason); diff != "" {
+		t.Errorf("Signal reason mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("buy", signal.Action); diff != "" {
+		t.Errorf("Signal action mismatch (-want +got):\n%s", diff)
+	}
+
+	// The weight of RSI alone no longer exceeds a raised buy threshold
+	params.RSIWeight = 1.0
+	params.BuyThreshold = 1.5
+	signal = service.GenerateTradingSignalWithParameters(indicator, 100.0, params)
+	if diff := cmp.Diff("hold", signal.Action); diff != "" {
+		t.Errorf("Signal action with weights mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(1.0, signal.Score); diff != "" {
+		t.Errorf("Signal score with weights mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTechnicalAnalysisService_GenerateTradingSignalWithParameters_BreakoutAndRange(t *testing.T) {
+	service := NewTechnicalAnalysisService()
+
+	indicator := &TechnicalIndicatorData{
+		Code:     "1234",
+		MA5:      100.0,
+		MA25:     100.0,
+		MA75:     100.0,
+		RSI:      25.0,
+		Breakout: UpsideBreakout,
+		InRange:  true,
+	}
+
+	// Breakouts and ranges do not change the signal with the default parameters
+	if diff := cmp.Diff("RSI oversold", service.GenerateTradingSignal(indicator, 100.0).Reason); diff != "" {
+		t.Errorf("Default signal reason mismatch (-want +got):\n%s", diff)
+	}
+
+	params := DefaultIndicatorParameters()
+	params.BreakoutWeight = 1.0
+	params.RangeScoreFactor = 0.25
+
+	signal := service.GenerateTradingSignalWithParameters(indicator, 100.0, params)
+	if diff := cmp.Diff(0.75, signal.Score); diff != "" {
+		t.Errorf("Decayed score mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("hold", signal.Action); diff != "" {
+		t.Errorf("Signal action in a range mismatch (-want +got):\n%s", diff)
+	}
+
+	indicator.InRange = false
+	signal = service.GenerateTradingSignalWithParameters(indicator, 100.0, params)
+	if diff := cmp.Diff(3.0, signal.Score); diff != "" {
+		t.Errorf("Score with a breakout mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestTechnicalAnalysisService_GenerateTradingSignal(t *testing.T) {
 	service := NewTechnicalAnalysisService()
 
@@ -483,13 +678,26 @@ func TestTechnicalAnalysisService_ConvertToModelIndicator(t *testing.T) {
 	if diff := cmp.Diff(data.Timestamp, result.Date); diff != "" {
 		t.Errorf("Date mismatch (-want +got):\n%s", diff)
 	}
-}
-
-// Helper functions for testing
 
-func abs(x float64) float64 {
-	if x < 0 {
-		return -x
+	want := map[string]string{
+		"Rsi14":         "60",
+		"Macd":          "1.5",
+		"MacdSignal":    "1",
+		"MacdHistogram": "0.5",
+		"Sma5":          "105",
+		"Sma25":         "100",
+		"Sma75":         "95",
+	}
+	got := map[string]string{
+		"Rsi14":         result.Rsi14.String(),
+		"Macd":          result.Macd.String(),
+		"MacdSignal":    result.MacdSignal.String(),
+		"MacdHistogram": result.MacdHistogram.String(),
+		"Sma5":          result.Sma5.String(),
+		"Sma25":         result.Sma25.String(),
+		"Sma75":         result.Sma75.String(),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Indicator values mismatch (-want +got):\n%s", diff)
 	}
-	return x
 }
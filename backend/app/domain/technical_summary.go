@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// Moving average trends of a technical summary.
+const (
+	MATrendUp       = "up"       // MA5 > MA25 > MA75
+	MATrendDown     = "down"     // MA5 < MA25 < MA75
+	MATrendSideways = "sideways" // moving averages not aligned
+)
+
+// TechnicalSummary is the one-line technical view of a holding in the daily report.
+type TechnicalSummary struct {
+	Code   string
+	Name   string
+	RSI    float64
+	Trend  string // MATrendUp, MATrendDown or MATrendSideways
+	Action string // "buy", "sell" or "hold", empty if the indicators are not available
+
+	// MADeviation is the deviation of the price from the medium moving average in percent
+	MADeviation float64
+
+	// Price is the current price the signal was generated at
+	Price float64
+	// LotCost is the cost of one trading unit at the price, set with WithinBuyingPower by
+	// JudgeBuySignals for a buy signal when the buying power is tracked
+	LotCost           float64
+	WithinBuyingPower bool
+}
+
+// Available reports whether the indicators of the holding were available.
+func (s TechnicalSummary) Available() bool {
+	return s.Action != ""
+}
+
+// NewTechnicalSummary summarizes the indicators and trading signal of a holding.
+// Without indicators, the summary tells that they are not available.
+func NewTechnicalSummary(code, name string, indicator *TechnicalIndicatorData, signal *TradingSignal) TechnicalSummary {
+	summary := TechnicalSummary{Code: code, Name: name}
+	if indicator == nil || signal == nil {
+		return summary
+	}
+
+	summary.RSI = indicator.RSI
+	summary.MADeviation = indicator.MADeviation
+	summary.Action = signal.Action
+	switch {
+	case indicator.MA5 > indicator.MA25 && indicator.MA25 > indicator.MA75:
+		summary.Trend = MATrendUp
+	case indicator.MA5 < indicator.MA25 && indicator.MA25 < indicator.MA75:
+		summary.Trend = MATrendDown
+	default:
+		summary.Trend = MATrendSideways
+	}
+	return summary
+}
+
+// TechnicalIndicatorDataFromModel converts stored indicators to the domain service format.
+func TechnicalIndicatorDataFromModel(indicator *models.TechnicalIndicator) *TechnicalIndicatorData {
+	return &TechnicalIndicatorData{
+		Code:        indicator.Code,
+		MA5:         utility.NullDecimalToFloat(indicator.Sma5),
+		MA25:        utility.NullDecimalToFloat(indicator.Sma25),
+		MA75:        utility.NullDecimalToFloat(indicator.Sma75),
+		RSI:         utility.NullDecimalToFloat(indicator.Rsi14),
+		MACD:        utility.NullDecimalToFloat(indicator.Macd),
+		Signal:      utility.NullDecimalToFloat(indicator.MacdSignal),
+		Histogram:   utility.NullDecimalToFloat(indicator.MacdHistogram),
+		MADeviation: utility.NullDecimalToFloat(indicator.Ma25Deviation),
+		Timestamp:   indicator.Date,
+	}
+}
+
+// FormatTechnicalLine formats a technical summary as a line of the report.
+func FormatTechnicalLine(summary TechnicalSummary) string {
+	if !summary.Available() {
+		return i18n.T("technical_summary.unavailable", summary.Name, summary.Code)
+	}
+
+	icon := "⚪"
+	switch summary.Action {
+	case "buy":
+		icon = "🟢"
+	case "sell":
+		icon = "🔴"
+	}
+	line := i18n.T("technical_summary.item", icon, summary.Name, summary.Code, summary.RSI,
+		i18n.T("technical_summary.trend."+summary.Trend), summary.MADeviation, i18n.T("technical_summary.action."+summary.Action))
+	if summary.LotCost > 0 {
+		key := "buying_power.signal_over"
+		if summary.WithinBuyingPower {
+			key = "buying_power.signal_ok"
+		}
+		line += " / " + i18n.T(key, formatCurrency(summary.LotCost))
+	}
+	return line
+}
+
+// FormatTechnicalSection formats the technical summaries as a section of the report.
+func FormatTechnicalSection(summaries []TechnicalSummary) string {
+	var b strings.Builder
+	b.WriteString(i18n.T("technical_summary.section") + "\n")
+	b.WriteString("━━━━━━━━━━━━━━━━━━━━\n")
+	for _, summary := range summaries {
+		b.WriteString(FormatTechnicalLine(summary) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestFormatTechnicalLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		indicator *TechnicalIndicatorData
+		signal    *TradingSignal
+		want      string
+	}{
+		{
+			name:      "uptrend with a buy signal",
+			indicator: &TechnicalIndicatorData{RSI: 28.44, MA5: 1100, MA25: 1050, MA75: 1000, MADeviation: 4.76},
+			signal:    &TradingSignal{Action: "buy"},
+			want:      "🟢 トヨタ自動車 (7203): RSI 28.4 / MA 上昇 / 乖離率 +4.8% / 買い",
+		},
+		{
+			name:      "moving averages not aligned",
+			indicator: &TechnicalIndicatorData{RSI: 50, MA5: 1000, MA25: 1050, MA75: 1000},
+			signal:    &TradingSignal{Action: "hold"},
+			want:      "⚪ トヨタ自動車 (7203): RSI 50.0 / MA 横ばい / 乖離率 +0.0% / 様子見",
+		},
+		{
+			name: "indicators not calculated",
+			want: "⚪ トヨタ自動車 (7203): 指標未計算 (価格履歴不足)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatTechnicalLine(NewTechnicalSummary("7203", "トヨタ自動車", tt.indicator, tt.signal))
+			if got != tt.want {
+				t.Errorf("FormatTechnicalLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// Kinds of the sources a trade note refers to.
+const (
+	TradeNoteSourceSignal = "signal" // an alert rule notification, by rule name
+	TradeNoteSourceReport = "report" // the daily report, by date
+)
+
+// tradeNoteExcerptLength is the number of characters of a note shown where it is referred to.
+const tradeNoteExcerptLength = 40
+
+// SignalNoteSource returns the source of a note written from the notification of an alert rule.
+func SignalNoteSource(ruleName string) string {
+	return TradeNoteSourceSignal + ":" + ruleName
+}
+
+// ReportNoteSource returns the source of a note written from the daily report of a day.
+func ReportNoteSource(date time.Time) string {
+	return TradeNoteSourceReport + ":" + date.Format("2006-01-02")
+}
+
+// ParseTradeNoteSource checks the source of a note, signal:<rule name> or report:<YYYY-MM-DD>.
+// An empty source is a note written by hand.
+func ParseTradeNoteSource(source string) (string, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return "", nil
+	}
+
+	kind, value, _ := strings.Cut(source, ":")
+	value = strings.TrimSpace(value)
+	switch kind {
+	case TradeNoteSourceSignal:
+		if value != "" {
+			return SignalNoteSource(value), nil
+		}
+	case TradeNoteSourceReport:
+		if date, err := time.Parse("2006-01-02", value); err == nil {
+			return ReportNoteSource(date), nil
+		}
+	}
+	return "", fmt.Errorf("参照元は signal:<ルール名> または report:<YYYY-MM-DD> の形式で指定してください: %s", source)
+}
+
+// FormatTradeNoteRef formats the line referring to a note from a report or a notification,
+// with the ID to show the whole note and the start of its first line.
+func FormatTradeNoteRef(note *models.TradeNote) string {
+	excerpt, _, _ := strings.Cut(strings.TrimSpace(note.Body), "\n")
+	if utf8.RuneCountInString(excerpt) > tradeNoteExcerptLength {
+		excerpt = string([]rune(excerpt)[:tradeNoteExcerptLength]) + "…"
+	}
+	return i18n.T("trade_note.ref", i18n.T("trade_note.kind."+note.Kind), note.ID, excerpt)
+}
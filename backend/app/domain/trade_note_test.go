@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+func TestParseTradeNoteSource(t *testing.T) {
+	tests := []struct {
+		source  string
+		want    string
+		wantErr bool
+	}{
+		{source: "", want: ""},
+		{source: "signal:rsi-oversold", want: "signal:rsi-oversold"},
+		{source: " report:2024-03-01 ", want: "report:2024-03-01"},
+		{source: "report:yesterday", wantErr: true},
+		{source: "signal:", wantErr: true},
+		{source: "news:1234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTradeNoteSource(tt.source)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTradeNoteSource(%q) = %q, %v, want %q (error %v)", tt.source, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatTradeNoteRef(t *testing.T) {
+	note := &models.TradeNote{
+		ID:   "01HQNOTE",
+		Kind: models.TradeNoteKindEntry,
+		Body: strings.Repeat("押し目", 20) + "\n2行目",
+	}
+	ref := FormatTradeNoteRef(note)
+	if !strings.Contains(ref, "エントリー理由") || !strings.Contains(ref, "01HQNOTE") || !strings.HasSuffix(ref, "…") {
+		t.Errorf("FormatTradeNoteRef() = %q, want the kind, the ID and the first line cut short", ref)
+	}
+
+	// The report refers to the note under its holding
+	summary := &PortfolioSummary{
+		Holdings: []HoldingSummary{{Code: "7203", Name: "トヨタ自動車", Shares: 100}},
+		Notes:    map[string]*models.TradeNote{"7203": note},
+	}
+	if report := GeneratePortfolioReport(summary); !strings.Contains(report, ref) {
+		t.Errorf("report lacks the note reference:\n%s", report)
+	}
+}
@@ -0,0 +1,53 @@
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// Broker types selectable by BROKER_TYPE.
+const (
+	TypePaper = "paper"
+)
+
+// BrokerClient defines the interface for securities brokers. A real broker API
+// implements it in this package and is selected in the container by its type.
+type BrokerClient interface {
+	// Name returns the broker name recorded with orders
+	Name() string
+
+	// GetBalance returns the cash balance and positions
+	GetBalance(ctx context.Context) (*Balance, error)
+
+	// PlaceOrder places an order and returns it with its current status
+	PlaceOrder(ctx context.Context, req OrderRequest) (*models.BrokerOrder, error)
+
+	// GetOrder returns an order by its ID, or nil if it does not exist
+	GetOrder(ctx context.Context, orderID string) (*models.BrokerOrder, error)
+
+	// GetExecutions returns the executions since the given time, oldest first
+	GetExecutions(ctx context.Context, since time.Time) ([]*models.BrokerExecution, error)
+}
+
+// OrderRequest represents a new order.
+type OrderRequest struct {
+	Code       string
+	Side       string // models.OrderSideBuy or models.OrderSideSell
+	Quantity   int
+	LimitPrice *float64 // nil for a market order
+}
+
+// Balance represents the cash balance and positions of an account.
+type Balance struct {
+	Cash      float64    `json:"cash"`
+	Positions []Position `json:"positions"`
+}
+
+// Position represents the shares held of a stock.
+type Position struct {
+	Code         string  `json:"code"`
+	Quantity     int     `json:"quantity"`
+	AveragePrice float64 `json:"average_price"`
+}
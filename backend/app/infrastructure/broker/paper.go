@@ -0,0 +1,305 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// PaperBroker implements BrokerClient with virtual fills recorded to the database.
+// Orders are accepted as pending and filled on the next trading day at the open price
+// when SettlePendingOrders runs (see domain.PaperFillPrice). Orders exceeding the cash or
+// position are rejected. Short selling is not supported.
+type PaperBroker struct {
+	orderRepo   repository.BrokerOrderRepository
+	stockRepo   repository.StockRepository
+	txManager   repository.TransactionManager
+	initialCash float64
+	now         func() time.Time
+}
+
+// NewPaperBroker creates a new paper trading broker starting with initialCash.
+func NewPaperBroker(
+	orderRepo repository.BrokerOrderRepository,
+	stockRepo repository.StockRepository,
+	txManager repository.TransactionManager,
+	initialCash float64,
+) *PaperBroker {
+	return &PaperBroker{
+		orderRepo:   orderRepo,
+		stockRepo:   stockRepo,
+		txManager:   txManager,
+		initialCash: initialCash,
+		now:         time.Now,
+	}
+}
+
+// Name returns the broker name.
+func (b *PaperBroker) Name() string {
+	return TypePaper
+}
+
+// InitialCash returns the cash the paper account started with.
+func (b *PaperBroker) InitialCash() float64 {
+	return b.initialCash
+}
+
+// GetBalance calculates the cash balance and positions from all executions.
+func (b *PaperBroker) GetBalance(ctx context.Context) (*Balance, error) {
+	executions, err := b.orderRepo.GetExecutions(ctx, b.Name(), time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executions: %w", err)
+	}
+	return calculateBalance(b.initialCash, executions), nil
+}
+
+// PlaceOrder checks the order against the latest price and records it as pending.
+// Orders that cannot be funded at the latest close (or limit) price are rejected immediately.
+func (b *PaperBroker) PlaceOrder(ctx context.Context, req OrderRequest) (*models.BrokerOrder, error) {
+	order := &models.BrokerOrder{
+		ID:        utility.NewULID(),
+		Broker:    b.Name(),
+		Code:      req.Code,
+		Side:      req.Side,
+		OrderType: models.OrderTypeMarket,
+		Quantity:  req.Quantity,
+		Status:    models.OrderStatusPending,
+		CreatedAt: null.TimeFrom(b.now()),
+	}
+	if req.LimitPrice != nil {
+		order.OrderType = models.OrderTypeLimit
+		order.LimitPrice = utility.FloatToNullDecimal(*req.LimitPrice)
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := b.txManager.WithTx(ctx, func(ctx context.Context) error {
+		latest, err := b.stockRepo.GetLatestPrice(ctx, order.Code)
+		if err != nil {
+			return fmt.Errorf("failed to get latest price: %w", err)
+		}
+		if latest == nil {
+			reject(order, models.OrderStatusRejected, i18n.T("broker.no_price_data"))
+		} else {
+			price := utility.DecimalToFloat(latest.ClosePrice)
+			if req.LimitPrice != nil && order.Side == models.OrderSideBuy {
+				price = *req.LimitPrice
+			}
+			if err := b.checkFunds(ctx, order, price); err != nil {
+				return err
+			}
+		}
+
+		if err := b.orderRepo.CreateOrder(ctx, order); err != nil {
+			return fmt.Errorf("failed to save order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Paper order %s: %s %s %d shares -> %s", order.ID, order.Side, order.Code, order.Quantity, order.Status)
+	return order, nil
+}
+
+// GetPendingOrders returns the orders waiting to be filled, oldest first.
+func (b *PaperBroker) GetPendingOrders(ctx context.Context) ([]*models.BrokerOrder, error) {
+	return b.orderRepo.GetPendingOrders(ctx, b.Name())
+}
+
+// SettlePendingOrders fills pending orders with the first stored price bar after the day they
+// were placed and returns the orders whose status changed. Orders stay pending until that bar is stored.
+// Limit orders the day's range does not reach are canceled.
+func (b *PaperBroker) SettlePendingOrders(ctx context.Context) ([]*models.BrokerOrder, error) {
+	orders, err := b.GetPendingOrders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending orders: %w", err)
+	}
+
+	settled := []*models.BrokerOrder{}
+	for _, order := range orders {
+		done, err := b.settle(ctx, order)
+		if err != nil {
+			return settled, fmt.Errorf("failed to settle order %s: %w", order.ID, err)
+		}
+		if done {
+			logrus.Infof("Paper order %s settled: %s %s %d shares -> %s", order.ID, order.Side, order.Code, order.Quantity, order.Status)
+			settled = append(settled, order)
+		}
+	}
+
+	return settled, nil
+}
+
+// settle fills or cancels a pending order with the next day's price bar.
+// Returns false if the bar is not stored yet.
+func (b *PaperBroker) settle(ctx context.Context, order *models.BrokerOrder) (bool, error) {
+	placedOn := models.TruncateToDate(order.CreatedAt.Time)
+	days := int(b.now().Sub(placedOn).Hours()/24) + 1
+
+	prices, err := b.stockRepo.GetPriceHistory(ctx, order.Code, days)
+	if err != nil {
+		return false, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	// Fills use the actual traded prices, not the split-adjusted ones
+	var bar *domain.StockPriceData
+	for _, price := range prices {
+		if models.TruncateToDate(price.Date).After(placedOn) {
+			bar = &domain.StockPriceData{
+				Code:  price.Code,
+				Date:  price.Date,
+				Open:  utility.DecimalToFloat(price.OpenPrice),
+				High:  utility.DecimalToFloat(price.HighPrice),
+				Low:   utility.DecimalToFloat(price.LowPrice),
+				Close: utility.DecimalToFloat(price.ClosePrice),
+			}
+			break
+		}
+	}
+	if bar == nil {
+		return false, nil
+	}
+
+	var limitPrice *float64
+	if order.OrderType == models.OrderTypeLimit {
+		limit := utility.NullDecimalToFloat(order.LimitPrice)
+		limitPrice = &limit
+	}
+
+	err = b.txManager.WithTx(ctx, func(ctx context.Context) error {
+		price, filled := domain.PaperFillPrice(order.Side, limitPrice, *bar)
+		if !filled {
+			reject(order, models.OrderStatusCanceled, i18n.T("broker.limit_not_reached",
+				*limitPrice, bar.Date.Format("2006-01-02"), bar.Low, bar.High))
+		} else if err := b.checkFunds(ctx, order, price); err != nil {
+			return err
+		}
+
+		if order.Status == models.OrderStatusPending {
+			order.Status = models.OrderStatusFilled
+			order.FilledQuantity = order.Quantity
+			order.AveragePrice = utility.FloatToNullDecimal(price)
+
+			execution := &models.BrokerExecution{
+				ID:         utility.NewULID(),
+				OrderID:    order.ID,
+				Broker:     order.Broker,
+				Code:       order.Code,
+				Side:       order.Side,
+				Quantity:   order.Quantity,
+				Price:      utility.FloatToDecimal(price),
+				ExecutedAt: bar.Date,
+			}
+			if err := b.orderRepo.CreateExecution(ctx, execution); err != nil {
+				return fmt.Errorf("failed to save execution: %w", err)
+			}
+		}
+
+		if err := b.orderRepo.UpdateOrder(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkFunds rejects the order if the cash or position is not enough at the price.
+func (b *PaperBroker) checkFunds(ctx context.Context, order *models.BrokerOrder, price float64) error {
+	balance, err := b.GetBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	if order.Side == models.OrderSideBuy {
+		if cost := price * float64(order.Quantity); cost > balance.Cash {
+			reject(order, models.OrderStatusRejected, i18n.T("broker.insufficient_cash", cost, balance.Cash))
+		}
+		return nil
+	}
+
+	held := 0
+	for _, position := range balance.Positions {
+		if position.Code == order.Code {
+			held = position.Quantity
+		}
+	}
+	if order.Quantity > held {
+		reject(order, models.OrderStatusRejected, i18n.T("broker.insufficient_shares", held))
+	}
+	return nil
+}
+
+// GetOrder returns an order by its ID.
+func (b *PaperBroker) GetOrder(ctx context.Context, orderID string) (*models.BrokerOrder, error) {
+	return b.orderRepo.GetOrder(ctx, b.Name(), orderID)
+}
+
+// GetExecutions returns the executions since the given time.
+func (b *PaperBroker) GetExecutions(ctx context.Context, since time.Time) ([]*models.BrokerExecution, error) {
+	return b.orderRepo.GetExecutions(ctx, b.Name(), since)
+}
+
+// reject sets the final status of an order that is not executed.
+func reject(order *models.BrokerOrder, status, message string) {
+	order.Status = status
+	order.Message = null.StringFrom(message)
+}
+
+// calculateBalance replays the executions from the initial cash.
+// The average price of a position is kept on sells and reset when it is closed.
+func calculateBalance(initialCash float64, executions []*models.BrokerExecution) *Balance {
+	balance := &Balance{Cash: initialCash, Positions: []Position{}}
+	positions := make(map[string]*Position)
+
+	for _, execution := range executions {
+		price := utility.DecimalToFloat(execution.Price)
+		amount := price * float64(execution.Quantity)
+
+		position, ok := positions[execution.Code]
+		if !ok {
+			position = &Position{Code: execution.Code}
+			positions[execution.Code] = position
+		}
+
+		if execution.Side == models.OrderSideBuy {
+			balance.Cash -= amount
+			total := position.AveragePrice*float64(position.Quantity) + amount
+			position.Quantity += execution.Quantity
+			position.AveragePrice = total / float64(position.Quantity)
+		} else {
+			balance.Cash += amount
+			position.Quantity -= execution.Quantity
+			if position.Quantity <= 0 {
+				position.Quantity = 0
+				position.AveragePrice = 0
+			}
+		}
+	}
+
+	for _, position := range positions {
+		if position.Quantity > 0 {
+			balance.Positions = append(balance.Positions, *position)
+		}
+	}
+	sort.Slice(balance.Positions, func(i, j int) bool {
+		return balance.Positions[i].Code < balance.Positions[j].Code
+	})
+
+	return balance
+}
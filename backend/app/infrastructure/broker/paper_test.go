@@ -0,0 +1,227 @@
+package broker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTransactionManager runs fn without a database transaction.
+func newTransactionManager() *mock.TransactionManagerMock {
+	return &mock.TransactionManagerMock{
+		WithTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+}
+
+// priceBars holds daily price bars by code, oldest first.
+type priceBars map[string][]*models.StockPrice
+
+func (b priceBars) add(code string, date time.Time, open, high, low, close float64) {
+	b[code] = append(b[code], &models.StockPrice{
+		Code:       code,
+		Date:       date,
+		OpenPrice:  utility.FloatToDecimal(open),
+		HighPrice:  utility.FloatToDecimal(high),
+		LowPrice:   utility.FloatToDecimal(low),
+		ClosePrice: utility.FloatToDecimal(close),
+	})
+}
+
+// newStockRepository returns the stored price bars.
+func newStockRepository(bars priceBars) *mock.StockRepositoryMock {
+	return &mock.StockRepositoryMock{
+		GetLatestPriceFunc: func(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+			prices := bars[stockCode]
+			if len(prices) == 0 {
+				return nil, nil
+			}
+			return prices[len(prices)-1], nil
+		},
+		GetPriceHistoryFunc: func(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+			return bars[stockCode], nil
+		},
+	}
+}
+
+// newBrokerOrderRepository keeps orders and executions in memory.
+func newBrokerOrderRepository() *mock.BrokerOrderRepositoryMock {
+	var (
+		orders     []*models.BrokerOrder
+		executions []*models.BrokerExecution
+	)
+	return &mock.BrokerOrderRepositoryMock{
+		CreateOrderFunc: func(ctx context.Context, order *models.BrokerOrder) error {
+			orders = append(orders, order)
+			return nil
+		},
+		GetPendingOrdersFunc: func(ctx context.Context, broker string) ([]*models.BrokerOrder, error) {
+			pending := []*models.BrokerOrder{}
+			for _, order := range orders {
+				if order.Status == models.OrderStatusPending {
+					pending = append(pending, order)
+				}
+			}
+			return pending, nil
+		},
+		UpdateOrderFunc: func(ctx context.Context, order *models.BrokerOrder) error {
+			return nil
+		},
+		CreateExecutionFunc: func(ctx context.Context, execution *models.BrokerExecution) error {
+			executions = append(executions, execution)
+			return nil
+		},
+		GetExecutionsFunc: func(ctx context.Context, broker string, since time.Time) ([]*models.BrokerExecution, error) {
+			return executions, nil
+		},
+	}
+}
+
+func TestPaperBroker_SettlePendingOrders(t *testing.T) {
+	day1 := time.Date(2024, 6, 3, 0, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	limit := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name       string
+		requests   []OrderRequest
+		wantStatus []string
+		want       *Balance
+	}{
+		{
+			name: "market orders fill at next day's open",
+			requests: []OrderRequest{
+				{Code: "7203", Side: models.OrderSideBuy, Quantity: 100},
+			},
+			wantStatus: []string{models.OrderStatusFilled},
+			want: &Balance{
+				Cash:      1000000 - 2050*100,
+				Positions: []Position{{Code: "7203", Quantity: 100, AveragePrice: 2050}},
+			},
+		},
+		{
+			name: "limit orders fill at the limit within the day's range or are canceled",
+			requests: []OrderRequest{
+				{Code: "7203", Side: models.OrderSideBuy, Quantity: 100, LimitPrice: limit(2000)},
+				{Code: "7203", Side: models.OrderSideBuy, Quantity: 100, LimitPrice: limit(1900)},
+			},
+			wantStatus: []string{models.OrderStatusFilled, models.OrderStatusCanceled},
+			want: &Balance{
+				Cash:      1000000 - 2000*100,
+				Positions: []Position{{Code: "7203", Quantity: 100, AveragePrice: 2000}},
+			},
+		},
+		{
+			name: "orders exceeding cash at the fill price are rejected",
+			requests: []OrderRequest{
+				{Code: "7203", Side: models.OrderSideBuy, Quantity: 300},
+				{Code: "7203", Side: models.OrderSideBuy, Quantity: 300},
+			},
+			wantStatus: []string{models.OrderStatusFilled, models.OrderStatusRejected},
+			want: &Balance{
+				Cash:      1000000 - 2050*300,
+				Positions: []Position{{Code: "7203", Quantity: 300, AveragePrice: 2050}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderRepo := newBrokerOrderRepository()
+			bars := priceBars{}
+			bars.add("7203", day1, 1980, 2010, 1970, 2000)
+			broker := NewPaperBroker(orderRepo, newStockRepository(bars), newTransactionManager(), 1000000)
+			broker.now = func() time.Time { return day1.Add(16 * time.Hour) }
+			ctx := context.Background()
+
+			var orders []*models.BrokerOrder
+			for _, req := range tt.requests {
+				order, err := broker.PlaceOrder(ctx, req)
+				if err != nil {
+					t.Fatalf("PlaceOrder() error = %v", err)
+				}
+				if order.Status != models.OrderStatusPending {
+					t.Fatalf("PlaceOrder() status = %s, want pending", order.Status)
+				}
+				orders = append(orders, order)
+			}
+
+			// No bar after the order day yet
+			settled, err := broker.SettlePendingOrders(ctx)
+			if err != nil {
+				t.Fatalf("SettlePendingOrders() error = %v", err)
+			}
+			if len(settled) != 0 {
+				t.Fatalf("SettlePendingOrders() settled %d orders before the next bar", len(settled))
+			}
+
+			bars.add("7203", day2, 2050, 2080, 1950, 2060)
+			broker.now = func() time.Time { return day2.Add(16 * time.Hour) }
+			if _, err := broker.SettlePendingOrders(ctx); err != nil {
+				t.Fatalf("SettlePendingOrders() error = %v", err)
+			}
+
+			var statuses []string
+			for _, order := range orders {
+				statuses = append(statuses, order.Status)
+			}
+			if diff := cmp.Diff(tt.wantStatus, statuses); diff != "" {
+				t.Errorf("order statuses mismatch (-want +got):\n%s", diff)
+			}
+
+			got, err := broker.GetBalance(ctx)
+			if err != nil {
+				t.Fatalf("GetBalance() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetBalance() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPaperBroker_PlaceOrder_Rejected(t *testing.T) {
+	tests := []struct {
+		name string
+		req  OrderRequest
+	}{
+		{name: "no price data", req: OrderRequest{Code: "9999", Side: models.OrderSideBuy, Quantity: 100}},
+		{name: "cash shortage at close", req: OrderRequest{Code: "7203", Side: models.OrderSideBuy, Quantity: 1000}},
+		{name: "no position to sell", req: OrderRequest{Code: "7203", Side: models.OrderSideSell, Quantity: 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars := priceBars{}
+			bars.add("7203", time.Date(2024, 6, 3, 0, 0, 0, 0, time.Local), 1980, 2010, 1970, 2000)
+			broker := NewPaperBroker(newBrokerOrderRepository(), newStockRepository(bars), newTransactionManager(), 1000000)
+
+			order, err := broker.PlaceOrder(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("PlaceOrder() error = %v", err)
+			}
+			if order.Status != models.OrderStatusRejected {
+				t.Errorf("PlaceOrder() status = %s, want rejected", order.Status)
+			}
+		})
+	}
+}
+
+func TestPaperBroker_PlaceOrder_Invalid(t *testing.T) {
+	orderRepo := newBrokerOrderRepository()
+	broker := NewPaperBroker(orderRepo, newStockRepository(priceBars{}), newTransactionManager(), 1000000)
+
+	_, err := broker.PlaceOrder(context.Background(), OrderRequest{Code: "7203", Side: "hold", Quantity: 100})
+	if err == nil {
+		t.Fatal("PlaceOrder() should fail for an invalid side")
+	}
+	if len(orderRepo.CreateOrderCalls()) != 0 {
+		t.Errorf("invalid order should not be saved, got %d orders", len(orderRepo.CreateOrderCalls()))
+	}
+}
@@ -0,0 +1,31 @@
+// Package calendar registers the scheduled corporate events of the watched stocks to an external calendar.
+package calendar
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"time"
+)
+
+// Event is an all-day event of an external calendar.
+type Event struct {
+	ID          string
+	Summary     string
+	Description string
+	Date        time.Time
+}
+
+// Calendar registers events to an external calendar.
+type Calendar interface {
+	// UpsertEvent creates the event, or updates the event with the same ID. It reports whether the event was created.
+	UpsertEvent(ctx context.Context, event Event) (bool, error)
+}
+
+// EventID derives a stable event ID from the key of the event, so that syncing again updates the
+// same event instead of adding a duplicate. The ID only uses lowercase hex digits, which Google
+// Calendar accepts as a client-specified ID.
+func EventID(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return "sa" + hex.EncodeToString(sum[:])
+}
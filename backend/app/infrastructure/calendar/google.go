@@ -0,0 +1,264 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Endpoints and scope of the Google Calendar API and its OAuth 2.0 authorization.
+const (
+	GoogleAuthURL         = "https://accounts.google.com/o/oauth2/v2/auth"
+	GoogleTokenURL        = "https://oauth2.googleapis.com/token"
+	GoogleCalendarBaseURL = "https://www.googleapis.com/calendar/v3"
+	GoogleCalendarScope   = "https://www.googleapis.com/auth/calendar.events"
+)
+
+// googleTokenMargin renews the access token this long before it expires.
+const googleTokenMargin = time.Minute
+
+// GoogleConfig holds the OAuth client and the calendar of the Google Calendar integration.
+// The refresh token is obtained once with AuthCodeURL and ExchangeCode.
+type GoogleConfig struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	CalendarID   string // "primary" for the main calendar of the account
+	Timeout      time.Duration
+
+	// Endpoints, overridden in tests
+	AuthURL  string
+	TokenURL string
+	BaseURL  string
+}
+
+// GoogleCalendar implements Calendar with the Google Calendar API. The access token is obtained
+// from the refresh token and renewed automatically.
+type GoogleCalendar struct {
+	config GoogleConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+	now         func() time.Time
+}
+
+// NewGoogleCalendar creates a Google Calendar client.
+func NewGoogleCalendar(config GoogleConfig) *GoogleCalendar {
+	if config.CalendarID == "" {
+		config.CalendarID = "primary"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.AuthURL == "" {
+		config.AuthURL = GoogleAuthURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = GoogleTokenURL
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = GoogleCalendarBaseURL
+	}
+
+	return &GoogleCalendar{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		now:    time.Now,
+	}
+}
+
+// googleToken is a response of the token endpoint.
+type googleToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// googleEvent is an all-day event of the Google Calendar API.
+type googleEvent struct {
+	ID           string          `json:"id"`
+	Summary      string          `json:"summary"`
+	Description  string          `json:"description"`
+	Start        googleEventDate `json:"start"`
+	End          googleEventDate `json:"end"`
+	Status       string          `json:"status"`
+	Transparency string          `json:"transparency"`
+}
+
+type googleEventDate struct {
+	Date string `json:"date"`
+}
+
+// AuthCodeURL returns the URL where the user authorizes the access to the calendar. The
+// authorization code is sent to redirectURI with the state, and is exchanged with ExchangeCode.
+func (g *GoogleCalendar) AuthCodeURL(redirectURI, state string) string {
+	query := url.Values{
+		"client_id":     {g.config.ClientID},
+		"redirect_uri":  {redirectURI},
+		"response_type": {"code"},
+		"scope":         {GoogleCalendarScope},
+		"access_type":   {"offline"},
+		"prompt":        {"consent"},
+		"state":         {state},
+	}
+	return g.config.AuthURL + "?" + query.Encode()
+}
+
+// ExchangeCode exchanges an authorization code for the refresh token to configure.
+func (g *GoogleCalendar) ExchangeCode(ctx context.Context, code, redirectURI string) (string, error) {
+	token, err := g.requestToken(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+	})
+	if err != nil {
+		return "", err
+	}
+	if token.RefreshToken == "" {
+		return "", fmt.Errorf("no refresh token returned: revoke the access of the app in the Google account and authorize again")
+	}
+	return token.RefreshToken, nil
+}
+
+// UpsertEvent creates the event, or updates the event with the same ID if it already exists,
+// including one deleted in the calendar.
+func (g *GoogleCalendar) UpsertEvent(ctx context.Context, event Event) (bool, error) {
+	body := googleEvent{
+		ID:           event.ID,
+		Summary:      event.Summary,
+		Description:  event.Description,
+		Start:        googleEventDate{Date: event.Date.Format("2006-01-02")},
+		End:          googleEventDate{Date: event.Date.AddDate(0, 0, 1).Format("2006-01-02")},
+		Status:       "confirmed",
+		Transparency: "transparent", // all-day events do not block the schedule
+	}
+
+	eventsPath := "/calendars/" + url.PathEscape(g.config.CalendarID) + "/events"
+	status, err := g.do(ctx, http.MethodPost, eventsPath, body)
+	if err != nil {
+		return false, err
+	}
+	if status != http.StatusConflict {
+		return true, nil
+	}
+
+	if _, err := g.do(ctx, http.MethodPut, eventsPath+"/"+url.PathEscape(event.ID), body); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// do sends a request to the Calendar API and returns the status, which is 2xx or 409 Conflict.
+// An access token rejected by the API is renewed once.
+func (g *GoogleCalendar) do(ctx context.Context, method, path string, body any) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		accessToken, err := g.token(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, g.config.BaseURL+path, bytes.NewReader(data))
+		if err != nil {
+			return 0, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return 0, fmt.Errorf("failed to call Google Calendar API: %w", err)
+		}
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0:
+			g.invalidateToken(accessToken)
+			continue
+		case resp.StatusCode/100 == 2, resp.StatusCode == http.StatusConflict:
+			return resp.StatusCode, nil
+		default:
+			return resp.StatusCode, fmt.Errorf("calendar API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+		}
+	}
+}
+
+// token returns a valid access token, obtaining a new one from the refresh token as needed.
+func (g *GoogleCalendar) token(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.accessToken != "" && g.now().Before(g.expiry) {
+		return g.accessToken, nil
+	}
+	if g.config.RefreshToken == "" {
+		return "", fmt.Errorf("refresh token of Google Calendar is not configured: run 'calendar auth' and set GOOGLE_CALENDAR_REFRESH_TOKEN")
+	}
+
+	token, err := g.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {g.config.RefreshToken},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	g.accessToken = token.AccessToken
+	g.expiry = g.now().Add(time.Duration(token.ExpiresIn)*time.Second - googleTokenMargin)
+	logrus.Debug("Google Calendar access token renewed")
+	return g.accessToken, nil
+}
+
+// invalidateToken discards the access token if it is still the current one.
+func (g *GoogleCalendar) invalidateToken(accessToken string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.accessToken == accessToken {
+		g.accessToken = ""
+	}
+}
+
+// requestToken requests a token of the grant with the client credentials.
+func (g *GoogleCalendar) requestToken(ctx context.Context, form url.Values) (*googleToken, error) {
+	form.Set("client_id", g.config.ClientID)
+	form.Set("client_secret", g.config.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request Google OAuth token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token googleToken
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 65536)).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to parse Google OAuth token response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return nil, fmt.Errorf("token request to Google OAuth failed (status %d): %s %s", resp.StatusCode, token.Error, token.Description)
+	}
+	return &token, nil
+}
@@ -0,0 +1,128 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeGoogleServer serves the token endpoint and the events of the primary calendar.
+type fakeGoogleServer struct {
+	mu         sync.Mutex
+	tokens     int
+	events     map[string]googleEvent
+	requests   []string
+	rejectOnce bool
+}
+
+func (f *fakeGoogleServer) handler(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		r.ParseForm()
+		if r.Form.Get("refresh_token") != "refresh" || r.Form.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		f.tokens++
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "access", "expires_in": 3600})
+		return
+	}
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	if r.Header.Get("Authorization") != "Bearer access" || f.rejectOnce {
+		f.rejectOnce = false
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var event googleEvent
+	json.NewDecoder(r.Body).Decode(&event)
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/calendars/primary/events":
+		if _, exists := f.events[event.ID]; exists {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/calendars/primary/events/"):
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	f.events[event.ID] = event
+	json.NewEncoder(w).Encode(event)
+}
+
+func TestGoogleCalendar_UpsertEvent(t *testing.T) {
+	fake := &fakeGoogleServer{events: make(map[string]googleEvent)}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	calendar := NewGoogleCalendar(GoogleConfig{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RefreshToken: "refresh",
+		TokenURL:     server.URL + "/token",
+		BaseURL:      server.URL,
+	})
+	ctx := context.Background()
+	event := Event{ID: EventID("7203/earnings/2025-03 Q1"), Summary: "7203 決算発表", Date: time.Date(2024, 8, 1, 0, 0, 0, 0, time.Local)}
+
+	created, err := calendar.UpsertEvent(ctx, event)
+	if err != nil || !created {
+		t.Fatalf("UpsertEvent() = %v, %v, want created", created, err)
+	}
+
+	// The rescheduled event updates the same event, after renewing a rejected access token
+	fake.mu.Lock()
+	fake.rejectOnce = true
+	fake.mu.Unlock()
+	event.Date = event.Date.AddDate(0, 0, 2)
+	created, err = calendar.UpsertEvent(ctx, event)
+	if err != nil || created {
+		t.Fatalf("UpsertEvent() = %v, %v, want updated", created, err)
+	}
+
+	want := googleEvent{
+		ID:           event.ID,
+		Summary:      "7203 決算発表",
+		Start:        googleEventDate{Date: "2024-08-03"},
+		End:          googleEventDate{Date: "2024-08-04"},
+		Status:       "confirmed",
+		Transparency: "transparent",
+	}
+	if diff := cmp.Diff(map[string]googleEvent{event.ID: want}, fake.events); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+	wantRequests := []string{
+		"POST /calendars/primary/events",
+		"POST /calendars/primary/events",
+		"POST /calendars/primary/events",
+		"PUT /calendars/primary/events/" + event.ID,
+	}
+	if diff := cmp.Diff(wantRequests, fake.requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+	if fake.tokens != 2 {
+		t.Errorf("tokens issued = %d, want 2", fake.tokens)
+	}
+}
+
+func TestGoogleCalendar_AuthCodeURL(t *testing.T) {
+	calendar := NewGoogleCalendar(GoogleConfig{ClientID: "client"})
+	got := calendar.AuthCodeURL("http://127.0.0.1:8085/callback", "state")
+	for _, part := range []string{"client_id=client", "access_type=offline", "scope=https%3A%2F%2Fwww.googleapis.com%2Fauth%2Fcalendar.events", "state=state"} {
+		if !strings.Contains(got, part) {
+			t.Errorf("AuthCodeURL() = %s, missing %s", got, part)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// SourceCoinGecko is the source of the prices of crypto assets published by CoinGecko.
+const SourceCoinGecko = "coingecko"
+
+const (
+	coinGeckoMarketsPath = "/api/v3/coins/markets"
+	coinGeckoChartPath   = "/api/v3/coins/%s/market_chart"
+	// coinGeckoCurrency is the currency the prices are quoted in.
+	coinGeckoCurrency = "jpy"
+	// MaxCryptoHistoricalDays is the longest period of daily prices available without a paid plan.
+	MaxCryptoHistoricalDays = 365
+)
+
+// DefaultCoinIDs maps the symbols of major crypto assets to their CoinGecko IDs.
+var DefaultCoinIDs = map[string]string{
+	"BTC":  "bitcoin",
+	"ETH":  "ethereum",
+	"XRP":  "ripple",
+	"SOL":  "solana",
+	"BCH":  "bitcoin-cash",
+	"LTC":  "litecoin",
+	"ADA":  "cardano",
+	"DOGE": "dogecoin",
+	"DOT":  "polkadot",
+	"XLM":  "stellar",
+}
+
+// CryptoDataClient retrieves the prices of crypto assets in yen. Crypto assets trade around the clock,
+// so a daily price is the quote of a calendar day rather than a trading day, stored under the symbol.
+type CryptoDataClient interface {
+	// GetCurrentPrice returns the latest quote of the symbol, with the high and low of the last 24 hours.
+	GetCurrentPrice(ctx context.Context, symbol string) (*models.StockPrice, error)
+	// GetHistoricalData returns the daily prices of the symbol of the last given days, oldest first.
+	GetHistoricalData(ctx context.Context, symbol string, days int) ([]*models.StockPrice, error)
+}
+
+// CoinGeckoClient implements CryptoDataClient using the CoinGecko API.
+type CoinGeckoClient struct {
+	client      *resty.Client
+	baseURL     string
+	apiKey      string
+	coinIDs     map[string]string
+	rateLimiter *RateLimiter
+}
+
+// CoinGeckoConfig holds the CoinGecko API client configuration.
+// APIKey is the optional demo API key; CoinIDs adds or overrides the IDs of DefaultCoinIDs.
+type CoinGeckoConfig struct {
+	BaseURL      string
+	APIKey       string
+	CoinIDs      map[string]string
+	Timeout      time.Duration
+	RetryCount   int
+	RateLimitRPS int
+}
+
+// DefaultCoinGeckoConfig returns default configuration for the CoinGecko API client.
+// The public API allows about 30 requests per minute.
+func DefaultCoinGeckoConfig() CoinGeckoConfig {
+	return CoinGeckoConfig{
+		BaseURL:      "https://api.coingecko.com",
+		Timeout:      30 * time.Second,
+		RetryCount:   3,
+		RateLimitRPS: 1,
+	}
+}
+
+// NewCoinGeckoClient creates a new CoinGecko API client with custom configuration.
+func NewCoinGeckoClient(config CoinGeckoConfig) *CoinGeckoClient {
+	client := resty.New()
+	client.SetTimeout(config.Timeout)
+	client.SetRetryCount(config.RetryCount)
+	client.SetRetryWaitTime(2 * time.Second)
+	client.SetRetryMaxWaitTime(30 * time.Second)
+	client.AddRetryCondition(func(r *resty.Response, err error) bool {
+		if r != nil && retry.IsTransientStatus(r.StatusCode()) {
+			return true
+		}
+		return err != nil && IsRetryableError(err)
+	})
+	rateLimiter := NewRateLimiter(config.RateLimitRPS)
+	client.OnBeforeRequest(rateLimiter.OnBeforeRequest)
+
+	coinIDs := make(map[string]string, len(DefaultCoinIDs)+len(config.CoinIDs))
+	for symbol, id := range DefaultCoinIDs {
+		coinIDs[symbol] = id
+	}
+	for symbol, id := range config.CoinIDs {
+		coinIDs[strings.ToUpper(symbol)] = id
+	}
+
+	return &CoinGeckoClient{
+		client:      client,
+		baseURL:     config.BaseURL,
+		apiKey:      config.APIKey,
+		coinIDs:     coinIDs,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// ParseCoinIDs parses the CoinGecko IDs of symbols, e.g. "BTC=bitcoin,ETH=ethereum".
+func ParseCoinIDs(value string) (map[string]string, error) {
+	ids := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		symbol, id, ok := strings.Cut(entry, "=")
+		symbol, id = strings.TrimSpace(symbol), strings.TrimSpace(id)
+		if !ok || symbol == "" || id == "" {
+			return nil, fmt.Errorf("invalid coin ID %q: expected symbol=id", entry)
+		}
+		ids[strings.ToUpper(symbol)] = id
+	}
+	return ids, nil
+}
+
+// coinID returns the CoinGecko ID of the symbol.
+func (c *CoinGeckoClient) coinID(symbol string) (string, error) {
+	id, ok := c.coinIDs[strings.ToUpper(symbol)]
+	if !ok {
+		return "", fmt.Errorf("unknown crypto symbol %s, add its CoinGecko ID to CRYPTO_COIN_IDS: %w", symbol, ErrNotFound)
+	}
+	return id, nil
+}
+
+// coinGeckoMarket is an entry of the markets endpoint.
+type coinGeckoMarket struct {
+	ID             string    `json:"id"`
+	CurrentPrice   float64   `json:"current_price"`
+	High24h        float64   `json:"high_24h"`
+	Low24h         float64   `json:"low_24h"`
+	PriceChange24h float64   `json:"price_change_24h"`
+	TotalVolume    float64   `json:"total_volume"`
+	LastUpdated    time.Time `json:"last_updated"`
+}
+
+// coinGeckoChart is the response of the market chart endpoint, pairs of a timestamp in milliseconds and a value.
+type coinGeckoChart struct {
+	Prices       [][2]float64 `json:"prices"`
+	TotalVolumes [][2]float64 `json:"total_volumes"`
+}
+
+// GetCurrentPrice fetches the latest quote of a crypto asset. The open is the price 24 hours ago.
+func (c *CoinGeckoClient) GetCurrentPrice(ctx context.Context, symbol string) (*models.StockPrice, error) {
+	id, err := c.coinID(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var markets []coinGeckoMarket
+	if err := c.get(ctx, symbol, coinGeckoMarketsPath, map[string]string{
+		"vs_currency": coinGeckoCurrency,
+		"ids":         id,
+	}, &markets); err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 || markets[0].CurrentPrice <= 0 {
+		return nil, fmt.Errorf("no price found for %s: %w", symbol, ErrNoData)
+	}
+
+	market := markets[0]
+	updated := market.LastUpdated.Local()
+	if market.LastUpdated.IsZero() {
+		updated = time.Now()
+	}
+	high, low := math.Max(market.High24h, market.CurrentPrice), market.Low24h
+	if low <= 0 || low > market.CurrentPrice {
+		low = market.CurrentPrice
+	}
+
+	return &models.StockPrice{
+		Code:       strings.ToUpper(symbol),
+		Date:       updated,
+		OpenPrice:  utility.FloatToDecimal(market.CurrentPrice - market.PriceChange24h),
+		HighPrice:  utility.FloatToDecimal(high),
+		LowPrice:   utility.FloatToDecimal(low),
+		ClosePrice: utility.FloatToDecimal(market.CurrentPrice),
+		Volume:     cryptoVolume(market.TotalVolume, market.CurrentPrice),
+		Source:     SourceCoinGecko,
+		FetchedAt:  null.TimeFrom(time.Now()),
+	}, nil
+}
+
+// GetHistoricalData fetches the daily prices of a crypto asset. CoinGecko publishes one price per day,
+// which is used for all of the open, high, low and close.
+func (c *CoinGeckoClient) GetHistoricalData(ctx context.Context, symbol string, days int) ([]*models.StockPrice, error) {
+	id, err := c.coinID(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if days > MaxCryptoHistoricalDays {
+		return nil, fmt.Errorf("historical period too long: %d days (max %d)", days, MaxCryptoHistoricalDays)
+	}
+
+	var chart coinGeckoChart
+	if err := c.get(ctx, symbol, fmt.Sprintf(coinGeckoChartPath, id), map[string]string{
+		"vs_currency": coinGeckoCurrency,
+		"days":        strconv.Itoa(days),
+		"interval":    "daily",
+	}, &chart); err != nil {
+		return nil, err
+	}
+
+	prices := parseCoinGeckoChart(strings.ToUpper(symbol), chart)
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no historical data found for %s: %w", symbol, ErrNoData)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"symbol":  symbol,
+		"records": len(prices),
+	}).Debug("Crypto prices fetched")
+
+	return prices, nil
+}
+
+// get sends a rate limited request and decodes the JSON response into result.
+func (c *CoinGeckoClient) get(ctx context.Context, symbol, path string, params map[string]string, result interface{}) error {
+	req := c.client.R().SetContext(ctx).SetQueryParams(params)
+	if c.apiKey != "" {
+		req.SetHeader("x-cg-demo-api-key", c.apiKey)
+	}
+	resp, err := req.Get(c.baseURL + path)
+	if err != nil {
+		if IsRetryableError(err) {
+			return fmt.Errorf("temporary error fetching crypto prices for %s: %w", symbol, err)
+		}
+		return fmt.Errorf("failed to fetch crypto prices for %s: %w", symbol, err)
+	}
+
+	if resp.StatusCode() != 200 {
+		if httpErr := ClassifyHTTPError(resp.StatusCode()); httpErr != nil {
+			return fmt.Errorf("API error for %s: %w (status: %d)", symbol, httpErr, resp.StatusCode())
+		}
+		return fmt.Errorf("API returned %w", &retry.StatusError{StatusCode: resp.StatusCode()})
+	}
+
+	if err := json.Unmarshal(resp.Body(), result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// parseCoinGeckoChart converts the daily points of a market chart into prices, one per day.
+// The chart ends with the current price, which replaces the point of the same day.
+func parseCoinGeckoChart(symbol string, chart coinGeckoChart) []*models.StockPrice {
+	volumes := make(map[string]float64, len(chart.TotalVolumes))
+	for _, point := range chart.TotalVolumes {
+		volumes[chartDate(point[0]).Format("2006-01-02")] = point[1]
+	}
+
+	fetchedAt := null.TimeFrom(time.Now())
+	byDate := make(map[string]*models.StockPrice, len(chart.Prices))
+	for _, point := range chart.Prices {
+		if point[1] <= 0 {
+			continue
+		}
+		date := chartDate(point[0])
+		key := date.Format("2006-01-02")
+		value := utility.FloatToDecimal(point[1])
+		byDate[key] = &models.StockPrice{
+			Code:       symbol,
+			Date:       date,
+			OpenPrice:  value,
+			HighPrice:  value,
+			LowPrice:   value,
+			ClosePrice: value,
+			Volume:     cryptoVolume(volumes[key], point[1]),
+			Source:     SourceCoinGecko,
+			FetchedAt:  fetchedAt,
+		}
+	}
+
+	prices := make([]*models.StockPrice, 0, len(byDate))
+	for _, price := range byDate {
+		prices = append(prices, price)
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Date.Before(prices[j].Date) })
+	return prices
+}
+
+// chartDate returns the local date of a chart timestamp in milliseconds.
+func chartDate(timestamp float64) time.Time {
+	t := time.UnixMilli(int64(timestamp)).Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// cryptoVolume converts a trading volume in yen into the number of coins traded.
+func cryptoVolume(volumeYen, price float64) int64 {
+	if price <= 0 {
+		return 0
+	}
+	return int64(math.Round(volumeYen / price))
+}
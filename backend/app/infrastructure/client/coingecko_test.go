@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestCoinGeckoClient(url string) *CoinGeckoClient {
+	return NewCoinGeckoClient(CoinGeckoConfig{
+		BaseURL:      url,
+		APIKey:       "demo-key",
+		CoinIDs:      map[string]string{"pepe": "pepe"},
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+}
+
+func TestCoinGeckoClient_GetCurrentPrice(t *testing.T) {
+	var apiKey, ids string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != coinGeckoMarketsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		apiKey = r.Header.Get("x-cg-demo-api-key")
+		ids = r.URL.Query().Get("ids")
+		w.Write([]byte(`[{"id":"bitcoin","current_price":9500000,"high_24h":9600000,"low_24h":9300000,
+			"price_change_24h":100000,"total_volume":95000000000,"last_updated":"2024-01-05T10:00:00.000Z"}]`))
+	}))
+	defer server.Close()
+
+	price, err := newTestCoinGeckoClient(server.URL).GetCurrentPrice(context.Background(), "btc")
+	if err != nil {
+		t.Fatalf("GetCurrentPrice() error = %v", err)
+	}
+
+	if apiKey != "demo-key" || ids != "bitcoin" {
+		t.Errorf("api key = %q, ids = %q", apiKey, ids)
+	}
+	got := []float64{
+		utility.DecimalToFloat(price.OpenPrice),
+		utility.DecimalToFloat(price.HighPrice),
+		utility.DecimalToFloat(price.LowPrice),
+		utility.DecimalToFloat(price.ClosePrice),
+		float64(price.Volume),
+	}
+	if diff := cmp.Diff([]float64{9400000, 9600000, 9300000, 9500000, 10000}, got); diff != "" {
+		t.Errorf("OHLCV mismatch (-want +got):\n%s", diff)
+	}
+	if price.Code != "BTC" || price.Source != SourceCoinGecko || !price.Date.Equal(time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("price = %+v", price)
+	}
+}
+
+func TestCoinGeckoClient_GetHistoricalData(t *testing.T) {
+	day := func(d int, hour int) float64 {
+		return float64(time.Date(2024, 1, d, hour, 0, 0, 0, time.Local).UnixMilli())
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/coins/pepe/market_chart" || r.URL.Query().Get("days") != "3" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// The chart ends with the current price of the last day
+		chart := coinGeckoChart{
+			Prices:       [][2]float64{{day(4, 0), 0.2}, {day(3, 0), 0.1}, {day(5, 0), 0.3}, {day(5, 15), 0.35}},
+			TotalVolumes: [][2]float64{{day(3, 0), 10}, {day(4, 0), 20}, {day(5, 0), 35}},
+		}
+		if err := json.NewEncoder(w).Encode(chart); err != nil {
+			t.Errorf("failed to encode chart: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	prices, err := newTestCoinGeckoClient(server.URL).GetHistoricalData(context.Background(), "PEPE", 3)
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+
+	type row struct {
+		Date   string
+		Close  float64
+		Volume int64
+	}
+	var got []row
+	for _, price := range prices {
+		got = append(got, row{price.Date.Format("2006-01-02"), utility.DecimalToFloat(price.ClosePrice), price.Volume})
+	}
+	want := []row{{"2024-01-03", 0.1, 100}, {"2024-01-04", 0.2, 100}, {"2024-01-05", 0.35, 100}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetHistoricalData() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCoinGeckoClient_UnknownSymbol(t *testing.T) {
+	client := newTestCoinGeckoClient("http://127.0.0.1:0")
+	if _, err := client.GetCurrentPrice(context.Background(), "UNKNOWN"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetCurrentPrice() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestParseCoinIDs(t *testing.T) {
+	ids, err := ParseCoinIDs(" pepe=pepe, WIF = dogwifcoin ,")
+	if err != nil {
+		t.Fatalf("ParseCoinIDs() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"PEPE": "pepe", "WIF": "dogwifcoin"}, ids); diff != "" {
+		t.Errorf("ParseCoinIDs() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ParseCoinIDs("BTC"); err == nil {
+		t.Error("ParseCoinIDs() should fail without an ID")
+	}
+}
@@ -2,10 +2,8 @@ package client
 
 import (
 	"errors"
-	"net"
-	"net/url"
-	"strings"
-	"syscall"
+
+	"github.com/boost-jp/stock-automation/app/utility/retry"
 )
 
 // Error types for better error handling and retry logic
@@ -20,6 +18,12 @@ var (
 	ErrNotFound     = errors.New("resource not found")
 	ErrBadRequest   = errors.New("bad request")
 	ErrUnauthorized = errors.New("unauthorized")
+	ErrNoData       = errors.New("no data available")
+	ErrUnsupported  = errors.New("not supported by the data source")
+	// ErrSchemaMismatch is returned when a response lacks required fields or has fields of unexpected types
+	ErrSchemaMismatch = errors.New("response schema mismatch")
+	// ErrQuotaExceeded is returned when the daily request limit of a data provider is used up
+	ErrQuotaExceeded = errors.New("daily quota exceeded")
 )
 
 // IsRetryableError determines if an error should trigger a retry
@@ -34,40 +38,8 @@ func IsRetryableError(err error) bool {
 		return true
 	}
 
-	// Check for network errors
-	if netErr, ok := err.(net.Error); ok {
-		return netErr.Temporary() || netErr.Timeout()
-	}
-
-	// Check for URL errors (DNS failures, etc.)
-	if urlErr, ok := err.(*url.Error); ok {
-		return IsRetryableError(urlErr.Err)
-	}
-
-	// Check for syscall errors
-	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
-		return true
-	}
-
-	// Check error messages for common retryable scenarios
-	errMsg := strings.ToLower(err.Error())
-	retryablePatterns := []string{
-		"timeout",
-		"temporary failure",
-		"connection reset",
-		"connection refused",
-		"no such host",
-		"too many requests",
-		"service unavailable",
-	}
-
-	for _, pattern := range retryablePatterns {
-		if strings.Contains(errMsg, pattern) {
-			return true
-		}
-	}
-
-	return false
+	// Network failures such as timeouts and refused connections
+	return retry.IsTransient(err)
 }
 
 // ClassifyHTTPError converts HTTP status codes to appropriate error types
@@ -56,12 +56,12 @@ func TestIsRetryableError(t *testing.T) {
 		{
 			name: "connection refused syscall error",
 			err:  syscall.ECONNREFUSED,
-			want: false, // syscall errors are not directly handled
+			want: true,
 		},
 		{
 			name: "connection reset syscall error",
 			err:  syscall.ECONNRESET,
-			want: false, // syscall errors are not directly handled
+			want: true,
 		},
 		{
 			name: "wrapped rate limit error is retryable",
@@ -217,8 +217,9 @@ func TestIsRetryableError_NetworkError(t *testing.T) {
 		timeout:   false,
 	}
 
-	if !IsRetryableError(netErr) {
-		t.Error("Expected temporary network error to be retryable")
+	// Temporary is deprecated and not reliable, so only timeouts are retried
+	if IsRetryableError(netErr) {
+		t.Error("Expected temporary network error without timeout to not be retryable")
 	}
 
 	netErr.temporary = false
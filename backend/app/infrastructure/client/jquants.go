@@ -0,0 +1,594 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Token lifetimes of the J-Quants API, shortened by a margin so that tokens are renewed before they expire.
+const (
+	jquantsIDTokenTTL      = 24*time.Hour - 10*time.Minute
+	jquantsRefreshTokenTTL = 7*24*time.Hour - time.Hour
+)
+
+// jquantsLatestPriceDays is the period searched for the latest daily quote.
+// It covers the 12-week delay of the free plan.
+const jquantsLatestPriceDays = 100
+
+// FundamentalDataClient provides financial indicators of stocks.
+type FundamentalDataClient interface {
+	GetFundamental(ctx context.Context, stockCode string) (*models.StockFundamental, error)
+}
+
+// MarketDataClient provides the daily quotes and the listed companies of the whole market.
+type MarketDataClient interface {
+	// GetMarketQuotes returns the daily prices of all stocks traded on the date, none on a holiday
+	GetMarketQuotes(ctx context.Context, date time.Time) ([]*models.StockPrice, error)
+	// GetListedCompanies returns the names of the listed companies by stock code
+	GetListedCompanies(ctx context.Context) (map[string]string, error)
+}
+
+// JQuantsClient implements StockDataClient, FundamentalDataClient and MarketDataClient using the J-Quants API of JPX.
+// The ID token is obtained from the refresh token, which is obtained from the mail address
+// and password when it is not configured or has expired; both are renewed automatically.
+type JQuantsClient struct {
+	client      *resty.Client
+	baseURL     string
+	rateLimiter *RateLimiter
+	quota       *QuotaManager
+
+	mailAddress string
+	password    string
+
+	mu                 sync.Mutex
+	refreshToken       string
+	refreshTokenExpiry time.Time
+	idToken            string
+	idTokenExpiry      time.Time
+	now                func() time.Time
+}
+
+// JQuantsConfig holds J-Quants client configuration.
+// Either RefreshToken or MailAddress and Password are required.
+type JQuantsConfig struct {
+	BaseURL      string
+	MailAddress  string
+	Password     string
+	RefreshToken string
+	Timeout      time.Duration
+	RetryCount   int
+	RateLimitRPS int
+}
+
+// DefaultJQuantsConfig returns default configuration for J-Quants client.
+func DefaultJQuantsConfig() JQuantsConfig {
+	return JQuantsConfig{
+		BaseURL:      "https://api.jquants.com",
+		Timeout:      30 * time.Second,
+		RetryCount:   3,
+		RateLimitRPS: 2,
+	}
+}
+
+// JQuantsDailyQuote is a daily quote of /v1/prices/daily_quotes. Prices are null on days without trades.
+type JQuantsDailyQuote struct {
+	Date            string   `json:"Date"`
+	Code            string   `json:"Code"`
+	Open            *float64 `json:"Open"`
+	High            *float64 `json:"High"`
+	Low             *float64 `json:"Low"`
+	Close           *float64 `json:"Close"`
+	Volume          *float64 `json:"Volume"`
+	AdjustmentClose *float64 `json:"AdjustmentClose"`
+}
+
+// JQuantsListedInfo is a listed company of /v1/listed/info.
+type JQuantsListedInfo struct {
+	Code        string `json:"Code"`
+	CompanyName string `json:"CompanyName"`
+}
+
+// JQuantsStatement is a financial statement of /v1/fins/statements. Values are strings, empty if not disclosed.
+type JQuantsStatement struct {
+	DisclosedDate                  string `json:"DisclosedDate"`
+	TypeOfCurrentPeriod            string `json:"TypeOfCurrentPeriod"`
+	EarningsPerShare               string `json:"EarningsPerShare"`
+	BookValuePerShare              string `json:"BookValuePerShare"`
+	Profit                         string `json:"Profit"`
+	Equity                         string `json:"Equity"`
+	ResultDividendPerShareAnnual   string `json:"ResultDividendPerShareAnnual"`
+	ForecastDividendPerShareAnnual string `json:"ForecastDividendPerShareAnnual"`
+}
+
+// NewJQuantsClient creates a new J-Quants client.
+func NewJQuantsClient(config JQuantsConfig) *JQuantsClient {
+	client := resty.New()
+	client.SetTimeout(config.Timeout)
+	client.SetRetryCount(config.RetryCount)
+	client.AddRetryCondition(func(r *resty.Response, err error) bool {
+		if r != nil && retry.IsTransientStatus(r.StatusCode()) {
+			return true
+		}
+		return err != nil && IsRetryableError(err)
+	})
+	rateLimiter := NewRateLimiter(config.RateLimitRPS)
+	client.OnBeforeRequest(rateLimiter.OnBeforeRequest)
+
+	c := &JQuantsClient{
+		client:       client,
+		baseURL:      config.BaseURL,
+		rateLimiter:  rateLimiter,
+		mailAddress:  config.MailAddress,
+		password:     config.Password,
+		refreshToken: config.RefreshToken,
+		now:          time.Now,
+	}
+	if c.refreshToken != "" {
+		c.refreshTokenExpiry = c.now().Add(jquantsRefreshTokenTTL)
+	}
+	return c
+}
+
+// SetQuotaManager counts the data requests against the daily quota of J-Quants.
+func (j *JQuantsClient) SetQuotaManager(quota *QuotaManager) {
+	j.quota = quota
+}
+
+// JQuantsCode maps a 4-digit stock code to the 5-digit J-Quants code, e.g. 7203 to 72030.
+func JQuantsCode(stockCode string) string {
+	if len(stockCode) == 4 {
+		return stockCode + "0"
+	}
+	return stockCode
+}
+
+// StockCodeFromJQuants maps a 5-digit J-Quants code to the 4-digit stock code, e.g. 72030 to 7203.
+// Codes of other forms are returned unchanged.
+func StockCodeFromJQuants(code string) string {
+	if len(code) == 5 && code[4] == '0' {
+		return code[:4]
+	}
+	return code
+}
+
+// GetCurrentPrice retrieves the latest daily quote available to the plan.
+func (j *JQuantsClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	prices, err := j.GetHistoricalData(ctx, stockCode, jquantsLatestPriceDays)
+	if err != nil {
+		return nil, err
+	}
+	return prices[len(prices)-1], nil
+}
+
+// GetHistoricalData retrieves daily stock price data of the last given days, up to MaxHistoricalDays.
+// Prices are the actual traded prices; the split-adjusted close is stored as the adjusted close.
+func (j *JQuantsClient) GetHistoricalData(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+	if days > MaxHistoricalDays {
+		return nil, fmt.Errorf("historical period too long: %d days (max %d)", days, MaxHistoricalDays)
+	}
+
+	end := j.now()
+	return j.getHistoricalRange(ctx, stockCode, end.AddDate(0, 0, -days), end)
+}
+
+// GetHistoricalDataRange retrieves the daily prices from the day of from to the day of to, both inclusive.
+func (j *JQuantsClient) GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	if err := validateHistoricalRange(from, to); err != nil {
+		return nil, err
+	}
+	return j.getHistoricalRange(ctx, stockCode, from, to)
+}
+
+// getHistoricalRange retrieves the daily prices between the days of start and end, both inclusive.
+func (j *JQuantsClient) getHistoricalRange(ctx context.Context, stockCode string, start, end time.Time) ([]*models.StockPrice, error) {
+	params := map[string]string{
+		"code": JQuantsCode(stockCode),
+		"from": start.Format("20060102"),
+		"to":   end.Format("20060102"),
+	}
+
+	var prices []*models.StockPrice
+	err := j.getPages(ctx, stockCode, "/v1/prices/daily_quotes", params, func(body []byte) (string, error) {
+		var page struct {
+			DailyQuotes   []JQuantsDailyQuote `json:"daily_quotes"`
+			PaginationKey string              `json:"pagination_key"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return "", err
+		}
+		for _, quote := range page.DailyQuotes {
+			if price := quote.toStockPrice(stockCode); price != nil {
+				prices = append(prices, price)
+			}
+		}
+		return page.PaginationKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no historical data found for %s: %w", stockCode, ErrNoData)
+	}
+	sort.Slice(prices, func(a, b int) bool {
+		return prices[a].Date.Before(prices[b].Date)
+	})
+
+	logrus.WithFields(logrus.Fields{
+		"code":    stockCode,
+		"records": len(prices),
+	}).Debug("J-Quants historical data fetched")
+
+	return prices, nil
+}
+
+// GetIntradayData is not supported because the J-Quants API provides daily quotes only.
+func (j *JQuantsClient) GetIntradayData(ctx context.Context, stockCode string, interval string) ([]*models.StockPrice, error) {
+	return nil, fmt.Errorf("intraday data of %s from J-Quants: %w", stockCode, ErrUnsupported)
+}
+
+// GetMarketQuotes retrieves the daily quotes of all stocks on the date. Stocks without trades are left out,
+// and a date without quotes, e.g. a holiday or a date not yet available to the plan, returns none.
+func (j *JQuantsClient) GetMarketQuotes(ctx context.Context, date time.Time) ([]*models.StockPrice, error) {
+	var prices []*models.StockPrice
+	err := j.getPages(ctx, "market", "/v1/prices/daily_quotes", map[string]string{"date": date.Format("20060102")}, func(body []byte) (string, error) {
+		var page struct {
+			DailyQuotes   []JQuantsDailyQuote `json:"daily_quotes"`
+			PaginationKey string              `json:"pagination_key"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return "", err
+		}
+		for _, quote := range page.DailyQuotes {
+			if price := quote.toStockPrice(StockCodeFromJQuants(quote.Code)); price != nil {
+				prices = append(prices, price)
+			}
+		}
+		return page.PaginationKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"date":    date.Format("2006-01-02"),
+		"records": len(prices),
+	}).Debug("J-Quants market quotes fetched")
+
+	return prices, nil
+}
+
+// GetListedCompanies retrieves the names of the listed companies by 4-digit stock code.
+func (j *JQuantsClient) GetListedCompanies(ctx context.Context) (map[string]string, error) {
+	names := make(map[string]string)
+	err := j.getPages(ctx, "market", "/v1/listed/info", nil, func(body []byte) (string, error) {
+		var page struct {
+			Info          []JQuantsListedInfo `json:"info"`
+			PaginationKey string              `json:"pagination_key"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return "", err
+		}
+		for _, info := range page.Info {
+			names[StockCodeFromJQuants(info.Code)] = info.CompanyName
+		}
+		return page.PaginationKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// GetFinancialStatements retrieves the financial statements of a stock ordered by disclosed date.
+func (j *JQuantsClient) GetFinancialStatements(ctx context.Context, stockCode string) ([]JQuantsStatement, error) {
+	var statements []JQuantsStatement
+	err := j.getPages(ctx, stockCode, "/v1/fins/statements", map[string]string{"code": JQuantsCode(stockCode)}, func(body []byte) (string, error) {
+		var page struct {
+			Statements    []JQuantsStatement `json:"statements"`
+			PaginationKey string             `json:"pagination_key"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return "", err
+		}
+		statements = append(statements, page.Statements...)
+		return page.PaginationKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(statements, func(a, b int) bool {
+		return statements[a].DisclosedDate < statements[b].DisclosedDate
+	})
+	return statements, nil
+}
+
+// GetFundamental calculates PER, PBR, ROE and dividend yield from the latest statements and daily quote.
+func (j *JQuantsClient) GetFundamental(ctx context.Context, stockCode string) (*models.StockFundamental, error) {
+	statements, err := j.GetFinancialStatements(ctx, stockCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no financial statements found for %s: %w", stockCode, ErrNoData)
+	}
+
+	price, err := j.GetCurrentPrice(ctx, stockCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return FundamentalFromStatements(stockCode, statements, utility.DecimalToFloat(price.ClosePrice)), nil
+}
+
+// FundamentalFromStatements calculates financial indicators at price from statements ordered by disclosed date.
+// EPS and ROE come from the latest full-year results, BPS from the latest statement disclosing it, and the
+// dividend from the latest annual forecast or result. Indicators that cannot be calculated are left null.
+func FundamentalFromStatements(stockCode string, statements []JQuantsStatement, price float64) *models.StockFundamental {
+	fundamental := &models.StockFundamental{Code: stockCode}
+	if price <= 0 {
+		return fundamental
+	}
+
+	var eps, bps, profit, equity, dividend null.Float64
+	for _, statement := range statements {
+		if statement.TypeOfCurrentPeriod == "FY" {
+			eps = parseJQuantsNull(statement.EarningsPerShare)
+			profit = parseJQuantsNull(statement.Profit)
+			equity = parseJQuantsNull(statement.Equity)
+			if value, ok := parseJQuantsValue(statement.ResultDividendPerShareAnnual); ok {
+				dividend = null.Float64From(value)
+			}
+		}
+		if value, ok := parseJQuantsValue(statement.BookValuePerShare); ok {
+			bps = null.Float64From(value)
+		}
+		if value, ok := parseJQuantsValue(statement.ForecastDividendPerShareAnnual); ok {
+			dividend = null.Float64From(value)
+		}
+	}
+
+	if eps.Valid && eps.Float64 != 0 {
+		fundamental.Per = null.Float64From(price / eps.Float64)
+	}
+	if bps.Valid && bps.Float64 > 0 {
+		fundamental.Pbr = null.Float64From(price / bps.Float64)
+	}
+	if profit.Valid && equity.Valid && equity.Float64 > 0 {
+		fundamental.Roe = null.Float64From(profit.Float64 / equity.Float64 * 100)
+	}
+	if dividend.Valid && dividend.Float64 >= 0 {
+		fundamental.DividendYield = null.Float64From(dividend.Float64 / price * 100)
+	}
+	return fundamental
+}
+
+// parseJQuantsValue parses a numeric statement value, false if it is not disclosed.
+func parseJQuantsValue(value string) (float64, bool) {
+	f, err := strconv.ParseFloat(value, 64)
+	return f, err == nil
+}
+
+// parseJQuantsNull parses a numeric statement value, null if it is not disclosed.
+func parseJQuantsNull(value string) null.Float64 {
+	f, ok := parseJQuantsValue(value)
+	return null.NewFloat64(f, ok)
+}
+
+// toStockPrice converts the quote to a stock price, nil if there were no trades on the day.
+func (q JQuantsDailyQuote) toStockPrice(stockCode string) *models.StockPrice {
+	if q.Open == nil || q.High == nil || q.Low == nil || q.Close == nil {
+		return nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", q.Date, time.Local)
+	if err != nil {
+		return nil
+	}
+
+	price := &models.StockPrice{
+		Code:       stockCode,
+		Date:       date,
+		OpenPrice:  utility.FloatToDecimal(*q.Open),
+		HighPrice:  utility.FloatToDecimal(*q.High),
+		LowPrice:   utility.FloatToDecimal(*q.Low),
+		ClosePrice: utility.FloatToDecimal(*q.Close),
+		Source:     SourceJQuants,
+		FetchedAt:  null.TimeFrom(time.Now()),
+	}
+	if q.Volume != nil {
+		price.Volume = int64(*q.Volume)
+	}
+	if q.AdjustmentClose != nil && *q.AdjustmentClose != *q.Close {
+		price.AdjClosePrice = utility.FloatToNullDecimal(*q.AdjustmentClose)
+	}
+	return price
+}
+
+// getPages requests path with the ID token and passes each page body to handle, which returns the
+// pagination key of the next page. An expired ID token is renewed once per request.
+func (j *JQuantsClient) getPages(ctx context.Context, stockCode, path string, params map[string]string, handle func(body []byte) (string, error)) error {
+	paginationKey := ""
+	for {
+		query := make(map[string]string, len(params)+1)
+		for key, value := range params {
+			query[key] = value
+		}
+		if paginationKey != "" {
+			query["pagination_key"] = paginationKey
+		}
+
+		body, err := j.get(ctx, stockCode, path, query)
+		if err != nil {
+			return err
+		}
+
+		paginationKey, err = handle(body)
+		if err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		if paginationKey == "" {
+			return nil
+		}
+	}
+}
+
+// get requests path with the ID token, renewing the token once if it is rejected.
+func (j *JQuantsClient) get(ctx context.Context, stockCode, path string, query map[string]string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		idToken, err := j.getIDToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := j.quota.Acquire(ctx, SourceJQuants); err != nil {
+			return nil, err
+		}
+
+		resp, err := j.client.R().
+			SetContext(ctx).
+			SetAuthToken(idToken).
+			SetQueryParams(query).
+			Get(j.baseURL + path)
+		if err != nil {
+			if IsRetryableError(err) {
+				return nil, fmt.Errorf("temporary error fetching data for %s: %w", stockCode, err)
+			}
+			return nil, fmt.Errorf("failed to fetch data for %s: %w", stockCode, err)
+		}
+
+		if resp.StatusCode() == http.StatusUnauthorized && attempt == 0 {
+			j.invalidateIDToken(idToken)
+			continue
+		}
+		if resp.StatusCode() != http.StatusOK {
+			if httpErr := ClassifyHTTPError(resp.StatusCode()); httpErr != nil {
+				return nil, fmt.Errorf("API error for %s: %w (status: %d)", stockCode, httpErr, resp.StatusCode())
+			}
+			return nil, fmt.Errorf("API returned %w", &retry.StatusError{StatusCode: resp.StatusCode()})
+		}
+		return resp.Body(), nil
+	}
+}
+
+// getIDToken returns a valid ID token, renewing it and the refresh token as needed.
+func (j *JQuantsClient) getIDToken(ctx context.Context) (string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	if j.idToken != "" && now.Before(j.idTokenExpiry) {
+		return j.idToken, nil
+	}
+
+	signedIn := false
+	if j.refreshToken == "" || !now.Before(j.refreshTokenExpiry) {
+		if err := j.authUser(ctx); err != nil {
+			return "", err
+		}
+		signedIn = true
+	}
+
+	err := j.authRefresh(ctx)
+	if err != nil && !signedIn && j.mailAddress != "" {
+		// The configured refresh token may have been revoked; sign in again
+		if err = j.authUser(ctx); err == nil {
+			err = j.authRefresh(ctx)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return j.idToken, nil
+}
+
+// invalidateIDToken discards the ID token rejected by the API unless it has already been renewed.
+func (j *JQuantsClient) invalidateIDToken(idToken string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.idToken == idToken {
+		j.idToken = ""
+	}
+}
+
+// authUser obtains a refresh token with the mail address and password. Called with mu held.
+func (j *JQuantsClient) authUser(ctx context.Context) error {
+	if j.mailAddress == "" || j.password == "" {
+		return fmt.Errorf("J-Quants refresh token expired and no mail address or password is configured: %w", ErrUnauthorized)
+	}
+
+	var result struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+	resp, err := j.client.R().
+		SetContext(ctx).
+		SetBody(map[string]string{"mailaddress": j.mailAddress, "password": j.password}).
+		SetResult(&result).
+		Post(j.baseURL + "/v1/token/auth_user")
+	if err := tokenError("auth_user", resp, err); err != nil {
+		return err
+	}
+	if result.RefreshToken == "" {
+		return fmt.Errorf("J-Quants auth_user returned no refresh token: %w", ErrUnauthorized)
+	}
+
+	j.refreshToken = result.RefreshToken
+	j.refreshTokenExpiry = j.now().Add(jquantsRefreshTokenTTL)
+	logrus.Debug("J-Quants refresh token obtained")
+	return nil
+}
+
+// authRefresh obtains an ID token with the refresh token. Called with mu held.
+func (j *JQuantsClient) authRefresh(ctx context.Context) error {
+	var result struct {
+		IDToken string `json:"idToken"`
+	}
+	resp, err := j.client.R().
+		SetContext(ctx).
+		SetQueryParam("refreshtoken", j.refreshToken).
+		SetResult(&result).
+		Post(j.baseURL + "/v1/token/auth_refresh")
+	if err := tokenError("auth_refresh", resp, err); err != nil {
+		return err
+	}
+	if result.IDToken == "" {
+		return fmt.Errorf("J-Quants auth_refresh returned no ID token: %w", ErrUnauthorized)
+	}
+
+	j.idToken = result.IDToken
+	j.idTokenExpiry = j.now().Add(jquantsIDTokenTTL)
+	logrus.Debug("J-Quants ID token refreshed")
+	return nil
+}
+
+// tokenError converts a failed token request to an error.
+func tokenError(endpoint string, resp *resty.Response, err error) error {
+	if err != nil {
+		return fmt.Errorf("J-Quants %s failed: %w", endpoint, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		httpErr := ClassifyHTTPError(resp.StatusCode())
+		if httpErr == nil {
+			httpErr = ErrBadRequest
+		}
+		if resp.StatusCode() == http.StatusBadRequest {
+			httpErr = ErrUnauthorized // invalid credentials or token
+		}
+		return fmt.Errorf("J-Quants %s failed: %w (status: %d)", endpoint, httpErr, resp.StatusCode())
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// CorporateEventClient provides the scheduled earnings announcements and dividend dates of stocks.
+type CorporateEventClient interface {
+	GetCorporateEvents(ctx context.Context, codes []string) ([]domain.CorporateEvent, error)
+}
+
+// JQuantsAnnouncement is a scheduled earnings announcement of /v1/fins/announcement.
+type JQuantsAnnouncement struct {
+	Date          string `json:"Date"`
+	Code          string `json:"Code"`
+	CompanyName   string `json:"CompanyName"`
+	FiscalYear    string `json:"FiscalYear"`
+	FiscalQuarter string `json:"FiscalQuarter"`
+}
+
+// JQuantsDividend is a dividend of /v1/fins/dividend. Dates and amounts not yet decided are "-".
+type JQuantsDividend struct {
+	Code              string `json:"Code"`
+	InterimFinalTerm  string `json:"InterimFinalTerm"`
+	RecordDate        string `json:"RecordDate"`
+	PayableDate       string `json:"PayableDate"`
+	GrossDividendRate string `json:"GrossDividendRate"`
+}
+
+// GetEarningsAnnouncements retrieves the scheduled earnings announcements of all stocks.
+// J-Quants lists the announcements of the next business day, so a daily sync registers each of them in time.
+func (j *JQuantsClient) GetEarningsAnnouncements(ctx context.Context) ([]JQuantsAnnouncement, error) {
+	var announcements []JQuantsAnnouncement
+	err := j.getPages(ctx, "market", "/v1/fins/announcement", nil, func(body []byte) (string, error) {
+		var page struct {
+			Announcement  []JQuantsAnnouncement `json:"announcement"`
+			PaginationKey string                `json:"pagination_key"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return "", err
+		}
+		announcements = append(announcements, page.Announcement...)
+		return page.PaginationKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+// GetDividends retrieves the dividends announced by a stock. The endpoint requires the premium plan.
+func (j *JQuantsClient) GetDividends(ctx context.Context, stockCode string) ([]JQuantsDividend, error) {
+	var dividends []JQuantsDividend
+	err := j.getPages(ctx, stockCode, "/v1/fins/dividend", map[string]string{"code": JQuantsCode(stockCode)}, func(body []byte) (string, error) {
+		var page struct {
+			Dividend      []JQuantsDividend `json:"dividend"`
+			PaginationKey string            `json:"pagination_key"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return "", err
+		}
+		dividends = append(dividends, page.Dividend...)
+		return page.PaginationKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dividends, nil
+}
+
+// GetCorporateEvents retrieves the earnings announcements, record dates and dividend payment dates
+// of the stocks. The dividends are left out if the plan does not include them.
+func (j *JQuantsClient) GetCorporateEvents(ctx context.Context, codes []string) ([]domain.CorporateEvent, error) {
+	announcements, err := j.GetEarningsAnnouncements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get earnings announcements: %w", err)
+	}
+
+	var dividends []JQuantsDividend
+	for _, code := range codes {
+		stockDividends, err := j.GetDividends(ctx, code)
+		if errors.Is(err, ErrUnauthorized) {
+			logrus.Warnf("Dividend dates are not available to the J-Quants plan, skipping them: %v", err)
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dividends of %s: %w", code, err)
+		}
+		dividends = append(dividends, stockDividends...)
+	}
+
+	return CorporateEventsFromJQuants(announcements, dividends, codes), nil
+}
+
+// CorporateEventsFromJQuants converts the announcements and dividends of the given stocks into
+// corporate events. Entries of other stocks and dates not yet decided are left out.
+func CorporateEventsFromJQuants(announcements []JQuantsAnnouncement, dividends []JQuantsDividend, codes []string) []domain.CorporateEvent {
+	targets := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		targets[code] = true
+	}
+
+	var events []domain.CorporateEvent
+	for _, announcement := range announcements {
+		code := StockCodeFromJQuants(announcement.Code)
+		date, ok := parseJQuantsDate(announcement.Date)
+		if !targets[code] || !ok {
+			continue
+		}
+		period := strings.TrimSpace(announcement.FiscalYear + " " + announcement.FiscalQuarter)
+		events = append(events, domain.CorporateEvent{
+			Code:   code,
+			Name:   announcement.CompanyName,
+			Type:   domain.CorporateEventEarnings,
+			Date:   date,
+			Period: period,
+			Detail: period,
+		})
+	}
+
+	for _, dividend := range dividends {
+		code := StockCodeFromJQuants(dividend.Code)
+		if !targets[code] {
+			continue
+		}
+		detail := ""
+		if _, ok := parseJQuantsValue(dividend.GrossDividendRate); ok {
+			detail = i18n.T("corporate_event.dividend_amount", dividend.GrossDividendRate)
+		}
+		if date, ok := parseJQuantsDate(dividend.RecordDate); ok {
+			events = append(events, domain.CorporateEvent{
+				Code: code, Type: domain.CorporateEventRecordDate, Date: date, Period: dividend.InterimFinalTerm, Detail: detail,
+			})
+		}
+		if date, ok := parseJQuantsDate(dividend.PayableDate); ok {
+			events = append(events, domain.CorporateEvent{
+				Code: code, Type: domain.CorporateEventDividendPayment, Date: date, Period: dividend.InterimFinalTerm, Detail: detail,
+			})
+		}
+	}
+	return events
+}
+
+// parseJQuantsDate parses a date of the J-Quants API in local time. Undecided dates ("-" or empty) return false.
+func parseJQuantsDate(value string) (time.Time, bool) {
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCorporateEventsFromJQuants(t *testing.T) {
+	announcements := []JQuantsAnnouncement{
+		{Date: "2024-08-01", Code: "72030", CompanyName: "トヨタ自動車", FiscalYear: "3月31日", FiscalQuarter: "第１四半期"},
+		{Date: "2024-08-01", Code: "99990", CompanyName: "Other", FiscalYear: "3月31日", FiscalQuarter: "第１四半期"},
+	}
+	dividends := []JQuantsDividend{
+		{Code: "72030", InterimFinalTerm: "2024-09", RecordDate: "2024-09-30", PayableDate: "-", GrossDividendRate: "40"},
+		{Code: "67580", InterimFinalTerm: "2024-09", RecordDate: "2024-09-30", PayableDate: "2024-12-02", GrossDividendRate: "-"},
+	}
+
+	got := CorporateEventsFromJQuants(announcements, dividends, []string{"7203", "6758"})
+
+	date := func(s string) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", s, time.Local)
+		return d
+	}
+	want := []domain.CorporateEvent{
+		{Code: "7203", Name: "トヨタ自動車", Type: domain.CorporateEventEarnings, Date: date("2024-08-01"), Period: "3月31日 第１四半期", Detail: "3月31日 第１四半期"},
+		{Code: "7203", Type: domain.CorporateEventRecordDate, Date: date("2024-09-30"), Period: "2024-09", Detail: "1株あたり配当 40円"},
+		{Code: "6758", Type: domain.CorporateEventRecordDate, Date: date("2024-09-30"), Period: "2024-09"},
+		{Code: "6758", Type: domain.CorporateEventDividendPayment, Date: date("2024-12-02"), Period: "2024-09"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CorporateEventsFromJQuants() mismatch (-want +got):\n%s", diff)
+	}
+}
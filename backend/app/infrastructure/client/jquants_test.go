@@ -0,0 +1,343 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeJQuantsServer serves the token and daily quote endpoints.
+// Only the latest issued ID token is accepted, and daily quotes are split into two pages.
+type fakeJQuantsServer struct {
+	mu            sync.Mutex
+	refreshToken  string
+	idToken       string
+	authUserCalls int
+	refreshCalls  int
+	issued        int
+}
+
+func (f *fakeJQuantsServer) handler(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/v1/token/auth_user":
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		f.authUserCalls++
+		if body["mailaddress"] != "user@example.com" || body["password"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.refreshToken = "refresh-valid"
+		json.NewEncoder(w).Encode(map[string]string{"refreshToken": f.refreshToken})
+	case "/v1/token/auth_refresh":
+		f.refreshCalls++
+		if r.URL.Query().Get("refreshtoken") != f.refreshToken {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.issued++
+		f.idToken = "id-" + strconv.Itoa(f.issued)
+		json.NewEncoder(w).Encode(map[string]string{"idToken": f.idToken})
+	case "/v1/prices/daily_quotes":
+		if r.Header.Get("Authorization") != "Bearer "+f.idToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if date := r.URL.Query().Get("date"); date != "" {
+			if date != "20240604" {
+				w.Write([]byte(`{"daily_quotes": []}`))
+				return
+			}
+			w.Write([]byte(`{"daily_quotes": [
+				{"Date": "2024-06-04", "Code": "72030", "Open": 3530, "High": 3600, "Low": 3510, "Close": 3590, "Volume": 9876500, "AdjustmentClose": 3590},
+				{"Date": "2024-06-04", "Code": "130A0", "Open": 800, "High": 820, "Low": 790, "Close": 815, "Volume": 120000, "AdjustmentClose": 815},
+				{"Date": "2024-06-04", "Code": "99990", "Open": null, "High": null, "Low": null, "Close": null, "Volume": 0, "AdjustmentClose": null}
+			]}`))
+			return
+		}
+		if r.URL.Query().Get("code") != "72030" {
+			w.Write([]byte(`{"daily_quotes": []}`))
+			return
+		}
+		if r.URL.Query().Get("pagination_key") == "" {
+			w.Write([]byte(`{"daily_quotes": [
+				{"Date": "2024-06-04", "Code": "72030", "Open": 3530, "High": 3600, "Low": 3510, "Close": 3590, "Volume": 9876500, "AdjustmentClose": 3590},
+				{"Date": "2024-06-05", "Code": "72030", "Open": null, "High": null, "Low": null, "Close": null, "Volume": 0, "AdjustmentClose": null}
+			], "pagination_key": "next"}`))
+			return
+		}
+		w.Write([]byte(`{"daily_quotes": [
+			{"Date": "2024-06-03", "Code": "72030", "Open": 7000, "High": 7100, "Low": 6960, "Close": 7040, "Volume": 6172800, "AdjustmentClose": 3520}
+		]}`))
+	case "/v1/listed/info":
+		if r.Header.Get("Authorization") != "Bearer "+f.idToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"info": [{"Code": "72030", "CompanyName": "トヨタ自動車"}, {"Code": "130A0", "CompanyName": "Veritas In Silico"}]}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// calls returns how many times auth_user and auth_refresh were called.
+func (f *fakeJQuantsServer) calls() (authUser, refresh int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.authUserCalls, f.refreshCalls
+}
+
+func newTestJQuantsClient(server *httptest.Server, config JQuantsConfig) *JQuantsClient {
+	config.BaseURL = server.URL
+	config.Timeout = 5 * time.Second
+	config.RateLimitRPS = 100
+	return NewJQuantsClient(config)
+}
+
+func TestJQuantsCode(t *testing.T) {
+	tests := map[string]string{
+		"7203":  "72030",
+		"72030": "72030",
+		"130A":  "130A0",
+	}
+
+	for code, want := range tests {
+		if got := JQuantsCode(code); got != want {
+			t.Errorf("JQuantsCode(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestStockCodeFromJQuants(t *testing.T) {
+	tests := map[string]string{
+		"72030": "7203",
+		"130A0": "130A",
+		"7203":  "7203",
+		"25935": "25935",
+	}
+
+	for code, want := range tests {
+		if got := StockCodeFromJQuants(code); got != want {
+			t.Errorf("StockCodeFromJQuants(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestJQuantsClient_Interface(t *testing.T) {
+	client := NewJQuantsClient(DefaultJQuantsConfig())
+
+	var _ StockDataClient = client
+	var _ FundamentalDataClient = client
+	var _ MarketDataClient = client
+}
+
+func TestJQuantsClient_GetMarketQuotes(t *testing.T) {
+	fake := &fakeJQuantsServer{}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	client := newTestJQuantsClient(server, JQuantsConfig{MailAddress: "user@example.com", Password: "secret"})
+	ctx := context.Background()
+
+	prices, err := client.GetMarketQuotes(ctx, time.Date(2024, 6, 4, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("GetMarketQuotes() error = %v", err)
+	}
+	var codes []string
+	for _, price := range prices {
+		codes = append(codes, price.Code)
+	}
+	// Stock codes are mapped to 4 digits, without the stock that was not traded
+	if diff := cmp.Diff([]string{"7203", "130A"}, codes); diff != "" {
+		t.Errorf("codes mismatch (-want +got):\n%s", diff)
+	}
+
+	holiday, err := client.GetMarketQuotes(ctx, time.Date(2024, 6, 8, 0, 0, 0, 0, time.Local))
+	if err != nil || len(holiday) != 0 {
+		t.Errorf("GetMarketQuotes() on a holiday = %d prices, %v; want none", len(holiday), err)
+	}
+
+	names, err := client.GetListedCompanies(ctx)
+	if err != nil {
+		t.Fatalf("GetListedCompanies() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"7203": "トヨタ自動車", "130A": "Veritas In Silico"}, names); diff != "" {
+		t.Errorf("names mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJQuantsClient_GetHistoricalData(t *testing.T) {
+	fake := &fakeJQuantsServer{}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	client := newTestJQuantsClient(server, JQuantsConfig{MailAddress: "user@example.com", Password: "secret"})
+
+	prices, err := client.GetHistoricalData(context.Background(), "7203", 30)
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+
+	type bar struct {
+		Date     string
+		Open     float64
+		Close    float64
+		AdjClose float64
+		Volume   int64
+	}
+	var got []bar
+	for _, price := range prices {
+		got = append(got, bar{
+			Date:     price.Date.Format("2006-01-02"),
+			Open:     utility.DecimalToFloat(price.OpenPrice),
+			Close:    utility.DecimalToFloat(price.ClosePrice),
+			AdjClose: utility.NullDecimalToFloat(price.AdjClosePrice),
+			Volume:   price.Volume,
+		})
+	}
+	// Sorted by date across pages, without the day of no trades; the adjusted close is kept only before the split
+	want := []bar{
+		{Date: "2024-06-03", Open: 7000, Close: 7040, AdjClose: 3520, Volume: 6172800},
+		{Date: "2024-06-04", Open: 3530, Close: 3590, AdjClose: 0, Volume: 9876500},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("prices mismatch (-want +got):\n%s", diff)
+	}
+
+	if authUser, refresh := fake.calls(); authUser != 1 || refresh != 1 {
+		t.Errorf("auth_user called %d times, auth_refresh %d times; want 1 each", authUser, refresh)
+	}
+}
+
+func TestJQuantsClient_TokenRenewal(t *testing.T) {
+	fake := &fakeJQuantsServer{refreshToken: "refresh-valid"}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	now := time.Now()
+	client := newTestJQuantsClient(server, JQuantsConfig{RefreshToken: "refresh-valid"})
+	client.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := client.GetHistoricalData(ctx, "7203", 30); err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+	if authUser, refresh := fake.calls(); authUser != 0 || refresh != 1 {
+		t.Fatalf("auth_user called %d times, auth_refresh %d times; want 0 and 1", authUser, refresh)
+	}
+
+	// The ID token is reused until it expires
+	if _, err := client.GetHistoricalData(ctx, "7203", 30); err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+	if _, refresh := fake.calls(); refresh != 1 {
+		t.Errorf("auth_refresh called %d times, want the ID token reused", refresh)
+	}
+
+	// An expired ID token is refreshed
+	now = now.Add(25 * time.Hour)
+	if _, err := client.GetHistoricalData(ctx, "7203", 30); err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+	if _, refresh := fake.calls(); refresh != 2 {
+		t.Errorf("auth_refresh called %d times, want 2 after the ID token expired", refresh)
+	}
+
+	// A token rejected by the API is refreshed once
+	fake.mu.Lock()
+	fake.idToken = "revoked"
+	fake.mu.Unlock()
+	if _, err := client.GetHistoricalData(ctx, "7203", 30); err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+	if _, refresh := fake.calls(); refresh != 3 {
+		t.Errorf("auth_refresh called %d times, want 3 after the ID token was rejected", refresh)
+	}
+
+	// The refresh token expires without credentials to sign in again
+	now = now.Add(8 * 24 * time.Hour)
+	_, err := client.GetHistoricalData(ctx, "7203", 30)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetHistoricalData() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestJQuantsClient_InvalidCredentials(t *testing.T) {
+	fake := &fakeJQuantsServer{}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	client := newTestJQuantsClient(server, JQuantsConfig{MailAddress: "user@example.com", Password: "wrong"})
+
+	_, err := client.GetCurrentPrice(context.Background(), "7203")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetCurrentPrice() error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestJQuantsClient_GetCurrentPrice(t *testing.T) {
+	fake := &fakeJQuantsServer{}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	defer server.Close()
+
+	client := newTestJQuantsClient(server, JQuantsConfig{MailAddress: "user@example.com", Password: "secret"})
+
+	price, err := client.GetCurrentPrice(context.Background(), "7203")
+	if err != nil {
+		t.Fatalf("GetCurrentPrice() error = %v", err)
+	}
+	if got := price.Date.Format("2006-01-02"); got != "2024-06-04" {
+		t.Errorf("Date = %s, want the latest trading day 2024-06-04", got)
+	}
+
+	if _, err := client.GetCurrentPrice(context.Background(), "0000"); !errors.Is(err, ErrNoData) {
+		t.Errorf("GetCurrentPrice() error = %v, want ErrNoData for a code without quotes", err)
+	}
+}
+
+func TestFundamentalFromStatements(t *testing.T) {
+	statements := []JQuantsStatement{
+		{DisclosedDate: "2023-05-10", TypeOfCurrentPeriod: "FY", EarningsPerShare: "180", BookValuePerShare: "2000",
+			Profit: "2400000000000", Equity: "30000000000000", ResultDividendPerShareAnnual: "60", ForecastDividendPerShareAnnual: "70"},
+		{DisclosedDate: "2024-05-08", TypeOfCurrentPeriod: "FY", EarningsPerShare: "200", BookValuePerShare: "2500",
+			Profit: "4000000000000", Equity: "32000000000000", ResultDividendPerShareAnnual: "75", ForecastDividendPerShareAnnual: ""},
+		{DisclosedDate: "2024-08-01", TypeOfCurrentPeriod: "1Q", EarningsPerShare: "60", BookValuePerShare: "",
+			ForecastDividendPerShareAnnual: "80"},
+	}
+
+	got := FundamentalFromStatements("7203", statements, 4000)
+	want := &models.StockFundamental{
+		Code:          "7203",
+		Per:           null.Float64From(20),
+		Pbr:           null.Float64From(1.6),
+		Roe:           null.Float64From(12.5),
+		DividendYield: null.Float64From(2),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FundamentalFromStatements() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Nothing can be calculated without a price or full-year results
+	got = FundamentalFromStatements("7203", statements[2:], 4000)
+	if got.Per.Valid || got.Pbr.Valid || got.Roe.Valid {
+		t.Errorf("FundamentalFromStatements() = %+v, want only the dividend yield", got)
+	}
+	if got := FundamentalFromStatements("7203", statements, 0); got.HasAnyValue() {
+		t.Errorf("FundamentalFromStatements() = %+v, want no values without a price", got)
+	}
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxQuotaThrottle caps how much the collection intervals are stretched when a quota runs low.
+const maxQuotaThrottle = 12.0
+
+// quotaLocation is the time zone in which the daily quotas are reset at midnight.
+var quotaLocation = time.FixedZone("JST", 9*60*60)
+
+// QuotaUsage represents the requests made to a provider today against its daily limit.
+type QuotaUsage struct {
+	Provider string
+	Limit    int // 0 means unlimited
+	Used     int
+	ResetAt  time.Time
+}
+
+// Remaining returns the requests left today, or -1 if the provider is unlimited.
+func (u QuotaUsage) Remaining() int {
+	if u.Limit <= 0 {
+		return -1
+	}
+	return max(u.Limit-u.Used, 0)
+}
+
+// Percent returns the used share of the limit in percent, 0 if the provider is unlimited.
+func (u QuotaUsage) Percent() float64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return float64(u.Used) / float64(u.Limit) * 100
+}
+
+// Exhausted reports whether no requests are left today.
+func (u QuotaUsage) Exhausted() bool {
+	return u.Limit > 0 && u.Used >= u.Limit
+}
+
+// QuotaAlertHandler is called when the usage of a provider reaches the warning level or the limit.
+type QuotaAlertHandler func(ctx context.Context, usage QuotaUsage)
+
+// QuotaManager tracks the daily request counts of each data provider against its limit.
+// Requests beyond the limit fail with ErrQuotaExceeded until the counts are reset at midnight JST.
+// The counts are kept in memory, so each process tracks its own requests.
+// A nil QuotaManager allows all requests.
+type QuotaManager struct {
+	mu          sync.Mutex
+	limits      map[string]int
+	warnPercent float64
+	used        map[string]int
+	alerted     map[string]string // level alerted today per provider: "warning" or "exhausted"
+	day         string
+	handler     QuotaAlertHandler
+	now         func() time.Time
+}
+
+// NewQuotaManager creates a quota manager with the daily limits per provider, warning when
+// warnPercent of a limit is used.
+func NewQuotaManager(limits map[string]int, warnPercent float64) *QuotaManager {
+	return &QuotaManager{
+		limits:      limits,
+		warnPercent: warnPercent,
+		used:        make(map[string]int),
+		alerted:     make(map[string]string),
+		now:         time.Now,
+	}
+}
+
+// ParseQuotaLimits parses daily limits like "yahoo=2000,jquants=1000".
+func ParseQuotaLimits(value string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		provider, limit, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid quota limit %q: expected provider=limit", entry)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid quota limit %q: limit must be a non-negative integer", entry)
+		}
+		limits[strings.ToLower(strings.TrimSpace(provider))] = n
+	}
+	return limits, nil
+}
+
+// SetAlertHandler sets the handler called when a provider reaches the warning level or the limit.
+func (q *QuotaManager) SetAlertHandler(handler QuotaAlertHandler) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handler = handler
+}
+
+// Acquire counts a request to the provider, or returns ErrQuotaExceeded if its daily limit is used up.
+// The handler is called once a day when the usage reaches the warning level and once when it reaches the limit.
+func (q *QuotaManager) Acquire(ctx context.Context, provider string) error {
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	q.resetIfNewDay()
+	usage := q.usage(provider)
+	if usage.Exhausted() {
+		q.mu.Unlock()
+		return fmt.Errorf("%s daily quota of %d requests: %w", provider, usage.Limit, ErrQuotaExceeded)
+	}
+
+	q.used[provider]++
+	usage.Used++
+	level := ""
+	switch {
+	case usage.Exhausted():
+		level = "exhausted"
+	case usage.Limit > 0 && usage.Percent() >= q.warnPercent:
+		level = "warning"
+	}
+	notify := level != "" && q.alerted[provider] != level && q.alerted[provider] != "exhausted"
+	if notify {
+		q.alerted[provider] = level
+	}
+	handler := q.handler
+	q.mu.Unlock()
+
+	if notify {
+		logrus.WithFields(logrus.Fields{
+			"provider": provider,
+			"used":     usage.Used,
+			"limit":    usage.Limit,
+		}).Warn("Data provider quota running low")
+		if handler != nil {
+			handler(ctx, usage)
+		}
+	}
+	return nil
+}
+
+// Usage returns today's usage of the provider.
+func (q *QuotaManager) Usage(provider string) QuotaUsage {
+	if q == nil {
+		return QuotaUsage{Provider: provider}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.resetIfNewDay()
+	return q.usage(provider)
+}
+
+// Usages returns today's usage of the providers with a limit or requests, sorted by provider.
+func (q *QuotaManager) Usages() []QuotaUsage {
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.resetIfNewDay()
+
+	providers := make(map[string]bool)
+	for provider := range q.limits {
+		providers[provider] = true
+	}
+	for provider := range q.used {
+		providers[provider] = true
+	}
+	usages := make([]QuotaUsage, 0, len(providers))
+	for provider := range providers {
+		usages = append(usages, q.usage(provider))
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Provider < usages[j].Provider })
+	return usages
+}
+
+// IntervalMultiplier returns how much the collection intervals should be stretched to save the quota
+// of the provider: 1 below the warning level, rising as the remaining requests run out, up to 12.
+func (q *QuotaManager) IntervalMultiplier(provider string) float64 {
+	usage := q.Usage(provider)
+	if usage.Limit <= 0 {
+		return 1
+	}
+	if usage.Exhausted() {
+		return maxQuotaThrottle
+	}
+
+	ratio := usage.Percent() / 100
+	warn := q.warnPercent / 100
+	if ratio < warn || warn >= 1 {
+		return 1
+	}
+	return min((1-warn)/(1-ratio), maxQuotaThrottle)
+}
+
+// usage returns the usage of the provider. Must be called with the lock held.
+func (q *QuotaManager) usage(provider string) QuotaUsage {
+	now := q.now().In(quotaLocation)
+	return QuotaUsage{
+		Provider: provider,
+		Limit:    q.limits[provider],
+		Used:     q.used[provider],
+		ResetAt:  time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, quotaLocation),
+	}
+}
+
+// resetIfNewDay clears the counts at midnight JST. Must be called with the lock held.
+func (q *QuotaManager) resetIfNewDay() {
+	day := q.now().In(quotaLocation).Format("2006-01-02")
+	if day == q.day {
+		return
+	}
+	q.day = day
+	q.used = make(map[string]int)
+	q.alerted = make(map[string]string)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseQuotaLimits(t *testing.T) {
+	got, err := ParseQuotaLimits(" Yahoo=2000, jquants=1000 ,")
+	if err != nil {
+		t.Fatalf("ParseQuotaLimits() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]int{"yahoo": 2000, "jquants": 1000}, got); diff != "" {
+		t.Errorf("ParseQuotaLimits() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, invalid := range []string{"yahoo", "yahoo=many", "yahoo=-1"} {
+		if _, err := ParseQuotaLimits(invalid); err == nil {
+			t.Errorf("ParseQuotaLimits(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestQuotaManager_Acquire(t *testing.T) {
+	now := time.Date(2024, 6, 7, 23, 0, 0, 0, quotaLocation)
+	quota := NewQuotaManager(map[string]int{SourceYahoo: 10}, 80)
+	quota.now = func() time.Time { return now }
+	var alerts []int
+	quota.SetAlertHandler(func(ctx context.Context, usage QuotaUsage) {
+		alerts = append(alerts, usage.Used)
+	})
+
+	for i := 0; i < 10; i++ {
+		if err := quota.Acquire(context.Background(), SourceYahoo); err != nil {
+			t.Fatalf("Acquire() #%d error = %v", i+1, err)
+		}
+	}
+	// Alerted at 80% and at the limit
+	if diff := cmp.Diff([]int{8, 10}, alerts); diff != "" {
+		t.Errorf("alerts mismatch (-want +got):\n%s", diff)
+	}
+
+	err := quota.Acquire(context.Background(), SourceYahoo)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Acquire() over the limit error = %v, want ErrQuotaExceeded", err)
+	}
+	if IsRetryableError(err) {
+		t.Errorf("Quota exceeded should not be retryable: %v", err)
+	}
+
+	// Providers without a limit are only counted
+	if err := quota.Acquire(context.Background(), SourceStooq); err != nil {
+		t.Errorf("Acquire() of an unlimited provider error = %v", err)
+	}
+	if got := quota.Usage(SourceStooq).Remaining(); got != -1 {
+		t.Errorf("Remaining() of an unlimited provider = %d, want -1", got)
+	}
+
+	// Reset at midnight JST
+	now = now.Add(time.Hour)
+	if err := quota.Acquire(context.Background(), SourceYahoo); err != nil {
+		t.Errorf("Acquire() after midnight error = %v", err)
+	}
+	if got := quota.Usage(SourceYahoo).Used; got != 1 {
+		t.Errorf("Used after midnight = %d, want 1", got)
+	}
+}
+
+func TestQuotaManager_IntervalMultiplier(t *testing.T) {
+	tests := []struct {
+		used int
+		want float64
+	}{
+		{used: 0, want: 1},
+		{used: 79, want: 1},
+		{used: 80, want: 1},
+		{used: 90, want: 2},
+		{used: 95, want: 4},
+		{used: 99, want: 12},
+		{used: 100, want: 12},
+	}
+
+	for _, tt := range tests {
+		quota := NewQuotaManager(map[string]int{SourceYahoo: 100}, 80)
+		for i := 0; i < tt.used; i++ {
+			quota.Acquire(context.Background(), SourceYahoo)
+		}
+		if got := quota.IntervalMultiplier(SourceYahoo); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("IntervalMultiplier() with %d used = %v, want %v", tt.used, got, tt.want)
+		}
+	}
+
+	var unset *QuotaManager
+	if got := unset.IntervalMultiplier(SourceYahoo); got != 1 {
+		t.Errorf("IntervalMultiplier() without a quota manager = %v, want 1", got)
+	}
+}
@@ -2,17 +2,39 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"sync"
 	"time"
 
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 )
 
+// Adaptive rate control parameters
+const (
+	// rateDecreaseFactor is applied to the current rate when the API returns 429
+	rateDecreaseFactor = 0.5
+	// rateRecoveryStep is the fraction of the maximum rate restored per recovery step
+	rateRecoveryStep = 0.1
+	// minRateFloor is the lowest rate an adaptive limiter slows down to (one request per 10 seconds),
+	// as a rate of zero would block every request
+	minRateFloor = 0.1
+)
+
 // RateLimiter provides rate limiting functionality for API calls
 type RateLimiter struct {
 	limiter   *rate.Limiter
 	mu        sync.Mutex
 	lastReset time.Time
+
+	// Adaptive rate control (disabled for fixed rate limiters)
+	adaptive     bool
+	maxRate      float64
+	minRate      float64
+	recoverAfter int
+	successes    int
 }
 
 // NewRateLimiter creates a new rate limiter with specified requests per second
@@ -23,12 +45,97 @@ func NewRateLimiter(rps int) *RateLimiter {
 	}
 }
 
+// NewAdaptiveRateLimiter creates a rate limiter that starts at maxRPS, slows down on rate limit
+// responses down to minRPS, and speeds up again after recoverAfter consecutive successes.
+// A minRPS below minRateFloor, e.g. zero or negative, is raised to it.
+func NewAdaptiveRateLimiter(maxRPS int, minRPS float64, recoverAfter int) *RateLimiter {
+	rl := NewRateLimiter(maxRPS)
+	rl.adaptive = true
+	rl.maxRate = float64(maxRPS)
+	if minRPS < minRateFloor {
+		logrus.Warnf("Minimum request rate %.2f RPS is too low, using %.2f RPS", minRPS, minRateFloor)
+		minRPS = minRateFloor
+	}
+	rl.minRate = math.Min(minRPS, rl.maxRate)
+	rl.recoverAfter = recoverAfter
+	if rl.recoverAfter <= 0 {
+		rl.recoverAfter = 1
+	}
+	return rl
+}
+
 // Wait blocks until the rate limiter allows another request
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	return rl.limiter.Wait(ctx)
 }
 
+// OnBeforeRequest is a resty request middleware that waits for the rate limiter before every
+// attempt of a request, including resty's retries
+func (rl *RateLimiter) OnBeforeRequest(c *resty.Client, r *resty.Request) error {
+	if err := rl.Wait(r.Context()); err != nil {
+		return fmt.Errorf("rate limiter error: %w", err)
+	}
+	return nil
+}
+
 // TryWait attempts to reserve a request slot without blocking
 func (rl *RateLimiter) TryWait() bool {
 	return rl.limiter.Allow()
 }
+
+// CurrentRPS returns the current requests per second
+func (rl *RateLimiter) CurrentRPS() float64 {
+	return float64(rl.limiter.Limit())
+}
+
+// OnRateLimited lowers the rate after a rate limit response
+func (rl *RateLimiter) OnRateLimited() {
+	if !rl.adaptive {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.successes = 0
+	current := rl.CurrentRPS()
+	next := math.Max(current*rateDecreaseFactor, rl.minRate)
+	if next == current {
+		return
+	}
+
+	rl.setRate(next)
+	logrus.Warnf("Rate limited by API, lowering request rate: %.2f -> %.2f RPS", current, next)
+}
+
+// OnSuccess records a successful request and gradually restores the rate
+func (rl *RateLimiter) OnSuccess() {
+	if !rl.adaptive {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	current := rl.CurrentRPS()
+	if current >= rl.maxRate {
+		rl.successes = 0
+		return
+	}
+
+	rl.successes++
+	if rl.successes < rl.recoverAfter {
+		return
+	}
+
+	rl.successes = 0
+	next := math.Min(current+rl.maxRate*rateRecoveryStep, rl.maxRate)
+	rl.setRate(next)
+	logrus.Infof("Raising request rate: %.2f -> %.2f RPS", current, next)
+}
+
+// setRate updates the limit and burst of the underlying limiter
+func (rl *RateLimiter) setRate(rps float64) {
+	rl.limiter.SetLimit(rate.Limit(rps))
+	rl.limiter.SetBurst(int(math.Max(1, math.Floor(rps))))
+}
@@ -175,3 +175,69 @@ func TestRateLimiter_Concurrent(t *testing.T) {
 		t.Errorf("Concurrent requests completed too quickly: %v", elapsed)
 	}
 }
+
+func TestRateLimiter_Adaptive(t *testing.T) {
+	rl := NewAdaptiveRateLimiter(10, 1, 3)
+
+	if got := rl.CurrentRPS(); got != 10 {
+		t.Fatalf("Initial rate = %v, want 10", got)
+	}
+
+	// Rate limit responses halve the rate down to the minimum
+	for _, want := range []float64{5, 2.5, 1.25, 1, 1} {
+		rl.OnRateLimited()
+		if got := rl.CurrentRPS(); got != want {
+			t.Errorf("Rate after 429 = %v, want %v", got, want)
+		}
+	}
+
+	// The rate recovers by 10% of the maximum after every 3 consecutive successes
+	for i := 0; i < 2; i++ {
+		rl.OnSuccess()
+	}
+	if got := rl.CurrentRPS(); got != 1 {
+		t.Errorf("Rate before recovery = %v, want 1", got)
+	}
+	rl.OnSuccess()
+	if got := rl.CurrentRPS(); got != 2 {
+		t.Errorf("Rate after recovery = %v, want 2", got)
+	}
+
+	// A rate limit response resets the success streak
+	rl.OnSuccess()
+	rl.OnSuccess()
+	rl.OnRateLimited()
+	rl.OnSuccess()
+	if got := rl.CurrentRPS(); got != 1 {
+		t.Errorf("Rate after reset streak = %v, want 1", got)
+	}
+
+	// The rate never exceeds the maximum
+	for i := 0; i < 100; i++ {
+		rl.OnSuccess()
+	}
+	if got := rl.CurrentRPS(); got != 10 {
+		t.Errorf("Recovered rate = %v, want 10", got)
+	}
+}
+
+func TestRateLimiter_AdaptiveMinRateFloor(t *testing.T) {
+	for _, minRPS := range []float64{0, -1} {
+		rl := NewAdaptiveRateLimiter(10, minRPS, 3)
+		for i := 0; i < 20; i++ {
+			rl.OnRateLimited()
+		}
+		if got := rl.CurrentRPS(); got != minRateFloor {
+			t.Errorf("NewAdaptiveRateLimiter(10, %v, 3) slowed down to %v, want %v", minRPS, got, minRateFloor)
+		}
+	}
+}
+
+func TestRateLimiter_FixedIgnoresFeedback(t *testing.T) {
+	rl := NewRateLimiter(10)
+
+	rl.OnRateLimited()
+	if got := rl.CurrentRPS(); got != 10 {
+		t.Errorf("Fixed rate limiter rate = %v, want 10", got)
+	}
+}
@@ -0,0 +1,264 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// stooqNoData is the body Stooq returns instead of CSV for unknown symbols or empty periods.
+const stooqNoData = "No data"
+
+// Date formats of Stooq CSV and query parameters.
+const (
+	stooqDateFormat  = "2006-01-02"
+	stooqQueryFormat = "20060102"
+)
+
+// StooqClient implements StockDataClient using the Stooq CSV download endpoints.
+// Stooq prices are already adjusted for splits, so no adjusted close is stored with them.
+type StooqClient struct {
+	client      *resty.Client
+	baseURL     string
+	rateLimiter *RateLimiter
+	quota       *QuotaManager
+}
+
+// StooqConfig holds Stooq client configuration.
+type StooqConfig struct {
+	BaseURL       string
+	Timeout       time.Duration
+	RetryCount    int
+	RetryWaitTime time.Duration
+	RetryMaxWait  time.Duration
+	RateLimitRPS  int
+}
+
+// DefaultStooqConfig returns default configuration for Stooq client.
+func DefaultStooqConfig() StooqConfig {
+	return StooqConfig{
+		BaseURL:       "https://stooq.com",
+		Timeout:       30 * time.Second,
+		RetryCount:    3,
+		RetryWaitTime: 1 * time.Second,
+		RetryMaxWait:  10 * time.Second,
+		RateLimitRPS:  2,
+	}
+}
+
+// NewStooqClient creates a new Stooq client with custom configuration.
+func NewStooqClient(config StooqConfig) *StooqClient {
+	client := resty.New()
+	client.SetTimeout(config.Timeout)
+	client.SetRetryCount(config.RetryCount)
+	client.SetRetryWaitTime(config.RetryWaitTime)
+	client.SetRetryMaxWaitTime(config.RetryMaxWait)
+	client.AddRetryCondition(func(r *resty.Response, err error) bool {
+		if r != nil && retry.IsTransientStatus(r.StatusCode()) {
+			return true
+		}
+		return err != nil && IsRetryableError(err)
+	})
+	rateLimiter := NewRateLimiter(config.RateLimitRPS)
+	client.OnBeforeRequest(rateLimiter.OnBeforeRequest)
+
+	return &StooqClient{
+		client:      client,
+		baseURL:     config.BaseURL,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// SetQuotaManager counts the requests against the daily quota of Stooq.
+func (s *StooqClient) SetQuotaManager(quota *QuotaManager) {
+	s.quota = quota
+}
+
+// StooqSymbol maps a Tokyo Stock Exchange code to its Stooq symbol, e.g. 7203 to 7203.jp.
+// Codes that already have a market suffix and symbols of market indices, e.g. ^nkx for the Nikkei
+// 225, are only lowercased.
+func StooqSymbol(stockCode string) string {
+	symbol := strings.ToLower(strings.TrimSpace(stockCode))
+	if strings.Contains(symbol, ".") || domain.IsMarketIndexSymbol(symbol) {
+		return symbol
+	}
+	return symbol + ".jp"
+}
+
+// GetCurrentPrice retrieves the latest quote of the current or last trading day.
+func (s *StooqClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	records, err := s.fetchCSV(ctx, stockCode, "/q/l/", map[string]string{
+		"s": StooqSymbol(stockCode),
+		"f": "sd2t2ohlcv",
+		"h": "",
+		"e": "csv",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Symbol,Date,Time,Open,High,Low,Close,Volume
+	if len(records) < 2 || len(records[1]) < 8 {
+		return nil, fmt.Errorf("no current price found for %s: %w", stockCode, ErrNoData)
+	}
+
+	price, err := parseStooqBar(stockCode, records[1][1], records[1][3:8])
+	if err != nil {
+		return nil, fmt.Errorf("no current price found for %s: %w", stockCode, err)
+	}
+	price.Date = time.Now()
+
+	logrus.WithFields(logrus.Fields{
+		"code":  stockCode,
+		"price": price.ClosePrice,
+	}).Debug("Stooq current price fetched")
+
+	return price, nil
+}
+
+// GetHistoricalData retrieves daily stock price data of the last given days, up to MaxHistoricalDays.
+func (s *StooqClient) GetHistoricalData(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+	if days > MaxHistoricalDays {
+		return nil, fmt.Errorf("historical period too long: %d days (max %d)", days, MaxHistoricalDays)
+	}
+
+	end := time.Now()
+	return s.getHistoricalRange(ctx, stockCode, end.AddDate(0, 0, -days), end)
+}
+
+// GetHistoricalDataRange retrieves the daily prices from the day of from to the day of to, both inclusive.
+func (s *StooqClient) GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	if err := validateHistoricalRange(from, to); err != nil {
+		return nil, err
+	}
+	return s.getHistoricalRange(ctx, stockCode, from, to)
+}
+
+// getHistoricalRange retrieves the daily prices between the days of start and end, both inclusive.
+func (s *StooqClient) getHistoricalRange(ctx context.Context, stockCode string, start, end time.Time) ([]*models.StockPrice, error) {
+	records, err := s.fetchCSV(ctx, stockCode, "/q/d/l/", map[string]string{
+		"s":  StooqSymbol(stockCode),
+		"i":  "d",
+		"d1": start.Format(stooqQueryFormat),
+		"d2": end.Format(stooqQueryFormat),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Date,Open,High,Low,Close,Volume
+	var prices []*models.StockPrice
+	for _, record := range records[1:] {
+		if len(record) < 6 {
+			continue
+		}
+		price, err := parseStooqBar(stockCode, record[0], record[1:6])
+		if err != nil {
+			continue
+		}
+		prices = append(prices, price)
+	}
+
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no historical data found for %s: %w", stockCode, ErrNoData)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"code":    stockCode,
+		"records": len(prices),
+	}).Debug("Stooq historical data fetched")
+
+	return prices, nil
+}
+
+// GetIntradayData is not supported because Stooq provides no free intraday data for Japanese stocks.
+func (s *StooqClient) GetIntradayData(ctx context.Context, stockCode string, interval string) ([]*models.StockPrice, error) {
+	return nil, fmt.Errorf("intraday data of %s from Stooq: %w", stockCode, ErrUnsupported)
+}
+
+// fetchCSV requests a Stooq CSV endpoint and returns its records including the header.
+func (s *StooqClient) fetchCSV(ctx context.Context, stockCode, path string, params map[string]string) ([][]string, error) {
+	if err := s.quota.Acquire(ctx, SourceStooq); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		Get(s.baseURL + path)
+	if err != nil {
+		if IsRetryableError(err) {
+			return nil, fmt.Errorf("temporary error fetching data for %s: %w", stockCode, err)
+		}
+		return nil, fmt.Errorf("failed to fetch data for %s: %w", stockCode, err)
+	}
+
+	if resp.StatusCode() != 200 {
+		if httpErr := ClassifyHTTPError(resp.StatusCode()); httpErr != nil {
+			return nil, fmt.Errorf("API error for %s: %w (status: %d)", stockCode, httpErr, resp.StatusCode())
+		}
+		return nil, fmt.Errorf("API returned %w", &retry.StatusError{StatusCode: resp.StatusCode()})
+	}
+
+	body := bytes.TrimSpace(resp.Body())
+	if len(body) == 0 || string(body) == stooqNoData {
+		return nil, fmt.Errorf("no data found for %s: %w", stockCode, ErrNoData)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no data found for %s: %w", stockCode, ErrNoData)
+	}
+	return records, nil
+}
+
+// parseStooqBar parses a date and its open, high, low, close and volume fields.
+// Missing values are reported as N/D by Stooq and make the bar invalid.
+func parseStooqBar(stockCode, date string, fields []string) (*models.StockPrice, error) {
+	day, err := time.ParseInLocation(stooqDateFormat, date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", date, ErrNoData)
+	}
+
+	values := make([]float64, 4)
+	for i := range values {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("invalid price %q on %s: %w", fields[i], date, ErrNoData)
+		}
+		values[i] = value
+	}
+
+	// Volume may be missing for indices; treat it as zero
+	volume, _ := strconv.ParseFloat(fields[4], 64)
+
+	return &models.StockPrice{
+		Code:       stockCode,
+		Date:       day,
+		OpenPrice:  utility.FloatToDecimal(values[0]),
+		HighPrice:  utility.FloatToDecimal(values[1]),
+		LowPrice:   utility.FloatToDecimal(values[2]),
+		ClosePrice: utility.FloatToDecimal(values[3]),
+		Volume:     int64(volume),
+		Source:     SourceStooq,
+		FetchedAt:  null.TimeFrom(time.Now()),
+	}, nil
+}
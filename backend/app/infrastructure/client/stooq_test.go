@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestStooqClient(handler http.HandlerFunc) (*StooqClient, func()) {
+	server := httptest.NewServer(handler)
+	client := NewStooqClient(StooqConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+	return client, server.Close
+}
+
+func TestStooqSymbol(t *testing.T) {
+	tests := map[string]string{
+		"7203":     "7203.jp",
+		" 6758 ":   "6758.jp",
+		"7203.JP":  "7203.jp",
+		"aapl.us":  "aapl.us",
+		"1306.JP ": "1306.jp",
+		"^NKX":     "^nkx",
+	}
+
+	for code, want := range tests {
+		if got := StooqSymbol(code); got != want {
+			t.Errorf("StooqSymbol(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestStooqClient_Interface(t *testing.T) {
+	var _ StockDataClient = NewStooqClient(DefaultStooqConfig())
+}
+
+func TestStooqClient_GetHistoricalData(t *testing.T) {
+	var query map[string]string
+	client, closeServer := newTestStooqClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/q/d/l/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = map[string]string{"s": r.URL.Query().Get("s"), "i": r.URL.Query().Get("i")}
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\r\n" +
+			"2024-06-03,3500,3550,3480,3520.5,12345600\r\n" +
+			"2024-06-04,N/D,N/D,N/D,N/D,N/D\r\n" +
+			"2024-06-05,3530,3600,3510,3590,9876500\r\n"))
+	})
+	defer closeServer()
+
+	prices, err := client.GetHistoricalData(context.Background(), "7203", 30)
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+
+	if diff := cmp.Diff(map[string]string{"s": "7203.jp", "i": "d"}, query); diff != "" {
+		t.Errorf("query mismatch (-want +got):\n%s", diff)
+	}
+
+	type bar struct {
+		Code   string
+		Date   string
+		Open   float64
+		High   float64
+		Low    float64
+		Close  float64
+		Volume int64
+	}
+	var got []bar
+	for _, price := range prices {
+		got = append(got, bar{
+			Code:   price.Code,
+			Date:   price.Date.Format("2006-01-02"),
+			Open:   utility.DecimalToFloat(price.OpenPrice),
+			High:   utility.DecimalToFloat(price.HighPrice),
+			Low:    utility.DecimalToFloat(price.LowPrice),
+			Close:  utility.DecimalToFloat(price.ClosePrice),
+			Volume: price.Volume,
+		})
+		if price.AdjClosePrice.Big != nil {
+			t.Errorf("AdjClosePrice of %s should not be set", price.Date.Format("2006-01-02"))
+		}
+	}
+	want := []bar{
+		{Code: "7203", Date: "2024-06-03", Open: 3500, High: 3550, Low: 3480, Close: 3520.5, Volume: 12345600},
+		{Code: "7203", Date: "2024-06-05", Open: 3530, High: 3600, Low: 3510, Close: 3590, Volume: 9876500},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("prices mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStooqClient_GetHistoricalDataRange(t *testing.T) {
+	var query map[string]string
+	client, closeServer := newTestStooqClient(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{"d1": r.URL.Query().Get("d1"), "d2": r.URL.Query().Get("d2")}
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\r\n" +
+			"2020-01-06,3500,3550,3480,3520,12345600\r\n"))
+	})
+	defer closeServer()
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2020, 12, 31, 0, 0, 0, 0, time.Local)
+	prices, err := client.GetHistoricalDataRange(context.Background(), "7203", from, to)
+	if err != nil {
+		t.Fatalf("GetHistoricalDataRange() error = %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"d1": "20200101", "d2": "20201231"}, query); diff != "" {
+		t.Errorf("query mismatch (-want +got):\n%s", diff)
+	}
+	if len(prices) != 1 {
+		t.Errorf("GetHistoricalDataRange() = %d prices, want 1", len(prices))
+	}
+
+	if _, err := client.GetHistoricalDataRange(context.Background(), "7203", to, from); err == nil {
+		t.Error("GetHistoricalDataRange() should fail for a period ending before it starts")
+	}
+	if _, err := client.GetHistoricalDataRange(context.Background(), "7203", from, from.AddDate(0, 0, MaxHistoricalDays+1)); err == nil {
+		t.Error("GetHistoricalDataRange() should fail for periods longer than MaxHistoricalDays")
+	}
+}
+
+func TestStooqClient_GetHistoricalData_NoData(t *testing.T) {
+	client, closeServer := newTestStooqClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("No data"))
+	})
+	defer closeServer()
+
+	_, err := client.GetHistoricalData(context.Background(), "0000", 30)
+	if !errors.Is(err, ErrNoData) {
+		t.Errorf("GetHistoricalData() error = %v, want ErrNoData", err)
+	}
+}
+
+func TestStooqClient_GetHistoricalData_TooLong(t *testing.T) {
+	client := NewStooqClient(DefaultStooqConfig())
+
+	if _, err := client.GetHistoricalData(context.Background(), "7203", MaxHistoricalDays+1); err == nil {
+		t.Error("GetHistoricalData() should fail for periods longer than MaxHistoricalDays")
+	}
+}
+
+func TestStooqClient_GetCurrentPrice(t *testing.T) {
+	client, closeServer := newTestStooqClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/q/l/" || r.URL.Query().Get("s") != "7203.jp" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("Symbol,Date,Time,Open,High,Low,Close,Volume\r\n" +
+			"7203.JP,2024-06-05,15:30:00,3530,3600,3510,3590,9876500\r\n"))
+	})
+	defer closeServer()
+
+	price, err := client.GetCurrentPrice(context.Background(), "7203")
+	if err != nil {
+		t.Fatalf("GetCurrentPrice() error = %v", err)
+	}
+	if got := utility.DecimalToFloat(price.ClosePrice); got != 3590 {
+		t.Errorf("ClosePrice = %v, want 3590", got)
+	}
+	if price.Volume != 9876500 {
+		t.Errorf("Volume = %d, want 9876500", price.Volume)
+	}
+}
+
+func TestStooqClient_GetCurrentPrice_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "unknown symbol", status: http.StatusOK, body: "Symbol,Date,Time,Open,High,Low,Close,Volume\r\n0000.JP,N/D,N/D,N/D,N/D,N/D,N/D,N/D\r\n", wantErr: ErrNoData},
+		{name: "rate limited", status: http.StatusTooManyRequests, wantErr: ErrRateLimit},
+		{name: "forbidden", status: http.StatusForbidden, wantErr: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, closeServer := newTestStooqClient(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			defer closeServer()
+
+			_, err := client.GetCurrentPrice(context.Background(), "0000")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCurrentPrice() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStooqClient_GetIntradayData_Unsupported(t *testing.T) {
+	client := NewStooqClient(DefaultStooqConfig())
+
+	_, err := client.GetIntradayData(context.Background(), "7203", "5m")
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("GetIntradayData() error = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+func TestToushinClient_GetFundPrices(t *testing.T) {
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != toushinCSVPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = map[string]string{"isinCd": r.URL.Query().Get("isinCd"), "associFundCd": r.URL.Query().Get("associFundCd")}
+		// The header is Shift_JIS encoded, which must not break the parsing
+		w.Write([]byte("\x94\x4e\x8c\x8e\x93\xfa,\x8a\xee\x8f\x80\x89\xbf\x8a\x7a(\x89\x7e)\r\n" +
+			"2024年01月04日,23100,150000,,\r\n" +
+			"2024年01月05日,23456,151000,,\r\n" +
+			"2024年01月09日,-,151000,,\r\n" +
+			"2024年01月10日,23500,152000,,\r\n"))
+	}))
+	defer server.Close()
+
+	client := NewToushinClient(ToushinConfig{BaseURL: server.URL, Timeout: 5 * time.Second, RateLimitRPS: 100})
+	from := time.Date(2024, 1, 5, 0, 0, 0, 0, time.Local)
+	prices, err := client.GetFundPrices(context.Background(), "JP90C000H1T1", "0331418A", from)
+	if err != nil {
+		t.Fatalf("GetFundPrices() error = %v", err)
+	}
+
+	if query["isinCd"] != "JP90C000H1T1" || query["associFundCd"] != "0331418A" {
+		t.Errorf("query = %v", query)
+	}
+	if len(prices) != 2 {
+		t.Fatalf("len(prices) = %d, want 2 (from the date, without the row missing its value)", len(prices))
+	}
+	first := prices[0]
+	if !first.Date.Equal(from) || utility.DecimalToFloat(first.ClosePrice) != 23456 || first.Code != "0331418A" || first.Source != SourceToushin {
+		t.Errorf("prices[0] = %+v", first)
+	}
+}
+
+func TestToushinClient_GetFundPricesNoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("\r\n"))
+	}))
+	defer server.Close()
+
+	client := NewToushinClient(ToushinConfig{BaseURL: server.URL, Timeout: 5 * time.Second, RateLimitRPS: 100})
+	_, err := client.GetFundPrices(context.Background(), "JP90C000H1T1", "0331418A", time.Time{})
+	if !errors.Is(err, ErrNoData) {
+		t.Errorf("GetFundPrices() error = %v, want ErrNoData", err)
+	}
+}
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// VCRMode selects whether the VCR transport records or replays HTTP interactions.
+type VCRMode int
+
+const (
+	// VCRModeReplay serves responses from recorded fixtures without network access
+	VCRModeReplay VCRMode = iota
+	// VCRModeRecord sends requests to the real API and records the responses as fixtures
+	VCRModeRecord
+)
+
+// ErrVCRFixtureNotFound is returned in replay mode when no fixture is recorded for a request.
+var ErrVCRFixtureNotFound = errors.New("vcr fixture not found")
+
+// VCRModeFromEnv returns VCRModeRecord if RECORD_MODE=1, VCRModeReplay otherwise.
+func VCRModeFromEnv() VCRMode {
+	if os.Getenv("RECORD_MODE") == "1" {
+		return VCRModeRecord
+	}
+	return VCRModeReplay
+}
+
+// vcrInteraction is a recorded HTTP response for a request.
+type vcrInteraction struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// VCRTransport is an http.RoundTripper that records HTTP responses as JSON fixtures
+// and replays them, so that tests can run without calling real APIs.
+// Requests with the same method, path and query (except ignored parameters) share a fixture file;
+// repeated requests are recorded in order and replayed in the same order.
+type VCRTransport struct {
+	dir          string
+	mode         VCRMode
+	next         http.RoundTripper
+	ignoreParams map[string]bool
+
+	mu       sync.Mutex
+	recorded map[string]bool // fixtures written by this transport in record mode
+	played   map[string]int  // next interaction index per fixture in replay mode
+}
+
+// NewVCRTransport creates a VCR transport storing fixtures in dir.
+// ignoreParams are query parameters excluded from request matching, such as timestamps.
+func NewVCRTransport(dir string, mode VCRMode, ignoreParams ...string) *VCRTransport {
+	ignore := make(map[string]bool, len(ignoreParams))
+	for _, param := range ignoreParams {
+		ignore[param] = true
+	}
+
+	return &VCRTransport{
+		dir:          dir,
+		mode:         mode,
+		next:         http.DefaultTransport,
+		ignoreParams: ignore,
+		recorded:     make(map[string]bool),
+		played:       make(map[string]int),
+	}
+}
+
+// NewYahooVCRTransport creates a VCR transport for Yahoo Finance requests in the mode given by RECORD_MODE.
+// Time-dependent period parameters are ignored when matching requests.
+func NewYahooVCRTransport(dir string) *VCRTransport {
+	return NewVCRTransport(dir, VCRModeFromEnv(), "period1", "period2")
+}
+
+// RoundTrip records or replays the response for the request.
+func (v *VCRTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := v.fixturePath(req)
+
+	if v.mode == VCRModeRecord {
+		return v.record(req, path)
+	}
+	return v.replay(req, path)
+}
+
+// record sends the request to the real API and appends the response to the fixture.
+func (v *VCRTransport) record(req *http.Request, path string) (*http.Response, error) {
+	resp, err := v.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// Start a fresh fixture on the first request of this recording session
+	var interactions []vcrInteraction
+	if v.recorded[path] {
+		if interactions, err = readVCRFixture(path); err != nil {
+			return nil, err
+		}
+	}
+
+	interactions = append(interactions, vcrInteraction{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       string(body),
+	})
+
+	if err := writeVCRFixture(path, interactions); err != nil {
+		return nil, err
+	}
+	v.recorded[path] = true
+
+	return resp, nil
+}
+
+// replay serves the next recorded response for the request.
+func (v *VCRTransport) replay(req *http.Request, path string) (*http.Response, error) {
+	interactions, err := readVCRFixture(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s %s (record with RECORD_MODE=1)", ErrVCRFixtureNotFound, req.Method, req.URL)
+		}
+		return nil, err
+	}
+	if len(interactions) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", ErrVCRFixtureNotFound, path)
+	}
+
+	v.mu.Lock()
+	index := v.played[path]
+	v.played[path] = index + 1
+	v.mu.Unlock()
+
+	// Keep serving the last response once all recorded responses are used
+	if index >= len(interactions) {
+		index = len(interactions) - 1
+	}
+	interaction := interactions[index]
+
+	header := interaction.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(interaction.Body)),
+		ContentLength: int64(len(interaction.Body)),
+		Request:       req,
+	}, nil
+}
+
+var vcrUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fixturePath returns the fixture file for the request.
+// The file name contains the last path segment for readability and a hash of the matched request.
+func (v *VCRTransport) fixturePath(req *http.Request) string {
+	query := url.Values{}
+	for key, values := range req.URL.Query() {
+		if !v.ignoreParams[key] {
+			query[key] = values
+		}
+	}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(req.Method + " " + req.URL.Path)
+	for _, key := range keys {
+		b.WriteString("&" + key + "=" + strings.Join(query[key], ","))
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	name := vcrUnsafeChars.ReplaceAllString(filepath.Base(req.URL.Path), "_")
+
+	return filepath.Join(v.dir, fmt.Sprintf("%s_%s_%s.json", req.Method, name, hex.EncodeToString(sum[:])[:12]))
+}
+
+// readVCRFixture reads recorded interactions from a fixture file.
+func readVCRFixture(path string) ([]vcrInteraction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var interactions []vcrInteraction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("failed to parse vcr fixture %s: %w", path, err)
+	}
+	return interactions, nil
+}
+
+// writeVCRFixture writes interactions to a fixture file.
+func writeVCRFixture(path string, interactions []vcrInteraction) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create vcr fixture directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode vcr fixture: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write vcr fixture: %w", err)
+	}
+	return nil
+}
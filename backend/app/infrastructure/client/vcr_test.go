@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+func TestVCRModeFromEnv(t *testing.T) {
+	t.Setenv("RECORD_MODE", "1")
+	if got := VCRModeFromEnv(); got != VCRModeRecord {
+		t.Errorf("VCRModeFromEnv() with RECORD_MODE=1 = %v, want VCRModeRecord", got)
+	}
+
+	t.Setenv("RECORD_MODE", "")
+	if got := VCRModeFromEnv(); got != VCRModeReplay {
+		t.Errorf("VCRModeFromEnv() without RECORD_MODE = %v, want VCRModeReplay", got)
+	}
+}
+
+func TestVCRTransport_RecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"chart": {
+				"result": [{
+					"meta": {
+						"symbol": "7203.T",
+						"regularMarketPrice": %d,
+						"regularMarketOpen": 2000,
+						"regularMarketDayLow": 1990,
+						"regularMarketDayHigh": 2100,
+						"regularMarketVolume": 1000000
+					}
+				}]
+			}
+		}`, 2000+requests)
+	}))
+
+	config := YahooFinanceConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	}
+
+	// Record two responses for the same request
+	recorder := NewYahooFinanceClientWithConfig(config)
+	recorder.client.SetTransport(NewVCRTransport(dir, VCRModeRecord))
+
+	for i := 1; i <= 2; i++ {
+		price, err := recorder.GetCurrentPrice(context.Background(), "7203")
+		if err != nil {
+			t.Fatalf("Record GetCurrentPrice() error = %v", err)
+		}
+		if got := utility.DecimalToFloat(price.ClosePrice); got != float64(2000+i) {
+			t.Errorf("Recorded price = %v, want %v", got, 2000+i)
+		}
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read fixture directory: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 fixture file, got %d", len(files))
+	}
+
+	// Replay without the server, in recorded order
+	server.Close()
+
+	player := NewYahooFinanceClientWithConfig(config)
+	player.client.SetTransport(NewVCRTransport(dir, VCRModeReplay))
+
+	for _, want := range []float64{2001, 2002, 2002} {
+		price, err := player.GetCurrentPrice(context.Background(), "7203")
+		if err != nil {
+			t.Fatalf("Replay GetCurrentPrice() error = %v", err)
+		}
+		if got := utility.DecimalToFloat(price.ClosePrice); got != want {
+			t.Errorf("Replayed price = %v, want %v", got, want)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected 2 requests to the server, got %d", requests)
+	}
+}
+
+func TestVCRTransport_IgnoreParams(t *testing.T) {
+	dir := t.TempDir()
+	transport := NewVCRTransport(dir, VCRModeReplay, "period1", "period2")
+
+	newRequest := func(rawURL string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		return req
+	}
+
+	base := "https://query1.finance.yahoo.com/v8/finance/chart/7203.T"
+	a := transport.fixturePath(newRequest(base + "?interval=1d&period1=100&period2=200"))
+	b := transport.fixturePath(newRequest(base + "?period2=300&interval=1d&period1=150"))
+	c := transport.fixturePath(newRequest(base + "?interval=5m&period1=100&period2=200"))
+
+	if a != b {
+		t.Errorf("Requests differing only in ignored params should share a fixture: %s != %s", a, b)
+	}
+	if a == c {
+		t.Errorf("Requests with different params should not share a fixture: %s", a)
+	}
+}
+
+func TestVCRTransport_ReplayMissingFixture(t *testing.T) {
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:      "https://query1.finance.yahoo.com",
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+	client.client.SetTransport(NewVCRTransport(t.TempDir(), VCRModeReplay))
+
+	_, err := client.GetCurrentPrice(context.Background(), "7203")
+	if !errors.Is(err, ErrVCRFixtureNotFound) {
+		t.Errorf("Expected ErrVCRFixtureNotFound, got %v", err)
+	}
+}
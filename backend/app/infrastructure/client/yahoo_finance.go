@@ -3,20 +3,70 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
 	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 )
 
 // StockDataClient defines the interface for stock data providers.
 type StockDataClient interface {
-	GetCurrentPrice(stockCode string) (*models.StockPrice, error)
-	GetHistoricalData(stockCode string, days int) ([]*models.StockPrice, error)
-	GetIntradayData(stockCode string, interval string) ([]*models.StockPrice, error)
+	GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error)
+	GetHistoricalData(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error)
+	// GetHistoricalDataRange retrieves the daily prices from the day of from to the day of to, both inclusive.
+	GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error)
+	GetIntradayData(ctx context.Context, stockCode string, interval string) ([]*models.StockPrice, error)
+}
+
+// Stock data source types.
+const (
+	SourceYahoo   = "yahoo"
+	SourceStooq   = "stooq"
+	SourceJQuants = "jquants"
+)
+
+const (
+	// MaxHistoricalDays is the longest period supported by GetHistoricalData (10 years).
+	MaxHistoricalDays = 3650
+	// historicalChunkDays is the longest period requested at once; longer periods are split.
+	historicalChunkDays = 365
+)
+
+// validateHistoricalRange checks that a period of historical data ends after it starts and is not
+// longer than MaxHistoricalDays.
+func validateHistoricalRange(from, to time.Time) error {
+	from, to = models.TruncateToDate(from), models.TruncateToDate(to)
+	if from.After(to) {
+		return fmt.Errorf("historical period starts after it ends: %s - %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	if from.AddDate(0, 0, MaxHistoricalDays).Before(to) {
+		return fmt.Errorf("historical period too long: %s - %s (max %d days)",
+			from.Format("2006-01-02"), to.Format("2006-01-02"), MaxHistoricalDays)
+	}
+	return nil
+}
+
+// pricesInDateRange returns the prices dated from the day of from to the day of to, both inclusive.
+func pricesInDateRange(prices []*models.StockPrice, from, to time.Time) []*models.StockPrice {
+	first, last := from.Format("2006-01-02"), to.Format("2006-01-02")
+	var inRange []*models.StockPrice
+	for _, price := range prices {
+		if date := price.Date.Format("2006-01-02"); date >= first && date <= last {
+			inRange = append(inRange, price)
+		}
+	}
+	return inRange
 }
 
 // YahooFinanceClient implements StockDataClient using Yahoo Finance API.
@@ -24,6 +74,10 @@ type YahooFinanceClient struct {
 	client      *resty.Client
 	baseURL     string
 	rateLimiter *RateLimiter
+	schema      *schemaMonitor
+	quota       *QuotaManager
+	raw         *rawResponseKeeper
+	retryPolicy retry.Policy
 }
 
 // Yahoo Finance APIレスポンス構造.
@@ -41,7 +95,10 @@ type YahooFinanceResponse struct {
 				Currency             string  `json:"currency"`
 				ExchangeName         string  `json:"exchangeName"`
 			} `json:"meta"`
-			Timestamp  []int64 `json:"timestamp"`
+			Timestamp []int64 `json:"timestamp"`
+			Events    struct {
+				Splits map[string]YahooSplitEvent `json:"splits"`
+			} `json:"events"`
 			Indicators struct {
 				Quote []struct {
 					Open   []float64 `json:"open"`
@@ -56,6 +113,25 @@ type YahooFinanceResponse struct {
 	} `json:"chart"`
 }
 
+// YahooSplitEvent is a stock split in the chart response, e.g. 5 for 1 with numerator 5 and denominator 1.
+type YahooSplitEvent struct {
+	Date        int64   `json:"date"`
+	Numerator   float64 `json:"numerator"`
+	Denominator float64 `json:"denominator"`
+}
+
+// splitFactorAfter returns the product of the split ratios after ts. Yahoo Finance adjusts
+// the prices before a split by this factor, so multiplying by it gives the actual traded price.
+func splitFactorAfter(splits map[string]YahooSplitEvent, ts int64) float64 {
+	factor := 1.0
+	for _, split := range splits {
+		if split.Date > ts && split.Numerator > 0 && split.Denominator > 0 {
+			factor *= split.Numerator / split.Denominator
+		}
+	}
+	return factor
+}
+
 // YahooFinanceConfig holds Yahoo Finance client configuration.
 type YahooFinanceConfig struct {
 	BaseURL       string
@@ -65,6 +141,12 @@ type YahooFinanceConfig struct {
 	RetryMaxWait  time.Duration
 	UserAgent     string
 	RateLimitRPS  int
+
+	// Adaptive rate control: lower the rate on 429 responses down to MinRateLimitRPS (at least 0.1),
+	// and raise it again after RateRecoverySuccesses consecutive successes.
+	AdaptiveRateLimit     bool
+	MinRateLimitRPS       float64
+	RateRecoverySuccesses int
 }
 
 // NewYahooFinanceClient creates a new Yahoo Finance client.
@@ -76,28 +158,90 @@ func NewYahooFinanceClient() *YahooFinanceClient {
 func NewYahooFinanceClientWithConfig(config YahooFinanceConfig) *YahooFinanceClient {
 	client := resty.New()
 	client.SetTimeout(config.Timeout)
-	client.SetRetryCount(config.RetryCount)
-	client.SetRetryWaitTime(config.RetryWaitTime)
-	client.SetRetryMaxWaitTime(config.RetryMaxWait)
-
-	// Add exponential backoff for retries
-	client.AddRetryCondition(func(r *resty.Response, err error) bool {
-		// Retry on server errors or rate limit
-		if r != nil && (r.StatusCode() >= 500 || r.StatusCode() == 429) {
-			return true
-		}
-		// Retry on network errors
-		if err != nil && IsRetryableError(err) {
-			return true
+
+	rateLimiter := NewRateLimiter(config.RateLimitRPS)
+	if config.AdaptiveRateLimit {
+		rateLimiter = NewAdaptiveRateLimiter(config.RateLimitRPS, config.MinRateLimitRPS, config.RateRecoverySuccesses)
+	}
+
+	// Every request, including the retries of get, waits for the rate limiter
+	client.OnBeforeRequest(rateLimiter.OnBeforeRequest)
+
+	// Feed every response (including retries) back to the rate limiter
+	client.OnAfterResponse(func(c *resty.Client, r *resty.Response) error {
+		switch {
+		case r.StatusCode() == 429:
+			rateLimiter.OnRateLimited()
+		case r.IsSuccess():
+			rateLimiter.OnSuccess()
 		}
-		return false
+		return nil
 	})
 
 	return &YahooFinanceClient{
 		client:      client,
 		baseURL:     config.BaseURL,
-		rateLimiter: NewRateLimiter(config.RateLimitRPS),
+		rateLimiter: rateLimiter,
+		schema:      newSchemaMonitor(),
+		raw:         newRawResponseKeeper(SourceYahoo),
+		retryPolicy: retry.Policy{
+			MaxRetries:   config.RetryCount,
+			InitialDelay: config.RetryWaitTime,
+			MaxDelay:     config.RetryMaxWait,
+			Jitter:       0.2,
+			Retryable:    IsRetryableError,
+		},
+	}
+}
+
+// SetSchemaAlertHandler sets the handler called when a response does not match the expected schema,
+// e.g. to send a critical alert instead of silently collecting no data after an API change.
+// The handler is called at most once an hour per endpoint.
+func (y *YahooFinanceClient) SetSchemaAlertHandler(handler SchemaAlertHandler) {
+	y.schema.setHandler(handler)
+}
+
+// SetRawResponseStore keeps the bodies of the responses that do not match the expected schema or
+// cannot be parsed in the store, at most once an hour per endpoint and stock.
+func (y *YahooFinanceClient) SetRawResponseStore(store RawResponseStore) {
+	y.raw.setStore(store)
+}
+
+// SetQuotaManager counts the requests against the daily quota of Yahoo Finance.
+func (y *YahooFinanceClient) SetQuotaManager(quota *QuotaManager) {
+	y.quota = quota
+}
+
+// get sends a GET request, retrying network errors, 429 and 5xx responses with exponential backoff.
+// A 429 response is retried after its Retry-After header if any. When the retries are used up,
+// the last response is returned for the caller to handle its status.
+func (y *YahooFinanceClient) get(ctx context.Context, url string, params map[string]string) (*resty.Response, error) {
+	var resp *resty.Response
+	_, err := y.retryPolicy.Do(ctx, func(ctx context.Context) error {
+		resp = nil
+		r, err := y.client.R().
+			SetContext(ctx).
+			SetQueryParams(params).
+			SetHeader("User-Agent", "Mozilla/5.0 (compatible; StockAutomation/1.0)").
+			Get(url)
+		if err != nil {
+			return err
+		}
+		resp = r
+
+		if r.StatusCode() == http.StatusTooManyRequests || r.StatusCode() >= 500 {
+			err := fmt.Errorf("status %d: %w", r.StatusCode(), ClassifyHTTPError(r.StatusCode()))
+			if seconds, parseErr := strconv.Atoi(r.Header().Get("Retry-After")); parseErr == nil && seconds > 0 {
+				return retry.After(err, time.Duration(seconds)*time.Second)
+			}
+			return err
+		}
+		return nil
+	})
+	if resp != nil {
+		return resp, nil
 	}
+	return nil, err
 }
 
 // DefaultYahooFinanceConfig returns default configuration for Yahoo Finance client.
@@ -110,21 +254,35 @@ func DefaultYahooFinanceConfig() YahooFinanceConfig {
 		RetryMaxWait:  10 * time.Second,
 		UserAgent:     "Mozilla/5.0 (compatible; StockAutomation/1.0)",
 		RateLimitRPS:  10,
+
+		AdaptiveRateLimit:     true,
+		MinRateLimitRPS:       0.5,
+		RateRecoverySuccesses: 20,
 	}
 }
 
+// YahooSymbol maps a securities code to its Yahoo Finance symbol, e.g. 7203 or 7203.T to 7203.T.
+// Symbols of market indices such as ^N225 are used as they are, and other codes that are not
+// securities codes get the Tokyo suffix appended unchanged.
+func YahooSymbol(stockCode string) string {
+	if code, err := domain.ParseStockCode(stockCode); err == nil {
+		return code.Symbol()
+	}
+	if domain.IsMarketIndexSymbol(stockCode) {
+		return stockCode
+	}
+	return stockCode + ".T"
+}
+
 // GetCurrentPrice retrieves real-time stock price.
-func (y *YahooFinanceClient) GetCurrentPrice(stockCode string) (*models.StockPrice, error) {
-	// Apply rate limiting
-	if err := y.rateLimiter.Wait(context.Background()); err != nil {
-		return nil, fmt.Errorf("rate limiter error: %w", err)
+func (y *YahooFinanceClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	if err := y.quota.Acquire(ctx, SourceYahoo); err != nil {
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/v8/finance/chart/%s.T", y.baseURL, stockCode)
+	url := fmt.Sprintf("%s/v8/finance/chart/%s", y.baseURL, YahooSymbol(stockCode))
 
-	resp, err := y.client.R().
-		SetHeader("User-Agent", "Mozilla/5.0 (compatible; StockAutomation/1.0)").
-		Get(url)
+	resp, err := y.get(ctx, url, nil)
 	if err != nil {
 		if IsRetryableError(err) {
 			return nil, fmt.Errorf("temporary error fetching data for %s: %w", stockCode, err)
@@ -136,59 +294,162 @@ func (y *YahooFinanceClient) GetCurrentPrice(stockCode string) (*models.StockPri
 		if httpErr := ClassifyHTTPError(resp.StatusCode()); httpErr != nil {
 			return nil, fmt.Errorf("API error for %s: %w (status: %d)", stockCode, httpErr, resp.StatusCode())
 		}
-		return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode())
+		return nil, fmt.Errorf("API returned %w", &retry.StatusError{StatusCode: resp.StatusCode()})
+	}
+
+	response, err := y.parseResponse(ctx, YahooEndpointCurrent, stockCode, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	stockPrice, err := currentPriceFromResponse(stockCode, response, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"code":  stockCode,
+		"price": stockPrice.ClosePrice,
+	}).Debug("Yahoo Finance current price fetched")
+
+	return stockPrice, nil
+}
+
+// parseResponse validates the schema of a chart response of the endpoint and parses it. The body
+// of a response that fails either is kept in the raw response store, if set.
+func (y *YahooFinanceClient) parseResponse(ctx context.Context, endpoint, stockCode string, resp *resty.Response) (*YahooFinanceResponse, error) {
+	if err := y.schema.check(ctx, endpoint, resp.Body()); err != nil {
+		y.raw.keep(ctx, endpoint, stockCode, resp, err)
+		return nil, fmt.Errorf("invalid %s response for %s: %w", endpoint, stockCode, err)
 	}
 
 	var response YahooFinanceResponse
 	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+		y.raw.keep(ctx, endpoint, stockCode, resp, err)
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	return &response, nil
+}
 
+// currentPriceFromResponse returns the current price in the meta of a chart response.
+func currentPriceFromResponse(stockCode string, response *YahooFinanceResponse, fetchedAt time.Time) (*models.StockPrice, error) {
 	if len(response.Chart.Result) == 0 {
 		return nil, fmt.Errorf("no data found for stock code: %s", stockCode)
 	}
 
-	result := response.Chart.Result[0]
-	meta := result.Meta
-
-	stockPrice := &models.StockPrice{
+	meta := response.Chart.Result[0].Meta
+	return &models.StockPrice{
 		Code:       stockCode,
-		Date:       time.Now(),
-		OpenPrice:  floatToDecimal(meta.RegularMarketOpen),
-		HighPrice:  floatToDecimal(meta.RegularMarketDayHigh),
-		LowPrice:   floatToDecimal(meta.RegularMarketDayLow),
-		ClosePrice: floatToDecimal(meta.RegularMarketPrice),
+		Date:       fetchedAt,
+		OpenPrice:  utility.FloatToDecimal(meta.RegularMarketOpen),
+		HighPrice:  utility.FloatToDecimal(meta.RegularMarketDayHigh),
+		LowPrice:   utility.FloatToDecimal(meta.RegularMarketDayLow),
+		ClosePrice: utility.FloatToDecimal(meta.RegularMarketPrice),
 		Volume:     meta.RegularMarketVolume,
+		Source:     SourceYahoo,
+		FetchedAt:  null.TimeFrom(fetchedAt),
+	}, nil
+}
+
+// GetHistoricalData retrieves historical stock price data of the last given days.
+// Periods longer than a year are requested in one-year chunks and merged, up to MaxHistoricalDays.
+func (y *YahooFinanceClient) GetHistoricalData(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+	if days > MaxHistoricalDays {
+		return nil, fmt.Errorf("historical period too long: %d days (max %d)", days, MaxHistoricalDays)
+	}
+
+	end := time.Now()
+	return y.getHistoricalChunks(ctx, stockCode, end.AddDate(0, 0, -days), end)
+}
+
+// GetHistoricalDataRange retrieves the daily prices from the day of from to the day of to, both inclusive.
+// Periods longer than a year are requested in one-year chunks and merged, up to MaxHistoricalDays.
+func (y *YahooFinanceClient) GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	if err := validateHistoricalRange(from, to); err != nil {
+		return nil, err
+	}
+
+	// The end of the request is exclusive, so it is the midnight after the last day
+	prices, err := y.getHistoricalChunks(ctx, stockCode, models.TruncateToDate(from), models.TruncateToDate(to).AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	prices = pricesInDateRange(prices, from, to)
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no historical data found for %s between %s and %s: %w",
+			stockCode, from.Format("2006-01-02"), to.Format("2006-01-02"), ErrNoData)
+	}
+	return prices, nil
+}
+
+// getHistoricalChunks retrieves the daily prices between start and end, in one-year chunks if longer.
+func (y *YahooFinanceClient) getHistoricalChunks(ctx context.Context, stockCode string, start, end time.Time) ([]*models.StockPrice, error) {
+	if !start.AddDate(0, 0, historicalChunkDays).Before(end) {
+		return y.getHistoricalRange(ctx, stockCode, start, end)
+	}
+
+	pricesByDate := make(map[string]*models.StockPrice)
+	for chunkStart := start; chunkStart.Before(end); chunkStart = chunkStart.AddDate(0, 0, historicalChunkDays) {
+		chunkEnd := chunkStart.AddDate(0, 0, historicalChunkDays)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		chunk, err := y.getHistoricalRange(ctx, stockCode, chunkStart, chunkEnd)
+		if err != nil {
+			// Periods before listing have no data
+			if errors.Is(err, ErrNoData) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to fetch historical data for %s (%s - %s): %w",
+				stockCode, chunkStart.Format("2006-01-02"), chunkEnd.Format("2006-01-02"), err)
+		}
+
+		for _, price := range chunk {
+			pricesByDate[price.Date.Format("2006-01-02")] = price
+		}
+	}
+
+	// As for a single request, a period without any data is an error and not an empty result
+	if len(pricesByDate) == 0 {
+		return nil, fmt.Errorf("no historical data found for %s: %w", stockCode, ErrNoData)
+	}
+
+	prices := make([]*models.StockPrice, 0, len(pricesByDate))
+	for _, price := range pricesByDate {
+		prices = append(prices, price)
 	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Date.Before(prices[j].Date)
+	})
 
 	logrus.WithFields(logrus.Fields{
-		"code":  stockCode,
-		"price": stockPrice.ClosePrice,
-	}).Debug("Yahoo Finance current price fetched")
+		"code":    stockCode,
+		"start":   start.Format("2006-01-02"),
+		"end":     end.Format("2006-01-02"),
+		"records": len(prices),
+	}).Debug("Yahoo Finance long-term historical data fetched")
 
-	return stockPrice, nil
+	return prices, nil
 }
 
-// GetHistoricalData retrieves historical stock price data.
-func (y *YahooFinanceClient) GetHistoricalData(stockCode string, days int) ([]*models.StockPrice, error) {
-	// Apply rate limiting
-	if err := y.rateLimiter.Wait(context.Background()); err != nil {
-		return nil, fmt.Errorf("rate limiter error: %w", err)
+// getHistoricalRange retrieves daily stock price data between start and end in a single request.
+func (y *YahooFinanceClient) getHistoricalRange(ctx context.Context, stockCode string, start, end time.Time) ([]*models.StockPrice, error) {
+	if err := y.quota.Acquire(ctx, SourceYahoo); err != nil {
+		return nil, err
 	}
 
-	endTime := time.Now().Unix()
-	startTime := time.Now().AddDate(0, 0, -days).Unix()
+	endTime := end.Unix()
+	startTime := start.Unix()
 
-	url := fmt.Sprintf("%s/v8/finance/chart/%s.T", y.baseURL, stockCode)
+	url := fmt.Sprintf("%s/v8/finance/chart/%s", y.baseURL, YahooSymbol(stockCode))
 
-	resp, err := y.client.R().
-		SetQueryParams(map[string]string{
-			"period1":  strconv.FormatInt(startTime, 10),
-			"period2":  strconv.FormatInt(endTime, 10),
-			"interval": "1d",
-		}).
-		SetHeader("User-Agent", "Mozilla/5.0 (compatible; StockAutomation/1.0)").
-		Get(url)
+	resp, err := y.get(ctx, url, map[string]string{
+		"period1":  strconv.FormatInt(startTime, 10),
+		"period2":  strconv.FormatInt(endTime, 10),
+		"interval": "1d",
+		"events":   "split",
+	})
 	if err != nil {
 		if IsRetryableError(err) {
 			return nil, fmt.Errorf("temporary error fetching historical data for %s: %w", stockCode, err)
@@ -196,13 +457,19 @@ func (y *YahooFinanceClient) GetHistoricalData(stockCode string, days int) ([]*m
 		return nil, fmt.Errorf("failed to fetch historical data for %s: %w", stockCode, err)
 	}
 
-	var response YahooFinanceResponse
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	response, err := y.parseResponse(ctx, YahooEndpointHistorical, stockCode, resp)
+	if err != nil {
+		return nil, err
 	}
 
+	return historicalPricesFromResponse(stockCode, response, time.Now())
+}
+
+// historicalPricesFromResponse returns the daily prices of a chart response, converted from the
+// split-adjusted quotes to the actual traded prices. Invalid data points are skipped.
+func historicalPricesFromResponse(stockCode string, response *YahooFinanceResponse, fetchedAt time.Time) ([]*models.StockPrice, error) {
 	if len(response.Chart.Result) == 0 {
-		return nil, fmt.Errorf("no historical data found for: %s", stockCode)
+		return nil, fmt.Errorf("no historical data found for %s: %w", stockCode, ErrNoData)
 	}
 
 	result := response.Chart.Result[0]
@@ -215,7 +482,7 @@ func (y *YahooFinanceClient) GetHistoricalData(stockCode string, days int) ([]*m
 	timestamps := result.Timestamp
 
 	if len(result.Indicators.Quote) == 0 {
-		return nil, fmt.Errorf("no quote indicators found for: %s", stockCode)
+		return nil, fmt.Errorf("no quote indicators found for %s: %w", stockCode, ErrNoData)
 	}
 
 	quotes := result.Indicators.Quote[0]
@@ -234,14 +501,19 @@ func (y *YahooFinanceClient) GetHistoricalData(stockCode string, days int) ([]*m
 			continue
 		}
 
+		// Quotes are split-adjusted; store the actual traded prices and keep the adjusted close
+		factor := splitFactorAfter(result.Events.Splits, ts)
 		price := &models.StockPrice{
-			Code:       stockCode,
-			Date:       time.Unix(ts, 0),
-			OpenPrice:  floatToDecimal(quotes.Open[i]),
-			HighPrice:  floatToDecimal(quotes.High[i]),
-			LowPrice:   floatToDecimal(quotes.Low[i]),
-			ClosePrice: floatToDecimal(quotes.Close[i]),
-			Volume:     quotes.Volume[i],
+			Code:          stockCode,
+			Date:          time.Unix(ts, 0),
+			OpenPrice:     utility.FloatToDecimal(quotes.Open[i] * factor),
+			HighPrice:     utility.FloatToDecimal(quotes.High[i] * factor),
+			LowPrice:      utility.FloatToDecimal(quotes.Low[i] * factor),
+			ClosePrice:    utility.FloatToDecimal(quotes.Close[i] * factor),
+			AdjClosePrice: utility.FloatToNullDecimal(quotes.Close[i]),
+			Volume:        int64(math.Round(float64(quotes.Volume[i]) / factor)),
+			Source:        SourceYahoo,
+			FetchedAt:     null.TimeFrom(fetchedAt),
 		}
 
 		prices = append(prices, price)
@@ -250,27 +522,24 @@ func (y *YahooFinanceClient) GetHistoricalData(stockCode string, days int) ([]*m
 	logrus.WithFields(logrus.Fields{
 		"code":    stockCode,
 		"records": len(prices),
+		"splits":  len(result.Events.Splits),
 	}).Debug("Yahoo Finance historical data fetched")
 
 	return prices, nil
 }
 
 // GetIntradayData retrieves intraday stock price data.
-func (y *YahooFinanceClient) GetIntradayData(stockCode string, interval string) ([]*models.StockPrice, error) {
-	// Apply rate limiting
-	if err := y.rateLimiter.Wait(context.Background()); err != nil {
-		return nil, fmt.Errorf("rate limiter error: %w", err)
+func (y *YahooFinanceClient) GetIntradayData(ctx context.Context, stockCode string, interval string) ([]*models.StockPrice, error) {
+	if err := y.quota.Acquire(ctx, SourceYahoo); err != nil {
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/v8/finance/chart/%s.T", y.baseURL, stockCode)
+	url := fmt.Sprintf("%s/v8/finance/chart/%s", y.baseURL, YahooSymbol(stockCode))
 
-	resp, err := y.client.R().
-		SetQueryParams(map[string]string{
-			"range":    "1d",
-			"interval": interval,
-		}).
-		SetHeader("User-Agent", "Mozilla/5.0 (compatible; StockAutomation/1.0)").
-		Get(url)
+	resp, err := y.get(ctx, url, map[string]string{
+		"range":    "1d",
+		"interval": interval,
+	})
 	if err != nil {
 		if IsRetryableError(err) {
 			return nil, fmt.Errorf("temporary error fetching intraday data for %s: %w", stockCode, err)
@@ -278,12 +547,18 @@ func (y *YahooFinanceClient) GetIntradayData(stockCode string, interval string)
 		return nil, fmt.Errorf("failed to fetch intraday data for %s: %w", stockCode, err)
 	}
 
-	var response YahooFinanceResponse
-	if err := json.Unmarshal(resp.Body(), &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	response, err := y.parseResponse(ctx, YahooEndpointIntraday, stockCode, resp)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(response.Chart.Result) == 0 {
+	return intradayPricesFromResponse(stockCode, response, time.Now())
+}
+
+// intradayPricesFromResponse returns the intraday prices of a chart response. Data points without
+// a close are skipped.
+func intradayPricesFromResponse(stockCode string, response *YahooFinanceResponse, fetchedAt time.Time) ([]*models.StockPrice, error) {
+	if len(response.Chart.Result) == 0 || len(response.Chart.Result[0].Indicators.Quote) == 0 {
 		return nil, fmt.Errorf("no intraday data found for: %s", stockCode)
 	}
 
@@ -301,11 +576,13 @@ func (y *YahooFinanceClient) GetIntradayData(stockCode string, interval string)
 		price := &models.StockPrice{
 			Code:       stockCode,
 			Date:       time.Unix(ts, 0),
-			OpenPrice:  floatToDecimal(quotes.Open[i]),
-			HighPrice:  floatToDecimal(quotes.High[i]),
-			LowPrice:   floatToDecimal(quotes.Low[i]),
-			ClosePrice: floatToDecimal(quotes.Close[i]),
+			OpenPrice:  utility.FloatToDecimal(quotes.Open[i]),
+			HighPrice:  utility.FloatToDecimal(quotes.High[i]),
+			LowPrice:   utility.FloatToDecimal(quotes.Low[i]),
+			ClosePrice: utility.FloatToDecimal(quotes.Close[i]),
 			Volume:     quotes.Volume[i],
+			Source:     SourceYahoo,
+			FetchedAt:  null.TimeFrom(fetchedAt),
 		}
 
 		prices = append(prices, price)
@@ -1,12 +1,19 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
 )
 
 func TestDefaultYahooFinanceConfig(t *testing.T) {
@@ -120,7 +127,7 @@ func TestYahooFinanceClient_GetCurrentPrice_RateLimit(t *testing.T) {
 	// Make rapid requests to test rate limiting
 	start := time.Now()
 	for i := 0; i < 3; i++ {
-		_, err := client.GetCurrentPrice("TEST")
+		_, err := client.GetCurrentPrice(context.Background(), "TEST")
 		if err != nil {
 			t.Errorf("GetCurrentPrice() error = %v", err)
 		}
@@ -133,6 +140,69 @@ func TestYahooFinanceClient_GetCurrentPrice_RateLimit(t *testing.T) {
 	}
 }
 
+func TestYahooFinanceClient_GetCurrentPrice_RateLimitRetries(t *testing.T) {
+	// Fail twice so that the third attempt succeeds
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"chart": {"result": [{"meta": {"symbol": "TEST", "regularMarketPrice": 100.5}}]}}`))
+	}))
+	defer server.Close()
+
+	config := YahooFinanceConfig{
+		BaseURL:       server.URL,
+		Timeout:       5 * time.Second,
+		RetryCount:    2,
+		RetryWaitTime: time.Millisecond,
+		RetryMaxWait:  time.Millisecond,
+		RateLimitRPS:  2,
+	}
+
+	client := NewYahooFinanceClientWithConfig(config)
+
+	start := time.Now()
+	if _, err := client.GetCurrentPrice(context.Background(), "TEST"); err != nil {
+		t.Fatalf("GetCurrentPrice() error = %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("Attempts = %d, want 3", got)
+	}
+	// With 2 RPS, the retries wait for the rate limiter instead of the 1ms retry delay
+	if elapsed < 400*time.Millisecond {
+		t.Errorf("Expected rate limiting to delay retries, but completed in %v", elapsed)
+	}
+}
+
+func TestYahooFinanceClient_AdaptiveRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:               server.URL,
+		Timeout:               5 * time.Second,
+		RateLimitRPS:          10,
+		AdaptiveRateLimit:     true,
+		MinRateLimitRPS:       1,
+		RateRecoverySuccesses: 5,
+	})
+
+	if _, err := client.GetCurrentPrice(context.Background(), "TEST"); !errors.Is(err, ErrRateLimit) {
+		t.Fatalf("Expected ErrRateLimit, got %v", err)
+	}
+
+	if got := client.rateLimiter.CurrentRPS(); got != 5 {
+		t.Errorf("Rate after 429 = %v, want 5", got)
+	}
+}
+
 func TestYahooFinanceClient_GetCurrentPrice_HTTPErrors(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -189,7 +259,7 @@ func TestYahooFinanceClient_GetCurrentPrice_HTTPErrors(t *testing.T) {
 			}
 
 			client := NewYahooFinanceClientWithConfig(config)
-			_, err := client.GetCurrentPrice("TEST")
+			_, err := client.GetCurrentPrice(context.Background(), "TEST")
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetCurrentPrice() error = %v, wantErr %v", err, tt.wantErr)
@@ -206,12 +276,42 @@ func TestYahooFinanceClient_GetCurrentPrice_InvalidCode(t *testing.T) {
 	client := NewYahooFinanceClient()
 
 	// Test with invalid stock code
-	_, err := client.GetCurrentPrice("INVALID_CODE_12345")
+	_, err := client.GetCurrentPrice(context.Background(), "INVALID_CODE_12345")
 	if err == nil {
 		t.Error("Expected error for invalid stock code")
 	}
 }
 
+func TestYahooFinanceClient_GetCurrentPrice_ContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	config := DefaultYahooFinanceConfig()
+	config.BaseURL = server.URL
+	config.RetryCount = 0
+	client := NewYahooFinanceClientWithConfig(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetCurrentPrice(ctx, "TEST")
+	if err == nil {
+		t.Fatal("Expected error for canceled context")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Request was not canceled promptly: %v", elapsed)
+	}
+}
+
 func TestYahooFinanceClient_GetHistoricalData_InvalidParams(t *testing.T) {
 	client := NewYahooFinanceClient()
 
@@ -243,7 +343,7 @@ func TestYahooFinanceClient_GetHistoricalData_InvalidParams(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := client.GetHistoricalData(tt.stockCode, tt.days)
+			_, err := client.GetHistoricalData(context.Background(), tt.stockCode, tt.days)
 
 			if tt.wantError && err == nil {
 				t.Error("Expected error but got none")
@@ -255,6 +355,229 @@ func TestYahooFinanceClient_GetHistoricalData_InvalidParams(t *testing.T) {
 	}
 }
 
+func TestYahooFinanceClient_GetHistoricalData_Chunked(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+
+	// Return a single daily record at the start of each requested period.
+	// Periods before 5 years ago have no data, as for a stock listed 5 years ago.
+	listedAt := time.Now().AddDate(-5, 0, 0).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		period1, err := strconv.ParseInt(r.URL.Query().Get("period1"), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if period1 < listedAt {
+			w.Write([]byte(`{"chart": {"result": [], "error": null}}`))
+			return
+		}
+		fmt.Fprintf(w, `{
+			"chart": {
+				"result": [{
+					"meta": {"symbol": "TEST"},
+					"timestamp": [%d],
+					"indicators": {"quote": [{
+						"open": [100.0], "high": [110.0], "low": [90.0], "close": [105.0], "volume": [1000]
+					}]}
+				}]
+			}
+		}`, period1)
+	}))
+	defer server.Close()
+
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+
+	prices, err := client.GetHistoricalData(context.Background(), "TEST", MaxHistoricalDays)
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 10 {
+		t.Errorf("Expected 10 chunked requests, got %d", requests)
+	}
+	if len(prices) < 4 || len(prices) > 6 {
+		t.Errorf("Expected records only from chunks after listing, got %d", len(prices))
+	}
+	for i := 1; i < len(prices); i++ {
+		if !prices[i-1].Date.Before(prices[i].Date) {
+			t.Errorf("Prices should be sorted by date: %v >= %v", prices[i-1].Date, prices[i].Date)
+		}
+	}
+}
+
+func TestYahooFinanceClient_GetHistoricalData_ChunkedNoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"chart": {"result": [], "error": null}}`))
+	}))
+	defer server.Close()
+
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+
+	// Every chunk is empty, so the error is the same as for a single request without data
+	for _, days := range []int{30, MaxHistoricalDays} {
+		prices, err := client.GetHistoricalData(context.Background(), "TEST", days)
+		if !errors.Is(err, ErrNoData) {
+			t.Errorf("GetHistoricalData(%d) = %d prices, %v, want ErrNoData", days, len(prices), err)
+		}
+	}
+}
+
+func TestYahooFinanceClient_GetHistoricalDataRange(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2022, 6, 30, 0, 0, 0, 0, time.Local)
+
+	var (
+		mu      sync.Mutex
+		periods [][2]int64
+	)
+	// Return the first and the last day of each requested period; the last is out of the range
+	// when the request ends at the midnight after the last day.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		period1, _ := strconv.ParseInt(r.URL.Query().Get("period1"), 10, 64)
+		period2, _ := strconv.ParseInt(r.URL.Query().Get("period2"), 10, 64)
+		mu.Lock()
+		periods = append(periods, [2]int64{period1, period2})
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"chart": {
+				"result": [{
+					"meta": {"symbol": "TEST"},
+					"timestamp": [%d, %d],
+					"indicators": {"quote": [{
+						"open": [100.0, 100.0], "high": [110.0, 110.0], "low": [90.0, 90.0], "close": [105.0, 105.0], "volume": [1000, 1000]
+					}]}
+				}]
+			}
+		}`, period1, period2)
+	}))
+	defer server.Close()
+
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+
+	prices, err := client.GetHistoricalDataRange(context.Background(), "TEST", from, to)
+	if err != nil {
+		t.Fatalf("GetHistoricalDataRange() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(periods) != 3 {
+		t.Fatalf("Expected 3 chunked requests, got %d", len(periods))
+	}
+	if periods[0][0] != from.Unix() || periods[2][1] != to.AddDate(0, 0, 1).Unix() {
+		t.Errorf("requested %v - %v, want %v - %v", time.Unix(periods[0][0], 0), time.Unix(periods[2][1], 0), from, to.AddDate(0, 0, 1))
+	}
+	if first, last := prices[0].Date.Format("2006-01-02"), prices[len(prices)-1].Date.Format("2006-01-02"); first != "2020-01-01" || last > "2022-06-30" {
+		t.Errorf("prices from %s to %s, want within 2020-01-01 - 2022-06-30", first, last)
+	}
+
+	if _, err := client.GetHistoricalDataRange(context.Background(), "TEST", to, from); err == nil {
+		t.Error("GetHistoricalDataRange() should fail for a period ending before it starts")
+	}
+}
+
+func TestYahooFinanceClient_GetHistoricalData_Splits(t *testing.T) {
+	day1 := time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC).Unix()
+	day2 := time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC).Unix()
+	splitAt := time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC).Unix()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("events") != "split" {
+			t.Errorf("Expected split events to be requested, got %q", r.URL.Query().Get("events"))
+		}
+
+		// 1:5 split on day 2; the quote of day 1 is split-adjusted by Yahoo Finance
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"chart": {
+				"result": [{
+					"meta": {"symbol": "TEST"},
+					"timestamp": [%d, %d],
+					"events": {"splits": {"%d": {"date": %d, "numerator": 5, "denominator": 1}}},
+					"indicators": {"quote": [{
+						"open": [990.0, 1010.0], "high": [1020.0, 1030.0], "low": [980.0, 1000.0],
+						"close": [1000.0, 1020.0], "volume": [50000, 60000]
+					}]}
+				}]
+			}
+		}`, day1, day2, splitAt, splitAt)
+	}))
+	defer server.Close()
+
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+
+	prices, err := client.GetHistoricalData(context.Background(), "TEST", 30)
+	if err != nil {
+		t.Fatalf("GetHistoricalData() error = %v", err)
+	}
+	if len(prices) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(prices))
+	}
+
+	tests := []struct {
+		name      string
+		price     *models.StockPrice
+		wantClose float64
+		wantAdj   float64
+		wantVol   int64
+	}{
+		{name: "Before split", price: prices[0], wantClose: 5000, wantAdj: 1000, wantVol: 10000},
+		{name: "On split", price: prices[1], wantClose: 1020, wantAdj: 1020, wantVol: 60000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utility.DecimalToFloat(tt.price.ClosePrice); got != tt.wantClose {
+				t.Errorf("ClosePrice = %v, want actual price %v", got, tt.wantClose)
+			}
+			if got := utility.NullDecimalToFloat(tt.price.AdjClosePrice); got != tt.wantAdj {
+				t.Errorf("AdjClosePrice = %v, want %v", got, tt.wantAdj)
+			}
+			if tt.price.Volume != tt.wantVol {
+				t.Errorf("Volume = %d, want %d", tt.price.Volume, tt.wantVol)
+			}
+		})
+	}
+}
+
+func TestYahooFinanceClient_GetHistoricalData_TooLong(t *testing.T) {
+	client := NewYahooFinanceClient()
+
+	_, err := client.GetHistoricalData(context.Background(), "1234", MaxHistoricalDays+1)
+	if err == nil {
+		t.Error("Expected error for period longer than MaxHistoricalDays")
+	}
+}
+
 func TestYahooFinanceClient_GetIntradayData_InvalidParams(t *testing.T) {
 	client := NewYahooFinanceClient()
 
@@ -280,7 +603,7 @@ func TestYahooFinanceClient_GetIntradayData_InvalidParams(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := client.GetIntradayData(tt.stockCode, tt.interval)
+			_, err := client.GetIntradayData(context.Background(), tt.stockCode, tt.interval)
 
 			if tt.wantError && err == nil {
 				t.Error("Expected error but got none")
@@ -302,20 +625,20 @@ func NewMockStockDataClient() *MockStockDataClient {
 		mockCurrentPrice: &models.StockPrice{
 			Code:       "1234",
 			Date:       time.Now(),
-			OpenPrice:  floatToDecimal(1000.0),
-			HighPrice:  floatToDecimal(1100.0),
-			LowPrice:   floatToDecimal(950.0),
-			ClosePrice: floatToDecimal(1050.0),
+			OpenPrice:  utility.FloatToDecimal(1000.0),
+			HighPrice:  utility.FloatToDecimal(1100.0),
+			LowPrice:   utility.FloatToDecimal(950.0),
+			ClosePrice: utility.FloatToDecimal(1050.0),
 			Volume:     1000000,
 		},
 		mockHistoricalData: []*models.StockPrice{
 			{
 				Code:       "1234",
 				Date:       time.Now().AddDate(0, 0, -1),
-				OpenPrice:  floatToDecimal(1000.0),
-				HighPrice:  floatToDecimal(1100.0),
-				LowPrice:   floatToDecimal(950.0),
-				ClosePrice: floatToDecimal(1050.0),
+				OpenPrice:  utility.FloatToDecimal(1000.0),
+				HighPrice:  utility.FloatToDecimal(1100.0),
+				LowPrice:   utility.FloatToDecimal(950.0),
+				ClosePrice: utility.FloatToDecimal(1050.0),
 				Volume:     1000000,
 			},
 		},
@@ -323,31 +646,38 @@ func NewMockStockDataClient() *MockStockDataClient {
 			{
 				Code:       "1234",
 				Date:       time.Now().Add(-1 * time.Hour),
-				OpenPrice:  floatToDecimal(1000.0),
-				HighPrice:  floatToDecimal(1100.0),
-				LowPrice:   floatToDecimal(950.0),
-				ClosePrice: floatToDecimal(1050.0),
+				OpenPrice:  utility.FloatToDecimal(1000.0),
+				HighPrice:  utility.FloatToDecimal(1100.0),
+				LowPrice:   utility.FloatToDecimal(950.0),
+				ClosePrice: utility.FloatToDecimal(1050.0),
 				Volume:     500000,
 			},
 		},
 	}
 }
 
-func (m *MockStockDataClient) GetCurrentPrice(stockCode string) (*models.StockPrice, error) {
+func (m *MockStockDataClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
 	if m.shouldReturnError {
 		return nil, &mockError{message: "mock error"}
 	}
 	return m.mockCurrentPrice, nil
 }
 
-func (m *MockStockDataClient) GetHistoricalData(stockCode string, days int) ([]*models.StockPrice, error) {
+func (m *MockStockDataClient) GetHistoricalData(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
 	if m.shouldReturnError {
 		return nil, &mockError{message: "mock error"}
 	}
 	return m.mockHistoricalData, nil
 }
 
-func (m *MockStockDataClient) GetIntradayData(stockCode string, interval string) ([]*models.StockPrice, error) {
+func (m *MockStockDataClient) GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	if m.shouldReturnError {
+		return nil, &mockError{message: "mock error"}
+	}
+	return pricesInDateRange(m.mockHistoricalData, from, to), nil
+}
+
+func (m *MockStockDataClient) GetIntradayData(ctx context.Context, stockCode string, interval string) ([]*models.StockPrice, error) {
 	if m.shouldReturnError {
 		return nil, &mockError{message: "mock error"}
 	}
@@ -370,7 +700,7 @@ func TestMockStockDataClient(t *testing.T) {
 	var _ StockDataClient = client
 
 	t.Run("GetCurrentPrice", func(t *testing.T) {
-		price, err := client.GetCurrentPrice("1234")
+		price, err := client.GetCurrentPrice(context.Background(), "1234")
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -383,7 +713,7 @@ func TestMockStockDataClient(t *testing.T) {
 	})
 
 	t.Run("GetHistoricalData", func(t *testing.T) {
-		prices, err := client.GetHistoricalData("1234", 30)
+		prices, err := client.GetHistoricalData(context.Background(), "1234", 30)
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -393,7 +723,7 @@ func TestMockStockDataClient(t *testing.T) {
 	})
 
 	t.Run("GetIntradayData", func(t *testing.T) {
-		prices, err := client.GetIntradayData("1234", "1m")
+		prices, err := client.GetIntradayData(context.Background(), "1234", "1m")
 		if err != nil {
 			t.Errorf("Unexpected error: %v", err)
 		}
@@ -405,17 +735,17 @@ func TestMockStockDataClient(t *testing.T) {
 	t.Run("Error handling", func(t *testing.T) {
 		client.shouldReturnError = true
 
-		_, err := client.GetCurrentPrice("1234")
+		_, err := client.GetCurrentPrice(context.Background(), "1234")
 		if err == nil {
 			t.Error("Expected error but got none")
 		}
 
-		_, err = client.GetHistoricalData("1234", 30)
+		_, err = client.GetHistoricalData(context.Background(), "1234", 30)
 		if err == nil {
 			t.Error("Expected error but got none")
 		}
 
-		_, err = client.GetIntradayData("1234", "1m")
+		_, err = client.GetIntradayData(context.Background(), "1234", "1m")
 		if err == nil {
 			t.Error("Expected error but got none")
 		}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxRawResponseBytes is the size up to which the body of a response is kept; the rest is cut off.
+const MaxRawResponseBytes = 1 << 20
+
+// rawResponseInterval is how often a response of the same endpoint and stock is kept, since every
+// request fails in the same way once the response structure changes.
+const rawResponseInterval = time.Hour
+
+// RawResponseStore keeps the bodies of data source responses that could not be parsed.
+type RawResponseStore interface {
+	SaveRawResponse(ctx context.Context, response *models.RawResponse) error
+}
+
+// rawResponseKeeper saves the responses that could not be parsed to the store, throttled per
+// endpoint and stock.
+type rawResponseKeeper struct {
+	source   string
+	mu       sync.Mutex
+	store    RawResponseStore
+	lastKept map[string]time.Time
+	now      func() time.Time
+}
+
+// newRawResponseKeeper creates a keeper of the responses of the data source without a store.
+func newRawResponseKeeper(source string) *rawResponseKeeper {
+	return &rawResponseKeeper{
+		source:   source,
+		lastKept: make(map[string]time.Time),
+		now:      time.Now,
+	}
+}
+
+// setStore sets the store the responses are saved to.
+func (k *rawResponseKeeper) setStore(store RawResponseStore) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.store = store
+}
+
+// keep saves the body of a response of the endpoint that failed to parse with parseErr, unless no
+// store is set or a response of the same endpoint and stock was kept within rawResponseInterval.
+// A response that cannot be saved is only logged, as it must not fail the request.
+func (k *rawResponseKeeper) keep(ctx context.Context, endpoint, stockCode string, resp *resty.Response, parseErr error) {
+	key := endpoint + "/" + stockCode
+	k.mu.Lock()
+	store := k.store
+	now := k.now()
+	last, kept := k.lastKept[key]
+	save := store != nil && (!kept || now.Sub(last) >= rawResponseInterval)
+	if save {
+		k.lastKept[key] = now
+	}
+	k.mu.Unlock()
+	if !save {
+		return
+	}
+
+	body := resp.Body()
+	raw := &models.RawResponse{
+		Source:     k.source,
+		Endpoint:   endpoint,
+		Code:       stockCode,
+		StatusCode: resp.StatusCode(),
+		Body:       body,
+		Error:      parseErr.Error(),
+		CreatedAt:  now,
+	}
+	if len(body) > MaxRawResponseBytes {
+		raw.Body, raw.Truncated = body[:MaxRawResponseBytes], true
+	}
+	if resp.RawResponse != nil && resp.RawResponse.Request != nil {
+		raw.URL = resp.RawResponse.Request.URL.String()
+	}
+
+	if err := store.SaveRawResponse(context.WithoutCancel(ctx), raw); err != nil {
+		logrus.Warnf("Failed to keep raw %s response of %s: %v", endpoint, stockCode, err)
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"id":       raw.ID,
+		"endpoint": endpoint,
+		"code":     stockCode,
+	}).Info("Raw response kept for debugging")
+}
+
+// ParseYahooResponse parses a chart response body of the endpoint like the client does, so that a
+// kept response can be parsed again, e.g. after fixing the parser. Historical and intraday
+// responses return their prices, and current ones the current price alone.
+func ParseYahooResponse(endpoint, stockCode string, body []byte) ([]*models.StockPrice, error) {
+	if err := validateChartSchema(endpoint, body); err != nil {
+		return nil, err
+	}
+
+	var response YahooFinanceResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	fetchedAt := time.Now()
+	switch endpoint {
+	case YahooEndpointCurrent:
+		price, err := currentPriceFromResponse(stockCode, &response, fetchedAt)
+		if err != nil {
+			return nil, err
+		}
+		return []*models.StockPrice{price}, nil
+	case YahooEndpointHistorical:
+		return historicalPricesFromResponse(stockCode, &response, fetchedAt)
+	case YahooEndpointIntraday:
+		return intradayPricesFromResponse(stockCode, &response, fetchedAt)
+	default:
+		return nil, fmt.Errorf("unknown Yahoo Finance endpoint: %s", endpoint)
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// memoryRawResponseStore is a RawResponseStore keeping the responses in memory.
+type memoryRawResponseStore []*models.RawResponse
+
+func (m *memoryRawResponseStore) SaveRawResponse(ctx context.Context, response *models.RawResponse) error {
+	response.ID = int64(len(*m) + 1)
+	*m = append(*m, response)
+	return nil
+}
+
+func TestYahooFinanceClient_RawResponseStore(t *testing.T) {
+	body := `<html>Service Unavailable</html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+	store := &memoryRawResponseStore{}
+	client.SetRawResponseStore(store)
+
+	// The same endpoint and stock is kept once an hour, other stocks separately
+	for _, code := range []string{"7203", "7203", "6758"} {
+		if _, err := client.GetCurrentPrice(context.Background(), code); err == nil {
+			t.Fatalf("GetCurrentPrice(%s) error = nil, want a parse error", code)
+		}
+	}
+	if len(*store) != 2 {
+		t.Fatalf("%d responses kept, want 2", len(*store))
+	}
+	kept := (*store)[0]
+	if kept.Endpoint != YahooEndpointCurrent || kept.Code != "7203" || string(kept.Body) != body || kept.StatusCode != http.StatusOK {
+		t.Errorf("kept response = %+v", kept)
+	}
+	if !strings.Contains(kept.URL, "/v8/finance/chart/7203.T") || kept.Error == "" {
+		t.Errorf("kept URL = %q, error = %q", kept.URL, kept.Error)
+	}
+
+	client.raw.now = func() time.Time { return time.Now().Add(rawResponseInterval) }
+	client.GetCurrentPrice(context.Background(), "7203")
+	if len(*store) != 3 {
+		t.Errorf("%d responses kept, want another after the interval", len(*store))
+	}
+}
+
+func TestParseYahooResponse(t *testing.T) {
+	body := []byte(`{"chart": {"result": [{
+		"meta": {"symbol": "7203.T", "regularMarketPrice": 2500},
+		"timestamp": [1704153600, 1704240000],
+		"indicators": {"quote": [{"open": [2400, 2450], "high": [2450, 2520], "low": [2390, 2440], "close": [2440, 2500], "volume": [1000, 1200]}]}
+	}], "error": null}}`)
+
+	prices, err := ParseYahooResponse(YahooEndpointHistorical, "7203", body)
+	if err != nil {
+		t.Fatalf("ParseYahooResponse() error = %v", err)
+	}
+	if len(prices) != 2 || prices[1].ClosePrice.String() != "2500" {
+		t.Errorf("prices = %+v, want 2 days closing at 2500", prices)
+	}
+
+	if _, err := ParseYahooResponse(YahooEndpointCurrent, "7203", []byte("<html>")); err == nil {
+		t.Error("ParseYahooResponse() error = nil for a body that is not JSON")
+	}
+}
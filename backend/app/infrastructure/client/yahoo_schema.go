@@ -0,0 +1,285 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Yahoo Finance chart endpoints, used to tell which request hit a schema change.
+const (
+	YahooEndpointCurrent    = "current"
+	YahooEndpointHistorical = "historical"
+	YahooEndpointIntraday   = "intraday"
+)
+
+// schemaAlertInterval is how often a schema change of the same endpoint is alerted,
+// since every stock fails in the same way once the response structure changes.
+const schemaAlertInterval = time.Hour
+
+// SchemaError reports that a Yahoo Finance response lacks required fields or has fields of unexpected types.
+type SchemaError struct {
+	Endpoint string
+	Problems []string
+}
+
+// Error returns the endpoint and the problems found in the response.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("unexpected Yahoo Finance %s response schema: %s", e.Endpoint, strings.Join(e.Problems, "; "))
+}
+
+// Is reports the error as ErrSchemaMismatch.
+func (e *SchemaError) Is(target error) bool {
+	return target == ErrSchemaMismatch
+}
+
+// SchemaAlertHandler is called when a Yahoo Finance response does not match the expected schema.
+type SchemaAlertHandler func(ctx context.Context, err *SchemaError)
+
+// schemaMonitor throttles the alerts of schema changes per endpoint.
+type schemaMonitor struct {
+	mu        sync.Mutex
+	handler   SchemaAlertHandler
+	lastAlert map[string]time.Time
+	now       func() time.Time
+}
+
+// newSchemaMonitor creates a schema monitor without a handler.
+func newSchemaMonitor() *schemaMonitor {
+	return &schemaMonitor{
+		lastAlert: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// setHandler sets the handler called on schema changes.
+func (m *schemaMonitor) setHandler(handler SchemaAlertHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = handler
+}
+
+// check validates the chart response body of the endpoint, and alerts at most once an hour per endpoint
+// if it does not match the schema.
+func (m *schemaMonitor) check(ctx context.Context, endpoint string, body []byte) error {
+	schemaErr := validateChartSchema(endpoint, body)
+	if schemaErr == nil {
+		return nil
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"endpoint": endpoint,
+		"problems": schemaErr.Problems,
+	}).Error("Yahoo Finance response schema mismatch")
+
+	m.mu.Lock()
+	handler := m.handler
+	now := m.now()
+	last, alerted := m.lastAlert[endpoint]
+	notify := handler != nil && (!alerted || now.Sub(last) >= schemaAlertInterval)
+	if notify {
+		m.lastAlert[endpoint] = now
+	}
+	m.mu.Unlock()
+
+	if notify {
+		handler(ctx, schemaErr)
+	}
+	return schemaErr
+}
+
+// validateChartSchema checks the fields of a chart response that the client depends on.
+// Responses with an API error, no result or no data points in the period are valid, since they
+// are handled as errors or empty data by the caller. Bodies that are not JSON are left to the parser.
+func validateChartSchema(endpoint string, body []byte) *SchemaError {
+	var root interface{}
+	if err := json.Unmarshal(body, &root); err != nil {
+		return nil
+	}
+
+	v := &schemaValidator{}
+	response, ok := root.(map[string]interface{})
+	if !ok {
+		v.mismatch("response", "object", root)
+		return v.result(endpoint)
+	}
+	chart, ok := v.object(response, "chart", "chart")
+	if !ok {
+		return v.result(endpoint)
+	}
+	if chart["error"] != nil {
+		return nil
+	}
+
+	rawResults, present := chart["result"]
+	if !present {
+		v.missing("chart.result")
+		return v.result(endpoint)
+	}
+	if rawResults == nil {
+		return nil
+	}
+	results, ok := rawResults.([]interface{})
+	if !ok {
+		v.mismatch("chart.result", "array", rawResults)
+		return v.result(endpoint)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	result, ok := results[0].(map[string]interface{})
+	if !ok {
+		v.mismatch("chart.result[0]", "object", results[0])
+		return v.result(endpoint)
+	}
+
+	if meta, ok := v.object(result, "meta", "chart.result[0].meta"); ok && endpoint == YahooEndpointCurrent {
+		v.number(meta, "regularMarketPrice", "chart.result[0].meta.regularMarketPrice", true)
+		for _, field := range []string{"regularMarketOpen", "regularMarketDayHigh", "regularMarketDayLow", "regularMarketVolume"} {
+			v.number(meta, field, "chart.result[0].meta."+field, false)
+		}
+	}
+	if endpoint == YahooEndpointCurrent {
+		return v.result(endpoint)
+	}
+
+	// Periods without trading have neither timestamps nor quotes
+	rawTimestamps, present := result["timestamp"]
+	if !present {
+		return v.result(endpoint)
+	}
+	timestamps, ok := rawTimestamps.([]interface{})
+	if !ok {
+		v.mismatch("chart.result[0].timestamp", "array", rawTimestamps)
+		return v.result(endpoint)
+	}
+	for i, ts := range timestamps {
+		if _, ok := ts.(float64); !ok {
+			v.mismatch(fmt.Sprintf("chart.result[0].timestamp[%d]", i), "number", ts)
+			break
+		}
+	}
+
+	indicators, ok := v.object(result, "indicators", "chart.result[0].indicators")
+	if !ok {
+		return v.result(endpoint)
+	}
+	rawQuotes, ok := indicators["quote"].([]interface{})
+	if !ok || len(rawQuotes) == 0 {
+		if !ok && indicators["quote"] != nil {
+			v.mismatch("chart.result[0].indicators.quote", "array", indicators["quote"])
+		} else {
+			v.missing("chart.result[0].indicators.quote")
+		}
+		return v.result(endpoint)
+	}
+	quote, ok := rawQuotes[0].(map[string]interface{})
+	if !ok {
+		v.mismatch("chart.result[0].indicators.quote[0]", "object", rawQuotes[0])
+		return v.result(endpoint)
+	}
+	for _, field := range []string{"open", "high", "low", "close", "volume"} {
+		v.numberArray(quote, field, "chart.result[0].indicators.quote[0]."+field)
+	}
+
+	return v.result(endpoint)
+}
+
+// schemaValidator collects the problems found in a decoded JSON response.
+type schemaValidator struct {
+	problems []string
+}
+
+func (v *schemaValidator) missing(path string) {
+	v.problems = append(v.problems, path+": missing")
+}
+
+func (v *schemaValidator) mismatch(path, want string, got interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf("%s: expected %s, got %s", path, want, jsonTypeName(got)))
+}
+
+// object returns the object field of parent, or records a problem if it is missing or not an object.
+func (v *schemaValidator) object(parent map[string]interface{}, field, path string) (map[string]interface{}, bool) {
+	value, present := parent[field]
+	if !present || value == nil {
+		v.missing(path)
+		return nil, false
+	}
+	child, ok := value.(map[string]interface{})
+	if !ok {
+		v.mismatch(path, "object", value)
+		return nil, false
+	}
+	return child, true
+}
+
+// number records a problem if the field of obj is not a number, or is missing when required.
+func (v *schemaValidator) number(obj map[string]interface{}, field, path string, required bool) {
+	value, present := obj[field]
+	if !present {
+		if required {
+			v.missing(path)
+		}
+		return
+	}
+	if _, ok := value.(float64); !ok {
+		v.mismatch(path, "number", value)
+	}
+}
+
+// numberArray records a problem if the field of obj is missing or not an array of numbers.
+// Null elements are allowed since Yahoo Finance returns null for data points without trades.
+func (v *schemaValidator) numberArray(obj map[string]interface{}, field, path string) {
+	value, present := obj[field]
+	if !present {
+		v.missing(path)
+		return
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		v.mismatch(path, "array", value)
+		return
+	}
+	for i, element := range values {
+		if element == nil {
+			continue
+		}
+		if _, ok := element.(float64); !ok {
+			v.mismatch(fmt.Sprintf("%s[%d]", path, i), "number", element)
+			return
+		}
+	}
+}
+
+// result returns the collected problems as a SchemaError, or nil if there are none.
+func (v *schemaValidator) result(endpoint string) *SchemaError {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &SchemaError{Endpoint: endpoint, Problems: v.problems}
+}
+
+// jsonTypeName returns the JSON type name of a decoded value.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
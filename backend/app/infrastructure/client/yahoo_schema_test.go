@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateChartSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		body     string
+		want     []string
+	}{
+		{
+			name:     "Valid current price",
+			endpoint: YahooEndpointCurrent,
+			body:     `{"chart": {"result": [{"meta": {"symbol": "7203.T", "regularMarketPrice": 2500, "regularMarketVolume": 1000}}], "error": null}}`,
+		},
+		{
+			name:     "Valid history with null quotes",
+			endpoint: YahooEndpointHistorical,
+			body: `{"chart": {"result": [{"meta": {"symbol": "7203.T"}, "timestamp": [1711584000, 1711670400],
+				"indicators": {"quote": [{"open": [1, null], "high": [1, null], "low": [1, null], "close": [1, null], "volume": [10, null]}]}}]}}`,
+		},
+		{
+			name:     "Period without trading",
+			endpoint: YahooEndpointHistorical,
+			body:     `{"chart": {"result": [{"meta": {"symbol": "7203.T"}, "indicators": {"quote": [{}]}}]}}`,
+		},
+		{
+			name:     "API error",
+			endpoint: YahooEndpointCurrent,
+			body:     `{"chart": {"result": null, "error": {"code": "Not Found", "description": "No data found"}}}`,
+		},
+		{
+			name:     "Not JSON",
+			endpoint: YahooEndpointCurrent,
+			body:     `<html>Service Unavailable</html>`,
+		},
+		{
+			name:     "Renamed root",
+			endpoint: YahooEndpointCurrent,
+			body:     `{"chartV2": {"result": []}}`,
+			want:     []string{"chart: missing"},
+		},
+		{
+			name:     "Missing price",
+			endpoint: YahooEndpointCurrent,
+			body:     `{"chart": {"result": [{"meta": {"symbol": "7203.T", "price": 2500}}]}}`,
+			want:     []string{"chart.result[0].meta.regularMarketPrice: missing"},
+		},
+		{
+			name:     "Price as string",
+			endpoint: YahooEndpointCurrent,
+			body:     `{"chart": {"result": [{"meta": {"regularMarketPrice": "2500", "regularMarketVolume": 1000}}]}}`,
+			want:     []string{"chart.result[0].meta.regularMarketPrice: expected number, got string"},
+		},
+		{
+			name:     "Quote fields changed",
+			endpoint: YahooEndpointHistorical,
+			body: `{"chart": {"result": [{"meta": {}, "timestamp": [1711584000],
+				"indicators": {"quote": [{"o": [1], "high": [1], "low": [1], "close": ["1"], "volume": 10}]}}]}}`,
+			want: []string{
+				"chart.result[0].indicators.quote[0].open: missing",
+				"chart.result[0].indicators.quote[0].close[0]: expected number, got string",
+				"chart.result[0].indicators.quote[0].volume: expected array, got number",
+			},
+		},
+		{
+			name:     "Quotes missing",
+			endpoint: YahooEndpointIntraday,
+			body:     `{"chart": {"result": [{"meta": {}, "timestamp": [1711584000], "indicators": {}}]}}`,
+			want:     []string{"chart.result[0].indicators.quote: missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			if err := validateChartSchema(tt.endpoint, []byte(tt.body)); err != nil {
+				got = err.Problems
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("validateChartSchema() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestYahooFinanceClient_SchemaAlert(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"chart": {"result": [{"meta": {"symbol": "TEST", "regularMarketPrice": null}}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewYahooFinanceClientWithConfig(YahooFinanceConfig{
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+		RateLimitRPS: 100,
+	})
+	var alerts []*SchemaError
+	client.SetSchemaAlertHandler(func(ctx context.Context, err *SchemaError) {
+		alerts = append(alerts, err)
+	})
+
+	// Every stock fails in the same way, but the change is alerted once
+	for i := 0; i < 3; i++ {
+		_, err := client.GetCurrentPrice(context.Background(), "TEST")
+		if !errors.Is(err, ErrSchemaMismatch) {
+			t.Fatalf("GetCurrentPrice() error = %v, want ErrSchemaMismatch", err)
+		}
+		if IsRetryableError(err) {
+			t.Errorf("Schema mismatch should not be retryable: %v", err)
+		}
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(alerts))
+	}
+	if alerts[0].Endpoint != YahooEndpointCurrent {
+		t.Errorf("Endpoint = %s, want %s", alerts[0].Endpoint, YahooEndpointCurrent)
+	}
+
+	// Alerted again after the interval
+	client.schema.now = func() time.Time { return time.Now().Add(schemaAlertInterval) }
+	client.GetCurrentPrice(context.Background(), "TEST")
+	if len(alerts) != 2 {
+		t.Errorf("Expected another alert after the interval, got %d", len(alerts))
+	}
+}
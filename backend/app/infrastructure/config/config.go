@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/boost-jp/stock-automation/app/infrastructure/database"
@@ -10,11 +11,39 @@ import (
 
 // Config holds application configuration.
 type Config struct {
-	Database DatabaseConfig `json:"database"`
-	Yahoo    YahooConfig    `json:"yahoo"`
-	Server   ServerConfig   `json:"server"`
-	Log      LogConfig      `json:"log"`
-	Slack    SlackConfig    `json:"slack"`
+	Database   DatabaseConfig   `json:"database"`
+	Yahoo      YahooConfig      `json:"yahoo"`
+	Stooq      StooqConfig      `json:"stooq"`
+	Toushin    ToushinConfig    `json:"toushin"`
+	CoinGecko  CoinGeckoConfig  `json:"coingecko"`
+	JQuants    JQuantsConfig    `json:"jquants"`
+	DataSource DataSourceConfig `json:"data_source"`
+	Server     ServerConfig     `json:"server"`
+	Log        LogConfig        `json:"log"`
+	Slack      SlackConfig      `json:"slack"`
+	Webhook    WebhookConfig    `json:"webhook"`
+	Discord    DiscordConfig    `json:"discord"`
+	Email      EmailConfig      `json:"email"`
+	Scheduler  SchedulerConfig  `json:"scheduler"`
+	Technical  TechnicalConfig  `json:"technical"`
+	Scoring    ScoringConfig    `json:"scoring"`
+	Discovery  DiscoveryConfig  `json:"discovery"`
+	Hedge      HedgeConfig      `json:"hedge"`
+	Calendar   CalendarConfig   `json:"calendar"`
+	Broker     BrokerConfig     `json:"broker"`
+	Portfolio  PortfolioConfig  `json:"portfolio"`
+	Features   FeatureConfig    `json:"features"`
+	Locale     string           `json:"locale"`   // language of reports and notifications (ja or en)
+	Timezone   string           `json:"timezone"` // time zone of reports, notifications and dates (the system one if empty)
+}
+
+// FeatureConfig holds feature flag configuration.
+type FeatureConfig struct {
+	Environment string `json:"environment"` // environment whose flags are read from the flag file
+	// FlagsFile is the YAML file of the flags per environment. A missing file sets no flags.
+	FlagsFile string `json:"flags_file"`
+	// CacheTTL is how long the flags overridden in the database are cached.
+	CacheTTL time.Duration `json:"cache_ttl"`
 }
 
 // DatabaseConfig holds database-related configuration.
@@ -27,6 +56,19 @@ type DatabaseConfig struct {
 	MaxOpenConns int           `json:"max_open_conns"`
 	MaxIdleConns int           `json:"max_idle_conns"`
 	MaxLifetime  time.Duration `json:"max_lifetime"`
+	// SlowQueryThreshold is the execution time above which repository queries are logged. Zero disables the logging.
+	SlowQueryThreshold time.Duration `json:"slow_query_threshold"`
+	// WriteBufferSize is the number of buffered price writes that are flushed in one batch. Zero writes prices one by one.
+	WriteBufferSize int `json:"write_buffer_size"`
+	// WriteBufferInterval is the longest a price write stays buffered.
+	WriteBufferInterval time.Duration `json:"write_buffer_interval"`
+	// WriteBufferJournal is the file buffered writes are kept in until flushed, replayed on the next start after a crash.
+	WriteBufferJournal string `json:"write_buffer_journal"`
+	// WriteBufferDeadLetter is the file buffered writes that kept failing are moved to, in the format of the journal.
+	WriteBufferDeadLetter string `json:"write_buffer_dead_letter"`
+	// RetentionPolicyFile is the YAML file of the retention periods applied by the cleanup job.
+	// Without the file the daily prices older than a year are deleted.
+	RetentionPolicyFile string `json:"retention_policy_file"`
 }
 
 // YahooConfig holds Yahoo Finance API configuration.
@@ -38,6 +80,78 @@ type YahooConfig struct {
 	RetryMaxWait  time.Duration `json:"retry_max_wait"`
 	RateLimitRPS  int           `json:"rate_limit_rps"`
 	UserAgent     string        `json:"user_agent"`
+
+	AdaptiveRateLimit     bool    `json:"adaptive_rate_limit"`
+	MinRateLimitRPS       float64 `json:"min_rate_limit_rps"`
+	RateRecoverySuccesses int     `json:"rate_recovery_successes"`
+}
+
+// StooqConfig holds Stooq configuration.
+type StooqConfig struct {
+	BaseURL      string        `json:"base_url"`
+	Timeout      time.Duration `json:"timeout"`
+	RetryCount   int           `json:"retry_count"`
+	RateLimitRPS int           `json:"rate_limit_rps"`
+}
+
+// ToushinConfig holds the configuration of the fund library of the Investment Trusts Association,
+// Japan, the source of the net asset values of investment trusts.
+type ToushinConfig struct {
+	BaseURL      string        `json:"base_url"`
+	Timeout      time.Duration `json:"timeout"`
+	RetryCount   int           `json:"retry_count"`
+	RateLimitRPS int           `json:"rate_limit_rps"`
+}
+
+// CoinGeckoConfig holds the configuration of the CoinGecko API, the source of the prices of crypto assets.
+type CoinGeckoConfig struct {
+	BaseURL      string        `json:"base_url"`
+	APIKey       string        `json:"-"`        // demo API key (optional)
+	CoinIDs      string        `json:"coin_ids"` // CoinGecko IDs of symbols not known by default, e.g. "PEPE=pepe"
+	Timeout      time.Duration `json:"timeout"`
+	RetryCount   int           `json:"retry_count"`
+	RateLimitRPS int           `json:"rate_limit_rps"`
+}
+
+// JQuantsConfig holds J-Quants API configuration.
+// Either RefreshToken or MailAddress and Password are required to use the API.
+type JQuantsConfig struct {
+	BaseURL      string        `json:"base_url"`
+	MailAddress  string        `json:"mail_address"`
+	Password     string        `json:"-"`
+	RefreshToken string        `json:"-"`
+	Timeout      time.Duration `json:"timeout"`
+	RateLimitRPS int           `json:"rate_limit_rps"`
+}
+
+// Enabled reports whether credentials for the J-Quants API are configured.
+func (c JQuantsConfig) Enabled() bool {
+	return c.RefreshToken != "" || (c.MailAddress != "" && c.Password != "")
+}
+
+// DataSourceConfig holds stock data source configuration.
+type DataSourceConfig struct {
+	Type             string  `json:"type"`               // yahoo, stooq or jquants
+	Priority         string  `json:"priority"`           // sources preferred for prices of the same day, most preferred first
+	DailyLimits      string  `json:"daily_limits"`       // daily request limits per provider, e.g. "yahoo=2000,jquants=1000"
+	QuotaWarnPercent float64 `json:"quota_warn_percent"` // usage of a daily limit at which to warn and slow down collection
+
+	// PriceMoveNotifyPercent is the change from the previous close from which the stocks are listed
+	// in the summary notification after each collection run. Zero disables the notification.
+	PriceMoveNotifyPercent float64 `json:"price_move_notify_percent"`
+	// MADeviationAlertPercent is the deviation from the 25-day moving average beyond which the
+	// collected stocks are alerted as overheated or oversold. Zero disables the alert.
+	MADeviationAlertPercent float64 `json:"ma_deviation_alert_percent"`
+	// PriceFeedMinPercent is the share of the collected stocks that must have a price of the
+	// trading day after the closing collection. Fewer raise a critical alert.
+	PriceFeedMinPercent float64 `json:"price_feed_min_percent"`
+	// QuarantineThreshold is the number of failures in a row after which a stock is left out of the
+	// collection, e.g. when it has been delisted, and retried after QuarantinePeriod. Zero disables it.
+	QuarantineThreshold int           `json:"quarantine_threshold"`
+	QuarantinePeriod    time.Duration `json:"quarantine_period"`
+	// MarketIndices are the market indices whose prices are collected for the market section of the
+	// daily report, e.g. "^N225=日経平均株価,^DJI=NYダウ". Empty disables the collection.
+	MarketIndices string `json:"market_indices"`
 }
 
 // ServerConfig holds server configuration.
@@ -45,6 +159,9 @@ type ServerConfig struct {
 	Port         int           `json:"port"`
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
+	AdminToken   string        `json:"admin_token"`    // bearer token required by the admin endpoints (disabled if empty)
+	ShareSecret  string        `json:"-"`              // key signing the share link tokens (share links disabled if empty)
+	ShareBaseURL string        `json:"share_base_url"` // address of the server in the share links
 }
 
 // LogConfig holds logging configuration.
@@ -55,24 +172,176 @@ type LogConfig struct {
 }
 
 // SlackConfig holds Slack notification configuration.
+// Critical alerts and reports go to their own webhook URL or channel when set, otherwise to the default ones.
+// With a bot token, messages are posted with the Web API instead of the webhooks, so that follow-ups
+// are replied in the thread of their report and job status messages are updated in place.
 type SlackConfig struct {
+	WebhookURL         string `json:"webhook_url"`
+	Channel            string `json:"channel"`
+	Username           string `json:"username"`
+	CriticalWebhookURL string `json:"critical_webhook_url"`
+	CriticalChannel    string `json:"critical_channel"`
+	ReportWebhookURL   string `json:"report_webhook_url"`
+	ReportChannel      string `json:"report_channel"`
+	BotToken           string `json:"-"`
+	StatusJobs         string `json:"status_jobs"` // comma-separated jobs whose status message is updated while running
+	// SendInterval is the interval between the messages sent to each webhook or channel, within the rate limit of Slack.
+	SendInterval time.Duration `json:"send_interval"`
+}
+
+// UsesWebAPI reports whether messages are posted with the Web API and the bot token.
+func (c SlackConfig) UsesWebAPI() bool {
+	return c.BotToken != ""
+}
+
+// StatusJobNames returns the jobs whose status message is updated while running.
+func (c SlackConfig) StatusJobNames() []string {
+	var names []string
+	for _, name := range strings.Split(c.StatusJobs, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// WebhookConfig holds generic webhook notification configuration.
+// Notifications are posted to the URL in addition to Slack when it is set.
+type WebhookConfig struct {
+	URL                 string        `json:"url"`
+	Secret              string        `json:"-"`
+	PayloadTemplateFile string        `json:"payload_template_file"`
+	Timeout             time.Duration `json:"timeout"`
+	MaxRetries          int           `json:"max_retries"`
+}
+
+// DiscordConfig holds Discord notification configuration.
+// Notifications are sent to the Discord webhook in addition to Slack when it is set.
+type DiscordConfig struct {
 	WebhookURL string `json:"webhook_url"`
-	Channel    string `json:"channel"`
 	Username   string `json:"username"`
 }
 
+// EmailConfig holds SMTP configuration for emailing reports as PDF attachments.
+type EmailConfig struct {
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     int    `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"-"`
+	From         string `json:"from"`
+	ReportTo     string `json:"report_to"` // comma-separated recipients of the reports
+}
+
+// Enabled reports whether an SMTP server, a sender and recipients are configured.
+func (c EmailConfig) Enabled() bool {
+	return c.SMTPHost != "" && c.From != "" && len(c.Recipients()) > 0
+}
+
+// Recipients returns the recipients of the reports.
+func (c EmailConfig) Recipients() []string {
+	var recipients []string
+	for _, address := range strings.Split(c.ReportTo, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			recipients = append(recipients, address)
+		}
+	}
+	return recipients
+}
+
+// SchedulerConfig holds per-job timeout configuration.
+type SchedulerConfig struct {
+	PriceUpdateTimeout time.Duration `json:"price_update_timeout"`
+	ReportTimeout      time.Duration `json:"report_timeout"`
+	CleanupTimeout     time.Duration `json:"cleanup_timeout"`
+	DataQualityTimeout time.Duration `json:"data_quality_timeout"`
+	Timezone           string        `json:"timezone"` // time zone of the job schedules, e.g. Asia/Tokyo
+	Market             string        `json:"market"`   // market whose trading hours gate price collection (tokyo or newyork)
+}
+
+// TechnicalConfig holds the configuration of the technical indicator calculation of the watch list.
+type TechnicalConfig struct {
+	Workers   int `json:"workers"`    // stocks calculated concurrently
+	BatchSize int `json:"batch_size"` // stocks whose price histories are read in one query
+}
+
+// ScoringConfig holds composite score weight configuration.
+type ScoringConfig struct {
+	TechnicalWeight   float64 `json:"technical_weight"`
+	FundamentalWeight float64 `json:"fundamental_weight"`
+}
+
+// DiscoveryConfig holds the configuration of the weekly watch list candidates.
+type DiscoveryConfig struct {
+	AutoAdd          bool    `json:"auto_add"` // add the candidates to the watch list instead of only proposing them
+	LookbackDays     int     `json:"lookback_days"`
+	RecentDays       int     `json:"recent_days"`
+	VolumeSurgeRatio float64 `json:"volume_surge_ratio"`
+	MinAverageVolume float64 `json:"min_average_volume"`
+	MaxCandidates    int     `json:"max_candidates"`
+}
+
+// HedgeConfig holds the rules of the hedge advice sent when held stocks fall sharply.
+type HedgeConfig struct {
+	DropPercent        float64 `json:"drop_percent"` // fall from the previous close that triggers the advice, zero disables it
+	InverseETFCode     string  `json:"inverse_etf_code"`
+	HedgeRatioPercent  float64 `json:"hedge_ratio_percent"`
+	MinExposurePercent float64 `json:"min_exposure_percent"`
+	TargetCashPercent  float64 `json:"target_cash_percent"`
+}
+
+// CalendarConfig holds the Google Calendar integration that registers the earnings announcements
+// and dividend dates of the watched and held stocks.
+type CalendarConfig struct {
+	GoogleClientID     string        `json:"google_client_id"`
+	GoogleClientSecret string        `json:"-"`
+	GoogleRefreshToken string        `json:"-"`
+	CalendarID         string        `json:"calendar_id"` // "primary" for the main calendar of the account
+	SyncDays           int           `json:"sync_days"`   // how many days ahead events are registered
+	Timeout            time.Duration `json:"timeout"`
+}
+
+// Enabled reports whether the OAuth client and its refresh token are configured.
+func (c CalendarConfig) Enabled() bool {
+	return c.GoogleClientID != "" && c.GoogleClientSecret != "" && c.GoogleRefreshToken != ""
+}
+
+// BrokerConfig holds broker configuration.
+type BrokerConfig struct {
+	Type             string  `json:"type"`
+	PaperInitialCash float64 `json:"paper_initial_cash"`
+	PaperOrderAmount float64 `json:"paper_order_amount"` // amount per signal order in paper trading
+}
+
+// PortfolioConfig holds portfolio management configuration.
+type PortfolioConfig struct {
+	CostMethod string `json:"cost_method"` // realized profit/loss method of sales (fifo or average)
+
+	// CashCode is the code of the cash holding the deposits and withdrawals are recorded to
+	CashCode string `json:"cash_code"`
+	// TargetCashPercent is the share of the portfolio value kept in cash and left out of the buying power
+	TargetCashPercent float64 `json:"target_cash_percent"`
+	// Benchmark is the symbol of the market index the daily change of the portfolio is compared with
+	Benchmark string `json:"benchmark"`
+}
+
 // LoadConfig loads configuration from environment variables.
 func LoadConfig() *Config {
 	return &Config{
 		Database: DatabaseConfig{
-			Host:         getEnv("DB_HOST", "localhost"),
-			Port:         getEnvAsInt("DB_PORT", 3306),
-			User:         getEnv("DB_USER", "root"),
-			Password:     getEnv("DB_PASSWORD", ""),
-			DatabaseName: getEnv("DB_NAME", "stock_automation"),
-			MaxOpenConns: getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns: getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
-			MaxLifetime:  getEnvAsDuration("DB_MAX_LIFETIME", 5*time.Minute),
+			Host:                  getEnv("DB_HOST", "localhost"),
+			Port:                  getEnvAsInt("DB_PORT", 3306),
+			User:                  getEnv("DB_USER", "root"),
+			Password:              getEnv("DB_PASSWORD", ""),
+			DatabaseName:          getEnv("DB_NAME", "stock_automation"),
+			MaxOpenConns:          getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:          getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+			MaxLifetime:           getEnvAsDuration("DB_MAX_LIFETIME", 5*time.Minute),
+			SlowQueryThreshold:    getEnvAsDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+			WriteBufferSize:       getEnvAsInt("DB_WRITE_BUFFER_SIZE", 100),
+			WriteBufferInterval:   getEnvAsDuration("DB_WRITE_BUFFER_INTERVAL", 5*time.Second),
+			WriteBufferJournal:    getEnv("DB_WRITE_BUFFER_JOURNAL", "data/price_write_buffer.jsonl"),
+			WriteBufferDeadLetter: getEnv("DB_WRITE_BUFFER_DEAD_LETTER", "data/price_write_buffer.dead.jsonl"),
+			RetentionPolicyFile:   getEnv("DB_RETENTION_POLICY_FILE", "configs/retention.yaml"),
 		},
 		Yahoo: YahooConfig{
 			BaseURL:       getEnv("YAHOO_BASE_URL", "https://query1.finance.yahoo.com"),
@@ -82,11 +351,60 @@ func LoadConfig() *Config {
 			RetryMaxWait:  getEnvAsDuration("YAHOO_RETRY_MAX_WAIT", 10*time.Second),
 			RateLimitRPS:  getEnvAsInt("YAHOO_RATE_LIMIT_RPS", 10),
 			UserAgent:     getEnv("YAHOO_USER_AGENT", "Mozilla/5.0 (compatible; StockAutomation/1.0)"),
+
+			AdaptiveRateLimit:     getEnvAsBool("YAHOO_ADAPTIVE_RATE_LIMIT", true),
+			MinRateLimitRPS:       getEnvAsFloat("YAHOO_MIN_RATE_LIMIT_RPS", 0.5),
+			RateRecoverySuccesses: getEnvAsInt("YAHOO_RATE_RECOVERY_SUCCESSES", 20),
+		},
+		Stooq: StooqConfig{
+			BaseURL:      getEnv("STOOQ_BASE_URL", "https://stooq.com"),
+			Timeout:      getEnvAsDuration("STOOQ_TIMEOUT", 30*time.Second),
+			RetryCount:   getEnvAsInt("STOOQ_RETRY_COUNT", 3),
+			RateLimitRPS: getEnvAsInt("STOOQ_RATE_LIMIT_RPS", 2),
+		},
+		Toushin: ToushinConfig{
+			BaseURL:      getEnv("TOUSHIN_BASE_URL", "https://toushin-lib.fwg.ne.jp"),
+			Timeout:      getEnvAsDuration("TOUSHIN_TIMEOUT", 30*time.Second),
+			RetryCount:   getEnvAsInt("TOUSHIN_RETRY_COUNT", 3),
+			RateLimitRPS: getEnvAsInt("TOUSHIN_RATE_LIMIT_RPS", 1),
+		},
+		CoinGecko: CoinGeckoConfig{
+			BaseURL:      getEnv("COINGECKO_BASE_URL", "https://api.coingecko.com"),
+			APIKey:       getEnv("COINGECKO_API_KEY", ""),
+			CoinIDs:      getEnv("CRYPTO_COIN_IDS", ""),
+			Timeout:      getEnvAsDuration("COINGECKO_TIMEOUT", 30*time.Second),
+			RetryCount:   getEnvAsInt("COINGECKO_RETRY_COUNT", 3),
+			RateLimitRPS: getEnvAsInt("COINGECKO_RATE_LIMIT_RPS", 1),
+		},
+		JQuants: JQuantsConfig{
+			BaseURL:      getEnv("JQUANTS_BASE_URL", "https://api.jquants.com"),
+			MailAddress:  getEnv("JQUANTS_MAIL_ADDRESS", ""),
+			Password:     getEnv("JQUANTS_PASSWORD", ""),
+			RefreshToken: getEnv("JQUANTS_REFRESH_TOKEN", ""),
+			Timeout:      getEnvAsDuration("JQUANTS_TIMEOUT", 30*time.Second),
+			RateLimitRPS: getEnvAsInt("JQUANTS_RATE_LIMIT_RPS", 2),
+		},
+		DataSource: DataSourceConfig{
+			Type:     getEnv("DATA_SOURCE_TYPE", "yahoo"),
+			Priority: getEnv("DATA_SOURCE_PRIORITY", "jquants,yahoo,stooq"),
+
+			DailyLimits:      getEnv("DATA_SOURCE_DAILY_LIMITS", ""),
+			QuotaWarnPercent: getEnvAsFloat("DATA_SOURCE_QUOTA_WARN_PERCENT", 80),
+
+			PriceMoveNotifyPercent:  getEnvAsFloat("PRICE_MOVE_NOTIFY_PERCENT", 0),
+			MADeviationAlertPercent: getEnvAsFloat("MA_DEVIATION_ALERT_PERCENT", 10),
+			PriceFeedMinPercent:     getEnvAsFloat("PRICE_FEED_MIN_PERCENT", 50),
+			QuarantineThreshold:     getEnvAsInt("COLLECT_QUARANTINE_THRESHOLD", 5),
+			QuarantinePeriod:        getEnvAsDuration("COLLECT_QUARANTINE_PERIOD", 24*time.Hour),
+			MarketIndices:           getEnv("MARKET_INDICES", "^N225=日経平均株価"),
 		},
 		Server: ServerConfig{
 			Port:         getEnvAsInt("SERVER_PORT", 8080),
 			ReadTimeout:  getEnvAsDuration("SERVER_READ_TIMEOUT", 10*time.Second),
 			WriteTimeout: getEnvAsDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
+			AdminToken:   getEnv("SERVER_ADMIN_TOKEN", ""),
+			ShareSecret:  getEnv("SERVER_SHARE_SECRET", ""),
+			ShareBaseURL: getEnv("SERVER_SHARE_BASE_URL", ""),
 		},
 		Log: LogConfig{
 			Level:      getEnv("LOG_LEVEL", "info"),
@@ -97,7 +415,93 @@ func LoadConfig() *Config {
 			WebhookURL: getEnv("SLACK_WEBHOOK_URL", ""),
 			Channel:    getEnv("SLACK_CHANNEL", "#general"),
 			Username:   getEnv("SLACK_USERNAME", "Stock Bot"),
+
+			CriticalWebhookURL: getEnv("SLACK_CRITICAL_WEBHOOK_URL", ""),
+			CriticalChannel:    getEnv("SLACK_CRITICAL_CHANNEL", ""),
+			ReportWebhookURL:   getEnv("SLACK_REPORT_WEBHOOK_URL", ""),
+			ReportChannel:      getEnv("SLACK_REPORT_CHANNEL", ""),
+
+			BotToken:   getEnv("SLACK_BOT_TOKEN", ""),
+			StatusJobs: getEnv("SLACK_STATUS_JOBS", ""),
+
+			SendInterval: getEnvAsDuration("SLACK_SEND_INTERVAL", time.Second),
+		},
+		Webhook: WebhookConfig{
+			URL:                 getEnv("WEBHOOK_URL", ""),
+			Secret:              getEnv("WEBHOOK_SECRET", ""),
+			PayloadTemplateFile: getEnv("WEBHOOK_PAYLOAD_TEMPLATE_FILE", ""),
+			Timeout:             getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxRetries:          getEnvAsInt("WEBHOOK_MAX_RETRIES", 3),
+		},
+		Discord: DiscordConfig{
+			WebhookURL: getEnv("DISCORD_WEBHOOK_URL", ""),
+			Username:   getEnv("DISCORD_USERNAME", "Stock Bot"),
+		},
+		Email: EmailConfig{
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("EMAIL_FROM", ""),
+			ReportTo:     getEnv("REPORT_EMAIL_TO", ""),
+		},
+		Scheduler: SchedulerConfig{
+			PriceUpdateTimeout: getEnvAsDuration("SCHEDULER_PRICE_UPDATE_TIMEOUT", 4*time.Minute),
+			ReportTimeout:      getEnvAsDuration("SCHEDULER_REPORT_TIMEOUT", 5*time.Minute),
+			CleanupTimeout:     getEnvAsDuration("SCHEDULER_CLEANUP_TIMEOUT", 30*time.Minute),
+			DataQualityTimeout: getEnvAsDuration("SCHEDULER_DATA_QUALITY_TIMEOUT", 10*time.Minute),
+			Timezone:           getEnv("SCHEDULER_TIMEZONE", "Asia/Tokyo"),
+			Market:             getEnv("MARKET", "tokyo"),
 		},
+		Technical: TechnicalConfig{
+			Workers:   getEnvAsInt("TECHNICAL_WORKERS", 8),
+			BatchSize: getEnvAsInt("TECHNICAL_BATCH_SIZE", 50),
+		},
+		Scoring: ScoringConfig{
+			TechnicalWeight:   getEnvAsFloat("SCORING_TECHNICAL_WEIGHT", 0.6),
+			FundamentalWeight: getEnvAsFloat("SCORING_FUNDAMENTAL_WEIGHT", 0.4),
+		},
+		Discovery: DiscoveryConfig{
+			AutoAdd:          getEnvAsBool("DISCOVERY_AUTO_ADD", false),
+			LookbackDays:     getEnvAsInt("DISCOVERY_LOOKBACK_DAYS", 60),
+			RecentDays:       getEnvAsInt("DISCOVERY_RECENT_DAYS", 5),
+			VolumeSurgeRatio: getEnvAsFloat("DISCOVERY_VOLUME_SURGE_RATIO", 3.0),
+			MinAverageVolume: getEnvAsFloat("DISCOVERY_MIN_AVERAGE_VOLUME", 100000),
+			MaxCandidates:    getEnvAsInt("DISCOVERY_MAX_CANDIDATES", 10),
+		},
+		Hedge: HedgeConfig{
+			DropPercent:        getEnvAsFloat("HEDGE_DROP_PERCENT", 5),
+			InverseETFCode:     getEnv("HEDGE_INVERSE_ETF_CODE", "1571"),
+			HedgeRatioPercent:  getEnvAsFloat("HEDGE_RATIO_PERCENT", 50),
+			MinExposurePercent: getEnvAsFloat("HEDGE_MIN_EXPOSURE_PERCENT", 10),
+			TargetCashPercent:  getEnvAsFloat("HEDGE_TARGET_CASH_PERCENT", 20),
+		},
+		Calendar: CalendarConfig{
+			GoogleClientID:     getEnv("GOOGLE_CALENDAR_CLIENT_ID", ""),
+			GoogleClientSecret: getEnv("GOOGLE_CALENDAR_CLIENT_SECRET", ""),
+			GoogleRefreshToken: getEnv("GOOGLE_CALENDAR_REFRESH_TOKEN", ""),
+			CalendarID:         getEnv("GOOGLE_CALENDAR_ID", "primary"),
+			SyncDays:           getEnvAsInt("CALENDAR_SYNC_DAYS", 90),
+			Timeout:            getEnvAsDuration("GOOGLE_CALENDAR_TIMEOUT", 30*time.Second),
+		},
+		Broker: BrokerConfig{
+			Type:             getEnv("BROKER_TYPE", "paper"),
+			PaperInitialCash: getEnvAsFloat("BROKER_PAPER_INITIAL_CASH", 10000000),
+			PaperOrderAmount: getEnvAsFloat("BROKER_PAPER_ORDER_AMOUNT", 1000000),
+		},
+		Portfolio: PortfolioConfig{
+			CostMethod:        getEnv("PORTFOLIO_COST_METHOD", "fifo"),
+			CashCode:          getEnv("PORTFOLIO_CASH_CODE", "JPY"),
+			TargetCashPercent: getEnvAsFloat("PORTFOLIO_TARGET_CASH_PERCENT", 10),
+			Benchmark:         getEnv("PORTFOLIO_BENCHMARK", "^N225"),
+		},
+		Features: FeatureConfig{
+			Environment: getEnv("APP_ENV", "development"),
+			FlagsFile:   getEnv("FEATURE_FLAGS_FILE", "configs/feature_flags.yaml"),
+			CacheTTL:    getEnvAsDuration("FEATURE_FLAGS_CACHE_TTL", 30*time.Second),
+		},
+		Locale:   getEnv("LOCALE", "ja"),
+		Timezone: getEnv("TIMEZONE", ""),
 	}
 }
 
@@ -139,6 +543,24 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if valueStr := os.Getenv(key); valueStr != "" {
+		if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+			return value
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if valueStr := os.Getenv(key); valueStr != "" {
+		if value, err := strconv.ParseBool(valueStr); err == nil {
+			return value
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if valueStr := os.Getenv(key); valueStr != "" {
 		if value, err := time.ParseDuration(valueStr); err == nil {
@@ -108,16 +108,28 @@ type Portfolio struct {
 	Code string `boil:"code" json:"code" toml:"code" yaml:"code"`
 	// 銘柄名
 	Name string `boil:"name" json:"name" toml:"name" yaml:"name"`
-	// 保有株数
-	Shares int `boil:"shares" json:"shares" toml:"shares" yaml:"shares"`
+	// 保有株数(暗号資産は1億分の1単位)
+	Shares int64 `boil:"shares" json:"shares" toml:"shares" yaml:"shares"`
 	// 購入価格
 	PurchasePrice types.Decimal `boil:"purchase_price" json:"purchase_price" toml:"purchase_price" yaml:"purchase_price"`
 	// 購入日
 	PurchaseDate time.Time `boil:"purchase_date" json:"purchase_date" toml:"purchase_date" yaml:"purchase_date"`
+	// ポジション種別(long/short)
+	PositionType string `boil:"position_type" json:"position_type" toml:"position_type" yaml:"position_type"`
+	// 委託保証金率(%)
+	MarginRate types.NullDecimal `boil:"margin_rate" json:"margin_rate,omitempty" toml:"margin_rate" yaml:"margin_rate,omitempty"`
+	// 年率金利・貸株料(%)
+	InterestRate types.NullDecimal `boil:"interest_rate" json:"interest_rate,omitempty" toml:"interest_rate" yaml:"interest_rate,omitempty"`
+	// 資産クラス(stock/etf/fund/crypto/cash)
+	AssetClass string `boil:"asset_class" json:"asset_class" toml:"asset_class" yaml:"asset_class"`
+	// ISINコード(投資信託)
+	Isin null.String `boil:"isin" json:"isin,omitempty" toml:"isin" yaml:"isin,omitempty"`
 	// 作成日時
 	CreatedAt null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	// 更新日時
 	UpdatedAt null.Time `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	// 削除日時
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *portfolioR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L portfolioL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -130,8 +142,14 @@ var PortfolioColumns = struct {
 	Shares        string
 	PurchasePrice string
 	PurchaseDate  string
+	PositionType  string
+	MarginRate    string
+	InterestRate  string
+	AssetClass    string
+	Isin          string
 	CreatedAt     string
 	UpdatedAt     string
+	DeletedAt     string
 }{
 	ID:            "id",
 	Code:          "code",
@@ -139,8 +157,14 @@ var PortfolioColumns = struct {
 	Shares:        "shares",
 	PurchasePrice: "purchase_price",
 	PurchaseDate:  "purchase_date",
+	PositionType:  "position_type",
+	MarginRate:    "margin_rate",
+	InterestRate:  "interest_rate",
+	AssetClass:    "asset_class",
+	Isin:          "isin",
 	CreatedAt:     "created_at",
 	UpdatedAt:     "updated_at",
+	DeletedAt:     "deleted_at",
 }
 
 var PortfolioTableColumns = struct {
@@ -150,8 +174,14 @@ var PortfolioTableColumns = struct {
 	Shares        string
 	PurchasePrice string
 	PurchaseDate  string
+	PositionType  string
+	MarginRate    string
+	InterestRate  string
+	AssetClass    string
+	Isin          string
 	CreatedAt     string
 	UpdatedAt     string
+	DeletedAt     string
 }{
 	ID:            "portfolios.id",
 	Code:          "portfolios.code",
@@ -159,8 +189,14 @@ var PortfolioTableColumns = struct {
 	Shares:        "portfolios.shares",
 	PurchasePrice: "portfolios.purchase_price",
 	PurchaseDate:  "portfolios.purchase_date",
+	PositionType:  "portfolios.position_type",
+	MarginRate:    "portfolios.margin_rate",
+	InterestRate:  "portfolios.interest_rate",
+	AssetClass:    "portfolios.asset_class",
+	Isin:          "portfolios.isin",
 	CreatedAt:     "portfolios.created_at",
 	UpdatedAt:     "portfolios.updated_at",
+	DeletedAt:     "portfolios.deleted_at",
 }
 
 // Generated where
@@ -277,24 +313,60 @@ func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var PortfolioWhere = struct {
 	ID            whereHelperstring
 	Code          whereHelperstring
 	Name          whereHelperstring
-	Shares        whereHelperint
+	Shares        whereHelperint64
 	PurchasePrice whereHelpertypes_Decimal
 	PurchaseDate  whereHelpertime_Time
+	PositionType  whereHelperstring
+	MarginRate    whereHelpertypes_NullDecimal
+	InterestRate  whereHelpertypes_NullDecimal
+	AssetClass    whereHelperstring
+	Isin          whereHelpernull_String
 	CreatedAt     whereHelpernull_Time
 	UpdatedAt     whereHelpernull_Time
+	DeletedAt     whereHelpernull_Time
 }{
 	ID:            whereHelperstring{field: "`portfolios`.`id`"},
 	Code:          whereHelperstring{field: "`portfolios`.`code`"},
 	Name:          whereHelperstring{field: "`portfolios`.`name`"},
-	Shares:        whereHelperint{field: "`portfolios`.`shares`"},
+	Shares:        whereHelperint64{field: "`portfolios`.`shares`"},
 	PurchasePrice: whereHelpertypes_Decimal{field: "`portfolios`.`purchase_price`"},
 	PurchaseDate:  whereHelpertime_Time{field: "`portfolios`.`purchase_date`"},
+	PositionType:  whereHelperstring{field: "`portfolios`.`position_type`"},
+	MarginRate:    whereHelpertypes_NullDecimal{field: "`portfolios`.`margin_rate`"},
+	InterestRate:  whereHelpertypes_NullDecimal{field: "`portfolios`.`interest_rate`"},
+	AssetClass:    whereHelperstring{field: "`portfolios`.`asset_class`"},
+	Isin:          whereHelpernull_String{field: "`portfolios`.`isin`"},
 	CreatedAt:     whereHelpernull_Time{field: "`portfolios`.`created_at`"},
 	UpdatedAt:     whereHelpernull_Time{field: "`portfolios`.`updated_at`"},
+	DeletedAt:     whereHelpernull_Time{field: "`portfolios`.`deleted_at`"},
 }
 
 // PortfolioRels is where relationship names are stored.
@@ -314,9 +386,9 @@ func (*portfolioR) NewStruct() *portfolioR {
 type portfolioL struct{}
 
 var (
-	portfolioAllColumns            = []string{"id", "code", "name", "shares", "purchase_price", "purchase_date", "created_at", "updated_at"}
-	portfolioColumnsWithoutDefault = []string{"id", "code", "name", "shares", "purchase_price", "purchase_date"}
-	portfolioColumnsWithDefault    = []string{"created_at", "updated_at"}
+	portfolioAllColumns            = []string{"id", "code", "name", "shares", "purchase_price", "purchase_date", "position_type", "margin_rate", "interest_rate", "asset_class", "isin", "created_at", "updated_at", "deleted_at"}
+	portfolioColumnsWithoutDefault = []string{"id", "code", "name", "shares", "purchase_price", "purchase_date", "margin_rate", "interest_rate", "isin", "deleted_at"}
+	portfolioColumnsWithDefault    = []string{"position_type", "asset_class", "created_at", "updated_at"}
 	portfolioPrimaryKeyColumns     = []string{"id"}
 	portfolioGeneratedColumns      = []string{}
 )
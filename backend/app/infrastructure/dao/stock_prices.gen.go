@@ -116,8 +116,16 @@ type StockPrice struct {
 	LowPrice types.Decimal `boil:"low_price" json:"low_price" toml:"low_price" yaml:"low_price"`
 	// 終値
 	ClosePrice types.Decimal `boil:"close_price" json:"close_price" toml:"close_price" yaml:"close_price"`
+	// 分割調整後終値
+	AdjClosePrice types.NullDecimal `boil:"adj_close_price" json:"adj_close_price,omitempty" toml:"adj_close_price" yaml:"adj_close_price,omitempty"`
 	// 出来高
 	Volume int64 `boil:"volume" json:"volume" toml:"volume" yaml:"volume"`
+	// 取得元データソース
+	Source string `boil:"source" json:"source" toml:"source" yaml:"source"`
+	// 取得日時
+	FetchedAt null.Time `boil:"fetched_at" json:"fetched_at,omitempty" toml:"fetched_at" yaml:"fetched_at,omitempty"`
+	// 確定値フラグ(ザラ場中の取得値は暫定)
+	IsFinal bool `boil:"is_final" json:"is_final" toml:"is_final" yaml:"is_final"`
 	// 作成日時
 	CreatedAt null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	// 更新日時
@@ -128,51 +136,67 @@ type StockPrice struct {
 }
 
 var StockPriceColumns = struct {
-	ID         string
-	Code       string
-	Date       string
-	OpenPrice  string
-	HighPrice  string
-	LowPrice   string
-	ClosePrice string
-	Volume     string
-	CreatedAt  string
-	UpdatedAt  string
+	ID            string
+	Code          string
+	Date          string
+	OpenPrice     string
+	HighPrice     string
+	LowPrice      string
+	ClosePrice    string
+	AdjClosePrice string
+	Volume        string
+	Source        string
+	FetchedAt     string
+	IsFinal       string
+	CreatedAt     string
+	UpdatedAt     string
 }{
-	ID:         "id",
-	Code:       "code",
-	Date:       "date",
-	OpenPrice:  "open_price",
-	HighPrice:  "high_price",
-	LowPrice:   "low_price",
-	ClosePrice: "close_price",
-	Volume:     "volume",
-	CreatedAt:  "created_at",
-	UpdatedAt:  "updated_at",
+	ID:            "id",
+	Code:          "code",
+	Date:          "date",
+	OpenPrice:     "open_price",
+	HighPrice:     "high_price",
+	LowPrice:      "low_price",
+	ClosePrice:    "close_price",
+	AdjClosePrice: "adj_close_price",
+	Volume:        "volume",
+	Source:        "source",
+	FetchedAt:     "fetched_at",
+	IsFinal:       "is_final",
+	CreatedAt:     "created_at",
+	UpdatedAt:     "updated_at",
 }
 
 var StockPriceTableColumns = struct {
-	ID         string
-	Code       string
-	Date       string
-	OpenPrice  string
-	HighPrice  string
-	LowPrice   string
-	ClosePrice string
-	Volume     string
-	CreatedAt  string
-	UpdatedAt  string
+	ID            string
+	Code          string
+	Date          string
+	OpenPrice     string
+	HighPrice     string
+	LowPrice      string
+	ClosePrice    string
+	AdjClosePrice string
+	Volume        string
+	Source        string
+	FetchedAt     string
+	IsFinal       string
+	CreatedAt     string
+	UpdatedAt     string
 }{
-	ID:         "stock_prices.id",
-	Code:       "stock_prices.code",
-	Date:       "stock_prices.date",
-	OpenPrice:  "stock_prices.open_price",
-	HighPrice:  "stock_prices.high_price",
-	LowPrice:   "stock_prices.low_price",
-	ClosePrice: "stock_prices.close_price",
-	Volume:     "stock_prices.volume",
-	CreatedAt:  "stock_prices.created_at",
-	UpdatedAt:  "stock_prices.updated_at",
+	ID:            "stock_prices.id",
+	Code:          "stock_prices.code",
+	Date:          "stock_prices.date",
+	OpenPrice:     "stock_prices.open_price",
+	HighPrice:     "stock_prices.high_price",
+	LowPrice:      "stock_prices.low_price",
+	ClosePrice:    "stock_prices.close_price",
+	AdjClosePrice: "stock_prices.adj_close_price",
+	Volume:        "stock_prices.volume",
+	Source:        "stock_prices.source",
+	FetchedAt:     "stock_prices.fetched_at",
+	IsFinal:       "stock_prices.is_final",
+	CreatedAt:     "stock_prices.created_at",
+	UpdatedAt:     "stock_prices.updated_at",
 }
 
 // Generated where
@@ -200,28 +224,45 @@ func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var StockPriceWhere = struct {
-	ID         whereHelperstring
-	Code       whereHelperstring
-	Date       whereHelpertime_Time
-	OpenPrice  whereHelpertypes_Decimal
-	HighPrice  whereHelpertypes_Decimal
-	LowPrice   whereHelpertypes_Decimal
-	ClosePrice whereHelpertypes_Decimal
-	Volume     whereHelperint64
-	CreatedAt  whereHelpernull_Time
-	UpdatedAt  whereHelpernull_Time
+	ID            whereHelperstring
+	Code          whereHelperstring
+	Date          whereHelpertime_Time
+	OpenPrice     whereHelpertypes_Decimal
+	HighPrice     whereHelpertypes_Decimal
+	LowPrice      whereHelpertypes_Decimal
+	ClosePrice    whereHelpertypes_Decimal
+	AdjClosePrice whereHelpertypes_NullDecimal
+	Volume        whereHelperint64
+	Source        whereHelperstring
+	FetchedAt     whereHelpernull_Time
+	IsFinal       whereHelperbool
+	CreatedAt     whereHelpernull_Time
+	UpdatedAt     whereHelpernull_Time
 }{
-	ID:         whereHelperstring{field: "`stock_prices`.`id`"},
-	Code:       whereHelperstring{field: "`stock_prices`.`code`"},
-	Date:       whereHelpertime_Time{field: "`stock_prices`.`date`"},
-	OpenPrice:  whereHelpertypes_Decimal{field: "`stock_prices`.`open_price`"},
-	HighPrice:  whereHelpertypes_Decimal{field: "`stock_prices`.`high_price`"},
-	LowPrice:   whereHelpertypes_Decimal{field: "`stock_prices`.`low_price`"},
-	ClosePrice: whereHelpertypes_Decimal{field: "`stock_prices`.`close_price`"},
-	Volume:     whereHelperint64{field: "`stock_prices`.`volume`"},
-	CreatedAt:  whereHelpernull_Time{field: "`stock_prices`.`created_at`"},
-	UpdatedAt:  whereHelpernull_Time{field: "`stock_prices`.`updated_at`"},
+	ID:            whereHelperstring{field: "`stock_prices`.`id`"},
+	Code:          whereHelperstring{field: "`stock_prices`.`code`"},
+	Date:          whereHelpertime_Time{field: "`stock_prices`.`date`"},
+	OpenPrice:     whereHelpertypes_Decimal{field: "`stock_prices`.`open_price`"},
+	HighPrice:     whereHelpertypes_Decimal{field: "`stock_prices`.`high_price`"},
+	LowPrice:      whereHelpertypes_Decimal{field: "`stock_prices`.`low_price`"},
+	ClosePrice:    whereHelpertypes_Decimal{field: "`stock_prices`.`close_price`"},
+	AdjClosePrice: whereHelpertypes_NullDecimal{field: "`stock_prices`.`adj_close_price`"},
+	Volume:        whereHelperint64{field: "`stock_prices`.`volume`"},
+	Source:        whereHelperstring{field: "`stock_prices`.`source`"},
+	FetchedAt:     whereHelpernull_Time{field: "`stock_prices`.`fetched_at`"},
+	IsFinal:       whereHelperbool{field: "`stock_prices`.`is_final`"},
+	CreatedAt:     whereHelpernull_Time{field: "`stock_prices`.`created_at`"},
+	UpdatedAt:     whereHelpernull_Time{field: "`stock_prices`.`updated_at`"},
 }
 
 // StockPriceRels is where relationship names are stored.
@@ -241,9 +282,9 @@ func (*stockPriceR) NewStruct() *stockPriceR {
 type stockPriceL struct{}
 
 var (
-	stockPriceAllColumns            = []string{"id", "code", "date", "open_price", "high_price", "low_price", "close_price", "volume", "created_at", "updated_at"}
-	stockPriceColumnsWithoutDefault = []string{"id", "code", "date", "open_price", "high_price", "low_price", "close_price", "volume"}
-	stockPriceColumnsWithDefault    = []string{"created_at", "updated_at"}
+	stockPriceAllColumns            = []string{"id", "code", "date", "open_price", "high_price", "low_price", "close_price", "adj_close_price", "volume", "source", "fetched_at", "is_final", "created_at", "updated_at"}
+	stockPriceColumnsWithoutDefault = []string{"id", "code", "date", "open_price", "high_price", "low_price", "close_price", "adj_close_price", "volume", "fetched_at"}
+	stockPriceColumnsWithDefault    = []string{"source", "is_final", "created_at", "updated_at"}
 	stockPricePrimaryKeyColumns     = []string{"id"}
 	stockPriceGeneratedColumns      = []string{}
 )
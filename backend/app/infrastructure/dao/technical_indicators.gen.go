@@ -122,6 +122,8 @@ type TechnicalIndicator struct {
 	Sma25 types.NullDecimal `boil:"sma_25" json:"sma_25,omitempty" toml:"sma_25" yaml:"sma_25,omitempty"`
 	// 75日移動平均
 	Sma75 types.NullDecimal `boil:"sma_75" json:"sma_75,omitempty" toml:"sma_75" yaml:"sma_75,omitempty"`
+	// 25日移動平均乖離率(%)
+	Ma25Deviation types.NullDecimal `boil:"ma25_deviation" json:"ma25_deviation,omitempty" toml:"ma25_deviation" yaml:"ma25_deviation,omitempty"`
 	// 作成日時
 	CreatedAt null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	// 更新日時
@@ -142,6 +144,7 @@ var TechnicalIndicatorColumns = struct {
 	Sma5          string
 	Sma25         string
 	Sma75         string
+	Ma25Deviation string
 	CreatedAt     string
 	UpdatedAt     string
 }{
@@ -155,6 +158,7 @@ var TechnicalIndicatorColumns = struct {
 	Sma5:          "sma_5",
 	Sma25:         "sma_25",
 	Sma75:         "sma_75",
+	Ma25Deviation: "ma25_deviation",
 	CreatedAt:     "created_at",
 	UpdatedAt:     "updated_at",
 }
@@ -170,6 +174,7 @@ var TechnicalIndicatorTableColumns = struct {
 	Sma5          string
 	Sma25         string
 	Sma75         string
+	Ma25Deviation string
 	CreatedAt     string
 	UpdatedAt     string
 }{
@@ -183,6 +188,7 @@ var TechnicalIndicatorTableColumns = struct {
 	Sma5:          "technical_indicators.sma_5",
 	Sma25:         "technical_indicators.sma_25",
 	Sma75:         "technical_indicators.sma_75",
+	Ma25Deviation: "technical_indicators.ma25_deviation",
 	CreatedAt:     "technical_indicators.created_at",
 	UpdatedAt:     "technical_indicators.updated_at",
 }
@@ -226,6 +232,7 @@ var TechnicalIndicatorWhere = struct {
 	Sma5          whereHelpertypes_NullDecimal
 	Sma25         whereHelpertypes_NullDecimal
 	Sma75         whereHelpertypes_NullDecimal
+	Ma25Deviation whereHelpertypes_NullDecimal
 	CreatedAt     whereHelpernull_Time
 	UpdatedAt     whereHelpernull_Time
 }{
@@ -239,6 +246,7 @@ var TechnicalIndicatorWhere = struct {
 	Sma5:          whereHelpertypes_NullDecimal{field: "`technical_indicators`.`sma_5`"},
 	Sma25:         whereHelpertypes_NullDecimal{field: "`technical_indicators`.`sma_25`"},
 	Sma75:         whereHelpertypes_NullDecimal{field: "`technical_indicators`.`sma_75`"},
+	Ma25Deviation: whereHelpertypes_NullDecimal{field: "`technical_indicators`.`ma25_deviation`"},
 	CreatedAt:     whereHelpernull_Time{field: "`technical_indicators`.`created_at`"},
 	UpdatedAt:     whereHelpernull_Time{field: "`technical_indicators`.`updated_at`"},
 }
@@ -260,8 +268,8 @@ func (*technicalIndicatorR) NewStruct() *technicalIndicatorR {
 type technicalIndicatorL struct{}
 
 var (
-	technicalIndicatorAllColumns            = []string{"id", "code", "date", "rsi_14", "macd", "macd_signal", "macd_histogram", "sma_5", "sma_25", "sma_75", "created_at", "updated_at"}
-	technicalIndicatorColumnsWithoutDefault = []string{"id", "code", "date", "rsi_14", "macd", "macd_signal", "macd_histogram", "sma_5", "sma_25", "sma_75"}
+	technicalIndicatorAllColumns            = []string{"id", "code", "date", "rsi_14", "macd", "macd_signal", "macd_histogram", "sma_5", "sma_25", "sma_75", "ma25_deviation", "created_at", "updated_at"}
+	technicalIndicatorColumnsWithoutDefault = []string{"id", "code", "date", "rsi_14", "macd", "macd_signal", "macd_histogram", "sma_5", "sma_25", "sma_75", "ma25_deviation"}
 	technicalIndicatorColumnsWithDefault    = []string{"created_at", "updated_at"}
 	technicalIndicatorPrimaryKeyColumns     = []string{"id"}
 	technicalIndicatorGeneratedColumns      = []string{}
@@ -114,53 +114,71 @@ type WatchList struct {
 	TargetSellPrice types.NullDecimal `boil:"target_sell_price" json:"target_sell_price,omitempty" toml:"target_sell_price" yaml:"target_sell_price,omitempty"`
 	// アクティブフラグ
 	IsActive null.Bool `boil:"is_active" json:"is_active,omitempty" toml:"is_active" yaml:"is_active,omitempty"`
+	// 収集間隔(分、0は既定の間隔)
+	CollectIntervalMinutes int `boil:"collect_interval_minutes" json:"collect_interval_minutes" toml:"collect_interval_minutes" yaml:"collect_interval_minutes"`
+	// 通知ミュート期限
+	MutedUntil null.Time `boil:"muted_until" json:"muted_until,omitempty" toml:"muted_until" yaml:"muted_until,omitempty"`
 	// 作成日時
 	CreatedAt null.Time `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	// 更新日時
 	UpdatedAt null.Time `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
+	// 削除日時
+	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *watchListR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L watchListL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var WatchListColumns = struct {
-	ID              string
-	Code            string
-	Name            string
-	TargetBuyPrice  string
-	TargetSellPrice string
-	IsActive        string
-	CreatedAt       string
-	UpdatedAt       string
+	ID                     string
+	Code                   string
+	Name                   string
+	TargetBuyPrice         string
+	TargetSellPrice        string
+	IsActive               string
+	CollectIntervalMinutes string
+	MutedUntil             string
+	CreatedAt              string
+	UpdatedAt              string
+	DeletedAt              string
 }{
-	ID:              "id",
-	Code:            "code",
-	Name:            "name",
-	TargetBuyPrice:  "target_buy_price",
-	TargetSellPrice: "target_sell_price",
-	IsActive:        "is_active",
-	CreatedAt:       "created_at",
-	UpdatedAt:       "updated_at",
+	ID:                     "id",
+	Code:                   "code",
+	Name:                   "name",
+	TargetBuyPrice:         "target_buy_price",
+	TargetSellPrice:        "target_sell_price",
+	IsActive:               "is_active",
+	CollectIntervalMinutes: "collect_interval_minutes",
+	MutedUntil:             "muted_until",
+	CreatedAt:              "created_at",
+	UpdatedAt:              "updated_at",
+	DeletedAt:              "deleted_at",
 }
 
 var WatchListTableColumns = struct {
-	ID              string
-	Code            string
-	Name            string
-	TargetBuyPrice  string
-	TargetSellPrice string
-	IsActive        string
-	CreatedAt       string
-	UpdatedAt       string
+	ID                     string
+	Code                   string
+	Name                   string
+	TargetBuyPrice         string
+	TargetSellPrice        string
+	IsActive               string
+	CollectIntervalMinutes string
+	MutedUntil             string
+	CreatedAt              string
+	UpdatedAt              string
+	DeletedAt              string
 }{
-	ID:              "watch_lists.id",
-	Code:            "watch_lists.code",
-	Name:            "watch_lists.name",
-	TargetBuyPrice:  "watch_lists.target_buy_price",
-	TargetSellPrice: "watch_lists.target_sell_price",
-	IsActive:        "watch_lists.is_active",
-	CreatedAt:       "watch_lists.created_at",
-	UpdatedAt:       "watch_lists.updated_at",
+	ID:                     "watch_lists.id",
+	Code:                   "watch_lists.code",
+	Name:                   "watch_lists.name",
+	TargetBuyPrice:         "watch_lists.target_buy_price",
+	TargetSellPrice:        "watch_lists.target_sell_price",
+	IsActive:               "watch_lists.is_active",
+	CollectIntervalMinutes: "watch_lists.collect_interval_minutes",
+	MutedUntil:             "watch_lists.muted_until",
+	CreatedAt:              "watch_lists.created_at",
+	UpdatedAt:              "watch_lists.updated_at",
+	DeletedAt:              "watch_lists.deleted_at",
 }
 
 // Generated where
@@ -190,23 +208,29 @@ func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsN
 func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var WatchListWhere = struct {
-	ID              whereHelperstring
-	Code            whereHelperstring
-	Name            whereHelperstring
-	TargetBuyPrice  whereHelpertypes_NullDecimal
-	TargetSellPrice whereHelpertypes_NullDecimal
-	IsActive        whereHelpernull_Bool
-	CreatedAt       whereHelpernull_Time
-	UpdatedAt       whereHelpernull_Time
+	ID                     whereHelperstring
+	Code                   whereHelperstring
+	Name                   whereHelperstring
+	TargetBuyPrice         whereHelpertypes_NullDecimal
+	TargetSellPrice        whereHelpertypes_NullDecimal
+	IsActive               whereHelpernull_Bool
+	CollectIntervalMinutes whereHelperint
+	MutedUntil             whereHelpernull_Time
+	CreatedAt              whereHelpernull_Time
+	UpdatedAt              whereHelpernull_Time
+	DeletedAt              whereHelpernull_Time
 }{
-	ID:              whereHelperstring{field: "`watch_lists`.`id`"},
-	Code:            whereHelperstring{field: "`watch_lists`.`code`"},
-	Name:            whereHelperstring{field: "`watch_lists`.`name`"},
-	TargetBuyPrice:  whereHelpertypes_NullDecimal{field: "`watch_lists`.`target_buy_price`"},
-	TargetSellPrice: whereHelpertypes_NullDecimal{field: "`watch_lists`.`target_sell_price`"},
-	IsActive:        whereHelpernull_Bool{field: "`watch_lists`.`is_active`"},
-	CreatedAt:       whereHelpernull_Time{field: "`watch_lists`.`created_at`"},
-	UpdatedAt:       whereHelpernull_Time{field: "`watch_lists`.`updated_at`"},
+	ID:                     whereHelperstring{field: "`watch_lists`.`id`"},
+	Code:                   whereHelperstring{field: "`watch_lists`.`code`"},
+	Name:                   whereHelperstring{field: "`watch_lists`.`name`"},
+	TargetBuyPrice:         whereHelpertypes_NullDecimal{field: "`watch_lists`.`target_buy_price`"},
+	TargetSellPrice:        whereHelpertypes_NullDecimal{field: "`watch_lists`.`target_sell_price`"},
+	IsActive:               whereHelpernull_Bool{field: "`watch_lists`.`is_active`"},
+	CollectIntervalMinutes: whereHelperint{field: "`watch_lists`.`collect_interval_minutes`"},
+	MutedUntil:             whereHelpernull_Time{field: "`watch_lists`.`muted_until`"},
+	CreatedAt:              whereHelpernull_Time{field: "`watch_lists`.`created_at`"},
+	UpdatedAt:              whereHelpernull_Time{field: "`watch_lists`.`updated_at`"},
+	DeletedAt:              whereHelpernull_Time{field: "`watch_lists`.`deleted_at`"},
 }
 
 // WatchListRels is where relationship names are stored.
@@ -226,9 +250,9 @@ func (*watchListR) NewStruct() *watchListR {
 type watchListL struct{}
 
 var (
-	watchListAllColumns            = []string{"id", "code", "name", "target_buy_price", "target_sell_price", "is_active", "created_at", "updated_at"}
-	watchListColumnsWithoutDefault = []string{"id", "code", "name", "target_buy_price", "target_sell_price"}
-	watchListColumnsWithDefault    = []string{"is_active", "created_at", "updated_at"}
+	watchListAllColumns            = []string{"id", "code", "name", "target_buy_price", "target_sell_price", "is_active", "collect_interval_minutes", "muted_until", "created_at", "updated_at", "deleted_at"}
+	watchListColumnsWithoutDefault = []string{"id", "code", "name", "target_buy_price", "target_sell_price", "muted_until", "deleted_at"}
+	watchListColumnsWithDefault    = []string{"is_active", "collect_interval_minutes", "created_at", "updated_at"}
 	watchListPrimaryKeyColumns     = []string{"id"}
 	watchListGeneratedColumns      = []string{}
 )
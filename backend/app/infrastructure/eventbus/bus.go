@@ -0,0 +1,92 @@
+// Package eventbus provides an in-process event bus, on which use cases publish what happened
+// and subscribers such as notifications, logs and statistics react without the publishers
+// knowing about them.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// Handler handles an event delivered to a subscriber.
+type Handler func(ctx context.Context, event domain.Event) error
+
+// Publisher publishes events. Use cases depend on it rather than on Bus.
+type Publisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
+// subscription is a handler subscribed to an event, or to all events if eventName is empty.
+type subscription struct {
+	eventName  string
+	subscriber string
+	handler    Handler
+}
+
+// Bus delivers published events to the subscribers synchronously, in the order they subscribed.
+// Handlers run on the goroutine of the publisher, so they should return quickly and hand slow
+// work such as sending notifications over to a goroutine of their own. A failing or panicking
+// handler is logged and does not keep the event from the other subscribers.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+// New creates an event bus without subscribers.
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe subscribes a handler to the events with the given name.
+// The subscriber name identifies the handler in the logs.
+func (b *Bus) Subscribe(eventName, subscriber string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, subscription{eventName: eventName, subscriber: subscriber, handler: handler})
+}
+
+// SubscribeAll subscribes a handler to all events.
+func (b *Bus) SubscribeAll(subscriber string, handler Handler) {
+	b.Subscribe("", subscriber, handler)
+}
+
+// Publish delivers an event to its subscribers.
+func (b *Bus) Publish(ctx context.Context, event domain.Event) {
+	b.mu.RLock()
+	subscriptions := make([]subscription, 0, len(b.subscriptions))
+	for _, s := range b.subscriptions {
+		if s.eventName == "" || s.eventName == event.EventName() {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subscriptions {
+		if err := deliver(ctx, s.handler, event); err != nil {
+			logrus.Errorf("Event subscriber %s failed to handle %s: %v", s.subscriber, event.EventName(), err)
+		}
+	}
+}
+
+// deliver runs a handler, turning a panic into an error.
+func deliver(ctx context.Context, handler Handler, event domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+// Publish publishes an event on publisher, doing nothing if publisher is nil so that the
+// event bus stays optional for use cases.
+func Publish(ctx context.Context, publisher Publisher, event domain.Event) {
+	if publisher == nil {
+		return
+	}
+	publisher.Publish(ctx, event)
+}
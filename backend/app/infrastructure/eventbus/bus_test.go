@@ -0,0 +1,74 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBus_Publish(t *testing.T) {
+	ctx := context.Background()
+	bus := New()
+
+	var delivered []string
+	record := func(subscriber string) Handler {
+		return func(ctx context.Context, event domain.Event) error {
+			delivered = append(delivered, subscriber+" "+event.EventName())
+			return nil
+		}
+	}
+	bus.Subscribe(domain.EventPriceUpdated, "prices", record("prices"))
+	bus.Subscribe(domain.EventAlertSent, "failing", func(ctx context.Context, event domain.Event) error {
+		return errors.New("unavailable")
+	})
+	bus.Subscribe(domain.EventAlertSent, "panicking", func(ctx context.Context, event domain.Event) error {
+		panic("broken subscriber")
+	})
+	bus.SubscribeAll("all", record("all"))
+
+	bus.Publish(ctx, domain.PriceUpdatedEvent{Updated: []string{"7203"}})
+	bus.Publish(ctx, domain.AlertSentEvent{Message: "alert"})
+	bus.Publish(ctx, domain.SignalGeneratedEvent{Code: "7203", Action: "buy"})
+
+	// Failing and panicking subscribers do not keep the events from the others
+	want := []string{
+		"prices price.updated",
+		"all price.updated",
+		"all alert.sent",
+		"all signal.generated",
+	}
+	if diff := cmp.Diff(want, delivered); diff != "" {
+		t.Errorf("delivered events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPublish_NilPublisher(t *testing.T) {
+	// Use cases without an event bus publish nothing
+	Publish(context.Background(), nil, domain.PriceUpdatedEvent{})
+}
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	stats := NewStats()
+	stats.now = func() time.Time { return now }
+
+	bus := New()
+	bus.SubscribeAll("stats", stats.Record)
+	bus.Publish(ctx, domain.PriceUpdatedEvent{})
+	bus.Publish(ctx, domain.AlertSentEvent{})
+	now = now.Add(time.Minute)
+	bus.Publish(ctx, domain.PriceUpdatedEvent{})
+
+	want := []EventStats{
+		{Name: domain.EventAlertSent, Count: 1, LastPublished: now.Add(-time.Minute)},
+		{Name: domain.EventPriceUpdated, Count: 2, LastPublished: now},
+	}
+	if diff := cmp.Diff(want, stats.Snapshot()); diff != "" {
+		t.Errorf("Snapshot() mismatch (-want +got):\n%s", diff)
+	}
+}
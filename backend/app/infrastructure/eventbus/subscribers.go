@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// LogEvent logs events, subscribed to all events for tracing what happened in the application.
+func LogEvent(ctx context.Context, event domain.Event) error {
+	logrus.WithField("event", event.EventName()).Debugf("Event published: %+v", event)
+	return nil
+}
+
+// EventStats is the number of times an event was published and when it was last published.
+type EventStats struct {
+	Name          string    `json:"name"`
+	Count         int64     `json:"count"`
+	LastPublished time.Time `json:"last_published"`
+}
+
+// Stats counts the published events, subscribed to all events with its Record method.
+type Stats struct {
+	mu     sync.Mutex
+	events map[string]*EventStats
+	now    func() time.Time
+}
+
+// NewStats creates empty event statistics.
+func NewStats() *Stats {
+	return &Stats{
+		events: make(map[string]*EventStats),
+		now:    time.Now,
+	}
+}
+
+// Record counts an event.
+func (s *Stats) Record(ctx context.Context, event domain.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.events[event.EventName()]
+	if !ok {
+		stats = &EventStats{Name: event.EventName()}
+		s.events[event.EventName()] = stats
+	}
+	stats.Count++
+	stats.LastPublished = s.now()
+	return nil
+}
+
+// Snapshot returns the statistics of the events published so far, ordered by name.
+func (s *Stats) Snapshot() []EventStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make([]EventStats, 0, len(s.events))
+	for _, stats := range s.events {
+		snapshot = append(snapshot, *stats)
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Name < snapshot[j].Name })
+	return snapshot
+}
@@ -0,0 +1,218 @@
+// Package htmlreport renders reports as standalone HTML pages to be viewed in a browser.
+package htmlreport
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/infrastructure/pdf"
+)
+
+// Chart layout in SVG user units, scaled to the page width by the browser.
+const (
+	chartWidth  = 640.0
+	chartHeight = 160.0
+	axisWidth   = 70.0
+	labelHeight = 16.0
+)
+
+// Render writes the report, laid out like the PDF report, as an HTML page.
+// The page embeds its style and charts so that it needs no other requests.
+func Render(w io.Writer, report pdf.Report) error {
+	page := pageView{Title: report.Title, Subtitle: report.Subtitle}
+	for _, section := range report.Sections {
+		view := sectionView{Heading: section.Heading, Lines: section.Lines, Table: section.Table}
+		if section.Chart != nil && len(section.Chart.Values) > 0 {
+			view.Chart = newChartView(section.Chart)
+		}
+		page.Sections = append(page.Sections, view)
+	}
+
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, page); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
+
+type pageView struct {
+	Title    string
+	Subtitle string
+	Sections []sectionView
+}
+
+type sectionView struct {
+	Heading string
+	Lines   []string
+	Table   *pdf.Table
+	Chart   *chartView
+}
+
+// chartView holds the coordinates of a chart drawn in an SVG of chartWidth by chartHeight plus the labels.
+type chartView struct {
+	Width, Height float64
+	Left, Right   float64
+	Top, Bottom   float64
+	Ticks         []tickView
+	Bars          []barView
+	Points        string // polyline points of a line chart
+	Labels        []labelView
+}
+
+type tickView struct {
+	Y     float64
+	Label string
+}
+
+type barView struct {
+	X, Y, Width, Height float64
+	Negative            bool
+	Title               string
+}
+
+type labelView struct {
+	X     float64
+	Label string
+}
+
+// newChartView scales the values of a chart to the SVG coordinates, as the PDF report draws it.
+func newChartView(chart *pdf.Chart) *chartView {
+	format := chart.Format
+	if format == nil {
+		format = func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	}
+
+	view := &chartView{Width: chartWidth, Height: chartHeight + labelHeight, Left: axisWidth, Right: chartWidth, Top: 8, Bottom: chartHeight}
+	top, bottom := view.Top, view.Bottom
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range chart.Values {
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	if chart.Kind == pdf.BarChart {
+		low, high = math.Min(low, 0), math.Max(high, 0)
+	}
+	if high == low {
+		low, high = low-1, high+1
+	}
+	scale := func(v float64) float64 {
+		return bottom - (v-low)/(high-low)*(bottom-top)
+	}
+
+	view.Ticks = []tickView{{scale(high), format(high)}, {scale(low), format(low)}}
+	if low < 0 && high > 0 {
+		view.Ticks = append(view.Ticks, tickView{scale(0), format(0)})
+	}
+
+	n := len(chart.Values)
+	slot := (view.Right - view.Left) / float64(n)
+	x := func(i int) float64 {
+		if chart.Kind == pdf.LineChart && n > 1 {
+			return view.Left + (view.Right-view.Left)*float64(i)/float64(n-1)
+		}
+		return view.Left + slot*(float64(i)+0.5)
+	}
+	label := func(i int) string {
+		if i < len(chart.Labels) {
+			return chart.Labels[i]
+		}
+		return ""
+	}
+
+	switch chart.Kind {
+	case pdf.BarChart:
+		zero := scale(0)
+		for i, v := range chart.Values {
+			y := scale(v)
+			view.Bars = append(view.Bars, barView{
+				X:        x(i) - slot*0.3,
+				Y:        math.Min(y, zero),
+				Width:    slot * 0.6,
+				Height:   math.Abs(zero - y),
+				Negative: v < 0,
+				Title:    label(i) + ": " + format(v),
+			})
+		}
+	case pdf.LineChart:
+		points := make([]string, n)
+		for i, v := range chart.Values {
+			points[i] = fmt.Sprintf("%.1f,%.1f", x(i), scale(v))
+		}
+		view.Points = strings.Join(points, " ")
+	}
+
+	// Labels, thinned out so that about 12 fit in the width
+	step := max(int(math.Ceil(float64(n)/12)), 1)
+	for i := 0; i < n; i += step {
+		view.Labels = append(view.Labels, labelView{X: x(i), Label: label(i)})
+	}
+	return view
+}
+
+var pageTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"right": func(table *pdf.Table, column int) bool {
+		return column < len(table.AlignRight) && table.AlignRight[column]
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex, nofollow">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 0 auto; max-width: 760px; padding: 16px; color: #222; }
+h1 { font-size: 1.4em; margin-bottom: 0; }
+.subtitle { color: #777; font-size: 0.85em; margin-top: 4px; }
+h2 { font-size: 1.1em; border-bottom: 1px solid #ddd; padding-bottom: 4px; margin-top: 28px; }
+p { margin: 2px 0; }
+.table { overflow-x: auto; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+th { background: #eee; text-align: left; }
+th, td { padding: 4px 6px; border-bottom: 1px solid #eee; white-space: nowrap; }
+.right { text-align: right; }
+svg { width: 100%; height: auto; font-size: 10px; }
+.grid { stroke: #e5e5e5; }
+.axis { stroke: #888; }
+.tick, .label { fill: #777; }
+.bar { fill: #21a045; }
+.bar.negative { fill: #cc3333; }
+.line { fill: none; stroke: #3366bf; stroke-width: 1.5; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Subtitle}}<p class="subtitle">{{.Subtitle}}</p>{{end}}
+{{range .Sections}}
+<section>
+<h2>{{.Heading}}</h2>
+{{range .Lines}}<p>{{if .}}{{.}}{{else}}&nbsp;{{end}}</p>
+{{end}}
+{{with .Table}}{{$table := .}}<div class="table"><table>
+<tr>{{range $i, $h := .Headers}}<th{{if right $table $i}} class="right"{{end}}>{{$h}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range $i, $cell := .}}<td{{if right $table $i}} class="right"{{end}}>{{$cell}}</td>{{end}}</tr>
+{{end}}</table></div>
+{{end}}
+{{with .Chart}}{{$chart := .}}<svg viewBox="0 0 {{.Width}} {{.Height}}" role="img">
+{{range .Ticks}}<line class="grid" x1="{{$chart.Left}}" y1="{{.Y}}" x2="{{$chart.Right}}" y2="{{.Y}}"/><text class="tick" x="{{$chart.Left}}" y="{{.Y}}" dx="-4" dy="3" text-anchor="end">{{.Label}}</text>
+{{end}}<line class="axis" x1="{{.Left}}" y1="{{.Top}}" x2="{{.Left}}" y2="{{.Bottom}}"/>
+
+{{range .Bars}}<rect class="bar{{if .Negative}} negative{{end}}" x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}"><title>{{.Title}}</title></rect>
+{{end}}
+{{if .Points}}<polyline class="line" points="{{.Points}}"/>{{end}}
+{{range .Labels}}<text class="label" x="{{.X}}" y="{{$chart.Height}}" dy="-3" text-anchor="middle">{{.Label}}</text>
+{{end}}
+</svg>
+{{end}}
+</section>
+{{end}}
+</body>
+</html>
+`))
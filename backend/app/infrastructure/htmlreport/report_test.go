@@ -0,0 +1,51 @@
+package htmlreport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/infrastructure/pdf"
+)
+
+func TestRender(t *testing.T) {
+	report := pdf.Report{
+		Title:    "日次レポート",
+		Subtitle: "2024-03-01 15:30:00",
+		Sections: []pdf.Section{
+			{Heading: "サマリー", Lines: []string{"評価額: 1,000,000円"}},
+			{Heading: "保有銘柄", Table: &pdf.Table{
+				Headers:    []string{"コード", "銘柄名", "損益"},
+				Rows:       [][]string{{"7203", "<script>alert(1)</script>", "+10,000"}},
+				AlignRight: []bool{false, false, true},
+			}},
+			{Heading: "損益", Chart: &pdf.Chart{Kind: pdf.BarChart, Labels: []string{"7203", "6758"}, Values: []float64{10000, -5000}}},
+			{Heading: "評価額の推移", Chart: &pdf.Chart{Kind: pdf.LineChart, Labels: []string{"3/1", "3/2"}, Values: []float64{100, 110}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	page := buf.String()
+
+	for _, want := range []string{
+		"<title>日次レポート</title>",
+		"評価額: 1,000,000円",
+		`<td class="right">&#43;10,000</td>`,
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+		`class="bar negative"`,
+		`<polyline class="line"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Render() page does not contain %q", want)
+		}
+	}
+	if strings.Contains(page, "<script>") {
+		t.Error("Render() did not escape the table cells")
+	}
+	if got := strings.Count(page, "<rect "); got != 2 {
+		t.Errorf("Render() drew %d bars, want 2", got)
+	}
+}
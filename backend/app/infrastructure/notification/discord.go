@@ -0,0 +1,361 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/sirupsen/logrus"
+)
+
+// Limits of a Discord webhook message.
+const (
+	discordMaxContent = 2000
+	discordMaxEmbeds  = 10
+	discordMaxFields  = 25
+)
+
+// Embed colors matching the Slack attachment colors.
+const (
+	discordColorGood    = 0x2EB67D
+	discordColorDanger  = 0xE01E5A
+	discordColorWarning = 0xECB22E
+	discordColorInfo    = 0x36C5F0
+)
+
+// DiscordNotifier sends notifications to a Discord channel through a webhook, with embeds for rich formatting.
+type DiscordNotifier struct {
+	webhookURL string
+	username   string
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+}
+
+type DiscordMessage struct {
+	Content  string         `json:"content,omitempty"`
+	Username string         `json:"username,omitempty"`
+	Embeds   []DiscordEmbed `json:"embeds,omitempty"`
+}
+
+type DiscordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color,omitempty"`
+	Fields      []DiscordField `json:"fields,omitempty"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+}
+
+type DiscordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// NewDiscordNotifier creates a Discord notifier posting to the webhook URL as the username.
+func NewDiscordNotifier(webhookURL, username string) *DiscordNotifier {
+	if webhookURL == "" {
+		logrus.Warn("Discord webhook URL not set")
+	}
+
+	return &DiscordNotifier{
+		webhookURL: webhookURL,
+		username:   username,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		maxRetries: 3,
+		retryDelay: 2 * time.Second,
+	}
+}
+
+func (d *DiscordNotifier) SendMessage(ctx context.Context, message string) error {
+	return d.SendMessageOfKind(ctx, KindGeneral, message)
+}
+
+// SendMessageOfKind sends a plain text message, split into several messages if it exceeds the Discord limit
+func (d *DiscordNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	for _, chunk := range splitMessage(message, discordMaxContent) {
+		if err := d.send(ctx, DiscordMessage{Content: chunk}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DiscordNotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	color := discordColorWarning
+	if alertType == "buy" {
+		color = discordColorGood
+	} else if alertType == "sell" {
+		color = discordColorDanger
+	}
+
+	msg := DiscordMessage{
+		Content: "🔔 " + i18n.T("slack.stock_alert.title", stockName, stockCode),
+		Embeds: []DiscordEmbed{
+			{
+				Title: i18n.T("slack.stock_alert.type", alertType),
+				Color: color,
+				Fields: []DiscordField{
+					{Name: i18n.T("slack.field.current_price"), Value: fmt.Sprintf("¥%.2f", currentPrice), Inline: true},
+					{Name: i18n.T("slack.field.target_price"), Value: fmt.Sprintf("¥%.2f", targetPrice), Inline: true},
+					{Name: i18n.T("slack.field.deviation"), Value: fmt.Sprintf("%.2f%%", (currentPrice-targetPrice)/targetPrice*100), Inline: true},
+				},
+				Timestamp: time.Now().Format(time.RFC3339),
+			},
+		},
+	}
+
+	return d.send(ctx, msg)
+}
+
+func (d *DiscordNotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	color := discordColorGood
+	if totalGain < 0 {
+		color = discordColorDanger
+	}
+
+	msg := DiscordMessage{
+		Content: "📊 " + i18n.T("slack.daily_report.title"),
+		Embeds: []DiscordEmbed{
+			{
+				Title: i18n.T("slack.daily_report.portfolio"),
+				Color: color,
+				Fields: []DiscordField{
+					{Name: i18n.T("slack.field.total_value"), Value: fmt.Sprintf("¥%.2f", totalValue), Inline: true},
+					{Name: i18n.T("slack.field.gain"), Value: fmt.Sprintf("¥%.2f", totalGain), Inline: true},
+					{Name: i18n.T("slack.field.gain_percent"), Value: fmt.Sprintf("%.2f%%", gainPercent), Inline: true},
+				},
+				Timestamp: time.Now().Format(time.RFC3339),
+			},
+		},
+	}
+
+	return d.send(ctx, msg)
+}
+
+// SendComprehensiveReport sends the daily report as a summary embed followed by holdings embeds,
+// in the same layout as the Slack report. Holdings beyond the embed limits are left out.
+func (d *DiscordNotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	if d.webhookURL == "" {
+		return nil
+	}
+
+	color := discordColorGood
+	if summary.TotalGain < 0 {
+		color = discordColorDanger
+	}
+
+	embeds := []DiscordEmbed{
+		{
+			Title: "📊 " + i18n.T("slack.comprehensive.summary"),
+			Color: color,
+			Fields: []DiscordField{
+				{Name: i18n.T("slack.field.total_value"), Value: fmt.Sprintf("¥%.0f", summary.TotalValue), Inline: true},
+				{Name: i18n.T("slack.field.total_cost"), Value: fmt.Sprintf("¥%.0f", summary.TotalCost), Inline: true},
+				{Name: i18n.T("slack.field.gain"), Value: fmt.Sprintf("¥%.0f", summary.TotalGain), Inline: true},
+				{Name: i18n.T("slack.field.gain_percent"), Value: fmt.Sprintf("%.2f%%", summary.TotalGainPercent), Inline: true},
+			},
+			Timestamp: summary.UpdatedAt.Format(time.RFC3339),
+		},
+	}
+
+	if len(summary.Allocations) > 1 {
+		embeds[0].Fields = append(embeds[0].Fields, DiscordField{
+			Name:  i18n.T("portfolio.allocation_section"),
+			Value: strings.Join(domain.FormatAssetAllocations(summary.Allocations), "\n"),
+		})
+	}
+	if summary.BuyingPower != nil {
+		embeds[0].Fields = append(embeds[0].Fields, DiscordField{
+			Name:  i18n.T("buying_power.section"),
+			Value: strings.Join(domain.FormatBuyingPowerLines(*summary.BuyingPower), "\n"),
+		})
+	}
+
+	// Holdings are split into embeds of at most 25 fields, unless turned off
+	shown := summary.Holdings
+	if summary.HideHoldings {
+		shown = nil
+	}
+	var holdings *DiscordEmbed
+	for _, holding := range shown {
+		if holdings == nil || len(holdings.Fields) == discordMaxFields {
+			if len(embeds) == discordMaxEmbeds {
+				logrus.Warnf("Discord report truncated to %d holdings", (discordMaxEmbeds-1)*discordMaxFields)
+				break
+			}
+			embeds = append(embeds, DiscordEmbed{
+				Title: "📈 " + i18n.T("slack.comprehensive.holdings"),
+				Color: discordColorInfo,
+			})
+			holdings = &embeds[len(embeds)-1]
+		}
+
+		holdingColor := "🟢"
+		if holding.Gain < 0 {
+			holdingColor = "🔴"
+		}
+		holdings.Fields = append(holdings.Fields, DiscordField{
+			Name: fmt.Sprintf("%s %s (%s)", holdingColor, holding.Name, holding.Code),
+			Value: i18n.T("slack.comprehensive.holding",
+				holding.FormatQuantity(), holding.CurrentPrice, holding.Gain, holding.GainPercent),
+		})
+	}
+
+	// The technical summaries, contribution rankings and goal progress take one more embed each
+	// if there is room left
+	if len(summary.Technicals) > 0 && len(embeds) < discordMaxEmbeds {
+		lines := make([]string, 0, len(summary.Technicals))
+		for _, technical := range summary.Technicals {
+			lines = append(lines, domain.FormatTechnicalLine(technical))
+		}
+		embeds = append(embeds, DiscordEmbed{
+			Title:       i18n.T("technical_summary.section"),
+			Description: strings.Join(lines, "\n"),
+			Color:       discordColorInfo,
+		})
+	}
+	if summary.Contributions != nil && len(embeds) < discordMaxEmbeds {
+		contributions := DiscordEmbed{
+			Title: i18n.T("contribution.section"),
+			Color: discordColorInfo,
+		}
+		for _, ranking := range domain.FormatContributionRankings(*summary.Contributions) {
+			contributions.Fields = append(contributions.Fields, DiscordField{
+				Name:  ranking.Title,
+				Value: strings.Join(ranking.Lines, "\n"),
+			})
+		}
+		if len(contributions.Fields) > 0 {
+			embeds = append(embeds, contributions)
+		}
+	}
+	if len(summary.Goals) > 0 && len(embeds) < discordMaxEmbeds {
+		goals := DiscordEmbed{
+			Title: i18n.T("goal.section"),
+			Color: discordColorWarning,
+		}
+		for i, goal := range summary.Goals {
+			if i == discordMaxFields {
+				break
+			}
+			goals.Fields = append(goals.Fields, DiscordField{
+				Name:  domain.FormatGoalLine(goal),
+				Value: domain.FormatGoalDetail(goal),
+			})
+		}
+		embeds = append(embeds, goals)
+	}
+
+	msg := DiscordMessage{
+		Content: "📊 " + i18n.T("slack.comprehensive.title"),
+		Embeds:  embeds,
+	}
+
+	return d.send(ctx, msg)
+}
+
+// send posts a message to the webhook, retrying on network errors, 429 and 5xx responses.
+// A 429 response is retried after the time Discord asks for.
+func (d *DiscordNotifier) send(ctx context.Context, msg DiscordMessage) error {
+	if d.webhookURL == "" {
+		logrus.Debug("Discord webhook URL not configured, skipping notification")
+		return nil
+	}
+	msg.Username = d.username
+
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	policy := newRetryPolicy("Discord", d.maxRetries, d.retryDelay)
+	attempts, err := policy.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", d.webhookURL, bytes.NewReader(jsonData))
+		if err != nil {
+			return retry.Permanent(fmt.Errorf("failed to create request: %w", err))
+		}
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("User-Agent", "Stock-Automation/1.0")
+
+		resp, err := d.client.Do(req)
+		if err != nil {
+			logrus.WithField("error", err).Error("Failed to send Discord notification")
+			return fmt.Errorf("failed to send message: %w", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			logrus.Info("Successfully sent Discord notification")
+			return nil
+		}
+
+		logrus.WithField("status_code", resp.StatusCode).Error("Discord API returned non-OK status")
+		err = fmt.Errorf("discord API returned status code: %d", resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return retry.After(err, discordRetryAfter(body, d.retryDelay))
+		}
+		return classifyHTTPStatus(resp, err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send Discord notification after %d attempts: %w", attempts, err)
+	}
+	return nil
+}
+
+// discordRetryAfter reads the seconds to wait from a rate limited response, or returns fallback.
+func discordRetryAfter(body []byte, fallback time.Duration) time.Duration {
+	var rateLimit struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if err := json.Unmarshal(body, &rateLimit); err != nil || rateLimit.RetryAfter <= 0 {
+		return fallback
+	}
+	return time.Duration(rateLimit.RetryAfter * float64(time.Second))
+}
+
+// splitMessage splits a message into chunks of at most limit characters, at line breaks where possible.
+func splitMessage(message string, limit int) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+
+	flush := func() {
+		if current.Len() > 0 {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+	}
+
+	for _, line := range strings.SplitAfter(message, "\n") {
+		runes := []rune(line)
+		if currentLen+len(runes) > limit {
+			flush()
+		}
+		// A line longer than the limit is cut into pieces
+		for len(runes) > limit {
+			chunks = append(chunks, string(runes[:limit]))
+			runes = runes[limit:]
+		}
+		current.WriteString(string(runes))
+		currentLen += len(runes)
+	}
+	flush()
+
+	if len(chunks) == 0 {
+		return []string{message}
+	}
+	return chunks
+}
@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDiscordNotifier(url string) *DiscordNotifier {
+	return &DiscordNotifier{
+		webhookURL: url,
+		username:   "Stock Bot",
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries: 3,
+		retryDelay: 100 * time.Millisecond,
+	}
+}
+
+func TestDiscordNotifier_SendComprehensiveReport(t *testing.T) {
+	var msg DiscordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json; charset=utf-8", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	summary := &domain.PortfolioSummary{
+		TotalValue: 900000,
+		TotalCost:  1000000,
+		TotalGain:  -100000,
+		UpdatedAt:  time.Now(),
+	}
+	for i := 0; i < 30; i++ {
+		summary.Holdings = append(summary.Holdings, domain.HoldingSummary{
+			Code: fmt.Sprintf("%d", 1000+i),
+			Name: "Stock",
+			Gain: float64(i - 15),
+		})
+	}
+
+	err := newTestDiscordNotifier(server.URL).SendComprehensiveReport(context.Background(), "report", summary)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Stock Bot", msg.Username)
+	// A summary embed and the holdings split by the 25 fields limit
+	if assert.Len(t, msg.Embeds, 3) {
+		assert.Equal(t, discordColorDanger, msg.Embeds[0].Color)
+		assert.Len(t, msg.Embeds[0].Fields, 4)
+		assert.Len(t, msg.Embeds[1].Fields, 25)
+		assert.Len(t, msg.Embeds[2].Fields, 5)
+		assert.True(t, strings.HasPrefix(msg.Embeds[1].Fields[0].Name, "🔴"))
+		assert.True(t, strings.HasPrefix(msg.Embeds[2].Fields[4].Name, "🟢"))
+	}
+}
+
+func TestDiscordNotifier_RateLimit(t *testing.T) {
+	attempts := 0
+	var retriedAfter time.Duration
+	var lastAttempt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			lastAttempt = time.Now()
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.3, "global": false}`))
+			return
+		}
+		retriedAfter = time.Since(lastAttempt)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := newTestDiscordNotifier(server.URL).SendMessage(context.Background(), "Test message")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.GreaterOrEqual(t, retriedAfter, 300*time.Millisecond)
+}
+
+func TestDiscordNotifier_ClientError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := newTestDiscordNotifier(server.URL).SendMessage(context.Background(), "Test message")
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts) // An unknown webhook is not retried
+}
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		limit   int
+		want    []string
+	}{
+		{"Short message", "hello", 10, []string{"hello"}},
+		{"Split at line breaks", "line1\nline2\nline3", 12, []string{"line1\nline2\n", "line3"}},
+		{"Long line is cut", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"Multibyte characters", "株価通知テスト", 3, []string{"株価通", "知テス", "ト"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitMessage(tt.message, tt.limit))
+		})
+	}
+}
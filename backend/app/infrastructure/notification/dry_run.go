@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+)
+
+// DryRunMessage is a message the dry run notifier would have sent, with its message kind.
+type DryRunMessage struct {
+	Kind MessageKind `json:"kind"`
+	SlackMessage
+}
+
+// DryRunNotifier writes the Slack messages it is asked to send to a writer as indented JSON
+// instead of sending them, so that a report can be checked before it is sent.
+type DryRunNotifier struct {
+	mu    sync.Mutex
+	w     io.Writer
+	count int
+}
+
+// NewDryRunNotifier creates a notification service writing the messages to w.
+func NewDryRunNotifier(w io.Writer) *DryRunNotifier {
+	return &DryRunNotifier{w: w}
+}
+
+// Count returns the number of messages written.
+func (d *DryRunNotifier) Count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.count
+}
+
+func (d *DryRunNotifier) SendMessage(ctx context.Context, message string) error {
+	return d.SendMessageOfKind(ctx, KindGeneral, message)
+}
+
+func (d *DryRunNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	return d.write(kind, SlackMessage{Text: message})
+}
+
+func (d *DryRunNotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	return d.write(KindCritical, stockAlertMessage(stockCode, stockName, currentPrice, targetPrice, alertType))
+}
+
+func (d *DryRunNotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	return d.write(KindReport, dailyReportMessage(totalValue, totalGain, gainPercent))
+}
+
+// SendComprehensiveReport writes the comprehensive daily report as the Slack notifier would send it.
+func (d *DryRunNotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	return d.write(KindReport, comprehensiveReportMessage(summary))
+}
+
+// write writes a message as indented JSON followed by a newline.
+func (d *DryRunNotifier) write(kind MessageKind, msg SlackMessage) error {
+	data, err := json.MarshalIndent(DryRunMessage{Kind: kind, SlackMessage: msg}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := fmt.Fprintf(d.w, "%s\n", data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	d.count++
+	return nil
+}
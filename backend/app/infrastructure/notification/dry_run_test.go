@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDryRunNotifier_SendComprehensiveReport(t *testing.T) {
+	var sent SlackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Errorf("invalid Slack payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	summary := &domain.PortfolioSummary{
+		TotalValue:       1500000,
+		TotalCost:        1400000,
+		TotalGain:        100000,
+		TotalGainPercent: 7.14,
+		Holdings: []domain.HoldingSummary{
+			{Code: "7203", Name: "Toyota", Shares: 100, CurrentPrice: 2500, CurrentValue: 250000, Gain: -10000, GainPercent: -3.85},
+		},
+	}
+
+	slack := &SlackNotifier{webhookURL: server.URL, client: &http.Client{Timeout: 10 * time.Second}, maxRetries: 1}
+	if err := slack.SendComprehensiveReport(context.Background(), "report", summary); err != nil {
+		t.Fatalf("SendComprehensiveReport() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	dryRun := NewDryRunNotifier(&buf)
+	if err := dryRun.SendComprehensiveReport(context.Background(), "report", summary); err != nil {
+		t.Fatalf("SendComprehensiveReport() error = %v", err)
+	}
+
+	var previewed DryRunMessage
+	if err := json.Unmarshal(buf.Bytes(), &previewed); err != nil {
+		t.Fatalf("dry run output is not JSON: %v\n%s", err, buf.String())
+	}
+	if previewed.Kind != KindReport {
+		t.Errorf("Kind = %q, want %q", previewed.Kind, KindReport)
+	}
+	// The preview is the payload the Slack notifier sends
+	if diff := cmp.Diff(sent, previewed.SlackMessage); diff != "" {
+		t.Errorf("preview mismatch (-sent +previewed):\n%s", diff)
+	}
+	if dryRun.Count() != 1 {
+		t.Errorf("Count() = %d, want 1", dryRun.Count())
+	}
+}
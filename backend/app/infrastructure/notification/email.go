@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// EmailSender sends emails with attachments, such as PDF reports, through an SMTP server.
+// It uses STARTTLS when the server supports it and authenticates when a username is set.
+type EmailSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+// NewEmailSender creates an email sender sending from the address to the recipients.
+func NewEmailSender(host string, port int, username, password, from string, to []string) *EmailSender {
+	return &EmailSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+		sendMail: smtp.SendMail,
+		now:      time.Now,
+	}
+}
+
+// Send sends an email with the plain text body and the attachments to the recipients.
+func (e *EmailSender) Send(ctx context.Context, subject, body string, attachments ...Attachment) error {
+	if len(e.to) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if e.username != "" {
+		auth = smtp.PlainAuth("", e.username, e.password, e.host)
+	}
+
+	addr := net.JoinHostPort(e.host, strconv.Itoa(e.port))
+	if err := e.sendMail(addr, auth, e.from, e.to, e.buildMessage(subject, body, attachments)); err != nil {
+		return fmt.Errorf("failed to send email via %s: %w", addr, err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"subject":     subject,
+		"recipients":  len(e.to),
+		"attachments": len(attachments),
+	}).Info("Email sent")
+	return nil
+}
+
+// buildMessage builds a MIME multipart message with the body and the base64 encoded attachments.
+func (e *EmailSender) buildMessage(subject, body string, attachments []Attachment) []byte {
+	boundary := fmt.Sprintf("stock-automation-%d", e.now().UnixNano())
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", e.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", e.now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	writeBase64Lines(&buf, []byte(body))
+
+	for _, attachment := range attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		fmt.Fprintf(&buf, "Content-Type: %s\r\n", contentType)
+		buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+		fmt.Fprintf(&buf, "Content-Disposition: attachment; filename=%q\r\n\r\n", attachment.Filename)
+		writeBase64Lines(&buf, attachment.Data)
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes()
+}
+
+// writeBase64Lines writes the data in base64 split into lines of 76 characters as required by MIME.
+func writeBase64Lines(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+}
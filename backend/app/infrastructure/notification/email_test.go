@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailSender_Send(t *testing.T) {
+	sender := NewEmailSender("smtp.example.com", 587, "user", "secret", "bot@example.com", []string{"a@example.com", "b@example.com"})
+	sender.now = func() time.Time { return time.Date(2024, 6, 7, 15, 30, 0, 0, time.UTC) }
+
+	var (
+		gotAddr string
+		gotAuth smtp.Auth
+		gotTo   []string
+		gotMsg  []byte
+	)
+	sender.sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotTo, gotMsg = addr, auth, to, msg
+		return nil
+	}
+
+	pdf := bytes.Repeat([]byte("%PDF-1.4 report "), 20)
+	err := sender.Send(context.Background(), "月次レポート", "レポートを添付します", Attachment{
+		Filename:    "report.pdf",
+		ContentType: "application/pdf",
+		Data:        pdf,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.NotNil(t, gotAuth)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, gotTo)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(gotMsg))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "月次レポート", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	body, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=UTF-8", body.Header.Get("Content-Type"))
+
+	attachment, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "report.pdf", attachment.FileName())
+	assert.Equal(t, "application/pdf", attachment.Header.Get("Content-Type"))
+	// multipart.Reader decodes only quoted-printable, so the base64 is decoded here (line breaks are ignored)
+	encoded, err := io.ReadAll(attachment)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(encoded)))
+	require.NoError(t, err)
+	assert.Equal(t, pdf, decoded)
+}
+
+func TestEmailSender_SendWithoutRecipients(t *testing.T) {
+	sender := NewEmailSender("smtp.example.com", 25, "", "", "bot@example.com", nil)
+	sender.sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		t.Fatal("sendMail should not be called")
+		return nil
+	}
+
+	assert.Error(t, sender.Send(context.Background(), "subject", "body"))
+}
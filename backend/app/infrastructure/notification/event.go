@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/eventbus"
+)
+
+// EventNotifier publishes an AlertSentEvent for each alert sent successfully through inner,
+// that is each critical message and stock alert. Reports and other messages are not published.
+type EventNotifier struct {
+	inner     NotificationService
+	publisher eventbus.Publisher
+	now       func() time.Time
+}
+
+// NewEventNotifier creates a notification service publishing the alerts sent through inner.
+func NewEventNotifier(inner NotificationService, publisher eventbus.Publisher) *EventNotifier {
+	return &EventNotifier{
+		inner:     inner,
+		publisher: publisher,
+		now:       time.Now,
+	}
+}
+
+func (e *EventNotifier) SendMessage(ctx context.Context, message string) error {
+	return e.inner.SendMessage(ctx, message)
+}
+
+// SendMessageOfKind publishes critical messages once sent.
+func (e *EventNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	if err := e.inner.SendMessageOfKind(ctx, kind, message); err != nil {
+		return err
+	}
+	if kind == KindCritical {
+		e.publisher.Publish(ctx, domain.AlertSentEvent{Kind: string(kind), Message: message, At: e.now()})
+	}
+	return nil
+}
+
+// SendStockAlert publishes stock alerts once sent.
+func (e *EventNotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	if err := e.inner.SendStockAlert(ctx, stockCode, stockName, currentPrice, targetPrice, alertType); err != nil {
+		return err
+	}
+	e.publisher.Publish(ctx, domain.AlertSentEvent{
+		Kind:    string(KindCritical),
+		Code:    stockCode,
+		Message: fmt.Sprintf("%s (%s) %s ¥%.2f / ¥%.2f", stockName, stockCode, alertType, currentPrice, targetPrice),
+		At:      e.now(),
+	})
+	return nil
+}
+
+func (e *EventNotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	return e.inner.SendDailyReport(ctx, totalValue, totalGain, gainPercent)
+}
+
+// SendComprehensiveReport sends the report through the inner notifier, as a plain report if it has no support for it.
+func (e *EventNotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	if sender, ok := e.inner.(ComprehensiveReportSender); ok {
+		return sender.SendComprehensiveReport(ctx, report, summary)
+	}
+	return e.inner.SendMessageOfKind(ctx, KindReport, report)
+}
@@ -1,13 +1,38 @@
 package notification
 
+import (
+	"context"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+)
+
 // NotificationService defines the interface for notification services.
 type NotificationService interface {
 	// SendMessage sends a plain text message
-	SendMessage(message string) error
+	SendMessage(ctx context.Context, message string) error
+
+	// SendMessageOfKind sends a plain text message to the destination of the message kind
+	SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error
 
 	// SendStockAlert sends a stock price alert
-	SendStockAlert(stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error
+	SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error
 
 	// SendDailyReport sends a daily portfolio report
-	SendDailyReport(totalValue, totalGain float64, gainPercent float64) error
+	SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error
+}
+
+// ComprehensiveReportSender is implemented by notification services that send the daily report
+// together with the portfolio summary, e.g. as a formatted message or structured data.
+type ComprehensiveReportSender interface {
+	SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error
+}
+
+// MessageUpdater is implemented by notification services that can edit a message after posting it,
+// e.g. to turn the status message of a running job into its result.
+type MessageUpdater interface {
+	// PostMessage posts a plain text message to the destination of the message kind and returns it
+	PostMessage(ctx context.Context, kind MessageKind, message string) (MessageRef, error)
+
+	// UpdateMessage replaces the text of a posted message
+	UpdateMessage(ctx context.Context, ref MessageRef, message string) error
 }
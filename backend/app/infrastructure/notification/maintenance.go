@@ -0,0 +1,109 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// Alert types recorded for suppressed alerts.
+const (
+	SuppressedStockAlert = "stock_alert"
+	SuppressedMessage    = "message"
+)
+
+// SuppressionStore looks up maintenance windows and records the alerts suppressed during them.
+type SuppressionStore interface {
+	GetActiveWindow(ctx context.Context, at time.Time) (*models.MaintenanceWindow, error)
+	SaveSuppressedAlert(ctx context.Context, alert *models.SuppressedAlert) error
+}
+
+// MaintenanceNotifier holds back alerts while a maintenance window is active.
+// Suppressed alerts are only recorded, to be sent later as a digest; reports and other messages pass through.
+// If the maintenance window cannot be checked, alerts are sent as usual.
+type MaintenanceNotifier struct {
+	inner NotificationService
+	store SuppressionStore
+	now   func() time.Time
+}
+
+// NewMaintenanceNotifier creates a notification service suppressing the alerts of inner during maintenance windows.
+func NewMaintenanceNotifier(inner NotificationService, store SuppressionStore) *MaintenanceNotifier {
+	return &MaintenanceNotifier{
+		inner: inner,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+func (m *MaintenanceNotifier) SendMessage(ctx context.Context, message string) error {
+	return m.inner.SendMessage(ctx, message)
+}
+
+// SendMessageOfKind suppresses critical messages during a maintenance window.
+func (m *MaintenanceNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	if kind == KindCritical && m.suppress(ctx, SuppressedMessage, message) {
+		return nil
+	}
+	return m.inner.SendMessageOfKind(ctx, kind, message)
+}
+
+// SendStockAlert suppresses stock alerts during a maintenance window.
+func (m *MaintenanceNotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	message := fmt.Sprintf("%s %s %s ¥%.2f / %s ¥%.2f",
+		i18n.T("slack.stock_alert.title", stockName, stockCode),
+		i18n.T("slack.stock_alert.type", alertType),
+		i18n.T("slack.field.current_price"), currentPrice,
+		i18n.T("slack.field.target_price"), targetPrice)
+	if m.suppress(ctx, SuppressedStockAlert, message) {
+		return nil
+	}
+	return m.inner.SendStockAlert(ctx, stockCode, stockName, currentPrice, targetPrice, alertType)
+}
+
+func (m *MaintenanceNotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	return m.inner.SendDailyReport(ctx, totalValue, totalGain, gainPercent)
+}
+
+// SendComprehensiveReport sends the report through the inner notifier, as a plain report if it has no support for it.
+func (m *MaintenanceNotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	if sender, ok := m.inner.(ComprehensiveReportSender); ok {
+		return sender.SendComprehensiveReport(ctx, report, summary)
+	}
+	return m.inner.SendMessageOfKind(ctx, KindReport, report)
+}
+
+// suppress records the alert and reports true if a maintenance window is active.
+func (m *MaintenanceNotifier) suppress(ctx context.Context, alertType, message string) bool {
+	now := m.now()
+	window, err := m.store.GetActiveWindow(ctx, now)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to check maintenance window, sending alert")
+		return false
+	}
+	if window == nil {
+		return false
+	}
+
+	alert := &models.SuppressedAlert{
+		MaintenanceWindowID: window.ID,
+		AlertType:           alertType,
+		Message:             message,
+		SuppressedAt:        now,
+	}
+	if err := m.store.SaveSuppressedAlert(ctx, alert); err != nil {
+		logrus.WithError(err).Warn("Failed to record suppressed alert, sending alert")
+		return false
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"maintenance_window_id": window.ID,
+		"alert_type":            alertType,
+	}).Info("Alert suppressed during maintenance")
+	return true
+}
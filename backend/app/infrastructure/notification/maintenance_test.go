@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSuppressionStore returns window as the active window and records the suppressed alerts.
+type fakeSuppressionStore struct {
+	window *models.MaintenanceWindow
+	err    error
+	alerts []*models.SuppressedAlert
+}
+
+func (f *fakeSuppressionStore) GetActiveWindow(ctx context.Context, at time.Time) (*models.MaintenanceWindow, error) {
+	if f.window != nil && !f.window.IsActive(at) {
+		return nil, f.err
+	}
+	return f.window, f.err
+}
+
+func (f *fakeSuppressionStore) SaveSuppressedAlert(ctx context.Context, alert *models.SuppressedAlert) error {
+	f.alerts = append(f.alerts, alert)
+	return nil
+}
+
+func TestMaintenanceNotifier(t *testing.T) {
+	now := time.Date(2024, 6, 5, 20, 0, 0, 0, time.Local)
+	active := &models.MaintenanceWindow{ID: "window", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+
+	tests := []struct {
+		name           string
+		store          *fakeSuppressionStore
+		kind           MessageKind
+		wantSent       int
+		wantSuppressed int
+	}{
+		{"Critical message during maintenance", &fakeSuppressionStore{window: active}, KindCritical, 0, 1},
+		{"Report during maintenance", &fakeSuppressionStore{window: active}, KindReport, 1, 0},
+		{"Critical message without maintenance", &fakeSuppressionStore{}, KindCritical, 1, 0},
+		{"Sends when the window cannot be checked", &fakeSuppressionStore{err: errors.New("db down")}, KindCritical, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &recordingNotifier{}
+			notifier := NewMaintenanceNotifier(inner, tt.store)
+			notifier.now = func() time.Time { return now }
+
+			err := notifier.SendMessageOfKind(context.Background(), tt.kind, "alert")
+			assert.NoError(t, err)
+			assert.Len(t, inner.messages, tt.wantSent)
+			if assert.Len(t, tt.store.alerts, tt.wantSuppressed) && tt.wantSuppressed > 0 {
+				assert.Equal(t, "window", tt.store.alerts[0].MaintenanceWindowID)
+				assert.Equal(t, SuppressedMessage, tt.store.alerts[0].AlertType)
+				assert.Equal(t, now, tt.store.alerts[0].SuppressedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+)
+
+// MultiNotifier sends every notification to several notification services, e.g. Slack and a webhook.
+// A failure of one service does not keep the others from being notified.
+type MultiNotifier struct {
+	notifiers []NotificationService
+}
+
+// NewMultiNotifier creates a notification service sending to all the notifiers.
+func NewMultiNotifier(notifiers ...NotificationService) *MultiNotifier {
+	return &MultiNotifier{notifiers: notifiers}
+}
+
+func (m *MultiNotifier) SendMessage(ctx context.Context, message string) error {
+	return m.each(func(n NotificationService) error {
+		return n.SendMessage(ctx, message)
+	})
+}
+
+func (m *MultiNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	return m.each(func(n NotificationService) error {
+		return n.SendMessageOfKind(ctx, kind, message)
+	})
+}
+
+func (m *MultiNotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	return m.each(func(n NotificationService) error {
+		return n.SendStockAlert(ctx, stockCode, stockName, currentPrice, targetPrice, alertType)
+	})
+}
+
+func (m *MultiNotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	return m.each(func(n NotificationService) error {
+		return n.SendDailyReport(ctx, totalValue, totalGain, gainPercent)
+	})
+}
+
+// SendComprehensiveReport sends the report with the summary to the notifiers supporting it,
+// and the report text to the others.
+func (m *MultiNotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	return m.each(func(n NotificationService) error {
+		if sender, ok := n.(ComprehensiveReportSender); ok {
+			return sender.SendComprehensiveReport(ctx, report, summary)
+		}
+		return n.SendMessageOfKind(ctx, KindReport, report)
+	})
+}
+
+// each calls fn for every notifier and joins the errors.
+func (m *MultiNotifier) each(fn func(n NotificationService) error) error {
+	var errs []error
+	for _, n := range m.notifiers {
+		if err := fn(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
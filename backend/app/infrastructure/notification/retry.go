@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/sirupsen/logrus"
+)
+
+// maxNotificationRetryDelay caps the backoff between the attempts of a notification.
+const maxNotificationRetryDelay = 30 * time.Second
+
+// newRetryPolicy returns the retry policy of a notification service, logging each retry with the service name.
+func newRetryPolicy(service string, maxRetries int, retryDelay time.Duration) retry.Policy {
+	return retry.Policy{
+		MaxRetries:   maxRetries,
+		InitialDelay: retryDelay,
+		MaxDelay:     maxNotificationRetryDelay,
+		Jitter:       0.2,
+		OnRetry: func(attempt int, err error, wait time.Duration) {
+			logrus.Warnf("Retrying %s notification in %v (attempt %d/%d): %v", service, wait, attempt, maxRetries, err)
+		},
+	}
+}
+
+// classifyHTTPStatus marks the error of a failed response for the retry policy: a 429 response is
+// retried after its Retry-After header if any, other transient statuses such as 5xx with backoff, and other responses not at all.
+func classifyHTTPStatus(resp *http.Response, err error) error {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if wait, ok := retryAfter(resp); ok {
+			return retry.After(err, wait)
+		}
+		return err
+	case retry.IsTransientStatus(resp.StatusCode):
+		return err
+	default:
+		return retry.Permanent(err)
+	}
+}
+
+// retryAfter returns the wait asked for by the Retry-After header of a response, given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+	}
+	return 0, false
+}
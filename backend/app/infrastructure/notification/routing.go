@@ -0,0 +1,34 @@
+package notification
+
+// MessageKind classifies notifications so that each kind can be sent to its own channel.
+type MessageKind string
+
+const (
+	KindCritical MessageKind = "critical" // alerts that need action, e.g. price alerts and exit lines
+	KindReport   MessageKind = "report"   // daily, monthly and other periodic reports
+	KindGeneral  MessageKind = "general"  // everything else
+)
+
+// Route is the destination of a message kind.
+// Empty fields fall back to the default webhook URL and channel.
+type Route struct {
+	WebhookURL string
+	Channel    string
+}
+
+// SetRoutes sets the destinations of message kinds. Kinds without a route use the default destination.
+func (s *SlackNotifier) SetRoutes(routes map[MessageKind]Route) {
+	s.routes = routes
+}
+
+// route returns the destination of a message kind.
+func (s *SlackNotifier) route(kind MessageKind) Route {
+	route := s.routes[kind]
+	if route.WebhookURL == "" {
+		route.WebhookURL = s.webhookURL
+	}
+	if route.Channel == "" {
+		route.Channel = s.channel
+	}
+	return route
+}
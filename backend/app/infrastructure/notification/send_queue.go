@@ -0,0 +1,131 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultSlackSendInterval is the interval between the messages sent to a Slack destination,
+// which accepts about one message per second.
+const defaultSlackSendInterval = time.Second
+
+// sendQueue spaces the messages sent to one destination and keeps them in order. Senders take
+// their turn in the order of Acquire and keep it until their message is sent or given up, so that
+// a message retried after a rate limited response still goes before the messages queued after it.
+// Each attempt waits for a slot at least the interval after the previous send, and a rate limited
+// response holds the destination until it accepts messages again.
+type sendQueue struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	next    time.Time       // earliest time of the next send
+	busy    bool            // a sender has the turn
+	waiters []chan struct{} // senders waiting for the turn, in arrival order
+}
+
+// newSendQueue creates a queue sending at most one message per interval.
+func newSendQueue(interval time.Duration) *sendQueue {
+	return &sendQueue{interval: interval}
+}
+
+// Acquire blocks until the caller has the turn to send to the destination or ctx is done.
+// The returned release hands the turn over to the next sender and must be called once the
+// message is sent or given up.
+func (q *sendQueue) Acquire(ctx context.Context) (release func(), err error) {
+	q.mu.Lock()
+	if !q.busy {
+		q.busy = true
+		q.mu.Unlock()
+		return q.release, nil
+	}
+	ready := make(chan struct{})
+	q.waiters = append(q.waiters, ready)
+	q.mu.Unlock()
+
+	select {
+	case <-ready:
+		return q.release, nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	for i, waiter := range q.waiters {
+		if waiter == ready {
+			q.waiters = append(q.waiters[:i], q.waiters[i+1:]...)
+			q.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+	q.mu.Unlock()
+	// The turn was handed over while ctx was done, so it goes on to the next sender
+	q.release()
+	return nil, ctx.Err()
+}
+
+// release hands the turn over to the sender waiting longest.
+func (q *sendQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.waiters) == 0 {
+		q.busy = false
+		return
+	}
+	next := q.waiters[0]
+	q.waiters = q.waiters[1:]
+	close(next)
+}
+
+// Wait blocks until the next message may be sent or ctx is done. Called by the sender with the
+// turn before each attempt.
+func (q *sendQueue) Wait(ctx context.Context) error {
+	// The slot is checked again after waiting, since a rate limited response may have held the queue meanwhile
+	for {
+		q.mu.Lock()
+		wait := time.Until(q.next)
+		if wait <= 0 {
+			q.next = time.Now().Add(q.interval)
+			q.mu.Unlock()
+			return nil
+		}
+		q.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Hold keeps the messages from being sent for d, e.g. the Retry-After of a rate limited response.
+func (q *sendQueue) Hold(d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if until := time.Now().Add(d); until.After(q.next) {
+		q.next = until
+	}
+}
+
+// sendQueues are the send queues of the destinations of a notifier, created on first use.
+type sendQueues struct {
+	mu     sync.Mutex
+	queues map[string]*sendQueue
+}
+
+// get returns the queue of the destination.
+func (s *sendQueues) get(destination string, interval time.Duration) *sendQueue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queues == nil {
+		s.queues = make(map[string]*sendQueue)
+	}
+	queue, ok := s.queues[destination]
+	if !ok {
+		queue = newSendQueue(interval)
+		s.queues[destination] = queue
+	}
+	return queue
+}
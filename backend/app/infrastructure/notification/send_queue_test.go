@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendQueue_Interval(t *testing.T) {
+	queue := newSendQueue(50 * time.Millisecond)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, queue.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	// The first send goes at once and the others one interval apart
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestSendQueue_Hold(t *testing.T) {
+	queue := newSendQueue(0)
+	assert.NoError(t, queue.Wait(context.Background()))
+
+	queue.Hold(150 * time.Millisecond)
+	start := time.Now()
+	assert.NoError(t, queue.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	// A shorter hold does not bring the next send forward
+	queue.Hold(100 * time.Millisecond)
+	queue.Hold(10 * time.Millisecond)
+	start = time.Now()
+	assert.NoError(t, queue.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestSendQueue_Order(t *testing.T) {
+	queue := newSendQueue(0)
+	release, err := queue.Acquire(context.Background())
+	assert.NoError(t, err)
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := queue.Acquire(context.Background())
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			release()
+		}()
+		// Lets the sender queue up before the next one
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A sender giving up leaves the queue without taking the turn of the others
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = queue.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	wg.Wait()
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+}
+
+func TestSendQueue_ContextCanceled(t *testing.T) {
+	queue := newSendQueue(time.Second)
+	assert.NoError(t, queue.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, queue.Wait(ctx), context.DeadlineExceeded)
+}
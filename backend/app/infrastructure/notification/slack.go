@@ -8,24 +8,38 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/boost-jp/stock-automation/app/domain"
 	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
 	"github.com/sirupsen/logrus"
 )
 
 type SlackNotifier struct {
 	webhookURL string
+	channel    string
+	username   string
+	routes     map[MessageKind]Route
 	client     *http.Client
 	maxRetries int
 	retryDelay time.Duration
 	logRepo    repository.NotificationLogRepository
+	// sendInterval spaces the messages sent to each webhook; zero sends them as they come
+	// but still holds them after a rate limited response
+	sendInterval time.Duration
+	queues       sendQueues
 }
 
 type SlackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Username    string            `json:"username,omitempty"`
 	Text        string            `json:"text"`
 	Attachments []SlackAttachment `json:"attachments,omitempty"`
+	ThreadTS    string            `json:"thread_ts,omitempty"` // parent message replied to, Web API only
+	TS          string            `json:"ts,omitempty"`        // message updated by chat.update, Web API only
 }
 
 type SlackAttachment struct {
@@ -51,8 +65,9 @@ func NewSlackNotifier() *SlackNotifier {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		maxRetries: 3,
-		retryDelay: 2 * time.Second,
+		maxRetries:   3,
+		retryDelay:   2 * time.Second,
+		sendInterval: defaultSlackSendInterval,
 	}
 }
 
@@ -64,32 +79,46 @@ func NewSlackNotificationService(webhookURL, channel, username string) Notificat
 
 	return &SlackNotifier{
 		webhookURL: webhookURL,
+		channel:    channel,
+		username:   username,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		maxRetries: 3,
-		retryDelay: 2 * time.Second,
+		maxRetries:   3,
+		retryDelay:   2 * time.Second,
+		sendInterval: defaultSlackSendInterval,
 	}
 }
 
-func (s *SlackNotifier) SendMessage(message string) error {
-	if s.webhookURL == "" {
-		logrus.Debug("Slack webhook URL not configured, skipping notification")
-		return nil
-	}
+func (s *SlackNotifier) SendMessage(ctx context.Context, message string) error {
+	return s.SendMessageOfKind(ctx, KindGeneral, message)
+}
 
+// SendMessageOfKind sends a plain text message to the destination of the message kind
+func (s *SlackNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
 	msg := SlackMessage{
 		Text: message,
 	}
 
-	return s.sendSlackMessageWithLog(context.Background(), msg, "message", nil)
+	return s.sendSlackMessageWithLog(ctx, kind, msg, "message", map[string]interface{}{"kind": kind})
 }
 
-func (s *SlackNotifier) SendStockAlert(stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
-	if s.webhookURL == "" {
-		return nil
+func (s *SlackNotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	msg := stockAlertMessage(stockCode, stockName, currentPrice, targetPrice, alertType)
+
+	metadata := map[string]interface{}{
+		"stock_code":    stockCode,
+		"stock_name":    stockName,
+		"current_price": currentPrice,
+		"target_price":  targetPrice,
+		"alert_type":    alertType,
 	}
 
+	return s.sendSlackMessageWithLog(ctx, KindCritical, msg, "stock_alert", metadata)
+}
+
+// stockAlertMessage builds the Slack message of a stock price alert
+func stockAlertMessage(stockCode, stockName string, currentPrice, targetPrice float64, alertType string) SlackMessage {
 	color := "warning"
 	if alertType == "buy" {
 		color = "good"
@@ -97,30 +126,30 @@ func (s *SlackNotifier) SendStockAlert(stockCode, stockName string, currentPrice
 		color = "danger"
 	}
 
-	msg := SlackMessage{
-		Text: fmt.Sprintf("🔔 株価アラート: %s (%s)", stockName, stockCode),
+	return SlackMessage{
+		Text: "🔔 " + i18n.T("slack.stock_alert.title", stockName, stockCode),
 		Attachments: []SlackAttachment{
 			{
 				Color: color,
-				Title: fmt.Sprintf("%s通知", alertType),
+				Title: i18n.T("slack.stock_alert.type", alertType),
 				Fields: []SlackField{
 					{
-						Title: "現在価格",
+						Title: i18n.T("slack.field.current_price"),
 						Value: fmt.Sprintf("¥%.2f", currentPrice),
 						Short: true,
 					},
 					{
-						Title: "目標価格",
+						Title: i18n.T("slack.field.target_price"),
 						Value: fmt.Sprintf("¥%.2f", targetPrice),
 						Short: true,
 					},
 					{
-						Title: "乖離率",
+						Title: i18n.T("slack.field.deviation"),
 						Value: fmt.Sprintf("%.2f%%", (currentPrice-targetPrice)/targetPrice*100),
 						Short: true,
 					},
 					{
-						Title: "時刻",
+						Title: i18n.T("slack.field.time"),
 						Value: time.Now().Format("2006-01-02 15:04:05"),
 						Short: true,
 					},
@@ -128,52 +157,51 @@ func (s *SlackNotifier) SendStockAlert(stockCode, stockName string, currentPrice
 			},
 		},
 	}
+}
+
+func (s *SlackNotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	msg := dailyReportMessage(totalValue, totalGain, gainPercent)
 
 	metadata := map[string]interface{}{
-		"stock_code":    stockCode,
-		"stock_name":    stockName,
-		"current_price": currentPrice,
-		"target_price":  targetPrice,
-		"alert_type":    alertType,
+		"total_value":  totalValue,
+		"total_gain":   totalGain,
+		"gain_percent": gainPercent,
 	}
 
-	return s.sendSlackMessageWithLog(context.Background(), msg, "stock_alert", metadata)
+	return s.sendSlackMessageWithLog(ctx, KindReport, msg, "daily_report", metadata)
 }
 
-func (s *SlackNotifier) SendDailyReport(totalValue, totalGain float64, gainPercent float64) error {
-	if s.webhookURL == "" {
-		return nil
-	}
-
+// dailyReportMessage builds the Slack message of the simple daily report
+func dailyReportMessage(totalValue, totalGain float64, gainPercent float64) SlackMessage {
 	color := "good"
 	if totalGain < 0 {
 		color = "danger"
 	}
 
-	msg := SlackMessage{
-		Text: "📊 本日の投資状況レポート",
+	return SlackMessage{
+		Text: "📊 " + i18n.T("slack.daily_report.title"),
 		Attachments: []SlackAttachment{
 			{
 				Color: color,
-				Title: "ポートフォリオ状況",
+				Title: i18n.T("slack.daily_report.portfolio"),
 				Fields: []SlackField{
 					{
-						Title: "総資産",
+						Title: i18n.T("slack.field.total_value"),
 						Value: fmt.Sprintf("¥%.2f", totalValue),
 						Short: true,
 					},
 					{
-						Title: "損益",
+						Title: i18n.T("slack.field.gain"),
 						Value: fmt.Sprintf("¥%.2f", totalGain),
 						Short: true,
 					},
 					{
-						Title: "損益率",
+						Title: i18n.T("slack.field.gain_percent"),
 						Value: fmt.Sprintf("%.2f%%", gainPercent),
 						Short: true,
 					},
 					{
-						Title: "更新時刻",
+						Title: i18n.T("slack.field.updated_at"),
 						Value: time.Now().Format("2006-01-02 15:04:05"),
 						Short: true,
 					},
@@ -181,22 +209,30 @@ func (s *SlackNotifier) SendDailyReport(totalValue, totalGain float64, gainPerce
 			},
 		},
 	}
-
-	metadata := map[string]interface{}{
-		"total_value":  totalValue,
-		"total_gain":   totalGain,
-		"gain_percent": gainPercent,
-	}
-
-	return s.sendSlackMessageWithLog(context.Background(), msg, "daily_report", metadata)
 }
 
 // SendComprehensiveReport sends a comprehensive daily report with enhanced formatting
-func (s *SlackNotifier) SendComprehensiveReport(report string, summary *domain.PortfolioSummary) error {
-	if s.webhookURL == "" {
+func (s *SlackNotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	if s.route(KindReport).WebhookURL == "" {
 		return nil
 	}
 
+	msg := comprehensiveReportMessage(summary)
+
+	metadata := map[string]interface{}{
+		"total_value":        summary.TotalValue,
+		"total_cost":         summary.TotalCost,
+		"total_gain":         summary.TotalGain,
+		"total_gain_percent": summary.TotalGainPercent,
+		"holdings_count":     len(summary.Holdings),
+	}
+
+	return s.sendSlackMessageWithLog(ctx, KindReport, msg, "comprehensive_report", metadata)
+}
+
+// comprehensiveReportMessage builds the Slack message of the comprehensive daily report,
+// with the summary, holdings, technicals, contributions and goals as attachments
+func comprehensiveReportMessage(summary *domain.PortfolioSummary) SlackMessage {
 	color := "good"
 	if summary.TotalGain < 0 {
 		color = "danger"
@@ -206,25 +242,25 @@ func (s *SlackNotifier) SendComprehensiveReport(report string, summary *domain.P
 	attachments := []SlackAttachment{
 		{
 			Color: color,
-			Title: "📊 ポートフォリオサマリー",
+			Title: "📊 " + i18n.T("slack.comprehensive.summary"),
 			Fields: []SlackField{
 				{
-					Title: "総資産",
+					Title: i18n.T("slack.field.total_value"),
 					Value: fmt.Sprintf("¥%.0f", summary.TotalValue),
 					Short: true,
 				},
 				{
-					Title: "総投資額",
+					Title: i18n.T("slack.field.total_cost"),
 					Value: fmt.Sprintf("¥%.0f", summary.TotalCost),
 					Short: true,
 				},
 				{
-					Title: "損益",
+					Title: i18n.T("slack.field.gain"),
 					Value: fmt.Sprintf("¥%.0f", summary.TotalGain),
 					Short: true,
 				},
 				{
-					Title: "損益率",
+					Title: i18n.T("slack.field.gain_percent"),
 					Value: fmt.Sprintf("%.2f%%", summary.TotalGainPercent),
 					Short: true,
 				},
@@ -232,11 +268,26 @@ func (s *SlackNotifier) SendComprehensiveReport(report string, summary *domain.P
 		},
 	}
 
-	// Add holdings details if available
-	if len(summary.Holdings) > 0 {
+	if len(summary.Allocations) > 1 {
+		attachments[0].Fields = append(attachments[0].Fields, SlackField{
+			Title: i18n.T("portfolio.allocation_section"),
+			Value: strings.Join(domain.FormatAssetAllocations(summary.Allocations), "\n"),
+			Short: false,
+		})
+	}
+	if summary.BuyingPower != nil {
+		attachments[0].Fields = append(attachments[0].Fields, SlackField{
+			Title: i18n.T("buying_power.section"),
+			Value: strings.Join(domain.FormatBuyingPowerLines(*summary.BuyingPower), "\n"),
+			Short: false,
+		})
+	}
+
+	// Add holdings details if available and not turned off
+	if len(summary.Holdings) > 0 && !summary.HideHoldings {
 		holdings := SlackAttachment{
 			Color:  "info",
-			Title:  "📈 保有銘柄詳細",
+			Title:  "📈 " + i18n.T("slack.comprehensive.holdings"),
 			Fields: []SlackField{},
 		}
 
@@ -248,8 +299,8 @@ func (s *SlackNotifier) SendComprehensiveReport(report string, summary *domain.P
 
 			holdings.Fields = append(holdings.Fields, SlackField{
 				Title: fmt.Sprintf("%s %s (%s)", holdingColor, holding.Name, holding.Code),
-				Value: fmt.Sprintf("数量: %d | 現在値: ¥%.0f | 損益: ¥%.0f (%.1f%%)",
-					holding.Shares, holding.CurrentPrice, holding.Gain, holding.GainPercent),
+				Value: i18n.T("slack.comprehensive.holding",
+					holding.FormatQuantity(), holding.CurrentPrice, holding.Gain, holding.GainPercent),
 				Short: false,
 			})
 		}
@@ -257,20 +308,59 @@ func (s *SlackNotifier) SendComprehensiveReport(report string, summary *domain.P
 		attachments = append(attachments, holdings)
 	}
 
-	msg := SlackMessage{
-		Text:        "📊 デイリーポートフォリオレポート",
-		Attachments: attachments,
+	// Add the technical summaries of the listed holdings
+	if len(summary.Technicals) > 0 {
+		lines := make([]string, 0, len(summary.Technicals))
+		for _, technical := range summary.Technicals {
+			lines = append(lines, domain.FormatTechnicalLine(technical))
+		}
+		attachments = append(attachments, SlackAttachment{
+			Color:  "info",
+			Title:  i18n.T("technical_summary.section"),
+			Fields: []SlackField{{Value: strings.Join(lines, "\n"), Short: false}},
+		})
 	}
 
-	metadata := map[string]interface{}{
-		"total_value":        summary.TotalValue,
-		"total_cost":         summary.TotalCost,
-		"total_gain":         summary.TotalGain,
-		"total_gain_percent": summary.TotalGainPercent,
-		"holdings_count":     len(summary.Holdings),
+	// Add the contribution rankings if analyzed
+	if summary.Contributions != nil {
+		contributions := SlackAttachment{
+			Color:  "info",
+			Title:  i18n.T("contribution.section"),
+			Fields: []SlackField{},
+		}
+		for _, ranking := range domain.FormatContributionRankings(*summary.Contributions) {
+			contributions.Fields = append(contributions.Fields, SlackField{
+				Title: ranking.Title,
+				Value: strings.Join(ranking.Lines, "\n"),
+				Short: false,
+			})
+		}
+		if len(contributions.Fields) > 0 {
+			attachments = append(attachments, contributions)
+		}
 	}
 
-	return s.sendSlackMessageWithLog(context.Background(), msg, "comprehensive_report", metadata)
+	// Add goal progress if goals are set
+	if len(summary.Goals) > 0 {
+		goals := SlackAttachment{
+			Color:  "warning",
+			Title:  i18n.T("goal.section"),
+			Fields: []SlackField{},
+		}
+		for _, goal := range summary.Goals {
+			goals.Fields = append(goals.Fields, SlackField{
+				Title: domain.FormatGoalLine(goal),
+				Value: domain.FormatGoalDetail(goal),
+				Short: false,
+			})
+		}
+		attachments = append(attachments, goals)
+	}
+
+	return SlackMessage{
+		Text:        "📊 " + i18n.T("slack.comprehensive.title"),
+		Attachments: attachments,
+	}
 }
 
 // SetLogRepository sets the notification log repository
@@ -278,17 +368,40 @@ func (s *SlackNotifier) SetLogRepository(logRepo repository.NotificationLogRepos
 	s.logRepo = logRepo
 }
 
-func (s *SlackNotifier) sendSlackMessage(msg SlackMessage) error {
-	return s.sendSlackMessageWithLog(context.Background(), msg, "generic", nil)
+// SetSendInterval sets the interval between the messages sent to each webhook. Set it before the
+// first message is sent.
+func (s *SlackNotifier) SetSendInterval(interval time.Duration) {
+	s.sendInterval = interval
+}
+
+func (s *SlackNotifier) sendSlackMessage(ctx context.Context, msg SlackMessage) error {
+	return s.sendSlackMessageWithLog(ctx, KindGeneral, msg, "generic", nil)
 }
 
-// sendSlackMessageWithLog sends a Slack message and logs the transmission
-func (s *SlackNotifier) sendSlackMessageWithLog(ctx context.Context, msg SlackMessage, notificationType string, metadata map[string]interface{}) error {
+// sendSlackMessageWithLog sends a Slack message to the destination of the message kind and logs the transmission
+func (s *SlackNotifier) sendSlackMessageWithLog(ctx context.Context, kind MessageKind, msg SlackMessage, notificationType string, metadata map[string]interface{}) error {
+	route := s.route(kind)
+	if route.WebhookURL == "" {
+		logrus.Debug("Slack webhook URL not configured, skipping notification")
+		return nil
+	}
+	msg.Channel = route.Channel
+	msg.Username = s.username
+
 	jsonData, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
+	// Messages to the webhook are sent in turn and in order, so that a burst of alerts does not exceed
+	// the rate limit of Slack. The turn is kept across the retries of the message.
+	queue := s.queues.get(route.WebhookURL, s.sendInterval)
+	release, err := queue.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for the turn to send Slack notification: %w", err)
+	}
+	defer release()
+
 	// Create notification log entry if repository is available
 	var logID int64
 	if s.logRepo != nil {
@@ -307,19 +420,15 @@ func (s *SlackNotifier) sendSlackMessageWithLog(ctx context.Context, msg SlackMe
 		}
 	}
 
-	var lastErr error
-	var attempts int
-	for attempt := 0; attempt <= s.maxRetries; attempt++ {
-		attempts = attempt + 1
-		if attempt > 0 {
-			logrus.Warnf("Retrying Slack notification (attempt %d/%d)", attempt, s.maxRetries)
-			time.Sleep(s.retryDelay)
+	policy := newRetryPolicy("Slack", s.maxRetries, s.retryDelay)
+	attempts, err := policy.Do(ctx, func(ctx context.Context) error {
+		if err := queue.Wait(ctx); err != nil {
+			return retry.Permanent(err)
 		}
 
-		req, err := http.NewRequest("POST", s.webhookURL, bytes.NewBuffer(jsonData))
+		req, err := http.NewRequestWithContext(ctx, "POST", route.WebhookURL, bytes.NewBuffer(jsonData))
 		if err != nil {
-			lastErr = fmt.Errorf("failed to create request: %w", err)
-			continue
+			return retry.Permanent(fmt.Errorf("failed to create request: %w", err))
 		}
 
 		req.Header.Set("Content-Type", "application/json; charset=utf-8")
@@ -330,33 +439,44 @@ func (s *SlackNotifier) sendSlackMessageWithLog(ctx context.Context, msg SlackMe
 		duration := time.Since(startTime)
 
 		if err != nil {
-			lastErr = fmt.Errorf("failed to send message: %w", err)
 			logrus.WithFields(logrus.Fields{
-				"attempt":  attempt + 1,
 				"error":    err,
 				"duration": duration,
 			}).Error("Failed to send Slack notification")
-			continue
+			return fmt.Errorf("failed to send message: %w", err)
 		}
 		defer resp.Body.Close()
 
+		if resp.StatusCode == http.StatusTooManyRequests {
+			// The other messages to the webhook wait too, instead of being rate limited in turn
+			wait, ok := retryAfter(resp)
+			if !ok {
+				wait = s.retryDelay
+			}
+			queue.Hold(wait)
+			logrus.WithFields(logrus.Fields{
+				"retry_after": wait,
+				"type":        notificationType,
+			}).Warn("Slack rate limited the notification")
+		}
+
 		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("slack API returned status code: %d", resp.StatusCode)
 			logrus.WithFields(logrus.Fields{
-				"attempt":     attempt + 1,
 				"status_code": resp.StatusCode,
 				"duration":    duration,
 			}).Error("Slack API returned non-OK status")
-			continue
+			return classifyHTTPStatus(resp, fmt.Errorf("slack API returned status code: %d", resp.StatusCode))
 		}
 
-		// Success
 		logrus.WithFields(logrus.Fields{
-			"attempt":  attempt + 1,
 			"duration": duration,
 			"type":     notificationType,
+			"kind":     kind,
 		}).Info("Successfully sent Slack notification")
+		return nil
+	})
 
+	if err == nil {
 		// Update log entry with success
 		if s.logRepo != nil && logID > 0 {
 			now := time.Now()
@@ -364,17 +484,16 @@ func (s *SlackNotifier) sendSlackMessageWithLog(ctx context.Context, msg SlackMe
 				logrus.Warnf("Failed to update notification log: %v", err)
 			}
 		}
-
 		return nil
 	}
 
-	// Update log entry with failure
+	// Update log entry with failure (even if the notification itself was canceled)
 	if s.logRepo != nil && logID > 0 {
-		errMsg := lastErr.Error()
-		if err := s.logRepo.UpdateStatus(ctx, logID, "failed", &errMsg, nil); err != nil {
+		errMsg := err.Error()
+		if err := s.logRepo.UpdateStatus(context.WithoutCancel(ctx), logID, "failed", &errMsg, nil); err != nil {
 			logrus.Warnf("Failed to update notification log: %v", err)
 		}
 	}
 
-	return fmt.Errorf("failed to send Slack notification after %d attempts: %w", attempts, lastErr)
+	return fmt.Errorf("failed to send Slack notification after %d attempts: %w", attempts, err)
 }
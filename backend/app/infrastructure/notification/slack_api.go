@@ -0,0 +1,294 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultSlackAPIURL is the base URL of the Slack Web API.
+const defaultSlackAPIURL = "https://slack.com/api"
+
+// SlackAPINotifier sends notifications with the Slack Web API and a bot token instead of webhooks,
+// so that messages of a thread key are replied in one thread and posted messages can be updated.
+// The messages are the same as those of SlackNotifier.
+type SlackAPINotifier struct {
+	token      string
+	apiURL     string
+	channel    string
+	username   string
+	channels   map[MessageKind]string
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+	threads    ThreadStore
+	logRepo    repository.NotificationLogRepository
+	// sendInterval spaces the calls for each channel; zero calls as they come
+	// but still holds the calls after a rate limited response
+	sendInterval time.Duration
+	queues       sendQueues
+}
+
+// slackAPIResponse is the part of a chat.postMessage or chat.update response used here.
+type slackAPIResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+// NewSlackAPINotifier creates a Slack notification service posting with the bot token to the
+// default channel. The channel must be one the bot is a member of.
+func NewSlackAPINotifier(token, channel, username string) *SlackAPINotifier {
+	return &SlackAPINotifier{
+		token:    token,
+		apiURL:   defaultSlackAPIURL,
+		channel:  channel,
+		username: username,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		maxRetries:   3,
+		retryDelay:   2 * time.Second,
+		sendInterval: defaultSlackSendInterval,
+	}
+}
+
+// SetChannels sets the channels of message kinds. Kinds without a channel use the default channel.
+func (s *SlackAPINotifier) SetChannels(channels map[MessageKind]string) {
+	s.channels = channels
+}
+
+// SetThreadStore sets the store of the threads. Without it, messages of a thread key are not threaded.
+func (s *SlackAPINotifier) SetThreadStore(threads ThreadStore) {
+	s.threads = threads
+}
+
+// SetLogRepository sets the notification log repository
+func (s *SlackAPINotifier) SetLogRepository(logRepo repository.NotificationLogRepository) {
+	s.logRepo = logRepo
+}
+
+// SetSendInterval sets the interval between the calls for each channel. Set it before the first
+// message is sent.
+func (s *SlackAPINotifier) SetSendInterval(interval time.Duration) {
+	s.sendInterval = interval
+}
+
+func (s *SlackAPINotifier) SendMessage(ctx context.Context, message string) error {
+	return s.SendMessageOfKind(ctx, KindGeneral, message)
+}
+
+// SendMessageOfKind sends a plain text message to the channel of the message kind
+func (s *SlackAPINotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	_, err := s.post(ctx, kind, SlackMessage{Text: message}, "message", map[string]interface{}{"kind": kind})
+	return err
+}
+
+func (s *SlackAPINotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	msg := stockAlertMessage(stockCode, stockName, currentPrice, targetPrice, alertType)
+	metadata := map[string]interface{}{
+		"stock_code":    stockCode,
+		"stock_name":    stockName,
+		"current_price": currentPrice,
+		"target_price":  targetPrice,
+		"alert_type":    alertType,
+	}
+	_, err := s.post(ctx, KindCritical, msg, "stock_alert", metadata)
+	return err
+}
+
+func (s *SlackAPINotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	msg := dailyReportMessage(totalValue, totalGain, gainPercent)
+	metadata := map[string]interface{}{
+		"total_value":  totalValue,
+		"total_gain":   totalGain,
+		"gain_percent": gainPercent,
+	}
+	_, err := s.post(ctx, KindReport, msg, "daily_report", metadata)
+	return err
+}
+
+// SendComprehensiveReport sends a comprehensive daily report with enhanced formatting
+func (s *SlackAPINotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	metadata := map[string]interface{}{
+		"total_value":        summary.TotalValue,
+		"total_cost":         summary.TotalCost,
+		"total_gain":         summary.TotalGain,
+		"total_gain_percent": summary.TotalGainPercent,
+		"holdings_count":     len(summary.Holdings),
+	}
+	_, err := s.post(ctx, KindReport, comprehensiveReportMessage(summary), "comprehensive_report", metadata)
+	return err
+}
+
+// PostMessage posts a plain text message to the channel of the message kind and returns it, so
+// that it can be updated later.
+func (s *SlackAPINotifier) PostMessage(ctx context.Context, kind MessageKind, message string) (MessageRef, error) {
+	return s.post(ctx, kind, SlackMessage{Text: message}, "message", map[string]interface{}{"kind": kind})
+}
+
+// UpdateMessage replaces the text of a posted message with chat.update.
+func (s *SlackAPINotifier) UpdateMessage(ctx context.Context, ref MessageRef, message string) error {
+	if _, err := s.call(ctx, "chat.update", SlackMessage{Channel: ref.Channel, TS: ref.TS, Text: message}); err != nil {
+		return fmt.Errorf("failed to update Slack message: %w", err)
+	}
+	return nil
+}
+
+// channelOf returns the channel of a message kind.
+func (s *SlackAPINotifier) channelOf(kind MessageKind) string {
+	if channel := s.channels[kind]; channel != "" {
+		return channel
+	}
+	return s.channel
+}
+
+// post posts a message with chat.postMessage and logs the transmission. A message with a thread key
+// in the context is replied in the thread of the key, or starts the thread if it has none yet.
+// A thread that cannot be looked up or recorded only costs the threading, not the message.
+func (s *SlackAPINotifier) post(ctx context.Context, kind MessageKind, msg SlackMessage, notificationType string, metadata map[string]interface{}) (MessageRef, error) {
+	msg.Channel = s.channelOf(kind)
+	msg.Username = s.username
+
+	key := threadKeyFromContext(ctx)
+	var thread *models.NotificationThread
+	if key != "" && s.threads != nil {
+		var err error
+		if thread, err = s.threads.GetThread(ctx, key); err != nil {
+			logrus.Warnf("Failed to get Slack thread %s: %v", key, err)
+		}
+		if thread != nil {
+			msg.Channel, msg.ThreadTS = thread.Channel, thread.TS
+		}
+	}
+
+	var logID int64
+	if s.logRepo != nil {
+		metadataJSON, _ := json.Marshal(metadata)
+		log := &repository.NotificationLog{
+			NotificationType: notificationType,
+			Status:           "pending",
+			Message:          sql.NullString{String: msg.Text, Valid: true},
+			Metadata:         metadataJSON,
+		}
+		if err := s.logRepo.Create(ctx, log); err != nil {
+			logrus.Warnf("Failed to create notification log: %v", err)
+		} else {
+			logID = log.ID
+		}
+	}
+
+	ref, err := s.call(ctx, "chat.postMessage", msg)
+	if s.logRepo != nil && logID > 0 {
+		status, errMsg, sentAt := "sent", (*string)(nil), (*time.Time)(nil)
+		if err != nil {
+			text := err.Error()
+			status, errMsg = "failed", &text
+		} else {
+			now := time.Now()
+			sentAt = &now
+		}
+		if err := s.logRepo.UpdateStatus(context.WithoutCancel(ctx), logID, status, errMsg, sentAt); err != nil {
+			logrus.Warnf("Failed to update notification log: %v", err)
+		}
+	}
+	if err != nil {
+		return MessageRef{}, fmt.Errorf("failed to send Slack notification: %w", err)
+	}
+
+	if key != "" && s.threads != nil && thread == nil {
+		err := s.threads.SaveThread(ctx, &models.NotificationThread{ThreadKey: key, Channel: ref.Channel, TS: ref.TS})
+		if err != nil {
+			logrus.Warnf("Failed to save Slack thread %s: %v", key, err)
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"type":   notificationType,
+		"kind":   kind,
+		"thread": key,
+	}).Info("Successfully sent Slack notification")
+	return ref, nil
+}
+
+// call calls a Slack Web API method with the message as its JSON arguments and returns the posted
+// or updated message. Calls for a channel are made in turn and in order at the send interval. Rate limits
+// hold the calls for the channel and are retried after their Retry-After, server errors with backoff,
+// before the calls queued after them.
+func (s *SlackAPINotifier) call(ctx context.Context, method string, msg SlackMessage) (MessageRef, error) {
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return MessageRef{}, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	var ref MessageRef
+	queue := s.queues.get(msg.Channel, s.sendInterval)
+	release, err := queue.Acquire(ctx)
+	if err != nil {
+		return MessageRef{}, fmt.Errorf("failed to wait for the turn to call %s: %w", method, err)
+	}
+	defer release()
+
+	policy := newRetryPolicy("Slack", s.maxRetries, s.retryDelay)
+	attempts, err := policy.Do(ctx, func(ctx context.Context) error {
+		if err := queue.Wait(ctx); err != nil {
+			return retry.Permanent(err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL+"/"+method, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return retry.Permanent(fmt.Errorf("failed to create request: %w", err))
+		}
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("Authorization", "Bearer "+s.token)
+		req.Header.Set("User-Agent", "Stock-Automation/1.0")
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to call %s: %w", method, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait, ok := retryAfter(resp)
+			if !ok {
+				wait = s.retryDelay
+			}
+			queue.Hold(wait)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return classifyHTTPStatus(resp, fmt.Errorf("slack API %s returned status code: %d", method, resp.StatusCode))
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read %s response: %w", method, err)
+		}
+		var result slackAPIResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return retry.Permanent(fmt.Errorf("failed to parse %s response: %w", method, err))
+		}
+		if !result.OK {
+			return retry.Permanent(fmt.Errorf("slack API %s failed: %s", method, result.Error))
+		}
+
+		ref = MessageRef{Channel: result.Channel, TS: result.TS}
+		return nil
+	})
+	if err != nil {
+		return MessageRef{}, fmt.Errorf("%s failed after %d attempts: %w", method, attempts, err)
+	}
+	return ref, nil
+}
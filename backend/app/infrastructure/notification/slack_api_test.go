@@ -0,0 +1,139 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+// memoryThreadStore is a ThreadStore keeping the threads in memory.
+type memoryThreadStore map[string]*models.NotificationThread
+
+func (m memoryThreadStore) GetThread(ctx context.Context, key string) (*models.NotificationThread, error) {
+	return m[key], nil
+}
+
+func (m memoryThreadStore) SaveThread(ctx context.Context, thread *models.NotificationThread) error {
+	m[thread.ThreadKey] = thread
+	return nil
+}
+
+// slackAPICall is a call received by the fake Slack API.
+type slackAPICall struct {
+	Method   string
+	Channel  string
+	Text     string
+	ThreadTS string
+	TS       string
+}
+
+// newFakeSlackAPI starts a Slack API answering each posted message with the next timestamp.
+func newFakeSlackAPI(t *testing.T, calls *[]slackAPICall) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer xoxb-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var msg SlackMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		call := slackAPICall{Method: r.URL.Path, Channel: msg.Channel, Text: msg.Text, ThreadTS: msg.ThreadTS, TS: msg.TS}
+		*calls = append(*calls, call)
+
+		if msg.Channel == "#archived" {
+			json.NewEncoder(w).Encode(slackAPIResponse{OK: false, Error: "is_archived"})
+			return
+		}
+		ts := msg.TS
+		if ts == "" {
+			ts = fmt.Sprintf("1700000000.%06d", len(*calls))
+		}
+		json.NewEncoder(w).Encode(slackAPIResponse{OK: true, Channel: "C" + msg.Channel[1:], TS: ts})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestSlackAPINotifier(apiURL string) *SlackAPINotifier {
+	notifier := NewSlackAPINotifier("xoxb-test", "#general", "Stock Bot")
+	notifier.apiURL = apiURL
+	notifier.retryDelay = 10 * time.Millisecond
+	notifier.SetSendInterval(0)
+	notifier.SetChannels(map[MessageKind]string{KindReport: "#reports"})
+	return notifier
+}
+
+func TestSlackAPINotifier_Thread(t *testing.T) {
+	var calls []slackAPICall
+	notifier := newTestSlackAPINotifier(newFakeSlackAPI(t, &calls).URL)
+	threads := memoryThreadStore{}
+	notifier.SetThreadStore(threads)
+
+	ctx := WithThread(context.Background(), "daily-report:2024-01-15")
+	if err := notifier.SendMessageOfKind(ctx, KindReport, "report"); err != nil {
+		t.Fatalf("SendMessageOfKind() error = %v", err)
+	}
+	// A follow-up of another kind is still replied in the thread of the report
+	if err := notifier.SendMessageOfKind(ctx, KindGeneral, "follow-up"); err != nil {
+		t.Fatalf("SendMessageOfKind() error = %v", err)
+	}
+	// Messages without a thread key are not threaded
+	if err := notifier.SendMessage(context.Background(), "other"); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	want := []slackAPICall{
+		{Method: "/chat.postMessage", Channel: "#reports", Text: "report"},
+		{Method: "/chat.postMessage", Channel: "Creports", Text: "follow-up", ThreadTS: "1700000000.000001"},
+		{Method: "/chat.postMessage", Channel: "#general", Text: "other"},
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("calls mismatch (-want +got):\n%s", diff)
+	}
+	if got := threads["daily-report:2024-01-15"]; got == nil || got.TS != "1700000000.000001" {
+		t.Errorf("saved thread = %+v, want the report message", got)
+	}
+}
+
+func TestSlackAPINotifier_UpdateMessage(t *testing.T) {
+	var calls []slackAPICall
+	notifier := newTestSlackAPINotifier(newFakeSlackAPI(t, &calls).URL)
+
+	ref, err := notifier.PostMessage(context.Background(), KindGeneral, "running")
+	if err != nil {
+		t.Fatalf("PostMessage() error = %v", err)
+	}
+	if err := notifier.UpdateMessage(context.Background(), ref, "succeeded"); err != nil {
+		t.Fatalf("UpdateMessage() error = %v", err)
+	}
+
+	want := []slackAPICall{
+		{Method: "/chat.postMessage", Channel: "#general", Text: "running"},
+		{Method: "/chat.update", Channel: "Cgeneral", Text: "succeeded", TS: "1700000000.000001"},
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("calls mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSlackAPINotifier_APIError(t *testing.T) {
+	var calls []slackAPICall
+	notifier := newTestSlackAPINotifier(newFakeSlackAPI(t, &calls).URL)
+	notifier.SetChannels(map[MessageKind]string{KindCritical: "#archived"})
+
+	if err := notifier.SendMessageOfKind(context.Background(), KindCritical, "alert"); err == nil {
+		t.Fatal("SendMessageOfKind() error = nil, want the API error")
+	}
+	// An error response is not retried
+	if len(calls) != 1 {
+		t.Errorf("calls = %d, want 1", len(calls))
+	}
+}
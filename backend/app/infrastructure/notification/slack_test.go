@@ -2,8 +2,10 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,7 +33,7 @@ func TestSlackNotifier_SendMessage(t *testing.T) {
 		retryDelay: 100 * time.Millisecond,
 	}
 
-	err := notifier.SendMessage("Test message")
+	err := notifier.SendMessage(context.Background(), "Test message")
 	assert.NoError(t, err)
 }
 
@@ -45,7 +47,7 @@ func TestSlackNotifier_SendMessage_NoWebhookURL(t *testing.T) {
 		retryDelay: 100 * time.Millisecond,
 	}
 
-	err := notifier.SendMessage("Test message")
+	err := notifier.SendMessage(context.Background(), "Test message")
 	assert.NoError(t, err) // Should not error when webhook URL is empty
 }
 
@@ -80,7 +82,7 @@ func TestSlackNotifier_SendStockAlert(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := notifier.SendStockAlert(tt.stockCode, tt.stockName, tt.currentPrice, tt.targetPrice, tt.alertType)
+			err := notifier.SendStockAlert(context.Background(), tt.stockCode, tt.stockName, tt.currentPrice, tt.targetPrice, tt.alertType)
 			assert.NoError(t, err)
 		})
 	}
@@ -115,7 +117,7 @@ func TestSlackNotifier_SendDailyReport(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := notifier.SendDailyReport(tt.totalValue, tt.totalGain, tt.gainPercent)
+			err := notifier.SendDailyReport(context.Background(), tt.totalValue, tt.totalGain, tt.gainPercent)
 			assert.NoError(t, err)
 		})
 	}
@@ -165,7 +167,7 @@ func TestSlackNotifier_SendComprehensiveReport(t *testing.T) {
 	}
 
 	report := "Test comprehensive report"
-	err := notifier.SendComprehensiveReport(report, summary)
+	err := notifier.SendComprehensiveReport(context.Background(), report, summary)
 	assert.NoError(t, err)
 }
 
@@ -191,7 +193,7 @@ func TestSlackNotifier_RetryMechanism(t *testing.T) {
 		retryDelay: 100 * time.Millisecond,
 	}
 
-	err := notifier.SendMessage("Test retry")
+	err := notifier.SendMessage(context.Background(), "Test retry")
 	assert.NoError(t, err)
 	assert.Equal(t, 3, attempts)
 }
@@ -211,7 +213,7 @@ func TestSlackNotifier_RetryFailure(t *testing.T) {
 		retryDelay: 100 * time.Millisecond,
 	}
 
-	err := notifier.SendMessage("Test fail")
+	err := notifier.SendMessage(context.Background(), "Test fail")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to send Slack notification after 3 attempts")
 }
@@ -226,11 +228,39 @@ func TestSlackNotifier_NetworkError(t *testing.T) {
 		retryDelay: 100 * time.Millisecond,
 	}
 
-	err := notifier.SendMessage("Test network error")
+	err := notifier.SendMessage(context.Background(), "Test network error")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to send Slack notification")
 }
 
+func TestSlackNotifier_ContextCanceled(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := &SlackNotifier{
+		webhookURL: server.URL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		maxRetries: 3,
+		retryDelay: 5 * time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := notifier.SendMessage(ctx, "Test cancel")
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
 // MockNotificationLogRepository for testing
 type MockNotificationLogRepository struct {
 	CreateFunc       func(ctx context.Context, log *repository.NotificationLog) error
@@ -294,7 +324,7 @@ func TestSlackNotifier_WithLogging(t *testing.T) {
 		logRepo:    mockRepo,
 	}
 
-	err := notifier.SendMessage("Test with logging")
+	err := notifier.SendMessage(context.Background(), "Test with logging")
 	assert.NoError(t, err)
 	assert.NotNil(t, createdLog)
 	assert.Equal(t, "message", createdLog.NotificationType)
@@ -302,3 +332,103 @@ func TestSlackNotifier_WithLogging(t *testing.T) {
 	assert.Equal(t, int64(123), updatedID)
 	assert.Equal(t, "sent", updatedStatus)
 }
+
+func TestSlackNotifier_Routing(t *testing.T) {
+	type received struct {
+		Webhook string
+		Channel string
+	}
+	var got []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg SlackMessage
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		got = append(got, received{Webhook: r.URL.Path, Channel: msg.Channel})
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotificationService(server.URL+"/default", "#general", "Stock Bot").(*SlackNotifier)
+	notifier.retryDelay = 10 * time.Millisecond
+	notifier.SetSendInterval(0)
+	notifier.SetRoutes(map[MessageKind]Route{
+		KindCritical: {Channel: "#alerts"},
+		KindReport:   {WebhookURL: server.URL + "/reports", Channel: "#reports"},
+	})
+
+	ctx := context.Background()
+	assert.NoError(t, notifier.SendStockAlert(ctx, "7203", "Toyota", 2600, 2500, "sell"))
+	assert.NoError(t, notifier.SendMessageOfKind(ctx, KindCritical, "Stop loss reached"))
+	assert.NoError(t, notifier.SendDailyReport(ctx, 1000000, 50000, 5))
+	assert.NoError(t, notifier.SendMessageOfKind(ctx, KindReport, "Monthly report"))
+	assert.NoError(t, notifier.SendMessage(ctx, "Hello"))
+
+	assert.Equal(t, []received{
+		{Webhook: "/default", Channel: "#alerts"},
+		{Webhook: "/default", Channel: "#alerts"},
+		{Webhook: "/reports", Channel: "#reports"},
+		{Webhook: "/reports", Channel: "#reports"},
+		{Webhook: "/default", Channel: "#general"},
+	}, got)
+}
+
+func TestSlackNotifier_RoutingWithoutDefault(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotificationService("", "", "").(*SlackNotifier)
+	notifier.SetRoutes(map[MessageKind]Route{KindCritical: {WebhookURL: server.URL}})
+
+	ctx := context.Background()
+	assert.NoError(t, notifier.SendMessage(ctx, "Skipped without a webhook URL"))
+	assert.NoError(t, notifier.SendMessageOfKind(ctx, KindCritical, "Sent to the critical webhook"))
+	assert.Equal(t, 1, calls)
+}
+
+func TestSlackNotifier_RateLimited(t *testing.T) {
+	var mu sync.Mutex
+	var received []time.Time
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg SlackMessage
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, time.Now())
+		texts = append(texts, msg.Text)
+		if len(received) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotificationService(server.URL, "#general", "Stock Bot").(*SlackNotifier)
+	notifier.retryDelay = 10 * time.Millisecond
+	notifier.SetSendInterval(0)
+
+	// The message sent while the first is rate limited waits for the Retry-After too
+	var wg sync.WaitGroup
+	for _, message := range []string{"first", "second"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, notifier.SendMessage(context.Background(), message))
+		}()
+		time.Sleep(100 * time.Millisecond)
+	}
+	wg.Wait()
+
+	assert.Len(t, received, 3)
+	for _, at := range received[1:] {
+		assert.GreaterOrEqual(t, at.Sub(received[0]), time.Second)
+	}
+	// The rate limited message is retried before the message queued after it
+	assert.Equal(t, []string{"first", "first", "second"}, texts)
+}
@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// ThreadStore keeps the message starting the thread of each key, so that the replies find the
+// thread across job runs and processes.
+type ThreadStore interface {
+	GetThread(ctx context.Context, key string) (*models.NotificationThread, error)
+	SaveThread(ctx context.Context, thread *models.NotificationThread) error
+}
+
+// MessageRef identifies a posted message.
+type MessageRef struct {
+	Channel string
+	TS      string
+}
+
+// threadKeyContextKey is the context key of the thread key.
+type threadKeyContextKey struct{}
+
+// WithThread returns a context whose messages belong to the thread of the key: the first message
+// of the key starts the thread and the later ones are replied in it. Notification services without
+// threads send the messages as usual.
+func WithThread(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, threadKeyContextKey{}, key)
+}
+
+// threadKeyFromContext returns the thread key of the context, empty if none.
+func threadKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(threadKeyContextKey{}).(string)
+	return key
+}
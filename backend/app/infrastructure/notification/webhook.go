@@ -0,0 +1,255 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookSignatureHeader carries the HMAC-SHA256 signature of the request body, as "sha256=<hex>".
+const WebhookSignatureHeader = "X-Stock-Automation-Signature"
+
+// WebhookEvent is the event posted to a webhook. Without a payload template it is posted as JSON as is.
+type WebhookEvent struct {
+	Type      string                 `json:"type"` // message, stock_alert, daily_report or comprehensive_report
+	Kind      MessageKind            `json:"kind"`
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// WebhookConfig configures a WebhookNotifier.
+type WebhookConfig struct {
+	URL string
+	// Secret signs the request bodies with HMAC-SHA256 when set.
+	Secret string
+	// PayloadTemplate is a text/template rendering the request body from a WebhookEvent, e.g.
+	// {"text": {{json .Message}}}. The rendered body must be JSON. Empty posts the event itself.
+	PayloadTemplate string
+	Timeout         time.Duration
+	MaxRetries      int
+	RetryDelay      time.Duration
+}
+
+// WebhookNotifier posts notification events to an arbitrary HTTP endpoint such as a home-made dashboard.
+type WebhookNotifier struct {
+	url        string
+	secret     []byte
+	template   *template.Template
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+}
+
+// NewWebhookNotifier creates a webhook notifier. Returns an error if the payload template does not parse.
+func NewWebhookNotifier(config WebhookConfig) (*WebhookNotifier, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("webhook URL is required")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = 2 * time.Second
+	}
+
+	n := &WebhookNotifier{
+		url:        config.URL,
+		secret:     []byte(config.Secret),
+		client:     &http.Client{Timeout: config.Timeout},
+		maxRetries: config.MaxRetries,
+		retryDelay: config.RetryDelay,
+	}
+
+	if strings.TrimSpace(config.PayloadTemplate) != "" {
+		tmpl, err := template.New("payload").Funcs(template.FuncMap{
+			"json": func(v interface{}) (string, error) {
+				b, err := json.Marshal(v)
+				return string(b), err
+			},
+		}).Parse(config.PayloadTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse webhook payload template: %w", err)
+		}
+		n.template = tmpl
+	}
+
+	return n, nil
+}
+
+func (n *WebhookNotifier) SendMessage(ctx context.Context, message string) error {
+	return n.SendMessageOfKind(ctx, KindGeneral, message)
+}
+
+// SendMessageOfKind posts a message event of the message kind
+func (n *WebhookNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	return n.Send(ctx, WebhookEvent{Type: "message", Kind: kind, Message: message})
+}
+
+func (n *WebhookNotifier) SendStockAlert(ctx context.Context, stockCode, stockName string, currentPrice, targetPrice float64, alertType string) error {
+	return n.Send(ctx, WebhookEvent{
+		Type:    "stock_alert",
+		Kind:    KindCritical,
+		Message: "🔔 " + i18n.T("slack.stock_alert.title", stockName, stockCode),
+		Data: map[string]interface{}{
+			"stock_code":    stockCode,
+			"stock_name":    stockName,
+			"current_price": currentPrice,
+			"target_price":  targetPrice,
+			"alert_type":    alertType,
+		},
+	})
+}
+
+func (n *WebhookNotifier) SendDailyReport(ctx context.Context, totalValue, totalGain float64, gainPercent float64) error {
+	return n.Send(ctx, WebhookEvent{
+		Type:    "daily_report",
+		Kind:    KindReport,
+		Message: "📊 " + i18n.T("slack.daily_report.title"),
+		Data: map[string]interface{}{
+			"total_value":  totalValue,
+			"total_gain":   totalGain,
+			"gain_percent": gainPercent,
+		},
+	})
+}
+
+// SendComprehensiveReport posts the daily report with the portfolio summary and holdings as data
+func (n *WebhookNotifier) SendComprehensiveReport(ctx context.Context, report string, summary *domain.PortfolioSummary) error {
+	shown := summary.Holdings
+	if summary.HideHoldings {
+		shown = nil
+	}
+	holdings := make([]map[string]interface{}, 0, len(shown))
+	for _, holding := range shown {
+		holdings = append(holdings, map[string]interface{}{
+			"code":          holding.Code,
+			"name":          holding.Name,
+			"asset_class":   holding.AssetClass,
+			"shares":        holding.Shares,
+			"current_price": holding.CurrentPrice,
+			"current_value": holding.CurrentValue,
+			"gain":          holding.Gain,
+			"gain_percent":  holding.GainPercent,
+		})
+	}
+
+	allocations := make(map[string]float64, len(summary.Allocations))
+	for _, allocation := range summary.Allocations {
+		allocations[allocation.AssetClass] = allocation.Value
+	}
+
+	return n.Send(ctx, WebhookEvent{
+		Type:    "comprehensive_report",
+		Kind:    KindReport,
+		Message: report,
+		Data: map[string]interface{}{
+			"total_value":        summary.TotalValue,
+			"total_cost":         summary.TotalCost,
+			"total_gain":         summary.TotalGain,
+			"total_gain_percent": summary.TotalGainPercent,
+			"holdings":           holdings,
+			"allocations":        allocations,
+		},
+	})
+}
+
+// Send posts an event, retrying on network errors, 429 and 5xx responses.
+func (n *WebhookNotifier) Send(ctx context.Context, event WebhookEvent) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	body, err := n.payload(event)
+	if err != nil {
+		return err
+	}
+
+	policy := newRetryPolicy("webhook", n.maxRetries, n.retryDelay)
+	attempts, err := policy.Do(ctx, func(ctx context.Context) error {
+		if err := n.post(ctx, body); err != nil {
+			logrus.WithField("error", err).Error("Failed to send webhook notification")
+			return err
+		}
+		logrus.WithFields(logrus.Fields{
+			"type": event.Type,
+			"kind": event.Kind,
+		}).Info("Successfully sent webhook notification")
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send webhook notification after %d attempts: %w", attempts, err)
+	}
+	return nil
+}
+
+// payload renders the request body of an event.
+func (n *WebhookNotifier) payload(event WebhookEvent) ([]byte, error) {
+	if n.template == nil {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal webhook event: %w", err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := n.template.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("failed to render webhook payload: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("webhook payload template rendered invalid JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// post sends a request body once. Failures not worth retrying are marked with retry.Permanent.
+func (n *WebhookNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", n.url, bytes.NewReader(body))
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", "Stock-Automation/1.0")
+	if len(n.secret) > 0 {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(n.secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return classifyHTTPStatus(resp, fmt.Errorf("webhook returned status code: %d", resp.StatusCode))
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the signature header value of a request body, "sha256=" followed by
+// the hex encoded HMAC-SHA256 of the body. Receivers verify a request by computing it with the shared secret.
+func SignWebhookPayload(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_SendStockAlert(t *testing.T) {
+	var event WebhookEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json; charset=utf-8", r.Header.Get("Content-Type"))
+		assert.Equal(t, SignWebhookPayload([]byte("secret"), body), r.Header.Get(WebhookSignatureHeader))
+		assert.NoError(t, json.Unmarshal(body, &event))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{URL: server.URL, Secret: "secret"})
+	require.NoError(t, err)
+
+	err = notifier.SendStockAlert(context.Background(), "7203", "Toyota", 2500, 2400, "buy")
+	assert.NoError(t, err)
+	assert.Equal(t, "stock_alert", event.Type)
+	assert.Equal(t, KindCritical, event.Kind)
+	assert.Equal(t, "7203", event.Data["stock_code"])
+	assert.Equal(t, 2500.0, event.Data["current_price"])
+	assert.False(t, event.Timestamp.IsZero())
+}
+
+func TestWebhookNotifier_PayloadTemplate(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		assert.Empty(t, r.Header.Get(WebhookSignatureHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{
+		URL:             server.URL,
+		PayloadTemplate: `{"title": {{json .Type}}, "body": {{json .Message}}, "kind": "{{.Kind}}"}`,
+	})
+	require.NoError(t, err)
+
+	err = notifier.SendMessageOfKind(context.Background(), KindReport, "line 1\n\"quoted\"")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"title": "message", "body": "line 1\n\"quoted\"", "kind": "report"}`, string(body))
+}
+
+func TestWebhookNotifier_InvalidPayloadTemplate(t *testing.T) {
+	_, err := NewWebhookNotifier(WebhookConfig{URL: "http://localhost", PayloadTemplate: `{"text": {{.Message}`})
+	assert.Error(t, err)
+
+	// Rendering a message without the json function breaks the JSON body
+	notifier, err := NewWebhookNotifier(WebhookConfig{URL: "http://localhost", PayloadTemplate: `{"text": {{.Message}}}`})
+	require.NoError(t, err)
+	err = notifier.SendMessage(context.Background(), "not quoted")
+	assert.ErrorContains(t, err, "invalid JSON")
+}
+
+func TestWebhookNotifier_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{"Recovers from server errors", []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK}, false, 3},
+		{"Gives up after max retries", []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, true, 3},
+		{"Does not retry client errors", []int{http.StatusBadRequest, http.StatusOK}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			notifier, err := NewWebhookNotifier(WebhookConfig{
+				URL:        server.URL,
+				MaxRetries: 2,
+				RetryDelay: 10 * time.Millisecond,
+			})
+			require.NoError(t, err)
+
+			err = notifier.SendMessage(context.Background(), "Test message")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
+// recordingNotifier records the messages sent to it and fails when err is set.
+type recordingNotifier struct {
+	NotificationService
+	messages []string
+	err      error
+}
+
+func (r *recordingNotifier) SendMessageOfKind(ctx context.Context, kind MessageKind, message string) error {
+	r.messages = append(r.messages, string(kind)+": "+message)
+	return r.err
+}
+
+func TestMultiNotifier(t *testing.T) {
+	failing := &recordingNotifier{err: errors.New("unavailable")}
+	working := &recordingNotifier{}
+	multi := NewMultiNotifier(failing, working)
+
+	err := multi.SendMessageOfKind(context.Background(), KindCritical, "alert")
+	assert.ErrorContains(t, err, "unavailable")
+	assert.Equal(t, []string{"critical: alert"}, working.messages)
+
+	// Notifiers without comprehensive report support receive the report text
+	err = multi.SendComprehensiveReport(context.Background(), "report", &domain.PortfolioSummary{})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"critical: alert", "report: report"}, working.messages)
+}
@@ -0,0 +1,205 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// fontName is the Japanese font used for all text. It is one of the fonts PDF viewers
+// supply themselves, so Japanese text can be written without embedding font files.
+const fontName = "HeiseiKakuGo-W5"
+
+// Color is an RGB color with components from 0 to 1.
+type Color struct {
+	R, G, B float64
+}
+
+// Colors used by the report layout.
+var (
+	Black     = Color{0, 0, 0}
+	Gray      = Color{0.5, 0.5, 0.5}
+	LightGray = Color{0.92, 0.92, 0.92}
+	Green     = Color{0.13, 0.55, 0.27}
+	Red       = Color{0.8, 0.2, 0.2}
+	Blue      = Color{0.2, 0.4, 0.75}
+)
+
+// Point is a position on a page in points.
+type Point struct {
+	X, Y float64
+}
+
+// Document is a minimal PDF 1.4 writer with text, lines and filled rectangles.
+// Positions are measured from the top-left corner of the page.
+// Characters outside the Basic Multilingual Plane, such as emoji, cannot be drawn and are dropped.
+type Document struct {
+	pages []*bytes.Buffer
+}
+
+// NewDocument creates an empty document. AddPage must be called before drawing.
+func NewDocument() *Document {
+	return &Document{}
+}
+
+// AddPage starts a new page, on which the following drawing is done.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// PageCount returns the number of pages.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text draws text with its baseline at y.
+func (d *Document) Text(x, y, size float64, color Color, text string) {
+	encoded := encodeText(text)
+	if encoded == "" {
+		return
+	}
+	fmt.Fprintf(d.page(), "BT %s rg /F1 %s Tf %s %s Td <%s> Tj ET\n",
+		formatColor(color), formatNumber(size), formatNumber(x), formatNumber(PageHeight-y), encoded)
+}
+
+// Line draws a straight line.
+func (d *Document) Line(x1, y1, x2, y2, width float64, color Color) {
+	d.Polyline([]Point{{x1, y1}, {x2, y2}}, width, color)
+}
+
+// Polyline draws lines connecting the points in order.
+func (d *Document) Polyline(points []Point, width float64, color Color) {
+	if len(points) < 2 {
+		return
+	}
+	w := d.page()
+	fmt.Fprintf(w, "%s RG %s w ", formatColor(color), formatNumber(width))
+	for i, p := range points {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(w, "%s %s %s ", formatNumber(p.X), formatNumber(PageHeight-p.Y), op)
+	}
+	w.WriteString("S\n")
+}
+
+// Rect draws a filled rectangle whose top-left corner is at (x, y).
+func (d *Document) Rect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(d.page(), "%s rg %s %s %s %s re f\n",
+		formatColor(color), formatNumber(x), formatNumber(PageHeight-y-height), formatNumber(width), formatNumber(height))
+}
+
+// TextWidth returns the width of text drawn at the font size. ASCII and half-width katakana
+// take half the width of other characters.
+func TextWidth(text string, size float64) float64 {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case !drawable(r):
+		case r < 0x7F || (r >= 0xFF61 && r <= 0xFF9F):
+			width += 0.5
+		default:
+			width += 1
+		}
+	}
+	return width * size
+}
+
+// WriteTo writes the document as a PDF file.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*bytes.Buffer{{}}
+	}
+
+	// Objects 1-5 are the catalog, the page tree and the font, followed by a page and its contents per page
+	const firstPage = 6
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s-UniJIS-UCS2-H /Encoding /UniJIS-UCS2-H /DescendantFonts [4 0 R] >>", fontName),
+		// Widths of the proportional ASCII and half-width katakana glyphs, matching TextWidth
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor 5 0 R /DW 1000 /W [1 100 500 327 389 500] >>", fontName),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 737 /StemV 114 >>", fontName),
+	)
+	for i, content := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				formatNumber(PageWidth), formatNumber(PageHeight), firstPage+2*i+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// page returns the contents of the current page, starting the first page if needed.
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// encodeText encodes text in UTF-16BE hex for the UniJIS-UCS2-H encoding, dropping the
+// characters it cannot represent.
+func encodeText(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		if !drawable(r) {
+			continue
+		}
+		fmt.Fprintf(&sb, "%04X", r)
+	}
+	return sb.String()
+}
+
+// drawable reports whether the character can be represented in the UniJIS-UCS2-H encoding.
+// Variation selectors are dropped too, as they only follow emoji.
+func drawable(r rune) bool {
+	return r >= 0x20 && r <= 0xFFFF && r != 0x7F && (r < 0xD800 || r > 0xDFFF) && r != 0xFE0F
+}
+
+// formatColor formats a color as the operands of the rg and RG operators.
+func formatColor(c Color) string {
+	return formatNumber(c.R) + " " + formatNumber(c.G) + " " + formatNumber(c.B)
+}
+
+// formatNumber formats a number with up to two decimals, as PDF does not accept exponents.
+func formatNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-" || s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	doc := NewDocument()
+	doc.AddPage()
+	doc.Text(40, 60, 12, Black, "評価額 ¥1,000 📈")
+	doc.Rect(40, 100, 50, 20, Green)
+	doc.AddPage()
+	doc.Line(40, 40, 200, 40, 1, Gray)
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("Output is not a PDF file:\n%s", out)
+	}
+	// The emoji is dropped and the rest is encoded in UTF-16BE
+	if want := "<8A554FA1984D002000A50031002C0030003000300020>"; !strings.Contains(out, want) {
+		t.Errorf("Text not encoded as %s:\n%s", want, out)
+	}
+	if !strings.Contains(out, "/Count 2") {
+		t.Errorf("Page count 2 not found:\n%s", out)
+	}
+
+	// Every object is at the offset in the cross-reference table
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	if match == nil {
+		t.Fatalf("startxref not found")
+	}
+	xref, _ := strconv.Atoi(match[1])
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out[xref:], -1)
+	if len(entries) != 9 {
+		t.Fatalf("Cross-reference entries = %d, want 9", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[1])
+		if want := fmt.Sprintf("%d 0 obj", i+1); !strings.HasPrefix(out[offset:], want) {
+			t.Errorf("Offset %d of object %d points to %q", offset, i+1, out[offset:offset+10])
+		}
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	rows := make([][]string, 80)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(7000 + i), "とても長い銘柄名の株式会社ホールディングス", "1,000"}
+	}
+	report := Report{
+		Title:    "ポートフォリオレポート",
+		Subtitle: "2024-06-07 15:30",
+		Sections: []Section{
+			{Heading: "サマリー", Lines: []string{"評価額: ¥1,000,000"}},
+			{Heading: "保有銘柄", Table: &Table{
+				Headers:    []string{"コード", "銘柄名", "評価額"},
+				Rows:       rows,
+				AlignRight: []bool{false, false, true},
+			}},
+			{Heading: "評価額推移", Chart: &Chart{Kind: LineChart, Labels: []string{"6/5", "6/6", "6/7"}, Values: []float64{100, 120, 90}}},
+			{Heading: "銘柄別損益", Chart: &Chart{Kind: BarChart, Labels: []string{"7203", "6758"}, Values: []float64{5000, -3000}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderReport(&buf, report); err != nil {
+		t.Fatalf("RenderReport() error = %v", err)
+	}
+	out := buf.String()
+
+	// The table continues on the next pages with its header repeated
+	if pages := strings.Count(out, "/Type /Page "); pages < 2 {
+		t.Errorf("Pages = %d, want 2 or more", pages)
+	}
+	if headers := strings.Count(out, "<"+encodeText("コード")+">"); headers < 2 {
+		t.Errorf("Table header drawn %d times, want once per page", headers)
+	}
+	// Long names are shortened to the column width
+	if strings.Contains(out, encodeText("ホールディングス")) {
+		t.Errorf("Long cell not shortened")
+	}
+}
+
+func TestFitText(t *testing.T) {
+	if got := fitText("7203", 10, 100); got != "7203" {
+		t.Errorf("fitText() = %q, want the text unchanged", got)
+	}
+	got := fitText("トヨタ自動車株式会社", 10, 50)
+	if got != "トヨタ自…" {
+		t.Errorf("fitText() = %q, want トヨタ自…", got)
+	}
+}
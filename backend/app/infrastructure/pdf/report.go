@@ -0,0 +1,273 @@
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// Report layout in points.
+const (
+	margin       = 40.0
+	contentWidth = PageWidth - 2*margin
+	lineHeight   = 15.0
+	rowHeight    = 16.0
+	chartHeight  = 140.0
+	axisWidth    = 60.0
+)
+
+// Report is the content of a report: a title followed by sections laid out from the top of the
+// first page, continued on new pages as needed.
+type Report struct {
+	Title    string
+	Subtitle string
+	Sections []Section
+}
+
+// Section is a headed block of a report. Its lines, table and chart are drawn in that order.
+type Section struct {
+	Heading string
+	Lines   []string
+	Table   *Table
+	Chart   *Chart
+}
+
+// Table is a table with a header row, repeated on each page the table continues on.
+type Table struct {
+	Headers    []string
+	Rows       [][]string
+	Widths     []float64 // relative widths of the columns, equal if empty
+	AlignRight []bool    // columns aligned to the right, e.g. amounts
+}
+
+// ChartKind is the kind of a chart.
+type ChartKind int
+
+const (
+	// BarChart draws a bar per value, green above zero and red below.
+	BarChart ChartKind = iota
+	// LineChart draws a line through the values, e.g. a time series.
+	LineChart
+)
+
+// Chart is a simple chart of values with a label each.
+type Chart struct {
+	Kind   ChartKind
+	Labels []string
+	Values []float64
+	Format func(v float64) string // formats the axis values, as integers if nil
+}
+
+// RenderReport lays out the report on A4 pages and writes it as a PDF file.
+func RenderReport(w io.Writer, report Report) error {
+	r := &reportRenderer{doc: NewDocument()}
+	r.newPage()
+
+	r.y += 18
+	r.doc.Text(margin, r.y, 18, Black, report.Title)
+	if report.Subtitle != "" {
+		r.y += 16
+		r.doc.Text(margin, r.y, 9, Gray, report.Subtitle)
+	}
+	r.y += 8
+
+	for _, section := range report.Sections {
+		r.section(section)
+	}
+
+	if _, err := r.doc.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+// reportRenderer draws the report from top to bottom, keeping the position of the next block.
+type reportRenderer struct {
+	doc *Document
+	y   float64
+}
+
+func (r *reportRenderer) newPage() {
+	r.doc.AddPage()
+	r.y = margin
+}
+
+// ensure starts a new page unless the height fits in the rest of the current page.
+func (r *reportRenderer) ensure(height float64) {
+	if r.y+height > PageHeight-margin {
+		r.newPage()
+	}
+}
+
+func (r *reportRenderer) section(section Section) {
+	// Keep the heading together with the first lines of its content
+	r.ensure(24 + 3*lineHeight)
+	r.y += 24
+	r.doc.Text(margin, r.y, 13, Black, section.Heading)
+	r.y += 5
+	r.doc.Line(margin, r.y, margin+contentWidth, r.y, 0.5, Gray)
+	r.y += 4
+
+	for _, line := range section.Lines {
+		r.ensure(lineHeight)
+		r.y += lineHeight
+		r.doc.Text(margin, r.y, 10, Black, fitText(line, 10, contentWidth))
+	}
+	if section.Table != nil {
+		r.y += 6
+		r.table(section.Table)
+	}
+	if section.Chart != nil && len(section.Chart.Values) > 0 {
+		r.y += 6
+		r.chart(section.Chart)
+	}
+}
+
+func (r *reportRenderer) table(table *Table) {
+	widths := make([]float64, len(table.Headers))
+	total := 0.0
+	for i := range widths {
+		widths[i] = 1
+		if i < len(table.Widths) && table.Widths[i] > 0 {
+			widths[i] = table.Widths[i]
+		}
+		total += widths[i]
+	}
+	for i := range widths {
+		widths[i] = widths[i] / total * contentWidth
+	}
+
+	row := func(cells []string, size float64, color Color) {
+		x := margin
+		for i, width := range widths {
+			if i < len(cells) {
+				text := fitText(cells[i], size, width-6)
+				tx := x + 3
+				if i < len(table.AlignRight) && table.AlignRight[i] {
+					tx = x + width - 3 - TextWidth(text, size)
+				}
+				r.doc.Text(tx, r.y+rowHeight-4.5, size, color, text)
+			}
+			x += width
+		}
+		r.y += rowHeight
+	}
+	header := func() {
+		r.doc.Rect(margin, r.y, contentWidth, rowHeight, LightGray)
+		row(table.Headers, 9, Black)
+	}
+
+	r.ensure(2 * rowHeight)
+	header()
+	for _, cells := range table.Rows {
+		if r.y+rowHeight > PageHeight-margin {
+			r.newPage()
+			header()
+		}
+		row(cells, 9, Black)
+		r.doc.Line(margin, r.y, margin+contentWidth, r.y, 0.3, LightGray)
+	}
+}
+
+func (r *reportRenderer) chart(chart *Chart) {
+	format := chart.Format
+	if format == nil {
+		format = func(v float64) string { return fmt.Sprintf("%.0f", v) }
+	}
+
+	r.ensure(chartHeight + 30)
+	left, right := margin+axisWidth, margin+contentWidth
+	top := r.y + 8
+	bottom := top + chartHeight
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range chart.Values {
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	if chart.Kind == BarChart {
+		low, high = math.Min(low, 0), math.Max(high, 0)
+	}
+	if high == low {
+		low, high = low-1, high+1
+	}
+	scale := func(v float64) float64 {
+		return bottom - (v-low)/(high-low)*chartHeight
+	}
+
+	// Axis with grid lines at the top, the bottom and zero
+	ticks := []float64{high, low}
+	if low < 0 && high > 0 {
+		ticks = append(ticks, 0)
+	}
+	for _, tick := range ticks {
+		y := scale(tick)
+		r.doc.Line(left, y, right, y, 0.3, LightGray)
+		label := format(tick)
+		r.doc.Text(left-4-TextWidth(label, 8), y+3, 8, Gray, label)
+	}
+	r.doc.Line(left, top, left, bottom, 0.5, Gray)
+
+	n := len(chart.Values)
+	slot := (right - left) / float64(n)
+	x := func(i int) float64 {
+		if chart.Kind == LineChart && n > 1 {
+			return left + (right-left)*float64(i)/float64(n-1)
+		}
+		return left + slot*(float64(i)+0.5)
+	}
+
+	switch chart.Kind {
+	case BarChart:
+		zero := scale(0)
+		for i, v := range chart.Values {
+			color := Green
+			if v < 0 {
+				color = Red
+			}
+			y := scale(v)
+			r.doc.Rect(x(i)-slot*0.3, math.Min(y, zero), slot*0.6, math.Abs(zero-y), color)
+		}
+	case LineChart:
+		points := make([]Point, n)
+		for i, v := range chart.Values {
+			points[i] = Point{x(i), scale(v)}
+		}
+		if n == 1 {
+			r.doc.Rect(points[0].X-1.5, points[0].Y-1.5, 3, 3, Blue)
+		}
+		r.doc.Polyline(points, 1.2, Blue)
+	}
+
+	// Labels, thinned out so that they do not overlap
+	widest := 0.0
+	for _, label := range chart.Labels {
+		widest = math.Max(widest, TextWidth(label, 7))
+	}
+	step := 1
+	if widest > 0 {
+		step = max(int(math.Ceil((widest+6)/((right-left)/float64(n)))), 1)
+	}
+	for i, label := range chart.Labels {
+		if i >= n || i%step != 0 {
+			continue
+		}
+		r.doc.Text(x(i)-TextWidth(label, 7)/2, bottom+11, 7, Gray, label)
+	}
+
+	r.y = bottom + 16
+}
+
+// fitText shortens text with an ellipsis to fit in the width at the font size.
+func fitText(text string, size, width float64) string {
+	if TextWidth(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if shortened := string(runes) + "…"; TextWidth(shortened, size) <= width {
+			return shortened
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package pdf
+
+import (
+	"io"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+)
+
+// DocumentRenderer renders the report documents of the domain as PDF reports.
+// The emoji decorations are left out as the font cannot draw them.
+type DocumentRenderer struct{}
+
+// Render writes the document as a PDF file.
+func (DocumentRenderer) Render(w io.Writer, doc *domain.ReportDocument) error {
+	return RenderReport(w, ReportFromDocument(doc))
+}
+
+// ReportFromDocument lays out a report document as sections of a PDF report. Each heading starts a section,
+// as does a block following a table since a section draws its table after its lines.
+func ReportFromDocument(doc *domain.ReportDocument) Report {
+	report := Report{Title: doc.Title, Subtitle: doc.Subtitle}
+	// section returns the section taking the next lines, starting one if needed
+	section := func() *Section {
+		if n := len(report.Sections); n > 0 && report.Sections[n-1].Table == nil {
+			return &report.Sections[n-1]
+		}
+		report.Sections = append(report.Sections, Section{})
+		return &report.Sections[len(report.Sections)-1]
+	}
+
+	for _, block := range doc.Blocks {
+		switch block.Kind {
+		case domain.ReportHeading:
+			report.Sections = append(report.Sections, Section{Heading: block.Text})
+		case domain.ReportText:
+			s := section()
+			s.Lines = append(s.Lines, block.Text)
+		case domain.ReportItem:
+			s := section()
+			s.Lines = append(s.Lines, block.Text)
+			for _, detail := range block.Details {
+				s.Lines = append(s.Lines, "    "+detail)
+			}
+		case domain.ReportBullet:
+			s := section()
+			s.Lines = append(s.Lines, "・"+block.Text)
+		case domain.ReportTable:
+			s := section()
+			s.Table = &Table{Headers: block.Table.Headers, Rows: block.Table.Rows, AlignRight: block.Table.AlignRight}
+		case domain.ReportBlank:
+			// Keep the spacing within a section, sections are spaced by the layout
+			if n := len(report.Sections); n > 0 && report.Sections[n-1].Table == nil && len(report.Sections[n-1].Lines) > 0 {
+				report.Sections[n-1].Lines = append(report.Sections[n-1].Lines, "")
+			}
+		}
+	}
+	return report
+}
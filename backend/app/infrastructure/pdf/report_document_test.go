@@ -0,0 +1,46 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReportFromDocument(t *testing.T) {
+	doc := domain.NewReportBuilder("レポート").
+		Subtitle("2024-01-05").
+		Line("概要").
+		Section("💼", "保有銘柄").
+		Item("📈", "トヨタ (7203)", "評価額: ¥1,000").
+		Blank().
+		Table([]string{"コード", "評価額"}, [][]string{{"7203", "¥1,000"}}, false, true).
+		Bullet("メモ").
+		Document()
+
+	want := Report{
+		Title:    "レポート",
+		Subtitle: "2024-01-05",
+		Sections: []Section{
+			{Lines: []string{"概要"}},
+			{
+				Heading: "保有銘柄",
+				Lines:   []string{"トヨタ (7203)", "    評価額: ¥1,000", ""},
+				Table:   &Table{Headers: []string{"コード", "評価額"}, Rows: [][]string{{"7203", "¥1,000"}}, AlignRight: []bool{false, true}},
+			},
+			{Lines: []string{"・メモ"}},
+		},
+	}
+	if diff := cmp.Diff(want, ReportFromDocument(doc)); diff != "" {
+		t.Errorf("ReportFromDocument() mismatch (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if err := (DocumentRenderer{}).Render(&buf, doc); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Errorf("Render() output is not a PDF file")
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// AdviceLogRepository defines advice log related operations.
+type AdviceLogRepository interface {
+	Save(ctx context.Context, log *models.AdviceLog) error
+	GetSince(ctx context.Context, adviceType string, since time.Time) ([]*models.AdviceLog, error)
+	GetLatest(ctx context.Context, adviceType string, limit int) ([]*models.AdviceLog, error)
+}
+
+// adviceLogRepositoryImpl implements AdviceLogRepository.
+type adviceLogRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewAdviceLogRepository creates a new advice log repository.
+func NewAdviceLogRepository(db boil.ContextExecutor) AdviceLogRepository {
+	return &adviceLogRepositoryImpl{db: db}
+}
+
+const adviceLogColumns = "id, advice_type, action, code, amount, reason, trigger_codes, notified, created_at"
+
+// Save records an advice.
+func (r *adviceLogRepositoryImpl) Save(ctx context.Context, log *models.AdviceLog) error {
+	if log.ID == "" {
+		log.ID = utility.NewULID()
+	}
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO advice_logs (id, advice_type, action, code, amount, reason, trigger_codes, notified, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		log.ID,
+		log.AdviceType,
+		log.Action,
+		log.Code,
+		log.Amount,
+		log.Reason,
+		log.TriggerCodes,
+		log.Notified,
+		log.CreatedAt,
+	)
+	return err
+}
+
+// GetSince retrieves the advice of a type recorded at or after since, oldest first.
+func (r *adviceLogRepositoryImpl) GetSince(ctx context.Context, adviceType string, since time.Time) ([]*models.AdviceLog, error) {
+	query := "SELECT " + adviceLogColumns + " FROM advice_logs WHERE advice_type = ? AND created_at >= ? ORDER BY created_at"
+	return r.query(ctx, query, adviceType, since)
+}
+
+// GetLatest retrieves the latest advice, newest first. All types are included if adviceType is empty.
+func (r *adviceLogRepositoryImpl) GetLatest(ctx context.Context, adviceType string, limit int) ([]*models.AdviceLog, error) {
+	query := "SELECT " + adviceLogColumns + " FROM advice_logs"
+	args := []interface{}{}
+	if adviceType != "" {
+		query += " WHERE advice_type = ?"
+		args = append(args, adviceType)
+	}
+	query += " ORDER BY created_at DESC LIMIT ?"
+	args = append(args, limit)
+	return r.query(ctx, query, args...)
+}
+
+// query runs a query returning advice log rows.
+func (r *adviceLogRepositoryImpl) query(ctx context.Context, query string, args ...interface{}) ([]*models.AdviceLog, error) {
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*models.AdviceLog{}
+	for rows.Next() {
+		log, err := scanAdviceLog(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// scanAdviceLog scans an advice log row.
+func scanAdviceLog(row rowScanner) (*models.AdviceLog, error) {
+	log := &models.AdviceLog{}
+	err := row.Scan(
+		&log.ID,
+		&log.AdviceType,
+		&log.Action,
+		&log.Code,
+		&log.Amount,
+		&log.Reason,
+		&log.TriggerCodes,
+		&log.Notified,
+		&log.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
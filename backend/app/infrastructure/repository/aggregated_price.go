@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// AggregatedPriceRepository defines weekly and monthly price bar related operations.
+type AggregatedPriceRepository interface {
+	SaveAggregatedPrices(ctx context.Context, period models.PricePeriod, prices []*models.AggregatedPrice) error
+	GetAggregatedPrices(ctx context.Context, period models.PricePeriod, code string, limit int) ([]*models.AggregatedPrice, error)
+}
+
+// aggregatedPriceRepositoryImpl implements AggregatedPriceRepository.
+type aggregatedPriceRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewAggregatedPriceRepository creates a new aggregated price repository.
+func NewAggregatedPriceRepository(db boil.ContextExecutor) AggregatedPriceRepository {
+	return &aggregatedPriceRepositoryImpl{db: db}
+}
+
+// aggregatedPriceTables maps price periods to their tables.
+var aggregatedPriceTables = map[models.PricePeriod]string{
+	models.PricePeriodWeekly:  "stock_prices_weekly",
+	models.PricePeriodMonthly: "stock_prices_monthly",
+}
+
+// aggregatedPriceBatchSize is the number of bars saved by one statement.
+const aggregatedPriceBatchSize = 500
+
+func aggregatedPriceTable(period models.PricePeriod) (string, error) {
+	table, ok := aggregatedPriceTables[period]
+	if !ok {
+		return "", fmt.Errorf("unknown price period: %s", period)
+	}
+	return table, nil
+}
+
+// SaveAggregatedPrices saves price bars, replacing the bars of the same code and period start.
+func (r *aggregatedPriceRepositoryImpl) SaveAggregatedPrices(ctx context.Context, period models.PricePeriod, prices []*models.AggregatedPrice) error {
+	table, err := aggregatedPriceTable(period)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(prices); start += aggregatedPriceBatchSize {
+		batch := prices[start:min(start+aggregatedPriceBatchSize, len(prices))]
+
+		values := make([]string, len(batch))
+		args := make([]interface{}, 0, len(batch)*10)
+		for i, price := range batch {
+			values[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+			args = append(args,
+				price.Code,
+				price.PeriodStart.Format("2006-01-02"),
+				price.PeriodEnd.Format("2006-01-02"),
+				price.OpenPrice,
+				price.HighPrice,
+				price.LowPrice,
+				price.ClosePrice,
+				price.AdjClosePrice,
+				price.Volume,
+				price.TradingDays,
+			)
+		}
+
+		query := "INSERT INTO " + table + ` (code, period_start, period_end, open_price, high_price, low_price, close_price, adj_close_price, volume, trading_days)
+			VALUES ` + strings.Join(values, ", ") + `
+			ON DUPLICATE KEY UPDATE
+				period_end = VALUES(period_end),
+				open_price = VALUES(open_price),
+				high_price = VALUES(high_price),
+				low_price = VALUES(low_price),
+				close_price = VALUES(close_price),
+				adj_close_price = VALUES(adj_close_price),
+				volume = VALUES(volume),
+				trading_days = VALUES(trading_days)`
+
+		if _, err := getExecutor(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAggregatedPrices retrieves the latest limit bars of a stock, oldest first.
+func (r *aggregatedPriceRepositoryImpl) GetAggregatedPrices(ctx context.Context, period models.PricePeriod, code string, limit int) ([]*models.AggregatedPrice, error) {
+	code = domain.NormalizeCode(code)
+	table, err := aggregatedPriceTable(period)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT code, period_start, period_end, open_price, high_price, low_price, close_price, adj_close_price, volume, trading_days, created_at, updated_at
+		FROM ` + table + `
+		WHERE code = ?
+		ORDER BY period_start DESC
+		LIMIT ?`
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, code, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []*models.AggregatedPrice{}
+	for rows.Next() {
+		price := &models.AggregatedPrice{}
+		err := rows.Scan(
+			&price.Code,
+			&price.PeriodStart,
+			&price.PeriodEnd,
+			&price.OpenPrice,
+			&price.HighPrice,
+			&price.LowPrice,
+			&price.ClosePrice,
+			&price.AdjClosePrice,
+			&price.Volume,
+			&price.TradingDays,
+			&price.CreatedAt,
+			&price.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Oldest first
+	for i, j := 0, len(prices)-1; i < j; i, j = i+1, j-1 {
+		prices[i], prices[j] = prices[j], prices[i]
+	}
+
+	return prices, nil
+}
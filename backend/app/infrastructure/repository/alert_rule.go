@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// AlertRuleRepository defines alert rule and evaluation history related operations.
+type AlertRuleRepository interface {
+	Create(ctx context.Context, rule *models.AlertRule) error
+	GetByName(ctx context.Context, name string) (*models.AlertRule, error)
+	GetAll(ctx context.Context) ([]*models.AlertRule, error)
+	Update(ctx context.Context, rule *models.AlertRule) error
+	Delete(ctx context.Context, id string) error
+
+	// Evaluation history operations
+	SaveEvaluation(ctx context.Context, evaluation *models.AlertRuleEvaluation) error
+	GetLastNotifiedAt(ctx context.Context, ruleID, code string) (*time.Time, error)
+	GetEvaluations(ctx context.Context, ruleID string, limit int) ([]*models.AlertRuleEvaluation, error)
+}
+
+// alertRuleRepositoryImpl implements AlertRuleRepository.
+type alertRuleRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewAlertRuleRepository creates a new alert rule repository.
+func NewAlertRuleRepository(db boil.ContextExecutor) AlertRuleRepository {
+	return &alertRuleRepositoryImpl{db: db}
+}
+
+const alertRuleColumns = "id, name, definition, enabled, created_at, updated_at"
+
+// Create creates a new alert rule.
+func (r *alertRuleRepositoryImpl) Create(ctx context.Context, rule *models.AlertRule) error {
+	if rule.ID == "" {
+		rule.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO alert_rules (id, name, definition, enabled)
+		VALUES (?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Definition,
+		rule.Enabled,
+	)
+	return err
+}
+
+// GetByName retrieves an alert rule by its name.
+// Returns nil if the rule does not exist.
+func (r *alertRuleRepositoryImpl) GetByName(ctx context.Context, name string) (*models.AlertRule, error) {
+	query := "SELECT " + alertRuleColumns + " FROM alert_rules WHERE name = ?"
+
+	rule, err := scanAlertRule(getExecutor(ctx, r.db).QueryRowContext(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetAll retrieves all alert rules ordered by name.
+func (r *alertRuleRepositoryImpl) GetAll(ctx context.Context) ([]*models.AlertRule, error) {
+	query := "SELECT " + alertRuleColumns + " FROM alert_rules ORDER BY name"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []*models.AlertRule{}
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Update updates an existing alert rule.
+func (r *alertRuleRepositoryImpl) Update(ctx context.Context, rule *models.AlertRule) error {
+	query := `
+		UPDATE alert_rules
+		SET name = ?, definition = ?, enabled = ?
+		WHERE id = ?`
+
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		rule.Name,
+		rule.Definition,
+		rule.Enabled,
+		rule.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("alert rule not found: %s", rule.ID)
+	}
+
+	return nil
+}
+
+// Delete removes an alert rule and its evaluation history.
+func (r *alertRuleRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if _, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM alert_rule_evaluations WHERE alert_rule_id = ?", id); err != nil {
+		return err
+	}
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM alert_rules WHERE id = ?", id)
+	return err
+}
+
+// SaveEvaluation saves a rule evaluation to the history.
+func (r *alertRuleRepositoryImpl) SaveEvaluation(ctx context.Context, evaluation *models.AlertRuleEvaluation) error {
+	if evaluation.ID == "" {
+		evaluation.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO alert_rule_evaluations (id, alert_rule_id, code, metric_values, notified, evaluated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		evaluation.ID,
+		evaluation.AlertRuleID,
+		evaluation.Code,
+		evaluation.MetricValues,
+		evaluation.Notified,
+		evaluation.EvaluatedAt,
+	)
+	return err
+}
+
+// GetLastNotifiedAt returns when the rule was last notified for a stock.
+// Returns nil if it has never been notified.
+func (r *alertRuleRepositoryImpl) GetLastNotifiedAt(ctx context.Context, ruleID, code string) (*time.Time, error) {
+	query := `
+		SELECT MAX(evaluated_at)
+		FROM alert_rule_evaluations
+		WHERE alert_rule_id = ? AND code = ? AND notified = TRUE`
+
+	var lastNotifiedAt sql.NullTime
+	if err := getExecutor(ctx, r.db).QueryRowContext(ctx, query, ruleID, code).Scan(&lastNotifiedAt); err != nil {
+		return nil, err
+	}
+
+	if !lastNotifiedAt.Valid {
+		return nil, nil
+	}
+	return &lastNotifiedAt.Time, nil
+}
+
+// GetEvaluations retrieves the latest evaluations, newest first.
+// All rules are included if ruleID is empty.
+func (r *alertRuleRepositoryImpl) GetEvaluations(ctx context.Context, ruleID string, limit int) ([]*models.AlertRuleEvaluation, error) {
+	query := `
+		SELECT e.id, e.alert_rule_id, ar.name, e.code, e.metric_values, e.notified, e.evaluated_at
+		FROM alert_rule_evaluations e
+		INNER JOIN alert_rules ar ON ar.id = e.alert_rule_id`
+
+	args := []interface{}{}
+	if ruleID != "" {
+		query += " WHERE e.alert_rule_id = ?"
+		args = append(args, ruleID)
+	}
+	query += " ORDER BY e.evaluated_at DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	evaluations := []*models.AlertRuleEvaluation{}
+	for rows.Next() {
+		evaluation := &models.AlertRuleEvaluation{}
+		err := rows.Scan(
+			&evaluation.ID,
+			&evaluation.AlertRuleID,
+			&evaluation.RuleName,
+			&evaluation.Code,
+			&evaluation.MetricValues,
+			&evaluation.Notified,
+			&evaluation.EvaluatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return evaluations, nil
+}
+
+// scanAlertRule scans an alert rule row.
+func scanAlertRule(row rowScanner) (*models.AlertRule, error) {
+	rule := &models.AlertRule{}
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Definition,
+		&rule.Enabled,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
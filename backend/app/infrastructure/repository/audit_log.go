@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// AuditLogFilter narrows down audit logs. Empty fields are not filtered.
+type AuditLogFilter struct {
+	EntityType string
+	Code       string
+	Source     string
+	Since      time.Time
+	Limit      int
+}
+
+// AuditLogRepository defines audit log related operations.
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *models.AuditLog) error
+	Find(ctx context.Context, filter AuditLogFilter) ([]*models.AuditLog, error)
+}
+
+// auditLogRepositoryImpl implements AuditLogRepository.
+type auditLogRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewAuditLogRepository creates a new audit log repository.
+func NewAuditLogRepository(db boil.ContextExecutor) AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+const auditLogColumns = "id, source, actor, action, entity_type, entity_id, code, before_value, after_value, created_at"
+
+// Create records an audit log.
+func (r *auditLogRepositoryImpl) Create(ctx context.Context, log *models.AuditLog) error {
+	if log.ID == "" {
+		log.ID = utility.NewULID()
+	}
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO audit_logs (` + auditLogColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		log.ID,
+		log.Source,
+		log.Actor,
+		log.Action,
+		log.EntityType,
+		log.EntityID,
+		log.Code,
+		log.Before,
+		log.After,
+		log.CreatedAt,
+	)
+	return err
+}
+
+// Find retrieves audit logs matching the filter, newest first.
+func (r *auditLogRepositoryImpl) Find(ctx context.Context, filter AuditLogFilter) ([]*models.AuditLog, error) {
+	query := "SELECT " + auditLogColumns + " FROM audit_logs WHERE 1 = 1"
+
+	args := []interface{}{}
+	if filter.EntityType != "" {
+		query += " AND entity_type = ?"
+		args = append(args, filter.EntityType)
+	}
+	if filter.Code != "" {
+		query += " AND code = ?"
+		args = append(args, filter.Code)
+	}
+	if filter.Source != "" {
+		query += " AND source = ?"
+		args = append(args, filter.Source)
+	}
+	if !filter.Since.IsZero() {
+		query += " AND created_at >= ?"
+		args = append(args, filter.Since)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []*models.AuditLog{}
+	for rows.Next() {
+		log := &models.AuditLog{}
+		err := rows.Scan(
+			&log.ID,
+			&log.Source,
+			&log.Actor,
+			&log.Action,
+			&log.EntityType,
+			&log.EntityID,
+			&log.Code,
+			&log.Before,
+			&log.After,
+			&log.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// auditContextKey is the context key of the operation source.
+type auditContextKey struct{}
+
+// auditSource identifies where an operation came from.
+type auditSource struct {
+	source string
+	actor  string
+}
+
+// WithAuditSource returns a context whose changes are recorded with the given source
+// (models.AuditSourceCLI, models.AuditSourceAPI or models.AuditSourceScheduler) and actor.
+func WithAuditSource(ctx context.Context, source, actor string) context.Context {
+	return context.WithValue(ctx, auditContextKey{}, auditSource{source: source, actor: actor})
+}
+
+// auditSourceFromContext returns the source and actor set by WithAuditSource.
+func auditSourceFromContext(ctx context.Context) (string, string) {
+	if s, ok := ctx.Value(auditContextKey{}).(auditSource); ok {
+		return s.source, s.actor
+	}
+	return models.AuditSourceUnknown, ""
+}
+
+// auditRecorder writes audit logs in the same transaction as the audited change.
+type auditRecorder struct {
+	auditRepo AuditLogRepository
+	txManager TransactionManager
+}
+
+// record runs change and records its audit log in a single transaction.
+// before and after are stored as JSON; nil means the record did not exist.
+func (a *auditRecorder) record(
+	ctx context.Context,
+	action, entityType, entityID, code string,
+	before interface{},
+	change func(ctx context.Context) (after interface{}, err error),
+) error {
+	return a.txManager.WithTx(ctx, func(ctx context.Context) error {
+		after, err := change(ctx)
+		if err != nil {
+			return err
+		}
+
+		beforeJSON, err := auditJSON(before)
+		if err != nil {
+			return err
+		}
+		afterJSON, err := auditJSON(after)
+		if err != nil {
+			return err
+		}
+
+		source, actor := auditSourceFromContext(ctx)
+		log := &models.AuditLog{
+			Source:     source,
+			Actor:      actor,
+			Action:     action,
+			EntityType: entityType,
+			EntityID:   entityID,
+			Code:       code,
+			Before:     beforeJSON,
+			After:      afterJSON,
+		}
+		if err := a.auditRepo.Create(ctx, log); err != nil {
+			return fmt.Errorf("failed to record audit log: %w", err)
+		}
+		return nil
+	})
+}
+
+// auditJSON marshals a record for the audit log.
+func auditJSON(v interface{}) (null.String, error) {
+	if v == nil {
+		return null.String{}, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return null.String{}, fmt.Errorf("failed to marshal audit value: %w", err)
+	}
+	return null.StringFrom(string(b)), nil
+}
+
+// auditedPortfolioRepository records portfolio changes to the audit log.
+type auditedPortfolioRepository struct {
+	PortfolioRepository
+	auditRecorder
+}
+
+// NewAuditedPortfolioRepository wraps a portfolio repository so that creates, updates,
+// deletes and restores are recorded to the audit log in the same transaction.
+func NewAuditedPortfolioRepository(repo PortfolioRepository, auditRepo AuditLogRepository, txManager TransactionManager) PortfolioRepository {
+	return &auditedPortfolioRepository{
+		PortfolioRepository: repo,
+		auditRecorder:       auditRecorder{auditRepo: auditRepo, txManager: txManager},
+	}
+}
+
+// Create creates a portfolio record and records it.
+func (r *auditedPortfolioRepository) Create(ctx context.Context, portfolio *models.Portfolio) error {
+	return r.record(ctx, models.AuditActionCreate, models.AuditEntityPortfolio, portfolio.ID, portfolio.Code, nil,
+		func(ctx context.Context) (interface{}, error) {
+			return portfolio, r.PortfolioRepository.Create(ctx, portfolio)
+		})
+}
+
+// Update updates a portfolio record and records the values before and after.
+func (r *auditedPortfolioRepository) Update(ctx context.Context, portfolio *models.Portfolio) error {
+	return r.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := r.PortfolioRepository.GetByID(ctx, portfolio.ID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return fmt.Errorf("portfolio not found: %s", portfolio.ID)
+		}
+
+		return r.record(ctx, models.AuditActionUpdate, models.AuditEntityPortfolio, portfolio.ID, portfolio.Code, before,
+			func(ctx context.Context) (interface{}, error) {
+				return portfolio, r.PortfolioRepository.Update(ctx, portfolio)
+			})
+	})
+}
+
+// Delete deletes a portfolio record and records the deleted values.
+func (r *auditedPortfolioRepository) Delete(ctx context.Context, id string) error {
+	return r.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := r.PortfolioRepository.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return r.PortfolioRepository.Delete(ctx, id)
+		}
+
+		return r.record(ctx, models.AuditActionDelete, models.AuditEntityPortfolio, id, before.Code, before,
+			func(ctx context.Context) (interface{}, error) {
+				return nil, r.PortfolioRepository.Delete(ctx, id)
+			})
+	})
+}
+
+// Purge removes a portfolio record for good and records the deleted values.
+func (r *auditedPortfolioRepository) Purge(ctx context.Context, id string) error {
+	return r.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := r.PortfolioRepository.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return r.PortfolioRepository.Purge(ctx, id)
+		}
+
+		return r.record(ctx, models.AuditActionDelete, models.AuditEntityPortfolio, id, before.Code, before,
+			func(ctx context.Context) (interface{}, error) {
+				return nil, r.PortfolioRepository.Purge(ctx, id)
+			})
+	})
+}
+
+// Restore restores a soft-deleted portfolio record and records the restored values.
+func (r *auditedPortfolioRepository) Restore(ctx context.Context, id string) error {
+	return r.txManager.WithTx(ctx, func(ctx context.Context) error {
+		deleted, err := r.PortfolioRepository.GetDeleted(ctx)
+		if err != nil {
+			return err
+		}
+		var before *models.Portfolio
+		for _, holding := range deleted {
+			if holding.ID == id {
+				before = holding
+				break
+			}
+		}
+		if before == nil {
+			return fmt.Errorf("deleted portfolio not found: %s", id)
+		}
+
+		return r.record(ctx, models.AuditActionRestore, models.AuditEntityPortfolio, id, before.Code, before,
+			func(ctx context.Context) (interface{}, error) {
+				after := *before
+				after.DeletedAt = null.Time{}
+				return after, r.PortfolioRepository.Restore(ctx, id)
+			})
+	})
+}
+
+// auditedStockRepository records watch list changes to the audit log.
+type auditedStockRepository struct {
+	StockRepository
+	auditRecorder
+}
+
+// NewAuditedStockRepository wraps a stock repository so that watch list creates, updates,
+// deletes and restores are recorded to the audit log in the same transaction.
+func NewAuditedStockRepository(repo StockRepository, auditRepo AuditLogRepository, txManager TransactionManager) StockRepository {
+	return &auditedStockRepository{
+		StockRepository: repo,
+		auditRecorder:   auditRecorder{auditRepo: auditRepo, txManager: txManager},
+	}
+}
+
+// AddToWatchList adds a watch list item and records it.
+func (r *auditedStockRepository) AddToWatchList(ctx context.Context, item *models.WatchList) error {
+	return r.record(ctx, models.AuditActionCreate, models.AuditEntityWatchList, item.ID, item.Code, nil,
+		func(ctx context.Context) (interface{}, error) {
+			return item, r.StockRepository.AddToWatchList(ctx, item)
+		})
+}
+
+// UpdateWatchList updates a watch list item and records the values before and after.
+func (r *auditedStockRepository) UpdateWatchList(ctx context.Context, item *models.WatchList) error {
+	return r.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := r.StockRepository.GetWatchListItem(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return fmt.Errorf("watch list item not found: %s", item.ID)
+		}
+
+		return r.record(ctx, models.AuditActionUpdate, models.AuditEntityWatchList, item.ID, item.Code, before,
+			func(ctx context.Context) (interface{}, error) {
+				return item, r.StockRepository.UpdateWatchList(ctx, item)
+			})
+	})
+}
+
+// DeleteFromWatchList deletes a watch list item and records the deleted values.
+func (r *auditedStockRepository) DeleteFromWatchList(ctx context.Context, id string) error {
+	return r.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := r.StockRepository.GetWatchListItem(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return r.StockRepository.DeleteFromWatchList(ctx, id)
+		}
+
+		return r.record(ctx, models.AuditActionDelete, models.AuditEntityWatchList, id, before.Code, before,
+			func(ctx context.Context) (interface{}, error) {
+				return nil, r.StockRepository.DeleteFromWatchList(ctx, id)
+			})
+	})
+}
+
+// RestoreWatchListItem restores a soft-deleted watch list item and records the restored values.
+func (r *auditedStockRepository) RestoreWatchListItem(ctx context.Context, id string) error {
+	return r.txManager.WithTx(ctx, func(ctx context.Context) error {
+		items, err := r.StockRepository.GetWatchList(ctx, true)
+		if err != nil {
+			return err
+		}
+		var before *models.WatchList
+		for _, item := range items {
+			if item.ID == id && item.DeletedAt.Valid {
+				before = item
+				break
+			}
+		}
+		if before == nil {
+			return fmt.Errorf("deleted watch list item not found: %s", id)
+		}
+
+		return r.record(ctx, models.AuditActionRestore, models.AuditEntityWatchList, id, before.Code, before,
+			func(ctx context.Context) (interface{}, error) {
+				after := *before
+				after.DeletedAt = null.Time{}
+				return after, r.StockRepository.RestoreWatchListItem(ctx, id)
+			})
+	})
+}
@@ -0,0 +1,139 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newAuditedPortfolioRepository keeps holdings and soft-deleted holdings in memory by ID.
+func newAuditedPortfolioRepository(holdings map[string]models.Portfolio) *mock.PortfolioRepositoryMock {
+	deleted := map[string]models.Portfolio{}
+	return &mock.PortfolioRepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.Portfolio, error) {
+			holding, ok := holdings[id]
+			if !ok {
+				return nil, nil
+			}
+			return &holding, nil
+		},
+		UpdateFunc: func(ctx context.Context, portfolio *models.Portfolio) error {
+			holdings[portfolio.ID] = *portfolio
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) error {
+			holding := holdings[id]
+			holding.DeletedAt = null.TimeFrom(deletedAt)
+			deleted[id] = holding
+			delete(holdings, id)
+			return nil
+		},
+		GetDeletedFunc: func(ctx context.Context) ([]*models.Portfolio, error) {
+			var result []*models.Portfolio
+			for _, holding := range deleted {
+				result = append(result, &holding)
+			}
+			return result, nil
+		},
+		RestoreFunc: func(ctx context.Context, id string) error {
+			holding := deleted[id]
+			holding.DeletedAt = null.Time{}
+			holdings[id] = holding
+			delete(deleted, id)
+			return nil
+		},
+	}
+}
+
+// deletedAt is the time holdings are soft-deleted at by newAuditedPortfolioRepository.
+var deletedAt = time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+
+func TestAuditedPortfolioRepository(t *testing.T) {
+	portfolio := newAuditedPortfolioRepository(map[string]models.Portfolio{"p1": {ID: "p1", Code: "7203", Name: "Toyota", Shares: 100}})
+	var logs []*models.AuditLog
+	audit := &mock.AuditLogRepositoryMock{
+		CreateFunc: func(ctx context.Context, log *models.AuditLog) error {
+			logs = append(logs, log)
+			return nil
+		},
+	}
+	txManager := &mock.TransactionManagerMock{
+		WithTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+	repo := repository.NewAuditedPortfolioRepository(portfolio, audit, txManager)
+
+	ctx := repository.WithAuditSource(context.Background(), models.AuditSourceCLI, "alice")
+	if err := repo.Update(ctx, &models.Portfolio{ID: "p1", Code: "7203", Name: "Toyota", Shares: 200}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repo.Delete(context.Background(), "p1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Restore(ctx, "p1"); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	want := []*models.AuditLog{
+		{
+			Source:     models.AuditSourceCLI,
+			Actor:      "alice",
+			Action:     models.AuditActionUpdate,
+			EntityType: models.AuditEntityPortfolio,
+			EntityID:   "p1",
+			Code:       "7203",
+			Before:     null.StringFrom(auditJSONString(t, models.Portfolio{ID: "p1", Code: "7203", Name: "Toyota", Shares: 100})),
+			After:      null.StringFrom(auditJSONString(t, models.Portfolio{ID: "p1", Code: "7203", Name: "Toyota", Shares: 200})),
+		},
+		{
+			Source:     models.AuditSourceUnknown,
+			Action:     models.AuditActionDelete,
+			EntityType: models.AuditEntityPortfolio,
+			EntityID:   "p1",
+			Code:       "7203",
+			Before:     null.StringFrom(auditJSONString(t, models.Portfolio{ID: "p1", Code: "7203", Name: "Toyota", Shares: 200})),
+		},
+		{
+			Source:     models.AuditSourceCLI,
+			Actor:      "alice",
+			Action:     models.AuditActionRestore,
+			EntityType: models.AuditEntityPortfolio,
+			EntityID:   "p1",
+			Code:       "7203",
+			Before: null.StringFrom(auditJSONString(t, models.Portfolio{ID: "p1", Code: "7203", Name: "Toyota", Shares: 200,
+				DeletedAt: null.TimeFrom(deletedAt)})),
+			After: null.StringFrom(auditJSONString(t, models.Portfolio{ID: "p1", Code: "7203", Name: "Toyota", Shares: 200})),
+		},
+	}
+	if diff := cmp.Diff(want, logs); diff != "" {
+		t.Errorf("Audit logs mismatch (-want +got):\n%s", diff)
+	}
+
+	// Updating a missing record fails without recording
+	if err := repo.Update(ctx, &models.Portfolio{ID: "missing"}); err == nil {
+		t.Error("Update() of missing record error = nil, want error")
+	}
+	// Restoring a record not deleted fails without recording
+	if err := repo.Restore(ctx, "p1"); err == nil {
+		t.Error("Restore() of record not deleted error = nil, want error")
+	}
+	if len(logs) != 3 {
+		t.Errorf("Audit logs = %d, want 3", len(logs))
+	}
+}
+
+func auditJSONString(t *testing.T, v interface{}) string {
+	t.Helper()
+	s, err := repository.AuditJSON(v)
+	if err != nil {
+		t.Fatalf("AuditJSON() error = %v", err)
+	}
+	return s.String
+}
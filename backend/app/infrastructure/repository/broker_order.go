@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// BrokerOrderRepository defines broker order and execution related operations.
+type BrokerOrderRepository interface {
+	CreateOrder(ctx context.Context, order *models.BrokerOrder) error
+	GetOrder(ctx context.Context, broker, id string) (*models.BrokerOrder, error)
+	GetOrders(ctx context.Context, broker string, limit int) ([]*models.BrokerOrder, error)
+	GetPendingOrders(ctx context.Context, broker string) ([]*models.BrokerOrder, error)
+	UpdateOrder(ctx context.Context, order *models.BrokerOrder) error
+
+	// Execution operations
+	CreateExecution(ctx context.Context, execution *models.BrokerExecution) error
+	GetExecutions(ctx context.Context, broker string, since time.Time) ([]*models.BrokerExecution, error)
+}
+
+// brokerOrderRepositoryImpl implements BrokerOrderRepository.
+type brokerOrderRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewBrokerOrderRepository creates a new broker order repository.
+func NewBrokerOrderRepository(db boil.ContextExecutor) BrokerOrderRepository {
+	return &brokerOrderRepositoryImpl{db: db}
+}
+
+const brokerOrderColumns = "id, broker, code, side, order_type, quantity, limit_price, status, filled_quantity, average_price, message, created_at, updated_at"
+
+const brokerExecutionColumns = "id, order_id, broker, code, side, quantity, price, executed_at"
+
+// CreateOrder creates a new order.
+func (r *brokerOrderRepositoryImpl) CreateOrder(ctx context.Context, order *models.BrokerOrder) error {
+	if order.ID == "" {
+		order.ID = utility.NewULID()
+	}
+	if !order.CreatedAt.Valid {
+		order.CreatedAt = null.TimeFrom(time.Now())
+	}
+
+	query := `
+		INSERT INTO broker_orders (id, broker, code, side, order_type, quantity, limit_price, status, filled_quantity, average_price, message, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		order.ID,
+		order.Broker,
+		order.Code,
+		order.Side,
+		order.OrderType,
+		order.Quantity,
+		order.LimitPrice,
+		order.Status,
+		order.FilledQuantity,
+		order.AveragePrice,
+		order.Message,
+		order.CreatedAt,
+	)
+	return err
+}
+
+// GetOrder retrieves an order by its ID.
+// Returns nil if the order does not exist.
+func (r *brokerOrderRepositoryImpl) GetOrder(ctx context.Context, broker, id string) (*models.BrokerOrder, error) {
+	query := "SELECT " + brokerOrderColumns + " FROM broker_orders WHERE broker = ? AND id = ?"
+
+	order, err := scanBrokerOrder(getExecutor(ctx, r.db).QueryRowContext(ctx, query, broker, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetOrders retrieves the latest orders, newest first.
+func (r *brokerOrderRepositoryImpl) GetOrders(ctx context.Context, broker string, limit int) ([]*models.BrokerOrder, error) {
+	query := "SELECT " + brokerOrderColumns + " FROM broker_orders WHERE broker = ? ORDER BY created_at DESC, id DESC LIMIT ?"
+
+	return r.queryOrders(ctx, query, broker, limit)
+}
+
+// GetPendingOrders retrieves the orders waiting to be filled, oldest first.
+func (r *brokerOrderRepositoryImpl) GetPendingOrders(ctx context.Context, broker string) ([]*models.BrokerOrder, error) {
+	query := "SELECT " + brokerOrderColumns + " FROM broker_orders WHERE broker = ? AND status = ? ORDER BY created_at, id"
+
+	return r.queryOrders(ctx, query, broker, models.OrderStatusPending)
+}
+
+// UpdateOrder updates the status and fill of an order.
+func (r *brokerOrderRepositoryImpl) UpdateOrder(ctx context.Context, order *models.BrokerOrder) error {
+	query := `
+		UPDATE broker_orders
+		SET status = ?, filled_quantity = ?, average_price = ?, message = ?
+		WHERE id = ?`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		order.Status,
+		order.FilledQuantity,
+		order.AveragePrice,
+		order.Message,
+		order.ID,
+	)
+	return err
+}
+
+// queryOrders runs a query selecting broker order columns.
+func (r *brokerOrderRepositoryImpl) queryOrders(ctx context.Context, query string, args ...interface{}) ([]*models.BrokerOrder, error) {
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []*models.BrokerOrder{}
+	for rows.Next() {
+		order, err := scanBrokerOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// CreateExecution records a fill of an order.
+func (r *brokerOrderRepositoryImpl) CreateExecution(ctx context.Context, execution *models.BrokerExecution) error {
+	if execution.ID == "" {
+		execution.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO broker_executions (` + brokerExecutionColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		execution.ID,
+		execution.OrderID,
+		execution.Broker,
+		execution.Code,
+		execution.Side,
+		execution.Quantity,
+		execution.Price,
+		execution.ExecutedAt,
+	)
+	return err
+}
+
+// GetExecutions retrieves the executions since the given time, oldest first.
+// All executions are returned if since is zero.
+func (r *brokerOrderRepositoryImpl) GetExecutions(ctx context.Context, broker string, since time.Time) ([]*models.BrokerExecution, error) {
+	query := "SELECT " + brokerExecutionColumns + " FROM broker_executions WHERE broker = ? AND executed_at >= ? ORDER BY executed_at, id"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, broker, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	executions := []*models.BrokerExecution{}
+	for rows.Next() {
+		execution := &models.BrokerExecution{}
+		err := rows.Scan(
+			&execution.ID,
+			&execution.OrderID,
+			&execution.Broker,
+			&execution.Code,
+			&execution.Side,
+			&execution.Quantity,
+			&execution.Price,
+			&execution.ExecutedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return executions, nil
+}
+
+// scanBrokerOrder scans a broker order row.
+func scanBrokerOrder(row rowScanner) (*models.BrokerOrder, error) {
+	order := &models.BrokerOrder{}
+	err := row.Scan(
+		&order.ID,
+		&order.Broker,
+		&order.Code,
+		&order.Side,
+		&order.OrderType,
+		&order.Quantity,
+		&order.LimitPrice,
+		&order.Status,
+		&order.FilledQuantity,
+		&order.AveragePrice,
+		&order.Message,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
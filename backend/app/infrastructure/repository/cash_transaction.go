@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// CashTransactionRepository defines cash transaction related operations.
+type CashTransactionRepository interface {
+	Save(ctx context.Context, transaction *models.CashTransaction) error
+	GetLatest(ctx context.Context, limit int) ([]*models.CashTransaction, error)
+}
+
+// cashTransactionRepositoryImpl implements CashTransactionRepository.
+type cashTransactionRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewCashTransactionRepository creates a new cash transaction repository.
+func NewCashTransactionRepository(db boil.ContextExecutor) CashTransactionRepository {
+	return &cashTransactionRepositoryImpl{db: db}
+}
+
+// Save records a deposit or withdrawal.
+func (r *cashTransactionRepositoryImpl) Save(ctx context.Context, transaction *models.CashTransaction) error {
+	if transaction.ID == "" {
+		transaction.ID = utility.NewULID()
+	}
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO cash_transactions (id, transaction_type, amount, balance_after, note, transacted_on, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		transaction.ID,
+		transaction.TransactionType,
+		transaction.Amount,
+		transaction.BalanceAfter,
+		transaction.Note,
+		transaction.TransactedOn,
+		transaction.CreatedAt,
+	)
+	return err
+}
+
+// GetLatest retrieves the latest transactions, newest first.
+func (r *cashTransactionRepositoryImpl) GetLatest(ctx context.Context, limit int) ([]*models.CashTransaction, error) {
+	query := `
+		SELECT id, transaction_type, amount, balance_after, note, transacted_on, created_at
+		FROM cash_transactions
+		ORDER BY transacted_on DESC, created_at DESC
+		LIMIT ?`
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := []*models.CashTransaction{}
+	for rows.Next() {
+		transaction := &models.CashTransaction{}
+		err := rows.Scan(
+			&transaction.ID,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.BalanceAfter,
+			&transaction.Note,
+			&transaction.TransactedOn,
+			&transaction.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
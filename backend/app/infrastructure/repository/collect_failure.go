@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// CollectFailureRepository defines operations of the records of stocks failing the price collection.
+type CollectFailureRepository interface {
+	Upsert(ctx context.Context, failure *models.CollectFailure) error
+	Delete(ctx context.Context, code string) (bool, error)
+	GetAll(ctx context.Context) ([]*models.CollectFailure, error)
+}
+
+// collectFailureRepositoryImpl implements CollectFailureRepository.
+type collectFailureRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewCollectFailureRepository creates a new collect failure repository.
+func NewCollectFailureRepository(db boil.ContextExecutor) CollectFailureRepository {
+	return &collectFailureRepositoryImpl{db: db}
+}
+
+// Upsert saves the failure record of a stock, replacing the one already saved.
+func (r *collectFailureRepositoryImpl) Upsert(ctx context.Context, failure *models.CollectFailure) error {
+	query := `
+		INSERT INTO collect_failures (code, consecutive_failures, last_error, last_failed_at, quarantined_until)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			consecutive_failures = VALUES(consecutive_failures),
+			last_error = VALUES(last_error),
+			last_failed_at = VALUES(last_failed_at),
+			quarantined_until = VALUES(quarantined_until)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		failure.Code,
+		failure.ConsecutiveFailures,
+		failure.LastError,
+		failure.LastFailedAt,
+		failure.QuarantinedUntil,
+	)
+	return err
+}
+
+// Delete removes the failure record of a stock, e.g. once it is collected again.
+// Returns false if the stock had none.
+func (r *collectFailureRepositoryImpl) Delete(ctx context.Context, code string) (bool, error) {
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM collect_failures WHERE code = ?", code)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetAll retrieves the failure records of all stocks, ordered by code.
+func (r *collectFailureRepositoryImpl) GetAll(ctx context.Context) ([]*models.CollectFailure, error) {
+	query := `
+		SELECT code, consecutive_failures, last_error, last_failed_at, quarantined_until
+		FROM collect_failures
+		ORDER BY code`
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	failures := []*models.CollectFailure{}
+	for rows.Next() {
+		failure := &models.CollectFailure{}
+		var quarantinedUntil sql.NullTime
+		if err := rows.Scan(
+			&failure.Code,
+			&failure.ConsecutiveFailures,
+			&failure.LastError,
+			&failure.LastFailedAt,
+			&quarantinedUntil,
+		); err != nil {
+			return nil, err
+		}
+		if quarantinedUntil.Valid {
+			failure.QuarantinedUntil = &quarantinedUntil.Time
+		}
+		failures = append(failures, failure)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return failures, nil
+}
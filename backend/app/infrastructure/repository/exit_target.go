@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// ExitTargetRepository defines take-profit and stop-loss line related operations.
+type ExitTargetRepository interface {
+	Upsert(ctx context.Context, target *models.ExitTarget) error
+	GetByCode(ctx context.Context, code string) (*models.ExitTarget, error)
+	GetAll(ctx context.Context) ([]*models.ExitTarget, error)
+	Delete(ctx context.Context, code string) error
+}
+
+// exitTargetRepositoryImpl implements ExitTargetRepository.
+type exitTargetRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewExitTargetRepository creates a new exit target repository.
+func NewExitTargetRepository(db boil.ContextExecutor) ExitTargetRepository {
+	return &exitTargetRepositoryImpl{db: db}
+}
+
+const exitTargetColumns = "code, take_profit_percent, stop_loss_percent, take_profit_notified, stop_loss_notified, created_at, updated_at"
+
+// Upsert creates or replaces the exit targets and notified state of a stock.
+func (r *exitTargetRepositoryImpl) Upsert(ctx context.Context, target *models.ExitTarget) error {
+	target.Code = domain.NormalizeCode(target.Code)
+	query := `
+		INSERT INTO exit_targets (code, take_profit_percent, stop_loss_percent, take_profit_notified, stop_loss_notified)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			take_profit_percent = VALUES(take_profit_percent),
+			stop_loss_percent = VALUES(stop_loss_percent),
+			take_profit_notified = VALUES(take_profit_notified),
+			stop_loss_notified = VALUES(stop_loss_notified)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		target.Code,
+		target.TakeProfitPercent,
+		target.StopLossPercent,
+		target.TakeProfitNotified,
+		target.StopLossNotified,
+	)
+	return err
+}
+
+// GetByCode retrieves the exit targets of a stock.
+// Returns nil if no targets are registered.
+func (r *exitTargetRepositoryImpl) GetByCode(ctx context.Context, code string) (*models.ExitTarget, error) {
+	code = domain.NormalizeCode(code)
+	query := "SELECT " + exitTargetColumns + " FROM exit_targets WHERE code = ?"
+
+	target, err := scanExitTarget(getExecutor(ctx, r.db).QueryRowContext(ctx, query, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// GetAll retrieves all registered exit targets ordered by code.
+func (r *exitTargetRepositoryImpl) GetAll(ctx context.Context) ([]*models.ExitTarget, error) {
+	query := "SELECT " + exitTargetColumns + " FROM exit_targets ORDER BY code"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	targets := []*models.ExitTarget{}
+	for rows.Next() {
+		target, err := scanExitTarget(rows)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// Delete removes the exit targets of a stock.
+func (r *exitTargetRepositoryImpl) Delete(ctx context.Context, code string) error {
+	code = domain.NormalizeCode(code)
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM exit_targets WHERE code = ?", code)
+	return err
+}
+
+// scanExitTarget scans an exit target row.
+func scanExitTarget(row rowScanner) (*models.ExitTarget, error) {
+	target := &models.ExitTarget{}
+	err := row.Scan(
+		&target.Code,
+		&target.TakeProfitPercent,
+		&target.StopLossPercent,
+		&target.TakeProfitNotified,
+		&target.StopLossNotified,
+		&target.CreatedAt,
+		&target.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
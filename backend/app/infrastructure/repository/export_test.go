@@ -0,0 +1,11 @@
+package repository
+
+import "time"
+
+// SetSchemaMigrationPhasesNow replaces the clock of a phase cache in tests.
+func SetSchemaMigrationPhasesNow(p *SchemaMigrationPhases, now func() time.Time) {
+	p.now = now
+}
+
+// AuditJSON is auditJSON exported for tests.
+var AuditJSON = auditJSON
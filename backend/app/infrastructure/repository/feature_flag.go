@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// FeatureFlagRepository defines feature flag override related operations.
+type FeatureFlagRepository interface {
+	Get(ctx context.Context, name string) (*models.FeatureFlag, error)
+	GetAll(ctx context.Context) ([]*models.FeatureFlag, error)
+	Save(ctx context.Context, flag *models.FeatureFlag) error
+	Delete(ctx context.Context, name string) error
+}
+
+// featureFlagRepositoryImpl implements FeatureFlagRepository.
+type featureFlagRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewFeatureFlagRepository creates a new feature flag repository.
+func NewFeatureFlagRepository(db boil.ContextExecutor) FeatureFlagRepository {
+	return &featureFlagRepositoryImpl{db: db}
+}
+
+const featureFlagColumns = "name, enabled, updated_by, created_at, updated_at"
+
+// Get retrieves the override of a feature flag by name.
+// Returns nil if the flag is not overridden.
+func (r *featureFlagRepositoryImpl) Get(ctx context.Context, name string) (*models.FeatureFlag, error) {
+	query := "SELECT " + featureFlagColumns + " FROM feature_flags WHERE name = ?"
+
+	flag, err := scanFeatureFlag(getExecutor(ctx, r.db).QueryRowContext(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+// GetAll retrieves all feature flag overrides ordered by name.
+func (r *featureFlagRepositoryImpl) GetAll(ctx context.Context) ([]*models.FeatureFlag, error) {
+	query := "SELECT " + featureFlagColumns + " FROM feature_flags ORDER BY name"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flags := []*models.FeatureFlag{}
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// Save creates or replaces the override of a feature flag.
+// The actor of the context is recorded as the user who changed it.
+func (r *featureFlagRepositoryImpl) Save(ctx context.Context, flag *models.FeatureFlag) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+	if flag.UpdatedBy == "" {
+		_, flag.UpdatedBy = auditSourceFromContext(ctx)
+	}
+
+	query := `
+		INSERT INTO feature_flags (name, enabled, updated_by)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			enabled = VALUES(enabled),
+			updated_by = VALUES(updated_by)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query, flag.Name, flag.Enabled, flag.UpdatedBy)
+	return err
+}
+
+// Delete removes the override of a feature flag, so that the flag file or the default applies again.
+func (r *featureFlagRepositoryImpl) Delete(ctx context.Context, name string) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM feature_flags WHERE name = ?", name)
+	return err
+}
+
+// scanFeatureFlag scans a feature flag row.
+func scanFeatureFlag(row rowScanner) (*models.FeatureFlag, error) {
+	flag := &models.FeatureFlag{}
+	err := row.Scan(
+		&flag.Name,
+		&flag.Enabled,
+		&flag.UpdatedBy,
+		&flag.CreatedAt,
+		&flag.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return flag, nil
+}
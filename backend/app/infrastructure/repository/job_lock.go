@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrJobLocked is returned when the job is already running in another process or goroutine.
+var ErrJobLocked = errors.New("job is already running")
+
+// jobLockPrefix namespaces the lock names of this application on a shared MySQL server.
+const jobLockPrefix = "stock-automation:"
+
+// JobLocker provides exclusive execution of jobs across processes sharing the database.
+type JobLocker interface {
+	// WithLock runs fn while holding the lock of the named job. If the lock is held by
+	// another session, fn is not run and ErrJobLocked is returned.
+	WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error
+}
+
+// jobLockerImpl implements JobLocker with MySQL advisory locks (GET_LOCK/RELEASE_LOCK).
+// Advisory locks belong to a connection, so the lock is taken on a dedicated connection
+// which also releases it if the process dies.
+type jobLockerImpl struct {
+	db *sql.DB
+}
+
+// NewJobLocker creates a new job locker.
+func NewJobLocker(db *sql.DB) JobLocker {
+	return &jobLockerImpl{db: db}
+}
+
+// WithLock runs fn while holding the advisory lock of the named job.
+func (l *jobLockerImpl) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection for job lock: %w", err)
+	}
+	defer conn.Close()
+
+	lockName := jobLockPrefix + name
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", lockName).Scan(&acquired); err != nil {
+		return fmt.Errorf("failed to acquire job lock %s: %w", name, err)
+	}
+	if !acquired.Valid {
+		return fmt.Errorf("failed to acquire job lock %s", name)
+	}
+	if acquired.Int64 != 1 {
+		return fmt.Errorf("%w: %s", ErrJobLocked, name)
+	}
+
+	defer func() {
+		// Released even if ctx was canceled by the job
+		if _, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName); err != nil {
+			logrus.Warnf("Failed to release job lock %s: %v", name, err)
+		}
+	}()
+
+	return fn(ctx)
+}
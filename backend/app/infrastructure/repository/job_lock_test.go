@@ -0,0 +1,98 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/testutil"
+)
+
+// isFreeLock reports whether the advisory lock of the named job is free, as seen from another session.
+func isFreeLock(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var free sql.NullInt64
+	if err := db.QueryRowContext(context.Background(), "SELECT IS_FREE_LOCK(?)", jobLockPrefix+name).Scan(&free); err != nil {
+		t.Fatalf("IS_FREE_LOCK(%s) error = %v", name, err)
+	}
+	return free.Valid && free.Int64 == 1
+}
+
+func TestJobLocker_WithLock(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Cleanup()
+	db := tdb.GetDB()
+	locker := NewJobLocker(db)
+	ctx := context.Background()
+
+	t.Run("acquires the lock while fn runs", func(t *testing.T) {
+		ran := false
+		err := locker.WithLock(ctx, "collect", func(ctx context.Context) error {
+			ran = true
+			if isFreeLock(t, db, "collect") {
+				t.Error("lock is free while fn runs, want it held")
+			}
+			return nil
+		})
+		if err != nil || !ran {
+			t.Fatalf("WithLock() error = %v, ran = %v, want fn run", err, ran)
+		}
+		if !isFreeLock(t, db, "collect") {
+			t.Error("lock is held after fn returned, want it released")
+		}
+	})
+
+	t.Run("returns ErrJobLocked when another session holds the lock", func(t *testing.T) {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Conn() error = %v", err)
+		}
+		defer conn.Close()
+		var acquired int
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", jobLockPrefix+"report").Scan(&acquired); err != nil || acquired != 1 {
+			t.Fatalf("GET_LOCK() = %d, %v", acquired, err)
+		}
+
+		ran := false
+		err = locker.WithLock(ctx, "report", func(ctx context.Context) error {
+			ran = true
+			return nil
+		})
+		if !errors.Is(err, ErrJobLocked) || ran {
+			t.Errorf("WithLock() error = %v, ran = %v, want ErrJobLocked without running fn", err, ran)
+		}
+
+		// Another job is not blocked by the lock
+		if err := locker.WithLock(ctx, "collect", func(ctx context.Context) error { return nil }); err != nil {
+			t.Errorf("WithLock() of another job error = %v", err)
+		}
+
+		if _, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", jobLockPrefix+"report"); err != nil {
+			t.Fatalf("RELEASE_LOCK() error = %v", err)
+		}
+	})
+
+	t.Run("releases the lock when fn fails", func(t *testing.T) {
+		jobErr := errors.New("job failed")
+		err := locker.WithLock(ctx, "collect", func(ctx context.Context) error {
+			return jobErr
+		})
+		if !errors.Is(err, jobErr) {
+			t.Fatalf("WithLock() error = %v, want the error of fn", err)
+		}
+		if !isFreeLock(t, db, "collect") {
+			t.Error("lock is held after fn failed, want it released")
+		}
+
+		ran := false
+		if err := locker.WithLock(ctx, "collect", func(ctx context.Context) error {
+			ran = true
+			return nil
+		}); err != nil || !ran {
+			t.Errorf("WithLock() after a failure error = %v, ran = %v, want fn run", err, ran)
+		}
+	})
+}
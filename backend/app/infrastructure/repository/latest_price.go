@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/sirupsen/logrus"
+)
+
+const latestPriceColumns = "code, date, open_price, high_price, low_price, close_price, adj_close_price, volume"
+
+// GetLatestPrices retrieves the latest stock prices of the codes in one query, keyed by code.
+// Codes without prices are not included in the result.
+//
+// The prices are read from latest_prices, a cache of the latest stock_prices row of each code
+// updated by the price writes of this repository. Codes missing from it are read from stock_prices
+// and written back.
+func (r *stockRepositoryImpl) GetLatestPrices(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+	codes = uniqueCodes(codes)
+	prices := make(map[string]*models.StockPrice, len(codes))
+	if len(codes) == 0 {
+		return prices, nil
+	}
+
+	query := "SELECT " + latestPriceColumns + " FROM latest_prices WHERE code IN (" + placeholders(len(codes)) + ")"
+	if err := r.queryLatestPrices(ctx, prices, query, codes); err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, code := range codes {
+		if _, ok := prices[code]; !ok {
+			missing = append(missing, code)
+		}
+	}
+	if len(missing) == 0 {
+		return prices, nil
+	}
+
+	// Codes saved before latest_prices was introduced are found by the unique index of stock_prices
+	query = `
+		SELECT sp.code, sp.date, sp.open_price, sp.high_price, sp.low_price, sp.close_price, sp.adj_close_price, sp.volume
+		FROM stock_prices sp
+		JOIN (
+			SELECT code, MAX(date) AS date FROM stock_prices WHERE code IN (` + placeholders(len(missing)) + `) GROUP BY code
+		) latest ON sp.code = latest.code AND sp.date = latest.date`
+
+	found := make(map[string]*models.StockPrice, len(missing))
+	if err := r.queryLatestPrices(ctx, found, query, missing); err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return prices, nil
+	}
+
+	backfill := make([]*models.StockPrice, 0, len(found))
+	for _, code := range missing {
+		if price, ok := found[code]; ok {
+			prices[code] = price
+			backfill = append(backfill, price)
+		}
+	}
+	if err := r.upsertLatestPrices(ctx, backfill); err != nil {
+		logrus.Warnf("Failed to backfill latest prices: %v", err)
+	}
+
+	return prices, nil
+}
+
+// GetPreviousPrices retrieves, for each of the codes, the stock price of the trading day before
+// its latest price in one query, keyed by code. Codes with fewer than two days of prices are not
+// included in the result.
+func (r *stockRepositoryImpl) GetPreviousPrices(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+	codes = uniqueCodes(codes)
+	prices := make(map[string]*models.StockPrice, len(codes))
+	if len(codes) == 0 {
+		return prices, nil
+	}
+
+	// Both days are looked up by the unique index of stock_prices
+	query := `
+		SELECT sp.code, sp.date, sp.open_price, sp.high_price, sp.low_price, sp.close_price, sp.adj_close_price, sp.volume
+		FROM stock_prices sp
+		JOIN (
+			SELECT p.code, MAX(p.date) AS date
+			FROM stock_prices p
+			JOIN (
+				SELECT code, MAX(date) AS date FROM stock_prices WHERE code IN (` + placeholders(len(codes)) + `) GROUP BY code
+			) latest ON p.code = latest.code AND p.date < latest.date
+			GROUP BY p.code
+		) previous ON sp.code = previous.code AND sp.date = previous.date`
+
+	if err := r.queryLatestPrices(ctx, prices, query, codes); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// queryLatestPrices runs a query selecting latest price columns for the codes and adds the rows to prices.
+func (r *stockRepositoryImpl) queryLatestPrices(ctx context.Context, prices map[string]*models.StockPrice, query string, codes []string) error {
+	args := make([]interface{}, len(codes))
+	for i, code := range codes {
+		args[i] = code
+	}
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		price := &models.StockPrice{}
+		err := rows.Scan(
+			&price.Code,
+			&price.Date,
+			&price.OpenPrice,
+			&price.HighPrice,
+			&price.LowPrice,
+			&price.ClosePrice,
+			&price.AdjClosePrice,
+			&price.Volume,
+		)
+		if err != nil {
+			return err
+		}
+		prices[price.Code] = price
+	}
+
+	return rows.Err()
+}
+
+// upsertLatestPrices writes the newest of the prices of each code to latest_prices.
+// A stored price of a later day is kept, and a price of the same day replaces the stored one
+// except for an adjusted close the price does not have.
+func (r *stockRepositoryImpl) upsertLatestPrices(ctx context.Context, prices []*models.StockPrice) error {
+	newest := make(map[string]*models.StockPrice)
+	var codes []string
+	for _, price := range prices {
+		current, ok := newest[price.Code]
+		if !ok {
+			codes = append(codes, price.Code)
+		}
+		if !ok || price.Date.After(current.Date) {
+			newest[price.Code] = price
+		}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+
+	// date is assigned last because the other assignments compare against the stored date
+	query := `
+		INSERT INTO latest_prices (` + latestPriceColumns + `)
+		VALUES ` + strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?), ", len(codes)), ", ") + `
+		ON DUPLICATE KEY UPDATE
+			open_price = IF(VALUES(date) >= date, VALUES(open_price), open_price),
+			high_price = IF(VALUES(date) >= date, VALUES(high_price), high_price),
+			low_price = IF(VALUES(date) >= date, VALUES(low_price), low_price),
+			close_price = IF(VALUES(date) >= date, VALUES(close_price), close_price),
+			adj_close_price = IF(VALUES(date) > date, VALUES(adj_close_price),
+				IF(VALUES(date) = date, COALESCE(VALUES(adj_close_price), adj_close_price), adj_close_price)),
+			volume = IF(VALUES(date) >= date, VALUES(volume), volume),
+			date = GREATEST(date, VALUES(date))`
+
+	args := make([]interface{}, 0, len(codes)*8)
+	for _, code := range codes {
+		price := newest[code]
+		args = append(args,
+			price.Code,
+			price.Date.Format("2006-01-02"),
+			price.OpenPrice,
+			price.HighPrice,
+			price.LowPrice,
+			price.ClosePrice,
+			price.AdjClosePrice,
+			price.Volume,
+		)
+	}
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query, args...)
+	return err
+}
+
+// uniqueCodes returns the codes without duplicates and empty codes, keeping their order.
+func uniqueCodes(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	unique := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		unique = append(unique, code)
+	}
+	return unique
+}
+
+// placeholders returns n comma separated placeholders for an IN clause.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
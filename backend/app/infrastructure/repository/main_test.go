@@ -0,0 +1,14 @@
+//go:build integration
+
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/testutil"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testutil.RunWithMySQL(m))
+}
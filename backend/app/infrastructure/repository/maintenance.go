@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// MaintenanceRepository defines maintenance window and suppressed alert related operations.
+type MaintenanceRepository interface {
+	CreateWindow(ctx context.Context, window *models.MaintenanceWindow) error
+	UpdateWindowEnd(ctx context.Context, id string, endsAt time.Time) error
+	GetActiveWindow(ctx context.Context, at time.Time) (*models.MaintenanceWindow, error)
+	GetWindowsPendingDigest(ctx context.Context, at time.Time) ([]*models.MaintenanceWindow, error)
+	MarkDigestSent(ctx context.Context, id string, sentAt time.Time) error
+
+	// Suppressed alert operations
+	SaveSuppressedAlert(ctx context.Context, alert *models.SuppressedAlert) error
+	GetSuppressedAlerts(ctx context.Context, windowID string) ([]*models.SuppressedAlert, error)
+}
+
+// maintenanceRepositoryImpl implements MaintenanceRepository.
+type maintenanceRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewMaintenanceRepository creates a new maintenance repository.
+func NewMaintenanceRepository(db boil.ContextExecutor) MaintenanceRepository {
+	return &maintenanceRepositoryImpl{db: db}
+}
+
+const maintenanceWindowColumns = "id, starts_at, ends_at, reason, digest_sent_at, created_at, updated_at"
+
+// CreateWindow creates a new maintenance window.
+func (r *maintenanceRepositoryImpl) CreateWindow(ctx context.Context, window *models.MaintenanceWindow) error {
+	if window.ID == "" {
+		window.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO maintenance_windows (id, starts_at, ends_at, reason)
+		VALUES (?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		window.ID,
+		window.StartsAt,
+		window.EndsAt,
+		window.Reason,
+	)
+	return err
+}
+
+// UpdateWindowEnd changes the end of a maintenance window, to extend it or to end it early.
+func (r *maintenanceRepositoryImpl) UpdateWindowEnd(ctx context.Context, id string, endsAt time.Time) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE maintenance_windows SET ends_at = ? WHERE id = ?", endsAt, id)
+	return err
+}
+
+// GetActiveWindow retrieves the maintenance window covering at, the one ending last if several do.
+// Returns nil if there is none.
+func (r *maintenanceRepositoryImpl) GetActiveWindow(ctx context.Context, at time.Time) (*models.MaintenanceWindow, error) {
+	query := "SELECT " + maintenanceWindowColumns + " FROM maintenance_windows WHERE starts_at <= ? AND ends_at > ? ORDER BY ends_at DESC LIMIT 1"
+
+	window, err := scanMaintenanceWindow(getExecutor(ctx, r.db).QueryRowContext(ctx, query, at, at))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return window, nil
+}
+
+// GetWindowsPendingDigest retrieves the maintenance windows ended by at whose digest has not been sent,
+// ordered by start.
+func (r *maintenanceRepositoryImpl) GetWindowsPendingDigest(ctx context.Context, at time.Time) ([]*models.MaintenanceWindow, error) {
+	query := "SELECT " + maintenanceWindowColumns + " FROM maintenance_windows WHERE ends_at <= ? AND digest_sent_at IS NULL ORDER BY starts_at"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	windows := []*models.MaintenanceWindow{}
+	for rows.Next() {
+		window, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+// MarkDigestSent records that the digest of a maintenance window has been sent.
+func (r *maintenanceRepositoryImpl) MarkDigestSent(ctx context.Context, id string, sentAt time.Time) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE maintenance_windows SET digest_sent_at = ? WHERE id = ?", sentAt, id)
+	return err
+}
+
+// SaveSuppressedAlert records an alert suppressed during a maintenance window.
+func (r *maintenanceRepositoryImpl) SaveSuppressedAlert(ctx context.Context, alert *models.SuppressedAlert) error {
+	if alert.ID == "" {
+		alert.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO suppressed_alerts (id, maintenance_window_id, alert_type, message, suppressed_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		alert.ID,
+		alert.MaintenanceWindowID,
+		alert.AlertType,
+		alert.Message,
+		alert.SuppressedAt,
+	)
+	return err
+}
+
+// GetSuppressedAlerts retrieves the alerts suppressed during a maintenance window in the order they were suppressed.
+func (r *maintenanceRepositoryImpl) GetSuppressedAlerts(ctx context.Context, windowID string) ([]*models.SuppressedAlert, error) {
+	query := `
+		SELECT id, maintenance_window_id, alert_type, message, suppressed_at
+		FROM suppressed_alerts
+		WHERE maintenance_window_id = ?
+		ORDER BY suppressed_at, id`
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, windowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := []*models.SuppressedAlert{}
+	for rows.Next() {
+		alert := &models.SuppressedAlert{}
+		err := rows.Scan(
+			&alert.ID,
+			&alert.MaintenanceWindowID,
+			&alert.AlertType,
+			&alert.Message,
+			&alert.SuppressedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// scanMaintenanceWindow scans a maintenance window row.
+func scanMaintenanceWindow(row rowScanner) (*models.MaintenanceWindow, error) {
+	window := &models.MaintenanceWindow{}
+	err := row.Scan(
+		&window.ID,
+		&window.StartsAt,
+		&window.EndsAt,
+		&window.Reason,
+		&window.DigestSentAt,
+		&window.CreatedAt,
+		&window.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return window, nil
+}
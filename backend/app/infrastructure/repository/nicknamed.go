@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/sirupsen/logrus"
+)
+
+// nicknameResolver replaces the official names of the listed records with the nicknames of their
+// stocks. The records are copied, so the official names of records read by code for updates are
+// never overwritten.
+type nicknameResolver struct {
+	nicknameRepo StockNicknameRepository
+}
+
+// nicknames returns the nicknames by code. Names are only displayed, so a failure to read them
+// leaves the official names.
+func (n *nicknameResolver) nicknames(ctx context.Context) map[string]string {
+	nicknames, err := n.nicknameRepo.GetAll(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get stock nicknames: %v", err)
+		return nil
+	}
+	byCode := make(map[string]string, len(nicknames))
+	for _, nickname := range nicknames {
+		byCode[nickname.Code] = nickname.Nickname
+	}
+	return byCode
+}
+
+type nicknamedPortfolioRepository struct {
+	PortfolioRepository
+	nicknameResolver
+}
+
+// NewNicknamedPortfolioRepository wraps a portfolio repository so that the holdings listed for the
+// reports, the CLI and the notifications are named by the nicknames of their stocks.
+// The holdings read by ID or code keep their official names.
+func NewNicknamedPortfolioRepository(repo PortfolioRepository, nicknameRepo StockNicknameRepository) PortfolioRepository {
+	return &nicknamedPortfolioRepository{
+		PortfolioRepository: repo,
+		nicknameResolver:    nicknameResolver{nicknameRepo: nicknameRepo},
+	}
+}
+
+// GetAll retrieves all holdings named by their nicknames.
+func (r *nicknamedPortfolioRepository) GetAll(ctx context.Context) ([]*models.Portfolio, error) {
+	holdings, err := r.PortfolioRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.rename(ctx, holdings), nil
+}
+
+// GetHoldingsByCode retrieves the holdings of the codes named by their nicknames.
+func (r *nicknamedPortfolioRepository) GetHoldingsByCode(ctx context.Context, codes []string) ([]*models.Portfolio, error) {
+	holdings, err := r.PortfolioRepository.GetHoldingsByCode(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	return r.rename(ctx, holdings), nil
+}
+
+func (r *nicknamedPortfolioRepository) rename(ctx context.Context, holdings []*models.Portfolio) []*models.Portfolio {
+	nicknames := r.nicknames(ctx)
+	if len(nicknames) == 0 {
+		return holdings
+	}
+	renamed := make([]*models.Portfolio, len(holdings))
+	for i, holding := range holdings {
+		renamed[i] = holding
+		if nickname, ok := nicknames[holding.Code]; ok {
+			copied := *holding
+			copied.Name = nickname
+			renamed[i] = &copied
+		}
+	}
+	return renamed
+}
+
+type nicknamedStockRepository struct {
+	StockRepository
+	nicknameResolver
+}
+
+// NewNicknamedStockRepository wraps a stock repository so that the watch list items listed for the
+// reports, the CLI and the notifications are named by the nicknames of their stocks.
+// The items read by ID or code keep their official names.
+func NewNicknamedStockRepository(repo StockRepository, nicknameRepo StockNicknameRepository) StockRepository {
+	return &nicknamedStockRepository{
+		StockRepository:  repo,
+		nicknameResolver: nicknameResolver{nicknameRepo: nicknameRepo},
+	}
+}
+
+// GetActiveWatchList retrieves the active watch list named by the nicknames.
+func (r *nicknamedStockRepository) GetActiveWatchList(ctx context.Context) ([]*models.WatchList, error) {
+	items, err := r.StockRepository.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.rename(ctx, items), nil
+}
+
+// GetWatchList retrieves the watch list named by the nicknames.
+func (r *nicknamedStockRepository) GetWatchList(ctx context.Context, includeDeleted bool) ([]*models.WatchList, error) {
+	items, err := r.StockRepository.GetWatchList(ctx, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	return r.rename(ctx, items), nil
+}
+
+func (r *nicknamedStockRepository) rename(ctx context.Context, items []*models.WatchList) []*models.WatchList {
+	nicknames := r.nicknames(ctx)
+	if len(nicknames) == 0 {
+		return items
+	}
+	renamed := make([]*models.WatchList, len(items))
+	for i, item := range items {
+		renamed[i] = item
+		if nickname, ok := nicknames[item.Code]; ok {
+			copied := *item
+			copied.Name = nickname
+			renamed[i] = &copied
+		}
+	}
+	return renamed
+}
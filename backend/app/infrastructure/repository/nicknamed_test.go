@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNicknamedRepositories(t *testing.T) {
+	var nicknamesErr error
+	nicknames := &mock.StockNicknameRepositoryMock{
+		GetAllFunc: func(ctx context.Context) ([]*models.StockNickname, error) {
+			return []*models.StockNickname{{Code: "8306", Nickname: "MUFG"}}, nicknamesErr
+		},
+	}
+	holdings := []*models.Portfolio{
+		{Code: "8306", Name: "三菱ＵＦＪフィナンシャル・グループ"},
+		{Code: "7203", Name: "トヨタ自動車"},
+	}
+	items := []*models.WatchList{{Code: "8306", Name: "三菱ＵＦＪフィナンシャル・グループ"}}
+
+	portfolioRepo := repository.NewNicknamedPortfolioRepository(&mock.PortfolioRepositoryMock{
+		GetAllFunc: func(ctx context.Context) ([]*models.Portfolio, error) { return holdings, nil },
+	}, nicknames)
+	got, err := portfolioRepo.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	var names []string
+	for _, holding := range got {
+		names = append(names, holding.Name)
+	}
+	if diff := cmp.Diff([]string{"MUFG", "トヨタ自動車"}, names); diff != "" {
+		t.Errorf("holding names mismatch (-want +got):\n%s", diff)
+	}
+	// The records of the wrapped repository keep their official names
+	if holdings[0].Name != "三菱ＵＦＪフィナンシャル・グループ" {
+		t.Errorf("wrapped holding renamed to %s", holdings[0].Name)
+	}
+
+	stockRepo := repository.NewNicknamedStockRepository(&mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) { return items, nil },
+	}, nicknames)
+	watched, err := stockRepo.GetActiveWatchList(context.Background())
+	if err != nil {
+		t.Fatalf("GetActiveWatchList() error = %v", err)
+	}
+	if watched[0].Name != "MUFG" {
+		t.Errorf("watch list name = %s, want MUFG", watched[0].Name)
+	}
+
+	// The official names are shown if the nicknames cannot be read
+	nicknamesErr = errors.New("connection refused")
+	got, err = portfolioRepo.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("GetAll() error = %v, want the official names", err)
+	}
+	if got[0].Name != "三菱ＵＦＪフィナンシャル・グループ" {
+		t.Errorf("holding name = %s, want the official name", got[0].Name)
+	}
+}
@@ -54,7 +54,7 @@ func (r *notificationLogRepository) Create(ctx context.Context, log *Notificatio
 			$1, $2, $3, $4, $5, $6, $7
 		) RETURNING id`
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := getExecutor(ctx, r.db).QueryRowContext(ctx, query,
 		log.NotificationType,
 		log.Status,
 		log.Message,
@@ -81,7 +81,7 @@ func (r *notificationLogRepository) UpdateStatus(ctx context.Context, id int64,
 		    updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id, status, errorMessage, sentAt)
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, query, id, status, errorMessage, sentAt)
 	if err != nil {
 		return err
 	}
@@ -107,7 +107,7 @@ func (r *notificationLogRepository) GetRecent(ctx context.Context, limit int) ([
 		LIMIT $1`
 
 	logs := []*NotificationLog{}
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +152,7 @@ func (r *notificationLogRepository) GetByType(ctx context.Context, notificationT
 		LIMIT $2`
 
 	logs := []*NotificationLog{}
-	rows, err := r.db.QueryContext(ctx, query, notificationType, limit)
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, notificationType, limit)
 	if err != nil {
 		return nil, err
 	}
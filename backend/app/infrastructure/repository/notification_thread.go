@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// NotificationThreadRepository defines notification thread related operations.
+type NotificationThreadRepository interface {
+	GetThread(ctx context.Context, key string) (*models.NotificationThread, error)
+	SaveThread(ctx context.Context, thread *models.NotificationThread) error
+}
+
+// notificationThreadRepositoryImpl implements NotificationThreadRepository.
+type notificationThreadRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewNotificationThreadRepository creates a new notification thread repository.
+func NewNotificationThreadRepository(db boil.ContextExecutor) NotificationThreadRepository {
+	return &notificationThreadRepositoryImpl{db: db}
+}
+
+// GetThread retrieves the thread of a key.
+// Returns nil if no message of the key has been sent.
+func (r *notificationThreadRepositoryImpl) GetThread(ctx context.Context, key string) (*models.NotificationThread, error) {
+	query := "SELECT thread_key, channel, ts, created_at FROM notification_threads WHERE thread_key = ?"
+
+	thread := &models.NotificationThread{}
+	err := getExecutor(ctx, r.db).QueryRowContext(ctx, query, key).Scan(
+		&thread.ThreadKey,
+		&thread.Channel,
+		&thread.TS,
+		&thread.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return thread, nil
+}
+
+// SaveThread records the message starting the thread of a key.
+// A thread already recorded for the key is kept, so that a racing message does not move the thread.
+func (r *notificationThreadRepositoryImpl) SaveThread(ctx context.Context, thread *models.NotificationThread) error {
+	if thread.CreatedAt.IsZero() {
+		thread.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT IGNORE INTO notification_threads (thread_key, channel, ts, created_at)
+		VALUES (?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query, thread.ThreadKey, thread.Channel, thread.TS, thread.CreatedAt)
+	return err
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/boost-jp/stock-automation/app/domain"
 	"github.com/boost-jp/stock-automation/app/domain/models"
 	"github.com/boost-jp/stock-automation/app/infrastructure/dao"
 )
@@ -18,8 +19,15 @@ type PortfolioRepository interface {
 	GetByCode(ctx context.Context, code string) (*models.Portfolio, error)
 	GetAll(ctx context.Context) ([]*models.Portfolio, error)
 	Update(ctx context.Context, portfolio *models.Portfolio) error
+	// Delete soft-deletes a portfolio record so that it can be restored. Soft-deleted records are
+	// left out of all the other reads.
 	Delete(ctx context.Context, id string) error
 
+	// Soft delete operations
+	GetDeleted(ctx context.Context) ([]*models.Portfolio, error)
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string) error
+
 	// Aggregate operations
 	GetTotalValue(ctx context.Context, currentPrices map[string]float64) (float64, error)
 	GetHoldingsByCode(ctx context.Context, codes []string) ([]*models.Portfolio, error)
@@ -37,17 +45,23 @@ func NewPortfolioRepository(db boil.ContextExecutor) PortfolioRepository {
 
 // Create creates a new portfolio record.
 func (r *portfolioRepositoryImpl) Create(ctx context.Context, portfolio *models.Portfolio) error {
+	portfolio.Code = domain.NormalizeCode(portfolio.Code)
 	// Convert domain model to DAO model
 	daoPortfolio := &dao.Portfolio{
 		ID:            portfolio.ID,
 		Code:          portfolio.Code,
 		Name:          portfolio.Name,
-		Shares:        portfolio.Shares,
+		Shares:        int64(portfolio.Shares),
 		PurchasePrice: portfolio.PurchasePrice,
 		PurchaseDate:  portfolio.PurchaseDate,
+		PositionType:  positionTypeOrDefault(portfolio.PositionType),
+		MarginRate:    portfolio.MarginRate,
+		InterestRate:  portfolio.InterestRate,
+		AssetClass:    portfolio.GetAssetClass(),
+		Isin:          portfolio.Isin,
 	}
 
-	err := daoPortfolio.Insert(ctx, r.db, boil.Infer())
+	err := daoPortfolio.Insert(ctx, getExecutor(ctx, r.db), boil.Infer())
 	if err != nil {
 		return err
 	}
@@ -59,9 +73,15 @@ func (r *portfolioRepositoryImpl) Create(ctx context.Context, portfolio *models.
 	return nil
 }
 
+// notDeleted is the query mod leaving out soft-deleted records.
+var notDeleted = qm.Where("deleted_at IS NULL")
+
 // GetByID retrieves a portfolio by its ID.
 func (r *portfolioRepositoryImpl) GetByID(ctx context.Context, id string) (*models.Portfolio, error) {
-	daoPortfolio, err := dao.FindPortfolio(ctx, r.db, id)
+	daoPortfolio, err := dao.Portfolios(
+		qm.Where("id = ?", id),
+		notDeleted,
+	).One(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -74,9 +94,11 @@ func (r *portfolioRepositoryImpl) GetByID(ctx context.Context, id string) (*mode
 
 // GetByCode retrieves a portfolio by stock code.
 func (r *portfolioRepositoryImpl) GetByCode(ctx context.Context, code string) (*models.Portfolio, error) {
+	code = domain.NormalizeCode(code)
 	daoPortfolio, err := dao.Portfolios(
 		qm.Where("code = ?", code),
-	).One(ctx, r.db)
+		notDeleted,
+	).One(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -89,7 +111,7 @@ func (r *portfolioRepositoryImpl) GetByCode(ctx context.Context, code string) (*
 
 // GetAll retrieves all portfolio records.
 func (r *portfolioRepositoryImpl) GetAll(ctx context.Context) ([]*models.Portfolio, error) {
-	daoPortfolios, err := dao.Portfolios().All(ctx, r.db)
+	daoPortfolios, err := dao.Portfolios(notDeleted).All(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		return nil, err
 	}
@@ -104,19 +126,26 @@ func (r *portfolioRepositoryImpl) GetAll(ctx context.Context) ([]*models.Portfol
 
 // Update updates an existing portfolio record.
 func (r *portfolioRepositoryImpl) Update(ctx context.Context, portfolio *models.Portfolio) error {
+	portfolio.Code = domain.NormalizeCode(portfolio.Code)
 	// Convert domain model to DAO model
 	daoPortfolio := &dao.Portfolio{
 		ID:            portfolio.ID,
 		Code:          portfolio.Code,
 		Name:          portfolio.Name,
-		Shares:        portfolio.Shares,
+		Shares:        int64(portfolio.Shares),
 		PurchasePrice: portfolio.PurchasePrice,
 		PurchaseDate:  portfolio.PurchaseDate,
+		PositionType:  positionTypeOrDefault(portfolio.PositionType),
+		MarginRate:    portfolio.MarginRate,
+		InterestRate:  portfolio.InterestRate,
+		AssetClass:    portfolio.GetAssetClass(),
+		Isin:          portfolio.Isin,
 		CreatedAt:     portfolio.CreatedAt,
 		UpdatedAt:     portfolio.UpdatedAt,
+		DeletedAt:     portfolio.DeletedAt,
 	}
 
-	_, err := daoPortfolio.Update(ctx, r.db, boil.Infer())
+	_, err := daoPortfolio.Update(ctx, getExecutor(ctx, r.db), boil.Infer())
 	if err != nil {
 		return err
 	}
@@ -127,10 +156,42 @@ func (r *portfolioRepositoryImpl) Update(ctx context.Context, portfolio *models.
 	return nil
 }
 
-// Delete removes a portfolio record by ID.
+// Delete soft-deletes a portfolio record by ID.
 func (r *portfolioRepositoryImpl) Delete(ctx context.Context, id string) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE portfolios SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", id)
+	return err
+}
+
+// GetDeleted retrieves the soft-deleted portfolio records, most recently deleted first.
+func (r *portfolioRepositoryImpl) GetDeleted(ctx context.Context) ([]*models.Portfolio, error) {
+	daoPortfolios, err := dao.Portfolios(
+		qm.Where("deleted_at IS NOT NULL"),
+		qm.OrderBy("deleted_at DESC"),
+	).All(ctx, getExecutor(ctx, r.db))
+	if err != nil {
+		return nil, err
+	}
+
+	portfolios := make([]*models.Portfolio, len(daoPortfolios))
+	for i, daoPortfolio := range daoPortfolios {
+		portfolios[i] = r.convertToModel(daoPortfolio)
+	}
+
+	return portfolios, nil
+}
+
+// Restore restores a soft-deleted portfolio record by ID.
+func (r *portfolioRepositoryImpl) Restore(ctx context.Context, id string) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE portfolios SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
+	return err
+}
+
+// Purge removes a portfolio record by ID for good, such as a holding sold out.
+func (r *portfolioRepositoryImpl) Purge(ctx context.Context, id string) error {
 	daoPortfolio := &dao.Portfolio{ID: id}
-	_, err := daoPortfolio.Delete(ctx, r.db)
+	_, err := daoPortfolio.Delete(ctx, getExecutor(ctx, r.db))
 	return err
 }
 
@@ -165,7 +226,8 @@ func (r *portfolioRepositoryImpl) GetHoldingsByCode(ctx context.Context, codes [
 
 	daoPortfolios, err := dao.Portfolios(
 		qm.WhereIn("code IN ?", args...),
-	).All(ctx, r.db)
+		notDeleted,
+	).All(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		return nil, err
 	}
@@ -184,10 +246,24 @@ func (r *portfolioRepositoryImpl) convertToModel(daoPortfolio *dao.Portfolio) *m
 		ID:            daoPortfolio.ID,
 		Code:          daoPortfolio.Code,
 		Name:          daoPortfolio.Name,
-		Shares:        daoPortfolio.Shares,
+		Shares:        int(daoPortfolio.Shares),
 		PurchasePrice: daoPortfolio.PurchasePrice,
 		PurchaseDate:  daoPortfolio.PurchaseDate,
+		PositionType:  daoPortfolio.PositionType,
+		MarginRate:    daoPortfolio.MarginRate,
+		InterestRate:  daoPortfolio.InterestRate,
+		AssetClass:    daoPortfolio.AssetClass,
+		Isin:          daoPortfolio.Isin,
 		CreatedAt:     daoPortfolio.CreatedAt,
 		UpdatedAt:     daoPortfolio.UpdatedAt,
+		DeletedAt:     daoPortfolio.DeletedAt,
+	}
+}
+
+// positionTypeOrDefault returns the position type, defaulting to a long position.
+func positionTypeOrDefault(positionType string) string {
+	if positionType == "" {
+		return models.PositionTypeLong
 	}
+	return positionType
 }
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// PortfolioGoalRepository defines portfolio goal related operations.
+type PortfolioGoalRepository interface {
+	Create(ctx context.Context, goal *models.PortfolioGoal) error
+	GetByName(ctx context.Context, name string) (*models.PortfolioGoal, error)
+	GetAll(ctx context.Context) ([]*models.PortfolioGoal, error)
+	Update(ctx context.Context, goal *models.PortfolioGoal) error
+	UpdateLastStatus(ctx context.Context, id, status string) error
+	Delete(ctx context.Context, id string) error
+}
+
+// portfolioGoalRepositoryImpl implements PortfolioGoalRepository.
+type portfolioGoalRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewPortfolioGoalRepository creates a new portfolio goal repository.
+func NewPortfolioGoalRepository(db boil.ContextExecutor) PortfolioGoalRepository {
+	return &portfolioGoalRepositoryImpl{db: db}
+}
+
+const portfolioGoalColumns = "id, name, baseline_value, target_value, start_date, deadline, last_status, created_at, updated_at"
+
+// Create creates a new goal.
+func (r *portfolioGoalRepositoryImpl) Create(ctx context.Context, goal *models.PortfolioGoal) error {
+	if goal.ID == "" {
+		goal.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO portfolio_goals (id, name, baseline_value, target_value, start_date, deadline, last_status)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		goal.ID,
+		goal.Name,
+		goal.BaselineValue,
+		goal.TargetValue,
+		goal.StartDate,
+		goal.Deadline,
+		goal.LastStatus,
+	)
+	return err
+}
+
+// GetByName retrieves a goal by its name.
+// Returns nil if the goal does not exist.
+func (r *portfolioGoalRepositoryImpl) GetByName(ctx context.Context, name string) (*models.PortfolioGoal, error) {
+	query := "SELECT " + portfolioGoalColumns + " FROM portfolio_goals WHERE name = ?"
+
+	goal, err := scanPortfolioGoal(getExecutor(ctx, r.db).QueryRowContext(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+// GetAll retrieves all goals ordered by deadline.
+func (r *portfolioGoalRepositoryImpl) GetAll(ctx context.Context) ([]*models.PortfolioGoal, error) {
+	query := "SELECT " + portfolioGoalColumns + " FROM portfolio_goals ORDER BY deadline, name"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	goals := []*models.PortfolioGoal{}
+	for rows.Next() {
+		goal, err := scanPortfolioGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, goal)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+}
+
+// Update updates the name, values, period and notified status of a goal.
+func (r *portfolioGoalRepositoryImpl) Update(ctx context.Context, goal *models.PortfolioGoal) error {
+	query := `
+		UPDATE portfolio_goals
+		SET name = ?, baseline_value = ?, target_value = ?, start_date = ?, deadline = ?, last_status = ?
+		WHERE id = ?`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		goal.Name,
+		goal.BaselineValue,
+		goal.TargetValue,
+		goal.StartDate,
+		goal.Deadline,
+		goal.LastStatus,
+		goal.ID,
+	)
+	return err
+}
+
+// UpdateLastStatus records the last notified pace of a goal.
+func (r *portfolioGoalRepositoryImpl) UpdateLastStatus(ctx context.Context, id, status string) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE portfolio_goals SET last_status = ? WHERE id = ?", status, id)
+	return err
+}
+
+// Delete deletes a goal.
+func (r *portfolioGoalRepositoryImpl) Delete(ctx context.Context, id string) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM portfolio_goals WHERE id = ?", id)
+	return err
+}
+
+// scanPortfolioGoal scans a portfolio goal row.
+func scanPortfolioGoal(row rowScanner) (*models.PortfolioGoal, error) {
+	goal := &models.PortfolioGoal{}
+	err := row.Scan(
+		&goal.ID,
+		&goal.Name,
+		&goal.BaselineValue,
+		&goal.TargetValue,
+		&goal.StartDate,
+		&goal.Deadline,
+		&goal.LastStatus,
+		&goal.CreatedAt,
+		&goal.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
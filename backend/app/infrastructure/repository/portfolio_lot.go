@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// PortfolioLotRepository defines portfolio lot related operations.
+type PortfolioLotRepository interface {
+	Create(ctx context.Context, lot *models.PortfolioLot) error
+	GetByCode(ctx context.Context, code string) ([]*models.PortfolioLot, error)
+	Update(ctx context.Context, lot *models.PortfolioLot) error
+	Delete(ctx context.Context, id string) error
+	DeleteByCode(ctx context.Context, code string) error
+	RestoreByCode(ctx context.Context, code string, since time.Time) error
+}
+
+// portfolioLotRepositoryImpl implements PortfolioLotRepository.
+type portfolioLotRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewPortfolioLotRepository creates a new portfolio lot repository.
+func NewPortfolioLotRepository(db boil.ContextExecutor) PortfolioLotRepository {
+	return &portfolioLotRepositoryImpl{db: db}
+}
+
+// Create creates a new lot.
+func (r *portfolioLotRepositoryImpl) Create(ctx context.Context, lot *models.PortfolioLot) error {
+	lot.Code = domain.NormalizeCode(lot.Code)
+	if lot.ID == "" {
+		lot.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO portfolio_lots (id, code, shares, purchase_price, purchase_date)
+		VALUES (?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		lot.ID,
+		lot.Code,
+		lot.Shares,
+		lot.PurchasePrice,
+		lot.PurchaseDate.Format("2006-01-02"),
+	)
+	return err
+}
+
+// GetByCode retrieves the lots of a stock from the oldest purchase.
+func (r *portfolioLotRepositoryImpl) GetByCode(ctx context.Context, code string) ([]*models.PortfolioLot, error) {
+	code = domain.NormalizeCode(code)
+	query := `
+		SELECT id, code, shares, purchase_price, average_price, purchase_date, created_at, updated_at
+		FROM portfolio_lots
+		WHERE code = ? AND deleted_at IS NULL
+		ORDER BY purchase_date, id`
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, code)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := []*models.PortfolioLot{}
+	for rows.Next() {
+		lot := &models.PortfolioLot{}
+		err := rows.Scan(
+			&lot.ID,
+			&lot.Code,
+			&lot.Shares,
+			&lot.PurchasePrice,
+			&lot.AveragePrice,
+			&lot.PurchaseDate,
+			&lot.CreatedAt,
+			&lot.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lots, nil
+}
+
+// Update updates the remaining shares and the average price of a lot. The purchase price is never changed.
+func (r *portfolioLotRepositoryImpl) Update(ctx context.Context, lot *models.PortfolioLot) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE portfolio_lots SET shares = ?, average_price = ? WHERE id = ?", lot.Shares, lot.AveragePrice, lot.ID)
+	return err
+}
+
+// Delete deletes a sold out lot.
+func (r *portfolioLotRepositoryImpl) Delete(ctx context.Context, id string) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM portfolio_lots WHERE id = ?", id)
+	return err
+}
+
+// DeleteByCode soft-deletes all the lots of a stock, with its removed holding.
+func (r *portfolioLotRepositoryImpl) DeleteByCode(ctx context.Context, code string) error {
+	code = domain.NormalizeCode(code)
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE portfolio_lots SET deleted_at = CURRENT_TIMESTAMP WHERE code = ? AND deleted_at IS NULL", code)
+	return err
+}
+
+// RestoreByCode restores the lots of a stock soft-deleted at or after since, the time its holding was removed.
+func (r *portfolioLotRepositoryImpl) RestoreByCode(ctx context.Context, code string, since time.Time) error {
+	code = domain.NormalizeCode(code)
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE portfolio_lots SET deleted_at = NULL WHERE code = ? AND deleted_at >= ?", code, since)
+	return err
+}
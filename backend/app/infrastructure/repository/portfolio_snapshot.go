@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// PortfolioSnapshotRepository defines daily portfolio snapshot related operations.
+type PortfolioSnapshotRepository interface {
+	Upsert(ctx context.Context, snapshot *models.PortfolioSnapshot) error
+	GetRange(ctx context.Context, from, to time.Time) ([]*models.PortfolioSnapshot, error)
+	GetLatestBefore(ctx context.Context, date time.Time) (*models.PortfolioSnapshot, error)
+}
+
+// portfolioSnapshotRepositoryImpl implements PortfolioSnapshotRepository.
+type portfolioSnapshotRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewPortfolioSnapshotRepository creates a new portfolio snapshot repository.
+func NewPortfolioSnapshotRepository(db boil.ContextExecutor) PortfolioSnapshotRepository {
+	return &portfolioSnapshotRepositoryImpl{db: db}
+}
+
+const portfolioSnapshotColumns = "snapshot_date, total_value, total_cost, total_gain, holdings_count, created_at, updated_at"
+
+// Upsert creates or replaces the snapshot of a day.
+func (r *portfolioSnapshotRepositoryImpl) Upsert(ctx context.Context, snapshot *models.PortfolioSnapshot) error {
+	query := `
+		INSERT INTO portfolio_snapshots (snapshot_date, total_value, total_cost, total_gain, holdings_count)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			total_value = VALUES(total_value),
+			total_cost = VALUES(total_cost),
+			total_gain = VALUES(total_gain),
+			holdings_count = VALUES(holdings_count)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		snapshot.DateKey(),
+		snapshot.TotalValue,
+		snapshot.TotalCost,
+		snapshot.TotalGain,
+		snapshot.HoldingsCount,
+	)
+	return err
+}
+
+// GetRange retrieves the snapshots between from and to (inclusive) ordered by date.
+func (r *portfolioSnapshotRepositoryImpl) GetRange(ctx context.Context, from, to time.Time) ([]*models.PortfolioSnapshot, error) {
+	query := "SELECT " + portfolioSnapshotColumns + " FROM portfolio_snapshots WHERE snapshot_date BETWEEN ? AND ? ORDER BY snapshot_date"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query,
+		from.Format(models.SnapshotDateFormat),
+		to.Format(models.SnapshotDateFormat),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []*models.PortfolioSnapshot{}
+	for rows.Next() {
+		snapshot, err := scanPortfolioSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// GetLatestBefore retrieves the latest snapshot before date.
+// Returns nil if there is no earlier snapshot.
+func (r *portfolioSnapshotRepositoryImpl) GetLatestBefore(ctx context.Context, date time.Time) (*models.PortfolioSnapshot, error) {
+	query := "SELECT " + portfolioSnapshotColumns + " FROM portfolio_snapshots WHERE snapshot_date < ? ORDER BY snapshot_date DESC LIMIT 1"
+
+	snapshot, err := scanPortfolioSnapshot(getExecutor(ctx, r.db).QueryRowContext(ctx, query, date.Format(models.SnapshotDateFormat)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// scanPortfolioSnapshot scans a portfolio snapshot row.
+func scanPortfolioSnapshot(row rowScanner) (*models.PortfolioSnapshot, error) {
+	snapshot := &models.PortfolioSnapshot{}
+	err := row.Scan(
+		&snapshot.SnapshotDate,
+		&snapshot.TotalValue,
+		&snapshot.TotalCost,
+		&snapshot.TotalGain,
+		&snapshot.HoldingsCount,
+		&snapshot.CreatedAt,
+		&snapshot.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// RawResponseRepository defines operations of the data source responses kept for debugging.
+type RawResponseRepository interface {
+	SaveRawResponse(ctx context.Context, response *models.RawResponse) error
+	GetByID(ctx context.Context, id int64) (*models.RawResponse, error)
+	GetLatest(ctx context.Context, limit int) ([]*models.RawResponse, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// rawResponseRepositoryImpl implements RawResponseRepository.
+type rawResponseRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewRawResponseRepository creates a new raw response repository.
+func NewRawResponseRepository(db boil.ContextExecutor) RawResponseRepository {
+	return &rawResponseRepositoryImpl{db: db}
+}
+
+const rawResponseColumns = "id, source, endpoint, code, url, status_code, body, truncated, error, created_at"
+
+// SaveRawResponse records a response body and sets its ID.
+func (r *rawResponseRepositoryImpl) SaveRawResponse(ctx context.Context, response *models.RawResponse) error {
+	if response.CreatedAt.IsZero() {
+		response.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO raw_responses (source, endpoint, code, url, status_code, body, truncated, error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		response.Source,
+		response.Endpoint,
+		response.Code,
+		response.URL,
+		response.StatusCode,
+		response.Body,
+		response.Truncated,
+		response.Error,
+		response.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	response.ID = id
+	return nil
+}
+
+// GetByID retrieves a response by ID.
+// Returns nil if it does not exist.
+func (r *rawResponseRepositoryImpl) GetByID(ctx context.Context, id int64) (*models.RawResponse, error) {
+	query := "SELECT " + rawResponseColumns + " FROM raw_responses WHERE id = ?"
+
+	response, err := scanRawResponse(getExecutor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetLatest retrieves the latest responses, newest first.
+func (r *rawResponseRepositoryImpl) GetLatest(ctx context.Context, limit int) ([]*models.RawResponse, error) {
+	query := "SELECT " + rawResponseColumns + " FROM raw_responses ORDER BY id DESC LIMIT ?"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	responses := []*models.RawResponse{}
+	for rows.Next() {
+		response, err := scanRawResponse(rows)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// DeleteBefore deletes the responses saved before the given time and returns the number deleted.
+func (r *rawResponseRepositoryImpl) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM raw_responses WHERE created_at < ?", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanRawResponse scans a raw response row.
+func scanRawResponse(row rowScanner) (*models.RawResponse, error) {
+	response := &models.RawResponse{}
+	err := row.Scan(
+		&response.ID,
+		&response.Source,
+		&response.Endpoint,
+		&response.Code,
+		&response.URL,
+		&response.StatusCode,
+		&response.Body,
+		&response.Truncated,
+		&response.Error,
+		&response.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
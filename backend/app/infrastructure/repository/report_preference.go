@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// ReportPreferenceRepository defines operations of the settings turning the report sections on or off.
+type ReportPreferenceRepository interface {
+	Upsert(ctx context.Context, preference *models.ReportPreference) error
+	DeleteAll(ctx context.Context) (int64, error)
+	GetAll(ctx context.Context) ([]*models.ReportPreference, error)
+}
+
+// reportPreferenceRepositoryImpl implements ReportPreferenceRepository.
+type reportPreferenceRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewReportPreferenceRepository creates a new report preference repository.
+func NewReportPreferenceRepository(db boil.ContextExecutor) ReportPreferenceRepository {
+	return &reportPreferenceRepositoryImpl{db: db}
+}
+
+// Upsert sets whether a section is shown, replacing the setting already saved.
+func (r *reportPreferenceRepositoryImpl) Upsert(ctx context.Context, preference *models.ReportPreference) error {
+	query := `
+		INSERT INTO report_preferences (section, enabled)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE enabled = VALUES(enabled)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query, preference.Section, preference.Enabled)
+	return err
+}
+
+// DeleteAll removes all settings, showing every section again, and returns the number removed.
+func (r *reportPreferenceRepositoryImpl) DeleteAll(ctx context.Context) (int64, error) {
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM report_preferences")
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetAll retrieves the settings of all sections, ordered by section.
+func (r *reportPreferenceRepositoryImpl) GetAll(ctx context.Context) ([]*models.ReportPreference, error) {
+	query := "SELECT section, enabled, updated_at FROM report_preferences ORDER BY section"
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	preferences := []*models.ReportPreference{}
+	for rows.Next() {
+		preference := &models.ReportPreference{}
+		if err := rows.Scan(&preference.Section, &preference.Enabled, &preference.UpdatedAt); err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, preference)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain"
+)
+
+// RetentionRepository defines operations of the rows past the retention period of a policy.
+// Tables and columns come from domain.RetentionTargets, never from user input.
+type RetentionRepository interface {
+	CountBefore(ctx context.Context, target domain.RetentionTarget, before time.Time) (int64, error)
+	ExportBefore(ctx context.Context, target domain.RetentionTarget, before time.Time, w io.Writer) (int64, error)
+	DeleteBefore(ctx context.Context, target domain.RetentionTarget, before time.Time, batchSize int) (int64, error)
+}
+
+// retentionRepositoryImpl implements RetentionRepository.
+type retentionRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewRetentionRepository creates a new retention repository.
+func NewRetentionRepository(db boil.ContextExecutor) RetentionRepository {
+	return &retentionRepositoryImpl{db: db}
+}
+
+// retentionWhere returns the condition selecting the rows of the target before the given time.
+func retentionWhere(target domain.RetentionTarget) string {
+	where := fmt.Sprintf("`%s` < ?", target.Column)
+	if target.Condition != "" {
+		where += " AND " + target.Condition
+	}
+	return where
+}
+
+// CountBefore returns the number of rows of the target before the given time.
+func (r *retentionRepositoryImpl) CountBefore(ctx context.Context, target domain.RetentionTarget, before time.Time) (int64, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM `%s` WHERE %s", target.Table, retentionWhere(target))
+
+	var count int64
+	if err := getExecutor(ctx, r.db).QueryRowContext(ctx, query, before).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ExportBefore writes the rows of the target before the given time to w as JSON Lines, one object
+// of the columns per row, and returns the number written. The rows are read with FOR UPDATE, so that
+// within a transaction no row can be written to the range until it ends, e.g. before they are deleted.
+func (r *retentionRepositoryImpl) ExportBefore(ctx context.Context, target domain.RetentionTarget, before time.Time, w io.Writer) (int64, error) {
+	query := fmt.Sprintf("SELECT * FROM `%s` WHERE %s ORDER BY `%s` FOR UPDATE", target.Table, retentionWhere(target), target.Column)
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	var exported int64
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return exported, err
+		}
+
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			// Text and decimal columns are scanned as bytes, which JSON would encode in base64
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		if err := encoder.Encode(row); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+
+	return exported, rows.Err()
+}
+
+// DeleteBefore deletes the rows of the target before the given time in batches of batchSize rows,
+// so that a large deletion does not lock the table for long, and returns the number deleted.
+// A batch size of zero or less deletes them at once.
+func (r *retentionRepositoryImpl) DeleteBefore(ctx context.Context, target domain.RetentionTarget, before time.Time, batchSize int) (int64, error) {
+	query := fmt.Sprintf("DELETE FROM `%s` WHERE %s", target.Table, retentionWhere(target))
+	if batchSize > 0 {
+		query += fmt.Sprintf(" LIMIT %d", batchSize)
+	}
+
+	var deleted int64
+	for {
+		result, err := getExecutor(ctx, r.db).ExecContext(ctx, query, before)
+		if err != nil {
+			return deleted, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += affected
+
+		if batchSize <= 0 || affected < int64(batchSize) {
+			return deleted, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+	}
+}
@@ -0,0 +1,128 @@
+//go:build integration
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/testutil"
+	"github.com/boost-jp/stock-automation/app/testutil/fixture"
+)
+
+// insertRetentionPrices inserts a daily price of 7203 for each of the given days.
+func insertRetentionPrices(t *testing.T, tdb *testutil.TestDB, days ...time.Time) {
+	t.Helper()
+	for _, day := range days {
+		if err := fixture.NewStockPrice().WithCode("7203").WithDate(day).Insert(context.Background(), tdb.GetBoilDB()); err != nil {
+			t.Fatalf("Failed to insert the price of %s: %v", day.Format("2006-01-02"), err)
+		}
+	}
+}
+
+func TestRetentionRepository(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Cleanup()
+	repo := NewRetentionRepository(tdb.GetBoilDB())
+	txManager := NewTransactionManager(tdb.GetDB())
+	ctx := context.Background()
+
+	target, err := domain.GetRetentionTarget("stock_prices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cutoff := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	reset := func(t *testing.T) {
+		t.Helper()
+		if err := tdb.TruncateAll(); err != nil {
+			t.Fatalf("Failed to truncate tables: %v", err)
+		}
+		// Five days before the cutoff and two days from it
+		insertRetentionPrices(t, tdb,
+			cutoff.AddDate(0, 0, -5), cutoff.AddDate(0, 0, -4), cutoff.AddDate(0, 0, -3),
+			cutoff.AddDate(0, 0, -2), cutoff.AddDate(0, 0, -1), cutoff, cutoff.AddDate(0, 0, 1))
+	}
+
+	t.Run("counts and exports the rows before the cutoff", func(t *testing.T) {
+		reset(t)
+
+		count, err := repo.CountBefore(ctx, target, cutoff)
+		if err != nil || count != 5 {
+			t.Fatalf("CountBefore() = %d, %v, want 5", count, err)
+		}
+
+		var buf bytes.Buffer
+		exported, err := repo.ExportBefore(ctx, target, cutoff, &buf)
+		if err != nil || exported != 5 {
+			t.Fatalf("ExportBefore() = %d, %v, want 5", exported, err)
+		}
+		decoder := json.NewDecoder(&buf)
+		for i := 0; decoder.More(); i++ {
+			var row map[string]any
+			if err := decoder.Decode(&row); err != nil {
+				t.Fatalf("Failed to decode exported row %d: %v", i, err)
+			}
+			if row["code"] != "7203" || row["close_price"] != "2050.00" {
+				t.Errorf("Exported row %d = %v, want the columns of the price with decimals as text", i, row)
+			}
+		}
+	})
+
+	t.Run("deletes the rows before the cutoff in batches", func(t *testing.T) {
+		reset(t)
+
+		deleted, err := repo.DeleteBefore(ctx, target, cutoff, 2)
+		if err != nil || deleted != 5 {
+			t.Fatalf("DeleteBefore(batchSize 2) = %d, %v, want 5", deleted, err)
+		}
+		if count, err := repo.CountBefore(ctx, target, cutoff.AddDate(0, 0, 2)); err != nil || count != 2 {
+			t.Errorf("Rows left = %d, %v, want the 2 rows from the cutoff", count, err)
+		}
+
+		// Nothing is left to delete
+		if deleted, err := repo.DeleteBefore(ctx, target, cutoff, 0); err != nil || deleted != 0 {
+			t.Errorf("DeleteBefore() again = %d, %v, want 0", deleted, err)
+		}
+	})
+
+	t.Run("locks the exported range within a transaction", func(t *testing.T) {
+		reset(t)
+
+		conn, err := tdb.GetDB().Conn(ctx)
+		if err != nil {
+			t.Fatalf("Conn() error = %v", err)
+		}
+		defer conn.Close()
+		if _, err := conn.ExecContext(ctx, "SET SESSION innodb_lock_wait_timeout = 1"); err != nil {
+			t.Fatalf("Failed to set the lock wait timeout: %v", err)
+		}
+
+		err = txManager.WithTx(ctx, func(ctx context.Context) error {
+			var buf bytes.Buffer
+			if _, err := repo.ExportBefore(ctx, target, cutoff, &buf); err != nil {
+				return err
+			}
+
+			// Another session cannot add a row to the exported range until the transaction ends
+			_, err := conn.ExecContext(ctx,
+				"INSERT INTO stock_prices (id, code, date, open_price, high_price, low_price, close_price, volume) VALUES (?, '6758', ?, 1, 1, 1, 1, 1)",
+				"01HQRETENTIONLOCK000000000", cutoff.AddDate(0, 0, -3))
+			if err == nil {
+				t.Error("Inserted a row into the exported range, want the insert blocked by the lock")
+			}
+
+			deleted, err := repo.DeleteBefore(ctx, target, cutoff, 0)
+			if err != nil || deleted != 5 {
+				t.Errorf("DeleteBefore() in the transaction = %d, %v, want the 5 exported rows", deleted, err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WithTx() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/sirupsen/logrus"
+)
+
+// SchemaMigrationRepository defines zero-downtime schema migration related operations.
+type SchemaMigrationRepository interface {
+	Get(ctx context.Context, name string) (*models.SchemaMigration, error)
+	GetAll(ctx context.Context) ([]*models.SchemaMigration, error)
+	Save(ctx context.Context, migration *models.SchemaMigration) error
+
+	// CountPending counts the rows not backfilled yet.
+	CountPending(ctx context.Context, def domain.SchemaMigrationDefinition) (int64, error)
+	// BackfillBatch backfills up to limit pending rows with a key after afterKey in key order.
+	// Returns the last key of the batch, or an empty key if no pending rows are left.
+	BackfillBatch(ctx context.Context, def domain.SchemaMigrationDefinition, afterKey string, limit int) (string, int64, error)
+}
+
+// schemaMigrationRepositoryImpl implements SchemaMigrationRepository.
+type schemaMigrationRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewSchemaMigrationRepository creates a new schema migration repository.
+func NewSchemaMigrationRepository(db boil.ContextExecutor) SchemaMigrationRepository {
+	return &schemaMigrationRepositoryImpl{db: db}
+}
+
+const schemaMigrationColumns = "name, phase, total_rows, backfilled_rows, last_key, started_at, backfill_started_at, backfilled_at, completed_at, created_at, updated_at"
+
+// Get retrieves a schema migration by name.
+// Returns nil if the migration has not been started.
+func (r *schemaMigrationRepositoryImpl) Get(ctx context.Context, name string) (*models.SchemaMigration, error) {
+	query := "SELECT " + schemaMigrationColumns + " FROM schema_migrations WHERE name = ?"
+
+	migration, err := scanSchemaMigration(getExecutor(ctx, r.db).QueryRowContext(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+// GetAll retrieves all schema migrations ordered by name.
+func (r *schemaMigrationRepositoryImpl) GetAll(ctx context.Context) ([]*models.SchemaMigration, error) {
+	query := "SELECT " + schemaMigrationColumns + " FROM schema_migrations ORDER BY name"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	migrations := []*models.SchemaMigration{}
+	for rows.Next() {
+		migration, err := scanSchemaMigration(rows)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return migrations, nil
+}
+
+// Save creates or replaces the phase and backfill progress of a schema migration.
+func (r *schemaMigrationRepositoryImpl) Save(ctx context.Context, migration *models.SchemaMigration) error {
+	if err := migration.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO schema_migrations (name, phase, total_rows, backfilled_rows, last_key, started_at, backfill_started_at, backfilled_at, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			phase = VALUES(phase),
+			total_rows = VALUES(total_rows),
+			backfilled_rows = VALUES(backfilled_rows),
+			last_key = VALUES(last_key),
+			started_at = VALUES(started_at),
+			backfill_started_at = VALUES(backfill_started_at),
+			backfilled_at = VALUES(backfilled_at),
+			completed_at = VALUES(completed_at)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		migration.Name,
+		migration.Phase,
+		migration.TotalRows,
+		migration.BackfilledRows,
+		migration.LastKey,
+		migration.StartedAt,
+		migration.BackfillStartedAt,
+		migration.BackfilledAt,
+		migration.CompletedAt,
+	)
+	return err
+}
+
+// CountPending counts the rows not backfilled yet.
+func (r *schemaMigrationRepositoryImpl) CountPending(ctx context.Context, def domain.SchemaMigrationDefinition) (int64, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", def.Table, def.Pending)
+
+	var count int64
+	if err := getExecutor(ctx, r.db).QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// BackfillBatch backfills up to limit pending rows with a key after afterKey in key order.
+// The keys of the batch are selected first, so that the UPDATE locks only the rows of the batch
+// by a range of the key instead of scanning the table.
+func (r *schemaMigrationRepositoryImpl) BackfillBatch(ctx context.Context, def domain.SchemaMigrationDefinition, afterKey string, limit int) (string, int64, error) {
+	exec := getExecutor(ctx, r.db)
+
+	selectQuery := fmt.Sprintf("SELECT %[1]s FROM %[2]s WHERE %[1]s > ? AND (%[3]s) ORDER BY %[1]s LIMIT ?",
+		def.KeyColumn, def.Table, def.Pending)
+	rows, err := exec.QueryContext(ctx, selectQuery, afterKey, limit)
+	if err != nil {
+		return "", 0, err
+	}
+	defer rows.Close()
+
+	var lastKey string
+	for rows.Next() {
+		if err := rows.Scan(&lastKey); err != nil {
+			return "", 0, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+	if lastKey == "" {
+		return "", 0, nil
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE %[1]s SET %[2]s WHERE %[3]s > ? AND %[3]s <= ? AND (%[4]s)",
+		def.Table, def.Backfill, def.KeyColumn, def.Pending)
+	result, err := exec.ExecContext(ctx, updateQuery, afterKey, lastKey)
+	if err != nil {
+		return "", 0, err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return "", 0, err
+	}
+
+	return lastKey, updated, nil
+}
+
+// scanSchemaMigration scans a schema migration row.
+func scanSchemaMigration(row rowScanner) (*models.SchemaMigration, error) {
+	migration := &models.SchemaMigration{}
+	err := row.Scan(
+		&migration.Name,
+		&migration.Phase,
+		&migration.TotalRows,
+		&migration.BackfilledRows,
+		&migration.LastKey,
+		&migration.StartedAt,
+		&migration.BackfillStartedAt,
+		&migration.BackfilledAt,
+		&migration.CompletedAt,
+		&migration.CreatedAt,
+		&migration.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+// DefaultSchemaMigrationPhaseTTL is how long SchemaMigrationPhases caches the phase of a migration.
+// A phase change reaches all running processes within this time.
+const DefaultSchemaMigrationPhaseTTL = 30 * time.Second
+
+// SchemaMigrationPhases tells repositories which schemas to write and read during a
+// zero-downtime schema migration. Phases are cached for a TTL so that checking the phase on
+// every write does not add a query, and the last known phase is kept if reloading it fails.
+type SchemaMigrationPhases struct {
+	repo SchemaMigrationRepository
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]schemaMigrationPhaseEntry
+}
+
+// schemaMigrationPhaseEntry is a cached phase of a migration.
+type schemaMigrationPhaseEntry struct {
+	phase    models.SchemaMigrationPhase
+	loadedAt time.Time
+}
+
+// NewSchemaMigrationPhases creates a schema migration phase cache.
+func NewSchemaMigrationPhases(repo SchemaMigrationRepository, ttl time.Duration) *SchemaMigrationPhases {
+	if ttl <= 0 {
+		ttl = DefaultSchemaMigrationPhaseTTL
+	}
+	return &SchemaMigrationPhases{
+		repo:    repo,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]schemaMigrationPhaseEntry),
+	}
+}
+
+// Phase returns the phase of a migration. A migration not started yet is pending.
+func (p *SchemaMigrationPhases) Phase(ctx context.Context, name string) models.SchemaMigrationPhase {
+	now := p.now()
+
+	p.mu.Lock()
+	entry, ok := p.entries[name]
+	p.mu.Unlock()
+	if ok && now.Sub(entry.loadedAt) < p.ttl {
+		return entry.phase
+	}
+
+	phase := models.SchemaMigrationPending
+	migration, err := p.repo.Get(ctx, name)
+	switch {
+	case err != nil:
+		logrus.Warnf("Failed to load phase of schema migration %s: %v", name, err)
+		if ok {
+			phase = entry.phase
+		}
+	case migration != nil:
+		phase = migration.Phase
+	}
+
+	p.mu.Lock()
+	p.entries[name] = schemaMigrationPhaseEntry{phase: phase, loadedAt: now}
+	p.mu.Unlock()
+	return phase
+}
+
+// WritesOld reports whether the old schema of a migration should be written.
+func (p *SchemaMigrationPhases) WritesOld(ctx context.Context, name string) bool {
+	return p.Phase(ctx, name).WritesOld()
+}
+
+// WritesNew reports whether the new schema of a migration should be written.
+func (p *SchemaMigrationPhases) WritesNew(ctx context.Context, name string) bool {
+	return p.Phase(ctx, name).WritesNew()
+}
+
+// ReadsNew reports whether the new schema of a migration should be read.
+func (p *SchemaMigrationPhases) ReadsNew(ctx context.Context, name string) bool {
+	return p.Phase(ctx, name).ReadsNew()
+}
+
+// Invalidate drops the cached phase of a migration so that the next call reloads it.
+func (p *SchemaMigrationPhases) Invalidate(name string) {
+	p.mu.Lock()
+	delete(p.entries, name)
+	p.mu.Unlock()
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil"
+)
+
+// backfillItemsMigration copies the integer price of backfill_items to a new decimal column.
+var backfillItemsMigration = domain.SchemaMigrationDefinition{
+	Name:      "backfill_items_price_decimal",
+	Table:     "backfill_items",
+	KeyColumn: "id",
+	Backfill:  "price_decimal = price",
+	Pending:   "price_decimal IS NULL",
+}
+
+func TestSchemaMigrationRepository_Backfill(t *testing.T) {
+	tdb := testutil.NewTestDB(t)
+	defer tdb.Cleanup()
+	repo := NewSchemaMigrationRepository(tdb.GetBoilDB())
+	ctx := context.Background()
+
+	if err := tdb.ExecSQL(`CREATE TABLE backfill_items (
+		id VARCHAR(26) PRIMARY KEY,
+		price INT NOT NULL,
+		price_decimal DECIMAL(10,2) NULL
+	)`); err != nil {
+		t.Fatalf("Failed to create backfill_items: %v", err)
+	}
+	// Five rows written before dual-writing, and one written to both columns
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		if err := tdb.ExecSQL("INSERT INTO backfill_items (id, price) VALUES (?, 100)", id); err != nil {
+			t.Fatalf("Failed to insert %s: %v", id, err)
+		}
+	}
+	if err := tdb.ExecSQL("INSERT INTO backfill_items (id, price, price_decimal) VALUES ('f', 100, 100)"); err != nil {
+		t.Fatalf("Failed to insert f: %v", err)
+	}
+
+	pending, err := repo.CountPending(ctx, backfillItemsMigration)
+	if err != nil || pending != 5 {
+		t.Fatalf("CountPending() = %d, %v, want 5", pending, err)
+	}
+
+	// Batches walk the key order and resume after the last key
+	batches := []struct {
+		afterKey    string
+		wantLastKey string
+		wantUpdated int64
+	}{
+		{"", "b", 2},
+		{"b", "d", 2},
+		{"d", "e", 1},
+		{"e", "", 0},
+	}
+	for _, batch := range batches {
+		lastKey, updated, err := repo.BackfillBatch(ctx, backfillItemsMigration, batch.afterKey, 2)
+		if err != nil {
+			t.Fatalf("BackfillBatch(after %q) error = %v", batch.afterKey, err)
+		}
+		if lastKey != batch.wantLastKey || updated != batch.wantUpdated {
+			t.Errorf("BackfillBatch(after %q) = %q, %d, want %q, %d", batch.afterKey, lastKey, updated, batch.wantLastKey, batch.wantUpdated)
+		}
+	}
+	if pending, err := repo.CountPending(ctx, backfillItemsMigration); err != nil || pending != 0 {
+		t.Errorf("CountPending() after the backfill = %d, %v, want 0", pending, err)
+	}
+
+	// A row before the last key that became pending again is found by walking from the start
+	if err := tdb.ExecSQL("UPDATE backfill_items SET price_decimal = NULL WHERE id = 'a'"); err != nil {
+		t.Fatalf("Failed to reset a: %v", err)
+	}
+	if lastKey, updated, err := repo.BackfillBatch(ctx, backfillItemsMigration, "e", 2); err != nil || lastKey != "" || updated != 0 {
+		t.Errorf("BackfillBatch(after e) = %q, %d, %v, want nothing after the last key", lastKey, updated, err)
+	}
+	if lastKey, updated, err := repo.BackfillBatch(ctx, backfillItemsMigration, "", 2); err != nil || lastKey != "a" || updated != 1 {
+		t.Errorf("BackfillBatch() from the start = %q, %d, %v, want a backfilled", lastKey, updated, err)
+	}
+
+	// The progress is saved and read back
+	migration := &models.SchemaMigration{
+		Name:           backfillItemsMigration.Name,
+		Phase:          models.SchemaMigrationBackfilling,
+		TotalRows:      5,
+		BackfilledRows: 2,
+		LastKey:        "b",
+	}
+	if err := repo.Save(ctx, migration); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := repo.Get(ctx, backfillItemsMigration.Name)
+	if err != nil || got == nil {
+		t.Fatalf("Get() = %v, %v", got, err)
+	}
+	if got.Phase != migration.Phase || got.BackfilledRows != 2 || got.LastKey != "b" {
+		t.Errorf("Get() = %+v, want the saved progress", got)
+	}
+}
@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+)
+
+func TestSchemaMigrationPhases(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	var (
+		migration *models.SchemaMigration
+		getErr    error
+	)
+	repo := &mock.SchemaMigrationRepositoryMock{
+		GetFunc: func(ctx context.Context, name string) (*models.SchemaMigration, error) {
+			return migration, getErr
+		},
+	}
+	phases := repository.NewSchemaMigrationPhases(repo, time.Minute)
+	repository.SetSchemaMigrationPhasesNow(phases, func() time.Time { return now })
+
+	// Not started yet
+	if phases.WritesNew(ctx, "m") || !phases.WritesOld(ctx, "m") {
+		t.Errorf("a migration not started should write the old schema only")
+	}
+
+	// The phase is cached until the TTL passes
+	migration = &models.SchemaMigration{Name: "m", Phase: models.SchemaMigrationDualWrite}
+	if phases.WritesNew(ctx, "m") {
+		t.Errorf("the cached phase should be used within the TTL")
+	}
+	now = now.Add(time.Minute)
+	if !phases.WritesNew(ctx, "m") || phases.ReadsNew(ctx, "m") {
+		t.Errorf("a dual-writing migration should write the new schema and read the old one")
+	}
+	if gets := len(repo.GetCalls()); gets != 2 {
+		t.Errorf("phase loaded %d times, want 2", gets)
+	}
+
+	// The last known phase is kept if reloading fails
+	getErr = errors.New("connection refused")
+	now = now.Add(time.Minute)
+	if got := phases.Phase(ctx, "m"); got != models.SchemaMigrationDualWrite {
+		t.Errorf("Phase() = %s after a failed reload, want %s", got, models.SchemaMigrationDualWrite)
+	}
+
+	// Invalidate reloads the phase right away
+	getErr = nil
+	migration.Phase = models.SchemaMigrationCompleted
+	phases.Invalidate("m")
+	if phases.WritesOld(ctx, "m") || !phases.ReadsNew(ctx, "m") {
+		t.Errorf("a completed migration should write and read the new schema only")
+	}
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// ShareLinkRepository defines operations of the links sharing the report over the web.
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *models.ShareLink) error
+	GetByID(ctx context.Context, id string) (*models.ShareLink, error)
+	GetAll(ctx context.Context) ([]*models.ShareLink, error)
+	Revoke(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+// shareLinkRepositoryImpl implements ShareLinkRepository.
+type shareLinkRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewShareLinkRepository creates a new share link repository.
+func NewShareLinkRepository(db boil.ContextExecutor) ShareLinkRepository {
+	return &shareLinkRepositoryImpl{db: db}
+}
+
+const shareLinkColumns = "id, label, expires_at, revoked_at, created_at"
+
+// Create records a new share link.
+func (r *shareLinkRepositoryImpl) Create(ctx context.Context, link *models.ShareLink) error {
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO share_links (id, label, expires_at, revoked_at, created_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		link.ID,
+		link.Label,
+		link.ExpiresAt,
+		link.RevokedAt,
+		link.CreatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves a share link by ID.
+// Returns nil if it does not exist.
+func (r *shareLinkRepositoryImpl) GetByID(ctx context.Context, id string) (*models.ShareLink, error) {
+	query := "SELECT " + shareLinkColumns + " FROM share_links WHERE id = ?"
+
+	link, err := scanShareLink(getExecutor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// GetAll retrieves all share links, newest first.
+func (r *shareLinkRepositoryImpl) GetAll(ctx context.Context) ([]*models.ShareLink, error) {
+	query := "SELECT " + shareLinkColumns + " FROM share_links ORDER BY created_at DESC, id DESC"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*models.ShareLink{}
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// Revoke marks a share link as revoked at the given time.
+// Returns false if the link does not exist or is already revoked.
+func (r *shareLinkRepositoryImpl) Revoke(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE share_links SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", at, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// scanShareLink scans a share link row.
+func scanShareLink(row rowScanner) (*models.ShareLink, error) {
+	link := &models.ShareLink{}
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&link.ID,
+		&link.Label,
+		&link.ExpiresAt,
+		&revokedAt,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		link.RevokedAt = &revokedAt.Time
+	}
+	return link, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/sirupsen/logrus"
+)
+
+// slowQueryThreshold is the execution time in nanoseconds above which queries are logged.
+// Zero disables the measurement.
+var slowQueryThreshold atomic.Int64
+
+// SetSlowQueryThreshold sets the execution time above which repository queries are logged as slow.
+// Zero or a negative duration disables the measurement.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	if threshold < 0 {
+		threshold = 0
+	}
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// timedExecutor measures the execution time of the queries run through it.
+// The time of Query and QueryRow covers the execution until the first row is available,
+// which is where MySQL spends the time of the queries the repositories run.
+type timedExecutor struct {
+	exec      boil.ContextExecutor
+	threshold time.Duration
+}
+
+// withQueryTiming wraps exec to log slow queries, or returns exec as is when the measurement is disabled.
+func withQueryTiming(exec boil.ContextExecutor) boil.ContextExecutor {
+	threshold := time.Duration(slowQueryThreshold.Load())
+	if threshold <= 0 || exec == nil {
+		return exec
+	}
+	return &timedExecutor{exec: exec, threshold: threshold}
+}
+
+func (e *timedExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer e.observe(time.Now(), query, args)
+	return e.exec.Exec(query, args...)
+}
+
+func (e *timedExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer e.observe(time.Now(), query, args)
+	return e.exec.Query(query, args...)
+}
+
+func (e *timedExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	defer e.observe(time.Now(), query, args)
+	return e.exec.QueryRow(query, args...)
+}
+
+func (e *timedExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer e.observe(time.Now(), query, args)
+	return e.exec.ExecContext(ctx, query, args...)
+}
+
+func (e *timedExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer e.observe(time.Now(), query, args)
+	return e.exec.QueryContext(ctx, query, args...)
+}
+
+func (e *timedExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	defer e.observe(time.Now(), query, args)
+	return e.exec.QueryRowContext(ctx, query, args...)
+}
+
+// observe logs the query if it took longer than the threshold.
+// Arguments are counted but not logged, since they may contain personal data such as notification messages.
+func (e *timedExecutor) observe(start time.Time, query string, args []interface{}) {
+	elapsed := time.Since(start)
+	if elapsed < e.threshold {
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"elapsed_ms": elapsed.Milliseconds(),
+		"args":       len(args),
+	}).Warnf("Slow query: %s", compactQuery(query))
+}
+
+// compactQuery collapses the whitespace of a query into single spaces so that it fits on one log line.
+func compactQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
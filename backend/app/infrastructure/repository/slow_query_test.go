@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestGetExecutor_SlowQueryLogging(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+	defer SetSlowQueryThreshold(0)
+
+	ctx := context.Background()
+	db := NewMockExecutor()
+
+	SetSlowQueryThreshold(0)
+	if getExecutor(ctx, db) != db {
+		t.Fatal("getExecutor() should return db as is when the measurement is disabled")
+	}
+
+	// Every query exceeds the threshold of 1ns
+	SetSlowQueryThreshold(time.Nanosecond)
+	query := `
+		UPDATE stock_prices
+		SET close_price = ?
+		WHERE code = ?`
+	if _, err := getExecutor(ctx, db).ExecContext(ctx, query, 100, "7203"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+
+	if diff := cmp.Diff([]string{query}, db.execQueries); diff != "" {
+		t.Errorf("executed queries mismatch (-want +got):\n%s", diff)
+	}
+	entry := hook.LastEntry()
+	if entry == nil {
+		t.Fatal("slow query was not logged")
+	}
+	if entry.Level != logrus.WarnLevel {
+		t.Errorf("log level = %v, want %v", entry.Level, logrus.WarnLevel)
+	}
+	if diff := cmp.Diff("Slow query: UPDATE stock_prices SET close_price = ? WHERE code = ?", entry.Message); diff != "" {
+		t.Errorf("log message mismatch (-want +got):\n%s", diff)
+	}
+	if entry.Data["args"] != 2 {
+		t.Errorf("logged args = %v, want 2", entry.Data["args"])
+	}
+}
@@ -7,8 +7,10 @@ import (
 
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/boost-jp/stock-automation/app/domain"
 	"github.com/boost-jp/stock-automation/app/domain/models"
 	"github.com/boost-jp/stock-automation/app/infrastructure/dao"
+	"github.com/boost-jp/stock-automation/app/utility"
 )
 
 // StockRepository defines stock price related operations.
@@ -16,20 +18,30 @@ type StockRepository interface {
 	// Stock price operations
 	SaveStockPrice(ctx context.Context, price *models.StockPrice) error
 	SaveStockPrices(ctx context.Context, prices []*models.StockPrice) error
+	UpdateStockPrice(ctx context.Context, price *models.StockPrice) error
 	GetLatestPrice(ctx context.Context, stockCode string) (*models.StockPrice, error)
+	GetLatestPrices(ctx context.Context, codes []string) (map[string]*models.StockPrice, error)
+	GetPreviousPrices(ctx context.Context, codes []string) (map[string]*models.StockPrice, error)
 	GetPriceHistory(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error)
+	GetPriceHistorySince(ctx context.Context, stockCode string, from time.Time) ([]*models.StockPrice, error)
+	GetPriceHistoryRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error)
+	GetPriceHistories(ctx context.Context, codes []string, from time.Time) (map[string][]*models.StockPrice, error)
 	CleanupOldData(ctx context.Context, days int) error
 
 	// Technical indicator operations
 	SaveTechnicalIndicator(ctx context.Context, indicator *models.TechnicalIndicator) error
 	GetLatestTechnicalIndicator(ctx context.Context, stockCode string) (*models.TechnicalIndicator, error)
+	GetLatestTechnicalIndicators(ctx context.Context, codes []string) (map[string]*models.TechnicalIndicator, error)
 
 	// Watch list operations
 	GetActiveWatchList(ctx context.Context) ([]*models.WatchList, error)
+	GetWatchList(ctx context.Context, includeDeleted bool) ([]*models.WatchList, error)
 	GetWatchListItem(ctx context.Context, id string) (*models.WatchList, error)
+	GetWatchListItemByCode(ctx context.Context, code string) (*models.WatchList, error)
 	AddToWatchList(ctx context.Context, item *models.WatchList) error
 	UpdateWatchList(ctx context.Context, item *models.WatchList) error
 	DeleteFromWatchList(ctx context.Context, id string) error
+	RestoreWatchListItem(ctx context.Context, id string) error
 }
 
 // stockRepositoryImpl implements StockRepository using SQLBoiler.
@@ -42,23 +54,30 @@ func NewStockRepository(db boil.ContextExecutor) StockRepository {
 	return &stockRepositoryImpl{db: db}
 }
 
-// SaveStockPrice saves a single stock price record.
+// SaveStockPrice saves a single stock price record and updates the latest price of the code.
 func (r *stockRepositoryImpl) SaveStockPrice(ctx context.Context, price *models.StockPrice) error {
 	// Convert domain model to DAO model
 	daoPrice := &dao.StockPrice{
-		Code:       price.Code,
-		Date:       price.Date,
-		OpenPrice:  price.OpenPrice,
-		HighPrice:  price.HighPrice,
-		LowPrice:   price.LowPrice,
-		ClosePrice: price.ClosePrice,
-		Volume:     price.Volume,
+		Code:          price.Code,
+		Date:          price.Date,
+		OpenPrice:     price.OpenPrice,
+		HighPrice:     price.HighPrice,
+		LowPrice:      price.LowPrice,
+		ClosePrice:    price.ClosePrice,
+		AdjClosePrice: price.AdjClosePrice,
+		Volume:        price.Volume,
+		Source:        price.Source,
+		FetchedAt:     price.FetchedAt,
+		IsFinal:       price.Final,
 	}
 
-	return daoPrice.Insert(ctx, r.db, boil.Infer())
+	if err := daoPrice.Insert(ctx, getExecutor(ctx, r.db), boil.Infer()); err != nil {
+		return err
+	}
+	return r.upsertLatestPrices(ctx, []*models.StockPrice{price})
 }
 
-// SaveStockPrices saves multiple stock price records in batches.
+// SaveStockPrices saves multiple stock price records in batches and updates the latest prices of their codes.
 func (r *stockRepositoryImpl) SaveStockPrices(ctx context.Context, prices []*models.StockPrice) error {
 	if len(prices) == 0 {
 		return nil
@@ -68,25 +87,63 @@ func (r *stockRepositoryImpl) SaveStockPrices(ctx context.Context, prices []*mod
 	daoPrices := make(dao.StockPriceSlice, len(prices))
 	for i, price := range prices {
 		daoPrices[i] = &dao.StockPrice{
-			Code:       price.Code,
-			Date:       price.Date,
-			OpenPrice:  price.OpenPrice,
-			HighPrice:  price.HighPrice,
-			LowPrice:   price.LowPrice,
-			ClosePrice: price.ClosePrice,
-			Volume:     price.Volume,
+			Code:          price.Code,
+			Date:          price.Date,
+			OpenPrice:     price.OpenPrice,
+			HighPrice:     price.HighPrice,
+			LowPrice:      price.LowPrice,
+			ClosePrice:    price.ClosePrice,
+			AdjClosePrice: price.AdjClosePrice,
+			Volume:        price.Volume,
+			Source:        price.Source,
+			FetchedAt:     price.FetchedAt,
+			IsFinal:       price.Final,
 		}
 	}
 
-	return daoPrices.InsertAll(ctx, r.db, boil.Infer())
+	if err := daoPrices.InsertAll(ctx, getExecutor(ctx, r.db), boil.Infer()); err != nil {
+		return err
+	}
+	return r.upsertLatestPrices(ctx, prices)
+}
+
+// UpdateStockPrice overwrites the prices, volume, source and final flag of the record of the same code
+// and trading day. A stored adjusted close is kept when the price has none. The latest price of the
+// code is updated too.
+func (r *stockRepositoryImpl) UpdateStockPrice(ctx context.Context, price *models.StockPrice) error {
+	query := `
+		UPDATE stock_prices
+		SET open_price = ?, high_price = ?, low_price = ?, close_price = ?, adj_close_price = COALESCE(?, adj_close_price), volume = ?,
+			source = ?, fetched_at = ?, is_final = ?
+		WHERE code = ? AND date = ?`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		price.OpenPrice,
+		price.HighPrice,
+		price.LowPrice,
+		price.ClosePrice,
+		price.AdjClosePrice,
+		price.Volume,
+		price.Source,
+		price.FetchedAt,
+		price.Final,
+		price.Code,
+		price.Date.Format("2006-01-02"),
+	)
+	if err != nil {
+		return err
+	}
+	return r.upsertLatestPrices(ctx, []*models.StockPrice{price})
 }
 
 // GetLatestPrice retrieves the latest stock price for a given stock code.
+// The query reads unique_code_date backwards and stops at the first row, without a filesort.
 func (r *stockRepositoryImpl) GetLatestPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	stockCode = domain.NormalizeCode(stockCode)
 	daoPrice, err := dao.StockPrices(
 		qm.Where("code = ?", stockCode),
 		qm.OrderBy("date desc"),
-	).One(ctx, r.db)
+	).One(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -96,24 +153,73 @@ func (r *stockRepositoryImpl) GetLatestPrice(ctx context.Context, stockCode stri
 
 	// Convert DAO model to domain model
 	return &models.StockPrice{
-		Code:       daoPrice.Code,
-		Date:       daoPrice.Date,
-		OpenPrice:  daoPrice.OpenPrice,
-		HighPrice:  daoPrice.HighPrice,
-		LowPrice:   daoPrice.LowPrice,
-		ClosePrice: daoPrice.ClosePrice,
-		Volume:     daoPrice.Volume,
+		Code:          daoPrice.Code,
+		Date:          daoPrice.Date,
+		OpenPrice:     daoPrice.OpenPrice,
+		HighPrice:     daoPrice.HighPrice,
+		LowPrice:      daoPrice.LowPrice,
+		ClosePrice:    daoPrice.ClosePrice,
+		AdjClosePrice: daoPrice.AdjClosePrice,
+		Volume:        daoPrice.Volume,
+		Source:        daoPrice.Source,
+		FetchedAt:     daoPrice.FetchedAt,
+		Final:         daoPrice.IsFinal,
 	}, nil
 }
 
 // GetPriceHistory retrieves stock price history for a given period.
+// The start date is compared as a DATE so that the query is a range scan of unique_code_date
+// and includes the prices of the start day.
 func (r *stockRepositoryImpl) GetPriceHistory(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
-	startTime := time.Now().AddDate(0, 0, -days)
+	return r.GetPriceHistorySince(ctx, stockCode, time.Now().AddDate(0, 0, -days))
+}
 
+// GetPriceHistorySince retrieves stock price history from the day of from, such as the start of a
+// period counted in business days.
+func (r *stockRepositoryImpl) GetPriceHistorySince(ctx context.Context, stockCode string, from time.Time) ([]*models.StockPrice, error) {
+	return r.getPriceHistory(ctx, qm.Where("code = ? AND date >= ?", domain.NormalizeCode(stockCode), from.Format("2006-01-02")))
+}
+
+// GetPriceHistoryRange retrieves stock price history from the day of from to the day of to, both
+// inclusive, for backfills and reads split into periods.
+func (r *stockRepositoryImpl) GetPriceHistoryRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	return r.getPriceHistory(ctx, qm.Where("code = ? AND date >= ? AND date <= ?",
+		domain.NormalizeCode(stockCode), from.Format("2006-01-02"), to.Format("2006-01-02")))
+}
+
+// GetPriceHistories retrieves the price history of the codes from the day of from in one query,
+// keyed by code and oldest first. Codes without prices are not included in the result.
+func (r *stockRepositoryImpl) GetPriceHistories(ctx context.Context, codes []string, from time.Time) (map[string][]*models.StockPrice, error) {
+	codes = uniqueCodes(codes)
+	histories := make(map[string][]*models.StockPrice, len(codes))
+	if len(codes) == 0 {
+		return histories, nil
+	}
+
+	args := make([]interface{}, len(codes))
+	for i, code := range codes {
+		args[i] = domain.NormalizeCode(code)
+	}
+	prices, err := r.getPriceHistory(ctx, qm.Expr(
+		qm.WhereIn("code IN ?", args...),
+		qm.Where("date >= ?", from.Format("2006-01-02")),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, price := range prices {
+		histories[price.Code] = append(histories[price.Code], price)
+	}
+	return histories, nil
+}
+
+// getPriceHistory retrieves the stock prices matching the condition, oldest first.
+func (r *stockRepositoryImpl) getPriceHistory(ctx context.Context, where qm.QueryMod) ([]*models.StockPrice, error) {
 	daoPrices, err := dao.StockPrices(
-		qm.Where("code = ? AND date >= ?", stockCode, startTime),
+		where,
 		qm.OrderBy("date asc"),
-	).All(ctx, r.db)
+	).All(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		return nil, err
 	}
@@ -122,13 +228,17 @@ func (r *stockRepositoryImpl) GetPriceHistory(ctx context.Context, stockCode str
 	prices := make([]*models.StockPrice, len(daoPrices))
 	for i, daoPrice := range daoPrices {
 		prices[i] = &models.StockPrice{
-			Code:       daoPrice.Code,
-			Date:       daoPrice.Date,
-			OpenPrice:  daoPrice.OpenPrice,
-			HighPrice:  daoPrice.HighPrice,
-			LowPrice:   daoPrice.LowPrice,
-			ClosePrice: daoPrice.ClosePrice,
-			Volume:     daoPrice.Volume,
+			Code:          daoPrice.Code,
+			Date:          daoPrice.Date,
+			OpenPrice:     daoPrice.OpenPrice,
+			HighPrice:     daoPrice.HighPrice,
+			LowPrice:      daoPrice.LowPrice,
+			ClosePrice:    daoPrice.ClosePrice,
+			AdjClosePrice: daoPrice.AdjClosePrice,
+			Volume:        daoPrice.Volume,
+			Source:        daoPrice.Source,
+			FetchedAt:     daoPrice.FetchedAt,
+			Final:         daoPrice.IsFinal,
 		}
 	}
 
@@ -137,39 +247,54 @@ func (r *stockRepositoryImpl) GetPriceHistory(ctx context.Context, stockCode str
 
 // CleanupOldData removes old stock price data to manage database size.
 func (r *stockRepositoryImpl) CleanupOldData(ctx context.Context, days int) error {
-	cutoffTime := time.Now().AddDate(0, 0, -days)
+	cutoffDate := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
 
 	_, err := dao.StockPrices(
-		qm.Where("date < ?", cutoffTime),
-	).DeleteAll(ctx, r.db)
+		qm.Where("date < ?", cutoffDate),
+	).DeleteAll(ctx, getExecutor(ctx, r.db))
 
 	return err
 }
 
 // SaveTechnicalIndicator saves a technical indicator record.
+// The indicator of the same stock and date is replaced, so recalculation can save it again.
 func (r *stockRepositoryImpl) SaveTechnicalIndicator(ctx context.Context, indicator *models.TechnicalIndicator) error {
-	// Convert domain model to DAO model
-	daoIndicator := &dao.TechnicalIndicator{
-		Code:          indicator.Code,
-		Date:          indicator.Date,
-		Sma5:          indicator.Sma5,
-		Sma25:         indicator.Sma25,
-		Sma75:         indicator.Sma75,
-		Rsi14:         indicator.Rsi14,
-		Macd:          indicator.Macd,
-		MacdSignal:    indicator.MacdSignal,
-		MacdHistogram: indicator.MacdHistogram,
-	}
-
-	return daoIndicator.Insert(ctx, r.db, boil.Infer())
+	query := `
+		INSERT INTO technical_indicators (id, code, date, sma_5, sma_25, sma_75, ma25_deviation, rsi_14, macd, macd_signal, macd_histogram)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			sma_5 = VALUES(sma_5),
+			sma_25 = VALUES(sma_25),
+			sma_75 = VALUES(sma_75),
+			ma25_deviation = VALUES(ma25_deviation),
+			rsi_14 = VALUES(rsi_14),
+			macd = VALUES(macd),
+			macd_signal = VALUES(macd_signal),
+			macd_histogram = VALUES(macd_histogram)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		utility.NewULID(),
+		indicator.Code,
+		indicator.Date,
+		indicator.Sma5,
+		indicator.Sma25,
+		indicator.Sma75,
+		indicator.Ma25Deviation,
+		indicator.Rsi14,
+		indicator.Macd,
+		indicator.MacdSignal,
+		indicator.MacdHistogram,
+	)
+	return err
 }
 
 // GetLatestTechnicalIndicator retrieves the latest technical indicator for a given stock code.
 func (r *stockRepositoryImpl) GetLatestTechnicalIndicator(ctx context.Context, stockCode string) (*models.TechnicalIndicator, error) {
+	stockCode = domain.NormalizeCode(stockCode)
 	daoIndicator, err := dao.TechnicalIndicators(
 		qm.Where("code = ?", stockCode),
 		qm.OrderBy("date desc"),
-	).One(ctx, r.db)
+	).One(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -177,50 +302,87 @@ func (r *stockRepositoryImpl) GetLatestTechnicalIndicator(ctx context.Context, s
 		return nil, err
 	}
 
-	// Convert DAO model to domain model
+	return convertTechnicalIndicatorToModel(daoIndicator), nil
+}
+
+// GetLatestTechnicalIndicators retrieves the latest technical indicator of each code in one query,
+// keyed by code. Codes without indicators are absent from the map.
+func (r *stockRepositoryImpl) GetLatestTechnicalIndicators(ctx context.Context, codes []string) (map[string]*models.TechnicalIndicator, error) {
+	codes = uniqueCodes(codes)
+	indicators := make(map[string]*models.TechnicalIndicator, len(codes))
+	if len(codes) == 0 {
+		return indicators, nil
+	}
+
+	args := make([]interface{}, len(codes))
+	for i, code := range codes {
+		args[i] = domain.NormalizeCode(code)
+	}
+	daoIndicators, err := dao.TechnicalIndicators(
+		qm.Where(`(code, date) IN (
+			SELECT code, MAX(date) FROM technical_indicators WHERE code IN (`+placeholders(len(args))+`) GROUP BY code
+		)`, args...),
+	).All(ctx, getExecutor(ctx, r.db))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, daoIndicator := range daoIndicators {
+		indicators[daoIndicator.Code] = convertTechnicalIndicatorToModel(daoIndicator)
+	}
+	return indicators, nil
+}
+
+// convertTechnicalIndicatorToModel converts a DAO technical indicator to the domain model.
+func convertTechnicalIndicatorToModel(daoIndicator *dao.TechnicalIndicator) *models.TechnicalIndicator {
 	return &models.TechnicalIndicator{
 		Code:          daoIndicator.Code,
 		Date:          daoIndicator.Date,
 		Sma5:          daoIndicator.Sma5,
 		Sma25:         daoIndicator.Sma25,
 		Sma75:         daoIndicator.Sma75,
+		Ma25Deviation: daoIndicator.Ma25Deviation,
 		Rsi14:         daoIndicator.Rsi14,
 		Macd:          daoIndicator.Macd,
 		MacdSignal:    daoIndicator.MacdSignal,
 		MacdHistogram: daoIndicator.MacdHistogram,
-	}, nil
+	}
 }
 
 // GetActiveWatchList retrieves all active watch list items.
 func (r *stockRepositoryImpl) GetActiveWatchList(ctx context.Context) ([]*models.WatchList, error) {
 	daoWatchList, err := dao.WatchLists(
 		qm.Where("is_active = ?", true),
-	).All(ctx, r.db)
+		notDeleted,
+	).All(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert DAO models to domain models
-	watchList := make([]*models.WatchList, len(daoWatchList))
-	for i, daoItem := range daoWatchList {
-		watchList[i] = &models.WatchList{
-			ID:              daoItem.ID,
-			Code:            daoItem.Code,
-			Name:            daoItem.Name,
-			TargetBuyPrice:  daoItem.TargetBuyPrice,
-			TargetSellPrice: daoItem.TargetSellPrice,
-			IsActive:        daoItem.IsActive,
-			CreatedAt:       daoItem.CreatedAt,
-			UpdatedAt:       daoItem.UpdatedAt,
-		}
+	return convertWatchListToModels(daoWatchList), nil
+}
+
+// GetWatchList retrieves all watch list items including inactive ones ordered by code,
+// and the soft-deleted ones as well if includeDeleted is set.
+func (r *stockRepositoryImpl) GetWatchList(ctx context.Context, includeDeleted bool) ([]*models.WatchList, error) {
+	mods := []qm.QueryMod{qm.OrderBy("code")}
+	if !includeDeleted {
+		mods = append(mods, notDeleted)
+	}
+	daoWatchList, err := dao.WatchLists(mods...).All(ctx, getExecutor(ctx, r.db))
+	if err != nil {
+		return nil, err
 	}
 
-	return watchList, nil
+	return convertWatchListToModels(daoWatchList), nil
 }
 
 // GetWatchListItem retrieves a watch list item by ID.
 func (r *stockRepositoryImpl) GetWatchListItem(ctx context.Context, id string) (*models.WatchList, error) {
-	daoItem, err := dao.FindWatchList(ctx, r.db, id)
+	daoItem, err := dao.WatchLists(
+		qm.Where("id = ?", id),
+		notDeleted,
+	).One(ctx, getExecutor(ctx, r.db))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -228,52 +390,106 @@ func (r *stockRepositoryImpl) GetWatchListItem(ctx context.Context, id string) (
 		return nil, err
 	}
 
-	return &models.WatchList{
-		ID:              daoItem.ID,
-		Code:            daoItem.Code,
-		Name:            daoItem.Name,
-		TargetBuyPrice:  daoItem.TargetBuyPrice,
-		TargetSellPrice: daoItem.TargetSellPrice,
-		IsActive:        daoItem.IsActive,
-		CreatedAt:       daoItem.CreatedAt,
-		UpdatedAt:       daoItem.UpdatedAt,
-	}, nil
+	return convertWatchListToModel(daoItem), nil
+}
+
+// GetWatchListItemByCode retrieves a watch list item by stock code.
+func (r *stockRepositoryImpl) GetWatchListItemByCode(ctx context.Context, code string) (*models.WatchList, error) {
+	code = domain.NormalizeCode(code)
+	daoItem, err := dao.WatchLists(
+		qm.Where("code = ?", code),
+		notDeleted,
+	).One(ctx, getExecutor(ctx, r.db))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return convertWatchListToModel(daoItem), nil
 }
 
 // AddToWatchList adds a new item to the watch list.
+// A soft-deleted item of the same code is removed for good, as the new item replaces it.
 func (r *stockRepositoryImpl) AddToWatchList(ctx context.Context, item *models.WatchList) error {
+	item.Code = domain.NormalizeCode(item.Code)
+	if _, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM watch_lists WHERE code = ? AND deleted_at IS NOT NULL", item.Code); err != nil {
+		return err
+	}
+
 	daoItem := &dao.WatchList{
-		ID:              item.ID,
-		Code:            item.Code,
-		Name:            item.Name,
-		TargetBuyPrice:  item.TargetBuyPrice,
-		TargetSellPrice: item.TargetSellPrice,
-		IsActive:        item.IsActive,
+		ID:                     item.ID,
+		Code:                   item.Code,
+		Name:                   item.Name,
+		TargetBuyPrice:         item.TargetBuyPrice,
+		TargetSellPrice:        item.TargetSellPrice,
+		IsActive:               item.IsActive,
+		CollectIntervalMinutes: item.CollectIntervalMinutes,
+		MutedUntil:             item.MutedUntil,
 	}
 
-	return daoItem.Insert(ctx, r.db, boil.Infer())
+	return daoItem.Insert(ctx, getExecutor(ctx, r.db), boil.Infer())
 }
 
 // UpdateWatchList updates an existing watch list item.
 func (r *stockRepositoryImpl) UpdateWatchList(ctx context.Context, item *models.WatchList) error {
+	item.Code = domain.NormalizeCode(item.Code)
 	daoItem := &dao.WatchList{
-		ID:              item.ID,
-		Code:            item.Code,
-		Name:            item.Name,
-		TargetBuyPrice:  item.TargetBuyPrice,
-		TargetSellPrice: item.TargetSellPrice,
-		IsActive:        item.IsActive,
-		CreatedAt:       item.CreatedAt,
-		UpdatedAt:       item.UpdatedAt,
+		ID:                     item.ID,
+		Code:                   item.Code,
+		Name:                   item.Name,
+		TargetBuyPrice:         item.TargetBuyPrice,
+		TargetSellPrice:        item.TargetSellPrice,
+		IsActive:               item.IsActive,
+		CollectIntervalMinutes: item.CollectIntervalMinutes,
+		MutedUntil:             item.MutedUntil,
+		CreatedAt:              item.CreatedAt,
+		UpdatedAt:              item.UpdatedAt,
+		DeletedAt:              item.DeletedAt,
 	}
 
-	_, err := daoItem.Update(ctx, r.db, boil.Infer())
+	_, err := daoItem.Update(ctx, getExecutor(ctx, r.db), boil.Infer())
 	return err
 }
 
-// DeleteFromWatchList removes an item from the watch list.
+// DeleteFromWatchList soft-deletes an item from the watch list.
 func (r *stockRepositoryImpl) DeleteFromWatchList(ctx context.Context, id string) error {
-	daoItem := &dao.WatchList{ID: id}
-	_, err := daoItem.Delete(ctx, r.db)
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE watch_lists SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", id)
+	return err
+}
+
+// RestoreWatchListItem restores a soft-deleted watch list item by ID.
+func (r *stockRepositoryImpl) RestoreWatchListItem(ctx context.Context, id string) error {
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx,
+		"UPDATE watch_lists SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
 	return err
 }
+
+// convertWatchListToModel converts a DAO watch list item to the domain model.
+func convertWatchListToModel(daoItem *dao.WatchList) *models.WatchList {
+	return &models.WatchList{
+		ID:                     daoItem.ID,
+		Code:                   daoItem.Code,
+		Name:                   daoItem.Name,
+		TargetBuyPrice:         daoItem.TargetBuyPrice,
+		TargetSellPrice:        daoItem.TargetSellPrice,
+		IsActive:               daoItem.IsActive,
+		CollectIntervalMinutes: daoItem.CollectIntervalMinutes,
+		MutedUntil:             daoItem.MutedUntil,
+		CreatedAt:              daoItem.CreatedAt,
+		UpdatedAt:              daoItem.UpdatedAt,
+		DeletedAt:              daoItem.DeletedAt,
+	}
+}
+
+// convertWatchListToModels converts DAO watch list items to domain models.
+func convertWatchListToModels(daoWatchList dao.WatchListSlice) []*models.WatchList {
+	watchList := make([]*models.WatchList, len(daoWatchList))
+	for i, daoItem := range daoWatchList {
+		watchList[i] = convertWatchListToModel(daoItem)
+	}
+	return watchList
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// StockFundamentalRepository defines financial indicator related operations.
+type StockFundamentalRepository interface {
+	Upsert(ctx context.Context, fundamental *models.StockFundamental) error
+	GetByCode(ctx context.Context, code string) (*models.StockFundamental, error)
+	Delete(ctx context.Context, code string) error
+}
+
+// stockFundamentalRepositoryImpl implements StockFundamentalRepository.
+type stockFundamentalRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewStockFundamentalRepository creates a new stock fundamental repository.
+func NewStockFundamentalRepository(db boil.ContextExecutor) StockFundamentalRepository {
+	return &stockFundamentalRepositoryImpl{db: db}
+}
+
+// Upsert creates or replaces the financial indicators of a stock.
+func (r *stockFundamentalRepositoryImpl) Upsert(ctx context.Context, fundamental *models.StockFundamental) error {
+	fundamental.Code = domain.NormalizeCode(fundamental.Code)
+	query := `
+		INSERT INTO stock_fundamentals (code, per, pbr, roe, dividend_yield)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			per = VALUES(per),
+			pbr = VALUES(pbr),
+			roe = VALUES(roe),
+			dividend_yield = VALUES(dividend_yield)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		fundamental.Code,
+		fundamental.Per,
+		fundamental.Pbr,
+		fundamental.Roe,
+		fundamental.DividendYield,
+	)
+	return err
+}
+
+// GetByCode retrieves the financial indicators of a stock.
+// Returns nil if no data is registered.
+func (r *stockFundamentalRepositoryImpl) GetByCode(ctx context.Context, code string) (*models.StockFundamental, error) {
+	code = domain.NormalizeCode(code)
+	query := `
+		SELECT code, per, pbr, roe, dividend_yield, created_at, updated_at
+		FROM stock_fundamentals
+		WHERE code = ?`
+
+	fundamental := &models.StockFundamental{}
+	err := getExecutor(ctx, r.db).QueryRowContext(ctx, query, code).Scan(
+		&fundamental.Code,
+		&fundamental.Per,
+		&fundamental.Pbr,
+		&fundamental.Roe,
+		&fundamental.DividendYield,
+		&fundamental.CreatedAt,
+		&fundamental.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return fundamental, nil
+}
+
+// Delete removes the financial indicators of a stock.
+func (r *stockFundamentalRepositoryImpl) Delete(ctx context.Context, code string) error {
+	code = domain.NormalizeCode(code)
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM stock_fundamentals WHERE code = ?", code)
+	return err
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+)
+
+// StockNicknameRepository defines stock nickname related operations.
+type StockNicknameRepository interface {
+	Upsert(ctx context.Context, nickname *models.StockNickname) error
+	Delete(ctx context.Context, code string) (bool, error)
+	GetByCode(ctx context.Context, code string) (*models.StockNickname, error)
+	GetAll(ctx context.Context) ([]*models.StockNickname, error)
+}
+
+// stockNicknameRepositoryImpl implements StockNicknameRepository.
+type stockNicknameRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewStockNicknameRepository creates a new stock nickname repository.
+func NewStockNicknameRepository(db boil.ContextExecutor) StockNicknameRepository {
+	return &stockNicknameRepositoryImpl{db: db}
+}
+
+// Upsert sets the nickname of a stock, replacing the one already set.
+func (r *stockNicknameRepositoryImpl) Upsert(ctx context.Context, nickname *models.StockNickname) error {
+	query := `
+		INSERT INTO stock_nicknames (code, nickname)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE nickname = VALUES(nickname)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query, nickname.Code, nickname.Nickname)
+	return err
+}
+
+// Delete removes the nickname of a stock. Returns false if the stock had none.
+func (r *stockNicknameRepositoryImpl) Delete(ctx context.Context, code string) (bool, error) {
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM stock_nicknames WHERE code = ?", code)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetByCode retrieves the nickname of a stock.
+// Returns nil if the stock has none.
+func (r *stockNicknameRepositoryImpl) GetByCode(ctx context.Context, code string) (*models.StockNickname, error) {
+	query := "SELECT code, nickname, created_at, updated_at FROM stock_nicknames WHERE code = ?"
+	nickname := &models.StockNickname{}
+	err := getExecutor(ctx, r.db).QueryRowContext(ctx, query, code).
+		Scan(&nickname.Code, &nickname.Nickname, &nickname.CreatedAt, &nickname.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nickname, nil
+}
+
+// GetAll retrieves the nicknames of all stocks, ordered by code.
+func (r *stockNicknameRepositoryImpl) GetAll(ctx context.Context) ([]*models.StockNickname, error) {
+	query := "SELECT code, nickname, created_at, updated_at FROM stock_nicknames ORDER BY code"
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nicknames := []*models.StockNickname{}
+	for rows.Next() {
+		nickname := &models.StockNickname{}
+		if err := rows.Scan(&nickname.Code, &nickname.Nickname, &nickname.CreatedAt, &nickname.UpdatedAt); err != nil {
+			return nil, err
+		}
+		nicknames = append(nicknames, nickname)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return nicknames, nil
+}
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aarondl/sqlboiler/v4/types"
 	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
 )
 
 // MockExecutor implements boil.ContextExecutor for testing.
@@ -15,6 +18,8 @@ type MockExecutor struct {
 	stockPrices         []*models.StockPrice
 	technicalIndicators []*models.TechnicalIndicator
 	watchLists          []*models.WatchList
+	execQueries         []string
+	execArgs            [][]interface{}
 }
 
 func NewMockExecutor() *MockExecutor {
@@ -148,6 +153,7 @@ func TestStockRepository_SaveTechnicalIndicator(t *testing.T) {
 		Sma5:          createTestNullDecimal(1050.0),
 		Sma25:         createTestNullDecimal(1000.0),
 		Sma75:         createTestNullDecimal(950.0),
+		Ma25Deviation: createTestNullDecimal(5.5),
 		Rsi14:         createTestNullDecimal(60.0),
 		Macd:          createTestNullDecimal(5.0),
 		MacdSignal:    createTestNullDecimal(3.0),
@@ -158,14 +164,102 @@ func TestStockRepository_SaveTechnicalIndicator(t *testing.T) {
 		mockDB := NewMockExecutor()
 		repo := NewStockRepository(mockDB)
 
-		err := repo.SaveTechnicalIndicator(ctx, indicator)
+		if err := repo.SaveTechnicalIndicator(ctx, indicator); err != nil {
+			t.Fatalf("SaveTechnicalIndicator() error = %v", err)
+		}
+
+		if len(mockDB.execQueries) != 1 {
+			t.Fatalf("executed %d queries, want 1", len(mockDB.execQueries))
+		}
+		if !strings.Contains(mockDB.execQueries[0], "ON DUPLICATE KEY UPDATE") {
+			t.Errorf("query should replace the indicator of the same date:\n%s", mockDB.execQueries[0])
+		}
 
-		// With mock executor, we expect this to fail
-		// In a real test, you would verify the indicator was saved
-		_ = err // Acknowledge that we're not testing the error for now
+		args := mockDB.execArgs[0]
+		got := []interface{}{args[1], args[2]}
+		for _, arg := range args[3:] {
+			got = append(got, arg.(types.NullDecimal).String())
+		}
+		want := []interface{}{"1234", indicator.Date, "1050", "1000", "950", "5.5", "60", "5", "3", "2"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("query args mismatch (-want +got):\n%s", diff)
+		}
 	})
 }
 
+func TestStockRepository_UpdateStockPrice(t *testing.T) {
+	ctx := context.Background()
+	mockDB := NewMockExecutor()
+	repo := NewStockRepository(mockDB)
+
+	price := &models.StockPrice{
+		Code:       "1234",
+		Date:       time.Date(2024, 6, 5, 14, 30, 0, 0, time.Local),
+		OpenPrice:  utility.FloatToDecimal(1000),
+		HighPrice:  utility.FloatToDecimal(1050),
+		LowPrice:   utility.FloatToDecimal(990),
+		ClosePrice: utility.FloatToDecimal(1020.5),
+		Volume:     123400,
+		Final:      true,
+	}
+	if err := repo.UpdateStockPrice(ctx, price); err != nil {
+		t.Fatalf("UpdateStockPrice() error = %v", err)
+	}
+
+	if len(mockDB.execQueries) != 2 {
+		t.Fatalf("executed %d queries, want 2", len(mockDB.execQueries))
+	}
+	if !strings.Contains(mockDB.execQueries[0], "WHERE code = ? AND date = ?") {
+		t.Errorf("query should update the record of the same trading day:\n%s", mockDB.execQueries[0])
+	}
+	if !strings.Contains(mockDB.execQueries[1], "INSERT INTO latest_prices") {
+		t.Errorf("query should update the latest price:\n%s", mockDB.execQueries[1])
+	}
+
+	args := mockDB.execArgs[0]
+	got := []interface{}{args[0].(types.Decimal).String(), args[3].(types.Decimal).String(), args[5], args[8], args[9], args[10]}
+	// The time of day is dropped to match the DATE column
+	want := []interface{}{"1000", "1020.5", int64(123400), true, "1234", "2024-06-05"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("query args mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]interface{}{"1234", "2024-06-05"}, mockDB.execArgs[1][:2]); diff != "" {
+		t.Errorf("latest price args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStockRepository_UpsertLatestPrices(t *testing.T) {
+	ctx := context.Background()
+	mockDB := NewMockExecutor()
+	repo := &stockRepositoryImpl{db: mockDB}
+
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.Local) }
+	prices := []*models.StockPrice{
+		{Code: "1234", Date: day(3), ClosePrice: utility.FloatToDecimal(1000)},
+		{Code: "5678", Date: day(5), ClosePrice: utility.FloatToDecimal(2000)},
+		{Code: "1234", Date: day(5), ClosePrice: utility.FloatToDecimal(1010)},
+		{Code: "1234", Date: day(4), ClosePrice: utility.FloatToDecimal(1005)},
+	}
+	if err := repo.upsertLatestPrices(ctx, prices); err != nil {
+		t.Fatalf("upsertLatestPrices() error = %v", err)
+	}
+
+	if len(mockDB.execQueries) != 1 {
+		t.Fatalf("executed %d queries, want 1", len(mockDB.execQueries))
+	}
+
+	// Only the newest price of each code is written, in the order the codes first appear
+	args := mockDB.execArgs[0]
+	if len(args) != 16 {
+		t.Fatalf("got %d args, want 16", len(args))
+	}
+	got := []interface{}{args[0], args[1], args[5].(types.Decimal).String(), args[8], args[9], args[13].(types.Decimal).String()}
+	want := []interface{}{"1234", "2024-06-05", "1010", "5678", "2024-06-05", "2000"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("query args mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestStockRepository_GetActiveWatchList(t *testing.T) {
 	ctx := context.Background()
 
@@ -188,9 +282,7 @@ func TestStockRepository_GetActiveWatchList(t *testing.T) {
 
 // Helper functions for testing
 func createTestNullDecimal(value float64) types.NullDecimal {
-	// For testing purposes, return empty null decimal
-	// In production, this would properly convert the value
-	return types.NullDecimal{}
+	return utility.FloatToNullDecimal(value)
 }
 
 // MockResult implements sql.Result for testing
@@ -205,6 +297,8 @@ func (m *MockExecutor) Exec(query string, args ...interface{}) (sql.Result, erro
 }
 
 func (m *MockExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	m.execQueries = append(m.execQueries, query)
+	m.execArgs = append(m.execArgs, args)
 	return MockResult{}, nil
 }
 
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// StrategyProfileRepository defines strategy profile related operations.
+type StrategyProfileRepository interface {
+	Create(ctx context.Context, profile *models.StrategyProfile) error
+	GetByID(ctx context.Context, id string) (*models.StrategyProfile, error)
+	GetByName(ctx context.Context, name string) (*models.StrategyProfile, error)
+	GetAll(ctx context.Context) ([]*models.StrategyProfile, error)
+	Update(ctx context.Context, profile *models.StrategyProfile) error
+	Delete(ctx context.Context, id string) error
+
+	// Stock assignment operations
+	AssignToStock(ctx context.Context, stockCode, profileID string) error
+	UnassignFromStock(ctx context.Context, stockCode string) error
+	GetByStockCode(ctx context.Context, stockCode string) (*models.StrategyProfile, error)
+	GetAssignments(ctx context.Context) (map[string]*models.StrategyProfile, error)
+}
+
+// strategyProfileRepositoryImpl implements StrategyProfileRepository.
+type strategyProfileRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewStrategyProfileRepository creates a new strategy profile repository.
+func NewStrategyProfileRepository(db boil.ContextExecutor) StrategyProfileRepository {
+	return &strategyProfileRepositoryImpl{db: db}
+}
+
+const strategyProfileColumns = "id, name, description, parameters, created_at, updated_at"
+
+// Create creates a new strategy profile.
+func (r *strategyProfileRepositoryImpl) Create(ctx context.Context, profile *models.StrategyProfile) error {
+	if profile.ID == "" {
+		profile.ID = utility.NewULID()
+	}
+
+	query := `
+		INSERT INTO strategy_profiles (id, name, description, parameters)
+		VALUES (?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		profile.ID,
+		profile.Name,
+		profile.Description,
+		profile.Parameters,
+	)
+	return err
+}
+
+// GetByID retrieves a strategy profile by its ID.
+func (r *strategyProfileRepositoryImpl) GetByID(ctx context.Context, id string) (*models.StrategyProfile, error) {
+	query := "SELECT " + strategyProfileColumns + " FROM strategy_profiles WHERE id = ?"
+	return r.queryOne(ctx, query, id)
+}
+
+// GetByName retrieves a strategy profile by its name.
+func (r *strategyProfileRepositoryImpl) GetByName(ctx context.Context, name string) (*models.StrategyProfile, error) {
+	query := "SELECT " + strategyProfileColumns + " FROM strategy_profiles WHERE name = ?"
+	return r.queryOne(ctx, query, name)
+}
+
+// GetAll retrieves all strategy profiles ordered by name.
+func (r *strategyProfileRepositoryImpl) GetAll(ctx context.Context) ([]*models.StrategyProfile, error) {
+	query := "SELECT " + strategyProfileColumns + " FROM strategy_profiles ORDER BY name"
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []*models.StrategyProfile{}
+	for rows.Next() {
+		profile, err := scanStrategyProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// Update updates an existing strategy profile.
+func (r *strategyProfileRepositoryImpl) Update(ctx context.Context, profile *models.StrategyProfile) error {
+	query := `
+		UPDATE strategy_profiles
+		SET name = ?, description = ?, parameters = ?
+		WHERE id = ?`
+
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		profile.Name,
+		profile.Description,
+		profile.Parameters,
+		profile.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("strategy profile not found: %s", profile.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a strategy profile and its stock assignments.
+func (r *strategyProfileRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if _, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM stock_strategy_profiles WHERE strategy_profile_id = ?", id); err != nil {
+		return err
+	}
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM strategy_profiles WHERE id = ?", id)
+	return err
+}
+
+// AssignToStock assigns a strategy profile to a stock, replacing any existing assignment.
+func (r *strategyProfileRepositoryImpl) AssignToStock(ctx context.Context, stockCode, profileID string) error {
+	stockCode = domain.NormalizeCode(stockCode)
+	query := `
+		INSERT INTO stock_strategy_profiles (code, strategy_profile_id)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE strategy_profile_id = VALUES(strategy_profile_id)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query, stockCode, profileID)
+	return err
+}
+
+// UnassignFromStock removes the strategy profile assignment of a stock.
+func (r *strategyProfileRepositoryImpl) UnassignFromStock(ctx context.Context, stockCode string) error {
+	stockCode = domain.NormalizeCode(stockCode)
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM stock_strategy_profiles WHERE code = ?", stockCode)
+	return err
+}
+
+// GetByStockCode retrieves the strategy profile assigned to a stock.
+// Returns nil if no profile is assigned.
+func (r *strategyProfileRepositoryImpl) GetByStockCode(ctx context.Context, stockCode string) (*models.StrategyProfile, error) {
+	stockCode = domain.NormalizeCode(stockCode)
+	query := `
+		SELECT sp.id, sp.name, sp.description, sp.parameters, sp.created_at, sp.updated_at
+		FROM strategy_profiles sp
+		INNER JOIN stock_strategy_profiles ssp ON ssp.strategy_profile_id = sp.id
+		WHERE ssp.code = ?`
+
+	return r.queryOne(ctx, query, stockCode)
+}
+
+// GetAssignments retrieves the strategy profiles assigned to stocks in one query, keyed by stock code.
+// Stocks without a profile are not included in the result.
+func (r *strategyProfileRepositoryImpl) GetAssignments(ctx context.Context) (map[string]*models.StrategyProfile, error) {
+	query := `
+		SELECT ssp.code, sp.id, sp.name, sp.description, sp.parameters, sp.created_at, sp.updated_at
+		FROM strategy_profiles sp
+		INNER JOIN stock_strategy_profiles ssp ON ssp.strategy_profile_id = sp.id`
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := make(map[string]*models.StrategyProfile)
+	for rows.Next() {
+		var code string
+		profile := &models.StrategyProfile{}
+		err := rows.Scan(
+			&code,
+			&profile.ID,
+			&profile.Name,
+			&profile.Description,
+			&profile.Parameters,
+			&profile.CreatedAt,
+			&profile.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		assignments[code] = profile
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// queryOne runs a query expected to return at most one strategy profile.
+func (r *strategyProfileRepositoryImpl) queryOne(ctx context.Context, query string, args ...interface{}) (*models.StrategyProfile, error) {
+	profile, err := scanStrategyProfile(getExecutor(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanStrategyProfile scans a strategy profile row.
+func scanStrategyProfile(row rowScanner) (*models.StrategyProfile, error) {
+	profile := &models.StrategyProfile{}
+	err := row.Scan(
+		&profile.ID,
+		&profile.Name,
+		&profile.Description,
+		&profile.Parameters,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
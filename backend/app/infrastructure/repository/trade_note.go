@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// TradeNoteRepository defines operations of the notes on the trade ideas of the stocks.
+type TradeNoteRepository interface {
+	Create(ctx context.Context, note *models.TradeNote) error
+	GetByID(ctx context.Context, id string) (*models.TradeNote, error)
+	GetByCode(ctx context.Context, code string, limit int) ([]*models.TradeNote, error)
+	GetRecent(ctx context.Context, limit int) ([]*models.TradeNote, error)
+	GetLatestByCodes(ctx context.Context, codes []string) (map[string]*models.TradeNote, error)
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// tradeNoteRepositoryImpl implements TradeNoteRepository.
+type tradeNoteRepositoryImpl struct {
+	db boil.ContextExecutor
+}
+
+// NewTradeNoteRepository creates a new trade note repository.
+func NewTradeNoteRepository(db boil.ContextExecutor) TradeNoteRepository {
+	return &tradeNoteRepositoryImpl{db: db}
+}
+
+const tradeNoteColumns = "id, code, kind, body, source, created_at, updated_at"
+
+// Create records a new note.
+func (r *tradeNoteRepositoryImpl) Create(ctx context.Context, note *models.TradeNote) error {
+	if note.ID == "" {
+		note.ID = utility.NewULID()
+	}
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = time.Now()
+	}
+	note.UpdatedAt = note.CreatedAt
+
+	query := `
+		INSERT INTO trade_notes (id, code, kind, body, source, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := getExecutor(ctx, r.db).ExecContext(ctx, query,
+		note.ID,
+		note.Code,
+		note.Kind,
+		note.Body,
+		note.Source,
+		note.CreatedAt,
+		note.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves a note by ID.
+// Returns nil if it does not exist.
+func (r *tradeNoteRepositoryImpl) GetByID(ctx context.Context, id string) (*models.TradeNote, error) {
+	query := "SELECT " + tradeNoteColumns + " FROM trade_notes WHERE id = ?"
+
+	note, err := scanTradeNote(getExecutor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// GetByCode retrieves the notes of a stock, newest first.
+func (r *tradeNoteRepositoryImpl) GetByCode(ctx context.Context, code string, limit int) ([]*models.TradeNote, error) {
+	query := "SELECT " + tradeNoteColumns + " FROM trade_notes WHERE code = ? ORDER BY created_at DESC, id DESC LIMIT ?"
+	return r.queryTradeNotes(ctx, query, code, limit)
+}
+
+// GetRecent retrieves the notes of all stocks, newest first.
+func (r *tradeNoteRepositoryImpl) GetRecent(ctx context.Context, limit int) ([]*models.TradeNote, error) {
+	query := "SELECT " + tradeNoteColumns + " FROM trade_notes ORDER BY created_at DESC, id DESC LIMIT ?"
+	return r.queryTradeNotes(ctx, query, limit)
+}
+
+// GetLatestByCodes retrieves the newest note of each code in one query, keyed by code.
+// Codes without notes are absent from the map.
+func (r *tradeNoteRepositoryImpl) GetLatestByCodes(ctx context.Context, codes []string) (map[string]*models.TradeNote, error) {
+	codes = uniqueCodes(codes)
+	latest := make(map[string]*models.TradeNote, len(codes))
+	if len(codes) == 0 {
+		return latest, nil
+	}
+
+	args := make([]interface{}, len(codes))
+	for i, code := range codes {
+		args[i] = code
+	}
+	// Notes are ordered oldest first so that the newest of each code is kept last
+	query := "SELECT " + tradeNoteColumns + " FROM trade_notes WHERE code IN (" + placeholders(len(codes)) + ") ORDER BY created_at, id"
+	notes, err := r.queryTradeNotes(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, note := range notes {
+		latest[note.Code] = note
+	}
+	return latest, nil
+}
+
+// Delete removes a note.
+// Returns false if it does not exist.
+func (r *tradeNoteRepositoryImpl) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := getExecutor(ctx, r.db).ExecContext(ctx, "DELETE FROM trade_notes WHERE id = ?", id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// queryTradeNotes runs a query selecting the note columns.
+func (r *tradeNoteRepositoryImpl) queryTradeNotes(ctx context.Context, query string, args ...interface{}) ([]*models.TradeNote, error) {
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []*models.TradeNote{}
+	for rows.Next() {
+		note, err := scanTradeNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// scanTradeNote scans a note row.
+func scanTradeNote(row rowScanner) (*models.TradeNote, error) {
+	note := &models.TradeNote{}
+	err := row.Scan(
+		&note.ID,
+		&note.Code,
+		&note.Kind,
+		&note.Body,
+		&note.Source,
+		&note.CreatedAt,
+		&note.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
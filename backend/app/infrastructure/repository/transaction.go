@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/sirupsen/logrus"
 )
 
 // Repositories groups all repository interfaces.
@@ -22,6 +24,11 @@ type Transaction struct {
 
 // TransactionManager manages database transactions.
 type TransactionManager interface {
+	// WithTx executes fn within a database transaction carried by the context.
+	// Repository calls made with the context passed to fn join the transaction.
+	// The transaction is committed if fn returns nil and rolled back otherwise.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// WithTransaction executes a function within a database transaction
 	WithTransaction(ctx context.Context, fn func(*Repositories) error) error
 
@@ -46,32 +53,49 @@ func NewTransactionManager(db *sql.DB) TransactionManager {
 	}
 }
 
-// WithTransaction executes a function within a database transaction.
-func (tm *transactionManagerImpl) WithTransaction(ctx context.Context, fn func(*Repositories) error) error {
-	tx, err := tm.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
+// txContextKey is the context key of the current transaction.
+type txContextKey struct{}
+
+// WithTx executes fn within a database transaction carried by the context.
+// A nested call joins the outer transaction, which is committed or rolled back by the outermost call.
+func (tm *transactionManagerImpl) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
 	}
 
-	// Create repositories with transaction
-	repos := &Repositories{
-		Stock:     NewStockRepository(tx),
-		Portfolio: NewPortfolioRepository(tx),
+	tx, err := tm.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	// Execute the function
-	if err := fn(repos); err != nil {
-		// Rollback on error
-		if rbErr := tx.Rollback(); rbErr != nil {
-			// Log rollback error, but return original error
-			// In production, you would use a proper logger here
-			return err
+	defer func() {
+		if p := recover(); p != nil {
+			rollback(tx)
+			panic(p)
 		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		rollback(tx)
 		return err
 	}
 
-	// Commit the transaction
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// WithTransaction executes a function within a database transaction.
+// Prefer WithTx, which also covers the repositories outside Repositories.
+func (tm *transactionManagerImpl) WithTransaction(ctx context.Context, fn func(*Repositories) error) error {
+	return tm.WithTx(ctx, func(ctx context.Context) error {
+		tx := getExecutor(ctx, tm.db)
+		return fn(&Repositories{
+			Stock:     NewStockRepository(tx),
+			Portfolio: NewPortfolioRepository(tx),
+		})
+	})
 }
 
 // GetRepositories returns repositories without transaction.
@@ -79,6 +103,22 @@ func (tm *transactionManagerImpl) GetRepositories() *Repositories {
 	return tm.repo
 }
 
+// rollback rolls back a transaction, logging the error since the original error is returned.
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		logrus.Errorf("Failed to rollback transaction: %v", err)
+	}
+}
+
+// getExecutor returns the transaction carried by the context, or db if there is none.
+// Queries run through it are logged when they exceed the slow query threshold.
+func getExecutor(ctx context.Context, db boil.ContextExecutor) boil.ContextExecutor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return withQueryTiming(tx)
+	}
+	return withQueryTiming(db)
+}
+
 // ExecutorWrapper wraps boil.ContextExecutor to ensure proper type.
 type ExecutorWrapper struct {
 	boil.ContextExecutor
@@ -95,3 +95,38 @@ func TestRepositories_Structure(t *testing.T) {
 		_ = repos.Portfolio
 	})
 }
+
+func TestTransactionManager_WithTxNested(t *testing.T) {
+	tm := NewTransactionManager(&sql.DB{})
+	tx := &sql.Tx{}
+	ctx := context.WithValue(context.Background(), txContextKey{}, tx)
+
+	// A nested call must join the outer transaction without beginning a new one
+	called := false
+	err := tm.WithTx(ctx, func(ctx context.Context) error {
+		called = true
+		if getExecutor(ctx, nil) != tx {
+			t.Error("Nested WithTx should use the outer transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+	if !called {
+		t.Error("WithTx() did not call fn")
+	}
+}
+
+func TestGetExecutor(t *testing.T) {
+	db := &sql.DB{}
+	if getExecutor(context.Background(), db) != db {
+		t.Error("getExecutor() without transaction should return db")
+	}
+
+	tx := &sql.Tx{}
+	ctx := context.WithValue(context.Background(), txContextKey{}, tx)
+	if getExecutor(ctx, db) != tx {
+		t.Error("getExecutor() should return the transaction in the context")
+	}
+}
@@ -0,0 +1,520 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	cerrors "github.com/boost-jp/stock-automation/app/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// writeBufferFlushTimeout bounds a flush run by the interval timer or on close.
+	writeBufferFlushTimeout = 30 * time.Second
+	// maxWriteAttempts is the number of flushes a write is retried in before it is given up.
+	maxWriteAttempts = 3
+)
+
+// Operations of the buffered writes.
+const (
+	bufferedInsert = "insert"
+	bufferedUpdate = "update"
+)
+
+// WriteBufferOptions configures the price write buffer.
+type WriteBufferOptions struct {
+	// MaxSize is the number of pending writes that triggers a flush.
+	MaxSize int
+	// FlushInterval is the longest a write stays pending. Zero flushes only by count and on reads.
+	FlushInterval time.Duration
+	// JournalPath is the file the pending writes are appended to until they are flushed, so that
+	// the writes of a crashed process are replayed on the next start. Empty disables the journal.
+	JournalPath string
+	// DeadLetterPath is the file the writes that failed maxWriteAttempts flushes are appended to,
+	// in the format of the journal, to be written by hand. Empty drops them with an error log.
+	DeadLetterPath string
+}
+
+// bufferedWrite is a pending price insert or update, and a line of the journal.
+type bufferedWrite struct {
+	Op       string             `json:"op"`
+	Price    *models.StockPrice `json:"price"`
+	attempts int
+}
+
+// BufferedStockRepository buffers the single price inserts and updates of a stock repository and
+// writes them in batches, one multi-row INSERT for the inserts, when MaxSize writes are pending,
+// every FlushInterval, before prices are read and on Close.
+//
+// GetLatestPrice returns the pending price of a code so that collection sees its own writes, and
+// the other price reads flush first. Writes in a transaction are not buffered. Failed writes stay
+// in the buffer and are retried by the next flush up to maxWriteAttempts times, then moved to the
+// dead letter file.
+type BufferedStockRepository struct {
+	StockRepository
+	opts WriteBufferOptions
+
+	mu      sync.Mutex
+	pending []*bufferedWrite
+	// latest is the newest pending price of each code
+	latest  map[string]*models.StockPrice
+	journal *os.File
+
+	// flushMu serializes the flushes so that the writes keep their order
+	flushMu sync.Mutex
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBufferedStockRepository wraps a stock repository with a write buffer. The writes left in the
+// journal by a process that did not flush them are loaded into the buffer to be written by the
+// first flush, and the interval flush is started.
+func NewBufferedStockRepository(repo StockRepository, opts WriteBufferOptions) (*BufferedStockRepository, error) {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 1
+	}
+	b := &BufferedStockRepository{
+		StockRepository: repo,
+		opts:            opts,
+		latest:          make(map[string]*models.StockPrice),
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+
+	if opts.JournalPath != "" {
+		recovered, err := readJournal(opts.JournalPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, write := range recovered {
+			b.add(write)
+		}
+		if len(recovered) > 0 {
+			logrus.Warnf("Recovered %d unflushed price writes from %s", len(recovered), opts.JournalPath)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(opts.JournalPath), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create write buffer journal directory: %w", err)
+		}
+		b.journal, err = os.OpenFile(opts.JournalPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open write buffer journal: %w", err)
+		}
+	}
+
+	go b.run()
+	return b, nil
+}
+
+// readJournal reads the writes of a journal, which does not exist after a clean shutdown.
+// A line cut off by a crash is the last one and is skipped.
+func readJournal(path string) ([]*bufferedWrite, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open write buffer journal: %w", err)
+	}
+	defer file.Close()
+
+	var writes []*bufferedWrite
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var write bufferedWrite
+		if err := json.Unmarshal(scanner.Bytes(), &write); err != nil || write.Price == nil {
+			logrus.Warnf("Skipping broken write buffer journal line: %s", scanner.Text())
+			continue
+		}
+		writes = append(writes, &write)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read write buffer journal: %w", err)
+	}
+	return writes, nil
+}
+
+// run flushes the buffer every FlushInterval until Close.
+func (b *BufferedStockRepository) run() {
+	defer close(b.done)
+	if b.opts.FlushInterval <= 0 {
+		<-b.stop
+		return
+	}
+
+	ticker := time.NewTicker(b.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), writeBufferFlushTimeout)
+			if err := b.Flush(ctx); err != nil {
+				logrus.Errorf("Failed to flush price writes: %v", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// SaveStockPrice buffers the insert of a price.
+func (b *BufferedStockRepository) SaveStockPrice(ctx context.Context, price *models.StockPrice) error {
+	if inTransaction(ctx) {
+		return b.StockRepository.SaveStockPrice(ctx, price)
+	}
+	return b.enqueue(ctx, &bufferedWrite{Op: bufferedInsert, Price: price})
+}
+
+// UpdateStockPrice buffers the update of the price of a trading day.
+func (b *BufferedStockRepository) UpdateStockPrice(ctx context.Context, price *models.StockPrice) error {
+	if inTransaction(ctx) {
+		return b.StockRepository.UpdateStockPrice(ctx, price)
+	}
+	return b.enqueue(ctx, &bufferedWrite{Op: bufferedUpdate, Price: price})
+}
+
+// enqueue journals and buffers a write, and flushes once MaxSize writes are pending.
+// The journal is synced before the write is accepted, so that it survives a crash of the host.
+func (b *BufferedStockRepository) enqueue(ctx context.Context, write *bufferedWrite) error {
+	b.mu.Lock()
+	if b.journal != nil {
+		line, err := json.Marshal(write)
+		if err == nil {
+			_, err = b.journal.Write(append(line, '\n'))
+		}
+		if err == nil {
+			err = b.journal.Sync()
+		}
+		if err != nil {
+			b.mu.Unlock()
+			return fmt.Errorf("failed to journal price write: %w", err)
+		}
+	}
+	b.add(write)
+	full := len(b.pending) >= b.opts.MaxSize
+	b.mu.Unlock()
+
+	if full {
+		return b.Flush(ctx)
+	}
+	return nil
+}
+
+// add buffers a write, replacing the pending write of the same code and trading day so that a
+// pending insert is inserted with the newest price.
+func (b *BufferedStockRepository) add(write *bufferedWrite) {
+	b.latest[write.Price.Code] = write.Price
+	for _, pending := range b.pending {
+		if pending.Price.Code == write.Price.Code && pending.Price.SameTradingDay(write.Price) {
+			pending.Price = write.Price
+			return
+		}
+	}
+	b.pending = append(b.pending, write)
+}
+
+// Flush writes the pending writes, the inserts in one batch. The writes that failed are kept in the
+// buffer to be retried and the error is returned.
+func (b *BufferedStockRepository) Flush(ctx context.Context) error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var inserts, updates []*bufferedWrite
+	for _, write := range batch {
+		if write.Op == bufferedInsert {
+			inserts = append(inserts, write)
+		} else {
+			updates = append(updates, write)
+		}
+	}
+
+	failed, err := b.writeInserts(ctx, inserts)
+	for _, write := range updates {
+		if updateErr := b.StockRepository.UpdateStockPrice(ctx, write.Price); updateErr != nil {
+			failed = append(failed, write)
+			err = errors.Join(err, fmt.Errorf("failed to update price of %s: %w", write.Price.Code, updateErr))
+		}
+	}
+
+	b.settle(batch, failed)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Flushed %d price writes", len(batch))
+	return nil
+}
+
+// writeInserts inserts the prices in one batch, falling back to one by one to isolate the failures
+// when the batch fails. A price already stored, such as one replayed from the journal after it was
+// written, is updated instead. Returns the writes that failed.
+func (b *BufferedStockRepository) writeInserts(ctx context.Context, inserts []*bufferedWrite) ([]*bufferedWrite, error) {
+	if len(inserts) == 0 {
+		return nil, nil
+	}
+
+	prices := make([]*models.StockPrice, len(inserts))
+	for i, write := range inserts {
+		prices[i] = write.Price
+	}
+	if err := b.StockRepository.SaveStockPrices(ctx, prices); err == nil {
+		return nil, nil
+	}
+
+	var failed []*bufferedWrite
+	var errs error
+	for _, write := range inserts {
+		err := b.StockRepository.SaveStockPrice(ctx, write.Price)
+		if cerrors.IsAlreadyExists(err) {
+			err = b.StockRepository.UpdateStockPrice(ctx, write.Price)
+		}
+		if err != nil {
+			failed = append(failed, write)
+			errs = errors.Join(errs, fmt.Errorf("failed to insert price of %s: %w", write.Price.Code, err))
+		}
+	}
+	return failed, errs
+}
+
+// settle puts the failed writes of a flushed batch back in front of the writes buffered during the
+// flush, moves the ones out of attempts to the dead letter file, drops the written ones from the
+// latest prices and rewrites the journal.
+func (b *BufferedStockRepository) settle(batch, failed []*bufferedWrite) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	retry := make([]*bufferedWrite, 0, len(failed)+len(b.pending))
+	var exhausted []*bufferedWrite
+	for _, write := range failed {
+		write.attempts++
+		if write.attempts >= maxWriteAttempts {
+			exhausted = append(exhausted, write)
+			continue
+		}
+		retry = append(retry, write)
+	}
+	if len(exhausted) > 0 {
+		b.deadLetter(exhausted)
+	}
+	buffered := b.pending
+	b.pending = retry
+	for _, write := range buffered {
+		b.add(write)
+	}
+
+	for _, write := range batch {
+		if b.latest[write.Price.Code] == write.Price && !b.isPending(write.Price) {
+			delete(b.latest, write.Price.Code)
+		}
+	}
+
+	if err := b.rewriteJournal(); err != nil {
+		logrus.Errorf("Failed to rewrite write buffer journal: %v", err)
+	}
+}
+
+// deadLetter appends the writes that ran out of attempts to the dead letter file. The writes are
+// dropped with an error log when there is no dead letter file or it cannot be written.
+func (b *BufferedStockRepository) deadLetter(writes []*bufferedWrite) {
+	err := errors.New("no dead letter file")
+	if b.opts.DeadLetterPath != "" {
+		err = appendJournal(b.opts.DeadLetterPath, writes)
+	}
+	for _, write := range writes {
+		if err != nil {
+			logrus.Errorf("Dropping price write of %s on %s after %d attempts: %v",
+				write.Price.Code, write.Price.Date.Format("2006-01-02"), write.attempts, err)
+			continue
+		}
+		logrus.Errorf("Moved price write of %s on %s to %s after %d attempts",
+			write.Price.Code, write.Price.Date.Format("2006-01-02"), b.opts.DeadLetterPath, write.attempts)
+	}
+}
+
+// appendJournal appends the writes to a file in the format of the journal and syncs it.
+func appendJournal(path string, writes []*bufferedWrite) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	for _, write := range writes {
+		line, err := json.Marshal(write)
+		if err == nil {
+			_, err = file.Write(append(line, '\n'))
+		}
+		if err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// isPending reports whether the price is still buffered.
+func (b *BufferedStockRepository) isPending(price *models.StockPrice) bool {
+	for _, write := range b.pending {
+		if write.Price == price {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteJournal replaces the journal with the pending writes.
+func (b *BufferedStockRepository) rewriteJournal() error {
+	if b.journal == nil {
+		return nil
+	}
+	if err := b.journal.Truncate(0); err != nil {
+		return err
+	}
+	for _, write := range b.pending {
+		line, err := json.Marshal(write)
+		if err != nil {
+			return err
+		}
+		if _, err := b.journal.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return b.journal.Sync()
+}
+
+// Pending returns the number of writes waiting to be flushed.
+func (b *BufferedStockRepository) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Close stops the interval flush and flushes the pending writes. The journal is removed when all
+// writes have been written, and kept for the next start otherwise.
+func (b *BufferedStockRepository) Close(ctx context.Context) error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.stop)
+		<-b.done
+
+		err = b.Flush(ctx)
+		if b.journal == nil {
+			return
+		}
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if closeErr := b.journal.Close(); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+		b.journal = nil
+		if len(b.pending) == 0 {
+			if removeErr := os.Remove(b.opts.JournalPath); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+				err = errors.Join(err, removeErr)
+			}
+		}
+	})
+	return err
+}
+
+// flushBeforeRead writes the pending prices so that a read sees them.
+// The read goes on if the flush fails, without the prices still pending.
+func (b *BufferedStockRepository) flushBeforeRead(ctx context.Context) {
+	if b.Pending() == 0 {
+		return
+	}
+	if err := b.Flush(ctx); err != nil {
+		logrus.Warnf("Failed to flush price writes before read: %v", err)
+	}
+}
+
+// GetLatestPrice returns the newest pending price of the code, or reads the latest stored one.
+// A pending price has the date of the latest trading day collected, so it is never older than the stored one.
+func (b *BufferedStockRepository) GetLatestPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	b.mu.Lock()
+	price, ok := b.latest[stockCode]
+	b.mu.Unlock()
+	if ok {
+		return price, nil
+	}
+	return b.StockRepository.GetLatestPrice(ctx, stockCode)
+}
+
+// SaveStockPrices writes the pending writes before the prices so that the writes keep their order.
+func (b *BufferedStockRepository) SaveStockPrices(ctx context.Context, prices []*models.StockPrice) error {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.SaveStockPrices(ctx, prices)
+}
+
+// GetLatestPrices flushes the pending writes and reads the latest prices of the codes.
+func (b *BufferedStockRepository) GetLatestPrices(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.GetLatestPrices(ctx, codes)
+}
+
+// GetPreviousPrices flushes the pending writes and reads the previous prices of the codes.
+func (b *BufferedStockRepository) GetPreviousPrices(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.GetPreviousPrices(ctx, codes)
+}
+
+// GetPriceHistory flushes the pending writes and reads the price history.
+func (b *BufferedStockRepository) GetPriceHistory(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.GetPriceHistory(ctx, stockCode, days)
+}
+
+// GetPriceHistorySince flushes the pending writes and reads the price history.
+func (b *BufferedStockRepository) GetPriceHistorySince(ctx context.Context, stockCode string, from time.Time) ([]*models.StockPrice, error) {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.GetPriceHistorySince(ctx, stockCode, from)
+}
+
+// GetPriceHistories flushes the pending writes and reads the price histories of the codes.
+func (b *BufferedStockRepository) GetPriceHistories(ctx context.Context, codes []string, from time.Time) (map[string][]*models.StockPrice, error) {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.GetPriceHistories(ctx, codes, from)
+}
+
+// GetPriceHistoryRange flushes the pending writes and reads the price history of the period.
+func (b *BufferedStockRepository) GetPriceHistoryRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.GetPriceHistoryRange(ctx, stockCode, from, to)
+}
+
+// CleanupOldData flushes the pending writes and removes the old prices.
+func (b *BufferedStockRepository) CleanupOldData(ctx context.Context, days int) error {
+	b.flushBeforeRead(ctx)
+	return b.StockRepository.CleanupOldData(ctx, days)
+}
+
+// inTransaction reports whether the context carries a transaction of TransactionManager.WithTx.
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txContextKey{}).(*sql.Tx)
+	return ok
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	cerrors "github.com/boost-jp/stock-automation/app/errors"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeStockWriter records the price writes by kind, failing the codes in fail.
+type fakeStockWriter struct {
+	StockRepository
+	batches  [][]string
+	inserted []string
+	updated  []string
+	fail     map[string]error
+	failAll  bool
+}
+
+func (f *fakeStockWriter) SaveStockPrices(ctx context.Context, prices []*models.StockPrice) error {
+	if f.failAll {
+		return errors.New("batch failed")
+	}
+	codes := make([]string, len(prices))
+	for i, price := range prices {
+		codes[i] = price.Code + "=" + price.ClosePrice.String()
+	}
+	f.batches = append(f.batches, codes)
+	return nil
+}
+
+func (f *fakeStockWriter) SaveStockPrice(ctx context.Context, price *models.StockPrice) error {
+	if err := f.fail[price.Code]; err != nil {
+		return err
+	}
+	f.inserted = append(f.inserted, price.Code)
+	return nil
+}
+
+func (f *fakeStockWriter) UpdateStockPrice(ctx context.Context, price *models.StockPrice) error {
+	f.updated = append(f.updated, price.Code+"="+price.ClosePrice.String())
+	return nil
+}
+
+func (f *fakeStockWriter) GetLatestPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	return nil, nil
+}
+
+func (f *fakeStockWriter) GetPriceHistory(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+	return nil, nil
+}
+
+func bufferedPrice(code string, close float64) *models.StockPrice {
+	return &models.StockPrice{
+		Code:       code,
+		Date:       time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+		OpenPrice:  utility.FloatToDecimal(close),
+		HighPrice:  utility.FloatToDecimal(close),
+		LowPrice:   utility.FloatToDecimal(close),
+		ClosePrice: utility.FloatToDecimal(close),
+	}
+}
+
+func TestBufferedStockRepository_FlushByCount(t *testing.T) {
+	ctx := context.Background()
+	writer := &fakeStockWriter{}
+	buffer, err := NewBufferedStockRepository(writer, WriteBufferOptions{MaxSize: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buffer.Close(ctx)
+
+	for _, price := range []*models.StockPrice{bufferedPrice("7203", 2500), bufferedPrice("6758", 13000)} {
+		if err := buffer.SaveStockPrice(ctx, price); err != nil {
+			t.Fatalf("SaveStockPrice() error = %v", err)
+		}
+	}
+	// A later quote of the same day replaces the pending insert
+	if err := buffer.UpdateStockPrice(ctx, bufferedPrice("7203", 2510)); err != nil {
+		t.Fatalf("UpdateStockPrice() error = %v", err)
+	}
+	if len(writer.batches) != 0 {
+		t.Fatalf("written before the buffer is full: %v", writer.batches)
+	}
+
+	latest, err := buffer.GetLatestPrice(ctx, "7203")
+	if err != nil || latest == nil || latest.ClosePrice.String() != "2510" {
+		t.Errorf("GetLatestPrice() = %v, %v, want the pending price 2510", latest, err)
+	}
+
+	if err := buffer.SaveStockPrice(ctx, bufferedPrice("9984", 9000)); err != nil {
+		t.Fatalf("SaveStockPrice() error = %v", err)
+	}
+	want := [][]string{{"7203=2510", "6758=13000", "9984=9000"}}
+	if diff := cmp.Diff(want, writer.batches); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+	if buffer.Pending() != 0 {
+		t.Errorf("Pending() = %d after flush, want 0", buffer.Pending())
+	}
+	if latest, _ := buffer.GetLatestPrice(ctx, "7203"); latest != nil {
+		t.Errorf("GetLatestPrice() = %v after flush, want the stored price", latest)
+	}
+}
+
+func TestBufferedStockRepository_FlushBeforeRead(t *testing.T) {
+	ctx := context.Background()
+	writer := &fakeStockWriter{}
+	buffer, err := NewBufferedStockRepository(writer, WriteBufferOptions{MaxSize: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buffer.Close(ctx)
+
+	if err := buffer.SaveStockPrice(ctx, bufferedPrice("7203", 2500)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := buffer.GetPriceHistory(ctx, "7203", 30); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([][]string{{"7203=2500"}}, writer.batches); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBufferedStockRepository_FailedBatch(t *testing.T) {
+	ctx := context.Background()
+	writer := &fakeStockWriter{
+		failAll: true,
+		fail: map[string]error{
+			"7203": cerrors.ErrAlreadyExists,
+			"6758": errors.New("connection lost"),
+		},
+	}
+	deadLetter := filepath.Join(t.TempDir(), "dead", "prices.jsonl")
+	buffer, err := NewBufferedStockRepository(writer, WriteBufferOptions{MaxSize: 100, DeadLetterPath: deadLetter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buffer.Close(ctx)
+
+	for _, code := range []string{"7203", "6758", "9984"} {
+		if err := buffer.SaveStockPrice(ctx, bufferedPrice(code, 1000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := buffer.Flush(ctx); err == nil {
+		t.Error("Flush() should return the error of the failed write")
+	}
+
+	if diff := cmp.Diff([]string{"9984"}, writer.inserted); diff != "" {
+		t.Errorf("inserted mismatch (-want +got):\n%s", diff)
+	}
+	// The price already stored is updated instead
+	if diff := cmp.Diff([]string{"7203=1000"}, writer.updated); diff != "" {
+		t.Errorf("updated mismatch (-want +got):\n%s", diff)
+	}
+	if buffer.Pending() != 1 {
+		t.Errorf("Pending() = %d, want the failed write kept for retry", buffer.Pending())
+	}
+
+	// The write is moved to the dead letter file after maxWriteAttempts flushes
+	for range maxWriteAttempts - 1 {
+		_ = buffer.Flush(ctx)
+	}
+	if buffer.Pending() != 0 {
+		t.Errorf("Pending() = %d, want the write given up after %d attempts", buffer.Pending(), maxWriteAttempts)
+	}
+	dead, err := readJournal(deadLetter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) != 1 || dead[0].Op != bufferedInsert || dead[0].Price.Code != "6758" {
+		t.Errorf("dead letter writes = %+v, want the insert of 6758", dead)
+	}
+}
+
+func TestBufferedStockRepository_JournalRecovery(t *testing.T) {
+	ctx := context.Background()
+	journal := filepath.Join(t.TempDir(), "buffer", "prices.jsonl")
+
+	crashed, err := NewBufferedStockRepository(&fakeStockWriter{}, WriteBufferOptions{MaxSize: 100, JournalPath: journal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, code := range []string{"7203", "6758"} {
+		if err := crashed.SaveStockPrice(ctx, bufferedPrice(code, 1000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The process ends without Close
+
+	writer := &fakeStockWriter{}
+	buffer, err := NewBufferedStockRepository(writer, WriteBufferOptions{MaxSize: 100, JournalPath: journal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffer.Pending() != 2 {
+		t.Fatalf("Pending() = %d after recovery, want 2", buffer.Pending())
+	}
+
+	if err := buffer.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if diff := cmp.Diff([][]string{{"7203=1000", "6758=1000"}}, writer.batches); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(journal); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("journal should be removed after all writes are flushed: %v", err)
+	}
+}
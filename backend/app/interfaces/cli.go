@@ -1,12 +1,35 @@
 package interfaces
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/broker"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/interfaces/graph"
+	"github.com/boost-jp/stock-automation/app/usecase"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
 	"github.com/sirupsen/logrus"
 )
 
@@ -32,20 +55,175 @@ func (c *CLI) Run(args []string) error {
 	switch command {
 	case "scheduler", "run":
 		return c.runScheduler()
+	case "all":
+		return c.runAll()
+	case "status":
+		return c.runStatus(args[2:])
+	case "job":
+		if len(args) < 3 {
+			return fmt.Errorf("job command requires subcommand: list, run")
+		}
+		return c.runJobCommand(args[2:])
 	case "collect":
-		return c.runDataCollection()
+		return c.runDataCollection(args[2:])
+	case "bulk-collect":
+		return c.runBulkCollect(args[2:])
 	case "report":
-		return c.runDailyReport()
+		return c.runReport(args[2:])
+	case "quality":
+		return c.runDataQualityReport()
+	case "seed":
+		return c.runSeed(args[2:])
+	case "recalc-indicators":
+		return c.runRecalcIndicators(args[2:])
+	case "aggregate":
+		return c.runAggregatePrices(args[2:])
+	case "reconcile":
+		return c.runReconcilePrices(args[2:])
+	case "inspect":
+		return c.runInspect(args[2:])
+	case "chart":
+		return c.runChart(args[2:])
+	case "tui":
+		return c.runTUI(args[2:])
+	case "interactive":
+		return c.runInteractive()
 	case "portfolio":
 		if len(args) < 3 {
-			return fmt.Errorf("portfolio command requires subcommand: add, list, remove")
+			return fmt.Errorf("portfolio command requires subcommand: add, buy, sell, lots, list, remove, restore, history, snapshot")
 		}
 		return c.runPortfolioCommand(args[2:])
 	case "watchlist":
 		if len(args) < 3 {
-			return fmt.Errorf("watchlist command requires subcommand: add, list, remove")
+			return fmt.Errorf("watchlist command requires subcommand: add, list, remove, restore, import, interval, mute, unmute")
 		}
 		return c.runWatchlistCommand(args[2:])
+	case "score":
+		return c.runScoreRanking()
+	case "discover":
+		return c.runDiscovery(args[2:])
+	case "fund-prices":
+		return c.runFundPrices(args[2:])
+	case "crypto-prices":
+		return c.runCryptoPrices(args[2:])
+	case "index-prices":
+		return c.runIndexPrices(args[2:])
+	case "briefing":
+		return c.runMorningBriefing(args[2:])
+	case "exit-target":
+		if len(args) < 3 {
+			return fmt.Errorf("exit-target command requires subcommand: set, list, remove, check")
+		}
+		return c.runExitTargetCommand(args[2:])
+	case "alert-rule":
+		if len(args) < 3 {
+			return fmt.Errorf("alert-rule command requires subcommand: add, update, list, show, enable, disable, remove, history, eval")
+		}
+		return c.runAlertRuleCommand(args[2:])
+	case "fundamental":
+		if len(args) < 3 {
+			return fmt.Errorf("fundamental command requires subcommand: set, fetch")
+		}
+		return c.runFundamentalCommand(args[2:])
+	case "strategy":
+		if len(args) < 3 {
+			return fmt.Errorf("strategy command requires subcommand: add, list, assign, unassign, compare")
+		}
+		return c.runStrategyCommand(args[2:])
+	case "audit":
+		return c.runAuditLog(args[2:])
+	case "paper":
+		if len(args) < 3 {
+			return fmt.Errorf("paper command requires subcommand: trade, report")
+		}
+		return c.runPaperTradeCommand(args[2:])
+	case "broker":
+		if len(args) < 3 {
+			return fmt.Errorf("broker command requires subcommand: balance, order, show, executions")
+		}
+		return c.runBrokerCommand(args[2:])
+	case "maintenance":
+		if len(args) < 3 {
+			return fmt.Errorf("maintenance command requires subcommand: on, off, status")
+		}
+		return c.runMaintenanceCommand(args[2:])
+	case "migration":
+		if len(args) < 3 {
+			return fmt.Errorf("migration command requires subcommand: list, advance, backfill, rollback")
+		}
+		return c.runSchemaMigrationCommand(args[2:])
+	case "flags":
+		if len(args) < 3 {
+			return fmt.Errorf("flags command requires subcommand: list, enable, disable, reset")
+		}
+		return c.runFeatureFlagCommand(args[2:])
+	case "hedge":
+		if len(args) < 3 {
+			return fmt.Errorf("hedge command requires subcommand: check, history")
+		}
+		return c.runHedgeCommand(args[2:])
+	case "calendar":
+		if len(args) < 3 {
+			return fmt.Errorf("calendar command requires subcommand: sync, auth")
+		}
+		return c.runCalendarCommand(args[2:])
+	case "goal":
+		if len(args) < 3 {
+			return fmt.Errorf("goal command requires subcommand: add, list, update, remove, check")
+		}
+		return c.runGoalCommand(args[2:])
+	case "cash":
+		if len(args) < 3 {
+			return fmt.Errorf("cash command requires subcommand: deposit, withdraw, status, history")
+		}
+		return c.runCashCommand(args[2:])
+	case "nickname":
+		if len(args) < 3 {
+			return fmt.Errorf("nickname command requires subcommand: set, remove, list")
+		}
+		return c.runNicknameCommand(args[2:])
+	case "quarantine":
+		if len(args) < 3 {
+			return fmt.Errorf("quarantine command requires subcommand: list, release")
+		}
+		return c.runQuarantineCommand(args[2:])
+	case "plan":
+		if len(args) < 3 {
+			return fmt.Errorf("plan command requires subcommand: simulate, compare")
+		}
+		return c.runPlanCommand(args[2:])
+	case "note":
+		if len(args) < 3 {
+			return fmt.Errorf("note command requires subcommand: add, list, show, remove")
+		}
+		return c.runNoteCommand(args[2:])
+	case "report-prefs":
+		if len(args) < 3 {
+			return fmt.Errorf("report-prefs command requires subcommand: list, enable, disable, reset")
+		}
+		return c.runReportPrefsCommand(args[2:])
+	case "share":
+		if len(args) < 3 {
+			return fmt.Errorf("share command requires subcommand: create, list, revoke")
+		}
+		return c.runShareCommand(args[2:])
+	case "debug":
+		if len(args) < 3 {
+			return fmt.Errorf("debug command requires subcommand: raw, replay, prune")
+		}
+		return c.runDebugCommand(args[2:])
+	case "retention":
+		if len(args) < 3 {
+			return fmt.Errorf("retention command requires subcommand: list, apply")
+		}
+		return c.runRetentionCommand(args[2:])
+	case "completion":
+		if len(args) < 3 {
+			return fmt.Errorf("completion command requires subcommand: bash, zsh, codes")
+		}
+		return c.runCompletion(args[2])
+	case "test-yahoo":
+		return c.runYahooDiagnostics(args[2:])
 	case "help":
 		c.printHelp()
 		return nil
@@ -73,23 +251,224 @@ func (c *CLI) runScheduler() error {
 	return nil
 }
 
+// runAll starts the scheduler, job worker and status server in this process and waits for shutdown signal
+func (c *CLI) runAll() error {
+	logrus.Info("Starting all subsystems...")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	worker := NewJobWorker(defaultJobQueueSize)
+	scheduler := c.container.GetScheduler()
+	scheduler.SetWorker(worker)
+
+	supervisor := NewSupervisor()
+	supervisor.Add(scheduler)
+	supervisor.Add(worker)
+	statusServer := NewStatusServer(c.container.GetConfig().Server, supervisor, scheduler, c.container.GetQuotaManager(), c.container.GetEventStats())
+	if c.container.GetConfig().Server.ShareSecret != "" {
+		statusServer.SetSharedReports(c.container.GetShareLinkUseCase())
+	}
+	statusServer.SetPortfolioHistory(c.container.GetPortfolioHistoryUseCase())
+	statusServer.SetTradeNotes(c.container.GetTradeNoteUseCase())
+	statusServer.SetGraphQL(graph.NewHandler(&graph.Resolver{
+		StockRepo:     c.container.GetStockRepository(),
+		PortfolioRepo: c.container.GetPortfolioRepository(),
+		Portfolio:     c.container.GetPortfolioReportUseCase(),
+	}))
+	supervisor.Add(statusServer)
+
+	// Blocks until a shutdown signal is received and all subsystems have stopped
+	supervisor.Run(ctx)
+	return nil
+}
+
+// runStatus displays the subsystem states of a running `all` process
+func (c *CLI) runStatus(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	addr := fs.String("addr", fmt.Sprintf("localhost:%d", c.container.GetConfig().Server.Port), "Status server address")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Get(fmt.Sprintf("http://%s/status", *addr))
+	if err != nil {
+		return fmt.Errorf("stock-automation is not running at %s: %w", *addr, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status from %s: %s", *addr, resp.Status)
+	}
+
+	var status SupervisorStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return fmt.Errorf("failed to decode status: %w", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(status)
+	}
+
+	fmt.Printf("\n🩺 Subsystem Status\n")
+	fmt.Printf("==================\n")
+	fmt.Printf("Started:      %s (up %s)\n", status.StartedAt.Format("2006-01-02 15:04:05"),
+		time.Since(status.StartedAt).Round(time.Second))
+
+	for _, subsystem := range status.Subsystems {
+		fmt.Printf("\n%s\n", subsystem.Name)
+		fmt.Printf("  State:        %s\n", subsystem.State)
+		fmt.Printf("  Restarts:     %d\n", subsystem.Restarts)
+		if !subsystem.StartedAt.IsZero() {
+			fmt.Printf("  Since:        %s\n", subsystem.StartedAt.Format("2006-01-02 15:04:05"))
+		}
+		if subsystem.LastError != "" {
+			fmt.Printf("  Last Error:   %s\n", subsystem.LastError)
+		}
+	}
+	return nil
+}
+
+// runJobCommand lists or triggers the scheduled jobs of a running `all` process
+func (c *CLI) runJobCommand(args []string) error {
+	fs := flag.NewFlagSet("job", flag.ContinueOnError)
+	addr := fs.String("addr", fmt.Sprintf("localhost:%d", c.container.GetConfig().Server.Port), "Status server address")
+
+	var req *http.Request
+	var err error
+	switch args[0] {
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/admin/jobs", *addr), nil)
+	case "run":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: job run <name> [--addr host:port]")
+		}
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/admin/jobs/%s/run", *addr, url.PathEscape(args[1])), nil)
+	default:
+		return fmt.Errorf("unknown job subcommand: %s", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	if token := c.container.GetConfig().Server.AdminToken; token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("stock-automation is not running at %s: %w", *addr, err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Jobs   []string   `json:"jobs"`
+		States []JobState `json:"states"`
+		Error  string     `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if body.Error != "" {
+		return fmt.Errorf("%s (%s)", body.Error, resp.Status)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status from %s: %s", *addr, resp.Status)
+	}
+
+	if args[0] == "list" {
+		fmt.Printf("%-22s %-10s %-17s %s\n", "Job", "Last", "At", "Depends on")
+		for _, state := range body.States {
+			last, at := "-", "-"
+			if state.Last != nil {
+				last, at = state.Last.Status, state.Last.At.Format("2006-01-02 15:04")
+			}
+			deps := strings.Join(state.DependsOn, ", ")
+			if state.Chained {
+				deps += " (chained)"
+			}
+			fmt.Printf("%-22s %-10s %-17s %s\n", state.Name, last, at, deps)
+		}
+		return nil
+	}
+	fmt.Printf("✅ Job %s accepted\n", args[1])
+	return nil
+}
+
+// jobBulkCollect is the lock name of the bulk-collect command, which has no scheduled job
+const jobBulkCollect = "bulk-collect"
+
+// withJobLock runs fn while holding the lock of the job, so the command does not run
+// concurrently with the same scheduled job or another CLI process
+func (c *CLI) withJobLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	err := c.container.GetJobLocker().WithLock(ctx, name, fn)
+	if errors.Is(err, repository.ErrJobLocked) {
+		return fmt.Errorf("%s is already running in another process: %w", name, err)
+	}
+	return err
+}
+
+// baseContext returns the root context of a command. Changes made by the command
+// are recorded to the audit log as CLI operations by the current OS user.
+func (c *CLI) baseContext() context.Context {
+	actor := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		actor = u.Username
+	}
+	return repository.WithAuditSource(context.Background(), models.AuditSourceCLI, actor)
+}
+
+// commandContext returns a context canceled on interrupt or after the timeout (zero means no deadline)
+func (c *CLI) commandContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(c.baseContext(), os.Interrupt, syscall.SIGTERM)
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
 // runDataCollection runs immediate data collection
-func (c *CLI) runDataCollection() error {
-	ctx := context.Background()
+func (c *CLI) runDataCollection(args []string) error {
+	fs := flag.NewFlagSet("collect", flag.ContinueOnError)
+	silent := fs.Bool("silent", false, "Do not send abnormal price and high error rate alerts")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.PriceUpdateTimeout)
+	defer cancel()
 	useCase := c.container.GetCollectDataUseCase()
 
 	logrus.Info("Running data collection...")
 
 	// Update all data
-	if err := useCase.UpdateAllPrices(ctx); err != nil {
+	err := c.withJobLock(ctx, JobPriceUpdate, func(ctx context.Context) error {
+		return useCase.UpdateAllPricesWithOptions(ctx, usecase.CollectOptions{Silent: *silent})
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update prices: %w", err)
 	}
 
-	if err := useCase.UpdateWatchList(ctx); err != nil {
+	if err := c.withJobLock(ctx, JobWatchListUpdate, useCase.UpdateWatchList); err != nil {
 		return fmt.Errorf("failed to update watch list: %w", err)
 	}
 
-	if err := useCase.UpdatePortfolio(ctx); err != nil {
+	if err := c.withJobLock(ctx, JobPortfolioUpdate, useCase.UpdatePortfolio); err != nil {
 		return fmt.Errorf("failed to update portfolio: %w", err)
 	}
 
@@ -97,14 +476,218 @@ func (c *CLI) runDataCollection() error {
 	return nil
 }
 
+// runBulkCollect collects historical data for watched and held stocks (or given codes)
+func (c *CLI) runBulkCollect(args []string) error {
+	fs := flag.NewFlagSet("bulk-collect", flag.ContinueOnError)
+	days := fs.Int("days", 365, "Number of days of historical data to collect (up to 10 years)")
+	fromFlag := fs.String("from", "", "First day to collect (YYYY-MM-DD), instead of --days")
+	toFlag := fs.String("to", "", "Last day to collect with --from (YYYY-MM-DD, default today)")
+	silent := fs.Bool("silent", false, "Do not send the high error rate alert")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days <= 0 {
+		return fmt.Errorf("days must be positive: %d", *days)
+	}
+	if *days > client.MaxHistoricalDays {
+		return fmt.Errorf("days must be at most %d: %d", client.MaxHistoricalDays, *days)
+	}
+
+	// --from collects the exact period instead of the last days
+	var from, to time.Time
+	if *fromFlag != "" {
+		var err error
+		if from, err = time.ParseInLocation("2006-01-02", *fromFlag, time.Local); err != nil {
+			return fmt.Errorf("invalid from date: %s", *fromFlag)
+		}
+		to = models.TruncateToDate(time.Now())
+		if *toFlag != "" {
+			if to, err = time.ParseInLocation("2006-01-02", *toFlag, time.Local); err != nil {
+				return fmt.Errorf("invalid to date: %s", *toFlag)
+			}
+		}
+		if from.After(to) {
+			return fmt.Errorf("from must not be after to: %s - %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+		if from.AddDate(0, 0, client.MaxHistoricalDays).Before(to) {
+			return fmt.Errorf("period must be at most %d days: %s - %s", client.MaxHistoricalDays,
+				from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+	} else if *toFlag != "" {
+		return fmt.Errorf("--to requires --from")
+	}
+
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
+
+	useCase := c.container.GetBulkCollectUseCase()
+	opts := usecase.CollectOptions{Silent: *silent}
+
+	var result *usecase.BulkCollectResult
+	err := c.withJobLock(ctx, jobBulkCollect, func(ctx context.Context) error {
+		codes := fs.Args()
+		if !from.IsZero() {
+			if len(codes) > 0 {
+				result = useCase.CollectCodesRange(ctx, codes, from, to, opts)
+				return nil
+			}
+			var err error
+			result, err = useCase.CollectAllRange(ctx, from, to, opts)
+			return err
+		}
+
+		if len(codes) > 0 {
+			result = useCase.CollectCodes(ctx, codes, *days, opts)
+			return nil
+		}
+
+		var err error
+		result, err = useCase.CollectAll(ctx, *days, opts)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect historical data: %w", err)
+	}
+
+	fmt.Printf("\n📦 Bulk Collection\n")
+	fmt.Printf("==================\n")
+	fmt.Printf("Stocks:   %d/%d succeeded\n", result.Succeeded, result.Requested)
+	fmt.Printf("Records:  %d saved\n", result.SavedRecords)
+	for code, err := range result.Failed {
+		fmt.Printf("  - %s: %v\n", code, err)
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("failed to collect %d stocks", len(result.Failed))
+	}
+	return nil
+}
+
+// runReport generates and sends the daily report, or the monthly report with --monthly.
+// With --format pdf the report is saved as a PDF file and/or emailed instead.
+func (c *CLI) runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	monthly := fs.Bool("monthly", false, "Send the monthly report with correlation analysis")
+	format := fs.String("format", "text", "Report format: text (sent as a notification) or pdf")
+	out := fs.String("out", "", "Output path of the PDF report")
+	email := fs.Bool("email", false, "Email the PDF report to REPORT_EMAIL_TO")
+	dryRun := fs.Bool("dry-run", false, "Print the formatted messages as JSON instead of sending them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch *format {
+	case "text":
+		if *out != "" || *email {
+			return fmt.Errorf("--out and --email require --format pdf")
+		}
+		if *dryRun {
+			return c.runReportDryRun(*monthly)
+		}
+		if *monthly {
+			return c.runMonthlyReport()
+		}
+		return c.runDailyReport()
+	case "pdf":
+		if *dryRun {
+			return fmt.Errorf("--dry-run requires --format text")
+		}
+		return c.runPDFReport(*monthly, *out, *email)
+	default:
+		return fmt.Errorf("invalid format: %s (text or pdf)", *format)
+	}
+}
+
+// runReportDryRun generates the daily or monthly report and prints the messages that would be
+// sent, with their attachments, as JSON to stdout without sending them
+func (c *CLI) runReportDryRun(monthly bool) error {
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
+
+	dryRun := notification.NewDryRunNotifier(os.Stdout)
+	useCase := c.container.GetPortfolioReportUseCase().WithNotifier(dryRun)
+
+	send := useCase.GenerateAndSendDailyReport
+	if monthly {
+		send = useCase.SendMonthlyReport
+	}
+	if err := send(ctx); err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+
+	if dryRun.Count() == 0 {
+		fmt.Fprintln(os.Stderr, "No report would be sent (no portfolio data)")
+	}
+	return nil
+}
+
+// runPDFReport saves the report as a PDF file and/or emails it as an attachment.
+// Without --email the report is saved to a dated file in the current directory unless --out is given.
+func (c *CLI) runPDFReport(monthly bool, out string, email bool) error {
+	sender := c.container.GetEmailSender()
+	if email && sender == nil {
+		return fmt.Errorf("email is not configured: set SMTP_HOST, EMAIL_FROM and REPORT_EMAIL_TO")
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
+
+	var buf bytes.Buffer
+	title, err := c.container.GetPortfolioReportUseCase().WriteReportPDF(ctx, &buf, monthly)
+	if err != nil {
+		return fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	filename := fmt.Sprintf("portfolio-report-%s.pdf", time.Now().Format("20060102"))
+	if monthly {
+		filename = fmt.Sprintf("portfolio-report-%s.pdf", time.Now().Format("200601"))
+	}
+	if out != "" {
+		filename = filepath.Base(out)
+	}
+
+	if out != "" || !email {
+		path := out
+		if path == "" {
+			path = filename
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to save PDF report: %w", err)
+		}
+		fmt.Printf("Saved %s (%d bytes)\n", path, buf.Len())
+	}
+
+	if email {
+		subject := i18n.T("pdf_report.email_subject", title, time.Now().Format("2006-01-02"))
+		attachment := notification.Attachment{Filename: filename, ContentType: "application/pdf", Data: buf.Bytes()}
+		if err := sender.Send(ctx, subject, i18n.T("pdf_report.email_body", title), attachment); err != nil {
+			return err
+		}
+		fmt.Printf("Emailed %s to %s\n", filename, c.container.GetConfig().Email.ReportTo)
+	}
+	return nil
+}
+
+// runMonthlyReport generates and sends the monthly report immediately
+func (c *CLI) runMonthlyReport() error {
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
+
+	logrus.Info("Generating monthly report...")
+
+	return c.withJobLock(ctx, JobMonthlyReport, c.container.GetPortfolioReportUseCase().SendMonthlyReport)
+}
+
 // runDailyReport generates and sends the daily report immediately
 func (c *CLI) runDailyReport() error {
-	ctx := context.Background()
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
 	useCase := c.container.GetPortfolioReportUseCase()
 
 	logrus.Info("Generating daily report...")
 
-	if err := useCase.GenerateAndSendDailyReport(ctx); err != nil {
+	if err := c.withJobLock(ctx, JobDailyReport, useCase.GenerateAndSendDailyReport); err != nil {
 		return fmt.Errorf("failed to generate daily report: %w", err)
 	}
 
@@ -112,93 +695,2999 @@ func (c *CLI) runDailyReport() error {
 	return nil
 }
 
-// runPortfolioCommand handles portfolio-related commands
-func (c *CLI) runPortfolioCommand(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("portfolio command requires subcommand: add, list, remove")
+// runDataQualityReport generates and sends the data quality report immediately
+func (c *CLI) runDataQualityReport() error {
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.DataQualityTimeout)
+	defer cancel()
+	useCase := c.container.GetDataQualityUseCase()
+
+	logrus.Info("Generating data quality report...")
+
+	if err := c.withJobLock(ctx, JobDataQualityReport, useCase.SendWeeklyReport); err != nil {
+		return fmt.Errorf("failed to send data quality report: %w", err)
 	}
 
-	ctx := context.Background()
-	subcommand := args[0]
+	logrus.Info("Data quality report sent successfully")
+	return nil
+}
 
-	switch subcommand {
-	case "add":
-		if len(args) < 5 {
-			return fmt.Errorf("usage: portfolio add <code> <name> <shares> <price>")
-		}
-		// TODO: Implement portfolio add functionality
-		return fmt.Errorf("portfolio add not implemented yet")
+// runRecalcIndicators recalculates and saves the technical indicators of a stock over a period
+func (c *CLI) runRecalcIndicators(args []string) error {
+	fs := flag.NewFlagSet("recalc-indicators", flag.ContinueOnError)
+	code := fs.String("code", "", "Stock code to recalculate (required)")
+	days := fs.Int("days", 365, "Number of days to recalculate")
+	businessDays := fs.Bool("business-days", false, "Count --days in business days of the market instead of calendar days")
 
-	case "list":
-		// Get portfolio statistics
-		reportUseCase := c.container.GetPortfolioReportUseCase()
-		summary, err := reportUseCase.GetPortfolioStatistics(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get portfolio statistics: %w", err)
-		}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *code == "" {
+		return fmt.Errorf("--code is required")
+	}
+	if *days <= 0 {
+		return fmt.Errorf("days must be positive: %d", *days)
+	}
+	stockCode, err := domain.NormalizeStockCode(*code)
+	if err != nil {
+		return err
+	}
 
-		// Display portfolio summary
-		fmt.Printf("\n📊 Portfolio Summary\n")
-		fmt.Printf("==================\n")
-		fmt.Printf("Total Value:  ¥%.2f\n", summary.TotalValue)
-		fmt.Printf("Total Cost:   ¥%.2f\n", summary.TotalCost)
-		fmt.Printf("Total Gain:   ¥%.2f (%.2f%%)\n", summary.TotalGain, summary.TotalGainPercent)
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
 
-		if len(summary.Holdings) > 0 {
-			fmt.Printf("\n📈 Holdings\n")
-			fmt.Printf("==================\n")
-			for _, holding := range summary.Holdings {
-				fmt.Printf("\n%s (%s)\n", holding.Name, holding.Code)
-				fmt.Printf("  Shares:       %d\n", holding.Shares)
-				fmt.Printf("  Price:        ¥%.2f\n", holding.CurrentPrice)
-				fmt.Printf("  Value:        ¥%.2f\n", holding.CurrentValue)
-				fmt.Printf("  Gain:         ¥%.2f (%.2f%%)\n", holding.Gain, holding.GainPercent)
-			}
-		}
-		return nil
+	period := domain.PriceHistoryPeriod{Days: *days, BusinessDays: *businessDays}
+	saved, err := c.container.GetTechnicalAnalysisUseCase().RecalculateIndicators(ctx, stockCode, period)
+	if err != nil {
+		return fmt.Errorf("failed to recalculate indicators: %w", err)
+	}
 
-	case "remove":
-		if len(args) < 2 {
-			return fmt.Errorf("usage: portfolio remove <code>")
-		}
-		// TODO: Implement portfolio remove functionality
-		return fmt.Errorf("portfolio remove not implemented yet")
+	fmt.Printf("Recalculated indicators of %s: %d days saved\n", stockCode, saved)
+	return nil
+}
 
-	default:
-		return fmt.Errorf("unknown portfolio subcommand: %s", subcommand)
+// runAggregatePrices aggregates daily prices into weekly and monthly bars
+func (c *CLI) runAggregatePrices(args []string) error {
+	fs := flag.NewFlagSet("aggregate", flag.ContinueOnError)
+	days := fs.Int("days", 365, "Number of days to aggregate, from the beginning of that month")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days <= 0 {
+		return fmt.Errorf("days must be positive: %d", *days)
+	}
+
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
+
+	saved, err := c.container.GetPriceAggregationUseCase().Aggregate(ctx, fs.Args(), *days)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate prices: %w", err)
 	}
+
+	fmt.Printf("Aggregated weekly and monthly prices: %d bars saved\n", saved)
+	return nil
 }
 
-// runWatchlistCommand handles watchlist-related commands
-func (c *CLI) runWatchlistCommand(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("watchlist command requires subcommand: add, list, remove")
+// runReconcilePrices compares the record counts and close sums of the stored prices with the data
+// source for each stock and period, to verify the data after an archive, restore or backfill
+func (c *CLI) runReconcilePrices(args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	days := fs.Int("days", 90, "Number of days to reconcile, from the beginning of that period")
+	periodFlag := fs.String("period", string(models.PricePeriodMonthly), "Period of the checksums (weekly or monthly)")
+	tolerance := fs.Float64("tolerance", 0, "Allowed difference of the close sums in percent of the source")
+	jsonOutput := fs.Bool("json", false, "Print all checksums as JSON")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days <= 0 || *days > client.MaxHistoricalDays {
+		return fmt.Errorf("days must be between 1 and %d: %d", client.MaxHistoricalDays, *days)
+	}
+	if *tolerance < 0 {
+		return fmt.Errorf("tolerance must not be negative: %g", *tolerance)
+	}
+	period, err := models.ParsePricePeriod(*periodFlag)
+	if err != nil {
+		return err
 	}
 
-	subcommand := args[0]
+	codes := make([]string, 0, fs.NArg())
+	for _, code := range fs.Args() {
+		codes = append(codes, domain.NormalizeCode(code))
+	}
 
-	switch subcommand {
-	case "add":
-		if len(args) < 3 {
-			return fmt.Errorf("usage: watchlist add <code> <name>")
-		}
-		// TODO: Implement watchlist add functionality
-		return fmt.Errorf("watchlist add not implemented yet")
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
 
-	case "list":
-		// TODO: Implement watchlist list functionality
-		return fmt.Errorf("watchlist list not implemented yet")
+	result, err := c.container.GetPriceReconciliationUseCase().Reconcile(ctx, codes, *days, period, *tolerance)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile prices: %w", err)
+	}
+	discrepancies := result.Discrepancies()
 
-	case "remove":
-		if len(args) < 2 {
-			return fmt.Errorf("usage: watchlist remove <code>")
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return err
 		}
-		// TODO: Implement watchlist remove functionality
-		return fmt.Errorf("watchlist remove not implemented yet")
+	} else {
+		fmt.Printf("\n🔎 Price Reconciliation (%s: %s - %s)\n", result.Period,
+			result.From.Format("2006-01-02"), result.To.AddDate(0, 0, -1).Format("2006-01-02"))
+		fmt.Printf("==========================================================\n")
+		fmt.Printf("Periods:  %d/%d matched\n", len(result.Reconciliations)-len(discrepancies), len(result.Reconciliations))
+		if len(discrepancies) > 0 {
+			fmt.Printf("\n%-8s %-10s %-20s %6s %6s %16s %16s\n", "Code", "Period", "Status", "DB", "Source", "DB close sum", "Source sum")
+			for _, d := range discrepancies {
+				var stored, source domain.PriceChecksum
+				if d.Stored != nil {
+					stored = *d.Stored
+				}
+				if d.Source != nil {
+					source = *d.Source
+				}
+				fmt.Printf("%-8s %-10s %-20s %6d %6d %16.2f %16.2f\n", d.Code, d.PeriodStart.Format("2006-01-02"), d.Status,
+					stored.Count, source.Count, stored.CloseSum, source.CloseSum)
+			}
+		}
+		for code, err := range result.Failed {
+			fmt.Printf("  - %s: %v\n", code, err)
+		}
+	}
+
+	if len(discrepancies) > 0 || len(result.Failed) > 0 {
+		return fmt.Errorf("%d periods differ from the source, %d stocks failed", len(discrepancies), len(result.Failed))
+	}
+	return nil
+}
+
+// runSeed fills the database with synthetic prices for performance testing
+func (c *CLI) runSeed(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	stocks := fs.Int("stocks", 1000, "Number of synthetic stocks")
+	years := fs.Int("years", 10, "Years of daily prices")
+	seed := fs.Int64("seed", 1, "Random seed; the same seed and end date generate the same data")
+	end := fs.String("end", "", "Last day of the prices (YYYY-MM-DD, default today)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	endDate := time.Now()
+	if *end != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *end, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid end date: %s", *end)
+		}
+		endDate = parsed
+	}
+
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
+
+	started := time.Now()
+	result, err := c.container.GetSeedUseCase().Seed(ctx, usecase.SeedOptions{
+		Stocks: *stocks,
+		Years:  *years,
+		Seed:   *seed,
+		End:    endDate,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to seed synthetic data: %w", err)
+	}
+
+	fmt.Printf("\n🌱 Synthetic Data\n")
+	fmt.Printf("=================\n")
+	fmt.Printf("Stocks:   %d/%d saved (%s...)\n", result.Stocks-len(result.Failed), result.Stocks, domain.SyntheticCodePrefix)
+	fmt.Printf("Records:  %d saved in %s\n", result.SavedRecords, time.Since(started).Round(time.Second))
+	for code, err := range result.Failed {
+		fmt.Printf("  - %s: %v\n", code, err)
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("failed to seed %d stocks", len(result.Failed))
+	}
+	return nil
+}
+
+// runInspect displays price, indicators, signal and holdings of a stock
+func (c *CLI) runInspect(args []string) error {
+	var code string
+	jsonOutput := false
+	for _, arg := range args {
+		switch arg {
+		case "--json", "-json":
+			jsonOutput = true
+		default:
+			code = domain.NormalizeCode(arg)
+		}
+	}
+
+	if code == "" {
+		return fmt.Errorf("usage: inspect <code> [--json]")
+	}
+
+	ctx := c.baseContext()
+	inspection, err := c.container.GetStockInspectionUseCase().Inspect(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", code, err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(inspection)
+	}
+
+	writeInspection(os.Stdout, inspection)
+	return nil
+}
+
+// runChart displays the close price chart of a stock, optionally posting it to Slack
+func (c *CLI) runChart(args []string) error {
+	fs := flag.NewFlagSet("chart", flag.ContinueOnError)
+	days := fs.Int("days", 60, "Number of days of closes to chart")
+	height := fs.Int("height", domain.DefaultChartHeight, "Number of rows of the chart")
+	width := fs.Int("width", domain.DefaultChartWidth, "Maximum number of columns of the chart")
+	slack := fs.Bool("slack", false, "Also post the chart to Slack as a code block")
+
+	// The code may come before or after the flags
+	var code string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		code, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if code == "" {
+		code = fs.Arg(0)
+	}
+	if code == "" {
+		return fmt.Errorf("usage: chart <code> [--days N] [--height N] [--width N] [--slack]")
+	}
+	code = domain.NormalizeCode(code)
+
+	ctx := c.baseContext()
+	useCase := c.container.GetPriceChartUseCase()
+	chart, err := useCase.Chart(ctx, code, *days)
+	if err != nil {
+		return fmt.Errorf("failed to chart %s: %w", code, err)
+	}
+
+	fmt.Println(chart.Summary())
+	if len(chart.Closes) > 0 {
+		fmt.Println(chart.Sparkline(*width))
+		fmt.Println()
+		fmt.Println(chart.Render(*height, *width))
+	}
+
+	if *slack {
+		if err := useCase.SendChart(ctx, chart, *height, *width); err != nil {
+			return err
+		}
+		fmt.Println("\nPosted the chart to Slack")
+	}
+	return nil
+}
+
+// writeInspection writes the price, indicators, signal, holding and targets of an inspected stock
+func writeInspection(w io.Writer, inspection *usecase.StockInspection) {
+	title := inspection.Code
+	if inspection.Name != "" {
+		title = fmt.Sprintf("%s (%s)", inspection.Name, inspection.Code)
+	}
+
+	fmt.Fprintf(w, "\n🔍 %s\n", title)
+	fmt.Fprintf(w, "==================\n")
+	status := "provisional"
+	if inspection.PriceFinal {
+		status = "final"
+	}
+	fmt.Fprintf(w, "Price:        ¥%.2f (%s, %s)\n", inspection.CurrentPrice, inspection.PriceDate.Format("2006-01-02"), status)
+	if inspection.PriceSource != "" {
+		fetched := ""
+		if inspection.FetchedAt != nil {
+			fetched = ", fetched " + inspection.FetchedAt.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "Source:       %s%s\n", inspection.PriceSource, fetched)
+	}
+
+	if ind := inspection.Indicators; ind != nil {
+		fmt.Fprintf(w, "\n📈 Indicators\n")
+		fmt.Fprintf(w, "==================\n")
+		fmt.Fprintf(w, "RSI(%d):       %.2f\n", ind.Parameters.RSIPeriod, ind.RSI)
+		fmt.Fprintf(w, "MACD:         %.2f / Signal %.2f / Hist %.2f\n", ind.MACD, ind.MACDSignal, ind.MACDHistogram)
+		fmt.Fprintf(w, "MA%-3d         ¥%.2f\n", ind.Parameters.ShortMAPeriod, ind.ShortMA)
+		fmt.Fprintf(w, "MA%-3d         ¥%.2f\n", ind.Parameters.MediumMAPeriod, ind.MediumMA)
+		fmt.Fprintf(w, "MA%-3d         ¥%.2f\n", ind.Parameters.LongMAPeriod, ind.LongMA)
+	}
+
+	if sig := inspection.Signal; sig != nil {
+		fmt.Fprintf(w, "\n🚦 Signal\n")
+		fmt.Fprintf(w, "==================\n")
+		fmt.Fprintf(w, "Action:       %s (confidence %.0f%%, score %.1f)\n", strings.ToUpper(sig.Action), sig.Confidence*100, sig.Score)
+		if sig.Reason != "" {
+			fmt.Fprintf(w, "Reason:       %s\n", sig.Reason)
+		}
+	}
+
+	fmt.Fprintf(w, "\n💼 Holding\n")
+	fmt.Fprintf(w, "==================\n")
+	if h := inspection.Holding; h != nil {
+		fmt.Fprintf(w, "Shares:       %d\n", h.Shares)
+		fmt.Fprintf(w, "Cost:         ¥%.2f\n", h.PurchasePrice)
+		fmt.Fprintf(w, "Value:        ¥%.2f\n", h.CurrentValue)
+		fmt.Fprintf(w, "Gain:         ¥%.2f (%.2f%%)\n", h.Gain, h.GainPercent)
+	} else {
+		fmt.Fprintln(w, "Not held")
+	}
+
+	fmt.Fprintf(w, "\n🎯 Targets\n")
+	fmt.Fprintf(w, "==================\n")
+	if t := inspection.Targets; t != nil && (t.TargetBuyPrice != nil || t.TargetSellPrice != nil) {
+		if t.TargetBuyPrice != nil {
+			fmt.Fprintf(w, "Buy:          ¥%.2f\n", *t.TargetBuyPrice)
+		}
+		if t.TargetSellPrice != nil {
+			fmt.Fprintf(w, "Sell:         ¥%.2f\n", *t.TargetSellPrice)
+		}
+	} else {
+		fmt.Fprintln(w, "No target prices")
+	}
+}
+
+// runPortfolioCommand handles portfolio-related commands
+func (c *CLI) runPortfolioCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("portfolio command requires subcommand: add, buy, sell, lots, list, remove, restore, history, snapshot")
+	}
+
+	ctx := c.baseContext()
+	subcommand := args[0]
+
+	switch subcommand {
+	case "add":
+		if len(args) < 5 {
+			return fmt.Errorf("usage: portfolio add <code> <name> <shares> <price> [purchase-date] [--short] [--margin-rate N] [--interest-rate N] [--asset-class stock|etf|fund|crypto|cash] [--isin CODE]")
+		}
+		price, err := strconv.ParseFloat(args[4], 64)
+		if err != nil {
+			return fmt.Errorf("invalid price: %s", args[4])
+		}
+		input := usecase.AddHoldingInput{
+			Code:          args[1],
+			Name:          args[2],
+			PurchasePrice: price,
+			PurchaseDate:  time.Now(),
+		}
+
+		rest := args[5:]
+		if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+			input.PurchaseDate, err = time.Parse("2006-01-02", rest[0])
+			if err != nil {
+				return fmt.Errorf("invalid purchase date (YYYY-MM-DD): %s", rest[0])
+			}
+			rest = rest[1:]
+		}
+
+		fs := flag.NewFlagSet("portfolio add", flag.ContinueOnError)
+		short := fs.Bool("short", false, "Register as a short (margin sell) position")
+		marginRate := fs.Float64("margin-rate", 0, "Margin rate (%)")
+		interestRate := fs.Float64("interest-rate", 0, "Annual interest or stock lending rate (%)")
+		fs.StringVar(&input.AssetClass, "asset-class", models.AssetClassStock, "Asset class (stock, etf, fund, crypto or cash)")
+		fs.StringVar(&input.ISIN, "isin", "", "ISIN of a fund, whose code is its association code")
+		if err := fs.Parse(rest); err != nil {
+			return err
+		}
+
+		// The quantity of a crypto asset is a fractional number of coins
+		if input.AssetClass == models.AssetClassCrypto {
+			quantity, err := strconv.ParseFloat(args[3], 64)
+			if err != nil {
+				return fmt.Errorf("invalid quantity: %s", args[3])
+			}
+			input.Shares = models.CryptoSharesFromQuantity(quantity)
+		} else if input.Shares, err = strconv.Atoi(args[3]); err != nil {
+			return fmt.Errorf("invalid shares: %s", args[3])
+		}
+
+		if *short {
+			input.PositionType = models.PositionTypeShort
+		}
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "margin-rate":
+				input.MarginRate = marginRate
+			case "interest-rate":
+				input.InterestRate = interestRate
+			}
+		})
+
+		holding, err := c.container.GetPortfolioUseCase().AddHolding(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to add portfolio holding: %w", err)
+		}
+		if holding.IsShort() {
+			fmt.Printf("Short position added: %s (%s) %d shares @ ¥%.2f (margin %.2f%%, interest %.2f%%)\n",
+				holding.Name, holding.Code, holding.Shares, price, holding.GetMarginRate(), holding.GetInterestRate())
+			return nil
+		}
+		switch {
+		case holding.IsCash():
+			fmt.Printf("Cash balance added: %s (%s) ¥%d\n", holding.Name, holding.Code, holding.Shares)
+			return nil
+		case holding.IsFund():
+			fmt.Printf("Fund added: %s (%s) %d units @ ¥%.2f per 10,000 units\n", holding.Name, holding.Code, holding.Shares, price)
+			fmt.Println("Run 'fund-prices' to collect its net asset values")
+			return nil
+		case holding.IsCrypto():
+			fmt.Printf("Crypto asset added: %s (%s) %s coins @ ¥%.2f\n",
+				holding.Name, holding.Code, strconv.FormatFloat(holding.CryptoQuantity(), 'f', -1, 64), price)
+			fmt.Println("Run 'crypto-prices' to collect its price history")
+			return nil
+		}
+		fmt.Printf("Portfolio holding added: %s (%s) %d shares @ ¥%.2f\n", holding.Name, holding.Code, holding.Shares, price)
+		return nil
+
+	case "buy":
+		if len(args) < 4 {
+			return fmt.Errorf("usage: portfolio buy <code> <shares> <price> [purchase-date]")
+		}
+		shares, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid shares: %s", args[2])
+		}
+		price, err := strconv.ParseFloat(args[3], 64)
+		if err != nil {
+			return fmt.Errorf("invalid price: %s", args[3])
+		}
+		input := usecase.AddLotInput{
+			Code:          domain.NormalizeCode(args[1]),
+			Shares:        shares,
+			PurchasePrice: price,
+			PurchaseDate:  time.Now(),
+		}
+		if len(args) > 4 {
+			input.PurchaseDate, err = time.Parse("2006-01-02", args[4])
+			if err != nil {
+				return fmt.Errorf("invalid purchase date (YYYY-MM-DD): %s", args[4])
+			}
+		}
+
+		holding, err := c.container.GetPortfolioUseCase().AddLot(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to add portfolio lot: %w", err)
+		}
+		fmt.Printf("Portfolio lot added: %s (%s) %d shares @ ¥%.2f, now %d shares @ ¥%.2f average\n",
+			holding.Name, holding.Code, shares, price, holding.Shares, holding.GetPurchasePrice())
+		return nil
+
+	case "sell":
+		return c.runPortfolioSell(ctx, args[1:])
+
+	case "lots":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: portfolio lots <code>")
+		}
+		code := domain.NormalizeCode(args[1])
+		lots, err := c.container.GetPortfolioUseCase().GetLots(ctx, code)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio lots: %w", err)
+		}
+
+		fmt.Printf("\n📦 Lots of %s\n", code)
+		fmt.Printf("==================\n")
+		for _, lot := range lots {
+			fmt.Printf("%s  %6d shares @ ¥%10.2f  Cost: ¥%.2f\n",
+				lot.PurchaseDate.Format("2006-01-02"), lot.Shares, lot.GetPurchasePrice(), lot.CalculatePurchaseCost())
+		}
+		return nil
+
+	case "list":
+		fs := flag.NewFlagSet("portfolio list", flag.ContinueOnError)
+		includeDeleted := fs.Bool("include-deleted", false, "Also list removed holdings that can be restored")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		// Get portfolio statistics
+		reportUseCase := c.container.GetPortfolioReportUseCase()
+		summary, err := reportUseCase.GetPortfolioStatistics(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio statistics: %w", err)
+		}
+
+		// Display portfolio summary
+		fmt.Printf("\n📊 Portfolio Summary\n")
+		fmt.Printf("==================\n")
+		fmt.Printf("Total Value:  ¥%.2f\n", summary.TotalValue)
+		fmt.Printf("Total Cost:   ¥%.2f\n", summary.TotalCost)
+		fmt.Printf("Total Gain:   ¥%.2f (%.2f%%)\n", summary.TotalGain, summary.TotalGainPercent)
+		if len(summary.Allocations) > 1 {
+			fmt.Printf("\n🧺 Asset Allocation\n")
+			fmt.Printf("==================\n")
+			for _, allocation := range summary.Allocations {
+				fmt.Printf("%-13s ¥%.2f (%.1f%%)\n", allocation.AssetClass+":", allocation.Value, allocation.Percent)
+			}
+		}
+
+		if len(summary.Holdings) > 0 {
+			fmt.Printf("\n📈 Holdings\n")
+			fmt.Printf("==================\n")
+			for _, holding := range summary.Holdings {
+				fmt.Printf("\n%s (%s) [%s, %s]\n", holding.Name, holding.Code, holding.AssetClass, holding.PositionType)
+				fmt.Printf("  Shares:       %s\n", holding.FormatQuantity())
+				fmt.Printf("  Price:        ¥%.2f\n", holding.CurrentPrice)
+				fmt.Printf("  Value:        ¥%.2f\n", holding.CurrentValue)
+				fmt.Printf("  Gain:         ¥%.2f (%.2f%%)\n", holding.Gain, holding.GainPercent)
+				if holding.RequiredMargin > 0 {
+					fmt.Printf("  Margin:       ¥%.2f\n", holding.RequiredMargin)
+				}
+				if holding.InterestCost > 0 {
+					fmt.Printf("  Interest:     ¥%.2f\n", holding.InterestCost)
+				}
+			}
+		}
+
+		if *includeDeleted {
+			deleted, err := c.container.GetPortfolioUseCase().ListDeletedHoldings(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n🗑  Removed Holdings (restore with: portfolio restore <code>)\n")
+			fmt.Printf("==================\n")
+			if len(deleted) == 0 {
+				fmt.Println("No removed holdings")
+			}
+			for _, holding := range deleted {
+				fmt.Printf("%s (%s) %s @ ¥%.2f  removed %s\n", holding.Name, holding.Code, formatHoldingQuantity(holding),
+					holding.GetPurchasePrice(), holding.DeletedAt.Time.Format("2006-01-02 15:04"))
+			}
+		}
+		return nil
+
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: portfolio remove <code>")
+		}
+		code := domain.NormalizeCode(args[1])
+		if err := c.container.GetPortfolioUseCase().RemoveHolding(ctx, code); err != nil {
+			return fmt.Errorf("failed to remove portfolio holding: %w", err)
+		}
+		fmt.Printf("Portfolio holding removed: %s (restore with: portfolio restore %s)\n", code, code)
+		return nil
+
+	case "restore":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: portfolio restore <code>")
+		}
+		holding, err := c.container.GetPortfolioUseCase().RestoreHolding(ctx, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to restore portfolio holding: %w", err)
+		}
+		fmt.Printf("Portfolio holding restored: %s (%s) %s\n", holding.Name, holding.Code, formatHoldingQuantity(holding))
+		return nil
+
+	case "history":
+		return c.runPortfolioHistory(ctx, args[1:])
+
+	case "snapshot":
+		if err := c.withJobLock(ctx, JobPortfolioSnapshot, c.container.GetPortfolioHistoryUseCase().SaveDailySnapshot); err != nil {
+			return fmt.Errorf("failed to save portfolio snapshot: %w", err)
+		}
+		fmt.Println("Portfolio snapshot saved")
+		return nil
+
+	default:
+		return fmt.Errorf("unknown portfolio subcommand: %s", subcommand)
+	}
+}
+
+// runPortfolioSell sells shares of a holding and displays the consumed lots and realized profit/loss
+func (c *CLI) runPortfolioSell(ctx context.Context, args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("usage: portfolio sell <code> <shares> <price> [--method fifo|average] [--json]")
+	}
+	shares, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid shares: %s", args[1])
+	}
+	price, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return fmt.Errorf("invalid price: %s", args[2])
+	}
+
+	fs := flag.NewFlagSet("portfolio sell", flag.ContinueOnError)
+	methodFlag := fs.String("method", "", "Realized profit/loss method (fifo or average, default PORTFOLIO_COST_METHOD)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(args[3:]); err != nil {
+		return err
+	}
+
+	input := usecase.SellInput{Code: domain.NormalizeCode(args[0]), Shares: shares, SellPrice: price}
+	if *methodFlag != "" {
+		input.Method, err = domain.ParseCostMethod(*methodFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := c.container.GetPortfolioUseCase().Sell(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to sell portfolio holding: %w", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	fmt.Printf("\n💴 Sold %s: %d shares @ ¥%.2f (%s)\n", result.Code, result.Shares, result.SellPrice, result.Method)
+	fmt.Printf("==================\n")
+	for _, lot := range result.ConsumedLots {
+		fmt.Printf("%s  %6d shares @ ¥%10.2f  Cost: ¥%12.2f  Gain: ¥%12.2f  (%d left)\n",
+			lot.PurchaseDate.Format("2006-01-02"), lot.Shares, lot.PurchasePrice, lot.CostBasis, lot.RealizedGain, lot.RemainingShares)
+	}
+	fmt.Printf("\nProceeds:      ¥%.2f\n", result.Proceeds)
+	fmt.Printf("Cost Basis:    ¥%.2f\n", result.CostBasis)
+	fmt.Printf("Realized Gain: ¥%.2f (%.2f%%)\n", result.RealizedGain, result.RealizedGainPercent)
+	return nil
+}
+
+// runPortfolioHistory displays the daily portfolio valuation over a period
+func (c *CLI) runPortfolioHistory(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("portfolio history", flag.ContinueOnError)
+	periodFlag := fs.String("period", string(domain.HistoryPeriod1M), "Period (1M, 3M, 1Y)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	period, err := domain.ParseHistoryPeriod(*periodFlag)
+	if err != nil {
+		return err
+	}
+
+	history, err := c.container.GetPortfolioHistoryUseCase().GetHistory(ctx, period)
+	if err != nil {
+		return fmt.Errorf("failed to get portfolio history: %w", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(history)
+	}
+
+	fmt.Printf("\n📈 Portfolio History (%s: %s - %s)\n", history.Period,
+		history.From.Format("2006-01-02"), history.To.Format("2006-01-02"))
+	fmt.Printf("==================\n")
+
+	if len(history.Points) == 0 {
+		fmt.Println("No portfolio snapshots")
+		return nil
+	}
+
+	for _, point := range history.Points {
+		mark := ""
+		if point.Interpolated {
+			mark = " *"
+		}
+		fmt.Printf("%s  Value: ¥%14.2f  Gain: ¥%14.2f (%6.2f%%)%s\n",
+			point.Date.Format("2006-01-02"), point.TotalValue, point.TotalGain, point.GainPercent, mark)
+	}
+
+	first := history.Points[0]
+	last := history.Points[len(history.Points)-1]
+	fmt.Printf("\nChange:       ¥%.2f\n", last.TotalValue-first.TotalValue)
+	fmt.Println("* carried over from the previous snapshot")
+	return nil
+}
+
+// formatHoldingQuantity returns the quantity of a holding as shown in the portfolio summary
+func formatHoldingQuantity(holding *models.Portfolio) string {
+	return domain.HoldingSummary{Shares: holding.Shares, AssetClass: holding.GetAssetClass()}.FormatQuantity()
+}
+
+// runWatchlistCommand handles watchlist-related commands
+func (c *CLI) runWatchlistCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("watchlist command requires subcommand: add, list, remove, restore, import, interval, mute, unmute")
+	}
+
+	subcommand := args[0]
+
+	switch subcommand {
+	case "add":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: watchlist add <code> <name>")
+		}
+		// TODO: Implement watchlist add functionality
+		return fmt.Errorf("watchlist add not implemented yet")
+
+	case "list":
+		return c.runWatchlistList(args[1:])
+
+	case "remove", "restore":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: watchlist %s <code>", subcommand)
+		}
+		code, err := domain.NormalizeStockCode(args[1])
+		if err != nil {
+			return err
+		}
+		ctx := c.baseContext()
+		useCase := c.container.GetWatchListUseCase()
+		if subcommand == "remove" {
+			if err := useCase.Remove(ctx, code); err != nil {
+				return err
+			}
+			fmt.Printf("Watchlist item removed: %s (restore with: watchlist restore %s)\n", code, code)
+			return nil
+		}
+		item, err := useCase.Restore(ctx, code)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Watchlist item restored: %s (%s)\n", item.Name, item.Code)
+		return nil
+
+	case "import":
+		return c.runWatchlistImport(args[1:])
+
+	case "interval":
+		return c.runWatchlistInterval(args[1:])
+
+	case "mute":
+		return c.runWatchlistMute(args[1:])
+
+	case "unmute":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: watchlist unmute <code>")
+		}
+		code, err := domain.NormalizeStockCode(args[1])
+		if err != nil {
+			return err
+		}
+		if _, err := c.container.GetWatchListUseCase().Unmute(c.baseContext(), code); err != nil {
+			return err
+		}
+		fmt.Printf("🔔 Alerts of %s unmuted\n", code)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown watchlist subcommand: %s", subcommand)
+	}
+}
+
+// runWatchlistList lists the watch list items, with the removed ones if --include-deleted is given
+func (c *CLI) runWatchlistList(args []string) error {
+	fs := flag.NewFlagSet("watchlist list", flag.ContinueOnError)
+	includeDeleted := fs.Bool("include-deleted", false, "Also list removed items that can be restored")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	items, err := c.container.GetWatchListUseCase().List(c.baseContext(), *includeDeleted)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		fmt.Println("No watch list items")
+		return nil
+	}
+
+	fmt.Printf("%-8s %-20s %-8s %s\n", "Code", "Name", "Interval", "Status")
+	for _, item := range items {
+		status := "active"
+		switch {
+		case item.DeletedAt.Valid:
+			status = "removed " + item.DeletedAt.Time.Format("2006-01-02 15:04")
+		case !item.IsActive.Valid || !item.IsActive.Bool:
+			status = "inactive"
+		case item.IsMuted(time.Now()):
+			status = "muted until " + item.MutedUntil.Time.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-8s %-20s %-8s %s\n", item.Code, item.Name, domain.FormatCollectInterval(item.CollectIntervalMinutes), status)
+	}
+	return nil
+}
+
+// runWatchlistMute mutes the alerts of a watch list item for the duration given by --for
+func (c *CLI) runWatchlistMute(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: watchlist mute <code> --for <7d|12h|30m>")
+	}
+	fs := flag.NewFlagSet("watchlist mute", flag.ContinueOnError)
+	duration := fs.String("for", "", "How long to mute the alerts (e.g. 7d, 12h, 30m)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *duration == "" {
+		return fmt.Errorf("usage: watchlist mute <code> --for <7d|12h|30m>")
+	}
+
+	code, err := domain.NormalizeStockCode(args[0])
+	if err != nil {
+		return err
+	}
+	d, err := domain.ParseAlertMuteDuration(*duration)
+	if err != nil {
+		return err
+	}
+	item, err := c.container.GetWatchListUseCase().Mute(c.baseContext(), code, d)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🔕 Alerts of %s muted until %s (unmute with: watchlist unmute %s)\n",
+		code, item.MutedUntil.Time.Format("2006-01-02 15:04"), code)
+	return nil
+}
+
+// runWatchlistInterval sets the collection interval of a watch list item, or lists the intervals without arguments
+func (c *CLI) runWatchlistInterval(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetWatchListUseCase()
+
+	if len(args) == 0 {
+		items, err := useCase.ListCollectIntervals(ctx)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			fmt.Println("No active watch list items")
+			return nil
+		}
+
+		fmt.Printf("%-8s %-20s %s\n", "Code", "Name", "Interval")
+		for _, item := range items {
+			fmt.Printf("%-8s %-20s %s\n", item.Code, item.Name, domain.FormatCollectInterval(item.CollectIntervalMinutes))
+		}
+		return nil
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("usage: watchlist interval [<code> <5m|1h|1d|default>]")
+	}
+
+	code, err := domain.NormalizeStockCode(args[0])
+	if err != nil {
+		return err
+	}
+	minutes, err := domain.ParseCollectInterval(args[1])
+	if err != nil {
+		return err
+	}
+	if err := useCase.SetCollectInterval(ctx, code, minutes); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Collection interval of %s set to %s\n", code, domain.FormatCollectInterval(minutes))
+	return nil
+}
+
+// runWatchlistImport imports watch list items from a CSV or JSON file
+func (c *CLI) runWatchlistImport(args []string) error {
+	fs := flag.NewFlagSet("watchlist import", flag.ContinueOnError)
+	filePath := fs.String("file", "", "Path to CSV or JSON file")
+	format := fs.String("format", "", "File format (csv, json). Detected from extension if omitted")
+	onDuplicate := fs.String("on-duplicate", string(usecase.DuplicateSkip), "Duplicate handling (skip, update)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *filePath == "" {
+		return fmt.Errorf("usage: watchlist import --file <path> [--format csv|json] [--on-duplicate skip|update]")
+	}
+
+	mode, err := usecase.ParseDuplicateMode(*onDuplicate)
+	if err != nil {
+		return err
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*filePath)), ".")
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	var items []usecase.WatchListImportItem
+	switch *format {
+	case "csv":
+		items, err = usecase.ParseWatchListCSV(file)
+	case "json":
+		items, err = usecase.ParseWatchListJSON(file)
+	default:
+		return fmt.Errorf("unsupported file format: %s (csv or json)", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx := c.baseContext()
+	result, err := c.container.GetWatchListUseCase().Import(ctx, items, mode)
+	if err != nil {
+		return fmt.Errorf("failed to import watch list: %w", err)
+	}
+
+	fmt.Printf("\n📥 Watchlist Import\n")
+	fmt.Printf("==================\n")
+	fmt.Printf("Created:  %d\n", result.Created)
+	fmt.Printf("Updated:  %d\n", result.Updated)
+	fmt.Printf("Skipped:  %d\n", result.Skipped)
+	fmt.Printf("Errors:   %d\n", len(result.Errors))
+	for _, msg := range result.Errors {
+		fmt.Printf("  - %s\n", msg)
+	}
+
+	return nil
+}
+
+// runScoreRanking displays the composite score ranking of the watch list
+func (c *CLI) runScoreRanking() error {
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
+
+	useCase := c.container.GetScoringUseCase()
+	ranked, err := useCase.GenerateRanking(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate score ranking: %w", err)
+	}
+
+	fmt.Println(useCase.GenerateRankingReport(ranked))
+	return nil
+}
+
+// runDiscovery shows the stocks of the market proposed for the watch list
+func (c *CLI) runDiscovery(args []string) error {
+	fs := flag.NewFlagSet("discover", flag.ContinueOnError)
+	add := fs.Bool("add", false, "Add the candidates to the watch list")
+	notify := fs.Bool("notify", false, "Send the candidates as a notification")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.DataQualityTimeout)
+	defer cancel()
+
+	useCase := c.container.GetDiscoveryUseCase()
+	candidates, err := useCase.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover candidates: %w", err)
+	}
+
+	var added []string
+	if *add && len(candidates) > 0 {
+		added, err = useCase.AddCandidates(ctx, candidates)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(domain.GenerateDiscoveryReport(candidates, added))
+
+	if *notify {
+		return useCase.SendCandidates(ctx, candidates, added)
+	}
+	return nil
+}
+
+// runFundPrices collects the net asset values of the funds in the portfolio
+func (c *CLI) runFundPrices(args []string) error {
+	fs := flag.NewFlagSet("fund-prices", flag.ContinueOnError)
+	days := fs.Int("days", 30, "Days of net asset values to collect")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days <= 0 {
+		return fmt.Errorf("days must be positive: %d", *days)
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.PriceUpdateTimeout)
+	defer cancel()
+
+	saved, err := c.container.GetFundPriceUseCase().CollectFundPrices(ctx, *days)
+	if err != nil {
+		return fmt.Errorf("failed to collect fund prices: %w", err)
+	}
+
+	fmt.Printf("Fund prices collected: %d records saved\n", saved)
+	return nil
+}
+
+// runCryptoPrices collects the daily price history of the crypto assets in the portfolio
+func (c *CLI) runCryptoPrices(args []string) error {
+	fs := flag.NewFlagSet("crypto-prices", flag.ContinueOnError)
+	days := fs.Int("days", 90, fmt.Sprintf("Days of prices to collect (max %d)", client.MaxCryptoHistoricalDays))
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days <= 0 || *days > client.MaxCryptoHistoricalDays {
+		return fmt.Errorf("days must be between 1 and %d: %d", client.MaxCryptoHistoricalDays, *days)
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.PriceUpdateTimeout)
+	defer cancel()
+
+	useCase := c.container.GetCryptoPriceUseCase()
+	saved, err := useCase.CollectCryptoHistory(ctx, *days)
+	if err != nil {
+		return fmt.Errorf("failed to collect crypto prices: %w", err)
+	}
+	if err := useCase.UpdateCryptoPrices(ctx); err != nil {
+		return fmt.Errorf("failed to update crypto prices: %w", err)
+	}
+
+	fmt.Printf("Crypto prices collected: %d records saved\n", saved)
+	return nil
+}
+
+// runIndexPrices collects the daily price history of the market indices watched and shows their latest closes
+func (c *CLI) runIndexPrices(args []string) error {
+	fs := flag.NewFlagSet("index-prices", flag.ContinueOnError)
+	days := fs.Int("days", 30, "Days of prices to collect")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days <= 0 {
+		return fmt.Errorf("days must be positive: %d", *days)
+	}
+
+	useCase := c.container.GetMarketIndexUseCase()
+	if len(useCase.Indices()) == 0 {
+		fmt.Println("No market indices configured (set MARKET_INDICES)")
+		return nil
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.PriceUpdateTimeout)
+	defer cancel()
+
+	saved, err := useCase.CollectIndexHistory(ctx, *days)
+	if err != nil {
+		return fmt.Errorf("failed to collect index prices: %w", err)
+	}
+	if err := useCase.UpdateIndexPrices(ctx); err != nil {
+		return fmt.Errorf("failed to update index prices: %w", err)
+	}
+	fmt.Printf("Index prices collected: %d records saved\n", saved)
+
+	quotes, err := useCase.Quotes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get index prices: %w", err)
+	}
+	for _, quote := range quotes {
+		change := "-"
+		if quote.HasPrevious {
+			change = fmt.Sprintf("%+.2f%%", quote.ChangePercent)
+		}
+		fmt.Printf("  %-8s %-16s %s %12.2f %8s\n", quote.Symbol, quote.Name, quote.Date.Format("2006-01-02"), quote.Close, change)
+	}
+	return nil
+}
+
+// runMorningBriefing shows the briefing sent before the market opens
+func (c *CLI) runMorningBriefing(args []string) error {
+	fs := flag.NewFlagSet("briefing", flag.ContinueOnError)
+	notify := fs.Bool("notify", false, "Send the briefing as a notification")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
+
+	useCase := c.container.GetMorningBriefingUseCase()
+	briefing, err := useCase.BuildBriefing(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build briefing: %w", err)
+	}
+
+	fmt.Print(domain.FormatMorningBriefing(*briefing))
+
+	if *notify {
+		return useCase.Send(ctx, briefing)
+	}
+	return nil
+}
+
+// runExitTargetCommand handles take-profit and stop-loss line commands
+func (c *CLI) runExitTargetCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetExitTargetUseCase()
+
+	switch args[0] {
+	case "set":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: exit-target set <code> [--take-profit N] [--stop-loss N]")
+		}
+
+		fs := flag.NewFlagSet("exit-target set", flag.ContinueOnError)
+		takeProfit := fs.Float64("take-profit", models.DefaultTakeProfitPercent, "Take-profit line (gain %)")
+		stopLoss := fs.Float64("stop-loss", models.DefaultStopLossPercent, "Stop-loss line (loss %)")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+
+		var takeProfitArg, stopLossArg *float64
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "take-profit":
+				takeProfitArg = takeProfit
+			case "stop-loss":
+				stopLossArg = stopLoss
+			}
+		})
+
+		target, err := useCase.SetTargets(ctx, domain.NormalizeCode(args[1]), takeProfitArg, stopLossArg)
+		if err != nil {
+			return fmt.Errorf("failed to set exit targets: %w", err)
+		}
+		fmt.Printf("Exit targets set: %s take profit +%.2f%%, stop loss -%.2f%%\n",
+			target.Code, target.TakeProfitPercent, target.StopLossPercent)
+		return nil
+
+	case "list":
+		statuses, err := useCase.ListTargets(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list exit targets: %w", err)
+		}
+
+		fmt.Printf("\n🎯 Exit Targets\n")
+		fmt.Printf("==================\n")
+		for _, status := range statuses {
+			source := "custom"
+			if !status.Registered {
+				source = "default"
+			}
+			fmt.Printf("\n%s (%s) [%s]\n", status.Holding.Name, status.Holding.Code, source)
+			fmt.Printf("  Take Profit:  +%.2f%% (¥%.2f)\n", status.Target.TakeProfitPercent, status.Target.TakeProfitPrice(status.Holding))
+			fmt.Printf("  Stop Loss:    -%.2f%% (¥%.2f)\n", status.Target.StopLossPercent, status.Target.StopLossPrice(status.Holding))
+			if status.HasPrice {
+				fmt.Printf("  Price:        ¥%.2f (%.2f%%)\n", status.CurrentPrice, status.GainPercent)
+			}
+		}
+		return nil
+
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: exit-target remove <code>")
+		}
+		code := domain.NormalizeCode(args[1])
+		if err := useCase.RemoveTargets(ctx, code); err != nil {
+			return fmt.Errorf("failed to remove exit targets: %w", err)
+		}
+		fmt.Printf("Exit targets removed (defaults apply): %s\n", code)
+		return nil
+
+	case "check":
+		if err := useCase.CheckTargets(ctx); err != nil {
+			return fmt.Errorf("failed to check exit targets: %w", err)
+		}
+		fmt.Println("Exit target check completed")
+		return nil
+
+	default:
+		return fmt.Errorf("unknown exit-target subcommand: %s", args[0])
+	}
+}
+
+// runAlertRuleCommand handles alert rule commands
+func (c *CLI) runAlertRuleCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetAlertRuleUseCase()
+
+	switch args[0] {
+	case "add", "update":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: alert-rule %s <rule.yaml>", args[0])
+		}
+		definition, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to read rule file: %w", err)
+		}
+
+		if args[0] == "add" {
+			rule, err := useCase.CreateRule(ctx, string(definition))
+			if err != nil {
+				return fmt.Errorf("failed to add alert rule: %w", err)
+			}
+			fmt.Printf("Alert rule added: %s\n", rule.Name)
+			return nil
+		}
+
+		rule, err := useCase.UpdateRule(ctx, string(definition))
+		if err != nil {
+			return fmt.Errorf("failed to update alert rule: %w", err)
+		}
+		fmt.Printf("Alert rule updated: %s\n", rule.Name)
+		return nil
+
+	case "list":
+		rules, err := useCase.ListRules(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list alert rules: %w", err)
+		}
+
+		fmt.Printf("\n🔔 Alert Rules\n")
+		fmt.Printf("==================\n")
+		if len(rules) == 0 {
+			fmt.Println("No alert rules")
+			return nil
+		}
+		for _, rule := range rules {
+			state := "enabled"
+			if !rule.Enabled {
+				state = "disabled"
+			}
+			fmt.Printf("\n%s [%s]\n", rule.Name, state)
+			def, err := domain.ParseAlertRuleDefinition([]byte(rule.Definition))
+			if err != nil {
+				fmt.Printf("  Invalid:      %v\n", err)
+				continue
+			}
+			fmt.Printf("  Conditions:   %s\n", def.Describe())
+			if len(def.Codes) > 0 {
+				fmt.Printf("  Codes:        %s\n", strings.Join(def.Codes, ", "))
+			}
+			fmt.Printf("  Cooldown:     %v\n", def.Cooldown)
+		}
+		return nil
+
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: alert-rule show <name>")
+		}
+		rule, err := useCase.GetRule(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Print(rule.Definition)
+		return nil
+
+	case "enable", "disable":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: alert-rule %s <name>", args[0])
+		}
+		if err := useCase.SetEnabled(ctx, args[1], args[0] == "enable"); err != nil {
+			return fmt.Errorf("failed to %s alert rule: %w", args[0], err)
+		}
+		fmt.Printf("Alert rule %sd: %s\n", args[0], args[1])
+		return nil
+
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: alert-rule remove <name>")
+		}
+		if err := useCase.DeleteRule(ctx, args[1]); err != nil {
+			return fmt.Errorf("failed to remove alert rule: %w", err)
+		}
+		fmt.Printf("Alert rule removed: %s\n", args[1])
+		return nil
+
+	case "history":
+		fs := flag.NewFlagSet("alert-rule history", flag.ContinueOnError)
+		limit := fs.Int("limit", 20, "Number of evaluations to show")
+
+		rest := args[1:]
+		name := ""
+		if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+			name = rest[0]
+			rest = rest[1:]
+		}
+		if err := fs.Parse(rest); err != nil {
+			return err
+		}
+
+		evaluations, err := useCase.GetHistory(ctx, name, *limit)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n📜 Alert Rule History\n")
+		fmt.Printf("==================\n")
+		if len(evaluations) == 0 {
+			fmt.Println("No matched evaluations")
+			return nil
+		}
+		for _, evaluation := range evaluations {
+			state := "notified"
+			if !evaluation.Notified {
+				state = "suppressed"
+			}
+			fmt.Printf("%s  %-20s %-8s [%s] %s\n", evaluation.EvaluatedAt.Format("2006-01-02 15:04"),
+				evaluation.RuleName, evaluation.Code, state, evaluation.MetricValues)
+		}
+		return nil
+
+	case "eval":
+		if err := useCase.EvaluateRules(ctx); err != nil {
+			return fmt.Errorf("failed to evaluate alert rules: %w", err)
+		}
+		fmt.Println("Alert rule evaluation completed")
+		return nil
+
+	default:
+		return fmt.Errorf("unknown alert-rule subcommand: %s", args[0])
+	}
+}
+
+// runFundamentalCommand handles financial indicator commands
+func (c *CLI) runFundamentalCommand(args []string) error {
+	if len(args) > 0 && args[0] == "fetch" {
+		return c.runFundamentalFetch(args[1:])
+	}
+	if len(args) == 0 || args[0] != "set" {
+		return fmt.Errorf("fundamental command requires subcommand: set, fetch")
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("usage: fundamental set <code> [--per N] [--pbr N] [--roe N] [--dividend-yield N]")
+	}
+
+	fs := flag.NewFlagSet("fundamental set", flag.ContinueOnError)
+	per := fs.Float64("per", 0, "Price earnings ratio")
+	pbr := fs.Float64("pbr", 0, "Price book-value ratio")
+	roe := fs.Float64("roe", 0, "Return on equity (%)")
+	dividendYield := fs.Float64("dividend-yield", 0, "Dividend yield (%)")
+
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+
+	code, err := domain.NormalizeStockCode(args[1])
+	if err != nil {
+		return err
+	}
+	fundamental := &models.StockFundamental{Code: code}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "per":
+			fundamental.Per = null.Float64From(*per)
+		case "pbr":
+			fundamental.Pbr = null.Float64From(*pbr)
+		case "roe":
+			fundamental.Roe = null.Float64From(*roe)
+		case "dividend-yield":
+			fundamental.DividendYield = null.Float64From(*dividendYield)
+		}
+	})
+
+	ctx := c.baseContext()
+	if err := c.container.GetScoringUseCase().SetFundamentals(ctx, fundamental); err != nil {
+		return fmt.Errorf("failed to set fundamentals: %w", err)
+	}
+
+	fmt.Printf("Fundamentals updated: %s\n", fundamental.Code)
+	return nil
+}
+
+// runFundamentalFetch calculates financial indicators from J-Quants statements and saves them
+func (c *CLI) runFundamentalFetch(codes []string) error {
+	if len(codes) == 0 {
+		return fmt.Errorf("usage: fundamental fetch <code...>")
+	}
+
+	fundamentalClient := c.container.GetFundamentalClient()
+	if fundamentalClient == nil {
+		return fmt.Errorf("fundamental fetch requires JQUANTS_REFRESH_TOKEN or JQUANTS_MAIL_ADDRESS and JQUANTS_PASSWORD")
+	}
+
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
+
+	failed := 0
+	for _, input := range codes {
+		code, err := domain.NormalizeStockCode(input)
+		var fundamental *models.StockFundamental
+		if err == nil {
+			fundamental, err = fundamentalClient.GetFundamental(ctx, code)
+		}
+		if err == nil {
+			err = c.container.GetScoringUseCase().SetFundamentals(ctx, fundamental)
+		}
+		if err != nil {
+			fmt.Printf("%s: failed: %v\n", input, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s: PER %s, PBR %s, ROE %s, dividend yield %s\n", code,
+			formatNullFloat(fundamental.Per), formatNullFloat(fundamental.Pbr),
+			formatNullFloat(fundamental.Roe), formatNullFloat(fundamental.DividendYield))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to fetch fundamentals of %d of %d stocks", failed, len(codes))
+	}
+	return nil
+}
+
+// runStrategyCommand handles strategy profile commands
+func (c *CLI) runStrategyCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("strategy command requires subcommand: add, list, assign, unassign, compare")
+	}
+
+	ctx := c.baseContext()
+	useCase := c.container.GetStrategyProfileUseCase()
+	subcommand := args[0]
+
+	switch subcommand {
+	case "add":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: strategy add <name> <parameters-json> [description]")
+		}
+		description := ""
+		if len(args) >= 4 {
+			description = args[3]
+		}
+		profile, err := useCase.CreateProfile(ctx, args[1], description, args[2])
+		if err != nil {
+			return fmt.Errorf("failed to create strategy profile: %w", err)
+		}
+		fmt.Printf("Strategy profile created: %s %s\n", profile.Name, profile.Parameters)
+		return nil
+
+	case "list":
+		profiles, err := useCase.ListProfiles(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n🧭 Strategy Profiles\n")
+		fmt.Printf("==================\n")
+		if len(profiles) == 0 {
+			fmt.Println("No strategy profiles registered (default parameters are used)")
+			return nil
+		}
+		for _, profile := range profiles {
+			fmt.Printf("\n%s\n", profile.Name)
+			if profile.Description.Valid {
+				fmt.Printf("  Description:  %s\n", profile.Description.String)
+			}
+			fmt.Printf("  Parameters:   %s\n", profile.Parameters)
+		}
+		return nil
+
+	case "assign":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: strategy assign <code> <name>")
+		}
+		code, err := domain.NormalizeStockCode(args[1])
+		if err != nil {
+			return err
+		}
+		return useCase.AssignProfile(ctx, code, args[2])
+
+	case "unassign":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: strategy unassign <code>")
+		}
+		code, err := domain.NormalizeStockCode(args[1])
+		if err != nil {
+			return err
+		}
+		return useCase.UnassignProfile(ctx, code)
+
+	case "compare":
+		return c.runStrategyCompare(args[1:])
+
+	default:
+		return fmt.Errorf("unknown strategy subcommand: %s", subcommand)
+	}
+}
+
+// runStrategyCompare backtests the strategy profiles against the default parameters and compares the results
+func (c *CLI) runStrategyCompare(args []string) error {
+	fs := flag.NewFlagSet("strategy compare", flag.ContinueOnError)
+	days := fs.Int("days", 365, "Number of days to backtest")
+	profiles := fs.String("profiles", "", "Comma-separated profile names (all profiles if empty)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, name := range strings.Split(*profiles, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
+
+	summaries, err := c.container.GetStrategyProfileUseCase().CompareStrategies(ctx, names, fs.Args(), *days)
+	if err != nil {
+		return fmt.Errorf("failed to compare strategies: %w", err)
+	}
+
+	fmt.Printf("\n🧪 Strategy Backtest (%d days)\n", *days)
+	fmt.Printf("==================\n")
+	fmt.Printf("%-16s %6s %6s %8s %10s %12s %10s\n", "Strategy", "Stocks", "Trades", "WinRate", "Return", "Buy&Hold", "MaxDD")
+	for _, summary := range summaries {
+		fmt.Printf("%-16s %6d %6d %7.1f%% %+9.2f%% %+11.2f%% %9.2f%%\n",
+			summary.Strategy, summary.Stocks, summary.Trades, summary.WinRate,
+			summary.AverageReturn, summary.AverageBuyAndHold, summary.WorstDrawdown)
+	}
+	fmt.Println("\nReturns are averaged over the stocks; signals fill at the next day's open, long only.")
+	return nil
+}
+
+// runAuditLog displays the audit log of portfolio and watch list changes
+func (c *CLI) runAuditLog(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	entity := fs.String("entity", "", "Entity type (portfolio or watch_list)")
+	code := fs.String("code", "", "Stock code")
+	source := fs.String("source", "", "Operation source (cli, api or scheduler)")
+	since := fs.String("since", "", "Show changes since this date (YYYY-MM-DD)")
+	limit := fs.Int("limit", 50, "Number of logs to show")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter := repository.AuditLogFilter{
+		EntityType: *entity,
+		Source:     *source,
+		Limit:      *limit,
+	}
+	if *code != "" {
+		filter.Code = domain.NormalizeCode(*code)
+	}
+	if *since != "" {
+		date, err := time.ParseInLocation("2006-01-02", *since, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date: %s", *since)
+		}
+		filter.Since = date
+	}
+
+	logs, err := c.container.GetAuditLogUseCase().GetLogs(c.baseContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		type auditLogJSON struct {
+			ID         string          `json:"id"`
+			Source     string          `json:"source"`
+			Actor      string          `json:"actor"`
+			Action     string          `json:"action"`
+			EntityType string          `json:"entity_type"`
+			EntityID   string          `json:"entity_id"`
+			Code       string          `json:"code"`
+			Before     json.RawMessage `json:"before"`
+			After      json.RawMessage `json:"after"`
+			CreatedAt  time.Time       `json:"created_at"`
+		}
+		rawJSON := func(s null.String) json.RawMessage {
+			if !s.Valid {
+				return json.RawMessage("null")
+			}
+			return json.RawMessage(s.String)
+		}
+
+		entries := make([]auditLogJSON, len(logs))
+		for i, log := range logs {
+			entries[i] = auditLogJSON{
+				ID:         log.ID,
+				Source:     log.Source,
+				Actor:      log.Actor,
+				Action:     log.Action,
+				EntityType: log.EntityType,
+				EntityID:   log.EntityID,
+				Code:       log.Code,
+				Before:     rawJSON(log.Before),
+				After:      rawJSON(log.After),
+				CreatedAt:  log.CreatedAt,
+			}
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	fmt.Printf("\n🗂  Audit Log\n")
+	fmt.Printf("==================\n")
+	if len(logs) == 0 {
+		fmt.Println("No changes recorded")
+		return nil
+	}
+	for _, log := range logs {
+		actor := log.Source
+		if log.Actor != "" {
+			actor = fmt.Sprintf("%s:%s", log.Source, log.Actor)
+		}
+		fmt.Printf("%s  %-6s %-10s %-8s by %s\n", log.CreatedAt.Format("2006-01-02 15:04:05"),
+			log.Action, log.EntityType, log.Code, actor)
+		if log.Before.Valid {
+			fmt.Printf("    before: %s\n", log.Before.String)
+		}
+		if log.After.Valid {
+			fmt.Printf("    after:  %s\n", log.After.String)
+		}
+	}
+	return nil
+}
+
+// runPaperTradeCommand handles signal-driven paper trading commands
+func (c *CLI) runPaperTradeCommand(args []string) error {
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
+	useCase := c.container.GetPaperTradeUseCase()
+
+	switch args[0] {
+	case "trade":
+		var result *usecase.PaperTradeResult
+		err := c.withJobLock(ctx, JobPaperTrade, func(ctx context.Context) error {
+			var err error
+			result, err = useCase.RunDailyTrading(ctx)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to run paper trading: %w", err)
+		}
+
+		fmt.Printf("\n📝 Paper Trading\n")
+		fmt.Printf("==================\n")
+		fmt.Printf("Settled: %d orders\n", len(result.Settled))
+		for _, order := range result.Settled {
+			printBrokerOrder(order)
+		}
+		fmt.Printf("Placed:  %d orders (filled at the next trading day's open)\n", len(result.Placed))
+		for _, order := range result.Placed {
+			printBrokerOrder(order)
+		}
+		return nil
+
+	case "report":
+		fs := flag.NewFlagSet("paper report", flag.ContinueOnError)
+		send := fs.Bool("send", false, "Send the report to Slack")
+		jsonOutput := fs.Bool("json", false, "Output as JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		if *send {
+			return useCase.SendPerformanceReport(ctx)
+		}
+
+		performance, err := useCase.GetPerformance(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get paper trade performance: %w", err)
+		}
+		if *jsonOutput {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(performance)
+		}
+		fmt.Println(useCase.GeneratePerformanceReport(performance))
+		return nil
+
+	default:
+		return fmt.Errorf("unknown paper subcommand: %s", args[0])
+	}
+}
+
+// runBrokerCommand handles balance, order and execution commands of the broker
+func (c *CLI) runBrokerCommand(args []string) error {
+	ctx := c.baseContext()
+	brokerClient := c.container.GetBrokerClient()
+
+	switch args[0] {
+	case "balance":
+		balance, err := brokerClient.GetBalance(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get balance: %w", err)
+		}
+
+		fmt.Printf("\n💴 Broker Balance (%s)\n", brokerClient.Name())
+		fmt.Printf("==================\n")
+		fmt.Printf("Cash:         ¥%.2f\n", balance.Cash)
+		for _, position := range balance.Positions {
+			fmt.Printf("  %-8s %6d shares @ ¥%.2f\n", position.Code, position.Quantity, position.AveragePrice)
+		}
+		return nil
+
+	case "order":
+		if len(args) < 4 {
+			return fmt.Errorf("usage: broker order <buy|sell> <code> <quantity> [--limit N]")
+		}
+		quantity, err := strconv.Atoi(args[3])
+		if err != nil {
+			return fmt.Errorf("invalid quantity: %s", args[3])
+		}
+
+		fs := flag.NewFlagSet("broker order", flag.ContinueOnError)
+		limit := fs.Float64("limit", 0, "Limit price (market order if omitted)")
+		if err := fs.Parse(args[4:]); err != nil {
+			return err
+		}
+
+		code, err := domain.NormalizeStockCode(args[2])
+		if err != nil {
+			return err
+		}
+		req := broker.OrderRequest{
+			Code:     code,
+			Side:     args[1],
+			Quantity: quantity,
+		}
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "limit" {
+				req.LimitPrice = limit
+			}
+		})
+
+		order, err := brokerClient.PlaceOrder(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to place order: %w", err)
+		}
+		printBrokerOrder(order)
+		return nil
+
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: broker show <order-id>")
+		}
+		order, err := brokerClient.GetOrder(ctx, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			return fmt.Errorf("order not found: %s", args[1])
+		}
+		printBrokerOrder(order)
+		return nil
+
+	case "executions":
+		fs := flag.NewFlagSet("broker executions", flag.ContinueOnError)
+		since := fs.String("since", "", "Show executions since this date (YYYY-MM-DD)")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var sinceTime time.Time
+		if *since != "" {
+			date, err := time.ParseInLocation("2006-01-02", *since, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --since date: %s", *since)
+			}
+			sinceTime = date
+		}
+
+		executions, err := brokerClient.GetExecutions(ctx, sinceTime)
+		if err != nil {
+			return fmt.Errorf("failed to get executions: %w", err)
+		}
+
+		fmt.Printf("\n🧾 Executions (%s)\n", brokerClient.Name())
+		fmt.Printf("==================\n")
+		if len(executions) == 0 {
+			fmt.Println("No executions")
+			return nil
+		}
+		for _, execution := range executions {
+			fmt.Printf("%s  %-4s %-8s %6d @ ¥%.2f  (order %s)\n", execution.ExecutedAt.Format("2006-01-02 15:04:05"),
+				execution.Side, execution.Code, execution.Quantity, utility.DecimalToFloat(execution.Price), execution.OrderID)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown broker subcommand: %s", args[0])
+	}
+}
+
+// runMaintenanceCommand handles maintenance mode commands
+func (c *CLI) runMaintenanceCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetMaintenanceUseCase()
+
+	switch args[0] {
+	case "on":
+		fs := flag.NewFlagSet("maintenance on", flag.ContinueOnError)
+		until := fs.String("until", "", "End of maintenance (HH:MM, YYYY-MM-DD HH:MM or a duration such as 90m)")
+		reason := fs.String("reason", "", "Reason for the maintenance")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *until == "" {
+			return fmt.Errorf("usage: maintenance on --until HH:MM [--reason TEXT]")
+		}
+
+		endsAt, err := domain.ParseMaintenanceUntil(*until, time.Now())
+		if err != nil {
+			return err
+		}
+		window, err := useCase.TurnOn(ctx, endsAt, *reason)
+		if err != nil {
+			return fmt.Errorf("failed to turn maintenance mode on: %w", err)
+		}
+		fmt.Printf("Maintenance mode on until %s (alerts are suppressed and sent as a digest afterwards)\n",
+			window.EndsAt.Format("2006-01-02 15:04"))
+		return nil
+
+	case "off":
+		ended, err := useCase.TurnOff(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to turn maintenance mode off: %w", err)
+		}
+		if !ended {
+			fmt.Println("Maintenance mode is not on")
+			return nil
+		}
+		fmt.Println("Maintenance mode off, digest of suppressed alerts sent")
+		return nil
+
+	case "status":
+		status, err := useCase.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get maintenance status: %w", err)
+		}
+		if status.Window == nil {
+			fmt.Println("Maintenance mode: off")
+			return nil
+		}
+		fmt.Printf("Maintenance mode: on\n")
+		fmt.Printf("  Period:     %s - %s\n",
+			status.Window.StartsAt.Format("2006-01-02 15:04"), status.Window.EndsAt.Format("2006-01-02 15:04"))
+		if status.Window.Reason != "" {
+			fmt.Printf("  Reason:     %s\n", status.Window.Reason)
+		}
+		fmt.Printf("  Suppressed: %d alerts\n", status.SuppressedCount)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown maintenance subcommand: %s", args[0])
+	}
+}
+
+// runSchemaMigrationCommand handles zero-downtime schema migration commands
+func (c *CLI) runSchemaMigrationCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetSchemaMigrationUseCase()
+
+	printMigration := func(migration *models.SchemaMigration) {
+		fmt.Printf("%s: %s\n", migration.Name, migration.Phase)
+		if migration.TotalRows > 0 || migration.BackfilledAt.Valid {
+			fmt.Printf("  Backfill: %d/%d rows (%.1f%%)", migration.BackfilledRows, migration.TotalRows, migration.BackfillPercent())
+			if eta := migration.BackfillETA(time.Now()); eta > 0 {
+				fmt.Printf(", about %s left", eta.Round(time.Second))
+			}
+			fmt.Println()
+		}
+	}
+
+	switch args[0] {
+	case "list":
+		statuses, err := useCase.List(ctx)
+		if err != nil {
+			return err
+		}
+		if len(statuses) == 0 {
+			fmt.Println("No schema migrations registered")
+			return nil
+		}
+		for _, status := range statuses {
+			printMigration(status.Migration)
+			if status.Definition.Description != "" {
+				fmt.Printf("  %s\n", status.Definition.Description)
+			}
+		}
+		return nil
+
+	case "advance", "rollback":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: migration %s <name>", args[0])
+		}
+		advance := useCase.Advance
+		if args[0] == "rollback" {
+			advance = useCase.Rollback
+		}
+		migration, err := advance(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		printMigration(migration)
+		fmt.Printf("Running processes follow the new phase within %s\n", repository.DefaultSchemaMigrationPhaseTTL)
+		return nil
+
+	case "backfill":
+		fs := flag.NewFlagSet("migration backfill", flag.ContinueOnError)
+		batch := fs.Int("batch", usecase.DefaultBackfillBatchSize, "Rows updated per transaction")
+		sleep := fs.Duration("sleep", 100*time.Millisecond, "Pause between batches")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() < 1 {
+			return fmt.Errorf("usage: migration backfill <name> [--batch N] [--sleep D]")
+		}
+
+		migration, err := useCase.Backfill(ctx, fs.Arg(0), usecase.BackfillOptions{BatchSize: *batch, Sleep: *sleep})
+		if migration != nil {
+			printMigration(migration)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Backfill completed, run 'migration advance %s' to read the new schema\n", migration.Name)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migration subcommand: %s", args[0])
+	}
+}
+
+// runFeatureFlagCommand shows the feature flags and overrides them in the database
+func (c *CLI) runFeatureFlagCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetFeatureFlagUseCase()
+
+	switch args[0] {
+	case "list":
+		jsonOutput := len(args) > 1 && (args[1] == "--json" || args[1] == "-json")
+		states, err := useCase.States(ctx)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(states)
+		}
+
+		fmt.Printf("Environment: %s\n\n", useCase.Environment())
+		fmt.Printf("%-24s %-5s %-8s %s\n", "Flag", "State", "Source", "Description")
+		for _, state := range states {
+			enabled := "off"
+			if state.Enabled {
+				enabled = "on"
+			}
+			fmt.Printf("%-24s %-5s %-8s %s\n", state.Name, enabled, state.Source, state.Description)
+		}
+		return nil
+
+	case "enable", "disable":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: flags %s <name>", args[0])
+		}
+		if err := useCase.SetOverride(ctx, args[1], args[0] == "enable"); err != nil {
+			return err
+		}
+		fmt.Printf("Feature %s %sd in the database\n", args[1], args[0])
+		fmt.Printf("Running processes follow the change within %s\n", c.container.GetConfig().Features.CacheTTL)
+		return nil
+
+	case "reset":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: flags reset <name>")
+		}
+		if err := useCase.ClearOverride(ctx, args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Override of %s removed, the flag file or the default applies\n", args[1])
+		return nil
+
+	default:
+		return fmt.Errorf("unknown flags subcommand: %s", args[0])
+	}
+}
+
+// runHedgeCommand shows the hedges proposed against the falling holdings and their history.
+// The hedges are only advised, no order is placed.
+func (c *CLI) runHedgeCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetHedgeAdviceUseCase()
+
+	switch args[0] {
+	case "check":
+		fs := flag.NewFlagSet("hedge check", flag.ContinueOnError)
+		notify := fs.Bool("notify", false, "Send the advice to Slack and record it, once a day per falling holding")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		var result *usecase.HedgeAdviceResult
+		var err error
+		if *notify {
+			result, err = useCase.Advise(ctx)
+		} else {
+			result, err = useCase.Evaluate(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(result.Drops) == 0 {
+			fmt.Printf("No holding fell %g%% or more from the previous close\n", c.container.GetConfig().Hedge.DropPercent)
+			return nil
+		}
+		fmt.Printf("%-6s %-20s %10s %10s %8s\n", "Code", "Name", "Prev", "Price", "Change")
+		for _, drop := range result.Drops {
+			fmt.Printf("%-6s %-20s %10.2f %10.2f %+7.2f%%\n", drop.Code, drop.Name, drop.PreviousClose, drop.Price, drop.ChangePercent)
+		}
+		fmt.Println()
+		for _, advice := range result.Advice {
+			fmt.Printf("[%s] %s\n", advice.Action, advice.Reason)
+		}
+		if *notify {
+			fmt.Println("\nAdvice sent and recorded (no order is placed)")
+		}
+		return nil
+
+	case "history":
+		fs := flag.NewFlagSet("hedge history", flag.ContinueOnError)
+		limit := fs.Int("limit", 20, "Number of advice to show")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		logs, err := useCase.History(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		if len(logs) == 0 {
+			fmt.Println("No hedge advice")
+			return nil
+		}
+		for _, log := range logs {
+			state := "notified"
+			if !log.Notified {
+				state = "failed"
+			}
+			fmt.Printf("%s  %-16s %-6s %14s [%s] triggered by %s\n", log.CreatedAt.Format("2006-01-02 15:04"),
+				log.Action, log.Code, domain.FormatCurrency(log.Amount), state, log.TriggerCodes)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown hedge subcommand: %s", args[0])
+	}
+}
+
+// runCalendarCommand registers the corporate events to Google Calendar and authorizes the access to it
+func (c *CLI) runCalendarCommand(args []string) error {
+	switch args[0] {
+	case "sync":
+		return c.runCalendarSync(args[1:])
+	case "auth":
+		return c.runCalendarAuth(args[1:])
+	default:
+		return fmt.Errorf("unknown calendar subcommand: %s", args[0])
+	}
+}
+
+// runCalendarSync registers the upcoming earnings announcements and dividend dates to the calendar,
+// or only lists them with --dry-run
+func (c *CLI) runCalendarSync(args []string) error {
+	fs := flag.NewFlagSet("calendar sync", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "List the events without registering them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.commandContext(c.container.GetConfig().Scheduler.ReportTimeout)
+	defer cancel()
+	useCase := c.container.GetCalendarSyncUseCase()
+
+	if *dryRun {
+		events, err := useCase.UpcomingEvents(ctx)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			fmt.Println("No upcoming corporate events")
+			return nil
+		}
+		fmt.Printf("%-10s %-6s %-16s %s\n", "Date", "Code", "Type", "Title")
+		for _, event := range events {
+			fmt.Printf("%-10s %-6s %-16s %s\n", event.Date.Format("2006-01-02"), event.Code, event.Type, event.Title())
+		}
+		return nil
+	}
+
+	result, err := useCase.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Synced %d events: %d created, %d updated, %d failed\n",
+		len(result.Events), result.Created, result.Updated, len(result.Failed))
+	for _, failure := range result.Failed {
+		fmt.Printf("  FAILED %s\n", failure)
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("failed to register %d events", len(result.Failed))
+	}
+	return nil
+}
+
+// runCalendarAuth runs the OAuth flow of Google Calendar through a loopback redirect and prints
+// the refresh token to set as GOOGLE_CALENDAR_REFRESH_TOKEN
+func (c *CLI) runCalendarAuth(args []string) error {
+	fs := flag.NewFlagSet("calendar auth", flag.ContinueOnError)
+	port := fs.Int("port", 8085, "Port of the local server receiving the authorization redirect")
+	timeout := fs.Duration("timeout", 5*time.Minute, "Time to wait for the authorization in the browser")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	googleCalendar := c.container.GetGoogleCalendar()
+	if googleCalendar == nil {
+		return fmt.Errorf("calendar auth requires GOOGLE_CALENDAR_CLIENT_ID and GOOGLE_CALENDAR_CLIENT_SECRET")
+	}
+
+	state := utility.NewULID()
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/callback", *port)
+
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("state") != state:
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		case query.Get("error") != "":
+			fmt.Fprintln(w, "Authorization was denied. You can close this window.")
+			errs <- fmt.Errorf("authorization denied: %s", query.Get("error"))
+			return
+		}
+		fmt.Fprintln(w, "Authorization completed. You can close this window.")
+		codes <- query.Get("code")
+	})
+	server := &http.Server{Addr: fmt.Sprintf("127.0.0.1:%d", *port), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+	defer server.Close()
+
+	fmt.Println("Open the following URL in a browser and allow access to the calendar:")
+	fmt.Println()
+	fmt.Println(googleCalendar.AuthCodeURL(redirectURI, state))
+	fmt.Println()
+
+	ctx, cancel := c.commandContext(*timeout)
+	defer cancel()
+
+	var code string
+	select {
+	case code = <-codes:
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("authorization not completed: %w", ctx.Err())
+	}
+
+	refreshToken, err := googleCalendar.ExchangeCode(ctx, code, redirectURI)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Set the refresh token in the environment:")
+	fmt.Printf("GOOGLE_CALENDAR_REFRESH_TOKEN=%s\n", refreshToken)
+	return nil
+}
+
+// runGoalCommand handles portfolio goal commands
+func (c *CLI) runGoalCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetGoalTrackingUseCase()
+
+	switch args[0] {
+	case "add", "update":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: goal %s <name> [--percent N | --value N] [--deadline YYYY-MM-DD]", args[0])
+		}
+
+		fs := flag.NewFlagSet("goal "+args[0], flag.ContinueOnError)
+		percent := fs.Float64("percent", 0, "Target increase of the portfolio value (%)")
+		value := fs.Float64("value", 0, "Target portfolio value (yen)")
+		deadline := fs.String("deadline", "", "Deadline (YYYY-MM-DD)")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+
+		input := usecase.GoalInput{Name: args[1], TargetPercent: *percent, TargetValue: *value}
+		if *deadline != "" {
+			date, err := time.ParseInLocation("2006-01-02", *deadline, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid deadline: %s", *deadline)
+			}
+			input.Deadline = date
+		}
+
+		if args[0] == "add" {
+			if (*percent == 0) == (*value == 0) || *deadline == "" {
+				return fmt.Errorf("usage: goal add <name> --percent N | --value N --deadline YYYY-MM-DD")
+			}
+			goal, err := useCase.CreateGoal(ctx, input)
+			if err != nil {
+				return fmt.Errorf("failed to add goal: %w", err)
+			}
+			fmt.Printf("Added goal %s: ¥%.0f -> ¥%.0f (+%.1f%%) by %s\n",
+				goal.Name, goal.BaselineValue, goal.TargetValue, goal.TargetPercent(), goal.Deadline.Format("2006-01-02"))
+			return nil
+		}
+
+		goal, err := useCase.UpdateGoal(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to update goal: %w", err)
+		}
+		fmt.Printf("Updated goal %s: ¥%.0f -> ¥%.0f (+%.1f%%) by %s\n",
+			goal.Name, goal.BaselineValue, goal.TargetValue, goal.TargetPercent(), goal.Deadline.Format("2006-01-02"))
+		return nil
+
+	case "list":
+		goals, err := useCase.ListGoals(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list goals: %w", err)
+		}
+		if len(goals) == 0 {
+			fmt.Println("No goals set")
+			return nil
+		}
+
+		fmt.Printf("%-20s %14s %14s %12s %9s %9s %-10s\n",
+			"NAME", "CURRENT", "TARGET", "DEADLINE", "PROGRESS", "ELAPSED", "STATUS")
+		for _, goal := range goals {
+			fmt.Printf("%-20s %14.0f %14.0f %12s %8.1f%% %8.1f%% %-10s\n",
+				goal.Name, goal.CurrentValue, goal.TargetValue, goal.Deadline.Format("2006-01-02"),
+				goal.ProgressPercent, goal.ElapsedPercent, goal.Status)
+		}
+		return nil
+
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: goal remove <name>")
+		}
+		if err := useCase.DeleteGoal(ctx, args[1]); err != nil {
+			return fmt.Errorf("failed to remove goal: %w", err)
+		}
+		fmt.Printf("Removed goal %s\n", args[1])
+		return nil
+
+	case "check":
+		notified, err := useCase.CheckPace(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check goals: %w", err)
+		}
+		fmt.Printf("Goal pace checked, %d notifications sent\n", notified)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown goal subcommand: %s", args[0])
+	}
+}
+
+// runCashCommand records deposits and withdrawals and shows the cash balance and buying power
+func (c *CLI) runCashCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetCashUseCase()
+
+	switch args[0] {
+	case "deposit", "withdraw":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: cash %s <amount> [--note TEXT] [--date YYYY-MM-DD]", args[0])
+		}
+		amount, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid amount: %s", args[1])
+		}
+
+		fs := flag.NewFlagSet("cash "+args[0], flag.ContinueOnError)
+		note := fs.String("note", "", "Note of the transaction")
+		date := fs.String("date", "", "Date of the transaction (YYYY-MM-DD, default today)")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+
+		input := usecase.CashInput{Amount: amount, Note: *note}
+		if *date != "" {
+			if input.Date, err = time.ParseInLocation("2006-01-02", *date, time.Local); err != nil {
+				return fmt.Errorf("invalid date: %s", *date)
+			}
+		}
+
+		var transaction *models.CashTransaction
+		if args[0] == "deposit" {
+			transaction, err = useCase.Deposit(ctx, input)
+		} else {
+			transaction, err = useCase.Withdraw(ctx, input)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record %s: %w", args[0], err)
+		}
+		fmt.Printf("Recorded %s of ¥%s on %s, balance ¥%s\n", transaction.TransactionType,
+			domain.FormatCurrency(float64(transaction.Amount)), transaction.TransactedOn.Format("2006-01-02"),
+			domain.FormatCurrency(float64(transaction.BalanceAfter)))
+		return nil
+
+	case "status":
+		power, err := c.container.GetPortfolioReportUseCase().GetBuyingPower(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to calculate buying power: %w", err)
+		}
+		fmt.Printf("Cash:          ¥%s (%.1f%%)\n", domain.FormatCurrency(power.Cash), power.CashPercent())
+		fmt.Printf("Invested:      %.1f%%\n", power.InvestedPercent)
+		fmt.Printf("Target cash:   ¥%s (%.0f%%)\n", domain.FormatCurrency(power.Reserved), power.TargetCashPercent)
+		if power.Margin > 0 {
+			fmt.Printf("Margin:        ¥%s\n", domain.FormatCurrency(power.Margin))
+		}
+		fmt.Printf("Buying power:  ¥%s\n", domain.FormatCurrency(power.Available))
+		if power.BelowTarget() {
+			fmt.Println("Cash is below the target cash position")
+		}
+		return nil
+
+	case "history":
+		fs := flag.NewFlagSet("cash history", flag.ContinueOnError)
+		limit := fs.Int("limit", 20, "Number of transactions to show")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		transactions, err := useCase.History(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		if len(transactions) == 0 {
+			fmt.Println("No cash transactions")
+			return nil
+		}
+		fmt.Printf("%-10s %-10s %14s %14s %s\n", "DATE", "TYPE", "AMOUNT", "BALANCE", "NOTE")
+		for _, transaction := range transactions {
+			fmt.Printf("%-10s %-10s %14s %14s %s\n", transaction.TransactedOn.Format("2006-01-02"), transaction.TransactionType,
+				domain.FormatCurrency(float64(transaction.SignedAmount())), domain.FormatCurrency(float64(transaction.BalanceAfter)),
+				transaction.Note)
+		}
+		return nil
 
 	default:
-		return fmt.Errorf("unknown watchlist subcommand: %s", subcommand)
+		return fmt.Errorf("unknown cash subcommand: %s", args[0])
+	}
+}
+
+// runNicknameCommand manages the nicknames shown instead of the official names of the stocks
+func (c *CLI) runNicknameCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetStockNicknameUseCase()
+
+	switch args[0] {
+	case "set":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: nickname set <code> <nickname>")
+		}
+		entry, err := useCase.SetNickname(ctx, args[1], strings.Join(args[2:], " "))
+		if err != nil {
+			return fmt.Errorf("failed to set nickname: %w", err)
+		}
+		fmt.Printf("%s (%s) is shown as %s\n", entry.Name, entry.Code, entry.Nickname)
+		return nil
+
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: nickname remove <code>")
+		}
+		if err := useCase.RemoveNickname(ctx, args[1]); err != nil {
+			return fmt.Errorf("failed to remove nickname: %w", err)
+		}
+		fmt.Printf("Removed nickname of %s\n", args[1])
+		return nil
+
+	case "list":
+		entries, err := useCase.ListNicknames(ctx)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Println("No nicknames set")
+			return nil
+		}
+		fmt.Printf("%-6s %-20s %s\n", "CODE", "NICKNAME", "NAME")
+		for _, entry := range entries {
+			name := entry.Name
+			if name == "" {
+				name = "(not watched or held)"
+			}
+			fmt.Printf("%-6s %-20s %s\n", entry.Code, entry.Nickname, name)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown nickname subcommand: %s", args[0])
+	}
+}
+
+// runQuarantineCommand handles the stocks failing the price collection
+func (c *CLI) runQuarantineCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetCollectDataUseCase()
+
+	switch args[0] {
+	case "list":
+		failures, err := useCase.ListCollectFailures(ctx)
+		if err != nil {
+			return err
+		}
+		if len(failures) == 0 {
+			fmt.Println("No stocks failing the price collection")
+			return nil
+		}
+		now := time.Now()
+		fmt.Printf("%-6s %-8s %-16s %-16s %s\n", "CODE", "FAILURES", "LAST FAILED", "RETRY AT", "LAST ERROR")
+		for _, failure := range failures {
+			retry := "-"
+			if failure.IsQuarantined(now) {
+				retry = failure.QuarantinedUntil.Format("2006-01-02 15:04")
+			} else if failure.QuarantinedUntil != nil {
+				retry = "next run"
+			}
+			fmt.Printf("%-6s %8d %-16s %-16s %s\n", failure.Code, failure.ConsecutiveFailures,
+				failure.LastFailedAt.Format("2006-01-02 15:04"), retry, failure.LastError)
+		}
+		return nil
+
+	case "release":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: quarantine release <code>")
+		}
+		if err := useCase.ReleaseQuarantine(ctx, args[1]); err != nil {
+			return fmt.Errorf("failed to release %s: %w", args[1], err)
+		}
+		fmt.Printf("Released %s, collected from the next run\n", args[1])
+		return nil
+
+	default:
+		return fmt.Errorf("unknown quarantine subcommand: %s", args[0])
+	}
+}
+
+// runPlanCommand projects a savings plan of monthly contributions and compares it with the portfolio snapshots
+func (c *CLI) runPlanCommand(args []string) error {
+	fs := flag.NewFlagSet("plan "+args[0], flag.ContinueOnError)
+	monthly := fs.Float64("monthly", 0, "Contribution per month (yen)")
+	rate := fs.Float64("rate", 0, "Expected return per year (%)")
+	initial := fs.Float64("initial", 0, "Value at the start of the plan (yen, simulate only)")
+	years := fs.Int("years", 20, "Years to project (simulate only)")
+	since := fs.String("since", "", "First day to compare (YYYY-MM-DD, default the first snapshot; compare only)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	useCase := c.container.GetSavingsPlanUseCase()
+	plan := domain.SavingsPlan{Initial: *initial, Monthly: *monthly, AnnualReturn: *rate}
+
+	switch args[0] {
+	case "simulate":
+		projections, err := useCase.Simulate(plan, *years)
+		if err != nil {
+			return fmt.Errorf("failed to simulate plan: %w", err)
+		}
+
+		fmt.Printf("Savings plan: ¥%s at start, ¥%s per month at %.1f%% a year\n\n",
+			domain.FormatCurrency(plan.Initial), domain.FormatCurrency(plan.Monthly), plan.AnnualReturn)
+		fmt.Printf("%4s  %16s  %16s  %16s\n", "Year", "Contributed", "Value", "Gain")
+		for _, projection := range projections {
+			fmt.Printf("%4d  %16s  %16s  %16s\n", projection.Year,
+				"¥"+domain.FormatCurrency(projection.Contributed),
+				"¥"+domain.FormatCurrency(projection.Value),
+				"¥"+domain.FormatCurrency(projection.Gain()))
+		}
+		return nil
+
+	case "compare":
+		var from time.Time
+		if *since != "" {
+			var err error
+			if from, err = time.ParseInLocation("2006-01-02", *since, time.Local); err != nil {
+				return fmt.Errorf("invalid since date: %s", *since)
+			}
+		}
+
+		comparisons, err := useCase.Compare(c.baseContext(), plan, from)
+		if err != nil {
+			return fmt.Errorf("failed to compare plan: %w", err)
+		}
+
+		fmt.Printf("Savings plan from %s: ¥%s per month at %.1f%% a year\n\n",
+			comparisons[0].Date.Format("2006-01-02"), domain.FormatCurrency(plan.Monthly), plan.AnnualReturn)
+		fmt.Printf("%-10s  %14s  %14s  %14s  %14s  %14s\n", "Date", "Planned cost", "Actual cost", "Planned value", "Actual value", "Difference")
+		for _, comparison := range comparisons {
+			fmt.Printf("%-10s  %14s  %14s  %14s  %14s  %14s\n", comparison.Date.Format("2006-01-02"),
+				"¥"+domain.FormatCurrency(comparison.PlannedContributed),
+				"¥"+domain.FormatCurrency(comparison.ActualContributed),
+				"¥"+domain.FormatCurrency(comparison.PlannedValue),
+				"¥"+domain.FormatCurrency(comparison.ActualValue),
+				"¥"+domain.FormatCurrency(comparison.Difference()))
+		}
+		if len(comparisons) > 1 {
+			fmt.Printf("\nActual contribution: ¥%s per month on average (plan ¥%s)\n",
+				domain.FormatCurrency(domain.AverageMonthlyContribution(comparisons)), domain.FormatCurrency(plan.Monthly))
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown plan subcommand: %s", args[0])
+	}
+}
+
+// runNoteCommand records and shows the notes on the trade ideas of the stocks
+func (c *CLI) runNoteCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetTradeNoteUseCase()
+
+	switch args[0] {
+	case "add":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: note add <code> [--kind hypothesis|entry|review] [--source signal:<rule>|report:<YYYY-MM-DD>] <text>")
+		}
+		fs := flag.NewFlagSet("note add", flag.ContinueOnError)
+		kind := fs.String("kind", models.TradeNoteKindHypothesis, "hypothesis, entry or review")
+		source := fs.String("source", "", "signal:<rule> or report:<YYYY-MM-DD> the note was written from")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		note, err := useCase.AddNote(ctx, usecase.TradeNoteInput{
+			Code:   args[1],
+			Kind:   *kind,
+			Body:   strings.Join(fs.Args(), " "),
+			Source: *source,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add note: %w", err)
+		}
+		fmt.Printf("Added %s note %s for %s\n", note.Kind, note.ID, note.Code)
+		return nil
+
+	case "list":
+		code := ""
+		rest := args[1:]
+		if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+			code, rest = rest[0], rest[1:]
+		}
+		fs := flag.NewFlagSet("note list", flag.ContinueOnError)
+		limit := fs.Int("limit", usecase.DefaultTradeNoteListLimit, "number of notes to show")
+		if err := fs.Parse(rest); err != nil {
+			return err
+		}
+		notes, err := useCase.ListNotes(ctx, code, *limit)
+		if err != nil {
+			return err
+		}
+		if len(notes) == 0 {
+			fmt.Println("No notes")
+			return nil
+		}
+		fmt.Printf("%-26s %-6s %-10s %-16s %s\n", "ID", "CODE", "KIND", "CREATED", "NOTE")
+		for _, note := range notes {
+			firstLine, _, _ := strings.Cut(note.Body, "\n")
+			fmt.Printf("%-26s %-6s %-10s %-16s %s\n",
+				note.ID, note.Code, note.Kind, note.CreatedAt.Format("2006-01-02 15:04"), firstLine)
+		}
+		return nil
+
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: note show <id>")
+		}
+		note, err := useCase.GetNote(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("ID:      %s\n", note.ID)
+		fmt.Printf("Code:    %s\n", note.Code)
+		fmt.Printf("Kind:    %s\n", note.Kind)
+		if note.Source != "" {
+			fmt.Printf("Source:  %s\n", note.Source)
+		}
+		fmt.Printf("Created: %s\n\n", note.CreatedAt.Format("2006-01-02 15:04"))
+		fmt.Println(note.Body)
+		return nil
+
+	case "remove":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: note remove <id>")
+		}
+		if err := useCase.DeleteNote(ctx, args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Removed note %s\n", args[1])
+		return nil
+
+	default:
+		return fmt.Errorf("unknown note subcommand: %s", args[0])
+	}
+}
+
+// runReportPrefsCommand turns the sections of the daily report on or off
+func (c *CLI) runReportPrefsCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetReportPreferenceUseCase()
+
+	switch args[0] {
+	case "list":
+		states, err := useCase.States(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-14s %-5s %s\n", "Section", "State", "Source")
+		for _, state := range states {
+			enabled := "off"
+			if state.Enabled {
+				enabled = "on"
+			}
+			source := "database"
+			if state.Default {
+				source = "default"
+			}
+			fmt.Printf("%-14s %-5s %s\n", state.Section, enabled, source)
+		}
+		return nil
+
+	case "enable", "disable":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: report-prefs %s <section>", args[0])
+		}
+		if err := useCase.SetEnabled(ctx, args[1], args[0] == "enable"); err != nil {
+			return err
+		}
+		fmt.Printf("Report section %s %sd from the next report\n", args[1], args[0])
+		return nil
+
+	case "reset":
+		removed, err := useCase.Reset(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed %d report preferences, all sections are shown\n", removed)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown report-prefs subcommand: %s", args[0])
+	}
+}
+
+// runShareCommand handles the links showing the daily report read-only on the web
+func (c *CLI) runShareCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetShareLinkUseCase()
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("share create", flag.ContinueOnError)
+		days := fs.Int("days", 7, "Days until the link expires")
+		label := fs.String("label", "", "Note on who the link is for")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		link, url, err := useCase.Create(ctx, *label, *days)
+		if err != nil {
+			return fmt.Errorf("failed to create share link: %w", err)
+		}
+		fmt.Printf("Created share link %s, valid until %s\n", link.ID, link.ExpiresAt.Format("2006-01-02 15:04"))
+		fmt.Printf("URL: %s\n", url)
+		fmt.Println("Anyone with the URL can view the daily report while the `all` process is running")
+		return nil
+
+	case "list":
+		links, err := useCase.List(ctx)
+		if err != nil {
+			return err
+		}
+		if len(links) == 0 {
+			fmt.Println("No share links")
+			return nil
+		}
+		now := time.Now()
+		fmt.Printf("%-26s %-8s %-16s %-16s %s\n", "ID", "STATE", "CREATED", "EXPIRES", "LABEL")
+		for _, link := range links {
+			state := "active"
+			switch {
+			case link.RevokedAt != nil:
+				state = "revoked"
+			case !link.IsActive(now):
+				state = "expired"
+			}
+			fmt.Printf("%-26s %-8s %-16s %-16s %s\n", link.ID, state, link.CreatedAt.Format("2006-01-02 15:04"),
+				link.ExpiresAt.Format("2006-01-02 15:04"), link.Label)
+		}
+		return nil
+
+	case "revoke":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: share revoke <id>")
+		}
+		if err := useCase.Revoke(ctx, args[1]); err != nil {
+			return fmt.Errorf("failed to revoke share link: %w", err)
+		}
+		fmt.Printf("Revoked share link %s\n", args[1])
+		return nil
+
+	default:
+		return fmt.Errorf("unknown share subcommand: %s", args[0])
+	}
+}
+
+// runDebugCommand handles the data source responses kept because they could not be parsed
+func (c *CLI) runDebugCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetRawResponseUseCase()
+
+	switch args[0] {
+	case "raw":
+		fs := flag.NewFlagSet("debug raw", flag.ContinueOnError)
+		limit := fs.Int("limit", 20, "Number of responses to show")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		responses, err := useCase.List(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		if len(responses) == 0 {
+			fmt.Println("No raw responses kept")
+			return nil
+		}
+		fmt.Printf("%-6s %-19s %-6s %-10s %-6s %6s %9s %s\n", "ID", "SAVED", "SOURCE", "ENDPOINT", "CODE", "STATUS", "BYTES", "ERROR")
+		for _, response := range responses {
+			fmt.Printf("%-6d %-19s %-6s %-10s %-6s %6d %9d %s\n", response.ID, response.CreatedAt.Format("2006-01-02 15:04:05"),
+				response.Source, response.Endpoint, response.Code, response.StatusCode, len(response.Body), response.Error)
+		}
+		return nil
+
+	case "replay":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: debug replay <id> [--body]")
+		}
+		id, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid id: %s", args[1])
+		}
+		fs := flag.NewFlagSet("debug replay", flag.ContinueOnError)
+		showBody := fs.Bool("body", false, "Print the response body")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+
+		replay, err := useCase.Replay(ctx, id)
+		if err != nil {
+			return err
+		}
+		response := replay.Response
+		fmt.Printf("Response %d: %s %s of %s, status %d, saved at %s\n", response.ID, response.Source, response.Endpoint,
+			response.Code, response.StatusCode, response.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("URL:            %s\n", response.URL)
+		fmt.Printf("Original error: %s\n", response.Error)
+		if response.Truncated {
+			fmt.Printf("Body was cut off at %d bytes, so it may not parse\n", len(response.Body))
+		}
+		if *showBody {
+			fmt.Printf("\n%s\n\n", response.Body)
+		}
+
+		if replay.Err != nil {
+			fmt.Printf("Still fails:    %v\n", replay.Err)
+			return nil
+		}
+		fmt.Printf("Parsed %d prices\n", len(replay.Prices))
+		for _, price := range replay.Prices {
+			fmt.Printf("  %s  O %s  H %s  L %s  C %s  V %d\n", price.Date.Format("2006-01-02 15:04"),
+				price.OpenPrice, price.HighPrice, price.LowPrice, price.ClosePrice, price.Volume)
+		}
+		return nil
+
+	case "prune":
+		fs := flag.NewFlagSet("debug prune", flag.ContinueOnError)
+		days := fs.Int("days", 30, "Keep the responses of the last N days")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		deleted, err := useCase.Prune(ctx, *days)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %d raw responses older than %d days\n", deleted, *days)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown debug subcommand: %s", args[0])
+	}
+}
+
+// runRetentionCommand handles the cleanup of the data past the retention periods of the retention policy
+func (c *CLI) runRetentionCommand(args []string) error {
+	ctx := c.baseContext()
+	useCase := c.container.GetRetentionUseCase()
+
+	switch args[0] {
+	case "list":
+		policy := useCase.Policy()
+		if len(policy.Rules) == 0 {
+			fmt.Println("No retention rules; no data is cleaned up")
+			return nil
+		}
+		now := time.Now()
+		fmt.Printf("%-24s %-6s %-8s %-10s %s\n", "TARGET", "KEEP", "ACTION", "CUTOFF", "DATA")
+		for _, rule := range policy.Rules {
+			fmt.Printf("%-24s %-6s %-8s %-10s %s\n", rule.Target.Name, rule.Keep, rule.Action,
+				rule.Keep.Cutoff(now).Format("2006-01-02"), rule.Target.Description)
+		}
+		if policy.ArchiveDir != "" {
+			fmt.Printf("Archive directory: %s\n", policy.ArchiveDir)
+		}
+		return nil
+
+	case "apply":
+		fs := flag.NewFlagSet("retention apply", flag.ContinueOnError)
+		dryRun := fs.Bool("dry-run", false, "Count the rows past the retention periods without deleting them")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		results, err := useCase.Apply(ctx, *dryRun)
+		for _, result := range results {
+			switch {
+			case result.Err != nil:
+				fmt.Printf("%-24s failed: %v\n", result.Rule.Target.Name, result.Err)
+			case *dryRun:
+				verb := "deleted"
+				if result.Rule.Action == domain.RetentionArchive {
+					verb = "archived"
+				}
+				fmt.Printf("%-24s %d rows before %s would be %s\n", result.Rule.Target.Name, result.Rows,
+					result.Cutoff.Format("2006-01-02"), verb)
+			case result.ArchivePath != "":
+				fmt.Printf("%-24s %d rows before %s archived to %s\n", result.Rule.Target.Name, result.Rows,
+					result.Cutoff.Format("2006-01-02"), result.ArchivePath)
+			default:
+				fmt.Printf("%-24s %d rows before %s deleted\n", result.Rule.Target.Name, result.Rows,
+					result.Cutoff.Format("2006-01-02"))
+			}
+		}
+		return err
+
+	default:
+		return fmt.Errorf("unknown retention subcommand: %s", args[0])
+	}
+}
+
+// runInteractive chooses operations and stocks from menus and runs them as CLI commands
+func (c *CLI) runInteractive() error {
+	fmt.Println("Stock Automation interactive mode. Choose an operation by its number, q to quit.")
+	return runInteractiveSession(os.Stdin, os.Stdout, c.interactiveStocks, func(args []string) error {
+		return c.Run(append([]string{completionProgram}, args...))
+	})
+}
+
+// interactiveStocks returns the watched stocks followed by the held stocks not watched.
+// Stocks that cannot be read are left out, so that codes can still be typed in.
+func (c *CLI) interactiveStocks() []stockChoice {
+	ctx := c.baseContext()
+	var stocks []stockChoice
+	seen := map[string]bool{}
+
+	watchList, err := c.container.GetStockRepository().GetActiveWatchList(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get watch list: %v", err)
+	}
+	for _, item := range watchList {
+		seen[item.Code] = true
+		stocks = append(stocks, stockChoice{Code: item.Code, Name: item.Name})
+	}
+
+	portfolio, err := c.container.GetPortfolioRepository().GetAll(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get portfolio: %v", err)
+	}
+	for _, holding := range portfolio {
+		if !seen[holding.Code] {
+			seen[holding.Code] = true
+			stocks = append(stocks, stockChoice{Code: holding.Code, Name: holding.Name})
+		}
+	}
+	return stocks
+}
+
+// runCompletion prints the shell completion script, or the codes of the watched and held stocks
+// completed by the script
+func (c *CLI) runCompletion(target string) error {
+	if target != "codes" {
+		return WriteCompletion(os.Stdout, target)
+	}
+
+	for _, code := range completionCodes(c.interactiveStocks()) {
+		fmt.Println(code)
+	}
+	return nil
+}
+
+// runYahooDiagnostics measures latency and success rate of each Yahoo Finance endpoint
+func (c *CLI) runYahooDiagnostics(args []string) error {
+	fs := flag.NewFlagSet("test-yahoo", flag.ContinueOnError)
+	runs := fs.Int("runs", 1, "Number of calls per stock and endpoint")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
+
+	report, err := c.container.GetYahooDiagnosticsUseCase().Run(ctx, fs.Args(), *runs)
+	if err != nil {
+		return fmt.Errorf("failed to run Yahoo Finance diagnostics: %w", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Printf("\n🩺 Yahoo Finance Diagnostics\n")
+	fmt.Printf("==================\n")
+	fmt.Printf("Stocks: %s (%d runs, %.0fms)\n\n", strings.Join(report.Codes, ", "), report.Runs, report.DurationMs)
+	fmt.Printf("%-12s %7s %8s %10s %10s %10s %10s\n", "Endpoint", "Calls", "Success", "Avg(ms)", "Min(ms)", "Max(ms)", "P95(ms)")
+	for _, endpoint := range report.Endpoints {
+		fmt.Printf("%-12s %7d %7.1f%% %10.1f %10.1f %10.1f %10.1f\n", endpoint.Endpoint, endpoint.Calls, endpoint.SuccessRate,
+			endpoint.AvgLatencyMs, endpoint.MinLatencyMs, endpoint.MaxLatencyMs, endpoint.P95LatencyMs)
+	}
+
+	failed := 0
+	for _, call := range report.Calls {
+		if call.Success {
+			continue
+		}
+		if failed == 0 {
+			fmt.Printf("\n❌ Failures\n")
+			fmt.Printf("==================\n")
+		}
+		failed++
+		fmt.Printf("  %-8s %-12s %v\n", call.Code, call.Endpoint, call.Error)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d calls failed", failed, len(report.Calls))
+	}
+	return nil
+}
+
+// printBrokerOrder displays an order and its status
+func printBrokerOrder(order *models.BrokerOrder) {
+	fmt.Printf("Order %s: %s %s %d shares (%s) -> %s\n",
+		order.ID, order.Side, order.Code, order.Quantity, order.OrderType, order.Status)
+	if order.FilledQuantity > 0 {
+		fmt.Printf("  Filled:       %d shares @ ¥%.2f\n", order.FilledQuantity, utility.NullDecimalToFloat(order.AveragePrice))
+	}
+	if order.Message.Valid {
+		fmt.Printf("  Message:      %s\n", order.Message.String)
+	}
+}
+
+// formatNullFloat formats an optional indicator, "-" if it could not be calculated
+func formatNullFloat(value null.Float64) string {
+	if !value.Valid {
+		return "-"
 	}
+	return fmt.Sprintf("%.2f", value.Float64)
 }
 
 // printHelp displays the help message
@@ -210,23 +3699,218 @@ Usage:
 
 Commands:
   scheduler, run    Start the scheduler (default)
-  collect          Run immediate data collection
-  report           Generate and send daily report
+  all              Start scheduler, job worker and status server in one process
+  status           Show subsystem states of a running 'all' process (--addr, --json)
+  job              Trigger scheduled jobs of a running 'all' process (--addr)
+    list           List jobs with their dependencies and latest results
+    run            Run a job now (e.g. price-update, daily-report)
+  collect          Run immediate data collection (--silent: no abnormal price or error rate alerts)
+  bulk-collect     Collect historical data (--days N up to 3650, or --from YYYY-MM-DD [--to YYYY-MM-DD], optional stock codes, --silent)
+  report           Generate and send daily report (--monthly for monthly report with correlation analysis)
+                   --dry-run prints the Slack messages with their attachments as JSON instead of sending them
+                   --format pdf saves it as a PDF with tables and charts (--out <path>, --email to attach it to an email)
+  quality          Generate and send price data quality report
+  recalc-indicators Recalculate and save technical indicators of a period (--code, --days N, --business-days)
+  aggregate        Aggregate daily prices into weekly and monthly bars (--days N [codes...])
+  reconcile        Compare record counts and close sums of stored prices with the data source per period
+                   (--days N, --period weekly|monthly, --tolerance percent, --json, [codes...])
+  seed             Save random walk prices of synthetic stocks SYN0001... for load testing (--stocks N, --years N, --seed N, --end YYYY-MM-DD)
+  inspect <code>   Show price, indicators, signal, holding and targets (--json for JSON)
+  chart <code>     Show an ASCII chart of closes (--days N, --height N, --width N, --slack to post it to Slack)
+  tui              Interactive dashboard of portfolio, watchlist and signals (--interval 30s)
+  interactive      Choose operations and stocks from menus and run them, showing the equivalent command
   portfolio        Manage portfolio
-    add            Add a stock to portfolio
-    list           List portfolio holdings
-    remove         Remove a stock from portfolio
+    add            Add a stock to portfolio (--short for a short position, --asset-class etf|fund|crypto|cash, --isin for a fund)
+    buy            Add a purchase lot to a long holding (<code> <shares> <price> [date])
+    sell           Sell shares from the oldest lots and show realized gain (--method fifo|average, --json)
+    lots           List the purchase lots of a holding
+    list           List portfolio holdings (--include-deleted to also list removed holdings)
+    remove         Remove a stock from portfolio (restorable)
+    restore        Restore a removed holding (<code>)
+    history        Show daily value and gain history (--period 1M|3M|1Y, --json)
+    snapshot       Save today's portfolio snapshot
   watchlist        Manage watchlist
     add            Add a stock to watchlist
-    list           List watchlist items
-    remove         Remove a stock from watchlist
+    list           List watchlist items (--include-deleted to also list removed items)
+    remove         Remove a stock from watchlist (restorable)
+    restore        Restore a removed stock to watchlist (<code>)
+    import         Import stocks from CSV/JSON (--file, --on-duplicate skip|update)
+    interval       Set the price collection interval of a stock (<code> <5m|1h|1d|default>), or list intervals
+    mute           Mute the alerts of a stock for a while (<code> --for 7d|12h|30m)
+    unmute         Lift the mute of the alerts of a stock (<code>)
+  score            Show composite score ranking of the watchlist
+  fund-prices      Collect net asset values of the funds in the portfolio (--days N)
+  crypto-prices    Collect daily prices of the crypto assets in the portfolio (--days N, max 365)
+  index-prices     Collect daily prices of the market indices in MARKET_INDICES and show their closes (--days N)
+  briefing         Show the pre-market briefing: indices overnight, previous closes of holdings, earnings today (--notify)
+  discover         Propose stocks with a volume surge or a new high for the watchlist (J-Quants, --add, --notify)
+  exit-target      Manage take-profit/stop-loss lines of holdings (default +20%/-10%)
+    set            Set lines (--take-profit N, --stop-loss N)
+    list           List lines of all holdings
+    remove         Revert a stock to default lines
+    check          Check lines and send sell suggestions
+  alert-rule       Manage alert rules defined in YAML (evaluated after price updates)
+    add            Add a rule from a YAML file
+    update         Replace a rule with a YAML file of the same name
+    list           List rules
+    show           Show the YAML definition of a rule
+    enable/disable Enable or disable a rule
+    remove         Remove a rule and its history
+    history        Show matched evaluations ([name] --limit N)
+    eval           Evaluate rules now
+  fundamental      Manage financial indicators
+    set            Set PER/PBR/ROE/dividend yield of a stock
+    fetch          Calculate indicators from J-Quants financial statements (<code...>)
+  strategy         Manage technical indicator strategy profiles
+    add            Add a profile (parameters as JSON)
+    list           List profiles
+    assign         Apply a profile to a stock
+    unassign       Revert a stock to default parameters
+    compare        Backtest profiles against the defaults ([codes...] --days N --profiles a,b)
+  audit            Show portfolio/watchlist change history (--entity, --code, --source, --since, --limit, --json)
+  paper            Paper trading following the trading signals of the watchlist
+    trade          Settle pending orders and place orders from today's signals
+    report         Compare paper trading with the real portfolio (--send, --json)
+  broker           Trade through the broker set by BROKER_TYPE (paper: filled at next day's open)
+    balance        Show cash and positions
+    order          Place an order (<buy|sell> <code> <quantity> [--limit N])
+    show           Show an order and its status
+    executions     List executions (--since YYYY-MM-DD)
+  maintenance      Suppress alerts during maintenance and send them as a digest afterwards
+    on             Turn maintenance mode on (--until HH:MM, --reason TEXT)
+    off            End maintenance now and send the digest
+    status         Show the maintenance window and suppressed alerts count
+  migration        Change the schema without downtime by dual-writing and backfilling
+    list           Show phases and backfill progress of schema migrations
+    advance        Move a migration to its next phase (pending → dual_write, backfilling → read_new → completed)
+    backfill       Copy existing rows to the new schema, resumable (<name> --batch N --sleep D)
+    rollback       Move a migration back to its previous phase
+  flags            Roll out features per environment (flag file FEATURE_FLAGS_FILE, overridden in the database)
+    list           Show the state of the feature flags and where they are set (--json)
+    enable         Turn a feature on in the database
+    disable        Turn a feature off in the database
+    reset          Remove the database override of a feature
+  hedge            Propose hedges when holdings fall sharply (advice only, no orders)
+    check          Show falling holdings and the proposed hedges (--notify to send and record them)
+    history        Show the recorded hedge advice (--limit N)
+  calendar         Register earnings announcements and dividend dates of watched and held stocks to Google Calendar
+    sync           Register the upcoming events (--dry-run to only list them)
+    auth           Authorize access to Google Calendar and print the refresh token (--port N)
+  goal             Manage portfolio value goals shown in the daily report
+    add            Add a goal from the current value (<name> --percent N | --value N --deadline YYYY-MM-DD)
+    list           Show progress and pace of goals
+    update         Change the target or deadline of a goal
+    remove         Remove a goal
+    check          Check the pace of goals and notify changes
+  cash             Record deposits and withdrawals of the cash balance (PORTFOLIO_CASH_CODE)
+    deposit        Add to the cash balance (<amount> --note TEXT --date YYYY-MM-DD)
+    withdraw       Take from the cash balance (<amount> --note TEXT --date YYYY-MM-DD)
+    status         Show the cash balance, invested ratio and buying power
+    history        Show the deposits and withdrawals (--limit N)
+  nickname         Show stocks by nicknames instead of their official names in reports, CLI and notifications
+    set            Set the nickname of a watched or held stock (<code> <nickname>)
+    remove         Show the official name again (<code>)
+    list           Show the nicknames with the official names
+  quarantine       Stocks left out of the price collection after failing in a row (COLLECT_QUARANTINE_THRESHOLD)
+    list           Show the failing stocks with their last error and when they are retried
+    release        Collect a stock again from the next run (<code>)
+  plan             Project savings of monthly contributions and compare them with the portfolio snapshots
+    simulate       Show the planned value by year (--monthly N --rate N --initial N --years N)
+    compare        Compare the plan with the value and cost at each month end (--monthly N --rate N --since YYYY-MM-DD)
+  note             Record the hypothesis, entry reason and review of trades, referred to from reports and alerts
+    add            Add a note (<code> --kind hypothesis|entry|review --source signal:<rule>|report:<date> <text>)
+    list           Show the notes of a stock or of all stocks, newest first ([<code>] --limit N)
+    show           Show the whole note (<id>)
+    remove         Remove a note (<id>)
+  report-prefs     Choose the sections of the daily report, PDF and shared page (totals are always shown)
+    list           Show whether each section is shown and where it is set
+    enable         Show a section again (holdings, allocations, buying_power, technicals, contributions, goals)
+    disable        Leave a section out of the report (<section>)
+    reset          Show all sections again
+  share            Share the daily report as a read-only web page served by all (SERVER_SHARE_SECRET)
+    create         Create a link to the report page (--days N, default 7, --label TEXT)
+    list           Show the links with their expiry and state
+    revoke         Stop a link from working before it expires (<id>)
+  debug            Inspect the data source responses kept because they could not be parsed
+    raw            List the kept responses (--limit N)
+    replay         Parse a kept response again with the current parser (<id> --body)
+    prune          Delete the kept responses older than N days (--days N, default 30)
+  retention        Clean up the data past the retention periods of DB_RETENTION_POLICY_FILE, as the daily cleanup job does
+    list           Show the retention period, action and cutoff date of each target
+    apply          Delete or archive the rows past the retention periods (--dry-run to only count them)
+  completion       Print the shell completion script of commands, subcommands and stock codes
+    bash           Bash script (source <(stock-automation completion bash))
+    zsh            Zsh script (source <(stock-automation completion zsh))
+    codes          Print the codes of the watched and held stocks completed by the scripts
+  test-yahoo       Measure latency and success rate of each Yahoo Finance endpoint ([codes...] --runs N, --json)
   help             Show this help message
 
 Examples:
   stock-automation                                   # Start scheduler
+  stock-automation all                               # Start all subsystems
+  stock-automation status                            # Check subsystem states
+  stock-automation job run price-update              # Collect prices now
   stock-automation collect                           # Run data collection
+  stock-automation bulk-collect --days 90 7203 6758  # Collect 90 days of history
+  stock-automation bulk-collect --days 3650 --silent  # Backfill 10 years without alerts
+  stock-automation bulk-collect --from 2020-01-01 --to 2020-12-31 7203  # Backfill exactly the year 2020
   stock-automation report                            # Send daily report
+  stock-automation report --monthly                  # Send monthly report
+  stock-automation report --dry-run                  # Print the daily report JSON without sending it
+  stock-automation report --format pdf --out report.pdf  # Save daily report as PDF
+  stock-automation report --monthly --format pdf --email # Email monthly report as PDF
+  stock-automation recalc-indicators --code 7203 --days 365  # Recalculate a year of indicators
+  stock-automation recalc-indicators --code 7203 --days 30 --business-days  # Recalculate the last 30 business days
+  stock-automation aggregate --days 3650             # Rebuild 10 years of weekly and monthly bars
+  stock-automation reconcile --days 365 7203         # Verify a year of stored prices of 7203 against the source
+  stock-automation seed --stocks 1000 --years 10 --seed 42  # Generate load test data
   stock-automation portfolio list                    # Show portfolio
+  stock-automation inspect 7203 --json               # Inspect a stock as JSON
+  stock-automation chart 7203 --days 60 --slack      # Chart 60 days of closes and post it to Slack
+  stock-automation tui --interval 10s                # Open the dashboard refreshed every 10 seconds
+  stock-automation test-yahoo --runs 3 7203 6758     # Diagnose Yahoo Finance API
   stock-automation portfolio add 7203 Toyota 100 2000  # Add to portfolio
-  stock-automation watchlist add 9983 FastRetailing    # Add to watchlist`)
+  stock-automation portfolio add 6758 Sony 100 3000 --short --margin-rate 30  # Add short position
+  stock-automation portfolio add 0331418A eMAXIS-Slim 1000000 18500 --asset-class fund --isin JP90C000H1T1  # Add a fund
+  stock-automation portfolio add JPY Cash 500000 1 --asset-class cash  # Add a cash balance
+  stock-automation portfolio add BTC Bitcoin 0.05 9500000 --asset-class crypto  # Add a crypto asset
+  stock-automation crypto-prices --days 365
+  stock-automation index-prices --days 365           # Collect the history of the market indices
+  stock-automation briefing --notify                 # Send the pre-market briefing now
+  stock-automation portfolio sell 7203 50 2600 --method average  # Sell with average cost
+  stock-automation portfolio history --period 3M     # Show 3-month portfolio history
+  stock-automation portfolio list --include-deleted  # Show portfolio with removed holdings
+  stock-automation portfolio restore 7203            # Restore a removed holding
+  stock-automation alert-rule add configs/alert_rules/oversold-volume-spike.yaml  # Add alert rule
+  stock-automation watchlist add 9983 FastRetailing    # Add to watchlist
+  stock-automation watchlist import --file watchlist.csv --on-duplicate update  # Bulk import
+  stock-automation watchlist interval 4563 1d          # Collect an illiquid stock once a day at close
+  stock-automation watchlist mute 7203 --for 7d        # Silence the alerts of a stock for a week
+  stock-automation exit-target set 7203 --take-profit 15 --stop-loss 8  # Set exit lines
+  stock-automation fundamental set 7203 --per 10.5 --pbr 1.1 --roe 12 --dividend-yield 2.8  # Set fundamentals
+  stock-automation fundamental fetch 7203 6758       # Fetch fundamentals from J-Quants
+  stock-automation strategy add swing '{"rsi_period":9,"short_ma_period":10}'  # Add strategy profile
+  stock-automation strategy assign 7203 swing          # Apply profile to stock
+  stock-automation strategy compare --days 365 --profiles swing 7203  # Compare backtest results
+  stock-automation discover --notify                 # Propose watchlist candidates to Slack
+  stock-automation audit --entity portfolio --since 2024-01-01  # Show portfolio changes
+  stock-automation broker order buy 7203 100 --limit 2500  # Place a limit buy order
+  stock-automation paper report                      # Show paper trading performance
+  stock-automation maintenance on --until 22:00      # Suppress alerts until 22:00
+  stock-automation migration list                    # Show schema migration progress
+  stock-automation flags disable paper_trading       # Stop scheduled paper trading
+  stock-automation hedge check                       # Show hedge ideas for falling holdings
+  stock-automation calendar sync --dry-run           # List upcoming earnings and dividend dates
+  stock-automation goal add year-end --percent 10 --deadline 2026-12-31  # Aim for +10% by year end
+  stock-automation cash deposit 300000 --note bonus  # Record a deposit to the cash balance
+  stock-automation nickname set 8306 MUFG             # Show 三菱ＵＦＪフィナンシャル・グループ as MUFG
+  stock-automation quarantine list                   # Show stocks failing the price collection
+  stock-automation plan simulate --monthly 50000 --rate 5 --years 20  # Project 20 years of savings
+  stock-automation plan compare --monthly 50000 --rate 5 --since 2025-01-01  # Compare the plan with the portfolio
+  stock-automation note add 7203 --kind entry 決算後の押し目で打診買い  # Record why a position was entered
+  stock-automation report-prefs disable technicals   # Leave the technical views out of the daily report
+  stock-automation share create --days 30 --label family  # Share the daily report for a month
+  stock-automation debug replay 12 --body            # Parse a kept Yahoo Finance response again
+  stock-automation retention apply --dry-run         # Count the rows the cleanup job would delete or archive
+  stock-automation completion bash > /etc/bash_completion.d/stock-automation  # Install bash completion`)
 }
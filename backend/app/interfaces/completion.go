@@ -0,0 +1,210 @@
+package interfaces
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// completionProgram is the command name the completion scripts are registered for.
+const completionProgram = "stock-automation"
+
+// cliCommand is a command of the CLI with its subcommands, the source of the completion scripts.
+type cliCommand struct {
+	Name        string
+	Subcommands []string
+}
+
+// cliCommands lists the commands in the order of the help.
+var cliCommands = []cliCommand{
+	{Name: "scheduler"},
+	{Name: "run"},
+	{Name: "all"},
+	{Name: "status"},
+	{Name: "job", Subcommands: []string{"list", "run"}},
+	{Name: "collect"},
+	{Name: "bulk-collect"},
+	{Name: "report"},
+	{Name: "quality"},
+	{Name: "recalc-indicators"},
+	{Name: "aggregate"},
+	{Name: "reconcile"},
+	{Name: "seed"},
+	{Name: "inspect"},
+	{Name: "chart"},
+	{Name: "tui"},
+	{Name: "interactive"},
+	{Name: "portfolio", Subcommands: []string{"add", "buy", "sell", "lots", "list", "remove", "restore", "history", "snapshot"}},
+	{Name: "watchlist", Subcommands: []string{"add", "list", "remove", "restore", "import", "interval", "mute", "unmute"}},
+	{Name: "score"},
+	{Name: "fund-prices"},
+	{Name: "crypto-prices"},
+	{Name: "index-prices"},
+	{Name: "briefing"},
+	{Name: "discover"},
+	{Name: "exit-target", Subcommands: []string{"set", "list", "remove", "check"}},
+	{Name: "alert-rule", Subcommands: []string{"add", "update", "list", "show", "enable", "disable", "remove", "history", "eval"}},
+	{Name: "fundamental", Subcommands: []string{"set", "fetch"}},
+	{Name: "strategy", Subcommands: []string{"add", "list", "assign", "unassign", "compare"}},
+	{Name: "audit"},
+	{Name: "paper", Subcommands: []string{"trade", "report"}},
+	{Name: "broker", Subcommands: []string{"balance", "order", "show", "executions"}},
+	{Name: "maintenance", Subcommands: []string{"on", "off", "status"}},
+	{Name: "migration", Subcommands: []string{"list", "advance", "backfill", "rollback"}},
+	{Name: "flags", Subcommands: []string{"list", "enable", "disable", "reset"}},
+	{Name: "hedge", Subcommands: []string{"check", "history"}},
+	{Name: "calendar", Subcommands: []string{"sync", "auth"}},
+	{Name: "goal", Subcommands: []string{"add", "list", "update", "remove", "check"}},
+	{Name: "cash", Subcommands: []string{"deposit", "withdraw", "status", "history"}},
+	{Name: "nickname", Subcommands: []string{"set", "remove", "list"}},
+	{Name: "quarantine", Subcommands: []string{"list", "release"}},
+	{Name: "plan", Subcommands: []string{"simulate", "compare"}},
+	{Name: "note", Subcommands: []string{"add", "list", "show", "remove"}},
+	{Name: "report-prefs", Subcommands: []string{"list", "enable", "disable", "reset"}},
+	{Name: "share", Subcommands: []string{"create", "list", "revoke"}},
+	{Name: "debug", Subcommands: []string{"raw", "replay", "prune"}},
+	{Name: "retention", Subcommands: []string{"list", "apply"}},
+	{Name: "completion", Subcommands: []string{"bash", "zsh", "codes"}},
+	{Name: "test-yahoo"},
+	{Name: "help"},
+}
+
+// cliCodeArguments are the commands, with their subcommand if any, whose first argument is the code
+// of a watched or held stock. It is completed with the codes printed by `completion codes`.
+var cliCodeArguments = []string{
+	"inspect",
+	"chart",
+	"portfolio buy",
+	"portfolio sell",
+	"portfolio lots",
+	"portfolio remove",
+	"portfolio restore",
+	"watchlist remove",
+	"watchlist restore",
+	"watchlist interval",
+	"watchlist mute",
+	"watchlist unmute",
+	"exit-target set",
+	"exit-target remove",
+	"fundamental set",
+	"fundamental fetch",
+	"strategy assign",
+	"strategy unassign",
+	"nickname set",
+	"nickname remove",
+	"note add",
+	"note list",
+	"quarantine release",
+}
+
+// WriteCompletion writes the completion script of the shell, bash or zsh.
+// Commands and subcommands are completed from the script itself, and stock codes by running
+// `completion codes`, which reads the watch list and the portfolio.
+func WriteCompletion(w io.Writer, shell string) error {
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion()
+	case "zsh":
+		script = zshCompletion()
+	default:
+		return fmt.Errorf("unsupported shell: %s (bash or zsh)", shell)
+	}
+
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// bashCompletion returns the completion script for bash.
+func bashCompletion() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s\n", completionProgram)
+	fmt.Fprintf(&b, "# Load with: source <(%s completion bash)\n\n", completionProgram)
+	b.WriteString("_stock_automation() {\n")
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    if [[ $COMP_CWORD -eq 1 ]]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", commandNames())
+	b.WriteString("        return\n    fi\n\n")
+	b.WriteString("    local subcommands=\"\"\n    case \"${COMP_WORDS[1]}\" in\n")
+	for _, command := range cliCommands {
+		if len(command.Subcommands) > 0 {
+			fmt.Fprintf(&b, "        %s) subcommands=%q ;;\n", command.Name, strings.Join(command.Subcommands, " "))
+		}
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    if [[ -n $subcommands && $COMP_CWORD -eq 2 ]]; then\n")
+	b.WriteString("        COMPREPLY=($(compgen -W \"$subcommands\" -- \"$cur\"))\n")
+	b.WriteString("        return\n    fi\n\n")
+	b.WriteString("    local key=\"${COMP_WORDS[1]}\" position=2\n")
+	b.WriteString("    if [[ -n $subcommands ]]; then\n")
+	b.WriteString("        key=\"${COMP_WORDS[1]} ${COMP_WORDS[2]}\" position=3\n    fi\n")
+	b.WriteString("    if [[ $COMP_CWORD -eq $position ]]; then\n        case \"$key\" in\n")
+	fmt.Fprintf(&b, "            %s)\n", codeArgumentPatterns())
+	b.WriteString("                COMPREPLY=($(compgen -W \"$(\"${COMP_WORDS[0]}\" completion codes 2>/dev/null)\" -- \"$cur\")) ;;\n")
+	b.WriteString("        esac\n    fi\n}\n\n")
+	fmt.Fprintf(&b, "complete -F _stock_automation %s\n", completionProgram)
+	return b.String()
+}
+
+// zshCompletion returns the completion script for zsh.
+func zshCompletion() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n", completionProgram)
+	fmt.Fprintf(&b, "# zsh completion for %s\n", completionProgram)
+	fmt.Fprintf(&b, "# Load with: source <(%s completion zsh), or save as _%s in a directory of $fpath\n\n", completionProgram, completionProgram)
+	b.WriteString("_stock_automation() {\n")
+	b.WriteString("    if (( CURRENT == 2 )); then\n")
+	fmt.Fprintf(&b, "        compadd -- %s\n", commandNames())
+	b.WriteString("        return\n    fi\n\n")
+	b.WriteString("    local -a subcommands\n    case \"${words[2]}\" in\n")
+	for _, command := range cliCommands {
+		if len(command.Subcommands) > 0 {
+			fmt.Fprintf(&b, "        %s) subcommands=(%s) ;;\n", command.Name, strings.Join(command.Subcommands, " "))
+		}
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    if (( CURRENT == 3 && ${#subcommands} > 0 )); then\n")
+	b.WriteString("        compadd -a subcommands\n")
+	b.WriteString("        return\n    fi\n\n")
+	b.WriteString("    local key=\"${words[2]}\" position=3\n")
+	b.WriteString("    if (( ${#subcommands} > 0 )); then\n")
+	b.WriteString("        key=\"${words[2]} ${words[3]}\" position=4\n    fi\n")
+	b.WriteString("    if (( CURRENT == position )); then\n        case \"$key\" in\n")
+	fmt.Fprintf(&b, "            %s)\n", codeArgumentPatterns())
+	b.WriteString("                compadd -- ${(f)\"$(\"${words[1]}\" completion codes 2>/dev/null)\"} ;;\n")
+	b.WriteString("        esac\n    fi\n}\n\n")
+	// Autoloaded from $fpath, the file is the body of the function _stock-automation
+	fmt.Fprintf(&b, "if [[ \"$funcstack[1]\" == \"_%s\" ]]; then\n", completionProgram)
+	b.WriteString("    _stock_automation \"$@\"\nelse\n")
+	fmt.Fprintf(&b, "    compdef _stock_automation %s\nfi\n", completionProgram)
+	return b.String()
+}
+
+// commandNames returns the names of the commands separated by spaces.
+func commandNames() string {
+	names := make([]string, len(cliCommands))
+	for i, command := range cliCommands {
+		names[i] = command.Name
+	}
+	return strings.Join(names, " ")
+}
+
+// codeArgumentPatterns returns the commands taking a stock code as a case pattern of the shells.
+func codeArgumentPatterns() string {
+	patterns := make([]string, len(cliCodeArguments))
+	for i, key := range cliCodeArguments {
+		patterns[i] = fmt.Sprintf("%q", key)
+	}
+	return strings.Join(patterns, "|")
+}
+
+// completionCodes returns the codes of the stocks in code order.
+func completionCodes(stocks []stockChoice) []string {
+	codes := make([]string, len(stocks))
+	for i, stock := range stocks {
+		codes[i] = stock.Code
+	}
+	sort.Strings(codes)
+	return codes
+}
@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"bytes"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCLICodeArguments(t *testing.T) {
+	// Every command taking a stock code must be completed as a command and subcommand
+	for _, key := range cliCodeArguments {
+		name, subcommand, _ := strings.Cut(key, " ")
+		i := slices.IndexFunc(cliCommands, func(command cliCommand) bool { return command.Name == name })
+		if i < 0 {
+			t.Errorf("%q: unknown command %s", key, name)
+			continue
+		}
+		if subcommands := cliCommands[i].Subcommands; (subcommand == "") != (len(subcommands) == 0) || (subcommand != "" && !slices.Contains(subcommands, subcommand)) {
+			t.Errorf("%q: subcommand does not match %v", key, subcommands)
+		}
+	}
+}
+
+func TestWriteCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh"} {
+		var buf bytes.Buffer
+		if err := WriteCompletion(&buf, shell); err != nil {
+			t.Fatalf("WriteCompletion(%s) error = %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), "completion codes") {
+			t.Errorf("WriteCompletion(%s) does not complete stock codes", shell)
+		}
+
+		path, err := exec.LookPath(shell)
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, "-n")
+		cmd.Stdin = &buf
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("%s -n: %v\n%s", shell, err, out)
+		}
+	}
+
+	if err := WriteCompletion(&bytes.Buffer{}, "fish"); err == nil {
+		t.Error("WriteCompletion(fish) error = nil")
+	}
+}
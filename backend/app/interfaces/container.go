@@ -1,13 +1,25 @@
 package interfaces
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/broker"
+	"github.com/boost-jp/stock-automation/app/infrastructure/calendar"
 	"github.com/boost-jp/stock-automation/app/infrastructure/client"
 	"github.com/boost-jp/stock-automation/app/infrastructure/config"
 	"github.com/boost-jp/stock-automation/app/infrastructure/database"
+	"github.com/boost-jp/stock-automation/app/infrastructure/eventbus"
 	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
 	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
 	"github.com/boost-jp/stock-automation/app/usecase"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/sirupsen/logrus"
 )
 
 // Container holds all the dependencies for the application
@@ -16,11 +28,52 @@ type Container struct {
 	config                    *config.Config
 	connectionManager         database.ConnectionManager
 	transactionManager        repository.TransactionManager
+	eventBus                  *eventbus.Bus
+	eventStats                *eventbus.Stats
+	jobLocker                 repository.JobLocker
 	stockRepository           repository.StockRepository
+	stockWriteBuffer          *repository.BufferedStockRepository
 	portfolioRepository       repository.PortfolioRepository
+	portfolioLotRepository    repository.PortfolioLotRepository
+	aggregatedPriceRepository repository.AggregatedPriceRepository
 	notificationLogRepository repository.NotificationLogRepository
+	strategyProfileRepository repository.StrategyProfileRepository
+	fundamentalRepository     repository.StockFundamentalRepository
+	exitTargetRepository      repository.ExitTargetRepository
+	snapshotRepository        repository.PortfolioSnapshotRepository
+	alertRuleRepository       repository.AlertRuleRepository
+	featureFlagRepository     repository.FeatureFlagRepository
+	adviceLogRepository       repository.AdviceLogRepository
+	threadRepository          repository.NotificationThreadRepository
+	featureFlagFile           *domain.FeatureFlagFile
+	auditLogRepository        repository.AuditLogRepository
+	brokerOrderRepository     repository.BrokerOrderRepository
+	maintenanceRepository     repository.MaintenanceRepository
+	schemaMigrationRepository repository.SchemaMigrationRepository
+	schemaMigrationPhases     *repository.SchemaMigrationPhases
+	goalRepository            repository.PortfolioGoalRepository
+	cashRepository            repository.CashTransactionRepository
+	nicknameRepository        repository.StockNicknameRepository
+	rawResponseRepository     repository.RawResponseRepository
+	shareLinkRepository       repository.ShareLinkRepository
+	collectFailureRepository  repository.CollectFailureRepository
+	reportPrefRepository      repository.ReportPreferenceRepository
+	tradeNoteRepository       repository.TradeNoteRepository
+	retentionRepository       repository.RetentionRepository
+	retentionPolicy           *domain.RetentionPolicy
 	stockDataClient           client.StockDataClient
+	quotaManager              *client.QuotaManager
+	fundamentalClient         client.FundamentalDataClient
+	marketClient              client.MarketDataClient
+	corporateEventClient      client.CorporateEventClient
+	googleCalendar            *calendar.GoogleCalendar
+	fundClient                client.FundPriceClient
+	cryptoClient              client.CryptoDataClient
+	paperBroker               *broker.PaperBroker
+	brokerClient              broker.BrokerClient
 	notificationService       notification.NotificationService
+	messageUpdater            notification.MessageUpdater
+	emailSender               *notification.EmailSender
 
 	// Domain Services
 	portfolioService         *domain.PortfolioService
@@ -28,8 +81,55 @@ type Container struct {
 
 	// Use Cases
 	collectDataUseCase       *usecase.CollectDataUseCase
+	priceMoveUseCase         *usecase.PriceMoveNotificationUseCase
+	maDeviationUseCase       *usecase.MADeviationAlertUseCase
+	hedgeAdviceUseCase       *usecase.HedgeAdviceUseCase
 	portfolioReportUseCase   *usecase.PortfolioReportUseCase
 	technicalAnalysisUseCase *usecase.TechnicalAnalysisUseCase
+	strategyProfileUseCase   *usecase.StrategyProfileUseCase
+	dataQualityUseCase       *usecase.DataQualityUseCase
+	watchListUseCase         *usecase.WatchListUseCase
+	stockInspectionUseCase   *usecase.StockInspectionUseCase
+	priceChartUseCase        *usecase.PriceChartUseCase
+	featureFlagUseCase       *usecase.FeatureFlagUseCase
+	bulkCollectUseCase       *usecase.BulkCollectUseCase
+	targetSyncUseCase        *usecase.CollectTargetSyncUseCase
+	portfolioUseCase         *usecase.PortfolioUseCase
+	scoringUseCase           *usecase.ScoringUseCase
+	discoveryUseCase         *usecase.DiscoveryUseCase
+	calendarSyncUseCase      *usecase.CalendarSyncUseCase
+	fundPriceUseCase         *usecase.FundPriceUseCase
+	cryptoPriceUseCase       *usecase.CryptoPriceUseCase
+	exitTargetUseCase        *usecase.ExitTargetUseCase
+	portfolioHistoryUseCase  *usecase.PortfolioHistoryUseCase
+	alertRuleUseCase         *usecase.AlertRuleUseCase
+	auditLogUseCase          *usecase.AuditLogUseCase
+	paperTradeUseCase        *usecase.PaperTradeUseCase
+	yahooDiagnosticsUseCase  *usecase.YahooDiagnosticsUseCase
+	seedUseCase              *usecase.SeedUseCase
+	maintenanceUseCase       *usecase.MaintenanceUseCase
+	schemaMigrationUseCase   *usecase.SchemaMigrationUseCase
+	priceAggregationUseCase  *usecase.PriceAggregationUseCase
+	priceReconcileUseCase    *usecase.PriceReconciliationUseCase
+	goalTrackingUseCase      *usecase.GoalTrackingUseCase
+	savingsPlanUseCase       *usecase.SavingsPlanUseCase
+	cashUseCase              *usecase.CashUseCase
+	nicknameUseCase          *usecase.StockNicknameUseCase
+	rawResponseUseCase       *usecase.RawResponseUseCase
+	shareLinkUseCase         *usecase.ShareLinkUseCase
+	reportPrefUseCase        *usecase.ReportPreferenceUseCase
+	tradeNoteUseCase         *usecase.TradeNoteUseCase
+	marketIndexUseCase       *usecase.MarketIndexUseCase
+	morningBriefingUseCase   *usecase.MorningBriefingUseCase
+	retentionUseCase         *usecase.RetentionUseCase
+
+	// Time zones of the market hours and of the job schedules, and the business days of the market
+	marketHours      domain.MarketHours
+	scheduleLocation *time.Location
+	businessDay      *domain.BusinessDay
+
+	// Market indices whose prices are collected for the daily report
+	marketIndices []domain.MarketIndex
 
 	// Interface
 	scheduler *DataScheduler
@@ -41,6 +141,36 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		config: cfg,
 	}
 
+	// Language of reports and notifications
+	if err := i18n.SetLocale(cfg.Locale); err != nil {
+		return nil, err
+	}
+
+	// Time zone of reports, notifications and dates, set before the database connection reads it
+	if cfg.Timezone != "" {
+		location, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+		time.Local = location
+	}
+
+	// Trading hours of the market and time zone of the job schedules
+	marketHours, err := domain.LookupMarketHours(cfg.Scheduler.Market)
+	if err != nil {
+		return nil, err
+	}
+	container.marketHours = marketHours
+	container.businessDay = domain.NewMarketBusinessDay(marketHours)
+	container.scheduleLocation, err = time.LoadLocation(cfg.Scheduler.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduler timezone %q: %w", cfg.Scheduler.Timezone, err)
+	}
+	container.marketIndices, err = domain.ParseMarketIndices(cfg.DataSource.MarketIndices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MARKET_INDICES: %w", err)
+	}
+
 	// Initialize infrastructure layer
 	if err := container.initializeInfrastructure(); err != nil {
 		return nil, err
@@ -77,42 +207,348 @@ func (c *Container) initializeInfrastructure() error {
 		return err
 	}
 	c.connectionManager = connMgr
+	repository.SetSlowQueryThreshold(c.config.Database.SlowQueryThreshold)
+
+	// In-process event bus, with the subscribers logging and counting all events
+	c.eventBus = eventbus.New()
+	c.eventStats = eventbus.NewStats()
+	c.eventBus.SubscribeAll("log", eventbus.LogEvent)
+	c.eventBus.SubscribeAll("stats", c.eventStats.Record)
 
 	// Transaction manager
 	c.transactionManager = repository.NewTransactionManager(connMgr.GetDB())
+	c.jobLocker = repository.NewJobLocker(connMgr.GetDB())
 
 	// Repositories
-	c.stockRepository = repository.NewStockRepository(connMgr.GetExecutor())
-	c.portfolioRepository = repository.NewPortfolioRepository(connMgr.GetExecutor())
+	// Portfolio and watch list changes are recorded to the audit log
+	c.auditLogRepository = repository.NewAuditLogRepository(connMgr.GetExecutor())
+	c.stockRepository = repository.NewAuditedStockRepository(
+		repository.NewStockRepository(connMgr.GetExecutor()), c.auditLogRepository, c.transactionManager)
+	// Price inserts and updates are written in batches, journaled so that a crash loses none of them
+	if c.config.Database.WriteBufferSize > 0 {
+		c.stockWriteBuffer, err = repository.NewBufferedStockRepository(c.stockRepository, repository.WriteBufferOptions{
+			MaxSize:        c.config.Database.WriteBufferSize,
+			FlushInterval:  c.config.Database.WriteBufferInterval,
+			JournalPath:    c.config.Database.WriteBufferJournal,
+			DeadLetterPath: c.config.Database.WriteBufferDeadLetter,
+		})
+		if err != nil {
+			return err
+		}
+		c.stockRepository = c.stockWriteBuffer
+	}
+	c.portfolioRepository = repository.NewAuditedPortfolioRepository(
+		repository.NewPortfolioRepository(connMgr.GetExecutor()), c.auditLogRepository, c.transactionManager)
+	// Listed holdings and watch list items are shown by the nicknames of their stocks
+	c.nicknameRepository = repository.NewStockNicknameRepository(connMgr.GetExecutor())
+	c.stockRepository = repository.NewNicknamedStockRepository(c.stockRepository, c.nicknameRepository)
+	c.portfolioRepository = repository.NewNicknamedPortfolioRepository(c.portfolioRepository, c.nicknameRepository)
+	c.portfolioLotRepository = repository.NewPortfolioLotRepository(connMgr.GetExecutor())
+	c.aggregatedPriceRepository = repository.NewAggregatedPriceRepository(connMgr.GetExecutor())
 	c.notificationLogRepository = repository.NewNotificationLogRepository(connMgr.GetExecutor())
+	c.strategyProfileRepository = repository.NewStrategyProfileRepository(connMgr.GetExecutor())
+	c.fundamentalRepository = repository.NewStockFundamentalRepository(connMgr.GetExecutor())
+	c.exitTargetRepository = repository.NewExitTargetRepository(connMgr.GetExecutor())
+	c.snapshotRepository = repository.NewPortfolioSnapshotRepository(connMgr.GetExecutor())
+	c.alertRuleRepository = repository.NewAlertRuleRepository(connMgr.GetExecutor())
+	c.featureFlagRepository = repository.NewFeatureFlagRepository(connMgr.GetExecutor())
+	c.adviceLogRepository = repository.NewAdviceLogRepository(connMgr.GetExecutor())
+	c.threadRepository = repository.NewNotificationThreadRepository(connMgr.GetExecutor())
+	c.brokerOrderRepository = repository.NewBrokerOrderRepository(connMgr.GetExecutor())
+	c.maintenanceRepository = repository.NewMaintenanceRepository(connMgr.GetExecutor())
+	c.schemaMigrationRepository = repository.NewSchemaMigrationRepository(connMgr.GetExecutor())
+	c.schemaMigrationPhases = repository.NewSchemaMigrationPhases(c.schemaMigrationRepository, repository.DefaultSchemaMigrationPhaseTTL)
+	c.goalRepository = repository.NewPortfolioGoalRepository(connMgr.GetExecutor())
+	c.cashRepository = repository.NewCashTransactionRepository(connMgr.GetExecutor())
+	c.rawResponseRepository = repository.NewRawResponseRepository(connMgr.GetExecutor())
+	c.shareLinkRepository = repository.NewShareLinkRepository(connMgr.GetExecutor())
+	c.collectFailureRepository = repository.NewCollectFailureRepository(connMgr.GetExecutor())
+	c.reportPrefRepository = repository.NewReportPreferenceRepository(connMgr.GetExecutor())
+	c.tradeNoteRepository = repository.NewTradeNoteRepository(connMgr.GetExecutor())
+	c.retentionRepository = repository.NewRetentionRepository(connMgr.GetExecutor())
+
+	// Feature flags of the environment set by the flag file
+	c.featureFlagFile, err = loadFeatureFlagFile(c.config.Features.FlagsFile)
+	if err != nil {
+		return err
+	}
+
+	// Retention periods of the data cleaned up daily
+	c.retentionPolicy, err = loadRetentionPolicy(c.config.Database.RetentionPolicyFile)
+	if err != nil {
+		return err
+	}
 
 	// External clients
-	yahooConfig := client.YahooFinanceConfig{
-		BaseURL:       c.config.Yahoo.BaseURL,
-		Timeout:       c.config.Yahoo.Timeout,
-		RetryCount:    c.config.Yahoo.RetryCount,
-		RetryWaitTime: c.config.Yahoo.RetryWaitTime,
-		RetryMaxWait:  c.config.Yahoo.RetryMaxWait,
-		UserAgent:     c.config.Yahoo.UserAgent,
-		RateLimitRPS:  c.config.Yahoo.RateLimitRPS,
-	}
-	c.stockDataClient = client.NewYahooFinanceClientWithConfig(yahooConfig)
-
-	// Notification service
+	quotaLimits, err := client.ParseQuotaLimits(c.config.DataSource.DailyLimits)
+	if err != nil {
+		return err
+	}
+	c.quotaManager = client.NewQuotaManager(quotaLimits, c.config.DataSource.QuotaWarnPercent)
+
+	var jquantsClient *client.JQuantsClient
+	if c.config.JQuants.Enabled() {
+		jquantsClient = c.newJQuantsClient()
+		jquantsClient.SetQuotaManager(c.quotaManager)
+		c.fundamentalClient = jquantsClient
+		c.marketClient = jquantsClient
+		c.corporateEventClient = jquantsClient
+	}
+
+	// Google Calendar, created with the OAuth client alone so that 'calendar auth' can obtain the refresh token
+	if c.config.Calendar.GoogleClientID != "" && c.config.Calendar.GoogleClientSecret != "" {
+		c.googleCalendar = calendar.NewGoogleCalendar(calendar.GoogleConfig{
+			ClientID:     c.config.Calendar.GoogleClientID,
+			ClientSecret: c.config.Calendar.GoogleClientSecret,
+			RefreshToken: c.config.Calendar.GoogleRefreshToken,
+			CalendarID:   c.config.Calendar.CalendarID,
+			Timeout:      c.config.Calendar.Timeout,
+		})
+	}
+	stockDataClient, err := c.newStockDataClient(jquantsClient)
+	if err != nil {
+		return err
+	}
+	if quotaClient, ok := stockDataClient.(interface{ SetQuotaManager(*client.QuotaManager) }); ok {
+		quotaClient.SetQuotaManager(c.quotaManager)
+	}
+	c.stockDataClient = stockDataClient
+	c.fundClient = client.NewToushinClient(client.ToushinConfig{
+		BaseURL:      c.config.Toushin.BaseURL,
+		Timeout:      c.config.Toushin.Timeout,
+		RetryCount:   c.config.Toushin.RetryCount,
+		RateLimitRPS: c.config.Toushin.RateLimitRPS,
+	})
+	coinIDs, err := client.ParseCoinIDs(c.config.CoinGecko.CoinIDs)
+	if err != nil {
+		return err
+	}
+	c.cryptoClient = client.NewCoinGeckoClient(client.CoinGeckoConfig{
+		BaseURL:      c.config.CoinGecko.BaseURL,
+		APIKey:       c.config.CoinGecko.APIKey,
+		CoinIDs:      coinIDs,
+		Timeout:      c.config.CoinGecko.Timeout,
+		RetryCount:   c.config.CoinGecko.RetryCount,
+		RateLimitRPS: c.config.CoinGecko.RateLimitRPS,
+	})
+
+	// Broker clients
+	// The paper broker is always available for paper trading regardless of the broker type
+	c.paperBroker = broker.NewPaperBroker(
+		c.brokerOrderRepository,
+		c.stockRepository,
+		c.transactionManager,
+		c.config.Broker.PaperInitialCash,
+	)
+	brokerClient, err := c.newBrokerClient()
+	if err != nil {
+		return err
+	}
+	c.brokerClient = brokerClient
+
+	// Notification service, posting with the Web API when a bot token is set
 	slackNotifier := notification.NewSlackNotificationService(
 		c.config.Slack.WebhookURL,
 		c.config.Slack.Channel,
 		c.config.Slack.Username,
 	)
+	if c.config.Slack.UsesWebAPI() {
+		slackAPI := notification.NewSlackAPINotifier(c.config.Slack.BotToken, c.config.Slack.Channel, c.config.Slack.Username)
+		slackAPI.SetLogRepository(c.notificationLogRepository)
+		slackAPI.SetSendInterval(c.config.Slack.SendInterval)
+		slackAPI.SetThreadStore(c.threadRepository)
+		slackAPI.SetChannels(map[notification.MessageKind]string{
+			notification.KindCritical: c.config.Slack.CriticalChannel,
+			notification.KindReport:   c.config.Slack.ReportChannel,
+		})
+		slackNotifier = slackAPI
+		c.messageUpdater = slackAPI
+	}
 	// Set notification log repository if it's a SlackNotifier
 	if sn, ok := slackNotifier.(*notification.SlackNotifier); ok {
 		sn.SetLogRepository(c.notificationLogRepository)
+		sn.SetSendInterval(c.config.Slack.SendInterval)
+		sn.SetRoutes(map[notification.MessageKind]notification.Route{
+			notification.KindCritical: {WebhookURL: c.config.Slack.CriticalWebhookURL, Channel: c.config.Slack.CriticalChannel},
+			notification.KindReport:   {WebhookURL: c.config.Slack.ReportWebhookURL, Channel: c.config.Slack.ReportChannel},
+		})
 	}
 	c.notificationService = slackNotifier
 
+	// Generic webhook and Discord, notified in addition to Slack
+	notifiers := []notification.NotificationService{slackNotifier}
+	if c.config.Webhook.URL != "" {
+		webhookNotifier, err := c.newWebhookNotifier()
+		if err != nil {
+			return err
+		}
+		notifiers = append(notifiers, webhookNotifier)
+	}
+	if c.config.Discord.WebhookURL != "" {
+		notifiers = append(notifiers, notification.NewDiscordNotifier(c.config.Discord.WebhookURL, c.config.Discord.Username))
+	}
+	if len(notifiers) > 1 {
+		c.notificationService = notification.NewMultiNotifier(notifiers...)
+	}
+
+	// PDF reports can be emailed when SMTP is configured
+	if c.config.Email.Enabled() {
+		email := c.config.Email
+		c.emailSender = notification.NewEmailSender(email.SMTPHost, email.SMTPPort, email.SMTPUsername, email.SMTPPassword, email.From, email.Recipients())
+	}
+
+	// Alerts actually sent are published on the event bus, so this wraps the notifiers before
+	// the maintenance notifier holds alerts back
+	c.notificationService = notification.NewEventNotifier(c.notificationService, c.eventBus)
+
+	// Alerts are held back during maintenance windows
+	c.notificationService = notification.NewMaintenanceNotifier(c.notificationService, c.maintenanceRepository)
+
+	// Yahoo Finance API changes otherwise silently result in no data, and the responses that
+	// cannot be parsed are kept for `debug replay`
+	if yahooClient, ok := c.stockDataClient.(*client.YahooFinanceClient); ok {
+		yahooClient.SetSchemaAlertHandler(c.alertSchemaChange)
+		yahooClient.SetRawResponseStore(c.rawResponseRepository)
+	}
+	c.quotaManager.SetAlertHandler(c.alertQuota)
+
 	return nil
 }
 
+// alertQuota sends a critical alert when the daily quota of a data provider runs low or is used up
+func (c *Container) alertQuota(ctx context.Context, usage client.QuotaUsage) {
+	resetAt := usage.ResetAt.Format("2006-01-02 15:04")
+	message := i18n.T("data_source.quota_warning", usage.Provider, usage.Percent(), usage.Used, usage.Limit, usage.Remaining(), resetAt)
+	if usage.Exhausted() {
+		message = i18n.T("data_source.quota_exhausted", usage.Provider, usage.Limit, resetAt)
+	}
+	if err := c.notificationService.SendMessageOfKind(ctx, notification.KindCritical, message); err != nil {
+		logrus.Errorf("Failed to send quota alert: %v", err)
+	}
+}
+
+// alertSchemaChange sends a critical alert when a data source response does not match the expected schema
+func (c *Container) alertSchemaChange(ctx context.Context, schemaErr *client.SchemaError) {
+	message := i18n.T("data_source.schema_changed", schemaErr.Endpoint, "- "+strings.Join(schemaErr.Problems, "\n- "))
+	if err := c.notificationService.SendMessageOfKind(ctx, notification.KindCritical, message); err != nil {
+		logrus.Errorf("Failed to send schema change alert: %v", err)
+	}
+}
+
+// newStockDataClient creates the stock data client selected by the data source type
+func (c *Container) newStockDataClient(jquantsClient *client.JQuantsClient) (client.StockDataClient, error) {
+	switch c.config.DataSource.Type {
+	case client.SourceYahoo:
+		return client.NewYahooFinanceClientWithConfig(client.YahooFinanceConfig{
+			BaseURL:       c.config.Yahoo.BaseURL,
+			Timeout:       c.config.Yahoo.Timeout,
+			RetryCount:    c.config.Yahoo.RetryCount,
+			RetryWaitTime: c.config.Yahoo.RetryWaitTime,
+			RetryMaxWait:  c.config.Yahoo.RetryMaxWait,
+			UserAgent:     c.config.Yahoo.UserAgent,
+			RateLimitRPS:  c.config.Yahoo.RateLimitRPS,
+
+			AdaptiveRateLimit:     c.config.Yahoo.AdaptiveRateLimit,
+			MinRateLimitRPS:       c.config.Yahoo.MinRateLimitRPS,
+			RateRecoverySuccesses: c.config.Yahoo.RateRecoverySuccesses,
+		}), nil
+	case client.SourceStooq:
+		stooqConfig := client.DefaultStooqConfig()
+		stooqConfig.BaseURL = c.config.Stooq.BaseURL
+		stooqConfig.Timeout = c.config.Stooq.Timeout
+		stooqConfig.RetryCount = c.config.Stooq.RetryCount
+		stooqConfig.RateLimitRPS = c.config.Stooq.RateLimitRPS
+		return client.NewStooqClient(stooqConfig), nil
+	case client.SourceJQuants:
+		if jquantsClient == nil {
+			return nil, fmt.Errorf("data source jquants requires JQUANTS_REFRESH_TOKEN or JQUANTS_MAIL_ADDRESS and JQUANTS_PASSWORD")
+		}
+		return jquantsClient, nil
+	default:
+		return nil, fmt.Errorf("unknown data source type: %s", c.config.DataSource.Type)
+	}
+}
+
+// loadFeatureFlagFile reads the feature flag file, which sets no flags if it does not exist
+func loadFeatureFlagFile(path string) (*domain.FeatureFlagFile, error) {
+	if path == "" {
+		return &domain.FeatureFlagFile{}, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &domain.FeatureFlagFile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature flag file: %w", err)
+	}
+	file, err := domain.ParseFeatureFlagFile(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// loadRetentionPolicy reads the retention policy file. Without the file the default policy is used.
+func loadRetentionPolicy(path string) (*domain.RetentionPolicy, error) {
+	if path == "" {
+		return domain.DefaultRetentionPolicy(), nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.DefaultRetentionPolicy(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retention policy file: %w", err)
+	}
+	policy, err := domain.ParseRetentionPolicy(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// newJQuantsClient creates the J-Quants client shared by stock data and financial indicators
+func (c *Container) newJQuantsClient() *client.JQuantsClient {
+	jquantsConfig := client.DefaultJQuantsConfig()
+	jquantsConfig.BaseURL = c.config.JQuants.BaseURL
+	jquantsConfig.MailAddress = c.config.JQuants.MailAddress
+	jquantsConfig.Password = c.config.JQuants.Password
+	jquantsConfig.RefreshToken = c.config.JQuants.RefreshToken
+	jquantsConfig.Timeout = c.config.JQuants.Timeout
+	jquantsConfig.RateLimitRPS = c.config.JQuants.RateLimitRPS
+	return client.NewJQuantsClient(jquantsConfig)
+}
+
+// newWebhookNotifier creates the generic webhook notifier with the payload template file if configured
+func (c *Container) newWebhookNotifier() (*notification.WebhookNotifier, error) {
+	var payloadTemplate string
+	if c.config.Webhook.PayloadTemplateFile != "" {
+		b, err := os.ReadFile(c.config.Webhook.PayloadTemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook payload template: %w", err)
+		}
+		payloadTemplate = string(b)
+	}
+
+	return notification.NewWebhookNotifier(notification.WebhookConfig{
+		URL:             c.config.Webhook.URL,
+		Secret:          c.config.Webhook.Secret,
+		PayloadTemplate: payloadTemplate,
+		Timeout:         c.config.Webhook.Timeout,
+		MaxRetries:      c.config.Webhook.MaxRetries,
+	})
+}
+
+// newBrokerClient creates the broker client selected by the broker type
+func (c *Container) newBrokerClient() (broker.BrokerClient, error) {
+	switch c.config.Broker.Type {
+	case broker.TypePaper:
+		return c.paperBroker, nil
+	default:
+		return nil, fmt.Errorf("unknown broker type: %s", c.config.Broker.Type)
+	}
+}
+
 // initializeDomain sets up the domain layer services
 func (c *Container) initializeDomain() {
 	c.portfolioService = domain.NewPortfolioService()
@@ -121,31 +557,362 @@ func (c *Container) initializeDomain() {
 
 // initializeUseCases sets up the use case layer
 func (c *Container) initializeUseCases() {
+	c.featureFlagUseCase = usecase.NewFeatureFlagUseCase(
+		c.featureFlagRepository,
+		c.config.Features.Environment,
+		c.featureFlagFile.Flags(c.config.Features.Environment),
+		c.config.Features.CacheTTL,
+	)
+
+	sourcePriority := domain.ParsePriceSourcePriority(c.config.DataSource.Priority)
+
 	c.collectDataUseCase = usecase.NewCollectDataUseCase(
 		c.stockRepository,
 		c.portfolioRepository,
 		c.stockDataClient,
+		sourcePriority,
+		func() float64 { return c.quotaManager.IntervalMultiplier(c.config.DataSource.Type) },
+	)
+	c.collectDataUseCase.SetMarketHours(c.marketHours)
+	c.collectDataUseCase.SetAlertNotifier(c.notificationService)
+	c.collectDataUseCase.SetEventPublisher(c.eventBus)
+	c.collectDataUseCase.SetQuarantine(
+		c.collectFailureRepository,
+		c.config.DataSource.QuarantineThreshold,
+		c.config.DataSource.QuarantinePeriod,
+	)
+
+	if c.config.DataSource.PriceMoveNotifyPercent > 0 {
+		c.priceMoveUseCase = usecase.NewPriceMoveNotificationUseCase(
+			c.stockRepository,
+			c.portfolioRepository,
+			c.notificationService,
+			c.config.DataSource.PriceMoveNotifyPercent,
+		)
+		c.eventBus.Subscribe(domain.EventPriceUpdated, "price-moves",
+			c.featureFlagUseCase.Gate(domain.FeaturePriceMoveNotify, c.priceMoveUseCase.HandlePriceUpdated))
+	}
+
+	c.bulkCollectUseCase = usecase.NewBulkCollectUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.stockDataClient,
+		sourcePriority,
+	)
+	c.bulkCollectUseCase.SetAlertNotifier(c.notificationService)
+
+	c.priceReconcileUseCase = usecase.NewPriceReconciliationUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.stockDataClient,
+		sourcePriority,
+	)
+
+	c.targetSyncUseCase = usecase.NewCollectTargetSyncUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.bulkCollectUseCase,
+		c.collectDataUseCase,
+	)
+
+	c.portfolioUseCase = usecase.NewPortfolioUseCase(
+		c.portfolioRepository,
+		c.portfolioLotRepository,
+		c.portfolioService,
+		c.transactionManager,
+		domain.CostMethod(c.config.Portfolio.CostMethod),
 	)
 
 	c.portfolioReportUseCase = usecase.NewPortfolioReportUseCase(
 		c.stockRepository,
 		c.portfolioRepository,
+		c.goalRepository,
+		c.snapshotRepository,
 		c.stockDataClient,
 		c.notificationService,
 	)
+	c.portfolioReportUseCase.SetTargetCashPercent(c.config.Portfolio.TargetCashPercent)
+	c.portfolioReportUseCase.SetReportPreferences(c.reportPrefRepository)
+	c.portfolioReportUseCase.SetTradeNotes(c.tradeNoteRepository)
+	c.reportPrefUseCase = usecase.NewReportPreferenceUseCase(c.reportPrefRepository)
 
 	c.technicalAnalysisUseCase = usecase.NewTechnicalAnalysisUseCase(
 		c.stockRepository,
+		c.strategyProfileRepository,
+		c.stockDataClient,
+	)
+	c.technicalAnalysisUseCase.SetBusinessDay(c.businessDay)
+	c.technicalAnalysisUseCase.SetConcurrency(c.config.Technical.Workers, c.config.Technical.BatchSize)
+	if c.config.DataSource.MADeviationAlertPercent > 0 {
+		c.maDeviationUseCase = usecase.NewMADeviationAlertUseCase(
+			c.technicalAnalysisUseCase,
+			c.stockRepository,
+			c.portfolioRepository,
+			c.notificationService,
+			c.config.DataSource.MADeviationAlertPercent,
+		)
+		c.eventBus.Subscribe(domain.EventPriceUpdated, "ma-deviation",
+			c.featureFlagUseCase.Gate(domain.FeatureMADeviationAlert, c.maDeviationUseCase.HandlePriceUpdated))
+	}
+
+	c.hedgeAdviceUseCase = usecase.NewHedgeAdviceUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.adviceLogRepository,
+		c.notificationService,
+		domain.HedgeAdviceRules{
+			DropPercent:        c.config.Hedge.DropPercent,
+			InverseETFCode:     c.config.Hedge.InverseETFCode,
+			HedgeRatioPercent:  c.config.Hedge.HedgeRatioPercent,
+			MinExposurePercent: c.config.Hedge.MinExposurePercent,
+			TargetCashPercent:  c.config.Hedge.TargetCashPercent,
+		},
+	)
+	if c.config.Hedge.DropPercent > 0 {
+		c.eventBus.Subscribe(domain.EventPriceUpdated, "hedge-advice",
+			c.featureFlagUseCase.Gate(domain.FeatureHedgeAdvice, c.hedgeAdviceUseCase.HandlePriceUpdated))
+	}
+	c.portfolioReportUseCase.SetTechnicalAnalysis(c.technicalAnalysisUseCase)
+
+	c.strategyProfileUseCase = usecase.NewStrategyProfileUseCase(
+		c.strategyProfileRepository,
+		c.stockRepository,
+		c.portfolioRepository,
+		c.transactionManager,
+	)
+
+	c.watchListUseCase = usecase.NewWatchListUseCase(
+		c.stockRepository,
+		c.transactionManager,
+	)
+
+	c.stockInspectionUseCase = usecase.NewStockInspectionUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.technicalAnalysisUseCase,
+	)
+	c.stockInspectionUseCase.SetNicknameRepository(c.nicknameRepository)
+
+	c.priceChartUseCase = usecase.NewPriceChartUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.notificationService,
+	)
+
+	c.scoringUseCase = usecase.NewScoringUseCase(
+		c.stockRepository,
+		c.fundamentalRepository,
+		c.technicalAnalysisUseCase,
+		c.notificationService,
+		domain.ScoringWeights{
+			Technical:   c.config.Scoring.TechnicalWeight,
+			Fundamental: c.config.Scoring.FundamentalWeight,
+		},
+	)
+
+	c.discoveryUseCase = usecase.NewDiscoveryUseCase(
+		c.marketClient,
+		c.stockRepository,
+		c.portfolioRepository,
+		c.watchListUseCase,
+		c.notificationService,
+		domain.DiscoveryCriteria{
+			LookbackDays:     c.config.Discovery.LookbackDays,
+			RecentDays:       c.config.Discovery.RecentDays,
+			VolumeSurgeRatio: c.config.Discovery.VolumeSurgeRatio,
+			MinAverageVolume: c.config.Discovery.MinAverageVolume,
+			MaxCandidates:    c.config.Discovery.MaxCandidates,
+		},
+		c.config.Discovery.AutoAdd,
+	)
+
+	// The calendar is left nil unless the refresh token is configured as well
+	var syncCalendar calendar.Calendar
+	if c.config.Calendar.Enabled() {
+		syncCalendar = c.googleCalendar
+	}
+	c.calendarSyncUseCase = usecase.NewCalendarSyncUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.corporateEventClient,
+		syncCalendar,
+		c.config.Calendar.SyncDays,
+	)
+
+	c.fundPriceUseCase = usecase.NewFundPriceUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.fundClient,
+	)
+
+	c.cryptoPriceUseCase = usecase.NewCryptoPriceUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.cryptoClient,
+	)
+
+	c.marketIndexUseCase = usecase.NewMarketIndexUseCase(
+		c.stockRepository,
+		c.stockDataClient,
+		c.marketIndices,
+	)
+	c.portfolioReportUseCase.SetMarketIndices(c.marketIndexUseCase, c.config.Portfolio.Benchmark)
+
+	c.morningBriefingUseCase = usecase.NewMorningBriefingUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.marketIndexUseCase,
+		c.corporateEventClient,
+		c.notificationService,
+	)
+
+	c.exitTargetUseCase = usecase.NewExitTargetUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.exitTargetRepository,
+		c.notificationService,
+		c.transactionManager,
+	)
+
+	c.portfolioHistoryUseCase = usecase.NewPortfolioHistoryUseCase(
+		c.snapshotRepository,
+		c.portfolioReportUseCase,
+	)
+
+	c.alertRuleUseCase = usecase.NewAlertRuleUseCase(
+		c.alertRuleRepository,
+		c.stockRepository,
+		c.technicalAnalysisUseCase,
+		c.notificationService,
+		c.transactionManager,
+	)
+	c.alertRuleUseCase.SetTradeNotes(c.tradeNoteRepository)
+	c.tradeNoteUseCase = usecase.NewTradeNoteUseCase(c.tradeNoteRepository)
+
+	c.auditLogUseCase = usecase.NewAuditLogUseCase(
+		c.auditLogRepository,
+	)
+
+	c.paperTradeUseCase = usecase.NewPaperTradeUseCase(
+		c.stockRepository,
+		c.technicalAnalysisUseCase,
+		c.portfolioReportUseCase,
+		c.paperBroker,
+		c.notificationService,
+		c.config.Broker.PaperOrderAmount,
+	)
+	c.paperTradeUseCase.SetEventPublisher(c.eventBus)
+
+	c.yahooDiagnosticsUseCase = usecase.NewYahooDiagnosticsUseCase(
 		c.stockDataClient,
 	)
+
+	c.seedUseCase = usecase.NewSeedUseCase(c.stockRepository)
+
+	c.dataQualityUseCase = usecase.NewDataQualityUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.notificationService,
+	)
+	c.dataQualityUseCase.SetBusinessDay(c.businessDay)
+	c.dataQualityUseCase.SetPriceFeedMinPercent(c.config.DataSource.PriceFeedMinPercent)
+	c.dataQualityUseCase.SetCollectFailures(c.collectFailureRepository)
+
+	c.priceAggregationUseCase = usecase.NewPriceAggregationUseCase(
+		c.stockRepository,
+		c.portfolioRepository,
+		c.aggregatedPriceRepository,
+	)
+
+	c.maintenanceUseCase = usecase.NewMaintenanceUseCase(
+		c.maintenanceRepository,
+		c.notificationService,
+	)
+
+	c.schemaMigrationUseCase = usecase.NewSchemaMigrationUseCase(
+		c.schemaMigrationRepository,
+		c.transactionManager,
+		c.schemaMigrationPhases,
+	)
+
+	c.goalTrackingUseCase = usecase.NewGoalTrackingUseCase(
+		c.goalRepository,
+		c.portfolioReportUseCase,
+		c.notificationService,
+	)
+	c.savingsPlanUseCase = usecase.NewSavingsPlanUseCase(c.snapshotRepository)
+
+	c.cashUseCase = usecase.NewCashUseCase(
+		c.portfolioRepository,
+		c.cashRepository,
+		c.transactionManager,
+		c.config.Portfolio.CashCode,
+	)
+
+	c.nicknameUseCase = usecase.NewStockNicknameUseCase(
+		c.nicknameRepository,
+		c.stockRepository,
+		c.portfolioRepository,
+	)
+
+	c.rawResponseUseCase = usecase.NewRawResponseUseCase(c.rawResponseRepository)
+
+	c.retentionUseCase = usecase.NewRetentionUseCase(c.retentionRepository, c.transactionManager, c.retentionPolicy)
+	if c.stockWriteBuffer != nil {
+		c.retentionUseCase.SetWriteBuffer(c.stockWriteBuffer)
+	}
+
+	// Share links point at the server, on localhost unless its public address is set
+	shareBaseURL := c.config.Server.ShareBaseURL
+	if shareBaseURL == "" {
+		shareBaseURL = fmt.Sprintf("http://localhost:%d", c.config.Server.Port)
+	}
+	c.shareLinkUseCase = usecase.NewShareLinkUseCase(
+		c.shareLinkRepository,
+		c.portfolioReportUseCase,
+		c.config.Server.ShareSecret,
+		shareBaseURL,
+	)
 }
 
 // initializeInterfaces sets up the interface layer
 func (c *Container) initializeInterfaces() {
 	c.scheduler = NewDataScheduler(
 		c.collectDataUseCase,
+		c.targetSyncUseCase,
+		c.technicalAnalysisUseCase,
 		c.portfolioReportUseCase,
+		c.dataQualityUseCase,
+		c.scoringUseCase,
+		c.discoveryUseCase,
+		c.calendarSyncUseCase,
+		c.fundPriceUseCase,
+		c.cryptoPriceUseCase,
+		c.marketIndexUseCase,
+		c.morningBriefingUseCase,
+		c.exitTargetUseCase,
+		c.portfolioHistoryUseCase,
+		c.alertRuleUseCase,
+		c.paperTradeUseCase,
+		c.maintenanceUseCase,
+		c.priceAggregationUseCase,
+		c.goalTrackingUseCase,
+		c.retentionUseCase,
+		c.marketHours,
+		c.scheduleLocation,
+		c.jobLocker,
+		c.config.Scheduler,
 	)
+	c.scheduler.SetFeatureFlags(c.featureFlagUseCase)
+	c.scheduler.SetNotifier(c.notificationService)
+	if c.messageUpdater != nil {
+		c.scheduler.SetStatusUpdater(c.messageUpdater, c.config.Slack.StatusJobNames())
+	}
+}
+
+// GetConfig returns the application configuration
+func (c *Container) GetConfig() *config.Config {
+	return c.config
 }
 
 // GetConnectionManager returns the database connection manager
@@ -168,6 +935,16 @@ func (c *Container) GetCollectDataUseCase() *usecase.CollectDataUseCase {
 	return c.collectDataUseCase
 }
 
+// GetBulkCollectUseCase returns the bulk collection use case
+func (c *Container) GetBulkCollectUseCase() *usecase.BulkCollectUseCase {
+	return c.bulkCollectUseCase
+}
+
+// GetPortfolioUseCase returns the portfolio use case
+func (c *Container) GetPortfolioUseCase() *usecase.PortfolioUseCase {
+	return c.portfolioUseCase
+}
+
 // GetPortfolioReportUseCase returns the portfolio report use case
 func (c *Container) GetPortfolioReportUseCase() *usecase.PortfolioReportUseCase {
 	return c.portfolioReportUseCase
@@ -178,6 +955,216 @@ func (c *Container) GetTechnicalAnalysisUseCase() *usecase.TechnicalAnalysisUseC
 	return c.technicalAnalysisUseCase
 }
 
+// GetStrategyProfileUseCase returns the strategy profile use case
+func (c *Container) GetStrategyProfileUseCase() *usecase.StrategyProfileUseCase {
+	return c.strategyProfileUseCase
+}
+
+// GetDataQualityUseCase returns the data quality use case
+func (c *Container) GetDataQualityUseCase() *usecase.DataQualityUseCase {
+	return c.dataQualityUseCase
+}
+
+// GetWatchListUseCase returns the watch list use case
+func (c *Container) GetWatchListUseCase() *usecase.WatchListUseCase {
+	return c.watchListUseCase
+}
+
+// GetStockInspectionUseCase returns the stock inspection use case
+func (c *Container) GetStockInspectionUseCase() *usecase.StockInspectionUseCase {
+	return c.stockInspectionUseCase
+}
+
+// GetPriceChartUseCase returns the price chart use case
+func (c *Container) GetPriceChartUseCase() *usecase.PriceChartUseCase {
+	return c.priceChartUseCase
+}
+
+// GetFeatureFlagUseCase returns the feature flag use case
+func (c *Container) GetFeatureFlagUseCase() *usecase.FeatureFlagUseCase {
+	return c.featureFlagUseCase
+}
+
+// GetScoringUseCase returns the scoring use case
+func (c *Container) GetScoringUseCase() *usecase.ScoringUseCase {
+	return c.scoringUseCase
+}
+
+// GetDiscoveryUseCase returns the watch list discovery use case
+func (c *Container) GetDiscoveryUseCase() *usecase.DiscoveryUseCase {
+	return c.discoveryUseCase
+}
+
+// GetHedgeAdviceUseCase returns the hedge advice use case
+func (c *Container) GetHedgeAdviceUseCase() *usecase.HedgeAdviceUseCase {
+	return c.hedgeAdviceUseCase
+}
+
+// GetCalendarSyncUseCase returns the calendar sync use case of the corporate events
+func (c *Container) GetCalendarSyncUseCase() *usecase.CalendarSyncUseCase {
+	return c.calendarSyncUseCase
+}
+
+// GetGoogleCalendar returns the Google Calendar client, nil if the OAuth client is not configured
+func (c *Container) GetGoogleCalendar() *calendar.GoogleCalendar {
+	return c.googleCalendar
+}
+
+// GetFundPriceUseCase returns the fund price use case
+func (c *Container) GetFundPriceUseCase() *usecase.FundPriceUseCase {
+	return c.fundPriceUseCase
+}
+
+// GetCryptoPriceUseCase returns the crypto price use case
+func (c *Container) GetCryptoPriceUseCase() *usecase.CryptoPriceUseCase {
+	return c.cryptoPriceUseCase
+}
+
+// GetMarketIndexUseCase returns the market index use case
+func (c *Container) GetMarketIndexUseCase() *usecase.MarketIndexUseCase {
+	return c.marketIndexUseCase
+}
+
+// GetMorningBriefingUseCase returns the morning briefing use case
+func (c *Container) GetMorningBriefingUseCase() *usecase.MorningBriefingUseCase {
+	return c.morningBriefingUseCase
+}
+
+// GetExitTargetUseCase returns the exit target use case
+func (c *Container) GetExitTargetUseCase() *usecase.ExitTargetUseCase {
+	return c.exitTargetUseCase
+}
+
+// GetPortfolioHistoryUseCase returns the portfolio history use case
+func (c *Container) GetPortfolioHistoryUseCase() *usecase.PortfolioHistoryUseCase {
+	return c.portfolioHistoryUseCase
+}
+
+// GetAlertRuleUseCase returns the alert rule use case
+func (c *Container) GetAlertRuleUseCase() *usecase.AlertRuleUseCase {
+	return c.alertRuleUseCase
+}
+
+// GetAuditLogUseCase returns the audit log use case
+func (c *Container) GetAuditLogUseCase() *usecase.AuditLogUseCase {
+	return c.auditLogUseCase
+}
+
+// GetPaperTradeUseCase returns the paper trade use case
+func (c *Container) GetPaperTradeUseCase() *usecase.PaperTradeUseCase {
+	return c.paperTradeUseCase
+}
+
+// GetYahooDiagnosticsUseCase returns the Yahoo Finance diagnostics use case
+func (c *Container) GetYahooDiagnosticsUseCase() *usecase.YahooDiagnosticsUseCase {
+	return c.yahooDiagnosticsUseCase
+}
+
+// GetSeedUseCase returns the synthetic data use case
+func (c *Container) GetSeedUseCase() *usecase.SeedUseCase {
+	return c.seedUseCase
+}
+
+// GetMaintenanceUseCase returns the maintenance mode use case
+func (c *Container) GetMaintenanceUseCase() *usecase.MaintenanceUseCase {
+	return c.maintenanceUseCase
+}
+
+// GetEventBus returns the in-process event bus
+func (c *Container) GetEventBus() *eventbus.Bus {
+	return c.eventBus
+}
+
+// GetEventStats returns the statistics of the events published on the event bus
+func (c *Container) GetEventStats() *eventbus.Stats {
+	return c.eventStats
+}
+
+// GetSchemaMigrationUseCase returns the zero-downtime schema migration use case
+func (c *Container) GetSchemaMigrationUseCase() *usecase.SchemaMigrationUseCase {
+	return c.schemaMigrationUseCase
+}
+
+// GetPriceAggregationUseCase returns the weekly and monthly price aggregation use case
+func (c *Container) GetPriceAggregationUseCase() *usecase.PriceAggregationUseCase {
+	return c.priceAggregationUseCase
+}
+
+// GetPriceReconciliationUseCase returns the price reconciliation use case
+func (c *Container) GetPriceReconciliationUseCase() *usecase.PriceReconciliationUseCase {
+	return c.priceReconcileUseCase
+}
+
+// GetGoalTrackingUseCase returns the goal tracking use case
+func (c *Container) GetGoalTrackingUseCase() *usecase.GoalTrackingUseCase {
+	return c.goalTrackingUseCase
+}
+
+// GetSavingsPlanUseCase returns the savings plan use case
+func (c *Container) GetSavingsPlanUseCase() *usecase.SavingsPlanUseCase {
+	return c.savingsPlanUseCase
+}
+
+// GetCashUseCase returns the cash deposit and withdrawal use case
+func (c *Container) GetCashUseCase() *usecase.CashUseCase {
+	return c.cashUseCase
+}
+
+// GetStockNicknameUseCase returns the stock nickname use case
+func (c *Container) GetStockNicknameUseCase() *usecase.StockNicknameUseCase {
+	return c.nicknameUseCase
+}
+
+// GetRawResponseUseCase returns the use case of the responses kept for debugging
+func (c *Container) GetRawResponseUseCase() *usecase.RawResponseUseCase {
+	return c.rawResponseUseCase
+}
+
+// GetRetentionUseCase returns the use case of the cleanup following the retention policy
+func (c *Container) GetRetentionUseCase() *usecase.RetentionUseCase {
+	return c.retentionUseCase
+}
+
+// GetShareLinkUseCase returns the use case of the links sharing the report on the web
+func (c *Container) GetShareLinkUseCase() *usecase.ShareLinkUseCase {
+	return c.shareLinkUseCase
+}
+
+// GetReportPreferenceUseCase returns the use case of the settings turning the report sections on or off
+func (c *Container) GetReportPreferenceUseCase() *usecase.ReportPreferenceUseCase {
+	return c.reportPrefUseCase
+}
+
+// GetTradeNoteUseCase returns the use case of the notes on the trade ideas of the stocks
+func (c *Container) GetTradeNoteUseCase() *usecase.TradeNoteUseCase {
+	return c.tradeNoteUseCase
+}
+
+// GetQuotaManager returns the daily request quotas of the data providers
+func (c *Container) GetQuotaManager() *client.QuotaManager {
+	return c.quotaManager
+}
+
+// GetEmailSender returns the email sender of the reports, or nil if SMTP is not configured
+func (c *Container) GetEmailSender() *notification.EmailSender {
+	return c.emailSender
+}
+
+// GetBrokerClient returns the broker client
+func (c *Container) GetBrokerClient() broker.BrokerClient {
+	return c.brokerClient
+}
+
+// GetFundamentalClient returns the financial indicator client, nil if no J-Quants credentials are configured
+func (c *Container) GetFundamentalClient() client.FundamentalDataClient {
+	return c.fundamentalClient
+}
+
+// GetJobLocker returns the job locker
+func (c *Container) GetJobLocker() repository.JobLocker {
+	return c.jobLocker
+}
+
 // GetScheduler returns the data scheduler
 func (c *Container) GetScheduler() *DataScheduler {
 	return c.scheduler
@@ -189,6 +1176,15 @@ func (c *Container) Close() error {
 		c.scheduler.Stop()
 	}
 
+	// Buffered price writes are flushed while the connection is still open
+	if c.stockWriteBuffer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := c.stockWriteBuffer.Close(ctx); err != nil {
+			logrus.Errorf("Failed to flush buffered price writes: %v", err)
+		}
+	}
+
 	if c.connectionManager != nil {
 		return c.connectionManager.Close()
 	}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Default batching of the loaders. Resolvers of one query run concurrently, so a short wait is
+// enough to collect the keys of all fields of a list into one fetch.
+const (
+	DefaultLoaderWait     = 2 * time.Millisecond
+	DefaultLoaderMaxBatch = 200
+)
+
+// BatchFunc fetches the values of the keys in one query. Keys absent from the result have no value.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys loaded within a short wait and fetches them with one call, so that
+// resolving a field on each item of a list does not run a query per item (N+1). Results are
+// cached for the lifetime of the loader, which is one request.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	cache   map[K]*loaderResult[V]
+	pending *loaderBatch[K, V]
+}
+
+// loaderResult is the value of a key, available once done is closed.
+type loaderResult[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+// loaderBatch is the keys waiting to be fetched together.
+type loaderBatch[K comparable, V any] struct {
+	keys       []K
+	results    []*loaderResult[V]
+	dispatched bool
+}
+
+// NewLoader creates a loader fetching the keys collected within wait, at most maxBatch at a time.
+func NewLoader[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	if wait <= 0 {
+		wait = DefaultLoaderWait
+	}
+	if maxBatch <= 0 {
+		maxBatch = DefaultLoaderMaxBatch
+	}
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*loaderResult[V]),
+	}
+}
+
+// Load returns the value of the key, waiting for the batch it joins to be fetched.
+// The batch is fetched with the context of its first key.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	result, ok := l.cache[key]
+	if !ok {
+		result = &loaderResult[V]{done: make(chan struct{})}
+		l.cache[key] = result
+
+		batch := l.pending
+		if batch == nil {
+			batch = &loaderBatch[K, V]{}
+			l.pending = batch
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx, batch) })
+		}
+		batch.keys = append(batch.keys, key)
+		batch.results = append(batch.results, result)
+		if len(batch.keys) >= l.maxBatch {
+			l.pending = nil
+			go l.dispatch(ctx, batch)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch fetches the keys of a batch once, either when its wait ends or when it is full.
+func (l *Loader[K, V]) dispatch(ctx context.Context, batch *loaderBatch[K, V]) {
+	l.mu.Lock()
+	if batch.dispatched {
+		l.mu.Unlock()
+		return
+	}
+	batch.dispatched = true
+	if l.pending == batch {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, batch.keys)
+	for i, key := range batch.keys {
+		result := batch.results[i]
+		if err != nil {
+			result.err = err
+		} else {
+			result.value = values[key]
+		}
+		close(result.done)
+	}
+}
@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoader_BatchesConcurrentLoads(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	loader := NewLoader(func(ctx context.Context, keys []string) (map[string]int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batch := append([]string(nil), keys...)
+		sort.Strings(batch)
+		batches = append(batches, batch)
+		return map[string]int{"1111": 1, "2222": 2}, nil
+	}, 10*time.Millisecond, 0)
+
+	ctx := context.Background()
+	codes := []string{"1111", "2222", "3333", "1111"}
+	got := make([]int, len(codes))
+	var wg sync.WaitGroup
+	for i, code := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := loader.Load(ctx, code)
+			if err != nil {
+				t.Errorf("Load(%s) error = %v", code, err)
+			}
+			got[i] = value
+		}()
+	}
+	wg.Wait()
+
+	if diff := cmp.Diff([]int{1, 2, 0, 1}, got); diff != "" {
+		t.Errorf("Load() values mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([][]string{{"1111", "2222", "3333"}}, batches); diff != "" {
+		t.Errorf("fetched batches mismatch (-want +got):\n%s", diff)
+	}
+
+	// Loaded keys are cached
+	if value, _ := loader.Load(ctx, "2222"); value != 2 || len(batches) != 1 {
+		t.Errorf("Load() of a cached key = %d after %d fetches, want 2 without a fetch", value, len(batches))
+	}
+}
+
+func TestLoader_MaxBatchAndErrors(t *testing.T) {
+	fetchErr := errors.New("db down")
+	var mu sync.Mutex
+	var sizes []int
+	loader := NewLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		mu.Lock()
+		sizes = append(sizes, len(keys))
+		mu.Unlock()
+		return nil, fetchErr
+	}, time.Hour, 2)
+
+	// A full batch is fetched without waiting
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := loader.Load(context.Background(), i); !errors.Is(err, fetchErr) {
+				t.Errorf("Load(%d) error = %v, want the fetch error", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if diff := cmp.Diff([]int{2}, sizes); diff != "" {
+		t.Errorf("fetched batch sizes mismatch (-want +got):\n%s", diff)
+	}
+
+	// A canceled request stops waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := loader.Load(ctx, 3); !errors.Is(err, context.Canceled) {
+		t.Errorf("Load() with a canceled context error = %v", err)
+	}
+}
+
+func TestLoaders_PriceHistoryByDays(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	stockRepo := &mock.StockRepositoryMock{
+		GetPriceHistoriesFunc: func(ctx context.Context, codes []string, from time.Time) (map[string][]*models.StockPrice, error) {
+			histories := make(map[string][]*models.StockPrice)
+			for _, code := range codes {
+				histories[code] = []*models.StockPrice{{Code: code, Date: from}}
+			}
+			return histories, nil
+		},
+	}
+
+	keys := []PriceHistoryKey{{Code: "1111", Days: 30}, {Code: "2222", Days: 30}, {Code: "1111", Days: 5}}
+	histories, err := loadPriceHistories(context.Background(), stockRepo, keys, now)
+	if err != nil {
+		t.Fatalf("loadPriceHistories() error = %v", err)
+	}
+	if calls := len(stockRepo.GetPriceHistoriesCalls()); calls != 2 {
+		t.Errorf("GetPriceHistories called %d times, want once per number of days", calls)
+	}
+	for _, key := range keys {
+		want := now.AddDate(0, 0, -key.Days)
+		if history := histories[key]; len(history) != 1 || !history[0].Date.Equal(want) {
+			t.Errorf("history of %+v = %v, want from %s", key, history, want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package graph
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// NewHandler creates the HTTP handler of the GraphQL API, accepting queries by GET and POST.
+// Each request is given its own loaders, so that the fields of a list are read in batches.
+func NewHandler(resolver *Resolver) http.Handler {
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	return Middleware(resolver.StockRepo, srv)
+}
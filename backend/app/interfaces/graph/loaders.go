@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+)
+
+// PriceHistoryKey is the key of the price history of a stock over the last days.
+type PriceHistoryKey struct {
+	Code string
+	Days int
+}
+
+// Loaders batches the reads of the stock fields resolved for each item of a list, such as the
+// latest price of every holding of the portfolio.
+type Loaders struct {
+	LatestPrice  *Loader[string, *models.StockPrice]
+	PriceHistory *Loader[PriceHistoryKey, []*models.StockPrice]
+	Indicator    *Loader[string, *models.TechnicalIndicator]
+}
+
+// NewLoaders creates the loaders of one request.
+func NewLoaders(stockRepo repository.StockRepository) *Loaders {
+	return &Loaders{
+		LatestPrice: NewLoader(stockRepo.GetLatestPrices, DefaultLoaderWait, DefaultLoaderMaxBatch),
+		PriceHistory: NewLoader(func(ctx context.Context, keys []PriceHistoryKey) (map[PriceHistoryKey][]*models.StockPrice, error) {
+			return loadPriceHistories(ctx, stockRepo, keys, time.Now())
+		}, DefaultLoaderWait, DefaultLoaderMaxBatch),
+		Indicator: NewLoader(stockRepo.GetLatestTechnicalIndicators, DefaultLoaderWait, DefaultLoaderMaxBatch),
+	}
+}
+
+// loadPriceHistories reads the histories with one query per number of days requested.
+func loadPriceHistories(
+	ctx context.Context,
+	stockRepo repository.StockRepository,
+	keys []PriceHistoryKey,
+	now time.Time,
+) (map[PriceHistoryKey][]*models.StockPrice, error) {
+	codesByDays := make(map[int][]string)
+	for _, key := range keys {
+		codesByDays[key.Days] = append(codesByDays[key.Days], key.Code)
+	}
+
+	histories := make(map[PriceHistoryKey][]*models.StockPrice, len(keys))
+	for days, codes := range codesByDays {
+		prices, err := stockRepo.GetPriceHistories(ctx, codes, now.AddDate(0, 0, -days))
+		if err != nil {
+			return nil, err
+		}
+		for code, history := range prices {
+			histories[PriceHistoryKey{Code: code, Days: days}] = history
+		}
+	}
+	return histories, nil
+}
+
+// loadersContextKey is the context key of the loaders of a request.
+type loadersContextKey struct{}
+
+// Middleware gives each request its own loaders, so that results are not shared between requests.
+func Middleware(stockRepo repository.StockRepository, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), loadersContextKey{}, NewLoaders(stockRepo))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// For returns the loaders of the request.
+func For(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersContextKey{}).(*Loaders)
+	return loaders
+}
@@ -0,0 +1,66 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graph
+
+import (
+	"time"
+)
+
+// テクニカル指標
+type Indicator struct {
+	// 計算日
+	Date time.Time `json:"date"`
+	// RSI(14日)
+	Rsi14 *float64 `json:"rsi14,omitempty"`
+	// MACD
+	Macd *float64 `json:"macd,omitempty"`
+	// MACDシグナル
+	MacdSignal *float64 `json:"macdSignal,omitempty"`
+	// MACDヒストグラム
+	MacdHistogram *float64 `json:"macdHistogram,omitempty"`
+	// 5日移動平均
+	Sma5 *float64 `json:"sma5,omitempty"`
+	// 25日移動平均
+	Sma25 *float64 `json:"sma25,omitempty"`
+	// 75日移動平均
+	Sma75 *float64 `json:"sma75,omitempty"`
+	// 25日移動平均乖離率(%)
+	Ma25Deviation *float64 `json:"ma25Deviation,omitempty"`
+}
+
+// 日足
+type PriceBar struct {
+	// 取引日
+	Date time.Time `json:"date"`
+	// 始値
+	Open float64 `json:"open"`
+	// 高値
+	High float64 `json:"high"`
+	// 安値
+	Low float64 `json:"low"`
+	// 終値
+	Close float64 `json:"close"`
+	// 分割調整後終値
+	AdjClose *float64 `json:"adjClose,omitempty"`
+	// 出来高
+	Volume int `json:"volume"`
+	// 確定値か（ザラ場中の取得値はfalse）
+	Final bool `json:"final"`
+}
+
+type Query struct {
+}
+
+// 銘柄。価格・指標は一覧の銘柄ごとにクエリを発行せず、リクエスト内でまとめて取得する
+type Stock struct {
+	// 銘柄コード
+	Code string `json:"code"`
+	// 銘柄名
+	Name string `json:"name"`
+	// 最新の日足（未取得ならnull）
+	LatestPrice *PriceBar `json:"latestPrice,omitempty"`
+	// 直近days日分の日足（古い順）
+	Prices []*PriceBar `json:"prices"`
+	// 最新のテクニカル指標（未計算ならnull）
+	Indicator *Indicator `json:"indicator,omitempty"`
+}
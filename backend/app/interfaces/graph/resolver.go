@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/usecase"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// This file will not be regenerated automatically.
+//
+// Resolver is the root resolver of the GraphQL API. The query resolvers generated from
+// api/graphql/schema.graphqls by make gen-graphql are its methods, and the fields of the stocks
+// read through the request loaders (see For) to avoid a query per item.
+type Resolver struct {
+	StockRepo     repository.StockRepository
+	PortfolioRepo repository.PortfolioRepository
+	Portfolio     *usecase.PortfolioReportUseCase
+}
+
+// toPriceBar converts a stored daily price to its GraphQL type.
+func toPriceBar(price *models.StockPrice) *PriceBar {
+	return &PriceBar{
+		Date:     price.Date,
+		Open:     utility.DecimalToFloat(price.OpenPrice),
+		High:     utility.DecimalToFloat(price.HighPrice),
+		Low:      utility.DecimalToFloat(price.LowPrice),
+		Close:    utility.DecimalToFloat(price.ClosePrice),
+		AdjClose: utility.NullDecimalToFloatPtr(price.AdjClosePrice),
+		Volume:   int(price.Volume),
+		Final:    price.Final,
+	}
+}
+
+// toIndicator converts stored technical indicators to their GraphQL type.
+func toIndicator(indicator *models.TechnicalIndicator) *Indicator {
+	return &Indicator{
+		Date:          indicator.Date,
+		Rsi14:         utility.NullDecimalToFloatPtr(indicator.Rsi14),
+		Macd:          utility.NullDecimalToFloatPtr(indicator.Macd),
+		MacdSignal:    utility.NullDecimalToFloatPtr(indicator.MacdSignal),
+		MacdHistogram: utility.NullDecimalToFloatPtr(indicator.MacdHistogram),
+		Sma5:          utility.NullDecimalToFloatPtr(indicator.Sma5),
+		Sma25:         utility.NullDecimalToFloatPtr(indicator.Sma25),
+		Sma75:         utility.NullDecimalToFloatPtr(indicator.Sma75),
+		Ma25Deviation: utility.NullDecimalToFloatPtr(indicator.Ma25Deviation),
+	}
+}
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHandler_WatchListReadsFieldsInBatches(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	stockRepo := &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{{Code: "7203", Name: "トヨタ自動車"}, {Code: "6758", Name: "ソニーグループ"}}, nil
+		},
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			return map[string]*models.StockPrice{
+				"7203": {Code: "7203", Date: date, ClosePrice: utility.FloatToDecimal(2500), Volume: 1000, Final: true},
+			}, nil
+		},
+		GetLatestTechnicalIndicatorsFunc: func(ctx context.Context, codes []string) (map[string]*models.TechnicalIndicator, error) {
+			return map[string]*models.TechnicalIndicator{
+				"6758": {Code: "6758", Date: date, Rsi14: utility.FloatToNullDecimal(55.5)},
+			}, nil
+		},
+	}
+	server := httptest.NewServer(NewHandler(&Resolver{StockRepo: stockRepo}))
+	defer server.Close()
+
+	query := `{"query":"{ watchList { code latestPrice { close final } indicator { rsi14 } } }"}`
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(query))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var got struct {
+		Data struct {
+			WatchList []struct {
+				Code        string
+				LatestPrice *struct {
+					Close float64
+					Final bool
+				}
+				Indicator *struct{ Rsi14 *float64 }
+			}
+		}
+		Errors []json.RawMessage
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(got.Errors) != 0 {
+		t.Fatalf("response errors = %s", got.Errors)
+	}
+
+	if len(got.Data.WatchList) != 2 {
+		t.Fatalf("watchList = %+v, want 2 stocks", got.Data.WatchList)
+	}
+	toyota, sony := got.Data.WatchList[0], got.Data.WatchList[1]
+	if toyota.LatestPrice == nil || toyota.LatestPrice.Close != 2500 || !toyota.LatestPrice.Final || toyota.Indicator != nil {
+		t.Errorf("watchList[0] = %+v, want the latest close without indicator", toyota)
+	}
+	if sony.LatestPrice != nil || sony.Indicator == nil || sony.Indicator.Rsi14 == nil || *sony.Indicator.Rsi14 != 55.5 {
+		t.Errorf("watchList[1] = %+v, want the indicator without latest price", sony)
+	}
+
+	// The fields of all stocks are read with one query each
+	if calls := stockRepo.GetLatestPricesCalls(); len(calls) != 1 || len(calls[0].Codes) != 2 {
+		t.Errorf("GetLatestPrices calls = %+v, want one call with both codes", calls)
+	}
+	if calls := len(stockRepo.GetLatestTechnicalIndicatorsCalls()); calls != 1 {
+		t.Errorf("GetLatestTechnicalIndicators called %d times, want once", calls)
+	}
+}
+
+func TestQueryResolver_Stock(t *testing.T) {
+	stockRepo := &mock.StockRepositoryMock{
+		GetWatchListItemByCodeFunc: func(ctx context.Context, code string) (*models.WatchList, error) {
+			if code == "7203" {
+				return &models.WatchList{Code: "7203", Name: "トヨタ自動車"}, nil
+			}
+			return nil, nil
+		},
+	}
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetByCodeFunc: func(ctx context.Context, code string) (*models.Portfolio, error) {
+			if code == "9984" {
+				return &models.Portfolio{Code: "9984", Name: "ソフトバンクグループ"}, nil
+			}
+			return nil, nil
+		},
+	}
+	resolver := (&Resolver{StockRepo: stockRepo, PortfolioRepo: portfolioRepo}).Query()
+
+	tests := []struct {
+		code string
+		want *Stock
+	}{
+		{code: "7203", want: &Stock{Code: "7203", Name: "トヨタ自動車"}},
+		{code: "9984", want: &Stock{Code: "9984", Name: "ソフトバンクグループ"}},
+		{code: "1111", want: nil},
+	}
+	for _, tt := range tests {
+		got, err := resolver.Stock(context.Background(), tt.code)
+		if err != nil {
+			t.Fatalf("Stock(%s) error = %v", tt.code, err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("Stock(%s) mismatch (-want +got):\n%s", tt.code, diff)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.55
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+)
+
+// Stock is the resolver for the stock field.
+func (r *holdingResolver) Stock(ctx context.Context, obj *domain.HoldingSummary) (*Stock, error) {
+	return &Stock{Code: obj.Code, Name: obj.Name}, nil
+}
+
+// WatchList is the resolver for the watchList field.
+func (r *queryResolver) WatchList(ctx context.Context) ([]*Stock, error) {
+	items, err := r.StockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+
+	stocks := make([]*Stock, 0, len(items))
+	for _, item := range items {
+		stocks = append(stocks, &Stock{Code: item.Code, Name: item.Name})
+	}
+	return stocks, nil
+}
+
+// Stock is the resolver for the stock field.
+func (r *queryResolver) Stock(ctx context.Context, code string) (*Stock, error) {
+	item, err := r.StockRepo.GetWatchListItemByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list item: %w", err)
+	}
+	if item != nil {
+		return &Stock{Code: item.Code, Name: item.Name}, nil
+	}
+
+	holding, err := r.PortfolioRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holding: %w", err)
+	}
+	if holding != nil {
+		return &Stock{Code: holding.Code, Name: holding.Name}, nil
+	}
+	return nil, nil
+}
+
+// Portfolio is the resolver for the portfolio field.
+func (r *queryResolver) Portfolio(ctx context.Context) (*domain.PortfolioSummary, error) {
+	return r.Resolver.Portfolio.GetPortfolioStatistics(ctx)
+}
+
+// LatestPrice is the resolver for the latestPrice field.
+func (r *stockResolver) LatestPrice(ctx context.Context, obj *Stock) (*PriceBar, error) {
+	price, err := For(ctx).LatestPrice.Load(ctx, obj.Code)
+	if err != nil || price == nil {
+		return nil, err
+	}
+	return toPriceBar(price), nil
+}
+
+// Prices is the resolver for the prices field.
+func (r *stockResolver) Prices(ctx context.Context, obj *Stock, days int) ([]*PriceBar, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("days must be positive: %d", days)
+	}
+
+	history, err := For(ctx).PriceHistory.Load(ctx, PriceHistoryKey{Code: obj.Code, Days: days})
+	if err != nil {
+		return nil, err
+	}
+
+	bars := make([]*PriceBar, 0, len(history))
+	for _, price := range history {
+		bars = append(bars, toPriceBar(price))
+	}
+	return bars, nil
+}
+
+// Indicator is the resolver for the indicator field.
+func (r *stockResolver) Indicator(ctx context.Context, obj *Stock) (*Indicator, error) {
+	indicator, err := For(ctx).Indicator.Load(ctx, obj.Code)
+	if err != nil || indicator == nil {
+		return nil, err
+	}
+	return toIndicator(indicator), nil
+}
+
+// Holding returns HoldingResolver implementation.
+func (r *Resolver) Holding() HoldingResolver { return &holdingResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Stock returns StockResolver implementation.
+func (r *Resolver) Stock() StockResolver { return &stockResolver{r} }
+
+type holdingResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type stockResolver struct{ *Resolver }
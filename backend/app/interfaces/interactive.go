@@ -0,0 +1,196 @@
+package interfaces
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// errInteractiveCanceled is returned by a prompt answered with an empty line, going back to the menu.
+var errInteractiveCanceled = errors.New("canceled")
+
+// stockChoice is a watched or held stock offered in the prompts of the interactive mode.
+type stockChoice struct {
+	Code string
+	Name string
+}
+
+// interactiveAction is an operation of the interactive mode. Its arguments are built from the answers
+// to its prompts and run as a command of the CLI.
+type interactiveAction struct {
+	Label string
+	Args  func(p *prompter) ([]string, error)
+}
+
+// interactiveActions lists the operations in the order of the menu.
+var interactiveActions = []interactiveAction{
+	{Label: "Inspect a stock", Args: func(p *prompter) ([]string, error) {
+		code, err := p.chooseStock()
+		return []string{"inspect", code}, err
+	}},
+	{Label: "Chart closes of a stock", Args: func(p *prompter) ([]string, error) {
+		code, err := p.chooseStock()
+		if err != nil {
+			return nil, err
+		}
+		days, err := p.ask("Days", "60")
+		return []string{"chart", code, "--days", days}, err
+	}},
+	{Label: "Show the portfolio", Args: func(p *prompter) ([]string, error) {
+		return []string{"portfolio", "list"}, nil
+	}},
+	{Label: "Show the watch list", Args: func(p *prompter) ([]string, error) {
+		return []string{"watchlist", "list"}, nil
+	}},
+	{Label: "Show the score ranking", Args: func(p *prompter) ([]string, error) {
+		return []string{"score"}, nil
+	}},
+	{Label: "Add a stock to the watch list", Args: func(p *prompter) ([]string, error) {
+		code, err := p.ask("Code", "")
+		if err != nil {
+			return nil, err
+		}
+		name, err := p.ask("Name", "")
+		return []string{"watchlist", "add", code, name}, err
+	}},
+	{Label: "Remove a stock from the watch list", Args: func(p *prompter) ([]string, error) {
+		code, err := p.chooseStock()
+		return []string{"watchlist", "remove", code}, err
+	}},
+	{Label: "Mute the alerts of a stock", Args: func(p *prompter) ([]string, error) {
+		code, err := p.chooseStock()
+		if err != nil {
+			return nil, err
+		}
+		period, err := p.ask("For (e.g. 7d, 12h)", "7d")
+		return []string{"watchlist", "mute", code, "--for", period}, err
+	}},
+	{Label: "Set take-profit and stop-loss lines", Args: func(p *prompter) ([]string, error) {
+		code, err := p.chooseStock()
+		if err != nil {
+			return nil, err
+		}
+		takeProfit, err := p.ask("Take profit %", "20")
+		if err != nil {
+			return nil, err
+		}
+		stopLoss, err := p.ask("Stop loss %", "10")
+		return []string{"exit-target", "set", code, "--take-profit", takeProfit, "--stop-loss", stopLoss}, err
+	}},
+	{Label: "Collect prices now", Args: func(p *prompter) ([]string, error) {
+		return []string{"collect"}, nil
+	}},
+}
+
+// prompter reads the answers of the interactive mode line by line.
+type prompter struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+	stocks  []stockChoice
+}
+
+// ask prints the question and returns the answer, or defaultValue for an empty line if there is one.
+// Returns errInteractiveCanceled for an empty line without a default and io.EOF at the end of input.
+func (p *prompter) ask(question, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+
+	answer := strings.TrimSpace(p.scanner.Text())
+	switch {
+	case answer != "":
+		return answer, nil
+	case defaultValue != "":
+		return defaultValue, nil
+	default:
+		return "", errInteractiveCanceled
+	}
+}
+
+// chooseStock lists the watched and held stocks and returns the code chosen by its number or typed in.
+func (p *prompter) chooseStock() (string, error) {
+	for i, stock := range p.stocks {
+		fmt.Fprintf(p.out, "  %2d) %-6s %s\n", i+1, stock.Code, stock.Name)
+	}
+	answer, err := p.ask("Stock (number or code, empty to cancel)", "")
+	if err != nil {
+		return "", err
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(p.stocks) {
+		return p.stocks[n-1].Code, nil
+	}
+	return strings.ToUpper(answer), nil
+}
+
+// runInteractiveSession shows the menu until q or the end of input, running the chosen operations
+// with run. The stocks are loaded again before each operation, since operations change them.
+// Errors of the operations are shown and the session goes on.
+func runInteractiveSession(in io.Reader, out io.Writer, loadStocks func() []stockChoice, run func(args []string) error) error {
+	p := &prompter{scanner: bufio.NewScanner(in), out: out}
+
+	for {
+		fmt.Fprintln(out)
+		for i, action := range interactiveActions {
+			fmt.Fprintf(out, "  %2d) %s\n", i+1, action.Label)
+		}
+		fmt.Fprintln(out, "   q) Quit")
+
+		answer, err := p.ask("Choose", "")
+		if errors.Is(err, errInteractiveCanceled) {
+			continue
+		}
+		if errors.Is(err, io.EOF) || answer == "q" {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > len(interactiveActions) {
+			fmt.Fprintf(out, "Unknown choice: %s\n", answer)
+			continue
+		}
+
+		p.stocks = loadStocks()
+		args, err := interactiveActions[n-1].Args(p)
+		if errors.Is(err, errInteractiveCanceled) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The command is shown so that it can be run directly next time
+		fmt.Fprintf(out, "\n$ %s %s\n", completionProgram, shellQuote(args))
+		if err := run(args); err != nil {
+			fmt.Fprintf(out, "Error: %v\n", err)
+		}
+	}
+}
+
+// shellQuote joins the arguments as a shell command line, quoting those with spaces.
+func shellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t'\"") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,54 @@
+package interfaces
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunInteractiveSession(t *testing.T) {
+	stocks := []stockChoice{{Code: "7203", Name: "トヨタ自動車"}, {Code: "6758", Name: "ソニーグループ"}}
+	input := strings.Join([]string{
+		"1", "2", // inspect the second stock
+		"8", "7203", "", // mute 7203 for the default period
+		"99",    // unknown choice
+		"7", "", // canceled
+		"3",                           // portfolio list, which fails
+		"6", "9983", "Fast Retailing", // add with a name containing a space
+		"q",
+		"10", // not read after quitting
+	}, "\n")
+
+	var ran [][]string
+	err := runInteractiveSession(strings.NewReader(input), io.Discard, func() []stockChoice { return stocks }, func(args []string) error {
+		ran = append(ran, args)
+		if args[0] == "portfolio" {
+			return errors.New("database down")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("runInteractiveSession() error = %v", err)
+	}
+
+	want := [][]string{
+		{"inspect", "6758"},
+		{"watchlist", "mute", "7203", "--for", "7d"},
+		{"portfolio", "list"},
+		{"watchlist", "add", "9983", "Fast Retailing"},
+	}
+	if diff := cmp.Diff(want, ran); diff != "" {
+		t.Errorf("commands run mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	got := shellQuote([]string{"watchlist", "add", "9983", "Fast Retailing", "it's"})
+	want := `watchlist add 9983 'Fast Retailing' 'it'\''s'`
+	if got != want {
+		t.Errorf("shellQuote() = %s, want %s", got, want)
+	}
+}
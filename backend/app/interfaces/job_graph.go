@@ -0,0 +1,152 @@
+package interfaces
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Results of the latest run of a job, used to decide whether the jobs depending on it can run.
+const (
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusSkipped   = "skipped" // not run because a job it depends on failed or was skipped
+)
+
+// JobResult is the result of the latest run of a job in this process.
+type JobResult struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// JobState is a job with its dependencies and the result of its latest run.
+type JobState struct {
+	Name      string     `json:"name"`
+	DependsOn []string   `json:"depends_on,omitempty"`
+	Chained   bool       `json:"chained,omitempty"`
+	Last      *JobResult `json:"last,omitempty"`
+}
+
+// validateJobGraph checks that the dependencies of the jobs are defined jobs and have no cycle.
+func validateJobGraph(jobs map[string]Job) error {
+	for _, name := range sortedJobNames(jobs) {
+		if jobs[name].Chained && len(jobs[name].DependsOn) == 0 {
+			return fmt.Errorf("chained job %s has no dependency to run after", name)
+		}
+		for _, dep := range jobs[name].DependsOn {
+			if _, ok := jobs[dep]; !ok {
+				return fmt.Errorf("job %s depends on undefined job %s", name, dep)
+			}
+		}
+	}
+
+	// Depth-first search, a job visited again while on the path closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int, len(jobs))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("job dependencies have a cycle: %s -> %s", strings.Join(path, " -> "), name)
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		path = append(path, name)
+		for _, dep := range jobs[name].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[name] = visited
+		return nil
+	}
+	for _, name := range sortedJobNames(jobs) {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependentJobs returns the names of the jobs that directly depend on a job, sorted by name.
+func dependentJobs(jobs map[string]Job, name string) []string {
+	var dependents []string
+	for _, job := range jobs {
+		for _, dep := range job.DependsOn {
+			if dep == name {
+				dependents = append(dependents, job.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// sortedJobNames returns the names of the jobs sorted by name.
+func sortedJobNames(jobs map[string]Job) []string {
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jobResults holds the result of the latest run of each job. The results are kept in memory, so a
+// job that has not run since the process started does not block the jobs depending on it.
+type jobResults struct {
+	mu      sync.Mutex
+	results map[string]JobResult
+}
+
+// newJobResults creates an empty result store.
+func newJobResults() *jobResults {
+	return &jobResults{results: make(map[string]JobResult)}
+}
+
+// record stores the result of a run of a job.
+func (r *jobResults) record(name string, result JobResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[name] = result
+}
+
+// get returns the result of the latest run of a job, false if it has not run.
+func (r *jobResults) get(name string) (JobResult, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result, ok := r.results[name]
+	return result, ok
+}
+
+// blockingDependency returns the first dependency of the job whose latest run failed or was
+// skipped, false if the job can run.
+func (r *jobResults) blockingDependency(job Job) (string, JobResult, bool) {
+	for _, dep := range job.DependsOn {
+		if result, ok := r.get(dep); ok && result.Status != JobStatusSucceeded {
+			return dep, result, true
+		}
+	}
+	return "", JobResult{}, false
+}
+
+// dependenciesSucceeded reports whether the latest runs of all dependencies of the job succeeded.
+func (r *jobResults) dependenciesSucceeded(job Job) bool {
+	for _, dep := range job.DependsOn {
+		if result, ok := r.get(dep); !ok || result.Status != JobStatusSucceeded {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateJobGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    []Job
+		wantErr string
+	}{
+		{
+			name: "dag",
+			jobs: []Job{
+				{Name: "prices"},
+				{Name: "indicators", DependsOn: []string{"prices"}, Chained: true},
+				{Name: "report", DependsOn: []string{"indicators", "prices"}},
+			},
+		},
+		{
+			name:    "undefined dependency",
+			jobs:    []Job{{Name: "report", DependsOn: []string{"indicators"}}},
+			wantErr: "job report depends on undefined job indicators",
+		},
+		{
+			name: "cycle",
+			jobs: []Job{
+				{Name: "a", DependsOn: []string{"c"}},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+			wantErr: "job dependencies have a cycle: a -> c -> b -> a",
+		},
+		{
+			name:    "chained without dependency",
+			jobs:    []Job{{Name: "indicators", Chained: true}},
+			wantErr: "chained job indicators has no dependency to run after",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := make(map[string]Job, len(tt.jobs))
+			for _, job := range tt.jobs {
+				jobs[job.Name] = job
+			}
+			err := validateJobGraph(jobs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateJobGraph() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("validateJobGraph() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// noopJobLocker runs jobs without a lock.
+type noopJobLocker struct{}
+
+func (noopJobLocker) WithLock(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeSchedulerNotifier records the alerts of the scheduler.
+type fakeSchedulerNotifier struct {
+	notification.NotificationService
+	messages []string
+}
+
+func (f *fakeSchedulerNotifier) SendMessageOfKind(ctx context.Context, kind notification.MessageKind, message string) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func TestDataScheduler_JobDependencies(t *testing.T) {
+	var ran []string
+	failPrices := true
+	run := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			ran = append(ran, name)
+			if name == "prices" && failPrices {
+				return errors.New("quote API unavailable")
+			}
+			return nil
+		}
+	}
+
+	notifier := &fakeSchedulerNotifier{}
+	ds := &DataScheduler{jobLocker: noopJobLocker{}, results: newJobResults(), notifier: notifier, ctx: context.Background()}
+	ds.jobs = ds.registerJobs([]Job{
+		{Name: "prices", Run: run("prices")},
+		{Name: "indicators", Run: run("indicators"), DependsOn: []string{"prices"}, Chained: true},
+		{Name: "report", Run: run("report"), DependsOn: []string{"indicators"}},
+		{Name: "snapshot", Run: run("snapshot"), DependsOn: []string{"prices"}},
+	})
+
+	// The failed prices skip the chained indicators, which in turn skip the report
+	ds.runJob("prices")
+	ds.runJob("report")
+	ds.runJob("snapshot")
+	// The prices succeed again and the indicators follow them
+	failPrices = false
+	ds.runJob("prices")
+	ds.runJob("report")
+
+	if diff := cmp.Diff([]string{"prices", "prices", "indicators", "report"}, ran); diff != "" {
+		t.Errorf("jobs run mismatch (-want +got):\n%s", diff)
+	}
+	wantMessages := []string{
+		"⏭️ 依存ジョブ prices が失敗したため、次のジョブをスキップしました: indicators\nエラー: quote API unavailable",
+		"⏭️ 依存ジョブ indicators がスキップされたため、次のジョブをスキップしました: report\nエラー: dependency prices did not succeed",
+		"⏭️ 依存ジョブ prices が失敗したため、次のジョブをスキップしました: snapshot\nエラー: quote API unavailable",
+	}
+	if diff := cmp.Diff(wantMessages, notifier.messages); diff != "" {
+		t.Errorf("alerts mismatch (-want +got):\n%s", diff)
+	}
+
+	states := make(map[string]string)
+	for _, state := range ds.JobStates() {
+		states[state.Name] = state.Last.Status
+	}
+	wantStates := map[string]string{
+		"prices":     JobStatusSucceeded,
+		"indicators": JobStatusSucceeded,
+		"report":     JobStatusSucceeded,
+		"snapshot":   JobStatusSkipped,
+	}
+	if diff := cmp.Diff(wantStates, states); diff != "" {
+		t.Errorf("job states mismatch (-want +got):\n%s", diff)
+	}
+}
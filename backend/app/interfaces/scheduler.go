@@ -2,102 +2,591 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/config"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
 	"github.com/boost-jp/stock-automation/app/usecase"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
 	"github.com/go-co-op/gocron"
 	"github.com/sirupsen/logrus"
 )
 
 // DataScheduler manages scheduled tasks for the application
 type DataScheduler struct {
-	collectorUseCase *usecase.CollectDataUseCase
-	reporterUseCase  *usecase.PortfolioReportUseCase
-	scheduler        *gocron.Scheduler
+	collectorUseCase   *usecase.CollectDataUseCase
+	targetSyncUseCase  *usecase.CollectTargetSyncUseCase
+	technicalUseCase   *usecase.TechnicalAnalysisUseCase
+	reporterUseCase    *usecase.PortfolioReportUseCase
+	dataQualityUseCase *usecase.DataQualityUseCase
+	scoringUseCase     *usecase.ScoringUseCase
+	discoveryUseCase   *usecase.DiscoveryUseCase
+	calendarUseCase    *usecase.CalendarSyncUseCase
+	fundPriceUseCase   *usecase.FundPriceUseCase
+	cryptoPriceUseCase *usecase.CryptoPriceUseCase
+	marketIndexUseCase *usecase.MarketIndexUseCase
+	briefingUseCase    *usecase.MorningBriefingUseCase
+	exitTargetUseCase  *usecase.ExitTargetUseCase
+	historyUseCase     *usecase.PortfolioHistoryUseCase
+	alertRuleUseCase   *usecase.AlertRuleUseCase
+	paperTradeUseCase  *usecase.PaperTradeUseCase
+	maintenanceUseCase *usecase.MaintenanceUseCase
+	aggregationUseCase *usecase.PriceAggregationUseCase
+	goalUseCase        *usecase.GoalTrackingUseCase
+	retentionUseCase   *usecase.RetentionUseCase
+	marketHours        domain.MarketHours
+	jobLocker          repository.JobLocker
+	featureFlags       *usecase.FeatureFlagUseCase
+	notifier           notification.NotificationService
+	statusUpdater      notification.MessageUpdater
+	statusJobs         map[string]bool
+	timeouts           config.SchedulerConfig
+	jobs               map[string]Job
+	results            *jobResults
+	worker             *JobWorker
+	scheduler          *gocron.Scheduler
+	ctx                context.Context
+	cancel             context.CancelFunc
 }
 
 // NewDataScheduler creates a new data scheduler
 func NewDataScheduler(
 	collectorUseCase *usecase.CollectDataUseCase,
+	targetSyncUseCase *usecase.CollectTargetSyncUseCase,
+	technicalUseCase *usecase.TechnicalAnalysisUseCase,
 	reporterUseCase *usecase.PortfolioReportUseCase,
+	dataQualityUseCase *usecase.DataQualityUseCase,
+	scoringUseCase *usecase.ScoringUseCase,
+	discoveryUseCase *usecase.DiscoveryUseCase,
+	calendarUseCase *usecase.CalendarSyncUseCase,
+	fundPriceUseCase *usecase.FundPriceUseCase,
+	cryptoPriceUseCase *usecase.CryptoPriceUseCase,
+	marketIndexUseCase *usecase.MarketIndexUseCase,
+	briefingUseCase *usecase.MorningBriefingUseCase,
+	exitTargetUseCase *usecase.ExitTargetUseCase,
+	historyUseCase *usecase.PortfolioHistoryUseCase,
+	alertRuleUseCase *usecase.AlertRuleUseCase,
+	paperTradeUseCase *usecase.PaperTradeUseCase,
+	maintenanceUseCase *usecase.MaintenanceUseCase,
+	aggregationUseCase *usecase.PriceAggregationUseCase,
+	goalUseCase *usecase.GoalTrackingUseCase,
+	retentionUseCase *usecase.RetentionUseCase,
+	marketHours domain.MarketHours,
+	location *time.Location,
+	jobLocker repository.JobLocker,
+	timeouts config.SchedulerConfig,
 ) *DataScheduler {
-	s := gocron.NewScheduler(time.FixedZone("JST", 9*60*60))
+	s := gocron.NewScheduler(location)
+	ctx, cancel := context.WithCancel(context.Background())
 
-	return &DataScheduler{
-		collectorUseCase: collectorUseCase,
-		reporterUseCase:  reporterUseCase,
-		scheduler:        s,
+	ds := &DataScheduler{
+		collectorUseCase:   collectorUseCase,
+		targetSyncUseCase:  targetSyncUseCase,
+		technicalUseCase:   technicalUseCase,
+		reporterUseCase:    reporterUseCase,
+		dataQualityUseCase: dataQualityUseCase,
+		scoringUseCase:     scoringUseCase,
+		discoveryUseCase:   discoveryUseCase,
+		calendarUseCase:    calendarUseCase,
+		fundPriceUseCase:   fundPriceUseCase,
+		cryptoPriceUseCase: cryptoPriceUseCase,
+		marketIndexUseCase: marketIndexUseCase,
+		briefingUseCase:    briefingUseCase,
+		exitTargetUseCase:  exitTargetUseCase,
+		historyUseCase:     historyUseCase,
+		alertRuleUseCase:   alertRuleUseCase,
+		paperTradeUseCase:  paperTradeUseCase,
+		maintenanceUseCase: maintenanceUseCase,
+		aggregationUseCase: aggregationUseCase,
+		goalUseCase:        goalUseCase,
+		retentionUseCase:   retentionUseCase,
+		marketHours:        marketHours,
+		jobLocker:          jobLocker,
+		timeouts:           timeouts,
+		results:            newJobResults(),
+		scheduler:          s,
+		ctx:                ctx,
+		cancel:             cancel,
 	}
+	ds.jobs = ds.defineJobs()
+	return ds
 }
 
-// StartScheduledCollection starts all scheduled tasks
-func (ds *DataScheduler) StartScheduledCollection() {
-	ctx := context.Background()
+// Names of the scheduled jobs. Each job can also be triggered on demand by name.
+const (
+	JobPriceUpdate         = "price-update"
+	JobClosingPriceUpdate  = "closing-price-update"
+	JobClosingConfirmation = "closing-price-confirmation"
+	JobIndicatorUpdate     = "indicator-update"
+	JobTargetSync          = "target-sync"
+	JobExitTargetCheck     = "exit-target-check"
+	JobAlertRuleEvaluation = "alert-rule-evaluation"
+	JobWatchListUpdate     = "watch-list-update"
+	JobPortfolioUpdate     = "portfolio-update"
+	JobDailyReport         = "daily-report"
+	JobDailyReportFollowUp = "daily-report-followup"
+	JobMonthlyReport       = "monthly-report"
+	JobPortfolioSnapshot   = "portfolio-snapshot"
+	JobPaperTrade          = "paper-trade"
+	JobPaperTradeReport    = "paper-trade-report"
+	JobCleanup             = "cleanup"
+	JobDataQualityReport   = "data-quality-report"
+	JobPriceFeedCheck      = "price-feed-check"
+	JobScoreRanking        = "score-ranking"
+	JobDiscovery           = "discovery"
+	JobMaintenanceDigest   = "maintenance-digest"
+	JobPriceAggregation    = "price-aggregation"
+	JobGoalPaceCheck       = "goal-pace-check"
+	JobFundPriceUpdate     = "fund-price-update"
+	JobCryptoPriceUpdate   = "crypto-price-update"
+	JobIndexPriceUpdate    = "index-price-update"
+	JobMorningBriefing     = "morning-briefing"
+	JobCalendarSync        = "calendar-sync"
+)
+
+var (
+	// ErrUnknownJob is returned when triggering a job that does not exist
+	ErrUnknownJob = errors.New("unknown job")
+	// ErrJobQueueFull is returned when the worker cannot accept a triggered job
+	ErrJobQueueFull = errors.New("job queue is full")
+)
 
-	// Every 5 minutes: Update prices (only during market hours)
+// defineJobs returns the jobs run by the schedule, keyed by name. The dependencies form a DAG:
+// closing prices → technical indicators → daily report and paper trading, so that a job is not
+// run on the stale data of a failed job before it.
+func (ds *DataScheduler) defineJobs() map[string]Job {
+	jobs := []Job{
+		{Name: JobPriceUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.collectorUseCase.UpdateDuePrices},
+		{Name: JobClosingPriceUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.collectorUseCase.UpdateAllPrices},
+		{Name: JobClosingConfirmation, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.collectorUseCase.RunClosingConfirmation},
+		{Name: JobIndicatorUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.technicalUseCase.AnalyzeWatchList,
+			DependsOn: []string{JobClosingPriceUpdate}, Chained: true},
+		{Name: JobTargetSync, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.targetSyncUseCase.RunScheduledSync},
+		{Name: JobExitTargetCheck, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.exitTargetUseCase.CheckTargets},
+		{Name: JobAlertRuleEvaluation, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.alertRuleUseCase.EvaluateRules},
+		{Name: JobWatchListUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.collectorUseCase.UpdateWatchList},
+		{Name: JobPortfolioUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.collectorUseCase.UpdatePortfolio},
+		{Name: JobDailyReport, Timeout: ds.timeouts.ReportTimeout, Run: ds.reporterUseCase.GenerateAndSendDailyReport,
+			DependsOn: []string{JobIndicatorUpdate}},
+		{Name: JobDailyReportFollowUp, Timeout: ds.timeouts.ReportTimeout, Run: ds.reporterUseCase.SendClosingFollowUp, Feature: domain.FeatureReportFollowUp,
+			DependsOn: []string{JobClosingConfirmation}, Chained: true},
+		{Name: JobMorningBriefing, Timeout: ds.timeouts.ReportTimeout, Run: ds.briefingUseCase.SendBriefing, Feature: domain.FeatureMorningBriefing},
+		{Name: JobMonthlyReport, Timeout: ds.timeouts.ReportTimeout, Run: ds.reporterUseCase.SendMonthlyReport},
+		{Name: JobPortfolioSnapshot, Timeout: ds.timeouts.ReportTimeout, Run: ds.historyUseCase.SaveDailySnapshot,
+			DependsOn: []string{JobClosingPriceUpdate}},
+		{Name: JobPaperTrade, Timeout: ds.timeouts.ReportTimeout, Run: ds.paperTradeUseCase.RunScheduledTrading, Feature: domain.FeaturePaperTrading,
+			DependsOn: []string{JobIndicatorUpdate}},
+		{Name: JobPaperTradeReport, Timeout: ds.timeouts.ReportTimeout, Run: ds.paperTradeUseCase.SendPerformanceReport, Feature: domain.FeaturePaperTrading},
+		{Name: JobCleanup, Timeout: ds.timeouts.CleanupTimeout, Run: func(ctx context.Context) error {
+			_, err := ds.retentionUseCase.Apply(ctx, false)
+			return err
+		}},
+		{Name: JobDataQualityReport, Timeout: ds.timeouts.DataQualityTimeout, Run: ds.dataQualityUseCase.SendWeeklyReport},
+		{Name: JobPriceFeedCheck, Timeout: ds.timeouts.DataQualityTimeout, Run: ds.dataQualityUseCase.RunPriceFeedCheck},
+		{Name: JobScoreRanking, Timeout: ds.timeouts.ReportTimeout, Run: ds.scoringUseCase.SendWeeklyRanking},
+		{Name: JobDiscovery, Timeout: ds.timeouts.DataQualityTimeout, Run: ds.discoveryUseCase.SendWeeklyCandidates, Feature: domain.FeatureDiscovery},
+		{Name: JobCalendarSync, Timeout: ds.timeouts.ReportTimeout, Run: ds.calendarUseCase.RunScheduledSync},
+		{Name: JobFundPriceUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.fundPriceUseCase.UpdateFundPrices},
+		{Name: JobCryptoPriceUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.cryptoPriceUseCase.UpdateCryptoPrices},
+		{Name: JobIndexPriceUpdate, Timeout: ds.timeouts.PriceUpdateTimeout, Run: ds.marketIndexUseCase.UpdateIndexPrices},
+		{Name: JobMaintenanceDigest, Timeout: ds.timeouts.ReportTimeout, Run: ds.maintenanceUseCase.SendDigests},
+		{Name: JobPriceAggregation, Timeout: ds.timeouts.CleanupTimeout, Run: ds.aggregationUseCase.AggregateRecent,
+			DependsOn: []string{JobClosingPriceUpdate}},
+		{Name: JobGoalPaceCheck, Timeout: ds.timeouts.ReportTimeout, Run: func(ctx context.Context) error {
+			_, err := ds.goalUseCase.CheckPace(ctx)
+			return err
+		}},
+	}
+	return ds.registerJobs(jobs)
+}
+
+// registerJobs keys the jobs by name. Each job holds its lock while running, so it is skipped if
+// the same job is still running here or in a CLI process, and records its result for the jobs
+// depending on it. It panics on an invalid dependency, as the jobs are defined in code.
+func (ds *DataScheduler) registerJobs(jobs []Job) map[string]Job {
+	byName := make(map[string]Job, len(jobs))
+	for _, job := range jobs {
+		name, run := job.Name, job.Run
+		run = ds.withStatusMessage(name, run)
+		job.Run = func(ctx context.Context) error {
+			err := ds.jobLocker.WithLock(ctx, name, run)
+			ds.completeJob(name, err)
+			return err
+		}
+		byName[job.Name] = job
+	}
+	if err := validateJobGraph(byName); err != nil {
+		panic(err)
+	}
+	return byName
+}
+
+// StartScheduledCollection starts all scheduled tasks. The times of day are in the time zone of
+// the scheduler (SCHEDULER_TIMEZONE), and the market hours in the time zone of the market.
+func (ds *DataScheduler) StartScheduledCollection() {
+	// Every 5 minutes: Update prices of the stocks whose collection interval has elapsed,
+	// check exit lines and evaluate alert rules (only during market hours)
 	ds.scheduler.Every(5).Minutes().Do(func() {
-		if isMarketOpen() {
-			if err := ds.collectorUseCase.UpdateAllPrices(ctx); err != nil {
-				logrus.Error("Failed to update prices:", err)
-			}
+		if ds.isMarketOpen() {
+			ds.runJob(JobPriceUpdate)
+			ds.runJob(JobExitTargetCheck)
+			ds.runJob(JobAlertRuleEvaluation)
 		}
 	})
 
+	// Every 15 minutes: Update prices of crypto assets, which trade around the clock
+	// including weekends and holidays
+	ds.scheduler.Every(15).Minutes().Do(func() {
+		ds.runJob(JobCryptoPriceUpdate)
+	})
+
+	// Every minute: Detect stocks added to or removed from the watch list and portfolio,
+	// and collect the initial history of added stocks.
+	// Send the digest of alerts suppressed during a maintenance window that has ended
+	ds.scheduler.Every(1).Minute().Do(func() {
+		ds.runJob(JobTargetSync)
+		ds.runJob(JobMaintenanceDigest)
+	})
+
 	// Every 30 minutes: Update configurations
 	ds.scheduler.Every(30).Minutes().Do(func() {
-		if err := ds.collectorUseCase.UpdateWatchList(ctx); err != nil {
-			logrus.Error("Failed to update watch list:", err)
+		ds.runJob(JobWatchListUpdate)
+		ds.runJob(JobPortfolioUpdate)
+	})
+
+	// Daily at 7:40 AM: Update the net asset values of funds, published the evening before, and
+	// the market indices, so that the daily report has the overnight closes of overseas indices
+	ds.scheduler.Every(1).Day().At("07:40").Do(func() {
+		ds.runJob(JobFundPriceUpdate)
+		ds.runJob(JobIndexPriceUpdate)
+	})
+
+	// Daily at 8:00 AM: Send daily report, unless the last indicator update failed or was skipped
+	ds.scheduler.Every(1).Day().At("08:00").Do(func() {
+		ds.runJob(JobDailyReport)
+	})
+
+	// Weekdays at 8:30 AM: Send the briefing of the markets overnight, the previous closes of the
+	// holdings and the earnings of today before the market opens
+	ds.scheduler.Every(1).Day().At("08:30").Do(func() {
+		if ds.isTradingDay() {
+			ds.runJob(JobMorningBriefing)
 		}
+	})
 
-		if err := ds.collectorUseCase.UpdatePortfolio(ctx); err != nil {
-			logrus.Error("Failed to update portfolio:", err)
+	// Monthly on the 1st at 7:30 AM: Send monthly report with correlation analysis
+	ds.scheduler.Every(1).Month(1).At("07:30").Do(func() {
+		ds.runJob(JobMonthlyReport)
+	})
+
+	// Weekdays at 3:10 PM: Update closing prices of all stocks, including those collected daily,
+	// followed by the technical indicators of the watched stocks once the prices are collected,
+	// and the closes of the market indices
+	ds.scheduler.Every(1).Day().At("15:10").Do(func() {
+		if ds.isTradingDay() {
+			ds.runJob(JobClosingPriceUpdate)
+			ds.runJob(JobIndexPriceUpdate)
 		}
 	})
 
-	// Daily at 8:00 AM JST: Send daily report
-	ds.scheduler.Every(1).Day().At("08:00").Do(func() {
-		if err := ds.reporterUseCase.GenerateAndSendDailyReport(ctx); err != nil {
-			logrus.Error("Failed to send daily report:", err)
+	// Daily at 3:30 PM: Save portfolio snapshot and check the pace of goals after market close
+	ds.scheduler.Every(1).Day().At("15:30").Do(func() {
+		ds.runJob(JobPortfolioSnapshot)
+		ds.runJob(JobGoalPaceCheck)
+	})
+
+	// Weekdays at 3:40 PM: Check that the closing collection updated the prices of today, which
+	// catches a collection that stopped updating prices without failing
+	ds.scheduler.Every(1).Day().At("15:40").Do(func() {
+		if ds.isTradingDay() {
+			ds.runJob(JobPriceFeedCheck)
 		}
 	})
 
-	// Daily at 2:00 AM JST: Cleanup old data
-	ds.scheduler.Every(1).Day().At("02:00").Do(func() {
-		if err := ds.collectorUseCase.CleanupOldData(ctx, 365); err != nil {
-			logrus.Error("Failed to cleanup old data:", err)
+	// Weekdays at 3:45 PM: Settle paper orders at today's open and place orders from today's signals
+	ds.scheduler.Every(1).Day().At("15:45").Do(func() {
+		if ds.isTradingDay() {
+			ds.runJob(JobPaperTrade)
+		}
+	})
+
+	// Weekly on Friday at 4:00 PM: Send paper trade performance compared with the real portfolio
+	ds.scheduler.Every(1).Friday().At("16:00").Do(func() {
+		ds.runJob(JobPaperTradeReport)
+	})
+
+	// Daily at 4:30 PM: Aggregate today's prices into weekly and monthly bars
+	ds.scheduler.Every(1).Day().At("16:30").Do(func() {
+		ds.runJob(JobPriceAggregation)
+	})
+
+	// Weekdays at 6:00 PM: Re-fetch today's daily bars and store them as final over the provisional
+	// prices collected during the session, then reply the closing values to the daily report
+	ds.scheduler.Every(1).Day().At("18:00").Do(func() {
+		if ds.isTradingDay() {
+			ds.runJob(JobClosingConfirmation)
 		}
 	})
 
+	// Daily at 8:00 PM: Register the earnings announcements and dividend dates of the watched and
+	// held stocks to Google Calendar, after J-Quants publishes the announcements of the next business day
+	ds.scheduler.Every(1).Day().At("20:00").Do(func() {
+		ds.runJob(JobCalendarSync)
+	})
+
+	// Daily at 2:00 AM: Delete or archive the data past the retention periods of the retention policy
+	ds.scheduler.Every(1).Day().At("02:00").Do(func() {
+		ds.runJob(JobCleanup)
+	})
+
+	// Weekly on Monday at 7:00 AM: Send data quality report, score ranking and watch list candidates
+	ds.scheduler.Every(1).Monday().At("07:00").Do(func() {
+		ds.runJob(JobDataQualityReport)
+		ds.runJob(JobScoreRanking)
+		ds.runJob(JobDiscovery)
+	})
+
 	ds.scheduler.StartAsync()
 	logrus.Info("Data collection scheduler started")
 }
 
-// Stop stops all scheduled tasks
+// Stop stops all scheduled tasks and cancels running jobs
 func (ds *DataScheduler) Stop() {
 	ds.scheduler.Stop()
+	ds.cancel()
 	logrus.Info("Data collection scheduler stopped")
 }
 
-// isMarketOpen checks if the Japanese stock market is currently open
-func isMarketOpen() bool {
-	now := time.Now().In(time.FixedZone("JST", 9*60*60))
-	weekday := now.Weekday()
+// SetWorker hands scheduled jobs to the worker instead of running them in the scheduler goroutine.
+// Must be called before StartScheduledCollection.
+func (ds *DataScheduler) SetWorker(worker *JobWorker) {
+	ds.worker = worker
+}
+
+// SetFeatureFlags skips the scheduled runs of the jobs whose feature is disabled.
+// Jobs triggered manually run regardless of the flags.
+func (ds *DataScheduler) SetFeatureFlags(flags *usecase.FeatureFlagUseCase) {
+	ds.featureFlags = flags
+}
+
+// SetNotifier alerts the jobs skipped because a job they depend on failed.
+func (ds *DataScheduler) SetNotifier(notifier notification.NotificationService) {
+	ds.notifier = notifier
+}
 
-	// Market is closed on weekends
-	if weekday == time.Saturday || weekday == time.Sunday {
-		return false
+// SetStatusUpdater posts a status message when one of the given jobs starts and updates it with the
+// result when the job ends, instead of leaving a message per state.
+func (ds *DataScheduler) SetStatusUpdater(updater notification.MessageUpdater, jobs []string) {
+	ds.statusUpdater = updater
+	ds.statusJobs = make(map[string]bool, len(jobs))
+	for _, name := range jobs {
+		if _, ok := ds.jobs[name]; !ok {
+			logrus.Warnf("Unknown job %s in the status jobs is ignored", name)
+			continue
+		}
+		ds.statusJobs[name] = true
 	}
+}
 
-	// Market hours: 9:00 AM - 3:00 PM JST (with lunch break 11:30 AM - 12:30 PM)
-	hour := now.Hour()
-	minute := now.Minute()
-	currentMinutes := hour*60 + minute
+// withStatusMessage wraps a job so that its status message is posted and updated while it runs, if
+// its status is followed. A status message that cannot be posted or updated does not fail the job.
+func (ds *DataScheduler) withStatusMessage(name string, run func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if ds.statusUpdater == nil || !ds.statusJobs[name] {
+			return run(ctx)
+		}
 
-	morningOpen := 9 * 60       // 9:00 AM
-	morningClose := 11*60 + 30  // 11:30 AM
-	afternoonOpen := 12*60 + 30 // 12:30 PM
-	afternoonClose := 15 * 60   // 3:00 PM
+		start := time.Now()
+		ref, err := ds.statusUpdater.PostMessage(ctx, notification.KindGeneral, i18n.T("scheduler.job_running", name, start.Format("15:04:05")))
+		if err != nil {
+			logrus.Warnf("Failed to post the status of %s: %v", name, err)
+			return run(ctx)
+		}
+
+		runErr := run(ctx)
+		elapsed := time.Since(start).Round(time.Second)
+		status := i18n.T("scheduler.job_succeeded", name, elapsed)
+		if runErr != nil {
+			status = i18n.T("scheduler.job_failed", name, elapsed, runErr)
+		}
+		if err := ds.statusUpdater.UpdateMessage(context.WithoutCancel(ctx), ref, status); err != nil {
+			logrus.Warnf("Failed to update the status of %s: %v", name, err)
+		}
+		return runErr
+	}
+}
+
+// Name returns the subsystem name.
+func (ds *DataScheduler) Name() string {
+	return "scheduler"
+}
+
+// Run starts the scheduled tasks and stops them when ctx is canceled.
+func (ds *DataScheduler) Run(ctx context.Context) error {
+	ds.StartScheduledCollection()
+	<-ctx.Done()
+	ds.Stop()
+	return ctx.Err()
+}
+
+// JobNames returns the names of the jobs that can be triggered, sorted by name.
+func (ds *DataScheduler) JobNames() []string {
+	return sortedJobNames(ds.jobs)
+}
+
+// JobStates returns the jobs with their dependencies and the results of their latest runs, sorted by name.
+func (ds *DataScheduler) JobStates() []JobState {
+	states := make([]JobState, 0, len(ds.jobs))
+	for _, name := range sortedJobNames(ds.jobs) {
+		job := ds.jobs[name]
+		state := JobState{Name: name, DependsOn: job.DependsOn, Chained: job.Chained}
+		if result, ok := ds.results.get(name); ok {
+			state.Last = &result
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// TriggerJob runs a job immediately outside its schedule. The job is queued if a worker
+// is set, otherwise it runs in the background. Market hours and dependencies are not checked,
+// and the chained jobs depending on it run after it succeeds.
+func (ds *DataScheduler) TriggerJob(name string) error {
+	job, ok := ds.jobs[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownJob, name)
+	}
+
+	logrus.Infof("Job %s triggered manually", name)
+	if ds.worker != nil {
+		if !ds.worker.Submit(job) {
+			return ErrJobQueueFull
+		}
+		return nil
+	}
+
+	go executeJob(ds.ctx, job)
+	return nil
+}
+
+// runJob runs a job with its own timeout, or queues it if a worker is set. The job is skipped
+// and alerted if the latest run of a job it depends on failed or was skipped.
+func (ds *DataScheduler) runJob(name string) {
+	job := ds.jobs[name]
+	if job.Feature != "" && ds.featureFlags != nil && !ds.featureFlags.IsEnabled(ds.ctx, job.Feature) {
+		logrus.Infof("Feature %s is disabled, skipping %s", job.Feature, name)
+		return
+	}
+	if dep, result, blocked := ds.results.blockingDependency(job); blocked {
+		ds.skipJobs(name, dep, result)
+		return
+	}
+	if ds.worker != nil {
+		if !ds.worker.Submit(job) {
+			logrus.Errorf("Job queue is full, skipping %s", name)
+		}
+		return
+	}
+
+	executeJob(ds.ctx, job)
+}
+
+// completeJob records the result of a run and runs the chained jobs depending on the job, or skips
+// them if it failed. A run skipped for the lock of another process is not recorded.
+func (ds *DataScheduler) completeJob(name string, err error) {
+	if errors.Is(err, repository.ErrJobLocked) {
+		return
+	}
+
+	result := JobResult{Status: JobStatusSucceeded, At: time.Now()}
+	if err != nil {
+		result.Status, result.Error = JobStatusFailed, err.Error()
+	}
+	ds.results.record(name, result)
+
+	for _, dependent := range dependentJobs(ds.jobs, name) {
+		if !ds.jobs[dependent].Chained {
+			continue // checked when its own schedule comes
+		}
+		if result.Status != JobStatusSucceeded {
+			ds.skipJobs(dependent, name, result)
+			continue
+		}
+		if ds.results.dependenciesSucceeded(ds.jobs[dependent]) {
+			ds.runJob(dependent)
+		}
+	}
+}
+
+// skipJobs skips a job, and the chained jobs depending on it, because a job it depends on failed
+// or was skipped, and sends one alert of the skipped jobs.
+func (ds *DataScheduler) skipJobs(name, dep string, depResult JobResult) {
+	skipped := ds.skipJob(name, dep, time.Now(), nil)
+	logrus.Warnf("Skipped %s because %s %s", strings.Join(skipped, ", "), dep, depResult.Status)
+	if ds.notifier == nil {
+		return
+	}
+
+	reason := i18n.T("scheduler.dependency_failed")
+	if depResult.Status == JobStatusSkipped {
+		reason = i18n.T("scheduler.dependency_skipped")
+	}
+	message := i18n.T("scheduler.jobs_skipped", dep, reason, strings.Join(skipped, ", "))
+	if depResult.Error != "" {
+		message += "\n" + i18n.T("scheduler.dependency_error", depResult.Error)
+	}
+	if err := ds.notifier.SendMessageOfKind(ds.ctx, notification.KindCritical, message); err != nil {
+		logrus.Errorf("Failed to send skipped jobs alert: %v", err)
+	}
+}
+
+// skipJob records a job and the chained jobs depending on it as skipped, returning their names.
+func (ds *DataScheduler) skipJob(name, dep string, at time.Time, skipped []string) []string {
+	ds.results.record(name, JobResult{Status: JobStatusSkipped, At: at, Error: fmt.Sprintf("dependency %s did not succeed", dep)})
+	skipped = append(skipped, name)
+	for _, dependent := range dependentJobs(ds.jobs, name) {
+		if ds.jobs[dependent].Chained {
+			skipped = ds.skipJob(dependent, name, at, skipped)
+		}
+	}
+	return skipped
+}
+
+// executeJob runs a job with its own timeout and logs the failure if any.
+// Changes made by the job are recorded to the audit log as scheduler operations.
+func executeJob(ctx context.Context, job Job) {
+	ctx = repository.WithAuditSource(ctx, models.AuditSourceScheduler, job.Name)
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	if err := job.Run(ctx); err != nil {
+		if errors.Is(err, repository.ErrJobLocked) {
+			logrus.Warnf("Job %s is already running, skipped", job.Name)
+			return
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			logrus.Errorf("Job %s timed out after %v: %v", job.Name, job.Timeout, err)
+			return
+		}
+		logrus.Errorf("Failed to run %s: %v", job.Name, err)
+	}
+}
+
+// isTradingDay checks if today is a trading day of the market
+func (ds *DataScheduler) isTradingDay() bool {
+	return ds.marketHours.IsTradingDay(time.Now())
+}
 
-	return (currentMinutes >= morningOpen && currentMinutes < morningClose) ||
-		(currentMinutes >= afternoonOpen && currentMinutes < afternoonClose)
+// isMarketOpen checks if the market is currently in a trading session
+func (ds *DataScheduler) isMarketOpen() bool {
+	return ds.marketHours.IsOpen(time.Now())
 }
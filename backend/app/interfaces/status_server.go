@@ -0,0 +1,443 @@
+package interfaces
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/config"
+	"github.com/boost-jp/stock-automation/app/infrastructure/eventbus"
+	"github.com/boost-jp/stock-automation/app/interfaces/api"
+	"github.com/boost-jp/stock-automation/app/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// statusServerShutdownTimeout is the time allowed for in-flight requests on shutdown
+const statusServerShutdownTimeout = 5 * time.Second
+
+// JobTrigger runs scheduled jobs on demand.
+type JobTrigger interface {
+	JobNames() []string
+	JobStates() []JobState
+	TriggerJob(name string) error
+}
+
+// SharedReportWriter writes the read-only report page of a share link.
+type SharedReportWriter interface {
+	WriteSharedReport(ctx context.Context, w io.Writer, token string) error
+}
+
+// PortfolioHistoryReader reads the daily portfolio valuation over a period.
+type PortfolioHistoryReader interface {
+	GetHistory(ctx context.Context, period domain.HistoryPeriod) (*usecase.PortfolioHistory, error)
+}
+
+// TradeNoteManager records, lists and removes the trade notes of each stock.
+type TradeNoteManager interface {
+	AddNote(ctx context.Context, input usecase.TradeNoteInput) (*models.TradeNote, error)
+	ListNotes(ctx context.Context, code string, limit int) ([]*models.TradeNote, error)
+	GetNote(ctx context.Context, id string) (*models.TradeNote, error)
+	DeleteNote(ctx context.Context, id string) error
+}
+
+// StatusServer serves the health check, the subsystem states, the data provider quotas and the
+// counts of published events over HTTP, the admin endpoints to trigger scheduled jobs without restarting,
+// the report pages of share links, the portfolio history, the trade notes and the GraphQL API of the dashboard.
+type StatusServer struct {
+	config     config.ServerConfig
+	supervisor *Supervisor
+	jobs       JobTrigger
+	quotas     *client.QuotaManager
+	events     *eventbus.Stats
+	shares     SharedReportWriter
+	history    PortfolioHistoryReader
+	notes      TradeNoteManager
+	graphql    http.Handler
+}
+
+var _ api.ServerInterface = (*StatusServer)(nil)
+
+// NewStatusServer creates a new status server.
+func NewStatusServer(cfg config.ServerConfig, supervisor *Supervisor, jobs JobTrigger, quotas *client.QuotaManager, events *eventbus.Stats) *StatusServer {
+	return &StatusServer{
+		config:     cfg,
+		supervisor: supervisor,
+		jobs:       jobs,
+		quotas:     quotas,
+		events:     events,
+	}
+}
+
+// SetSharedReports serves the report pages of share links at /share/{token}, which responds 404 without them.
+func (s *StatusServer) SetSharedReports(shares SharedReportWriter) {
+	s.shares = shares
+}
+
+// SetPortfolioHistory serves the portfolio history at /portfolio/history.
+func (s *StatusServer) SetPortfolioHistory(history PortfolioHistoryReader) {
+	s.history = history
+}
+
+// SetTradeNotes serves the trade notes at /notes.
+func (s *StatusServer) SetTradeNotes(notes TradeNoteManager) {
+	s.notes = notes
+}
+
+// SetGraphQL serves the GraphQL API at /graphql. It requires the admin token, since it exposes the holdings.
+func (s *StatusServer) SetGraphQL(handler http.Handler) {
+	s.graphql = handler
+}
+
+// Name returns the subsystem name.
+func (s *StatusServer) Name() string {
+	return "server"
+}
+
+// Run serves HTTP requests until ctx is canceled.
+func (s *StatusServer) Run(ctx context.Context) error {
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.config.Port),
+		Handler:      s.Handler(),
+		ReadTimeout:  s.config.ReadTimeout,
+		WriteTimeout: s.config.WriteTimeout,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logrus.Infof("Status server listening on %s", server.Addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("status server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), statusServerShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down status server: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Handler returns the HTTP handler of the status server. The routes of api/openapi.yaml are served
+// by the generated router, which validates the requests against the schema.
+func (s *StatusServer) Handler() http.Handler {
+	validate, err := api.RequestValidator()
+	if err != nil {
+		// The schema is embedded in the generated code, so this fails only if it is broken
+		panic(err)
+	}
+
+	mux := http.NewServeMux()
+	if s.graphql != nil {
+		mux.Handle("/graphql", s.requireAdmin(s.graphql.ServeHTTP))
+	}
+	return api.HandlerWithOptions(s, api.StdHTTPServerOptions{
+		BaseRouter: mux,
+		// The last middleware runs first, so requests are authenticated before validation
+		Middlewares: []api.MiddlewareFunc{validate, s.authenticate},
+		ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeJSON(w, http.StatusBadRequest, api.Error{Error: err.Error()})
+		},
+	})
+}
+
+// GetHealth responds while the server is up.
+func (s *StatusServer) GetHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, api.Health{Status: "ok"})
+}
+
+// GetStatus returns the states of the subsystems.
+func (s *StatusServer) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status := s.supervisor.Status()
+	subsystems := make([]api.SubsystemStatus, 0, len(status.Subsystems))
+	for _, subsystem := range status.Subsystems {
+		subsystems = append(subsystems, api.SubsystemStatus{
+			Name:      subsystem.Name,
+			State:     api.SubsystemStatusState(subsystem.State),
+			Restarts:  subsystem.Restarts,
+			StartedAt: subsystem.StartedAt,
+			LastError: subsystem.LastError,
+		})
+	}
+	writeJSON(w, http.StatusOK, api.SupervisorStatus{StartedAt: status.StartedAt, Subsystems: subsystems})
+}
+
+// GetQuotas returns today's usage of the data provider quotas.
+func (s *StatusServer) GetQuotas(w http.ResponseWriter, r *http.Request) {
+	quotas := []api.QuotaStatus{}
+	for _, usage := range s.quotas.Usages() {
+		quotas = append(quotas, api.QuotaStatus{
+			Provider:  usage.Provider,
+			Limit:     usage.Limit,
+			Used:      usage.Used,
+			Remaining: usage.Remaining(),
+			ResetAt:   usage.ResetAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, api.QuotaList{Quotas: quotas})
+}
+
+// GetEvents returns the counts of the published events.
+func (s *StatusServer) GetEvents(w http.ResponseWriter, r *http.Request) {
+	events := []api.EventStats{}
+	for _, event := range s.events.Snapshot() {
+		events = append(events, api.EventStats{Name: event.Name, Count: event.Count, LastPublished: event.LastPublished})
+	}
+	writeJSON(w, http.StatusOK, api.EventList{Events: events})
+}
+
+// ListJobs returns the scheduled jobs with their dependencies and latest results.
+func (s *StatusServer) ListJobs(w http.ResponseWriter, r *http.Request) {
+	states := []api.JobState{}
+	for _, state := range s.jobs.JobStates() {
+		jobState := api.JobState{Name: state.Name, DependsOn: state.DependsOn, Chained: state.Chained}
+		if state.Last != nil {
+			jobState.Last = &api.JobResult{Status: state.Last.Status, At: state.Last.At, Error: state.Last.Error}
+		}
+		states = append(states, jobState)
+	}
+	writeJSON(w, http.StatusOK, api.JobList{Jobs: s.jobs.JobNames(), States: states})
+}
+
+// RunJob queues a scheduled job to run now.
+func (s *StatusServer) RunJob(w http.ResponseWriter, r *http.Request, name string) {
+	err := s.jobs.TriggerJob(name)
+	switch {
+	case errors.Is(err, ErrUnknownJob):
+		writeJSON(w, http.StatusNotFound, api.Error{Error: err.Error()})
+	case errors.Is(err, ErrJobQueueFull):
+		writeJSON(w, http.StatusServiceUnavailable, api.Error{Error: err.Error()})
+	case err != nil:
+		writeJSON(w, http.StatusInternalServerError, api.Error{Error: err.Error()})
+	default:
+		writeJSON(w, http.StatusAccepted, api.JobAccepted{Job: name, Status: "accepted"})
+	}
+}
+
+// GetPortfolioHistory returns the daily portfolio valuation over the period, 1M by default.
+func (s *StatusServer) GetPortfolioHistory(w http.ResponseWriter, r *http.Request, params api.GetPortfolioHistoryParams) {
+	if s.history == nil {
+		writeJSON(w, http.StatusNotFound, api.Error{Error: "portfolio history is not available"})
+		return
+	}
+
+	period := domain.HistoryPeriod1M
+	if params.Period != nil {
+		parsed, err := domain.ParseHistoryPeriod(string(*params.Period))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, api.Error{Error: err.Error()})
+			return
+		}
+		period = parsed
+	}
+
+	history, err := s.history.GetHistory(r.Context(), period)
+	if err != nil {
+		logrus.Errorf("Failed to get portfolio history: %v", err)
+		writeJSON(w, http.StatusInternalServerError, api.Error{Error: "failed to get portfolio history"})
+		return
+	}
+
+	points := make([]api.PortfolioHistoryPoint, 0, len(history.Points))
+	for _, point := range history.Points {
+		points = append(points, api.PortfolioHistoryPoint{
+			Date:         point.Date,
+			TotalValue:   point.TotalValue,
+			TotalCost:    point.TotalCost,
+			TotalGain:    point.TotalGain,
+			GainPercent:  point.GainPercent,
+			Interpolated: point.Interpolated,
+		})
+	}
+	writeJSON(w, http.StatusOK, api.PortfolioHistory{
+		Period: api.PortfolioHistoryPeriod(history.Period),
+		From:   history.From,
+		To:     history.To,
+		Points: points,
+	})
+}
+
+// ListTradeNotes returns the trade notes of a stock, or of all stocks, newest first.
+func (s *StatusServer) ListTradeNotes(w http.ResponseWriter, r *http.Request, params api.ListTradeNotesParams) {
+	if s.notes == nil {
+		writeJSON(w, http.StatusNotFound, api.Error{Error: "trade notes are not available"})
+		return
+	}
+
+	var code string
+	if params.Code != nil {
+		code = *params.Code
+	}
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	notes, err := s.notes.ListNotes(r.Context(), code, limit)
+	if err != nil {
+		logrus.Errorf("Failed to list trade notes: %v", err)
+		writeJSON(w, http.StatusInternalServerError, api.Error{Error: "failed to list trade notes"})
+		return
+	}
+
+	result := make([]api.TradeNote, 0, len(notes))
+	for _, note := range notes {
+		result = append(result, toTradeNote(note))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// AddTradeNote records a trade note on a stock.
+func (s *StatusServer) AddTradeNote(w http.ResponseWriter, r *http.Request) {
+	if s.notes == nil {
+		writeJSON(w, http.StatusNotFound, api.Error{Error: "trade notes are not available"})
+		return
+	}
+
+	var body api.AddTradeNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, api.Error{Error: err.Error()})
+		return
+	}
+	input := usecase.TradeNoteInput{Code: body.Code, Body: body.Body, Source: body.Source}
+	if body.Kind != nil {
+		input.Kind = string(*body.Kind)
+	}
+
+	note, err := s.notes.AddNote(r.Context(), input)
+	switch {
+	case errors.Is(err, usecase.ErrInvalidTradeNote):
+		writeJSON(w, http.StatusBadRequest, api.Error{Error: err.Error()})
+	case err != nil:
+		logrus.Errorf("Failed to add trade note: %v", err)
+		writeJSON(w, http.StatusInternalServerError, api.Error{Error: "failed to add trade note"})
+	default:
+		writeJSON(w, http.StatusCreated, toTradeNote(note))
+	}
+}
+
+// GetTradeNote returns a trade note.
+func (s *StatusServer) GetTradeNote(w http.ResponseWriter, r *http.Request, id string) {
+	if s.notes == nil {
+		writeJSON(w, http.StatusNotFound, api.Error{Error: "trade notes are not available"})
+		return
+	}
+
+	note, err := s.notes.GetNote(r.Context(), id)
+	switch {
+	case errors.Is(err, usecase.ErrTradeNoteNotFound):
+		writeJSON(w, http.StatusNotFound, api.Error{Error: err.Error()})
+	case err != nil:
+		logrus.Errorf("Failed to get trade note: %v", err)
+		writeJSON(w, http.StatusInternalServerError, api.Error{Error: "failed to get trade note"})
+	default:
+		writeJSON(w, http.StatusOK, toTradeNote(note))
+	}
+}
+
+// DeleteTradeNote removes a trade note.
+func (s *StatusServer) DeleteTradeNote(w http.ResponseWriter, r *http.Request, id string) {
+	if s.notes == nil {
+		writeJSON(w, http.StatusNotFound, api.Error{Error: "trade notes are not available"})
+		return
+	}
+
+	err := s.notes.DeleteNote(r.Context(), id)
+	switch {
+	case errors.Is(err, usecase.ErrTradeNoteNotFound):
+		writeJSON(w, http.StatusNotFound, api.Error{Error: err.Error()})
+	case err != nil:
+		logrus.Errorf("Failed to remove trade note: %v", err)
+		writeJSON(w, http.StatusInternalServerError, api.Error{Error: "failed to remove trade note"})
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// toTradeNote converts a trade note to its response.
+func toTradeNote(note *models.TradeNote) api.TradeNote {
+	return api.TradeNote{
+		Id:        note.ID,
+		Code:      note.Code,
+		Kind:      api.TradeNoteKind(note.Kind),
+		Body:      note.Body,
+		Source:    note.Source,
+		CreatedAt: note.CreatedAt,
+	}
+}
+
+// GetSharedReport serves the report page of a share link. The page is not cached, indexed or
+// sent as referrer, since the token in its URL grants access.
+func (s *StatusServer) GetSharedReport(w http.ResponseWriter, r *http.Request, token string) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+	if s.shares == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	// Nothing is written on error, so the error response replaces the page
+	err := s.shares.WriteSharedReport(r.Context(), w, token)
+	switch {
+	case errors.Is(err, usecase.ErrShareLinkUnavailable):
+		http.Error(w, "This link has expired or is not valid.", http.StatusNotFound)
+	case err != nil:
+		logrus.Errorf("Failed to write shared report: %v", err)
+		http.Error(w, "The report is not available now.", http.StatusInternalServerError)
+	}
+}
+
+// authenticate requires the admin token on the operations secured by bearerAuth in the schema.
+func (s *StatusServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, secured := r.Context().Value(api.BearerAuthScopes).([]string); secured {
+			s.requireAdmin(next.ServeHTTP)(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireAdmin rejects requests without the admin token. The admin endpoints are disabled
+// when no token is configured, since the server listens on all interfaces.
+func (s *StatusServer) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.AdminToken == "" {
+			writeJSON(w, http.StatusForbidden, api.Error{Error: "admin endpoints are disabled without SERVER_ADMIN_TOKEN"})
+			return
+		}
+		want := []byte("Bearer " + s.config.AdminToken)
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			writeJSON(w, http.StatusUnauthorized, api.Error{Error: "unauthorized"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logrus.Warnf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Subsystem is a long-running part of the application managed by the supervisor.
+// Run blocks until ctx is canceled or the subsystem fails.
+type Subsystem interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// SubsystemState is the running state of a subsystem.
+type SubsystemState string
+
+const (
+	SubsystemRunning    SubsystemState = "running"
+	SubsystemRestarting SubsystemState = "restarting"
+	SubsystemStopped    SubsystemState = "stopped"
+)
+
+// SubsystemStatus represents the state of a subsystem.
+type SubsystemStatus struct {
+	Name      string         `json:"name"`
+	State     SubsystemState `json:"state"`
+	Restarts  int            `json:"restarts"`
+	StartedAt time.Time      `json:"started_at"`
+	LastError string         `json:"last_error,omitempty"`
+}
+
+// SupervisorStatus represents the state of all subsystems.
+type SupervisorStatus struct {
+	StartedAt  time.Time         `json:"started_at"`
+	Subsystems []SubsystemStatus `json:"subsystems"`
+}
+
+// Supervisor runs subsystems as goroutines in a single process and restarts
+// them with exponential backoff when they fail or panic.
+type Supervisor struct {
+	subsystems      []Subsystem
+	restartDelay    time.Duration
+	maxRestartDelay time.Duration
+
+	mu        sync.RWMutex
+	startedAt time.Time
+	statuses  map[string]*SubsystemStatus
+}
+
+// NewSupervisor creates a new supervisor.
+func NewSupervisor() *Supervisor {
+	return &Supervisor{
+		restartDelay:    time.Second,
+		maxRestartDelay: time.Minute,
+		statuses:        make(map[string]*SubsystemStatus),
+	}
+}
+
+// Add registers a subsystem. Subsystems must be added before Run.
+func (s *Supervisor) Add(subsystem Subsystem) {
+	s.subsystems = append(s.subsystems, subsystem)
+	s.statuses[subsystem.Name()] = &SubsystemStatus{
+		Name:  subsystem.Name(),
+		State: SubsystemStopped,
+	}
+}
+
+// Run starts all subsystems and blocks until ctx is canceled and all subsystems have stopped.
+func (s *Supervisor) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.startedAt = time.Now()
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, subsystem := range s.subsystems {
+		wg.Add(1)
+		go func(subsystem Subsystem) {
+			defer wg.Done()
+			s.supervise(ctx, subsystem)
+		}(subsystem)
+	}
+
+	wg.Wait()
+	logrus.Info("All subsystems stopped")
+}
+
+// Status returns the state of all subsystems in registration order.
+func (s *Supervisor) Status() SupervisorStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := SupervisorStatus{
+		StartedAt:  s.startedAt,
+		Subsystems: make([]SubsystemStatus, 0, len(s.subsystems)),
+	}
+	for _, subsystem := range s.subsystems {
+		status.Subsystems = append(status.Subsystems, *s.statuses[subsystem.Name()])
+	}
+	return status
+}
+
+// supervise runs a subsystem until ctx is canceled, restarting it whenever it stops on its own.
+func (s *Supervisor) supervise(ctx context.Context, subsystem Subsystem) {
+	name := subsystem.Name()
+	delay := s.restartDelay
+
+	for {
+		startedAt := time.Now()
+
+		err := s.runSafely(ctx, subsystem, startedAt)
+		if ctx.Err() != nil {
+			if errors.Is(err, context.Canceled) {
+				err = nil
+			}
+			s.setState(name, SubsystemStopped, err)
+			logrus.Infof("Subsystem %s stopped", name)
+			return
+		}
+
+		if err == nil {
+			err = fmt.Errorf("subsystem exited unexpectedly")
+		}
+
+		// Reset the backoff if the subsystem was running stably
+		if time.Since(startedAt) > s.maxRestartDelay {
+			delay = s.restartDelay
+		}
+
+		s.setState(name, SubsystemRestarting, err)
+		logrus.Errorf("Subsystem %s failed, restarting in %v: %v", name, delay, err)
+
+		select {
+		case <-ctx.Done():
+			s.setState(name, SubsystemStopped, err)
+			return
+		case <-time.After(delay):
+		}
+
+		s.mu.Lock()
+		s.statuses[name].Restarts++
+		s.mu.Unlock()
+
+		delay *= 2
+		if delay > s.maxRestartDelay {
+			delay = s.maxRestartDelay
+		}
+	}
+}
+
+// runSafely runs a subsystem and converts a panic into an error.
+func (s *Supervisor) runSafely(ctx context.Context, subsystem Subsystem, startedAt time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	s.mu.Lock()
+	status := s.statuses[subsystem.Name()]
+	status.State = SubsystemRunning
+	status.StartedAt = startedAt
+	s.mu.Unlock()
+
+	logrus.Infof("Subsystem %s started", subsystem.Name())
+	return subsystem.Run(ctx)
+}
+
+// setState updates the state of a subsystem, recording err as its last error if not nil.
+func (s *Supervisor) setState(name string, state SubsystemState, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.statuses[name]
+	status.State = state
+	if err != nil {
+		status.LastError = err.Error()
+	}
+}
@@ -0,0 +1,572 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/config"
+	"github.com/boost-jp/stock-automation/app/infrastructure/eventbus"
+	"github.com/boost-jp/stock-automation/app/interfaces/api"
+	"github.com/boost-jp/stock-automation/app/usecase"
+)
+
+// fakeSubsystem fails the first failures runs and then blocks until canceled.
+type fakeSubsystem struct {
+	name     string
+	failures int32
+	panics   bool
+	runs     atomic.Int32
+}
+
+func (f *fakeSubsystem) Name() string { return f.name }
+
+func (f *fakeSubsystem) Run(ctx context.Context) error {
+	if f.runs.Add(1) <= f.failures {
+		if f.panics {
+			panic("boom")
+		}
+		return errors.New("boom")
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func newTestSupervisor(subsystems ...Subsystem) *Supervisor {
+	s := NewSupervisor()
+	s.restartDelay = time.Millisecond
+	s.maxRestartDelay = 10 * time.Millisecond
+	for _, subsystem := range subsystems {
+		s.Add(subsystem)
+	}
+	return s
+}
+
+func waitForState(t *testing.T, s *Supervisor, name string, state SubsystemState) SubsystemStatus {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, status := range s.Status().Subsystems {
+			if status.Name == name && status.State == state {
+				return status
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Subsystem %s did not reach state %s: %+v", name, state, s.Status())
+	return SubsystemStatus{}
+}
+
+func TestSupervisor_RestartsFailedSubsystems(t *testing.T) {
+	failing := &fakeSubsystem{name: "failing", failures: 2}
+	panicking := &fakeSubsystem{name: "panicking", failures: 1, panics: true}
+	s := newTestSupervisor(failing, panicking)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	// Wait until both subsystems are running after their restarts
+	deadline := time.Now().Add(2 * time.Second)
+	for failing.runs.Load() < 3 || panicking.runs.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Subsystems were not restarted: %+v", s.Status())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	status := waitForState(t, s, "failing", SubsystemRunning)
+	if status.Restarts != 2 || status.LastError != "boom" {
+		t.Errorf("failing status = %+v, want 2 restarts with last error boom", status)
+	}
+	status = waitForState(t, s, "panicking", SubsystemRunning)
+	if status.Restarts != 1 || status.LastError != "panic: boom" {
+		t.Errorf("panicking status = %+v, want 1 restart with last error panic: boom", status)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Supervisor did not stop after cancel")
+	}
+
+	for _, status := range s.Status().Subsystems {
+		if status.State != SubsystemStopped {
+			t.Errorf("Subsystem %s state = %s, want stopped", status.Name, status.State)
+		}
+	}
+}
+
+func TestJobWorker_RunsJobsInOrder(t *testing.T) {
+	worker := NewJobWorker(10)
+
+	var order []string
+	done := make(chan struct{})
+	for _, name := range []string{"first", "second", "third"} {
+		if !worker.Submit(Job{Name: name, Run: func(ctx context.Context) error {
+			order = append(order, name)
+			if name == "third" {
+				close(done)
+			}
+			return nil
+		}}) {
+			t.Fatalf("Submit(%s) = false, want true", name)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Run(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Jobs were not executed")
+	}
+
+	want := []string{"first", "second", "third"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("Job order = %v, want %v", order, want)
+			break
+		}
+	}
+}
+
+func TestJobWorker_SubmitFullQueue(t *testing.T) {
+	worker := NewJobWorker(1)
+	job := Job{Name: "job", Run: func(ctx context.Context) error { return nil }}
+
+	if !worker.Submit(job) {
+		t.Fatal("First Submit() = false, want true")
+	}
+	if worker.Submit(job) {
+		t.Error("Submit() to a full queue = true, want false")
+	}
+}
+
+func TestStatusServer_Handler(t *testing.T) {
+	s := newTestSupervisor(&fakeSubsystem{name: "scheduler"})
+	events := eventbus.NewStats()
+	if err := events.Record(context.Background(), domain.PriceUpdatedEvent{}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	server := httptest.NewServer(NewStatusServer(config.ServerConfig{}, s, &fakeJobTrigger{}, nil, events).Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET /health error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /health status = %d, want 200", resp.StatusCode)
+	}
+
+	resp, err = http.Get(server.URL + "/status")
+	if err != nil {
+		t.Fatalf("GET /status error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var status SupervisorStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode status: %v", err)
+	}
+	if len(status.Subsystems) != 1 || status.Subsystems[0].Name != "scheduler" || status.Subsystems[0].State != SubsystemStopped {
+		t.Errorf("GET /status = %+v, want stopped scheduler", status)
+	}
+
+	resp, err = http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET /events error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var published struct {
+		Events []eventbus.EventStats `json:"events"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&published); err != nil {
+		t.Fatalf("Failed to decode events: %v", err)
+	}
+	if len(published.Events) != 1 || published.Events[0].Name != domain.EventPriceUpdated || published.Events[0].Count != 1 {
+		t.Errorf("GET /events = %+v, want one %s event", published.Events, domain.EventPriceUpdated)
+	}
+}
+
+// fakeJobTrigger records triggered jobs and knows a single job.
+type fakeJobTrigger struct {
+	full      bool
+	triggered []string
+}
+
+func (f *fakeJobTrigger) JobNames() []string { return []string{JobPriceUpdate} }
+
+func (f *fakeJobTrigger) JobStates() []JobState { return []JobState{{Name: JobPriceUpdate}} }
+
+func (f *fakeJobTrigger) TriggerJob(name string) error {
+	if name != JobPriceUpdate {
+		return ErrUnknownJob
+	}
+	if f.full {
+		return ErrJobQueueFull
+	}
+	f.triggered = append(f.triggered, name)
+	return nil
+}
+
+func TestStatusServer_TriggerJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		job        string
+		token      string
+		noToken    bool
+		full       bool
+		wantStatus int
+	}{
+		{name: "accepted", job: JobPriceUpdate, token: "secret", wantStatus: http.StatusAccepted},
+		{name: "unknown job", job: "unknown", token: "secret", wantStatus: http.StatusNotFound},
+		{name: "queue full", job: JobPriceUpdate, token: "secret", full: true, wantStatus: http.StatusServiceUnavailable},
+		{name: "wrong token", job: JobPriceUpdate, token: "wrong", wantStatus: http.StatusUnauthorized},
+		{name: "missing token", job: JobPriceUpdate, wantStatus: http.StatusUnauthorized},
+		{name: "disabled without configured token", job: JobPriceUpdate, noToken: true, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.ServerConfig{AdminToken: "secret"}
+			if tt.noToken {
+				cfg.AdminToken = ""
+			}
+			trigger := &fakeJobTrigger{full: tt.full}
+			server := httptest.NewServer(NewStatusServer(cfg, newTestSupervisor(), trigger, nil, nil).Handler())
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/admin/jobs/"+tt.job+"/run", nil)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("POST /admin/jobs/%s/run error = %v", tt.job, err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("POST /admin/jobs/%s/run status = %d, want %d", tt.job, resp.StatusCode, tt.wantStatus)
+			}
+			wantTriggered := tt.wantStatus == http.StatusAccepted
+			if triggered := len(trigger.triggered) == 1; triggered != wantTriggered {
+				t.Errorf("job triggered = %v, want %v", triggered, wantTriggered)
+			}
+		})
+	}
+}
+
+// fakeSharedReports writes a page for the token "valid" only.
+type fakeSharedReports struct {
+	err error
+}
+
+func (f *fakeSharedReports) WriteSharedReport(ctx context.Context, w io.Writer, token string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if token != "valid" {
+		return usecase.ErrShareLinkUnavailable
+	}
+	_, err := io.WriteString(w, "<html>report</html>")
+	return err
+}
+
+func TestStatusServer_SharedReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		err        error
+		wantStatus int
+	}{
+		{name: "valid", token: "valid", wantStatus: http.StatusOK},
+		{name: "invalid", token: "invalid", wantStatus: http.StatusNotFound},
+		{name: "report fails", token: "valid", err: errors.New("database down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusServer := NewStatusServer(config.ServerConfig{}, newTestSupervisor(), &fakeJobTrigger{}, nil, nil)
+			statusServer.SetSharedReports(&fakeSharedReports{err: tt.err})
+			server := httptest.NewServer(statusServer.Handler())
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + "/share/" + tt.token)
+			if err != nil {
+				t.Fatalf("GET /share/%s error = %v", tt.token, err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("GET /share/%s status = %d, want %d", tt.token, resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get("Cache-Control"); got != "no-store" {
+				t.Errorf("Cache-Control = %q, want no-store", got)
+			}
+			if gotPage := string(body) == "<html>report</html>"; gotPage != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("GET /share/%s body = %q", tt.token, body)
+			}
+		})
+	}
+
+	// The route is not served without share links
+	server := httptest.NewServer(NewStatusServer(config.ServerConfig{}, newTestSupervisor(), &fakeJobTrigger{}, nil, nil).Handler())
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/share/valid")
+	if err != nil {
+		t.Fatalf("GET /share/valid error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /share/valid without share links status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestStatusServer_GraphQL(t *testing.T) {
+	statusServer := NewStatusServer(config.ServerConfig{AdminToken: "secret"}, newTestSupervisor(), &fakeJobTrigger{}, nil, nil)
+	statusServer.SetGraphQL(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": nil})
+	}))
+	server := httptest.NewServer(statusServer.Handler())
+	defer server.Close()
+
+	for token, wantStatus := range map[string]int{"secret": http.StatusOK, "": http.StatusUnauthorized} {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/graphql", strings.NewReader(`{"query":"{ watchList { code } }"}`))
+		if err != nil {
+			t.Fatalf("NewRequest() error = %v", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST /graphql error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != wantStatus {
+			t.Errorf("POST /graphql with token %q status = %d, want %d", token, resp.StatusCode, wantStatus)
+		}
+	}
+}
+
+// fakePortfolioHistory returns one point for the requested period.
+type fakePortfolioHistory struct {
+	periods []domain.HistoryPeriod
+}
+
+func (f *fakePortfolioHistory) GetHistory(ctx context.Context, period domain.HistoryPeriod) (*usecase.PortfolioHistory, error) {
+	f.periods = append(f.periods, period)
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	return &usecase.PortfolioHistory{
+		Period: period,
+		From:   date,
+		To:     date,
+		Points: []domain.PortfolioHistoryPoint{{Date: date, TotalValue: 1100000, TotalCost: 1000000, TotalGain: 100000, GainPercent: 10}},
+	}, nil
+}
+
+func TestStatusServer_PortfolioHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		token      string
+		wantStatus int
+		wantPeriod domain.HistoryPeriod
+	}{
+		{name: "default period", token: "secret", wantStatus: http.StatusOK, wantPeriod: domain.HistoryPeriod1M},
+		{name: "3M", query: "?period=3M", token: "secret", wantStatus: http.StatusOK, wantPeriod: domain.HistoryPeriod3M},
+		{name: "invalid period", query: "?period=5Y", token: "secret", wantStatus: http.StatusBadRequest},
+		{name: "missing token", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &fakePortfolioHistory{}
+			statusServer := NewStatusServer(config.ServerConfig{AdminToken: "secret"}, newTestSupervisor(), &fakeJobTrigger{}, nil, nil)
+			statusServer.SetPortfolioHistory(history)
+			server := httptest.NewServer(statusServer.Handler())
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/portfolio/history"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET /portfolio/history error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("GET /portfolio/history%s status = %d, want %d", tt.query, resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if len(history.periods) != 0 {
+					t.Errorf("history read for a rejected request: %v", history.periods)
+				}
+				return
+			}
+
+			var got api.PortfolioHistory
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode history: %v", err)
+			}
+			if got.Period != api.PortfolioHistoryPeriod(tt.wantPeriod) || len(got.Points) != 1 || got.Points[0].TotalGain != 100000 {
+				t.Errorf("GET /portfolio/history%s = %+v", tt.query, got)
+			}
+		})
+	}
+}
+
+// fakeTradeNotes keeps the trade notes in memory.
+type fakeTradeNotes struct {
+	notes map[string]*models.TradeNote
+}
+
+func (f *fakeTradeNotes) AddNote(ctx context.Context, input usecase.TradeNoteInput) (*models.TradeNote, error) {
+	if strings.HasPrefix(input.Source, "bad") {
+		return nil, usecase.ErrInvalidTradeNote
+	}
+	note := &models.TradeNote{ID: "01HQNOTE", Code: input.Code, Kind: input.Kind, Body: input.Body, Source: input.Source}
+	f.notes[note.ID] = note
+	return note, nil
+}
+
+func (f *fakeTradeNotes) ListNotes(ctx context.Context, code string, limit int) ([]*models.TradeNote, error) {
+	var notes []*models.TradeNote
+	for _, note := range f.notes {
+		if code == "" || note.Code == code {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+func (f *fakeTradeNotes) GetNote(ctx context.Context, id string) (*models.TradeNote, error) {
+	note, ok := f.notes[id]
+	if !ok {
+		return nil, usecase.ErrTradeNoteNotFound
+	}
+	return note, nil
+}
+
+func (f *fakeTradeNotes) DeleteNote(ctx context.Context, id string) error {
+	if _, ok := f.notes[id]; !ok {
+		return usecase.ErrTradeNoteNotFound
+	}
+	delete(f.notes, id)
+	return nil
+}
+
+func TestStatusServer_TradeNotes(t *testing.T) {
+	notes := &fakeTradeNotes{notes: map[string]*models.TradeNote{}}
+	statusServer := NewStatusServer(config.ServerConfig{AdminToken: "secret"}, newTestSupervisor(), &fakeJobTrigger{}, nil, nil)
+	statusServer.SetTradeNotes(notes)
+	server := httptest.NewServer(statusServer.Handler())
+	defer server.Close()
+
+	do := func(method, path, body, token string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest() error = %v", err)
+		}
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s error = %v", method, path, err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	if resp := do(http.MethodGet, "/notes", "", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /notes without token status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	rejected := []struct {
+		name string
+		body string
+	}{
+		{name: "unknown kind", body: `{"code":"7203","kind":"idea","body":"決算後の押し目"}`},
+		{name: "empty body", body: `{"code":"7203","body":""}`},
+		{name: "invalid source", body: `{"code":"7203","body":"決算後の押し目","source":"bad"}`},
+	}
+	for _, tt := range rejected {
+		if resp := do(http.MethodPost, "/notes", tt.body, "secret"); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("POST /notes with %s status = %d, want %d", tt.name, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+	if len(notes.notes) != 0 {
+		t.Fatalf("saved %d notes, want the invalid ones rejected", len(notes.notes))
+	}
+
+	resp := do(http.MethodPost, "/notes", `{"code":"7203","kind":"entry","body":"決算後の押し目","source":"signal:rsi-oversold"}`, "secret")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /notes status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+	var added api.TradeNote
+	if err := json.NewDecoder(resp.Body).Decode(&added); err != nil {
+		t.Fatalf("Failed to decode note: %v", err)
+	}
+	if added.Id != "01HQNOTE" || added.Kind != api.Entry || added.Source != "signal:rsi-oversold" {
+		t.Errorf("POST /notes = %+v", added)
+	}
+
+	resp = do(http.MethodGet, "/notes?code=7203&limit=5", "", "secret")
+	var listed []api.TradeNote
+	if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil {
+		t.Fatalf("Failed to decode notes: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || len(listed) != 1 || listed[0].Body != "決算後の押し目" {
+		t.Errorf("GET /notes status = %d, notes = %+v", resp.StatusCode, listed)
+	}
+	if resp := do(http.MethodGet, "/notes?limit=0", "", "secret"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("GET /notes?limit=0 status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	if resp := do(http.MethodGet, "/notes/01HQNOTE", "", "secret"); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /notes/01HQNOTE status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if resp := do(http.MethodDelete, "/notes/01HQNOTE", "", "secret"); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("DELETE /notes/01HQNOTE status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	if resp := do(http.MethodGet, "/notes/01HQNOTE", "", "secret"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET of a removed note status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	if resp := do(http.MethodDelete, "/notes/01HQNOTE", "", "secret"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("DELETE of a removed note status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,579 @@
+package interfaces
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// ANSI escape sequences used to draw the dashboard.
+const (
+	ansiClear      = "\x1b[H\x1b[2J"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+	ansiReverse    = "\x1b[7m"
+	ansiBold       = "\x1b[1m"
+	ansiReset      = "\x1b[0m"
+	ansiGreen      = "\x1b[32m"
+	ansiRed        = "\x1b[31m"
+)
+
+// tuiView identifies a tab of the dashboard.
+type tuiView int
+
+const (
+	tuiViewPortfolio tuiView = iota
+	tuiViewWatchlist
+	tuiViewSignals
+	tuiViewCount
+)
+
+// title returns the tab title of the view.
+func (v tuiView) title() string {
+	switch v {
+	case tuiViewPortfolio:
+		return "Portfolio"
+	case tuiViewWatchlist:
+		return "Watchlist"
+	default:
+		return "Signals"
+	}
+}
+
+// tuiCommand is the side effect requested by a key press, run by the dashboard loop.
+type tuiCommand int
+
+const (
+	tuiCommandNone tuiCommand = iota
+	tuiCommandQuit
+	tuiCommandRefresh
+	tuiCommandInspect
+)
+
+// tuiWatchItem is a watch list row of the dashboard.
+type tuiWatchItem struct {
+	Code            string
+	Name            string
+	Price           float64
+	TargetBuyPrice  *float64
+	TargetSellPrice *float64
+}
+
+// tuiSignalItem is a signal row of the dashboard.
+type tuiSignalItem struct {
+	Code   string
+	Name   string
+	Price  float64
+	Signal *domain.TradingSignal
+}
+
+// tuiData is the data shown on the dashboard, reloaded on every refresh.
+type tuiData struct {
+	Portfolio *domain.PortfolioSummary
+	Watchlist []tuiWatchItem
+	Signals   []tuiSignalItem
+	UpdatedAt time.Time
+	Err       error
+}
+
+// tuiModel holds the state of the dashboard. It is updated by key presses and rendered
+// by View, while loading data and reading keys are left to the dashboard loop.
+type tuiModel struct {
+	view     tuiView
+	selected [tuiViewCount]int
+	data     tuiData
+	detail   string // inspection of the selected stock, shown instead of the tabs when set
+}
+
+// setData replaces the dashboard data and keeps the selections within the rows.
+func (m *tuiModel) setData(data tuiData) {
+	m.data = data
+	for v := tuiView(0); v < tuiViewCount; v++ {
+		if n := len(m.codes(v)); m.selected[v] >= n {
+			m.selected[v] = max(n-1, 0)
+		}
+	}
+}
+
+// codes returns the stock codes of the rows of the view.
+func (m *tuiModel) codes(v tuiView) []string {
+	var codes []string
+	switch v {
+	case tuiViewPortfolio:
+		if m.data.Portfolio != nil {
+			for _, h := range m.data.Portfolio.Holdings {
+				codes = append(codes, h.Code)
+			}
+		}
+	case tuiViewWatchlist:
+		for _, w := range m.data.Watchlist {
+			codes = append(codes, w.Code)
+		}
+	case tuiViewSignals:
+		for _, s := range m.data.Signals {
+			codes = append(codes, s.Code)
+		}
+	}
+	return codes
+}
+
+// selectedCode returns the stock code of the selected row, or "" if the view has no rows.
+func (m *tuiModel) selectedCode() string {
+	codes := m.codes(m.view)
+	if len(codes) == 0 {
+		return ""
+	}
+	return codes[m.selected[m.view]]
+}
+
+// Update applies a key press and returns the command to run.
+func (m *tuiModel) Update(key string) tuiCommand {
+	switch key {
+	case "q", "ctrl+c":
+		return tuiCommandQuit
+	case "r":
+		return tuiCommandRefresh
+	}
+
+	if m.detail != "" {
+		if key == "esc" || key == "backspace" || key == "left" || key == "h" {
+			m.detail = ""
+		}
+		return tuiCommandNone
+	}
+
+	switch key {
+	case "1", "2", "3":
+		m.view = tuiView(key[0] - '1')
+	case "tab", "right", "l":
+		m.view = (m.view + 1) % tuiViewCount
+	case "shift+tab", "left", "h":
+		m.view = (m.view + tuiViewCount - 1) % tuiViewCount
+	case "down", "j":
+		if m.selected[m.view] < len(m.codes(m.view))-1 {
+			m.selected[m.view]++
+		}
+	case "up", "k":
+		if m.selected[m.view] > 0 {
+			m.selected[m.view]--
+		}
+	case "enter":
+		if m.selectedCode() != "" {
+			return tuiCommandInspect
+		}
+	}
+	return tuiCommandNone
+}
+
+// View renders the dashboard.
+func (m *tuiModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ansiBold + "📊 Stock Automation" + ansiReset + "  ")
+	for v := tuiView(0); v < tuiViewCount; v++ {
+		tab := fmt.Sprintf(" %d %s ", v+1, v.title())
+		if v == m.view && m.detail == "" {
+			tab = ansiReverse + tab + ansiReset
+		}
+		b.WriteString(tab)
+	}
+	if !m.data.UpdatedAt.IsZero() {
+		fmt.Fprintf(&b, "  updated %s", m.data.UpdatedAt.Format("15:04:05"))
+	}
+	b.WriteString("\n\n")
+
+	if m.detail != "" {
+		b.WriteString(m.detail)
+		b.WriteString("\nEsc: back  r: refresh  q: quit\n")
+		return b.String()
+	}
+
+	if m.data.Err != nil {
+		fmt.Fprintf(&b, ansiRed+"⚠️ %v"+ansiReset+"\n\n", m.data.Err)
+	}
+
+	switch m.view {
+	case tuiViewPortfolio:
+		m.renderPortfolio(&b)
+	case tuiViewWatchlist:
+		m.renderWatchlist(&b)
+	case tuiViewSignals:
+		m.renderSignals(&b)
+	}
+
+	b.WriteString("\n1-3/Tab: switch  j/k: move  Enter: details  r: refresh  q: quit\n")
+	return b.String()
+}
+
+func (m *tuiModel) renderPortfolio(b *strings.Builder) {
+	summary := m.data.Portfolio
+	if summary == nil || len(summary.Holdings) == 0 {
+		b.WriteString("No holdings\n")
+		return
+	}
+
+	fmt.Fprintf(b, "Value ¥%s  Cost ¥%s  Gain %s\n\n",
+		formatAmount(summary.TotalValue), formatAmount(summary.TotalCost),
+		colorGain(fmt.Sprintf("¥%s (%+.2f%%)", formatAmount(summary.TotalGain), summary.TotalGainPercent), summary.TotalGain))
+	fmt.Fprintf(b, "  %-8s %-16s %8s %12s %14s %22s\n", "Code", "Name", "Shares", "Price", "Value", "Gain")
+	for i, h := range summary.Holdings {
+		row := fmt.Sprintf("%-8s %-16s %8s %12.2f %14s", h.Code, truncateRunes(h.Name, 16), h.FormatQuantity(), h.CurrentPrice, formatAmount(h.CurrentValue))
+		gain := colorGain(fmt.Sprintf("%22s", fmt.Sprintf("¥%s (%+.2f%%)", formatAmount(h.Gain), h.GainPercent)), h.Gain)
+		m.writeRow(b, i, row+" "+gain)
+	}
+}
+
+func (m *tuiModel) renderWatchlist(b *strings.Builder) {
+	if len(m.data.Watchlist) == 0 {
+		b.WriteString("No active watch list items\n")
+		return
+	}
+
+	fmt.Fprintf(b, "  %-8s %-16s %12s %12s %12s\n", "Code", "Name", "Price", "Buy", "Sell")
+	for i, w := range m.data.Watchlist {
+		row := fmt.Sprintf("%-8s %-16s %12s %12s %12s", w.Code, truncateRunes(w.Name, 16),
+			formatPrice(&w.Price), formatPrice(w.TargetBuyPrice), formatPrice(w.TargetSellPrice))
+		switch {
+		case w.Price > 0 && w.TargetBuyPrice != nil && w.Price <= *w.TargetBuyPrice:
+			row += "  " + ansiGreen + "buy target reached" + ansiReset
+		case w.Price > 0 && w.TargetSellPrice != nil && w.Price >= *w.TargetSellPrice:
+			row += "  " + ansiRed + "sell target reached" + ansiReset
+		}
+		m.writeRow(b, i, row)
+	}
+}
+
+func (m *tuiModel) renderSignals(b *strings.Builder) {
+	if len(m.data.Signals) == 0 {
+		b.WriteString("No signals\n")
+		return
+	}
+
+	fmt.Fprintf(b, "  %-8s %-16s %12s %-6s %6s %7s  %s\n", "Code", "Name", "Price", "Action", "Conf", "Score", "Reason")
+	for i, s := range m.data.Signals {
+		action := fmt.Sprintf("%-6s", strings.ToUpper(s.Signal.Action))
+		switch s.Signal.Action {
+		case "buy":
+			action = ansiGreen + action + ansiReset
+		case "sell":
+			action = ansiRed + action + ansiReset
+		}
+		row := fmt.Sprintf("%-8s %-16s %12.2f %s %5.0f%% %7.1f  %s", s.Code, truncateRunes(s.Name, 16),
+			s.Price, action, s.Signal.Confidence*100, s.Signal.Score, truncateRunes(s.Signal.Reason, 40))
+		m.writeRow(b, i, row)
+	}
+}
+
+// writeRow writes a table row, marking the selected one.
+func (m *tuiModel) writeRow(b *strings.Builder, index int, row string) {
+	if index == m.selected[m.view] {
+		b.WriteString(ansiReverse + "> " + row + ansiReset + "\n")
+		return
+	}
+	b.WriteString("  " + row + "\n")
+}
+
+// colorGain colors text green for gains and red for losses.
+func colorGain(text string, gain float64) string {
+	switch {
+	case gain > 0:
+		return ansiGreen + text + ansiReset
+	case gain < 0:
+		return ansiRed + text + ansiReset
+	default:
+		return text
+	}
+}
+
+// formatAmount formats a yen amount with thousands separators.
+func formatAmount(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := fmt.Sprintf("%.0f", amount)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
+
+// formatPrice formats an optional price, "-" when it is unset or zero.
+func formatPrice(price *float64) string {
+	if price == nil || *price == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *price)
+}
+
+// truncateRunes shortens text to at most n characters.
+func truncateRunes(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// loadTUIData loads the portfolio, the watch list and the signals of the held and watched stocks.
+// Failures are reported on the dashboard instead of ending it.
+func (c *CLI) loadTUIData(ctx context.Context) tuiData {
+	data := tuiData{UpdatedAt: time.Now()}
+	var errs []string
+
+	summary, err := c.container.GetPortfolioReportUseCase().GetPortfolioStatistics(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("portfolio: %v", err))
+	}
+	data.Portfolio = summary
+
+	stockRepo := c.container.GetStockRepository()
+	watchList, err := stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("watch list: %v", err))
+	}
+	codes := make([]string, 0, len(watchList))
+	for _, w := range watchList {
+		codes = append(codes, w.Code)
+	}
+	latest := map[string]float64{}
+	if len(codes) > 0 {
+		prices, err := stockRepo.GetLatestPrices(ctx, codes)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("latest prices: %v", err))
+		}
+		for code, price := range prices {
+			latest[code] = utility.DecimalToFloat(price.ClosePrice)
+		}
+	}
+	for _, w := range watchList {
+		data.Watchlist = append(data.Watchlist, tuiWatchItem{
+			Code:            w.Code,
+			Name:            w.Name,
+			Price:           latest[w.Code],
+			TargetBuyPrice:  utility.NullDecimalToFloatPtr(w.TargetBuyPrice),
+			TargetSellPrice: utility.NullDecimalToFloatPtr(w.TargetSellPrice),
+		})
+	}
+
+	// Signals of the held stocks first, then of the watched ones
+	names := map[string]string{}
+	var signalCodes []string
+	if summary != nil {
+		for _, h := range summary.Holdings {
+			if _, ok := names[h.Code]; !ok {
+				names[h.Code] = h.Name
+				signalCodes = append(signalCodes, h.Code)
+			}
+		}
+	}
+	for _, w := range watchList {
+		if _, ok := names[w.Code]; !ok {
+			names[w.Code] = w.Name
+			signalCodes = append(signalCodes, w.Code)
+		}
+	}
+	analysis := c.container.GetTechnicalAnalysisUseCase()
+	for _, code := range signalCodes {
+		evaluation, err := analysis.EvaluateSignal(ctx, code)
+		if err != nil || evaluation.Signal == nil {
+			continue
+		}
+		data.Signals = append(data.Signals, tuiSignalItem{
+			Code:   code,
+			Name:   names[code],
+			Price:  evaluation.CurrentPrice,
+			Signal: evaluation.Signal,
+		})
+	}
+	// Actionable signals first, the most confident at the top
+	sort.SliceStable(data.Signals, func(i, j int) bool {
+		ai, aj := data.Signals[i].Signal.Action != "hold", data.Signals[j].Signal.Action != "hold"
+		if ai != aj {
+			return ai
+		}
+		return data.Signals[i].Signal.Confidence > data.Signals[j].Signal.Confidence
+	})
+
+	if len(errs) > 0 {
+		data.Err = fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return data
+}
+
+// runTUI runs the interactive terminal dashboard until q is pressed or the process is interrupted
+func (c *CLI) runTUI(args []string) error {
+	flags := flag.NewFlagSet("tui", flag.ContinueOnError)
+	interval := flags.Duration("interval", 30*time.Second, "refresh interval")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return fmt.Errorf("tui requires an interactive terminal")
+	}
+
+	restore, err := enableRawMode()
+	if err != nil {
+		return fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+	// Logs would break the screen, so they are discarded while the dashboard is shown
+	logOutput := logrus.StandardLogger().Out
+	logrus.SetOutput(io.Discard)
+	fmt.Print(ansiHideCursor)
+	defer func() {
+		fmt.Print(ansiReset + ansiShowCursor + ansiClear)
+		logrus.SetOutput(logOutput)
+		restore()
+	}()
+
+	ctx, cancel := c.commandContext(0)
+	defer cancel()
+
+	keys := make(chan string)
+	go readKeys(os.Stdin, keys)
+
+	model := &tuiModel{}
+	render := func() { fmt.Print(ansiClear + model.View()) }
+	fmt.Print(ansiClear + "Loading...\n")
+	model.setData(c.loadTUIData(ctx))
+	render()
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			model.setData(c.loadTUIData(ctx))
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch model.Update(key) {
+			case tuiCommandQuit:
+				return nil
+			case tuiCommandRefresh:
+				model.setData(c.loadTUIData(ctx))
+				if model.detail != "" {
+					model.detail = c.inspectForTUI(ctx, model.selectedCode())
+				}
+			case tuiCommandInspect:
+				model.detail = c.inspectForTUI(ctx, model.selectedCode())
+			}
+		}
+		render()
+	}
+}
+
+// inspectForTUI renders the inspection of a stock for the detail view of the dashboard.
+func (c *CLI) inspectForTUI(ctx context.Context, code string) string {
+	inspection, err := c.container.GetStockInspectionUseCase().Inspect(ctx, code)
+	if err != nil {
+		return fmt.Sprintf("⚠️ failed to inspect %s: %v\n", code, err)
+	}
+	var b strings.Builder
+	writeInspection(&b, inspection)
+	return strings.TrimPrefix(b.String(), "\n")
+}
+
+// enableRawMode switches the terminal to read key presses without echo or line buffering,
+// and returns a function restoring the previous settings.
+func enableRawMode() (func(), error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, err
+	}
+	return func() {
+		if _, err := stty(strings.TrimSpace(saved)); err != nil {
+			logrus.WithError(err).Warn("Failed to restore terminal settings")
+		}
+	}, nil
+}
+
+// stty runs stty on the terminal of the standard input.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %s: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// readKeys reads key presses from r and sends their names to keys until r is closed.
+func readKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 32)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// parseKeys converts the bytes read from the terminal into key names.
+func parseKeys(input []byte) []string {
+	var keys []string
+	for i := 0; i < len(input); i++ {
+		switch b := input[i]; b {
+		case 0x1b:
+			if i+2 < len(input) && input[i+1] == '[' {
+				switch input[i+2] {
+				case 'A':
+					keys = append(keys, "up")
+				case 'B':
+					keys = append(keys, "down")
+				case 'C':
+					keys = append(keys, "right")
+				case 'D':
+					keys = append(keys, "left")
+				case 'Z':
+					keys = append(keys, "shift+tab")
+				}
+				i += 2
+				continue
+			}
+			keys = append(keys, "esc")
+		case '\r', '\n':
+			keys = append(keys, "enter")
+		case '\t':
+			keys = append(keys, "tab")
+		case 0x7f, 0x08:
+			keys = append(keys, "backspace")
+		case 0x03:
+			keys = append(keys, "ctrl+c")
+		default:
+			keys = append(keys, string(rune(b)))
+		}
+	}
+	return keys
+}
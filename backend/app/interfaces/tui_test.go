@@ -0,0 +1,66 @@
+package interfaces
+
+import (
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("j\x1b[A\x1b[B\r\t\x1bq"))
+	want := []string{"j", "up", "down", "enter", "tab", "esc", "q"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseKeys() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTUIModel_Update(t *testing.T) {
+	model := &tuiModel{}
+	model.setData(tuiData{
+		Portfolio: &domain.PortfolioSummary{Holdings: []domain.HoldingSummary{{Code: "7203"}, {Code: "6758"}}},
+		Watchlist: []tuiWatchItem{{Code: "9983"}},
+	})
+
+	// Selection stops at the last row
+	for _, key := range []string{"j", "j", "down"} {
+		model.Update(key)
+	}
+	if got := model.selectedCode(); got != "6758" {
+		t.Errorf("selectedCode() = %s, want 6758", got)
+	}
+
+	// Each view keeps its own selection
+	model.Update("2")
+	if got := model.selectedCode(); got != "9983" {
+		t.Errorf("selectedCode() after switching = %s, want 9983", got)
+	}
+	model.Update("tab")
+	if got := model.Update("enter"); got != tuiCommandNone {
+		t.Errorf("Update(enter) without signals = %v, want none", got)
+	}
+
+	// Enter opens the detail, where moving keys are ignored until Esc
+	model.Update("1")
+	if got := model.Update("enter"); got != tuiCommandInspect {
+		t.Errorf("Update(enter) = %v, want inspect", got)
+	}
+	model.detail = "7203"
+	model.Update("2")
+	if model.view != tuiViewPortfolio {
+		t.Errorf("View switched while the detail is shown")
+	}
+	model.Update("esc")
+	if model.detail != "" {
+		t.Errorf("Detail not closed by esc")
+	}
+
+	// Rows removed on refresh keep the selection in range
+	model.setData(tuiData{Portfolio: &domain.PortfolioSummary{Holdings: []domain.HoldingSummary{{Code: "7203"}}}})
+	if got := model.selectedCode(); got != "7203" {
+		t.Errorf("selectedCode() after refresh = %s, want 7203", got)
+	}
+	if got := model.Update("q"); got != tuiCommandQuit {
+		t.Errorf("Update(q) = %v, want quit", got)
+	}
+}
@@ -0,0 +1,70 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultJobQueueSize is the number of pending jobs the worker holds before rejecting new ones
+const defaultJobQueueSize = 100
+
+// Job is a unit of background work executed by the job worker.
+type Job struct {
+	Name    string
+	Timeout time.Duration // zero means no deadline
+	Run     func(ctx context.Context) error
+	Feature string // feature flag the scheduled runs require, empty if always run
+	// DependsOn lists the jobs whose latest runs must have succeeded for the scheduled runs of this
+	// job. If one of them failed or was skipped, this job is skipped and alerted instead.
+	DependsOn []string
+	// Chained jobs have no schedule of their own and run as soon as all their dependencies succeed.
+	Chained bool
+}
+
+// JobWorker executes jobs submitted by the scheduler one at a time in submission order.
+type JobWorker struct {
+	jobs chan Job
+}
+
+// NewJobWorker creates a job worker holding up to queueSize pending jobs.
+func NewJobWorker(queueSize int) *JobWorker {
+	return &JobWorker{jobs: make(chan Job, queueSize)}
+}
+
+// Name returns the subsystem name.
+func (w *JobWorker) Name() string {
+	return "worker"
+}
+
+// Submit queues a job without blocking. Returns false if the queue is full.
+func (w *JobWorker) Submit(job Job) bool {
+	select {
+	case w.jobs <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+// Pending returns the number of queued jobs.
+func (w *JobWorker) Pending() int {
+	return len(w.jobs)
+}
+
+// Run executes queued jobs until ctx is canceled. Jobs still queued at that point are kept
+// and run when the worker is started again.
+func (w *JobWorker) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			if pending := w.Pending(); pending > 0 {
+				logrus.Warnf("Job worker stopped with %d pending jobs", pending)
+			}
+			return ctx.Err()
+		case job := <-w.jobs:
+			executeJob(ctx, job)
+		}
+	}
+}
@@ -47,17 +47,18 @@ func NewTestDB(t *testing.T) *TestDB {
 	if err != nil {
 		t.Fatalf("Failed to connect to MySQL: %v", err)
 	}
-	defer rootDB.Close()
 
 	// Create test database
 	_, err = rootDB.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", config.DatabaseName))
 	if err != nil {
+		rootDB.Close()
 		t.Fatalf("Failed to create test database: %v", err)
 	}
 
 	// Connect to test database
 	connMgr, err := database.NewConnectionManager(config)
 	if err != nil {
+		rootDB.Close()
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
 
@@ -66,6 +67,7 @@ func NewTestDB(t *testing.T) *TestDB {
 	// Create schema
 	if err := createTestSchema(db); err != nil {
 		connMgr.Close()
+		rootDB.Close()
 		t.Fatalf("Failed to create test schema: %v", err)
 	}
 
@@ -76,8 +78,9 @@ func NewTestDB(t *testing.T) *TestDB {
 		cleanupFuncs:   []func() error{},
 	}
 
-	// Add cleanup function to drop database
+	// Add cleanup function to drop database; the root connection is kept open until then
 	testDB.cleanupFuncs = append(testDB.cleanupFuncs, func() error {
+		defer rootDB.Close()
 		_, err := rootDB.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", config.DatabaseName))
 		return err
 	})
@@ -117,6 +120,7 @@ func (tdb *TestDB) TruncateAll() error {
 	tables := []string{
 		dao.TableNames.Portfolios,
 		dao.TableNames.StockPrices,
+		"latest_prices",
 		dao.TableNames.TechnicalIndicators,
 		dao.TableNames.WatchLists,
 	}
@@ -197,8 +201,11 @@ func createEmbeddedTestSchema(db *sql.DB) error {
 			is_active BOOLEAN NOT NULL DEFAULT TRUE,
 			target_buy_price DECIMAL(10,2),
 			target_sell_price DECIMAL(10,2),
+			collect_interval_minutes INT NOT NULL DEFAULT 0,
+			muted_until DATETIME,
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			deleted_at DATETIME
 		)`,
 		`CREATE TABLE IF NOT EXISTS stock_prices (
 			id VARCHAR(26) PRIMARY KEY,
@@ -208,13 +215,27 @@ func createEmbeddedTestSchema(db *sql.DB) error {
 			high_price DECIMAL(10,2) NOT NULL,
 			low_price DECIMAL(10,2) NOT NULL,
 			close_price DECIMAL(10,2) NOT NULL,
+			adj_close_price DECIMAL(12,4),
 			volume BIGINT NOT NULL,
+			source VARCHAR(20) NOT NULL DEFAULT '',
+			fetched_at DATETIME,
+			is_final BOOLEAN NOT NULL DEFAULT FALSE,
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 			UNIQUE KEY unique_code_date (code, date),
-			INDEX idx_code (code),
 			INDEX idx_date (date)
 		)`,
+		`CREATE TABLE IF NOT EXISTS latest_prices (
+			code VARCHAR(10) PRIMARY KEY,
+			date DATE NOT NULL,
+			open_price DECIMAL(10,2) NOT NULL,
+			high_price DECIMAL(10,2) NOT NULL,
+			low_price DECIMAL(10,2) NOT NULL,
+			close_price DECIMAL(10,2) NOT NULL,
+			adj_close_price DECIMAL(12,4),
+			volume BIGINT NOT NULL,
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+		)`,
 		`CREATE TABLE IF NOT EXISTS technical_indicators (
 			id VARCHAR(26) PRIMARY KEY,
 			code VARCHAR(10) NOT NULL,
@@ -226,23 +247,42 @@ func createEmbeddedTestSchema(db *sql.DB) error {
 			sma_5 DECIMAL(10,2),
 			sma_25 DECIMAL(10,2),
 			sma_75 DECIMAL(10,2),
+			ma25_deviation DECIMAL(7,2),
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 			UNIQUE KEY unique_code_date (code, date),
-			INDEX idx_code (code),
 			INDEX idx_date (date)
 		)`,
 		`CREATE TABLE IF NOT EXISTS portfolios (
 			id VARCHAR(26) PRIMARY KEY,
 			code VARCHAR(10) NOT NULL,
 			name VARCHAR(100) NOT NULL,
-			shares INT NOT NULL,
+			shares BIGINT NOT NULL,
 			purchase_price DECIMAL(10,2) NOT NULL,
 			purchase_date DATE NOT NULL,
+			position_type VARCHAR(10) NOT NULL DEFAULT 'long',
+			margin_rate DECIMAL(5,2),
+			interest_rate DECIMAL(5,2),
+			asset_class VARCHAR(10) NOT NULL DEFAULT 'stock',
+			isin VARCHAR(12),
 			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			deleted_at DATETIME,
 			INDEX idx_code (code)
 		)`,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			name VARCHAR(100) PRIMARY KEY,
+			phase VARCHAR(20) NOT NULL DEFAULT 'pending',
+			total_rows BIGINT NOT NULL DEFAULT 0,
+			backfilled_rows BIGINT NOT NULL DEFAULT 0,
+			last_key VARCHAR(255) NOT NULL DEFAULT '',
+			started_at TIMESTAMP NULL,
+			backfill_started_at TIMESTAMP NULL,
+			backfilled_at TIMESTAMP NULL,
+			completed_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+		)`,
 	}
 
 	// Execute each table creation separately
@@ -5,8 +5,8 @@ import (
 	"time"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
-	"github.com/boost-jp/stock-automation/app/infrastructure/client"
 	"github.com/boost-jp/stock-automation/app/infrastructure/dao"
+	"github.com/boost-jp/stock-automation/app/utility"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -23,7 +23,7 @@ func NewPortfolio() *PortfolioBuilder {
 			Code:          "7203",
 			Name:          "トヨタ自動車",
 			Shares:        100,
-			PurchasePrice: client.FloatToDecimal(2000.0),
+			PurchasePrice: utility.FloatToDecimal(2000.0),
 			PurchaseDate:  time.Now(),
 		},
 	}
@@ -49,13 +49,13 @@ func (b *PortfolioBuilder) WithName(name string) *PortfolioBuilder {
 
 // WithShares sets the number of shares
 func (b *PortfolioBuilder) WithShares(shares int) *PortfolioBuilder {
-	b.portfolio.Shares = shares
+	b.portfolio.Shares = int64(shares)
 	return b
 }
 
 // WithPurchasePrice sets the purchase price
 func (b *PortfolioBuilder) WithPurchasePrice(price float64) *PortfolioBuilder {
-	b.portfolio.PurchasePrice = client.FloatToDecimal(price)
+	b.portfolio.PurchasePrice = utility.FloatToDecimal(price)
 	return b
 }
 
@@ -65,6 +65,18 @@ func (b *PortfolioBuilder) WithPurchaseDate(date time.Time) *PortfolioBuilder {
 	return b
 }
 
+// WithPositionType sets the position type (long/short)
+func (b *PortfolioBuilder) WithPositionType(positionType string) *PortfolioBuilder {
+	b.portfolio.PositionType = positionType
+	return b
+}
+
+// WithAssetClass sets the asset class (stock/etf/fund/crypto/cash)
+func (b *PortfolioBuilder) WithAssetClass(assetClass string) *PortfolioBuilder {
+	b.portfolio.AssetClass = assetClass
+	return b
+}
+
 // Build returns the built portfolio
 func (b *PortfolioBuilder) Build() *dao.Portfolio {
 	return b.portfolio
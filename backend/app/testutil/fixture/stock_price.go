@@ -5,8 +5,8 @@ import (
 	"time"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
-	"github.com/boost-jp/stock-automation/app/infrastructure/client"
 	"github.com/boost-jp/stock-automation/app/infrastructure/dao"
+	"github.com/boost-jp/stock-automation/app/utility"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -22,10 +22,10 @@ func NewStockPrice() *StockPriceBuilder {
 			ID:         ulid.MustNew(ulid.Now(), nil).String(),
 			Code:       "7203",
 			Date:       time.Now(),
-			OpenPrice:  client.FloatToDecimal(2000.0),
-			HighPrice:  client.FloatToDecimal(2100.0),
-			LowPrice:   client.FloatToDecimal(1950.0),
-			ClosePrice: client.FloatToDecimal(2050.0),
+			OpenPrice:  utility.FloatToDecimal(2000.0),
+			HighPrice:  utility.FloatToDecimal(2100.0),
+			LowPrice:   utility.FloatToDecimal(1950.0),
+			ClosePrice: utility.FloatToDecimal(2050.0),
 			Volume:     1000000,
 		},
 	}
@@ -51,10 +51,10 @@ func (b *StockPriceBuilder) WithDate(date time.Time) *StockPriceBuilder {
 
 // WithPrices sets all price values at once
 func (b *StockPriceBuilder) WithPrices(open, high, low, close float64) *StockPriceBuilder {
-	b.stockPrice.OpenPrice = client.FloatToDecimal(open)
-	b.stockPrice.HighPrice = client.FloatToDecimal(high)
-	b.stockPrice.LowPrice = client.FloatToDecimal(low)
-	b.stockPrice.ClosePrice = client.FloatToDecimal(close)
+	b.stockPrice.OpenPrice = utility.FloatToDecimal(open)
+	b.stockPrice.HighPrice = utility.FloatToDecimal(high)
+	b.stockPrice.LowPrice = utility.FloatToDecimal(low)
+	b.stockPrice.ClosePrice = utility.FloatToDecimal(close)
 	return b
 }
 
@@ -6,8 +6,8 @@ import (
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/types"
-	"github.com/boost-jp/stock-automation/app/infrastructure/client"
 	"github.com/boost-jp/stock-automation/app/infrastructure/dao"
+	"github.com/boost-jp/stock-automation/app/utility"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -56,13 +56,13 @@ func (b *WatchListBuilder) WithIsActive(active bool) *WatchListBuilder {
 
 // WithTargetBuyPrice sets the target buy price
 func (b *WatchListBuilder) WithTargetBuyPrice(price float64) *WatchListBuilder {
-	b.watchList.TargetBuyPrice = client.FloatToNullDecimal(price)
+	b.watchList.TargetBuyPrice = utility.FloatToNullDecimal(price)
 	return b
 }
 
 // WithTargetSellPrice sets the target sell price
 func (b *WatchListBuilder) WithTargetSellPrice(price float64) *WatchListBuilder {
-	b.watchList.TargetSellPrice = client.FloatToNullDecimal(price)
+	b.watchList.TargetSellPrice = utility.FloatToNullDecimal(price)
 	return b
 }
 
@@ -0,0 +1,25 @@
+// Package mock provides mocks of the repository interfaces generated by moq. A test sets only the
+// functions of the methods it uses, and records the calls to assert on them; calling a method
+// whose function is not set panics.
+//
+// Regenerate the mocks after changing an interface with:
+//
+//	make gen-mocks
+package mock
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out stock_repository.gen.go -pkg mock ../../infrastructure/repository StockRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out portfolio_repository.gen.go -pkg mock ../../infrastructure/repository PortfolioRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out transaction_manager.gen.go -pkg mock ../../infrastructure/repository TransactionManager
+//go:generate go run github.com/matryer/moq@v0.5.3 -out portfolio_lot_repository.gen.go -pkg mock ../../infrastructure/repository PortfolioLotRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out share_link_repository.gen.go -pkg mock ../../infrastructure/repository ShareLinkRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out feature_flag_repository.gen.go -pkg mock ../../infrastructure/repository FeatureFlagRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out advice_log_repository.gen.go -pkg mock ../../infrastructure/repository AdviceLogRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out cash_transaction_repository.gen.go -pkg mock ../../infrastructure/repository CashTransactionRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out raw_response_repository.gen.go -pkg mock ../../infrastructure/repository RawResponseRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out collect_failure_repository.gen.go -pkg mock ../../infrastructure/repository CollectFailureRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out trade_note_repository.gen.go -pkg mock ../../infrastructure/repository TradeNoteRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out retention_repository.gen.go -pkg mock ../../infrastructure/repository RetentionRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out schema_migration_repository.gen.go -pkg mock ../../infrastructure/repository SchemaMigrationRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out audit_log_repository.gen.go -pkg mock ../../infrastructure/repository AuditLogRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out broker_order_repository.gen.go -pkg mock ../../infrastructure/repository BrokerOrderRepository
+//go:generate go run github.com/matryer/moq@v0.5.3 -out stock_nickname_repository.gen.go -pkg mock ../../infrastructure/repository StockNicknameRepository
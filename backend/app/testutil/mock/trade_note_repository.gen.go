@@ -0,0 +1,340 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+)
+
+// Ensure, that TradeNoteRepositoryMock does implement repository.TradeNoteRepository.
+// If this is not the case, regenerate this file with moq.
+var _ repository.TradeNoteRepository = &TradeNoteRepositoryMock{}
+
+// TradeNoteRepositoryMock is a mock implementation of repository.TradeNoteRepository.
+//
+//	func TestSomethingThatUsesTradeNoteRepository(t *testing.T) {
+//
+//		// make and configure a mocked repository.TradeNoteRepository
+//		mockedTradeNoteRepository := &TradeNoteRepositoryMock{
+//			CreateFunc: func(ctx context.Context, note *models.TradeNote) error {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(ctx context.Context, id string) (bool, error) {
+//				panic("mock out the Delete method")
+//			},
+//			GetByCodeFunc: func(ctx context.Context, code string, limit int) ([]*models.TradeNote, error) {
+//				panic("mock out the GetByCode method")
+//			},
+//			GetByIDFunc: func(ctx context.Context, id string) (*models.TradeNote, error) {
+//				panic("mock out the GetByID method")
+//			},
+//			GetLatestByCodesFunc: func(ctx context.Context, codes []string) (map[string]*models.TradeNote, error) {
+//				panic("mock out the GetLatestByCodes method")
+//			},
+//			GetRecentFunc: func(ctx context.Context, limit int) ([]*models.TradeNote, error) {
+//				panic("mock out the GetRecent method")
+//			},
+//		}
+//
+//		// use mockedTradeNoteRepository in code that requires repository.TradeNoteRepository
+//		// and then make assertions.
+//
+//	}
+type TradeNoteRepositoryMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, note *models.TradeNote) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, id string) (bool, error)
+
+	// GetByCodeFunc mocks the GetByCode method.
+	GetByCodeFunc func(ctx context.Context, code string, limit int) ([]*models.TradeNote, error)
+
+	// GetByIDFunc mocks the GetByID method.
+	GetByIDFunc func(ctx context.Context, id string) (*models.TradeNote, error)
+
+	// GetLatestByCodesFunc mocks the GetLatestByCodes method.
+	GetLatestByCodesFunc func(ctx context.Context, codes []string) (map[string]*models.TradeNote, error)
+
+	// GetRecentFunc mocks the GetRecent method.
+	GetRecentFunc func(ctx context.Context, limit int) ([]*models.TradeNote, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Note is the note argument value.
+			Note *models.TradeNote
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// GetByCode holds details about calls to the GetByCode method.
+		GetByCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetByID holds details about calls to the GetByID method.
+		GetByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// GetLatestByCodes holds details about calls to the GetLatestByCodes method.
+		GetLatestByCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Codes is the codes argument value.
+			Codes []string
+		}
+		// GetRecent holds details about calls to the GetRecent method.
+		GetRecent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int
+		}
+	}
+	lockCreate           sync.RWMutex
+	lockDelete           sync.RWMutex
+	lockGetByCode        sync.RWMutex
+	lockGetByID          sync.RWMutex
+	lockGetLatestByCodes sync.RWMutex
+	lockGetRecent        sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *TradeNoteRepositoryMock) Create(ctx context.Context, note *models.TradeNote) error {
+	if mock.CreateFunc == nil {
+		panic("TradeNoteRepositoryMock.CreateFunc: method is nil but TradeNoteRepository.Create was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Note *models.TradeNote
+	}{
+		Ctx:  ctx,
+		Note: note,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, note)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedTradeNoteRepository.CreateCalls())
+func (mock *TradeNoteRepositoryMock) CreateCalls() []struct {
+	Ctx  context.Context
+	Note *models.TradeNote
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Note *models.TradeNote
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *TradeNoteRepositoryMock) Delete(ctx context.Context, id string) (bool, error) {
+	if mock.DeleteFunc == nil {
+		panic("TradeNoteRepositoryMock.DeleteFunc: method is nil but TradeNoteRepository.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedTradeNoteRepository.DeleteCalls())
+func (mock *TradeNoteRepositoryMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// GetByCode calls GetByCodeFunc.
+func (mock *TradeNoteRepositoryMock) GetByCode(ctx context.Context, code string, limit int) ([]*models.TradeNote, error) {
+	if mock.GetByCodeFunc == nil {
+		panic("TradeNoteRepositoryMock.GetByCodeFunc: method is nil but TradeNoteRepository.GetByCode was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Code  string
+		Limit int
+	}{
+		Ctx:   ctx,
+		Code:  code,
+		Limit: limit,
+	}
+	mock.lockGetByCode.Lock()
+	mock.calls.GetByCode = append(mock.calls.GetByCode, callInfo)
+	mock.lockGetByCode.Unlock()
+	return mock.GetByCodeFunc(ctx, code, limit)
+}
+
+// GetByCodeCalls gets all the calls that were made to GetByCode.
+// Check the length with:
+//
+//	len(mockedTradeNoteRepository.GetByCodeCalls())
+func (mock *TradeNoteRepositoryMock) GetByCodeCalls() []struct {
+	Ctx   context.Context
+	Code  string
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Code  string
+		Limit int
+	}
+	mock.lockGetByCode.RLock()
+	calls = mock.calls.GetByCode
+	mock.lockGetByCode.RUnlock()
+	return calls
+}
+
+// GetByID calls GetByIDFunc.
+func (mock *TradeNoteRepositoryMock) GetByID(ctx context.Context, id string) (*models.TradeNote, error) {
+	if mock.GetByIDFunc == nil {
+		panic("TradeNoteRepositoryMock.GetByIDFunc: method is nil but TradeNoteRepository.GetByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockGetByID.Lock()
+	mock.calls.GetByID = append(mock.calls.GetByID, callInfo)
+	mock.lockGetByID.Unlock()
+	return mock.GetByIDFunc(ctx, id)
+}
+
+// GetByIDCalls gets all the calls that were made to GetByID.
+// Check the length with:
+//
+//	len(mockedTradeNoteRepository.GetByIDCalls())
+func (mock *TradeNoteRepositoryMock) GetByIDCalls() []struct {
+	Ctx context.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  string
+	}
+	mock.lockGetByID.RLock()
+	calls = mock.calls.GetByID
+	mock.lockGetByID.RUnlock()
+	return calls
+}
+
+// GetLatestByCodes calls GetLatestByCodesFunc.
+func (mock *TradeNoteRepositoryMock) GetLatestByCodes(ctx context.Context, codes []string) (map[string]*models.TradeNote, error) {
+	if mock.GetLatestByCodesFunc == nil {
+		panic("TradeNoteRepositoryMock.GetLatestByCodesFunc: method is nil but TradeNoteRepository.GetLatestByCodes was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Codes []string
+	}{
+		Ctx:   ctx,
+		Codes: codes,
+	}
+	mock.lockGetLatestByCodes.Lock()
+	mock.calls.GetLatestByCodes = append(mock.calls.GetLatestByCodes, callInfo)
+	mock.lockGetLatestByCodes.Unlock()
+	return mock.GetLatestByCodesFunc(ctx, codes)
+}
+
+// GetLatestByCodesCalls gets all the calls that were made to GetLatestByCodes.
+// Check the length with:
+//
+//	len(mockedTradeNoteRepository.GetLatestByCodesCalls())
+func (mock *TradeNoteRepositoryMock) GetLatestByCodesCalls() []struct {
+	Ctx   context.Context
+	Codes []string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Codes []string
+	}
+	mock.lockGetLatestByCodes.RLock()
+	calls = mock.calls.GetLatestByCodes
+	mock.lockGetLatestByCodes.RUnlock()
+	return calls
+}
+
+// GetRecent calls GetRecentFunc.
+func (mock *TradeNoteRepositoryMock) GetRecent(ctx context.Context, limit int) ([]*models.TradeNote, error) {
+	if mock.GetRecentFunc == nil {
+		panic("TradeNoteRepositoryMock.GetRecentFunc: method is nil but TradeNoteRepository.GetRecent was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetRecent.Lock()
+	mock.calls.GetRecent = append(mock.calls.GetRecent, callInfo)
+	mock.lockGetRecent.Unlock()
+	return mock.GetRecentFunc(ctx, limit)
+}
+
+// GetRecentCalls gets all the calls that were made to GetRecent.
+// Check the length with:
+//
+//	len(mockedTradeNoteRepository.GetRecentCalls())
+func (mock *TradeNoteRepositoryMock) GetRecentCalls() []struct {
+	Ctx   context.Context
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int
+	}
+	mock.lockGetRecent.RLock()
+	calls = mock.calls.GetRecent
+	mock.lockGetRecent.RUnlock()
+	return calls
+}
@@ -0,0 +1,111 @@
+//go:build integration
+
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mysql"
+)
+
+// Defaults of the MySQL container, overridable by TEST_DB_IMAGE and TEST_DB_PASSWORD.
+const (
+	defaultMySQLImage    = "mysql:8.0"
+	defaultMySQLPassword = "password"
+	mysqlStartupTimeout  = 2 * time.Minute
+)
+
+// MySQLContainer is a disposable MySQL server for integration tests.
+type MySQLContainer struct {
+	container *mysql.MySQLContainer
+	Host      string
+	Port      int
+	User      string
+	Password  string
+}
+
+// StartMySQLContainer starts a MySQL container and points NewTestDB at it
+// by setting TEST_DB_HOST, TEST_DB_PORT, TEST_DB_USER and TEST_DB_PASSWORD.
+func StartMySQLContainer(ctx context.Context) (*MySQLContainer, error) {
+	password := getEnvOrDefault("TEST_DB_PASSWORD", defaultMySQLPassword)
+
+	ctx, cancel := context.WithTimeout(ctx, mysqlStartupTimeout)
+	defer cancel()
+
+	container, err := mysql.Run(ctx, getEnvOrDefault("TEST_DB_IMAGE", defaultMySQLImage),
+		mysql.WithDatabase("stock_automation_test"),
+		mysql.WithUsername("root"),
+		mysql.WithPassword(password),
+	)
+	if err != nil {
+		if container != nil {
+			testcontainers.TerminateContainer(container)
+		}
+		return nil, fmt.Errorf("failed to start MySQL container: %w", err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		testcontainers.TerminateContainer(container)
+		return nil, fmt.Errorf("failed to get MySQL container host: %w", err)
+	}
+	port, err := container.MappedPort(ctx, "3306/tcp")
+	if err != nil {
+		testcontainers.TerminateContainer(container)
+		return nil, fmt.Errorf("failed to get MySQL container port: %w", err)
+	}
+
+	c := &MySQLContainer{
+		container: container,
+		Host:      host,
+		Port:      port.Int(),
+		User:      "root",
+		Password:  password,
+	}
+
+	os.Setenv("TEST_DB_HOST", c.Host)
+	os.Setenv("TEST_DB_PORT", strconv.Itoa(c.Port))
+	os.Setenv("TEST_DB_USER", c.User)
+	os.Setenv("TEST_DB_PASSWORD", c.Password)
+
+	return c, nil
+}
+
+// Terminate stops and removes the container.
+func (c *MySQLContainer) Terminate() error {
+	return testcontainers.TerminateContainer(c.container)
+}
+
+// RunWithMySQL runs the tests of a package against a MySQL container started for them,
+// and removes the container afterwards. Use it from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(testutil.RunWithMySQL(m))
+//	}
+//
+// When TEST_DB_HOST is set, the tests use that server instead of starting a container.
+func RunWithMySQL(m *testing.M) int {
+	if os.Getenv("TEST_DB_HOST") != "" {
+		return m.Run()
+	}
+
+	container, err := StartMySQLContainer(context.Background())
+	if err != nil {
+		log.Printf("Failed to start MySQL for integration tests: %v", err)
+		return 1
+	}
+	defer func() {
+		if err := container.Terminate(); err != nil {
+			log.Printf("Failed to terminate MySQL container: %v", err)
+		}
+	}()
+
+	return m.Run()
+}
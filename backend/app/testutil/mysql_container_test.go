@@ -0,0 +1,50 @@
+//go:build integration
+
+package testutil
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunWithMySQL(m))
+}
+
+func TestNewTestDB(t *testing.T) {
+	tdb := NewTestDB(t)
+	defer func() {
+		if err := tdb.Cleanup(); err != nil {
+			t.Errorf("Cleanup() error = %v", err)
+		}
+	}()
+
+	rows, err := tdb.DB.Query("SHOW TABLES")
+	if err != nil {
+		t.Fatalf("SHOW TABLES error = %v", err)
+	}
+	defer rows.Close()
+
+	tables := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		tables[name] = true
+	}
+
+	want := map[string]bool{"latest_prices": true, "portfolios": true, "stock_prices": true, "technical_indicators": true, "watch_lists": true}
+	if diff := cmp.Diff(want, tables); diff != "" {
+		t.Errorf("tables mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := tdb.ExecSQL("INSERT INTO watch_lists (id, code, name) VALUES (?, ?, ?)", "01J0000000000000000000000A", "7203", "Toyota"); err != nil {
+		t.Fatalf("ExecSQL() error = %v", err)
+	}
+	if err := tdb.TruncateAll(); err != nil {
+		t.Fatalf("TruncateAll() error = %v", err)
+	}
+}
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// AlertRuleUseCase manages alert rules and evaluates them against the latest prices.
+type AlertRuleUseCase struct {
+	alertRuleRepo    repository.AlertRuleRepository
+	stockRepo        repository.StockRepository
+	technicalUseCase *TechnicalAnalysisUseCase
+	notifier         notification.NotificationService
+	txManager        repository.TransactionManager
+	noteRepo         repository.TradeNoteRepository
+}
+
+// NewAlertRuleUseCase creates a new alert rule use case.
+func NewAlertRuleUseCase(
+	alertRuleRepo repository.AlertRuleRepository,
+	stockRepo repository.StockRepository,
+	technicalUseCase *TechnicalAnalysisUseCase,
+	notifier notification.NotificationService,
+	txManager repository.TransactionManager,
+) *AlertRuleUseCase {
+	return &AlertRuleUseCase{
+		alertRuleRepo:    alertRuleRepo,
+		stockRepo:        stockRepo,
+		technicalUseCase: technicalUseCase,
+		notifier:         notifier,
+		txManager:        txManager,
+	}
+}
+
+// SetTradeNotes sets the trade notes whose newest note of the stock is referred to in the alerts.
+func (uc *AlertRuleUseCase) SetTradeNotes(noteRepo repository.TradeNoteRepository) {
+	uc.noteRepo = noteRepo
+}
+
+// CreateRule validates the YAML definition and stores a new enabled rule named after it.
+func (uc *AlertRuleUseCase) CreateRule(ctx context.Context, definitionYAML string) (*models.AlertRule, error) {
+	def, normalized, err := parseAlertRule(definitionYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &models.AlertRule{
+		Name:       def.Name,
+		Definition: normalized,
+		Enabled:    true,
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.alertRuleRepo.GetByName(ctx, def.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get alert rule: %w", err)
+		}
+		if existing != nil {
+			return fmt.Errorf("alert rule already exists: %s", def.Name)
+		}
+
+		if err := uc.alertRuleRepo.Create(ctx, rule); err != nil {
+			return fmt.Errorf("failed to create alert rule: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Alert rule created: %s (%s)", rule.Name, def.Describe())
+	return rule, nil
+}
+
+// UpdateRule replaces the definition of the rule with the same name. The enabled state is kept.
+func (uc *AlertRuleUseCase) UpdateRule(ctx context.Context, definitionYAML string) (*models.AlertRule, error) {
+	def, normalized, err := parseAlertRule(definitionYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule *models.AlertRule
+	err = uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		rule, err = uc.getRule(ctx, def.Name)
+		if err != nil {
+			return err
+		}
+
+		rule.Definition = normalized
+		if err := uc.alertRuleRepo.Update(ctx, rule); err != nil {
+			return fmt.Errorf("failed to update alert rule: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Alert rule updated: %s (%s)", rule.Name, def.Describe())
+	return rule, nil
+}
+
+// SetEnabled enables or disables a rule.
+func (uc *AlertRuleUseCase) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		rule, err := uc.getRule(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		rule.Enabled = enabled
+		if err := uc.alertRuleRepo.Update(ctx, rule); err != nil {
+			return fmt.Errorf("failed to update alert rule: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Alert rule %s enabled=%t", name, enabled)
+	return nil
+}
+
+// DeleteRule deletes a rule and its evaluation history in a single transaction.
+func (uc *AlertRuleUseCase) DeleteRule(ctx context.Context, name string) error {
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		rule, err := uc.getRule(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.alertRuleRepo.Delete(ctx, rule.ID); err != nil {
+			return fmt.Errorf("failed to delete alert rule: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Alert rule deleted: %s", name)
+	return nil
+}
+
+// GetRule returns a rule by name.
+func (uc *AlertRuleUseCase) GetRule(ctx context.Context, name string) (*models.AlertRule, error) {
+	return uc.getRule(ctx, name)
+}
+
+// ListRules returns all rules.
+func (uc *AlertRuleUseCase) ListRules(ctx context.Context) ([]*models.AlertRule, error) {
+	rules, err := uc.alertRuleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetHistory returns the latest matched evaluations of a rule, or of all rules if name is empty.
+func (uc *AlertRuleUseCase) GetHistory(ctx context.Context, name string, limit int) ([]*models.AlertRuleEvaluation, error) {
+	ruleID := ""
+	if name != "" {
+		rule, err := uc.getRule(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		ruleID = rule.ID
+	}
+
+	evaluations, err := uc.alertRuleRepo.GetEvaluations(ctx, ruleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rule history: %w", err)
+	}
+	return evaluations, nil
+}
+
+// EvaluateRules evaluates all enabled rules against the stored prices of their target stocks.
+// Matched evaluations are saved to the history and notified unless the rule is in cooldown for the stock.
+// Stocks whose alerts are muted in the watch list are not evaluated.
+func (uc *AlertRuleUseCase) EvaluateRules(ctx context.Context) error {
+	rules, err := uc.alertRuleRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get alert rules: %w", err)
+	}
+
+	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get watch list: %w", err)
+	}
+
+	names := make(map[string]string, len(watchList))
+	watchCodes := make([]string, 0, len(watchList))
+	for _, item := range watchList {
+		names[item.Code] = item.Name
+		watchCodes = append(watchCodes, item.Code)
+	}
+	muted := mutedStockCodes(watchList, time.Now())
+
+	// Metrics are calculated once per stock and shared between rules
+	metricsCache := make(map[string]domain.AlertMetrics)
+	evaluated, matches, notified := 0, 0, 0
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		def, err := domain.ParseAlertRuleDefinition([]byte(rule.Definition))
+		if err != nil {
+			logrus.Errorf("Skipping invalid alert rule %s: %v", rule.Name, err)
+			continue
+		}
+		evaluated++
+
+		codes := def.Codes
+		if len(codes) == 0 {
+			codes = watchCodes
+		}
+
+		for _, code := range codes {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if muted[code] {
+				continue
+			}
+
+			metrics, ok := metricsCache[code]
+			if !ok {
+				evaluation, err := uc.technicalUseCase.EvaluateSignal(ctx, code)
+				if err != nil {
+					logrus.Warnf("Failed to calculate alert metrics for %s: %v", code, err)
+					continue
+				}
+				metrics = domain.BuildAlertMetrics(evaluation.Prices, evaluation.Indicator)
+				metricsCache[code] = metrics
+			}
+
+			result := def.Evaluate(metrics)
+			if !result.Matched {
+				continue
+			}
+			matches++
+
+			sent, err := uc.handleMatch(ctx, rule, def, code, names[code], result)
+			if err != nil {
+				logrus.Errorf("Failed to handle alert rule %s for %s: %v", rule.Name, code, err)
+				continue
+			}
+			if sent {
+				notified++
+			}
+		}
+	}
+
+	logrus.Infof("Alert rule evaluation completed: %d rules, %d matches, %d notifications", evaluated, matches, notified)
+	return nil
+}
+
+// handleMatch notifies a matched rule unless it is in cooldown and saves the evaluation to the history.
+func (uc *AlertRuleUseCase) handleMatch(
+	ctx context.Context,
+	rule *models.AlertRule,
+	def *domain.AlertRuleDefinition,
+	code, name string,
+	result domain.AlertEvaluation,
+) (bool, error) {
+	now := time.Now()
+
+	lastNotifiedAt, err := uc.alertRuleRepo.GetLastNotifiedAt(ctx, rule.ID, code)
+	if err != nil {
+		return false, fmt.Errorf("failed to get last notification: %w", err)
+	}
+
+	sent := false
+	if lastNotifiedAt == nil || now.Sub(*lastNotifiedAt) >= def.Cooldown {
+		message := formatAlertRuleMessage(def, code, name, result, uc.latestNote(ctx, code))
+		if err := uc.notifier.SendMessageOfKind(ctx, notification.KindCritical, message); err != nil {
+			// Saved as not notified so that the alert is retried on the next evaluation
+			logrus.Errorf("Failed to send alert for rule %s (%s): %v", rule.Name, code, err)
+		} else {
+			sent = true
+		}
+	}
+
+	values, err := json.Marshal(result.Values)
+	if err != nil {
+		return sent, fmt.Errorf("failed to marshal metric values: %w", err)
+	}
+
+	evaluation := &models.AlertRuleEvaluation{
+		AlertRuleID:  rule.ID,
+		Code:         code,
+		MetricValues: string(values),
+		Notified:     sent,
+		EvaluatedAt:  now,
+	}
+	if err := uc.alertRuleRepo.SaveEvaluation(ctx, evaluation); err != nil {
+		return sent, fmt.Errorf("failed to save alert rule evaluation: %w", err)
+	}
+
+	return sent, nil
+}
+
+// latestNote returns the newest trade note of a stock, or nil if it has none or it cannot be read.
+func (uc *AlertRuleUseCase) latestNote(ctx context.Context, code string) *models.TradeNote {
+	if uc.noteRepo == nil {
+		return nil
+	}
+	notes, err := uc.noteRepo.GetLatestByCodes(ctx, []string{code})
+	if err != nil {
+		logrus.Warnf("Failed to get trade note of %s: %v", code, err)
+		return nil
+	}
+	return notes[code]
+}
+
+// getRule returns a rule by name, or an error if it does not exist.
+func (uc *AlertRuleUseCase) getRule(ctx context.Context, name string) (*models.AlertRule, error) {
+	rule, err := uc.alertRuleRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rule: %w", err)
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("alert rule not found: %s", name)
+	}
+	return rule, nil
+}
+
+// parseAlertRule parses a YAML definition and returns it with its normalized YAML for storage.
+func parseAlertRule(definitionYAML string) (*domain.AlertRuleDefinition, string, error) {
+	def, err := domain.ParseAlertRuleDefinition([]byte(definitionYAML))
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Store the normalized definition so that defaults are explicit
+	normalized, err := def.MarshalYAMLString()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal alert rule: %w", err)
+	}
+	return def, normalized, nil
+}
+
+// formatAlertRuleMessage formats the notification of a matched rule, referring to the newest note of the stock if any.
+func formatAlertRuleMessage(def *domain.AlertRuleDefinition, code, name string, result domain.AlertEvaluation, note *models.TradeNote) string {
+	title := code
+	if name != "" {
+		title = fmt.Sprintf("%s (%s)", name, code)
+	}
+
+	message := "🔔 " + i18n.T("alert_rule.title", def.Name) + "\n\n"
+	message += i18n.T("alert_rule.stock", title) + "\n"
+	message += i18n.T("alert_rule.conditions", def.Describe()) + "\n"
+	if def.Description != "" {
+		message += i18n.T("alert_rule.description", def.Description) + "\n"
+	}
+	message += i18n.T("alert_rule.values", result.Values.FormatValues())
+	if note != nil {
+		message += "\n" + domain.FormatTradeNoteRef(note)
+	}
+	return message
+}
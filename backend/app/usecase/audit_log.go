@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+)
+
+// defaultAuditLogLimit is the number of audit logs returned when no limit is given
+const defaultAuditLogLimit = 50
+
+// AuditLogUseCase queries the audit log of portfolio and watch list changes.
+// The changes themselves are recorded by the audited repositories.
+type AuditLogUseCase struct {
+	auditRepo repository.AuditLogRepository
+}
+
+// NewAuditLogUseCase creates a new audit log use case.
+func NewAuditLogUseCase(auditRepo repository.AuditLogRepository) *AuditLogUseCase {
+	return &AuditLogUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+// GetLogs returns the audit logs matching the filter, newest first.
+func (uc *AuditLogUseCase) GetLogs(ctx context.Context, filter repository.AuditLogFilter) ([]*models.AuditLog, error) {
+	switch filter.EntityType {
+	case "", models.AuditEntityPortfolio, models.AuditEntityWatchList:
+	default:
+		return nil, fmt.Errorf("unknown entity type: %s (portfolio or watch_list)", filter.EntityType)
+	}
+
+	switch filter.Source {
+	case "", models.AuditSourceCLI, models.AuditSourceAPI, models.AuditSourceScheduler, models.AuditSourceUnknown:
+	default:
+		return nil, fmt.Errorf("unknown source: %s (cli, api or scheduler)", filter.Source)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLogLimit
+	}
+
+	logs, err := uc.auditRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+	return logs, nil
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility/retry"
+	"github.com/sirupsen/logrus"
+)
+
+// BulkCollectResult summarizes a bulk historical data collection.
+type BulkCollectResult struct {
+	Requested    int
+	Succeeded    int
+	SavedRecords int
+	Failed       map[string]error
+}
+
+// BulkCollectUseCase collects historical price data for many stocks at once.
+type BulkCollectUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	stockClient   client.StockDataClient
+	priority      domain.PriceSourcePriority
+	maxWorkers    int
+	retryPolicy   retry.Policy
+	notifier      notification.NotificationService
+}
+
+// NewBulkCollectUseCase creates a new bulk collection use case.
+func NewBulkCollectUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	stockClient client.StockDataClient,
+	priority domain.PriceSourcePriority,
+) *BulkCollectUseCase {
+	return &BulkCollectUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		stockClient:   stockClient,
+		priority:      priority,
+		maxWorkers:    5, // Limit concurrent API calls
+		retryPolicy: retry.Policy{
+			MaxRetries:   3,
+			InitialDelay: 2 * time.Second,
+			MaxDelay:     30 * time.Second,
+			Jitter:       0.2,
+			Retryable:    client.IsRetryableError,
+		},
+	}
+}
+
+// SetAlertNotifier sets the notifier of the high error rate alert.
+// No alerts are sent without a notifier.
+func (uc *BulkCollectUseCase) SetAlertNotifier(notifier notification.NotificationService) {
+	uc.notifier = notifier
+}
+
+// historicalPeriod is the period of a bulk collection: the days from from to to, or the last days if from is zero.
+type historicalPeriod struct {
+	days     int
+	from, to time.Time
+}
+
+// CollectAll collects historical data of the last given days for all watched and held stocks.
+func (uc *BulkCollectUseCase) CollectAll(ctx context.Context, days int, opts CollectOptions) (*BulkCollectResult, error) {
+	codes, err := collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.CollectCodes(ctx, codes, days, opts), nil
+}
+
+// CollectAllRange collects historical data from the day of from to the day of to for all watched and held stocks.
+func (uc *BulkCollectUseCase) CollectAllRange(ctx context.Context, from, to time.Time, opts CollectOptions) (*BulkCollectResult, error) {
+	codes, err := collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.CollectCodesRange(ctx, codes, from, to, opts), nil
+}
+
+// collectTargetCodes returns the sorted codes of the watched and held stocks.
+func collectTargetCodes(ctx context.Context, stockRepo repository.StockRepository, portfolioRepo repository.PortfolioRepository) ([]string, error) {
+	watchList, err := stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+
+	portfolio, err := portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	stockCodes := make(map[string]bool)
+	for _, item := range watchList {
+		stockCodes[item.Code] = true
+	}
+	for _, item := range listedHoldings(portfolio) {
+		stockCodes[item.Code] = true
+	}
+
+	codes := make([]string, 0, len(stockCodes))
+	for code := range stockCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes, nil
+}
+
+// CollectCodes collects historical data of the last given days for the given stock codes.
+// Records already stored are skipped, so the collection can be re-run safely.
+// An alert is sent if many of the stocks failed.
+func (uc *BulkCollectUseCase) CollectCodes(ctx context.Context, codes []string, days int, opts CollectOptions) *BulkCollectResult {
+	return uc.collectCodes(ctx, codes, historicalPeriod{days: days}, opts)
+}
+
+// CollectCodesRange collects historical data from the day of from to the day of to, both inclusive,
+// for the given stock codes, so that a backfill can be split into exact periods.
+// Records already stored are skipped and an alert is sent if many of the stocks failed, as with CollectCodes.
+func (uc *BulkCollectUseCase) CollectCodesRange(ctx context.Context, codes []string, from, to time.Time, opts CollectOptions) *BulkCollectResult {
+	return uc.collectCodes(ctx, codes, historicalPeriod{from: from, to: to}, opts)
+}
+
+// collectCodes collects historical data of the period for the given stock codes.
+func (uc *BulkCollectUseCase) collectCodes(ctx context.Context, codes []string, period historicalPeriod, opts CollectOptions) *BulkCollectResult {
+	var (
+		mu    sync.Mutex
+		saved int
+	)
+
+	failed := runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, stockCode string) error {
+		count, err := uc.collectStock(ctx, stockCode, period)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		saved += count
+		mu.Unlock()
+		return nil
+	})
+
+	for stockCode, err := range failed {
+		logrus.Errorf("Failed to collect historical data for %s: %v", stockCode, err)
+	}
+	if domain.IsHighCollectErrorRate(len(failed), len(codes)) {
+		sendCollectAlert(ctx, uc.notifier, opts, domain.FormatCollectErrorRateAlert(failed, len(codes)))
+	}
+
+	result := &BulkCollectResult{
+		Requested:    len(codes),
+		Succeeded:    len(codes) - len(failed),
+		SavedRecords: saved,
+		Failed:       failed,
+	}
+
+	logrus.Infof("Bulk collection completed: %d/%d stocks, %d records saved",
+		result.Succeeded, result.Requested, result.SavedRecords)
+
+	return result
+}
+
+// collectStock fetches historical data with retries and saves records not yet stored.
+// Stored records are replaced only by records from a data source of higher priority.
+func (uc *BulkCollectUseCase) collectStock(ctx context.Context, stockCode string, period historicalPeriod) (int, error) {
+	prices, err := uc.fetchWithRetry(ctx, stockCode, period)
+	if err != nil {
+		return 0, err
+	}
+	prices = uc.priority.Resolve(prices)
+
+	var existing []*models.StockPrice
+	if period.from.IsZero() {
+		existing, err = uc.stockRepo.GetPriceHistory(ctx, stockCode, period.days+1)
+	} else {
+		existing, err = uc.stockRepo.GetPriceHistoryRange(ctx, stockCode, period.from, period.to)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stored price history: %w", err)
+	}
+
+	stored := make(map[string]*models.StockPrice, len(existing))
+	for _, price := range existing {
+		stored[price.Date.Format("2006-01-02")] = price
+	}
+
+	// The prices of finished trading days replace the provisional ones collected during their sessions
+	domain.MarkFinalPrices(prices, time.Now())
+	newPrices := make([]*models.StockPrice, 0, len(prices))
+	var replaced []*models.StockPrice
+	for _, price := range prices {
+		current, ok := stored[price.Date.Format("2006-01-02")]
+		switch {
+		case !ok:
+			newPrices = append(newPrices, price)
+		case current.Final && !price.Final:
+			// A provisional bar of today does not replace a confirmed price
+		case price.Final && !current.Final, uc.priority.Outranks(price.Source, current.Source):
+			replaced = append(replaced, price)
+		}
+	}
+
+	if err := uc.stockRepo.SaveStockPrices(ctx, newPrices); err != nil {
+		return 0, fmt.Errorf("failed to save historical data: %w", err)
+	}
+	for _, price := range replaced {
+		if err := uc.stockRepo.UpdateStockPrice(ctx, price); err != nil {
+			return len(newPrices), fmt.Errorf("failed to replace price of %s: %w", price.Date.Format("2006-01-02"), err)
+		}
+	}
+
+	logrus.Debugf("Historical data collected for %s: %d fetched, %d saved, %d replaced by source priority",
+		stockCode, len(prices), len(newPrices), len(replaced))
+	return len(newPrices) + len(replaced), nil
+}
+
+// fetchWithRetry fetches historical data, retrying retryable errors with exponential backoff.
+func (uc *BulkCollectUseCase) fetchWithRetry(ctx context.Context, stockCode string, period historicalPeriod) ([]*models.StockPrice, error) {
+	policy := uc.retryPolicy
+	policy.OnRetry = func(attempt int, err error, wait time.Duration) {
+		logrus.Warnf("Retrying historical data for %s in %v (attempt %d/%d): %v", stockCode, wait, attempt, policy.MaxRetries, err)
+	}
+
+	var prices []*models.StockPrice
+	_, err := policy.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if period.from.IsZero() {
+			prices, err = uc.stockClient.GetHistoricalData(ctx, stockCode, period.days)
+		} else {
+			prices, err = uc.stockClient.GetHistoricalDataRange(ctx, stockCode, period.from, period.to)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/calendar"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultCalendarSyncDays is how many days ahead the corporate events are registered to the calendar.
+const DefaultCalendarSyncDays = 90
+
+// CalendarSyncResult summarizes a sync of the corporate events to the calendar.
+type CalendarSyncResult struct {
+	Events  []domain.CorporateEvent
+	Created int
+	Updated int
+	Failed  []string
+}
+
+// CalendarSyncUseCase registers the earnings announcements, record dates and dividend payment
+// dates of the watched and held stocks to an external calendar. Each event has an ID derived from
+// its key, so syncing again updates the events, e.g. a rescheduled announcement, without duplicates.
+type CalendarSyncUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	eventClient   client.CorporateEventClient
+	calendar      calendar.Calendar
+	days          int
+	now           func() time.Time
+}
+
+// NewCalendarSyncUseCase creates a new calendar sync use case. The corporate events are not available
+// if eventClient is nil, and the sync is disabled if calendar is nil as well.
+func NewCalendarSyncUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	eventClient client.CorporateEventClient,
+	cal calendar.Calendar,
+	days int,
+) *CalendarSyncUseCase {
+	if days <= 0 {
+		days = DefaultCalendarSyncDays
+	}
+	return &CalendarSyncUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		eventClient:   eventClient,
+		calendar:      cal,
+		days:          days,
+		now:           time.Now,
+	}
+}
+
+// Enabled reports whether both the corporate events and the calendar are configured.
+func (uc *CalendarSyncUseCase) Enabled() bool {
+	return uc.eventClient != nil && uc.calendar != nil
+}
+
+// UpcomingEvents returns the corporate events of the watched and held stocks from today
+// until the sync period ends, in date order.
+func (uc *CalendarSyncUseCase) UpcomingEvents(ctx context.Context) ([]domain.CorporateEvent, error) {
+	if uc.eventClient == nil {
+		return nil, fmt.Errorf("corporate events require J-Quants credentials (JQUANTS_REFRESH_TOKEN or JQUANTS_MAIL_ADDRESS and JQUANTS_PASSWORD)")
+	}
+
+	codes, err := collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	events, err := uc.eventClient.GetCorporateEvents(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get corporate events: %w", err)
+	}
+
+	names, err := watchedStockNames(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get stock names: %v", err)
+	}
+	for i := range events {
+		if name := names[events[i].Code]; name != "" {
+			events[i].Name = name
+		}
+	}
+
+	now := uc.now()
+	return domain.UpcomingCorporateEvents(events, now, now.AddDate(0, 0, uc.days)), nil
+}
+
+// Sync registers the upcoming corporate events to the calendar. An event that fails to be
+// registered is reported in the result and does not stop the others.
+func (uc *CalendarSyncUseCase) Sync(ctx context.Context) (*CalendarSyncResult, error) {
+	if uc.calendar == nil {
+		return nil, fmt.Errorf("calendar sync requires Google Calendar credentials (GOOGLE_CALENDAR_CLIENT_ID, GOOGLE_CALENDAR_CLIENT_SECRET and GOOGLE_CALENDAR_REFRESH_TOKEN)")
+	}
+
+	events, err := uc.UpcomingEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CalendarSyncResult{Events: events}
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		created, err := uc.calendar.UpsertEvent(ctx, calendar.Event{
+			ID:          calendar.EventID(event.Key()),
+			Summary:     event.Title(),
+			Description: event.Description(),
+			Date:        event.Date,
+		})
+		if err != nil {
+			logrus.Errorf("Failed to register %s to the calendar: %v", event.Key(), err)
+			result.Failed = append(result.Failed, fmt.Sprintf("%s %s: %v", event.Date.Format("2006-01-02"), event.Title(), err))
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	logrus.Infof("Calendar sync completed: %d events, created=%d, updated=%d, failed=%d",
+		len(events), result.Created, result.Updated, len(result.Failed))
+	return result, nil
+}
+
+// RunScheduledSync syncs the calendar for the scheduled job. Does nothing if the sync is disabled.
+func (uc *CalendarSyncUseCase) RunScheduledSync(ctx context.Context) error {
+	if !uc.Enabled() {
+		logrus.Debug("Calendar sync is disabled without J-Quants credentials and Google Calendar")
+		return nil
+	}
+
+	result, err := uc.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("failed to register %d of %d events to the calendar", len(result.Failed), len(result.Events))
+	}
+	return nil
+}
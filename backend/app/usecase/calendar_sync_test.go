@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/calendar"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeCorporateEventClient returns the events of the requested stocks.
+type fakeCorporateEventClient struct {
+	events []domain.CorporateEvent
+	codes  []string
+}
+
+func (f *fakeCorporateEventClient) GetCorporateEvents(ctx context.Context, codes []string) ([]domain.CorporateEvent, error) {
+	f.codes = codes
+	return f.events, nil
+}
+
+// fakeCalendar records the events by ID, failing the summaries in fail.
+type fakeCalendar struct {
+	events map[string]calendar.Event
+	fail   map[string]bool
+}
+
+func (f *fakeCalendar) UpsertEvent(ctx context.Context, event calendar.Event) (bool, error) {
+	if f.fail[event.Summary] {
+		return false, errors.New("quota exceeded")
+	}
+	_, exists := f.events[event.ID]
+	f.events[event.ID] = event
+	return !exists, nil
+}
+
+func TestCalendarSyncUseCase_Sync(t *testing.T) {
+	now := time.Date(2024, 6, 10, 8, 0, 0, 0, time.Local)
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.Local) }
+
+	stockRepo := &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{{Code: "7203", Name: "トヨタ自動車"}}, nil
+		},
+	}
+	portfolioRepo := newHoldingsRepository([]*models.Portfolio{{Code: "6758", Name: "ソニーグループ"}})
+	events := &fakeCorporateEventClient{events: []domain.CorporateEvent{
+		{Code: "7203", Name: "トヨタ自動車株式会社", Type: domain.CorporateEventEarnings, Date: day(12), Period: "Q1"},
+		{Code: "6758", Type: domain.CorporateEventRecordDate, Date: day(28), Period: "2024-06"},
+		{Code: "6758", Type: domain.CorporateEventDividendPayment, Date: day(5), Period: "2024-03"}, // past
+	}}
+	cal := &fakeCalendar{events: make(map[string]calendar.Event), fail: map[string]bool{}}
+
+	uc := NewCalendarSyncUseCase(stockRepo, portfolioRepo, events, cal, 30)
+	uc.now = func() time.Time { return now }
+
+	result, err := uc.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"6758", "7203"}, events.codes); diff != "" {
+		t.Errorf("codes mismatch (-want +got):\n%s", diff)
+	}
+	if result.Created != 2 || result.Updated != 0 || len(result.Failed) != 0 {
+		t.Errorf("result = %+v, want 2 created", result)
+	}
+
+	earnings := cal.events[calendar.EventID("7203/earnings/Q1")]
+	if earnings.Summary != "トヨタ自動車 (7203) 決算発表" || !earnings.Date.Equal(day(12)) {
+		t.Errorf("earnings event = %+v", earnings)
+	}
+
+	// Syncing again updates the same events, and a failure does not stop the others
+	events.events[0].Date = day(14)
+	cal.fail["ソニーグループ (6758) 権利確定日"] = true
+	result, err = uc.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if result.Created != 0 || result.Updated != 1 || len(result.Failed) != 1 {
+		t.Errorf("result = %+v, want 1 updated and 1 failed", result)
+	}
+	if len(cal.events) != 2 || !cal.events[calendar.EventID("7203/earnings/Q1")].Date.Equal(day(14)) {
+		t.Errorf("events = %+v, want the rescheduled earnings updated in place", cal.events)
+	}
+	if err := uc.RunScheduledSync(context.Background()); err == nil {
+		t.Error("RunScheduledSync() should return an error when an event fails")
+	}
+}
+
+func TestCalendarSyncUseCase_Disabled(t *testing.T) {
+	uc := NewCalendarSyncUseCase(&mock.StockRepositoryMock{}, newHoldingsRepository(nil), nil, nil, 0)
+	if uc.Enabled() {
+		t.Error("Enabled() = true without J-Quants and the calendar")
+	}
+	if err := uc.RunScheduledSync(context.Background()); err != nil {
+		t.Errorf("RunScheduledSync() error = %v, want nothing done", err)
+	}
+	if _, err := uc.Sync(context.Background()); err == nil {
+		t.Error("Sync() should fail without the calendar")
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// cashHoldingName is the name of the cash holding created by the first deposit.
+const cashHoldingName = "現金"
+
+// CashInput represents a deposit or withdrawal. The date defaults to today.
+type CashInput struct {
+	Amount int
+	Note   string
+	Date   time.Time
+}
+
+// CashUseCase records the deposits and withdrawals of the portfolio. The cash balance is kept as a
+// cash holding of the portfolio, and each transaction is recorded with the balance after it.
+type CashUseCase struct {
+	portfolioRepo repository.PortfolioRepository
+	cashRepo      repository.CashTransactionRepository
+	txManager     repository.TransactionManager
+	cashCode      string
+	now           func() time.Time
+}
+
+// NewCashUseCase creates a new cash use case keeping the balance in the cash holding of the code.
+func NewCashUseCase(
+	portfolioRepo repository.PortfolioRepository,
+	cashRepo repository.CashTransactionRepository,
+	txManager repository.TransactionManager,
+	cashCode string,
+) *CashUseCase {
+	return &CashUseCase{
+		portfolioRepo: portfolioRepo,
+		cashRepo:      cashRepo,
+		txManager:     txManager,
+		cashCode:      domain.NormalizeCode(cashCode),
+		now:           time.Now,
+	}
+}
+
+// Deposit adds the amount to the cash balance, creating the cash holding on the first deposit.
+func (uc *CashUseCase) Deposit(ctx context.Context, input CashInput) (*models.CashTransaction, error) {
+	return uc.record(ctx, models.CashTransactionDeposit, input)
+}
+
+// Withdraw takes the amount from the cash balance. The balance cannot go below zero.
+func (uc *CashUseCase) Withdraw(ctx context.Context, input CashInput) (*models.CashTransaction, error) {
+	return uc.record(ctx, models.CashTransactionWithdrawal, input)
+}
+
+// History returns the latest transactions, newest first.
+func (uc *CashUseCase) History(ctx context.Context, limit int) ([]*models.CashTransaction, error) {
+	transactions, err := uc.cashRepo.GetLatest(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cash transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// record updates the cash holding by the transaction and records it in one database transaction.
+func (uc *CashUseCase) record(ctx context.Context, transactionType string, input CashInput) (*models.CashTransaction, error) {
+	date := input.Date
+	if date.IsZero() {
+		date = uc.now()
+	}
+	transaction := &models.CashTransaction{
+		TransactionType: transactionType,
+		Amount:          input.Amount,
+		Note:            input.Note,
+		TransactedOn:    models.TruncateToDate(date),
+	}
+	if err := transaction.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		holding, err := uc.portfolioRepo.GetByCode(ctx, uc.cashCode)
+		if err != nil {
+			return fmt.Errorf("failed to get cash holding: %w", err)
+		}
+		if holding != nil && !holding.IsCash() {
+			return fmt.Errorf("%s は現金残高ではありません", uc.cashCode)
+		}
+
+		balance := transaction.SignedAmount()
+		if holding != nil {
+			balance += holding.Shares
+		}
+		if balance < 0 {
+			current := 0
+			if holding != nil {
+				current = holding.Shares
+			}
+			return fmt.Errorf("出金額 ¥%s が現金残高 ¥%s を超えています",
+				domain.FormatCurrency(float64(transaction.Amount)), domain.FormatCurrency(float64(current)))
+		}
+		transaction.BalanceAfter = balance
+
+		if holding == nil {
+			holding = &models.Portfolio{
+				ID:            utility.NewULID(),
+				Code:          uc.cashCode,
+				Name:          cashHoldingName,
+				Shares:        balance,
+				PurchasePrice: utility.FloatToDecimal(1),
+				PurchaseDate:  transaction.TransactedOn,
+				PositionType:  models.PositionTypeLong,
+				AssetClass:    models.AssetClassCash,
+			}
+			if err := uc.portfolioRepo.Create(ctx, holding); err != nil {
+				return fmt.Errorf("failed to create cash holding: %w", err)
+			}
+		} else {
+			holding.Shares = balance
+			if err := uc.portfolioRepo.Update(ctx, holding); err != nil {
+				return fmt.Errorf("failed to update cash holding: %w", err)
+			}
+		}
+
+		if err := uc.cashRepo.Save(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to save cash transaction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Cash %s recorded: ¥%d (balance ¥%d)", transactionType, transaction.Amount, transaction.BalanceAfter)
+	return transaction, nil
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCashUseCase_DepositAndWithdraw(t *testing.T) {
+	holdings := map[string]*models.Portfolio{}
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetByCodeFunc: func(ctx context.Context, code string) (*models.Portfolio, error) {
+			return holdings[code], nil
+		},
+		CreateFunc: func(ctx context.Context, portfolio *models.Portfolio) error {
+			holdings[portfolio.Code] = portfolio
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, portfolio *models.Portfolio) error {
+			holdings[portfolio.Code] = portfolio
+			return nil
+		},
+	}
+	txManager := &mock.TransactionManagerMock{
+		WithTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+	cashRepo := &mock.CashTransactionRepositoryMock{
+		SaveFunc: func(ctx context.Context, transaction *models.CashTransaction) error { return nil },
+	}
+	uc := NewCashUseCase(portfolioRepo, cashRepo, txManager, "JPY")
+	day := time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+	uc.now = func() time.Time { return day.Add(9 * time.Hour) }
+	ctx := context.Background()
+
+	// A withdrawal without a cash balance is rejected
+	if _, err := uc.Withdraw(ctx, CashInput{Amount: 1000}); err == nil {
+		t.Fatal("Withdraw() without cash error = nil, want error")
+	}
+	// The first deposit creates the cash holding
+	if _, err := uc.Deposit(ctx, CashInput{Amount: 500000, Note: "初回入金"}); err != nil {
+		t.Fatalf("Deposit() error = %v", err)
+	}
+	if _, err := uc.Withdraw(ctx, CashInput{Amount: 200000, Date: day.AddDate(0, 0, 1)}); err != nil {
+		t.Fatalf("Withdraw() error = %v", err)
+	}
+	// The balance cannot go below zero
+	if _, err := uc.Withdraw(ctx, CashInput{Amount: 300001}); err == nil {
+		t.Error("Withdraw() over the balance error = nil, want error")
+	}
+	if _, err := uc.Deposit(ctx, CashInput{Amount: 0}); err == nil {
+		t.Error("Deposit(0) error = nil, want error")
+	}
+
+	holding := holdings["JPY"]
+	if holding == nil || !holding.IsCash() || holding.Shares != 300000 {
+		t.Fatalf("cash holding = %+v, want a cash balance of 300000", holding)
+	}
+
+	type recorded struct {
+		Type         string
+		Amount       int
+		BalanceAfter int
+		Note         string
+		TransactedOn time.Time
+	}
+	var got []recorded
+	for _, call := range cashRepo.SaveCalls() {
+		transaction := call.Transaction
+		got = append(got, recorded{transaction.TransactionType, transaction.Amount, transaction.BalanceAfter, transaction.Note, transaction.TransactedOn})
+	}
+	want := []recorded{
+		{models.CashTransactionDeposit, 500000, 500000, "初回入金", day},
+		{models.CashTransactionWithdrawal, 200000, 300000, "", day.AddDate(0, 0, 1)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("transactions mismatch (-want +got):\n%s", diff)
+	}
+}
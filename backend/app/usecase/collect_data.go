@@ -2,34 +2,92 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/boost-jp/stock-automation/app/domain"
 	"github.com/boost-jp/stock-automation/app/domain/models"
 	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/eventbus"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
 	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
 	"github.com/sirupsen/logrus"
 )
 
+// CollectOptions controls the alerts of a price collection.
+type CollectOptions struct {
+	// Silent suppresses the abnormal price and high error rate alerts, e.g. while backfilling
+	// history or collecting many stocks for the first time. The problems are still logged.
+	Silent bool
+}
+
+// sendCollectAlert sends an alert about a price collection unless the collection is silent
+// or no notifier is set.
+func sendCollectAlert(ctx context.Context, notifier notification.NotificationService, opts CollectOptions, message string) {
+	if notifier == nil {
+		return
+	}
+	if opts.Silent {
+		logrus.Infof("Collection alert suppressed (silent): %s", message)
+		return
+	}
+	if err := notifier.SendMessageOfKind(ctx, notification.KindCritical, message); err != nil {
+		logrus.Errorf("Failed to send collection alert: %v", err)
+	}
+}
+
 // CollectDataUseCase handles data collection business logic.
 type CollectDataUseCase struct {
 	stockRepo     repository.StockRepository
 	portfolioRepo repository.PortfolioRepository
 	stockClient   client.StockDataClient
+	priority      domain.PriceSourcePriority
+	throttle      func() float64
+	marketHours   domain.MarketHours
 	maxWorkers    int
+	notifier      notification.NotificationService
+	events        eventbus.Publisher
+	quality       *domain.DataQualityService
+
+	// Stocks failing too many times in a row are left out of the collection for a while
+	failureRepo         repository.CollectFailureRepository
+	quarantineThreshold int
+	quarantinePeriod    time.Duration
+
+	// lastCollected records when each stock was last collected by UpdateDuePrices or UpdateAllPrices
+	mu            sync.Mutex
+	lastCollected map[string]time.Time
+	now           func() time.Time
 }
 
 // NewCollectDataUseCase creates a new data collection use case.
+// throttle returns the factor stretching the collection intervals, e.g. while the quota of the
+// data provider runs low; nil keeps the intervals as set.
 func NewCollectDataUseCase(
 	stockRepo repository.StockRepository,
 	portfolioRepo repository.PortfolioRepository,
 	stockClient client.StockDataClient,
+	priority domain.PriceSourcePriority,
+	throttle func() float64,
 ) *CollectDataUseCase {
 	return &CollectDataUseCase{
 		stockRepo:     stockRepo,
 		portfolioRepo: portfolioRepo,
 		stockClient:   stockClient,
+		priority:      priority,
+		throttle:      throttle,
+		marketHours:   domain.TokyoMarketHours(),
 		maxWorkers:    5, // Limit concurrent API calls
+		quality:       domain.NewDataQualityService(),
+		lastCollected: make(map[string]time.Time),
+		now:           time.Now,
+
+		quarantineThreshold: domain.DefaultQuarantineThreshold,
+		quarantinePeriod:    domain.DefaultQuarantinePeriod,
 	}
 }
 
@@ -49,95 +107,349 @@ func (uc *CollectDataUseCase) UpdatePortfolio(ctx context.Context) error {
 	return nil
 }
 
-// UpdateAllPrices updates prices for all watched stocks and portfolio.
+// SetAlertNotifier sets the notifier of the abnormal price and high error rate alerts.
+// No alerts are sent without a notifier.
+func (uc *CollectDataUseCase) SetAlertNotifier(notifier notification.NotificationService) {
+	uc.notifier = notifier
+}
+
+// SetEventPublisher sets the publisher of the price update events.
+func (uc *CollectDataUseCase) SetEventPublisher(events eventbus.Publisher) {
+	uc.events = events
+}
+
+// SetQuarantine records the failures of each stock in failureRepo and leaves a stock out of the
+// collection once it has failed threshold times in a row, retrying it after period. The period doubles
+// with each failed retry. Stocks are never left out without a repository or with a threshold of zero.
+func (uc *CollectDataUseCase) SetQuarantine(failureRepo repository.CollectFailureRepository, threshold int, period time.Duration) {
+	uc.failureRepo = failureRepo
+	uc.quarantineThreshold = threshold
+	uc.quarantinePeriod = period
+}
+
+// UpdateAllPrices updates prices for all watched stocks and portfolio regardless of their collection intervals.
 func (uc *CollectDataUseCase) UpdateAllPrices(ctx context.Context) error {
-	// Fetch watch list from database
-	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	return uc.UpdateAllPricesWithOptions(ctx, CollectOptions{})
+}
+
+// UpdateAllPricesWithOptions updates prices for all watched stocks and portfolio like UpdateAllPrices,
+// with the alerts controlled by opts.
+func (uc *CollectDataUseCase) UpdateAllPricesWithOptions(ctx context.Context, opts CollectOptions) error {
+	watchList, portfolio, err := uc.getTargets(ctx)
 	if err != nil {
 		return err
 	}
 
-	// Fetch portfolio from database
-	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	return uc.UpdatePricesForStocks(ctx, watchList, portfolio, opts)
+}
+
+// UpdateDuePrices updates prices of the stocks whose collection interval has elapsed.
+// Watch list items use their own interval and other held stocks the default one, stretched by the
+// throttle. Stocks not collected by this process yet are compared with the fetch time of their
+// latest stored price.
+func (uc *CollectDataUseCase) UpdateDuePrices(ctx context.Context) error {
+	watchList, portfolio, err := uc.getTargets(ctx)
 	if err != nil {
 		return err
 	}
 
-	return uc.UpdatePricesForStocks(ctx, watchList, portfolio)
+	intervals := make(map[string]time.Duration)
+	for _, item := range portfolio {
+		intervals[item.Code] = domain.DefaultCollectInterval
+	}
+	// The interval of a watch list item applies even if the stock is held
+	for _, item := range watchList {
+		intervals[item.Code] = domain.CollectInterval(item.CollectIntervalMinutes)
+	}
+	if uc.throttle != nil {
+		if factor := uc.throttle(); factor > 1 {
+			logrus.Infof("Collection intervals stretched %.1fx to save the data provider quota", factor)
+			for code, interval := range intervals {
+				intervals[code] = time.Duration(float64(interval) * factor)
+			}
+		}
+	}
+
+	now := uc.now()
+	var unknown []string
+	uc.mu.Lock()
+	for code, interval := range intervals {
+		if _, ok := uc.lastCollected[code]; !ok && interval > domain.DefaultCollectInterval {
+			unknown = append(unknown, code)
+		}
+	}
+	uc.mu.Unlock()
+
+	fetchedAt := make(map[string]time.Time, len(unknown))
+	if len(unknown) > 0 {
+		latest, err := uc.stockRepo.GetLatestPrices(ctx, unknown)
+		if err != nil {
+			return err
+		}
+		for code, price := range latest {
+			if price.FetchedAt.Valid {
+				fetchedAt[code] = price.FetchedAt.Time
+			}
+		}
+	}
+
+	var due []string
+	uc.mu.Lock()
+	for code, interval := range intervals {
+		last, ok := uc.lastCollected[code]
+		if !ok {
+			last = fetchedAt[code]
+		}
+		if domain.IsCollectionDue(interval, last, now) {
+			due = append(due, code)
+		}
+	}
+	uc.mu.Unlock()
+
+	logrus.Debugf("%d of %d stocks due for price update", len(due), len(intervals))
+	uc.updatePrices(ctx, due, now, CollectOptions{})
+	return nil
 }
 
 // UpdatePricesForStocks updates prices for specific watch list and portfolio items.
-func (uc *CollectDataUseCase) UpdatePricesForStocks(ctx context.Context, watchList []*models.WatchList, portfolio []*models.Portfolio) error {
+func (uc *CollectDataUseCase) UpdatePricesForStocks(ctx context.Context, watchList []*models.WatchList, portfolio []*models.Portfolio, opts CollectOptions) error {
 	// Collect all unique stock codes
 	stockCodes := make(map[string]bool)
 	for _, item := range watchList {
 		stockCodes[item.Code] = true
 	}
-	for _, item := range portfolio {
+	for _, item := range listedHoldings(portfolio) {
 		stockCodes[item.Code] = true
 	}
 
-	// Create a channel for stock codes and a semaphore for limiting concurrency
-	codeChan := make(chan string, len(stockCodes))
+	codes := make([]string, 0, len(stockCodes))
 	for code := range stockCodes {
-		codeChan <- code
-	}
-	close(codeChan)
-
-	// Create worker pool
-	var wg sync.WaitGroup
-	errorChan := make(chan error, len(stockCodes))
-
-	for i := 0; i < uc.maxWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for stockCode := range codeChan {
-				if err := uc.UpdateStockPrice(ctx, stockCode); err != nil {
-					logrus.Errorf("Failed to update price for %s: %v", stockCode, err)
-					errorChan <- err
-				}
-			}
-		}()
+		codes = append(codes, code)
 	}
 
-	wg.Wait()
-	close(errorChan)
+	uc.updatePrices(ctx, codes, uc.now(), opts)
+	return nil
+}
 
-	// Check if there were any errors
-	var errors []error
-	for err := range errorChan {
-		errors = append(errors, err)
+// getTargets fetches the active watch list and the portfolio from the database.
+func (uc *CollectDataUseCase) getTargets(ctx context.Context) ([]*models.WatchList, []*models.Portfolio, error) {
+	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	return watchList, listedHoldings(portfolio), nil
+}
+
+// listedHoldings returns the holdings traded on an exchange, leaving out the investment trusts
+// and cash balances whose prices do not come from the stock data sources.
+func listedHoldings(portfolio []*models.Portfolio) []*models.Portfolio {
+	listed := make([]*models.Portfolio, 0, len(portfolio))
+	for _, holding := range portfolio {
+		if holding.IsListed() {
+			listed = append(listed, holding)
+		}
+	}
+	return listed
+}
+
+// updatePrices updates the prices of the codes concurrently, except the quarantined stocks, and records
+// the stocks updated successfully as collected at now. An alert is sent if many of the stocks failed,
+// and a price update event is published once the run has completed.
+func (uc *CollectDataUseCase) updatePrices(ctx context.Context, codes []string, now time.Time, opts CollectOptions) {
+	codes, failures := uc.skipQuarantined(ctx, codes, now)
+
+	errors := runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, stockCode string) error {
+		return uc.UpdateStockPrice(ctx, stockCode, opts)
+	})
+	for stockCode, err := range errors {
+		logrus.Errorf("Failed to update price for %s: %v", stockCode, err)
+	}
+	uc.recordFailures(ctx, codes, errors, failures, now, opts)
+
 	if len(errors) > 0 {
 		logrus.Warnf("Encountered %d errors during price updates", len(errors))
 	}
+	if domain.IsHighCollectErrorRate(len(errors), len(codes)) {
+		sendCollectAlert(ctx, uc.notifier, opts, domain.FormatCollectErrorRateAlert(errors, len(codes)))
+	}
+
+	event := domain.PriceUpdatedEvent{Silent: opts.Silent, At: now}
+	uc.mu.Lock()
+	for _, code := range codes {
+		if _, failed := errors[code]; failed {
+			event.Failed = append(event.Failed, code)
+			continue
+		}
+		uc.lastCollected[code] = now
+		event.Updated = append(event.Updated, code)
+	}
+	uc.mu.Unlock()
 
+	if len(codes) > 0 {
+		eventbus.Publish(ctx, uc.events, event)
+	}
+}
+
+// skipQuarantined returns the codes not quarantined at now, with the failure records of the stocks
+// that have failed recently. All codes are returned if the records cannot be read.
+func (uc *CollectDataUseCase) skipQuarantined(ctx context.Context, codes []string, now time.Time) ([]string, map[string]*models.CollectFailure) {
+	if uc.failureRepo == nil || uc.quarantineThreshold <= 0 || len(codes) == 0 {
+		return codes, nil
+	}
+
+	records, err := uc.failureRepo.GetAll(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get collect failures, collecting all stocks: %v", err)
+		return codes, nil
+	}
+	failures := make(map[string]*models.CollectFailure, len(records))
+	for _, failure := range records {
+		failures[failure.Code] = failure
+	}
+
+	collected := make([]string, 0, len(codes))
+	var skipped []string
+	for _, code := range codes {
+		if failure, ok := failures[code]; ok && failure.IsQuarantined(now) {
+			skipped = append(skipped, code)
+			continue
+		}
+		collected = append(collected, code)
+	}
+	if len(skipped) > 0 {
+		logrus.Infof("%d quarantined stocks skipped: %s", len(skipped), strings.Join(skipped, ", "))
+	}
+	return collected, failures
+}
+
+// recordFailures counts the failures of the stocks collected at now, quarantining those failing too
+// many times in a row, and clears the records of the stocks collected successfully.
+func (uc *CollectDataUseCase) recordFailures(ctx context.Context, codes []string, errs map[string]error, failures map[string]*models.CollectFailure, now time.Time, opts CollectOptions) {
+	if uc.failureRepo == nil || uc.quarantineThreshold <= 0 {
+		return
+	}
+
+	for _, code := range codes {
+		err, failed := errs[code]
+		if !failed {
+			if _, ok := failures[code]; ok {
+				if _, err := uc.failureRepo.Delete(ctx, code); err != nil {
+					logrus.Warnf("Failed to clear collect failures of %s: %v", code, err)
+				} else if failures[code].QuarantinedUntil != nil {
+					logrus.Infof("%s collected again, released from quarantine", code)
+				}
+			}
+			continue
+		}
+
+		failure, quarantined := domain.RecordCollectFailure(failures[code], code, err, now, uc.quarantineThreshold, uc.quarantinePeriod)
+		if err := uc.failureRepo.Upsert(ctx, failure); err != nil {
+			logrus.Warnf("Failed to record collect failure of %s: %v", code, err)
+			continue
+		}
+		if quarantined {
+			logrus.Warnf("%s quarantined until %s after %d failures in a row", code,
+				failure.QuarantinedUntil.Format("2006-01-02 15:04"), failure.ConsecutiveFailures)
+			sendCollectAlert(ctx, uc.notifier, opts, domain.FormatQuarantineAlert(failure))
+		}
+	}
+}
+
+// ListCollectFailures returns the stocks failing the price collection, ordered by code, with the
+// period the quarantined ones are left out of the collection.
+func (uc *CollectDataUseCase) ListCollectFailures(ctx context.Context) ([]*models.CollectFailure, error) {
+	if uc.failureRepo == nil {
+		return []*models.CollectFailure{}, nil
+	}
+	failures, err := uc.failureRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collect failures: %w", err)
+	}
+	return failures, nil
+}
+
+// ReleaseQuarantine clears the failures of a stock, so that it is collected from the next run.
+func (uc *CollectDataUseCase) ReleaseQuarantine(ctx context.Context, code string) error {
+	if uc.failureRepo == nil {
+		return fmt.Errorf("価格取得失敗の記録が有効になっていません")
+	}
+	deleted, err := uc.failureRepo.Delete(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to clear collect failures: %w", err)
+	}
+	if !deleted {
+		return fmt.Errorf("%s は価格取得に失敗していません", code)
+	}
+
+	logrus.Infof("Collect failures of %s cleared", code)
 	return nil
 }
 
 // UpdateStockPrice updates the price for a single stock.
-func (uc *CollectDataUseCase) UpdateStockPrice(ctx context.Context, stockCode string) error {
-	price, err := uc.stockClient.GetCurrentPrice(stockCode)
+// Nothing is written when the price is unchanged from the latest stored one, e.g. while the
+// market is closed, and the record of the same trading day is updated instead of inserting another one
+// unless it is already final or comes from a data source of higher priority. An alert is sent if the price moved
+// abnormally far from the latest stored one; the price is stored all the same.
+func (uc *CollectDataUseCase) UpdateStockPrice(ctx context.Context, stockCode string, opts CollectOptions) error {
+	price, err := uc.stockClient.GetCurrentPrice(ctx, stockCode)
+	if err != nil {
+		return err
+	}
+
+	latest, err := uc.stockRepo.GetLatestPrice(ctx, stockCode)
 	if err != nil {
 		return err
 	}
 
-	if err := uc.stockRepo.SaveStockPrice(ctx, price); err != nil {
+	switch {
+	case latest == nil:
+		err = uc.stockRepo.SaveStockPrice(ctx, price)
+	case latest.SameQuote(price):
+		logrus.Debugf("Price unchanged for %s, skipped", stockCode)
+		return nil
+	case latest.SameTradingDay(price):
+		if latest.Final {
+			logrus.Debugf("Final price of %s kept over the current quote", stockCode)
+			return nil
+		}
+		if uc.priority.Outranks(latest.Source, price.Source) {
+			logrus.Debugf("Price of %s from %s kept over %s", stockCode, latest.Source, price.Source)
+			return nil
+		}
+		err = uc.stockRepo.UpdateStockPrice(ctx, price)
+	default:
+		err = uc.stockRepo.SaveStockPrice(ctx, price)
+	}
+	if err != nil {
 		return err
 	}
 
+	if latest != nil {
+		previousClose, close := utility.DecimalToFloat(latest.ClosePrice), utility.DecimalToFloat(price.ClosePrice)
+		if uc.quality.IsAbnormalChange(previousClose, close) {
+			logrus.Warnf("Abnormal price change for %s: %.2f -> %.2f", stockCode, previousClose, close)
+			sendCollectAlert(ctx, uc.notifier, opts, domain.FormatPriceAnomalyAlert(stockCode, previousClose, close))
+		}
+	}
+
 	logrus.Debugf("Price updated for %s: %.2f", stockCode, price.ClosePrice)
 	return nil
 }
 
 // CollectHistoricalData collects historical data for technical analysis.
+// The prices of the trading days before today are stored as final.
 func (uc *CollectDataUseCase) CollectHistoricalData(ctx context.Context, stockCode string, days int) error {
-	prices, err := uc.stockClient.GetHistoricalData(stockCode, days)
+	prices, err := uc.stockClient.GetHistoricalData(ctx, stockCode, days)
 	if err != nil {
 		return err
 	}
+	domain.MarkFinalPrices(prices, uc.now())
 
 	if err := uc.stockRepo.SaveStockPrices(ctx, prices); err != nil {
 		return err
@@ -147,20 +459,133 @@ func (uc *CollectDataUseCase) CollectHistoricalData(ctx context.Context, stockCo
 	return nil
 }
 
-// IsMarketOpen checks if the market is currently open.
-func (uc *CollectDataUseCase) IsMarketOpen() bool {
-	now := time.Now()
-	jst, _ := time.LoadLocation("Asia/Tokyo")
-	nowJST := now.In(jst)
+// PriceConfirmationResult is the outcome of confirming the closing prices of a trading day.
+type PriceConfirmationResult struct {
+	Date      time.Time
+	Confirmed []string         // codes whose price of the day was stored as final
+	Kept      []string         // codes already final from a source of higher priority
+	Missing   []string         // codes the data source has no price of the day for yet
+	Failed    map[string]error // codes that could not be confirmed
+}
 
-	// Check if weekend
-	if nowJST.Weekday() == time.Saturday || nowJST.Weekday() == time.Sunday {
-		return false
+// ConfirmClosingPrices re-fetches the daily bars of today after the market has closed and stores
+// them as final over the provisional prices collected during the session, inserting the stocks
+// without a price of the day. A price already final is only replaced by a source of higher or the
+// same priority. An alert is sent if many of the stocks failed.
+func (uc *CollectDataUseCase) ConfirmClosingPrices(ctx context.Context) (*PriceConfirmationResult, error) {
+	watchList, portfolio, err := uc.getTargets(ctx)
+	if err != nil {
+		return nil, err
 	}
+	seen := make(map[string]bool)
+	var codes []string
+	for _, item := range watchList {
+		if !seen[item.Code] {
+			seen[item.Code] = true
+			codes = append(codes, item.Code)
+		}
+	}
+	for _, item := range portfolio {
+		if !seen[item.Code] {
+			seen[item.Code] = true
+			codes = append(codes, item.Code)
+		}
+	}
+
+	day := models.TruncateToDate(uc.now().In(uc.marketHours.Location()))
+	result := &PriceConfirmationResult{Date: day}
+	var mu sync.Mutex
+	result.Failed = runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, stockCode string) error {
+		outcome, err := uc.confirmClosingPrice(ctx, stockCode, day)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch outcome {
+		case confirmationStored:
+			result.Confirmed = append(result.Confirmed, stockCode)
+		case confirmationKept:
+			result.Kept = append(result.Kept, stockCode)
+		case confirmationMissing:
+			result.Missing = append(result.Missing, stockCode)
+		}
+		return nil
+	})
+	sort.Strings(result.Confirmed)
+	sort.Strings(result.Kept)
+	sort.Strings(result.Missing)
 
-	// Market hours: 9:00 - 15:00 JST
-	hour := nowJST.Hour()
-	return hour >= 9 && hour < 15
+	for stockCode, err := range result.Failed {
+		logrus.Errorf("Failed to confirm closing price for %s: %v", stockCode, err)
+	}
+	if len(result.Missing) > 0 {
+		logrus.Warnf("No closing price of %s yet for %s", day.Format("2006-01-02"), strings.Join(result.Missing, ", "))
+	}
+	if domain.IsHighCollectErrorRate(len(result.Failed), len(codes)) {
+		sendCollectAlert(ctx, uc.notifier, CollectOptions{}, domain.FormatCollectErrorRateAlert(result.Failed, len(codes)))
+	}
+
+	logrus.Infof("Closing prices of %s confirmed: %d confirmed, %d kept, %d missing, %d failed",
+		day.Format("2006-01-02"), len(result.Confirmed), len(result.Kept), len(result.Missing), len(result.Failed))
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("failed to confirm closing prices of %d of %d stocks", len(result.Failed), len(codes))
+	}
+	return result, nil
+}
+
+// RunClosingConfirmation confirms the closing prices of today, for the scheduler.
+func (uc *CollectDataUseCase) RunClosingConfirmation(ctx context.Context) error {
+	_, err := uc.ConfirmClosingPrices(ctx)
+	return err
+}
+
+// Outcomes of confirming the closing price of a stock.
+const (
+	confirmationStored = iota
+	confirmationKept
+	confirmationMissing
+)
+
+// confirmClosingPrice stores the final price of the day of a stock.
+func (uc *CollectDataUseCase) confirmClosingPrice(ctx context.Context, stockCode string, day time.Time) (int, error) {
+	prices, err := uc.stockClient.GetHistoricalDataRange(ctx, stockCode, day, day)
+	if err != nil {
+		return 0, err
+	}
+	var final *models.StockPrice
+	for _, price := range prices {
+		if price.Date.Format("2006-01-02") == day.Format("2006-01-02") {
+			final = price
+		}
+	}
+	if final == nil {
+		return confirmationMissing, nil
+	}
+	final.Final = true
+
+	stored, err := uc.stockRepo.GetPriceHistoryRange(ctx, stockCode, day, day)
+	if err != nil {
+		return 0, err
+	}
+	if len(stored) == 0 {
+		return confirmationStored, uc.stockRepo.SaveStockPrice(ctx, final)
+	}
+	if stored[0].Final && uc.priority.Outranks(stored[0].Source, final.Source) {
+		return confirmationKept, nil
+	}
+	return confirmationStored, uc.stockRepo.UpdateStockPrice(ctx, final)
+}
+
+// SetMarketHours sets the trading hours of the market the stocks are collected from,
+// the Tokyo Stock Exchange by default.
+func (uc *CollectDataUseCase) SetMarketHours(marketHours domain.MarketHours) {
+	uc.marketHours = marketHours
+}
+
+// IsMarketOpen checks if the market is currently open.
+func (uc *CollectDataUseCase) IsMarketOpen() bool {
+	return uc.marketHours.IsOpen(uc.now())
 }
 
 // CleanupOldData removes old data from the database.
@@ -0,0 +1,448 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newPriceStockRepository serves the latest price and records how prices are written.
+func newPriceStockRepository(latest *models.StockPrice, writes *[]string) *mock.StockRepositoryMock {
+	return &mock.StockRepositoryMock{
+		GetLatestPriceFunc: func(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+			return latest, nil
+		},
+		SaveStockPriceFunc: func(ctx context.Context, price *models.StockPrice) error {
+			*writes = append(*writes, "insert "+price.Date.Format("2006-01-02"))
+			return nil
+		},
+		UpdateStockPriceFunc: func(ctx context.Context, price *models.StockPrice) error {
+			*writes = append(*writes, "update "+price.Date.Format("2006-01-02"))
+			return nil
+		},
+	}
+}
+
+// fakeCurrentPriceClient returns a fixed current price.
+type fakeCurrentPriceClient struct {
+	client.StockDataClient
+	price *models.StockPrice
+}
+
+func (f *fakeCurrentPriceClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	return f.price, nil
+}
+
+func TestCollectDataUseCase_UpdateStockPrice(t *testing.T) {
+	quote := func(date time.Time, close float64, volume int64) *models.StockPrice {
+		return &models.StockPrice{
+			Code:       "7203",
+			Date:       date,
+			OpenPrice:  utility.FloatToDecimal(3530),
+			HighPrice:  utility.FloatToDecimal(3600),
+			LowPrice:   utility.FloatToDecimal(3510),
+			ClosePrice: utility.FloatToDecimal(close),
+			Volume:     volume,
+		}
+	}
+	from := func(price *models.StockPrice, source string) *models.StockPrice {
+		price.Source = source
+		return price
+	}
+	friday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.Local)
+	fridayAfternoon := time.Date(2024, 6, 7, 14, 0, 0, 0, time.Local)
+	saturday := time.Date(2024, 6, 8, 10, 0, 0, 0, time.Local)
+	monday := time.Date(2024, 6, 10, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		latest     *models.StockPrice
+		current    *models.StockPrice
+		wantWrites []string
+	}{
+		{
+			name:       "No stored price",
+			latest:     nil,
+			current:    quote(fridayAfternoon, 3590, 9876500),
+			wantWrites: []string{"insert 2024-06-07"},
+		},
+		{
+			name:       "Price changed during the day",
+			latest:     quote(friday, 3580, 8000000),
+			current:    quote(fridayAfternoon, 3590, 9876500),
+			wantWrites: []string{"update 2024-06-07"},
+		},
+		{
+			name:       "Unchanged while the market is closed",
+			latest:     quote(friday, 3590, 9876500),
+			current:    quote(saturday, 3590, 9876500),
+			wantWrites: nil,
+		},
+		{
+			name:       "New trading day",
+			latest:     quote(friday, 3590, 9876500),
+			current:    quote(monday, 3600, 120000),
+			wantWrites: []string{"insert 2024-06-10"},
+		},
+		{
+			name:       "Same day from a source of lower priority",
+			latest:     from(quote(friday, 3580, 8000000), client.SourceJQuants),
+			current:    from(quote(fridayAfternoon, 3590, 9876500), client.SourceYahoo),
+			wantWrites: nil,
+		},
+		{
+			name:       "Same day already final",
+			latest:     &models.StockPrice{Code: "7203", Date: friday, ClosePrice: utility.FloatToDecimal(3590), Final: true},
+			current:    quote(fridayAfternoon, 3600, 9876500),
+			wantWrites: nil,
+		},
+		{
+			name:       "Same day from a source of higher priority",
+			latest:     from(quote(friday, 3580, 8000000), client.SourceStooq),
+			current:    from(quote(fridayAfternoon, 3590, 9876500), client.SourceYahoo),
+			wantWrites: []string{"update 2024-06-07"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			stockRepo := newPriceStockRepository(tt.latest, &writes)
+			uc := NewCollectDataUseCase(stockRepo, nil, &fakeCurrentPriceClient{price: tt.current},
+				domain.ParsePriceSourcePriority(domain.DefaultPriceSourcePriority), nil)
+
+			if err := uc.UpdateStockPrice(context.Background(), "7203", CollectOptions{}); err != nil {
+				t.Fatalf("UpdateStockPrice() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.wantWrites, writes); diff != "" {
+				t.Errorf("writes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeAlertNotifier records the messages sent.
+type fakeAlertNotifier struct {
+	notification.NotificationService
+	mu       sync.Mutex
+	messages []string
+}
+
+func (f *fakeAlertNotifier) SendMessageOfKind(ctx context.Context, kind notification.MessageKind, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func TestCollectDataUseCase_UpdateStockPrice_Alerts(t *testing.T) {
+	latest := &models.StockPrice{Code: "7203", Date: time.Date(2024, 6, 7, 0, 0, 0, 0, time.Local), ClosePrice: utility.FloatToDecimal(3000)}
+	current := &models.StockPrice{Code: "7203", Date: time.Date(2024, 6, 10, 9, 30, 0, 0, time.Local), ClosePrice: utility.FloatToDecimal(300)}
+
+	for _, silent := range []bool{false, true} {
+		var writes []string
+		notifier := &fakeAlertNotifier{}
+		uc := NewCollectDataUseCase(newPriceStockRepository(latest, &writes), nil, &fakeCurrentPriceClient{price: current},
+			domain.ParsePriceSourcePriority(domain.DefaultPriceSourcePriority), nil)
+		uc.SetAlertNotifier(notifier)
+
+		if err := uc.UpdateStockPrice(context.Background(), "7203", CollectOptions{Silent: silent}); err != nil {
+			t.Fatalf("UpdateStockPrice() error = %v", err)
+		}
+		// The abnormal price is stored all the same
+		if diff := cmp.Diff([]string{"insert 2024-06-10"}, writes); diff != "" {
+			t.Errorf("writes mismatch (-want +got):\n%s", diff)
+		}
+		if wantAlerts := map[bool]int{false: 1, true: 0}[silent]; len(notifier.messages) != wantAlerts {
+			t.Errorf("silent=%v: %d alerts sent, want %d: %q", silent, len(notifier.messages), wantAlerts, notifier.messages)
+		}
+	}
+}
+
+// newDueStockRepository serves the watch list and the latest prices of the stocks.
+func newDueStockRepository(watchList []*models.WatchList, latest map[string]*models.StockPrice) *mock.StockRepositoryMock {
+	return &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return watchList, nil
+		},
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			prices := make(map[string]*models.StockPrice)
+			for _, code := range codes {
+				if price, ok := latest[code]; ok {
+					prices[code] = price
+				}
+			}
+			return prices, nil
+		},
+		GetLatestPriceFunc: func(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+			return nil, nil
+		},
+		SaveStockPriceFunc: func(ctx context.Context, price *models.StockPrice) error {
+			return nil
+		},
+	}
+}
+
+// fakeRecordingPriceClient records the codes whose current price is requested.
+type fakeRecordingPriceClient struct {
+	client.StockDataClient
+	mu        sync.Mutex
+	requested []string
+}
+
+func (f *fakeRecordingPriceClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requested = append(f.requested, stockCode)
+	return &models.StockPrice{Code: stockCode, Date: time.Now()}, nil
+}
+
+// takeRequested returns the requested codes sorted and clears them.
+func (f *fakeRecordingPriceClient) takeRequested() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requested := f.requested
+	f.requested = nil
+	sort.Strings(requested)
+	return requested
+}
+
+func TestCollectDataUseCase_UpdateDuePrices(t *testing.T) {
+	start := time.Date(2024, 6, 7, 10, 0, 0, 0, time.Local)
+	stockRepo := newDueStockRepository(
+		[]*models.WatchList{
+			{Code: "1001"},
+			{Code: "1002", CollectIntervalMinutes: 60},
+			{Code: "1003", CollectIntervalMinutes: 24 * 60},
+		},
+		// Collected at the close of the previous day
+		map[string]*models.StockPrice{
+			"1003": {Code: "1003", FetchedAt: null.TimeFrom(start.Add(-19 * time.Hour))},
+		},
+	)
+	// 1002 is also held, but collected at the interval of the watch list
+	portfolioRepo := newHoldingsRepository([]*models.Portfolio{{Code: "1002"}, {Code: "1004"}})
+	priceClient := &fakeRecordingPriceClient{}
+	uc := NewCollectDataUseCase(stockRepo, portfolioRepo, priceClient, nil, nil)
+
+	runs := []struct {
+		at   time.Time
+		want []string
+	}{
+		{at: start, want: []string{"1001", "1002", "1004"}},
+		{at: start.Add(5*time.Minute + 3*time.Second), want: []string{"1001", "1004"}},
+		{at: start.Add(time.Hour), want: []string{"1001", "1002", "1004"}},
+	}
+	for _, run := range runs {
+		uc.now = func() time.Time { return run.at }
+		if err := uc.UpdateDuePrices(context.Background()); err != nil {
+			t.Fatalf("UpdateDuePrices() error = %v", err)
+		}
+		if diff := cmp.Diff(run.want, priceClient.takeRequested()); diff != "" {
+			t.Errorf("requested codes at %s mismatch (-want +got):\n%s", run.at.Format("15:04:05"), diff)
+		}
+	}
+
+	// Daily stocks are collected with all the others at the close
+	if err := uc.UpdateAllPrices(context.Background()); err != nil {
+		t.Fatalf("UpdateAllPrices() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"1001", "1002", "1003", "1004"}, priceClient.takeRequested()); diff != "" {
+		t.Errorf("requested codes at the close mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// fakeDailyBarClient serves the daily bars of the day by code, or an error for the failing codes.
+type fakeDailyBarClient struct {
+	client.StockDataClient
+	bars   map[string]*models.StockPrice
+	failed map[string]bool
+}
+
+func (f *fakeDailyBarClient) GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	if f.failed[stockCode] {
+		return nil, errors.New("quote API unavailable")
+	}
+	if bar, ok := f.bars[stockCode]; ok {
+		copied := *bar
+		return []*models.StockPrice{&copied}, nil
+	}
+	return nil, nil
+}
+
+func TestCollectDataUseCase_ConfirmClosingPrices(t *testing.T) {
+	day := time.Date(2024, 6, 7, 0, 0, 0, 0, time.Local)
+	bar := func(code, source string, close float64, final bool) *models.StockPrice {
+		return &models.StockPrice{Code: code, Date: day, ClosePrice: utility.FloatToDecimal(close), Source: source, Final: final}
+	}
+	stored := map[string]*models.StockPrice{
+		"7203": bar("7203", client.SourceYahoo, 3580, false),  // provisional price of the session
+		"9984": bar("9984", client.SourceJQuants, 9100, true), // already final from a preferred source
+	}
+	source := &fakeDailyBarClient{
+		bars: map[string]*models.StockPrice{
+			"7203": bar("7203", client.SourceYahoo, 3590, false),
+			"6758": bar("6758", client.SourceYahoo, 13200, false),
+			"9984": bar("9984", client.SourceYahoo, 9110, false),
+		},
+		failed: map[string]bool{"4063": true},
+	}
+
+	var mu sync.Mutex
+	var writes []string
+	record := func(op string, price *models.StockPrice) {
+		mu.Lock()
+		defer mu.Unlock()
+		writes = append(writes, fmt.Sprintf("%s %s %.0f final=%t", op, price.Code, utility.DecimalToFloat(price.ClosePrice), price.Final))
+	}
+	stockRepo := &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{{Code: "7203"}, {Code: "6758"}, {Code: "8306"}}, nil
+		},
+		GetPriceHistoryRangeFunc: func(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+			if price, ok := stored[stockCode]; ok {
+				return []*models.StockPrice{price}, nil
+			}
+			return nil, nil
+		},
+		SaveStockPriceFunc: func(ctx context.Context, price *models.StockPrice) error {
+			record("insert", price)
+			return nil
+		},
+		UpdateStockPriceFunc: func(ctx context.Context, price *models.StockPrice) error {
+			record("update", price)
+			return nil
+		},
+	}
+	portfolioRepo := newHoldingsRepository([]*models.Portfolio{{Code: "7203"}, {Code: "9984"}, {Code: "4063"}})
+	uc := NewCollectDataUseCase(stockRepo, portfolioRepo, source, domain.ParsePriceSourcePriority(domain.DefaultPriceSourcePriority), nil)
+	// The day is the trading day of the market whatever the time zone of the host
+	uc.now = func() time.Time { return time.Date(2024, 6, 7, 18, 0, 0, 0, domain.TokyoMarketHours().Location()) }
+
+	result, err := uc.ConfirmClosingPrices(context.Background())
+	if err == nil {
+		t.Error("ConfirmClosingPrices() error = nil, want the failure of 4063")
+	}
+
+	sort.Strings(writes)
+	wantWrites := []string{"insert 6758 13200 final=true", "update 7203 3590 final=true"}
+	if diff := cmp.Diff(wantWrites, writes); diff != "" {
+		t.Errorf("writes mismatch (-want +got):\n%s", diff)
+	}
+	got := map[string][]string{"confirmed": result.Confirmed, "kept": result.Kept, "missing": result.Missing}
+	for code := range result.Failed {
+		got["failed"] = append(got["failed"], code)
+	}
+	want := map[string][]string{
+		"confirmed": {"6758", "7203"},
+		"kept":      {"9984"},
+		"missing":   {"8306"},
+		"failed":    {"4063"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// newCollectFailureRepositoryMock returns a repository mock keeping the failure records in failures.
+// The records are guarded by a mutex, as the collection workers record failures concurrently.
+func newCollectFailureRepositoryMock(failures map[string]*models.CollectFailure) *mock.CollectFailureRepositoryMock {
+	var mu sync.Mutex
+	return &mock.CollectFailureRepositoryMock{
+		UpsertFunc: func(ctx context.Context, failure *models.CollectFailure) error {
+			mu.Lock()
+			defer mu.Unlock()
+			copied := *failure
+			failures[failure.Code] = &copied
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, code string) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			_, ok := failures[code]
+			delete(failures, code)
+			return ok, nil
+		},
+		GetAllFunc: func(ctx context.Context) ([]*models.CollectFailure, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			records := []*models.CollectFailure{}
+			for _, failure := range failures {
+				copied := *failure
+				records = append(records, &copied)
+			}
+			return records, nil
+		},
+	}
+}
+
+// fakeFailingPriceClient records the requested codes like fakeRecordingPriceClient, failing for the failing codes.
+type fakeFailingPriceClient struct {
+	fakeRecordingPriceClient
+	failing map[string]bool
+}
+
+func (f *fakeFailingPriceClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	price, _ := f.fakeRecordingPriceClient.GetCurrentPrice(ctx, stockCode)
+	if f.failing[stockCode] {
+		return nil, fmt.Errorf("no data for %s", stockCode)
+	}
+	return price, nil
+}
+
+func TestCollectDataUseCase_Quarantine(t *testing.T) {
+	start := time.Date(2024, 6, 7, 10, 0, 0, 0, time.Local)
+	stockRepo := newDueStockRepository([]*models.WatchList{{Code: "1001"}, {Code: "1002"}}, nil)
+	priceClient := &fakeFailingPriceClient{failing: map[string]bool{"1002": true}}
+	failureRepo := newCollectFailureRepositoryMock(map[string]*models.CollectFailure{})
+	notifier := &fakeAlertNotifier{}
+	uc := NewCollectDataUseCase(stockRepo, newHoldingsRepository(nil), priceClient, nil, nil)
+	uc.SetAlertNotifier(notifier)
+	uc.SetQuarantine(failureRepo, 3, time.Hour)
+
+	collect := func(at time.Time) []string {
+		t.Helper()
+		uc.now = func() time.Time { return at }
+		if err := uc.UpdateAllPrices(context.Background()); err != nil {
+			t.Fatalf("UpdateAllPrices() error = %v", err)
+		}
+		return priceClient.takeRequested()
+	}
+
+	// 1002 is collected until it has failed 3 times in a row
+	for i := 0; i < 3; i++ {
+		if diff := cmp.Diff([]string{"1001", "1002"}, collect(start.Add(time.Duration(i)*time.Minute))); diff != "" {
+			t.Errorf("run %d requested codes mismatch (-want +got):\n%s", i+1, diff)
+		}
+	}
+	if len(notifier.messages) != 1 {
+		t.Errorf("%d alerts sent, want 1 on quarantine: %q", len(notifier.messages), notifier.messages)
+	}
+
+	// and left out then until the retry an hour later
+	if diff := cmp.Diff([]string{"1001"}, collect(start.Add(30*time.Minute))); diff != "" {
+		t.Errorf("requested codes while quarantined mismatch (-want +got):\n%s", diff)
+	}
+	priceClient.failing = nil
+	if diff := cmp.Diff([]string{"1001", "1002"}, collect(start.Add(2*time.Hour+time.Minute))); diff != "" {
+		t.Errorf("requested codes at retry mismatch (-want +got):\n%s", diff)
+	}
+
+	// A successful retry clears the record
+	if failures, _ := uc.ListCollectFailures(context.Background()); len(failures) != 0 {
+		t.Errorf("ListCollectFailures() = %d records after success, want none", len(failures))
+	}
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// InitialHistoryDays is the period of historical data collected when a stock is added to the collection targets.
+const InitialHistoryDays = 365
+
+// CollectTargetChanges summarizes the changes of the collection targets detected by a sync.
+type CollectTargetChanges struct {
+	Added       []string         // codes added to the watch list or portfolio since the last sync
+	Removed     []string         // codes no longer watched or held
+	Initialized []string         // added codes whose initial historical data was collected
+	Failed      map[string]error // added codes whose initial collection failed (retried on the next sync)
+}
+
+// CollectTargetSyncUseCase detects stocks added to or removed from the watch list and portfolio
+// while the server is running. Added stocks without stored prices get their initial history
+// collected right away instead of waiting for a bulk collection.
+type CollectTargetSyncUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	bulkCollect   *BulkCollectUseCase
+	collectData   *CollectDataUseCase
+
+	mu    sync.Mutex
+	known map[string]bool // nil until the first sync
+}
+
+// NewCollectTargetSyncUseCase creates a new collection target sync use case.
+func NewCollectTargetSyncUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	bulkCollect *BulkCollectUseCase,
+	collectData *CollectDataUseCase,
+) *CollectTargetSyncUseCase {
+	return &CollectTargetSyncUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		bulkCollect:   bulkCollect,
+		collectData:   collectData,
+	}
+}
+
+// SyncTargets compares the current collection targets with those of the last sync.
+// On the first sync every target is treated as added, so stocks added while the
+// server was stopped are initialized as well.
+func (uc *CollectTargetSyncUseCase) SyncTargets(ctx context.Context) (*CollectTargetChanges, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	codes, err := collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &CollectTargetChanges{Failed: make(map[string]error)}
+	current := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		current[code] = true
+		if !uc.known[code] {
+			changes.Added = append(changes.Added, code)
+		}
+	}
+	for code := range uc.known {
+		if !current[code] {
+			changes.Removed = append(changes.Removed, code)
+		}
+	}
+	sort.Strings(changes.Removed)
+
+	for _, code := range changes.Added {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		initialized, err := uc.initialize(ctx, code)
+		if err != nil {
+			logrus.Errorf("Failed to initialize collection of %s: %v", code, err)
+			changes.Failed[code] = err
+			delete(current, code) // retried on the next sync
+			continue
+		}
+		if initialized {
+			changes.Initialized = append(changes.Initialized, code)
+		}
+	}
+
+	firstSync := uc.known == nil
+	uc.known = current
+
+	if !firstSync && (len(changes.Added) > 0 || len(changes.Removed) > 0) {
+		logrus.Infof("Collection targets changed: %d added, %d removed", len(changes.Added), len(changes.Removed))
+	}
+	for _, code := range changes.Removed {
+		logrus.Infof("Stopped collecting %s", code)
+	}
+
+	return changes, nil
+}
+
+// RunScheduledSync runs the collection target sync for the scheduler.
+func (uc *CollectTargetSyncUseCase) RunScheduledSync(ctx context.Context) error {
+	_, err := uc.SyncTargets(ctx)
+	return err
+}
+
+// initialize collects the initial history of a stock without stored prices, and its current
+// price while the market is open. Returns false if prices were already stored.
+func (uc *CollectTargetSyncUseCase) initialize(ctx context.Context, code string) (bool, error) {
+	latest, err := uc.stockRepo.GetLatestPrice(ctx, code)
+	if err != nil {
+		return false, fmt.Errorf("failed to get latest price: %w", err)
+	}
+	if latest != nil {
+		return false, nil
+	}
+
+	// The initial collection is silent, as the history of a new stock may well contain jumps
+	// such as stock splits, which the data quality report tells about instead
+	result := uc.bulkCollect.CollectCodes(ctx, []string{code}, InitialHistoryDays, CollectOptions{Silent: true})
+	if err := result.Failed[code]; err != nil {
+		return false, err
+	}
+
+	if uc.collectData.IsMarketOpen() {
+		if err := uc.collectData.UpdateStockPrice(ctx, code, CollectOptions{Silent: true}); err != nil {
+			// The history is saved, so the price is left to the next price update
+			logrus.Warnf("Failed to update current price for %s: %v", code, err)
+		}
+	}
+
+	logrus.Infof("Started collecting %s: %d days of history saved", code, result.SavedRecords)
+	return true, nil
+}
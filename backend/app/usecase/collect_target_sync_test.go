@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTargetStockRepository serves the watch list and stores saved prices in memory.
+func newTargetStockRepository(watchList *[]*models.WatchList, prices map[string][]*models.StockPrice) *mock.StockRepositoryMock {
+	saveStockPrices := func(ctx context.Context, saved []*models.StockPrice) error {
+		for _, price := range saved {
+			prices[price.Code] = append(prices[price.Code], price)
+		}
+		return nil
+	}
+	return &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return *watchList, nil
+		},
+		GetLatestPriceFunc: func(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+			stored := prices[stockCode]
+			if len(stored) == 0 {
+				return nil, nil
+			}
+			return stored[len(stored)-1], nil
+		},
+		GetPriceHistoryFunc: func(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+			return prices[stockCode], nil
+		},
+		SaveStockPricesFunc: saveStockPrices,
+		SaveStockPriceFunc: func(ctx context.Context, price *models.StockPrice) error {
+			return saveStockPrices(ctx, []*models.StockPrice{price})
+		},
+	}
+}
+
+// fakeHistoryClient returns one bar per call and fails for the codes in failing.
+type fakeHistoryClient struct {
+	client.StockDataClient
+	failing   map[string]bool
+	requested []string
+}
+
+func (f *fakeHistoryClient) GetHistoricalData(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+	f.requested = append(f.requested, stockCode)
+	if f.failing[stockCode] {
+		return nil, errors.New("not found")
+	}
+	return []*models.StockPrice{{Code: stockCode, Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.Local)}}, nil
+}
+
+func (f *fakeHistoryClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	return &models.StockPrice{Code: stockCode, Date: time.Now()}, nil
+}
+
+func TestCollectTargetSyncUseCase_SyncTargets(t *testing.T) {
+	watchList := []*models.WatchList{{Code: "7203"}}
+	stockRepo := newTargetStockRepository(&watchList, map[string][]*models.StockPrice{
+		"7203": {{Code: "7203", Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.Local)}},
+	})
+	var holdings []*models.Portfolio
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetAllFunc: func(ctx context.Context) ([]*models.Portfolio, error) {
+			return holdings, nil
+		},
+	}
+	stockClient := &fakeHistoryClient{failing: map[string]bool{"9999": true}}
+	bulkCollect := NewBulkCollectUseCase(stockRepo, portfolioRepo, stockClient, nil)
+	bulkCollect.retryPolicy.MaxRetries = 0
+	useCase := NewCollectTargetSyncUseCase(stockRepo, portfolioRepo, bulkCollect,
+		NewCollectDataUseCase(stockRepo, portfolioRepo, stockClient, nil, nil))
+	ctx := context.Background()
+
+	// First sync: stocks with stored prices are not collected again
+	changes, err := useCase.SyncTargets(ctx)
+	if err != nil {
+		t.Fatalf("SyncTargets() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"7203"}, changes.Added); diff != "" {
+		t.Errorf("first sync Added mismatch (-want +got):\n%s", diff)
+	}
+	if len(changes.Initialized) != 0 || len(stockClient.requested) != 0 {
+		t.Errorf("first sync initialized %v, requested %v, want none", changes.Initialized, stockClient.requested)
+	}
+
+	// Stocks added while running get their history collected
+	watchList = []*models.WatchList{{Code: "6758"}, {Code: "9999"}}
+	holdings = []*models.Portfolio{{Code: "9984"}}
+	changes, err = useCase.SyncTargets(ctx)
+	if err != nil {
+		t.Fatalf("SyncTargets() error = %v", err)
+	}
+	want := &CollectTargetChanges{
+		Added:       []string{"6758", "9984", "9999"},
+		Removed:     []string{"7203"},
+		Initialized: []string{"6758", "9984"},
+	}
+	if diff := cmp.Diff(want, changes, cmp.FilterPath(func(p cmp.Path) bool {
+		return p.String() == "Failed"
+	}, cmp.Ignore())); diff != "" {
+		t.Errorf("second sync mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := changes.Failed["9999"]; !ok || len(changes.Failed) != 1 {
+		t.Errorf("second sync Failed = %v, want 9999", changes.Failed)
+	}
+
+	// Failed stocks are retried, initialized stocks are not
+	stockClient.requested = nil
+	changes, err = useCase.SyncTargets(ctx)
+	if err != nil {
+		t.Fatalf("SyncTargets() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"9999"}, changes.Added); diff != "" {
+		t.Errorf("third sync Added mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"9999"}, stockClient.requested); diff != "" {
+		t.Errorf("third sync requested mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// CryptoPriceUseCase collects the prices of the crypto assets in the portfolio. They are stored as
+// the daily prices of the symbol of each asset, so that the reports value them like any other holding.
+// Crypto assets trade around the clock, so the prices are collected regardless of the market hours.
+type CryptoPriceUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	cryptoClient  client.CryptoDataClient
+}
+
+// NewCryptoPriceUseCase creates a new crypto price use case.
+func NewCryptoPriceUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	cryptoClient client.CryptoDataClient,
+) *CryptoPriceUseCase {
+	return &CryptoPriceUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		cryptoClient:  cryptoClient,
+	}
+}
+
+// UpdateCryptoPrices updates the prices of the crypto assets held. The record of the current day is
+// updated until the day ends, so it holds the last price of the day as its close.
+func (uc *CryptoPriceUseCase) UpdateCryptoPrices(ctx context.Context) error {
+	symbols, err := uc.cryptoSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, symbol := range symbols {
+		if err := uc.updatePrice(ctx, symbol); err != nil {
+			logrus.Errorf("Failed to update crypto price for %s: %v", symbol, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		logrus.Warnf("Encountered %d errors during crypto price updates", failed)
+	}
+	return nil
+}
+
+// CollectCryptoHistory saves the daily prices of the last given days of the crypto assets held that are
+// not stored yet. Returns the number of records saved; assets that fail are logged and skipped.
+func (uc *CryptoPriceUseCase) CollectCryptoHistory(ctx context.Context, days int) (int, error) {
+	symbols, err := uc.cryptoSymbols(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	saved, failed := 0, 0
+	for _, symbol := range symbols {
+		count, err := uc.collectHistory(ctx, symbol, days)
+		if err != nil {
+			logrus.Errorf("Failed to collect crypto prices for %s: %v", symbol, err)
+			failed++
+			continue
+		}
+		saved += count
+	}
+
+	logrus.Infof("Crypto prices collected: %d records saved, %d assets failed", saved, failed)
+	return saved, nil
+}
+
+// cryptoSymbols returns the symbols of the crypto assets held, each once.
+func (uc *CryptoPriceUseCase) cryptoSymbols(ctx context.Context) ([]string, error) {
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, holding := range portfolio {
+		if holding.IsCrypto() && !seen[holding.Code] {
+			seen[holding.Code] = true
+			symbols = append(symbols, holding.Code)
+		}
+	}
+	return symbols, nil
+}
+
+// updatePrice saves the current price of a crypto asset, updating the record of the same day if any.
+func (uc *CryptoPriceUseCase) updatePrice(ctx context.Context, symbol string) error {
+	price, err := uc.cryptoClient.GetCurrentPrice(ctx, symbol)
+	if err != nil {
+		return err
+	}
+
+	latest, err := uc.stockRepo.GetLatestPrice(ctx, symbol)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case latest == nil:
+		err = uc.stockRepo.SaveStockPrice(ctx, price)
+	case latest.SameQuote(price):
+		return nil
+	case latest.SameTradingDay(price):
+		err = uc.stockRepo.UpdateStockPrice(ctx, price)
+	default:
+		err = uc.stockRepo.SaveStockPrice(ctx, price)
+	}
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Crypto price updated for %s: %.2f", symbol, price.ClosePrice)
+	return nil
+}
+
+// collectHistory saves the daily prices of a crypto asset not stored yet.
+func (uc *CryptoPriceUseCase) collectHistory(ctx context.Context, symbol string, days int) (int, error) {
+	prices, err := uc.cryptoClient.GetHistoricalData(ctx, symbol, days)
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := uc.stockRepo.GetPriceHistory(ctx, symbol, days+1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stored price history: %w", err)
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, price := range existing {
+		stored[price.Date.Format("2006-01-02")] = true
+	}
+
+	newPrices := make([]*models.StockPrice, 0, len(prices))
+	for _, price := range prices {
+		if !stored[price.Date.Format("2006-01-02")] {
+			newPrices = append(newPrices, price)
+		}
+	}
+
+	if err := uc.stockRepo.SaveStockPrices(ctx, newPrices); err != nil {
+		return 0, fmt.Errorf("failed to save crypto prices: %w", err)
+	}
+	return len(newPrices), nil
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// DataQualityUseCase handles price data quality reporting.
+type DataQualityUseCase struct {
+	stockRepo      repository.StockRepository
+	portfolioRepo  repository.PortfolioRepository
+	notifier       notification.NotificationService
+	qualityService *domain.DataQualityService
+	analysisSvc    *domain.TechnicalAnalysisService
+	businessDay    *domain.BusinessDay
+	feedMinPercent float64
+	failureRepo    repository.CollectFailureRepository
+	now            func() time.Time
+}
+
+// NewDataQualityUseCase creates a new data quality use case.
+func NewDataQualityUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	notifier notification.NotificationService,
+) *DataQualityUseCase {
+	return &DataQualityUseCase{
+		stockRepo:      stockRepo,
+		portfolioRepo:  portfolioRepo,
+		notifier:       notifier,
+		qualityService: domain.NewDataQualityService(),
+		analysisSvc:    domain.NewTechnicalAnalysisService(),
+		businessDay:    domain.NewBusinessDay(time.Local, nil),
+		feedMinPercent: domain.DefaultPriceFeedMinPercent,
+		now:            time.Now,
+	}
+}
+
+// SetBusinessDay sets the business day calendar of the market the prices are collected from.
+// Weekdays in the local time zone are business days if not set.
+func (uc *DataQualityUseCase) SetBusinessDay(businessDay *domain.BusinessDay) {
+	uc.businessDay = businessDay
+}
+
+// SetPriceFeedMinPercent sets the share of the collected stocks that must have a price of the
+// trading day for CheckPriceFeed not to alert.
+func (uc *DataQualityUseCase) SetPriceFeedMinPercent(percent float64) {
+	uc.feedMinPercent = percent
+}
+
+// GenerateReport aggregates data quality metrics of watched and held stocks for the last given days.
+func (uc *DataQualityUseCase) GenerateReport(ctx context.Context, days int) (*domain.DataQualityReport, error) {
+	targets, err := uc.collectTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report := &domain.DataQualityReport{
+		From:        now.AddDate(0, 0, -days),
+		To:          now,
+		Stocks:      make([]domain.StockDataQuality, 0, len(targets)),
+		GeneratedAt: now,
+	}
+
+	codes := make([]string, 0, len(targets))
+	for code := range targets {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		prices, err := uc.stockRepo.GetPriceHistory(ctx, code, days)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get price history for %s: %w", code, err)
+		}
+
+		quality := uc.qualityService.AnalyzeStockPrices(
+			code,
+			targets[code],
+			uc.analysisSvc.ConvertStockPrices(prices),
+			report.From,
+			report.To,
+		)
+		report.Stocks = append(report.Stocks, quality)
+	}
+
+	return report, nil
+}
+
+// SendWeeklyReport generates the data quality report for the last 7 days and sends it.
+func (uc *DataQualityUseCase) SendWeeklyReport(ctx context.Context) error {
+	report, err := uc.GenerateReport(ctx, 7)
+	if err != nil {
+		return fmt.Errorf("failed to generate data quality report: %w", err)
+	}
+
+	text := uc.qualityService.GenerateDataQualityReport(report)
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, text); err != nil {
+		return fmt.Errorf("failed to send data quality report: %w", err)
+	}
+
+	logrus.Infof("Data quality report sent: %d stocks, %d with issues", len(report.Stocks), report.IssueCount())
+	return nil
+}
+
+// SetCollectFailures sets the failure records of the price collection, so that the price feed check
+// does not expect the prices of the quarantined stocks, which the collection leaves out.
+func (uc *DataQualityUseCase) SetCollectFailures(failureRepo repository.CollectFailureRepository) {
+	uc.failureRepo = failureRepo
+}
+
+// CheckPriceFeed checks that the listed stocks collected from the data sources have a price of
+// today, one per stock on a business day, and sends a critical alert when far fewer of them do.
+// This catches a collection that stopped updating prices without failing, e.g. a data source
+// returning old prices. Returns nil on days that are not business days.
+func (uc *DataQualityUseCase) CheckPriceFeed(ctx context.Context) (*domain.PriceFeedStatus, error) {
+	now := uc.now()
+	if !uc.businessDay.IsBusinessDay(now) {
+		logrus.Info("Price feed check skipped: not a business day")
+		return nil, nil
+	}
+
+	codes, err := uc.collectedCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	codes = uc.withoutQuarantined(ctx, codes, now)
+
+	latest, err := uc.stockRepo.GetLatestPrices(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest prices: %w", err)
+	}
+
+	location := uc.businessDay.Location()
+	day := now.In(location).Format("2006-01-02")
+	status := &domain.PriceFeedStatus{
+		Date:     models.TruncateToDate(now.In(location)),
+		Expected: len(codes),
+	}
+	for _, code := range codes {
+		// The dates are compared in the market time zone, whatever zone the price date was read in
+		if price, ok := latest[code]; ok && price.Date.In(location).Format("2006-01-02") >= day {
+			status.Updated++
+			continue
+		}
+		status.Stale = append(status.Stale, code)
+	}
+
+	logrus.Infof("Price feed of %s: %d of %d stocks updated", day, status.Updated, status.Expected)
+	if !status.IsStalled(uc.feedMinPercent) {
+		return status, nil
+	}
+
+	logrus.Warnf("Price feed stalled: %d of %d stocks have a price of %s", status.Updated, status.Expected, day)
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindCritical, domain.FormatPriceFeedAlert(*status)); err != nil {
+		return status, fmt.Errorf("failed to send price feed alert: %w", err)
+	}
+	return status, nil
+}
+
+// RunPriceFeedCheck checks the price feed of today, for the scheduler.
+func (uc *DataQualityUseCase) RunPriceFeedCheck(ctx context.Context) error {
+	_, err := uc.CheckPriceFeed(ctx)
+	return err
+}
+
+// collectedCodes returns the codes of the watched stocks and the listed holdings, whose prices
+// are collected from the stock data sources, in code order.
+func (uc *DataQualityUseCase) collectedCodes(ctx context.Context) ([]string, error) {
+	seen := make(map[string]bool)
+
+	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+	for _, item := range watchList {
+		seen[item.Code] = true
+	}
+
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+	for _, holding := range portfolio {
+		if holding.IsListed() {
+			seen[holding.Code] = true
+		}
+	}
+
+	codes := make([]string, 0, len(seen))
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes, nil
+}
+
+// withoutQuarantined returns the codes not quarantined at now. All codes are returned if the
+// failure records cannot be read.
+func (uc *DataQualityUseCase) withoutQuarantined(ctx context.Context, codes []string, now time.Time) []string {
+	if uc.failureRepo == nil {
+		return codes
+	}
+
+	failures, err := uc.failureRepo.GetAll(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get collect failures, expecting all stocks: %v", err)
+		return codes
+	}
+	quarantined := make(map[string]bool)
+	for _, failure := range failures {
+		if failure.IsQuarantined(now) {
+			quarantined[failure.Code] = true
+		}
+	}
+	if len(quarantined) == 0 {
+		return codes
+	}
+
+	expected := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if !quarantined[code] {
+			expected = append(expected, code)
+		}
+	}
+	logrus.Infof("Price feed check leaves out %d quarantined stocks", len(codes)-len(expected))
+	return expected
+}
+
+// collectTargets returns stock codes and names of the watch list and portfolio.
+func (uc *DataQualityUseCase) collectTargets(ctx context.Context) (map[string]string, error) {
+	targets := make(map[string]string)
+
+	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+	for _, item := range watchList {
+		targets[item.Code] = item.Name
+	}
+
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+	for _, holding := range portfolio {
+		if holding.IsCash() {
+			continue
+		}
+		targets[holding.Code] = holding.Name
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDataQualityUseCase_CheckPriceFeed(t *testing.T) {
+	today := time.Date(2024, 6, 10, 15, 40, 0, 0, time.Local) // Monday
+	yesterday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		updated   int
+		wantStale int
+		wantAlert bool
+	}{
+		{name: "all updated", now: today, updated: 10, wantStale: 0},
+		{name: "a few stale stocks", now: today, updated: 6, wantStale: 4},
+		{name: "most stocks not updated", now: today, updated: 2, wantStale: 8, wantAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var watchList []*models.WatchList
+			latest := make(map[string]*models.StockPrice)
+			for i := 0; i < 10; i++ {
+				code := fmt.Sprintf("%d", 1300+i)
+				watchList = append(watchList, &models.WatchList{Code: code})
+				date := yesterday
+				if i < tt.updated {
+					date = time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+				}
+				latest[code] = &models.StockPrice{Code: code, Date: date}
+			}
+			stockRepo := &mock.StockRepositoryMock{
+				GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+					return watchList, nil
+				},
+				GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+					return latest, nil
+				},
+			}
+			portfolioRepo := &mock.PortfolioRepositoryMock{
+				GetAllFunc: func(ctx context.Context) ([]*models.Portfolio, error) {
+					// Funds are priced by their net asset values and not expected in the feed
+					return []*models.Portfolio{{Code: "1300"}, {Code: "FUND1", AssetClass: models.AssetClassFund}}, nil
+				},
+			}
+			notifier := &fakeAlertNotifier{}
+			uc := NewDataQualityUseCase(stockRepo, portfolioRepo, notifier)
+			uc.now = func() time.Time { return tt.now }
+
+			status, err := uc.CheckPriceFeed(context.Background())
+			if err != nil {
+				t.Fatalf("CheckPriceFeed() error = %v", err)
+			}
+			if status.Expected != 10 || status.Updated != tt.updated || len(status.Stale) != tt.wantStale {
+				t.Errorf("status = %d of %d updated with %d stale, want %d of 10 with %d stale",
+					status.Updated, status.Expected, len(status.Stale), tt.updated, tt.wantStale)
+			}
+			if got := len(notifier.messages) > 0; got != tt.wantAlert {
+				t.Errorf("alert sent = %v, want %v: %q", got, tt.wantAlert, notifier.messages)
+			}
+		})
+	}
+}
+
+func TestDataQualityUseCase_CheckPriceFeed_MarketTimeZone(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2024, 6, 10, 15, 40, 0, 0, tokyo) // Monday
+	latest := map[string]*models.StockPrice{
+		// Midnight of the trading day in Tokyo, read as the evening before in UTC
+		"1300": {Code: "1300", Date: time.Date(2024, 6, 9, 15, 0, 0, 0, time.UTC)},
+		"1301": {Code: "1301", Date: time.Date(2024, 6, 7, 0, 0, 0, 0, tokyo)},
+	}
+	stockRepo := &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{{Code: "1300"}, {Code: "1301"}}, nil
+		},
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			return latest, nil
+		},
+	}
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetAllFunc: func(ctx context.Context) ([]*models.Portfolio, error) {
+			return nil, nil
+		},
+	}
+	uc := NewDataQualityUseCase(stockRepo, portfolioRepo, &fakeAlertNotifier{})
+	uc.SetBusinessDay(domain.NewBusinessDay(tokyo, nil))
+	uc.now = func() time.Time { return now }
+
+	status, err := uc.CheckPriceFeed(context.Background())
+	if err != nil {
+		t.Fatalf("CheckPriceFeed() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"1301"}, status.Stale); status.Updated != 1 || diff != "" {
+		t.Errorf("status = %d updated, stale mismatch (-want +got):\n%s", status.Updated, diff)
+	}
+}
+
+func TestDataQualityUseCase_CheckPriceFeed_Quarantined(t *testing.T) {
+	today := time.Date(2024, 6, 10, 15, 40, 0, 0, time.Local) // Monday
+	yesterday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.Local)
+	until := today.Add(24 * time.Hour)
+	expired := today.Add(-time.Hour)
+
+	var watchList []*models.WatchList
+	latest := make(map[string]*models.StockPrice)
+	for i := 0; i < 10; i++ {
+		code := fmt.Sprintf("%d", 1300+i)
+		watchList = append(watchList, &models.WatchList{Code: code})
+		date := time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+		if i >= 5 {
+			date = yesterday
+		}
+		latest[code] = &models.StockPrice{Code: code, Date: date}
+	}
+	stockRepo := &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return watchList, nil
+		},
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			return latest, nil
+		},
+	}
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetAllFunc: func(ctx context.Context) ([]*models.Portfolio, error) {
+			return nil, nil
+		},
+	}
+	// The collection leaves out 1305-1308, and retries 1309 whose quarantine has expired
+	records := map[string]*models.CollectFailure{
+		"1309": {Code: "1309", ConsecutiveFailures: 5, QuarantinedUntil: &expired},
+	}
+	for _, code := range []string{"1305", "1306", "1307", "1308"} {
+		records[code] = &models.CollectFailure{Code: code, ConsecutiveFailures: 5, QuarantinedUntil: &until}
+	}
+	failures := newCollectFailureRepositoryMock(records)
+
+	notifier := &fakeAlertNotifier{}
+	uc := NewDataQualityUseCase(stockRepo, portfolioRepo, notifier)
+	uc.SetCollectFailures(failures)
+	uc.now = func() time.Time { return today }
+
+	status, err := uc.CheckPriceFeed(context.Background())
+	if err != nil {
+		t.Fatalf("CheckPriceFeed() error = %v", err)
+	}
+	if status.Expected != 6 || status.Updated != 5 {
+		t.Errorf("status = %d of %d updated, want 5 of 6 without the quarantined stocks", status.Updated, status.Expected)
+	}
+	if diff := cmp.Diff([]string{"1309"}, status.Stale); diff != "" {
+		t.Errorf("stale mismatch (-want +got):\n%s", diff)
+	}
+	if len(notifier.messages) > 0 {
+		t.Errorf("alert sent for quarantined stocks: %q", notifier.messages)
+	}
+}
+
+func TestDataQualityUseCase_CheckPriceFeed_NotBusinessDay(t *testing.T) {
+	uc := NewDataQualityUseCase(&mock.StockRepositoryMock{}, &mock.PortfolioRepositoryMock{}, &fakeAlertNotifier{})
+	uc.now = func() time.Time { return time.Date(2024, 6, 8, 15, 40, 0, 0, time.Local) } // Saturday
+
+	status, err := uc.CheckPriceFeed(context.Background())
+	if err != nil || status != nil {
+		t.Errorf("CheckPriceFeed() = %v, %v, want nil, nil", status, err)
+	}
+}
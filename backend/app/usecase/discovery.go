@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// maxDiscoveryScanDays is the calendar days searched back for the trading days of market quotes.
+// It covers the 12-week delay of the J-Quants free plan.
+const maxDiscoveryScanDays = 200
+
+// DiscoveryUseCase proposes stocks of the whole market whose volume surged or which made a new high
+// as candidates for the watch list. Candidates are only proposed unless automatic addition is enabled.
+type DiscoveryUseCase struct {
+	marketClient     client.MarketDataClient
+	stockRepo        repository.StockRepository
+	portfolioRepo    repository.PortfolioRepository
+	watchListUseCase *WatchListUseCase
+	notifier         notification.NotificationService
+	criteria         domain.DiscoveryCriteria
+	autoAdd          bool
+	now              func() time.Time
+}
+
+// NewDiscoveryUseCase creates a new discovery use case. Discovery is disabled if marketClient is nil.
+func NewDiscoveryUseCase(
+	marketClient client.MarketDataClient,
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	watchListUseCase *WatchListUseCase,
+	notifier notification.NotificationService,
+	criteria domain.DiscoveryCriteria,
+	autoAdd bool,
+) *DiscoveryUseCase {
+	if err := criteria.Validate(); err != nil {
+		logrus.Warnf("Invalid discovery criteria, using defaults: %v", err)
+		criteria = domain.DefaultDiscoveryCriteria()
+	}
+
+	return &DiscoveryUseCase{
+		marketClient:     marketClient,
+		stockRepo:        stockRepo,
+		portfolioRepo:    portfolioRepo,
+		watchListUseCase: watchListUseCase,
+		notifier:         notifier,
+		criteria:         criteria,
+		autoAdd:          autoAdd,
+		now:              time.Now,
+	}
+}
+
+// Enabled reports whether market quotes are available for discovery.
+func (uc *DiscoveryUseCase) Enabled() bool {
+	return uc.marketClient != nil
+}
+
+// Discover detects the candidates from the market quotes of the last trading days,
+// leaving out the stocks already in the watch list or the portfolio.
+func (uc *DiscoveryUseCase) Discover(ctx context.Context) ([]domain.DiscoveryCandidate, error) {
+	if !uc.Enabled() {
+		return nil, fmt.Errorf("discovery requires J-Quants credentials (JQUANTS_REFRESH_TOKEN or JQUANTS_MAIL_ADDRESS and JQUANTS_PASSWORD)")
+	}
+
+	history, err := uc.fetchMarketHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range targets {
+		delete(history, code)
+	}
+
+	candidates := domain.FindDiscoveryCandidates(history, uc.criteria)
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	names, err := uc.marketClient.GetListedCompanies(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get listed company names for discovery: %v", err)
+	}
+	for i := range candidates {
+		candidates[i].Name = names[candidates[i].Code]
+	}
+
+	logrus.Infof("Discovery found %d candidates among %d stocks", len(candidates), len(history))
+	return candidates, nil
+}
+
+// fetchMarketHistory returns the market quotes of the last LookbackDays trading days by stock code,
+// oldest first. Days are searched back from today, and days without quotes are skipped.
+func (uc *DiscoveryUseCase) fetchMarketHistory(ctx context.Context) (map[string][]domain.StockPriceData, error) {
+	service := domain.NewTechnicalAnalysisService()
+	var days [][]domain.StockPriceData // newest first
+
+	today := uc.now()
+	for back := 0; back < maxDiscoveryScanDays && len(days) < uc.criteria.LookbackDays; back++ {
+		date := today.AddDate(0, 0, -back)
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+
+		prices, err := uc.marketClient.GetMarketQuotes(ctx, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get market quotes of %s: %w", date.Format("2006-01-02"), err)
+		}
+		if len(prices) > 0 {
+			days = append(days, service.ConvertStockPrices(prices))
+		}
+	}
+
+	if len(days) < uc.criteria.LookbackDays {
+		logrus.Warnf("Market quotes of only %d of %d trading days found for discovery", len(days), uc.criteria.LookbackDays)
+	}
+
+	history := make(map[string][]domain.StockPriceData)
+	for i := len(days) - 1; i >= 0; i-- {
+		for _, price := range days[i] {
+			history[price.Code] = append(history[price.Code], price)
+		}
+	}
+	return history, nil
+}
+
+// AddCandidates adds the candidates to the watch list and returns the codes added.
+// Candidates already registered, e.g. as inactive items, are skipped.
+func (uc *DiscoveryUseCase) AddCandidates(ctx context.Context, candidates []domain.DiscoveryCandidate) ([]string, error) {
+	var added []string
+	for _, candidate := range candidates {
+		name := candidate.Name
+		if name == "" {
+			name = candidate.Code
+		}
+
+		result, err := uc.watchListUseCase.Import(ctx, []WatchListImportItem{{Code: candidate.Code, Name: name}}, DuplicateSkip)
+		if err != nil {
+			return added, fmt.Errorf("failed to add %s to the watch list: %w", candidate.Code, err)
+		}
+		for _, message := range result.Errors {
+			logrus.Warnf("Discovery candidate not added: %s", message)
+		}
+		if result.Created > 0 {
+			added = append(added, candidate.Code)
+		}
+	}
+
+	logrus.Infof("Discovery candidates added to the watch list: %d", len(added))
+	return added, nil
+}
+
+// SendWeeklyCandidates detects the candidates, adds them to the watch list if automatic addition
+// is enabled, and sends the proposal. Does nothing if discovery is disabled.
+func (uc *DiscoveryUseCase) SendWeeklyCandidates(ctx context.Context) error {
+	if !uc.Enabled() {
+		logrus.Debug("Discovery is disabled without J-Quants credentials")
+		return nil
+	}
+
+	candidates, err := uc.Discover(ctx)
+	if err != nil {
+		return err
+	}
+
+	var added []string
+	if uc.autoAdd && len(candidates) > 0 {
+		added, err = uc.AddCandidates(ctx, candidates)
+		if err != nil {
+			return err
+		}
+	}
+
+	return uc.SendCandidates(ctx, candidates, added)
+}
+
+// SendCandidates sends the proposal of the candidates, marking the codes added to the watch list.
+func (uc *DiscoveryUseCase) SendCandidates(ctx context.Context, candidates []domain.DiscoveryCandidate, added []string) error {
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, domain.GenerateDiscoveryReport(candidates, added)); err != nil {
+		return fmt.Errorf("failed to send discovery candidates: %w", err)
+	}
+
+	logrus.Infof("Discovery candidates sent: %d", len(candidates))
+	return nil
+}
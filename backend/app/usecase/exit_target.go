@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// ExitTargetStatus represents the exit lines of a holding at its latest price.
+type ExitTargetStatus struct {
+	Holding      *models.Portfolio
+	Target       *models.ExitTarget
+	Registered   bool // false if the default lines are applied
+	CurrentPrice float64
+	GainPercent  float64
+	HasPrice     bool
+}
+
+// ExitTargetUseCase handles take-profit and stop-loss lines of portfolio holdings.
+type ExitTargetUseCase struct {
+	stockRepo      repository.StockRepository
+	portfolioRepo  repository.PortfolioRepository
+	exitTargetRepo repository.ExitTargetRepository
+	notifier       notification.NotificationService
+	txManager      repository.TransactionManager
+}
+
+// NewExitTargetUseCase creates a new exit target use case.
+func NewExitTargetUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	exitTargetRepo repository.ExitTargetRepository,
+	notifier notification.NotificationService,
+	txManager repository.TransactionManager,
+) *ExitTargetUseCase {
+	return &ExitTargetUseCase{
+		stockRepo:      stockRepo,
+		portfolioRepo:  portfolioRepo,
+		exitTargetRepo: exitTargetRepo,
+		notifier:       notifier,
+		txManager:      txManager,
+	}
+}
+
+// SetTargets sets the take-profit and/or stop-loss lines of a holding in percent.
+// Unspecified lines keep their current (or default) values. The notified state is reset.
+func (uc *ExitTargetUseCase) SetTargets(ctx context.Context, code string, takeProfit, stopLoss *float64) (*models.ExitTarget, error) {
+	var target *models.ExitTarget
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		holding, err := uc.portfolioRepo.GetByCode(ctx, code)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio holding: %w", err)
+		}
+		if holding == nil {
+			return fmt.Errorf("holding not found: %s", code)
+		}
+
+		target, _, err = uc.getTarget(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		if takeProfit != nil {
+			target.TakeProfitPercent = *takeProfit
+		}
+		if stopLoss != nil {
+			target.StopLossPercent = *stopLoss
+		}
+		target.TakeProfitNotified = false
+		target.StopLossNotified = false
+
+		if err := target.Validate(); err != nil {
+			return err
+		}
+
+		if err := uc.exitTargetRepo.Upsert(ctx, target); err != nil {
+			return fmt.Errorf("failed to save exit targets: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Exit targets set for %s: take profit +%.2f%%, stop loss -%.2f%%",
+		code, target.TakeProfitPercent, target.StopLossPercent)
+	return target, nil
+}
+
+// RemoveTargets removes the registered lines of a holding, reverting it to the default lines.
+func (uc *ExitTargetUseCase) RemoveTargets(ctx context.Context, code string) error {
+	if err := uc.exitTargetRepo.Delete(ctx, code); err != nil {
+		return fmt.Errorf("failed to delete exit targets: %w", err)
+	}
+
+	logrus.Infof("Exit targets removed for %s", code)
+	return nil
+}
+
+// ListTargets returns the exit lines of all holdings with their latest prices.
+func (uc *ExitTargetUseCase) ListTargets(ctx context.Context) ([]ExitTargetStatus, error) {
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	statuses := make([]ExitTargetStatus, 0, len(portfolio))
+	for _, holding := range portfolio {
+		if holding.IsCash() {
+			continue // a cash balance has no price to exit at
+		}
+		target, registered, err := uc.getTarget(ctx, holding.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		status := ExitTargetStatus{
+			Holding:    holding,
+			Target:     target,
+			Registered: registered,
+		}
+
+		price, err := uc.stockRepo.GetLatestPrice(ctx, holding.Code)
+		if err != nil {
+			logrus.Warnf("Failed to get price for %s: %v", holding.Code, err)
+		} else if price != nil {
+			status.CurrentPrice = utility.DecimalToFloat(price.ClosePrice)
+			status.GainPercent = holding.CalculateGainPercent(status.CurrentPrice)
+			status.HasPrice = true
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// CheckTargets checks all holdings against their exit lines and sends a sell suggestion
+// when a line is reached. Each line is notified once until it is reached again.
+func (uc *ExitTargetUseCase) CheckTargets(ctx context.Context) error {
+	statuses, err := uc.ListTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	alerts := 0
+	for _, status := range statuses {
+		if !status.HasPrice {
+			continue
+		}
+
+		signal, changed := status.Target.Evaluate(status.GainPercent)
+		if signal != models.ExitSignalNone {
+			message := formatExitAlert(status, signal)
+			if err := uc.notifier.SendMessageOfKind(ctx, notification.KindCritical, message); err != nil {
+				// Keep the previous state so that the alert is retried on the next check
+				logrus.Errorf("Failed to send exit alert for %s: %v", status.Holding.Code, err)
+				continue
+			}
+			alerts++
+		}
+
+		if changed {
+			if err := uc.exitTargetRepo.Upsert(ctx, status.Target); err != nil {
+				logrus.Errorf("Failed to save exit target state for %s: %v", status.Holding.Code, err)
+			}
+		}
+	}
+
+	logrus.Infof("Exit target check completed: %d holdings, %d alerts", len(statuses), alerts)
+	return nil
+}
+
+// getTarget returns the registered exit targets of a stock, or the default lines if none are registered.
+func (uc *ExitTargetUseCase) getTarget(ctx context.Context, code string) (*models.ExitTarget, bool, error) {
+	target, err := uc.exitTargetRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get exit targets: %w", err)
+	}
+	if target == nil {
+		return models.NewDefaultExitTarget(code), false, nil
+	}
+	return target, true, nil
+}
+
+// formatExitAlert formats a sell suggestion for a holding that reached an exit line.
+func formatExitAlert(status ExitTargetStatus, signal models.ExitSignal) string {
+	holding := status.Holding
+	action := i18n.T("exit_target.action.sell")
+	if holding.IsShort() {
+		action = i18n.T("exit_target.action.cover")
+	}
+
+	title := "🎯 " + i18n.T("exit_target.take_profit.title", holding.Name, holding.Code)
+	line := i18n.T("exit_target.take_profit.line", status.Target.TakeProfitPercent, status.Target.TakeProfitPrice(holding))
+	if signal == models.ExitSignalStopLoss {
+		title = "🛑 " + i18n.T("exit_target.stop_loss.title", holding.Name, holding.Code)
+		line = i18n.T("exit_target.stop_loss.line", status.Target.StopLossPercent, status.Target.StopLossPrice(holding))
+	}
+
+	return title + "\n\n" +
+		i18n.T("exit_target.current_price", status.CurrentPrice) + "\n" +
+		i18n.T("exit_target.purchase_price", holding.GetPurchasePrice()) + "\n" +
+		i18n.T("exit_target.gain_percent", status.GainPercent) + "\n" +
+		line + "\n\n💡 " +
+		i18n.T("exit_target.suggestion", action)
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/eventbus"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultFeatureFlagCacheTTL is how long the database overrides of the feature flags are cached.
+// A flag changed by the flags command reaches all running processes within this time.
+const DefaultFeatureFlagCacheTTL = 30 * time.Second
+
+// FeatureFlagUseCase tells whether the features rolled out step by step are enabled, resolving the
+// database overrides, the flags of the flag file for the environment and the defaults in this order.
+// The overrides are cached for a TTL so that checking a flag on every job does not add a query,
+// and the last known overrides are kept if reloading them fails.
+type FeatureFlagUseCase struct {
+	repo        repository.FeatureFlagRepository
+	environment string
+	fileFlags   map[string]bool
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	overrides map[string]bool
+	loadedAt  time.Time
+}
+
+// NewFeatureFlagUseCase creates a new feature flag use case with the flags the flag file sets in the environment.
+func NewFeatureFlagUseCase(
+	repo repository.FeatureFlagRepository,
+	environment string,
+	fileFlags map[string]bool,
+	ttl time.Duration,
+) *FeatureFlagUseCase {
+	if ttl <= 0 {
+		ttl = DefaultFeatureFlagCacheTTL
+	}
+	return &FeatureFlagUseCase{
+		repo:        repo,
+		environment: environment,
+		fileFlags:   fileFlags,
+		ttl:         ttl,
+		now:         time.Now,
+	}
+}
+
+// Environment returns the environment whose flags are read from the flag file.
+func (uc *FeatureFlagUseCase) Environment() string {
+	return uc.environment
+}
+
+// IsEnabled reports whether a feature is enabled. Flags not registered are disabled.
+func (uc *FeatureFlagUseCase) IsEnabled(ctx context.Context, name string) bool {
+	if _, err := domain.GetFeatureFlagDefinition(name); err != nil {
+		logrus.Warnf("Unknown feature flag %s is treated as disabled", name)
+		return false
+	}
+
+	for _, state := range domain.ResolveFeatureFlags(uc.fileFlags, uc.cachedOverrides(ctx)) {
+		if state.Name == name {
+			return state.Enabled
+		}
+	}
+	return false
+}
+
+// cachedOverrides returns the database overrides, reloading them once the TTL has passed.
+func (uc *FeatureFlagUseCase) cachedOverrides(ctx context.Context) map[string]bool {
+	now := uc.now()
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.overrides != nil && now.Sub(uc.loadedAt) < uc.ttl {
+		return uc.overrides
+	}
+
+	overrides, err := uc.loadOverrides(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to load feature flag overrides: %v", err)
+		if uc.overrides == nil {
+			return nil
+		}
+		overrides = uc.overrides
+	}
+	uc.overrides = overrides
+	uc.loadedAt = now
+	return overrides
+}
+
+// loadOverrides reads the database overrides by name.
+func (uc *FeatureFlagUseCase) loadOverrides(ctx context.Context) (map[string]bool, error) {
+	flags, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		overrides[flag.Name] = flag.Enabled
+	}
+	return overrides, nil
+}
+
+// States returns the current state of the registered flags ordered by name, read from the
+// database without the cache.
+func (uc *FeatureFlagUseCase) States(ctx context.Context) ([]domain.FeatureFlagState, error) {
+	overrides, err := uc.loadOverrides(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flag overrides: %w", err)
+	}
+	return domain.ResolveFeatureFlags(uc.fileFlags, overrides), nil
+}
+
+// SetOverride turns a feature on or off in the database, over the flag file and the default.
+func (uc *FeatureFlagUseCase) SetOverride(ctx context.Context, name string, enabled bool) error {
+	if _, err := domain.GetFeatureFlagDefinition(name); err != nil {
+		return err
+	}
+	if err := uc.repo.Save(ctx, &models.FeatureFlag{Name: name, Enabled: enabled}); err != nil {
+		return fmt.Errorf("failed to save feature flag %s: %w", name, err)
+	}
+	uc.invalidate()
+	return nil
+}
+
+// ClearOverride removes the database override of a feature, so that the flag file or the default applies again.
+func (uc *FeatureFlagUseCase) ClearOverride(ctx context.Context, name string) error {
+	if _, err := domain.GetFeatureFlagDefinition(name); err != nil {
+		return err
+	}
+	if err := uc.repo.Delete(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete feature flag %s: %w", name, err)
+	}
+	uc.invalidate()
+	return nil
+}
+
+// invalidate makes the next check reload the overrides.
+func (uc *FeatureFlagUseCase) invalidate() {
+	uc.mu.Lock()
+	uc.overrides = nil
+	uc.mu.Unlock()
+}
+
+// Gate wraps an event handler so that it handles events only while the feature is enabled.
+func (uc *FeatureFlagUseCase) Gate(name string, handler eventbus.Handler) eventbus.Handler {
+	return func(ctx context.Context, event domain.Event) error {
+		if !uc.IsEnabled(ctx, name) {
+			logrus.Debugf("Feature %s is disabled, %s event skipped", name, event.EventName())
+			return nil
+		}
+		return handler(ctx, event)
+	}
+}
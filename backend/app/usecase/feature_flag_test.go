@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+)
+
+// newFeatureFlagRepositoryMock returns a repository mock keeping the feature flag overrides in flags.
+func newFeatureFlagRepositoryMock(flags map[string]bool) *mock.FeatureFlagRepositoryMock {
+	return &mock.FeatureFlagRepositoryMock{
+		GetAllFunc: func(ctx context.Context) ([]*models.FeatureFlag, error) {
+			var overrides []*models.FeatureFlag
+			for name, enabled := range flags {
+				overrides = append(overrides, &models.FeatureFlag{Name: name, Enabled: enabled})
+			}
+			return overrides, nil
+		},
+		SaveFunc: func(ctx context.Context, flag *models.FeatureFlag) error {
+			flags[flag.Name] = flag.Enabled
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, name string) error {
+			delete(flags, name)
+			return nil
+		},
+	}
+}
+
+func TestFeatureFlagUseCase(t *testing.T) {
+	ctx := context.Background()
+	repo := newFeatureFlagRepositoryMock(map[string]bool{})
+	uc := NewFeatureFlagUseCase(repo, "staging", map[string]bool{domain.FeaturePaperTrading: false}, time.Minute)
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	if uc.IsEnabled(ctx, domain.FeaturePaperTrading) {
+		t.Error("paper trading should be disabled by the flag file")
+	}
+	if !uc.IsEnabled(ctx, domain.FeatureDiscovery) {
+		t.Error("discovery should be enabled by default")
+	}
+	if loads := len(repo.GetAllCalls()); loads != 1 {
+		t.Errorf("overrides loaded %d times within the TTL, want 1", loads)
+	}
+
+	if err := uc.SetOverride(ctx, domain.FeaturePaperTrading, true); err != nil {
+		t.Fatalf("SetOverride() error = %v", err)
+	}
+	if !uc.IsEnabled(ctx, domain.FeaturePaperTrading) {
+		t.Error("the database override should take precedence over the flag file")
+	}
+
+	// The last known overrides are kept while the database cannot be read
+	getAll := repo.GetAllFunc
+	repo.GetAllFunc = func(ctx context.Context) ([]*models.FeatureFlag, error) {
+		return nil, errors.New("connection refused")
+	}
+	now = now.Add(2 * time.Minute)
+	if !uc.IsEnabled(ctx, domain.FeaturePaperTrading) {
+		t.Error("the cached override should be kept when reloading fails")
+	}
+	repo.GetAllFunc = getAll
+
+	if err := uc.ClearOverride(ctx, domain.FeaturePaperTrading); err != nil {
+		t.Fatalf("ClearOverride() error = %v", err)
+	}
+	if uc.IsEnabled(ctx, domain.FeaturePaperTrading) {
+		t.Error("the flag file should apply again after the override is removed")
+	}
+
+	if err := uc.SetOverride(ctx, "no_such_feature", true); err == nil {
+		t.Error("SetOverride() should fail for a flag not registered")
+	}
+	if uc.IsEnabled(ctx, "no_such_feature") {
+		t.Error("a flag not registered should be disabled")
+	}
+}
+
+func TestFeatureFlagUseCase_Gate(t *testing.T) {
+	ctx := context.Background()
+	repo := newFeatureFlagRepositoryMock(map[string]bool{domain.FeatureMADeviationAlert: false})
+	uc := NewFeatureFlagUseCase(repo, "production", nil, time.Minute)
+
+	handled := 0
+	handler := uc.Gate(domain.FeatureMADeviationAlert, func(ctx context.Context, event domain.Event) error {
+		handled++
+		return nil
+	})
+
+	if err := handler(ctx, domain.PriceUpdatedEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	if handled != 0 {
+		t.Error("the handler should not run while the feature is disabled")
+	}
+
+	if err := uc.SetOverride(ctx, domain.FeatureMADeviationAlert, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := handler(ctx, domain.PriceUpdatedEvent{}); err != nil {
+		t.Fatal(err)
+	}
+	if handled != 1 {
+		t.Errorf("handler ran %d times, want 1", handled)
+	}
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// fundPriceUpdateDays is the period of the net asset values checked by the scheduled update,
+// long enough to fill the gaps left by holidays and failed updates.
+const fundPriceUpdateDays = 30
+
+// FundPriceUseCase collects the net asset values of the investment trusts in the portfolio.
+// They are stored as the daily prices of the association code of each fund, so that the reports
+// value the funds like any other holding.
+type FundPriceUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	fundClient    client.FundPriceClient
+}
+
+// NewFundPriceUseCase creates a new fund price use case.
+func NewFundPriceUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	fundClient client.FundPriceClient,
+) *FundPriceUseCase {
+	return &FundPriceUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		fundClient:    fundClient,
+	}
+}
+
+// UpdateFundPrices saves the net asset values of the funds held published recently.
+func (uc *FundPriceUseCase) UpdateFundPrices(ctx context.Context) error {
+	_, err := uc.CollectFundPrices(ctx, fundPriceUpdateDays)
+	return err
+}
+
+// CollectFundPrices saves the net asset values of the last given days of the funds held that are not
+// stored yet. Returns the number of records saved; funds that fail are logged and skipped.
+func (uc *FundPriceUseCase) CollectFundPrices(ctx context.Context, days int) (int, error) {
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	saved, failed := 0, 0
+	for _, holding := range portfolio {
+		if !holding.IsFund() {
+			continue
+		}
+		count, err := uc.collectFund(ctx, holding, days)
+		if err != nil {
+			logrus.Errorf("Failed to collect fund prices for %s: %v", holding.Code, err)
+			failed++
+			continue
+		}
+		saved += count
+	}
+
+	logrus.Infof("Fund prices collected: %d records saved, %d funds failed", saved, failed)
+	return saved, nil
+}
+
+// collectFund saves the net asset values of a fund not stored yet.
+func (uc *FundPriceUseCase) collectFund(ctx context.Context, holding *models.Portfolio, days int) (int, error) {
+	prices, err := uc.fundClient.GetFundPrices(ctx, holding.Isin.String, holding.Code, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := uc.stockRepo.GetPriceHistory(ctx, holding.Code, days+1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stored price history: %w", err)
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, price := range existing {
+		stored[price.Date.Format("2006-01-02")] = true
+	}
+
+	newPrices := make([]*models.StockPrice, 0, len(prices))
+	for _, price := range prices {
+		if !stored[price.Date.Format("2006-01-02")] {
+			newPrices = append(newPrices, price)
+		}
+	}
+
+	if err := uc.stockRepo.SaveStockPrices(ctx, newPrices); err != nil {
+		return 0, fmt.Errorf("failed to save fund prices: %w", err)
+	}
+	return len(newPrices), nil
+}
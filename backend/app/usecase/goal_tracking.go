@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// GoalInput represents the target and deadline of a goal.
+// The target is either an increase in percent of the current value or an absolute value.
+type GoalInput struct {
+	Name          string
+	TargetPercent float64
+	TargetValue   float64
+	Deadline      time.Time
+}
+
+// GoalTrackingUseCase manages portfolio goals and notifies when their pace changes.
+type GoalTrackingUseCase struct {
+	goalRepo        repository.PortfolioGoalRepository
+	portfolioReport *PortfolioReportUseCase
+	notifier        notification.NotificationService
+	now             func() time.Time
+}
+
+// NewGoalTrackingUseCase creates a new goal tracking use case.
+func NewGoalTrackingUseCase(
+	goalRepo repository.PortfolioGoalRepository,
+	portfolioReport *PortfolioReportUseCase,
+	notifier notification.NotificationService,
+) *GoalTrackingUseCase {
+	return &GoalTrackingUseCase{
+		goalRepo:        goalRepo,
+		portfolioReport: portfolioReport,
+		notifier:        notifier,
+		now:             time.Now,
+	}
+}
+
+// CreateGoal creates a goal whose progress is measured from the current portfolio value.
+func (uc *GoalTrackingUseCase) CreateGoal(ctx context.Context, input GoalInput) (*models.PortfolioGoal, error) {
+	existing, err := uc.goalRepo.GetByName(ctx, input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("目標 %s は既に存在します", input.Name)
+	}
+
+	currentValue, err := uc.currentValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	goal := &models.PortfolioGoal{
+		Name:          input.Name,
+		BaselineValue: currentValue,
+		TargetValue:   targetValue(currentValue, input),
+		StartDate:     time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		Deadline:      input.Deadline,
+	}
+	if err := goal.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.goalRepo.Create(ctx, goal); err != nil {
+		return nil, fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	logrus.Infof("Goal %s created: ¥%.0f -> ¥%.0f by %s",
+		goal.Name, goal.BaselineValue, goal.TargetValue, goal.Deadline.Format("2006-01-02"))
+	return goal, nil
+}
+
+// UpdateGoal updates the target and deadline of a goal. Zero fields of the input are left unchanged,
+// and a percent target is applied to the value when the goal was set. The notified pace is reset.
+func (uc *GoalTrackingUseCase) UpdateGoal(ctx context.Context, input GoalInput) (*models.PortfolioGoal, error) {
+	goal, err := uc.getGoal(ctx, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.TargetPercent != 0 || input.TargetValue != 0 {
+		goal.TargetValue = targetValue(goal.BaselineValue, input)
+	}
+	if !input.Deadline.IsZero() {
+		goal.Deadline = input.Deadline
+	}
+	goal.LastStatus = ""
+	if err := goal.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.goalRepo.Update(ctx, goal); err != nil {
+		return nil, fmt.Errorf("failed to update goal: %w", err)
+	}
+	return goal, nil
+}
+
+// DeleteGoal deletes a goal.
+func (uc *GoalTrackingUseCase) DeleteGoal(ctx context.Context, name string) error {
+	goal, err := uc.getGoal(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.goalRepo.Delete(ctx, goal.ID); err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+	return nil
+}
+
+// ListGoals returns the progress of all goals at the current portfolio value.
+func (uc *GoalTrackingUseCase) ListGoals(ctx context.Context) ([]domain.GoalProgress, error) {
+	goals, err := uc.goalRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goals: %w", err)
+	}
+	if len(goals) == 0 {
+		return nil, nil
+	}
+
+	currentValue, err := uc.currentValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	progress := make([]domain.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		progress = append(progress, domain.CalculateGoalProgress(goal, currentValue, now))
+	}
+	return progress, nil
+}
+
+// CheckPace notifies the goals whose pace changed since the last check and returns how many were notified.
+// A goal on track from the start is not notified, and achieved or missed goals are notified only once.
+func (uc *GoalTrackingUseCase) CheckPace(ctx context.Context) (int, error) {
+	goals, err := uc.goalRepo.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get goals: %w", err)
+	}
+
+	var pending []*models.PortfolioGoal
+	for _, goal := range goals {
+		switch domain.GoalStatus(goal.LastStatus) {
+		case domain.GoalStatusAchieved, domain.GoalStatusMissed:
+			continue
+		}
+		pending = append(pending, goal)
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	currentValue, err := uc.currentValue(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := uc.now()
+	notified := 0
+	for _, goal := range pending {
+		progress := domain.CalculateGoalProgress(goal, currentValue, now)
+		if string(progress.Status) == goal.LastStatus {
+			continue
+		}
+
+		if goal.LastStatus != "" || progress.Status != domain.GoalStatusOnTrack {
+			if err := uc.notifier.SendMessageOfKind(ctx, notification.KindCritical, domain.FormatGoalNotification(progress)); err != nil {
+				logrus.Errorf("Failed to send goal notification for %s: %v", goal.Name, err)
+				continue
+			}
+			notified++
+		}
+
+		if err := uc.goalRepo.UpdateLastStatus(ctx, goal.ID, string(progress.Status)); err != nil {
+			return notified, fmt.Errorf("failed to update goal status: %w", err)
+		}
+	}
+
+	logrus.Infof("Checked %d goals, %d pace changes notified", len(pending), notified)
+	return notified, nil
+}
+
+// getGoal returns the goal with the name or an error if it does not exist.
+func (uc *GoalTrackingUseCase) getGoal(ctx context.Context, name string) (*models.PortfolioGoal, error) {
+	goal, err := uc.goalRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+	if goal == nil {
+		return nil, fmt.Errorf("目標 %s が見つかりません", name)
+	}
+	return goal, nil
+}
+
+// currentValue returns the current total value of the portfolio.
+func (uc *GoalTrackingUseCase) currentValue(ctx context.Context) (float64, error) {
+	summary, err := uc.portfolioReport.GetPortfolioStatistics(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get portfolio value: %w", err)
+	}
+	return summary.TotalValue, nil
+}
+
+// targetValue returns the absolute target value of the input, or baseline increased by the percent target.
+func targetValue(baseline float64, input GoalInput) float64 {
+	if input.TargetValue > 0 {
+		return input.TargetValue
+	}
+	return baseline * (1 + input.TargetPercent/100)
+}
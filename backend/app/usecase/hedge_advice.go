@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// hedgeAdviceTimeout bounds the hedge advice of a collection run.
+const hedgeAdviceTimeout = time.Minute
+
+// HedgeAdviceResult is the falling holdings and the hedge actions proposed against them.
+type HedgeAdviceResult struct {
+	Drops  []domain.HedgeDrop
+	Advice []domain.HedgeAdvice
+}
+
+// HedgeAdviceUseCase proposes hedges, such as an inverse ETF or raising cash, when held stocks fall
+// sharply from the previous close. The advice is only notified and recorded in the advice logs,
+// no order is placed.
+type HedgeAdviceUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	adviceRepo    repository.AdviceLogRepository
+	notifier      notification.NotificationService
+	rules         domain.HedgeAdviceRules
+	now           func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewHedgeAdviceUseCase creates a new hedge advice use case.
+func NewHedgeAdviceUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	adviceRepo repository.AdviceLogRepository,
+	notifier notification.NotificationService,
+	rules domain.HedgeAdviceRules,
+) *HedgeAdviceUseCase {
+	return &HedgeAdviceUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		adviceRepo:    adviceRepo,
+		notifier:      notifier,
+		rules:         rules,
+		now:           time.Now,
+	}
+}
+
+// HandlePriceUpdated is the event bus handler of the price update events. The advice is evaluated
+// on a goroutine of its own so that the collection job does not wait for it.
+// Silent collection runs are not advised.
+func (uc *HedgeAdviceUseCase) HandlePriceUpdated(ctx context.Context, event domain.Event) error {
+	updated, ok := event.(domain.PriceUpdatedEvent)
+	if !ok || updated.Silent || len(updated.Updated) == 0 {
+		return nil
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), hedgeAdviceTimeout)
+		defer cancel()
+		if _, err := uc.Advise(ctx); err != nil {
+			logrus.Errorf("Failed to advise hedges: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Evaluate returns the held stocks that fell by the threshold or more from the previous close and
+// the hedges proposed against them, without notifying or recording them. The stocks whose alerts
+// are muted in the watch list are left out.
+func (uc *HedgeAdviceUseCase) Evaluate(ctx context.Context) (*HedgeAdviceResult, error) {
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	codes := make([]string, 0, len(portfolio))
+	for _, holding := range portfolio {
+		if !holding.IsCash() {
+			codes = append(codes, holding.Code)
+		}
+	}
+	if len(codes) == 0 {
+		return &HedgeAdviceResult{}, nil
+	}
+
+	latest, err := uc.stockRepo.GetLatestPrices(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest prices: %w", err)
+	}
+	previous, err := uc.stockRepo.GetPreviousPrices(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous prices: %w", err)
+	}
+
+	currentPrices := make(map[string]float64, len(latest))
+	for code, price := range latest {
+		currentPrices[code] = utility.DecimalToFloat(price.ClosePrice)
+	}
+	previousCloses := make(map[string]float64, len(previous))
+	for code, price := range previous {
+		previousCloses[code] = utility.DecimalToFloat(price.ClosePrice)
+	}
+
+	muted, err := loadMutedStockCodes(ctx, uc.stockRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get muted stocks: %v", err)
+	}
+	summary := domain.CalculatePortfolioSummary(portfolio, currentPrices)
+	var drops []domain.HedgeDrop
+	for _, drop := range domain.FindHedgeDrops(summary, previousCloses, uc.rules.DropPercent) {
+		if !muted[drop.Code] {
+			drops = append(drops, drop)
+		}
+	}
+	return &HedgeAdviceResult{
+		Drops:  drops,
+		Advice: domain.EvaluateHedgeAdvice(summary, drops, uc.rules),
+	}, nil
+}
+
+// Advise notifies the hedges proposed against the falling holdings and records them in the advice
+// logs. A holding triggers the advice once a day, so nothing is sent if no other holding fell since
+// the last advice of the day.
+func (uc *HedgeAdviceUseCase) Advise(ctx context.Context) (*HedgeAdviceResult, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	result, err := uc.Evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	advised, err := uc.advisedToday(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNewDrop := false
+	for _, drop := range result.Drops {
+		if !advised[drop.Code] {
+			hasNewDrop = true
+		}
+	}
+	if !hasNewDrop || len(result.Advice) == 0 {
+		return &HedgeAdviceResult{}, nil
+	}
+
+	triggers := make([]string, len(result.Drops))
+	for i, drop := range result.Drops {
+		triggers[i] = drop.Code
+	}
+
+	sendErr := uc.notifier.SendMessageOfKind(ctx, notification.KindCritical,
+		domain.FormatHedgeAdvice(result.Drops, result.Advice, uc.rules.DropPercent))
+	for _, advice := range result.Advice {
+		err := uc.adviceRepo.Save(ctx, &models.AdviceLog{
+			AdviceType:   models.AdviceTypeHedge,
+			Action:       advice.Action,
+			Code:         advice.Code,
+			Amount:       advice.Amount,
+			Reason:       advice.Reason,
+			TriggerCodes: strings.Join(triggers, ","),
+			Notified:     sendErr == nil,
+			CreatedAt:    uc.now(),
+		})
+		if err != nil {
+			logrus.Errorf("Failed to record hedge advice %s: %v", advice.Action, err)
+		}
+	}
+	if sendErr != nil {
+		return nil, fmt.Errorf("failed to send hedge advice: %w", sendErr)
+	}
+
+	logrus.Infof("Advised %d hedges against %d falling holdings", len(result.Advice), len(result.Drops))
+	return result, nil
+}
+
+// advisedToday returns the codes of the holdings that already triggered the advice today.
+func (uc *HedgeAdviceUseCase) advisedToday(ctx context.Context) (map[string]bool, error) {
+	logs, err := uc.adviceRepo.GetSince(ctx, models.AdviceTypeHedge, models.TruncateToDate(uc.now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advice logs: %w", err)
+	}
+
+	advised := make(map[string]bool)
+	for _, log := range logs {
+		for _, code := range log.Triggers() {
+			advised[code] = true
+		}
+	}
+	return advised, nil
+}
+
+// History returns the latest hedge advice, newest first.
+func (uc *HedgeAdviceUseCase) History(ctx context.Context, limit int) ([]*models.AdviceLog, error) {
+	logs, err := uc.adviceRepo.GetLatest(ctx, models.AdviceTypeHedge, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advice logs: %w", err)
+	}
+	return logs, nil
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHedgeAdviceUseCase_Advise(t *testing.T) {
+	day := time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+	closes := map[string]float64{"7203": 2600, "6758": 11700}
+	previous := map[string]float64{"7203": 3000, "6758": 12000}
+
+	stockRepo := &mock.StockRepositoryMock{
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			prices := make(map[string]*models.StockPrice)
+			for code, price := range closes {
+				prices[code] = &models.StockPrice{Code: code, Date: day, ClosePrice: utility.FloatToDecimal(price)}
+			}
+			return prices, nil
+		},
+		GetPreviousPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			prices := make(map[string]*models.StockPrice)
+			for code, price := range previous {
+				prices[code] = &models.StockPrice{Code: code, Date: day.AddDate(0, 0, -3), ClosePrice: utility.FloatToDecimal(price)}
+			}
+			return prices, nil
+		},
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return nil, nil
+		},
+	}
+	holdings := newHoldingsRepository([]*models.Portfolio{
+		{Code: "7203", Name: "トヨタ自動車", Shares: 100, PurchasePrice: utility.FloatToDecimal(3000), PurchaseDate: day},
+		{Code: "6758", Name: "ソニーグループ", Shares: 100, PurchasePrice: utility.FloatToDecimal(12000), PurchaseDate: day},
+	})
+	var logs []*models.AdviceLog
+	adviceRepo := &mock.AdviceLogRepositoryMock{
+		SaveFunc: func(ctx context.Context, log *models.AdviceLog) error {
+			logs = append(logs, log)
+			return nil
+		},
+		GetSinceFunc: func(ctx context.Context, adviceType string, since time.Time) ([]*models.AdviceLog, error) {
+			var found []*models.AdviceLog
+			for _, log := range logs {
+				if log.AdviceType == adviceType && !log.CreatedAt.Before(since) {
+					found = append(found, log)
+				}
+			}
+			return found, nil
+		},
+	}
+	notifier := &fakeAlertNotifier{}
+	uc := NewHedgeAdviceUseCase(stockRepo, holdings, adviceRepo, notifier, domain.HedgeAdviceRules{
+		DropPercent:        5,
+		InverseETFCode:     "1571",
+		HedgeRatioPercent:  50,
+		MinExposurePercent: 10,
+	})
+	uc.now = func() time.Time { return day.Add(15 * time.Hour) }
+
+	// 7203 fell 13.33%: an inverse ETF and a partial sale are proposed
+	if _, err := uc.Advise(context.Background()); err != nil {
+		t.Fatalf("Advise() error = %v", err)
+	}
+	// 7203 was already advised today
+	closes["7203"] = 2500
+	if _, err := uc.Advise(context.Background()); err != nil {
+		t.Fatalf("Advise() error = %v", err)
+	}
+	// 6758 falls too
+	closes["6758"] = 11000
+	if _, err := uc.Advise(context.Background()); err != nil {
+		t.Fatalf("Advise() error = %v", err)
+	}
+
+	if len(notifier.messages) != 2 {
+		t.Fatalf("messages = %q, want 2", notifier.messages)
+	}
+	want := "🛡️ 前回終値から5%以上下落した保有銘柄 (1銘柄)\n" +
+		"- 7203 トヨタ自動車 ¥3000.00 → ¥2600.00 (-13.33%、評価額 ¥260,000)\n" +
+		"\n" +
+		"ヘッジ案:\n" +
+		"- インバースETF 1571 を約¥130,000買い、下落銘柄の評価額 ¥260,000(ポートフォリオの18.2%)の50%をヘッジ\n" +
+		"- 7203 トヨタ自動車 が-13.33%と大きく下落、保有の半分(約¥130,000)の売却を検討\n" +
+		"※ 提案のみで自動では執行しません"
+	if diff := cmp.Diff(want, notifier.messages[0]); diff != "" {
+		t.Errorf("message mismatch (-want +got):\n%s", diff)
+	}
+
+	var got []string
+	for _, log := range logs {
+		if !log.Notified {
+			t.Errorf("advice %s is not recorded as notified", log.Action)
+		}
+		got = append(got, log.Action+":"+log.Code+":"+log.TriggerCodes)
+	}
+	wantLogs := []string{
+		"inverse_etf:1571:7203",
+		"reduce_position:7203:7203",
+		"inverse_etf:1571:7203,6758",
+		"reduce_position:7203:7203,6758",
+	}
+	if diff := cmp.Diff(wantLogs, got); diff != "" {
+		t.Errorf("advice logs mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// maDeviationAlertTimeout bounds the indicator update and alert of a collection run.
+const maDeviationAlertTimeout = 2 * time.Minute
+
+// maDeviationState is the direction of the deviation of a stock last alerted, on the date of its indicators.
+type maDeviationState struct {
+	date     string
+	positive bool
+}
+
+// MADeviationAlertUseCase updates the technical indicators of the collected stocks after each
+// price collection run and alerts the stocks whose close deviates from the 25-day moving average
+// beyond the threshold, as a sign of overheating or overselling.
+type MADeviationAlertUseCase struct {
+	technical        *TechnicalAnalysisUseCase
+	stockRepo        repository.StockRepository
+	portfolioRepo    repository.PortfolioRepository
+	notifier         notification.NotificationService
+	thresholdPercent float64
+
+	// alerted records the direction alerted per stock, so that a stock is alerted once a day
+	// unless its deviation turns to the other side
+	mu      sync.Mutex
+	alerted map[string]maDeviationState
+}
+
+// NewMADeviationAlertUseCase creates a new moving average deviation alert use case.
+func NewMADeviationAlertUseCase(
+	technical *TechnicalAnalysisUseCase,
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	notifier notification.NotificationService,
+	thresholdPercent float64,
+) *MADeviationAlertUseCase {
+	return &MADeviationAlertUseCase{
+		technical:        technical,
+		stockRepo:        stockRepo,
+		portfolioRepo:    portfolioRepo,
+		notifier:         notifier,
+		thresholdPercent: thresholdPercent,
+		alerted:          make(map[string]maDeviationState),
+	}
+}
+
+// HandlePriceUpdated is the event bus handler of the price update events. The deviations are
+// checked on a goroutine of their own so that the collection job does not wait for them.
+// Silent collection runs are not alerted.
+func (uc *MADeviationAlertUseCase) HandlePriceUpdated(ctx context.Context, event domain.Event) error {
+	updated, ok := event.(domain.PriceUpdatedEvent)
+	if !ok || updated.Silent || len(updated.Updated) == 0 {
+		return nil
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), maDeviationAlertTimeout)
+		defer cancel()
+		if err := uc.CheckDeviations(ctx, updated.Updated); err != nil {
+			logrus.Errorf("Failed to check moving average deviations: %v", err)
+		}
+	}()
+	return nil
+}
+
+// CheckDeviations calculates and saves the technical indicators of the given stocks, and sends one
+// alert of the stocks deviating from the moving average beyond the threshold that have not been
+// alerted in the same direction on the day. Stocks with too short a price history are skipped, and
+// the indicators of the stocks whose alerts are muted in the watch list are saved without an alert.
+func (uc *MADeviationAlertUseCase) CheckDeviations(ctx context.Context, codes []string) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	muted, err := loadMutedStockCodes(ctx, uc.stockRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get muted stocks: %v", err)
+	}
+
+	var alerts []domain.MADeviationAlert
+	states := make(map[string]maDeviationState)
+	for _, code := range codes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		indicator, err := uc.technical.CalculateAndSaveTechnicalIndicators(ctx, code)
+		if err != nil {
+			logrus.Debugf("Skipping moving average deviation of %s: %v", code, err)
+			continue
+		}
+
+		deviation := utility.NullDecimalToFloat(indicator.Ma25Deviation)
+		movingAverage := utility.NullDecimalToFloat(indicator.Sma25)
+		if muted[code] || movingAverage <= 0 || !domain.IsMADeviationAlert(deviation, uc.thresholdPercent) {
+			continue
+		}
+
+		state := maDeviationState{date: indicator.Date.Format("2006-01-02"), positive: deviation > 0}
+		if uc.alerted[code] == state {
+			continue
+		}
+		alerts = append(alerts, domain.MADeviationAlert{
+			Code:             code,
+			Price:            movingAverage * (1 + deviation/100),
+			MovingAverage:    movingAverage,
+			DeviationPercent: deviation,
+		})
+		states[code] = state
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	names, err := watchedStockNames(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get stock names: %v", err)
+	}
+	for i := range alerts {
+		alerts[i].Name = names[alerts[i].Code]
+	}
+
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindCritical, domain.FormatMADeviationAlert(alerts, uc.thresholdPercent)); err != nil {
+		return fmt.Errorf("failed to send moving average deviation alert: %w", err)
+	}
+	for code, state := range states {
+		uc.alerted[code] = state
+	}
+	logrus.Infof("Alerted moving average deviations of %d stocks", len(alerts))
+	return nil
+}
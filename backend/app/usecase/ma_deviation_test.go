@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+// closePrices returns daily prices of code ending on day with the given closes, oldest first.
+func closePrices(code string, day time.Time, closes ...float64) []*models.StockPrice {
+	prices := make([]*models.StockPrice, len(closes))
+	for i, c := range closes {
+		price := utility.FloatToDecimal(c)
+		prices[i] = &models.StockPrice{
+			Code:       code,
+			Date:       day.AddDate(0, 0, i-len(closes)+1),
+			OpenPrice:  price,
+			HighPrice:  price,
+			LowPrice:   price,
+			ClosePrice: price,
+		}
+	}
+	return prices
+}
+
+// repeatClose returns count closes of the same price.
+func repeatClose(price float64, count int) []float64 {
+	closes := make([]float64, count)
+	for i := range closes {
+		closes[i] = price
+	}
+	return closes
+}
+
+func TestMADeviationAlertUseCase_CheckDeviations(t *testing.T) {
+	day := time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+	history := map[string][]*models.StockPrice{
+		"7203": closePrices("7203", day, append(repeatClose(1000, 29), 1200)...), // +19.05% from the 25-day MA
+		"6758": closePrices("6758", day, repeatClose(1000, 30)...),
+		"9984": closePrices("9984", day, 1000, 2000), // too short for indicators
+	}
+
+	stockRepo := &mock.StockRepositoryMock{
+		GetPriceHistoryFunc: func(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+			return history[stockCode], nil
+		},
+		SaveTechnicalIndicatorFunc: func(ctx context.Context, indicator *models.TechnicalIndicator) error {
+			return nil
+		},
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{{Code: "7203", Name: "トヨタ自動車"}}, nil
+		},
+	}
+	notifier := &fakeAlertNotifier{}
+	uc := NewMADeviationAlertUseCase(NewTechnicalAnalysisUseCase(stockRepo, nil, nil), stockRepo, newHoldingsRepository(nil), notifier, 10)
+	codes := []string{"7203", "6758", "9984"}
+
+	// The second check on the same day alerts nothing new
+	for range 2 {
+		if err := uc.CheckDeviations(context.Background(), codes); err != nil {
+			t.Fatalf("CheckDeviations() error = %v", err)
+		}
+	}
+
+	want := []string{
+		"🔥 25日移動平均乖離率が±10%を超えた銘柄 (1銘柄)\n" +
+			"- 7203 トヨタ自動車 ¥1200.00 / 25日線 ¥1008.00 (乖離率 +19.05%、買われすぎ)",
+	}
+	if diff := cmp.Diff(want, notifier.messages); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+
+	saved := stockRepo.SaveTechnicalIndicatorCalls()
+	if len(saved) != 4 {
+		t.Fatalf("%d indicators saved, want 4", len(saved))
+	}
+	if got := utility.NullDecimalToFloat(saved[0].Indicator.Ma25Deviation); got < 19.04 || got > 19.05 {
+		t.Errorf("saved deviation = %v, want about 19.05", got)
+	}
+}
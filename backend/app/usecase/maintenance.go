@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// MaintenanceStatus represents the active maintenance window and the alerts suppressed so far.
+type MaintenanceStatus struct {
+	Window          *models.MaintenanceWindow // nil if maintenance mode is off
+	SuppressedCount int
+}
+
+// MaintenanceUseCase handles maintenance mode, during which alerts are suppressed
+// and sent as a digest once the maintenance window ends.
+type MaintenanceUseCase struct {
+	maintenanceRepo repository.MaintenanceRepository
+	notifier        notification.NotificationService
+	now             func() time.Time
+}
+
+// NewMaintenanceUseCase creates a new maintenance use case.
+func NewMaintenanceUseCase(
+	maintenanceRepo repository.MaintenanceRepository,
+	notifier notification.NotificationService,
+) *MaintenanceUseCase {
+	return &MaintenanceUseCase{
+		maintenanceRepo: maintenanceRepo,
+		notifier:        notifier,
+		now:             time.Now,
+	}
+}
+
+// TurnOn turns maintenance mode on until the given time.
+// If a maintenance window is already active, its end is moved to until instead.
+func (uc *MaintenanceUseCase) TurnOn(ctx context.Context, until time.Time, reason string) (*models.MaintenanceWindow, error) {
+	now := uc.now()
+
+	active, err := uc.maintenanceRepo.GetActiveWindow(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active maintenance window: %w", err)
+	}
+	if active != nil {
+		active.EndsAt = until
+		if err := active.Validate(); err != nil {
+			return nil, err
+		}
+		if err := uc.maintenanceRepo.UpdateWindowEnd(ctx, active.ID, until); err != nil {
+			return nil, fmt.Errorf("failed to extend maintenance window: %w", err)
+		}
+		logrus.Infof("Maintenance window %s extended until %s", active.ID, until.Format("2006-01-02 15:04"))
+		return active, nil
+	}
+
+	window := &models.MaintenanceWindow{
+		StartsAt: now,
+		EndsAt:   until,
+		Reason:   reason,
+	}
+	if err := window.Validate(); err != nil {
+		return nil, err
+	}
+	if err := uc.maintenanceRepo.CreateWindow(ctx, window); err != nil {
+		return nil, fmt.Errorf("failed to create maintenance window: %w", err)
+	}
+
+	logrus.Infof("Maintenance mode on until %s", until.Format("2006-01-02 15:04"))
+	return window, nil
+}
+
+// TurnOff ends the active maintenance window now and sends the digest of the suppressed alerts.
+// Returns false if maintenance mode was not on.
+func (uc *MaintenanceUseCase) TurnOff(ctx context.Context) (bool, error) {
+	now := uc.now()
+
+	active, err := uc.maintenanceRepo.GetActiveWindow(ctx, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to get active maintenance window: %w", err)
+	}
+	if active == nil {
+		return false, nil
+	}
+
+	if err := uc.maintenanceRepo.UpdateWindowEnd(ctx, active.ID, now); err != nil {
+		return false, fmt.Errorf("failed to end maintenance window: %w", err)
+	}
+	logrus.Info("Maintenance mode off")
+
+	if err := uc.SendDigests(ctx); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// Status returns the active maintenance window and the number of alerts suppressed during it.
+func (uc *MaintenanceUseCase) Status(ctx context.Context) (*MaintenanceStatus, error) {
+	active, err := uc.maintenanceRepo.GetActiveWindow(ctx, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active maintenance window: %w", err)
+	}
+	if active == nil {
+		return &MaintenanceStatus{}, nil
+	}
+
+	alerts, err := uc.maintenanceRepo.GetSuppressedAlerts(ctx, active.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppressed alerts: %w", err)
+	}
+
+	return &MaintenanceStatus{Window: active, SuppressedCount: len(alerts)}, nil
+}
+
+// SendDigests sends a digest of the suppressed alerts for every ended maintenance window whose digest has not been sent.
+// Nothing is sent while a maintenance window is still active, so that overlapping windows are reported together.
+func (uc *MaintenanceUseCase) SendDigests(ctx context.Context) error {
+	now := uc.now()
+
+	active, err := uc.maintenanceRepo.GetActiveWindow(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get active maintenance window: %w", err)
+	}
+	if active != nil {
+		return nil
+	}
+
+	windows, err := uc.maintenanceRepo.GetWindowsPendingDigest(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance windows: %w", err)
+	}
+
+	for _, window := range windows {
+		alerts, err := uc.maintenanceRepo.GetSuppressedAlerts(ctx, window.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get suppressed alerts: %w", err)
+		}
+
+		if len(alerts) > 0 {
+			digest := domain.FormatMaintenanceDigest(window, alerts)
+			if err := uc.notifier.SendMessageOfKind(ctx, notification.KindCritical, digest); err != nil {
+				return fmt.Errorf("failed to send maintenance digest: %w", err)
+			}
+		}
+
+		if err := uc.maintenanceRepo.MarkDigestSent(ctx, window.ID, now); err != nil {
+			return fmt.Errorf("failed to mark maintenance digest as sent: %w", err)
+		}
+		logrus.Infof("Maintenance digest sent for window %s with %d alerts", window.ID, len(alerts))
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// MarketIndexUseCase collects the prices of the market indices watched, such as the Nikkei 225.
+// They are stored as the daily prices of the symbol of each index like the prices of a stock, but
+// are never part of the portfolio; the daily report shows them in its market section and compares
+// the portfolio with the benchmark among them.
+type MarketIndexUseCase struct {
+	stockRepo   repository.StockRepository
+	stockClient client.StockDataClient
+	indices     []domain.MarketIndex
+	now         func() time.Time
+}
+
+// NewMarketIndexUseCase creates a new market index use case collecting the given indices.
+func NewMarketIndexUseCase(
+	stockRepo repository.StockRepository,
+	stockClient client.StockDataClient,
+	indices []domain.MarketIndex,
+) *MarketIndexUseCase {
+	return &MarketIndexUseCase{
+		stockRepo:   stockRepo,
+		stockClient: stockClient,
+		indices:     indices,
+		now:         time.Now,
+	}
+}
+
+// Indices returns the indices watched.
+func (uc *MarketIndexUseCase) Indices() []domain.MarketIndex {
+	return uc.indices
+}
+
+// UpdateIndexPrices updates the current prices of the indices. The record of the current trading
+// day is updated until its close is confirmed, like the prices of the stocks.
+func (uc *MarketIndexUseCase) UpdateIndexPrices(ctx context.Context) error {
+	failed := 0
+	for _, index := range uc.indices {
+		if err := uc.updatePrice(ctx, index.Symbol); err != nil {
+			logrus.Errorf("Failed to update index price for %s: %v", index.Symbol, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		logrus.Warnf("Encountered %d errors during index price updates", failed)
+	}
+	return nil
+}
+
+// CollectIndexHistory saves the daily prices of the last given days of the indices that are not
+// stored yet. Returns the number of records saved; indices that fail are logged and skipped.
+func (uc *MarketIndexUseCase) CollectIndexHistory(ctx context.Context, days int) (int, error) {
+	saved, failed := 0, 0
+	for _, index := range uc.indices {
+		count, err := uc.collectHistory(ctx, index.Symbol, days)
+		if err != nil {
+			logrus.Errorf("Failed to collect index prices for %s: %v", index.Symbol, err)
+			failed++
+			continue
+		}
+		saved += count
+	}
+
+	logrus.Infof("Index prices collected: %d records saved, %d indices failed", saved, failed)
+	return saved, nil
+}
+
+// Quotes returns the latest stored close of each index with its change from the previous close.
+// Indices without a stored price are left out.
+func (uc *MarketIndexUseCase) Quotes(ctx context.Context) ([]domain.MarketIndexQuote, error) {
+	if len(uc.indices) == 0 {
+		return nil, nil
+	}
+
+	symbols := make([]string, len(uc.indices))
+	for i, index := range uc.indices {
+		symbols[i] = index.Symbol
+	}
+	latest, err := uc.stockRepo.GetLatestPrices(ctx, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index prices: %w", err)
+	}
+	previous, err := uc.stockRepo.GetPreviousPrices(ctx, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous index prices: %w", err)
+	}
+
+	quotes := make([]domain.MarketIndexQuote, 0, len(latest))
+	for _, index := range uc.indices {
+		price, ok := latest[index.Symbol]
+		if !ok {
+			continue
+		}
+		var previousClose float64
+		if prev, ok := previous[index.Symbol]; ok {
+			previousClose = utility.DecimalToFloat(prev.ClosePrice)
+		}
+		quotes = append(quotes, domain.NewMarketIndexQuote(index, price.Date, utility.DecimalToFloat(price.ClosePrice), previousClose))
+	}
+	return quotes, nil
+}
+
+// updatePrice saves the current price of an index, updating the record of the same trading day
+// unless its close is already final.
+func (uc *MarketIndexUseCase) updatePrice(ctx context.Context, symbol string) error {
+	price, err := uc.stockClient.GetCurrentPrice(ctx, symbol)
+	if err != nil {
+		return err
+	}
+
+	latest, err := uc.stockRepo.GetLatestPrice(ctx, symbol)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case latest == nil:
+		err = uc.stockRepo.SaveStockPrice(ctx, price)
+	case latest.SameQuote(price):
+		return nil
+	case latest.SameTradingDay(price):
+		if latest.Final {
+			return nil
+		}
+		err = uc.stockRepo.UpdateStockPrice(ctx, price)
+	default:
+		err = uc.stockRepo.SaveStockPrice(ctx, price)
+	}
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Index price updated for %s: %.2f", symbol, price.ClosePrice)
+	return nil
+}
+
+// collectHistory saves the daily prices of an index not stored yet. The prices of the trading days
+// before today are stored as final.
+func (uc *MarketIndexUseCase) collectHistory(ctx context.Context, symbol string, days int) (int, error) {
+	prices, err := uc.stockClient.GetHistoricalData(ctx, symbol, days)
+	if err != nil {
+		return 0, err
+	}
+	domain.MarkFinalPrices(prices, uc.now())
+
+	existing, err := uc.stockRepo.GetPriceHistory(ctx, symbol, days+1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stored price history: %w", err)
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, price := range existing {
+		stored[price.Date.Format("2006-01-02")] = true
+	}
+
+	newPrices := make([]*models.StockPrice, 0, len(prices))
+	for _, price := range prices {
+		if !stored[price.Date.Format("2006-01-02")] {
+			newPrices = append(newPrices, price)
+		}
+	}
+
+	if err := uc.stockRepo.SaveStockPrices(ctx, newPrices); err != nil {
+		return 0, fmt.Errorf("failed to save index prices: %w", err)
+	}
+	return len(newPrices), nil
+}
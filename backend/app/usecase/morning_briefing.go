@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// MorningBriefingUseCase sends the briefing before the market opens: the closes of the market indices
+// overnight, the previous closes of the listed holdings and the earnings of the watched and held
+// stocks announced today. Each part is optional, so a part that cannot be read is left empty.
+type MorningBriefingUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	marketIndex   *MarketIndexUseCase
+	eventClient   client.CorporateEventClient
+	notifier      notification.NotificationService
+	now           func() time.Time
+}
+
+// NewMorningBriefingUseCase creates a new morning briefing use case. The earnings are not available
+// if eventClient is nil.
+func NewMorningBriefingUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	marketIndex *MarketIndexUseCase,
+	eventClient client.CorporateEventClient,
+	notifier notification.NotificationService,
+) *MorningBriefingUseCase {
+	return &MorningBriefingUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		marketIndex:   marketIndex,
+		eventClient:   eventClient,
+		notifier:      notifier,
+		now:           time.Now,
+	}
+}
+
+// BuildBriefing gathers the briefing of today.
+func (uc *MorningBriefingUseCase) BuildBriefing(ctx context.Context) (*domain.MorningBriefing, error) {
+	briefing := &domain.MorningBriefing{Date: uc.now()}
+
+	if uc.marketIndex != nil {
+		quotes, err := uc.marketIndex.Quotes(ctx)
+		if err != nil {
+			logrus.Warnf("Failed to get market index quotes: %v", err)
+		}
+		briefing.Indices = quotes
+	}
+
+	holdings, err := uc.holdingCloses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	briefing.Holdings = holdings
+
+	if uc.eventClient == nil {
+		briefing.EarningsUnavailable = true
+	} else if earnings, err := uc.todaysEarnings(ctx, briefing.Date); err != nil {
+		logrus.Warnf("Failed to get earnings announcements: %v", err)
+		briefing.EarningsUnavailable = true
+	} else {
+		briefing.Earnings = earnings
+	}
+
+	return briefing, nil
+}
+
+// SendBriefing sends the briefing of today.
+func (uc *MorningBriefingUseCase) SendBriefing(ctx context.Context) error {
+	briefing, err := uc.BuildBriefing(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.Send(ctx, briefing)
+}
+
+// Send sends a briefing as a report.
+func (uc *MorningBriefingUseCase) Send(ctx context.Context, briefing *domain.MorningBriefing) error {
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, domain.FormatMorningBriefing(*briefing)); err != nil {
+		return fmt.Errorf("failed to send morning briefing: %w", err)
+	}
+
+	logrus.Infof("Morning briefing sent: %d indices, %d holdings, %d earnings",
+		len(briefing.Indices), len(briefing.Holdings), len(briefing.Earnings))
+	return nil
+}
+
+// holdingCloses returns the latest stored close of each listed holding, in the order of the portfolio.
+// Holdings without a stored price are left out.
+func (uc *MorningBriefingUseCase) holdingCloses(ctx context.Context) ([]domain.HoldingClose, error) {
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	holdings := listedHoldings(portfolio)
+	codes := make([]string, len(holdings))
+	for i, holding := range holdings {
+		codes[i] = holding.Code
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	latest, err := uc.stockRepo.GetLatestPrices(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest prices: %w", err)
+	}
+	previous, err := uc.stockRepo.GetPreviousPrices(ctx, codes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous prices: %w", err)
+	}
+
+	seen := make(map[string]bool, len(holdings))
+	closes := make([]domain.HoldingClose, 0, len(holdings))
+	for _, holding := range holdings {
+		price, ok := latest[holding.Code]
+		if !ok || seen[holding.Code] {
+			continue
+		}
+		seen[holding.Code] = true
+
+		var previousClose float64
+		if prev, ok := previous[holding.Code]; ok {
+			previousClose = utility.DecimalToFloat(prev.ClosePrice)
+		}
+		closes = append(closes, domain.NewHoldingClose(holding.Code, holding.Name, utility.DecimalToFloat(price.ClosePrice), previousClose))
+	}
+	return closes, nil
+}
+
+// todaysEarnings returns the earnings of the watched and held stocks announced today.
+func (uc *MorningBriefingUseCase) todaysEarnings(ctx context.Context, today time.Time) ([]domain.CorporateEvent, error) {
+	codes, err := collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	events, err := uc.eventClient.GetCorporateEvents(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := watchedStockNames(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get stock names: %v", err)
+	}
+	for i := range events {
+		if name := names[events[i].Code]; name != "" {
+			events[i].Name = name
+		}
+	}
+	return domain.TodaysEarnings(events, today), nil
+}
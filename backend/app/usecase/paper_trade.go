@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/broker"
+	"github.com/boost-jp/stock-automation/app/infrastructure/eventbus"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// PaperTradeResult summarizes a daily paper trading run.
+type PaperTradeResult struct {
+	Settled []*models.BrokerOrder // pending orders filled, canceled or rejected
+	Placed  []*models.BrokerOrder // new orders from trading signals
+}
+
+// PaperTradeUseCase trades the watch list virtually by following the generated trading signals.
+// Buy signals open a position of about orderAmount and sell signals close it; the orders are
+// filled by the paper broker at the next trading day's open.
+type PaperTradeUseCase struct {
+	stockRepo        repository.StockRepository
+	technicalUseCase *TechnicalAnalysisUseCase
+	reportUseCase    *PortfolioReportUseCase
+	paperBroker      *broker.PaperBroker
+	notifier         notification.NotificationService
+	events           eventbus.Publisher
+	orderAmount      float64
+}
+
+// NewPaperTradeUseCase creates a new paper trade use case.
+func NewPaperTradeUseCase(
+	stockRepo repository.StockRepository,
+	technicalUseCase *TechnicalAnalysisUseCase,
+	reportUseCase *PortfolioReportUseCase,
+	paperBroker *broker.PaperBroker,
+	notifier notification.NotificationService,
+	orderAmount float64,
+) *PaperTradeUseCase {
+	return &PaperTradeUseCase{
+		stockRepo:        stockRepo,
+		technicalUseCase: technicalUseCase,
+		reportUseCase:    reportUseCase,
+		paperBroker:      paperBroker,
+		notifier:         notifier,
+		orderAmount:      orderAmount,
+	}
+}
+
+// SetEventPublisher sets the publisher of the trading signal events.
+func (uc *PaperTradeUseCase) SetEventPublisher(events eventbus.Publisher) {
+	uc.events = events
+}
+
+// RunDailyTrading settles the pending orders with the stored prices and then places
+// new orders from today's trading signals.
+func (uc *PaperTradeUseCase) RunDailyTrading(ctx context.Context) (*PaperTradeResult, error) {
+	settled, err := uc.paperBroker.SettlePendingOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	placed, err := uc.PlaceSignalOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Paper trading completed: %d orders settled, %d orders placed", len(settled), len(placed))
+	return &PaperTradeResult{Settled: settled, Placed: placed}, nil
+}
+
+// RunScheduledTrading runs the daily paper trading for the scheduler.
+func (uc *PaperTradeUseCase) RunScheduledTrading(ctx context.Context) error {
+	_, err := uc.RunDailyTrading(ctx)
+	return err
+}
+
+// PlaceSignalOrders places a buy order for watched stocks with a buy signal and no position,
+// and a sell order of the whole position for stocks with a sell signal.
+// Stocks with a pending order are skipped.
+func (uc *PaperTradeUseCase) PlaceSignalOrders(ctx context.Context) ([]*models.BrokerOrder, error) {
+	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+
+	balance, err := uc.paperBroker.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[string]int, len(balance.Positions))
+	for _, position := range balance.Positions {
+		held[position.Code] = position.Quantity
+	}
+
+	pending, err := uc.paperBroker.GetPendingOrders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending orders: %w", err)
+	}
+	hasPending := make(map[string]bool, len(pending))
+	for _, order := range pending {
+		hasPending[order.Code] = true
+	}
+
+	placed := []*models.BrokerOrder{}
+	for _, item := range watchList {
+		if err := ctx.Err(); err != nil {
+			return placed, err
+		}
+		if hasPending[item.Code] {
+			continue
+		}
+
+		evaluation, err := uc.technicalUseCase.EvaluateSignal(ctx, item.Code)
+		if err != nil {
+			logrus.Errorf("Failed to evaluate signal for %s: %v", item.Code, err)
+			continue
+		}
+		if evaluation.Signal == nil {
+			continue
+		}
+		if action := evaluation.Signal.Action; action == "buy" || action == "sell" {
+			eventbus.Publish(ctx, uc.events, domain.SignalGeneratedEvent{
+				Code:       item.Code,
+				Action:     action,
+				Confidence: evaluation.Signal.Confidence,
+				Reason:     evaluation.Signal.Reason,
+				Price:      evaluation.CurrentPrice,
+				At:         time.Now(),
+			})
+		}
+
+		req := broker.OrderRequest{Code: item.Code}
+		switch {
+		case evaluation.Signal.Action == "buy" && held[item.Code] == 0:
+			req.Side = models.OrderSideBuy
+			req.Quantity = domain.PaperOrderQuantity(uc.orderAmount, evaluation.CurrentPrice)
+		case evaluation.Signal.Action == "sell" && held[item.Code] > 0:
+			req.Side = models.OrderSideSell
+			req.Quantity = held[item.Code]
+		default:
+			continue
+		}
+		if req.Quantity == 0 {
+			logrus.Debugf("Order amount ¥%.0f is below one lot of %s at ¥%.2f", uc.orderAmount, item.Code, evaluation.CurrentPrice)
+			continue
+		}
+
+		order, err := uc.paperBroker.PlaceOrder(ctx, req)
+		if err != nil {
+			logrus.Errorf("Failed to place paper order for %s: %v", item.Code, err)
+			continue
+		}
+		placed = append(placed, order)
+	}
+
+	return placed, nil
+}
+
+// GetPerformance values the paper account at the latest prices and compares it with the real portfolio.
+func (uc *PaperTradeUseCase) GetPerformance(ctx context.Context) (*domain.PaperTradePerformance, error) {
+	balance, err := uc.paperBroker.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]domain.PaperPosition, 0, len(balance.Positions))
+	for _, position := range balance.Positions {
+		currentPrice := position.AveragePrice
+		latest, err := uc.stockRepo.GetLatestPrice(ctx, position.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest price for %s: %w", position.Code, err)
+		}
+		if latest != nil {
+			currentPrice = utility.DecimalToFloat(latest.ClosePrice)
+		}
+		positions = append(positions, domain.NewPaperPosition(position.Code, position.Quantity, position.AveragePrice, currentPrice))
+	}
+
+	executions, err := uc.paperBroker.GetExecutions(ctx, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := uc.reportUseCase.GetPortfolioStatistics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio statistics: %w", err)
+	}
+
+	return domain.NewPaperTradePerformance(uc.paperBroker.InitialCash(), balance.Cash, positions, len(executions), summary), nil
+}
+
+// GeneratePerformanceReport generates the formatted paper trading performance report.
+func (uc *PaperTradeUseCase) GeneratePerformanceReport(performance *domain.PaperTradePerformance) string {
+	return domain.GeneratePaperTradeReport(performance)
+}
+
+// SendPerformanceReport sends the comparison of paper trading and the real portfolio.
+func (uc *PaperTradeUseCase) SendPerformanceReport(ctx context.Context) error {
+	performance, err := uc.GetPerformance(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, uc.GeneratePerformanceReport(performance)); err != nil {
+		return fmt.Errorf("failed to send paper trade report: %w", err)
+	}
+
+	logrus.Infof("Paper trade report sent: Total Value=¥%.0f (%.2f%%)", performance.TotalValue, performance.GainPercent)
+	return nil
+}
@@ -0,0 +1,422 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// PortfolioUseCase handles portfolio holding management.
+// Long holdings are kept as lots by purchase date; the holding carries their total shares and average price.
+type PortfolioUseCase struct {
+	portfolioRepo    repository.PortfolioRepository
+	lotRepo          repository.PortfolioLotRepository
+	portfolioService *domain.PortfolioService
+	txManager        repository.TransactionManager
+	costMethod       domain.CostMethod
+}
+
+// NewPortfolioUseCase creates a new portfolio use case.
+// costMethod is the method of calculating realized profit/loss when a sale does not specify one.
+func NewPortfolioUseCase(
+	portfolioRepo repository.PortfolioRepository,
+	lotRepo repository.PortfolioLotRepository,
+	portfolioService *domain.PortfolioService,
+	txManager repository.TransactionManager,
+	costMethod domain.CostMethod,
+) *PortfolioUseCase {
+	return &PortfolioUseCase{
+		portfolioRepo:    portfolioRepo,
+		lotRepo:          lotRepo,
+		portfolioService: portfolioService,
+		txManager:        txManager,
+		costMethod:       costMethod,
+	}
+}
+
+// Default margin model applied to short positions when rates are not specified.
+const (
+	defaultShortMarginRate   = 30.0
+	defaultShortInterestRate = 1.15
+)
+
+// AddHoldingInput represents a new portfolio holding.
+// MarginRate and InterestRate are optional and default to the standard margin model for short positions.
+// A fund takes its ISIN and its association code as the code, with the purchase price per 10,000 units;
+// a crypto asset takes its symbol as the code and the shares in units of 1/models.CryptoQuantityUnits coins
+// with the purchase price per coin; a cash balance takes the amount in yen as the shares.
+type AddHoldingInput struct {
+	Code          string
+	Name          string
+	Shares        int
+	PurchasePrice float64
+	PurchaseDate  time.Time
+	PositionType  string
+	MarginRate    *float64
+	InterestRate  *float64
+	AssetClass    string
+	ISIN          string
+}
+
+// AddHolding registers a new portfolio holding.
+func (uc *PortfolioUseCase) AddHolding(ctx context.Context, input AddHoldingInput) (*models.Portfolio, error) {
+	positionType := input.PositionType
+	if positionType == "" {
+		positionType = models.PositionTypeLong
+	}
+
+	marginRate, interestRate := input.MarginRate, input.InterestRate
+	if positionType == models.PositionTypeShort {
+		if marginRate == nil {
+			rate := defaultShortMarginRate
+			marginRate = &rate
+		}
+		if interestRate == nil {
+			rate := defaultShortInterestRate
+			interestRate = &rate
+		}
+	}
+
+	assetClass := input.AssetClass
+	if assetClass == "" {
+		assetClass = models.AssetClassStock
+	}
+	code, purchasePrice := domain.NormalizeCode(input.Code), input.PurchasePrice
+	switch assetClass {
+	case models.AssetClassStock, models.AssetClassETF:
+		var err error
+		if code, err = domain.NormalizeStockCode(input.Code); err != nil {
+			return nil, err
+		}
+	case models.AssetClassCash:
+		// A cash balance is held as shares of one yen
+		purchasePrice = 1
+	}
+
+	holding := &models.Portfolio{
+		ID:            utility.NewULID(),
+		Code:          code,
+		Name:          input.Name,
+		Shares:        input.Shares,
+		PurchasePrice: utility.FloatToDecimal(purchasePrice),
+		PurchaseDate:  input.PurchaseDate,
+		PositionType:  positionType,
+		MarginRate:    utility.FloatPtrToNullDecimal(marginRate),
+		InterestRate:  utility.FloatPtrToNullDecimal(interestRate),
+		AssetClass:    assetClass,
+	}
+	if input.ISIN != "" {
+		holding.Isin = null.StringFrom(input.ISIN)
+	}
+
+	if err := holding.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.portfolioRepo.GetByCode(ctx, holding.Code)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio holding: %w", err)
+		}
+		if existing != nil {
+			return fmt.Errorf("holding already exists: %s", holding.Code)
+		}
+
+		if err := uc.portfolioRepo.Create(ctx, holding); err != nil {
+			return fmt.Errorf("failed to create portfolio holding: %w", err)
+		}
+		// Lots are kept for the long positions of listed stocks and ETFs
+		if holding.IsShort() || !holding.IsListed() {
+			return nil
+		}
+		_, err = uc.createLot(ctx, holding)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Portfolio holding added: %s (%s) %d shares, %s %s", input.Name, holding.Code, input.Shares, assetClass, positionType)
+	return holding, nil
+}
+
+// RemoveHolding removes a portfolio holding by stock code.
+// The holding and its lots are soft-deleted and can be restored with RestoreHolding.
+func (uc *PortfolioUseCase) RemoveHolding(ctx context.Context, code string) error {
+	var holding *models.Portfolio
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		holding, err = uc.portfolioRepo.GetByCode(ctx, code)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio holding: %w", err)
+		}
+		if holding == nil {
+			return fmt.Errorf("holding not found: %s", code)
+		}
+
+		if err := uc.portfolioRepo.Delete(ctx, holding.ID); err != nil {
+			return fmt.Errorf("failed to delete portfolio holding: %w", err)
+		}
+		if err := uc.lotRepo.DeleteByCode(ctx, code); err != nil {
+			return fmt.Errorf("failed to delete portfolio lots: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Portfolio holding removed: %s (%s)", holding.Name, code)
+	return nil
+}
+
+// ListDeletedHoldings returns the removed holdings that can be restored, most recently removed first.
+func (uc *PortfolioUseCase) ListDeletedHoldings(ctx context.Context) ([]*models.Portfolio, error) {
+	holdings, err := uc.portfolioRepo.GetDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted portfolio holdings: %w", err)
+	}
+	return holdings, nil
+}
+
+// RestoreHolding restores the most recently removed holding of a stock code with the lots removed
+// with it. A holding cannot be restored while the stock is held again.
+func (uc *PortfolioUseCase) RestoreHolding(ctx context.Context, code string) (*models.Portfolio, error) {
+	code = domain.NormalizeCode(code)
+
+	var holding *models.Portfolio
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		active, err := uc.portfolioRepo.GetByCode(ctx, code)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio holding: %w", err)
+		}
+		if active != nil {
+			return fmt.Errorf("銘柄 %s は保有中のため復元できません", code)
+		}
+
+		deleted, err := uc.portfolioRepo.GetDeleted(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get deleted portfolio holdings: %w", err)
+		}
+		for _, candidate := range deleted {
+			if candidate.Code == code {
+				holding = candidate
+				break
+			}
+		}
+		if holding == nil {
+			return fmt.Errorf("削除された保有銘柄が見つかりません: %s", code)
+		}
+
+		if err := uc.portfolioRepo.Restore(ctx, holding.ID); err != nil {
+			return fmt.Errorf("failed to restore portfolio holding: %w", err)
+		}
+		if err := uc.lotRepo.RestoreByCode(ctx, code, holding.DeletedAt.Time); err != nil {
+			return fmt.Errorf("failed to restore portfolio lots: %w", err)
+		}
+		holding.DeletedAt = null.Time{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Portfolio holding restored: %s (%s)", holding.Name, code)
+	return holding, nil
+}
+
+// AddLotInput represents an additional purchase of a long holding.
+type AddLotInput struct {
+	Code          string
+	Shares        int
+	PurchasePrice float64
+	PurchaseDate  time.Time
+}
+
+// AddLot adds a purchase lot to an existing long holding and updates its shares and average price.
+func (uc *PortfolioUseCase) AddLot(ctx context.Context, input AddLotInput) (*models.Portfolio, error) {
+	var holding *models.Portfolio
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		holding, err = uc.getLongHolding(ctx, input.Code)
+		if err != nil {
+			return err
+		}
+
+		lots, err := uc.getLots(ctx, holding)
+		if err != nil {
+			return err
+		}
+
+		lot := &models.PortfolioLot{
+			Code:          input.Code,
+			Shares:        input.Shares,
+			PurchasePrice: utility.FloatToDecimal(input.PurchasePrice),
+			PurchaseDate:  input.PurchaseDate,
+		}
+		if err := lot.Validate(); err != nil {
+			return err
+		}
+		if err := uc.lotRepo.Create(ctx, lot); err != nil {
+			return fmt.Errorf("failed to create portfolio lot: %w", err)
+		}
+
+		uc.portfolioService.ApplyLots(holding, append(lots, lot))
+		if err := uc.portfolioRepo.Update(ctx, holding); err != nil {
+			return fmt.Errorf("failed to update portfolio holding: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Portfolio lot added: %s %d shares @ %.2f", input.Code, input.Shares, input.PurchasePrice)
+	return holding, nil
+}
+
+// GetLots returns the lots of a long holding from the oldest purchase.
+func (uc *PortfolioUseCase) GetLots(ctx context.Context, code string) ([]*models.PortfolioLot, error) {
+	var lots []*models.PortfolioLot
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		holding, err := uc.getLongHolding(ctx, code)
+		if err != nil {
+			return err
+		}
+		lots, err = uc.getLots(ctx, holding)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lots, nil
+}
+
+// SellInput represents a sale of shares of a long holding.
+// An empty Method uses the configured cost method.
+type SellInput struct {
+	Code      string
+	Shares    int
+	SellPrice float64
+	Method    domain.CostMethod
+}
+
+// Sell sells shares of a long holding from its oldest lots and returns the consumed lots with the realized profit/loss.
+// The holding is removed when all its shares are sold.
+func (uc *PortfolioUseCase) Sell(ctx context.Context, input SellInput) (*domain.SaleResult, error) {
+	method := input.Method
+	if method == "" {
+		method = uc.costMethod
+	}
+
+	var result *domain.SaleResult
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		holding, err := uc.getLongHolding(ctx, input.Code)
+		if err != nil {
+			return err
+		}
+
+		lots, err := uc.getLots(ctx, holding)
+		if err != nil {
+			return err
+		}
+
+		result, err = uc.portfolioService.CalculateSale(lots, input.Shares, input.SellPrice, method)
+		if err != nil {
+			return err
+		}
+
+		for _, consumed := range result.ConsumedLots {
+			if consumed.RemainingShares > 0 {
+				continue
+			}
+			if err := uc.lotRepo.Delete(ctx, consumed.LotID); err != nil {
+				return fmt.Errorf("failed to delete portfolio lot: %w", err)
+			}
+		}
+		// The remaining lots carry the sold shares and their average price; the purchase prices are kept
+		for _, lot := range result.RemainingLots {
+			if err := uc.lotRepo.Update(ctx, lot); err != nil {
+				return fmt.Errorf("failed to update portfolio lot: %w", err)
+			}
+		}
+
+		if len(result.RemainingLots) == 0 {
+			// A sold out holding is not restorable, so it is removed for good
+			if err := uc.portfolioRepo.Purge(ctx, holding.ID); err != nil {
+				return fmt.Errorf("failed to delete portfolio holding: %w", err)
+			}
+			return nil
+		}
+
+		uc.portfolioService.ApplyLots(holding, result.RemainingLots)
+		if err := uc.portfolioRepo.Update(ctx, holding); err != nil {
+			return fmt.Errorf("failed to update portfolio holding: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Portfolio holding sold: %s %d shares @ %.2f, realized gain %.2f (%s)",
+		input.Code, input.Shares, input.SellPrice, result.RealizedGain, result.Method)
+	return result, nil
+}
+
+// getLongHolding returns the long holding of a stock, or an error if there is none.
+func (uc *PortfolioUseCase) getLongHolding(ctx context.Context, code string) (*models.Portfolio, error) {
+	holding, err := uc.portfolioRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio holding: %w", err)
+	}
+	if holding == nil {
+		return nil, fmt.Errorf("holding not found: %s", code)
+	}
+	if holding.IsShort() {
+		return nil, fmt.Errorf("lots are only kept for long positions: %s", code)
+	}
+	if !holding.IsListed() {
+		return nil, fmt.Errorf("lots are only kept for stocks and ETFs: %s", code)
+	}
+	return holding, nil
+}
+
+// getLots returns the lots of a holding. A holding registered before lots were kept
+// is converted to a single lot of its shares at its purchase price.
+func (uc *PortfolioUseCase) getLots(ctx context.Context, holding *models.Portfolio) ([]*models.PortfolioLot, error) {
+	lots, err := uc.lotRepo.GetByCode(ctx, holding.Code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio lots: %w", err)
+	}
+	if len(lots) > 0 {
+		return lots, nil
+	}
+
+	lot, err := uc.createLot(ctx, holding)
+	if err != nil {
+		return nil, err
+	}
+	return []*models.PortfolioLot{lot}, nil
+}
+
+// createLot creates a lot of all the shares of a holding at its purchase price.
+func (uc *PortfolioUseCase) createLot(ctx context.Context, holding *models.Portfolio) (*models.PortfolioLot, error) {
+	lot := &models.PortfolioLot{
+		Code:          holding.Code,
+		Shares:        holding.Shares,
+		PurchasePrice: holding.PurchasePrice,
+		PurchaseDate:  holding.PurchaseDate,
+	}
+	if err := uc.lotRepo.Create(ctx, lot); err != nil {
+		return nil, fmt.Errorf("failed to create portfolio lot: %w", err)
+	}
+	return lot, nil
+}
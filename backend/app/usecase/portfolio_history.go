@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// PortfolioHistory represents the daily portfolio valuation over a period.
+type PortfolioHistory struct {
+	Period domain.HistoryPeriod           `json:"period"`
+	From   time.Time                      `json:"from"`
+	To     time.Time                      `json:"to"`
+	Points []domain.PortfolioHistoryPoint `json:"points"`
+}
+
+// PortfolioHistoryUseCase handles daily portfolio snapshots and their history.
+type PortfolioHistoryUseCase struct {
+	snapshotRepo  repository.PortfolioSnapshotRepository
+	reportUseCase *PortfolioReportUseCase
+}
+
+// NewPortfolioHistoryUseCase creates a new portfolio history use case.
+func NewPortfolioHistoryUseCase(
+	snapshotRepo repository.PortfolioSnapshotRepository,
+	reportUseCase *PortfolioReportUseCase,
+) *PortfolioHistoryUseCase {
+	return &PortfolioHistoryUseCase{
+		snapshotRepo:  snapshotRepo,
+		reportUseCase: reportUseCase,
+	}
+}
+
+// SaveDailySnapshot saves the current portfolio valuation as today's snapshot.
+// Running it again on the same day overwrites the snapshot.
+func (uc *PortfolioHistoryUseCase) SaveDailySnapshot(ctx context.Context) error {
+	summary, err := uc.reportUseCase.GetPortfolioStatistics(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := models.NewPortfolioSnapshot(time.Now(), summary.TotalValue, summary.TotalCost, len(summary.Holdings))
+	if err := uc.snapshotRepo.Upsert(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to save portfolio snapshot: %w", err)
+	}
+
+	logrus.Infof("Portfolio snapshot saved for %s: Total Value=¥%.0f, Gain=¥%.0f",
+		snapshot.DateKey(), snapshot.TotalValue, snapshot.TotalGain)
+	return nil
+}
+
+// GetHistory returns the daily valuation and gain over the period ending today.
+// Days without a snapshot carry over the previous day's values.
+func (uc *PortfolioHistoryUseCase) GetHistory(ctx context.Context, period domain.HistoryPeriod) (*PortfolioHistory, error) {
+	to := models.TruncateToDate(time.Now())
+	from := period.StartDate(to)
+
+	snapshots, err := uc.snapshotRepo.GetRange(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio snapshots: %w", err)
+	}
+
+	previous, err := uc.snapshotRepo.GetLatestBefore(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous portfolio snapshot: %w", err)
+	}
+
+	return &PortfolioHistory{
+		Period: period,
+		From:   from,
+		To:     to,
+		Points: domain.FillPortfolioHistory(snapshots, previous, from, to),
+	}, nil
+}
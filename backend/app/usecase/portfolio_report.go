@@ -3,12 +3,16 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
 	"github.com/boost-jp/stock-automation/app/infrastructure/client"
 	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
 	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
 	"github.com/sirupsen/logrus"
 )
 
@@ -16,28 +20,113 @@ import (
 type PortfolioReportUseCase struct {
 	stockRepo     repository.StockRepository
 	portfolioRepo repository.PortfolioRepository
+	goalRepo      repository.PortfolioGoalRepository
+	snapshotRepo  repository.PortfolioSnapshotRepository
 	stockClient   client.StockDataClient
 	notifier      notification.NotificationService
+	technical     *TechnicalAnalysisUseCase
+	prefsRepo     repository.ReportPreferenceRepository
+	noteRepo      repository.TradeNoteRepository
+	marketIndex   *MarketIndexUseCase
+
+	// benchmark is the symbol of the index the daily change of the portfolio is compared with
+	benchmark string
+
+	// targetCashPercent is the share of the portfolio value kept out of the buying power
+	targetCashPercent float64
+
+	correlationService *domain.CorrelationAnalysisService
+	maxWorkers         int
 }
 
+// correlationLookbackDays is the price history period used for the correlation analysis
+const correlationLookbackDays = 90
+
 // NewPortfolioReportUseCase creates a new portfolio report use case.
 func NewPortfolioReportUseCase(
 	stockRepo repository.StockRepository,
 	portfolioRepo repository.PortfolioRepository,
+	goalRepo repository.PortfolioGoalRepository,
+	snapshotRepo repository.PortfolioSnapshotRepository,
 	stockClient client.StockDataClient,
 	notifier notification.NotificationService,
 ) *PortfolioReportUseCase {
 	return &PortfolioReportUseCase{
 		stockRepo:     stockRepo,
 		portfolioRepo: portfolioRepo,
+		goalRepo:      goalRepo,
+		snapshotRepo:  snapshotRepo,
 		stockClient:   stockClient,
 		notifier:      notifier,
+
+		correlationService: domain.NewCorrelationAnalysisService(),
+		maxWorkers:         8, // Limit concurrent queries per holding
 	}
 }
 
+// SetTechnicalAnalysis sets the technical analysis used for the technical summary of the daily report.
+// The daily report has no technical summary without it.
+func (uc *PortfolioReportUseCase) SetTechnicalAnalysis(technical *TechnicalAnalysisUseCase) {
+	uc.technical = technical
+}
+
+// SetReportPreferences sets the settings turning the sections of the daily report on or off.
+// All sections are shown without them.
+func (uc *PortfolioReportUseCase) SetReportPreferences(prefsRepo repository.ReportPreferenceRepository) {
+	uc.prefsRepo = prefsRepo
+}
+
+// SetTradeNotes sets the trade notes whose newest note is referred to under each holding of the daily report.
+func (uc *PortfolioReportUseCase) SetTradeNotes(noteRepo repository.TradeNoteRepository) {
+	uc.noteRepo = noteRepo
+}
+
+// SetMarketIndices sets the indices shown in the market section of the daily report, comparing the
+// portfolio with the index of the benchmark symbol among them.
+func (uc *PortfolioReportUseCase) SetMarketIndices(marketIndex *MarketIndexUseCase, benchmark string) {
+	uc.marketIndex = marketIndex
+	uc.benchmark = benchmark
+}
+
+// reportPreferences returns the sections of the daily report to show.
+// All sections are shown if the settings cannot be read, since the report matters more than its layout.
+func (uc *PortfolioReportUseCase) reportPreferences(ctx context.Context) domain.ReportPreferences {
+	if uc.prefsRepo == nil {
+		return nil
+	}
+	preferences, err := uc.prefsRepo.GetAll(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get report preferences, showing all sections: %v", err)
+		return nil
+	}
+	return domain.NewReportPreferences(preferences)
+}
+
+// SetTargetCashPercent sets the target cash position, the share of the portfolio value in percent
+// kept in cash and left out of the buying power.
+func (uc *PortfolioReportUseCase) SetTargetCashPercent(percent float64) {
+	uc.targetCashPercent = percent
+}
+
+// WithNotifier returns a copy of the use case sending the reports to the given notifier,
+// e.g. a dry run notifier to preview a report without sending it.
+func (uc *PortfolioReportUseCase) WithNotifier(notifier notification.NotificationService) *PortfolioReportUseCase {
+	copied := *uc
+	copied.notifier = notifier
+	return &copied
+}
+
+// dailyReportThreadKey returns the thread key of the daily report of a day, which the follow-ups of
+// the day are replied to.
+func dailyReportThreadKey(day time.Time) string {
+	return "daily-report:" + day.Format("2006-01-02")
+}
+
 // GenerateAndSendDailyReport generates and sends the daily portfolio report.
+// The report starts the thread of the day that its follow-ups are replied to.
 func (uc *PortfolioReportUseCase) GenerateAndSendDailyReport(ctx context.Context) error {
 	logrus.Info("Generating daily portfolio report...")
+	ctx = notification.WithThread(ctx, dailyReportThreadKey(time.Now()))
 
 	// Get portfolio
 	portfolio, err := uc.portfolioRepo.GetAll(ctx)
@@ -51,31 +140,47 @@ func (uc *PortfolioReportUseCase) GenerateAndSendDailyReport(ctx context.Context
 	}
 
 	// Get current prices
-	currentPrices := make(map[string]float64)
-	for _, holding := range portfolio {
-		price, err := uc.stockRepo.GetLatestPrice(ctx, holding.Code)
-		if err != nil {
-			logrus.Warnf("Failed to get price for %s: %v", holding.Code, err)
-			continue
-		}
-		currentPrices[holding.Code] = client.DecimalToFloat(price.ClosePrice)
+	currentPrices, missing, err := uc.getCurrentPrices(ctx, portfolio)
+	if err != nil {
+		return err
 	}
+	logMissingPrices(missing)
 
-	// Calculate portfolio summary
+	// Calculate portfolio summary with the sections turned on in the report preferences
 	summary := domain.CalculatePortfolioSummary(portfolio, currentPrices)
+	prefs := uc.reportPreferences(ctx)
+	if prefs.Enabled(domain.ReportSectionTechnicals) {
+		uc.attachTechnicals(ctx, summary, portfolio, currentPrices)
+	}
+	if prefs.Enabled(domain.ReportSectionBuyingPower) {
+		uc.attachBuyingPower(summary)
+	}
+	if prefs.Enabled(domain.ReportSectionContributions) {
+		uc.attachContributions(ctx, summary, portfolio, currentPrices)
+	}
+	if prefs.Enabled(domain.ReportSectionGoals) {
+		uc.attachGoals(ctx, summary)
+	}
+	if prefs.Enabled(domain.ReportSectionHoldings) {
+		uc.attachNotes(ctx, summary)
+	}
+	if prefs.Enabled(domain.ReportSectionMarket) {
+		uc.attachMarket(ctx, summary, portfolio, currentPrices)
+	}
+	prefs.Apply(summary)
 
 	// Generate comprehensive report
 	report := domain.GeneratePortfolioReport(summary)
 
 	// Use type assertion to check if notifier supports comprehensive report
-	if slackNotifier, ok := uc.notifier.(*notification.SlackNotifier); ok {
-		// Send comprehensive report if SlackNotifier is available
-		if err := slackNotifier.SendComprehensiveReport(report, summary); err != nil {
+	if sender, ok := uc.notifier.(notification.ComprehensiveReportSender); ok {
+		// Send comprehensive report if the notifier supports it
+		if err := sender.SendComprehensiveReport(ctx, report, summary); err != nil {
 			return err
 		}
 	} else {
 		// Fallback to simple daily report
-		if err := uc.notifier.SendDailyReport(summary.TotalValue, summary.TotalGain, summary.TotalGainPercent); err != nil {
+		if err := uc.notifier.SendDailyReport(ctx, summary.TotalValue, summary.TotalGain, summary.TotalGainPercent); err != nil {
 			return err
 		}
 	}
@@ -86,6 +191,55 @@ func (uc *PortfolioReportUseCase) GenerateAndSendDailyReport(ctx context.Context
 	return nil
 }
 
+// SendClosingFollowUp replies the closing values of today to the thread of this morning's daily
+// report: the portfolio valued at the closes just collected, compared with the previous closes the
+// report was based on. Nothing is sent without holdings.
+func (uc *PortfolioReportUseCase) SendClosingFollowUp(ctx context.Context) error {
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	if len(portfolio) == 0 {
+		logrus.Info("No portfolio data found, skipping closing follow-up")
+		return nil
+	}
+
+	currentPrices, missing, err := uc.getCurrentPrices(ctx, portfolio)
+	if err != nil {
+		return err
+	}
+	logMissingPrices(missing)
+
+	codes := make([]string, 0, len(currentPrices))
+	for code := range currentPrices {
+		codes = append(codes, code)
+	}
+	previous, err := uc.stockRepo.GetPreviousPrices(ctx, codes)
+	if err != nil {
+		return fmt.Errorf("failed to get previous prices: %w", err)
+	}
+	// Holdings without a previous close count as unchanged
+	previousPrices := make(map[string]float64, len(currentPrices))
+	for code, price := range currentPrices {
+		previousPrices[code] = price
+		if price, ok := previous[code]; ok {
+			previousPrices[code] = utility.DecimalToFloat(price.ClosePrice)
+		}
+	}
+
+	summary := domain.CalculatePortfolioSummary(portfolio, currentPrices)
+	before := domain.CalculatePortfolioSummary(portfolio, previousPrices)
+	message := domain.FormatClosingFollowUp(summary, before.TotalValue)
+
+	ctx = notification.WithThread(ctx, dailyReportThreadKey(time.Now()))
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, message); err != nil {
+		return fmt.Errorf("failed to send closing follow-up: %w", err)
+	}
+
+	logrus.Infof("Closing follow-up sent: Total Value=¥%.0f", summary.TotalValue)
+	return nil
+}
+
 // SendPortfolioAnalysis sends detailed portfolio domain.
 func (uc *PortfolioReportUseCase) SendPortfolioAnalysis(ctx context.Context) error {
 	// Get portfolio
@@ -95,13 +249,9 @@ func (uc *PortfolioReportUseCase) SendPortfolioAnalysis(ctx context.Context) err
 	}
 
 	// Get current prices
-	currentPrices := make(map[string]float64)
-	for _, holding := range portfolio {
-		price, err := uc.stockRepo.GetLatestPrice(ctx, holding.Code)
-		if err != nil {
-			continue
-		}
-		currentPrices[holding.Code] = client.DecimalToFloat(price.ClosePrice)
+	currentPrices, _, err := uc.getCurrentPrices(ctx, portfolio)
+	if err != nil {
+		return err
 	}
 
 	// Generate detailed report
@@ -109,7 +259,7 @@ func (uc *PortfolioReportUseCase) SendPortfolioAnalysis(ctx context.Context) err
 	report := domain.GeneratePortfolioReport(summary)
 
 	// Send via notification
-	return uc.notifier.SendMessage(report)
+	return uc.notifier.SendMessageOfKind(ctx, notification.KindReport, report)
 }
 
 // GenerateComprehensiveDailyReport generates a comprehensive daily report with error handling.
@@ -123,38 +273,39 @@ func (uc *PortfolioReportUseCase) GenerateComprehensiveDailyReport(ctx context.C
 	}
 
 	if len(portfolio) == 0 {
-		return "📊 ポートフォリオレポート\n\n💡 現在ポートフォリオにデータがありません", nil
+		return i18n.T("portfolio.title") + "\n\n💡 " + i18n.T("portfolio.empty"), nil
 	}
 
 	// Get current prices with error tracking
-	currentPrices := make(map[string]float64)
-	var priceErrors []string
+	currentPrices, missing, err := uc.getCurrentPrices(ctx, portfolio)
+	if err != nil {
+		return "", err
+	}
+	logMissingPrices(missing)
 
-	for _, holding := range portfolio {
-		price, err := uc.stockRepo.GetLatestPrice(ctx, holding.Code)
-		if err != nil {
-			errorMsg := fmt.Sprintf("%s (%s): 価格取得エラー", holding.Name, holding.Code)
-			priceErrors = append(priceErrors, errorMsg)
-			logrus.Warnf("Failed to get price for %s: %v", holding.Code, err)
-			continue
-		}
-		currentPrices[holding.Code] = client.DecimalToFloat(price.ClosePrice)
+	var priceErrors []string
+	for _, holding := range missing {
+		priceErrors = append(priceErrors, i18n.T("report.price_error_item", holding.Name, holding.Code))
 	}
 
 	// Generate report
 	summary := domain.CalculatePortfolioSummary(portfolio, currentPrices)
+	uc.attachTechnicals(ctx, summary, portfolio, currentPrices)
+	uc.attachBuyingPower(summary)
+	uc.attachContributions(ctx, summary, portfolio, currentPrices)
+	uc.attachGoals(ctx, summary)
 	report := domain.GeneratePortfolioReport(summary)
 
 	// Add errors if any
 	if len(priceErrors) > 0 {
-		report += "\n⚠️ 価格取得エラー:\n"
+		report += "\n⚠️ " + i18n.T("report.price_errors") + "\n"
 		for _, errorMsg := range priceErrors {
 			report += fmt.Sprintf("   - %s\n", errorMsg)
 		}
 	}
 
 	// Add timestamp
-	report += fmt.Sprintf("\n🕐 生成時刻: %s", time.Now().Format("2006-01-02 15:04:05"))
+	report += "\n🕐 " + i18n.T("report.generated_at", time.Now().Format("2006-01-02 15:04:05"))
 
 	return report, nil
 }
@@ -167,7 +318,7 @@ func (uc *PortfolioReportUseCase) SendComprehensiveDailyReport(ctx context.Conte
 	}
 
 	// Send via notification
-	if err := uc.notifier.SendMessage(report); err != nil {
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, report); err != nil {
 		return fmt.Errorf("failed to send comprehensive report: %w", err)
 	}
 
@@ -188,17 +339,359 @@ func (uc *PortfolioReportUseCase) GetPortfolioStatistics(ctx context.Context) (*
 	}
 
 	// Get current prices
-	currentPrices := make(map[string]float64)
+	currentPrices, missing, err := uc.getCurrentPrices(ctx, portfolio)
+	if err != nil {
+		return nil, err
+	}
+	logMissingPrices(missing)
+
+	// Calculate statistics
+	summary := domain.CalculatePortfolioSummary(portfolio, currentPrices)
+	return summary, nil
+}
+
+// attachContributions sets the contribution analysis of the holdings to the summary, with the
+// daily changes from the closes of the previous trading day. The daily changes are left out if
+// the previous closes cannot be read.
+func (uc *PortfolioReportUseCase) attachContributions(ctx context.Context, summary *domain.PortfolioSummary, portfolio []*models.Portfolio, currentPrices map[string]float64) {
+	analysis := uc.contributions(ctx, portfolio, currentPrices)
+	summary.Contributions = &analysis
+}
+
+// contributions analyzes the contributions of the holdings with a current price.
+func (uc *PortfolioReportUseCase) contributions(ctx context.Context, portfolio []*models.Portfolio, currentPrices map[string]float64) domain.ContributionAnalysis {
+	codes := make([]string, 0, len(currentPrices))
 	for _, holding := range portfolio {
-		price, err := uc.stockRepo.GetLatestPrice(ctx, holding.Code)
+		if _, ok := currentPrices[holding.Code]; ok {
+			codes = append(codes, holding.Code)
+		}
+	}
+
+	previousPrices := make(map[string]float64, len(codes))
+	prices, err := uc.stockRepo.GetPreviousPrices(ctx, codes)
+	if err != nil {
+		logrus.Warnf("Failed to get previous prices: %v", err)
+	}
+	for code, price := range prices {
+		previousPrices[code] = utility.DecimalToFloat(price.ClosePrice)
+	}
+
+	return domain.CalculateContributions(portfolio, currentPrices, previousPrices)
+}
+
+// attachMarket sets the closes of the market indices to the summary, comparing the daily change of
+// the portfolio with the benchmark. The section is optional in the report, so failures are only logged.
+func (uc *PortfolioReportUseCase) attachMarket(ctx context.Context, summary *domain.PortfolioSummary, portfolio []*models.Portfolio, currentPrices map[string]float64) {
+	if uc.marketIndex == nil {
+		return
+	}
+
+	quotes, err := uc.marketIndex.Quotes(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get market index quotes: %v", err)
+		return
+	}
+	if len(quotes) == 0 {
+		return
+	}
+
+	// The daily change of the portfolio is analyzed with the contributions, which may be turned off
+	analysis := summary.Contributions
+	if analysis == nil {
+		contributions := uc.contributions(ctx, portfolio, currentPrices)
+		analysis = &contributions
+	}
+	market := domain.NewMarketSummary(quotes, uc.benchmark, analysis.DailyChangePercent)
+	summary.Market = &market
+}
+
+// attachTechnicals sets the technical summaries of the listed holdings with a current price to the summary,
+// summarizing the holdings concurrently in the order of the portfolio. The summaries are optional in the
+// report, so failures are only logged.
+func (uc *PortfolioReportUseCase) attachTechnicals(ctx context.Context, summary *domain.PortfolioSummary, portfolio []*models.Portfolio, currentPrices map[string]float64) {
+	if uc.technical == nil {
+		return
+	}
+
+	holdings := make(map[string]*models.Portfolio)
+	var codes []string
+	for _, holding := range listedHoldings(portfolio) {
+		if _, ok := currentPrices[holding.Code]; !ok {
+			continue
+		}
+		if _, ok := holdings[holding.Code]; !ok {
+			holdings[holding.Code] = holding
+			codes = append(codes, holding.Code)
+		}
+	}
+
+	var mu sync.Mutex
+	technicals := make(map[string]domain.TechnicalSummary, len(codes))
+	failed := runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, code string) error {
+		holding := holdings[code]
+		technical, err := uc.technical.SummarizeHolding(ctx, holding.Code, holding.Name, currentPrices[code])
 		if err != nil {
-			logrus.Warnf("Failed to get price for %s: %v", holding.Code, err)
+			return err
+		}
+		mu.Lock()
+		technicals[code] = technical
+		mu.Unlock()
+		return nil
+	})
+	for _, code := range codes {
+		if err, ok := failed[code]; ok {
+			logrus.Warnf("Failed to summarize technical indicators of %s: %v", code, err)
 			continue
 		}
-		currentPrices[holding.Code] = client.DecimalToFloat(price.ClosePrice)
+		summary.Technicals = append(summary.Technicals, technicals[code])
 	}
+}
+
+// attachNotes sets the newest trade note of each holding to the summary.
+// The notes are optional in the report, so failures are only logged.
+func (uc *PortfolioReportUseCase) attachNotes(ctx context.Context, summary *domain.PortfolioSummary) {
+	if uc.noteRepo == nil {
+		return
+	}
+
+	codes := make([]string, len(summary.Holdings))
+	for i, holding := range summary.Holdings {
+		codes[i] = holding.Code
+	}
+	notes, err := uc.noteRepo.GetLatestByCodes(ctx, codes)
+	if err != nil {
+		logrus.Warnf("Failed to get trade notes: %v", err)
+		return
+	}
+	summary.Notes = notes
+}
+
+// attachBuyingPower sets the buying power to the summary if the portfolio holds cash, and judges
+// whether one unit of each held stock with a buy signal fits in it.
+func (uc *PortfolioReportUseCase) attachBuyingPower(summary *domain.PortfolioSummary) {
+	if !domain.HasCash(summary) {
+		return
+	}
+	power := domain.CalculateBuyingPower(summary, uc.targetCashPercent)
+	summary.BuyingPower = &power
+	domain.JudgeBuySignals(summary.Technicals, power)
+}
+
+// GetBuyingPower returns the buying power of the portfolio at the latest prices.
+func (uc *PortfolioReportUseCase) GetBuyingPower(ctx context.Context) (domain.BuyingPower, error) {
+	summary, err := uc.GetPortfolioStatistics(ctx)
+	if err != nil {
+		return domain.BuyingPower{}, err
+	}
+	return domain.CalculateBuyingPower(summary, uc.targetCashPercent), nil
+}
+
+// attachGoals sets the progress of the goals whose deadline has not passed to the summary.
+// Goals are optional in the report, so failures are only logged.
+func (uc *PortfolioReportUseCase) attachGoals(ctx context.Context, summary *domain.PortfolioSummary) {
+	if uc.goalRepo == nil {
+		return
+	}
+
+	goals, err := uc.goalRepo.GetAll(ctx)
+	if err != nil {
+		logrus.Warnf("Failed to get portfolio goals: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, goal := range goals {
+		if !now.Before(goal.Deadline) {
+			continue
+		}
+		summary.Goals = append(summary.Goals, domain.CalculateGoalProgress(goal, summary.TotalValue, now))
+	}
+}
+
+// GenerateMonthlyReport generates the monthly report with the portfolio summary, the
+// correlation analysis of the holdings' daily returns over the last 90 days, and the
+// monthly return calendar of the last 12 months with the year-to-date return.
+func (uc *PortfolioReportUseCase) GenerateMonthlyReport(ctx context.Context) (string, error) {
+	logrus.Info("Generating monthly portfolio report...")
+
+	now := time.Now()
+	header := i18n.T("report.monthly_title", now.Year(), int(now.Month())) + "\n\n"
+
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	if len(portfolio) == 0 {
+		return header + "💡 " + i18n.T("portfolio.empty"), nil
+	}
+
+	currentPrices, missing, err := uc.getCurrentPrices(ctx, portfolio)
+	if err != nil {
+		return "", err
+	}
+	logMissingPrices(missing)
 
-	// Calculate statistics
 	summary := domain.CalculatePortfolioSummary(portfolio, currentPrices)
-	return summary, nil
+
+	// Correlation of daily returns between holdings, with the price histories fetched concurrently
+	var (
+		codes     []string
+		mu        sync.Mutex
+		histories = make(map[string][]*models.StockPrice, len(summary.Holdings))
+	)
+	for _, holding := range summary.Holdings {
+		if _, ok := histories[holding.Code]; !ok {
+			histories[holding.Code] = nil
+			codes = append(codes, holding.Code)
+		}
+	}
+	failed := runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, code string) error {
+		prices, err := uc.stockRepo.GetPriceHistory(ctx, code, correlationLookbackDays)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		histories[code] = prices
+		mu.Unlock()
+		return nil
+	})
+	for code, err := range failed {
+		logrus.Warnf("Failed to get price history for %s: %v", code, err)
+	}
+
+	analysisSvc := domain.NewTechnicalAnalysisService()
+	inputs := make([]domain.CorrelationInput, 0, len(summary.Holdings))
+	for _, holding := range summary.Holdings {
+		if _, ok := failed[holding.Code]; ok {
+			continue
+		}
+		prices := histories[holding.Code]
+
+		inputs = append(inputs, domain.CorrelationInput{
+			Code:   holding.Code,
+			Name:   holding.Name,
+			Weight: holding.CurrentValue,
+			Short:  holding.PositionType == models.PositionTypeShort,
+			Prices: analysisSvc.ConvertStockPrices(prices),
+		})
+	}
+	analysis := uc.correlationService.Analyze(inputs)
+
+	report := header
+	report += domain.GeneratePortfolioReport(summary)
+	report += "\n" + uc.correlationService.FormatCorrelationReport(analysis)
+	if monthly, ok := uc.monthlyReturns(ctx, now); ok {
+		report += "\n" + domain.FormatMonthlyReturnSection(monthly)
+	}
+	report += "\n🕐 " + i18n.T("report.generated_at", now.Format("2006-01-02 15:04:05"))
+
+	logrus.Infof("Monthly report generated: %d holdings, average correlation %.2f, effective holdings %.1f",
+		len(analysis.Codes), analysis.AverageCorrelation, analysis.EffectiveHoldings)
+
+	return report, nil
+}
+
+// monthlyReturns calculates the monthly returns of the last 12 months from the portfolio snapshots.
+// The calendar is left out of the report if the snapshots cannot be read.
+func (uc *PortfolioReportUseCase) monthlyReturns(ctx context.Context, now time.Time) (domain.MonthlyReturnSummary, bool) {
+	if uc.snapshotRepo == nil {
+		return domain.MonthlyReturnSummary{}, false
+	}
+
+	// From the month before the first month, whose last snapshot is the start of the first month
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -domain.MonthlyReturnMonths, 0)
+	snapshots, err := uc.snapshotRepo.GetRange(ctx, from, now)
+	if err != nil {
+		logrus.Warnf("Failed to get portfolio snapshots for monthly returns: %v", err)
+		return domain.MonthlyReturnSummary{}, false
+	}
+
+	return domain.CalculateMonthlyReturns(snapshots, now, domain.MonthlyReturnMonths), true
+}
+
+// getCurrentPrices retrieves the latest closes of the holdings in one query, falling back to one
+// query per holding if the batch fails, so that only the holdings whose price cannot be read are left
+// unpriced. Returns the prices keyed by code and the holdings without a price.
+func (uc *PortfolioReportUseCase) getCurrentPrices(ctx context.Context, portfolio []*models.Portfolio) (map[string]float64, []*models.Portfolio, error) {
+	codes := make([]string, 0, len(portfolio))
+	for _, holding := range portfolio {
+		if !holding.IsCash() {
+			codes = append(codes, holding.Code)
+		}
+	}
+
+	prices, err := uc.stockRepo.GetLatestPrices(ctx, codes)
+	if err != nil {
+		logrus.Warnf("Failed to get current prices in one query, fetching them one by one: %v", err)
+		var fallbackErr error
+		prices, fallbackErr = uc.getLatestPricesByCode(ctx, codes)
+		if fallbackErr != nil {
+			return nil, nil, fmt.Errorf("failed to get current prices: %w", err)
+		}
+	}
+
+	currentPrices := make(map[string]float64, len(prices))
+	var missing []*models.Portfolio
+	for _, holding := range portfolio {
+		if holding.IsCash() {
+			continue // valued at its balance by the portfolio summary
+		}
+		price, ok := prices[holding.Code]
+		if !ok {
+			missing = append(missing, holding)
+			continue
+		}
+		currentPrices[holding.Code] = utility.DecimalToFloat(price.ClosePrice)
+	}
+
+	return currentPrices, missing, nil
+}
+
+// getLatestPricesByCode reads the latest price of each code concurrently, leaving out the codes
+// whose price cannot be read. Fails only if no price can be read at all.
+func (uc *PortfolioReportUseCase) getLatestPricesByCode(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+	var mu sync.Mutex
+	prices := make(map[string]*models.StockPrice, len(codes))
+	failed := runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, code string) error {
+		price, err := uc.stockRepo.GetLatestPrice(ctx, code)
+		if err != nil {
+			return err
+		}
+		if price != nil {
+			mu.Lock()
+			prices[code] = price
+			mu.Unlock()
+		}
+		return nil
+	})
+	for code, err := range failed {
+		logrus.Warnf("Failed to get current price of %s: %v", code, err)
+	}
+	if len(codes) > 0 && len(failed) == len(codes) {
+		return nil, fmt.Errorf("no current price could be read")
+	}
+	return prices, nil
+}
+
+// logMissingPrices logs the holdings left out of a report for lack of a price.
+func logMissingPrices(missing []*models.Portfolio) {
+	for _, holding := range missing {
+		logrus.Warnf("No price found for %s", holding.Code)
+	}
+}
+
+// SendMonthlyReport generates and sends the monthly report via notification.
+func (uc *PortfolioReportUseCase) SendMonthlyReport(ctx context.Context) error {
+	report, err := uc.GenerateMonthlyReport(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate monthly report: %w", err)
+	}
+
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, report); err != nil {
+		return fmt.Errorf("failed to send monthly report: %w", err)
+	}
+
+	logrus.Info("Monthly report sent successfully")
+	return nil
 }
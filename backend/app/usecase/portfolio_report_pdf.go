@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/htmlreport"
+	"github.com/boost-jp/stock-automation/app/infrastructure/pdf"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// pdfValueHistoryDays is the period of the value chart in the daily PDF report.
+// The monthly report charts the months of its monthly return calendar.
+const pdfValueHistoryDays = 90
+
+// WriteReportPDF writes the daily report, or the monthly report if monthly is set, as a PDF file with
+// the summary, the holdings table and charts of the gains by holding and the portfolio value history.
+// The monthly report adds a chart of the monthly returns and the year-to-date return.
+// Returns the title of the report.
+func (uc *PortfolioReportUseCase) WriteReportPDF(ctx context.Context, w io.Writer, monthly bool) (string, error) {
+	report, holdings, err := uc.buildReport(ctx, time.Now(), monthly)
+	if err != nil {
+		return "", err
+	}
+
+	if err := pdf.RenderReport(w, report); err != nil {
+		return "", err
+	}
+
+	logrus.Infof("PDF report generated: %s, %d holdings", report.Title, holdings)
+	return report.Title, nil
+}
+
+// WriteReportHTML writes the daily report with the same content as the PDF report as an HTML page.
+// Returns the title of the report.
+func (uc *PortfolioReportUseCase) WriteReportHTML(ctx context.Context, w io.Writer) (string, error) {
+	report, _, err := uc.buildReport(ctx, time.Now(), false)
+	if err != nil {
+		return "", err
+	}
+
+	if err := htmlreport.Render(w, report); err != nil {
+		return "", err
+	}
+	return report.Title, nil
+}
+
+// buildReport builds the sections of the daily or monthly report as of now.
+// Returns the report and the number of holdings in it.
+func (uc *PortfolioReportUseCase) buildReport(ctx context.Context, now time.Time, monthly bool) (pdf.Report, int, error) {
+	report := pdf.Report{
+		Title:    i18n.T("pdf_report.daily_title"),
+		Subtitle: i18n.T("report.generated_at", now.Format("2006-01-02 15:04:05")),
+	}
+	if monthly {
+		report.Title = i18n.T("pdf_report.monthly_title", now.Year(), int(now.Month()))
+	}
+
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return pdf.Report{}, 0, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	if len(portfolio) == 0 {
+		report.Sections = []pdf.Section{{
+			Heading: i18n.T("pdf_report.summary"),
+			Lines:   []string{i18n.T("portfolio.empty")},
+		}}
+		return report, 0, nil
+	}
+
+	currentPrices, missing, err := uc.getCurrentPrices(ctx, portfolio)
+	if err != nil {
+		return pdf.Report{}, 0, err
+	}
+	logMissingPrices(missing)
+
+	// The holdings and allocations follow the report preferences like the daily report
+	summary := domain.CalculatePortfolioSummary(portfolio, currentPrices)
+	uc.reportPreferences(ctx).Apply(summary)
+	report.Sections = append(report.Sections, pdfSummarySection(summary, missing))
+	if !summary.HideHoldings {
+		report.Sections = append(report.Sections, pdfHoldingsSection(summary))
+		if len(summary.Holdings) > 0 {
+			report.Sections = append(report.Sections, pdfGainSection(summary))
+		}
+	}
+
+	from := now.AddDate(0, 0, -pdfValueHistoryDays)
+	labelFormat := "1/2"
+	if monthly {
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -(domain.MonthlyReturnMonths - 1), 0)
+		labelFormat = "2006/1/2"
+	}
+	if section, ok := uc.pdfValueHistorySection(ctx, from, now, labelFormat); ok {
+		report.Sections = append(report.Sections, section)
+	}
+
+	if monthly {
+		if returns, ok := uc.monthlyReturns(ctx, now); ok && len(returns.Months) > 0 {
+			report.Sections = append(report.Sections, pdfMonthlyReturnSection(returns))
+		}
+	}
+
+	return report, len(summary.Holdings), nil
+}
+
+// pdfSummarySection returns the totals of the portfolio and the holdings left out for lack of a price.
+func pdfSummarySection(summary *domain.PortfolioSummary, missing []*models.Portfolio) pdf.Section {
+	lines := []string{
+		i18n.T("portfolio.total_value", domain.FormatCurrency(summary.TotalValue)),
+		i18n.T("portfolio.total_cost", domain.FormatCurrency(summary.TotalCost)),
+		i18n.T("pdf_report.total_gain", domain.FormatCurrency(summary.TotalGain), summary.TotalGainPercent),
+		i18n.T("pdf_report.holdings_count", len(summary.Holdings)),
+	}
+	if len(summary.Allocations) > 1 {
+		lines = append(lines, "", i18n.T("portfolio.allocation_section"))
+		lines = append(lines, domain.FormatAssetAllocations(summary.Allocations)...)
+	}
+	for _, holding := range missing {
+		lines = append(lines, i18n.T("report.price_error_item", holding.Name, holding.Code))
+	}
+	return pdf.Section{Heading: i18n.T("pdf_report.summary"), Lines: lines}
+}
+
+// pdfHoldingsSection returns the table of the holdings.
+func pdfHoldingsSection(summary *domain.PortfolioSummary) pdf.Section {
+	table := &pdf.Table{
+		Headers: []string{
+			i18n.T("pdf_report.column.code"),
+			i18n.T("pdf_report.column.name"),
+			i18n.T("pdf_report.column.shares"),
+			i18n.T("pdf_report.column.price"),
+			i18n.T("pdf_report.column.value"),
+			i18n.T("pdf_report.column.gain"),
+			i18n.T("pdf_report.column.gain_percent"),
+		},
+		Widths:     []float64{0.8, 2.4, 0.9, 1.1, 1.4, 1.3, 0.9},
+		AlignRight: []bool{false, false, true, true, true, true, true},
+	}
+	for _, holding := range summary.Holdings {
+		name := holding.Name
+		if holding.PositionType == models.PositionTypeShort {
+			name = i18n.T("pdf_report.short", name)
+		}
+		quantity := domain.FormatCurrency(float64(holding.Shares))
+		if holding.AssetClass == models.AssetClassCrypto {
+			quantity = holding.FormatQuantity()
+		}
+		table.Rows = append(table.Rows, []string{
+			holding.Code,
+			name,
+			quantity,
+			domain.FormatCurrency(holding.CurrentPrice),
+			domain.FormatCurrency(holding.CurrentValue),
+			domain.FormatCurrency(holding.Gain),
+			fmt.Sprintf("%+.2f%%", holding.GainPercent),
+		})
+	}
+	return pdf.Section{Heading: i18n.T("pdf_report.holdings"), Table: table}
+}
+
+// pdfGainSection returns the bar chart of the gains by holding.
+func pdfGainSection(summary *domain.PortfolioSummary) pdf.Section {
+	chart := &pdf.Chart{Kind: pdf.BarChart, Format: domain.FormatCurrency}
+	for _, holding := range summary.Holdings {
+		chart.Labels = append(chart.Labels, holding.Code)
+		chart.Values = append(chart.Values, holding.Gain)
+	}
+	return pdf.Section{Heading: i18n.T("pdf_report.gain_chart"), Chart: chart}
+}
+
+// pdfValueHistorySection returns the line chart of the portfolio value from the daily snapshots.
+// The chart is left out of the report if the snapshots cannot be read.
+func (uc *PortfolioReportUseCase) pdfValueHistorySection(ctx context.Context, from, to time.Time, labelFormat string) (pdf.Section, bool) {
+	if uc.snapshotRepo == nil {
+		return pdf.Section{}, false
+	}
+
+	snapshots, err := uc.snapshotRepo.GetRange(ctx, from, to)
+	if err != nil {
+		logrus.Warnf("Failed to get portfolio snapshots for the value chart: %v", err)
+		return pdf.Section{}, false
+	}
+	if len(snapshots) == 0 {
+		return pdf.Section{}, false
+	}
+
+	chart := &pdf.Chart{Kind: pdf.LineChart, Format: domain.FormatCurrency}
+	for _, snapshot := range snapshots {
+		chart.Labels = append(chart.Labels, snapshot.SnapshotDate.Format(labelFormat))
+		chart.Values = append(chart.Values, snapshot.TotalValue)
+	}
+	return pdf.Section{Heading: i18n.T("pdf_report.value_chart"), Chart: chart}, true
+}
+
+// pdfMonthlyReturnSection returns the bar chart of the monthly returns with the year-to-date return.
+func pdfMonthlyReturnSection(returns domain.MonthlyReturnSummary) pdf.Section {
+	section := pdf.Section{Heading: i18n.T("pdf_report.monthly_return_chart")}
+	if returns.HasYTD {
+		section.Lines = []string{i18n.T("pdf_report.ytd", returns.AsOf.Year(), returns.YTDReturn)}
+	}
+
+	chart := &pdf.Chart{
+		Kind:   pdf.BarChart,
+		Format: func(v float64) string { return fmt.Sprintf("%+.1f", v) },
+	}
+	for _, month := range returns.Months {
+		chart.Labels = append(chart.Labels, fmt.Sprintf("%d/%d", month.Year, int(month.Month)))
+		chart.Values = append(chart.Values, month.Return)
+	}
+	section.Chart = chart
+	return section
+}
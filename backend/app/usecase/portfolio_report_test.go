@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
+)
+
+// newBatchPriceStockRepository serves latest prices by batch, without previous prices, and fails single price queries.
+func newBatchPriceStockRepository(prices map[string]*models.StockPrice) *mock.StockRepositoryMock {
+	return &mock.StockRepositoryMock{
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			latest := make(map[string]*models.StockPrice)
+			for _, code := range codes {
+				if price, ok := prices[code]; ok {
+					latest[code] = price
+				}
+			}
+			return latest, nil
+		},
+		GetLatestPriceFunc: func(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+			return nil, fmt.Errorf("prices should be fetched by batch")
+		},
+		GetPreviousPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			return map[string]*models.StockPrice{}, nil
+		},
+	}
+}
+
+// newHoldingsRepository returns fixed holdings.
+func newHoldingsRepository(holdings []*models.Portfolio) *mock.PortfolioRepositoryMock {
+	return &mock.PortfolioRepositoryMock{
+		GetAllFunc: func(ctx context.Context) ([]*models.Portfolio, error) {
+			return holdings, nil
+		},
+	}
+}
+
+func TestPortfolioReportUseCase_GenerateComprehensiveDailyReport(t *testing.T) {
+	prices := make(map[string]*models.StockPrice)
+	var holdings []*models.Portfolio
+	for i := 0; i < 100; i++ {
+		code := fmt.Sprintf("%d", 1000+i)
+		holdings = append(holdings, &models.Portfolio{
+			Code:          code,
+			Name:          "Stock " + code,
+			Shares:        100,
+			PurchasePrice: utility.FloatToDecimal(1000),
+			PurchaseDate:  time.Date(2024, 1, 4, 0, 0, 0, 0, time.Local),
+			PositionType:  models.PositionTypeLong,
+		})
+		// The last holding has no price
+		if i < 99 {
+			prices[code] = &models.StockPrice{Code: code, ClosePrice: utility.FloatToDecimal(1100)}
+		}
+	}
+
+	stockRepo := newBatchPriceStockRepository(prices)
+	uc := NewPortfolioReportUseCase(stockRepo, newHoldingsRepository(holdings), nil, nil, nil, nil)
+	report, err := uc.GenerateComprehensiveDailyReport(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateComprehensiveDailyReport() error = %v", err)
+	}
+
+	if queries := len(stockRepo.GetLatestPricesCalls()) + len(stockRepo.GetPreviousPricesCalls()); queries != 2 {
+		t.Errorf("fetched prices %d times, want 2", queries)
+	}
+	if !strings.Contains(report, i18n.T("report.price_error_item", "Stock 1099", "1099")) {
+		t.Errorf("report should list the holding without a price:\n%s", report)
+	}
+	if strings.Contains(report, i18n.T("report.price_error_item", "Stock 1098", "1098")) {
+		t.Errorf("report should not list holdings with a price as errors:\n%s", report)
+	}
+}
+
+func TestPortfolioReportUseCase_GenerateComprehensiveDailyReport_BatchFailure(t *testing.T) {
+	var holdings []*models.Portfolio
+	for _, code := range []string{"7203", "6758", "9984"} {
+		holdings = append(holdings, &models.Portfolio{
+			Code:          code,
+			Name:          "Stock " + code,
+			Shares:        100,
+			PurchasePrice: utility.FloatToDecimal(1000),
+			PurchaseDate:  time.Date(2024, 1, 4, 0, 0, 0, 0, time.Local),
+			PositionType:  models.PositionTypeLong,
+		})
+	}
+
+	stockRepo := &mock.StockRepositoryMock{
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			return nil, errors.New("query timed out")
+		},
+		GetLatestPriceFunc: func(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+			if stockCode == "6758" {
+				return nil, errors.New("connection lost")
+			}
+			return &models.StockPrice{Code: stockCode, ClosePrice: utility.FloatToDecimal(1100)}, nil
+		},
+		GetPreviousPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			return map[string]*models.StockPrice{}, nil
+		},
+	}
+	uc := NewPortfolioReportUseCase(stockRepo, newHoldingsRepository(holdings), nil, nil, nil, nil)
+	report, err := uc.GenerateComprehensiveDailyReport(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateComprehensiveDailyReport() error = %v, want the report without the failed holding", err)
+	}
+
+	if calls := len(stockRepo.GetLatestPriceCalls()); calls != 3 {
+		t.Errorf("fetched %d prices one by one, want 3", calls)
+	}
+	if !strings.Contains(report, i18n.T("report.price_error_item", "Stock 6758", "6758")) {
+		t.Errorf("report should list the holding whose price failed:\n%s", report)
+	}
+	if strings.Contains(report, i18n.T("report.price_error_item", "Stock 7203", "7203")) {
+		t.Errorf("report should not list holdings priced one by one as errors:\n%s", report)
+	}
+
+	// The report fails when no price can be read at all
+	stockRepo.GetLatestPriceFunc = func(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+		return nil, errors.New("connection lost")
+	}
+	if _, err := uc.GenerateComprehensiveDailyReport(context.Background()); err == nil {
+		t.Error("GenerateComprehensiveDailyReport() should fail without any price")
+	}
+}
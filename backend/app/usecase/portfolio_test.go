@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPortfolioUseCase_RestoreHolding(t *testing.T) {
+	removedAt := time.Date(2024, 6, 10, 9, 0, 0, 0, time.Local)
+	deleted := []*models.Portfolio{
+		{ID: "p3", Code: "7203", Name: "Toyota", Shares: 200, DeletedAt: null.TimeFrom(removedAt)},
+		{ID: "p2", Code: "6758", Name: "Sony", Shares: 100, DeletedAt: null.TimeFrom(removedAt.AddDate(0, 0, -1))},
+		{ID: "p1", Code: "7203", Name: "Toyota", Shares: 100, DeletedAt: null.TimeFrom(removedAt.AddDate(0, 0, -2))},
+	}
+	active := map[string]*models.Portfolio{"6758": {ID: "p4", Code: "6758", Name: "Sony", Shares: 300}}
+
+	var restored []string
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetByCodeFunc: func(ctx context.Context, code string) (*models.Portfolio, error) {
+			return active[code], nil
+		},
+		GetDeletedFunc: func(ctx context.Context) ([]*models.Portfolio, error) {
+			return deleted, nil
+		},
+		RestoreFunc: func(ctx context.Context, id string) error {
+			restored = append(restored, id)
+			return nil
+		},
+	}
+	txManager := &mock.TransactionManagerMock{
+		WithTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+	var restoredLots []time.Time
+	lotRepo := &mock.PortfolioLotRepositoryMock{
+		RestoreByCodeFunc: func(ctx context.Context, code string, since time.Time) error {
+			restoredLots = append(restoredLots, since)
+			return nil
+		},
+	}
+	uc := NewPortfolioUseCase(portfolioRepo, lotRepo, domain.NewPortfolioService(), txManager, domain.CostMethodFIFO)
+
+	// The most recently removed holding of the code is restored with the lots removed with it
+	holding, err := uc.RestoreHolding(context.Background(), "7203")
+	if err != nil {
+		t.Fatalf("RestoreHolding(7203) error = %v", err)
+	}
+	if holding.ID != "p3" || holding.DeletedAt.Valid {
+		t.Errorf("RestoreHolding(7203) = %s (deleted_at valid: %v), want p3 not deleted", holding.ID, holding.DeletedAt.Valid)
+	}
+
+	// A stock held again cannot be restored
+	if _, err := uc.RestoreHolding(context.Background(), "6758"); err == nil {
+		t.Error("RestoreHolding(6758) error = nil, want error as the stock is held")
+	}
+	// Nothing to restore
+	if _, err := uc.RestoreHolding(context.Background(), "9984"); err == nil {
+		t.Error("RestoreHolding(9984) error = nil, want error")
+	}
+
+	if diff := cmp.Diff([]string{"p3"}, restored); diff != "" {
+		t.Errorf("restored mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]time.Time{removedAt}, restoredLots); diff != "" {
+		t.Errorf("lots restored since mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPortfolioUseCase_RemoveHolding(t *testing.T) {
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetByCodeFunc: func(ctx context.Context, code string) (*models.Portfolio, error) {
+			return &models.Portfolio{ID: "p1", Code: code, Name: "Toyota", Shares: 200}, nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) error { return nil },
+	}
+	lotRepo := &mock.PortfolioLotRepositoryMock{
+		DeleteByCodeFunc: func(ctx context.Context, code string) error { return nil },
+	}
+	txManager := &mock.TransactionManagerMock{
+		WithTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+	uc := NewPortfolioUseCase(portfolioRepo, lotRepo, domain.NewPortfolioService(), txManager, domain.CostMethodFIFO)
+
+	if err := uc.RemoveHolding(context.Background(), "7203"); err != nil {
+		t.Fatalf("RemoveHolding(7203) error = %v", err)
+	}
+
+	// The holding and its lots are soft-deleted, so that they can be restored; nothing is purged
+	if len(portfolioRepo.DeleteCalls()) != 1 || len(portfolioRepo.PurgeCalls()) != 0 {
+		t.Errorf("holding deleted %d times, purged %d times, want soft-deleted once",
+			len(portfolioRepo.DeleteCalls()), len(portfolioRepo.PurgeCalls()))
+	}
+	if calls := lotRepo.DeleteByCodeCalls(); len(calls) != 1 || calls[0].Code != "7203" || len(lotRepo.DeleteCalls()) != 0 {
+		t.Errorf("lots soft-deleted %+v, deleted %d, want the lots of 7203 soft-deleted", calls, len(lotRepo.DeleteCalls()))
+	}
+}
+
+func TestPortfolioUseCase_Sell_AverageInSteps(t *testing.T) {
+	day1 := time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)
+	holding := &models.Portfolio{ID: "p1", Code: "7203", Name: "Toyota", Shares: 200, PurchaseDate: day1}
+	lots := map[string]*models.PortfolioLot{
+		"lot1": {ID: "lot1", Code: "7203", Shares: 100, PurchasePrice: utility.FloatToDecimal(1000), PurchaseDate: day1},
+		"lot2": {ID: "lot2", Code: "7203", Shares: 100, PurchasePrice: utility.FloatToDecimal(2000), PurchaseDate: day1.AddDate(0, 2, 0)},
+	}
+
+	portfolioRepo := &mock.PortfolioRepositoryMock{
+		GetByCodeFunc: func(ctx context.Context, code string) (*models.Portfolio, error) {
+			return holding, nil
+		},
+		UpdateFunc: func(ctx context.Context, p *models.Portfolio) error { return nil },
+		PurgeFunc: func(ctx context.Context, id string) error {
+			holding = nil
+			return nil
+		},
+	}
+	lotRepo := &mock.PortfolioLotRepositoryMock{
+		GetByCodeFunc: func(ctx context.Context, code string) ([]*models.PortfolioLot, error) {
+			var result []*models.PortfolioLot
+			for _, id := range []string{"lot1", "lot2"} {
+				if lot, ok := lots[id]; ok {
+					copied := *lot
+					result = append(result, &copied)
+				}
+			}
+			return result, nil
+		},
+		UpdateFunc: func(ctx context.Context, lot *models.PortfolioLot) error {
+			lots[lot.ID] = lot
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) error {
+			delete(lots, id)
+			return nil
+		},
+	}
+	txManager := &mock.TransactionManagerMock{
+		WithTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+	uc := NewPortfolioUseCase(portfolioRepo, lotRepo, domain.NewPortfolioService(), txManager, domain.CostMethodAverage)
+
+	// The second sale charges the same average as the first, since the remaining lot is saved with it
+	var costBasis []float64
+	for _, shares := range []int{100, 100} {
+		result, err := uc.Sell(context.Background(), SellInput{Code: "7203", Shares: shares, SellPrice: 1800})
+		if err != nil {
+			t.Fatalf("Sell(%d) error = %v", shares, err)
+		}
+		costBasis = append(costBasis, result.CostBasis)
+		if lot, ok := lots["lot2"]; ok && lot.GetPurchasePrice() != 2000 {
+			t.Errorf("lot2 purchase price = %v after Sell(%d), want it kept", lot.GetPurchasePrice(), shares)
+		}
+	}
+
+	if diff := cmp.Diff([]float64{150000, 150000}, costBasis); diff != "" {
+		t.Errorf("CostBasis mismatch (-want +got):\n%s", diff)
+	}
+	if len(lots) != 0 || holding != nil {
+		t.Errorf("lots = %v, holding = %v after selling all shares, want both removed", lots, holding)
+	}
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// recentAggregationDays is the period re-aggregated by the scheduled job,
+// covering the current and previous month and the weeks in them.
+const recentAggregationDays = 35
+
+// PriceAggregationUseCase aggregates daily prices into weekly and monthly bars.
+// The bars are kept after old daily prices are cleaned up, for long-term moving averages and monthly RSI.
+type PriceAggregationUseCase struct {
+	stockRepo      repository.StockRepository
+	portfolioRepo  repository.PortfolioRepository
+	aggregatedRepo repository.AggregatedPriceRepository
+	maxWorkers     int
+	now            func() time.Time
+}
+
+// NewPriceAggregationUseCase creates a new price aggregation use case.
+func NewPriceAggregationUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	aggregatedRepo repository.AggregatedPriceRepository,
+) *PriceAggregationUseCase {
+	return &PriceAggregationUseCase{
+		stockRepo:      stockRepo,
+		portfolioRepo:  portfolioRepo,
+		aggregatedRepo: aggregatedRepo,
+		maxWorkers:     4,
+		now:            time.Now,
+	}
+}
+
+// AggregateRecent re-aggregates the recent weekly and monthly bars of the watch list and portfolio stocks.
+func (uc *PriceAggregationUseCase) AggregateRecent(ctx context.Context) error {
+	_, err := uc.Aggregate(ctx, nil, recentAggregationDays)
+	return err
+}
+
+// Aggregate aggregates the daily prices of the last days into weekly and monthly bars of the stocks,
+// or of the watch list and portfolio stocks if codes is empty. The aggregation starts from the beginning
+// of the month days ago so that no bar is built from part of its days. Returns the number of bars saved.
+func (uc *PriceAggregationUseCase) Aggregate(ctx context.Context, codes []string, days int) (int, error) {
+	if len(codes) == 0 {
+		var err error
+		codes, err = uc.targetCodes(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	now := uc.now()
+	from := models.PricePeriodMonthly.Start(now.AddDate(0, 0, -days))
+	historyDays := int(math.Ceil(now.Sub(from).Hours() / 24))
+
+	var saved atomic.Int64
+	errs := runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, code string) error {
+		prices, err := uc.stockRepo.GetPriceHistory(ctx, code, historyDays)
+		if err != nil {
+			return fmt.Errorf("failed to get price history: %w", err)
+		}
+
+		for _, period := range []models.PricePeriod{models.PricePeriodWeekly, models.PricePeriodMonthly} {
+			bars := domain.AggregatePrices(prices, period)
+			// The week containing the first day of the month started before the history
+			for len(bars) > 0 && bars[0].PeriodStart.Before(from) {
+				bars = bars[1:]
+			}
+			if err := uc.aggregatedRepo.SaveAggregatedPrices(ctx, period, bars); err != nil {
+				return fmt.Errorf("failed to save %s prices: %w", period, err)
+			}
+			saved.Add(int64(len(bars)))
+		}
+		return nil
+	})
+
+	for code, err := range errs {
+		logrus.Errorf("Failed to aggregate prices for %s: %v", code, err)
+	}
+	if len(errs) > 0 {
+		return int(saved.Load()), fmt.Errorf("failed to aggregate prices for %d of %d stocks", len(errs), len(codes))
+	}
+
+	logrus.Infof("Aggregated %d weekly and monthly bars for %d stocks", saved.Load(), len(codes))
+	return int(saved.Load()), nil
+}
+
+// targetCodes returns the codes of the active watch list and portfolio stocks.
+func (uc *PriceAggregationUseCase) targetCodes(ctx context.Context) ([]string, error) {
+	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+	portfolio, err := uc.portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+
+	unique := make(map[string]bool)
+	for _, item := range watchList {
+		unique[item.Code] = true
+	}
+	for _, holding := range portfolio {
+		if !holding.IsCash() {
+			unique[holding.Code] = true
+		}
+	}
+
+	codes := make([]string, 0, len(unique))
+	for code := range unique {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// PriceChartUseCase builds the close price charts of stocks for the terminal and chat notifications.
+type PriceChartUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	notifier      notification.NotificationService
+	analysisSvc   *domain.TechnicalAnalysisService
+}
+
+// NewPriceChartUseCase creates a new price chart use case.
+func NewPriceChartUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	notifier notification.NotificationService,
+) *PriceChartUseCase {
+	return &PriceChartUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		notifier:      notifier,
+		analysisSvc:   domain.NewTechnicalAnalysisService(),
+	}
+}
+
+// Chart returns the chart of the split-adjusted closes of the stock over the last days.
+// The name is taken from the watch list or the portfolio, and is empty for other stocks.
+func (uc *PriceChartUseCase) Chart(ctx context.Context, code string, days int) (*domain.PriceChart, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("期間の日数は1以上である必要があります: %d", days)
+	}
+
+	prices, err := uc.stockRepo.GetPriceHistory(ctx, code, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history for %s: %w", code, err)
+	}
+
+	names, err := watchedStockNames(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get stock names: %v", err)
+	}
+	return domain.NewPriceChart(code, names[code], uc.analysisSvc.ConvertStockPrices(prices)), nil
+}
+
+// SendChart sends the chart to the notification channel, with the ASCII chart in a code block.
+func (uc *PriceChartUseCase) SendChart(ctx context.Context, chart *domain.PriceChart, height, width int) error {
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindGeneral, domain.FormatPriceChartMessage(chart, height, width)); err != nil {
+		return fmt.Errorf("failed to send price chart: %w", err)
+	}
+	return nil
+}
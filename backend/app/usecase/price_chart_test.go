@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPriceChartUseCase(t *testing.T) {
+	day := time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+	stockRepo := &mock.StockRepositoryMock{
+		GetPriceHistoryFunc: func(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+			return closePrices(stockCode, day, 1000, 1050, 1100), nil
+		},
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{{Code: "7203", Name: "トヨタ自動車"}}, nil
+		},
+	}
+	notifier := &fakeAlertNotifier{}
+	uc := NewPriceChartUseCase(stockRepo, newHoldingsRepository(nil), notifier)
+
+	chart, err := uc.Chart(context.Background(), "7203", 60)
+	if err != nil {
+		t.Fatalf("Chart() error = %v", err)
+	}
+	if chart.Name != "トヨタ自動車" {
+		t.Errorf("Name = %q, want トヨタ自動車", chart.Name)
+	}
+	if diff := cmp.Diff([]float64{1000, 1050, 1100}, chart.Closes); diff != "" {
+		t.Errorf("Closes mismatch (-want +got):\n%s", diff)
+	}
+	if calls := stockRepo.GetPriceHistoryCalls(); len(calls) != 1 || calls[0].Days != 60 {
+		t.Errorf("GetPriceHistory() calls = %+v, want 60 days", calls)
+	}
+
+	if err := uc.SendChart(context.Background(), chart, 5, 60); err != nil {
+		t.Fatalf("SendChart() error = %v", err)
+	}
+	if len(notifier.messages) != 1 || !strings.Contains(notifier.messages[0], "```\n1100.00 |") {
+		t.Errorf("messages = %q, want the chart in a code block", notifier.messages)
+	}
+
+	if _, err := uc.Chart(context.Background(), "7203", 0); err == nil {
+		t.Error("Chart() should fail for zero days")
+	}
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// priceMoveNotifyTimeout bounds the notification of the price moves of a collection run.
+const priceMoveNotifyTimeout = time.Minute
+
+// priceMoveState is the move level of a stock last notified, on the trading day of its price.
+type priceMoveState struct {
+	date  string
+	level int
+}
+
+// PriceMoveNotificationUseCase sends one summary of the stocks that moved by the threshold or more
+// from the previous close after each price collection run, instead of a notification per stock.
+type PriceMoveNotificationUseCase struct {
+	stockRepo        repository.StockRepository
+	portfolioRepo    repository.PortfolioRepository
+	notifier         notification.NotificationService
+	thresholdPercent float64
+
+	// notified records the level of the moves notified, so that a stock is notified again on the
+	// same day only when it moves further
+	mu       sync.Mutex
+	notified map[string]priceMoveState
+}
+
+// NewPriceMoveNotificationUseCase creates a new price move notification use case.
+func NewPriceMoveNotificationUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	notifier notification.NotificationService,
+	thresholdPercent float64,
+) *PriceMoveNotificationUseCase {
+	return &PriceMoveNotificationUseCase{
+		stockRepo:        stockRepo,
+		portfolioRepo:    portfolioRepo,
+		notifier:         notifier,
+		thresholdPercent: thresholdPercent,
+		notified:         make(map[string]priceMoveState),
+	}
+}
+
+// HandlePriceUpdated is the event bus handler of the price update events. The moves are notified
+// on a goroutine of their own so that the collection job does not wait for the notification.
+// Silent collection runs are not notified.
+func (uc *PriceMoveNotificationUseCase) HandlePriceUpdated(ctx context.Context, event domain.Event) error {
+	updated, ok := event.(domain.PriceUpdatedEvent)
+	if !ok || updated.Silent || len(updated.Updated) == 0 {
+		return nil
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), priceMoveNotifyTimeout)
+		defer cancel()
+		if err := uc.NotifyMoves(ctx, updated.Updated); err != nil {
+			logrus.Errorf("Failed to notify price moves: %v", err)
+		}
+	}()
+	return nil
+}
+
+// NotifyMoves sends the summary of the given stocks whose latest price moved by the threshold or
+// more from the previous close, leaving out the stocks already notified at that level on the day.
+// Stocks whose alerts are muted in the watch list are left out. Nothing is sent if no stock moved.
+func (uc *PriceMoveNotificationUseCase) NotifyMoves(ctx context.Context, codes []string) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	muted, err := loadMutedStockCodes(ctx, uc.stockRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get muted stocks: %v", err)
+	}
+
+	latest, err := uc.stockRepo.GetLatestPrices(ctx, codes)
+	if err != nil {
+		return fmt.Errorf("failed to get latest prices: %w", err)
+	}
+	previous, err := uc.stockRepo.GetPreviousPrices(ctx, codes)
+	if err != nil {
+		return fmt.Errorf("failed to get previous prices: %w", err)
+	}
+
+	var moves []domain.PriceMove
+	levels := make(map[string]priceMoveState)
+	for _, code := range codes {
+		price, ok := latest[code]
+		if !ok || muted[code] {
+			continue
+		}
+		previousPrice, ok := previous[code]
+		if !ok {
+			continue
+		}
+		previousClose := utility.DecimalToFloat(previousPrice.ClosePrice)
+		if previousClose <= 0 {
+			continue
+		}
+
+		move := domain.NewPriceMove(code, "", previousClose, utility.DecimalToFloat(price.ClosePrice))
+		date := price.Date.Format("2006-01-02")
+		level := domain.PriceMoveLevel(move.ChangePercent, uc.thresholdPercent)
+		notified := uc.notified[code]
+		if notified.date != date {
+			notified = priceMoveState{date: date}
+		}
+		if !domain.IsNewPriceMoveLevel(level, notified.level) {
+			continue
+		}
+		moves = append(moves, move)
+		levels[code] = priceMoveState{date: date, level: level}
+	}
+	if len(moves) == 0 {
+		return nil
+	}
+
+	names, err := watchedStockNames(ctx, uc.stockRepo, uc.portfolioRepo)
+	if err != nil {
+		logrus.Warnf("Failed to get stock names: %v", err)
+	}
+	for i := range moves {
+		moves[i].Name = names[moves[i].Code]
+	}
+
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, domain.FormatPriceMoveSummary(moves, uc.thresholdPercent)); err != nil {
+		return fmt.Errorf("failed to send price moves: %w", err)
+	}
+	for code, state := range levels {
+		uc.notified[code] = state
+	}
+	logrus.Infof("Notified price moves of %d stocks", len(moves))
+	return nil
+}
+
+// watchedStockNames returns the names of the watched and held stocks by code.
+func watchedStockNames(ctx context.Context, stockRepo repository.StockRepository, portfolioRepo repository.PortfolioRepository) (map[string]string, error) {
+	names := make(map[string]string)
+
+	watchList, err := stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return names, fmt.Errorf("failed to get watch list: %w", err)
+	}
+	for _, item := range watchList {
+		names[item.Code] = item.Name
+	}
+
+	portfolio, err := portfolioRepo.GetAll(ctx)
+	if err != nil {
+		return names, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+	for _, holding := range portfolio {
+		names[holding.Code] = holding.Name
+	}
+	return names, nil
+}
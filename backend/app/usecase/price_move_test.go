@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPriceMoveNotificationUseCase_NotifyMoves(t *testing.T) {
+	day := time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+	closes := map[string]float64{"7203": 3100, "6758": 12100, "9984": 7200}
+	previous := map[string]float64{"7203": 3000, "6758": 12000, "9984": 8000}
+
+	stockRepo := &mock.StockRepositoryMock{
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			prices := make(map[string]*models.StockPrice)
+			for code, price := range closes {
+				prices[code] = &models.StockPrice{Code: code, Date: day, ClosePrice: utility.FloatToDecimal(price)}
+			}
+			return prices, nil
+		},
+		GetPreviousPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			prices := make(map[string]*models.StockPrice)
+			for code, price := range previous {
+				prices[code] = &models.StockPrice{Code: code, Date: day.AddDate(0, 0, -3), ClosePrice: utility.FloatToDecimal(price)}
+			}
+			return prices, nil
+		},
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{{Code: "7203", Name: "トヨタ自動車"}}, nil
+		},
+	}
+	notifier := &fakeAlertNotifier{}
+	uc := NewPriceMoveNotificationUseCase(stockRepo, newHoldingsRepository(nil), notifier, 3)
+	codes := []string{"7203", "6758", "9984"}
+
+	// 7203 +3.33% and 9984 -10% are notified, 6758 +0.83% is not
+	if err := uc.NotifyMoves(context.Background(), codes); err != nil {
+		t.Fatalf("NotifyMoves() error = %v", err)
+	}
+	// Nothing new: no notification
+	if err := uc.NotifyMoves(context.Background(), codes); err != nil {
+		t.Fatalf("NotifyMoves() error = %v", err)
+	}
+	// 7203 moves further to +6.67%
+	closes["7203"] = 3200
+	if err := uc.NotifyMoves(context.Background(), codes); err != nil {
+		t.Fatalf("NotifyMoves() error = %v", err)
+	}
+
+	want := []string{
+		"📊 前回終値から±3%以上動いた銘柄 (2銘柄)\n" +
+			"- 9984 ¥8000.00 → ¥7200.00 (-10.00%)\n" +
+			"- 7203 トヨタ自動車 ¥3000.00 → ¥3100.00 (+3.33%)",
+		"📊 前回終値から±3%以上動いた銘柄 (1銘柄)\n" +
+			"- 7203 トヨタ自動車 ¥3000.00 → ¥3200.00 (+6.67%)",
+	}
+	if diff := cmp.Diff(want, notifier.messages); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPriceMoveNotificationUseCase_NotifyMovesMuted(t *testing.T) {
+	day := time.Date(2024, 6, 10, 0, 0, 0, 0, time.Local)
+	closes := map[string]float64{"7203": 3100, "9984": 7200}
+	previous := map[string]float64{"7203": 3000, "9984": 8000}
+
+	stockRepo := &mock.StockRepositoryMock{
+		GetLatestPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			prices := make(map[string]*models.StockPrice)
+			for code, price := range closes {
+				prices[code] = &models.StockPrice{Code: code, Date: day, ClosePrice: utility.FloatToDecimal(price)}
+			}
+			return prices, nil
+		},
+		GetPreviousPricesFunc: func(ctx context.Context, codes []string) (map[string]*models.StockPrice, error) {
+			prices := make(map[string]*models.StockPrice)
+			for code, price := range previous {
+				prices[code] = &models.StockPrice{Code: code, Date: day.AddDate(0, 0, -3), ClosePrice: utility.FloatToDecimal(price)}
+			}
+			return prices, nil
+		},
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return []*models.WatchList{
+				{Code: "7203", Name: "トヨタ自動車", MutedUntil: null.TimeFrom(time.Now().Add(time.Hour))},
+				{Code: "9984", Name: "ソフトバンクグループ", MutedUntil: null.TimeFrom(time.Now().Add(-time.Hour))},
+			}, nil
+		},
+	}
+	notifier := &fakeAlertNotifier{}
+	uc := NewPriceMoveNotificationUseCase(stockRepo, newHoldingsRepository(nil), notifier, 3)
+
+	// 7203 is muted, the mute of 9984 has expired
+	if err := uc.NotifyMoves(context.Background(), []string{"7203", "9984"}); err != nil {
+		t.Fatalf("NotifyMoves() error = %v", err)
+	}
+
+	want := []string{
+		"📊 前回終値から±3%以上動いた銘柄 (1銘柄)\n" +
+			"- 9984 ソフトバンクグループ ¥8000.00 → ¥7200.00 (-10.00%)",
+	}
+	if diff := cmp.Diff(want, notifier.messages); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// PriceReconciliationResult is the reconciliation of the stored daily prices with the data source.
+type PriceReconciliationResult struct {
+	Period          models.PricePeriod           `json:"period"`
+	From            time.Time                    `json:"from"`
+	To              time.Time                    `json:"to"` // exclusive, today's prices are still changing
+	Reconciliations []domain.PriceReconciliation `json:"reconciliations"`
+	Failed          map[string]error             `json:"-"`
+}
+
+// Discrepancies returns the reconciliations of the periods whose checksums do not match.
+func (r *PriceReconciliationResult) Discrepancies() []domain.PriceReconciliation {
+	var discrepancies []domain.PriceReconciliation
+	for _, reconciliation := range r.Reconciliations {
+		if reconciliation.Status != domain.ReconcileMatched {
+			discrepancies = append(discrepancies, reconciliation)
+		}
+	}
+	return discrepancies
+}
+
+// PriceReconciliationUseCase verifies the stored daily prices after an archive, restore or backfill by
+// comparing the record count and close sum of each stock and period with the data source.
+type PriceReconciliationUseCase struct {
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	stockClient   client.StockDataClient
+	priority      domain.PriceSourcePriority
+	maxWorkers    int
+	now           func() time.Time
+}
+
+// NewPriceReconciliationUseCase creates a new price reconciliation use case.
+func NewPriceReconciliationUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	stockClient client.StockDataClient,
+	priority domain.PriceSourcePriority,
+) *PriceReconciliationUseCase {
+	return &PriceReconciliationUseCase{
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		stockClient:   stockClient,
+		priority:      priority,
+		maxWorkers:    5, // Limit concurrent API calls
+		now:           time.Now,
+	}
+}
+
+// Reconcile compares the checksums of the stored prices of the last days with those of the data source
+// for each period, for the stocks or the watched and held stocks if codes is empty. The comparison starts
+// from the beginning of the period days ago so that no period is compared on part of its days, and ends
+// before today. The stocks whose prices could not be read are returned in Failed.
+func (uc *PriceReconciliationUseCase) Reconcile(
+	ctx context.Context,
+	codes []string,
+	days int,
+	period models.PricePeriod,
+	tolerancePercent float64,
+) (*PriceReconciliationResult, error) {
+	if len(codes) == 0 {
+		var err error
+		codes, err = collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := uc.now()
+	result := &PriceReconciliationResult{
+		Period: period,
+		From:   period.Start(now.AddDate(0, 0, -days)),
+		To:     models.TruncateToDate(now),
+	}
+
+	var mu sync.Mutex
+	result.Failed = runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, code string) error {
+		reconciliations, err := uc.reconcileStock(ctx, code, result.From, result.To, period, tolerancePercent)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		result.Reconciliations = append(result.Reconciliations, reconciliations...)
+		mu.Unlock()
+		return nil
+	})
+
+	for code, err := range result.Failed {
+		logrus.Errorf("Failed to reconcile prices for %s: %v", code, err)
+	}
+	sort.SliceStable(result.Reconciliations, func(i, j int) bool {
+		a, b := result.Reconciliations[i], result.Reconciliations[j]
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		return a.PeriodStart.Before(b.PeriodStart)
+	})
+
+	logrus.Infof("Reconciled prices of %d stocks: %d of %d periods differ from the source",
+		len(codes)-len(result.Failed), len(result.Discrepancies()), len(result.Reconciliations))
+	return result, nil
+}
+
+// reconcileStock compares the stored and source prices of a stock from from until before to.
+func (uc *PriceReconciliationUseCase) reconcileStock(
+	ctx context.Context,
+	code string,
+	from, to time.Time,
+	period models.PricePeriod,
+	tolerancePercent float64,
+) ([]domain.PriceReconciliation, error) {
+	last := to.AddDate(0, 0, -1)
+	stored, err := uc.stockRepo.GetPriceHistoryRange(ctx, code, from, last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored price history: %w", err)
+	}
+
+	source, err := uc.stockClient.GetHistoricalDataRange(ctx, code, from, last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get historical data: %w", err)
+	}
+
+	return domain.ReconcilePriceChecksums(
+		domain.CalculatePriceChecksums(stored, period),
+		domain.CalculatePriceChecksums(uc.priority.Resolve(source), period),
+		tolerancePercent,
+	), nil
+}
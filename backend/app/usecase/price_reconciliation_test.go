@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+// pricesFromTo returns the prices dated from the day of from to the day of to.
+func pricesFromTo(prices []*models.StockPrice, from, to time.Time) []*models.StockPrice {
+	var inRange []*models.StockPrice
+	for _, price := range prices {
+		if date := models.TruncateToDate(price.Date); !date.Before(from) && !date.After(to) {
+			inRange = append(inRange, price)
+		}
+	}
+	return inRange
+}
+
+// fakeSourceClient serves the historical data of each stock.
+type fakeSourceClient struct {
+	client.StockDataClient
+	prices map[string][]*models.StockPrice
+}
+
+func (f *fakeSourceClient) GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+	prices, ok := f.prices[stockCode]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return pricesFromTo(prices, from, to), nil
+}
+
+func TestPriceReconciliationUseCase_Reconcile(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.Local)
+	price := func(code string, month time.Month, day int, close float64, source string) *models.StockPrice {
+		return &models.StockPrice{
+			Code:       code,
+			Date:       time.Date(2024, month, day, 0, 0, 0, 0, time.Local),
+			ClosePrice: utility.FloatToDecimal(close),
+			Source:     source,
+		}
+	}
+
+	stored := map[string][]*models.StockPrice{
+		"7203": {
+			price("7203", 1, 31, 900, client.SourceYahoo), // before the first period
+			price("7203", 2, 1, 1000, client.SourceYahoo),
+			price("7203", 2, 2, 1010, client.SourceYahoo),
+			price("7203", 3, 1, 1100, client.SourceYahoo),
+			price("7203", 3, 15, 1120, client.SourceYahoo), // today
+		},
+	}
+	source := &fakeSourceClient{prices: map[string][]*models.StockPrice{
+		"7203": {
+			price("7203", 2, 1, 1000, client.SourceYahoo),
+			price("7203", 2, 2, 1010, client.SourceStooq),
+			price("7203", 2, 2, 1010, client.SourceYahoo), // the same day from another source
+			price("7203", 3, 1, 1100, client.SourceYahoo),
+			price("7203", 3, 4, 1105, client.SourceYahoo), // missing after a restore
+			price("7203", 3, 15, 1130, client.SourceYahoo),
+		},
+	}}
+	stockRepo := &mock.StockRepositoryMock{
+		GetPriceHistoryRangeFunc: func(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
+			return pricesFromTo(stored[stockCode], from, to), nil
+		},
+	}
+	useCase := NewPriceReconciliationUseCase(stockRepo, nil, source, nil)
+	useCase.now = func() time.Time { return now }
+
+	result, err := useCase.Reconcile(context.Background(), []string{"7203", "9999"}, 30, models.PricePeriodMonthly, 0)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if !result.From.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)) || !result.To.Equal(models.TruncateToDate(now)) {
+		t.Errorf("Reconcile() period = %v - %v", result.From, result.To)
+	}
+	type summary struct {
+		Status      string
+		DB, Source  int
+		DBSum, Diff float64
+	}
+	var got []summary
+	for _, r := range result.Reconciliations {
+		got = append(got, summary{r.Status, r.Stored.Count, r.Source.Count, r.Stored.CloseSum, r.Source.CloseSum - r.Stored.CloseSum})
+	}
+	want := []summary{
+		{Status: domain.ReconcileMatched, DB: 2, Source: 2, DBSum: 2010},
+		{Status: domain.ReconcileMismatched, DB: 1, Source: 2, DBSum: 1100, Diff: 1105},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Reconcile() mismatch (-want +got):\n%s", diff)
+	}
+	if len(result.Discrepancies()) != 1 {
+		t.Errorf("Discrepancies() = %d, want 1", len(result.Discrepancies()))
+	}
+	if _, ok := result.Failed["9999"]; !ok || len(result.Failed) != 1 {
+		t.Errorf("Failed = %v, want 9999", result.Failed)
+	}
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// RawResponseReplay is the result of parsing a kept response again.
+type RawResponseReplay struct {
+	Response *models.RawResponse
+	Prices   []*models.StockPrice // prices parsed from the body, nil if it still fails
+	Err      error                // error parsing the body, nil if it is parsed now
+}
+
+// RawResponseUseCase inspects the data source responses kept because they could not be parsed.
+type RawResponseUseCase struct {
+	rawRepo repository.RawResponseRepository
+}
+
+// NewRawResponseUseCase creates a new raw response use case.
+func NewRawResponseUseCase(rawRepo repository.RawResponseRepository) *RawResponseUseCase {
+	return &RawResponseUseCase{rawRepo: rawRepo}
+}
+
+// List returns the latest kept responses, newest first.
+func (uc *RawResponseUseCase) List(ctx context.Context, limit int) ([]*models.RawResponse, error) {
+	responses, err := uc.rawRepo.GetLatest(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw responses: %w", err)
+	}
+	return responses, nil
+}
+
+// Replay parses the body of a kept response again with the current parser of its data source.
+// A body that still fails to parse is not an error of the replay but returned in the result.
+func (uc *RawResponseUseCase) Replay(ctx context.Context, id int64) (*RawResponseReplay, error) {
+	response, err := uc.rawRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw response: %w", err)
+	}
+	if response == nil {
+		return nil, fmt.Errorf("レスポンス %d が見つかりません", id)
+	}
+	if response.Source != client.SourceYahoo {
+		return nil, fmt.Errorf("%s のレスポンスの再パースには対応していません", response.Source)
+	}
+
+	replay := &RawResponseReplay{Response: response}
+	replay.Prices, replay.Err = client.ParseYahooResponse(response.Endpoint, response.Code, response.Body)
+	return replay, nil
+}
+
+// Prune deletes the responses kept more than the given days ago and returns the number deleted.
+func (uc *RawResponseUseCase) Prune(ctx context.Context, days int) (int64, error) {
+	if days < 0 {
+		return 0, fmt.Errorf("日数は0以上で指定してください: %d", days)
+	}
+
+	deleted, err := uc.rawRepo.DeleteBefore(ctx, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete raw responses: %w", err)
+	}
+
+	logrus.Infof("Raw responses older than %d days deleted: %d", days, deleted)
+	return deleted, nil
+}
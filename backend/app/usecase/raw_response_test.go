@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+)
+
+func TestRawResponseUseCase_Replay(t *testing.T) {
+	responses := map[int64]*models.RawResponse{
+		1: {ID: 1, Source: client.SourceYahoo, Endpoint: client.YahooEndpointCurrent, Code: "7203",
+			Body: []byte(`{"chart": {"result": [{"meta": {"symbol": "7203.T", "regularMarketPrice": 2500}}], "error": null}}`)},
+		2: {ID: 2, Source: client.SourceYahoo, Endpoint: client.YahooEndpointCurrent, Code: "7203",
+			Body: []byte(`<html>Service Unavailable</html>`)},
+		3: {ID: 3, Source: client.SourceStooq, Endpoint: "daily", Code: "7203", CreatedAt: time.Now()},
+	}
+	repo := &mock.RawResponseRepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id int64) (*models.RawResponse, error) {
+			return responses[id], nil
+		},
+	}
+	uc := NewRawResponseUseCase(repo)
+
+	// A body the parser handles now returns its prices
+	replay, err := uc.Replay(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Replay(1) error = %v", err)
+	}
+	if replay.Err != nil || len(replay.Prices) != 1 || replay.Prices[0].ClosePrice.String() != "2500" {
+		t.Errorf("Replay(1) = %+v, %v, want the current price 2500", replay.Prices, replay.Err)
+	}
+
+	// A body that still fails is reported in the result, not as an error of the replay
+	replay, err = uc.Replay(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Replay(2) error = %v", err)
+	}
+	if replay.Err == nil {
+		t.Error("Replay(2) parsed a body that is not JSON")
+	}
+
+	if _, err := uc.Replay(context.Background(), 3); err == nil {
+		t.Error("Replay(3) error = nil for an unsupported source")
+	}
+	if _, err := uc.Replay(context.Background(), 4); err == nil {
+		t.Error("Replay(4) error = nil for a missing response")
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+)
+
+// ReportSectionState is whether a section of the daily report is shown and whether it was set explicitly.
+type ReportSectionState struct {
+	Section domain.ReportSection `json:"section"`
+	Enabled bool                 `json:"enabled"`
+	Default bool                 `json:"default"`
+}
+
+// ReportPreferenceUseCase manages the settings turning the sections of the daily report on or off.
+type ReportPreferenceUseCase struct {
+	repo repository.ReportPreferenceRepository
+}
+
+// NewReportPreferenceUseCase creates a new report preference use case.
+func NewReportPreferenceUseCase(repo repository.ReportPreferenceRepository) *ReportPreferenceUseCase {
+	return &ReportPreferenceUseCase{repo: repo}
+}
+
+// States returns the state of every section in the order of the report.
+func (uc *ReportPreferenceUseCase) States(ctx context.Context) ([]ReportSectionState, error) {
+	preferences, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report preferences: %w", err)
+	}
+	prefs := domain.NewReportPreferences(preferences)
+
+	states := make([]ReportSectionState, 0, len(domain.ReportSections))
+	for _, section := range domain.ReportSections {
+		_, set := prefs[section]
+		states = append(states, ReportSectionState{
+			Section: section,
+			Enabled: prefs.Enabled(section),
+			Default: !set,
+		})
+	}
+	return states, nil
+}
+
+// SetEnabled turns a section of the daily report on or off.
+func (uc *ReportPreferenceUseCase) SetEnabled(ctx context.Context, name string, enabled bool) error {
+	section, err := domain.ParseReportSection(name)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.Upsert(ctx, &models.ReportPreference{Section: string(section), Enabled: enabled}); err != nil {
+		return fmt.Errorf("failed to save report preference: %w", err)
+	}
+	return nil
+}
+
+// Reset removes all settings so that every section is shown again, returning the number removed.
+func (uc *ReportPreferenceUseCase) Reset(ctx context.Context) (int64, error) {
+	removed, err := uc.repo.DeleteAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset report preferences: %w", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// retentionDeleteBatchSize is the number of rows deleted at once by the delete action.
+const retentionDeleteBatchSize = 10000
+
+// RetentionResult is the result of applying a retention rule.
+type RetentionResult struct {
+	Rule        domain.RetentionRule
+	Cutoff      time.Time // rows before it are past the retention period
+	Rows        int64     // rows deleted, or to be deleted in a dry run
+	ArchivePath string    // file the rows were archived to, empty if none
+	Err         error
+}
+
+// pendingWriteFlusher writes the buffered price writes, so that old prices still in the buffer are cleaned up too.
+type pendingWriteFlusher interface {
+	Flush(ctx context.Context) error
+}
+
+// RetentionUseCase cleans up the data past the retention periods declared by the retention policy.
+type RetentionUseCase struct {
+	retentionRepo repository.RetentionRepository
+	txManager     repository.TransactionManager
+	policy        *domain.RetentionPolicy
+	writeBuffer   pendingWriteFlusher
+}
+
+// NewRetentionUseCase creates a new retention use case.
+func NewRetentionUseCase(
+	retentionRepo repository.RetentionRepository,
+	txManager repository.TransactionManager,
+	policy *domain.RetentionPolicy,
+) *RetentionUseCase {
+	return &RetentionUseCase{
+		retentionRepo: retentionRepo,
+		txManager:     txManager,
+		policy:        policy,
+	}
+}
+
+// SetWriteBuffer sets the buffer of price writes flushed before the cleanup.
+func (uc *RetentionUseCase) SetWriteBuffer(buffer pendingWriteFlusher) {
+	uc.writeBuffer = buffer
+}
+
+// Policy returns the retention policy applied.
+func (uc *RetentionUseCase) Policy() *domain.RetentionPolicy {
+	return uc.policy
+}
+
+// Apply deletes or archives the rows past the retention period of each rule. A dry run only counts
+// them. A failing rule does not stop the others; the results are returned with an error counting the failures.
+func (uc *RetentionUseCase) Apply(ctx context.Context, dryRun bool) ([]*RetentionResult, error) {
+	if !dryRun && uc.writeBuffer != nil {
+		if err := uc.writeBuffer.Flush(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush price writes: %w", err)
+		}
+	}
+
+	now := time.Now()
+	results := make([]*RetentionResult, 0, len(uc.policy.Rules))
+	failed := 0
+	for _, rule := range uc.policy.Rules {
+		result := &RetentionResult{Rule: rule, Cutoff: rule.Keep.Cutoff(now)}
+		results = append(results, result)
+
+		switch {
+		case dryRun:
+			result.Rows, result.Err = uc.retentionRepo.CountBefore(ctx, rule.Target, result.Cutoff)
+		case rule.Action == domain.RetentionArchive:
+			result.Rows, result.ArchivePath, result.Err = uc.archive(ctx, rule.Target, result.Cutoff, now)
+		default:
+			result.Rows, result.Err = uc.retentionRepo.DeleteBefore(ctx, rule.Target, result.Cutoff, retentionDeleteBatchSize)
+		}
+
+		if result.Err != nil {
+			failed++
+			logrus.Errorf("Failed to apply the retention policy of %s: %v", rule.Target.Name, result.Err)
+			continue
+		}
+		if !dryRun {
+			logrus.Infof("Retention policy of %s applied (%s): %d rows before %s",
+				rule.Target.Name, rule.Action, result.Rows, result.Cutoff.Format("2006-01-02"))
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("failed to apply %d of %d retention rules", failed, len(results))
+	}
+	return results, nil
+}
+
+// archive writes the rows of the target before the cutoff to a gzipped JSON Lines file of the archive
+// directory and deletes them in the same transaction. The export locks the rows it reads, and the
+// transaction is rolled back if the deletion does not match the export, so that no row is deleted
+// without being archived.
+func (uc *RetentionUseCase) archive(ctx context.Context, target domain.RetentionTarget, cutoff, now time.Time) (int64, string, error) {
+	if err := os.MkdirAll(uc.policy.ArchiveDir, 0o755); err != nil {
+		return 0, "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	path := filepath.Join(uc.policy.ArchiveDir,
+		fmt.Sprintf("%s_before_%s_%s.jsonl.gz", target.Name, cutoff.Format("20060102"), now.Format("20060102150405")))
+
+	var archived int64
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		exported, err := uc.exportFile(ctx, target, cutoff, path)
+		if err != nil || exported == 0 {
+			return err
+		}
+
+		deleted, err := uc.retentionRepo.DeleteBefore(ctx, target, cutoff, 0)
+		if err != nil {
+			return fmt.Errorf("failed to delete archived rows: %w", err)
+		}
+		if deleted != exported {
+			return fmt.Errorf("archived %d rows of %s but %d would be deleted", exported, target.Name, deleted)
+		}
+		archived = deleted
+		return nil
+	})
+	if err != nil {
+		_ = os.Remove(path)
+		return 0, "", err
+	}
+	if archived == 0 {
+		_ = os.Remove(path)
+		return 0, "", nil
+	}
+	return archived, path, nil
+}
+
+// exportFile writes the rows of the target before the cutoff to a new gzipped file and returns the number written.
+func (uc *RetentionUseCase) exportFile(ctx context.Context, target domain.RetentionTarget, cutoff time.Time, path string) (int64, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	exported, err := uc.retentionRepo.ExportBefore(ctx, target, cutoff, gz)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export rows: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write archive file: %w", err)
+	}
+	// The rows are deleted once the archive is on disk
+	if err := file.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to write archive file: %w", err)
+	}
+	return exported, nil
+}
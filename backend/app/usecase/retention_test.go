@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+)
+
+func TestRetentionUseCase_ArchiveMismatch(t *testing.T) {
+	target, _ := domain.GetRetentionTarget("stock_prices")
+	policy := &domain.RetentionPolicy{
+		ArchiveDir: t.TempDir(),
+		Rules:      []domain.RetentionRule{{Target: target, Keep: domain.RetentionPeriod{Days: 30}, Action: domain.RetentionArchive}},
+	}
+	rolledBack := false
+	txManager := &mock.TransactionManagerMock{
+		WithTxFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			rolledBack = err != nil
+			return err
+		},
+	}
+	// A row is written to the range between the export and the deletion
+	repo := &mock.RetentionRepositoryMock{
+		ExportBeforeFunc: func(ctx context.Context, target domain.RetentionTarget, before time.Time, w io.Writer) (int64, error) {
+			_, err := io.WriteString(w, "{}\n{}\n")
+			return 2, err
+		},
+		DeleteBeforeFunc: func(ctx context.Context, target domain.RetentionTarget, before time.Time, batchSize int) (int64, error) {
+			return 3, nil
+		},
+	}
+	uc := NewRetentionUseCase(repo, txManager, policy)
+
+	results, err := uc.Apply(context.Background(), false)
+	if err == nil || results[0].Err == nil {
+		t.Fatalf("Apply() error = %v, want the mismatch reported", err)
+	}
+	if !rolledBack {
+		t.Error("Transaction committed, want it rolled back so that no row is deleted without being archived")
+	}
+	if results[0].Rows != 0 || results[0].ArchivePath != "" {
+		t.Errorf("Apply() = %d rows archived to %q, want none", results[0].Rows, results[0].ArchivePath)
+	}
+	if files, _ := os.ReadDir(policy.ArchiveDir); len(files) != 0 {
+		t.Errorf("Archive directory has %d files, want the incomplete archive removed", len(files))
+	}
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+)
+
+// SavingsPlanUseCase projects savings plans of monthly contributions and compares them with the
+// actual portfolio, read from the daily portfolio snapshots.
+type SavingsPlanUseCase struct {
+	snapshotRepo repository.PortfolioSnapshotRepository
+	now          func() time.Time
+}
+
+// NewSavingsPlanUseCase creates a new savings plan use case.
+func NewSavingsPlanUseCase(snapshotRepo repository.PortfolioSnapshotRepository) *SavingsPlanUseCase {
+	return &SavingsPlanUseCase{
+		snapshotRepo: snapshotRepo,
+		now:          time.Now,
+	}
+}
+
+// Simulate returns the planned value of the plan at the end of each of the years.
+func (uc *SavingsPlanUseCase) Simulate(plan domain.SavingsPlan, years int) ([]domain.PlanProjection, error) {
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	if plan.Initial == 0 && plan.Monthly == 0 {
+		return nil, fmt.Errorf("初期資産か毎月の積立額を指定してください")
+	}
+	if years <= 0 || years > domain.MaxSavingsPlanYears {
+		return nil, fmt.Errorf("期間は1〜%d年で指定してください: %d", domain.MaxSavingsPlanYears, years)
+	}
+	return plan.Project(years), nil
+}
+
+// Compare compares the portfolio at the end of each month since the day of since with the plan
+// started from the first snapshot on or after it. All snapshots are compared if since is zero.
+func (uc *SavingsPlanUseCase) Compare(ctx context.Context, plan domain.SavingsPlan, since time.Time) ([]domain.PlanComparison, error) {
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := uc.snapshotRepo.GetRange(ctx, since, models.TruncateToDate(uc.now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio snapshots: %w", err)
+	}
+	comparisons := domain.ComparePlan(plan, snapshots, since)
+	if len(comparisons) == 0 {
+		return nil, fmt.Errorf("比較するポートフォリオのスナップショットがありません (portfolio snapshot で記録できます)")
+	}
+	return comparisons, nil
+}
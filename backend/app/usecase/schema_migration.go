@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBackfillBatchSize is the number of rows backfilled per transaction.
+const DefaultBackfillBatchSize = 1000
+
+// SchemaMigrationStatus is a registered schema migration with its phase and backfill progress.
+type SchemaMigrationStatus struct {
+	Definition domain.SchemaMigrationDefinition
+	Migration  *models.SchemaMigration // phase pending if the migration has not been started
+}
+
+// BackfillOptions configures a backfill run.
+type BackfillOptions struct {
+	BatchSize int           // rows per transaction, DefaultBackfillBatchSize if zero
+	Sleep     time.Duration // pause between batches to leave room for the application's queries
+}
+
+// SchemaMigrationUseCase moves schema migrations through their phases without stopping the
+// application: writing both schemas, backfilling existing rows, switching reads to the new
+// schema and finally stopping writes to the old one.
+type SchemaMigrationUseCase struct {
+	migrationRepo repository.SchemaMigrationRepository
+	txManager     repository.TransactionManager
+	phases        *repository.SchemaMigrationPhases
+	now           func() time.Time
+	sleep         func(ctx context.Context, d time.Duration) error
+}
+
+// NewSchemaMigrationUseCase creates a new schema migration use case.
+func NewSchemaMigrationUseCase(
+	migrationRepo repository.SchemaMigrationRepository,
+	txManager repository.TransactionManager,
+	phases *repository.SchemaMigrationPhases,
+) *SchemaMigrationUseCase {
+	return &SchemaMigrationUseCase{
+		migrationRepo: migrationRepo,
+		txManager:     txManager,
+		phases:        phases,
+		now:           time.Now,
+		sleep:         sleepContext,
+	}
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// List returns all registered schema migrations with their phases.
+func (uc *SchemaMigrationUseCase) List(ctx context.Context) ([]SchemaMigrationStatus, error) {
+	migrations, err := uc.migrationRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema migrations: %w", err)
+	}
+	byName := make(map[string]*models.SchemaMigration, len(migrations))
+	for _, migration := range migrations {
+		byName[migration.Name] = migration
+	}
+
+	var statuses []SchemaMigrationStatus
+	for _, def := range domain.SchemaMigrationDefinitions() {
+		migration, ok := byName[def.Name]
+		if !ok {
+			migration = &models.SchemaMigration{Name: def.Name, Phase: models.SchemaMigrationPending}
+		}
+		statuses = append(statuses, SchemaMigrationStatus{Definition: def, Migration: migration})
+	}
+	return statuses, nil
+}
+
+// Advance moves a schema migration to its next phase.
+// Starting a migration begins dual-writing, and the backfill must finish before reads switch to the new schema.
+func (uc *SchemaMigrationUseCase) Advance(ctx context.Context, name string) (*models.SchemaMigration, error) {
+	def, migration, err := uc.load(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var remaining int64
+	if migration.Phase == models.SchemaMigrationBackfilling {
+		if remaining, err = uc.migrationRepo.CountPending(ctx, def); err != nil {
+			return nil, fmt.Errorf("failed to count rows to backfill: %w", err)
+		}
+	}
+	next, err := domain.NextSchemaMigrationPhase(migration, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	switch next {
+	case models.SchemaMigrationDualWrite:
+		migration.StartedAt = null.TimeFrom(now)
+	case models.SchemaMigrationCompleted:
+		migration.CompletedAt = null.TimeFrom(now)
+	}
+	return uc.moveTo(ctx, migration, next)
+}
+
+// Rollback moves a schema migration back to its previous phase.
+// Stopping dual-writes resets the backfill progress, as rows written meanwhile have to be backfilled again.
+func (uc *SchemaMigrationUseCase) Rollback(ctx context.Context, name string) (*models.SchemaMigration, error) {
+	_, migration, err := uc.load(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := domain.PreviousSchemaMigrationPhase(migration)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous == models.SchemaMigrationPending {
+		migration.TotalRows = 0
+		migration.BackfilledRows = 0
+		migration.LastKey = ""
+		migration.StartedAt = null.Time{}
+		migration.BackfillStartedAt = null.Time{}
+		migration.BackfilledAt = null.Time{}
+	}
+	return uc.moveTo(ctx, migration, previous)
+}
+
+// Backfill copies the rows written before dual-writing started to the new schema, one batch per
+// transaction. The progress is saved with each batch, so a backfill stopped by ctx or an error
+// resumes where it left off the next time.
+func (uc *SchemaMigrationUseCase) Backfill(ctx context.Context, name string, opts BackfillOptions) (*models.SchemaMigration, error) {
+	def, migration, err := uc.load(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch migration.Phase {
+	case models.SchemaMigrationDualWrite:
+		// Rows written from now on already have the new schema, so the count does not grow.
+		total, err := uc.migrationRepo.CountPending(ctx, def)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count rows to backfill: %w", err)
+		}
+		migration.TotalRows = total
+		migration.BackfillStartedAt = null.TimeFrom(uc.now())
+		if migration, err = uc.moveTo(ctx, migration, models.SchemaMigrationBackfilling); err != nil {
+			return nil, err
+		}
+	case models.SchemaMigrationBackfilling:
+		if migration.BackfilledAt.Valid {
+			return migration, nil
+		}
+	default:
+		return nil, fmt.Errorf("マイグレーション %s はバックフィルできるフェーズではありません (%s)", name, migration.Phase)
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBackfillBatchSize
+	}
+
+	logrus.Infof("Backfilling schema migration %s from key %q (%d/%d rows)",
+		name, migration.LastKey, migration.BackfilledRows, migration.TotalRows)
+	restarted := false
+	for {
+		if err := ctx.Err(); err != nil {
+			return migration, err
+		}
+
+		var done bool
+		err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+			lastKey, updated, err := uc.migrationRepo.BackfillBatch(ctx, def, migration.LastKey, batchSize)
+			if err != nil {
+				return err
+			}
+			if lastKey == "" {
+				// Rows before the last key may have become pending again, e.g. after a rollback,
+				// so the table is walked once more from the start before finishing.
+				if migration.LastKey == "" || restarted {
+					migration.BackfilledAt = null.TimeFrom(uc.now())
+					done = true
+				} else {
+					restarted = true
+				}
+				migration.LastKey = ""
+			} else {
+				migration.LastKey = lastKey
+				migration.BackfilledRows += updated
+			}
+			return uc.migrationRepo.Save(ctx, migration)
+		})
+		if err != nil {
+			return migration, fmt.Errorf("failed to backfill schema migration %s: %w", name, err)
+		}
+		if done {
+			logrus.Infof("Backfill of schema migration %s completed (%d rows)", name, migration.BackfilledRows)
+			return migration, nil
+		}
+
+		logrus.Debugf("Backfilled schema migration %s up to key %q (%.1f%%)", name, migration.LastKey, migration.BackfillPercent())
+		if err := uc.sleep(ctx, opts.Sleep); err != nil {
+			return migration, err
+		}
+	}
+}
+
+// load returns the definition and the current state of a registered schema migration.
+func (uc *SchemaMigrationUseCase) load(ctx context.Context, name string) (domain.SchemaMigrationDefinition, *models.SchemaMigration, error) {
+	def, err := domain.GetSchemaMigrationDefinition(name)
+	if err != nil {
+		return domain.SchemaMigrationDefinition{}, nil, err
+	}
+	migration, err := uc.migrationRepo.Get(ctx, name)
+	if err != nil {
+		return domain.SchemaMigrationDefinition{}, nil, fmt.Errorf("failed to get schema migration %s: %w", name, err)
+	}
+	if migration == nil {
+		migration = &models.SchemaMigration{Name: name, Phase: models.SchemaMigrationPending}
+	}
+	return def, migration, nil
+}
+
+// moveTo saves a schema migration in the given phase.
+func (uc *SchemaMigrationUseCase) moveTo(ctx context.Context, migration *models.SchemaMigration, phase models.SchemaMigrationPhase) (*models.SchemaMigration, error) {
+	from := migration.Phase
+	migration.Phase = phase
+	if err := uc.migrationRepo.Save(ctx, migration); err != nil {
+		return nil, fmt.Errorf("failed to save schema migration %s: %w", migration.Name, err)
+	}
+	uc.phases.Invalidate(migration.Name)
+	logrus.Infof("Schema migration %s moved from %s to %s", migration.Name, from, phase)
+	return migration, nil
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/notification"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// ScoringUseCase handles composite scoring of watched stocks.
+type ScoringUseCase struct {
+	stockRepo        repository.StockRepository
+	fundamentalRepo  repository.StockFundamentalRepository
+	technicalUseCase *TechnicalAnalysisUseCase
+	notifier         notification.NotificationService
+	scoringService   *domain.ScoringService
+}
+
+// NewScoringUseCase creates a new scoring use case.
+func NewScoringUseCase(
+	stockRepo repository.StockRepository,
+	fundamentalRepo repository.StockFundamentalRepository,
+	technicalUseCase *TechnicalAnalysisUseCase,
+	notifier notification.NotificationService,
+	weights domain.ScoringWeights,
+) *ScoringUseCase {
+	if err := weights.Validate(); err != nil {
+		logrus.Warnf("Invalid scoring weights, using defaults: %v", err)
+		weights = domain.DefaultScoringWeights()
+	}
+
+	return &ScoringUseCase{
+		stockRepo:        stockRepo,
+		fundamentalRepo:  fundamentalRepo,
+		technicalUseCase: technicalUseCase,
+		notifier:         notifier,
+		scoringService:   domain.NewScoringService(weights),
+	}
+}
+
+// SetFundamentals registers the financial indicators of a stock.
+func (uc *ScoringUseCase) SetFundamentals(ctx context.Context, fundamental *models.StockFundamental) error {
+	if err := fundamental.Validate(); err != nil {
+		return err
+	}
+
+	if err := uc.fundamentalRepo.Upsert(ctx, fundamental); err != nil {
+		return fmt.Errorf("failed to save fundamentals: %w", err)
+	}
+
+	logrus.Infof("Fundamentals updated for %s", fundamental.Code)
+	return nil
+}
+
+// GenerateRanking scores all active watch list stocks and returns them ranked by total score.
+// Stocks without price data are skipped.
+func (uc *ScoringUseCase) GenerateRanking(ctx context.Context) ([]domain.StockScore, error) {
+	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+
+	scores := make([]domain.StockScore, 0, len(watchList))
+	for _, item := range watchList {
+		evaluation, err := uc.technicalUseCase.EvaluateSignal(ctx, item.Code)
+		if err != nil {
+			logrus.Errorf("Failed to evaluate %s: %v", item.Code, err)
+			continue
+		}
+		if evaluation.Signal == nil {
+			logrus.Debugf("No price data for %s, skipping score", item.Code)
+			continue
+		}
+
+		fundamental, err := uc.fundamentalRepo.GetByCode(ctx, item.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fundamentals for %s: %w", item.Code, err)
+		}
+
+		scores = append(scores, uc.scoringService.Score(item.Code, item.Name, evaluation.Signal, fundamental))
+	}
+
+	return uc.scoringService.RankScores(scores), nil
+}
+
+// SendWeeklyRanking generates the score ranking and sends it.
+func (uc *ScoringUseCase) SendWeeklyRanking(ctx context.Context) error {
+	ranked, err := uc.GenerateRanking(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate score ranking: %w", err)
+	}
+
+	if err := uc.notifier.SendMessageOfKind(ctx, notification.KindReport, uc.scoringService.GenerateRankingReport(ranked)); err != nil {
+		return fmt.Errorf("failed to send score ranking: %w", err)
+	}
+
+	logrus.Infof("Score ranking sent: %d stocks", len(ranked))
+	return nil
+}
+
+// GenerateRankingReport generates the formatted score ranking.
+func (uc *ScoringUseCase) GenerateRankingReport(ranked []domain.StockScore) string {
+	return uc.scoringService.GenerateRankingReport(ranked)
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// SeedOptions configures the synthetic data to generate.
+type SeedOptions struct {
+	Stocks int       // number of synthetic stocks
+	Years  int       // years of daily prices up to End
+	Seed   int64     // random seed; the same seed and End always give the same data
+	End    time.Time // last day of the prices
+}
+
+// SeedResult summarizes a synthetic data generation.
+type SeedResult struct {
+	Stocks       int
+	SavedRecords int
+	Failed       map[string]error
+}
+
+// SeedUseCase fills the database with synthetic prices for performance testing.
+type SeedUseCase struct {
+	stockRepo  repository.StockRepository
+	maxWorkers int
+}
+
+// NewSeedUseCase creates a new synthetic data use case.
+func NewSeedUseCase(stockRepo repository.StockRepository) *SeedUseCase {
+	return &SeedUseCase{
+		stockRepo:  stockRepo,
+		maxWorkers: 4, // Limit concurrent bulk inserts
+	}
+}
+
+// Seed generates random walk prices for the synthetic stocks SYN0001, SYN0002, ... and saves them.
+// Each stock has its own random source derived from the seed, so the data does not depend on
+// the order in which stocks are processed.
+func (uc *SeedUseCase) Seed(ctx context.Context, opts SeedOptions) (*SeedResult, error) {
+	if opts.Stocks <= 0 {
+		return nil, fmt.Errorf("stocks must be positive: %d", opts.Stocks)
+	}
+	if opts.Years <= 0 {
+		return nil, fmt.Errorf("years must be positive: %d", opts.Years)
+	}
+
+	start := opts.End.AddDate(-opts.Years, 0, 1)
+	codes := make([]string, opts.Stocks)
+	index := make(map[string]int, opts.Stocks)
+	for i := range codes {
+		codes[i] = domain.SyntheticStockCode(i)
+		index[codes[i]] = i
+	}
+
+	var (
+		mu    sync.Mutex
+		saved int
+	)
+	failed := runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, code string) error {
+		rng := rand.New(rand.NewSource(opts.Seed*1000003 + int64(index[code])))
+		params := domain.RandomSyntheticPriceParams(rng)
+		prices := domain.GenerateSyntheticPrices(rng, code, start, opts.End, params)
+
+		if err := uc.stockRepo.SaveStockPrices(ctx, prices); err != nil {
+			return fmt.Errorf("failed to save prices: %w", err)
+		}
+
+		mu.Lock()
+		saved += len(prices)
+		done := saved
+		mu.Unlock()
+		logrus.Debugf("Synthetic prices saved for %s: %d records (%d in total)", code, len(prices), done)
+		return nil
+	})
+
+	return &SeedResult{
+		Stocks:       opts.Stocks,
+		SavedRecords: saved,
+		Failed:       failed,
+	}, nil
+}
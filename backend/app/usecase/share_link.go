@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrShareLinkUnavailable is returned for a share token that is invalid, expired or revoked.
+// The cause is not told apart so that the page reveals nothing to a holder of a wrong token.
+var ErrShareLinkUnavailable = errors.New("share link is not available")
+
+// ShareLinkUseCase manages the links that show the daily report read-only on the web, so that it
+// can be shared with people without access to Slack or the CLI.
+type ShareLinkUseCase struct {
+	shareRepo repository.ShareLinkRepository
+	report    *PortfolioReportUseCase
+	secret    []byte
+	baseURL   string
+	now       func() time.Time
+}
+
+// NewShareLinkUseCase creates a new share link use case.
+// Tokens are signed with secret, and the links are built on baseURL, the address of the server.
+func NewShareLinkUseCase(shareRepo repository.ShareLinkRepository, report *PortfolioReportUseCase, secret, baseURL string) *ShareLinkUseCase {
+	return &ShareLinkUseCase{
+		shareRepo: shareRepo,
+		report:    report,
+		secret:    []byte(secret),
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		now:       time.Now,
+	}
+}
+
+// Create creates a link valid for the given days and returns it with its URL.
+func (uc *ShareLinkUseCase) Create(ctx context.Context, label string, days int) (*models.ShareLink, string, error) {
+	if len(uc.secret) == 0 {
+		return nil, "", fmt.Errorf("共有リンクを作成するにはSERVER_SHARE_SECRETを設定してください")
+	}
+	if days < 1 || days > domain.MaxShareLinkDays {
+		return nil, "", fmt.Errorf("有効期限は1〜%d日で指定してください: %d", domain.MaxShareLinkDays, days)
+	}
+
+	now := uc.now()
+	link := &models.ShareLink{
+		ID:        utility.NewULID(),
+		Label:     label,
+		ExpiresAt: now.AddDate(0, 0, days),
+		CreatedAt: now,
+	}
+	if err := uc.shareRepo.Create(ctx, link); err != nil {
+		return nil, "", fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	logrus.Infof("Share link %s created, expires at %s", link.ID, link.ExpiresAt.Format("2006-01-02 15:04"))
+	return link, uc.URL(link), nil
+}
+
+// URL returns the address of the report page of a link.
+func (uc *ShareLinkUseCase) URL(link *models.ShareLink) string {
+	return uc.baseURL + "/share/" + domain.SignShareToken(uc.secret, link.ID, link.ExpiresAt)
+}
+
+// List returns all links, newest first.
+func (uc *ShareLinkUseCase) List(ctx context.Context) ([]*models.ShareLink, error) {
+	links, err := uc.shareRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+	return links, nil
+}
+
+// Revoke stops a link from working before it expires.
+func (uc *ShareLinkUseCase) Revoke(ctx context.Context, id string) error {
+	revoked, err := uc.shareRepo.Revoke(ctx, id, uc.now())
+	if err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("共有リンク %s が見つからないか、既に失効しています", id)
+	}
+
+	logrus.Infof("Share link %s revoked", id)
+	return nil
+}
+
+// Authorize returns the link of a token if it can be used now.
+// Returns ErrShareLinkUnavailable if the token is not signed with the secret, has expired or is revoked.
+func (uc *ShareLinkUseCase) Authorize(ctx context.Context, token string) (*models.ShareLink, error) {
+	if len(uc.secret) == 0 {
+		return nil, ErrShareLinkUnavailable
+	}
+
+	now := uc.now()
+	id, err := domain.ParseShareToken(uc.secret, token, now)
+	if err != nil {
+		return nil, ErrShareLinkUnavailable
+	}
+
+	link, err := uc.shareRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	if link == nil || !link.IsActive(now) {
+		return nil, ErrShareLinkUnavailable
+	}
+	return link, nil
+}
+
+// WriteSharedReport writes the daily report page if the token can be used now.
+// Nothing is written on error.
+func (uc *ShareLinkUseCase) WriteSharedReport(ctx context.Context, w io.Writer, token string) error {
+	link, err := uc.Authorize(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if _, err := uc.report.WriteReportHTML(ctx, &buf); err != nil {
+		return fmt.Errorf("failed to write shared report: %w", err)
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return err
+	}
+
+	logrus.Infof("Shared report viewed through link %s", link.ID)
+	return nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+)
+
+func TestShareLinkUseCase_Authorize(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	links := map[string]*models.ShareLink{}
+	repo := &mock.ShareLinkRepositoryMock{
+		CreateFunc: func(ctx context.Context, link *models.ShareLink) error {
+			links[link.ID] = link
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*models.ShareLink, error) {
+			return links[id], nil
+		},
+		RevokeFunc: func(ctx context.Context, id string, at time.Time) (bool, error) {
+			link, ok := links[id]
+			if !ok || link.RevokedAt != nil {
+				return false, nil
+			}
+			link.RevokedAt = &at
+			return true, nil
+		},
+	}
+	uc := NewShareLinkUseCase(repo, nil, "secret", "https://example.com/")
+	uc.now = func() time.Time { return now }
+
+	link, url, err := uc.Create(ctx, "family", 7)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	token, ok := strings.CutPrefix(url, "https://example.com/share/")
+	if !ok {
+		t.Fatalf("Create() url = %s, want under https://example.com/share/", url)
+	}
+
+	if got, err := uc.Authorize(ctx, token); err != nil || got.ID != link.ID {
+		t.Errorf("Authorize() = %v, %v, want the created link", got, err)
+	}
+
+	// A token signed with another secret is rejected before looking up the link
+	other := NewShareLinkUseCase(repo, nil, "other", "https://example.com")
+	other.now = uc.now
+	if _, err := other.Authorize(ctx, token); !errors.Is(err, ErrShareLinkUnavailable) {
+		t.Errorf("Authorize() with another secret error = %v, want ErrShareLinkUnavailable", err)
+	}
+
+	// The link stops working once it expires
+	uc.now = func() time.Time { return now.AddDate(0, 0, 7) }
+	if _, err := uc.Authorize(ctx, token); !errors.Is(err, ErrShareLinkUnavailable) {
+		t.Errorf("Authorize() after expiry error = %v, want ErrShareLinkUnavailable", err)
+	}
+
+	// or once it is revoked
+	uc.now = func() time.Time { return now.Add(time.Hour) }
+	if err := uc.Revoke(ctx, link.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if _, err := uc.Authorize(ctx, token); !errors.Is(err, ErrShareLinkUnavailable) {
+		t.Errorf("Authorize() after revoking error = %v, want ErrShareLinkUnavailable", err)
+	}
+	if err := uc.Revoke(ctx, link.ID); err == nil {
+		t.Error("Revoke() error = nil for a revoked link")
+	}
+}
+
+func TestShareLinkUseCase_Create(t *testing.T) {
+	ctx := context.Background()
+	repo := &mock.ShareLinkRepositoryMock{
+		CreateFunc: func(ctx context.Context, link *models.ShareLink) error { return nil },
+	}
+
+	if _, _, err := NewShareLinkUseCase(repo, nil, "", "http://localhost:8080").Create(ctx, "", 7); err == nil {
+		t.Error("Create() error = nil without a secret")
+	}
+
+	uc := NewShareLinkUseCase(repo, nil, "secret", "http://localhost:8080")
+	for _, days := range []int{0, 366} {
+		if _, _, err := uc.Create(ctx, "", days); err == nil {
+			t.Errorf("Create(%d days) error = nil", days)
+		}
+	}
+	if calls := repo.CreateCalls(); len(calls) != 0 {
+		t.Errorf("Create() saved %d links on errors", len(calls))
+	}
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+)
+
+// StockInspection represents a one-shot view of a single stock.
+type StockInspection struct {
+	Code         string               `json:"code"`
+	Name         string               `json:"name,omitempty"`
+	CurrentPrice float64              `json:"current_price"`
+	PriceDate    time.Time            `json:"price_date"`
+	PriceSource  string               `json:"price_source,omitempty"`
+	FetchedAt    *time.Time           `json:"fetched_at,omitempty"`
+	PriceFinal   bool                 `json:"price_final"`
+	Indicators   *InspectionIndicator `json:"indicators,omitempty"`
+	Signal       *InspectionSignal    `json:"signal,omitempty"`
+	Holding      *InspectionHolding   `json:"holding,omitempty"`
+	Targets      *InspectionTargets   `json:"targets,omitempty"`
+}
+
+// InspectionIndicator represents the latest technical indicators of a stock.
+type InspectionIndicator struct {
+	RSI           float64 `json:"rsi"`
+	MACD          float64 `json:"macd"`
+	MACDSignal    float64 `json:"macd_signal"`
+	MACDHistogram float64 `json:"macd_histogram"`
+	ShortMA       float64 `json:"short_ma"`
+	MediumMA      float64 `json:"medium_ma"`
+	LongMA        float64 `json:"long_ma"`
+
+	Parameters domain.IndicatorParameters `json:"parameters"`
+}
+
+// InspectionSignal represents the trading signal of a stock.
+type InspectionSignal struct {
+	Action     string  `json:"action"`
+	Confidence float64 `json:"confidence"`
+	Score      float64 `json:"score"`
+	Reason     string  `json:"reason"`
+}
+
+// InspectionHolding represents the portfolio holding of a stock.
+type InspectionHolding struct {
+	Shares        int     `json:"shares"`
+	PurchasePrice float64 `json:"purchase_price"`
+	CurrentValue  float64 `json:"current_value"`
+	Gain          float64 `json:"gain"`
+	GainPercent   float64 `json:"gain_percent"`
+}
+
+// InspectionTargets represents the watch list target prices of a stock.
+type InspectionTargets struct {
+	TargetBuyPrice  *float64 `json:"target_buy_price,omitempty"`
+	TargetSellPrice *float64 `json:"target_sell_price,omitempty"`
+}
+
+// StockInspectionUseCase aggregates price, indicators, signal and holdings of a stock.
+type StockInspectionUseCase struct {
+	stockRepo        repository.StockRepository
+	portfolioRepo    repository.PortfolioRepository
+	technicalUseCase *TechnicalAnalysisUseCase
+	nicknameRepo     repository.StockNicknameRepository
+}
+
+// NewStockInspectionUseCase creates a new stock inspection use case.
+func NewStockInspectionUseCase(
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	technicalUseCase *TechnicalAnalysisUseCase,
+) *StockInspectionUseCase {
+	return &StockInspectionUseCase{
+		stockRepo:        stockRepo,
+		portfolioRepo:    portfolioRepo,
+		technicalUseCase: technicalUseCase,
+	}
+}
+
+// SetNicknameRepository sets the nicknames the stocks are shown by instead of their official names.
+func (uc *StockInspectionUseCase) SetNicknameRepository(nicknameRepo repository.StockNicknameRepository) {
+	uc.nicknameRepo = nicknameRepo
+}
+
+// Inspect collects the current state of a stock from stored data.
+// Indicators are calculated from the price history with the stock's strategy profile.
+func (uc *StockInspectionUseCase) Inspect(ctx context.Context, stockCode string) (*StockInspection, error) {
+	latest, err := uc.stockRepo.GetLatestPrice(ctx, stockCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest price: %w", err)
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no price data for %s", stockCode)
+	}
+
+	inspection := &StockInspection{
+		Code:         stockCode,
+		CurrentPrice: utility.DecimalToFloat(latest.ClosePrice),
+		PriceDate:    latest.Date,
+		PriceSource:  latest.Source,
+		PriceFinal:   latest.Final,
+	}
+	if latest.FetchedAt.Valid {
+		inspection.FetchedAt = &latest.FetchedAt.Time
+	}
+
+	evaluation, err := uc.technicalUseCase.EvaluateSignal(ctx, stockCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if indicator := evaluation.Indicator; indicator != nil {
+		inspection.Indicators = &InspectionIndicator{
+			RSI:           indicator.RSI,
+			MACD:          indicator.MACD,
+			MACDSignal:    indicator.Signal,
+			MACDHistogram: indicator.Histogram,
+			ShortMA:       indicator.MA5,
+			MediumMA:      indicator.MA25,
+			LongMA:        indicator.MA75,
+			Parameters:    evaluation.Parameters,
+		}
+
+		inspection.Signal = &InspectionSignal{
+			Action:     evaluation.Signal.Action,
+			Confidence: evaluation.Signal.Confidence,
+			Score:      evaluation.Signal.Score,
+			Reason:     evaluation.Signal.Reason,
+		}
+	}
+
+	holding, err := uc.portfolioRepo.GetByCode(ctx, stockCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio holding: %w", err)
+	}
+	if holding != nil {
+		inspection.Name = holding.Name
+		inspection.Holding = &InspectionHolding{
+			Shares:        holding.Shares,
+			PurchasePrice: holding.GetPurchasePrice(),
+			CurrentValue:  holding.CalculateCurrentValue(inspection.CurrentPrice),
+			Gain:          holding.CalculateGain(inspection.CurrentPrice),
+			GainPercent:   holding.CalculateGainPercent(inspection.CurrentPrice),
+		}
+	}
+
+	watchItem, err := uc.stockRepo.GetWatchListItemByCode(ctx, stockCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list item: %w", err)
+	}
+	if watchItem != nil {
+		inspection.Name = watchItem.Name
+		inspection.Targets = &InspectionTargets{
+			TargetBuyPrice:  utility.NullDecimalToFloatPtr(watchItem.TargetBuyPrice),
+			TargetSellPrice: utility.NullDecimalToFloatPtr(watchItem.TargetSellPrice),
+		}
+	}
+
+	if uc.nicknameRepo != nil {
+		nickname, err := uc.nicknameRepo.GetByCode(ctx, stockCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nickname: %w", err)
+		}
+		if nickname != nil {
+			inspection.Name = nickname.Nickname
+		}
+	}
+
+	return inspection, nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// StockNicknameEntry is a nickname with the official name of its stock.
+type StockNicknameEntry struct {
+	Code     string
+	Name     string // official name in the watch list or the portfolio, empty if the stock is neither
+	Nickname string
+}
+
+// StockNicknameUseCase manages the nicknames shown instead of the official names of the stocks.
+type StockNicknameUseCase struct {
+	nicknameRepo  repository.StockNicknameRepository
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+}
+
+// NewStockNicknameUseCase creates a new stock nickname use case.
+func NewStockNicknameUseCase(
+	nicknameRepo repository.StockNicknameRepository,
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+) *StockNicknameUseCase {
+	return &StockNicknameUseCase{
+		nicknameRepo:  nicknameRepo,
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+	}
+}
+
+// SetNickname sets the nickname of a watched or held stock, replacing the one already set.
+func (uc *StockNicknameUseCase) SetNickname(ctx context.Context, code, nickname string) (*StockNicknameEntry, error) {
+	entry := &models.StockNickname{Code: domain.NormalizeCode(code), Nickname: strings.TrimSpace(nickname)}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	name, err := uc.officialName(ctx, entry.Code)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("銘柄 %s はウォッチリストにもポートフォリオにもありません", entry.Code)
+	}
+
+	if err := uc.nicknameRepo.Upsert(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to save nickname: %w", err)
+	}
+
+	logrus.Infof("Nickname of %s (%s) set: %s", name, entry.Code, entry.Nickname)
+	return &StockNicknameEntry{Code: entry.Code, Name: name, Nickname: entry.Nickname}, nil
+}
+
+// RemoveNickname removes the nickname of a stock so that its official name is shown again.
+func (uc *StockNicknameUseCase) RemoveNickname(ctx context.Context, code string) error {
+	code = domain.NormalizeCode(code)
+	removed, err := uc.nicknameRepo.Delete(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to remove nickname: %w", err)
+	}
+	if !removed {
+		return fmt.Errorf("銘柄 %s にニックネームは設定されていません", code)
+	}
+
+	logrus.Infof("Nickname of %s removed", code)
+	return nil
+}
+
+// ListNicknames returns the nicknames with the official names of their stocks, ordered by code.
+func (uc *StockNicknameUseCase) ListNicknames(ctx context.Context) ([]StockNicknameEntry, error) {
+	nicknames, err := uc.nicknameRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nicknames: %w", err)
+	}
+
+	entries := make([]StockNicknameEntry, 0, len(nicknames))
+	for _, nickname := range nicknames {
+		name, err := uc.officialName(ctx, nickname.Code)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, StockNicknameEntry{Code: nickname.Code, Name: name, Nickname: nickname.Nickname})
+	}
+	return entries, nil
+}
+
+// officialName returns the name of the stock in the watch list, or in the portfolio if it is not
+// watched. Records read by code keep their official names. Empty if the stock is neither.
+func (uc *StockNicknameUseCase) officialName(ctx context.Context, code string) (string, error) {
+	item, err := uc.stockRepo.GetWatchListItemByCode(ctx, code)
+	if err != nil {
+		return "", fmt.Errorf("failed to get watch list item: %w", err)
+	}
+	if item != nil {
+		return item.Name, nil
+	}
+
+	holding, err := uc.portfolioRepo.GetByCode(ctx, code)
+	if err != nil {
+		return "", fmt.Errorf("failed to get portfolio holding: %w", err)
+	}
+	if holding != nil {
+		return holding.Name, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// StrategyProfileUseCase handles strategy profile management.
+type StrategyProfileUseCase struct {
+	strategyRepo  repository.StrategyProfileRepository
+	stockRepo     repository.StockRepository
+	portfolioRepo repository.PortfolioRepository
+	txManager     repository.TransactionManager
+}
+
+// NewStrategyProfileUseCase creates a new strategy profile use case.
+func NewStrategyProfileUseCase(
+	strategyRepo repository.StrategyProfileRepository,
+	stockRepo repository.StockRepository,
+	portfolioRepo repository.PortfolioRepository,
+	txManager repository.TransactionManager,
+) *StrategyProfileUseCase {
+	return &StrategyProfileUseCase{
+		strategyRepo:  strategyRepo,
+		stockRepo:     stockRepo,
+		portfolioRepo: portfolioRepo,
+		txManager:     txManager,
+	}
+}
+
+// CreateProfile validates the JSON parameters and stores a new strategy profile.
+func (uc *StrategyProfileUseCase) CreateProfile(ctx context.Context, name, description, parametersJSON string) (*models.StrategyProfile, error) {
+	params, err := domain.ParseIndicatorParameters([]byte(parametersJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	// Store the normalized parameters so that omitted fields are explicit
+	normalized, err := params.MarshalJSONString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+
+	profile := &models.StrategyProfile{
+		Name:        name,
+		Description: null.NewString(description, description != ""),
+		Parameters:  normalized,
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.strategyRepo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get strategy profile: %w", err)
+		}
+		if existing != nil {
+			return fmt.Errorf("strategy profile already exists: %s", name)
+		}
+
+		if err := uc.strategyRepo.Create(ctx, profile); err != nil {
+			return fmt.Errorf("failed to create strategy profile: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Strategy profile created: %s", name)
+	return profile, nil
+}
+
+// ListProfiles returns all strategy profiles.
+func (uc *StrategyProfileUseCase) ListProfiles(ctx context.Context) ([]*models.StrategyProfile, error) {
+	profiles, err := uc.strategyRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strategy profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// AssignProfile assigns the named strategy profile to a stock.
+func (uc *StrategyProfileUseCase) AssignProfile(ctx context.Context, stockCode, profileName string) error {
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		profile, err := uc.strategyRepo.GetByName(ctx, profileName)
+		if err != nil {
+			return fmt.Errorf("failed to get strategy profile: %w", err)
+		}
+		if profile == nil {
+			return fmt.Errorf("strategy profile not found: %s", profileName)
+		}
+
+		if err := uc.strategyRepo.AssignToStock(ctx, stockCode, profile.ID); err != nil {
+			return fmt.Errorf("failed to assign strategy profile: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Strategy profile %s assigned to %s", profileName, stockCode)
+	return nil
+}
+
+// UnassignProfile removes the strategy profile assignment so the defaults are used again.
+func (uc *StrategyProfileUseCase) UnassignProfile(ctx context.Context, stockCode string) error {
+	if err := uc.strategyRepo.UnassignFromStock(ctx, stockCode); err != nil {
+		return fmt.Errorf("failed to unassign strategy profile: %w", err)
+	}
+	return nil
+}
+
+// DefaultStrategyName is the name under which the default parameters are compared with the strategy profiles.
+const DefaultStrategyName = "default"
+
+// CompareStrategies backtests the named strategy profiles, all of them if names is empty, on the stored
+// prices of the last days and summarizes the performance of each. The default parameters are always
+// compared first as the baseline. Stocks default to the watch list and the portfolio.
+func (uc *StrategyProfileUseCase) CompareStrategies(ctx context.Context, names, codes []string, days int) ([]domain.StrategyBacktestSummary, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("days must be positive: %d", days)
+	}
+
+	strategies, err := uc.comparedStrategies(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(codes) == 0 {
+		codes, err = collectTargetCodes(ctx, uc.stockRepo, uc.portfolioRepo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Fetch enough history for the indicators of the strategy needing the most prices on the first day
+	historyDays := 0
+	for _, strategy := range strategies {
+		historyDays = max(historyDays, historyDaysFor(strategy.params))
+	}
+
+	from := time.Now().AddDate(0, 0, -days)
+	service := domain.NewTechnicalAnalysisService()
+	results := make([][]*domain.SignalBacktestResult, len(strategies))
+	for _, code := range codes {
+		prices, err := uc.stockRepo.GetPriceHistory(ctx, code, days+historyDays)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get price history of %s: %w", code, err)
+		}
+		priceData := service.ConvertStockPrices(prices)
+
+		for i, strategy := range strategies {
+			results[i] = append(results[i], domain.RunSignalBacktest(priceData, strategy.params, from))
+		}
+	}
+
+	summaries := make([]domain.StrategyBacktestSummary, len(strategies))
+	for i, strategy := range strategies {
+		summaries[i] = domain.SummarizeSignalBacktests(strategy.name, results[i])
+	}
+
+	logrus.Infof("Compared %d strategies on %d stocks over %d days", len(strategies), len(codes), days)
+	return summaries, nil
+}
+
+// namedParameters is a strategy compared by CompareStrategies.
+type namedParameters struct {
+	name   string
+	params domain.IndicatorParameters
+}
+
+// comparedStrategies returns the default parameters followed by the named strategy profiles,
+// or all strategy profiles if names is empty.
+func (uc *StrategyProfileUseCase) comparedStrategies(ctx context.Context, names []string) ([]namedParameters, error) {
+	var profiles []*models.StrategyProfile
+	if len(names) == 0 {
+		all, err := uc.strategyRepo.GetAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get strategy profiles: %w", err)
+		}
+		profiles = all
+	}
+	for _, name := range names {
+		if name == DefaultStrategyName {
+			continue
+		}
+		profile, err := uc.strategyRepo.GetByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get strategy profile: %w", err)
+		}
+		if profile == nil {
+			return nil, fmt.Errorf("strategy profile not found: %s", name)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	strategies := []namedParameters{{name: DefaultStrategyName, params: domain.DefaultIndicatorParameters()}}
+	for _, profile := range profiles {
+		params, err := domain.ParseIndicatorParameters([]byte(profile.Parameters))
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategy profile %s: %w", profile.Name, err)
+		}
+		strategies = append(strategies, namedParameters{name: profile.Name, params: params})
+	}
+	return strategies, nil
+}
@@ -3,41 +3,164 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/boost-jp/stock-automation/app/domain"
 	"github.com/boost-jp/stock-automation/app/domain/models"
 	"github.com/boost-jp/stock-automation/app/infrastructure/client"
 	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/boost-jp/stock-automation/app/utility/i18n"
 	"github.com/sirupsen/logrus"
 )
 
 // TechnicalAnalysisUseCase handles technical analysis business logic.
 type TechnicalAnalysisUseCase struct {
-	stockRepo   repository.StockRepository
-	stockClient client.StockDataClient
+	stockRepo    repository.StockRepository
+	strategyRepo repository.StrategyProfileRepository
+	stockClient  client.StockDataClient
+	businessDay  *domain.BusinessDay
+	maxWorkers   int
+	batchSize    int
 }
 
 // NewTechnicalAnalysisUseCase creates a new technical analysis use case.
 func NewTechnicalAnalysisUseCase(
 	stockRepo repository.StockRepository,
+	strategyRepo repository.StrategyProfileRepository,
 	stockClient client.StockDataClient,
 ) *TechnicalAnalysisUseCase {
 	return &TechnicalAnalysisUseCase{
-		stockRepo:   stockRepo,
-		stockClient: stockClient,
+		stockRepo:    stockRepo,
+		strategyRepo: strategyRepo,
+		stockClient:  stockClient,
+		maxWorkers:   8,
+		batchSize:    50,
 	}
 }
 
+// SetBusinessDay sets the business day calendar used for periods given in business days.
+// Weekdays in the local time zone are business days if not set.
+func (uc *TechnicalAnalysisUseCase) SetBusinessDay(businessDay *domain.BusinessDay) {
+	uc.businessDay = businessDay
+}
+
+// SetConcurrency sets the number of stocks whose indicators are calculated concurrently and the
+// number of stocks whose price histories are read in one query by AnalyzeWatchList.
+// Values of zero or less keep the defaults.
+func (uc *TechnicalAnalysisUseCase) SetConcurrency(maxWorkers, batchSize int) {
+	if maxWorkers > 0 {
+		uc.maxWorkers = maxWorkers
+	}
+	if batchSize > 0 {
+		uc.batchSize = batchSize
+	}
+}
+
+// ResolveIndicatorParameters returns the indicator parameters applied to a stock.
+// Falls back to the default parameters when no strategy profile is assigned.
+// The returned parameters can also be reused by backtests to reproduce the same calculation.
+func (uc *TechnicalAnalysisUseCase) ResolveIndicatorParameters(ctx context.Context, stockCode string) (domain.IndicatorParameters, error) {
+	if uc.strategyRepo == nil {
+		return domain.DefaultIndicatorParameters(), nil
+	}
+
+	profile, err := uc.strategyRepo.GetByStockCode(ctx, stockCode)
+	if err != nil {
+		return domain.IndicatorParameters{}, fmt.Errorf("failed to get strategy profile: %w", err)
+	}
+
+	return parametersOfProfile(profile, stockCode)
+}
+
+// parametersOfProfile returns the indicator parameters of the strategy profile assigned to a stock,
+// the default parameters if the profile is nil.
+func parametersOfProfile(profile *models.StrategyProfile, stockCode string) (domain.IndicatorParameters, error) {
+	if profile == nil {
+		return domain.DefaultIndicatorParameters(), nil
+	}
+
+	params, err := domain.ParseIndicatorParameters([]byte(profile.Parameters))
+	if err != nil {
+		return domain.IndicatorParameters{}, fmt.Errorf("invalid strategy profile %s: %w", profile.Name, err)
+	}
+
+	logrus.Debugf("Strategy profile %s applied to %s", profile.Name, stockCode)
+	return params, nil
+}
+
+// SignalEvaluation holds indicators and the trading signal calculated from stored prices.
+type SignalEvaluation struct {
+	Parameters   domain.IndicatorParameters
+	Prices       []domain.StockPriceData // price history used for the calculation, oldest first
+	Indicator    *domain.TechnicalIndicatorData
+	Signal       *domain.TradingSignal
+	CurrentPrice float64
+}
+
+// EvaluateSignal calculates indicators from the stored price history with the stock's parameters
+// and generates the trading signal. Indicator and Signal are nil when no price data is stored.
+func (uc *TechnicalAnalysisUseCase) EvaluateSignal(ctx context.Context, stockCode string) (*SignalEvaluation, error) {
+	params, err := uc.ResolveIndicatorParameters(ctx, stockCode)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := uc.stockRepo.GetPriceHistory(ctx, stockCode, historyDaysFor(params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	service := domain.NewTechnicalAnalysisService()
+	priceData := service.ConvertStockPrices(prices)
+	evaluation := &SignalEvaluation{Parameters: params, Prices: priceData}
+
+	indicator := service.CalculateAllIndicatorsWithParameters(priceData, params)
+	if indicator == nil {
+		return evaluation, nil
+	}
+
+	evaluation.Indicator = indicator
+	evaluation.CurrentPrice = priceData[len(priceData)-1].Close
+	evaluation.Signal = service.GenerateTradingSignalWithParameters(indicator, evaluation.CurrentPrice, params)
+	return evaluation, nil
+}
+
+// historyDaysFor returns the calendar days of price history needed for the parameters
+// (weekends and holidays included).
+func historyDaysFor(params domain.IndicatorParameters) int {
+	historyDays := 100
+	if required := params.RequiredDataPoints() * 2; required > historyDays {
+		historyDays = required
+	}
+	return historyDays
+}
+
+// minIndicatorDataPoints is the number of price records required to save indicators.
+const minIndicatorDataPoints = 20
+
 // CalculateAndSaveTechnicalIndicators calculates and saves technical indicators for a stock.
-func (uc *TechnicalAnalysisUseCase) CalculateAndSaveTechnicalIndicators(ctx context.Context, stockCode string) error {
+// Returns the saved indicators of the latest price date.
+func (uc *TechnicalAnalysisUseCase) CalculateAndSaveTechnicalIndicators(ctx context.Context, stockCode string) (*models.TechnicalIndicator, error) {
+	params, err := uc.ResolveIndicatorParameters(ctx, stockCode)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get historical prices
-	prices, err := uc.stockRepo.GetPriceHistory(ctx, stockCode, 100)
+	prices, err := uc.stockRepo.GetPriceHistory(ctx, stockCode, historyDaysFor(params))
 	if err != nil {
-		return fmt.Errorf("failed to get price history: %w", err)
+		return nil, fmt.Errorf("failed to get price history: %w", err)
 	}
 
-	if len(prices) < 20 {
-		return fmt.Errorf("insufficient data for technical analysis: %d records", len(prices))
+	return uc.calculateAndSave(ctx, stockCode, params, prices)
+}
+
+// calculateAndSave calculates the indicators of a stock from its price history, oldest first,
+// and saves them.
+func (uc *TechnicalAnalysisUseCase) calculateAndSave(ctx context.Context, stockCode string, params domain.IndicatorParameters, prices []*models.StockPrice) (*models.TechnicalIndicator, error) {
+	if len(prices) < minIndicatorDataPoints {
+		return nil, fmt.Errorf("insufficient data for technical analysis: %d records", len(prices))
 	}
 
 	// Convert to non-pointer slice for analysis functions
@@ -47,21 +170,68 @@ func (uc *TechnicalAnalysisUseCase) CalculateAndSaveTechnicalIndicators(ctx cont
 	}
 
 	// Use the existing analysis functions
-	indicator := domain.CalculateAllIndicators(priceValues)
+	indicator := domain.CalculateAllIndicatorsWithParameters(priceValues, params)
 
 	// Set the stock code (indicator already has the correct structure)
 	if indicator == nil {
-		return fmt.Errorf("failed to calculate indicators")
+		return nil, fmt.Errorf("failed to calculate indicators")
 	}
 	indicator.Code = stockCode
 
 	// Save to database
 	if err := uc.stockRepo.SaveTechnicalIndicator(ctx, indicator); err != nil {
-		return fmt.Errorf("failed to save technical indicator: %w", err)
+		return nil, fmt.Errorf("failed to save technical indicator: %w", err)
 	}
 
 	logrus.Infof("Technical indicators calculated and saved for %s", stockCode)
-	return nil
+	return indicator, nil
+}
+
+// RecalculateIndicators recalculates and saves the indicators of each trading day in the period
+// from the stored prices, replacing indicators already saved. The period is counted on the
+// business day calendar when given in business days. Days with fewer than
+// minIndicatorDataPoints prices up to that day are skipped. Returns the number of days saved.
+func (uc *TechnicalAnalysisUseCase) RecalculateIndicators(ctx context.Context, stockCode string, period domain.PriceHistoryPeriod) (int, error) {
+	if err := period.Validate(); err != nil {
+		return 0, err
+	}
+
+	params, err := uc.ResolveIndicatorParameters(ctx, stockCode)
+	if err != nil {
+		return 0, err
+	}
+
+	// Include the history needed to calculate the indicators of the first day.
+	from := period.Start(time.Now(), uc.businessDay)
+	prices, err := uc.stockRepo.GetPriceHistorySince(ctx, stockCode, from.AddDate(0, 0, -historyDaysFor(params)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	service := domain.NewTechnicalAnalysisService()
+	priceData := service.ConvertStockPrices(prices)
+
+	saved := 0
+	for i, price := range priceData {
+		if price.Date.Before(from) || i+1 < minIndicatorDataPoints {
+			continue
+		}
+
+		data := service.CalculateAllIndicatorsWithParameters(priceData[:i+1], params)
+		if data == nil {
+			continue
+		}
+
+		indicator := service.ConvertToModelIndicator(data)
+		indicator.Code = stockCode
+		if err := uc.stockRepo.SaveTechnicalIndicator(ctx, indicator); err != nil {
+			return saved, fmt.Errorf("failed to save technical indicator of %s: %w", price.Date.Format("2006-01-02"), err)
+		}
+		saved++
+	}
+
+	logrus.Infof("Technical indicators recalculated for %s: %d days", stockCode, saved)
+	return saved, nil
 }
 
 // GetTechnicalAnalysis retrieves the latest technical analysis for a stock.
@@ -73,26 +243,153 @@ func (uc *TechnicalAnalysisUseCase) GetTechnicalAnalysis(ctx context.Context, st
 	return indicator, nil
 }
 
-// AnalyzeWatchList performs technical analysis on all watched stocks.
+// SummarizeHolding returns the one-line technical view of a held stock for the daily report.
+// The latest stored indicators are used, falling back to indicators calculated from the stored
+// price history when they have not been calculated yet. The summary tells that the indicators
+// are not available if the price history is too short for them.
+func (uc *TechnicalAnalysisUseCase) SummarizeHolding(ctx context.Context, code, name string, currentPrice float64) (domain.TechnicalSummary, error) {
+	stored, err := uc.stockRepo.GetLatestTechnicalIndicator(ctx, code)
+	if err != nil {
+		return domain.TechnicalSummary{}, fmt.Errorf("failed to get technical indicator: %w", err)
+	}
+	if stored == nil {
+		evaluation, err := uc.EvaluateSignal(ctx, code)
+		if err != nil {
+			return domain.TechnicalSummary{}, err
+		}
+		if len(evaluation.Prices) < minIndicatorDataPoints {
+			return domain.NewTechnicalSummary(code, name, nil, nil), nil
+		}
+		summary := domain.NewTechnicalSummary(code, name, evaluation.Indicator, evaluation.Signal)
+		summary.Price = currentPrice
+		return summary, nil
+	}
+
+	params, err := uc.ResolveIndicatorParameters(ctx, code)
+	if err != nil {
+		return domain.TechnicalSummary{}, err
+	}
+	indicator := domain.TechnicalIndicatorDataFromModel(stored)
+	signal := domain.NewTechnicalAnalysisService().GenerateTradingSignalWithParameters(indicator, currentPrice, params)
+	summary := domain.NewTechnicalSummary(code, name, indicator, signal)
+	summary.Price = currentPrice
+	return summary, nil
+}
+
+// AnalyzeWatchList calculates and saves the technical indicators of all watched stocks.
+// The strategy profiles are read in one query and the price histories in one query per batch of
+// stocks, and the stocks of a batch are calculated concurrently. A stock that fails is logged
+// without stopping the others.
 func (uc *TechnicalAnalysisUseCase) AnalyzeWatchList(ctx context.Context) error {
+	startTime := time.Now()
+
 	// Get active watch list
 	watchList, err := uc.stockRepo.GetActiveWatchList(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get watch list: %w", err)
 	}
 
-	// Analyze each stock
-	for _, item := range watchList {
-		if err := uc.CalculateAndSaveTechnicalIndicators(ctx, item.Code); err != nil {
-			logrus.Errorf("Failed to analyze %s: %v", item.Code, err)
-			continue
+	codes := make([]string, len(watchList))
+	for i, item := range watchList {
+		codes[i] = item.Code
+	}
+
+	params, errs, err := uc.resolveAllIndicatorParameters(ctx, codes)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(codes); start += uc.batchSize {
+		end := start + uc.batchSize
+		if end > len(codes) {
+			end = len(codes)
+		}
+		var batch []string
+		for _, code := range codes[start:end] {
+			if _, ok := params[code]; ok {
+				batch = append(batch, code)
+			}
+		}
+		for code, err := range uc.analyzeBatch(ctx, batch, params, startTime) {
+			errs[code] = err
 		}
 	}
 
-	logrus.Infof("Technical analysis completed for %d stocks", len(watchList))
+	for _, code := range codes {
+		if err, ok := errs[code]; ok {
+			logrus.Errorf("Failed to analyze %s: %v", code, err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("technical analysis canceled: %w", err)
+	}
+
+	logrus.Infof("Technical analysis completed for %d stocks (%d failed) in %s",
+		len(codes), len(errs), time.Since(startTime).Round(time.Millisecond))
 	return nil
 }
 
+// resolveAllIndicatorParameters returns the indicator parameters of the stocks, reading the
+// strategy profile assignments in one query. Stocks whose profile is invalid are returned in the
+// errors instead of the parameters.
+func (uc *TechnicalAnalysisUseCase) resolveAllIndicatorParameters(ctx context.Context, codes []string) (map[string]domain.IndicatorParameters, map[string]error, error) {
+	var assignments map[string]*models.StrategyProfile
+	if uc.strategyRepo != nil {
+		var err error
+		if assignments, err = uc.strategyRepo.GetAssignments(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to get strategy profiles: %w", err)
+		}
+	}
+
+	params := make(map[string]domain.IndicatorParameters, len(codes))
+	errs := make(map[string]error)
+	for _, code := range codes {
+		p, err := parametersOfProfile(assignments[domain.NormalizeCode(code)], code)
+		if err != nil {
+			errs[code] = err
+			continue
+		}
+		params[code] = p
+	}
+	return params, errs, nil
+}
+
+// analyzeBatch reads the price histories of a batch of stocks in one query and calculates and
+// saves their indicators with at most maxWorkers concurrent stocks. The history is read for the
+// longest period the batch needs and cut to the period of each stock. Returns the errors keyed by
+// stock code.
+func (uc *TechnicalAnalysisUseCase) analyzeBatch(ctx context.Context, codes []string, params map[string]domain.IndicatorParameters, now time.Time) map[string]error {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	historyDays := 0
+	for _, code := range codes {
+		if days := historyDaysFor(params[code]); days > historyDays {
+			historyDays = days
+		}
+	}
+
+	histories, err := uc.stockRepo.GetPriceHistories(ctx, codes, now.AddDate(0, 0, -historyDays))
+	if err != nil {
+		errs := make(map[string]error, len(codes))
+		for _, code := range codes {
+			errs[code] = fmt.Errorf("failed to get price history: %w", err)
+		}
+		return errs
+	}
+
+	return runForCodes(ctx, codes, uc.maxWorkers, func(ctx context.Context, stockCode string) error {
+		from := now.AddDate(0, 0, -historyDaysFor(params[stockCode])).Format("2006-01-02")
+		prices := histories[domain.NormalizeCode(stockCode)]
+		for len(prices) > 0 && prices[0].Date.Format("2006-01-02") < from {
+			prices = prices[1:]
+		}
+		_, err := uc.calculateAndSave(ctx, stockCode, params[stockCode], prices)
+		return err
+	})
+}
+
 // GetTradingSignals generates trading signals based on technical indicators.
 func (uc *TechnicalAnalysisUseCase) GetTradingSignals(ctx context.Context, stockCode string) ([]string, error) {
 	indicator, err := uc.stockRepo.GetLatestTechnicalIndicator(ctx, stockCode)
@@ -100,41 +397,46 @@ func (uc *TechnicalAnalysisUseCase) GetTradingSignals(ctx context.Context, stock
 		return nil, fmt.Errorf("failed to get technical indicator: %w", err)
 	}
 
+	params, err := uc.ResolveIndicatorParameters(ctx, stockCode)
+	if err != nil {
+		return nil, err
+	}
+
 	var signals []string
 
 	// RSI signals
-	rsi := client.NullDecimalToFloat(indicator.Rsi14)
+	rsi := utility.NullDecimalToFloat(indicator.Rsi14)
 	if rsi > 0 {
-		if rsi < 30 {
-			signals = append(signals, "RSI買いシグナル（売られすぎ）")
-		} else if rsi > 70 {
-			signals = append(signals, "RSI売りシグナル（買われすぎ）")
+		if rsi < params.RSIOversold {
+			signals = append(signals, i18n.T("signal.rsi_oversold"))
+		} else if rsi > params.RSIOverbought {
+			signals = append(signals, i18n.T("signal.rsi_overbought"))
 		}
 	}
 
 	// MACD signals
-	macd := client.NullDecimalToFloat(indicator.Macd)
-	signal := client.NullDecimalToFloat(indicator.MacdSignal)
+	macd := utility.NullDecimalToFloat(indicator.Macd)
+	signal := utility.NullDecimalToFloat(indicator.MacdSignal)
 	if macd > 0 && signal > 0 {
 		if macd > signal {
-			signals = append(signals, "MACDゴールデンクロス（買いシグナル）")
+			signals = append(signals, i18n.T("signal.macd_golden_cross"))
 		} else if macd < signal {
-			signals = append(signals, "MACDデッドクロス（売りシグナル）")
+			signals = append(signals, i18n.T("signal.macd_dead_cross"))
 		}
 	}
 
 	// Moving average signals based on current price position
 	currentPrice, err := uc.stockRepo.GetLatestPrice(ctx, stockCode)
 	if err == nil {
-		price := client.DecimalToFloat(currentPrice.ClosePrice)
-		sma5 := client.NullDecimalToFloat(indicator.Sma5)
-		sma25 := client.NullDecimalToFloat(indicator.Sma25)
+		price := utility.DecimalToFloat(currentPrice.ClosePrice)
+		sma5 := utility.NullDecimalToFloat(indicator.Sma5)
+		sma25 := utility.NullDecimalToFloat(indicator.Sma25)
 
 		if sma5 > 0 && sma25 > 0 {
 			if price > sma5 && sma5 > sma25 {
-				signals = append(signals, "上昇トレンド（価格 > 5日移動平均 > 25日移動平均）")
+				signals = append(signals, i18n.T("signal.uptrend"))
 			} else if price < sma5 && sma5 < sma25 {
-				signals = append(signals, "下降トレンド（価格 < 5日移動平均 < 25日移動平均）")
+				signals = append(signals, i18n.T("signal.downtrend"))
 			}
 		}
 	}
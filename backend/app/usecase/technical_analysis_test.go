@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newIndicatorStockRepository serves stored prices and accepts saved indicators.
+func newIndicatorStockRepository(prices []*models.StockPrice) *mock.StockRepositoryMock {
+	return &mock.StockRepositoryMock{
+		GetPriceHistoryFunc: func(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
+			return prices, nil
+		},
+		GetPriceHistorySinceFunc: func(ctx context.Context, stockCode string, from time.Time) ([]*models.StockPrice, error) {
+			return prices, nil
+		},
+		SaveTechnicalIndicatorFunc: func(ctx context.Context, indicator *models.TechnicalIndicator) error {
+			return nil
+		},
+	}
+}
+
+// dailyPrices returns count daily prices of code ending today, oldest first.
+func dailyPrices(code string, count int) []*models.StockPrice {
+	now := time.Now()
+	prices := make([]*models.StockPrice, count)
+	for i := range prices {
+		closePrice := 1000 + float64(i%7)*10
+		prices[i] = &models.StockPrice{
+			Code:       code,
+			Date:       now.AddDate(0, 0, -(count - 1 - i)),
+			OpenPrice:  utility.FloatToDecimal(closePrice),
+			HighPrice:  utility.FloatToDecimal(closePrice + 5),
+			LowPrice:   utility.FloatToDecimal(closePrice - 5),
+			ClosePrice: utility.FloatToDecimal(closePrice),
+			Volume:     1000,
+		}
+	}
+	return prices
+}
+
+func TestTechnicalAnalysisUseCase_RecalculateIndicators(t *testing.T) {
+	tests := []struct {
+		name      string
+		prices    int
+		days      int
+		wantSaved int
+	}{
+		{name: "only days in the period", prices: 60, days: 10, wantSaved: 10},
+		{name: "skips days with insufficient history", prices: 25, days: 365, wantSaved: 25 - minIndicatorDataPoints + 1},
+		{name: "no prices", prices: 0, days: 30, wantSaved: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices := dailyPrices("7203", tt.prices)
+			stockRepo := newIndicatorStockRepository(prices)
+			uc := NewTechnicalAnalysisUseCase(stockRepo, nil, nil)
+
+			saved, err := uc.RecalculateIndicators(context.Background(), "7203", domain.PriceHistoryPeriod{Days: tt.days})
+			if err != nil {
+				t.Fatalf("RecalculateIndicators() error = %v", err)
+			}
+			if saved != tt.wantSaved {
+				t.Errorf("saved = %d, want %d", saved, tt.wantSaved)
+			}
+			calls := stockRepo.SaveTechnicalIndicatorCalls()
+			if len(calls) != tt.wantSaved {
+				t.Fatalf("%d indicators saved, want %d", len(calls), tt.wantSaved)
+			}
+			if tt.wantSaved == 0 {
+				return
+			}
+
+			// Each trading day gets its own indicator, the last one on the latest price date.
+			var wantDates, gotDates []time.Time
+			for _, price := range prices[tt.prices-tt.wantSaved:] {
+				wantDates = append(wantDates, price.Date)
+			}
+			for _, call := range calls {
+				indicator := call.Indicator
+				if indicator.Code != "7203" {
+					t.Errorf("indicator code = %s, want 7203", indicator.Code)
+				}
+				if indicator.Sma5.Big == nil || indicator.Rsi14.Big == nil {
+					t.Errorf("indicator of %s has no values", indicator.Date.Format("2006-01-02"))
+				}
+				gotDates = append(gotDates, indicator.Date)
+			}
+			if diff := cmp.Diff(wantDates, gotDates); diff != "" {
+				t.Errorf("indicator dates mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTechnicalAnalysisUseCase_AnalyzeWatchList(t *testing.T) {
+	var watchList []*models.WatchList
+	histories := make(map[string][]*models.StockPrice)
+	for i := 0; i < 100; i++ {
+		code := fmt.Sprintf("%d", 1000+i)
+		watchList = append(watchList, &models.WatchList{Code: code})
+		histories[code] = dailyPrices(code, 120)
+	}
+	// Too short a history fails only its own stock
+	histories["1000"] = dailyPrices("1000", 10)
+
+	var mu sync.Mutex
+	saved := make(map[string]bool)
+	stockRepo := &mock.StockRepositoryMock{
+		GetActiveWatchListFunc: func(ctx context.Context) ([]*models.WatchList, error) {
+			return watchList, nil
+		},
+		GetPriceHistoriesFunc: func(ctx context.Context, codes []string, from time.Time) (map[string][]*models.StockPrice, error) {
+			result := make(map[string][]*models.StockPrice, len(codes))
+			for _, code := range codes {
+				result[code] = histories[code]
+			}
+			return result, nil
+		},
+		SaveTechnicalIndicatorFunc: func(ctx context.Context, indicator *models.TechnicalIndicator) error {
+			mu.Lock()
+			defer mu.Unlock()
+			saved[indicator.Code] = true
+			return nil
+		},
+	}
+	uc := NewTechnicalAnalysisUseCase(stockRepo, nil, nil)
+	uc.SetConcurrency(4, 30)
+
+	if err := uc.AnalyzeWatchList(context.Background()); err != nil {
+		t.Fatalf("AnalyzeWatchList() error = %v", err)
+	}
+
+	// The histories are read in batches instead of per stock
+	var batchSizes []int
+	for _, call := range stockRepo.GetPriceHistoriesCalls() {
+		batchSizes = append(batchSizes, len(call.Codes))
+	}
+	if diff := cmp.Diff([]int{30, 30, 30, 10}, batchSizes); diff != "" {
+		t.Errorf("batch sizes mismatch (-want +got):\n%s", diff)
+	}
+	if len(saved) != 99 || saved["1000"] {
+		t.Errorf("%d stocks saved (1000 saved: %v), want 99 without 1000", len(saved), saved["1000"])
+	}
+}
+
+func TestTechnicalAnalysisUseCase_RecalculateIndicators_InvalidDays(t *testing.T) {
+	uc := NewTechnicalAnalysisUseCase(&mock.StockRepositoryMock{}, nil, nil)
+
+	if _, err := uc.RecalculateIndicators(context.Background(), "7203", domain.PriceHistoryPeriod{}); err == nil {
+		t.Error("RecalculateIndicators() should fail for non-positive days")
+	}
+}
+
+func TestTechnicalAnalysisUseCase_RecalculateIndicators_BusinessDays(t *testing.T) {
+	prices := dailyPrices("7203", 60)
+	stockRepo := newIndicatorStockRepository(prices)
+	calendar := domain.NewBusinessDay(time.Local, nil)
+	uc := NewTechnicalAnalysisUseCase(stockRepo, nil, nil)
+	uc.SetBusinessDay(calendar)
+
+	saved, err := uc.RecalculateIndicators(context.Background(), "7203", domain.PriceHistoryPeriod{Days: 10, BusinessDays: true})
+	if err != nil {
+		t.Fatalf("RecalculateIndicators() error = %v", err)
+	}
+
+	// The daily prices include weekends, which are saved when they fall in the period.
+	start := calendar.PeriodStart(time.Now(), 10)
+	want := 0
+	for _, price := range prices {
+		if !price.Date.Before(start) {
+			want++
+		}
+	}
+	if saved != want {
+		t.Errorf("saved = %d, want %d", saved, want)
+	}
+
+	calls := stockRepo.GetPriceHistorySinceCalls()
+	if len(calls) != 1 || !calls[0].From.Before(start) {
+		t.Errorf("price history should be read from before %v to include the history of the first day: %+v", start, calls)
+	}
+}
+
+func TestTechnicalAnalysisUseCase_SummarizeHolding(t *testing.T) {
+	stored := &models.TechnicalIndicator{
+		Code:  "7203",
+		Rsi14: utility.FloatToNullDecimal(25),
+		Sma5:  utility.FloatToNullDecimal(1100),
+		Sma25: utility.FloatToNullDecimal(1050),
+		Sma75: utility.FloatToNullDecimal(1000),
+	}
+
+	tests := []struct {
+		name          string
+		stored        *models.TechnicalIndicator
+		prices        int
+		wantAvailable bool
+		wantTrend     string
+	}{
+		{name: "stored indicators", stored: stored, prices: 0, wantAvailable: true, wantTrend: domain.MATrendUp},
+		{name: "calculated from the price history", stored: nil, prices: 100, wantAvailable: true},
+		{name: "not enough price history", stored: nil, prices: 5, wantAvailable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stockRepo := newIndicatorStockRepository(dailyPrices("7203", tt.prices))
+			stockRepo.GetLatestTechnicalIndicatorFunc = func(ctx context.Context, stockCode string) (*models.TechnicalIndicator, error) {
+				return tt.stored, nil
+			}
+			uc := NewTechnicalAnalysisUseCase(stockRepo, nil, nil)
+
+			got, err := uc.SummarizeHolding(context.Background(), "7203", "トヨタ自動車", 1120)
+			if err != nil {
+				t.Fatalf("SummarizeHolding() error = %v", err)
+			}
+			if got.Available() != tt.wantAvailable {
+				t.Errorf("Available() = %v, want %v (%+v)", got.Available(), tt.wantAvailable, got)
+			}
+			if tt.wantTrend != "" && got.Trend != tt.wantTrend {
+				t.Errorf("Trend = %q, want %q", got.Trend, tt.wantTrend)
+			}
+			if len(stockRepo.GetPriceHistoryCalls()) > 0 && tt.stored != nil {
+				t.Errorf("price history should not be read when indicators are stored")
+			}
+		})
+	}
+}
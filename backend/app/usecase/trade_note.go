@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTradeNoteListLimit is the number of notes listed when no limit is given.
+const DefaultTradeNoteListLimit = 20
+
+var (
+	// ErrTradeNoteNotFound is returned for a note ID that does not exist.
+	ErrTradeNoteNotFound = errors.New("trade note not found")
+	// ErrInvalidTradeNote is returned for a note that fails validation.
+	ErrInvalidTradeNote = errors.New("invalid trade note")
+)
+
+// TradeNoteInput is a note to record on a stock.
+type TradeNoteInput struct {
+	Code   string
+	Kind   string
+	Body   string
+	Source string // signal:<rule name> or report:<YYYY-MM-DD>, empty for a note written by hand
+}
+
+// TradeNoteUseCase records the hypotheses, entry reasons and reviews of the trades of each stock.
+type TradeNoteUseCase struct {
+	noteRepo repository.TradeNoteRepository
+}
+
+// NewTradeNoteUseCase creates a new trade note use case.
+func NewTradeNoteUseCase(noteRepo repository.TradeNoteRepository) *TradeNoteUseCase {
+	return &TradeNoteUseCase{noteRepo: noteRepo}
+}
+
+// AddNote records a note on a stock.
+func (uc *TradeNoteUseCase) AddNote(ctx context.Context, input TradeNoteInput) (*models.TradeNote, error) {
+	source, err := domain.ParseTradeNoteSource(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTradeNote, err)
+	}
+
+	note := &models.TradeNote{
+		Code:   domain.NormalizeCode(input.Code),
+		Kind:   input.Kind,
+		Body:   strings.TrimSpace(input.Body),
+		Source: source,
+	}
+	if note.Kind == "" {
+		note.Kind = models.TradeNoteKindHypothesis
+	}
+	if err := note.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTradeNote, err)
+	}
+
+	if err := uc.noteRepo.Create(ctx, note); err != nil {
+		return nil, fmt.Errorf("failed to save trade note: %w", err)
+	}
+
+	logrus.Infof("Trade note %s (%s) recorded for %s", note.ID, note.Kind, note.Code)
+	return note, nil
+}
+
+// ListNotes returns the notes of a stock, or of all stocks if code is empty, newest first.
+func (uc *TradeNoteUseCase) ListNotes(ctx context.Context, code string, limit int) ([]*models.TradeNote, error) {
+	if limit <= 0 {
+		limit = DefaultTradeNoteListLimit
+	}
+
+	var notes []*models.TradeNote
+	var err error
+	if code == "" {
+		notes, err = uc.noteRepo.GetRecent(ctx, limit)
+	} else {
+		notes, err = uc.noteRepo.GetByCode(ctx, domain.NormalizeCode(code), limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade notes: %w", err)
+	}
+	return notes, nil
+}
+
+// GetNote returns a note by ID, or ErrTradeNoteNotFound.
+func (uc *TradeNoteUseCase) GetNote(ctx context.Context, id string) (*models.TradeNote, error) {
+	note, err := uc.noteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade note: %w", err)
+	}
+	if note == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTradeNoteNotFound, id)
+	}
+	return note, nil
+}
+
+// DeleteNote removes a note. Returns ErrTradeNoteNotFound if there is no such note.
+func (uc *TradeNoteUseCase) DeleteNote(ctx context.Context, id string) error {
+	removed, err := uc.noteRepo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove trade note: %w", err)
+	}
+	if !removed {
+		return fmt.Errorf("%w: %s", ErrTradeNoteNotFound, id)
+	}
+
+	logrus.Infof("Trade note %s removed", id)
+	return nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/testutil/mock"
+)
+
+func TestTradeNoteUseCase_AddAndList(t *testing.T) {
+	var notes []*models.TradeNote
+	repo := &mock.TradeNoteRepositoryMock{
+		CreateFunc: func(ctx context.Context, note *models.TradeNote) error {
+			note.ID = "01HQNOTE"
+			notes = append(notes, note)
+			return nil
+		},
+		GetByCodeFunc: func(ctx context.Context, code string, limit int) ([]*models.TradeNote, error) {
+			return notes, nil
+		},
+		GetRecentFunc: func(ctx context.Context, limit int) ([]*models.TradeNote, error) {
+			return notes, nil
+		},
+	}
+	uc := NewTradeNoteUseCase(repo)
+	ctx := context.Background()
+
+	note, err := uc.AddNote(ctx, TradeNoteInput{Code: "７２０３.T", Body: "  決算後の押し目  ", Source: "signal:rsi-oversold"})
+	if err != nil {
+		t.Fatalf("AddNote() error = %v", err)
+	}
+	if note.Code != "7203" || note.Kind != models.TradeNoteKindHypothesis || note.Body != "決算後の押し目" || note.Source != "signal:rsi-oversold" {
+		t.Errorf("AddNote() = %+v, want the code normalized, a hypothesis by default and the body trimmed", note)
+	}
+
+	invalid := []TradeNoteInput{
+		{Code: "7203", Body: " "},
+		{Code: "7203", Kind: "idea", Body: "メモ"},
+		{Code: "7203", Body: "メモ", Source: "report:today"},
+	}
+	for _, input := range invalid {
+		if _, err := uc.AddNote(ctx, input); !errors.Is(err, ErrInvalidTradeNote) {
+			t.Errorf("AddNote(%+v) error = %v, want ErrInvalidTradeNote", input, err)
+		}
+	}
+	if len(repo.CreateCalls()) != 1 {
+		t.Errorf("saved %d notes, want the invalid ones rejected", len(repo.CreateCalls()))
+	}
+
+	if _, err := uc.ListNotes(ctx, "7203.T", 0); err != nil {
+		t.Fatalf("ListNotes(7203.T) error = %v", err)
+	}
+	if calls := repo.GetByCodeCalls(); len(calls) != 1 || calls[0].Code != "7203" || calls[0].Limit != DefaultTradeNoteListLimit {
+		t.Errorf("ListNotes(7203.T) read %+v, want 7203 with the default limit", calls)
+	}
+	if _, err := uc.ListNotes(ctx, "", 5); err != nil {
+		t.Fatalf("ListNotes() error = %v", err)
+	}
+	if calls := repo.GetRecentCalls(); len(calls) != 1 || calls[0].Limit != 5 {
+		t.Errorf("ListNotes() of all stocks read %+v, want the recent notes with limit 5", calls)
+	}
+}
+
+func TestTradeNoteUseCase_NotFound(t *testing.T) {
+	repo := &mock.TradeNoteRepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.TradeNote, error) {
+			return nil, nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) (bool, error) {
+			return false, nil
+		},
+	}
+	uc := NewTradeNoteUseCase(repo)
+
+	if _, err := uc.GetNote(context.Background(), "01HQNONE"); !errors.Is(err, ErrTradeNoteNotFound) {
+		t.Errorf("GetNote() error = %v, want ErrTradeNoteNotFound", err)
+	}
+	if err := uc.DeleteNote(context.Background(), "01HQNONE"); !errors.Is(err, ErrTradeNoteNotFound) {
+		t.Errorf("DeleteNote() error = %v, want ErrTradeNoteNotFound", err)
+	}
+}
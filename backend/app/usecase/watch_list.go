@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/utility"
+	"github.com/sirupsen/logrus"
+)
+
+// DuplicateMode specifies how to handle codes already registered in the watch list.
+type DuplicateMode string
+
+const (
+	// DuplicateSkip leaves existing watch list items unchanged
+	DuplicateSkip DuplicateMode = "skip"
+	// DuplicateUpdate overwrites name and target prices of existing items
+	DuplicateUpdate DuplicateMode = "update"
+)
+
+// ParseDuplicateMode parses a duplicate handling mode.
+func ParseDuplicateMode(s string) (DuplicateMode, error) {
+	switch DuplicateMode(s) {
+	case DuplicateSkip, DuplicateUpdate:
+		return DuplicateMode(s), nil
+	default:
+		return "", fmt.Errorf("unknown duplicate mode: %s (skip or update)", s)
+	}
+}
+
+// WatchListImportItem represents a single row of a watch list import file.
+type WatchListImportItem struct {
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	TargetBuyPrice  *float64 `json:"target_buy_price,omitempty"`
+	TargetSellPrice *float64 `json:"target_sell_price,omitempty"`
+	CollectInterval string   `json:"collect_interval,omitempty"` // e.g. "5m", "1h" or "1d", empty for the default
+}
+
+// WatchListImportResult summarizes the result of a watch list import.
+type WatchListImportResult struct {
+	Created int
+	Updated int
+	Skipped int
+	Errors  []string
+}
+
+// WatchListUseCase handles watch list management.
+type WatchListUseCase struct {
+	stockRepo repository.StockRepository
+	txManager repository.TransactionManager
+}
+
+// NewWatchListUseCase creates a new watch list use case.
+func NewWatchListUseCase(stockRepo repository.StockRepository, txManager repository.TransactionManager) *WatchListUseCase {
+	return &WatchListUseCase{
+		stockRepo: stockRepo,
+		txManager: txManager,
+	}
+}
+
+// Import registers watch list items in bulk. Invalid rows are reported in the result
+// and do not stop the import of the remaining rows. The import runs in a single transaction,
+// so a database error rolls back all rows.
+func (uc *WatchListUseCase) Import(ctx context.Context, items []WatchListImportItem, mode DuplicateMode) (*WatchListImportResult, error) {
+	result := &WatchListImportResult{}
+
+	err := uc.txManager.WithTx(ctx, func(ctx context.Context) error {
+		for i, item := range items {
+			code, err := domain.NormalizeStockCode(item.Code)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("row %d (%s): %v", i+1, item.Code, err))
+				continue
+			}
+			watchItem := &models.WatchList{
+				Code:            code,
+				Name:            strings.TrimSpace(item.Name),
+				TargetBuyPrice:  utility.FloatPtrToNullDecimal(item.TargetBuyPrice),
+				TargetSellPrice: utility.FloatPtrToNullDecimal(item.TargetSellPrice),
+				IsActive:        null.BoolFrom(true),
+			}
+
+			if item.CollectInterval != "" {
+				minutes, err := domain.ParseCollectInterval(item.CollectInterval)
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("row %d (%s): %v", i+1, item.Code, err))
+					continue
+				}
+				watchItem.CollectIntervalMinutes = minutes
+			}
+
+			if err := watchItem.Validate(); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("row %d (%s): %v", i+1, item.Code, err))
+				continue
+			}
+
+			existing, err := uc.stockRepo.GetWatchListItemByCode(ctx, watchItem.Code)
+			if err != nil {
+				return fmt.Errorf("failed to get watch list item %s: %w", watchItem.Code, err)
+			}
+
+			if existing == nil {
+				watchItem.ID = utility.NewULID()
+				if err := uc.stockRepo.AddToWatchList(ctx, watchItem); err != nil {
+					return fmt.Errorf("row %d (%s): failed to add to watch list: %w", i+1, item.Code, err)
+				}
+				result.Created++
+				continue
+			}
+
+			if mode != DuplicateUpdate {
+				result.Skipped++
+				continue
+			}
+
+			existing.Name = watchItem.Name
+			existing.TargetBuyPrice = watchItem.TargetBuyPrice
+			existing.TargetSellPrice = watchItem.TargetSellPrice
+			existing.IsActive = watchItem.IsActive
+			existing.CollectIntervalMinutes = watchItem.CollectIntervalMinutes
+			if err := uc.stockRepo.UpdateWatchList(ctx, existing); err != nil {
+				return fmt.Errorf("row %d (%s): failed to update watch list: %w", i+1, item.Code, err)
+			}
+			result.Updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Watch list import completed: created=%d, updated=%d, skipped=%d, errors=%d",
+		result.Created, result.Updated, result.Skipped, len(result.Errors))
+
+	return result, nil
+}
+
+// SetCollectInterval sets the collection interval of a watch list item in minutes, 0 for the default interval.
+func (uc *WatchListUseCase) SetCollectInterval(ctx context.Context, code string, minutes int) error {
+	item, err := uc.stockRepo.GetWatchListItemByCode(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to get watch list item %s: %w", code, err)
+	}
+	if item == nil {
+		return fmt.Errorf("watch list item not found: %s", code)
+	}
+
+	item.CollectIntervalMinutes = minutes
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	if err := uc.stockRepo.UpdateWatchList(ctx, item); err != nil {
+		return fmt.Errorf("failed to update watch list item %s: %w", code, err)
+	}
+
+	logrus.Infof("Collection interval of %s set to %s", code, domain.FormatCollectInterval(minutes))
+	return nil
+}
+
+// Mute mutes the alerts of a watch list item until the given duration has passed.
+// A mute already set is replaced.
+func (uc *WatchListUseCase) Mute(ctx context.Context, code string, d time.Duration) (*models.WatchList, error) {
+	return uc.setMutedUntil(ctx, code, null.TimeFrom(time.Now().Add(d)))
+}
+
+// Unmute lifts the mute of the alerts of a watch list item.
+func (uc *WatchListUseCase) Unmute(ctx context.Context, code string) (*models.WatchList, error) {
+	return uc.setMutedUntil(ctx, code, null.Time{})
+}
+
+// setMutedUntil sets until when the alerts of a watch list item are muted.
+func (uc *WatchListUseCase) setMutedUntil(ctx context.Context, code string, mutedUntil null.Time) (*models.WatchList, error) {
+	item, err := uc.stockRepo.GetWatchListItemByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list item %s: %w", code, err)
+	}
+	if item == nil {
+		return nil, fmt.Errorf("watch list item not found: %s", code)
+	}
+
+	item.MutedUntil = mutedUntil
+	if err := uc.stockRepo.UpdateWatchList(ctx, item); err != nil {
+		return nil, fmt.Errorf("failed to update watch list item %s: %w", code, err)
+	}
+
+	if mutedUntil.Valid {
+		logrus.Infof("Alerts of %s muted until %s", code, mutedUntil.Time.Format(time.RFC3339))
+	} else {
+		logrus.Infof("Alerts of %s unmuted", code)
+	}
+	return item, nil
+}
+
+// mutedStockCodes returns the codes of the active watch list items whose alerts are muted at the given time.
+func mutedStockCodes(watchList []*models.WatchList, at time.Time) map[string]bool {
+	muted := make(map[string]bool)
+	for _, item := range watchList {
+		if item.IsMuted(at) {
+			muted[item.Code] = true
+		}
+	}
+	return muted
+}
+
+// loadMutedStockCodes reads the active watch list and returns the codes whose alerts are muted now.
+func loadMutedStockCodes(ctx context.Context, stockRepo repository.StockRepository) (map[string]bool, error) {
+	watchList, err := stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+	return mutedStockCodes(watchList, time.Now()), nil
+}
+
+// ListCollectIntervals returns the active watch list items with their collection intervals.
+func (uc *WatchListUseCase) ListCollectIntervals(ctx context.Context) ([]*models.WatchList, error) {
+	items, err := uc.stockRepo.GetActiveWatchList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+	return items, nil
+}
+
+// List returns the watch list items including inactive ones in code order, and the removed
+// ones as well if includeDeleted is set.
+func (uc *WatchListUseCase) List(ctx context.Context, includeDeleted bool) ([]*models.WatchList, error) {
+	items, err := uc.stockRepo.GetWatchList(ctx, includeDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+	return items, nil
+}
+
+// Remove removes a stock from the watch list. The item is soft-deleted and can be restored with Restore.
+func (uc *WatchListUseCase) Remove(ctx context.Context, code string) error {
+	item, err := uc.stockRepo.GetWatchListItemByCode(ctx, code)
+	if err != nil {
+		return fmt.Errorf("failed to get watch list item %s: %w", code, err)
+	}
+	if item == nil {
+		return fmt.Errorf("watch list item not found: %s", code)
+	}
+
+	if err := uc.stockRepo.DeleteFromWatchList(ctx, item.ID); err != nil {
+		return fmt.Errorf("failed to delete watch list item %s: %w", code, err)
+	}
+
+	logrus.Infof("Watch list item removed: %s (%s)", item.Name, code)
+	return nil
+}
+
+// Restore restores a removed stock to the watch list.
+func (uc *WatchListUseCase) Restore(ctx context.Context, code string) (*models.WatchList, error) {
+	items, err := uc.stockRepo.GetWatchList(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch list: %w", err)
+	}
+
+	var item *models.WatchList
+	for _, candidate := range items {
+		if candidate.Code == code && candidate.DeletedAt.Valid {
+			item = candidate
+			break
+		}
+	}
+	if item == nil {
+		return nil, fmt.Errorf("削除されたウォッチリスト銘柄が見つかりません: %s", code)
+	}
+
+	if err := uc.stockRepo.RestoreWatchListItem(ctx, item.ID); err != nil {
+		return nil, fmt.Errorf("failed to restore watch list item %s: %w", code, err)
+	}
+	item.DeletedAt = null.Time{}
+
+	logrus.Infof("Watch list item restored: %s (%s)", item.Name, code)
+	return item, nil
+}
+
+// ParseWatchListCSV parses watch list rows in "code,name,target_buy_price,target_sell_price,collect_interval" format.
+// A header row starting with "code" is skipped. Target prices and the collection interval may be empty.
+func ParseWatchListCSV(r io.Reader) ([]WatchListImportItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	items := make([]WatchListImportItem, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+
+		if i == 0 && strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff")), "code") {
+			continue
+		}
+
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: code and name are required", i+1)
+		}
+
+		item := WatchListImportItem{
+			Code: strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff")),
+			Name: strings.TrimSpace(record[1]),
+		}
+
+		if len(record) > 2 {
+			if item.TargetBuyPrice, err = parseOptionalFloat(record[2]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid target buy price: %w", i+1, err)
+			}
+		}
+
+		if len(record) > 3 {
+			if item.TargetSellPrice, err = parseOptionalFloat(record[3]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid target sell price: %w", i+1, err)
+			}
+		}
+
+		if len(record) > 4 {
+			item.CollectInterval = strings.TrimSpace(record[4])
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// ParseWatchListJSON parses a JSON array of watch list items.
+func ParseWatchListJSON(r io.Reader) ([]WatchListImportItem, error) {
+	var items []WatchListImportItem
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return items, nil
+}
+
+// parseOptionalFloat parses a float value, returning nil for empty strings.
+func parseOptionalFloat(s string) (*float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+)
+
+// runForCodes runs fn for each stock code with at most maxWorkers concurrent calls.
+//
+// The next code is given to a worker only when it is free to start it, so no codes are queued
+// ahead of the workers and codes not yet started when ctx is canceled are skipped with the error
+// of ctx. Returns the errors keyed by stock code.
+func runForCodes(ctx context.Context, codes []string, maxWorkers int, fn func(ctx context.Context, stockCode string) error) map[string]error {
+	if maxWorkers <= 0 {
+		maxWorkers = 1
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errors = make(map[string]error)
+	)
+	fail := func(stockCode string, err error) {
+		mu.Lock()
+		errors[stockCode] = err
+		mu.Unlock()
+	}
+
+	// Unbuffered, so that a code is handed over only to a worker ready to run it
+	codeChan := make(chan string)
+	for i := 0; i < maxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for stockCode := range codeChan {
+				err := ctx.Err()
+				if err == nil {
+					err = fn(ctx, stockCode)
+				}
+				if err != nil {
+					fail(stockCode, err)
+				}
+			}
+		}()
+	}
+
+	for _, stockCode := range codes {
+		if err := ctx.Err(); err != nil {
+			fail(stockCode, err)
+			continue
+		}
+		select {
+		case codeChan <- stockCode:
+		case <-ctx.Done():
+			fail(stockCode, ctx.Err())
+		}
+	}
+	close(codeChan)
+
+	wg.Wait()
+	return errors
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunForCodes(t *testing.T) {
+	codes := []string{"7203", "6758", "9984", "8306", "6861"}
+	failErr := errors.New("failed")
+
+	var running, maxRunning int32
+	errs := runForCodes(context.Background(), codes, 2, func(ctx context.Context, code string) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			prev := atomic.LoadInt32(&maxRunning)
+			if current <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, current) {
+				break
+			}
+		}
+		if code == "9984" {
+			return failErr
+		}
+		return nil
+	})
+
+	if len(errs) != 1 || !errors.Is(errs["9984"], failErr) {
+		t.Errorf("Expected only 9984 to fail, got %v", errs)
+	}
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 concurrent workers, got %d", maxRunning)
+	}
+}
+
+func TestRunForCodes_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := int32(0)
+	errs := runForCodes(ctx, []string{"7203", "6758"}, 1, func(ctx context.Context, code string) error {
+		atomic.AddInt32(&called, 1)
+		return nil
+	})
+
+	if called != 0 {
+		t.Errorf("Expected no calls after cancellation, got %d", called)
+	}
+	if len(errs) != 2 || !errors.Is(errs["7203"], context.Canceled) {
+		t.Errorf("Expected context.Canceled for all codes, got %v", errs)
+	}
+}
+
+func TestRunForCodes_Backpressure(t *testing.T) {
+	codes := make([]string, 100)
+	for i := range codes {
+		codes[i] = strconv.Itoa(1000 + i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var called int32
+	started := make(chan struct{}, len(codes))
+	done := make(chan map[string]error)
+	go func() {
+		done <- runForCodes(ctx, codes, 2, func(ctx context.Context, code string) error {
+			atomic.AddInt32(&called, 1)
+			started <- struct{}{}
+			<-ctx.Done()
+			return nil
+		})
+	}()
+
+	// Both workers are busy, so the other codes wait for a free worker and are skipped on cancellation
+	<-started
+	<-started
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	errs := <-done
+	if called != 2 {
+		t.Errorf("Expected only the 2 codes given to the busy workers to run, got %d", called)
+	}
+	if len(errs) != 98 || !errors.Is(errs["1099"], context.Canceled) {
+		t.Errorf("Expected context.Canceled for the 98 waiting codes, got %d errors", len(errs))
+	}
+}
+
+// BenchmarkRunForCodes reports the allocations of a run for many codes.
+// Profile with -memprofile to see where they come from.
+func BenchmarkRunForCodes(b *testing.B) {
+	codes := make([]string, 10000)
+	for i := range codes {
+		codes[i] = strconv.Itoa(100000 + i)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		runForCodes(context.Background(), codes, 8, func(ctx context.Context, code string) error {
+			return nil
+		})
+	}
+}
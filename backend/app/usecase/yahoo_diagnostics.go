@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/infrastructure/client"
+)
+
+// Endpoints measured by the connectivity diagnostics.
+const (
+	EndpointCurrent    = "current"
+	EndpointHistorical = "historical"
+	EndpointIntraday   = "intraday"
+)
+
+// DefaultDiagnosticCodes are the stocks diagnosed when none are given.
+var DefaultDiagnosticCodes = []string{"7203", "6758", "9984"}
+
+// DiagnosticCall is the result of a single API call.
+type DiagnosticCall struct {
+	Code      string  `json:"code"`
+	Endpoint  string  `json:"endpoint"`
+	Success   bool    `json:"success"`
+	LatencyMs float64 `json:"latency_ms"`
+	Records   int     `json:"records"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// EndpointDiagnosis aggregates the calls of an endpoint.
+type EndpointDiagnosis struct {
+	Endpoint     string  `json:"endpoint"`
+	Calls        int     `json:"calls"`
+	Successes    int     `json:"successes"`
+	SuccessRate  float64 `json:"success_rate"` // percent
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MinLatencyMs float64 `json:"min_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+	P95LatencyMs float64 `json:"p95_latency_ms"`
+}
+
+// DiagnosticReport is the result of the connectivity diagnostics.
+type DiagnosticReport struct {
+	StartedAt  time.Time           `json:"started_at"`
+	DurationMs float64             `json:"duration_ms"`
+	Codes      []string            `json:"codes"`
+	Runs       int                 `json:"runs"`
+	Endpoints  []EndpointDiagnosis `json:"endpoints"`
+	Calls      []DiagnosticCall    `json:"calls"`
+}
+
+// YahooDiagnosticsUseCase measures the latency and success rate of the stock data endpoints.
+type YahooDiagnosticsUseCase struct {
+	stockClient client.StockDataClient
+}
+
+// NewYahooDiagnosticsUseCase creates a new Yahoo Finance diagnostics use case.
+func NewYahooDiagnosticsUseCase(stockClient client.StockDataClient) *YahooDiagnosticsUseCase {
+	return &YahooDiagnosticsUseCase{stockClient: stockClient}
+}
+
+// Run calls the current, historical and intraday endpoints for each stock runs times.
+// Failed calls are recorded in the report instead of aborting the diagnostics.
+func (uc *YahooDiagnosticsUseCase) Run(ctx context.Context, codes []string, runs int) (*DiagnosticReport, error) {
+	if len(codes) == 0 {
+		codes = DefaultDiagnosticCodes
+	}
+	if runs <= 0 {
+		return nil, fmt.Errorf("runs must be positive: %d", runs)
+	}
+
+	endpoints := []struct {
+		name  string
+		fetch func(ctx context.Context, code string) (int, error)
+	}{
+		{EndpointCurrent, func(ctx context.Context, code string) (int, error) {
+			_, err := uc.stockClient.GetCurrentPrice(ctx, code)
+			return 1, err
+		}},
+		{EndpointHistorical, func(ctx context.Context, code string) (int, error) {
+			prices, err := uc.stockClient.GetHistoricalData(ctx, code, 30)
+			return len(prices), err
+		}},
+		{EndpointIntraday, func(ctx context.Context, code string) (int, error) {
+			prices, err := uc.stockClient.GetIntradayData(ctx, code, "5m")
+			return len(prices), err
+		}},
+	}
+
+	report := &DiagnosticReport{StartedAt: time.Now(), Codes: codes, Runs: runs}
+	for run := 0; run < runs; run++ {
+		for _, code := range codes {
+			for _, endpoint := range endpoints {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+
+				start := time.Now()
+				records, err := endpoint.fetch(ctx, code)
+				call := DiagnosticCall{
+					Code:      code,
+					Endpoint:  endpoint.name,
+					Success:   err == nil,
+					LatencyMs: durationMs(time.Since(start)),
+				}
+				if err != nil {
+					call.Error = err.Error()
+				} else {
+					call.Records = records
+				}
+				report.Calls = append(report.Calls, call)
+			}
+		}
+	}
+
+	report.DurationMs = durationMs(time.Since(report.StartedAt))
+	report.Endpoints = summarizeDiagnosticCalls(report.Calls)
+	return report, nil
+}
+
+// summarizeDiagnosticCalls aggregates the calls per endpoint in the order the endpoints first appear.
+func summarizeDiagnosticCalls(calls []DiagnosticCall) []EndpointDiagnosis {
+	latencies := make(map[string][]float64)
+	summaries := []EndpointDiagnosis{}
+	index := make(map[string]int)
+
+	for _, call := range calls {
+		i, ok := index[call.Endpoint]
+		if !ok {
+			i = len(summaries)
+			index[call.Endpoint] = i
+			summaries = append(summaries, EndpointDiagnosis{Endpoint: call.Endpoint})
+		}
+
+		summaries[i].Calls++
+		if call.Success {
+			summaries[i].Successes++
+		}
+		latencies[call.Endpoint] = append(latencies[call.Endpoint], call.LatencyMs)
+	}
+
+	for i := range summaries {
+		summary := &summaries[i]
+		values := latencies[summary.Endpoint]
+		sort.Float64s(values)
+
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		summary.SuccessRate = float64(summary.Successes) / float64(summary.Calls) * 100
+		summary.AvgLatencyMs = total / float64(len(values))
+		summary.MinLatencyMs = values[0]
+		summary.MaxLatencyMs = values[len(values)-1]
+		summary.P95LatencyMs = values[(len(values)*95+99)/100-1]
+	}
+
+	return summaries
+}
+
+// durationMs converts a duration to milliseconds.
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
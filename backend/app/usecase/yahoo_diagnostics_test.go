@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarizeDiagnosticCalls(t *testing.T) {
+	calls := []DiagnosticCall{
+		{Code: "7203", Endpoint: EndpointCurrent, Success: true, LatencyMs: 120},
+		{Code: "7203", Endpoint: EndpointHistorical, Success: true, LatencyMs: 300},
+		{Code: "6758", Endpoint: EndpointCurrent, Success: false, LatencyMs: 80},
+		{Code: "6758", Endpoint: EndpointHistorical, Success: true, LatencyMs: 200},
+		{Code: "9984", Endpoint: EndpointCurrent, Success: true, LatencyMs: 100},
+		{Code: "9984", Endpoint: EndpointHistorical, Success: false, LatencyMs: 1000},
+		{Code: "8306", Endpoint: EndpointCurrent, Success: true, LatencyMs: 100},
+		{Code: "8306", Endpoint: EndpointHistorical, Success: false, LatencyMs: 500},
+	}
+
+	want := []EndpointDiagnosis{
+		{
+			Endpoint:     EndpointCurrent,
+			Calls:        4,
+			Successes:    3,
+			SuccessRate:  75,
+			AvgLatencyMs: 100,
+			MinLatencyMs: 80,
+			MaxLatencyMs: 120,
+			P95LatencyMs: 120,
+		},
+		{
+			Endpoint:     EndpointHistorical,
+			Calls:        4,
+			Successes:    2,
+			SuccessRate:  50,
+			AvgLatencyMs: 500,
+			MinLatencyMs: 200,
+			MaxLatencyMs: 1000,
+			P95LatencyMs: 1000,
+		},
+	}
+
+	got := summarizeDiagnosticCalls(calls)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("summarizeDiagnosticCalls() mismatch (-want +got):\n%s", diff)
+	}
+}
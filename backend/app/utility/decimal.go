@@ -0,0 +1,71 @@
+package utility
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/ericlagergren/decimal"
+)
+
+// FloatToDecimal converts a float64 to types.Decimal using the shortest decimal representation
+// that converts back to the same float64, so 0.1 is stored as 0.1 and not as its binary approximation.
+// NaN and infinities, which cannot be stored, convert to zero.
+func FloatToDecimal(value float64) types.Decimal {
+	return types.NewDecimal(floatToBig(value))
+}
+
+// FloatToNullDecimal converts a float64 to a valid types.NullDecimal.
+// NaN and infinities convert to null.
+func FloatToNullDecimal(value float64) types.NullDecimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return types.NullDecimal{}
+	}
+	return types.NewNullDecimal(floatToBig(value))
+}
+
+// FloatPtrToNullDecimal converts an optional float64 to types.NullDecimal, null if value is nil.
+func FloatPtrToNullDecimal(value *float64) types.NullDecimal {
+	if value == nil {
+		return types.NullDecimal{}
+	}
+	return FloatToNullDecimal(*value)
+}
+
+// DecimalToFloat converts types.Decimal to the nearest float64, 0 if unset.
+func DecimalToFloat(d types.Decimal) float64 {
+	return bigToFloat(d.Big)
+}
+
+// NullDecimalToFloat converts types.NullDecimal to the nearest float64, 0 if null.
+func NullDecimalToFloat(d types.NullDecimal) float64 {
+	return bigToFloat(d.Big)
+}
+
+// NullDecimalToFloatPtr converts types.NullDecimal to *float64, nil if null.
+func NullDecimalToFloatPtr(d types.NullDecimal) *float64 {
+	if d.Big == nil {
+		return nil
+	}
+	f := bigToFloat(d.Big)
+	return &f
+}
+
+// floatToBig converts a float64 to its shortest exact decimal representation.
+func floatToBig(value float64) *decimal.Big {
+	d := new(decimal.Big)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return d
+	}
+	d.SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	return d
+}
+
+// bigToFloat converts a decimal to the nearest float64, 0 if nil.
+func bigToFloat(d *decimal.Big) float64 {
+	if d == nil {
+		return 0
+	}
+	f, _ := d.Float64()
+	return f
+}
@@ -0,0 +1,109 @@
+package utility
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aarondl/sqlboiler/v4/types"
+	"github.com/ericlagergren/decimal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFloatToDecimal(t *testing.T) {
+	// Variables keep the sum from being folded exactly as an untyped constant
+	tenth, twoTenths := 0.1, 0.2
+
+	tests := []struct {
+		name  string
+		value float64
+		want  string
+	}{
+		{name: "integer", value: 1050, want: "1050"},
+		{name: "binary inexact fraction", value: 0.1, want: "0.1"},
+		{name: "sum with rounding error", value: tenth + twoTenths, want: "0.30000000000000004"},
+		{name: "price with yen fraction", value: 2345.5, want: "2345.5"},
+		{name: "more digits than six", value: 1.23456789, want: "1.23456789"},
+		{name: "negative", value: -12.75, want: "-12.75"},
+		{name: "large", value: 123456789012.25, want: "123456789012.25"},
+		{name: "NaN", value: math.NaN(), want: "0"},
+		{name: "infinity", value: math.Inf(1), want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FloatToDecimal(tt.value)
+			if diff := cmp.Diff(tt.want, got.String()); diff != "" {
+				t.Errorf("FloatToDecimal() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFloatToDecimal_RoundTrip(t *testing.T) {
+	values := []float64{0, 0.1, 1.15, 30, 999.99, 1234.5678, 1.0 / 3, 2.0 / 3 * 1000, 1e-7, 98765.4321}
+
+	for _, value := range values {
+		if got := DecimalToFloat(FloatToDecimal(value)); got != value {
+			t.Errorf("DecimalToFloat(FloatToDecimal(%v)) = %v", value, got)
+		}
+		if got := NullDecimalToFloat(FloatToNullDecimal(value)); got != value {
+			t.Errorf("NullDecimalToFloat(FloatToNullDecimal(%v)) = %v", value, got)
+		}
+	}
+}
+
+func TestFloatToNullDecimal(t *testing.T) {
+	if got := FloatToNullDecimal(0); got.Big == nil {
+		t.Error("FloatToNullDecimal(0) should not be null")
+	}
+	if got := FloatToNullDecimal(math.NaN()); got.Big != nil {
+		t.Errorf("FloatToNullDecimal(NaN) = %v, want null", got)
+	}
+	if got := FloatToNullDecimal(math.Inf(-1)); got.Big != nil {
+		t.Errorf("FloatToNullDecimal(-Inf) = %v, want null", got)
+	}
+}
+
+func TestFloatPtrToNullDecimal(t *testing.T) {
+	if got := FloatPtrToNullDecimal(nil); got.Big != nil {
+		t.Errorf("FloatPtrToNullDecimal(nil) = %v, want null", got)
+	}
+
+	value := 1.15
+	if diff := cmp.Diff("1.15", FloatPtrToNullDecimal(&value).String()); diff != "" {
+		t.Errorf("FloatPtrToNullDecimal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecimalToFloat_Unset(t *testing.T) {
+	if got := DecimalToFloat(types.Decimal{}); got != 0 {
+		t.Errorf("DecimalToFloat(unset) = %v, want 0", got)
+	}
+	if got := NullDecimalToFloat(types.NullDecimal{}); got != 0 {
+		t.Errorf("NullDecimalToFloat(null) = %v, want 0", got)
+	}
+}
+
+func TestDecimalToFloat_FromString(t *testing.T) {
+	// Values read from DECIMAL columns arrive as exact decimal strings.
+	d := new(decimal.Big)
+	d.SetString("2817.5000")
+
+	if got := DecimalToFloat(types.NewDecimal(d)); got != 2817.5 {
+		t.Errorf("DecimalToFloat() = %v, want 2817.5", got)
+	}
+}
+
+func TestNullDecimalToFloatPtr(t *testing.T) {
+	if got := NullDecimalToFloatPtr(types.NullDecimal{}); got != nil {
+		t.Errorf("NullDecimalToFloatPtr(null) = %v, want nil", *got)
+	}
+
+	got := NullDecimalToFloatPtr(FloatToNullDecimal(3200.5))
+	if got == nil {
+		t.Fatal("NullDecimalToFloatPtr() = nil, want a value")
+	}
+	if *got != 3200.5 {
+		t.Errorf("NullDecimalToFloatPtr() = %v, want 3200.5", *got)
+	}
+}
@@ -0,0 +1,65 @@
+// Package i18n provides the message catalogs of reports and notifications.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+)
+
+// Supported locales.
+const (
+	Japanese = "ja"
+	English  = "en"
+)
+
+// DefaultLocale is used until SetLocale is called and for messages missing in a catalog.
+const DefaultLocale = Japanese
+
+// catalogs maps a locale to its messages. Messages are fmt format strings keyed by ID.
+var catalogs = map[string]map[string]string{
+	Japanese: jaMessages,
+	English:  enMessages,
+}
+
+var current atomic.Value
+
+// SetLocale switches the locale of all messages.
+func SetLocale(locale string) error {
+	if _, ok := catalogs[locale]; !ok {
+		return fmt.Errorf("unsupported locale: %s (supported: %v)", locale, Locales())
+	}
+	current.Store(locale)
+	return nil
+}
+
+// Locale returns the current locale.
+func Locale() string {
+	if locale, ok := current.Load().(string); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Locales returns the supported locales.
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// T formats the message of the current locale with args. Falls back to the default
+// locale if the message is missing, and to the key itself if it is unknown.
+func T(key string, args ...any) string {
+	format, ok := catalogs[Locale()][key]
+	if !ok {
+		format, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// verbPattern matches fmt verbs, capturing the verb letter.
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?([a-zA-Z])`)
+
+// verbs returns the verb letters of a message in order. Flags, width and precision may differ
+// between locales, e.g. a zero-padded month, and escaped percent signs are not verbs.
+func verbs(message string) []string {
+	var letters []string
+	for _, match := range verbPattern.FindAllStringSubmatch(strings.ReplaceAll(message, "%%", ""), -1) {
+		letters = append(letters, match[1])
+	}
+	return letters
+}
+
+func TestCatalogs_Consistent(t *testing.T) {
+	for locale, messages := range catalogs {
+		if locale == DefaultLocale {
+			continue
+		}
+
+		for key, want := range catalogs[DefaultLocale] {
+			got, ok := messages[key]
+			if !ok {
+				t.Errorf("%s: missing message %q", locale, key)
+				continue
+			}
+			if diff := cmp.Diff(verbs(want), verbs(got)); diff != "" {
+				t.Errorf("%s: verbs of %q differ from %s (-want +got):\n%s", locale, key, DefaultLocale, diff)
+			}
+		}
+		for key := range messages {
+			if _, ok := catalogs[DefaultLocale][key]; !ok {
+				t.Errorf("%s: message %q is not in %s", locale, key, DefaultLocale)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer func() {
+		if err := SetLocale(DefaultLocale); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if got := T("exit_target.gain_percent", 12.5); got != "損益率: 12.50%" {
+		t.Errorf("T() = %q, want Japanese by default", got)
+	}
+
+	if err := SetLocale(English); err != nil {
+		t.Fatalf("SetLocale() error = %v", err)
+	}
+	if got := T("exit_target.gain_percent", 12.5); got != "Gain: 12.50%" {
+		t.Errorf("T() = %q, want English", got)
+	}
+	if got := T("unknown.key"); got != "unknown.key" {
+		t.Errorf("T() = %q, want the key for an unknown message", got)
+	}
+
+	if err := SetLocale("fr"); err == nil {
+		t.Error("SetLocale() should fail for an unsupported locale")
+	}
+	if got := Locale(); got != English {
+		t.Errorf("Locale() = %s, want %s after a failed SetLocale", got, English)
+	}
+}
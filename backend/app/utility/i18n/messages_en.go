@@ -0,0 +1,303 @@
+package i18n
+
+// enMessages is the English message catalog.
+var enMessages = map[string]string{
+	// Common report messages
+	"report.no_stocks":        "No stocks to report",
+	"report.generated_at":     "Generated at: %s",
+	"report.price_errors":     "Price errors:",
+	"report.price_error_item": "%s (%s): failed to get price",
+	"report.monthly_title":    "📅 Monthly Portfolio Report (%d-%02d)",
+	"report.closing_followup": "📌 Closing values: value ¥%s (¥%s, %+.2f%% from the previous close) / gain ¥%s (%+.2f%%)",
+
+	// Portfolio report
+	"portfolio.empty":              "No portfolio data",
+	"portfolio.title":              "📊 Portfolio Report",
+	"portfolio.total_section":      "💰 Total Assets",
+	"portfolio.total_value":        "Current value: ¥%s",
+	"portfolio.total_cost":         "Cost basis: ¥%s",
+	"portfolio.total_gain":         "Gain: %s ¥%s (%.2f%%)",
+	"portfolio.holdings_section":   "📋 Holdings",
+	"portfolio.short_holding":      "%s %s (%s) [short]",
+	"portfolio.short_shares":       "Sold short: %d shares @ ¥%s",
+	"portfolio.long_shares":        "Shares: %d @ ¥%s",
+	"portfolio.current_price":      "Current price: ¥%s",
+	"portfolio.required_margin":    "Required margin: ¥%s",
+	"portfolio.interest_cost":      "Interest cost: ¥%s",
+	"portfolio.holding_gain":       "Gain: ¥%s (%.2f%%)",
+	"portfolio.allocation_section": "🧺 Asset Allocation",
+	"portfolio.allocation":         "%s: ¥%s (%.1f%%)",
+	"portfolio.cash_balance":       "Balance: ¥%s",
+	"portfolio.fund_units":         "Units: %s @ ¥%s per 10,000 units",
+	"portfolio.etf_units":          "Units: %d @ ¥%s",
+	"portfolio.crypto_quantity":    "Quantity: %s %s @ ¥%s",
+	"asset_class.stock":            "Stocks",
+	"asset_class.etf":              "ETFs",
+	"asset_class.fund":             "Mutual funds",
+	"asset_class.crypto":           "Crypto assets",
+	"asset_class.cash":             "Cash",
+
+	// Correlation analysis
+	"correlation.level.unknown":        "N/A",
+	"correlation.level.good":           "Good",
+	"correlation.level.fair":           "Fair",
+	"correlation.level.poor":           "Poor",
+	"correlation.title":                "🔗 Diversification Analysis",
+	"correlation.too_few_holdings":     "Fewer than 2 holdings have enough prices to calculate correlations",
+	"correlation.insufficient_history": "Insufficient price history: %s",
+	"correlation.targets":              "Holdings: %d (returns of %d trading days)",
+	"correlation.average":              "Average correlation: %.2f",
+	"correlation.effective_holdings":   "Effective holdings: %.1f / %d",
+	"correlation.diversification":      "Diversification: %s",
+	"correlation.matrix":               "Correlation matrix",
+	"correlation.high_pairs":           "⚠️ Highly correlated pairs (correlation %.1f or higher)",
+	"correlation.excluded":             "Excluded for insufficient price history: %s",
+
+	// Score ranking
+	"scoring.title":     "🏆 Watch List Composite Score Ranking",
+	"scoring.weights":   "Weights: technical %.0f%% / fundamental %.0f%%",
+	"scoring.rank":      "%d. %s (%s) %.0f pts",
+	"scoring.breakdown": "Technical: %.0f / Fundamental: %s",
+
+	// Watch list discovery
+	"discovery.title":        "🔎 Watch List Candidates",
+	"discovery.none":         "No candidates this week",
+	"discovery.candidate":    "%d. %s (%s) ¥%s (%+.2f%%)",
+	"discovery.volume_surge": "Volume surge: %.1fx the usual volume",
+	"discovery.new_high":     "New high: above the high of the last %d trading days",
+	"discovery.added":        "✅ Added to the watch list",
+	"discovery.hint":         "To add them: stock-automation discover --add",
+
+	// Data quality report
+	"data_quality.title":        "🩺 Price Data Quality Report",
+	"data_quality.period":       "Period: %s - %s",
+	"data_quality.summary":      "Stocks: %d / Needs review: %d",
+	"data_quality.no_data":      "no data",
+	"data_quality.missing":      "Missing: %.1f%% (%d/%d days)",
+	"data_quality.anomalies":    "Anomalies: %d",
+	"data_quality.last_updated": "Last updated: %s",
+
+	// Paper trade report
+	"paper_trade.title":       "📝 Paper Trading Performance Report",
+	"paper_trade.value":       "Value: ¥%.0f (cash ¥%.0f / stocks ¥%.0f)",
+	"paper_trade.gain":        "Gain: ¥%+.0f (%+.2f%%) / initial cash ¥%.0f",
+	"paper_trade.trades":      "Executions: %d",
+	"paper_trade.positions":   "Positions",
+	"paper_trade.position":    "%s %d shares @¥%.2f → ¥%.2f (%+.2f%%)",
+	"paper_trade.comparison":  "Comparison with the real portfolio",
+	"paper_trade.real":        "Real portfolio: ¥%.0f (%+.2f%%)",
+	"paper_trade.paper_ahead": "paper trading is ahead",
+	"paper_trade.real_ahead":  "the real portfolio is ahead",
+	"paper_trade.difference":  "Difference: %+.2fpt (%s)",
+
+	// Paper broker order messages
+	"broker.no_price_data":       "No price data",
+	"broker.limit_not_reached":   "Limit %.2f was not reached (%s low %.2f high %.2f)",
+	"broker.insufficient_cash":   "Insufficient cash (required ¥%.0f, balance ¥%.0f)",
+	"broker.insufficient_shares": "Insufficient shares (held %d)",
+
+	// Exit target notification
+	"exit_target.action.sell":       "selling",
+	"exit_target.action.cover":      "buying back",
+	"exit_target.take_profit.title": "Take-profit line reached: %s (%s)",
+	"exit_target.take_profit.line":  "Take-profit line: +%.2f%% (¥%.2f)",
+	"exit_target.stop_loss.title":   "Stop-loss line reached: %s (%s)",
+	"exit_target.stop_loss.line":    "Stop-loss line: -%.2f%% (¥%.2f)",
+	"exit_target.current_price":     "Current price: ¥%.2f",
+	"exit_target.purchase_price":    "Purchase price: ¥%.2f",
+	"exit_target.gain_percent":      "Gain: %.2f%%",
+	"exit_target.suggestion":        "Consider %s",
+
+	// Alert rule notification
+	"alert_rule.title":       "Alert: %s",
+	"alert_rule.stock":       "Stock: %s",
+	"alert_rule.conditions":  "Conditions: %s",
+	"alert_rule.description": "Description: %s",
+	"alert_rule.values":      "Values: %s",
+
+	// Maintenance digest
+	"maintenance.digest_title":  "🛠️ Alerts suppressed during maintenance (%d)",
+	"maintenance.digest_period": "Period: %s - %s",
+	"maintenance.digest_reason": "Reason: %s",
+	"maintenance.digest_more":   "...and %d more",
+
+	// Technical summary
+	"technical_summary.section":        "📈 Technical Summary",
+	"technical_summary.item":           "%s %s (%s): RSI %.1f / MA %s / MA dev %+.1f%% / %s",
+	"technical_summary.unavailable":    "⚪ %s (%s): indicators not calculated (not enough price history)",
+	"technical_summary.trend.up":       "up",
+	"technical_summary.trend.down":     "down",
+	"technical_summary.trend.sideways": "sideways",
+	"technical_summary.action.buy":     "buy",
+	"technical_summary.action.sell":    "sell",
+	"technical_summary.action.hold":    "hold",
+
+	// Buying power
+	"buying_power.section":      "💴 Buying Power",
+	"buying_power.cash":         "Cash: ¥%s (%.1f%%)",
+	"buying_power.invested":     "Invested: %.1f%%",
+	"buying_power.reserved":     "Target cash (%.0f%%): ¥%s",
+	"buying_power.margin":       "Required margin of short positions: ¥%s",
+	"buying_power.available":    "Buying power: ¥%s",
+	"buying_power.below_target": "⚠️ Cash is below the target cash position",
+	"buying_power.signal_ok":    "within buying power (1 unit ¥%s)",
+	"buying_power.signal_over":  "exceeds buying power (1 unit ¥%s)",
+
+	// Market indices
+	"market.section":      "🌐 Market",
+	"market.index":        "%s: %s",
+	"market.index_change": "%s: %s (%+.2f%%)",
+	"market.benchmark":    "Portfolio %+.2f%% vs %s %+.2f%% (excess %+.2fpt)",
+
+	// Morning briefing
+	"briefing.title":                "🌅 Pre-market briefing (%s)",
+	"briefing.market_section":       "🌐 Markets overnight",
+	"briefing.no_indices":           "No index prices",
+	"briefing.holdings_section":     "💼 Previous closes of holdings",
+	"briefing.holding":              "%s (%s): ¥%s",
+	"briefing.holding_change":       "%s (%s): ¥%s (%+.2f%%)",
+	"briefing.no_holdings":          "No prices of holdings",
+	"briefing.earnings_section":     "📅 Earnings today",
+	"briefing.no_earnings":          "No earnings announcements of watched or held stocks",
+	"briefing.earnings_unavailable": "Earnings schedule unavailable (J-Quants credentials required)",
+
+	// Contribution analysis
+	"contribution.section":    "🏆 Profit/Loss Contribution",
+	"contribution.top":        "▲ Top %d contributors",
+	"contribution.worst":      "▼ Worst %d contributors",
+	"contribution.gain_item":  "%d. %s (%s): ¥%s (%+.2f%%, contribution %+.2fpt)",
+	"contribution.movers":     "⚡ Today's biggest movers (day change ¥%s, %+.2f%%)",
+	"contribution.mover_item": "%d. %s (%s): %+.2f%% ¥%s (contribution %+.2fpt)",
+
+	// Portfolio goals
+	"goal.section":         "🎯 Goal progress",
+	"goal.line":            "%s %s: %.1f%% reached (%.0f%% of period elapsed) %s",
+	"goal.detail":          "Value ¥%s / target ¥%s (deadline %s)",
+	"goal.status.on_track": "on track",
+	"goal.status.behind":   "behind pace",
+	"goal.status.achieved": "achieved",
+	"goal.status.missed":   "missed",
+	"goal.notify.achieved": "🎉 Goal \"%s\" achieved: value ¥%s (target ¥%s)",
+	"goal.notify.missed":   "🔴 Goal \"%s\" was not reached by %s: value ¥%s (target ¥%s)",
+	"goal.notify.behind":   "🟡 Goal \"%s\" is behind pace: %.1f%% reached / %.0f%% of period elapsed (¥%s to go)",
+	"goal.notify.on_track": "🟢 Goal \"%s\" is back on track: %.1f%% reached / %.0f%% of period elapsed",
+
+	// Monthly return calendar
+	"monthly_return.title":   "📆 Monthly return calendar",
+	"monthly_return.no_data": "Not enough snapshots to calculate monthly returns",
+	"monthly_return.legend":  "🟩 +3%% or more 🟢 0 to +3%% 🔴 -3 to 0%% 🟥 -3%% or less ⬜ no data",
+	"monthly_return.line":    "%s %d-%02d: %+.2f%%",
+	"monthly_return.ytd":     "📈 Year-to-date return (%d): %+.2f%%",
+
+	// PDF report
+	"pdf_report.daily_title":          "Daily Portfolio Report",
+	"pdf_report.monthly_title":        "Monthly Portfolio Report (%d-%02d)",
+	"pdf_report.summary":              "Summary",
+	"pdf_report.total_gain":           "Gain: ¥%s (%+.2f%%)",
+	"pdf_report.holdings_count":       "Holdings: %d",
+	"pdf_report.holdings":             "Holdings",
+	"pdf_report.column.code":          "Code",
+	"pdf_report.column.name":          "Name",
+	"pdf_report.column.shares":        "Shares",
+	"pdf_report.column.price":         "Price",
+	"pdf_report.column.value":         "Value",
+	"pdf_report.column.gain":          "Gain",
+	"pdf_report.column.gain_percent":  "Gain %%",
+	"pdf_report.short":                "%s (short)",
+	"pdf_report.gain_chart":           "Gain by holding (JPY)",
+	"pdf_report.value_chart":          "Portfolio value",
+	"pdf_report.monthly_return_chart": "Monthly returns (%%)",
+	"pdf_report.ytd":                  "Year-to-date return (%d): %+.2f%%",
+	"pdf_report.email_subject":        "%s (%s)",
+	"pdf_report.email_body":           "The %s is attached as a PDF.",
+
+	// Data source schema changes
+	"data_source.schema_changed":  "🚨 Yahoo Finance (%s) responses do not match the expected structure. Data collection may be returning no data.\n%s",
+	"data_source.quota_warning":   "⚠️ %s has used %.0f%% of today's request limit (%d / %d, %d left). Collection is slowed down automatically. Resets at %s",
+	"data_source.quota_exhausted": "🚨 %s has reached today's request limit (%d). No data can be fetched until %s",
+
+	// Collection alerts
+	"collect_alert.error_rate": "🚨 High error rate in price collection: %d of %d stocks (%.0f%%) failed",
+	"collect_alert.error_more": "...and %d more",
+	"collect_alert.anomaly":    "⚠️ Possibly abnormal price: %s from ¥%.2f to ¥%.2f (%+.2f%%)",
+
+	// Price feed monitoring
+	"price_feed.stalled": "🚨 Prices of too many stocks are not updated: on %s, %d of %d stocks (%.0f%%) have a price",
+	"price_feed.hint":    "The collection jobs may have finished without errors. Check the data source responses and the scheduler logs",
+
+	// Collection quarantine
+	"collect_quarantine.quarantined": "⚠️ %s failed %d times in a row and is left out of the price collection until %s, then retried automatically: %s",
+
+	// Trade notes
+	"trade_note.ref":             "📝 %s note (%s): %s",
+	"trade_note.kind.hypothesis": "Hypothesis",
+	"trade_note.kind.entry":      "Entry reason",
+	"trade_note.kind.review":     "Review",
+
+	// Price moves
+	"price_move.title": "📊 Stocks that moved ±%g%% or more from the previous close (%d stocks)",
+	"price_move.line":  "- %s ¥%.2f → ¥%.2f (%+.2f%%)",
+
+	// Moving average deviation
+	"ma_deviation.title":      "🔥 Stocks deviating more than ±%g%% from the 25-day moving average (%d stocks)",
+	"ma_deviation.line":       "- %s ¥%.2f / 25-day MA ¥%.2f (deviation %+.2f%%, %s)",
+	"ma_deviation.overheated": "overbought",
+	"ma_deviation.oversold":   "oversold",
+
+	// Hedge advice
+	"hedge.title":           "🛡️ Holdings that fell %g%% or more from the previous close (%d stocks)",
+	"hedge.drop_line":       "- %s ¥%.2f → ¥%.2f (%+.2f%%, value ¥%s)",
+	"hedge.advice_header":   "Hedge ideas:",
+	"hedge.inverse_etf":     "Inverse ETF %s: buy about ¥%s to hedge the falling value of ¥%s (%.1f%% of the portfolio) by %g%%",
+	"hedge.raise_cash":      "Cash ratio %.1f%% is below the target %g%%: sell about ¥%s to raise cash",
+	"hedge.reduce_position": "%s fell %+.2f%%, consider selling half of the position (about ¥%s)",
+	"hedge.disclaimer":      "* Advice only, nothing is executed automatically",
+
+	// Scheduler
+	"scheduler.jobs_skipped":       "⏭️ Since the job %s %s, the following jobs were skipped: %s",
+	"scheduler.dependency_failed":  "failed",
+	"scheduler.dependency_skipped": "was skipped",
+	"scheduler.dependency_error":   "Error: %s",
+	"scheduler.job_running":        "⏳ Job %s is running (started at %s)",
+	"scheduler.job_succeeded":      "✅ Job %s succeeded (%v)",
+	"scheduler.job_failed":         "❌ Job %s failed (%v): %v",
+
+	// Price charts
+	"price_chart.title":   "📈 %s closing prices %s to %s (%d days)",
+	"price_chart.summary": "Close ¥%.2f / High ¥%.2f / Low ¥%.2f / Change %+.2f%%",
+	"price_chart.no_data": "📈 %s: no prices in the period",
+
+	// Corporate events
+	"corporate_event.earnings":         "%s earnings announcement",
+	"corporate_event.record_date":      "%s record date",
+	"corporate_event.dividend_payment": "%s dividend payment",
+	"corporate_event.description":      "Stock code: %s\nRegistered by stock-automation",
+	"corporate_event.dividend_amount":  "Dividend per share ¥%s",
+
+	// Technical signals
+	"signal.rsi_oversold":      "RSI buy signal (oversold)",
+	"signal.rsi_overbought":    "RSI sell signal (overbought)",
+	"signal.macd_golden_cross": "MACD golden cross (buy signal)",
+	"signal.macd_dead_cross":   "MACD dead cross (sell signal)",
+	"signal.uptrend":           "Uptrend (price > 5-day MA > 25-day MA)",
+	"signal.downtrend":         "Downtrend (price < 5-day MA < 25-day MA)",
+
+	// Slack notifications
+	"slack.stock_alert.title":      "Stock alert: %s (%s)",
+	"slack.stock_alert.type":       "%s alert",
+	"slack.daily_report.title":     "Today's Investment Report",
+	"slack.daily_report.portfolio": "Portfolio",
+	"slack.comprehensive.title":    "Daily Portfolio Report",
+	"slack.comprehensive.summary":  "Portfolio Summary",
+	"slack.comprehensive.holdings": "Holdings",
+	"slack.comprehensive.holding":  "Shares: %s | Price: ¥%.0f | Gain: ¥%.0f (%.1f%%)",
+	"slack.field.current_price":    "Current price",
+	"slack.field.target_price":     "Target price",
+	"slack.field.deviation":        "Deviation",
+	"slack.field.time":             "Time",
+	"slack.field.total_value":      "Total value",
+	"slack.field.total_cost":       "Total cost",
+	"slack.field.gain":             "Gain",
+	"slack.field.gain_percent":     "Gain %%",
+	"slack.field.updated_at":       "Updated at",
+}
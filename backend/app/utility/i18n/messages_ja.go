@@ -0,0 +1,303 @@
+package i18n
+
+// jaMessages is the Japanese message catalog.
+var jaMessages = map[string]string{
+	// Common report messages
+	"report.no_stocks":        "対象銘柄がありません",
+	"report.generated_at":     "生成時刻: %s",
+	"report.price_errors":     "価格取得エラー:",
+	"report.price_error_item": "%s (%s): 価格取得エラー",
+	"report.monthly_title":    "📅 月次ポートフォリオレポート (%d年%d月)",
+	"report.closing_followup": "📌 大引け後の確定値: 評価額 ¥%s (前日比 ¥%s, %+.2f%%) / 損益 ¥%s (%+.2f%%)",
+
+	// Portfolio report
+	"portfolio.empty":              "ポートフォリオにデータがありません",
+	"portfolio.title":              "📊 ポートフォリオレポート",
+	"portfolio.total_section":      "💰 総資産状況",
+	"portfolio.total_value":        "現在価値: ¥%s",
+	"portfolio.total_cost":         "投資元本: ¥%s",
+	"portfolio.total_gain":         "損益: %s ¥%s (%.2f%%)",
+	"portfolio.holdings_section":   "📋 個別銘柄",
+	"portfolio.short_holding":      "%s %s (%s) [空売り]",
+	"portfolio.short_shares":       "売建数: %d株 @ ¥%s",
+	"portfolio.long_shares":        "保有数: %d株 @ ¥%s",
+	"portfolio.current_price":      "現在価格: ¥%s",
+	"portfolio.required_margin":    "必要保証金: ¥%s",
+	"portfolio.interest_cost":      "金利コスト: ¥%s",
+	"portfolio.holding_gain":       "損益: ¥%s (%.2f%%)",
+	"portfolio.allocation_section": "🧺 資産クラス別配分",
+	"portfolio.allocation":         "%s: ¥%s (%.1f%%)",
+	"portfolio.cash_balance":       "残高: ¥%s",
+	"portfolio.fund_units":         "保有口数: %s口 @ ¥%s (1万口あたり)",
+	"portfolio.etf_units":          "保有数: %d口 @ ¥%s",
+	"portfolio.crypto_quantity":    "保有数量: %s %s @ ¥%s",
+	"asset_class.stock":            "株式",
+	"asset_class.etf":              "ETF",
+	"asset_class.fund":             "投資信託",
+	"asset_class.crypto":           "暗号資産",
+	"asset_class.cash":             "現金",
+
+	// Correlation analysis
+	"correlation.level.unknown":        "判定不可",
+	"correlation.level.good":           "良好",
+	"correlation.level.fair":           "普通",
+	"correlation.level.poor":           "低い",
+	"correlation.title":                "🔗 分散投資分析",
+	"correlation.too_few_holdings":     "相関を計算できる保有銘柄が2つ未満です",
+	"correlation.insufficient_history": "価格履歴不足: %s",
+	"correlation.targets":              "対象: %d銘柄 (%d営業日のリターン)",
+	"correlation.average":              "平均相関: %.2f",
+	"correlation.effective_holdings":   "有効銘柄数: %.1f / %d銘柄",
+	"correlation.diversification":      "分散度: %s",
+	"correlation.matrix":               "相関行列",
+	"correlation.high_pairs":           "⚠️ 連動性の高い組み合わせ (相関%.1f以上)",
+	"correlation.excluded":             "価格履歴不足のため除外: %s",
+
+	// Score ranking
+	"scoring.title":     "🏆 ウォッチリスト総合スコアランキング",
+	"scoring.weights":   "重み: テクニカル %.0f%% / ファンダメンタル %.0f%%",
+	"scoring.rank":      "%d. %s (%s) %.0f点",
+	"scoring.breakdown": "テクニカル: %.0f / ファンダメンタル: %s",
+
+	// Watch list discovery
+	"discovery.title":        "🔎 ウォッチリスト追加候補",
+	"discovery.none":         "今週の候補はありません",
+	"discovery.candidate":    "%d. %s (%s) ¥%s (%+.2f%%)",
+	"discovery.volume_surge": "出来高急増: 平常時の%.1f倍",
+	"discovery.new_high":     "新高値: 直近%d営業日の高値を更新",
+	"discovery.added":        "✅ ウォッチリストに追加しました",
+	"discovery.hint":         "追加するには: stock-automation discover --add",
+
+	// Data quality report
+	"data_quality.title":        "🩺 価格データ品質レポート",
+	"data_quality.period":       "対象期間: %s 〜 %s",
+	"data_quality.summary":      "対象銘柄: %d件 / 要確認: %d件",
+	"data_quality.no_data":      "データなし",
+	"data_quality.missing":      "欠損率: %.1f%% (%d/%d日)",
+	"data_quality.anomalies":    "異常値: %d件",
+	"data_quality.last_updated": "最終更新日: %s",
+
+	// Paper trade report
+	"paper_trade.title":       "📝 ペーパートレード成績レポート",
+	"paper_trade.value":       "評価額: ¥%.0f (現金 ¥%.0f / 株式 ¥%.0f)",
+	"paper_trade.gain":        "損益: ¥%+.0f (%+.2f%%) / 初期資金 ¥%.0f",
+	"paper_trade.trades":      "約定回数: %d回",
+	"paper_trade.positions":   "保有ポジション",
+	"paper_trade.position":    "%s %d株 @¥%.2f → ¥%.2f (%+.2f%%)",
+	"paper_trade.comparison":  "実ポートフォリオとの比較",
+	"paper_trade.real":        "実ポートフォリオ: ¥%.0f (%+.2f%%)",
+	"paper_trade.paper_ahead": "ペーパートレードが上回っています",
+	"paper_trade.real_ahead":  "実ポートフォリオが上回っています",
+	"paper_trade.difference":  "差: %+.2fpt (%s)",
+
+	// Paper broker order messages
+	"broker.no_price_data":       "価格データがありません",
+	"broker.limit_not_reached":   "指値%.2fに届きませんでした(%s 安値%.2f 高値%.2f)",
+	"broker.insufficient_cash":   "買付余力が不足しています(必要%.0f円、残高%.0f円)",
+	"broker.insufficient_shares": "保有数量が不足しています(保有%d株)",
+
+	// Exit target notification
+	"exit_target.action.sell":       "売却",
+	"exit_target.action.cover":      "買い戻し",
+	"exit_target.take_profit.title": "利確ライン到達: %s (%s)",
+	"exit_target.take_profit.line":  "利確ライン: +%.2f%% (¥%.2f)",
+	"exit_target.stop_loss.title":   "損切りライン到達: %s (%s)",
+	"exit_target.stop_loss.line":    "損切りライン: -%.2f%% (¥%.2f)",
+	"exit_target.current_price":     "現在価格: ¥%.2f",
+	"exit_target.purchase_price":    "取得価格: ¥%.2f",
+	"exit_target.gain_percent":      "損益率: %.2f%%",
+	"exit_target.suggestion":        "%sを検討してください",
+
+	// Alert rule notification
+	"alert_rule.title":       "アラート: %s",
+	"alert_rule.stock":       "銘柄: %s",
+	"alert_rule.conditions":  "条件: %s",
+	"alert_rule.description": "説明: %s",
+	"alert_rule.values":      "指標: %s",
+
+	// Maintenance digest
+	"maintenance.digest_title":  "🛠️ メンテナンス中に抑制したアラート (%d件)",
+	"maintenance.digest_period": "期間: %s 〜 %s",
+	"maintenance.digest_reason": "理由: %s",
+	"maintenance.digest_more":   "...他%d件",
+
+	// Technical summary
+	"technical_summary.section":        "📈 テクニカルサマリー",
+	"technical_summary.item":           "%s %s (%s): RSI %.1f / MA %s / 乖離率 %+.1f%% / %s",
+	"technical_summary.unavailable":    "⚪ %s (%s): 指標未計算 (価格履歴不足)",
+	"technical_summary.trend.up":       "上昇",
+	"technical_summary.trend.down":     "下降",
+	"technical_summary.trend.sideways": "横ばい",
+	"technical_summary.action.buy":     "買い",
+	"technical_summary.action.sell":    "売り",
+	"technical_summary.action.hold":    "様子見",
+
+	// Buying power
+	"buying_power.section":      "💴 購入余力",
+	"buying_power.cash":         "現金残高: ¥%s (%.1f%%)",
+	"buying_power.invested":     "投下資本比率: %.1f%%",
+	"buying_power.reserved":     "目標キャッシュ (%.0f%%): ¥%s",
+	"buying_power.margin":       "信用建玉の必要保証金: ¥%s",
+	"buying_power.available":    "購入余力: ¥%s",
+	"buying_power.below_target": "⚠️ 現金残高が目標キャッシュポジションを下回っています",
+	"buying_power.signal_ok":    "購入余力内 (1単元 ¥%s)",
+	"buying_power.signal_over":  "購入余力不足 (1単元 ¥%s)",
+
+	// Market indices
+	"market.section":      "🌐 市況",
+	"market.index":        "%s: %s",
+	"market.index_change": "%s: %s (%+.2f%%)",
+	"market.benchmark":    "ポートフォリオ %+.2f%% vs %s %+.2f%% (差 %+.2fpt)",
+
+	// Morning briefing
+	"briefing.title":                "🌅 寄り前ブリーフィング (%s)",
+	"briefing.market_section":       "🌐 前日の市況",
+	"briefing.no_indices":           "指数の価格がありません",
+	"briefing.holdings_section":     "💼 保有銘柄の前日終値",
+	"briefing.holding":              "%s (%s): ¥%s",
+	"briefing.holding_change":       "%s (%s): ¥%s (%+.2f%%)",
+	"briefing.no_holdings":          "保有銘柄の価格がありません",
+	"briefing.earnings_section":     "📅 本日の決算発表",
+	"briefing.no_earnings":          "監視・保有銘柄の決算発表はありません",
+	"briefing.earnings_unavailable": "決算予定を取得できません (J-Quantsの認証情報が必要です)",
+
+	// Contribution analysis
+	"contribution.section":    "🏆 損益寄与度",
+	"contribution.top":        "▲ 寄与度トップ%d",
+	"contribution.worst":      "▼ 寄与度ワースト%d",
+	"contribution.gain_item":  "%d. %s (%s): ¥%s (%+.2f%%, 寄与 %+.2fpt)",
+	"contribution.movers":     "⚡ 今日最も動いた保有銘柄 (前日比 ¥%s, %+.2f%%)",
+	"contribution.mover_item": "%d. %s (%s): %+.2f%% ¥%s (寄与 %+.2fpt)",
+
+	// Portfolio goals
+	"goal.section":         "🎯 目標達成度",
+	"goal.line":            "%s %s: 進捗 %.1f%% (期間経過 %.0f%%) %s",
+	"goal.detail":          "評価額 ¥%s / 目標 ¥%s (期限 %s)",
+	"goal.status.on_track": "達成ペース",
+	"goal.status.behind":   "未達ペース",
+	"goal.status.achieved": "達成",
+	"goal.status.missed":   "期限切れ",
+	"goal.notify.achieved": "🎉 目標「%s」を達成しました: 評価額 ¥%s (目標 ¥%s)",
+	"goal.notify.missed":   "🔴 目標「%s」は期限 %s までに達成できませんでした: 評価額 ¥%s (目標 ¥%s)",
+	"goal.notify.behind":   "🟡 目標「%s」が未達ペースです: 進捗 %.1f%% / 期間経過 %.0f%% (残り ¥%s)",
+	"goal.notify.on_track": "🟢 目標「%s」が達成ペースに戻りました: 進捗 %.1f%% / 期間経過 %.0f%%",
+
+	// Monthly return calendar
+	"monthly_return.title":   "📆 月次騰落カレンダー",
+	"monthly_return.no_data": "スナップショットが不足しているため月次リターンを計算できません",
+	"monthly_return.legend":  "🟩 +3%%以上 🟢 0〜+3%% 🔴 -3〜0%% 🟥 -3%%以下 ⬜ データなし",
+	"monthly_return.line":    "%s %d年%d月: %+.2f%%",
+	"monthly_return.ytd":     "📈 年初来リターン (%d年): %+.2f%%",
+
+	// PDF report
+	"pdf_report.daily_title":          "ポートフォリオ日次レポート",
+	"pdf_report.monthly_title":        "月次ポートフォリオレポート (%d年%d月)",
+	"pdf_report.summary":              "サマリー",
+	"pdf_report.total_gain":           "損益: ¥%s (%+.2f%%)",
+	"pdf_report.holdings_count":       "保有銘柄数: %d",
+	"pdf_report.holdings":             "保有銘柄",
+	"pdf_report.column.code":          "コード",
+	"pdf_report.column.name":          "銘柄名",
+	"pdf_report.column.shares":        "株数",
+	"pdf_report.column.price":         "現在値",
+	"pdf_report.column.value":         "評価額",
+	"pdf_report.column.gain":          "損益",
+	"pdf_report.column.gain_percent":  "損益率",
+	"pdf_report.short":                "%s (空売り)",
+	"pdf_report.gain_chart":           "銘柄別損益 (円)",
+	"pdf_report.value_chart":          "評価額推移",
+	"pdf_report.monthly_return_chart": "月次リターン (%%)",
+	"pdf_report.ytd":                  "年初来リターン (%d年): %+.2f%%",
+	"pdf_report.email_subject":        "%s (%s)",
+	"pdf_report.email_body":           "%sをPDFで添付します。",
+
+	// Data source schema changes
+	"data_source.schema_changed":  "🚨 Yahoo Finance (%s) のレスポンス構造が想定と異なります。データ収集が0件になっている可能性があります。\n%s",
+	"data_source.quota_warning":   "⚠️ %s の本日のリクエスト数が上限の%.0f%%に達しました (%d / %d、残り%d)。収集頻度を自動的に下げています。リセット: %s",
+	"data_source.quota_exhausted": "🚨 %s の本日のリクエスト上限 (%d) に達しました。%s まで取得できません",
+
+	// Collection alerts
+	"collect_alert.error_rate": "🚨 価格収集のエラー率が高くなっています: %d / %d銘柄 (%.0f%%) が失敗",
+	"collect_alert.error_more": "...他%d銘柄",
+	"collect_alert.anomaly":    "⚠️ 価格異常値の可能性: %s 前回 ¥%.2f → 今回 ¥%.2f (%+.2f%%)",
+
+	// Price feed monitoring
+	"price_feed.stalled": "🚨 価格が更新されていない銘柄が多すぎます: %s の価格があるのは %d / %d銘柄 (%.0f%%)",
+	"price_feed.hint":    "収集ジョブはエラーなく終了している可能性があります。データソースの応答とスケジューラのログを確認してください",
+
+	// Collection quarantine
+	"collect_quarantine.quarantined": "⚠️ %s の価格取得が%d回連続で失敗したため、%s まで収集対象から外します（期限後に自動で再試行）: %s",
+
+	// Trade notes
+	"trade_note.ref":             "📝 %sノート (%s): %s",
+	"trade_note.kind.hypothesis": "投資仮説",
+	"trade_note.kind.entry":      "エントリー理由",
+	"trade_note.kind.review":     "振り返り",
+
+	// Price moves
+	"price_move.title": "📊 前回終値から±%g%%以上動いた銘柄 (%d銘柄)",
+	"price_move.line":  "- %s ¥%.2f → ¥%.2f (%+.2f%%)",
+
+	// Moving average deviation
+	"ma_deviation.title":      "🔥 25日移動平均乖離率が±%g%%を超えた銘柄 (%d銘柄)",
+	"ma_deviation.line":       "- %s ¥%.2f / 25日線 ¥%.2f (乖離率 %+.2f%%、%s)",
+	"ma_deviation.overheated": "買われすぎ",
+	"ma_deviation.oversold":   "売られすぎ",
+
+	// Hedge advice
+	"hedge.title":           "🛡️ 前回終値から%g%%以上下落した保有銘柄 (%d銘柄)",
+	"hedge.drop_line":       "- %s ¥%.2f → ¥%.2f (%+.2f%%、評価額 ¥%s)",
+	"hedge.advice_header":   "ヘッジ案:",
+	"hedge.inverse_etf":     "インバースETF %s を約¥%s買い、下落銘柄の評価額 ¥%s(ポートフォリオの%.1f%%)の%g%%をヘッジ",
+	"hedge.raise_cash":      "現金比率 %.1f%% を目標の%g%%まで引き上げるため、約¥%sを売却して現金化",
+	"hedge.reduce_position": "%s が%+.2f%%と大きく下落、保有の半分(約¥%s)の売却を検討",
+	"hedge.disclaimer":      "※ 提案のみで自動では執行しません",
+
+	// Scheduler
+	"scheduler.jobs_skipped":       "⏭️ 依存ジョブ %s が%sため、次のジョブをスキップしました: %s",
+	"scheduler.dependency_failed":  "失敗した",
+	"scheduler.dependency_skipped": "スキップされた",
+	"scheduler.dependency_error":   "エラー: %s",
+	"scheduler.job_running":        "⏳ ジョブ %s を実行中 (%s 開始)",
+	"scheduler.job_succeeded":      "✅ ジョブ %s が完了しました (%v)",
+	"scheduler.job_failed":         "❌ ジョブ %s が失敗しました (%v): %v",
+
+	// Price charts
+	"price_chart.title":   "📈 %s 終値チャート %s〜%s (%d日分)",
+	"price_chart.summary": "終値 ¥%.2f / 高値 ¥%.2f / 安値 ¥%.2f / 期間騰落率 %+.2f%%",
+	"price_chart.no_data": "📈 %s: 期間内の価格データがありません",
+
+	// Corporate events
+	"corporate_event.earnings":         "%s 決算発表",
+	"corporate_event.record_date":      "%s 権利確定日",
+	"corporate_event.dividend_payment": "%s 配当支払日",
+	"corporate_event.description":      "銘柄コード: %s\nstock-automation が登録した予定です",
+	"corporate_event.dividend_amount":  "1株あたり配当 %s円",
+
+	// Technical signals
+	"signal.rsi_oversold":      "RSI買いシグナル（売られすぎ）",
+	"signal.rsi_overbought":    "RSI売りシグナル（買われすぎ）",
+	"signal.macd_golden_cross": "MACDゴールデンクロス（買いシグナル）",
+	"signal.macd_dead_cross":   "MACDデッドクロス（売りシグナル）",
+	"signal.uptrend":           "上昇トレンド（価格 > 5日移動平均 > 25日移動平均）",
+	"signal.downtrend":         "下降トレンド（価格 < 5日移動平均 < 25日移動平均）",
+
+	// Slack notifications
+	"slack.stock_alert.title":      "株価アラート: %s (%s)",
+	"slack.stock_alert.type":       "%s通知",
+	"slack.daily_report.title":     "本日の投資状況レポート",
+	"slack.daily_report.portfolio": "ポートフォリオ状況",
+	"slack.comprehensive.title":    "デイリーポートフォリオレポート",
+	"slack.comprehensive.summary":  "ポートフォリオサマリー",
+	"slack.comprehensive.holdings": "保有銘柄詳細",
+	"slack.comprehensive.holding":  "数量: %s | 現在値: ¥%.0f | 損益: ¥%.0f (%.1f%%)",
+	"slack.field.current_price":    "現在価格",
+	"slack.field.target_price":     "目標価格",
+	"slack.field.deviation":        "乖離率",
+	"slack.field.time":             "時刻",
+	"slack.field.total_value":      "総資産",
+	"slack.field.total_cost":       "総投資額",
+	"slack.field.gain":             "損益",
+	"slack.field.gain_percent":     "損益率",
+	"slack.field.updated_at":       "更新時刻",
+}
@@ -0,0 +1,225 @@
+// Package retry retries failed operations with exponential backoff and jitter.
+//
+// Errors are retryable unless the policy classifies them as permanent or the operation marks them
+// with Permanent. An operation can also ask for a specific wait with After, e.g. from a Retry-After header.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Policy configures how an operation is retried.
+type Policy struct {
+	MaxRetries   int           // retries after the first attempt
+	InitialDelay time.Duration // wait before the first retry
+	MaxDelay     time.Duration // cap of a single wait, unlimited if zero
+	Multiplier   float64       // growth of the wait per retry, 2 if zero
+	Jitter       float64       // random share of the wait added or taken off, from 0 to 1
+	MaxElapsed   time.Duration // time after the first attempt beyond which no retry is started, unlimited if zero
+
+	// Retryable classifies the errors of the operation, all errors being retryable if nil
+	Retryable func(err error) bool
+	// OnRetry is called before waiting for a retry, e.g. to log the failure
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+// permanentError marks an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying, regardless of the policy.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// afterError marks a retryable error with the wait requested by the remote side.
+type afterError struct {
+	err  error
+	wait time.Duration
+}
+
+func (e *afterError) Error() string { return e.err.Error() }
+func (e *afterError) Unwrap() error { return e.err }
+
+// After marks err as retryable after wait instead of the backoff of the policy,
+// e.g. the wait asked for by a rate limited response.
+func After(err error, wait time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &afterError{err: err, wait: wait}
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retryable, the retries are used up,
+// MaxElapsed has passed or ctx is done. Returns the number of attempts made and the last error of fn
+// without the markers of Permanent and After, or the error of ctx if it was done before an attempt.
+func (p Policy) Do(ctx context.Context, fn func(ctx context.Context) error) (int, error) {
+	start := time.Now()
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			if lastErr != nil {
+				return attempt - 1, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+			return attempt - 1, err
+		}
+
+		err := fn(ctx)
+		if err == nil {
+			return attempt, nil
+		}
+		lastErr = unwrapMarker(err)
+
+		if attempt > p.MaxRetries || !p.retryable(err) || ctx.Err() != nil {
+			return attempt, lastErr
+		}
+
+		wait := p.Backoff(attempt)
+		var after *afterError
+		if errors.As(err, &after) && after.wait > 0 {
+			wait = after.wait
+		}
+		if p.MaxElapsed > 0 && time.Since(start)+wait > p.MaxElapsed {
+			return attempt, lastErr
+		}
+
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, lastErr, wait)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// Backoff returns the wait before the retry following the attempt (1 for the first attempt):
+// InitialDelay grown by Multiplier per retry, capped at MaxDelay, with the jitter applied.
+func (p Policy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	wait := float64(p.InitialDelay) * math.Pow(multiplier, float64(max(attempt-1, 0)))
+	if p.MaxDelay > 0 {
+		wait = math.Min(wait, float64(p.MaxDelay))
+	}
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		wait *= 1 - jitter + 2*jitter*rand.Float64()
+	}
+	return time.Duration(wait)
+}
+
+// retryable reports whether the error is worth retrying under the policy.
+func (p Policy) retryable(err error) bool {
+	if IsPermanent(err) {
+		return false
+	}
+	var after *afterError
+	if errors.As(err, &after) || p.Retryable == nil {
+		return true
+	}
+	return p.Retryable(err)
+}
+
+// unwrapMarker removes the marker of Permanent or After from an error returned as is.
+func unwrapMarker(err error) error {
+	switch e := err.(type) {
+	case *permanentError:
+		return e.err
+	case *afterError:
+		return e.err
+	default:
+		return err
+	}
+}
+
+// StatusError is the error of an HTTP response with a failure status.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string { return fmt.Sprintf("status code %d", e.StatusCode) }
+
+// IsTransientStatus reports whether an HTTP status is likely to change when the request is retried:
+// a request timeout, rate limiting or a server error other than an unsupported request.
+func IsTransientStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	case http.StatusNotImplemented, http.StatusHTTPVersionNotSupported:
+		return false
+	default:
+		return code >= 500
+	}
+}
+
+// IsTransient reports whether err is a failure likely to succeed when retried,
+// such as a timeout, a refused or reset connection, a temporary DNS failure or a transient HTTP status.
+// A host that does not exist is not retried.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Timeouts of dials, reads and lookups, also when wrapped in a *url.Error
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return IsTransientStatus(statusErr.StatusCode)
+	}
+
+	// Check error messages of errors that lost their type, e.g. formatted with %v
+	errMsg := strings.ToLower(err.Error())
+	retryablePatterns := []string{
+		"timeout",
+		"temporary failure",
+		"connection reset",
+		"connection refused",
+		"too many requests",
+		"service unavailable",
+	}
+
+	for _, pattern := range retryablePatterns {
+		if strings.Contains(errMsg, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
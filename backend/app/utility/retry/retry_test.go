@@ -0,0 +1,163 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var errTemporary = errors.New("temporary")
+
+func TestPolicy_Do(t *testing.T) {
+	tests := []struct {
+		name         string
+		errs         []error // errors of the attempts, succeeding after them
+		retryable    func(err error) bool
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "Succeeds at once", wantAttempts: 1},
+		{name: "Recovers after retries", errs: []error{errTemporary, errTemporary}, wantAttempts: 3},
+		{name: "Gives up after max retries", errs: []error{errTemporary, errTemporary, errTemporary, errTemporary}, wantAttempts: 3, wantErr: errTemporary},
+		{name: "Permanent error is not retried", errs: []error{Permanent(errTemporary)}, wantAttempts: 1, wantErr: errTemporary},
+		{
+			name:         "Error classified as permanent",
+			errs:         []error{errTemporary},
+			retryable:    func(err error) bool { return !errors.Is(err, errTemporary) },
+			wantAttempts: 1,
+			wantErr:      errTemporary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := Policy{MaxRetries: 2, InitialDelay: time.Millisecond, Retryable: tt.retryable}
+			calls := 0
+			attempts, err := policy.Do(context.Background(), func(ctx context.Context) error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+
+			if attempts != tt.wantAttempts || calls != tt.wantAttempts {
+				t.Errorf("Do() attempts = %d (called %d times), want %d", attempts, calls, tt.wantAttempts)
+			}
+			if err != tt.wantErr {
+				t.Errorf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicy_DoAfter(t *testing.T) {
+	var waits []time.Duration
+	policy := Policy{
+		MaxRetries:   1,
+		InitialDelay: time.Hour,
+		Retryable:    func(err error) bool { return false },
+		OnRetry:      func(attempt int, err error, wait time.Duration) { waits = append(waits, wait) },
+	}
+
+	calls := 0
+	_, err := policy.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return After(errTemporary, 10*time.Millisecond)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	// Retried after the requested wait even though the policy classifies the error as permanent
+	if len(waits) != 1 || waits[0] != 10*time.Millisecond {
+		t.Errorf("waits = %v, want [10ms]", waits)
+	}
+}
+
+func TestPolicy_DoCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	policy := Policy{MaxRetries: 3, InitialDelay: 5 * time.Second}
+	start := time.Now()
+	attempts, err := policy.Do(ctx, func(ctx context.Context) error {
+		return errTemporary
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want context.DeadlineExceeded", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Do() attempts = %d, want 1", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do() waited %v after the context was done", elapsed)
+	}
+}
+
+func TestPolicy_DoMaxElapsed(t *testing.T) {
+	policy := Policy{MaxRetries: 10, InitialDelay: 20 * time.Millisecond, Multiplier: 1, MaxElapsed: 50 * time.Millisecond}
+	attempts, err := policy.Do(context.Background(), func(ctx context.Context) error {
+		return errTemporary
+	})
+
+	// Retries at 20ms and 40ms, the next one would start after 60ms
+	if attempts != 3 || err != errTemporary {
+		t.Errorf("Do() = %d, %v, want 3 attempts and the last error", attempts, err)
+	}
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := Policy{InitialDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second} {
+		if got := policy.Backoff(attempt); got != want {
+			t.Errorf("Backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.Backoff(2); got < time.Second || got > 3*time.Second {
+			t.Fatalf("Backoff(2) with jitter = %v, want between 1s and 3s", got)
+		}
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: errors.New("dial tcp: lookup example.com: no such host"), want: false},
+		{err: errors.New("read: connection reset by peer"), want: true},
+		{err: context.DeadlineExceeded, want: true},
+		{err: syscall.ETIMEDOUT, want: true},
+		{err: &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}, want: true},
+		{err: &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}, want: true},
+		{err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}, want: false},
+		{err: &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}}}, want: false},
+		{err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}, want: true},
+		{err: &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}, want: true},
+		{err: fmt.Errorf("API returned %w", &StatusError{StatusCode: http.StatusServiceUnavailable}), want: true},
+		{err: &StatusError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{err: &StatusError{StatusCode: http.StatusNotImplemented}, want: false},
+		{err: &StatusError{StatusCode: http.StatusNotFound}, want: false},
+		{err: errors.New("invalid character in JSON"), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.want {
+			t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
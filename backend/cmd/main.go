@@ -23,6 +23,8 @@ func main() {
 		showVersion = flag.Bool("version", false, "Show version information")
 		logLevel    = flag.String("log-level", "info", "Log level (debug, info, warn, error)")
 		configPath  = flag.String("config", "configs/config.yaml", "Path to configuration file")
+		cpuProfile  = flag.String("cpuprofile", "", "Write a CPU profile of the command to the file")
+		memProfile  = flag.String("memprofile", "", "Write a heap profile of the command to the file and log its memory usage")
 	)
 
 	flag.Parse()
@@ -47,6 +49,14 @@ func main() {
 		ForceColors:   false,
 	})
 
+	// 補完スクリプトはデータベースに接続せずに出力する
+	if flag.Arg(0) == "completion" && (flag.Arg(1) == "bash" || flag.Arg(1) == "zsh") {
+		if err := interfaces.WriteCompletion(os.Stdout, flag.Arg(1)); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		return
+	}
+
 	// 設定ファイル読み込み
 	cfg, err := config.Load(*configPath)
 	if err != nil {
@@ -60,11 +70,19 @@ func main() {
 	}
 	defer container.Close()
 
+	// プロファイル(-cpuprofile/-memprofile)はコマンドの終了時に書き出す
+	profiler, err := startProfiling(*cpuProfile, *memProfile)
+	if err != nil {
+		log.Fatalf("Failed to start profiling: %v", err)
+	}
+
 	// CLIインターフェースの実行
 	cli := interfaces.NewCLI(container)
 	args := append([]string{os.Args[0]}, flag.Args()...)
 
-	if err := cli.Run(args); err != nil {
+	err = cli.Run(args)
+	profiler.stop()
+	if err != nil {
 		log.Fatalf("Error: %v", err)
 	}
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/sirupsen/logrus"
+)
+
+// profiler writes the CPU and heap profiles of a command, so that the memory used by the
+// collection of many stocks can be checked with go tool pprof.
+type profiler struct {
+	cpuFile    *os.File
+	memProfile string
+}
+
+// startProfiling starts the CPU profile if cpuProfile is set. The heap profile is written by stop.
+func startProfiling(cpuProfile, memProfile string) (*profiler, error) {
+	p := &profiler{memProfile: memProfile}
+	if cpuProfile != "" {
+		file, err := os.Create(cpuProfile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		p.cpuFile = file
+	}
+	return p, nil
+}
+
+// stop ends the CPU profile and writes the heap profile, logging the memory obtained from the
+// OS during the command, which is about its peak usage.
+func (p *profiler) stop() {
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		p.cpuFile.Close()
+	}
+	if p.memProfile == "" {
+		return
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	logrus.Infof("Memory: heap in use %.1f MiB, heap obtained %.1f MiB, total obtained %.1f MiB, allocated %.1f MiB in total, %d GCs",
+		mebibytes(stats.HeapInuse), mebibytes(stats.HeapSys), mebibytes(stats.Sys), mebibytes(stats.TotalAlloc), stats.NumGC)
+
+	file, err := os.Create(p.memProfile)
+	if err != nil {
+		logrus.Errorf("Failed to create heap profile: %v", err)
+		return
+	}
+	defer file.Close()
+	// The allocations of the whole command are kept in the profile (-sample_index=alloc_space)
+	if err := pprof.Lookup("allocs").WriteTo(file, 0); err != nil {
+		logrus.Errorf("Failed to write heap profile: %v", err)
+	}
+}
+
+func mebibytes(bytes uint64) float64 {
+	return float64(bytes) / (1 << 20)
+}
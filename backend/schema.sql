@@ -9,14 +9,64 @@ CREATE TABLE stock_prices (
     high_price DECIMAL(10,2) NOT NULL COMMENT '高値',
     low_price DECIMAL(10,2) NOT NULL COMMENT '安値',
     close_price DECIMAL(10,2) NOT NULL COMMENT '終値',
+    adj_close_price DECIMAL(12,4) COMMENT '分割調整後終値',
     volume BIGINT NOT NULL COMMENT '出来高',
+    source VARCHAR(20) NOT NULL DEFAULT '' COMMENT '取得元データソース',
+    fetched_at DATETIME COMMENT '取得日時',
+    is_final BOOLEAN NOT NULL DEFAULT FALSE COMMENT '確定値フラグ(ザラ場中の取得値は暫定)',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
     UNIQUE KEY unique_code_date (code, `date`),
-    INDEX idx_code (code),
     INDEX idx_date (`date`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='株価データ';
 
+-- 最新株価テーブル(銘柄ごとにstock_pricesの最新行を保持するキャッシュ)
+CREATE TABLE latest_prices (
+    code VARCHAR(10) PRIMARY KEY COMMENT '銘柄コード',
+    `date` DATE NOT NULL COMMENT '取引日',
+    open_price DECIMAL(10,2) NOT NULL COMMENT '始値',
+    high_price DECIMAL(10,2) NOT NULL COMMENT '高値',
+    low_price DECIMAL(10,2) NOT NULL COMMENT '安値',
+    close_price DECIMAL(10,2) NOT NULL COMMENT '終値',
+    adj_close_price DECIMAL(12,4) COMMENT '分割調整後終値',
+    volume BIGINT NOT NULL COMMENT '出来高',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='最新株価';
+
+-- 週足テーブル(日足から集計)
+CREATE TABLE stock_prices_weekly (
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    period_start DATE NOT NULL COMMENT '週の開始日(月曜日)',
+    period_end DATE NOT NULL COMMENT '週内の最終取引日',
+    open_price DECIMAL(10,2) NOT NULL COMMENT '始値',
+    high_price DECIMAL(10,2) NOT NULL COMMENT '高値',
+    low_price DECIMAL(10,2) NOT NULL COMMENT '安値',
+    close_price DECIMAL(10,2) NOT NULL COMMENT '終値',
+    adj_close_price DECIMAL(12,4) COMMENT '分割調整後終値',
+    volume BIGINT NOT NULL COMMENT '出来高',
+    trading_days INT NOT NULL COMMENT '取引日数',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    PRIMARY KEY (code, period_start)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='週足';
+
+-- 月足テーブル(日足から集計)
+CREATE TABLE stock_prices_monthly (
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    period_start DATE NOT NULL COMMENT '月初日',
+    period_end DATE NOT NULL COMMENT '月内の最終取引日',
+    open_price DECIMAL(10,2) NOT NULL COMMENT '始値',
+    high_price DECIMAL(10,2) NOT NULL COMMENT '高値',
+    low_price DECIMAL(10,2) NOT NULL COMMENT '安値',
+    close_price DECIMAL(10,2) NOT NULL COMMENT '終値',
+    adj_close_price DECIMAL(12,4) COMMENT '分割調整後終値',
+    volume BIGINT NOT NULL COMMENT '出来高',
+    trading_days INT NOT NULL COMMENT '取引日数',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    PRIMARY KEY (code, period_start)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='月足';
+
 -- テクニカル指標テーブル
 CREATE TABLE technical_indicators (
     id VARCHAR(26) PRIMARY KEY,
@@ -29,10 +79,10 @@ CREATE TABLE technical_indicators (
     sma_5 DECIMAL(10,2) COMMENT '5日移動平均',
     sma_25 DECIMAL(10,2) COMMENT '25日移動平均',
     sma_75 DECIMAL(10,2) COMMENT '75日移動平均',
+    ma25_deviation DECIMAL(7,2) COMMENT '25日移動平均乖離率(%)',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
     UNIQUE KEY unique_code_date (code, `date`),
-    INDEX idx_code (code),
     INDEX idx_date (`date`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='テクニカル指標';
 
@@ -41,14 +91,34 @@ CREATE TABLE portfolios (
     id VARCHAR(26) PRIMARY KEY,
     code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
     name VARCHAR(100) NOT NULL COMMENT '銘柄名',
-    shares INT NOT NULL COMMENT '保有株数',
+    shares BIGINT NOT NULL COMMENT '保有株数(暗号資産は1億分の1単位)',
     purchase_price DECIMAL(10,2) NOT NULL COMMENT '購入価格',
     purchase_date DATE NOT NULL COMMENT '購入日',
+    position_type VARCHAR(10) NOT NULL DEFAULT 'long' COMMENT 'ポジション種別(long/short)',
+    margin_rate DECIMAL(5,2) COMMENT '委託保証金率(%)',
+    interest_rate DECIMAL(5,2) COMMENT '年率金利・貸株料(%)',
+    asset_class VARCHAR(10) NOT NULL DEFAULT 'stock' COMMENT '資産クラス(stock/etf/fund/crypto/cash)',
+    isin VARCHAR(12) COMMENT 'ISINコード(投資信託)',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    deleted_at DATETIME COMMENT '削除日時(論理削除)',
     INDEX idx_code (code)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ポートフォリオ';
 
+-- ポートフォリオ取得ロットテーブル
+CREATE TABLE portfolio_lots (
+    id VARCHAR(26) PRIMARY KEY,
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    shares INT NOT NULL COMMENT '残株数',
+    purchase_price DECIMAL(10,2) NOT NULL COMMENT '取得単価',
+    average_price DECIMAL(10,2) COMMENT '平均取得単価(移動平均法で売却した後)',
+    purchase_date DATE NOT NULL COMMENT '取得日',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    deleted_at DATETIME COMMENT '削除日時(論理削除)',
+    INDEX idx_code_purchase_date (code, purchase_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ポートフォリオ取得ロット';
+
 -- ウォッチリストテーブル
 CREATE TABLE watch_lists (
     id VARCHAR(26) PRIMARY KEY,
@@ -57,8 +127,291 @@ CREATE TABLE watch_lists (
     target_buy_price DECIMAL(10,2) COMMENT '目標買い価格',
     target_sell_price DECIMAL(10,2) COMMENT '目標売り価格',
     is_active BOOLEAN DEFAULT TRUE COMMENT 'アクティブフラグ',
+    collect_interval_minutes INT NOT NULL DEFAULT 0 COMMENT '収集間隔(分、0は既定の間隔)',
+    muted_until DATETIME COMMENT '通知ミュート期限',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    deleted_at DATETIME COMMENT '削除日時(論理削除)',
     UNIQUE KEY unique_code (code),
     INDEX idx_active (is_active)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ウォッチリスト';
\ No newline at end of file
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ウォッチリスト';
+
+-- 戦略プロファイルテーブル
+CREATE TABLE strategy_profiles (
+    id VARCHAR(26) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'プロファイル名',
+    description VARCHAR(255) COMMENT '説明',
+    parameters JSON NOT NULL COMMENT 'テクニカル指標パラメータ',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    UNIQUE KEY unique_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='戦略プロファイル';
+
+-- 銘柄別戦略プロファイル割当テーブル
+CREATE TABLE stock_strategy_profiles (
+    code VARCHAR(10) PRIMARY KEY COMMENT '銘柄コード',
+    strategy_profile_id VARCHAR(26) NOT NULL COMMENT '戦略プロファイルID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    INDEX idx_strategy_profile_id (strategy_profile_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='銘柄別戦略プロファイル割当';
+
+-- 財務指標テーブル
+CREATE TABLE stock_fundamentals (
+    code VARCHAR(10) PRIMARY KEY COMMENT '銘柄コード',
+    per DECIMAL(10,2) COMMENT '株価収益率',
+    pbr DECIMAL(10,2) COMMENT '株価純資産倍率',
+    roe DECIMAL(10,2) COMMENT '自己資本利益率(%)',
+    dividend_yield DECIMAL(10,2) COMMENT '配当利回り(%)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='財務指標';
+
+-- 利確・損切りラインテーブル
+CREATE TABLE exit_targets (
+    code VARCHAR(10) PRIMARY KEY COMMENT '銘柄コード',
+    take_profit_percent DECIMAL(5,2) NOT NULL DEFAULT 20.00 COMMENT '利確ライン(損益率%)',
+    stop_loss_percent DECIMAL(5,2) NOT NULL DEFAULT 10.00 COMMENT '損切りライン(損失率%)',
+    take_profit_notified BOOLEAN NOT NULL DEFAULT FALSE COMMENT '利確ライン通知済み',
+    stop_loss_notified BOOLEAN NOT NULL DEFAULT FALSE COMMENT '損切りライン通知済み',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='利確・損切りライン';
+
+-- ポートフォリオ日次スナップショットテーブル
+CREATE TABLE portfolio_snapshots (
+    snapshot_date DATE PRIMARY KEY COMMENT 'スナップショット日',
+    total_value DECIMAL(15,2) NOT NULL COMMENT '評価額',
+    total_cost DECIMAL(15,2) NOT NULL COMMENT '取得額',
+    total_gain DECIMAL(15,2) NOT NULL COMMENT '評価損益',
+    holdings_count INT NOT NULL DEFAULT 0 COMMENT '保有銘柄数',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ポートフォリオ日次スナップショット';
+
+-- ポートフォリオ目標テーブル
+CREATE TABLE portfolio_goals (
+    id VARCHAR(26) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL COMMENT '目標名',
+    baseline_value DECIMAL(15,2) NOT NULL COMMENT '設定時の評価額',
+    target_value DECIMAL(15,2) NOT NULL COMMENT '目標評価額',
+    start_date DATE NOT NULL COMMENT '開始日',
+    deadline DATE NOT NULL COMMENT '期限',
+    last_status VARCHAR(10) NOT NULL DEFAULT '' COMMENT '最後に通知したペース',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    UNIQUE KEY uk_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ポートフォリオ目標';
+
+-- アラートルールテーブル
+CREATE TABLE alert_rules (
+    id VARCHAR(26) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'ルール名',
+    definition TEXT NOT NULL COMMENT 'ルール定義(YAML)',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE COMMENT '有効フラグ',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    UNIQUE KEY unique_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='アラートルール';
+
+-- アラートルール評価履歴テーブル
+CREATE TABLE alert_rule_evaluations (
+    id VARCHAR(26) PRIMARY KEY,
+    alert_rule_id VARCHAR(26) NOT NULL COMMENT 'アラートルールID',
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    metric_values JSON NOT NULL COMMENT '評価時の指標値',
+    notified BOOLEAN NOT NULL DEFAULT FALSE COMMENT '通知済み',
+    evaluated_at TIMESTAMP NOT NULL COMMENT '評価日時',
+    INDEX idx_rule_code_evaluated_at (alert_rule_id, code, evaluated_at),
+    INDEX idx_evaluated_at (evaluated_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='アラートルール評価履歴';
+
+-- 監査ログテーブル
+CREATE TABLE audit_logs (
+    id VARCHAR(26) PRIMARY KEY,
+    source VARCHAR(20) NOT NULL COMMENT '操作元(cli/api/scheduler)',
+    actor VARCHAR(100) NOT NULL DEFAULT '' COMMENT '操作者',
+    action VARCHAR(10) NOT NULL COMMENT '操作(create/update/delete/restore)',
+    entity_type VARCHAR(20) NOT NULL COMMENT '対象種別',
+    entity_id VARCHAR(26) NOT NULL COMMENT '対象ID',
+    code VARCHAR(10) NOT NULL DEFAULT '' COMMENT '銘柄コード',
+    before_value JSON NULL COMMENT '変更前の値',
+    after_value JSON NULL COMMENT '変更後の値',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '記録日時',
+    INDEX idx_entity (entity_type, entity_id, created_at),
+    INDEX idx_code_created_at (code, created_at),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='監査ログ';
+
+-- ブローカー注文テーブル
+CREATE TABLE broker_orders (
+    id VARCHAR(26) PRIMARY KEY,
+    broker VARCHAR(20) NOT NULL COMMENT 'ブローカー名',
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    side VARCHAR(4) NOT NULL COMMENT '売買区分(buy/sell)',
+    order_type VARCHAR(10) NOT NULL COMMENT '注文種別(market/limit)',
+    quantity INT NOT NULL COMMENT '注文数量',
+    limit_price DECIMAL(10,2) NULL COMMENT '指値',
+    status VARCHAR(10) NOT NULL COMMENT '注文状態(pending/filled/canceled/rejected)',
+    filled_quantity INT NOT NULL DEFAULT 0 COMMENT '約定数量',
+    average_price DECIMAL(10,2) NULL COMMENT '平均約定価格',
+    message VARCHAR(255) NULL COMMENT '取消・拒否理由',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    INDEX idx_broker_created_at (broker, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ブローカー注文';
+
+-- ブローカー約定テーブル
+CREATE TABLE broker_executions (
+    id VARCHAR(26) PRIMARY KEY,
+    order_id VARCHAR(26) NOT NULL COMMENT '注文ID',
+    broker VARCHAR(20) NOT NULL COMMENT 'ブローカー名',
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    side VARCHAR(4) NOT NULL COMMENT '売買区分(buy/sell)',
+    quantity INT NOT NULL COMMENT '約定数量',
+    price DECIMAL(10,2) NOT NULL COMMENT '約定価格',
+    executed_at TIMESTAMP NOT NULL COMMENT '約定日時',
+    INDEX idx_order_id (order_id),
+    INDEX idx_broker_executed_at (broker, executed_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ブローカー約定';
+
+-- メンテナンスウィンドウテーブル
+CREATE TABLE maintenance_windows (
+    id VARCHAR(26) PRIMARY KEY,
+    starts_at TIMESTAMP NOT NULL COMMENT '開始日時',
+    ends_at TIMESTAMP NOT NULL COMMENT '終了日時',
+    reason VARCHAR(255) NOT NULL DEFAULT '' COMMENT '理由',
+    digest_sent_at TIMESTAMP NULL COMMENT 'ダイジェスト送信日時',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時',
+    INDEX idx_ends_at (ends_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='メンテナンスウィンドウ';
+
+-- 抑制アラートテーブル
+CREATE TABLE suppressed_alerts (
+    id VARCHAR(26) PRIMARY KEY,
+    maintenance_window_id VARCHAR(26) NOT NULL COMMENT 'メンテナンスウィンドウID',
+    alert_type VARCHAR(50) NOT NULL COMMENT 'アラート種別',
+    message TEXT NOT NULL COMMENT 'メッセージ',
+    suppressed_at TIMESTAMP NOT NULL COMMENT '抑制日時',
+    INDEX idx_maintenance_window_id (maintenance_window_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='メンテナンス中に抑制したアラート';
+
+-- ゼロダウンタイムマイグレーションテーブル(dual-writeのフェーズとバックフィル進捗)
+CREATE TABLE schema_migrations (
+    name VARCHAR(100) PRIMARY KEY,
+    phase VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT 'フェーズ (pending, dual_write, backfilling, read_new, completed)',
+    total_rows BIGINT NOT NULL DEFAULT 0 COMMENT 'バックフィル対象行数',
+    backfilled_rows BIGINT NOT NULL DEFAULT 0 COMMENT 'バックフィル済み行数',
+    last_key VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'バックフィル済みの最後のキー',
+    started_at TIMESTAMP NULL COMMENT 'dual-write開始日時',
+    backfill_started_at TIMESTAMP NULL COMMENT 'バックフィル開始日時',
+    backfilled_at TIMESTAMP NULL COMMENT 'バックフィル完了日時',
+    completed_at TIMESTAMP NULL COMMENT '移行完了日時',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ゼロダウンタイムマイグレーション';
+
+-- フィーチャーフラグテーブル(フラグファイルと既定値より優先される上書き)
+CREATE TABLE feature_flags (
+    name VARCHAR(100) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL COMMENT '有効フラグ',
+    updated_by VARCHAR(100) NOT NULL DEFAULT '' COMMENT '変更者',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='フィーチャーフラグ';
+
+-- アドバイス履歴テーブル(提案のみで自動執行しない)
+CREATE TABLE advice_logs (
+    id VARCHAR(26) PRIMARY KEY,
+    advice_type VARCHAR(20) NOT NULL COMMENT 'アドバイス種別(hedge)',
+    action VARCHAR(20) NOT NULL COMMENT '提案内容(inverse_etf/raise_cash/reduce_position)',
+    code VARCHAR(10) NOT NULL DEFAULT '' COMMENT '対象銘柄コード',
+    amount DECIMAL(15,2) NOT NULL DEFAULT 0 COMMENT '提案金額',
+    reason TEXT NOT NULL COMMENT '提案理由',
+    trigger_codes VARCHAR(255) NOT NULL DEFAULT '' COMMENT '提案のきっかけになった銘柄コード(カンマ区切り)',
+    notified BOOLEAN NOT NULL DEFAULT FALSE COMMENT '通知済み',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '記録日時',
+    INDEX idx_type_created_at (advice_type, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='アドバイス履歴';
+
+-- 通知スレッドテーブル
+CREATE TABLE notification_threads (
+    thread_key VARCHAR(100) PRIMARY KEY COMMENT 'スレッドキー(例: daily-report:2024-01-15)',
+    channel VARCHAR(50) NOT NULL COMMENT 'SlackチャンネルID',
+    ts VARCHAR(30) NOT NULL COMMENT '親メッセージのタイムスタンプ',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '作成日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Slack通知のスレッド';
+
+-- 入出金履歴テーブル
+CREATE TABLE cash_transactions (
+    id VARCHAR(26) PRIMARY KEY,
+    transaction_type VARCHAR(20) NOT NULL COMMENT '入出金種別(deposit/withdrawal)',
+    amount BIGINT NOT NULL COMMENT '金額(円)',
+    balance_after BIGINT NOT NULL COMMENT '入出金後の現金残高(円)',
+    note VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'メモ',
+    transacted_on DATE NOT NULL COMMENT '入出金日',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '記録日時',
+    INDEX idx_transacted_on (transacted_on)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='現金の入出金履歴';
+
+-- 銘柄ニックネームテーブル
+CREATE TABLE stock_nicknames (
+    code VARCHAR(10) PRIMARY KEY COMMENT '銘柄コード',
+    nickname VARCHAR(50) NOT NULL COMMENT 'レポート・CLI・通知で正式名の代わりに表示する名前',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '作成日時',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='銘柄の表示名';
+
+-- APIレスポンス生データテーブル
+CREATE TABLE raw_responses (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    source VARCHAR(20) NOT NULL COMMENT 'データソース(yahoo)',
+    endpoint VARCHAR(20) NOT NULL COMMENT 'エンドポイント(current/historical/intraday)',
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    url TEXT NOT NULL COMMENT 'リクエストURL',
+    status_code INT NOT NULL COMMENT 'HTTPステータスコード',
+    body MEDIUMBLOB NOT NULL COMMENT 'レスポンスボディ',
+    truncated BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'ボディが上限で切り捨てられたか',
+    error TEXT NOT NULL COMMENT 'パースに失敗したエラー',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '保存日時',
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='パースに失敗したAPIレスポンスの生データ(デバッグ用)';
+
+-- 共有リンクテーブル
+CREATE TABLE share_links (
+    id VARCHAR(26) PRIMARY KEY,
+    label VARCHAR(100) NOT NULL DEFAULT '' COMMENT '共有相手などのメモ',
+    expires_at TIMESTAMP(3) NOT NULL COMMENT '有効期限',
+    revoked_at TIMESTAMP(3) NULL DEFAULT NULL COMMENT '失効日時',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '作成日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='読み取り専用レポートの共有リンク';
+
+-- 価格取得失敗テーブル
+CREATE TABLE collect_failures (
+    code VARCHAR(10) PRIMARY KEY COMMENT '銘柄コード',
+    consecutive_failures INT NOT NULL COMMENT '連続失敗回数',
+    last_error TEXT NOT NULL COMMENT '最後のエラー',
+    last_failed_at TIMESTAMP(3) NOT NULL COMMENT '最後に失敗した日時',
+    quarantined_until TIMESTAMP(3) NULL DEFAULT NULL COMMENT '隔離期限(期限後に再試行)',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='価格取得に連続で失敗している銘柄と隔離期限';
+
+-- レポート設定テーブル
+CREATE TABLE report_preferences (
+    section VARCHAR(30) PRIMARY KEY COMMENT 'レポートの項目(holdings/allocations/buying_power/technicals/contributions/goals)',
+    enabled BOOLEAN NOT NULL COMMENT '日次レポートに表示するか',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新日時'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='日次レポートの項目の表示設定(未設定の項目は表示)';
+
+-- 取引アイデアノートテーブル
+CREATE TABLE trade_notes (
+    id VARCHAR(26) PRIMARY KEY,
+    code VARCHAR(10) NOT NULL COMMENT '銘柄コード',
+    kind VARCHAR(20) NOT NULL COMMENT '種別(hypothesis/entry/review)',
+    body TEXT NOT NULL COMMENT '本文',
+    source VARCHAR(100) NOT NULL DEFAULT '' COMMENT '参照元(signal:<ルール名>/report:<日付>)',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '作成日時',
+    updated_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3) COMMENT '更新日時',
+    INDEX idx_code_created (code, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='銘柄ごとの投資仮説・エントリー理由・振り返りメモ';
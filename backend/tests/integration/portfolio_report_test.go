@@ -1,7 +1,10 @@
+//go:build integration
+
 package integration
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,15 +24,19 @@ type mockStockDataClient struct{}
 
 var _ client.StockDataClient = (*mockStockDataClient)(nil)
 
-func (m *mockStockDataClient) GetCurrentPrice(stockCode string) (*models.StockPrice, error) {
+func (m *mockStockDataClient) GetCurrentPrice(ctx context.Context, stockCode string) (*models.StockPrice, error) {
+	return nil, nil
+}
+
+func (m *mockStockDataClient) GetHistoricalData(ctx context.Context, stockCode string, days int) ([]*models.StockPrice, error) {
 	return nil, nil
 }
 
-func (m *mockStockDataClient) GetHistoricalData(stockCode string, days int) ([]*models.StockPrice, error) {
+func (m *mockStockDataClient) GetHistoricalDataRange(ctx context.Context, stockCode string, from, to time.Time) ([]*models.StockPrice, error) {
 	return nil, nil
 }
 
-func (m *mockStockDataClient) GetIntradayData(stockCode string, interval string) ([]*models.StockPrice, error) {
+func (m *mockStockDataClient) GetIntradayData(ctx context.Context, stockCode string, interval string) ([]*models.StockPrice, error) {
 	return nil, nil
 }
 
@@ -45,13 +52,17 @@ type mockNotificationService struct {
 
 var _ notification.NotificationService = (*mockNotificationService)(nil)
 
-func (m *mockNotificationService) SendMessage(message string) error {
+func (m *mockNotificationService) SendMessage(ctx context.Context, message string) error {
 	m.sendMessageCalled = true
 	m.lastMessage = message
 	return nil
 }
 
-func (m *mockNotificationService) SendDailyReport(totalValue, totalGain, gainPercent float64) error {
+func (m *mockNotificationService) SendMessageOfKind(ctx context.Context, kind notification.MessageKind, message string) error {
+	return m.SendMessage(ctx, message)
+}
+
+func (m *mockNotificationService) SendDailyReport(ctx context.Context, totalValue, totalGain, gainPercent float64) error {
 	m.sendDailyReportCalled = true
 	m.lastTotalValue = totalValue
 	m.lastTotalGain = totalGain
@@ -59,7 +70,7 @@ func (m *mockNotificationService) SendDailyReport(totalValue, totalGain, gainPer
 	return nil
 }
 
-func (m *mockNotificationService) SendStockAlert(stockCode string, stockName string, currentPrice float64, changePercent float64, alertType string) error {
+func (m *mockNotificationService) SendStockAlert(ctx context.Context, stockCode string, stockName string, currentPrice float64, changePercent float64, alertType string) error {
 	return nil
 }
 
@@ -188,7 +199,7 @@ func TestPortfolioReportUseCase_GenerateAndSendDailyReport(t *testing.T) {
 			notifier := &mockNotificationService{}
 
 			// Create use case with real repositories and mock external services
-			uc := usecase.NewPortfolioReportUseCase(stockRepo, portfolioRepo, &mockStockDataClient{}, notifier)
+			uc := usecase.NewPortfolioReportUseCase(stockRepo, portfolioRepo, nil, nil, &mockStockDataClient{}, notifier)
 
 			// Execute test
 			err := uc.GenerateAndSendDailyReport(ctx)
@@ -333,7 +344,7 @@ func TestPortfolioReportUseCase_GenerateComprehensiveDailyReport(t *testing.T) {
 			tt.setupFunc(t)
 
 			// Create use case with real repositories and mock external services
-			uc := usecase.NewPortfolioReportUseCase(stockRepo, portfolioRepo, &mockStockDataClient{}, &mockNotificationService{})
+			uc := usecase.NewPortfolioReportUseCase(stockRepo, portfolioRepo, nil, nil, &mockStockDataClient{}, &mockNotificationService{})
 
 			// Execute test
 			report, err := uc.GenerateComprehensiveDailyReport(ctx)
@@ -345,7 +356,7 @@ func TestPortfolioReportUseCase_GenerateComprehensiveDailyReport(t *testing.T) {
 
 			if !tt.wantErr {
 				for _, expected := range tt.expectedContains {
-					if !contains(report, expected) {
+					if !strings.Contains(report, expected) {
 						t.Errorf("report does not contain expected string: %s\nActual report:\n%s", expected, report)
 					}
 				}
@@ -430,7 +441,7 @@ func TestPortfolioReportUseCase_GetPortfolioStatistics(t *testing.T) {
 			tt.setupFunc(t)
 
 			// Create use case with real repositories and mock external services
-			uc := usecase.NewPortfolioReportUseCase(stockRepo, portfolioRepo, &mockStockDataClient{}, &mockNotificationService{})
+			uc := usecase.NewPortfolioReportUseCase(stockRepo, portfolioRepo, nil, nil, &mockStockDataClient{}, &mockNotificationService{})
 
 			// Execute test
 			summary, err := uc.GetPortfolioStatistics(ctx)
@@ -458,19 +469,3 @@ func TestPortfolioReportUseCase_GetPortfolioStatistics(t *testing.T) {
 		})
 	}
 }
-
-// Helper function to check if a string contains a substring
-func contains(s, substr string) bool {
-	if len(substr) == 0 {
-		return true
-	}
-	if len(s) < len(substr) {
-		return false
-	}
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
@@ -0,0 +1,125 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/testutil"
+	"github.com/boost-jp/stock-automation/app/testutil/fixture"
+	"github.com/boost-jp/stock-automation/app/usecase"
+)
+
+// countArchivedRows returns the number of JSON Lines rows of a gzipped archive file.
+func countArchivedRows(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open the archive: %v", err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Failed to read the archive: %v", err)
+	}
+
+	rows := 0
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		rows++
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Failed to read the archive: %v", err)
+	}
+	return rows
+}
+
+func TestRetentionUseCase_Apply(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	prices, _ := domain.GetRetentionTarget("stock_prices")
+	indicators, _ := domain.GetRetentionTarget("technical_indicators")
+	policy := &domain.RetentionPolicy{
+		ArchiveDir: t.TempDir(),
+		Rules: []domain.RetentionRule{
+			{Target: prices, Keep: domain.RetentionPeriod{Days: 30}, Action: domain.RetentionArchive},
+			{Target: indicators, Keep: domain.RetentionPeriod{Days: 30}, Action: domain.RetentionDelete},
+		},
+	}
+	uc := usecase.NewRetentionUseCase(
+		repository.NewRetentionRepository(testDB.GetBoilDB()),
+		repository.NewTransactionManager(testDB.GetDB()),
+		policy,
+	)
+
+	// Three days past the retention period and one within it
+	today := time.Now()
+	for i, days := range []int{-40, -35, -31, -1} {
+		day := today.AddDate(0, 0, days)
+		if err := fixture.NewStockPrice().WithCode("7203").WithDate(day).Insert(ctx, testDB.GetBoilDB()); err != nil {
+			t.Fatalf("Failed to insert test stock price: %v", err)
+		}
+		if err := testDB.ExecSQL("INSERT INTO technical_indicators (id, code, date) VALUES (?, '7203', ?)",
+			fmt.Sprintf("01HQINDICATOR%013d", i), day); err != nil {
+			t.Fatalf("Failed to insert test indicator: %v", err)
+		}
+	}
+
+	// A dry run only counts the rows
+	results, err := uc.Apply(ctx, true)
+	if err != nil {
+		t.Fatalf("Apply(dry run) error = %v", err)
+	}
+	for _, result := range results {
+		if result.Rows != 3 || result.ArchivePath != "" {
+			t.Errorf("Apply(dry run) of %s = %d rows archived to %q, want 3 rows counted", result.Rule.Target.Name, result.Rows, result.ArchivePath)
+		}
+	}
+
+	results, err = uc.Apply(ctx, false)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if got := results[0]; got.Rows != 3 || got.ArchivePath == "" {
+		t.Fatalf("Apply() of stock_prices = %d rows archived to %q, want 3 rows archived", got.Rows, got.ArchivePath)
+	}
+	if rows := countArchivedRows(t, results[0].ArchivePath); rows != 3 {
+		t.Errorf("Archive has %d rows, want the 3 deleted", rows)
+	}
+	if got := results[1]; got.Rows != 3 || got.ArchivePath != "" {
+		t.Errorf("Apply() of technical_indicators = %d rows archived to %q, want 3 rows deleted", got.Rows, got.ArchivePath)
+	}
+
+	for _, table := range []string{"stock_prices", "technical_indicators"} {
+		var count int
+		if err := testDB.GetDB().QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&count); err != nil {
+			t.Fatalf("Failed to count %s: %v", table, err)
+		}
+		if count != 1 {
+			t.Errorf("%s has %d rows left, want the 1 within the retention period", table, count)
+		}
+	}
+
+	// Nothing is left to archive, so no file is written
+	results, err = uc.Apply(ctx, false)
+	if err != nil {
+		t.Fatalf("Apply() again error = %v", err)
+	}
+	if got := results[0]; got.Rows != 0 || got.ArchivePath != "" {
+		t.Errorf("Apply() again of stock_prices = %d rows archived to %q, want nothing archived", got.Rows, got.ArchivePath)
+	}
+}
@@ -0,0 +1,139 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boost-jp/stock-automation/app/domain"
+	"github.com/boost-jp/stock-automation/app/domain/models"
+	"github.com/boost-jp/stock-automation/app/infrastructure/repository"
+	"github.com/boost-jp/stock-automation/app/testutil"
+	"github.com/boost-jp/stock-automation/app/usecase"
+)
+
+// backfillItemsMigration copies the integer price of backfill_items to a new decimal column.
+const backfillItemsMigration = "backfill_items_price_decimal"
+
+func init() {
+	domain.RegisterSchemaMigration(domain.SchemaMigrationDefinition{
+		Name:      backfillItemsMigration,
+		Table:     "backfill_items",
+		KeyColumn: "id",
+		Backfill:  "price_decimal = price",
+		Pending:   "price_decimal IS NULL",
+	})
+}
+
+func TestSchemaMigrationUseCase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	testDB := testutil.NewTestDB(t)
+	defer testDB.Cleanup()
+
+	if err := testDB.ExecSQL(`CREATE TABLE backfill_items (
+		id VARCHAR(26) PRIMARY KEY,
+		price INT NOT NULL,
+		price_decimal DECIMAL(10,2) NULL
+	)`); err != nil {
+		t.Fatalf("Failed to create backfill_items: %v", err)
+	}
+
+	migrationRepo := repository.NewSchemaMigrationRepository(testDB.GetBoilDB())
+	phases := repository.NewSchemaMigrationPhases(migrationRepo, time.Minute)
+	uc := usecase.NewSchemaMigrationUseCase(migrationRepo, repository.NewTransactionManager(testDB.GetDB()), phases)
+
+	// writeItem writes a row the way the application does, to the new column only while dual-writing
+	writeItem := func(id string) {
+		t.Helper()
+		query := "INSERT INTO backfill_items (id, price) VALUES (?, 100)"
+		if phases.WritesNew(ctx, backfillItemsMigration) {
+			query = "INSERT INTO backfill_items (id, price, price_decimal) VALUES (?, 100, 100)"
+		}
+		if err := testDB.ExecSQL(query, id); err != nil {
+			t.Fatalf("Failed to write %s: %v", id, err)
+		}
+	}
+	countPending := func() int {
+		t.Helper()
+		var count int
+		if err := testDB.GetDB().QueryRowContext(ctx, "SELECT COUNT(*) FROM backfill_items WHERE price_decimal IS NULL").Scan(&count); err != nil {
+			t.Fatalf("Failed to count pending rows: %v", err)
+		}
+		return count
+	}
+
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		writeItem(id)
+	}
+
+	// Starting the migration begins dual-writing right away in this process
+	migration, err := uc.Advance(ctx, backfillItemsMigration)
+	if err != nil || migration.Phase != models.SchemaMigrationDualWrite {
+		t.Fatalf("Advance() = %v, %v, want dual_write", migration, err)
+	}
+	writeItem("f")
+	if got := countPending(); got != 5 {
+		t.Errorf("Pending rows = %d, want the 5 written before dual-writing", got)
+	}
+	if _, err := uc.Advance(ctx, backfillItemsMigration); err == nil {
+		t.Error("Advance() before the backfill succeeded, want an error")
+	}
+
+	// A backfill stopped after the first batch keeps its progress
+	stopCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	migration, err = uc.Backfill(stopCtx, backfillItemsMigration, usecase.BackfillOptions{BatchSize: 2, Sleep: time.Hour})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Backfill() stopped error = %v, want context.DeadlineExceeded", err)
+	}
+	saved, err := migrationRepo.Get(ctx, backfillItemsMigration)
+	if err != nil || saved.Phase != models.SchemaMigrationBackfilling || saved.TotalRows != 5 || saved.BackfilledRows != 2 || saved.LastKey != "b" {
+		t.Fatalf("Saved progress = %+v, %v, want 2 of 5 rows up to key b", saved, err)
+	}
+
+	// The resumed backfill also picks up a row before the last key that became pending again
+	if err := testDB.ExecSQL("UPDATE backfill_items SET price_decimal = NULL WHERE id = 'a'"); err != nil {
+		t.Fatalf("Failed to reset a: %v", err)
+	}
+	migration, err = uc.Backfill(ctx, backfillItemsMigration, usecase.BackfillOptions{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("Backfill() resumed error = %v", err)
+	}
+	if !migration.BackfilledAt.Valid || migration.BackfilledRows != 6 {
+		t.Errorf("Backfill() = %+v, want completed with 6 rows backfilled", migration)
+	}
+	if got := countPending(); got != 0 {
+		t.Errorf("Pending rows after the backfill = %d, want 0", got)
+	}
+
+	// Reads switch to the new schema once the backfill is done
+	migration, err = uc.Advance(ctx, backfillItemsMigration)
+	if err != nil || migration.Phase != models.SchemaMigrationReadNew {
+		t.Fatalf("Advance() after the backfill = %v, %v, want read_new", migration, err)
+	}
+	if !phases.ReadsNew(ctx, backfillItemsMigration) {
+		t.Error("ReadsNew() = false in read_new")
+	}
+
+	// Rolling back to pending stops dual-writing and resets the progress
+	for _, want := range []models.SchemaMigrationPhase{models.SchemaMigrationBackfilling, models.SchemaMigrationPending} {
+		if migration, err = uc.Rollback(ctx, backfillItemsMigration); err != nil || migration.Phase != want {
+			t.Fatalf("Rollback() = %v, %v, want %s", migration, err, want)
+		}
+	}
+	saved, err = migrationRepo.Get(ctx, backfillItemsMigration)
+	if err != nil || saved.TotalRows != 0 || saved.BackfilledRows != 0 || saved.LastKey != "" || saved.StartedAt.Valid {
+		t.Errorf("Saved migration after the rollback = %+v, %v, want the progress reset", saved, err)
+	}
+	writeItem("g")
+	if got := countPending(); got != 1 {
+		t.Errorf("Pending rows after the rollback = %d, want the row written to the old column only", got)
+	}
+}